@@ -81,6 +81,30 @@ func initCmd(subFlags *flag.FlagSet, args []string) error {
 	return nil
 }
 
+func initDatabasesCmd(subFlags *flag.FlagSet, args []string) error {
+	waitTime := subFlags.Duration("wait_time", 5*time.Minute, "how long to wait for mysqld")
+	var dbNames flagutil.StringListValue
+	subFlags.Var(&dbNames, "db_names", "comma-separated list of the databases to create, e.g. vt_keyspace1,vt_keyspace2")
+	subFlags.Parse(args)
+
+	// There ought to be an existing my.cnf, so use it to find mysqld.
+	mysqld, cnf, err := mysqlctl.OpenMysqldAndMycnf(uint32(*tabletUID))
+	if err != nil {
+		return fmt.Errorf("failed to find mysql config: %v", err)
+	}
+	defer mysqld.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), *waitTime)
+	defer cancel()
+	if err := mysqld.Wait(ctx, cnf); err != nil {
+		return fmt.Errorf("failed waiting for mysql: %v", err)
+	}
+	if err := mysqld.InitDatabases(ctx, dbNames); err != nil {
+		return fmt.Errorf("failed to create the databases: %v", err)
+	}
+	return nil
+}
+
 func reinitConfigCmd(subFlags *flag.FlagSet, args []string) error {
 	// There ought to be an existing my.cnf, so use it to find mysqld.
 	mysqld, cnf, err := mysqlctl.OpenMysqldAndMycnf(uint32(*tabletUID))
@@ -200,6 +224,8 @@ type command struct {
 var commands = []command{
 	{"init", initCmd, "[-wait_time=5m] [-init_db_sql_file=]",
 		"Initializes the directory structure and starts mysqld"},
+	{"init_databases", initDatabasesCmd, "[-wait_time=5m] -db_names=<db1>,<db2>,...",
+		"Creates the databases of the tablets sharing an already 'init'-ed mysqld. The vttablets sharing it run with -mysql_shared_instance"},
 	{"init_config", initConfigCmd, "",
 		"Initializes the directory structure, creates my.cnf file, but does not start mysqld"},
 	{"reinit_config", reinitConfigCmd, "",
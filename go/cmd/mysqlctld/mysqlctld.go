@@ -31,6 +31,7 @@ import (
 	"vitess.io/vitess/go/vt/log"
 	"vitess.io/vitess/go/vt/logutil"
 	"vitess.io/vitess/go/vt/mysqlctl"
+	"vitess.io/vitess/go/vt/secrets"
 	"vitess.io/vitess/go/vt/servenv"
 )
 
@@ -60,6 +61,7 @@ func main() {
 	// mysqlctld only starts and stops mysql, only needs dba.
 	dbconfigs.RegisterFlags(dbconfigs.Dba)
 	servenv.ParseFlags("mysqlctld")
+	secrets.HandleSIGHUP()
 
 	// We'll register this OnTerm handler before mysqld starts, so we get notified
 	// if mysqld dies on its own without us (or our RPC client) telling it to.
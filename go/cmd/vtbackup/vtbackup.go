@@ -80,6 +80,7 @@ import (
 	"vitess.io/vitess/go/vt/mysqlctl"
 	"vitess.io/vitess/go/vt/mysqlctl/backupstorage"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	"vitess.io/vitess/go/vt/secrets"
 	"vitess.io/vitess/go/vt/servenv"
 	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/topo/topoproto"
@@ -134,6 +135,7 @@ func main() {
 	mysqlctl.RegisterFlags()
 
 	servenv.ParseFlags("vtbackup")
+	secrets.HandleSIGHUP()
 
 	if *detachedMode {
 		// this method will call os.Exit and kill this process
@@ -167,6 +169,7 @@ func main() {
 	// Open connection to topology server.
 	topoServer := topo.Open()
 	defer topoServer.Close()
+	backupstorage.SetTopoServer(topoServer)
 
 	// Try to take a backup, if it's been long enough since the last one.
 	// Skip pruning if backup wasn't fully successful. We don't want to be
@@ -0,0 +1,46 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// vtcdcsink runs the CDC sinks declared in the topology, outside of
+// vtctld.
+package main
+
+import (
+	"vitess.io/vitess/go/vt/cdcsink"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/topo"
+)
+
+func init() {
+	servenv.RegisterDefaultFlags()
+}
+
+func main() {
+	servenv.ParseFlags("vtcdcsink")
+	servenv.Init()
+	defer servenv.Close()
+
+	if !cdcsink.Enabled() {
+		log.Exitf("-cdc_sink_vtgate_address is required")
+	}
+
+	ts := topo.Open()
+	defer ts.Close()
+
+	cdcsink.Init(ts)
+	servenv.RunDefault()
+}
@@ -34,6 +34,7 @@ import (
 	"vitess.io/vitess/go/trace"
 	"vitess.io/vitess/go/vt/log"
 	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/mysqlctl/backupstorage"
 	"vitess.io/vitess/go/vt/servenv"
 	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/vtctl"
@@ -97,6 +98,7 @@ func main() {
 
 	ts := topo.Open()
 	defer ts.Close()
+	backupstorage.SetTopoServer(ts)
 
 	vtctl.WorkflowManager = workflow.NewManager(ts)
 
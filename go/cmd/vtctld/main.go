@@ -17,6 +17,7 @@ limitations under the License.
 package main
 
 import (
+	"vitess.io/vitess/go/vt/secrets"
 	"vitess.io/vitess/go/vt/servenv"
 	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/vtctld"
@@ -33,6 +34,7 @@ var (
 
 func main() {
 	servenv.ParseFlags("vtctld")
+	secrets.HandleSIGHUP()
 	servenv.Init()
 	defer servenv.Close()
 
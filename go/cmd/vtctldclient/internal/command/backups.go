@@ -19,12 +19,17 @@ package command
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/json2"
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/logutil"
 	"vitess.io/vitess/go/vt/topo/topoproto"
 
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
 )
 
@@ -35,6 +40,97 @@ var GetBackups = &cobra.Command{
 	RunE: commandGetBackups,
 }
 
+// BackupKeyspace makes a BackupKeyspace gRPC call to a vtctld.
+var BackupKeyspace = &cobra.Command{
+	Use:   "BackupKeyspace [--concurrency 4] [--shard-concurrency 1] [--allow-primary] [--shards SHARDS ...] <keyspace>",
+	Short: "Backs up all the shards of a keyspace, each on its replica tablet the least behind its primary, and outputs the outcome of each shard. Use GetKeyspaceBackupRuns to follow its progress.",
+	Args:  cobra.ExactArgs(1),
+	RunE:  commandBackupKeyspace,
+}
+
+// ListKeyspaceBackups makes a ListKeyspaceBackups gRPC call to a vtctld.
+var ListKeyspaceBackups = &cobra.Command{
+	Use:   "ListKeyspaceBackups <keyspace>",
+	Short: "Outputs the backups of all the shards of a keyspace, with their time and tablet.",
+	Args:  cobra.ExactArgs(1),
+	RunE:  commandListKeyspaceBackups,
+}
+
+// GetKeyspaceBackupRuns makes a GetKeyspaceBackupRuns gRPC call to a vtctld.
+var GetKeyspaceBackupRuns = &cobra.Command{
+	Use:   "GetKeyspaceBackupRuns [<keyspace>]",
+	Short: "Outputs the progress of the running and the last keyspace backups of the vtctld, including the ones of the backup schedules.",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  commandGetKeyspaceBackupRuns,
+}
+
+// PruneBackups makes a PruneBackups gRPC call to a vtctld.
+var PruneBackups = &cobra.Command{
+	Use:   "PruneBackups {--keep-last N | --max-age DURATION} [--dry-run] <keyspace>",
+	Short: "Removes the backups of the shards of a keyspace which are neither among the last ones nor younger than the max age. The most recent backup of each shard is always kept.",
+	Args:  cobra.ExactArgs(1),
+	RunE:  commandPruneBackups,
+}
+
+// SetBackupSchedule makes a SetBackupSchedule gRPC call to a vtctld.
+var SetBackupSchedule = &cobra.Command{
+	Use:   "SetBackupSchedule --cron CRON [--concurrency 4] [--shard-concurrency 1] [--allow-primary] [--keep-last N] [--max-age DURATION] <keyspace>",
+	Short: "Sets the backup schedule of a keyspace: the vtctlds back up all its shards whenever the cron expression matches, then remove the backups out of the retention.",
+	Args:  cobra.ExactArgs(1),
+	RunE:  commandSetBackupSchedule,
+}
+
+// GetBackupSchedule makes a GetBackupSchedule gRPC call to a vtctld.
+var GetBackupSchedule = &cobra.Command{
+	Use:   "GetBackupSchedule <keyspace>",
+	Short: "Outputs the backup schedule of a keyspace, its last run and its next run.",
+	Args:  cobra.ExactArgs(1),
+	RunE:  commandGetBackupSchedule,
+}
+
+// DeleteBackupSchedule makes a DeleteBackupSchedule gRPC call to a vtctld.
+var DeleteBackupSchedule = &cobra.Command{
+	Use:   "DeleteBackupSchedule <keyspace>",
+	Short: "Deletes the backup schedule of a keyspace. Its backups are kept.",
+	Args:  cobra.ExactArgs(1),
+	RunE:  commandDeleteBackupSchedule,
+}
+
+// SetBackupStorageConfig makes a SetBackupStorageConfig gRPC call to a
+// vtctld.
+var SetBackupStorageConfig = &cobra.Command{
+	Use:   "SetBackupStorageConfig <keyspace> <config json>",
+	Short: "Sets the backup storage config of a keyspace, which overrides the backup storage flags of the tablets for the backups of its shards.",
+	Args:  cobra.ExactArgs(2),
+	RunE:  commandSetBackupStorageConfig,
+}
+
+// GetBackupStorageConfig makes a GetBackupStorageConfig gRPC call to a
+// vtctld.
+var GetBackupStorageConfig = &cobra.Command{
+	Use:   "GetBackupStorageConfig <keyspace>",
+	Short: "Outputs the backup storage config of a keyspace.",
+	Args:  cobra.ExactArgs(1),
+	RunE:  commandGetBackupStorageConfig,
+}
+
+// DeleteBackupStorageConfig makes a DeleteBackupStorageConfig gRPC call to a
+// vtctld.
+var DeleteBackupStorageConfig = &cobra.Command{
+	Use:   "DeleteBackupStorageConfig <keyspace>",
+	Short: "Deletes the backup storage config of a keyspace, so that the backup storage flags of the tablets apply again.",
+	Args:  cobra.ExactArgs(1),
+	RunE:  commandDeleteBackupStorageConfig,
+}
+
+// RestoreToPointInTime makes a RestoreToPointInTime gRPC call to a vtctld.
+var RestoreToPointInTime = &cobra.Command{
+	Use:   "RestoreToPointInTime {--restore-to-pos POSITION | --restore-to-timestamp TIME} [--dry-run] <keyspace/shard>",
+	Short: "Restores a shard to a GTID position or to a time on a new spare tablet, started by the provision_pitr_tablet hook of the vtctld, and outputs the backups it restores. The tablet doesn't replicate.",
+	Args:  cobra.ExactArgs(1),
+	RunE:  commandRestoreToPointInTime,
+}
+
 var getBackupsOptions = struct {
 	Limit      uint32
 	OutputJSON bool
@@ -77,8 +173,324 @@ func commandGetBackups(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+var backupKeyspaceOptions = struct {
+	Concurrency      int
+	ShardConcurrency int
+	AllowPrimary     bool
+	Shards           []string
+}{}
+
+func commandBackupKeyspace(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.BackupKeyspace(commandCtx, &vtctldatapb.BackupKeyspaceRequest{
+		Keyspace:          cmd.Flags().Arg(0),
+		Shards:            backupKeyspaceOptions.Shards,
+		Concurrency:       uint32(backupKeyspaceOptions.ShardConcurrency),
+		BackupConcurrency: uint32(backupKeyspaceOptions.Concurrency),
+		AllowPrimary:      backupKeyspaceOptions.AllowPrimary,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, event := range resp.Events {
+		fmt.Println(logutil.EventString(event))
+	}
+
+	data, err := cli.MarshalJSON(resp.Run)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	if resp.Run.Error != "" {
+		return fmt.Errorf("keyspace backup failed: %s", resp.Run.Error)
+	}
+
+	return nil
+}
+
+func commandListKeyspaceBackups(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.ListKeyspaceBackups(commandCtx, &vtctldatapb.ListKeyspaceBackupsRequest{
+		Keyspace: cmd.Flags().Arg(0),
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp.Backups)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
+
+func commandGetKeyspaceBackupRuns(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.GetKeyspaceBackupRuns(commandCtx, &vtctldatapb.GetKeyspaceBackupRunsRequest{
+		Keyspace: cmd.Flags().Arg(0),
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp.Runs)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
+
+var pruneBackupsOptions = struct {
+	KeepLast int
+	MaxAge   time.Duration
+	DryRun   bool
+}{}
+
+func commandPruneBackups(cmd *cobra.Command, args []string) error {
+	if pruneBackupsOptions.KeepLast <= 0 && pruneBackupsOptions.MaxAge <= 0 {
+		return fmt.Errorf("one of --keep-last and --max-age must be set")
+	}
+
+	cli.FinishedParsing(cmd)
+
+	req := &vtctldatapb.PruneBackupsRequest{
+		Keyspace: cmd.Flags().Arg(0),
+		KeepLast: uint32(pruneBackupsOptions.KeepLast),
+		DryRun:   pruneBackupsOptions.DryRun,
+	}
+	if pruneBackupsOptions.MaxAge > 0 {
+		req.MaxAge = protoutil.DurationToProto(pruneBackupsOptions.MaxAge)
+	}
+
+	resp, err := client.PruneBackups(commandCtx, req)
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp.Pruned)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
+
+var setBackupScheduleOptions = struct {
+	Cron             string
+	Concurrency      int
+	ShardConcurrency int
+	AllowPrimary     bool
+	KeepLast         int
+	MaxAge           time.Duration
+}{}
+
+func commandSetBackupSchedule(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	schedule := &topodatapb.BackupSchedule{
+		Cron:              setBackupScheduleOptions.Cron,
+		Concurrency:       uint32(setBackupScheduleOptions.ShardConcurrency),
+		BackupConcurrency: uint32(setBackupScheduleOptions.Concurrency),
+		AllowPrimary:      setBackupScheduleOptions.AllowPrimary,
+		KeepLast:          uint32(setBackupScheduleOptions.KeepLast),
+	}
+	if setBackupScheduleOptions.MaxAge > 0 {
+		schedule.MaxAge = protoutil.DurationToProto(setBackupScheduleOptions.MaxAge)
+	}
+
+	_, err := client.SetBackupSchedule(commandCtx, &vtctldatapb.SetBackupScheduleRequest{
+		Keyspace: cmd.Flags().Arg(0),
+		Schedule: schedule,
+	})
+
+	return err
+}
+
+func commandGetBackupSchedule(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.GetBackupSchedule(commandCtx, &vtctldatapb.GetBackupScheduleRequest{
+		Keyspace: cmd.Flags().Arg(0),
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
+
+func commandDeleteBackupSchedule(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	_, err := client.DeleteBackupSchedule(commandCtx, &vtctldatapb.DeleteBackupScheduleRequest{
+		Keyspace: cmd.Flags().Arg(0),
+	})
+
+	return err
+}
+
+func commandSetBackupStorageConfig(cmd *cobra.Command, args []string) error {
+	config := &topodatapb.BackupStorageConfig{}
+	if err := json2.Unmarshal([]byte(cmd.Flags().Arg(1)), config); err != nil {
+		return fmt.Errorf("invalid backup storage config %v: %w", cmd.Flags().Arg(1), err)
+	}
+
+	cli.FinishedParsing(cmd)
+
+	_, err := client.SetBackupStorageConfig(commandCtx, &vtctldatapb.SetBackupStorageConfigRequest{
+		Keyspace: cmd.Flags().Arg(0),
+		Config:   config,
+	})
+
+	return err
+}
+
+func commandGetBackupStorageConfig(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.GetBackupStorageConfig(commandCtx, &vtctldatapb.GetBackupStorageConfigRequest{
+		Keyspace: cmd.Flags().Arg(0),
+	})
+	if err != nil {
+		return err
+	}
+
+	if resp.Config == nil {
+		return fmt.Errorf("keyspace %v has no backup storage config", cmd.Flags().Arg(0))
+	}
+
+	data, err := cli.MarshalJSON(resp.Config)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
+
+func commandDeleteBackupStorageConfig(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	_, err := client.DeleteBackupStorageConfig(commandCtx, &vtctldatapb.DeleteBackupStorageConfigRequest{
+		Keyspace: cmd.Flags().Arg(0),
+	})
+
+	return err
+}
+
+var restoreToPointInTimeOptions = struct {
+	RestoreToPos       string
+	RestoreToTimestamp string
+	DryRun             bool
+}{}
+
+func commandRestoreToPointInTime(cmd *cobra.Command, args []string) error {
+	if (restoreToPointInTimeOptions.RestoreToPos == "") == (restoreToPointInTimeOptions.RestoreToTimestamp == "") {
+		return fmt.Errorf("exactly one of --restore-to-pos and --restore-to-timestamp must be set")
+	}
+
+	keyspace, shard, err := topoproto.ParseKeyspaceShard(cmd.Flags().Arg(0))
+	if err != nil {
+		return err
+	}
+
+	req := &vtctldatapb.RestoreToPointInTimeRequest{
+		Keyspace:     keyspace,
+		Shard:        shard,
+		RestoreToPos: restoreToPointInTimeOptions.RestoreToPos,
+		DryRun:       restoreToPointInTimeOptions.DryRun,
+	}
+	if restoreToPointInTimeOptions.RestoreToTimestamp != "" {
+		toTime, err := time.Parse(time.RFC3339, restoreToPointInTimeOptions.RestoreToTimestamp)
+		if err != nil {
+			return fmt.Errorf("invalid --restore-to-timestamp %q: %w", restoreToPointInTimeOptions.RestoreToTimestamp, err)
+		}
+
+		req.RestoreToTimestamp = protoutil.TimeToProto(toTime)
+	}
+
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.RestoreToPointInTime(commandCtx, req)
+	if err != nil {
+		return err
+	}
+
+	for _, event := range resp.Events {
+		fmt.Println(logutil.EventString(event))
+	}
+
+	resp.Events = nil
+
+	data, err := cli.MarshalJSON(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
+
 func init() {
 	GetBackups.Flags().Uint32VarP(&getBackupsOptions.Limit, "limit", "l", 0, "Retrieve only the most recent N backups")
 	GetBackups.Flags().BoolVarP(&getBackupsOptions.OutputJSON, "json", "j", false, "Output backup info in JSON format rather than a list of backups")
 	Root.AddCommand(GetBackups)
+
+	BackupKeyspace.Flags().IntVar(&backupKeyspaceOptions.Concurrency, "concurrency", 4, "The number of compression/checksum jobs to run simultaneously for each shard.")
+	BackupKeyspace.Flags().IntVar(&backupKeyspaceOptions.ShardConcurrency, "shard-concurrency", 1, "The number of shards to back up simultaneously.")
+	BackupKeyspace.Flags().BoolVar(&backupKeyspaceOptions.AllowPrimary, "allow-primary", false, "Back up a shard on its primary if it has no other tablet to back up. With the builtin backup engine, this shuts down the primary mysqld for as long as the backup takes.")
+	BackupKeyspace.Flags().StringSliceVar(&backupKeyspaceOptions.Shards, "shards", nil, "The shards to back up. Defaults to all the shards of the keyspace.")
+	Root.AddCommand(BackupKeyspace)
+
+	Root.AddCommand(ListKeyspaceBackups)
+	Root.AddCommand(GetKeyspaceBackupRuns)
+
+	PruneBackups.Flags().IntVar(&pruneBackupsOptions.KeepLast, "keep-last", 0, "Keep the given number of the most recent backups of each shard.")
+	PruneBackups.Flags().DurationVar(&pruneBackupsOptions.MaxAge, "max-age", 0, "Keep the backups younger than the given duration.")
+	PruneBackups.Flags().BoolVar(&pruneBackupsOptions.DryRun, "dry-run", false, "Only output the backups which would be removed.")
+	Root.AddCommand(PruneBackups)
+
+	SetBackupSchedule.Flags().StringVar(&setBackupScheduleOptions.Cron, "cron", "", "The cron expression of the schedule, in the local time of the vtctlds, e.g. '0 3 * * *' or '@daily'.")
+	SetBackupSchedule.Flags().IntVar(&setBackupScheduleOptions.Concurrency, "concurrency", 4, "The number of compression/checksum jobs to run simultaneously for each shard.")
+	SetBackupSchedule.Flags().IntVar(&setBackupScheduleOptions.ShardConcurrency, "shard-concurrency", 1, "The number of shards to back up simultaneously.")
+	SetBackupSchedule.Flags().BoolVar(&setBackupScheduleOptions.AllowPrimary, "allow-primary", false, "Back up a shard on its primary if it has no other tablet to back up.")
+	SetBackupSchedule.Flags().IntVar(&setBackupScheduleOptions.KeepLast, "keep-last", 0, "After each successful run, keep the given number of the most recent backups of each shard.")
+	SetBackupSchedule.Flags().DurationVar(&setBackupScheduleOptions.MaxAge, "max-age", 0, "After each successful run, keep the backups younger than the given duration.")
+	SetBackupSchedule.MarkFlagRequired("cron")
+	Root.AddCommand(SetBackupSchedule)
+
+	Root.AddCommand(GetBackupSchedule)
+	Root.AddCommand(DeleteBackupSchedule)
+
+	Root.AddCommand(SetBackupStorageConfig)
+	Root.AddCommand(GetBackupStorageConfig)
+	Root.AddCommand(DeleteBackupStorageConfig)
+
+	RestoreToPointInTime.Flags().StringVar(&restoreToPointInTimeOptions.RestoreToPos, "restore-to-pos", "", "The GTID position to restore to, with its flavor, e.g. MySQL56/<uuid>:1-100.")
+	RestoreToPointInTime.Flags().StringVar(&restoreToPointInTimeOptions.RestoreToTimestamp, "restore-to-timestamp", "", "The time to restore to, in RFC 3339 format, e.g. 2021-04-01T15:04:05Z.")
+	RestoreToPointInTime.Flags().BoolVar(&restoreToPointInTimeOptions.DryRun, "dry-run", false, "Only output the backups which would be restored.")
+	Root.AddCommand(RestoreToPointInTime)
 }
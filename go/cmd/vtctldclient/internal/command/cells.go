@@ -57,9 +57,9 @@ the same group (alias).`,
 	}
 	// DeleteCellInfo makes a DeleteCellInfo gRPC call to a vtctld.
 	DeleteCellInfo = &cobra.Command{
-		Use:                   "DeleteCellInfo [--force] <cell>",
+		Use:                   "DeleteCellInfo [--force] [--drain] <cell>",
 		Short:                 "Deletes the CellInfo for the provided cell.",
-		Long:                  "Deletes the CellInfo for the provided cell. The cell cannot be referenced by any Shard record.",
+		Long:                  "Deletes the CellInfo for the provided cell. The cell cannot have SrvKeyspace or tablet records, unless --force is specified, and cannot be referenced by any CellsAlias. With --drain, the SrvKeyspace records of the cell are deleted first, so its vtgates stop serving the keyspaces.",
 		DisableFlagsInUseLine: true,
 		Args:                  cobra.ExactArgs(1),
 		RunE:                  commandDeleteCellInfo,
@@ -151,20 +151,26 @@ func commandAddCellsAlias(cmd *cobra.Command, args []string) error {
 
 var deleteCellInfoOptions = struct {
 	Force bool
+	Drain bool
 }{}
 
 func commandDeleteCellInfo(cmd *cobra.Command, args []string) error {
 	cli.FinishedParsing(cmd)
 
 	cell := cmd.Flags().Arg(0)
-	_, err := client.DeleteCellInfo(commandCtx, &vtctldatapb.DeleteCellInfoRequest{
+	resp, err := client.DeleteCellInfo(commandCtx, &vtctldatapb.DeleteCellInfoRequest{
 		Name:  cell,
 		Force: deleteCellInfoOptions.Force,
+		Drain: deleteCellInfoOptions.Drain,
 	})
 	if err != nil {
 		return err
 	}
 
+	for _, keyspace := range resp.DrainedKeyspaces {
+		fmt.Printf("Removed cell %s from the serving graph of keyspace %s\n", cell, keyspace)
+	}
+
 	fmt.Printf("Deleted cell %s\n", cell)
 	return nil
 }
@@ -291,6 +297,7 @@ func init() {
 	Root.AddCommand(AddCellsAlias)
 
 	DeleteCellInfo.Flags().BoolVarP(&deleteCellInfoOptions.Force, "force", "f", false, "Proceeds even if the cell's topology server cannot be reached. The assumption is that you shut down the entire cell, and just need to update the global topo data.")
+	DeleteCellInfo.Flags().BoolVar(&deleteCellInfoOptions.Drain, "drain", false, "Removes the cell from the serving graph of all the keyspaces first, by deleting their SrvKeyspace in the cell.")
 	Root.AddCommand(DeleteCellInfo)
 	Root.AddCommand(DeleteCellsAlias)
 
@@ -17,15 +17,21 @@ limitations under the License.
 package command
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
 
 	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/protoutil"
 	"vitess.io/vitess/go/vt/logutil"
 	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
 
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
@@ -33,6 +39,20 @@ import (
 )
 
 var (
+	// ApplyMySQLUsers makes an ApplyMySQLUsers gRPC call to a vtctld.
+	ApplyMySQLUsers = &cobra.Command{
+		Use:   "ApplyMySQLUsers [--dry-run] [--wait-replicas-timeout DURATION] [--concurrency N] <keyspace>",
+		Short: "Makes the MySQL users of the primaries of a keyspace match the declared ones, and waits for the replicas to converge.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  commandApplyMySQLUsers,
+	}
+	// CheckMySQLUsers makes a CheckMySQLUsers gRPC call to a vtctld.
+	CheckMySQLUsers = &cobra.Command{
+		Use:   "CheckMySQLUsers [--cells c1,c2,...] [--concurrency N] <keyspace|keyspace/shard>",
+		Short: "Reports the tablets whose MySQL users drift from the ones declared for the keyspace.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  commandCheckMySQLUsers,
+	}
 	// CreateKeyspace makes a CreateKeyspace gRPC call to a vtctld.
 	CreateKeyspace = &cobra.Command{
 		Use:  "CreateKeyspace KEYSPACE_NAME [--force] [--sharding-column-name NAME --sharding-column-type TYPE] [--base-keyspace KEYSPACE --snapshot-timestamp TIME] [--served-from DB_TYPE:KEYSPACE ...]",
@@ -52,6 +72,20 @@ var (
 		Args:    cobra.ExactArgs(1),
 		RunE:    commandFindAllShardsInKeyspace,
 	}
+	// GetCDCSinks makes a GetCDCSinks gRPC call to a vtctld.
+	GetCDCSinks = &cobra.Command{
+		Use:   "GetCDCSinks <keyspace>",
+		Short: "Returns the CDC sinks of a keyspace.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  commandGetCDCSinks,
+	}
+	// GetCellServingOverride makes a GetCellServingOverride gRPC call to a vtctld.
+	GetCellServingOverride = &cobra.Command{
+		Use:   "GetCellServingOverride [--cell <cell>] <keyspace>",
+		Short: "Returns the cells marked as \"do not serve\" for a keyspace, and why.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  commandGetCellServingOverride,
+	}
 	// GetKeyspace makes a GetKeyspace gRPC call to a vtctld.
 	GetKeyspace = &cobra.Command{
 		Use:     "GetKeyspace keyspace",
@@ -66,14 +100,129 @@ var (
 		Args:    cobra.NoArgs,
 		RunE:    commandGetKeyspaces,
 	}
+	// GetMySQLUsers makes a GetMySQLUsers gRPC call to a vtctld.
+	GetMySQLUsers = &cobra.Command{
+		Use:   "GetMySQLUsers <keyspace>",
+		Short: "Returns the MySQL users declared for the tablets of a keyspace.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  commandGetMySQLUsers,
+	}
 	// RemoveKeyspaceCell makes a RemoveKeyspaceCell gRPC call to a vtctld.
 	RemoveKeyspaceCell = &cobra.Command{
 		Use:  "RemoveKeyspaceCell <keyspace> <cell>",
 		Args: cobra.ExactArgs(2),
 		RunE: commandRemoveKeyspaceCell,
 	}
+	// SetCDCSinks makes a SetCDCSinks gRPC call to a vtctld.
+	SetCDCSinks = &cobra.Command{
+		Use:   "SetCDCSinks {--sinks JSON | --sinks-file FILE} <keyspace>",
+		Short: "Declares the CDC sinks of a keyspace. No sinks remove them.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  commandSetCDCSinks,
+	}
+	// SetCellServingOverride makes a SetCellServingOverride gRPC call to a vtctld.
+	SetCellServingOverride = &cobra.Command{
+		Use:   "SetCellServingOverride [--do-not-serve] [--reason <reason>] <keyspace> <cell>",
+		Short: "Marks a cell as \"do not serve\" for a keyspace with --do-not-serve, or removes the mark without it.",
+		Long: `Marks a cell as "do not serve" for a keyspace with --do-not-serve, or removes the mark without it.
+The vtgates of the other cells don't route the queries of the keyspace to the
+tablets of a marked cell, whatever their health. The queries to the primary are
+still routed to it. Only the vtgates started with
+-enable_keyspace_cell_serving_overrides apply the marks.`,
+		Args: cobra.ExactArgs(2),
+		RunE: commandSetCellServingOverride,
+	}
+	// SetMySQLUsers makes a SetMySQLUsers gRPC call to a vtctld.
+	SetMySQLUsers = &cobra.Command{
+		Use:   "SetMySQLUsers {--users JSON | --users-file FILE} <keyspace>",
+		Short: "Declares the MySQL users of the tablets of a keyspace. No users remove the declaration.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  commandSetMySQLUsers,
+	}
+	// ValidateConfig makes a ValidateConfig gRPC call to a vtctld.
+	ValidateConfig = &cobra.Command{
+		Use:   "ValidateConfig [--cells c1,c2,...] [--mysql-variables v1,v2,...] [--tablet-flags f1,f2,...] [--golden-config JSON | --golden-config-file FILE] <keyspace|keyspace/shard>",
+		Short: "Reports the tablets whose MySQL global variables or vttablet flags drift from the golden config or from the majority of the tablets.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  commandValidateConfig,
+	}
 )
 
+var applyMySQLUsersOptions = struct {
+	DryRun              bool
+	WaitReplicasTimeout time.Duration
+	Concurrency         uint32
+}{}
+
+func commandApplyMySQLUsers(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	keyspace := cmd.Flags().Arg(0)
+	resp, err := client.ApplyMySQLUsers(commandCtx, &vtctldatapb.ApplyMySQLUsersRequest{
+		Keyspace:            keyspace,
+		DryRun:              applyMySQLUsersOptions.DryRun,
+		WaitReplicasTimeout: protoutil.DurationToProto(applyMySQLUsersOptions.WaitReplicasTimeout),
+		Concurrency:         applyMySQLUsersOptions.Concurrency,
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	for _, shard := range resp.Shards {
+		if shard.Error != "" {
+			return fmt.Errorf("cannot apply the MySQL users of shard %v/%v: %v", keyspace, shard.Shard, shard.Error)
+		}
+	}
+
+	return nil
+}
+
+var checkMySQLUsersOptions = struct {
+	Cells       []string
+	Concurrency uint32
+}{}
+
+func commandCheckMySQLUsers(cmd *cobra.Command, args []string) error {
+	req := &vtctldatapb.CheckMySQLUsersRequest{
+		Keyspace:    cmd.Flags().Arg(0),
+		Cells:       checkMySQLUsersOptions.Cells,
+		Concurrency: checkMySQLUsersOptions.Concurrency,
+	}
+	if strings.Contains(req.Keyspace, "/") {
+		var err error
+		if req.Keyspace, req.Shard, err = topoproto.ParseKeyspaceShard(cmd.Flags().Arg(0)); err != nil {
+			return err
+		}
+	}
+
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.CheckMySQLUsers(commandCtx, req)
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp.Drift)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	if !resp.Drift.Consistent {
+		return fmt.Errorf("the MySQL users of the tablets of %v drift from the declared ones", cmd.Flags().Arg(0))
+	}
+
+	return nil
+}
+
 var createKeyspaceOptions = struct {
 	Force             bool
 	AllowEmptyVSchema bool
@@ -204,6 +353,51 @@ func commandFindAllShardsInKeyspace(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func commandGetCDCSinks(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.GetCDCSinks(commandCtx, &vtctldatapb.GetCDCSinksRequest{
+		Keyspace: cmd.Flags().Arg(0),
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
+
+var getCellServingOverrideOptions = struct {
+	Cell string
+}{}
+
+func commandGetCellServingOverride(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.GetCellServingOverride(commandCtx, &vtctldatapb.GetCellServingOverrideRequest{
+		Keyspace: cmd.Flags().Arg(0),
+		Cell:     getCellServingOverrideOptions.Cell,
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
+
 func commandGetKeyspace(cmd *cobra.Command, args []string) error {
 	cli.FinishedParsing(cmd)
 
@@ -239,6 +433,26 @@ func commandGetKeyspaces(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func commandGetMySQLUsers(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.GetMySQLUsers(commandCtx, &vtctldatapb.GetMySQLUsersRequest{
+		Keyspace: cmd.Flags().Arg(0),
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
+
 var removeKeyspaceCellOptions = struct {
 	Force     bool
 	Recursive bool
@@ -266,7 +480,185 @@ func commandRemoveKeyspaceCell(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+var setCDCSinksOptions = struct {
+	Sinks     string
+	SinksFile string
+}{}
+
+func commandSetCDCSinks(cmd *cobra.Command, args []string) error {
+	if (setCDCSinksOptions.Sinks == "") == (setCDCSinksOptions.SinksFile == "") {
+		return errors.New("must specify exactly one of --sinks and --sinks-file")
+	}
+
+	data := []byte(setCDCSinksOptions.Sinks)
+	if setCDCSinksOptions.SinksFile != "" {
+		var err error
+		if data, err = ioutil.ReadFile(setCDCSinksOptions.SinksFile); err != nil {
+			return err
+		}
+	}
+
+	// The sinks are in the format output by GetCDCSinks.
+	sinks := &vtctldatapb.GetCDCSinksResponse{}
+	if err := protojson.Unmarshal(data, sinks); err != nil {
+		return fmt.Errorf("cannot parse the sinks: %w", err)
+	}
+
+	req := &vtctldatapb.SetCDCSinksRequest{
+		Keyspace: cmd.Flags().Arg(0),
+		Sinks:    sinks.Sinks,
+	}
+
+	cli.FinishedParsing(cmd)
+
+	if _, err := client.SetCDCSinks(commandCtx, req); err != nil {
+		return err
+	}
+
+	fmt.Printf("Successfully set the CDC sinks of keyspace %s.\n", req.Keyspace)
+
+	return nil
+}
+
+var setCellServingOverrideOptions = struct {
+	DoNotServe bool
+	Reason     string
+}{}
+
+func commandSetCellServingOverride(cmd *cobra.Command, args []string) error {
+	req := &vtctldatapb.SetCellServingOverrideRequest{
+		Keyspace: cmd.Flags().Arg(0),
+		Cell:     cmd.Flags().Arg(1),
+		Override: &topodatapb.CellServingOverride{
+			DoNotServe: setCellServingOverrideOptions.DoNotServe,
+			Reason:     setCellServingOverrideOptions.Reason,
+		},
+	}
+
+	cli.FinishedParsing(cmd)
+
+	if _, err := client.SetCellServingOverride(commandCtx, req); err != nil {
+		return err
+	}
+
+	if req.Override.DoNotServe {
+		fmt.Printf("Successfully marked cell %s as \"do not serve\" for keyspace %s.\n", req.Cell, req.Keyspace)
+	} else {
+		fmt.Printf("Successfully removed the \"do not serve\" mark of cell %s for keyspace %s.\n", req.Cell, req.Keyspace)
+	}
+
+	return nil
+}
+
+var setMySQLUsersOptions = struct {
+	Users     string
+	UsersFile string
+}{}
+
+func commandSetMySQLUsers(cmd *cobra.Command, args []string) error {
+	if (setMySQLUsersOptions.Users == "") == (setMySQLUsersOptions.UsersFile == "") {
+		return errors.New("must specify exactly one of --users and --users-file")
+	}
+
+	data := []byte(setMySQLUsersOptions.Users)
+	if setMySQLUsersOptions.UsersFile != "" {
+		var err error
+		if data, err = ioutil.ReadFile(setMySQLUsersOptions.UsersFile); err != nil {
+			return err
+		}
+	}
+
+	// The users are in the format output by GetMySQLUsers.
+	users := &vtctldatapb.GetMySQLUsersResponse{}
+	if err := protojson.Unmarshal(data, users); err != nil {
+		return fmt.Errorf("cannot parse the users: %w", err)
+	}
+
+	req := &vtctldatapb.SetMySQLUsersRequest{
+		Keyspace: cmd.Flags().Arg(0),
+		Users:    users.Users,
+	}
+
+	cli.FinishedParsing(cmd)
+
+	if _, err := client.SetMySQLUsers(commandCtx, req); err != nil {
+		return err
+	}
+
+	fmt.Printf("Successfully set the MySQL users of keyspace %s.\n", req.Keyspace)
+
+	return nil
+}
+
+var validateConfigOptions = struct {
+	Cells            []string
+	Concurrency      uint32
+	MySQLVariables   []string
+	TabletFlags      []string
+	GoldenConfig     string
+	GoldenConfigFile string
+}{}
+
+func commandValidateConfig(cmd *cobra.Command, args []string) error {
+	if validateConfigOptions.GoldenConfig != "" && validateConfigOptions.GoldenConfigFile != "" {
+		return errors.New("cannot specify both --golden-config and --golden-config-file")
+	}
+
+	req := &vtctldatapb.ValidateConfigRequest{
+		Keyspace:       cmd.Flags().Arg(0),
+		Cells:          validateConfigOptions.Cells,
+		Concurrency:    validateConfigOptions.Concurrency,
+		MysqlVariables: validateConfigOptions.MySQLVariables,
+		TabletFlags:    validateConfigOptions.TabletFlags,
+	}
+	if strings.Contains(req.Keyspace, "/") {
+		var err error
+		if req.Keyspace, req.Shard, err = topoproto.ParseKeyspaceShard(cmd.Flags().Arg(0)); err != nil {
+			return err
+		}
+	}
+
+	golden := []byte(validateConfigOptions.GoldenConfig)
+	if validateConfigOptions.GoldenConfigFile != "" {
+		var err error
+		if golden, err = ioutil.ReadFile(validateConfigOptions.GoldenConfigFile); err != nil {
+			return err
+		}
+	}
+	if len(golden) > 0 {
+		req.Golden = &vtctldatapb.ValidateConfigResponse_Config{}
+		if err := json.Unmarshal(golden, req.Golden); err != nil {
+			return fmt.Errorf("cannot parse the golden config: %w", err)
+		}
+	}
+
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.ValidateConfig(commandCtx, req)
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
+
 func init() {
+	ApplyMySQLUsers.Flags().BoolVar(&applyMySQLUsersOptions.DryRun, "dry-run", false, "Only returns the statements which would be executed on the primaries.")
+	ApplyMySQLUsers.Flags().DurationVar(&applyMySQLUsersOptions.WaitReplicasTimeout, "wait-replicas-timeout", 30*time.Second, "How long to wait for the replicas to converge.")
+	ApplyMySQLUsers.Flags().Uint32Var(&applyMySQLUsersOptions.Concurrency, "concurrency", 10, "The maximum number of tablets queried at the same time.")
+	Root.AddCommand(ApplyMySQLUsers)
+
+	CheckMySQLUsers.Flags().StringSliceVarP(&checkMySQLUsersOptions.Cells, "cells", "c", nil, "The cells whose tablets are checked. If empty, all cells are considered.")
+	CheckMySQLUsers.Flags().Uint32Var(&checkMySQLUsersOptions.Concurrency, "concurrency", 10, "The maximum number of tablets queried at the same time.")
+	Root.AddCommand(CheckMySQLUsers)
+
 	CreateKeyspace.Flags().BoolVarP(&createKeyspaceOptions.Force, "force", "f", false, "Proceeds even if the keyspace already exists. Does not overwrite the existing keyspace record")
 	CreateKeyspace.Flags().BoolVarP(&createKeyspaceOptions.AllowEmptyVSchema, "allow-empty-vschema", "e", false, "Allows a new keyspace to have no vschema")
 	CreateKeyspace.Flags().StringVar(&createKeyspaceOptions.ShardingColumnName, "sharding-column-name", "", "The column name to use for sharding operations")
@@ -281,10 +673,35 @@ func init() {
 	Root.AddCommand(DeleteKeyspace)
 
 	Root.AddCommand(FindAllShardsInKeyspace)
+	Root.AddCommand(GetCDCSinks)
+	GetCellServingOverride.Flags().StringVar(&getCellServingOverrideOptions.Cell, "cell", "", "Only returns the mark of this cell.")
+	Root.AddCommand(GetCellServingOverride)
+
 	Root.AddCommand(GetKeyspace)
 	Root.AddCommand(GetKeyspaces)
+	Root.AddCommand(GetMySQLUsers)
 
 	RemoveKeyspaceCell.Flags().BoolVarP(&removeKeyspaceCellOptions.Force, "force", "f", false, "Proceed even if the cell's topology server cannot be reached. The assumption is that you turned down the entire cell, and just need to update the global topo data.")
 	RemoveKeyspaceCell.Flags().BoolVarP(&removeKeyspaceCellOptions.Recursive, "recursive", "r", false, "Also delete all tablets in that cell beloning to the specified keyspace.")
 	Root.AddCommand(RemoveKeyspaceCell)
+
+	SetCDCSinks.Flags().StringVar(&setCDCSinksOptions.Sinks, "sinks", "", "The sinks as a JSON object, in the format output by GetCDCSinks.")
+	SetCDCSinks.Flags().StringVar(&setCDCSinksOptions.SinksFile, "sinks-file", "", "A file containing the sinks as JSON.")
+	Root.AddCommand(SetCDCSinks)
+
+	SetCellServingOverride.Flags().BoolVar(&setCellServingOverrideOptions.DoNotServe, "do-not-serve", false, "Marks the cell as \"do not serve\", instead of removing the mark.")
+	SetCellServingOverride.Flags().StringVar(&setCellServingOverrideOptions.Reason, "reason", "", "Why the cell must not serve the keyspace, logged by the vtgates.")
+	Root.AddCommand(SetCellServingOverride)
+
+	SetMySQLUsers.Flags().StringVar(&setMySQLUsersOptions.Users, "users", "", "The users as a JSON object, in the format output by GetMySQLUsers.")
+	SetMySQLUsers.Flags().StringVar(&setMySQLUsersOptions.UsersFile, "users-file", "", "A file containing the users as JSON.")
+	Root.AddCommand(SetMySQLUsers)
+
+	ValidateConfig.Flags().StringSliceVarP(&validateConfigOptions.Cells, "cells", "c", nil, "The cells whose tablets are validated. If empty, all cells are considered.")
+	ValidateConfig.Flags().Uint32Var(&validateConfigOptions.Concurrency, "concurrency", 10, "The maximum number of tablets queried at the same time.")
+	ValidateConfig.Flags().StringSliceVar(&validateConfigOptions.MySQLVariables, "mysql-variables", nil, "The MySQL global variables to compare. Defaults to the ones whose mismatch breaks replication or validation, such as sql_mode and character_set_server.")
+	ValidateConfig.Flags().StringSliceVar(&validateConfigOptions.TabletFlags, "tablet-flags", nil, "The vttablet flags to compare.")
+	ValidateConfig.Flags().StringVar(&validateConfigOptions.GoldenConfig, "golden-config", "", "The golden config as a JSON object with mysql_variables and flags objects. Its settings are compared with it rather than with the majority of the tablets.")
+	ValidateConfig.Flags().StringVar(&validateConfigOptions.GoldenConfigFile, "golden-config-file", "", "A file containing the golden config as JSON.")
+	Root.AddCommand(ValidateConfig)
 }
@@ -0,0 +1,133 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/protoutil"
+
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+var (
+	// AcquireKeyspaceLock makes an AcquireKeyspaceLock gRPC call to a vtctld.
+	AcquireKeyspaceLock = &cobra.Command{
+		Use:   "AcquireKeyspaceLock --purpose <purpose> [--shard <shard>] [--ttl <duration>] <keyspace>",
+		Short: "Locks a keyspace, or a shard, on behalf of an external tool.",
+		Long: `Locks a keyspace, or a shard, on behalf of an external tool, so it can
+coordinate with the operations of Vitess which take the same lock.
+
+The vtctld holds the lock until it is released with ReleaseLock or its TTL
+expires, and loses it if it restarts. The id of the lock is printed.`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+		RunE:                  commandAcquireKeyspaceLock,
+	}
+	// ListLocks makes a ListLocks gRPC call to a vtctld.
+	ListLocks = &cobra.Command{
+		Use:   "ListLocks",
+		Short: "Lists the keyspace and shard locks the vtctld holds on behalf of external tools.",
+		Args:  cobra.NoArgs,
+		RunE:  commandListLocks,
+	}
+	// ReleaseLock makes a ReleaseLock gRPC call to a vtctld.
+	ReleaseLock = &cobra.Command{
+		Use:                   "ReleaseLock <id>",
+		Short:                 "Releases a lock acquired with AcquireKeyspaceLock.",
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+		RunE:                  commandReleaseLock,
+	}
+)
+
+var acquireKeyspaceLockOptions = struct {
+	Shard   string
+	Purpose string
+	TTL     time.Duration
+}{}
+
+func commandAcquireKeyspaceLock(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.AcquireKeyspaceLock(commandCtx, &vtctldatapb.AcquireKeyspaceLockRequest{
+		Keyspace: cmd.Flags().Arg(0),
+		Shard:    acquireKeyspaceLockOptions.Shard,
+		Purpose:  acquireKeyspaceLockOptions.Purpose,
+		Ttl:      protoutil.DurationToProto(acquireKeyspaceLockOptions.TTL),
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp.Lock)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
+
+func commandListLocks(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.ListLocks(commandCtx, &vtctldatapb.ListLocksRequest{})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp.Locks)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
+
+func commandReleaseLock(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	id := cmd.Flags().Arg(0)
+	_, err := client.ReleaseLock(commandCtx, &vtctldatapb.ReleaseLockRequest{
+		Id: id,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Released lock %s\n", id)
+	return nil
+}
+
+func init() {
+	AcquireKeyspaceLock.Flags().StringVar(&acquireKeyspaceLockOptions.Shard, "shard", "", "Locks this shard of the keyspace instead of the whole keyspace.")
+	AcquireKeyspaceLock.Flags().StringVar(&acquireKeyspaceLockOptions.Purpose, "purpose", "", "Why the lock is held, recorded in the lock for the operations waiting for it.")
+	AcquireKeyspaceLock.Flags().DurationVar(&acquireKeyspaceLockOptions.TTL, "ttl", 10*time.Minute, "Duration after which the lock is released if it was not released before.")
+	AcquireKeyspaceLock.MarkFlagRequired("purpose")
+	Root.AddCommand(AcquireKeyspaceLock)
+
+	Root.AddCommand(ListLocks)
+	Root.AddCommand(ReleaseLock)
+}
@@ -17,7 +17,13 @@ limitations under the License.
 package command
 
 import (
+	"bufio"
 	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -29,6 +35,7 @@ import (
 	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/topo/topoproto"
 
+	logutilpb "vitess.io/vitess/go/vt/proto/logutil"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
 )
@@ -54,6 +61,17 @@ var (
 		Long: "string",
 		RunE: commandPlannedReparentShard,
 	}
+	// ReparentShardInteractive guides an operator through a reparent of a
+	// shard, making PlanReparentShard, PlannedReparentShard or
+	// EmergencyReparentShard, and VerifyReparentShard gRPC calls to a vtctld.
+	ReparentShardInteractive = &cobra.Command{
+		Use:  "ReparentShardInteractive <keyspace/shard>",
+		Args: cobra.ExactArgs(1),
+		Long: "Plans a reparent of the shard and shows the candidates for promotion with their rankings, which tablets will be " +
+			"repointed and the expected write downtime, then asks which tablet to promote. It then runs the reparent, streaming its " +
+			"progress, and prints a verification report of the replication of the shard.",
+		RunE: commandReparentShardInteractive,
+	}
 	// ReparentTablet makes a ReparentTablet gRPC call to a vtctld.
 	ReparentTablet = &cobra.Command{
 		Use: "ReparentTablet <alias>",
@@ -76,6 +94,10 @@ var emergencyReparentShardOptions = struct {
 	WaitReplicasTimeout       time.Duration
 	NewPrimaryAliasStr        string
 	IgnoreReplicaAliasStrList []string
+	AllowedCells              []string
+	ExcludeTabletAliasStrList []string
+	MaxReplicationLag         time.Duration
+	DryRun                    bool
 }{}
 
 func commandEmergencyReparentShard(cmd *cobra.Command, args []string) error {
@@ -87,6 +109,7 @@ func commandEmergencyReparentShard(cmd *cobra.Command, args []string) error {
 	var (
 		newPrimaryAlias      *topodatapb.TabletAlias
 		ignoreReplicaAliases = make([]*topodatapb.TabletAlias, len(emergencyReparentShardOptions.IgnoreReplicaAliasStrList))
+		excludeTabletAliases = make([]*topodatapb.TabletAlias, len(emergencyReparentShardOptions.ExcludeTabletAliasStrList))
 	)
 
 	if emergencyReparentShardOptions.NewPrimaryAliasStr != "" {
@@ -105,6 +128,15 @@ func commandEmergencyReparentShard(cmd *cobra.Command, args []string) error {
 		ignoreReplicaAliases[i] = alias
 	}
 
+	for i, aliasStr := range emergencyReparentShardOptions.ExcludeTabletAliasStrList {
+		alias, err := topoproto.ParseTabletAlias(aliasStr)
+		if err != nil {
+			return err
+		}
+
+		excludeTabletAliases[i] = alias
+	}
+
 	cli.FinishedParsing(cmd)
 
 	resp, err := client.EmergencyReparentShard(commandCtx, &vtctldatapb.EmergencyReparentShardRequest{
@@ -113,6 +145,10 @@ func commandEmergencyReparentShard(cmd *cobra.Command, args []string) error {
 		NewPrimary:          newPrimaryAlias,
 		IgnoreReplicas:      ignoreReplicaAliases,
 		WaitReplicasTimeout: protoutil.DurationToProto(emergencyReparentShardOptions.WaitReplicasTimeout),
+		AllowedCells:        emergencyReparentShardOptions.AllowedCells,
+		ExcludeTablets:      excludeTabletAliases,
+		MaxReplicationLag:   protoutil.DurationToProto(emergencyReparentShardOptions.MaxReplicationLag),
+		DryRun:              emergencyReparentShardOptions.DryRun,
 	})
 	if err != nil {
 		return err
@@ -122,6 +158,10 @@ func commandEmergencyReparentShard(cmd *cobra.Command, args []string) error {
 		fmt.Println(logutil.EventString(event))
 	}
 
+	if emergencyReparentShardOptions.DryRun && resp.PromotedPrimary != nil {
+		fmt.Printf("Dry run: %s would be promoted to primary\n", topoproto.TabletAliasString(resp.PromotedPrimary))
+	}
+
 	return nil
 }
 
@@ -212,6 +252,185 @@ func commandPlannedReparentShard(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+var reparentShardInteractiveOptions = struct {
+	Emergency            bool
+	NewPrimaryAliasStr   string
+	AvoidPrimaryAliasStr string
+	WaitReplicasTimeout  time.Duration
+	Yes                  bool
+}{}
+
+func commandReparentShardInteractive(cmd *cobra.Command, args []string) error {
+	keyspace, shard, err := topoproto.ParseKeyspaceShard(cmd.Flags().Arg(0))
+	if err != nil {
+		return err
+	}
+
+	opts := reparentShardInteractiveOptions
+	var newPrimaryAlias, avoidPrimaryAlias *topodatapb.TabletAlias
+	if opts.NewPrimaryAliasStr != "" {
+		newPrimaryAlias, err = topoproto.ParseTabletAlias(opts.NewPrimaryAliasStr)
+		if err != nil {
+			return err
+		}
+	}
+	if opts.AvoidPrimaryAliasStr != "" {
+		avoidPrimaryAlias, err = topoproto.ParseTabletAlias(opts.AvoidPrimaryAliasStr)
+		if err != nil {
+			return err
+		}
+	}
+
+	cli.FinishedParsing(cmd)
+
+	plan, err := client.PlanReparentShard(commandCtx, &vtctldatapb.PlanReparentShardRequest{
+		Keyspace:            keyspace,
+		Shard:               shard,
+		Emergency:           opts.Emergency,
+		NewPrimary:          newPrimaryAlias,
+		AvoidPrimary:        avoidPrimaryAlias,
+		WaitReplicasTimeout: protoutil.DurationToProto(opts.WaitReplicasTimeout),
+	})
+	if err != nil {
+		return err
+	}
+	printReparentPlan(plan)
+
+	newPrimary := plan.NewPrimary
+	if !opts.Yes {
+		fmt.Printf("\nPromote %v? Type yes to proceed, the alias of another candidate to promote it instead, or anything else to abort: ", aliasOrNone(newPrimary))
+		answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && err != io.EOF {
+			return err
+		}
+		answer = strings.TrimSpace(answer)
+		switch candidate := reparentCandidate(plan, answer); {
+		case answer == "yes":
+		case candidate != nil:
+			newPrimary = candidate.Tablet
+		default:
+			return fmt.Errorf("reparent of %v/%v aborted", keyspace, shard)
+		}
+	}
+	if newPrimary == nil {
+		return fmt.Errorf("no tablet can be promoted in %v/%v", keyspace, shard)
+	}
+
+	// The reparent RPCs return their events even if they fail.
+	var events []*logutilpb.Event
+	if opts.Emergency {
+		fmt.Printf("\nRunning EmergencyReparentShard to promote %v\n", topoproto.TabletAliasString(newPrimary))
+		var resp *vtctldatapb.EmergencyReparentShardResponse
+		resp, err = client.EmergencyReparentShard(commandCtx, &vtctldatapb.EmergencyReparentShardRequest{
+			Keyspace:            keyspace,
+			Shard:               shard,
+			NewPrimary:          newPrimary,
+			WaitReplicasTimeout: protoutil.DurationToProto(opts.WaitReplicasTimeout),
+		})
+		if resp != nil {
+			events = resp.Events
+		}
+	} else {
+		fmt.Printf("\nRunning PlannedReparentShard to promote %v\n", topoproto.TabletAliasString(newPrimary))
+		var resp *vtctldatapb.PlannedReparentShardResponse
+		resp, err = client.PlannedReparentShard(commandCtx, &vtctldatapb.PlannedReparentShardRequest{
+			Keyspace:            keyspace,
+			Shard:               shard,
+			NewPrimary:          newPrimary,
+			WaitReplicasTimeout: protoutil.DurationToProto(opts.WaitReplicasTimeout),
+		})
+		if resp != nil {
+			events = resp.Events
+		}
+	}
+	for _, event := range events {
+		fmt.Println(logutil.EventString(event))
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("\nVerifying %v/%v\n", keyspace, shard)
+	report, err := client.VerifyReparentShard(commandCtx, &vtctldatapb.VerifyReparentShardRequest{
+		Keyspace: keyspace,
+		Shard:    shard,
+	})
+	if err != nil {
+		return err
+	}
+	printReparentVerification(report)
+	if !report.Healthy {
+		return fmt.Errorf("shard %v/%v has replication problems", keyspace, shard)
+	}
+	return nil
+}
+
+func reparentCandidate(plan *vtctldatapb.PlanReparentShardResponse, alias string) *vtctldatapb.PlanReparentShardResponse_Candidate {
+	for _, candidate := range plan.Candidates {
+		if topoproto.TabletAliasString(candidate.Tablet) == alias && candidate.Ineligible == "" {
+			return candidate
+		}
+	}
+	return nil
+}
+
+func aliasOrNone(alias *topodatapb.TabletAlias) string {
+	if alias == nil {
+		return "<none>"
+	}
+	return topoproto.TabletAliasString(alias)
+}
+
+func printReparentPlan(plan *vtctldatapb.PlanReparentShardResponse) {
+	action := "PlannedReparentShard"
+	if plan.Emergency {
+		action = "EmergencyReparentShard"
+	}
+	fmt.Printf("%v plan for %v/%v\n", action, plan.Keyspace, plan.Shard)
+	fmt.Printf("Current primary: %v\n", aliasOrNone(plan.CurrentPrimary))
+	fmt.Printf("New primary: %v\n\n", aliasOrNone(plan.NewPrimary))
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "RANK\tTABLET\tLAG\tPOSITION\tINELIGIBLE")
+	for _, candidate := range plan.Candidates {
+		rank := "-"
+		if candidate.Rank > 0 {
+			rank = strconv.Itoa(int(candidate.Rank))
+		}
+		fmt.Fprintf(w, "%v\t%v\t%vs\t%v\t%v\n", rank, topoproto.TabletAliasString(candidate.Tablet), candidate.LagSeconds, candidate.Position, candidate.Ineligible)
+	}
+	w.Flush()
+
+	repointed := "<none>"
+	if len(plan.Repointed) > 0 {
+		repointed = strings.Join(topoproto.TabletAliasList(plan.Repointed).ToStringSlice(), ", ")
+	}
+	expectedDowntime := plan.ExpectedDowntime
+	if expectedDowntime == "" {
+		expectedDowntime = "<none>"
+	}
+	fmt.Printf("\nRepointed to the new primary: %v\n", repointed)
+	fmt.Printf("Expected write downtime: %v\n", expectedDowntime)
+	for _, warning := range plan.Warnings {
+		fmt.Printf("WARNING: %v\n", warning)
+	}
+}
+
+func printReparentVerification(report *vtctldatapb.VerifyReparentShardResponse) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "TABLET\tTYPE\tSOURCE\tLAG\tPROBLEM")
+	for _, tablet := range report.Tablets {
+		fmt.Fprintf(w, "%v\t%v\t%v\t%vs\t%v\n", topoproto.TabletAliasString(tablet.Tablet), topoproto.TabletTypeLString(tablet.Type), tablet.Source, tablet.LagSeconds, tablet.Problem)
+	}
+	w.Flush()
+
+	if report.Healthy {
+		fmt.Printf("\n%v/%v is healthy, with primary %v\n", report.Keyspace, report.Shard, aliasOrNone(report.Primary))
+	} else {
+		fmt.Printf("\n%v/%v has replication problems\n", report.Keyspace, report.Shard)
+	}
+}
+
 func commandReparentTablet(cmd *cobra.Command, args []string) error {
 	alias, err := topoproto.ParseTabletAlias(cmd.Flags().Arg(0))
 	if err != nil {
@@ -262,6 +481,10 @@ func init() {
 	EmergencyReparentShard.Flags().DurationVar(&emergencyReparentShardOptions.WaitReplicasTimeout, "wait-replicas-timeout", *topo.RemoteOperationTimeout, "Time to wait for replicas to catch up in reparenting.")
 	EmergencyReparentShard.Flags().StringVar(&emergencyReparentShardOptions.NewPrimaryAliasStr, "new-primary", "", "Alias of a tablet that should be the new primary. If not specified, the vtctld will select the best candidate to promote.")
 	EmergencyReparentShard.Flags().StringSliceVarP(&emergencyReparentShardOptions.IgnoreReplicaAliasStrList, "ignore-replicas", "i", nil, "Comma-separated, repeated list of replica tablet aliases to ignore during the emergency reparent.")
+	EmergencyReparentShard.Flags().StringSliceVar(&emergencyReparentShardOptions.AllowedCells, "allowed-cells", nil, "Comma-separated, repeated list of cells the new primary must be in.")
+	EmergencyReparentShard.Flags().StringSliceVar(&emergencyReparentShardOptions.ExcludeTabletAliasStrList, "exclude-tablets", nil, "Comma-separated, repeated list of tablet aliases which must not be promoted.")
+	EmergencyReparentShard.Flags().DurationVar(&emergencyReparentShardOptions.MaxReplicationLag, "max-replication-lag", 0, "If not zero, the tablets which lagged more before replication was stopped are not promoted.")
+	EmergencyReparentShard.Flags().BoolVar(&emergencyReparentShardOptions.DryRun, "dry-run", false, "Only prints which tablet would be promoted and why, without stopping replication or promoting it.")
 	Root.AddCommand(EmergencyReparentShard)
 
 	InitShardPrimary.Flags().DurationVar(&initShardPrimaryOptions.WaitReplicasTimeout, "wait-replicas-timeout", 30*time.Second, "time to wait for replicas to catch up in reparenting")
@@ -273,6 +496,13 @@ func init() {
 	PlannedReparentShard.Flags().StringVar(&plannedReparentShardOptions.AvoidPrimaryAliasStr, "avoid-primary", "", "Alias of a tablet that should not be the primary; i.e. \"reparent to any other tablet if this one is the primary\".")
 	Root.AddCommand(PlannedReparentShard)
 
+	ReparentShardInteractive.Flags().BoolVar(&reparentShardInteractiveOptions.Emergency, "emergency", false, "Runs an EmergencyReparentShard instead of a PlannedReparentShard.")
+	ReparentShardInteractive.Flags().StringVar(&reparentShardInteractiveOptions.NewPrimaryAliasStr, "new-primary", "", "Alias of a tablet that should be the new primary. If not specified, the best ranked candidate is proposed.")
+	ReparentShardInteractive.Flags().StringVar(&reparentShardInteractiveOptions.AvoidPrimaryAliasStr, "avoid-primary", "", "Alias of a tablet that should not be the new primary.")
+	ReparentShardInteractive.Flags().DurationVar(&reparentShardInteractiveOptions.WaitReplicasTimeout, "wait-replicas-timeout", *topo.RemoteOperationTimeout, "Time to wait for replicas to catch up in reparenting.")
+	ReparentShardInteractive.Flags().BoolVar(&reparentShardInteractiveOptions.Yes, "yes", false, "Promotes the proposed tablet without asking.")
+	Root.AddCommand(ReparentShardInteractive)
+
 	Root.AddCommand(ReparentTablet)
 	Root.AddCommand(TabletExternallyReparented)
 }
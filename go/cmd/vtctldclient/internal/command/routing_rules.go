@@ -40,14 +40,33 @@ var (
 		Args:                  cobra.NoArgs,
 		RunE:                  commandApplyRoutingRules,
 	}
+	// ApplyRoutingRulesBatch makes an ApplyRoutingRulesBatch gRPC call to a vtctld.
+	ApplyRoutingRulesBatch = &cobra.Command{
+		Use:   "ApplyRoutingRulesBatch [--rules RULES | --rules-file RULES_FILE] [--delete-tables=t1,t2,...] [--replace] [--expected-version VERSION] [--vtgates=host:port,...] [--cells=c1,c2,...] [--skip-rebuild] [--dry-run]",
+		Short: "Atomically sets the routing rules of some tables, and deletes the ones of others.",
+		Long: `Atomically sets the routing rules of the tables in the given rules, and deletes the ones of the --delete-tables, or replaces all the rules with --replace.
+
+The rules are validated, and only saved if they are at --expected-version, as displayed by GetRoutingRules --with-version, or didn't change since they were read. Displays the changes of the rules, and with --vtgates, the queries in the plan cache of the vtgates whose routing changes.`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.NoArgs,
+		RunE:                  commandApplyRoutingRulesBatch,
+	}
 	// GetRoutingRules makes a GetRoutingRules gRPC call to a vtctld.
 	GetRoutingRules = &cobra.Command{
-		Use:                   "GetRoutingRules",
+		Use:                   "GetRoutingRules [--with-version]",
 		Short:                 "Displays the VSchema routing rules.",
 		DisableFlagsInUseLine: true,
 		Args:                  cobra.NoArgs,
 		RunE:                  commandGetRoutingRules,
 	}
+	// ValidateRoutingRules makes a ValidateRoutingRules gRPC call to a vtctld.
+	ValidateRoutingRules = &cobra.Command{
+		Use:                   "ValidateRoutingRules {--rules RULES | --rules-file RULES_FILE}",
+		Short:                 "Validates the VSchema routing rules against the current VSchemas, without applying them.",
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.NoArgs,
+		RunE:                  commandValidateRoutingRules,
+	}
 )
 
 var applyRoutingRulesOptions = struct {
@@ -127,6 +146,58 @@ func commandApplyRoutingRules(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+var applyRoutingRulesBatchOptions = struct {
+	Rules           string
+	RulesFilePath   string
+	DeleteTables    []string
+	Replace         bool
+	ExpectedVersion string
+	Vtgates         []string
+	Cells           []string
+	SkipRebuild     bool
+	DryRun          bool
+}{}
+
+func commandApplyRoutingRulesBatch(cmd *cobra.Command, args []string) error {
+	if applyRoutingRulesBatchOptions.Rules != "" && applyRoutingRulesBatchOptions.RulesFilePath != "" {
+		return fmt.Errorf("cannot pass both --rules (=%s) and --rules-file (=%s)", applyRoutingRulesBatchOptions.Rules, applyRoutingRulesBatchOptions.RulesFilePath)
+	}
+
+	cli.FinishedParsing(cmd)
+
+	rr, err := readRoutingRules(applyRoutingRulesBatchOptions.Rules, applyRoutingRulesBatchOptions.RulesFilePath)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.ApplyRoutingRulesBatch(commandCtx, &vtctldatapb.ApplyRoutingRulesBatchRequest{
+		Rules:           rr.Rules,
+		DeleteTables:    applyRoutingRulesBatchOptions.DeleteTables,
+		Replace:         applyRoutingRulesBatchOptions.Replace,
+		ExpectedVersion: applyRoutingRulesBatchOptions.ExpectedVersion,
+		DryRun:          applyRoutingRulesBatchOptions.DryRun,
+		Vtgates:         applyRoutingRulesBatchOptions.Vtgates,
+		SkipRebuild:     applyRoutingRulesBatchOptions.SkipRebuild,
+		RebuildCells:    applyRoutingRulesBatchOptions.Cells,
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
+
+var getRoutingRulesOptions = struct {
+	WithVersion bool
+}{}
+
 func commandGetRoutingRules(cmd *cobra.Command, args []string) error {
 	cli.FinishedParsing(cmd)
 
@@ -135,7 +206,12 @@ func commandGetRoutingRules(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	data, err := cli.MarshalJSON(resp.RoutingRules)
+	var data []byte
+	if getRoutingRulesOptions.WithVersion {
+		data, err = cli.MarshalJSON(resp)
+	} else {
+		data, err = cli.MarshalJSON(resp.RoutingRules)
+	}
 	if err != nil {
 		return err
 	}
@@ -145,6 +221,68 @@ func commandGetRoutingRules(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+var validateRoutingRulesOptions = struct {
+	Rules         string
+	RulesFilePath string
+}{}
+
+func commandValidateRoutingRules(cmd *cobra.Command, args []string) error {
+	if validateRoutingRulesOptions.Rules != "" && validateRoutingRulesOptions.RulesFilePath != "" {
+		return fmt.Errorf("cannot pass both --rules (=%s) and --rules-file (=%s)", validateRoutingRulesOptions.Rules, validateRoutingRulesOptions.RulesFilePath)
+	}
+
+	if validateRoutingRulesOptions.Rules == "" && validateRoutingRulesOptions.RulesFilePath == "" {
+		return errors.New("must pass exactly one of --rules and --rules-file")
+	}
+
+	cli.FinishedParsing(cmd)
+
+	rr, err := readRoutingRules(validateRoutingRulesOptions.Rules, validateRoutingRulesOptions.RulesFilePath)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.ValidateRoutingRules(commandCtx, &vtctldatapb.ValidateRoutingRulesRequest{
+		RoutingRules: rr,
+	})
+	if err != nil {
+		return err
+	}
+
+	if resp.Error != "" {
+		return fmt.Errorf("invalid routing rules: %s", resp.Error)
+	}
+
+	fmt.Println("ValidateRoutingRules: ok")
+
+	return nil
+}
+
+// readRoutingRules parses the routing rules given as JSON, either in rules
+// or in the file at rulesFilePath. No rules are empty routing rules.
+func readRoutingRules(rules string, rulesFilePath string) (*vschemapb.RoutingRules, error) {
+	rulesBytes := []byte(rules)
+	if rulesFilePath != "" {
+		data, err := ioutil.ReadFile(rulesFilePath)
+		if err != nil {
+			return nil, err
+		}
+
+		rulesBytes = data
+	}
+
+	rr := &vschemapb.RoutingRules{}
+	if len(rulesBytes) == 0 {
+		return rr, nil
+	}
+
+	if err := json2.Unmarshal(rulesBytes, rr); err != nil {
+		return nil, err
+	}
+
+	return rr, nil
+}
+
 func init() {
 	ApplyRoutingRules.Flags().StringVarP(&applyRoutingRulesOptions.Rules, "rules", "r", "", "Routing rules, specified as a string")
 	ApplyRoutingRules.Flags().StringVarP(&applyRoutingRulesOptions.RulesFilePath, "rules-file", "f", "", "Path to a file containing routing rules specified as JSON")
@@ -153,5 +291,21 @@ func init() {
 	ApplyRoutingRules.Flags().BoolVarP(&applyRoutingRulesOptions.DryRun, "dry-run", "d", false, "Load the specified routing rules as a validation step, but do not actually apply the rules to the topo.")
 	Root.AddCommand(ApplyRoutingRules)
 
+	ApplyRoutingRulesBatch.Flags().StringVarP(&applyRoutingRulesBatchOptions.Rules, "rules", "r", "", "Routing rules of the tables to set, specified as a string")
+	ApplyRoutingRulesBatch.Flags().StringVarP(&applyRoutingRulesBatchOptions.RulesFilePath, "rules-file", "f", "", "Path to a file containing the routing rules of the tables to set, specified as JSON")
+	ApplyRoutingRulesBatch.Flags().StringSliceVar(&applyRoutingRulesBatchOptions.DeleteTables, "delete-tables", nil, "Tables whose routing rules are deleted")
+	ApplyRoutingRulesBatch.Flags().BoolVar(&applyRoutingRulesBatchOptions.Replace, "replace", false, "Replace all the routing rules with the given ones")
+	ApplyRoutingRulesBatch.Flags().StringVar(&applyRoutingRulesBatchOptions.ExpectedVersion, "expected-version", "", "Only apply the rules if the current ones are at this version, as displayed by GetRoutingRules --with-version")
+	ApplyRoutingRulesBatch.Flags().StringSliceVar(&applyRoutingRulesBatchOptions.Vtgates, "vtgates", nil, "Host:port of the vtgates whose plan cache is checked for the queries whose routing changes")
+	ApplyRoutingRulesBatch.Flags().StringSliceVarP(&applyRoutingRulesBatchOptions.Cells, "cells", "c", nil, "Limit the VSchema graph rebuilding to the specified cells. Ignored if --skip-rebuild is specified.")
+	ApplyRoutingRulesBatch.Flags().BoolVar(&applyRoutingRulesBatchOptions.SkipRebuild, "skip-rebuild", false, "Skip rebuilding the SrvVSchema objects.")
+	ApplyRoutingRulesBatch.Flags().BoolVarP(&applyRoutingRulesBatchOptions.DryRun, "dry-run", "d", false, "Validate the rules and display the changes, without applying them.")
+	Root.AddCommand(ApplyRoutingRulesBatch)
+
+	GetRoutingRules.Flags().BoolVar(&getRoutingRulesOptions.WithVersion, "with-version", false, "Also display the version of the routing rules, to use with ApplyRoutingRulesBatch --expected-version.")
 	Root.AddCommand(GetRoutingRules)
+
+	ValidateRoutingRules.Flags().StringVarP(&validateRoutingRulesOptions.Rules, "rules", "r", "", "Routing rules, specified as a string")
+	ValidateRoutingRules.Flags().StringVarP(&validateRoutingRulesOptions.RulesFilePath, "rules-file", "f", "", "Path to a file containing routing rules specified as JSON")
+	Root.AddCommand(ValidateRoutingRules)
 }
@@ -19,11 +19,15 @@ package command
 import (
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"os/user"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/protoutil"
 	"vitess.io/vitess/go/vt/topo/topoproto"
 
 	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
@@ -36,6 +40,117 @@ var GetSchema = &cobra.Command{
 	RunE: commandGetSchema,
 }
 
+// GetOnlineDDLProgress makes a GetOnlineDDLProgress gRPC call to a vtctld.
+var GetOnlineDDLProgress = &cobra.Command{
+	Use:   "GetOnlineDDLProgress <keyspace> <migration_uuid>",
+	Short: "Outputs the copy progress, ETA, throttling and vreplication lag of an online DDL migration on each shard, and their aggregates.",
+	Args:  cobra.ExactArgs(2),
+	RunE:  commandGetOnlineDDLProgress,
+}
+
+// PreviewDeclarativeSchema makes a PreviewDeclarativeSchema gRPC call to a
+// vtctld.
+var PreviewDeclarativeSchema = &cobra.Command{
+	Use:   "PreviewDeclarativeSchema {--sql SQL | --sql-file SQL_FILE} [--allow-drops] <keyspace>",
+	Short: "Outputs the tables to create, alter and drop to converge the schema of a keyspace to the CREATE TABLE statements of all its tables.",
+	Args:  cobra.ExactArgs(1),
+	RunE:  commandPreviewDeclarativeSchema,
+}
+
+// GetGCTables makes a GetGCTables gRPC call to a vtctld.
+var GetGCTables = &cobra.Command{
+	Use:   "GetGCTables <keyspace>",
+	Short: "Outputs the dropped tables in the HOLD, PURGE, EVAC and DROP states of the table GC of a keyspace, and when they move to their next state.",
+	Args:  cobra.ExactArgs(1),
+	RunE:  commandGetGCTables,
+}
+
+// SetGCTablesRetention makes a SetGCTablesRetention gRPC call to a vtctld.
+var SetGCTablesRetention = &cobra.Command{
+	Use:   "SetGCTablesRetention --retention RETENTION <keyspace> {HOLD | EVAC} [<uuid> ...]",
+	Short: "Keeps the HOLD or EVAC tables of the table GC of a keyspace, or only those of the given UUIDs, in their state for the given retention from now on.",
+	Args:  cobra.MinimumNArgs(2),
+	RunE:  commandSetGCTablesRetention,
+}
+
+// AdvanceGCTable makes an AdvanceGCTable gRPC call to a vtctld.
+var AdvanceGCTable = &cobra.Command{
+	Use:   "AdvanceGCTable <keyspace> <uuid>",
+	Short: "Moves the HOLD or EVAC tables of a UUID to their next state at the next check of the table GC.",
+	Args:  cobra.ExactArgs(2),
+	RunE:  commandAdvanceGCTable,
+}
+
+// RestoreGCTable makes a RestoreGCTable gRPC call to a vtctld.
+var RestoreGCTable = &cobra.Command{
+	Use:   "RestoreGCTable [--to-table TABLE] <keyspace> <uuid>",
+	Short: "Renames the HOLD tables of a UUID back to the given table, or to the table dropped by the online DDL migration of the UUID.",
+	Args:  cobra.ExactArgs(2),
+	RunE:  commandRestoreGCTable,
+}
+
+// ApproveMigration makes an ApproveMigration gRPC call to a vtctld.
+var ApproveMigration = &cobra.Command{
+	Use:   "ApproveMigration [--reviewer IDENTITY] <keyspace> <migration_uuid>",
+	Short: "Approves an online DDL migration pending approval, which is then queued on all the shards.",
+	Args:  cobra.ExactArgs(2),
+	RunE:  commandApproveMigration,
+}
+
+// RejectMigration makes a RejectMigration gRPC call to a vtctld.
+var RejectMigration = &cobra.Command{
+	Use:   "RejectMigration [--reviewer IDENTITY] [--message REASON] <keyspace> <migration_uuid>",
+	Short: "Rejects an online DDL migration pending approval, which is then cancelled on all the shards.",
+	Args:  cobra.ExactArgs(2),
+	RunE:  commandRejectMigration,
+}
+
+var reviewMigrationOptions = struct {
+	Reviewer string
+	Message  string
+}{}
+
+// RevertMigration makes a RevertMigration gRPC call to a vtctld.
+var RevertMigration = &cobra.Command{
+	Use:   "RevertMigration [--dry-run] [--request-context CONTEXT] <keyspace> <migration_uuid>",
+	Short: "Reverts a complete online DDL migration, if its artifacts are still kept on all the shards, and outputs the UUID of the revert migration.",
+	Args:  cobra.ExactArgs(2),
+	RunE:  commandRevertMigration,
+}
+
+// SeedTable makes a SeedTable gRPC call to a vtctld.
+var SeedTable = &cobra.Command{
+	Use:   "SeedTable [--batch-size N] [--max-rows-per-second N] [--max-source-rows N] <keyspace> <table>",
+	Short: "Inserts the rows of the seed source declared in the VSchema for a table on the primaries of its shards, and outputs the progress of each shard. The rows whose keys already exist are skipped.",
+	Args:  cobra.ExactArgs(2),
+	RunE:  commandSeedTable,
+}
+
+var revertMigrationOptions = struct {
+	DryRun         bool
+	RequestContext string
+}{}
+
+var seedTableOptions = struct {
+	BatchSize        int64
+	MaxRowsPerSecond int64
+	MaxSourceRows    int64
+}{}
+
+var setGCTablesRetentionOptions = struct {
+	Retention time.Duration
+}{}
+
+var restoreGCTableOptions = struct {
+	ToTable string
+}{}
+
+var previewDeclarativeSchemaOptions = struct {
+	SQL        string
+	SQLFile    string
+	AllowDrops bool
+}{}
+
 var getSchemaOptions = struct {
 	Tables         []string
 	ExcludeTables  []string
@@ -90,6 +205,272 @@ func commandGetSchema(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func commandGetOnlineDDLProgress(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.GetOnlineDDLProgress(commandCtx, &vtctldatapb.GetOnlineDDLProgressRequest{
+		Keyspace: cmd.Flags().Arg(0),
+		Uuid:     cmd.Flags().Arg(1),
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
+
+func commandPreviewDeclarativeSchema(cmd *cobra.Command, args []string) error {
+	desiredSchema := previewDeclarativeSchemaOptions.SQL
+	switch {
+	case desiredSchema != "" && previewDeclarativeSchemaOptions.SQLFile != "":
+		return errors.New("can only pass one of --sql and --sql-file")
+	case previewDeclarativeSchemaOptions.SQLFile != "":
+		data, err := ioutil.ReadFile(previewDeclarativeSchemaOptions.SQLFile)
+		if err != nil {
+			return err
+		}
+		desiredSchema = string(data)
+	case desiredSchema == "":
+		return errors.New("one of --sql and --sql-file is required")
+	}
+
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.PreviewDeclarativeSchema(commandCtx, &vtctldatapb.PreviewDeclarativeSchemaRequest{
+		Keyspace:   cmd.Flags().Arg(0),
+		Sql:        desiredSchema,
+		AllowDrops: previewDeclarativeSchemaOptions.AllowDrops,
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
+
+func commandApproveMigration(cmd *cobra.Command, args []string) error {
+	reviewer, err := migrationReviewer()
+	if err != nil {
+		return err
+	}
+
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.ApproveMigration(commandCtx, &vtctldatapb.ApproveMigrationRequest{
+		Keyspace: cmd.Flags().Arg(0),
+		Uuid:     cmd.Flags().Arg(1),
+		Reviewer: reviewer,
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
+
+func commandRejectMigration(cmd *cobra.Command, args []string) error {
+	reviewer, err := migrationReviewer()
+	if err != nil {
+		return err
+	}
+
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.RejectMigration(commandCtx, &vtctldatapb.RejectMigrationRequest{
+		Keyspace: cmd.Flags().Arg(0),
+		Uuid:     cmd.Flags().Arg(1),
+		Reviewer: reviewer,
+		Message:  reviewMigrationOptions.Message,
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
+
+// migrationReviewer returns the identity recorded as the reviewer of a
+// migration: --reviewer, or else the local user.
+func migrationReviewer() (string, error) {
+	if reviewMigrationOptions.Reviewer != "" {
+		return reviewMigrationOptions.Reviewer, nil
+	}
+
+	u, err := user.Current()
+	if err != nil {
+		return "", fmt.Errorf("cannot determine the reviewer, use --reviewer: %v", err)
+	}
+
+	return u.Username, nil
+}
+
+func commandRevertMigration(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.RevertMigration(commandCtx, &vtctldatapb.RevertMigrationRequest{
+		Keyspace:       cmd.Flags().Arg(0),
+		Uuid:           cmd.Flags().Arg(1),
+		RequestContext: revertMigrationOptions.RequestContext,
+		DryRun:         revertMigrationOptions.DryRun,
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
+
+func commandSeedTable(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.SeedTable(commandCtx, &vtctldatapb.SeedTableRequest{
+		Keyspace:         cmd.Flags().Arg(0),
+		Table:            cmd.Flags().Arg(1),
+		BatchSize:        seedTableOptions.BatchSize,
+		MaxRowsPerSecond: seedTableOptions.MaxRowsPerSecond,
+		MaxSourceRows:    seedTableOptions.MaxSourceRows,
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	for _, shard := range resp.Shards {
+		if shard.Error != "" {
+			return fmt.Errorf("SeedTable failed on shard %s: %s", shard.Shard, shard.Error)
+		}
+	}
+
+	return nil
+}
+
+func commandGetGCTables(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.GetGCTables(commandCtx, &vtctldatapb.GetGCTablesRequest{
+		Keyspace: cmd.Flags().Arg(0),
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
+
+func commandSetGCTablesRetention(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.SetGCTablesRetention(commandCtx, &vtctldatapb.SetGCTablesRetentionRequest{
+		Keyspace:  cmd.Flags().Arg(0),
+		State:     cmd.Flags().Arg(1),
+		Uuids:     cmd.Flags().Args()[2:],
+		Retention: protoutil.DurationToProto(setGCTablesRetentionOptions.Retention),
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
+
+func commandAdvanceGCTable(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.AdvanceGCTable(commandCtx, &vtctldatapb.AdvanceGCTableRequest{
+		Keyspace: cmd.Flags().Arg(0),
+		Uuid:     cmd.Flags().Arg(1),
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
+
+func commandRestoreGCTable(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.RestoreGCTable(commandCtx, &vtctldatapb.RestoreGCTableRequest{
+		Keyspace: cmd.Flags().Arg(0),
+		Uuid:     cmd.Flags().Arg(1),
+		ToTable:  restoreGCTableOptions.ToTable,
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
+
 func init() {
 	GetSchema.Flags().StringSliceVar(&getSchemaOptions.Tables, "tables", nil, "TODO")
 	GetSchema.Flags().StringSliceVar(&getSchemaOptions.ExcludeTables, "exclude-tables", nil, "TODO")
@@ -98,4 +479,33 @@ func init() {
 	GetSchema.Flags().BoolVarP(&getSchemaOptions.TableSizesOnly, "table-sizes-only", "s", false, "TODO")
 
 	Root.AddCommand(GetSchema)
+	Root.AddCommand(GetOnlineDDLProgress)
+
+	PreviewDeclarativeSchema.Flags().StringVar(&previewDeclarativeSchemaOptions.SQL, "sql", "", "The semicolon-delimited CREATE TABLE statements of all the tables of the keyspace.")
+	PreviewDeclarativeSchema.Flags().StringVar(&previewDeclarativeSchemaOptions.SQLFile, "sql-file", "", "Path to a file containing the CREATE TABLE statements of all the tables of the keyspace.")
+	PreviewDeclarativeSchema.Flags().BoolVar(&previewDeclarativeSchemaOptions.AllowDrops, "allow-drops", false, "Drop the tables of the keyspace which aren't in the desired schema.")
+	Root.AddCommand(PreviewDeclarativeSchema)
+
+	ApproveMigration.Flags().StringVar(&reviewMigrationOptions.Reviewer, "reviewer", "", "The identity recorded as the reviewer of the migration. Defaults to the local user.")
+	Root.AddCommand(ApproveMigration)
+
+	RejectMigration.Flags().StringVar(&reviewMigrationOptions.Reviewer, "reviewer", "", "The identity recorded as the reviewer of the migration. Defaults to the local user.")
+	RejectMigration.Flags().StringVar(&reviewMigrationOptions.Message, "message", "", "The reason of the rejection.")
+	Root.AddCommand(RejectMigration)
+
+	RevertMigration.Flags().BoolVar(&revertMigrationOptions.DryRun, "dry-run", false, "Only outputs the revert windows of the migration on each shard, without reverting it.")
+	RevertMigration.Flags().StringVar(&revertMigrationOptions.RequestContext, "request-context", "", "The request context of the revert migration. Defaults to vtctl:<uuid>.")
+	Root.AddCommand(RevertMigration)
+
+	SeedTable.Flags().Int64Var(&seedTableOptions.BatchSize, "batch-size", 1000, "The number of rows inserted per statement.")
+	SeedTable.Flags().Int64Var(&seedTableOptions.MaxRowsPerSecond, "max-rows-per-second", 0, "The maximum number of rows inserted per second on each shard. 0 means unlimited.")
+	SeedTable.Flags().Int64Var(&seedTableOptions.MaxSourceRows, "max-source-rows", 100000, "The maximum number of rows read from each shard of a source table.")
+	Root.AddCommand(SeedTable)
+
+	Root.AddCommand(GetGCTables)
+	SetGCTablesRetention.Flags().DurationVar(&setGCTablesRetentionOptions.Retention, "retention", 24*time.Hour, "The time the tables stay in their state from now on.")
+	Root.AddCommand(SetGCTablesRetention)
+	Root.AddCommand(AdvanceGCTable)
+	RestoreGCTable.Flags().StringVar(&restoreGCTableOptions.ToTable, "to-table", "", "The table to rename the HOLD tables to. Defaults to the table dropped by the online DDL migration of the UUID.")
+	Root.AddCommand(RestoreGCTable)
 }
@@ -17,12 +17,16 @@ limitations under the License.
 package command
 
 import (
+	"errors"
 	"fmt"
+	"io/ioutil"
 
 	"github.com/spf13/cobra"
 
 	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/json2"
 
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
 )
 
@@ -35,6 +39,14 @@ var (
 		RunE:                  commandGetSrvKeyspaces,
 		DisableFlagsInUseLine: true,
 	}
+	// GetSrvKeyspaceWithVersion makes a GetSrvKeyspaceWithVersion gRPC call to a vtctld.
+	GetSrvKeyspaceWithVersion = &cobra.Command{
+		Use:                   "GetSrvKeyspaceWithVersion <keyspace> <cell>",
+		Short:                 "Returns the SrvKeyspace for the given keyspace in a cell, with its version, to edit it with UpdateSrvKeyspace.",
+		Args:                  cobra.ExactArgs(2),
+		RunE:                  commandGetSrvKeyspaceWithVersion,
+		DisableFlagsInUseLine: true,
+	}
 	// GetSrvVSchema makes a GetSrvVSchema gRPC call to a vtctld.
 	GetSrvVSchema = &cobra.Command{
 		Use:                   "GetSrvVSchema cell",
@@ -51,14 +63,38 @@ var (
 		RunE:                  commandGetSrvVSchemas,
 		DisableFlagsInUseLine: true,
 	}
+	// RebuildKeyspaceGraph makes a RebuildKeyspaceGraph gRPC call to a vtctld.
+	RebuildKeyspaceGraph = &cobra.Command{
+		Use:                   "RebuildKeyspaceGraph [--cells=c1,c2,...] [--allow-partial] <keyspace>",
+		Short:                 "Rebuilds the cell-specific SrvKeyspace of a keyspace from its global shard records in the provided cells (or all cells if none provided), and outputs the rebuild report.",
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+		RunE:                  commandRebuildKeyspaceGraph,
+	}
 	// RebuildVSchemaGraph makes a RebuildVSchemaGraph gRPC call to a vtctld.
 	RebuildVSchemaGraph = &cobra.Command{
 		Use:                   "RebuildVSchemaGraph [--cells=c1,c2,...]",
-		Short:                 "Rebuilds the cell-specific SrvVSchema from the global VSchema objects in the provided cells (or all cells if none provided).",
+		Short:                 "Rebuilds the cell-specific SrvVSchema from the global VSchema objects in the provided cells (or all cells if none provided), and outputs the rebuild report.",
 		DisableFlagsInUseLine: true,
 		Args:                  cobra.NoArgs,
 		RunE:                  commandRebuildVSchemaGraph,
 	}
+	// UpdateSrvKeyspace makes an UpdateSrvKeyspace gRPC call to a vtctld.
+	UpdateSrvKeyspace = &cobra.Command{
+		Use:                   "UpdateSrvKeyspace {--srv-keyspace SRV_KEYSPACE | --srv-keyspace-file SRV_KEYSPACE_FILE} [--expected-version VERSION] [--dry-run] <keyspace> <cell>",
+		Short:                 "Replaces the SrvKeyspace of a keyspace in a cell, e.g. during a manual recovery, if it is still at the expected version. Without an expected version, the SrvKeyspace is created.",
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(2),
+		RunE:                  commandUpdateSrvKeyspace,
+	}
+	// ValidateSrvKeyspace makes a ValidateSrvKeyspace gRPC call to a vtctld.
+	ValidateSrvKeyspace = &cobra.Command{
+		Use:                   "ValidateSrvKeyspace {--srv-keyspace SRV_KEYSPACE | --srv-keyspace-file SRV_KEYSPACE_FILE}",
+		Short:                 "Checks that each served type of a SrvKeyspace has a single partition, whose shards cover the whole key space without gaps or overlaps.",
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.NoArgs,
+		RunE:                  commandValidateSrvKeyspace,
+	}
 )
 
 func commandGetSrvKeyspaces(cmd *cobra.Command, args []string) error {
@@ -85,6 +121,27 @@ func commandGetSrvKeyspaces(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func commandGetSrvKeyspaceWithVersion(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.GetSrvKeyspaceWithVersion(commandCtx, &vtctldatapb.GetSrvKeyspaceWithVersionRequest{
+		Keyspace: cmd.Flags().Arg(0),
+		Cell:     cmd.Flags().Arg(1),
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
+
 func commandGetSrvVSchema(cmd *cobra.Command, args []string) error {
 	cli.FinishedParsing(cmd)
 
@@ -134,6 +191,33 @@ func commandGetSrvVSchemas(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+var rebuildKeyspaceGraphOptions = struct {
+	Cells        []string
+	AllowPartial bool
+}{}
+
+func commandRebuildKeyspaceGraph(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.RebuildKeyspaceGraph(commandCtx, &vtctldatapb.RebuildKeyspaceGraphRequest{
+		Keyspace:     cmd.Flags().Arg(0),
+		Cells:        rebuildKeyspaceGraphOptions.Cells,
+		AllowPartial: rebuildKeyspaceGraphOptions.AllowPartial,
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp.Report)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
+
 var rebuildVSchemaGraphOptions = struct {
 	Cells []string
 }{}
@@ -141,23 +225,163 @@ var rebuildVSchemaGraphOptions = struct {
 func commandRebuildVSchemaGraph(cmd *cobra.Command, args []string) error {
 	cli.FinishedParsing(cmd)
 
-	_, err := client.RebuildVSchemaGraph(commandCtx, &vtctldatapb.RebuildVSchemaGraphRequest{
+	resp, err := client.RebuildVSchemaGraph(commandCtx, &vtctldatapb.RebuildVSchemaGraphRequest{
 		Cells: rebuildVSchemaGraphOptions.Cells,
 	})
 	if err != nil {
 		return err
 	}
 
-	fmt.Println("RebuildVSchemaGraph: ok")
+	data, err := cli.MarshalJSON(resp.Report)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
+
+// srvKeyspaceOptions are the flags of the commands taking a SrvKeyspace as
+// JSON.
+type srvKeyspaceOptions struct {
+	SrvKeyspace         string
+	SrvKeyspaceFilePath string
+}
+
+func (opts *srvKeyspaceOptions) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&opts.SrvKeyspace, "srv-keyspace", "", "The SrvKeyspace, as JSON.")
+	cmd.Flags().StringVar(&opts.SrvKeyspaceFilePath, "srv-keyspace-file", "", "A file containing the SrvKeyspace, as JSON.")
+}
+
+func (opts *srvKeyspaceOptions) check() error {
+	if opts.SrvKeyspace != "" && opts.SrvKeyspaceFilePath != "" {
+		return fmt.Errorf("cannot pass both --srv-keyspace (=%s) and --srv-keyspace-file (=%s)", opts.SrvKeyspace, opts.SrvKeyspaceFilePath)
+	}
+
+	if opts.SrvKeyspace == "" && opts.SrvKeyspaceFilePath == "" {
+		return errors.New("must pass exactly one of --srv-keyspace and --srv-keyspace-file")
+	}
+
+	return nil
+}
+
+func (opts *srvKeyspaceOptions) srvKeyspace() (*topodatapb.SrvKeyspace, error) {
+	data := []byte(opts.SrvKeyspace)
+	if opts.SrvKeyspaceFilePath != "" {
+		var err error
+		data, err = ioutil.ReadFile(opts.SrvKeyspaceFilePath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	srvKeyspace := &topodatapb.SrvKeyspace{}
+	if err := json2.Unmarshal(data, srvKeyspace); err != nil {
+		return nil, fmt.Errorf("cannot parse SrvKeyspace: %w", err)
+	}
+
+	return srvKeyspace, nil
+}
+
+var updateSrvKeyspaceOptions = struct {
+	srvKeyspaceOptions
+	ExpectedVersion string
+	DryRun          bool
+}{}
+
+func commandUpdateSrvKeyspace(cmd *cobra.Command, args []string) error {
+	if err := updateSrvKeyspaceOptions.check(); err != nil {
+		return err
+	}
+
+	cli.FinishedParsing(cmd)
+
+	srvKeyspace, err := updateSrvKeyspaceOptions.srvKeyspace()
+	if err != nil {
+		return err
+	}
+
+	if updateSrvKeyspaceOptions.DryRun {
+		resp, err := client.ValidateSrvKeyspace(commandCtx, &vtctldatapb.ValidateSrvKeyspaceRequest{
+			SrvKeyspace: srvKeyspace,
+		})
+		if err != nil {
+			return err
+		}
+
+		if resp.Error != "" {
+			return fmt.Errorf("invalid SrvKeyspace: %s", resp.Error)
+		}
+
+		fmt.Println("[DRY RUN] The SrvKeyspace is valid, skipping its update")
+
+		return nil
+	}
+
+	resp, err := client.UpdateSrvKeyspace(commandCtx, &vtctldatapb.UpdateSrvKeyspaceRequest{
+		Keyspace:        cmd.Flags().Arg(0),
+		Cell:            cmd.Flags().Arg(1),
+		SrvKeyspace:     srvKeyspace,
+		ExpectedVersion: updateSrvKeyspaceOptions.ExpectedVersion,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("New version of the SrvKeyspace: %s\n", resp.Version)
+
+	return nil
+}
+
+var validateSrvKeyspaceOptions srvKeyspaceOptions
+
+func commandValidateSrvKeyspace(cmd *cobra.Command, args []string) error {
+	if err := validateSrvKeyspaceOptions.check(); err != nil {
+		return err
+	}
+
+	cli.FinishedParsing(cmd)
+
+	srvKeyspace, err := validateSrvKeyspaceOptions.srvKeyspace()
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.ValidateSrvKeyspace(commandCtx, &vtctldatapb.ValidateSrvKeyspaceRequest{
+		SrvKeyspace: srvKeyspace,
+	})
+	if err != nil {
+		return err
+	}
+
+	if resp.Error != "" {
+		return fmt.Errorf("invalid SrvKeyspace: %s", resp.Error)
+	}
+
+	fmt.Println("ValidateSrvKeyspace: ok")
 
 	return nil
 }
 
 func init() {
 	Root.AddCommand(GetSrvKeyspaces)
+	Root.AddCommand(GetSrvKeyspaceWithVersion)
 	Root.AddCommand(GetSrvVSchema)
 	Root.AddCommand(GetSrvVSchemas)
 
+	RebuildKeyspaceGraph.Flags().StringSliceVarP(&rebuildKeyspaceGraphOptions.Cells, "cells", "c", nil, "Specifies a comma-separated list of cells to update")
+	RebuildKeyspaceGraph.Flags().BoolVar(&rebuildKeyspaceGraphOptions.AllowPartial, "allow-partial", false, "Specifies whether a SNAPSHOT keyspace is allowed to serve with an incomplete set of shards. Ignored for all other types of keyspaces")
+	Root.AddCommand(RebuildKeyspaceGraph)
+
 	RebuildVSchemaGraph.Flags().StringSliceVarP(&rebuildVSchemaGraphOptions.Cells, "cells", "c", nil, "Specifies a comma-separated list of cells to look for tablets")
 	Root.AddCommand(RebuildVSchemaGraph)
+
+	updateSrvKeyspaceOptions.addFlags(UpdateSrvKeyspace)
+	UpdateSrvKeyspace.Flags().StringVar(&updateSrvKeyspaceOptions.ExpectedVersion, "expected-version", "", "The version of the SrvKeyspace being replaced, as returned by GetSrvKeyspaceWithVersion. If empty, the SrvKeyspace is created.")
+	UpdateSrvKeyspace.Flags().BoolVar(&updateSrvKeyspaceOptions.DryRun, "dry-run", false, "Only validates the SrvKeyspace, without updating it.")
+	Root.AddCommand(UpdateSrvKeyspace)
+
+	validateSrvKeyspaceOptions.addFlags(ValidateSrvKeyspace)
+	Root.AddCommand(ValidateSrvKeyspace)
 }
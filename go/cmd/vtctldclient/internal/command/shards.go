@@ -22,6 +22,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/vt/logutil"
 	"vitess.io/vitess/go/vt/topo/topoproto"
 
 	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
@@ -40,12 +41,40 @@ var (
 		Args: cobra.MinimumNArgs(1),
 		RunE: commandDeleteShards,
 	}
+	// FenceShard makes a FenceShard gRPC request to a vtctld.
+	FenceShard = &cobra.Command{
+		Use:   "FenceShard [--reason REASON] <keyspace/shard>",
+		Short: "Stops the writes of a shard: the vtgates stop sending queries to its primary, and the primary becomes read-only. Prints the fence, with the final replication position of the primary.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  commandFenceShard,
+	}
 	// GetShard makes a GetShard gRPC request to a vtctld.
 	GetShard = &cobra.Command{
 		Use:  "GetShard <keyspace/shard>",
 		Args: cobra.ExactArgs(1),
 		RunE: commandGetShard,
 	}
+	// GetShardFence makes a GetShardFence gRPC request to a vtctld.
+	GetShardFence = &cobra.Command{
+		Use:   "GetShardFence <keyspace/shard>",
+		Short: "Prints the fence of a fenced shard.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  commandGetShardFence,
+	}
+	// GetShardReplicationGraph makes a GetShardReplicationGraph gRPC request to a vtctld.
+	GetShardReplicationGraph = &cobra.Command{
+		Use:   "GetShardReplicationGraph <keyspace/shard>",
+		Short: "Prints the live MySQL replication topology of the shard, and the tablets which don't replicate from its primary or whose replication is stopped.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  commandGetShardReplicationGraph,
+	}
+	// GetShardServingTimeline makes a GetShardServingTimeline gRPC request to a vtctld.
+	GetShardServingTimeline = &cobra.Command{
+		Use:   "GetShardServingTimeline <keyspace/shard>",
+		Short: "Prints the transitions between serving and not serving of the tablets of the shard, oldest first.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  commandGetShardServingTimeline,
+	}
 	// RemoveShardCell makes a RemoveShardCell gRPC request to a vtctld.
 	RemoveShardCell = &cobra.Command{
 		Use:  "RemoveShardCell <keyspace/shard> <cell>",
@@ -62,6 +91,13 @@ Use ctrl-C to interrupt the command and see partial results if needed.`,
 		Args: cobra.ExactArgs(1),
 		RunE: commandShardReplicationPositions,
 	}
+	// UnfenceShard makes an UnfenceShard gRPC request to a vtctld.
+	UnfenceShard = &cobra.Command{
+		Use:   "UnfenceShard <keyspace/shard>",
+		Short: "Lets a fenced shard accept writes again.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  commandUnfenceShard,
+	}
 )
 
 var createShardOptions = struct {
@@ -125,6 +161,41 @@ func commandDeleteShards(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+var fenceShardOptions = struct {
+	Reason string
+}{}
+
+func commandFenceShard(cmd *cobra.Command, args []string) error {
+	keyspace, shard, err := topoproto.ParseKeyspaceShard(cmd.Flags().Arg(0))
+	if err != nil {
+		return err
+	}
+
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.FenceShard(commandCtx, &vtctldatapb.FenceShardRequest{
+		Keyspace: keyspace,
+		Shard:    shard,
+		Reason:   fenceShardOptions.Reason,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, event := range resp.Events {
+		fmt.Println(logutil.EventString(event))
+	}
+
+	data, err := cli.MarshalJSON(resp.Fence)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
+
 func commandGetShard(cmd *cobra.Command, args []string) error {
 	keyspace, shard, err := topoproto.ParseKeyspaceShard(cmd.Flags().Arg(0))
 	if err != nil {
@@ -151,6 +222,84 @@ func commandGetShard(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func commandGetShardFence(cmd *cobra.Command, args []string) error {
+	keyspace, shard, err := topoproto.ParseKeyspaceShard(cmd.Flags().Arg(0))
+	if err != nil {
+		return err
+	}
+
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.GetShardFence(commandCtx, &vtctldatapb.GetShardFenceRequest{
+		Keyspace: keyspace,
+		Shard:    shard,
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp.Fence)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
+
+func commandGetShardReplicationGraph(cmd *cobra.Command, args []string) error {
+	keyspace, shard, err := topoproto.ParseKeyspaceShard(cmd.Flags().Arg(0))
+	if err != nil {
+		return err
+	}
+
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.GetShardReplicationGraph(commandCtx, &vtctldatapb.GetShardReplicationGraphRequest{
+		Keyspace: keyspace,
+		Shard:    shard,
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp.Graph)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
+
+func commandGetShardServingTimeline(cmd *cobra.Command, args []string) error {
+	keyspace, shard, err := topoproto.ParseKeyspaceShard(cmd.Flags().Arg(0))
+	if err != nil {
+		return err
+	}
+
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.GetShardServingTimeline(commandCtx, &vtctldatapb.GetShardServingTimelineRequest{
+		Keyspace: keyspace,
+		Shard:    shard,
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
+
 var removeShardCellOptions = struct {
 	Force     bool
 	Recursive bool
@@ -215,6 +364,31 @@ func commandShardReplicationPositions(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func commandUnfenceShard(cmd *cobra.Command, args []string) error {
+	keyspace, shard, err := topoproto.ParseKeyspaceShard(cmd.Flags().Arg(0))
+	if err != nil {
+		return err
+	}
+
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.UnfenceShard(commandCtx, &vtctldatapb.UnfenceShardRequest{
+		Keyspace: keyspace,
+		Shard:    shard,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, event := range resp.Events {
+		fmt.Println(logutil.EventString(event))
+	}
+
+	fmt.Printf("Successfully unfenced shard %s/%s\n", keyspace, shard)
+
+	return nil
+}
+
 func init() {
 	CreateShard.Flags().BoolVarP(&createShardOptions.Force, "force", "f", false, "")
 	CreateShard.Flags().BoolVarP(&createShardOptions.IncludeParent, "include-parent", "p", false, "")
@@ -224,11 +398,18 @@ func init() {
 	DeleteShards.Flags().BoolVarP(&deleteShardsOptions.EvenIfServing, "even-if-serving", "f", false, "Remove the shard even if it is serving. Use with caution.")
 	Root.AddCommand(DeleteShards)
 
+	FenceShard.Flags().StringVar(&fenceShardOptions.Reason, "reason", "", "Records why the shard is fenced.")
+	Root.AddCommand(FenceShard)
+
 	Root.AddCommand(GetShard)
+	Root.AddCommand(GetShardFence)
+	Root.AddCommand(GetShardReplicationGraph)
+	Root.AddCommand(GetShardServingTimeline)
 
 	RemoveShardCell.Flags().BoolVarP(&removeShardCellOptions.Force, "force", "f", false, "Proceed even if the cell's topology server cannot be reached. The assumption is that you turned down the entire cell, and just need to update the global topo data.")
 	RemoveShardCell.Flags().BoolVarP(&removeShardCellOptions.Recursive, "recursive", "r", false, "Also delete all tablets in that cell beloning to the specified shard.")
 	Root.AddCommand(RemoveShardCell)
 
 	Root.AddCommand(ShardReplicationPositions)
+	Root.AddCommand(UnfenceShard)
 }
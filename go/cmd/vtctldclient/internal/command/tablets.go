@@ -19,10 +19,13 @@ package command
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/logutil"
 	"vitess.io/vitess/go/vt/topo/topoproto"
 
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
@@ -32,16 +35,29 @@ import (
 var (
 	// ChangeTabletType makes a ChangeTabletType gRPC call to a vtctld.
 	ChangeTabletType = &cobra.Command{
-		Use:   "ChangeTabletType [--dry-run] <alias> <tablet-type>",
+		Use:   "ChangeTabletType [--dry-run] [--force] <alias> <tablet-type>",
 		Short: "Changes the db type for the specified tablet, if possible.",
 		Long: `Changes the db type for the specified tablet, if possible.
 
 This command is used primarily to arrange replicas, and it will not convert a primary.
+It fails if fewer serving tablets of the former type than the -min_tablets_per_cell
+minimum of the vtctld would be left in the shard and cell of the tablet, unless --force is set.
 NOTE: This command automatically updates the serving graph.`,
 		DisableFlagsInUseLine: true,
 		Args:                  cobra.ExactArgs(2),
 		RunE:                  commandChangeTabletType,
 	}
+	// DecommissionTablet makes a DecommissionTablet gRPC call to a vtctld.
+	DecommissionTablet = &cobra.Command{
+		Use:   "DecommissionTablet [--drain-grace <duration>] <alias>",
+		Short: "Drains, stops the replication of and deletes a tablet nothing depends on.",
+		Long: `Checks the tablet isn't the primary or the last replica of its shard, nor the
+source of vreplication streams, then drains it, stops its replication and deletes
+it from the topology. Refuses with the reasons otherwise.`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+		RunE:                  commandDecommissionTablet,
+	}
 	// DeleteTablets makes a DeleteTablets gRPC call to a vtctld.
 	DeleteTablets = &cobra.Command{
 		Use:                   "DeleteTablets <alias> [ <alias> ... ]",
@@ -50,6 +66,14 @@ NOTE: This command automatically updates the serving graph.`,
 		Args:                  cobra.MinimumNArgs(1),
 		RunE:                  commandDeleteTablets,
 	}
+	// DrainTablet makes a DrainTablet gRPC call to a vtctld.
+	DrainTablet = &cobra.Command{
+		Use:                   "DrainTablet <alias>",
+		Short:                 "Stops the gateways from sending new queries to the tablet, letting the in-flight ones finish.",
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+		RunE:                  commandDrainTablet,
+	}
 	// GetTablet makes a GetTablet gRPC call to a vtctld.
 	GetTablet = &cobra.Command{
 		Use:                   "GetTablet <alias>",
@@ -80,6 +104,18 @@ Valid output formats are "awk" and "json".`,
 		Args:                  cobra.NoArgs,
 		RunE:                  commandGetTablets,
 	}
+	// RecommendTabletTypeChange makes a RecommendTabletTypeChange gRPC call to a vtctld.
+	RecommendTabletTypeChange = &cobra.Command{
+		Use:   "RecommendTabletTypeChange <keyspace/shard> <cell> <from-tablet-type> <to-tablet-type>",
+		Short: "Lists the tablets which can be changed from one type to another, the ones with the least impact first.",
+		Long: `Lists the tablets of the shard and cell which can be changed from one type to
+another with ChangeTabletType: first the changes which keep the -min_tablets_per_cell
+minimum, then the drained tablets, the unreachable tablets, and the tablets with the
+most replication lag.`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(4),
+		RunE:                  commandRecommendTabletTypeChange,
+	}
 	// RefreshState makes a RefreshState gRPC call to a vtctld.
 	RefreshState = &cobra.Command{
 		Use:                   "RefreshState <alias>",
@@ -96,10 +132,19 @@ Valid output formats are "awk" and "json".`,
 		Args:                  cobra.ExactArgs(1),
 		RunE:                  commandRefreshStateByShard,
 	}
+	// UndrainTablet makes an UndrainTablet gRPC call to a vtctld.
+	UndrainTablet = &cobra.Command{
+		Use:                   "UndrainTablet <alias>",
+		Short:                 "Lets the gateways send queries to a drained tablet again.",
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+		RunE:                  commandUndrainTablet,
+	}
 )
 
 var changeTabletTypeOptions = struct {
 	DryRun bool
+	Force  bool
 }{}
 
 func commandChangeTabletType(cmd *cobra.Command, args []string) error {
@@ -122,6 +167,7 @@ func commandChangeTabletType(cmd *cobra.Command, args []string) error {
 		TabletAlias: alias,
 		DbType:      newType,
 		DryRun:      changeTabletTypeOptions.DryRun,
+		Force:       changeTabletTypeOptions.Force,
 	})
 	if err != nil {
 		return err
@@ -133,6 +179,36 @@ func commandChangeTabletType(cmd *cobra.Command, args []string) error {
 
 	fmt.Printf("- %v\n", cli.MarshalTabletAWK(resp.BeforeTablet))
 	fmt.Printf("+ %v\n", cli.MarshalTabletAWK(resp.AfterTablet))
+	if resp.Impact != nil && resp.Impact.Violation != "" {
+		fmt.Printf("WARNING: %v\n", resp.Impact.Violation)
+	}
+
+	return nil
+}
+
+var decommissionTabletOptions = struct {
+	DrainGrace time.Duration
+}{}
+
+func commandDecommissionTablet(cmd *cobra.Command, args []string) error {
+	alias, err := topoproto.ParseTabletAlias(cmd.Flags().Arg(0))
+	if err != nil {
+		return err
+	}
+
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.DecommissionTablet(commandCtx, &vtctldatapb.DecommissionTabletRequest{
+		TabletAlias: alias,
+		DrainGrace:  protoutil.DurationToProto(decommissionTabletOptions.DrainGrace),
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, event := range resp.Events {
+		fmt.Println(logutil.EventString(event))
+	}
 
 	return nil
 }
@@ -163,6 +239,25 @@ func commandDeleteTablets(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func commandDrainTablet(cmd *cobra.Command, args []string) error {
+	alias, err := topoproto.ParseTabletAlias(cmd.Flags().Arg(0))
+	if err != nil {
+		return err
+	}
+
+	cli.FinishedParsing(cmd)
+
+	_, err = client.DrainTablet(commandCtx, &vtctldatapb.DrainTabletRequest{
+		TabletAlias: alias,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Drained %s\n", topoproto.TabletAliasString(alias))
+	return nil
+}
+
 func commandGetTablet(cmd *cobra.Command, args []string) error {
 	aliasStr := cmd.Flags().Arg(0)
 	alias, err := topoproto.ParseTabletAlias(aliasStr)
@@ -193,6 +288,8 @@ var getTabletsOptions = struct {
 	Shard    string
 
 	TabletAliasStrings []string
+	TabletTypeStrings  []string
+	Hostname           string
 
 	Format string
 	Strict bool
@@ -230,6 +327,15 @@ func commandGetTablets(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("--shard (= %s) cannot be passed without also passing --keyspace", getTabletsOptions.Shard)
 	}
 
+	var tabletTypes []topodatapb.TabletType
+	for _, typeString := range getTabletsOptions.TabletTypeStrings {
+		tabletType, err := topoproto.ParseTabletType(typeString)
+		if err != nil {
+			return err
+		}
+		tabletTypes = append(tabletTypes, tabletType)
+	}
+
 	cli.FinishedParsing(cmd)
 
 	resp, err := client.GetTablets(commandCtx, &vtctldatapb.GetTabletsRequest{
@@ -238,6 +344,8 @@ func commandGetTablets(cmd *cobra.Command, args []string) error {
 		Keyspace:      getTabletsOptions.Keyspace,
 		Shard:         getTabletsOptions.Shard,
 		Strict:        getTabletsOptions.Strict,
+		TabletTypes:   tabletTypes,
+		Hostname:      getTabletsOptions.Hostname,
 	})
 	if err != nil {
 		return err
@@ -260,6 +368,44 @@ func commandGetTablets(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func commandRecommendTabletTypeChange(cmd *cobra.Command, args []string) error {
+	keyspace, shard, err := topoproto.ParseKeyspaceShard(cmd.Flags().Arg(0))
+	if err != nil {
+		return err
+	}
+
+	fromType, err := topoproto.ParseTabletType(cmd.Flags().Arg(2))
+	if err != nil {
+		return err
+	}
+
+	toType, err := topoproto.ParseTabletType(cmd.Flags().Arg(3))
+	if err != nil {
+		return err
+	}
+
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.RecommendTabletTypeChange(commandCtx, &vtctldatapb.RecommendTabletTypeChangeRequest{
+		Keyspace: keyspace,
+		Shard:    shard,
+		Cell:     cmd.Flags().Arg(1),
+		FromType: fromType,
+		ToType:   toType,
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+	return nil
+}
+
 func commandRefreshState(cmd *cobra.Command, args []string) error {
 	alias, err := topoproto.ParseTabletAlias(cmd.Flags().Arg(0))
 	if err != nil {
@@ -314,25 +460,56 @@ func commandRefreshStateByShard(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func commandUndrainTablet(cmd *cobra.Command, args []string) error {
+	alias, err := topoproto.ParseTabletAlias(cmd.Flags().Arg(0))
+	if err != nil {
+		return err
+	}
+
+	cli.FinishedParsing(cmd)
+
+	_, err = client.UndrainTablet(commandCtx, &vtctldatapb.UndrainTabletRequest{
+		TabletAlias: alias,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Undrained %s\n", topoproto.TabletAliasString(alias))
+	return nil
+}
+
 func init() {
 	ChangeTabletType.Flags().BoolVarP(&changeTabletTypeOptions.DryRun, "dry-run", "d", false, "Shows the proposed change without actually executing it")
+	ChangeTabletType.Flags().BoolVar(&changeTabletTypeOptions.Force, "force", false, "Changes the type even if it leaves fewer serving tablets of the former type than the -min_tablets_per_cell minimum")
 	Root.AddCommand(ChangeTabletType)
 
+	DecommissionTablet.Flags().DurationVar(&decommissionTabletOptions.DrainGrace, "drain-grace", 10*time.Second, "Time to wait after draining the tablet for its in-flight queries to finish.")
+	Root.AddCommand(DecommissionTablet)
+
 	DeleteTablets.Flags().BoolVarP(&deleteTabletsOptions.AllowPrimary, "allow-primary", "p", false, "Allow the primary tablet of a shard to be deleted. Use with caution.")
 	Root.AddCommand(DeleteTablets)
 
+	Root.AddCommand(DrainTablet)
+
 	Root.AddCommand(GetTablet)
 
 	GetTablets.Flags().StringSliceVarP(&getTabletsOptions.TabletAliasStrings, "tablet-alias", "t", nil, "List of tablet aliases to filter by")
 	GetTablets.Flags().StringSliceVarP(&getTabletsOptions.Cells, "cell", "c", nil, "List of cells to filter tablets by")
 	GetTablets.Flags().StringVarP(&getTabletsOptions.Keyspace, "keyspace", "k", "", "Keyspace to filter tablets by")
 	GetTablets.Flags().StringVarP(&getTabletsOptions.Shard, "shard", "s", "", "Shard to filter tablets by")
+	GetTablets.Flags().StringSliceVar(&getTabletsOptions.TabletTypeStrings, "tablet-type", nil, "List of tablet types to filter tablets by")
+	GetTablets.Flags().StringVar(&getTabletsOptions.Hostname, "hostname", "", "Only list the tablets whose hostname contains this substring")
 	GetTablets.Flags().StringVar(&getTabletsOptions.Format, "format", "awk", "Output format to use; valid choices are (json, awk)")
 	GetTablets.Flags().BoolVar(&getTabletsOptions.Strict, "strict", false, "Require all cells to return successful tablet data. Without --strict, tablet listings may be partial.")
 	Root.AddCommand(GetTablets)
 
+	Root.AddCommand(RecommendTabletTypeChange)
+
 	Root.AddCommand(RefreshState)
 
 	RefreshStateByShard.Flags().StringSliceVarP(&refreshStateByShardOptions.Cells, "cells", "c", nil, "If specified, only call RefreshState on tablets in the specified cells. If empty, all cells are considered.")
 	Root.AddCommand(RefreshStateByShard)
+
+	Root.AddCommand(UndrainTablet)
 }
@@ -0,0 +1,120 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+var (
+	// ValidatePermissions makes a ValidatePermissions gRPC call to a vtctld.
+	ValidatePermissions = &cobra.Command{
+		Use:   "ValidatePermissions [--shard <shard>] [--cells <cell1,cell2,...>] [--concurrency <n>] <keyspace>",
+		Short: "Compares the permissions of the tablets of a keyspace with the ones of the primary of its first shard.",
+		Long: `Compares the permissions of the tablets of a keyspace with the ones of the
+primary of its first shard, and prints the grants which differ for each tablet.`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+		RunE:                  commandValidatePermissions,
+	}
+	// ValidateVersion makes a ValidateVersion gRPC call to a vtctld.
+	ValidateVersion = &cobra.Command{
+		Use:   "ValidateVersion [--shard <shard>] [--cells <cell1,cell2,...>] [--concurrency <n>] <keyspace>",
+		Short: "Prints the version each tablet of a keyspace runs.",
+		Long: `Prints the version each tablet of a keyspace runs, and the tablets running
+each version by shard.`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+		RunE:                  commandValidateVersion,
+	}
+)
+
+var validatePermissionsOptions = struct {
+	Shard       string
+	Cells       []string
+	Concurrency uint32
+}{}
+
+func commandValidatePermissions(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.ValidatePermissions(commandCtx, &vtctldatapb.ValidatePermissionsRequest{
+		Keyspace:    cmd.Flags().Arg(0),
+		Shard:       validatePermissionsOptions.Shard,
+		Cells:       validatePermissionsOptions.Cells,
+		Concurrency: validatePermissionsOptions.Concurrency,
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
+
+var validateVersionOptions = struct {
+	Shard       string
+	Cells       []string
+	Concurrency uint32
+}{}
+
+func commandValidateVersion(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.ValidateVersion(commandCtx, &vtctldatapb.ValidateVersionRequest{
+		Keyspace:    cmd.Flags().Arg(0),
+		Shard:       validateVersionOptions.Shard,
+		Cells:       validateVersionOptions.Cells,
+		Concurrency: validateVersionOptions.Concurrency,
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
+
+func init() {
+	ValidatePermissions.Flags().StringVar(&validatePermissionsOptions.Shard, "shard", "", "Only validate the tablets of this shard.")
+	ValidatePermissions.Flags().StringSliceVarP(&validatePermissionsOptions.Cells, "cells", "c", nil, "Only validate the tablets of these cells.")
+	ValidatePermissions.Flags().Uint32Var(&validatePermissionsOptions.Concurrency, "concurrency", 10, "The maximum number of tablets queried at the same time.")
+	Root.AddCommand(ValidatePermissions)
+
+	ValidateVersion.Flags().StringVar(&validateVersionOptions.Shard, "shard", "", "Only validate the tablets of this shard.")
+	ValidateVersion.Flags().StringSliceVarP(&validateVersionOptions.Cells, "cells", "c", nil, "Only validate the tablets of these cells.")
+	ValidateVersion.Flags().Uint32Var(&validateVersionOptions.Concurrency, "concurrency", 10, "The maximum number of tablets queried at the same time.")
+	Root.AddCommand(ValidateVersion)
+}
@@ -18,11 +18,16 @@ package command
 
 import (
 	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/topo/topoproto"
 
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
 )
 
@@ -33,6 +38,61 @@ var (
 		Args: cobra.ExactArgs(1),
 		RunE: commandGetWorkflows,
 	}
+	// GetWorkflowAuditLog makes a GetWorkflowAuditLog gRPC call to a vtctld.
+	GetWorkflowAuditLog = &cobra.Command{
+		Use:   "GetWorkflowAuditLog <uuid>",
+		Short: "Outputs the actions on a workflow of the workflow manager, with who requested them and from which address. The log is kept after the workflow is deleted.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  commandGetWorkflowAuditLog,
+	}
+	// GetWorkflowProgress makes a GetWorkflowProgress gRPC call to a vtctld.
+	GetWorkflowProgress = &cobra.Command{
+		Use:   "GetWorkflowProgress <keyspace.workflow>",
+		Short: "Outputs the copy progress, throughput, replication lag and last error of the streams of a vreplication workflow, and their aggregates.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  commandGetWorkflowProgress,
+	}
+	// SplitReads makes a SplitReads gRPC call to a vtctld.
+	SplitReads = &cobra.Command{
+		Use:   "SplitReads [--cells c1,c2,...] [--tablet-types replica,rdonly] <percent> <keyspace.workflow>",
+		Short: "Routes a percentage of the reads of the tables of a MoveTables workflow to its target keyspace, e.g. 1, 10, 50, then 100.",
+		Long: `Routes a percentage of the reads of the tables of a MoveTables workflow to its
+target keyspace, so the reads can be switched in steps, e.g. 1, 10, 50, then 100.
+0 routes all the reads to the source keyspace again, and 100 is the same as
+switching the reads.`,
+		Args: cobra.ExactArgs(2),
+		RunE: commandSplitReads,
+	}
+	// StartHostMaintenance makes a StartHostMaintenance gRPC call to a vtctld.
+	StartHostMaintenance = &cobra.Command{
+		Use:   "StartHostMaintenance [--uncordon] [--drain-grace <duration>] [--wait-replicas-timeout <duration>] <cell> <hostname>",
+		Short: "Creates and starts a host_maintenance workflow which cordons the tablets of a host, or uncordons them with --uncordon, and outputs its uuid.",
+		Args:  cobra.ExactArgs(2),
+		RunE:  commandStartHostMaintenance,
+	}
+	// VerifyReverseReplication makes a VerifyReverseReplication gRPC call to a
+	// vtctld.
+	VerifyReverseReplication = &cobra.Command{
+		Use:   "VerifyReverseReplication [--max-lag <duration>] [--checksum [--sample-rate <n>]] <keyspace.workflow>",
+		Short: "Verifies that the reverse streams of a workflow whose writes were switched exist, are running and have caught up, and optionally compares sampled checksums of the moved tables between the source and the target.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  commandVerifyReverseReplication,
+	}
+	// UpdateWorkflowThrottling makes an UpdateWorkflowThrottling gRPC call to
+	// a vtctld.
+	UpdateWorkflowThrottling = &cobra.Command{
+		Use:   "UpdateWorkflowThrottling [--rows-per-sec <rows>] [--bytes-per-sec <bytes>] [--max-concurrent-copies <streams>] <keyspace.workflow>",
+		Short: "Changes the rows/sec, bytes/sec and concurrent copy stream limits of the copy phase of a vreplication workflow on each of its target primaries. Only the limits passed are changed, and 0 removes a limit.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  commandUpdateWorkflowThrottling,
+	}
+	// ListVDiffResults makes a ListVDiffResults gRPC call to a vtctld.
+	ListVDiffResults = &cobra.Command{
+		Use:   "ListVDiffResults [--limit <count>] <keyspace.workflow>",
+		Short: "Lists the stored results of the VDiffs of a workflow, from the newest, including those run by a schedule of the vdiff workflow.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  commandListVDiffResults,
+	}
 )
 
 var getWorkflowsOptions = struct {
@@ -63,7 +123,268 @@ func commandGetWorkflows(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func commandGetWorkflowAuditLog(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.GetWorkflowAuditLog(commandCtx, &vtctldatapb.GetWorkflowAuditLogRequest{
+		Uuid: cmd.Flags().Arg(0),
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp.Entries)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
+
+func commandGetWorkflowProgress(cmd *cobra.Command, args []string) error {
+	keyspace, workflow, err := cli.ParseKeyspaceWorkflow(cmd.Flags().Arg(0))
+	if err != nil {
+		return err
+	}
+
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.GetWorkflowProgress(commandCtx, &vtctldatapb.GetWorkflowProgressRequest{
+		Keyspace: keyspace,
+		Workflow: workflow,
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
+
+var splitReadsOptions = struct {
+	Cells             []string
+	TabletTypeStrings []string
+}{}
+
+func commandSplitReads(cmd *cobra.Command, args []string) error {
+	percent, err := strconv.ParseInt(cmd.Flags().Arg(0), 10, 32)
+	if err != nil {
+		return fmt.Errorf("invalid percent %s: %w", cmd.Flags().Arg(0), err)
+	}
+
+	keyspace, workflow, err := cli.ParseKeyspaceWorkflow(cmd.Flags().Arg(1))
+	if err != nil {
+		return err
+	}
+
+	var tabletTypes []topodatapb.TabletType
+	for _, typeString := range splitReadsOptions.TabletTypeStrings {
+		tabletType, err := topoproto.ParseTabletType(typeString)
+		if err != nil {
+			return err
+		}
+		tabletTypes = append(tabletTypes, tabletType)
+	}
+
+	cli.FinishedParsing(cmd)
+
+	_, err = client.SplitReads(commandCtx, &vtctldatapb.SplitReadsRequest{
+		Keyspace:    keyspace,
+		Workflow:    workflow,
+		Percent:     int32(percent),
+		TabletTypes: tabletTypes,
+		Cells:       splitReadsOptions.Cells,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Successfully routed %d%% of the reads of workflow %s to keyspace %s.\n", percent, cmd.Flags().Arg(1), keyspace)
+
+	return nil
+}
+
+var startHostMaintenanceOptions = struct {
+	Uncordon            bool
+	DrainGrace          time.Duration
+	WaitReplicasTimeout time.Duration
+}{}
+
+func commandStartHostMaintenance(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.StartHostMaintenance(commandCtx, &vtctldatapb.StartHostMaintenanceRequest{
+		Cell:                cmd.Flags().Arg(0),
+		Hostname:            cmd.Flags().Arg(1),
+		Uncordon:            startHostMaintenanceOptions.Uncordon,
+		DrainGrace:          protoutil.DurationToProto(startHostMaintenanceOptions.DrainGrace),
+		WaitReplicasTimeout: protoutil.DurationToProto(startHostMaintenanceOptions.WaitReplicasTimeout),
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("uuid: %s\n", resp.Uuid)
+
+	return nil
+}
+
+var verifyReverseReplicationOptions = struct {
+	MaxLag     time.Duration
+	Checksum   bool
+	SampleRate int
+}{}
+
+func commandVerifyReverseReplication(cmd *cobra.Command, args []string) error {
+	keyspace, workflow, err := cli.ParseKeyspaceWorkflow(cmd.Flags().Arg(0))
+	if err != nil {
+		return err
+	}
+
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.VerifyReverseReplication(commandCtx, &vtctldatapb.VerifyReverseReplicationRequest{
+		Keyspace:   keyspace,
+		Workflow:   workflow,
+		MaxLag:     protoutil.DurationToProto(verifyReverseReplicationOptions.MaxLag),
+		Checksum:   verifyReverseReplicationOptions.Checksum,
+		SampleRate: int64(verifyReverseReplicationOptions.SampleRate),
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	// The report is printed even if the verification fails.
+	if !resp.Verified {
+		return fmt.Errorf("the reverse replication of workflow %s is not verified: %s", cmd.Flags().Arg(0), resp.Reason)
+	}
+
+	return nil
+}
+
+var updateWorkflowThrottlingOptions = struct {
+	RowsPerSec          int64
+	BytesPerSec         int64
+	MaxConcurrentCopies int64
+}{}
+
+func commandUpdateWorkflowThrottling(cmd *cobra.Command, args []string) error {
+	keyspace, workflow, err := cli.ParseKeyspaceWorkflow(cmd.Flags().Arg(0))
+	if err != nil {
+		return err
+	}
+
+	var fields []string
+	if cmd.Flags().Changed("rows-per-sec") {
+		fields = append(fields, "rows_per_second")
+	}
+	if cmd.Flags().Changed("bytes-per-sec") {
+		fields = append(fields, "bytes_per_second")
+	}
+	if cmd.Flags().Changed("max-concurrent-copies") {
+		fields = append(fields, "max_concurrent_copies")
+	}
+	if len(fields) == 0 {
+		return fmt.Errorf("at least one of --rows-per-sec, --bytes-per-sec and --max-concurrent-copies is required")
+	}
+
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.UpdateWorkflowThrottling(commandCtx, &vtctldatapb.UpdateWorkflowThrottlingRequest{
+		Keyspace: keyspace,
+		Workflow: workflow,
+		Throttling: &topodatapb.WorkflowThrottling{
+			RowsPerSecond:       updateWorkflowThrottlingOptions.RowsPerSec,
+			BytesPerSecond:      updateWorkflowThrottlingOptions.BytesPerSec,
+			MaxConcurrentCopies: updateWorkflowThrottlingOptions.MaxConcurrentCopies,
+		},
+		Fields: fields,
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp.Throttling)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
+
+var listVDiffResultsOptions = struct {
+	Limit int
+}{}
+
+func commandListVDiffResults(cmd *cobra.Command, args []string) error {
+	keyspace, workflow, err := cli.ParseKeyspaceWorkflow(cmd.Flags().Arg(0))
+	if err != nil {
+		return err
+	}
+
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.ListVDiffResults(commandCtx, &vtctldatapb.ListVDiffResultsRequest{
+		Keyspace: keyspace,
+		Workflow: workflow,
+		Limit:    int64(listVDiffResultsOptions.Limit),
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp.Results)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
+
 func init() {
 	GetWorkflows.Flags().BoolVarP(&getWorkflowsOptions.ShowAll, "show-all", "a", false, "Show all workflows instead of just active workflows")
 	Root.AddCommand(GetWorkflows)
+	Root.AddCommand(GetWorkflowAuditLog)
+	Root.AddCommand(GetWorkflowProgress)
+
+	SplitReads.Flags().StringSliceVarP(&splitReadsOptions.Cells, "cells", "c", nil, "The cells whose SrvVSchema is rebuilt. If empty, all cells are rebuilt.")
+	SplitReads.Flags().StringSliceVar(&splitReadsOptions.TabletTypeStrings, "tablet-types", []string{"replica", "rdonly"}, "The tablet types whose reads are split.")
+	Root.AddCommand(SplitReads)
+
+	StartHostMaintenance.Flags().BoolVar(&startHostMaintenanceOptions.Uncordon, "uncordon", false, "Uncordon the tablets of the host after the maintenance: restart the replication which was running before the cordon and undrain them.")
+	StartHostMaintenance.Flags().DurationVar(&startHostMaintenanceOptions.DrainGrace, "drain-grace", 30*time.Second, "How long to wait after draining a tablet for its in-flight queries to complete.")
+	StartHostMaintenance.Flags().DurationVar(&startHostMaintenanceOptions.WaitReplicasTimeout, "wait-replicas-timeout", 30*time.Second, "The time to wait for the replicas to catch up during the planned reparent of a primary.")
+	Root.AddCommand(StartHostMaintenance)
+
+	VerifyReverseReplication.Flags().DurationVar(&verifyReverseReplicationOptions.MaxLag, "max-lag", 30*time.Second, "Maximum lag of the reverse streams for them to be caught up.")
+	VerifyReverseReplication.Flags().BoolVar(&verifyReverseReplicationOptions.Checksum, "checksum", false, "Compare the checksums of a sample of the rows of the moved tables between the source and the target. It scans the tables on the primaries.")
+	VerifyReverseReplication.Flags().IntVar(&verifyReverseReplicationOptions.SampleRate, "sample-rate", 100, "With --checksum, compare one row in sample-rate, sampled by a hash of their primary key. 1 compares all the rows.")
+	Root.AddCommand(VerifyReverseReplication)
+
+	UpdateWorkflowThrottling.Flags().Int64Var(&updateWorkflowThrottlingOptions.RowsPerSec, "rows-per-sec", 0, "Maximum number of rows copied per second on each target primary. 0 removes the limit.")
+	UpdateWorkflowThrottling.Flags().Int64Var(&updateWorkflowThrottlingOptions.BytesPerSec, "bytes-per-sec", 0, "Maximum number of bytes copied per second on each target primary. 0 removes the limit.")
+	UpdateWorkflowThrottling.Flags().Int64Var(&updateWorkflowThrottlingOptions.MaxConcurrentCopies, "max-concurrent-copies", 0, "Maximum number of streams copying rows at the same time on each target primary. 0 removes the limit.")
+	Root.AddCommand(UpdateWorkflowThrottling)
+
+	ListVDiffResults.Flags().IntVar(&listVDiffResultsOptions.Limit, "limit", 0, "Maximum number of results to list. 0 lists all of them.")
+	Root.AddCommand(ListVDiffResults)
 }
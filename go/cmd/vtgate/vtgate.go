@@ -27,8 +27,10 @@ import (
 	"vitess.io/vitess/go/vt/vterrors"
 
 	"vitess.io/vitess/go/exit"
+	"vitess.io/vitess/go/netutil"
 	"vitess.io/vitess/go/vt/discovery"
 	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/secrets"
 	"vitess.io/vitess/go/vt/servenv"
 	"vitess.io/vitess/go/vt/srvtopo"
 	"vitess.io/vitess/go/vt/topo"
@@ -108,10 +110,44 @@ func CheckCellFlags(ctx context.Context, serv srvtopo.Server, cell string, cells
 	return nil
 }
 
+// registerVtGate registers the vtgate in the topology of its cell, so that
+// vtctld can discover it, and unregisters it when the vtgate is stopped.
+func registerVtGate(ts *topo.Server) {
+	if *servenv.Port == 0 {
+		// Without a web server, there is nothing vtctld could reach.
+		return
+	}
+	hostname, err := netutil.FullyQualifiedHostname()
+	if err != nil {
+		log.Warningf("Cannot get the hostname, not registering the vtgate in cell %v: %v", *cell, err)
+		return
+	}
+	registration := &topodatapb.VtGate{
+		Hostname: hostname,
+		Port:     int32(*servenv.Port),
+		GrpcPort: int32(*servenv.GRPCPort),
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), *topo.RemoteOperationTimeout)
+	defer cancel()
+	if err := ts.RegisterVtGate(ctx, *cell, registration); err != nil {
+		log.Warningf("Cannot register the vtgate in cell %v: %v", *cell, err)
+		return
+	}
+
+	servenv.OnTermSync(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), *topo.RemoteOperationTimeout)
+		defer cancel()
+		if err := ts.UnregisterVtGate(ctx, *cell, topo.VtGateAddr(registration)); err != nil {
+			log.Warningf("Cannot unregister the vtgate from cell %v: %v", *cell, err)
+		}
+	})
+}
+
 func main() {
 	defer exit.Recover()
 
 	servenv.ParseFlags("vtgate")
+	secrets.HandleSIGHUP()
 	servenv.Init()
 
 	ts := topo.Open()
@@ -139,6 +175,10 @@ func main() {
 		log.Exitf("tablet_types_to_wait should contain at least one serving tablet type")
 	}
 
+	if err := vtgate.LoadCellsToWatchFile(); err != nil {
+		log.Exitf("cannot read cells_to_watch_file: %v", err)
+	}
+
 	err := CheckCellFlags(context.Background(), resilientServer, *cell, *vtgate.CellsToWatch)
 	if err != nil {
 		log.Exitf("cells_to_watch validation failed: %v", err)
@@ -160,6 +200,7 @@ func main() {
 		// Flags are parsed now. Parse the template using the actual flag value and overwrite the current template.
 		discovery.ParseTabletURLTemplateFromFlag()
 		addStatusParts(vtg)
+		registerVtGate(ts)
 	})
 	servenv.OnClose(func() {
 		_ = vtg.Gateway().Close(context.Background())
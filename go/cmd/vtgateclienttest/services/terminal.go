@@ -74,6 +74,10 @@ func (c *terminalClient) ResolveTransaction(ctx context.Context, dtid string) er
 	return errTerminal
 }
 
+func (c *terminalClient) GetBufferStatus(ctx context.Context) (*vtgatepb.GetBufferStatusResponse, error) {
+	return nil, errTerminal
+}
+
 func (c *terminalClient) VStream(ctx context.Context, tabletType topodatapb.TabletType, vgtid *binlogdatapb.VGtid, filter *binlogdatapb.Filter, flags *vtgatepb.VStreamFlags, send func([]*binlogdatapb.VEvent) error) error {
 	return errTerminal
 }
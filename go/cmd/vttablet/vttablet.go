@@ -29,6 +29,7 @@ import (
 	"vitess.io/vitess/go/vt/log"
 	"vitess.io/vitess/go/vt/mysqlctl"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	"vitess.io/vitess/go/vt/secrets"
 	"vitess.io/vitess/go/vt/servenv"
 	"vitess.io/vitess/go/vt/tableacl"
 	"vitess.io/vitess/go/vt/tableacl/simpleacl"
@@ -48,6 +49,7 @@ var (
 	enforceTableACLConfig        = flag.Bool("enforce-tableacl-config", false, "if this flag is true, vttablet will fail to start if a valid tableacl config does not exist")
 	tableACLConfig               = flag.String("table-acl-config", "", "path to table access checker config file; send SIGHUP to reload this file")
 	tableACLConfigReloadInterval = flag.Duration("table-acl-config-reload-interval", 0, "Ticker to reload ACLs. Duration flag, format e.g.: 30s. Default: do not reload")
+	tableQuotaConfig             = flag.String("table-quota-config", "", "path to the per-table quota config file, limiting the rows and bytes queries can read from tables; send SIGHUP to reload this file")
 	tabletPath                   = flag.String("tablet-path", "", "tablet alias")
 	tabletConfig                 = flag.String("tablet_config", "", "YAML file config for tablet")
 
@@ -63,6 +65,7 @@ func main() {
 	mysqlctl.RegisterFlags()
 
 	servenv.ParseFlags("vttablet")
+	secrets.HandleSIGHUP()
 	servenv.Init()
 
 	if *tabletPath == "" {
@@ -197,10 +200,12 @@ func extractOnlineDDL() error {
 }
 
 func createTabletServer(config *tabletenv.TabletConfig, ts *topo.Server, tabletAlias *topodatapb.TabletAlias) *tabletserver.TabletServer {
-	if *tableACLConfig != "" {
-		// To override default simpleacl, other ACL plugins must set themselves to be default ACL factory
+	if *tableACLConfig != "" || *tableQuotaConfig != "" {
+		// To override default simpleacl, other ACL plugins must set themselves to be default ACL factory.
+		// The table quotas also use it to match their users.
 		tableacl.Register("simpleacl", &simpleacl.Factory{})
-	} else if *enforceTableACLConfig {
+	}
+	if *tableACLConfig == "" && *enforceTableACLConfig {
 		log.Exit("table acl config has to be specified with table-acl-config flag because enforce-tableacl-config is set.")
 	}
 	// creates and registers the query service
@@ -211,5 +216,8 @@ func createTabletServer(config *tabletenv.TabletConfig, ts *topo.Server, tabletA
 	})
 	servenv.OnClose(qsc.StopService)
 	qsc.InitACL(*tableACLConfig, *enforceTableACLConfig, *tableACLConfigReloadInterval)
+	if *tableQuotaConfig != "" {
+		qsc.InitTableQuotas(*tableQuotaConfig)
+	}
 	return qsc
 }
@@ -22,6 +22,7 @@ import (
 	"crypto/x509"
 	"encoding/pem"
 	"fmt"
+	"io/ioutil"
 	"net"
 	"strconv"
 	"strings"
@@ -29,6 +30,7 @@ import (
 
 	"context"
 
+	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vttls"
@@ -122,8 +124,12 @@ func Connect(ctx context.Context, params *ConnParams) (*Conn, error) {
 		// canceled, the connection will be closed. That will
 		// make any read or write just return with an error
 		// right away.
+		err = c.clientHandshake(characterSet, params)
+		if err == nil && params.Roles != "" {
+			err = c.activateRoles(params.Roles)
+		}
 		status <- connectResult{
-			err: c.clientHandshake(characterSet, params),
+			err: err,
 		}
 	}()
 
@@ -294,7 +300,19 @@ func (c *Conn) clientHandshake(characterSet uint8, params *ConnParams) error {
 		c.Capabilities |= CapabilityClientSSL
 	}
 
-	// Password encryption.
+	// Password encryption. We answer with the plugin of the params, or the
+	// default plugin of the server if we support it. The server switches
+	// to the plugin of the user if it's another one.
+	switch params.AuthPlugin {
+	case "":
+		if c.authPluginName != CachingSha2Password {
+			c.authPluginName = MysqlNativePassword
+		}
+	case MysqlNativePassword, CachingSha2Password:
+		c.authPluginName = params.AuthPlugin
+	default:
+		return NewSQLError(CRServerHandshakeErr, SSUnknownSQLState, "unsupported client auth plugin %v, expected %v or %v", params.AuthPlugin, MysqlNativePassword, CachingSha2Password)
+	}
 	var scrambledPassword []byte
 	if c.authPluginName == CachingSha2Password {
 		scrambledPassword = ScrambleCachingSha2Password(salt, []byte(params.Pass))
@@ -706,9 +724,16 @@ func (c *Conn) handleAuthMoreDataPacket(data byte, params *ConnParams) error {
 				return err
 			}
 		} else {
-			// If we are not using an SSL connection or Unix socket, we have to fetch a public key
-			// from the server to encrypt password
-			pub, err := c.requestPublicKey()
+			// If we are not using an SSL connection or Unix socket, we have to encrypt the
+			// password with the public key of the server: the configured one, or fetched from
+			// the server.
+			var pub *rsa.PublicKey
+			var err error
+			if params.ServerPublicKey != "" {
+				pub, err = readPublicKey(params.ServerPublicKey)
+			} else {
+				pub, err = c.requestPublicKey()
+			}
 			if err != nil {
 				return err
 			}
@@ -763,13 +788,75 @@ func (c *Conn) requestPublicKey() (rsaKey *rsa.PublicKey, err error) {
 		return nil, ParseErrorPacket(response)
 	}
 
-	block, _ := pem.Decode(response[1:])
-	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	pub, err := parsePublicKey(response[1:])
 	if err != nil {
 		return nil, vterrors.Errorf(vtrpc.Code_INTERNAL, "failed to parse public key from server: %v", err)
 	}
+	return pub, nil
+}
+
+// readPublicKey reads the RSA public key of the server from a PEM file.
+func readPublicKey(path string) (*rsa.PublicKey, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, vterrors.Errorf(vtrpc.Code_INTERNAL, "cannot read the server public key: %v", err)
+	}
+	pub, err := parsePublicKey(data)
+	if err != nil {
+		return nil, vterrors.Errorf(vtrpc.Code_INTERNAL, "failed to parse public key from %v: %v", path, err)
+	}
+	return pub, nil
+}
 
-	return pub.(*rsa.PublicKey), nil
+func parsePublicKey(data []byte) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found")
+	}
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	rsaPub, ok := pub.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("not an RSA public key")
+	}
+	return rsaPub, nil
+}
+
+// activateRoles activates the roles of the user for the session, so that
+// the privileges granted through them apply.
+func (c *Conn) activateRoles(roles string) error {
+	if _, err := c.ExecuteFetch(setRoleQuery(roles), 0, false); err != nil {
+		return NewSQLError(CRServerHandshakeErr, SSUnknownSQLState, "cannot activate roles %v: %v", roles, err)
+	}
+	return nil
+}
+
+// setRoleQuery returns the SET ROLE statement for the roles of
+// ConnParams.Roles.
+func setRoleQuery(roles string) string {
+	switch keyword := strings.ToUpper(strings.TrimSpace(roles)); keyword {
+	case "ALL", "DEFAULT", "NONE":
+		return "SET ROLE " + keyword
+	}
+	var quoted []string
+	for _, role := range strings.Split(roles, ",") {
+		role = strings.TrimSpace(role)
+		if role == "" {
+			continue
+		}
+		name, host := role, ""
+		if i := strings.LastIndex(role, "@"); i >= 0 {
+			name, host = role[:i], role[i+1:]
+		}
+		spec := sqltypes.EncodeStringSQL(name)
+		if host != "" {
+			spec += "@" + sqltypes.EncodeStringSQL(host)
+		}
+		quoted = append(quoted, spec)
+	}
+	return "SET ROLE " + strings.Join(quoted, ", ")
 }
 
 // writeClearTextPassword writes the clear text password.
@@ -142,3 +142,36 @@ func TestConnectTimeout(t *testing.T) {
 	os.Remove(name)
 	assertSQLError(t, err, CRConnectionError, SSUnknownSQLState, "connection refused", "")
 }
+
+func TestSetRoleQuery(t *testing.T) {
+	testcases := []struct {
+		roles string
+		want  string
+	}{
+		{"all", "SET ROLE ALL"},
+		{" DEFAULT ", "SET ROLE DEFAULT"},
+		{"app_rw", "SET ROLE 'app_rw'"},
+		{"app_rw@%, reporting@localhost,", "SET ROLE 'app_rw'@'%', 'reporting'@'localhost'"},
+		{"o'brien", "SET ROLE 'o\\'brien'"},
+	}
+	for _, tc := range testcases {
+		if got := setRoleQuery(tc.roles); got != tc.want {
+			t.Errorf("setRoleQuery(%q) = %q, want %q", tc.roles, got, tc.want)
+		}
+	}
+}
+
+func TestReadPublicKey(t *testing.T) {
+	fd, err := ioutil.TempFile("", "public_key")
+	if err != nil {
+		t.Fatalf("cannot create TempFile: %v", err)
+	}
+	defer os.Remove(fd.Name())
+	fd.WriteString("not a key")
+	fd.Close()
+
+	_, err = readPublicKey(fd.Name())
+	if err == nil || !strings.Contains(err.Error(), "no PEM data found") {
+		t.Errorf("readPublicKey() = %v, want a PEM error", err)
+	}
+}
@@ -149,6 +149,10 @@ type Conn struct {
 	// PrepareData is the map to use a prepared statement.
 	PrepareData map[uint32]*PrepareData
 
+	// maxPreparedStatements if non-zero bounds the size of PrepareData.
+	// It is copied from the listener.
+	maxPreparedStatements int
+
 	// protects the bufferedWriter and bufferedReader
 	bufMu sync.Mutex
 
@@ -164,6 +168,10 @@ type Conn struct {
 	// closed is set to true when Close() is called on the connection.
 	closed sync2.AtomicBool
 
+	// closeAfterCommand is set to true when CloseAfterCommand() is called
+	// on the connection.
+	closeAfterCommand sync2.AtomicBool
+
 	// ConnectionID is set:
 	// - at Connect() time for clients, with the value returned by
 	// the server.
@@ -201,6 +209,11 @@ type PrepareData struct {
 	BindVars    map[string]*querypb.BindVariable
 	StatementID uint32
 	ParamsCount uint16
+
+	// paramsBound is set once the client sent the types of the
+	// parameters. They are reused by the next executions, which don't
+	// have to send them again.
+	paramsBound bool
 }
 
 // execResult is an enum signifying the result of executing a query
@@ -239,6 +252,8 @@ func newServerConn(conn net.Conn, listener *Listener) *Conn {
 		listener:    listener,
 		closed:      sync2.NewAtomicBool(false),
 		PrepareData: make(map[uint32]*PrepareData),
+
+		maxPreparedStatements: listener.MaxPreparedStatements,
 	}
 	if listener.connReadBufferSize > 0 {
 		c.bufferedReader = bufio.NewReaderSize(conn, listener.connReadBufferSize)
@@ -682,6 +697,13 @@ func (c *Conn) Close() {
 	}
 }
 
+// CloseAfterCommand asks the server to close the connection once its
+// current command completed, e.g. so the client reconnects gracefully to
+// another server. It is only used by the server.
+func (c *Conn) CloseAfterCommand() {
+	c.closeAfterCommand.Set(true)
+}
+
 // IsClosed returns true if this connection was ever closed by the
 // Close() method.  Note if the other side closes the connection, but
 // Close() wasn't called, this will return false.
@@ -1099,19 +1121,22 @@ func (c *Conn) handleComPrepare(handler Handler, data []byte) (kontinue bool) {
 		queries = []string{query}
 	}
 
-	// Popoulate PrepareData
-	c.StatementID++
-	prepare := &PrepareData{
-		StatementID: c.StatementID,
-		PrepareStmt: queries[0],
+	if max := c.maxPreparedStatements; max > 0 && len(c.PrepareData) >= max {
+		err := NewSQLError(ERMaxPreparedStmtCount, SSClientError, "Can't create more than max_prepared_stmt_count statements (current value: %v)", max)
+		return c.writeErrorPacketFromErrorAndLog(err)
 	}
 
 	statement, err := sqlparser.ParseStrictDDL(query)
 	if err != nil {
 		log.Errorf("Conn %v: Error parsing prepared statement: %v", c, err)
-		if !c.writeErrorPacketFromErrorAndLog(err) {
-			return false
-		}
+		return c.writeErrorPacketFromErrorAndLog(err)
+	}
+
+	// Popoulate PrepareData
+	c.StatementID++
+	prepare := &PrepareData{
+		StatementID: c.StatementID,
+		PrepareStmt: queries[0],
 	}
 
 	paramsCount := uint16(0)
@@ -1142,6 +1167,9 @@ func (c *Conn) handleComPrepare(handler Handler, data []byte) (kontinue bool) {
 	fld, err := handler.ComPrepare(c, queries[0], bindVars)
 
 	if err != nil {
+		// The statement can't be executed, so it doesn't count towards
+		// the prepared statements of the connection.
+		delete(c.PrepareData, c.StatementID)
 		return c.writeErrorPacketFromErrorAndLog(err)
 	}
 
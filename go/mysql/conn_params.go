@@ -44,6 +44,22 @@ type ConnParams struct {
 	// The following is only set to force the client to connect without
 	// using CapabilityClientDeprecateEOF
 	DisableClientDeprecateEOF bool
+
+	// AuthPlugin is the authentication plugin the client answers the
+	// initial handshake with: MysqlNativePassword or CachingSha2Password.
+	// By default, it's the default plugin of the server. Either way, the
+	// server can switch to the plugin of the user.
+	AuthPlugin string `json:"auth_plugin,omitempty"`
+	// ServerPublicKey is the path to the PEM file of the RSA public key of
+	// the server, which encrypts the password when the caching_sha2_password
+	// full authentication happens on a connection which is neither TLS nor
+	// a Unix socket. If empty, the key is requested from the server.
+	ServerPublicKey string `json:"server_public_key,omitempty"`
+	// Roles are activated with SET ROLE right after connecting, for the
+	// users whose privileges are granted through MySQL 8.0 roles: ALL,
+	// DEFAULT, or a comma separated list of roles, e.g. "app_rw,reporting"
+	// or "app_rw@%".
+	Roles string `json:"roles,omitempty"`
 }
 
 // EnableSSL will set the right flag on the parameters.
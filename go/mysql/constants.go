@@ -317,6 +317,9 @@ const (
 const (
 	// ERVitessMaxRowsExceeded is when a user tries to select more rows than the max rows as enforced by vitess.
 	ERVitessMaxRowsExceeded = 10001
+
+	// ERVitessTableQuotaExceeded is when a query reads more rows or bytes than a per-table quota allows.
+	ERVitessTableQuotaExceeded = 10002
 )
 
 // Error codes for server-side errors.
@@ -349,6 +352,7 @@ const (
 	ERTooManyUserConnections = 1203
 	ERLockTableFull          = 1206
 	ERUserLimitReached       = 1226
+	ERMaxPreparedStmtCount   = 1461
 
 	// deadline exceeded
 	ERLockWaitTimeout = 1205
@@ -522,6 +526,7 @@ const (
 	ERDataTooLong                  = 1406
 	ERForbidSchemaChange           = 1450
 	ERDataOutOfRange               = 1690
+	ERCheckConstraintViolated      = 3819
 
 	// server not available
 	ERServerIsntAvailable = 3168
@@ -277,6 +277,12 @@ func (c *Conn) SetReplicationSourceCommand(params *ConnParams, host string, port
 	if params.SslKey != "" {
 		args = append(args, fmt.Sprintf("MASTER_SSL_KEY = '%s'", params.SslKey))
 	}
+	// Without TLS, the replication user of caching_sha2_password needs the
+	// public key of the source to send its password (MySQL 8.0 only). Each
+	// source has its own key, so it's requested from the source.
+	if params.AuthPlugin == CachingSha2Password && !params.SslEnabled() {
+		args = append(args, "GET_MASTER_PUBLIC_KEY = 1")
+	}
 	args = append(args, c.flavor.changeReplicationSourceArg())
 	return "CHANGE MASTER TO\n  " + strings.Join(args, ",\n  ")
 }
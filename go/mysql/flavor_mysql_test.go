@@ -79,6 +79,28 @@ func TestMysql56SetMasterCommandsSSL(t *testing.T) {
 	}
 }
 
+func TestMysql80SetMasterCommandsCachingSha2(t *testing.T) {
+	params := &ConnParams{
+		Uname:      "username",
+		Pass:       "password",
+		AuthPlugin: CachingSha2Password,
+	}
+	want := `CHANGE MASTER TO
+  MASTER_HOST = 'localhost',
+  MASTER_PORT = 123,
+  MASTER_USER = 'username',
+  MASTER_PASSWORD = 'password',
+  MASTER_CONNECT_RETRY = 1234,
+  GET_MASTER_PUBLIC_KEY = 1,
+  MASTER_AUTO_POSITION = 1`
+	conn := &Conn{flavor: mysqlFlavor80{}}
+	assert.Equal(t, want, conn.SetReplicationSourceCommand(params, "localhost", 123, 1234))
+
+	// The password is sent over TLS.
+	params.EnableSSL()
+	assert.NotContains(t, conn.SetReplicationSourceCommand(params, "localhost", 123, 1234), "PUBLIC_KEY")
+}
+
 func TestMysqlRetrieveMasterServerId(t *testing.T) {
 	resultMap := map[string]string{
 		"Master_Server_Id": "1",
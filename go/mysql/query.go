@@ -602,6 +602,7 @@ func (c *Conn) parseComStmtExecute(prepareData map[uint32]*PrepareData, data []b
 
 			prepare.ParamsType[i] = int32(valType)
 		}
+		prepare.paramsBound = true
 	}
 
 	for i := 0; i < len(prepare.ParamsType); i++ {
@@ -616,6 +617,12 @@ func (c *Conn) parseComStmtExecute(prepareData map[uint32]*PrepareData, data []b
 		if (bitMap[i/8] & (1 << uint(i%8))) > 0 {
 			val, pos, ok = c.parseStmtArgs(nil, sqltypes.Null, pos)
 		} else {
+			if !prepare.paramsBound {
+				// The types are only sent by the first execution, or
+				// when they change: without them, the values can't be
+				// decoded.
+				return stmtID, 0, NewSQLError(CRMalformedPacket, SSUnknownSQLState, "parameter types were never sent for statement %v", stmtID)
+			}
 			val, pos, ok = c.parseStmtArgs(payload, querypb.Type(prepare.ParamsType[i]), pos)
 		}
 		if !ok {
@@ -346,6 +346,67 @@ func TestComStmtExecuteUpdStmt(t *testing.T) {
 	assert.EqualValues(t, querypb.Type_CHAR, prepData.ParamsType[28], "got: %s", querypb.Type(prepData.ParamsType[28]))
 }
 
+func TestComStmtExecuteReusesParamsType(t *testing.T) {
+	listener, sConn, cConn := createSocketPair(t)
+	defer func() {
+		listener.Close()
+		sConn.Close()
+		cConn.Close()
+	}()
+
+	prepareDataMap := map[uint32]*PrepareData{
+		1: {
+			StatementID: 1,
+			ParamsCount: 1,
+			ParamsType:  make([]int32, 1),
+			BindVars:    map[string]*querypb.BindVariable{},
+		}}
+	prepare := prepareDataMap[1]
+
+	// The value of the parameter, without its type.
+	withoutTypes := []byte{ComStmtExecute, 1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 5}
+	_, _, err := sConn.parseComStmtExecute(prepareDataMap, withoutTypes)
+	require.Error(t, err, "the parameter types were never sent")
+
+	// The first execution sends the type of the parameter, a tinyint.
+	withTypes := []byte{ComStmtExecute, 1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1, 1, 0, 4}
+	_, _, err = sConn.parseComStmtExecute(prepareDataMap, withTypes)
+	require.NoError(t, err)
+	assert.Equal(t, querypb.Type_INT8, querypb.Type(prepare.ParamsType[0]))
+	assert.Equal(t, sqltypes.Int64BindVariable(4), prepare.BindVars["v1"])
+
+	// The next ones reuse it.
+	prepare.BindVars = map[string]*querypb.BindVariable{}
+	_, _, err = sConn.parseComStmtExecute(prepareDataMap, withoutTypes)
+	require.NoError(t, err)
+	assert.Equal(t, sqltypes.Int64BindVariable(5), prepare.BindVars["v1"])
+}
+
+func TestComStmtPrepareMaxPreparedStatements(t *testing.T) {
+	listener, sConn, cConn := createSocketPair(t)
+	defer func() {
+		listener.Close()
+		sConn.Close()
+		cConn.Close()
+	}()
+
+	prepare, _ := MockPrepareData(t)
+	sConn.PrepareData = map[uint32]*PrepareData{prepare.StatementID: prepare}
+	sConn.maxPreparedStatements = 1
+
+	err := cConn.writePacket(preparePacket(t, "select * from test_table where id = ?"))
+	require.NoError(t, err)
+	// The statement is refused before the handler is called.
+	require.True(t, sConn.handleNextCommand(&testRun{t: t}))
+
+	resp, err := cConn.ReadPacket()
+	require.NoError(t, err)
+	require.EqualValues(t, ErrPacket, resp[0])
+	err = ParseErrorPacket(resp)
+	require.EqualValues(t, ERMaxPreparedStmtCount, err.(*SQLError).Number(), "%v", err)
+	assert.Len(t, sConn.PrepareData, 1)
+}
+
 func TestComStmtClose(t *testing.T) {
 	listener, sConn, cConn := createSocketPair(t)
 	defer func() {
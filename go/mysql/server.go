@@ -156,6 +156,11 @@ type Listener struct {
 	// beyond which a warning is logged to identify the slow connection
 	SlowConnectWarnThreshold sync2.AtomicDuration
 
+	// MaxPreparedStatements if non-zero is the maximum number of
+	// prepared statements a connection can have open at once. Above it,
+	// COM_STMT_PREPARE fails until the client closes some of them.
+	MaxPreparedStatements int
+
 	// The following parameters are changed by the Accept routine.
 
 	// Incrementing ID for connection id.
@@ -493,7 +498,7 @@ func (l *Listener) handle(conn net.Conn, connectionID uint32, acceptTime time.Ti
 
 	for {
 		kontinue := c.handleNextCommand(l.handler)
-		if !kontinue {
+		if !kontinue || c.closeAfterCommand.Get() {
 			return
 		}
 	}
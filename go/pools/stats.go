@@ -0,0 +1,135 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pools
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/stats"
+)
+
+// This file exports the stats of all the pools of a process under the same
+// names, labelled by keyspace, shard, type and name of pool, so that they
+// can be aggregated regardless of the component which owns the pool. The
+// components keep exporting their own per-pool stats as well.
+
+// StatsSource is a pool whose stats are exported.
+type StatsSource interface {
+	Capacity() int64
+	Available() int64
+	Active() int64
+	InUse() int64
+	WaitCount() int64
+	WaitTime() time.Duration
+	IdleClosed() int64
+	Exhausted() int64
+}
+
+// Types of pools.
+const (
+	// StatsTypeApp is a pool of connections with the app credentials,
+	// used by the queries of the applications.
+	StatsTypeApp = "app"
+	// StatsTypeDba is a pool of connections with the dba credentials.
+	StatsTypeDba = "dba"
+	// StatsTypeTransaction is a pool of connections used by transactions
+	// and reserved connections.
+	StatsTypeTransaction = "transaction"
+	// StatsTypeStream is a pool of connections used by streaming queries.
+	StatsTypeStream = "stream"
+	// StatsTypeInternal is a pool used by an internal component.
+	StatsTypeInternal = "internal"
+)
+
+var poolStatsLabels = []string{"Keyspace", "Shard", "Type", "Pool"}
+
+type poolStatsKey struct {
+	typ, name string
+}
+
+var (
+	poolStatsMu       sync.Mutex
+	poolStatsSources  = make(map[poolStatsKey]StatsSource)
+	poolStatsKeyspace string
+	poolStatsShard    string
+
+	poolWaitTimings = stats.NewMultiTimings(
+		"PoolWaitTimings",
+		"Histogram of the time spent waiting for a resource of a pool",
+		poolStatsLabels)
+)
+
+func init() {
+	gauge := func(name, help string, f func(StatsSource) int64) {
+		stats.NewGaugesFuncWithMultiLabels(name, help, poolStatsLabels, func() map[string]int64 {
+			return poolStatsValues(f)
+		})
+	}
+	counter := func(name, help string, f func(StatsSource) int64) {
+		stats.NewCountersFuncWithMultiLabels(name, help, poolStatsLabels, func() map[string]int64 {
+			return poolStatsValues(f)
+		})
+	}
+	gauge("PoolCapacity", "Capacity of the pool", func(s StatsSource) int64 { return s.Capacity() })
+	gauge("PoolAvailable", "Number of available resources of the pool", func(s StatsSource) int64 { return s.Available() })
+	gauge("PoolActive", "Number of open resources of the pool", func(s StatsSource) int64 { return s.Active() })
+	gauge("PoolInUse", "Number of resources of the pool in use", func(s StatsSource) int64 { return s.InUse() })
+	counter("PoolWaitCount", "Number of times a resource of the pool was waited for", func(s StatsSource) int64 { return s.WaitCount() })
+	counter("PoolWaitTimeNs", "Time spent waiting for a resource of the pool, in nanoseconds", func(s StatsSource) int64 { return int64(s.WaitTime()) })
+	counter("PoolIdleClosed", "Number of resources of the pool closed because they were idle", func(s StatsSource) int64 { return s.IdleClosed() })
+	counter("PoolExhausted", "Number of times the pool had no available resource", func(s StatsSource) int64 { return s.Exhausted() })
+}
+
+// RegisterStats exports the stats of the pool, with the given type and
+// name. A pool registered with the same type and name replaces the
+// previous one.
+func RegisterStats(typ, name string, pool StatsSource) {
+	poolStatsMu.Lock()
+	defer poolStatsMu.Unlock()
+	poolStatsSources[poolStatsKey{typ: typ, name: name}] = pool
+}
+
+// SetStatsTarget sets the keyspace and shard labels of the stats of the
+// pools, e.g. the target of the tablet.
+func SetStatsTarget(keyspace, shard string) {
+	poolStatsMu.Lock()
+	defer poolStatsMu.Unlock()
+	poolStatsKeyspace = keyspace
+	poolStatsShard = shard
+}
+
+// RecordWait records the time spent waiting for a resource of the pool
+// since start, in the PoolWaitTimings histogram.
+func RecordWait(typ, name string, start time.Time) {
+	poolStatsMu.Lock()
+	keyspace, shard := poolStatsKeyspace, poolStatsShard
+	poolStatsMu.Unlock()
+	poolWaitTimings.Record([]string{keyspace, shard, typ, name}, start)
+}
+
+func poolStatsValues(f func(StatsSource) int64) map[string]int64 {
+	poolStatsMu.Lock()
+	defer poolStatsMu.Unlock()
+	values := make(map[string]int64, len(poolStatsSources))
+	for key, source := range poolStatsSources {
+		labels := strings.Join([]string{poolStatsKeyspace, poolStatsShard, key.typ, key.name}, ".")
+		values[labels] = f(source)
+	}
+	return values
+}
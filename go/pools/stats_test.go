@@ -0,0 +1,58 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pools
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeStatsSource struct {
+	capacity int64
+}
+
+func (f *fakeStatsSource) Capacity() int64         { return f.capacity }
+func (f *fakeStatsSource) Available() int64        { return 1 }
+func (f *fakeStatsSource) Active() int64           { return 2 }
+func (f *fakeStatsSource) InUse() int64            { return 3 }
+func (f *fakeStatsSource) WaitCount() int64        { return 4 }
+func (f *fakeStatsSource) WaitTime() time.Duration { return 5 }
+func (f *fakeStatsSource) IdleClosed() int64       { return 6 }
+func (f *fakeStatsSource) Exhausted() int64        { return 7 }
+
+func TestPoolStats(t *testing.T) {
+	SetStatsTarget("ks", "-80")
+	defer SetStatsTarget("", "")
+	RegisterStats(StatsTypeApp, "TestPoolStats", &fakeStatsSource{capacity: 10})
+	RegisterStats(StatsTypeDba, "TestPoolStats", &fakeStatsSource{capacity: 20})
+
+	capacities := poolStatsValues(func(s StatsSource) int64 { return s.Capacity() })
+	assert.EqualValues(t, 10, capacities["ks.-80.app.TestPoolStats"])
+	assert.EqualValues(t, 20, capacities["ks.-80.dba.TestPoolStats"])
+
+	// Registering the same pool again replaces it.
+	RegisterStats(StatsTypeApp, "TestPoolStats", &fakeStatsSource{capacity: 30})
+	capacities = poolStatsValues(func(s StatsSource) int64 { return s.Capacity() })
+	assert.EqualValues(t, 30, capacities["ks.-80.app.TestPoolStats"])
+	exhausted := poolStatsValues(func(s StatsSource) int64 { return s.Exhausted() })
+	assert.EqualValues(t, 7, exhausted["ks.-80.app.TestPoolStats"])
+
+	RecordWait(StatsTypeApp, "TestPoolStats", time.Now().Add(-time.Millisecond))
+	assert.EqualValues(t, 1, poolWaitTimings.Counts()["ks.-80.app.TestPoolStats"])
+}
@@ -19,6 +19,7 @@ package sqltypes
 
 import (
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -275,6 +276,8 @@ func (v Value) EncodeSQL(b BinWriter) {
 	switch {
 	case v.typ == Null:
 		b.Write(nullstr)
+	case v.typ == Geometry:
+		encodeBytesSQLHex(v.val, b)
 	case v.IsQuoted():
 		encodeBytesSQL(v.val, b)
 	case v.typ == Bit:
@@ -290,6 +293,8 @@ func (v Value) EncodeSQLStringBuilder(b *strings.Builder) {
 	switch {
 	case v.typ == Null:
 		b.Write(nullstr)
+	case v.typ == Geometry:
+		encodeBytesSQLHex(v.val, b)
 	case v.IsQuoted():
 		encodeBytesSQLStringBuilder(v.val, b)
 	case v.typ == Bit:
@@ -305,6 +310,8 @@ func (v Value) EncodeSQLBytes2(b *bytes2.Buffer) {
 	switch {
 	case v.typ == Null:
 		b.Write(nullstr)
+	case v.typ == Geometry:
+		encodeBytesSQLHex(v.val, b)
 	case v.IsQuoted():
 		encodeBytesSQLBytes2(v.val, b)
 	case v.typ == Bit:
@@ -366,6 +373,12 @@ func (v Value) IsBinary() bool {
 	return IsBinary(v.typ)
 }
 
+// IsGeometry returns true if Value is a spatial value, in the internal
+// format of MySQL: a 4 bytes little endian SRID followed by the WKB.
+func (v Value) IsGeometry() bool {
+	return v.typ == Geometry
+}
+
 // IsDateTime returns true if Value is datetime.
 func (v Value) IsDateTime() bool {
 	dt := int(querypb.Type_DATETIME)
@@ -480,6 +493,18 @@ func encodeBytesSQLBits(val []byte, b BinWriter) {
 	fmt.Fprint(b, "'")
 }
 
+// encodeBytesSQLHex encodes the bytes as a hexadecimal literal, which is
+// a binary string whatever the character set of the connection. It is used
+// for the spatial values, which MySQL accepts in their internal format.
+func encodeBytesSQLHex(val []byte, b BinWriter) {
+	buf := make([]byte, 3+hex.EncodedLen(len(val)))
+	buf[0] = 'X'
+	buf[1] = '\''
+	hex.Encode(buf[2:], val)
+	buf[len(buf)-1] = '\''
+	b.Write(buf)
+}
+
 func encodeBytesASCII(val []byte, b BinWriter) {
 	buf := &bytes2.Buffer{}
 	buf.WriteByte('\'')
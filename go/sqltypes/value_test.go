@@ -445,6 +445,10 @@ func TestEncode(t *testing.T) {
 		in:       TestValue(Bit, "a"),
 		outSQL:   "b'01100001'",
 		outASCII: "'YQ=='",
+	}, {
+		in:       TestValue(Geometry, "\x00\x00\x00\x00\x01\x01\x00\x00\x00'\n"),
+		outSQL:   "X'000000000101000000270a'",
+		outASCII: "'AAAAAAEBAAAAJwo='",
 	}}
 	for _, tcase := range testcases {
 		buf := &bytes.Buffer{}
@@ -52,3 +52,40 @@ func CounterForDimension(mt MultiTracker, dimension string) CountTracker {
 
 	panic(fmt.Sprintf("label %v is not one of %v", dimension, mt.Labels()))
 }
+
+// CounterForDimensions returns a CountTracker for the combination of the
+// provided dimensions, whose categories join their values with ".", e.g.
+// "keyspace.shard". It will panic if a dimension isn't a legal label for
+// mt.
+func CounterForDimensions(mt MultiTracker, dimensions ...string) CountTracker {
+	indexes := make([]int, len(dimensions))
+	for i, dimension := range dimensions {
+		indexes[i] = -1
+		for j, lab := range mt.Labels() {
+			if lab == dimension {
+				indexes[i] = j
+			}
+		}
+		if indexes[i] < 0 {
+			panic(fmt.Sprintf("label %v is not one of %v", dimension, mt.Labels()))
+		}
+	}
+	return wrappedCountTracker{
+		f: func() map[string]int64 {
+			result := make(map[string]int64)
+			for k, v := range mt.Counts() {
+				if k == "All" {
+					result[k] = v
+					continue
+				}
+				values := strings.Split(k, ".")
+				category := make([]string, len(indexes))
+				for i, index := range indexes {
+					category[i] = values[index]
+				}
+				result[strings.Join(category, ".")] += v
+			}
+			return result
+		},
+	}
+}
@@ -44,3 +44,18 @@ func TestMultiTimingsCounterFor(t *testing.T) {
 		}
 	}
 }
+
+func TestMultiTimingsCounterForDimensions(t *testing.T) {
+	clear()
+	mtm := NewMultiTimings("multitimings4", "help", []string{"dim1", "dim2", "dim3"})
+
+	mtm.Add([]string{"tag1a", "tag1b", "tag1c"}, 500*time.Microsecond)
+	mtm.Add([]string{"tag1a", "tag2b", "tag1c"}, 500*time.Millisecond)
+	mtm.Add([]string{"tag1a", "tag2b", "tag2c"}, 500*time.Millisecond)
+
+	want := map[string]int64{"tag1a.tag1c": 2, "tag1a.tag2c": 1, "All": 3}
+	counts := CounterForDimensions(mtm, "dim1", "dim3").Counts()
+	if !reflect.DeepEqual(want, counts) {
+		t.Errorf("CounterForDimensions(mtm, dim1, dim3).Counts()=%v, want %v", counts, want)
+	}
+}
@@ -199,7 +199,7 @@ func (sb StatsBackend) addExpVar(kv expvar.KeyValue) {
 				}
 			}
 		}
-	case *stats.Rates, *stats.RatesFunc, *stats.String, *stats.StringFunc, *stats.StringMapFunc,
+	case *stats.Rates, *stats.RatesFunc, *stats.WindowRates, *stats.String, *stats.StringFunc, *stats.StringMapFunc,
 		stats.StringFunc, stats.StringMapFunc:
 		// Silently ignore metrics that does not make sense to be exported to statsd
 	default:
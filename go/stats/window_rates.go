@@ -0,0 +1,197 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stats
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// DefaultRateWindows are the usual windows of WindowRates: the last
+// minute, 5 minutes and hour.
+var DefaultRateWindows = []time.Duration{time.Minute, 5 * time.Minute, time.Hour}
+
+// WindowRates reports the average rate of each category of a CountTracker
+// over sliding windows of time, e.g. the last minute, 5 minutes and hour.
+// Unlike Rates, which reports a series of rates over consecutive intervals,
+// it reports a single rate per window, so the recent behavior of a process
+// can be read without a metrics pipeline.
+type WindowRates struct {
+	// mu guards all fields.
+	mu           sync.Mutex
+	countTracker CountTracker
+	windows      []time.Duration
+	interval     time.Duration
+	timeStamps   *RingInt64
+	// counts has a value for each category at each of the timeStamps,
+	// from the first sample where it appeared.
+	counts  map[string]*RingInt64
+	samples int
+}
+
+var (
+	windowRatesMu sync.Mutex
+	windowRates   = make(map[string]*WindowRates)
+)
+
+// NewWindowRates reports the rates of countTracker over the windows,
+// sampling its counts every interval. The minimum interval is 1 second.
+// If passing the special value of -1s as interval, we don't snapshot, and
+// 100 samples are kept (use this for tests).
+func NewWindowRates(name string, countTracker CountTracker, windows []time.Duration, interval time.Duration) *WindowRates {
+	if interval < 1*time.Second && interval != -1*time.Second {
+		panic("interval too small")
+	}
+	samples := 100
+	if interval > 0 {
+		var longest time.Duration
+		for _, window := range windows {
+			if window > longest {
+				longest = window
+			}
+		}
+		samples = int(longest/interval) + 2
+	}
+	wr := &WindowRates{
+		countTracker: countTracker,
+		windows:      windows,
+		interval:     interval,
+		timeStamps:   NewRingInt64(samples),
+		counts:       make(map[string]*RingInt64),
+		samples:      samples,
+	}
+	if name != "" {
+		publish(name, wr)
+		windowRatesMu.Lock()
+		windowRates[name] = wr
+		windowRatesMu.Unlock()
+	}
+	if interval > 0 {
+		go wr.track()
+	}
+	return wr
+}
+
+func (wr *WindowRates) track() {
+	for {
+		wr.snapshot()
+		<-time.After(wr.interval)
+	}
+}
+
+func (wr *WindowRates) snapshot() {
+	wr.mu.Lock()
+	defer wr.mu.Unlock()
+
+	first := len(wr.timeStamps.Values()) == 0
+	wr.timeStamps.Add(timeNow().UnixNano())
+	counts := wr.countTracker.Counts()
+	for k, values := range wr.counts {
+		if _, ok := counts[k]; !ok {
+			// Keep the values of the categories aligned with the
+			// timestamps.
+			last := values.Values()
+			values.Add(last[len(last)-1])
+		}
+	}
+	for k, v := range counts {
+		values, ok := wr.counts[k]
+		if !ok {
+			values = NewRingInt64(wr.samples)
+			if !first {
+				// The category appeared since the previous sample.
+				values.Add(0)
+			}
+			wr.counts[k] = values
+		}
+		values.Add(v)
+	}
+}
+
+// Get returns for each category (string) its rate per second over each
+// window, by window name, e.g. "1m".
+func (wr *WindowRates) Get() map[string]map[string]float64 {
+	wr.mu.Lock()
+	defer wr.mu.Unlock()
+
+	rateMap := make(map[string]map[string]float64)
+	timeStamps := wr.timeStamps.Values()
+	if len(timeStamps) <= 1 {
+		return rateMap
+	}
+	// Sampling jitter must not exclude the sample at the start of a window.
+	var tolerance time.Duration
+	if wr.interval > 0 {
+		tolerance = wr.interval / 2
+	}
+	last := len(timeStamps) - 1
+	for k, ring := range wr.counts {
+		values := ring.Values()
+		rates := make(map[string]float64, len(wr.windows))
+		for _, window := range wr.windows {
+			// Find the oldest sample of the category within the window.
+			i := last
+			for i > 0 && last-i+1 < len(values) && timeStamps[last]-timeStamps[i-1] <= int64(window+tolerance) {
+				i--
+			}
+			rate := 0.0
+			if i < last {
+				elapsed := float64(timeStamps[last]-timeStamps[i]) / 1e9
+				rate = float64(values[len(values)-1]-values[len(values)-1-(last-i)]) / elapsed
+			}
+			// Round rate with a precision of 0.1.
+			rates[RateWindowName(window)] = math.Floor(rate*10+0.5) / 10
+		}
+		rateMap[k] = rates
+	}
+	return rateMap
+}
+
+func (wr *WindowRates) String() string {
+	data, err := json.Marshal(wr.Get())
+	if err != nil {
+		data, _ = json.Marshal(err.Error())
+	}
+	return string(data)
+}
+
+// RateWindowName returns the short name of a window, e.g. "5m".
+func RateWindowName(window time.Duration) string {
+	switch {
+	case window%time.Hour == 0:
+		return fmt.Sprintf("%dh", window/time.Hour)
+	case window%time.Minute == 0:
+		return fmt.Sprintf("%dm", window/time.Minute)
+	default:
+		return fmt.Sprintf("%ds", window/time.Second)
+	}
+}
+
+// GetAllWindowRates returns the rates of all the published WindowRates, by
+// name.
+func GetAllWindowRates() map[string]map[string]map[string]float64 {
+	windowRatesMu.Lock()
+	defer windowRatesMu.Unlock()
+	all := make(map[string]map[string]map[string]float64, len(windowRates))
+	for name, wr := range windowRates {
+		all[name] = wr.Get()
+	}
+	return all
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stats
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWindowRates(t *testing.T) {
+	now := time.Now()
+	timeNow = func() time.Time {
+		return now
+	}
+	defer func() { timeNow = time.Now }()
+
+	clear()
+	c := NewCountersWithSingleLabel("wrcounter1", "wrcounter help", "label")
+	wr := NewWindowRates("wrates1", c, []time.Duration{2 * time.Second, 4 * time.Second}, -1*time.Second)
+	checkWindowRates(t, wr, "before the first snapshot", `{}`)
+
+	c.Add("tag1", 0)
+	wr.snapshot()
+	now = now.Add(interval)
+	c.Add("tag1", 10)
+	wr.snapshot()
+	checkWindowRates(t, wr, "after 1s", `{"tag1":{"2s":10,"4s":10}}`)
+
+	now = now.Add(interval)
+	c.Add("tag1", 20)
+	wr.snapshot()
+	checkWindowRates(t, wr, "after 2s", `{"tag1":{"2s":15,"4s":15}}`)
+
+	// tag2 appears, and tag1 gets no more counts.
+	now = now.Add(interval)
+	c.Add("tag2", 6)
+	wr.snapshot()
+	checkWindowRates(t, wr, "after 3s", `{"tag1":{"2s":10,"4s":10},"tag2":{"2s":6,"4s":6}}`)
+
+	if got := GetAllWindowRates()["wrates1"]["tag1"]["2s"]; got != 10 {
+		t.Errorf("GetAllWindowRates: want rate 10, got %v", got)
+	}
+}
+
+func checkWindowRates(t *testing.T, wr *WindowRates, desc string, want string) {
+	t.Helper()
+	if got := wr.String(); got != want {
+		t.Errorf("%v: want %s, got %s", desc, want, got)
+	}
+}
+
+func TestRateWindowName(t *testing.T) {
+	for window, want := range map[time.Duration]string{
+		time.Minute:      "1m",
+		5 * time.Minute:  "5m",
+		time.Hour:        "1h",
+		90 * time.Second: "90s",
+	} {
+		if got := RateWindowName(window); got != want {
+			t.Errorf("RateWindowName(%v): want %s, got %s", window, want, got)
+		}
+	}
+}
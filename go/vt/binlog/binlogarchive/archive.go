@@ -0,0 +1,498 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package binlogarchive stores the binlog events of a shard in the backup
+// storage, so the consumers of the binlogs can catch up after the binlogs
+// were purged from MySQL.
+//
+// The archive of a shard is a sequence of segments. Each segment is a
+// backup in the backup storage, named after its creation time so that
+// ListBackups returns them in order. A segment holds the raw binlog
+// events, starting with a FORMAT_DESCRIPTION_EVENT, and a manifest with
+// the GTID range of the complete transactions it holds. The manifests
+// are the index of the archive by GTID.
+package binlogarchive
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/mysqlctl/backupstorage"
+)
+
+const (
+	manifestFile = "MANIFEST"
+	eventsFile   = "events"
+)
+
+// Dir returns the directory of the archive of the shard in the backup
+// storage.
+func Dir(keyspace, shard string) string {
+	return path.Join("binlog_archive", keyspace, shard)
+}
+
+// Manifest describes a segment of the archive.
+type Manifest struct {
+	Keyspace string
+	Shard    string
+	// Flavor is the flavor of the binlog events, e.g. MySQL56.
+	Flavor string
+	// StartPosition is the position before the first transaction of the
+	// segment, and EndPosition the position after its last complete
+	// transaction.
+	StartPosition mysql.Position
+	EndPosition   mysql.Position
+	Transactions  int64
+	StartTime     time.Time
+	EndTime       time.Time
+}
+
+// Segment is a segment of the archive in the backup storage.
+type Segment struct {
+	Manifest *Manifest
+	bh       backupstorage.BackupHandle
+}
+
+// Name returns the name of the segment in the backup storage.
+func (s *Segment) Name() string {
+	return s.bh.Name()
+}
+
+// ListSegments returns the segments of the archive of the shard, from the
+// oldest. The segments still being written are skipped.
+func ListSegments(ctx context.Context, bs backupstorage.BackupStorage, keyspace, shard string) ([]*Segment, error) {
+	bhs, err := bs.ListBackups(ctx, Dir(keyspace, shard))
+	if err != nil {
+		return nil, err
+	}
+	var segments []*Segment
+	for _, bh := range bhs {
+		manifest, err := readManifest(ctx, bh)
+		if err != nil {
+			log.V(2).Infof("Skipping binlog archive segment %v/%v: %v", bh.Directory(), bh.Name(), err)
+			continue
+		}
+		segments = append(segments, &Segment{Manifest: manifest, bh: bh})
+	}
+	return segments, nil
+}
+
+func readManifest(ctx context.Context, bh backupstorage.BackupHandle) (*Manifest, error) {
+	rc, err := bh.ReadFile(ctx, manifestFile)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	data, err := ioutil.ReadAll(rc)
+	if err != nil {
+		return nil, err
+	}
+	manifest := &Manifest{}
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, fmt.Errorf("bad manifest: %v", err)
+	}
+	return manifest, nil
+}
+
+// Writer writes the binlog events streamed from the master of a shard to
+// the archive. A new segment is started every segmentDuration, at a
+// transaction boundary.
+type Writer struct {
+	bs              backupstorage.BackupStorage
+	keyspace        string
+	shard           string
+	segmentDuration time.Duration
+
+	format mysql.BinlogFormat
+	// formatEvent is the last FORMAT_DESCRIPTION_EVENT, written at the
+	// start of each segment.
+	formatEvent []byte
+	// pending is the GTID of the transaction being written, until its
+	// end is seen.
+	pending mysql.GTID
+	segment *segmentWriter
+
+	// mu protects pos, the position after the last complete transaction.
+	mu  sync.Mutex
+	pos mysql.Position
+}
+
+// segmentWriter is a segment being written.
+type segmentWriter struct {
+	bh       backupstorage.BackupHandle
+	wc       io.WriteCloser
+	buf      *bufio.Writer
+	manifest *Manifest
+}
+
+// NewWriter returns a Writer which archives the events streamed from
+// startPos, which must be the end position of the archive if it isn't
+// empty.
+func NewWriter(bs backupstorage.BackupStorage, keyspace, shard string, startPos mysql.Position, segmentDuration time.Duration) *Writer {
+	return &Writer{
+		bs:              bs,
+		keyspace:        keyspace,
+		shard:           shard,
+		segmentDuration: segmentDuration,
+		pos:             startPos,
+	}
+}
+
+// Position returns the position after the last complete transaction
+// written to the archive.
+func (w *Writer) Position() mysql.Position {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.pos
+}
+
+// Write writes a binlog event to the archive.
+func (w *Writer) Write(ctx context.Context, ev mysql.BinlogEvent) error {
+	if !ev.IsValid() {
+		return fmt.Errorf("can't archive binlog event: invalid data: %#v", ev)
+	}
+	data, err := eventBytes(ev)
+	if err != nil {
+		return err
+	}
+	if ev.IsFormatDescription() {
+		if w.format, err = ev.Format(); err != nil {
+			return fmt.Errorf("can't parse FORMAT_DESCRIPTION_EVENT: %v", err)
+		}
+		w.formatEvent = data
+		if w.segment != nil {
+			return w.segment.write(data)
+		}
+		return nil
+	}
+	if w.format.IsZero() {
+		// The fake ROTATE_EVENT at the start of the stream.
+		return nil
+	}
+
+	stripped, _, err := ev.StripChecksum(w.format)
+	if err != nil {
+		return fmt.Errorf("can't strip checksum from binlog event: %v", err)
+	}
+	switch {
+	case stripped.IsGTID():
+		gtid, _, err := stripped.GTID(w.format)
+		if err != nil {
+			return fmt.Errorf("can't get GTID from binlog event: %v", err)
+		}
+		if w.pending != nil {
+			// The previous transaction ended without a COMMIT, e.g. a
+			// DDL in a QUERY_EVENT we didn't recognize.
+			w.complete()
+		}
+		if err := w.Rotate(ctx); err != nil {
+			return err
+		}
+		if w.segment == nil {
+			if err := w.openSegment(ctx); err != nil {
+				return err
+			}
+		}
+		w.pending = gtid
+	case w.segment == nil:
+		// The events before the first transaction, e.g. the
+		// PREVIOUS_GTIDS_EVENT, are not needed.
+		return nil
+	}
+	if err := w.segment.write(data); err != nil {
+		return err
+	}
+
+	switch {
+	case stripped.IsXID():
+		w.complete()
+	case stripped.IsQuery():
+		q, err := stripped.Query(w.format)
+		if err != nil {
+			return fmt.Errorf("can't get query from binlog event: %v", err)
+		}
+		if q.SQL != "BEGIN" {
+			// A COMMIT, or a DDL which is its own transaction.
+			w.complete()
+		}
+	}
+	return nil
+}
+
+// Rotate ends the current segment if it spans segmentDuration, and no
+// transaction is being written. It's called on each transaction, and
+// should be called periodically while the binlogs are idle, so the
+// readers see the last transactions.
+func (w *Writer) Rotate(ctx context.Context) error {
+	if w.segment == nil || w.pending != nil || time.Since(w.segment.manifest.StartTime) < w.segmentDuration {
+		return nil
+	}
+	return w.closeSegment(ctx)
+}
+
+// complete records that the pending transaction was completely written.
+func (w *Writer) complete() {
+	if w.pending == nil {
+		return
+	}
+	w.mu.Lock()
+	w.pos = mysql.AppendGTID(w.pos, w.pending)
+	w.mu.Unlock()
+	w.pending = nil
+	w.segment.manifest.Transactions++
+}
+
+func (w *Writer) openSegment(ctx context.Context) error {
+	now := time.Now().UTC()
+	bh, err := w.bs.StartBackup(ctx, Dir(w.keyspace, w.shard), now.Format("20060102.150405.000000000"))
+	if err != nil {
+		return err
+	}
+	wc, err := bh.AddFile(ctx, eventsFile, backupstorage.FileSizeUnknown)
+	if err != nil {
+		bh.AbortBackup(ctx)
+		return err
+	}
+	pos := w.Position()
+	if pos.GTIDSet == nil {
+		bh.AbortBackup(ctx)
+		return fmt.Errorf("can't archive binlog events without a GTID start position")
+	}
+	w.segment = &segmentWriter{
+		bh:  bh,
+		wc:  wc,
+		buf: bufio.NewWriter(wc),
+		manifest: &Manifest{
+			Keyspace:      w.keyspace,
+			Shard:         w.shard,
+			Flavor:        pos.GTIDSet.Flavor(),
+			StartPosition: pos,
+			StartTime:     now,
+		},
+	}
+	return w.segment.write(w.formatEvent)
+}
+
+// closeSegment ends the current segment after the last complete
+// transaction. The events of an incomplete transaction are in the segment,
+// but the readers skip them.
+func (w *Writer) closeSegment(ctx context.Context) error {
+	segment := w.segment
+	w.segment = nil
+	segment.manifest.EndPosition = w.Position()
+	segment.manifest.EndTime = time.Now().UTC()
+	if err := segment.close(ctx); err != nil {
+		segment.bh.AbortBackup(ctx)
+		return err
+	}
+	log.Infof("Archived binlog segment %v/%v up to %v", segment.bh.Directory(), segment.bh.Name(), segment.manifest.EndPosition)
+	return nil
+}
+
+// Close ends the current segment, if any.
+func (w *Writer) Close(ctx context.Context) error {
+	if w.segment == nil {
+		return nil
+	}
+	return w.closeSegment(ctx)
+}
+
+func (s *segmentWriter) write(data []byte) error {
+	var length [4]byte
+	binary.LittleEndian.PutUint32(length[:], uint32(len(data)))
+	if _, err := s.buf.Write(length[:]); err != nil {
+		return err
+	}
+	_, err := s.buf.Write(data)
+	return err
+}
+
+func (s *segmentWriter) close(ctx context.Context) error {
+	if err := s.buf.Flush(); err != nil {
+		return err
+	}
+	if err := s.wc.Close(); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s.manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	wc, err := s.bh.AddFile(ctx, manifestFile, int64(len(data)))
+	if err != nil {
+		return err
+	}
+	if _, err := wc.Write(data); err != nil {
+		wc.Close()
+		return err
+	}
+	if err := wc.Close(); err != nil {
+		return err
+	}
+	return s.bh.EndBackup(ctx)
+}
+
+func eventBytes(ev mysql.BinlogEvent) ([]byte, error) {
+	b, ok := ev.(interface{ Bytes() []byte })
+	if !ok {
+		return nil, fmt.Errorf("can't archive binlog event of type %T", ev)
+	}
+	return b.Bytes(), nil
+}
+
+// EndPosition returns the end position of the archive of the shard, or
+// the zero position if it is empty.
+func EndPosition(ctx context.Context, bs backupstorage.BackupStorage, keyspace, shard string) (mysql.Position, error) {
+	segments, err := ListSegments(ctx, bs, keyspace, shard)
+	if err != nil || len(segments) == 0 {
+		return mysql.Position{}, err
+	}
+	return segments[len(segments)-1].Manifest.EndPosition, nil
+}
+
+// Stream reads the archived transactions of a shard which are not in a
+// position, in order.
+type Stream struct {
+	events chan mysql.BinlogEvent
+	// err is set before events is closed.
+	err error
+}
+
+// NewStream starts streaming the archived transactions which are not in
+// startPos. It returns an error if the archive doesn't have all the
+// transactions after startPos, up to its end.
+func NewStream(ctx context.Context, bs backupstorage.BackupStorage, keyspace, shard string, startPos mysql.Position) (*Stream, error) {
+	segments, err := ListSegments(ctx, bs, keyspace, shard)
+	if err != nil {
+		return nil, err
+	}
+	// Skip the segments startPos already has.
+	for len(segments) > 0 && startPos.AtLeast(segments[0].Manifest.EndPosition) {
+		segments = segments[1:]
+	}
+	if len(segments) > 0 && !startPos.AtLeast(segments[0].Manifest.StartPosition) {
+		return nil, fmt.Errorf("the binlog archive of %v/%v starts at %v, after %v", keyspace, shard, segments[0].Manifest.StartPosition, startPos)
+	}
+
+	s := &Stream{events: make(chan mysql.BinlogEvent)}
+	go func() {
+		defer close(s.events)
+		for _, segment := range segments {
+			if s.err = s.streamSegment(ctx, segment, startPos); s.err != nil {
+				return
+			}
+		}
+	}()
+	return s, nil
+}
+
+// Events returns the channel of the events. It is closed at the end of the
+// archive, or on error.
+func (s *Stream) Events() <-chan mysql.BinlogEvent {
+	return s.events
+}
+
+// Err returns the error which ended the stream. It's only valid once the
+// events channel is closed.
+func (s *Stream) Err() error {
+	return s.err
+}
+
+// streamSegment sends the events of the complete transactions of the
+// segment which are not in startPos.
+func (s *Stream) streamSegment(ctx context.Context, segment *Segment, startPos mysql.Position) error {
+	rc, err := segment.bh.ReadFile(ctx, eventsFile)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	r := bufio.NewReader(rc)
+
+	manifest := segment.Manifest
+	var format mysql.BinlogFormat
+	skip := true
+	for {
+		var length [4]byte
+		if _, err := io.ReadFull(r, length[:]); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		data := make([]byte, binary.LittleEndian.Uint32(length[:]))
+		if _, err := io.ReadFull(r, data); err != nil {
+			return err
+		}
+		ev, err := newEvent(manifest.Flavor, data)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case ev.IsFormatDescription():
+			if format, err = ev.Format(); err != nil {
+				return fmt.Errorf("can't parse FORMAT_DESCRIPTION_EVENT: %v", err)
+			}
+		case format.IsZero():
+			return fmt.Errorf("binlog archive segment %v doesn't start with a FORMAT_DESCRIPTION_EVENT", segment.Name())
+		default:
+			stripped, _, err := ev.StripChecksum(format)
+			if err != nil {
+				return err
+			}
+			if stripped.IsGTID() {
+				gtid, _, err := stripped.GTID(format)
+				if err != nil {
+					return err
+				}
+				if !manifest.EndPosition.GTIDSet.ContainsGTID(gtid) {
+					// The rest of the segment is an incomplete transaction.
+					return nil
+				}
+				skip = startPos.GTIDSet != nil && startPos.GTIDSet.ContainsGTID(gtid)
+			}
+			if skip {
+				continue
+			}
+		}
+
+		select {
+		case s.events <- ev:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func newEvent(flavor string, data []byte) (mysql.BinlogEvent, error) {
+	switch flavor {
+	case mysql.Mysql56FlavorID:
+		return mysql.NewMysql56BinlogEvent(data), nil
+	case mysql.MariadbFlavorID:
+		return mysql.NewMariadbBinlogEvent(data), nil
+	}
+	return nil, fmt.Errorf("unsupported binlog archive flavor %q", flavor)
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binlogarchive
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/vt/mysqlctl/filebackupstorage"
+)
+
+// archivedGTIDs returns the GTIDs of the transactions of the stream.
+func archivedGTIDs(t *testing.T, s *Stream) []mysql.GTID {
+	t.Helper()
+	var format mysql.BinlogFormat
+	var gtids []mysql.GTID
+	for ev := range s.Events() {
+		if ev.IsFormatDescription() {
+			var err error
+			format, err = ev.Format()
+			require.NoError(t, err)
+			continue
+		}
+		if ev.IsGTID() {
+			gtid, _, err := ev.GTID(format)
+			require.NoError(t, err)
+			gtids = append(gtids, gtid)
+		}
+	}
+	require.NoError(t, s.Err())
+	return gtids
+}
+
+func TestArchive(t *testing.T) {
+	root, err := ioutil.TempDir("", "binlogarchive")
+	require.NoError(t, err)
+	defer os.RemoveAll(root)
+	defer func(saved string) { *filebackupstorage.FileBackupStorageRoot = saved }(*filebackupstorage.FileBackupStorageRoot)
+	*filebackupstorage.FileBackupStorageRoot = root
+	bs := &filebackupstorage.FileBackupStorage{}
+	ctx := context.Background()
+
+	f := mysql.NewMariaDBBinlogFormat()
+	s := mysql.NewFakeBinlogStream()
+	s.ServerID = 62344
+	gtid := func(seq uint64) mysql.BinlogEvent {
+		return mysql.NewMariaDBGTIDEvent(f, s, mysql.MariadbGTID{Domain: 0, Sequence: seq}, true /* hasBegin */)
+	}
+
+	startPos := mysql.MustParsePosition(mysql.MariadbFlavorID, "0-62344-10")
+	w := NewWriter(bs, "ks", "0", startPos, time.Hour)
+	write := func(evs ...mysql.BinlogEvent) {
+		for _, ev := range evs {
+			require.NoError(t, w.Write(ctx, ev))
+		}
+	}
+
+	// The first segment has the transactions 11 and 12, and the start of
+	// the transaction 13.
+	write(mysql.NewRotateEvent(f, s, 4, "binlog.000001"), mysql.NewFormatDescriptionEvent(f, s))
+	write(gtid(11), mysql.NewXIDEvent(f, s))
+	write(gtid(12), mysql.NewXIDEvent(f, s))
+	write(gtid(13))
+	require.NoError(t, w.Close(ctx))
+	assert.Equal(t, "0-62344-12", w.Position().String())
+
+	// The second segment starts again from the transaction 13.
+	w = NewWriter(bs, "ks", "0", w.Position(), time.Hour)
+	write(mysql.NewFormatDescriptionEvent(f, s))
+	write(gtid(13), mysql.NewXIDEvent(f, s))
+	write(gtid(14), mysql.NewXIDEvent(f, s))
+	require.NoError(t, w.Close(ctx))
+
+	segments, err := ListSegments(ctx, bs, "ks", "0")
+	require.NoError(t, err)
+	require.Len(t, segments, 2)
+	assert.Equal(t, "0-62344-10", segments[0].Manifest.StartPosition.String())
+	assert.Equal(t, "0-62344-12", segments[0].Manifest.EndPosition.String())
+	assert.Equal(t, int64(2), segments[0].Manifest.Transactions)
+	assert.Equal(t, "0-62344-14", segments[1].Manifest.EndPosition.String())
+	endPos, err := EndPosition(ctx, bs, "ks", "0")
+	require.NoError(t, err)
+	assert.Equal(t, "0-62344-14", endPos.String())
+
+	// The incomplete transaction 13 of the first segment is skipped.
+	stream, err := NewStream(ctx, bs, "ks", "0", startPos)
+	require.NoError(t, err)
+	var seqs []uint64
+	for _, gtid := range archivedGTIDs(t, stream) {
+		seqs = append(seqs, gtid.SequenceNumber().(uint64))
+	}
+	assert.Equal(t, []uint64{11, 12, 13, 14}, seqs)
+
+	// The transactions already in the position are skipped.
+	stream, err = NewStream(ctx, bs, "ks", "0", mysql.MustParsePosition(mysql.MariadbFlavorID, "0-62344-11"))
+	require.NoError(t, err)
+	assert.Len(t, archivedGTIDs(t, stream), 3)
+	stream, err = NewStream(ctx, bs, "ks", "0", endPos)
+	require.NoError(t, err)
+	assert.Empty(t, archivedGTIDs(t, stream))
+
+	// A position before the archive can't catch up from it.
+	_, err = NewStream(ctx, bs, "ks", "0", mysql.MustParsePosition(mysql.MariadbFlavorID, "0-62344-5"))
+	assert.Error(t, err)
+}
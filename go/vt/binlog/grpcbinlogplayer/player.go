@@ -21,7 +21,6 @@ import (
 
 	"google.golang.org/grpc"
 
-	"vitess.io/vitess/go/netutil"
 	"vitess.io/vitess/go/vt/binlog/binlogplayer"
 	"vitess.io/vitess/go/vt/grpcclient"
 
@@ -46,7 +45,7 @@ type client struct {
 }
 
 func (client *client) Dial(tablet *topodatapb.Tablet) error {
-	addr := netutil.JoinHostPort(tablet.Hostname, tablet.PortMap["grpc"])
+	addr := grpcclient.TabletTarget(tablet.Hostname, tablet.PortMap["grpc"])
 	var err error
 	opt, err := grpcclient.SecureDialOption(*cert, *key, *ca, *name)
 	if err != nil {
@@ -0,0 +1,178 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cdcsink publishes the row changes of the tables of keyspaces to
+// a Kafka-compatible producer. The sinks are declared per keyspace in the
+// topology, and each one streams its tables with a VStream through vtgate.
+// The position of the stream is committed by the producer in the same
+// transaction as the messages, so the row changes are published exactly
+// once across restarts.
+package cdcsink
+
+import (
+	"context"
+	"flag"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/topo"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+var (
+	refreshInterval = flag.Duration("cdc_sink_refresh_interval", 30*time.Second, "how often the CDC sink service reads the CDC sinks of the keyspaces from the topology")
+	retryDelay      = flag.Duration("cdc_sink_retry_delay", 10*time.Second, "delay before restarting a CDC sink after an error")
+)
+
+// Manager runs the CDC sinks declared in the topology, and restarts them
+// when their declaration changes.
+type Manager struct {
+	ts       *topo.Server
+	conn     VStreamer
+	producer Producer
+
+	// mu protects running.
+	mu sync.Mutex
+	// running are the running sinks, by name.
+	running map[string]*runningSink
+}
+
+// runningSink is a sink being run by the Manager.
+type runningSink struct {
+	config *topodatapb.CDCSink
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewManager returns a Manager which streams through conn and publishes
+// with producer.
+func NewManager(ts *topo.Server, conn VStreamer, producer Producer) *Manager {
+	return &Manager{
+		ts:       ts,
+		conn:     conn,
+		producer: producer,
+		running:  make(map[string]*runningSink),
+	}
+}
+
+// Run runs the sinks until ctx is done.
+func (m *Manager) Run(ctx context.Context) {
+	defer m.stopAll()
+	for {
+		if err := m.refresh(ctx); err != nil {
+			log.Warningf("Cannot refresh the CDC sinks: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(*refreshInterval):
+		}
+	}
+}
+
+// refresh starts the sinks declared in the topology which aren't running,
+// and stops the ones which aren't declared anymore or changed.
+func (m *Manager) refresh(ctx context.Context) error {
+	keyspaces, err := m.ts.GetKeyspaces(ctx)
+	if err != nil {
+		return err
+	}
+	declared := make(map[string]*sink)
+	for _, keyspace := range keyspaces {
+		ki, err := m.ts.GetKeyspace(ctx, keyspace)
+		switch {
+		case topo.IsErrType(err, topo.NoNode):
+			continue
+		case err != nil:
+			return err
+		}
+		for _, config := range ki.CdcSinks {
+			s := newSink(keyspace, config, m.conn, m.producer)
+			declared[s.name()] = s
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for name, rs := range m.running {
+		if s, ok := declared[name]; ok && proto.Equal(s.config, rs.config) {
+			continue
+		}
+		log.Infof("Stopping CDC sink %v", name)
+		rs.cancel()
+		<-rs.done
+		delete(m.running, name)
+	}
+	for name, s := range declared {
+		if _, ok := m.running[name]; ok {
+			continue
+		}
+		log.Infof("Starting CDC sink %v", name)
+		sinkCtx, cancel := context.WithCancel(ctx)
+		rs := &runningSink{
+			config: s.config,
+			cancel: cancel,
+			done:   make(chan struct{}),
+		}
+		m.running[name] = rs
+		go m.runSink(sinkCtx, s, rs.done)
+	}
+	return nil
+}
+
+// runSink streams the sink until ctx is done, restarting it from its last
+// offset after -cdc_sink_retry_delay on errors.
+func (m *Manager) runSink(ctx context.Context, s *sink, done chan struct{}) {
+	defer close(done)
+	for {
+		err := s.stream(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		sinkErrors.Add(s.name(), 1)
+		log.Warningf("CDC sink %v failed, restarting in %v: %v", s.name(), *retryDelay, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(*retryDelay):
+		}
+	}
+}
+
+func (m *Manager) stopAll() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for name, rs := range m.running {
+		rs.cancel()
+		<-rs.done
+		delete(m.running, name)
+	}
+}
+
+// Running returns the declarations of the running sinks, by name.
+func (m *Manager) Running() map[string]*topodatapb.CDCSink {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	running := make(map[string]*topodatapb.CDCSink, len(m.running))
+	for name, rs := range m.running {
+		running[name] = rs.config
+	}
+	return running
+}
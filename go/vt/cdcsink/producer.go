@@ -0,0 +1,77 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cdcsink
+
+import (
+	"context"
+	"flag"
+	"fmt"
+)
+
+var producerImplementation = flag.String("cdc_sink_producer", "", "which implementation of the CDC sink producer to publish the row changes with, e.g. a Kafka-compatible producer plugin")
+
+// Message is a message published to a topic.
+type Message struct {
+	Topic string
+	// Key is the partitioning key of the message. The changes of a row
+	// have the same key, so they keep their order.
+	Key   []byte
+	Value []byte
+}
+
+// Producer publishes the messages of the CDC sinks. It is the interface
+// of a Kafka-compatible producer: the implementations must support
+// transactions which publish messages and commit the offset of a sink
+// atomically, so that a sink restarting from its last offset publishes
+// each row change exactly once.
+type Producer interface {
+	// Offset returns the offset last committed for the sink, or "" if
+	// none was.
+	Offset(ctx context.Context, sink string) (string, error)
+
+	// Publish publishes the messages and commits the offset of the sink
+	// in a single transaction. If it fails, none of the messages are
+	// visible to the consumers and the offset is unchanged.
+	Publish(ctx context.Context, sink string, messages []*Message, offset string) error
+
+	// Close closes the producer.
+	Close() error
+}
+
+// ProducerFactory creates a Producer.
+type ProducerFactory func() (Producer, error)
+
+var producerFactories = make(map[string]ProducerFactory)
+
+// RegisterProducer registers a Producer implementation. It should be
+// called from an init function.
+func RegisterProducer(name string, factory ProducerFactory) {
+	if _, ok := producerFactories[name]; ok {
+		panic(fmt.Sprintf("CDC sink producer %v is already registered", name))
+	}
+	producerFactories[name] = factory
+}
+
+// NewProducer creates a Producer with the implementation selected by
+// -cdc_sink_producer.
+func NewProducer() (Producer, error) {
+	factory, ok := producerFactories[*producerImplementation]
+	if !ok {
+		return nil, fmt.Errorf("no registered implementation of CDC sink producer %q", *producerImplementation)
+	}
+	return factory()
+}
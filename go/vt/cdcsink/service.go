@@ -0,0 +1,60 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cdcsink
+
+import (
+	"context"
+	"flag"
+
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/vtgate/vtgateconn"
+)
+
+var vtgateAddress = flag.String("cdc_sink_vtgate_address", "", "address of the vtgate the CDC sinks stream from. If set, the CDC sink service runs the CDC sinks declared in the topology")
+
+// Enabled returns true if the CDC sink service is configured.
+func Enabled() bool {
+	return *vtgateAddress != ""
+}
+
+// Init runs the CDC sink service once the process serves, if
+// -cdc_sink_vtgate_address is set. It runs alongside vtctld, or in its
+// own process.
+func Init(ts *topo.Server) {
+	if !Enabled() {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	servenv.OnTermSync(cancel)
+	servenv.OnRun(func() {
+		producer, err := NewProducer()
+		if err != nil {
+			log.Exitf("Cannot create the CDC sink producer: %v", err)
+		}
+		conn, err := vtgateconn.Dial(ctx, *vtgateAddress)
+		if err != nil {
+			log.Exitf("Cannot dial vtgate %v for the CDC sinks: %v", *vtgateAddress, err)
+		}
+		go func() {
+			defer producer.Close()
+			defer conn.Close()
+			NewManager(ts, conn, producer).Run(ctx)
+		}()
+	})
+}
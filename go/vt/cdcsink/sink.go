@@ -0,0 +1,299 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cdcsink
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/encoding/prototext"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vtgate/vtgateconn"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+var (
+	sinkMessages = stats.NewCountersWithSingleLabel("CDCSinkMessages", "Number of row changes published by the CDC sinks", "sink")
+	sinkCommits  = stats.NewCountersWithSingleLabel("CDCSinkCommits", "Number of offsets committed by the CDC sinks", "sink")
+	sinkErrors   = stats.NewCountersWithSingleLabel("CDCSinkErrors", "Number of errors which restarted the CDC sinks", "sink")
+)
+
+// idleCommitInterval is the minimum interval between the commits of the
+// offset of a sink for the transactions without row changes of its
+// tables.
+var idleCommitInterval = 1 * time.Minute
+
+// VStreamer streams the events of a keyspace. It is implemented by
+// vtgateconn.VTGateConn.
+type VStreamer interface {
+	VStream(ctx context.Context, tabletType topodatapb.TabletType, vgtid *binlogdatapb.VGtid, filter *binlogdatapb.Filter, flags *vtgatepb.VStreamFlags) (vtgateconn.VStreamReader, error)
+}
+
+// RowChange is the value of the messages published by the sinks, as
+// JSON. The values of the columns are strings, or nil for NULL.
+type RowChange struct {
+	Keyspace string `json:"keyspace"`
+	Table    string `json:"table"`
+	// Before is nil for an insert, and After for a delete.
+	Before map[string]interface{} `json:"before,omitempty"`
+	After  map[string]interface{} `json:"after,omitempty"`
+	// Timestamp is the time of the transaction, in seconds since epoch.
+	Timestamp int64 `json:"timestamp"`
+}
+
+// sink streams the row changes of the tables of a CDC sink, and publishes
+// them with their offset when their transaction commits.
+type sink struct {
+	keyspace string
+	config   *topodatapb.CDCSink
+	conn     VStreamer
+	producer Producer
+
+	// fields are the fields of the tables, by table name qualified by
+	// the keyspace.
+	fields map[string][]*querypb.Field
+	// pending are the messages of the current transaction, and vgtid its
+	// position once known.
+	pending    []*Message
+	vgtid      *binlogdatapb.VGtid
+	lastCommit time.Time
+}
+
+func newSink(keyspace string, config *topodatapb.CDCSink, conn VStreamer, producer Producer) *sink {
+	return &sink{
+		keyspace: keyspace,
+		config:   config,
+		conn:     conn,
+		producer: producer,
+	}
+}
+
+// name returns the name of the sink, under which the producer stores its
+// offset.
+func (s *sink) name() string {
+	return s.keyspace + "/" + s.config.Name
+}
+
+// stream streams the row changes from the last committed offset until an
+// error or ctx is done.
+func (s *sink) stream(ctx context.Context) error {
+	tabletType := topodatapb.TabletType_MASTER
+	if s.config.TabletType != "" {
+		var err error
+		if tabletType, err = topoproto.ParseTabletType(s.config.TabletType); err != nil {
+			return err
+		}
+	}
+	offset, err := s.producer.Offset(ctx, s.name())
+	if err != nil {
+		return err
+	}
+	vgtid, err := startVGtid(s.keyspace, offset)
+	if err != nil {
+		return err
+	}
+
+	reader, err := s.conn.VStream(ctx, tabletType, vgtid, s.filter(), &vtgatepb.VStreamFlags{})
+	if err != nil {
+		return err
+	}
+	s.fields = make(map[string][]*querypb.Field)
+	s.pending = nil
+	s.vgtid = nil
+	s.lastCommit = time.Now()
+	for {
+		events, err := reader.Recv()
+		switch {
+		case err == io.EOF:
+			return fmt.Errorf("vstream of %v ended", s.keyspace)
+		case err != nil:
+			return err
+		}
+		for _, ev := range events {
+			if err := s.handle(ctx, ev); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// startVGtid returns the position to stream from: the offset if any, or
+// the current position of all the shards of the keyspace.
+func startVGtid(keyspace, offset string) (*binlogdatapb.VGtid, error) {
+	if offset == "" {
+		return &binlogdatapb.VGtid{
+			ShardGtids: []*binlogdatapb.ShardGtid{{
+				Keyspace: keyspace,
+				Gtid:     "current",
+			}},
+		}, nil
+	}
+	vgtid := &binlogdatapb.VGtid{}
+	if err := prototext.Unmarshal([]byte(offset), vgtid); err != nil {
+		return nil, fmt.Errorf("bad offset %q: %v", offset, err)
+	}
+	return vgtid, nil
+}
+
+// filter returns the filter of the tables of the sink.
+func (s *sink) filter() *binlogdatapb.Filter {
+	if len(s.config.Tables) == 0 {
+		return &binlogdatapb.Filter{
+			Rules: []*binlogdatapb.Rule{{Match: "/.*"}},
+		}
+	}
+	filter := &binlogdatapb.Filter{}
+	for _, table := range s.config.Tables {
+		filter.Rules = append(filter.Rules, &binlogdatapb.Rule{
+			Match:  table,
+			Filter: fmt.Sprintf("select * from %v", table),
+		})
+	}
+	return filter
+}
+
+func (s *sink) handle(ctx context.Context, ev *binlogdatapb.VEvent) error {
+	switch ev.Type {
+	case binlogdatapb.VEventType_FIELD:
+		s.fields[ev.FieldEvent.TableName] = ev.FieldEvent.Fields
+	case binlogdatapb.VEventType_ROW:
+		fields, ok := s.fields[ev.RowEvent.TableName]
+		if !ok {
+			return fmt.Errorf("no fields for table %v", ev.RowEvent.TableName)
+		}
+		for _, rc := range ev.RowEvent.RowChanges {
+			msg, err := s.message(ev.RowEvent.TableName, fields, rc, ev.Timestamp)
+			if err != nil {
+				return err
+			}
+			s.pending = append(s.pending, msg)
+		}
+	case binlogdatapb.VEventType_VGTID:
+		s.vgtid = ev.Vgtid
+	case binlogdatapb.VEventType_COMMIT, binlogdatapb.VEventType_DDL, binlogdatapb.VEventType_OTHER:
+		return s.commit(ctx)
+	}
+	return nil
+}
+
+// message returns the message of a row change.
+func (s *sink) message(tableName string, fields []*querypb.Field, rc *binlogdatapb.RowChange, timestamp int64) (*Message, error) {
+	table := tableName
+	if i := strings.IndexByte(tableName, '.'); i >= 0 {
+		table = tableName[i+1:]
+	}
+	change := &RowChange{
+		Keyspace:  s.keyspace,
+		Table:     table,
+		Timestamp: timestamp,
+	}
+	key := table
+	if rc.Before != nil {
+		change.Before, key = rowValues(fields, rc.Before, table)
+	}
+	if rc.After != nil {
+		change.After, key = rowValues(fields, rc.After, table)
+	}
+	value, err := json.Marshal(change)
+	if err != nil {
+		return nil, err
+	}
+	return &Message{
+		Topic: s.config.Topic,
+		Key:   []byte(key),
+		Value: value,
+	}, nil
+}
+
+// rowValues returns the values of the row by column name, and its key:
+// the table name followed by the values of the primary key columns.
+func rowValues(fields []*querypb.Field, row *querypb.Row, table string) (map[string]interface{}, string) {
+	values := make(map[string]interface{}, len(fields))
+	key := []string{table}
+	for i, v := range sqltypes.MakeRowTrusted(fields, row) {
+		if i >= len(fields) {
+			break
+		}
+		if v.IsNull() {
+			values[fields[i].Name] = nil
+		} else {
+			values[fields[i].Name] = v.ToString()
+		}
+		if fields[i].Flags&uint32(querypb.MySqlFlag_PRI_KEY_FLAG) != 0 {
+			key = append(key, v.ToString())
+		}
+	}
+	return values, strings.Join(key, "/")
+}
+
+// commit publishes the messages of the transaction with its position as
+// the offset. The offset of a transaction without messages is only
+// committed every idleCommitInterval.
+func (s *sink) commit(ctx context.Context) error {
+	if s.vgtid == nil {
+		return nil
+	}
+	if len(s.pending) == 0 && time.Since(s.lastCommit) < idleCommitInterval {
+		return nil
+	}
+	offset, err := prototext.Marshal(s.vgtid)
+	if err != nil {
+		return err
+	}
+	if err := s.producer.Publish(ctx, s.name(), s.pending, string(offset)); err != nil {
+		return err
+	}
+	sinkMessages.Add(s.name(), int64(len(s.pending)))
+	sinkCommits.Add(s.name(), 1)
+	s.pending = nil
+	s.lastCommit = time.Now()
+	return nil
+}
+
+// Validate checks the declaration of the CDC sinks of a keyspace.
+func Validate(sinks []*topodatapb.CDCSink) error {
+	names := make(map[string]bool)
+	for _, config := range sinks {
+		if config.Name == "" {
+			return fmt.Errorf("a CDC sink has no name")
+		}
+		if names[config.Name] {
+			return fmt.Errorf("duplicate CDC sink %v", config.Name)
+		}
+		names[config.Name] = true
+		if config.Topic == "" {
+			return fmt.Errorf("CDC sink %v has no topic", config.Name)
+		}
+		if config.TabletType != "" {
+			if _, err := topoproto.ParseTabletType(config.TabletType); err != nil {
+				return fmt.Errorf("CDC sink %v: %v", config.Name, err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cdcsink
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+	"vitess.io/vitess/go/vt/vtgate/vtgateconn"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+// memoryProducer is a Producer which keeps the messages in memory.
+type memoryProducer struct {
+	mu       sync.Mutex
+	messages []*Message
+	offsets  map[string]string
+}
+
+func (mp *memoryProducer) Offset(ctx context.Context, sink string) (string, error) {
+	mp.mu.Lock()
+	defer mp.mu.Unlock()
+	return mp.offsets[sink], nil
+}
+
+func (mp *memoryProducer) Publish(ctx context.Context, sink string, messages []*Message, offset string) error {
+	mp.mu.Lock()
+	defer mp.mu.Unlock()
+	mp.messages = append(mp.messages, messages...)
+	if mp.offsets == nil {
+		mp.offsets = make(map[string]string)
+	}
+	mp.offsets[sink] = offset
+	return nil
+}
+
+func (mp *memoryProducer) Close() error {
+	return nil
+}
+
+// fakeVStreamer returns the batches of events, then io.EOF, or blocks
+// until ctx is done if block is set.
+type fakeVStreamer struct {
+	batches [][]*binlogdatapb.VEvent
+	block   bool
+
+	mu     sync.Mutex
+	vgtids []*binlogdatapb.VGtid
+}
+
+func (fv *fakeVStreamer) VStream(ctx context.Context, tabletType topodatapb.TabletType, vgtid *binlogdatapb.VGtid, filter *binlogdatapb.Filter, flags *vtgatepb.VStreamFlags) (vtgateconn.VStreamReader, error) {
+	fv.mu.Lock()
+	defer fv.mu.Unlock()
+	fv.vgtids = append(fv.vgtids, vgtid)
+	return &fakeReader{ctx: ctx, batches: fv.batches, block: fv.block}, nil
+}
+
+type fakeReader struct {
+	ctx     context.Context
+	batches [][]*binlogdatapb.VEvent
+	block   bool
+}
+
+func (fr *fakeReader) Recv() ([]*binlogdatapb.VEvent, error) {
+	if len(fr.batches) == 0 {
+		if fr.block {
+			<-fr.ctx.Done()
+			return nil, fr.ctx.Err()
+		}
+		return nil, io.EOF
+	}
+	batch := fr.batches[0]
+	fr.batches = fr.batches[1:]
+	return batch, nil
+}
+
+func TestSink(t *testing.T) {
+	fields := []*querypb.Field{
+		{Name: "id", Type: sqltypes.Int64, Flags: uint32(querypb.MySqlFlag_PRI_KEY_FLAG)},
+		{Name: "name", Type: sqltypes.VarChar},
+	}
+	row := func(name string) *querypb.Row {
+		return sqltypes.RowToProto3([]sqltypes.Value{sqltypes.NewInt64(1), sqltypes.NewVarChar(name)})
+	}
+	vgtid := func(gtid string) *binlogdatapb.VGtid {
+		return &binlogdatapb.VGtid{ShardGtids: []*binlogdatapb.ShardGtid{{Keyspace: "ks", Shard: "0", Gtid: gtid}}}
+	}
+	fv := &fakeVStreamer{batches: [][]*binlogdatapb.VEvent{{
+		{Type: binlogdatapb.VEventType_BEGIN},
+		{Type: binlogdatapb.VEventType_FIELD, FieldEvent: &binlogdatapb.FieldEvent{TableName: "ks.t1", Fields: fields}},
+		{Type: binlogdatapb.VEventType_ROW, Timestamp: 10, RowEvent: &binlogdatapb.RowEvent{TableName: "ks.t1", RowChanges: []*binlogdatapb.RowChange{
+			{After: row("a")},
+			{Before: row("a"), After: row("b")},
+		}}},
+		{Type: binlogdatapb.VEventType_VGTID, Vgtid: vgtid("MySQL56/gtid1")},
+		{Type: binlogdatapb.VEventType_COMMIT},
+	}, {
+		// A transaction without row changes of the tables of the sink
+		// doesn't commit its offset right away.
+		{Type: binlogdatapb.VEventType_BEGIN},
+		{Type: binlogdatapb.VEventType_VGTID, Vgtid: vgtid("MySQL56/gtid2")},
+		{Type: binlogdatapb.VEventType_COMMIT},
+	}}}
+	mp := &memoryProducer{}
+	s := newSink("ks", &topodatapb.CDCSink{Name: "audit", Topic: "audit", Tables: []string{"t1"}}, fv, mp)
+
+	err := s.stream(context.Background())
+	assert.EqualError(t, err, "vstream of ks ended")
+	require.Len(t, mp.messages, 2)
+	assert.Equal(t, "audit", mp.messages[0].Topic)
+	assert.Equal(t, "t1/1", string(mp.messages[0].Key))
+	change := &RowChange{}
+	require.NoError(t, json.Unmarshal(mp.messages[1].Value, change))
+	assert.Equal(t, &RowChange{
+		Keyspace:  "ks",
+		Table:     "t1",
+		Before:    map[string]interface{}{"id": "1", "name": "a"},
+		After:     map[string]interface{}{"id": "1", "name": "b"},
+		Timestamp: 10,
+	}, change)
+
+	// The sink restarts from the offset of the last commit.
+	fv.batches = nil
+	assert.Error(t, s.stream(context.Background()))
+	require.Len(t, fv.vgtids, 2)
+	assert.Equal(t, "current", fv.vgtids[0].ShardGtids[0].Gtid)
+	assert.True(t, proto.Equal(vgtid("MySQL56/gtid1"), fv.vgtids[1]), "got %v", fv.vgtids[1])
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, Validate([]*topodatapb.CDCSink{{Name: "a", Topic: "a"}, {Name: "b", Topic: "b", TabletType: "replica"}}))
+	assert.Error(t, Validate([]*topodatapb.CDCSink{{Topic: "a"}}))
+	assert.Error(t, Validate([]*topodatapb.CDCSink{{Name: "a", Topic: "a"}, {Name: "a", Topic: "b"}}))
+	assert.Error(t, Validate([]*topodatapb.CDCSink{{Name: "a"}}))
+	assert.Error(t, Validate([]*topodatapb.CDCSink{{Name: "a", Topic: "a", TabletType: "bad"}}))
+}
+
+func TestManager(t *testing.T) {
+	ctx := context.Background()
+	ts := memorytopo.NewServer("cell1")
+	require.NoError(t, ts.CreateKeyspace(ctx, "ks", &topodatapb.Keyspace{}))
+	m := NewManager(ts, &fakeVStreamer{block: true}, &memoryProducer{})
+	defer m.stopAll()
+
+	setSinks := func(sinks ...*topodatapb.CDCSink) {
+		t.Helper()
+		lockCtx, unlock, err := ts.LockKeyspace(ctx, "ks", "SetCDCSinks")
+		require.NoError(t, err)
+		defer unlock(&err)
+		ki, err := ts.GetKeyspace(lockCtx, "ks")
+		require.NoError(t, err)
+		ki.CdcSinks = sinks
+		require.NoError(t, ts.UpdateKeyspace(lockCtx, ki))
+	}
+
+	setSinks(&topodatapb.CDCSink{Name: "audit", Topic: "audit"})
+	require.NoError(t, m.refresh(ctx))
+	running := m.Running()
+	require.Len(t, running, 1)
+	assert.Equal(t, "audit", running["ks/audit"].Topic)
+
+	// A changed sink is restarted.
+	setSinks(&topodatapb.CDCSink{Name: "audit", Topic: "audit2"})
+	require.NoError(t, m.refresh(ctx))
+	assert.Equal(t, "audit2", m.Running()["ks/audit"].Topic)
+
+	// A removed sink is stopped.
+	setSinks()
+	require.NoError(t, m.refresh(ctx))
+	assert.Empty(t, m.Running())
+}
@@ -22,6 +22,7 @@ package dbconfigs
 // link with this library, so we should be safe.
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"flag"
@@ -37,6 +38,7 @@ import (
 
 	"vitess.io/vitess/go/mysql"
 	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/secrets"
 )
 
 var (
@@ -257,7 +259,9 @@ func readFromFile(filePath string) (string, error) {
 }
 
 // WithCredentials returns a copy of the provided ConnParams that we can use
-// to connect, after going through the CredentialsServer.
+// to connect, after going through the CredentialsServer. Passwords given
+// as secret:// references are resolved here, so that rotated secrets are
+// picked up by new connections.
 func withCredentials(cp *mysql.ConnParams) (*mysql.ConnParams, error) {
 	result := *cp
 	user, passwd, err := GetCredentialsServer().GetUserAndPassword(cp.Uname)
@@ -271,6 +275,12 @@ func withCredentials(cp *mysql.ConnParams) (*mysql.ConnParams, error) {
 		// things will just "work"
 		err = nil
 	}
+	if err != nil {
+		return &result, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), secrets.ResolveTimeout())
+	defer cancel()
+	result.Pass, err = secrets.Resolve(ctx, result.Pass)
 	return &result, err
 }
 
@@ -82,6 +82,7 @@ type DBConfigs struct {
 	ServerName                 string `json:"serverName,omitempty"`
 	ConnectTimeoutMilliseconds int    `json:"connectTimeoutMilliseconds,omitempty"`
 	DBName                     string `json:"dbName,omitempty"`
+	ServerPublicKey            string `json:"serverPublicKey,omitempty"`
 
 	App          UserConfig `json:"app,omitempty"`
 	Dba          UserConfig `json:"dba,omitempty"`
@@ -106,6 +107,9 @@ type UserConfig struct {
 	Password string `json:"password,omitempty"`
 	UseSSL   bool   `json:"useSsl,omitempty"`
 	UseTCP   bool   `json:"useTcp,omitempty"`
+	// AuthPlugin and Roles are the mysql.ConnParams of the same names.
+	AuthPlugin string `json:"authPlugin,omitempty"`
+	Roles      string `json:"roles,omitempty"`
 }
 
 // RegisterFlags registers the flags for the given DBConfigFlag.
@@ -132,6 +136,7 @@ func registerBaseFlags() {
 	flag.StringVar(&GlobalDBConfigs.SslKey, "db_ssl_key", "", "connection ssl key")
 	flag.StringVar(&GlobalDBConfigs.ServerName, "db_server_name", "", "server name of the DB we are connecting to.")
 	flag.IntVar(&GlobalDBConfigs.ConnectTimeoutMilliseconds, "db_connect_timeout_ms", 0, "connection timeout to mysqld in milliseconds (0 for no timeout)")
+	flag.StringVar(&GlobalDBConfigs.ServerPublicKey, "db_server_public_key", "", "path to the PEM file of the RSA public key of mysqld, used to send the password of the caching_sha2_password users over connections which are neither TLS nor a unix socket. If empty, the key is requested from mysqld.")
 }
 
 // The flags will change the global singleton
@@ -146,6 +151,8 @@ func registerPerUserFlags(userKey string, uc *UserConfig, cp *mysql.ConnParams)
 	flag.StringVar(&uc.Password, newPasswordFlag, "", "db "+userKey+" password")
 
 	flag.BoolVar(&uc.UseSSL, "db_"+userKey+"_use_ssl", true, "Set this flag to false to make the "+userKey+" connection to not use ssl")
+	flag.StringVar(&uc.AuthPlugin, "db_"+userKey+"_auth_plugin", "", "db "+userKey+" auth plugin to answer the handshake with: mysql_native_password or caching_sha2_password. Defaults to the default plugin of mysqld")
+	flag.StringVar(&uc.Roles, "db_"+userKey+"_roles", "", "db "+userKey+" MySQL 8.0 roles to activate on connect: ALL, DEFAULT, or a comma separated list of roles")
 
 	flag.StringVar(&cp.Host, "db-config-"+userKey+"-host", "", "deprecated: use db_host")
 	flag.IntVar(&cp.Port, "db-config-"+userKey+"-port", 0, "deprecated: use db_port")
@@ -362,6 +369,9 @@ func (dbcfgs *DBConfigs) InitWithSocket(defaultSocketFile string) {
 
 		cp.Uname = uc.User
 		cp.Pass = uc.Password
+		cp.AuthPlugin = uc.AuthPlugin
+		cp.Roles = uc.Roles
+		cp.ServerPublicKey = dbcfgs.ServerPublicKey
 		if uc.UseSSL {
 			cp.SslCa = dbcfgs.SslCa
 			cp.SslCaPath = dbcfgs.SslCaPath
@@ -405,6 +415,16 @@ func (dbcfgs *DBConfigs) getParams(userKey string, dbc *DBConfigs) (*UserConfig,
 	return uc, cp
 }
 
+// UserConfigs returns the configs of all the users, by user key.
+func (dbcfgs *DBConfigs) UserConfigs() map[string]*UserConfig {
+	userConfigs := make(map[string]*UserConfig, len(All))
+	for _, userKey := range All {
+		uc, _ := dbcfgs.getParams(userKey, dbcfgs)
+		userConfigs[userKey] = uc
+	}
+	return userConfigs
+}
+
 // SetDbParams sets the dba and app params
 func (dbcfgs *DBConfigs) SetDbParams(dbaParams, appParams mysql.ConnParams) {
 	dbcfgs.dbaParams = dbaParams
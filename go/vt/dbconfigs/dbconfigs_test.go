@@ -215,6 +215,32 @@ func TestUseTCP(t *testing.T) {
 	assert.Equal(t, want, dbConfigs.dbaParams)
 }
 
+func TestAuthPluginAndRoles(t *testing.T) {
+	dbConfigs := DBConfigs{
+		Host:            "a",
+		Port:            1,
+		ServerPublicKey: "/vt/mysqld_public_key.pem",
+		App: UserConfig{
+			User:       "app",
+			UseTCP:     true,
+			AuthPlugin: mysql.CachingSha2Password,
+			Roles:      "app_rw",
+		},
+	}
+	dbConfigs.InitWithSocket("default")
+
+	want := mysql.ConnParams{
+		Host:            "a",
+		Port:            1,
+		Uname:           "app",
+		AuthPlugin:      mysql.CachingSha2Password,
+		Roles:           "app_rw",
+		ServerPublicKey: "/vt/mysqld_public_key.pem",
+	}
+	assert.Equal(t, want, dbConfigs.appParams)
+	assert.Equal(t, "", dbConfigs.dbaParams.AuthPlugin)
+}
+
 func TestAccessors(t *testing.T) {
 	dbc := &DBConfigs{
 		appParams:      mysql.ConnParams{},
@@ -249,6 +275,13 @@ func TestAccessors(t *testing.T) {
 	if got, want := dbc.ReplConnector().connParams.DbName, ""; got != want {
 		t.Errorf("dbc.Repl().DbName: %v, want %v", got, want)
 	}
+	userConfigs := dbc.UserConfigs()
+	if got, want := len(userConfigs), len(All); got != want {
+		t.Errorf("len(dbc.UserConfigs()): %v, want %v", got, want)
+	}
+	if got, want := userConfigs[AllPrivs], &dbc.Allprivs; got != want {
+		t.Errorf("dbc.UserConfigs()[AllPrivs]: %p, want %p", got, want)
+	}
 }
 
 func TestCredentialsFileHUP(t *testing.T) {
@@ -85,9 +85,26 @@ func NewConnectionPool(name string, capacity int, idleTimeout time.Duration, dns
 	stats.NewGaugeDurationFunc(name+"IdleTimeout", "Connection pool idle timeout", cp.IdleTimeout)
 	stats.NewGaugeFunc(name+"IdleClosed", "Connection pool idle closed", cp.IdleClosed)
 	stats.NewCounterFunc(name+"Exhausted", "Number of times pool had zero available slots", cp.Exhausted)
+	pools.RegisterStats(statsType(name), name, cp)
 	return cp
 }
 
+// statsType returns the type of the pool in the stats of the pools.
+func statsType(name string) string {
+	switch name {
+	case "DbaConnPool":
+		return pools.StatsTypeDba
+	case "AppConnPool":
+		return pools.StatsTypeApp
+	default:
+		return pools.StatsTypeInternal
+	}
+}
+
+func (cp *ConnectionPool) logWait(start time.Time) {
+	pools.RecordWait(statsType(cp.name), cp.name, start)
+}
+
 func (cp *ConnectionPool) pool() (p *pools.ResourcePool) {
 	cp.mu.Lock()
 	p = cp.connections
@@ -144,7 +161,11 @@ func (cp *ConnectionPool) Open(info dbconfigs.Connector) {
 	cp.mu.Lock()
 	defer cp.mu.Unlock()
 	cp.info = info
-	cp.connections = pools.NewResourcePool(cp.connect, cp.capacity, cp.capacity, cp.idleTimeout, 0, nil)
+	var logWait func(time.Time)
+	if cp.name != "" {
+		logWait = cp.logWait
+	}
+	cp.connections = pools.NewResourcePool(cp.connect, cp.capacity, cp.capacity, cp.idleTimeout, 0, logWait)
 	// Check if we need to resolve a hostname (The Host is not just an IP  address).
 	if cp.resolutionFrequency > 0 && net.ParseIP(info.Host()) == nil {
 		cp.hostIsNotIP = true
@@ -75,13 +75,15 @@ func (fhc *FakeHealthCheck) WaitForAllServingTablets(ctx context.Context, target
 	return nil
 }
 
-// GetHealthyTabletStats returns only the healthy tablets - Serving true and LastError is not nil
+// GetHealthyTabletStats returns only the healthy tablets - Serving true and LastError is not nil,
+// and not drained unless they are masters
 func (fhc *FakeHealthCheck) GetHealthyTabletStats(target *querypb.Target) []*TabletHealth {
 	result := make([]*TabletHealth, 0)
 	fhc.mu.Lock()
 	defer fhc.mu.Unlock()
 	for _, item := range fhc.items {
-		if proto.Equal(item.ts.Target, target) && item.ts.Serving && item.ts.LastError == nil {
+		drained := target.TabletType != topodatapb.TabletType_MASTER && topoproto.IsTabletDrained(item.ts.Tablet)
+		if proto.Equal(item.ts.Target, target) && item.ts.Serving && item.ts.LastError == nil && !drained {
 			result = append(result, item.ts)
 		}
 	}
@@ -143,6 +145,15 @@ func (fhc *FakeHealthCheck) ReplaceTablet(old, new *topodatapb.Tablet) {
 	fhc.AddTablet(new)
 }
 
+// UpdateTablet updates the tablet record of a known tablet.
+func (fhc *FakeHealthCheck) UpdateTablet(tablet *topodatapb.Tablet) {
+	fhc.mu.Lock()
+	defer fhc.mu.Unlock()
+	if item, ok := fhc.items[TabletToMapKey(tablet)]; ok {
+		item.ts.Tablet = tablet
+	}
+}
+
 // TabletConnection returns the TabletConn of the given tablet.
 func (fhc *FakeHealthCheck) TabletConnection(alias *topodatapb.TabletAlias, target *querypb.Target) (queryservice.QueryService, error) {
 	aliasStr := topoproto.TabletAliasString(alias)
@@ -91,6 +91,7 @@ func (fhc *FakeLegacyHealthCheck) AddTablet(tablet *topodatapb.Tablet, name stri
 			Up:      true,
 			Name:    name,
 			Stats:   &querypb.RealtimeStats{},
+			Drained: topoproto.IsTabletDrained(tablet),
 		},
 	}
 
@@ -128,6 +129,22 @@ func (fhc *FakeLegacyHealthCheck) ReplaceTablet(old, new *topodatapb.Tablet, nam
 	fhc.AddTablet(new, name)
 }
 
+// UpdateTablet applies the drain state of the tablet.
+func (fhc *FakeLegacyHealthCheck) UpdateTablet(tablet *topodatapb.Tablet) {
+	fhc.mu.Lock()
+	defer fhc.mu.Unlock()
+	item, ok := fhc.items[TabletToMapKey(tablet)]
+	if !ok {
+		return
+	}
+	item.ts.Tablet = tablet
+	item.ts.Drained = topoproto.IsTabletDrained(tablet)
+
+	if fhc.listener != nil {
+		fhc.listener.StatsUpdate(item.ts)
+	}
+}
+
 // GetConnection returns the TabletConn of the given tablet.
 func (fhc *FakeLegacyHealthCheck) GetConnection(key string) queryservice.QueryService {
 	fhc.mu.RLock()
@@ -159,6 +159,10 @@ type TabletRecorder interface {
 	RemoveTablet(tablet *topodata.Tablet)
 	// ReplaceTablet does an AddTablet and RemoveTablet in one call, effectively replacing the old tablet with the new.
 	ReplaceTablet(old, new *topodata.Tablet)
+	// UpdateTablet is called when the record of a known tablet changed
+	// without a change of its address, e.g. of its drain state or of its
+	// traffic weight.
+	UpdateTablet(tablet *topodata.Tablet)
 }
 
 type keyspaceShardTabletType string
@@ -230,8 +234,27 @@ type HealthCheckImpl struct {
 	healthData map[keyspaceShardTabletType]map[tabletAliasString]*TabletHealth
 	// another map keyed by keyspace.shard.tabletType, this one containing a sorted list of TabletHealth
 	healthy map[keyspaceShardTabletType][]*TabletHealth
+	// drained contains the tablets which are drained. They are excluded
+	// from the healthy lists of non-master tablet types.
+	drained map[tabletAliasString]bool
+	// tablets contains the records of the tablets updated by UpdateTablet.
+	// They replace the records passed to AddTablet in the TabletHealth of
+	// the tablets, so that the gateways see their current traffic weight.
+	tablets map[tabletAliasString]*topodata.Tablet
+	// lagThresholds are the replication lag thresholds by keyspace, set by
+	// SetReplicationLagThresholds. They replace the lag flags for the
+	// healthy lists of the keyspaces which have some.
+	lagThresholds map[string]*topodata.ReplicationLagThresholds
+	// lagExcluded contains the number of tablets excluded by the lag
+	// thresholds from each healthy list computed with lagThresholds.
+	lagExcluded map[keyspaceShardTabletType]lagExclusions
 	// connsWG keeps track of all launched Go routines that monitor tablet connections.
 	connsWG sync.WaitGroup
+	// ctx and tabletFilter are used to create the topology watchers.
+	ctx          context.Context
+	tabletFilter TabletFilter
+	// twMu protects topoWatchers, which can change in SetCellsToWatch.
+	twMu sync.Mutex
 	// topology watchers that inform healthcheck of tablets being added and deleted
 	topoWatchers []*TopologyWatcher
 	// cellAliases is a cache of cell aliases
@@ -268,34 +291,25 @@ func NewHealthCheck(ctx context.Context, retryDelay, healthCheckTimeout time.Dur
 		healthByAlias:      make(map[tabletAliasString]*tabletHealthCheck),
 		healthData:         make(map[keyspaceShardTabletType]map[tabletAliasString]*TabletHealth),
 		healthy:            make(map[keyspaceShardTabletType][]*TabletHealth),
+		drained:            make(map[tabletAliasString]bool),
+		tablets:            make(map[tabletAliasString]*topodata.Tablet),
+		lagExcluded:        make(map[keyspaceShardTabletType]lagExclusions),
 		subscribers:        make(map[chan *TabletHealth]struct{}),
 		cellAliases:        make(map[string]string),
 	}
 	var topoWatchers []*TopologyWatcher
-	var filter TabletFilter
 	cells := strings.Split(cellsToWatch, ",")
 	if len(cells) == 0 {
 		cells = append(cells, localCell)
 	}
+	hc.ctx = ctx
+	hc.tabletFilter = newTabletFilterFromFlags()
 	for _, c := range cells {
 		log.Infof("Setting up healthcheck for cell: %v", c)
 		if c == "" {
 			continue
 		}
-		if len(TabletFilters) > 0 {
-			if len(KeyspacesToWatch) > 0 {
-				log.Exitf("Only one of -keyspaces_to_watch and -tablet_filters may be specified at a time")
-			}
-
-			fbs, err := NewFilterByShard(TabletFilters)
-			if err != nil {
-				log.Exitf("Cannot parse tablet_filters parameter: %v", err)
-			}
-			filter = fbs
-		} else if len(KeyspacesToWatch) > 0 {
-			filter = NewFilterByKeyspace(KeyspacesToWatch)
-		}
-		topoWatchers = append(topoWatchers, NewCellTabletsWatcher(ctx, topoServer, hc, filter, c, *RefreshInterval, *RefreshKnownTablets, *TopoReadConcurrency))
+		topoWatchers = append(topoWatchers, hc.newCellTabletsWatcher(c))
 	}
 
 	hc.topoWatchers = topoWatchers
@@ -311,6 +325,85 @@ func NewHealthCheck(ctx context.Context, retryDelay, healthCheckTimeout time.Dur
 	return hc
 }
 
+// newTabletFilterFromFlags returns the tablet filter configured by
+// -tablet_filters or -keyspaces_to_watch, if any.
+func newTabletFilterFromFlags() TabletFilter {
+	if len(TabletFilters) > 0 {
+		if len(KeyspacesToWatch) > 0 {
+			log.Exitf("Only one of -keyspaces_to_watch and -tablet_filters may be specified at a time")
+		}
+
+		fbs, err := NewFilterByShard(TabletFilters)
+		if err != nil {
+			log.Exitf("Cannot parse tablet_filters parameter: %v", err)
+		}
+		return fbs
+	} else if len(KeyspacesToWatch) > 0 {
+		return NewFilterByKeyspace(KeyspacesToWatch)
+	}
+	return nil
+}
+
+func (hc *HealthCheckImpl) newCellTabletsWatcher(cell string) *TopologyWatcher {
+	return NewCellTabletsWatcher(hc.ctx, hc.ts, hc, hc.tabletFilter, cell, *RefreshInterval, *RefreshKnownTablets, *TopoReadConcurrency)
+}
+
+// CellsToWatch returns the cells whose tablets are watched.
+func (hc *HealthCheckImpl) CellsToWatch() []string {
+	hc.twMu.Lock()
+	defer hc.twMu.Unlock()
+	cells := make([]string, 0, len(hc.topoWatchers))
+	for _, tw := range hc.topoWatchers {
+		cells = append(cells, tw.cell)
+	}
+	sort.Strings(cells)
+	return cells
+}
+
+// SetCellsToWatch changes the cells whose tablets are watched, given as a
+// comma separated list like -cells_to_watch. The watchers of the removed
+// cells are stopped and their tablets removed from the healthcheck, and
+// watchers are started for the new cells.
+func (hc *HealthCheckImpl) SetCellsToWatch(cellsToWatch string) error {
+	wanted := make(map[string]bool)
+	for _, c := range strings.Split(cellsToWatch, ",") {
+		if c = strings.TrimSpace(c); c != "" {
+			wanted[c] = true
+		}
+	}
+	if len(wanted) == 0 {
+		return fmt.Errorf("at least one cell must be watched")
+	}
+
+	hc.twMu.Lock()
+	var kept, removed []*TopologyWatcher
+	for _, tw := range hc.topoWatchers {
+		if wanted[tw.cell] {
+			kept = append(kept, tw)
+			delete(wanted, tw.cell)
+		} else {
+			removed = append(removed, tw)
+		}
+	}
+	for c := range wanted {
+		log.Infof("Setting up healthcheck for cell: %v", c)
+		tw := hc.newCellTabletsWatcher(c)
+		kept = append(kept, tw)
+		go tw.Start()
+	}
+	hc.topoWatchers = kept
+	hc.twMu.Unlock()
+
+	// RemoveAllTablets locks hc.mu, which must not be acquired while
+	// holding twMu.
+	for _, tw := range removed {
+		log.Infof("Stopping healthcheck for cell: %v", tw.cell)
+		tw.Stop()
+		tw.RemoveAllTablets()
+	}
+	return nil
+}
+
 // AddTablet adds the tablet, and starts health check.
 // It does not block on making connection.
 // name is an optional tag for the tablet, e.g. an alternative address.
@@ -349,6 +442,9 @@ func (hc *HealthCheckImpl) AddTablet(tablet *topodata.Tablet) {
 		return
 	}
 	hc.healthByAlias[tabletAliasString(tabletAlias)] = thc
+	if topoproto.IsTabletDrained(tablet) {
+		hc.drained[tabletAliasString(tabletAlias)] = true
+	}
 	res := thc.SimpleCopy()
 	if ths, ok := hc.healthData[key]; !ok {
 		hc.healthData[key] = make(map[tabletAliasString]*TabletHealth)
@@ -375,6 +471,49 @@ func (hc *HealthCheckImpl) ReplaceTablet(old, new *topodata.Tablet) {
 	hc.AddTablet(new)
 }
 
+// UpdateTablet applies the drain state and the traffic weight of the
+// tablet. A drained tablet is removed from the healthy lists so that no new
+// queries are sent to it, but its connection is kept so that the in-flight
+// queries can finish.
+func (hc *HealthCheckImpl) UpdateTablet(tablet *topodata.Tablet) {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+
+	tabletAlias := tabletAliasString(topoproto.TabletAliasString(tablet.Alias))
+	if _, ok := hc.healthByAlias[tabletAlias]; !ok {
+		return
+	}
+	hc.tablets[tabletAlias] = tablet
+	// The TabletHealth are shared with the callers of
+	// GetHealthyTabletStats, so they are replaced rather than modified.
+	for key, ths := range hc.healthData {
+		if th, ok := ths[tabletAlias]; ok && topoproto.TabletTrafficWeight(th.Tablet) != topoproto.TabletTrafficWeight(tablet) {
+			updated := *th
+			updated.Tablet = tablet
+			ths[tabletAlias] = &updated
+			if th.Target.TabletType != topodata.TabletType_MASTER {
+				hc.recomputeHealthy(key)
+			}
+		}
+	}
+	drained := topoproto.IsTabletDrained(tablet)
+	if hc.drained[tabletAlias] == drained {
+		return
+	}
+	if drained {
+		log.Infof("Draining tablet %v", tabletAlias)
+		hc.drained[tabletAlias] = true
+	} else {
+		log.Infof("Undraining tablet %v", tabletAlias)
+		delete(hc.drained, tabletAlias)
+	}
+	for key, ths := range hc.healthData {
+		if th, ok := ths[tabletAlias]; ok && th.Target.TabletType != topodata.TabletType_MASTER {
+			hc.recomputeHealthy(key)
+		}
+	}
+}
+
 func (hc *HealthCheckImpl) deleteTablet(tablet *topodata.Tablet) {
 	log.Infof("Removing tablet from healthcheck: %v", tablet)
 	hc.mu.Lock()
@@ -392,6 +531,8 @@ func (hc *HealthCheckImpl) deleteTablet(tablet *topodata.Tablet) {
 	// which will call finalizeConn, which will close the connection.
 	th.cancelFunc()
 	delete(hc.healthByAlias, tabletAlias)
+	delete(hc.drained, tabletAlias)
+	delete(hc.tablets, tabletAlias)
 	// delete from map by keyspace.shard.tabletType
 	ths, ok := hc.healthData[key]
 	if !ok {
@@ -413,6 +554,9 @@ func (hc *HealthCheckImpl) updateHealth(th *TabletHealth, prevTarget *query.Targ
 
 	tabletAlias := tabletAliasString(topoproto.TabletAliasString(th.Tablet.Alias))
 	targetKey := hc.keyFromTarget(th.Target)
+	if tablet, ok := hc.tablets[tabletAlias]; ok {
+		th.Tablet = tablet
+	}
 	targetChanged := prevTarget.TabletType != th.Target.TabletType || prevTarget.Keyspace != th.Target.Keyspace || prevTarget.Shard != th.Target.Shard
 	if targetChanged {
 		// Error counter has to be set here in case we get a new tablet type for the first time in a stream response
@@ -486,13 +630,44 @@ func (hc *HealthCheckImpl) updateHealth(th *TabletHealth, prevTarget *query.Targ
 func (hc *HealthCheckImpl) recomputeHealthy(key keyspaceShardTabletType) {
 	all := hc.healthData[key]
 	allArray := make([]*TabletHealth, 0, len(all))
-	for _, s := range all {
+	for alias, s := range all {
 		// Only tablets in same cell / cellAlias are included in healthy list.
-		if hc.isIncluded(s.Tablet.Type, s.Tablet.Alias) {
+		// Drained tablets are never included.
+		if hc.isIncluded(s.Tablet.Type, s.Tablet.Alias) && !hc.drained[alias] {
 			allArray = append(allArray, s)
 		}
 	}
-	hc.healthy[key] = FilterStatsByReplicationLag(allArray)
+	var threshold *topodata.ReplicationLagThreshold
+	for _, s := range all {
+		threshold = topoproto.ReplicationLagThresholdForTabletType(hc.lagThresholds[s.Target.Keyspace], topoproto.TabletTypeLString(s.Target.TabletType))
+		break
+	}
+	if threshold == nil {
+		delete(hc.lagExcluded, key)
+		hc.healthy[key] = FilterStatsByReplicationLag(allArray)
+		return
+	}
+	var excluded lagExclusions
+	hc.healthy[key], excluded = filterStatsByLagThreshold(allArray, threshold)
+	hc.lagExcluded[key] = excluded
+}
+
+// SetReplicationLagThresholds replaces the replication lag thresholds of
+// the keyspaces, and recomputes the healthy lists of non-master tablet
+// types with them.
+func (hc *HealthCheckImpl) SetReplicationLagThresholds(keyspaces map[string]*topodata.ReplicationLagThresholds) {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+
+	hc.lagThresholds = keyspaces
+	for key, ths := range hc.healthData {
+		for _, th := range ths {
+			if th.Target.TabletType != topodata.TabletType_MASTER {
+				hc.recomputeHealthy(key)
+			}
+			break
+		}
+	}
 }
 
 // Subscribe adds a listener. Used by vtgate buffer to learn about master changes.
@@ -563,9 +738,11 @@ func (hc *HealthCheckImpl) Close() error {
 	}
 	hc.healthByAlias = nil
 	hc.healthData = nil
+	hc.twMu.Lock()
 	for _, tw := range hc.topoWatchers {
 		tw.Stop()
 	}
+	hc.twMu.Unlock()
 	for s := range hc.subscribers {
 		close(s)
 	}
@@ -752,6 +929,8 @@ func (hc *HealthCheckImpl) isIncluded(tabletType topodata.TabletType, tabletAlia
 // topologyWatcherMaxRefreshLag returns the maximum lag since the watched
 // cells were refreshed from the topo server
 func (hc *HealthCheckImpl) topologyWatcherMaxRefreshLag() time.Duration {
+	hc.twMu.Lock()
+	defer hc.twMu.Unlock()
 	var lag time.Duration
 	for _, tw := range hc.topoWatchers {
 		cellLag := tw.RefreshLag()
@@ -764,6 +943,8 @@ func (hc *HealthCheckImpl) topologyWatcherMaxRefreshLag() time.Duration {
 
 // topologyWatcherChecksum returns a checksum of the topology watcher state
 func (hc *HealthCheckImpl) topologyWatcherChecksum() int64 {
+	hc.twMu.Lock()
+	defer hc.twMu.Unlock()
 	var checksum int64
 	for _, tw := range hc.topoWatchers {
 		checksum = checksum ^ int64(tw.TopoChecksum())
@@ -791,6 +972,12 @@ func (hc *HealthCheckImpl) RegisterStats() {
 		[]string{"Keyspace", "ShardName", "TabletType"},
 		hc.servingConnStats)
 
+	stats.NewGaugesFuncWithMultiLabels(
+		"HealthcheckLagExcludedTablets",
+		"the number of tablets excluded from serving by the replication lag thresholds of their keyspace",
+		[]string{"Keyspace", "ShardName", "TabletType", "Threshold"},
+		hc.lagExcludedStats)
+
 	stats.NewGaugeFunc(
 		"HealthcheckChecksum",
 		"crc32 checksum of the current healthcheck state",
@@ -835,6 +1022,19 @@ func (hc *HealthCheckImpl) servingConnStats() map[string]int64 {
 	return res
 }
 
+// lagExcludedStats returns the number of tablets excluded by the soft and
+// hard replication lag thresholds per keyspace/shard/tablet type.
+func (hc *HealthCheckImpl) lagExcludedStats() map[string]int64 {
+	res := make(map[string]int64)
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+	for key, excluded := range hc.lagExcluded {
+		res[string(key)+".soft"] = int64(excluded.soft)
+		res[string(key)+".hard"] = int64(excluded.hard)
+	}
+	return res
+}
+
 // stateChecksum returns a crc32 checksum of the healthcheck state
 func (hc *HealthCheckImpl) stateChecksum() int64 {
 	// CacheStatus is sorted so this should be stable across vtgates
@@ -28,6 +28,8 @@ import (
 	"testing"
 	"time"
 
+	"google.golang.org/protobuf/proto"
+
 	"vitess.io/vitess/go/vt/log"
 
 	"vitess.io/vitess/go/test/utils"
@@ -706,6 +708,139 @@ func TestRemoveTablet(t *testing.T) {
 	assert.Empty(t, a, "wrong result, expected empty list")
 }
 
+// TestDrainTablet tests that drained tablets are not returned as healthy.
+func TestDrainTablet(t *testing.T) {
+	ts := memorytopo.NewServer("cell")
+	hc := createTestHc(ts)
+	defer hc.Close()
+	tablet := createTestTablet(0, "cell", "a")
+	tablet.Type = topodatapb.TabletType_REPLICA
+	input := make(chan *querypb.StreamHealthResponse)
+	fc := createFakeConn(tablet, input)
+
+	resultChan := hc.Subscribe()
+	hc.AddTablet(tablet)
+	<-resultChan
+
+	target := &querypb.Target{Keyspace: "k", Shard: "s", TabletType: topodatapb.TabletType_REPLICA}
+	input <- &querypb.StreamHealthResponse{
+		TabletAlias:   tablet.Alias,
+		Target:        target,
+		Serving:       true,
+		RealtimeStats: &querypb.RealtimeStats{SecondsBehindMaster: 1, CpuUsage: 0.2},
+	}
+	<-resultChan
+	assert.Len(t, hc.GetHealthyTabletStats(target), 1)
+
+	// Drain: the tablet is not healthy anymore, but its connection is kept.
+	drained := proto.Clone(tablet).(*topodatapb.Tablet)
+	topoproto.SetTabletDrained(drained, true)
+	hc.UpdateTablet(drained)
+	assert.Empty(t, hc.GetHealthyTabletStats(target))
+	assert.False(t, fc.isCanceled(), "the connection of a drained tablet must not be closed")
+
+	// Health updates don't make it healthy again.
+	input <- &querypb.StreamHealthResponse{
+		TabletAlias:   tablet.Alias,
+		Target:        target,
+		Serving:       true,
+		RealtimeStats: &querypb.RealtimeStats{SecondsBehindMaster: 2, CpuUsage: 0.2},
+	}
+	<-resultChan
+	assert.Empty(t, hc.GetHealthyTabletStats(target))
+
+	// Undrain.
+	hc.UpdateTablet(tablet)
+	assert.Len(t, hc.GetHealthyTabletStats(target), 1)
+}
+
+// TestTabletTrafficWeight tests that the healthy tablets have the current
+// traffic weight of their tablet record.
+func TestTabletTrafficWeight(t *testing.T) {
+	ts := memorytopo.NewServer("cell")
+	hc := createTestHc(ts)
+	defer hc.Close()
+	tablet := createTestTablet(0, "cell", "a")
+	tablet.Type = topodatapb.TabletType_REPLICA
+	input := make(chan *querypb.StreamHealthResponse)
+	createFakeConn(tablet, input)
+
+	resultChan := hc.Subscribe()
+	hc.AddTablet(tablet)
+	<-resultChan
+
+	target := &querypb.Target{Keyspace: "k", Shard: "s", TabletType: topodatapb.TabletType_REPLICA}
+	shr := &querypb.StreamHealthResponse{
+		TabletAlias:   tablet.Alias,
+		Target:        target,
+		Serving:       true,
+		RealtimeStats: &querypb.RealtimeStats{SecondsBehindMaster: 1, CpuUsage: 0.2},
+	}
+	input <- shr
+	<-resultChan
+	healthy := hc.GetHealthyTabletStats(target)
+	require.Len(t, healthy, 1)
+	assert.Equal(t, topoproto.MaxTabletTrafficWeight, topoproto.TabletTrafficWeight(healthy[0].Tablet))
+
+	weighted := proto.Clone(tablet).(*topodatapb.Tablet)
+	require.NoError(t, topoproto.SetTabletTrafficWeight(weighted, 1))
+	hc.UpdateTablet(weighted)
+	healthy = hc.GetHealthyTabletStats(target)
+	require.Len(t, healthy, 1)
+	assert.Equal(t, 1, topoproto.TabletTrafficWeight(healthy[0].Tablet))
+
+	// Health updates keep the weight.
+	input <- shr
+	<-resultChan
+	healthy = hc.GetHealthyTabletStats(target)
+	require.Len(t, healthy, 1)
+	assert.Equal(t, 1, topoproto.TabletTrafficWeight(healthy[0].Tablet))
+}
+
+// TestReplicationLagThresholds tests that the replication lag thresholds
+// of a keyspace replace the lag flags for its healthy lists.
+func TestReplicationLagThresholds(t *testing.T) {
+	ts := memorytopo.NewServer("cell")
+	hc := createTestHc(ts)
+	defer hc.Close()
+	tablet := createTestTablet(0, "cell", "a")
+	tablet.Type = topodatapb.TabletType_REPLICA
+	input := make(chan *querypb.StreamHealthResponse)
+	createFakeConn(tablet, input)
+
+	resultChan := hc.Subscribe()
+	hc.AddTablet(tablet)
+	<-resultChan
+
+	target := &querypb.Target{Keyspace: "k", Shard: "s", TabletType: topodatapb.TabletType_REPLICA}
+	input <- &querypb.StreamHealthResponse{
+		TabletAlias:   tablet.Alias,
+		Target:        target,
+		Serving:       true,
+		RealtimeStats: &querypb.RealtimeStats{SecondsBehindMaster: 20, CpuUsage: 0.2},
+	}
+	<-resultChan
+	assert.Len(t, hc.GetHealthyTabletStats(target), 1)
+	assert.Empty(t, hc.lagExcludedStats())
+
+	// The thresholds of other tablet types don't apply.
+	hc.SetReplicationLagThresholds(map[string]*topodatapb.ReplicationLagThresholds{
+		"k": {TabletTypes: map[string]*topodatapb.ReplicationLagThreshold{"rdonly": {HardSeconds: 10}}},
+	})
+	assert.Len(t, hc.GetHealthyTabletStats(target), 1)
+	assert.Empty(t, hc.lagExcludedStats())
+
+	hc.SetReplicationLagThresholds(map[string]*topodatapb.ReplicationLagThresholds{
+		"k": {Default: &topodatapb.ReplicationLagThreshold{SoftSeconds: 5, HardSeconds: 10}},
+	})
+	assert.Empty(t, hc.GetHealthyTabletStats(target))
+	assert.Equal(t, map[string]int64{"k.s.replica.soft": 0, "k.s.replica.hard": 1}, hc.lagExcludedStats())
+
+	hc.SetReplicationLagThresholds(nil)
+	assert.Len(t, hc.GetHealthyTabletStats(target), 1)
+	assert.Empty(t, hc.lagExcludedStats())
+}
+
 // TestGetHealthyTablets tests the functionality of GetHealthyTabletStats.
 func TestGetHealthyTablets(t *testing.T) {
 	ts := memorytopo.NewServer("cell")
@@ -950,6 +1085,37 @@ func TestMasterInOtherCell(t *testing.T) {
 	mustMatch(t, want, a[0], "Expecting healthy master")
 }
 
+func TestSetCellsToWatch(t *testing.T) {
+	ctx := context.Background()
+	ts := memorytopo.NewServer("cell1", "cell2")
+	hc := NewHealthCheck(ctx, 1*time.Millisecond, time.Hour, ts, "cell1", "cell1")
+	defer hc.Close()
+	assert.Equal(t, []string{"cell1"}, hc.CellsToWatch())
+
+	tablet := createTestTablet(1, "cell2", "host1")
+	tablet.Type = topodatapb.TabletType_REPLICA
+	require.NoError(t, ts.CreateTablet(ctx, tablet))
+	createFakeConn(tablet, make(chan *querypb.StreamHealthResponse))
+	hasTablet := func() bool {
+		hc.mu.Lock()
+		defer hc.mu.Unlock()
+		_, ok := hc.healthByAlias[tabletAliasString(topoproto.TabletAliasString(tablet.Alias))]
+		return ok
+	}
+
+	// Turn up cell2.
+	require.NoError(t, hc.SetCellsToWatch("cell1,cell2"))
+	assert.Equal(t, []string{"cell1", "cell2"}, hc.CellsToWatch())
+	assert.Eventually(t, hasTablet, 5*time.Second, 10*time.Millisecond)
+
+	// Turn it down: its tablets are removed.
+	require.NoError(t, hc.SetCellsToWatch("cell1"))
+	assert.Equal(t, []string{"cell1"}, hc.CellsToWatch())
+	assert.False(t, hasTablet())
+
+	assert.Error(t, hc.SetCellsToWatch(" , "))
+}
+
 func TestReplicaInOtherCell(t *testing.T) {
 	ts := memorytopo.NewServer("cell1", "cell2")
 	hc := NewHealthCheck(context.Background(), 1*time.Millisecond, time.Hour, ts, "cell1", "cell1, cell2")
@@ -156,6 +156,8 @@ type LegacyTabletStats struct {
 	// LastError is the error we last saw when trying to get the
 	// tablet's healthcheck.
 	LastError error
+	// Drained is true if the tablet is drained: it gets no new queries.
+	Drained bool
 }
 
 // String is defined because we want to print a []*LegacyTabletStats array nicely.
@@ -174,6 +176,7 @@ func (e *LegacyTabletStats) DeepEqual(f *LegacyTabletStats) bool {
 		e.Serving == f.Serving &&
 		e.TabletExternallyReparentedTimestamp == f.TabletExternallyReparentedTimestamp &&
 		proto.Equal(e.Stats, f.Stats) &&
+		e.Drained == f.Drained &&
 		((e.LastError == nil && f.LastError == nil) ||
 			(e.LastError != nil && f.LastError != nil && e.LastError.Error() == f.LastError.Error()))
 }
@@ -270,6 +273,10 @@ type LegacyTabletRecorder interface {
 
 	// ReplaceTablet does an AddTablet and RemoveTablet in one call, effectively replacing the old tablet with the new.
 	ReplaceTablet(old, new *topodatapb.Tablet, name string)
+
+	// UpdateTablet applies the drain state of the tablet, without
+	// replacing its connection.
+	UpdateTablet(tablet *topodatapb.Tablet)
 }
 
 // LegacyHealthCheck defines the interface of health checking module.
@@ -369,6 +376,9 @@ type legacyTabletHealth struct {
 	conn queryservice.QueryService
 	// latestTabletStats stores the latest health stats of the tablet.
 	latestTabletStats LegacyTabletStats
+	// drained is the drain state of the tablet, applied to the health
+	// stats sent to the listener.
+	drained bool
 }
 
 // NewLegacyDefaultHealthCheck creates a new LegacyHealthCheck object with a default configuration.
@@ -484,6 +494,7 @@ func (hc *LegacyHealthCheckImpl) updateHealth(ts *LegacyTabletStats, conn querys
 		return
 	}
 	oldts := th.latestTabletStats
+	ts.Drained = th.drained
 	th.latestTabletStats = *ts
 	th.conn = conn
 	hc.mu.Unlock()
@@ -788,6 +799,7 @@ func (hc *LegacyHealthCheckImpl) AddTablet(tablet *topodatapb.Tablet, name strin
 	hc.addrToHealth[key] = &legacyTabletHealth{
 		cancelFunc:        cancelFunc,
 		latestTabletStats: hcc.tabletStats,
+		drained:           topoproto.IsTabletDrained(tablet),
 	}
 	hc.initialUpdatesWG.Add(1)
 	hc.connsWG.Add(1)
@@ -808,6 +820,35 @@ func (hc *LegacyHealthCheckImpl) ReplaceTablet(old, new *topodatapb.Tablet, name
 	hc.AddTablet(new, name)
 }
 
+// UpdateTablet applies the drain state of the tablet. A drained tablet is
+// removed from the healthy lists of the listener so that no new queries
+// are sent to it, but its connection is kept so that the in-flight queries
+// can finish.
+func (hc *LegacyHealthCheckImpl) UpdateTablet(tablet *topodatapb.Tablet) {
+	drained := topoproto.IsTabletDrained(tablet)
+	hc.mu.Lock()
+	th, ok := hc.addrToHealth[TabletToMapKey(tablet)]
+	if !ok || th.drained == drained {
+		hc.mu.Unlock()
+		return
+	}
+	th.drained = drained
+	th.latestTabletStats.Drained = drained
+	ts := th.latestTabletStats.Copy()
+	hc.mu.Unlock()
+
+	if drained {
+		log.Infof("Draining tablet %v", topoproto.TabletAliasString(tablet.Alias))
+	} else {
+		log.Infof("Undraining tablet %v", topoproto.TabletAliasString(tablet.Alias))
+	}
+	// Before its first health update, the target of the tablet is unknown:
+	// the drain state is sent with that update.
+	if hc.listener != nil && ts.Target.TabletType != topodatapb.TabletType_UNKNOWN {
+		hc.listener.StatsUpdate(ts)
+	}
+}
+
 // WaitForInitialStatsUpdates waits until all tablets added via AddTablet() call
 // were propagated to downstream via corresponding StatsUpdate() calls.
 func (hc *LegacyHealthCheckImpl) WaitForInitialStatsUpdates() {
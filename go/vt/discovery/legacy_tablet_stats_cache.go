@@ -17,6 +17,8 @@ limitations under the License.
 package discovery
 
 import (
+	"fmt"
+	"sort"
 	"sync"
 
 	"context"
@@ -219,7 +221,8 @@ func (tc *LegacyTabletStatsCache) StatsUpdate(ts *LegacyTabletStats) {
 			// We have an existing entry, and a new entry.
 			// Remember if they are both good (most common case).
 			trivialNonMasterUpdate = existing.LastError == nil && existing.Serving && ts.LastError == nil &&
-				ts.Serving && ts.Target.TabletType != topodatapb.TabletType_MASTER && existing.TrivialStatsUpdate(ts)
+				ts.Serving && ts.Target.TabletType != topodatapb.TabletType_MASTER && existing.Drained == ts.Drained &&
+				existing.TrivialStatsUpdate(ts)
 
 			// We already have the entry, update the
 			// values if necessary.  (will update both
@@ -257,8 +260,12 @@ func (tc *LegacyTabletStatsCache) StatsUpdate(ts *LegacyTabletStats) {
 		}
 
 		// Now we need to do some work. Recompute our healthy list.
+		// Drained tablets don't get new queries.
 		allArray = make([]*LegacyTabletStats, 0, len(e.all))
 		for _, s := range e.all {
+			if s.Drained {
+				continue
+			}
 			allArray = append(allArray, s)
 		}
 		e.healthy = FilterLegacyStatsByReplicationLag(allArray)
@@ -301,6 +308,64 @@ func (tc *LegacyTabletStatsCache) GetHealthyTabletStats(keyspace, shard string,
 	return result
 }
 
+// CacheStatus returns a displayable version of the cache, in the format
+// of HealthCheckImpl.CacheStatus, grouped by cell and target.
+func (tc *LegacyTabletStatsCache) CacheStatus() TabletsCacheStatusList {
+	tc.mu.RLock()
+	var entries []*legacyTabletStatsCacheEntry
+	for _, shards := range tc.entries {
+		for _, tabletTypes := range shards {
+			for _, e := range tabletTypes {
+				entries = append(entries, e)
+			}
+		}
+	}
+	tc.mu.RUnlock()
+
+	tcsMap := make(map[string]*TabletsCacheStatus)
+	for _, e := range entries {
+		e.mu.RLock()
+		for _, ts := range e.all {
+			th := legacyTabletStatsToHealth(ts)
+			key := fmt.Sprintf("%v.%v.%v.%v", th.Tablet.Alias.Cell, th.Target.Keyspace, th.Target.Shard, th.Target.TabletType.String())
+			tcs, ok := tcsMap[key]
+			if !ok {
+				tcs = &TabletsCacheStatus{
+					Cell:   th.Tablet.Alias.Cell,
+					Target: th.Target,
+				}
+				tcsMap[key] = tcs
+			}
+			tcs.TabletsStats = append(tcs.TabletsStats, th)
+		}
+		e.mu.RUnlock()
+	}
+
+	tcsl := make(TabletsCacheStatusList, 0, len(tcsMap))
+	for _, tcs := range tcsMap {
+		tcsl = append(tcsl, tcs)
+	}
+	sort.Sort(tcsl)
+	return tcsl
+}
+
+// legacyTabletStatsToHealth converts a LegacyTabletStats to a TabletHealth,
+// without a connection.
+func legacyTabletStatsToHealth(ts *LegacyTabletStats) *TabletHealth {
+	stats := ts.Stats
+	if stats == nil {
+		stats = &querypb.RealtimeStats{}
+	}
+	return &TabletHealth{
+		Tablet:              ts.Tablet,
+		Target:              ts.Target,
+		Stats:               stats,
+		MasterTermStartTime: ts.TabletExternallyReparentedTimestamp,
+		LastError:           ts.LastError,
+		Serving:             ts.Up && ts.Serving,
+	}
+}
+
 // ResetForTesting is for use in tests only.
 func (tc *LegacyTabletStatsCache) ResetForTesting() {
 	tc.mu.Lock()
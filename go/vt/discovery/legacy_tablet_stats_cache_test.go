@@ -18,8 +18,12 @@ package discovery
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
 	"vitess.io/vitess/go/vt/log"
 
 	"vitess.io/vitess/go/vt/topo"
@@ -278,3 +282,96 @@ func TestLegacyTabletStatsCache(t *testing.T) {
 		t.Errorf("unexpected result: %v", a)
 	}
 }
+
+func TestLegacyTabletStatsCacheStatus(t *testing.T) {
+	ts := memorytopo.NewServer("cell")
+	tsc := &LegacyTabletStatsCache{
+		cell:        "cell",
+		ts:          ts,
+		entries:     make(map[string]map[string]map[topodatapb.TabletType]*legacyTabletStatsCacheEntry),
+		cellAliases: make(map[string]string),
+	}
+	assert.Empty(t, tsc.CacheStatus())
+
+	replica := &LegacyTabletStats{
+		Key:     "t1",
+		Tablet:  topo.NewTablet(10, "cell", "host1"),
+		Target:  &querypb.Target{Keyspace: "k", Shard: "s", TabletType: topodatapb.TabletType_REPLICA},
+		Up:      true,
+		Serving: true,
+		Stats:   &querypb.RealtimeStats{SecondsBehindMaster: 1},
+	}
+	master := &LegacyTabletStats{
+		Key:                                 "t2",
+		Tablet:                              topo.NewTablet(11, "cell", "host2"),
+		Target:                              &querypb.Target{Keyspace: "k", Shard: "s", TabletType: topodatapb.TabletType_MASTER},
+		Up:                                  true,
+		Serving:                             false,
+		TabletExternallyReparentedTimestamp: 10,
+		LastError:                           fmt.Errorf("health check failed"),
+	}
+	tsc.StatsUpdate(replica)
+	tsc.StatsUpdate(master)
+
+	tcsl := tsc.CacheStatus()
+	require.Len(t, tcsl, 2)
+	// Sorted by cell and target.
+	assert.Equal(t, topodatapb.TabletType_MASTER, tcsl[0].Target.TabletType)
+	assert.Equal(t, "cell", tcsl[0].Cell)
+	require.Len(t, tcsl[0].TabletsStats, 1)
+	assert.False(t, tcsl[0].TabletsStats[0].Serving)
+	assert.EqualError(t, tcsl[0].TabletsStats[0].LastError, "health check failed")
+	assert.EqualValues(t, 10, tcsl[0].TabletsStats[0].MasterTermStartTime)
+	assert.NotNil(t, tcsl[0].TabletsStats[0].Stats)
+
+	assert.Equal(t, topodatapb.TabletType_REPLICA, tcsl[1].Target.TabletType)
+	require.Len(t, tcsl[1].TabletsStats, 1)
+	assert.True(t, tcsl[1].TabletsStats[0].Serving)
+	assert.EqualValues(t, 1, tcsl[1].TabletsStats[0].Stats.SecondsBehindMaster)
+	assert.Contains(t, string(tcsl[1].StatusAsHTML()), "RepLag: 1")
+}
+
+func TestLegacyTabletStatsCacheDrained(t *testing.T) {
+	ts := memorytopo.NewServer("cell")
+	tsc := &LegacyTabletStatsCache{
+		cell:        "cell",
+		ts:          ts,
+		entries:     make(map[string]map[string]map[topodatapb.TabletType]*legacyTabletStatsCacheEntry),
+		cellAliases: make(map[string]string),
+	}
+
+	replica1 := &LegacyTabletStats{
+		Key:     "t1",
+		Tablet:  topo.NewTablet(10, "cell", "host1"),
+		Target:  &querypb.Target{Keyspace: "k", Shard: "s", TabletType: topodatapb.TabletType_REPLICA},
+		Up:      true,
+		Serving: true,
+		Stats:   &querypb.RealtimeStats{SecondsBehindMaster: 1},
+	}
+	replica2 := &LegacyTabletStats{
+		Key:     "t2",
+		Tablet:  topo.NewTablet(11, "cell", "host2"),
+		Target:  &querypb.Target{Keyspace: "k", Shard: "s", TabletType: topodatapb.TabletType_REPLICA},
+		Up:      true,
+		Serving: true,
+		Stats:   &querypb.RealtimeStats{SecondsBehindMaster: 1},
+	}
+	tsc.StatsUpdate(replica1)
+	tsc.StatsUpdate(replica2)
+	assert.Len(t, tsc.GetHealthyTabletStats("k", "s", topodatapb.TabletType_REPLICA), 2)
+
+	// A drained tablet isn't healthy, but is still known.
+	drained := replica1.Copy()
+	drained.Drained = true
+	tsc.StatsUpdate(drained)
+	healthy := tsc.GetHealthyTabletStats("k", "s", topodatapb.TabletType_REPLICA)
+	require.Len(t, healthy, 1)
+	assert.Equal(t, "t2", healthy[0].Key)
+	assert.Len(t, tsc.GetTabletStats("k", "s", topodatapb.TabletType_REPLICA), 2)
+
+	// Undraining it makes it healthy again.
+	undrained := drained.Copy()
+	undrained.Drained = false
+	tsc.StatsUpdate(undrained)
+	assert.Len(t, tsc.GetHealthyTabletStats("k", "s", topodatapb.TabletType_REPLICA), 2)
+}
@@ -236,6 +236,11 @@ func (tw *LegacyTopologyWatcher) loadTablets() {
 			replacedTablets[alias] = newVal
 			tw.tr.ReplaceTablet(val.tablet, newVal.tablet, alias)
 			topologyWatcherOperations.Add(topologyWatcherOpReplaceTablet, 1)
+		} else if topoproto.IsTabletDrained(val.tablet) != topoproto.IsTabletDrained(newVal.tablet) {
+			// The tablet was drained or undrained: keep its connection
+			// so that in-flight queries can finish.
+			tw.tr.UpdateTablet(newVal.tablet)
+			topologyWatcherOperations.Add(topologyWatcherOpUpdateTablet, 1)
 		}
 	}
 
@@ -289,6 +294,23 @@ func (tw *LegacyTopologyWatcher) Stop() {
 	tw.wg.Wait()
 }
 
+// Cell returns the cell whose tablets are watched.
+func (tw *LegacyTopologyWatcher) Cell() string {
+	return tw.cell
+}
+
+// RemoveAllTablets removes all the tablets of the watcher from its
+// LegacyTabletRecorder. It must be called after Stop.
+func (tw *LegacyTopologyWatcher) RemoveAllTablets() {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	for _, val := range tw.tablets {
+		tw.tr.RemoveTablet(val.tablet)
+		topologyWatcherOperations.Add(topologyWatcherOpRemoveTablet, 1)
+	}
+	tw.tablets = make(map[string]*legacyTabletInfo)
+}
+
 // RefreshLag returns the time since the last refresh
 func (tw *LegacyTopologyWatcher) RefreshLag() time.Duration {
 	tw.mu.Lock()
@@ -378,6 +400,13 @@ func (fbs *LegacyFilterByShard) ReplaceTablet(old, new *topodatapb.Tablet, name
 	}
 }
 
+// UpdateTablet is part of the LegacyTabletRecorder interface.
+func (fbs *LegacyFilterByShard) UpdateTablet(tablet *topodatapb.Tablet) {
+	if fbs.isIncluded(tablet) {
+		fbs.tr.UpdateTablet(tablet)
+	}
+}
+
 // isIncluded returns true iff the tablet's keyspace and shard should be
 // forwarded to the underlying LegacyTabletRecorder.
 func (fbs *LegacyFilterByShard) isIncluded(tablet *topodatapb.Tablet) bool {
@@ -449,6 +478,13 @@ func (fbk *LegacyFilterByKeyspace) ReplaceTablet(old *topodatapb.Tablet, new *to
 	}
 }
 
+// UpdateTablet is part of the LegacyTabletRecorder interface.
+func (fbk *LegacyFilterByKeyspace) UpdateTablet(tablet *topodatapb.Tablet) {
+	if fbk.isIncluded(tablet) {
+		fbk.tr.UpdateTablet(tablet)
+	}
+}
+
 // isIncluded returns true if the tablet's keyspace should be
 // forwarded to the underlying LegacyTabletRecorder.
 func (fbk *LegacyFilterByKeyspace) isIncluded(tablet *topodatapb.Tablet) bool {
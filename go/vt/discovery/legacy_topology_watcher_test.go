@@ -23,12 +23,15 @@ import (
 
 	"context"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
 
 	"vitess.io/vitess/go/vt/logutil"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/topo/memorytopo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
 )
 
 func checkLegacyOpCounts(t *testing.T, tw *LegacyTopologyWatcher, prevCounts, deltas map[string]int64) map[string]int64 {
@@ -488,3 +491,41 @@ func TestLegacyFilterByKeyspace(t *testing.T) {
 		}
 	}
 }
+
+func TestLegacyTopologyWatcherDrainTablet(t *testing.T) {
+	ts := memorytopo.NewServer("aa")
+	fhc := NewFakeLegacyHealthCheck()
+	topologyWatcherOperations.ZeroAll()
+	counts := topologyWatcherOperations.Counts()
+	tw := NewLegacyCellTabletsWatcher(context.Background(), ts, fhc, "aa", 10*time.Minute, true, 5)
+	require.NoError(t, tw.WaitForInitialTopology())
+	counts = checkLegacyOpCounts(t, tw, counts, map[string]int64{"ListTablets": 1})
+
+	tablet := &topodatapb.Tablet{
+		Alias:    &topodatapb.TabletAlias{Cell: "aa", Uid: 0},
+		Hostname: "host1",
+		PortMap:  map[string]int32{"vt": 123},
+		Keyspace: "keyspace",
+		Shard:    "shard",
+		Type:     topodatapb.TabletType_REPLICA,
+	}
+	require.NoError(t, ts.CreateTablet(context.Background(), tablet))
+	tw.loadTablets()
+	counts = checkLegacyOpCounts(t, tw, counts, map[string]int64{"ListTablets": 1, "GetTablet": 1, "AddTablet": 1})
+
+	// Draining the tablet updates it in place.
+	_, err := ts.UpdateTabletFields(context.Background(), tablet.Alias, func(t *topodatapb.Tablet) error {
+		topoproto.SetTabletDrained(t, true)
+		return nil
+	})
+	require.NoError(t, err)
+	tw.loadTablets()
+	counts = checkLegacyOpCounts(t, tw, counts, map[string]int64{"ListTablets": 1, "GetTablet": 1, "UpdateTablet": 1})
+	assert.True(t, topoproto.IsTabletDrained(fhc.GetAllTablets()[TabletToMapKey(tablet)]))
+
+	// Nothing changed.
+	tw.loadTablets()
+	checkLegacyOpCounts(t, tw, counts, map[string]int64{"ListTablets": 1, "GetTablet": 1})
+
+	tw.Stop()
+}
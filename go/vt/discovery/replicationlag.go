@@ -21,6 +21,8 @@ import (
 	"fmt"
 	"sort"
 	"time"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 )
 
 var (
@@ -138,6 +140,55 @@ func filterStatsByLag(tabletHealthList []*TabletHealth) []*TabletHealth {
 	return res
 }
 
+// lagExclusions are the numbers of tablets excluded by the soft and hard
+// replication lag thresholds from a healthy list.
+type lagExclusions struct {
+	soft int
+	hard int
+}
+
+// filterStatsByLagThreshold filters the list of TabletHealth with the
+// replication lag thresholds of their keyspace and tablet type, like
+// filterStatsByLag does with the flags: tablets with lag above the hard
+// threshold are never returned, and tablets with lag above the soft
+// threshold are only returned, by increasing lag, to return at least
+// minNumTablets tablets. It also returns how many tablets each threshold
+// excluded.
+func filterStatsByLagThreshold(tabletHealthList []*TabletHealth, threshold *topodatapb.ReplicationLagThreshold) ([]*TabletHealth, lagExclusions) {
+	soft, hard := *lowReplicationLag, *highReplicationLagMinServing
+	if threshold.SoftSeconds > 0 {
+		soft = time.Duration(threshold.SoftSeconds) * time.Second
+	}
+	if threshold.HardSeconds > 0 {
+		hard = time.Duration(threshold.HardSeconds) * time.Second
+	}
+	var excluded lagExclusions
+	list := make([]tabletLagSnapshot, 0, len(tabletHealthList))
+	for _, ts := range tabletHealthList {
+		if !ts.Serving || ts.LastError != nil || ts.Stats == nil {
+			continue
+		}
+		if float64(ts.Stats.SecondsBehindMaster) > hard.Seconds() {
+			excluded.hard++
+			continue
+		}
+		list = append(list, tabletLagSnapshot{
+			ts:     ts,
+			replag: ts.Stats.SecondsBehindMaster})
+	}
+	sort.Sort(tabletLagSnapshotList(list))
+
+	res := make([]*TabletHealth, 0, len(list))
+	for i := 0; i < len(list); i++ {
+		if float64(list[i].replag) <= soft.Seconds() || i < *minNumTablets {
+			res = append(res, list[i].ts)
+		} else {
+			excluded.soft++
+		}
+	}
+	return res, excluded
+}
+
 func filterStatsByLagWithLegacyAlgorithm(tabletHealthList []*TabletHealth) []*TabletHealth {
 	list := make([]*TabletHealth, 0, len(tabletHealthList))
 	// filter non-serving tablets
@@ -23,6 +23,7 @@ import (
 	"vitess.io/vitess/go/test/utils"
 
 	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	"vitess.io/vitess/go/vt/topo"
 )
 
@@ -215,3 +216,73 @@ func TestFilterStatsByReplicationLagOneTabletMin(t *testing.T) {
 	// Reset to the default
 	testSetMinNumTablets(2)
 }
+
+func TestFilterStatsByLagThreshold(t *testing.T) {
+	cases := []struct {
+		description string
+		threshold   *topodatapb.ReplicationLagThreshold
+		input       []uint32
+		output      []uint32
+		excluded    lagExclusions
+	}{
+		{
+			"soft threshold of 5s - lags of (1s, 2s, 10s) return the first two.",
+			&topodatapb.ReplicationLagThreshold{SoftSeconds: 5},
+			[]uint32{10, 1, 2},
+			[]uint32{1, 2},
+			lagExclusions{soft: 1},
+		},
+		{
+			"soft threshold of 5s - lags of (1s, 10s, 20s) return at least 2 items.",
+			&topodatapb.ReplicationLagThreshold{SoftSeconds: 5},
+			[]uint32{1, 10, 20},
+			[]uint32{1, 10},
+			lagExclusions{soft: 1},
+		},
+		{
+			"hard threshold of 15s - lags of (1s, 10s, 20s) never return the last one.",
+			&topodatapb.ReplicationLagThreshold{SoftSeconds: 5, HardSeconds: 15},
+			[]uint32{1, 20, 10},
+			[]uint32{1, 10},
+			lagExclusions{hard: 1},
+		},
+		{
+			"hard threshold of 15s - lags of (20s, 30s) return nothing.",
+			&topodatapb.ReplicationLagThreshold{HardSeconds: 15},
+			[]uint32{20, 30},
+			[]uint32{},
+			lagExclusions{hard: 2},
+		},
+		{
+			"no threshold set - the flags apply.",
+			&topodatapb.ReplicationLagThreshold{},
+			[]uint32{1, 40, 40, 3 * 60 * 60},
+			[]uint32{1, 40},
+			lagExclusions{soft: 1, hard: 1},
+		},
+	}
+
+	for _, tc := range cases {
+		lts := make([]*TabletHealth, len(tc.input))
+		for i, lag := range tc.input {
+			lts[i] = &TabletHealth{
+				Tablet:  topo.NewTablet(uint32(i+1), "cell", fmt.Sprintf("host-%vs-behind", lag)),
+				Serving: true,
+				Stats:   &querypb.RealtimeStats{SecondsBehindMaster: lag},
+			}
+		}
+		got, excluded := filterStatsByLagThreshold(lts, tc.threshold)
+		if len(got) != len(tc.output) {
+			t.Errorf("filterStatsByLagThreshold(%v) failed: got output:\n%v\nExpected: %v", tc.description, got, tc.output)
+			continue
+		}
+		for i, elag := range tc.output {
+			if got[i].Stats.SecondsBehindMaster != elag {
+				t.Errorf("filterStatsByLagThreshold(%v) failed: got output:\n%v\nExpected value index %v to be %v", tc.description, got, i, elag)
+			}
+		}
+		if excluded != tc.excluded {
+			t.Errorf("filterStatsByLagThreshold(%v) failed: got exclusions %+v, expected %+v", tc.description, excluded, tc.excluded)
+		}
+	}
+}
@@ -25,8 +25,8 @@ import (
 
 	"vitess.io/vitess/go/sync2"
 
+	"vitess.io/vitess/go/vt/eventlog"
 	"vitess.io/vitess/go/vt/grpcclient"
-	"vitess.io/vitess/go/vt/log"
 	"vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/topo/topoproto"
 	"vitess.io/vitess/go/vt/topotools"
@@ -106,9 +106,15 @@ func (thc *tabletHealthCheck) SimpleCopy() *TabletHealth {
 // thc.mu must be locked before calling this function
 func (thc *tabletHealthCheck) setServingState(serving bool, reason string) {
 	if !thc.loggedServingState || (serving != thc.Serving) {
-		// Emit the log from a separate goroutine to avoid holding
-		// the th lock while logging is happening
-		log.Infof("HealthCheckUpdate(Serving State): tablet: %v serving %v => %v for %v/%v (%v) reason: %s",
+		// The changes are rate limited per tablet, so a flapping
+		// tablet doesn't flood the logs.
+		eventlog.Infof("healthcheck_serving_change", topoproto.TabletAliasString(thc.Tablet.Alias), eventlog.Fields{
+			"tablet":      topotools.TabletIdent(thc.Tablet),
+			"keyspace":    thc.Tablet.GetKeyspace(),
+			"shard":       thc.Tablet.GetShard(),
+			"tablet_type": thc.Target.GetTabletType().String(),
+			"serving":     serving,
+		}, "HealthCheckUpdate(Serving State): tablet: %v serving %v => %v for %v/%v (%v) reason: %s",
 			topotools.TabletIdent(thc.Tablet),
 			thc.Serving,
 			serving,
@@ -326,7 +332,9 @@ func (thc *tabletHealthCheck) checkConn(hc *HealthCheckImpl) {
 }
 
 func (thc *tabletHealthCheck) closeConnection(ctx context.Context, err error) {
-	log.Warningf("tablet %v healthcheck stream error: %v", thc.Tablet.Alias, err)
+	eventlog.Warningf("healthcheck_stream_error", topoproto.TabletAliasString(thc.Tablet.Alias), eventlog.Fields{
+		"error": err.Error(),
+	}, "tablet %v healthcheck stream error: %v", thc.Tablet.Alias, err)
 	thc.setServingState(false, err.Error())
 	thc.LastError = err
 	_ = thc.Conn.Close(ctx)
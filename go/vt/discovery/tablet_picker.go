@@ -18,6 +18,7 @@ package discovery
 
 import (
 	"fmt"
+	"io"
 	"math/rand"
 	"strings"
 	"sync"
@@ -29,12 +30,14 @@ import (
 
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 
+	"vitess.io/vitess/go/vt/vttablet/queryservice"
 	"vitess.io/vitess/go/vt/vttablet/tabletconn"
 
 	"vitess.io/vitess/go/vt/log"
 
 	"context"
 
+	querypb "vitess.io/vitess/go/vt/proto/query"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/vterrors"
@@ -67,6 +70,13 @@ type TabletPicker struct {
 	keyspace    string
 	shard       string
 	tabletTypes []topodatapb.TabletType
+
+	// maxReplicationLag is the maximum replication lag of the picked
+	// tablet, or 0 for no limit. If waitForCatchup is true and all the
+	// matching tablets are lagging, the picker waits for one of them to
+	// catch up instead of failing.
+	maxReplicationLag time.Duration
+	waitForCatchup    bool
 }
 
 // NewTabletPicker returns a TabletPicker.
@@ -98,6 +108,16 @@ func NewTabletPicker(ts *topo.Server, cells []string, keyspace, shard, tabletTyp
 	}, nil
 }
 
+// SetMaxReplicationLag makes the picker only pick a tablet whose
+// replication lag is at most maxLag, as reported by its health stream. If
+// no matching tablet is within maxLag, PickForStreaming fails, or waits
+// for one to catch up if waitForCatchup is true. A maxLag of 0 removes the
+// limit.
+func (tp *TabletPicker) SetMaxReplicationLag(maxLag time.Duration, waitForCatchup bool) {
+	tp.maxReplicationLag = maxLag
+	tp.waitForCatchup = waitForCatchup
+}
+
 // PickForStreaming picks an available tablet
 // All tablets that belong to tp.cells are evaluated and one is
 // chosen at random
@@ -126,6 +146,7 @@ func (tp *TabletPicker) PickForStreaming(ctx context.Context) (*topodatapb.Table
 			continue
 		}
 		// try at most len(candidate) times to find a healthy tablet
+		lagging := 0
 		for i := 0; i < len(candidates); i++ {
 			idx := rand.Intn(len(candidates))
 			ti := candidates[idx]
@@ -141,12 +162,76 @@ func (tp *TabletPicker) PickForStreaming(ctx context.Context) (*topodatapb.Table
 				}
 				continue
 			}
+			if tp.maxReplicationLag > 0 {
+				lag, err := replicationLag(ctx, conn)
+				if err == nil && lag > tp.maxReplicationLag {
+					err = fmt.Errorf("replication lag %v is above %v", lag, tp.maxReplicationLag)
+					lagging++
+				}
+				if err != nil {
+					log.Infof("tablet picker skipped tablet %v: %v", topoproto.TabletAliasString(ti.Alias), err)
+					_ = conn.Close(ctx)
+					candidates = append(candidates[:idx], candidates[idx+1:]...)
+					i--
+					continue
+				}
+			}
 			// OK to use ctx here because it is not actually used by the underlying Close implementation
 			_ = conn.Close(ctx)
 			log.Infof("tablet picker found tablet %s", ti.Tablet.String())
 			return ti.Tablet, nil
 		}
+		if lagging == 0 {
+			continue
+		}
+		tp.incLaggingTabletStat()
+		if !tp.waitForCatchup {
+			return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "no tablet of shard %s.%s in cells %v with tablet types %v has a replication lag below %v",
+				tp.keyspace, tp.shard, tp.cells, topoproto.MakeStringTypeList(tp.tabletTypes), tp.maxReplicationLag)
+		}
+		log.Infof("All the tablets of shard %s.%s, cells %v, tabletTypes %v are lagging, waiting %.3f seconds for them to catch up",
+			tp.keyspace, tp.shard, tp.cells, tp.tabletTypes, float64(GetTabletPickerRetryDelay().Milliseconds())/1000.0)
+		timer := time.NewTimer(GetTabletPickerRetryDelay())
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, vterrors.Errorf(vtrpcpb.Code_CANCELED, "context has expired")
+		case <-timer.C:
+		}
+	}
+}
+
+// TabletReplicationLag returns the replication lag of the tablet, as
+// reported by its health stream.
+func TabletReplicationLag(ctx context.Context, tablet *topodatapb.Tablet) (time.Duration, error) {
+	conn, err := tabletconn.GetDialer()(tablet, true)
+	if err != nil {
+		return 0, err
 	}
+	defer conn.Close(ctx)
+	return replicationLag(ctx, conn)
+}
+
+// replicationLag returns the replication lag from the first health
+// response of the tablet. It fails if the tablet is unhealthy.
+func replicationLag(ctx context.Context, conn queryservice.QueryService) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(ctx, *topo.RemoteOperationTimeout)
+	defer cancel()
+	var shr *querypb.StreamHealthResponse
+	err := conn.StreamHealth(ctx, func(response *querypb.StreamHealthResponse) error {
+		shr = response
+		return io.EOF
+	})
+	if err != nil && err != io.EOF {
+		return 0, err
+	}
+	if shr == nil || shr.RealtimeStats == nil {
+		return 0, fmt.Errorf("no health stats")
+	}
+	if shr.RealtimeStats.HealthError != "" {
+		return 0, fmt.Errorf("unhealthy: %v", shr.RealtimeStats.HealthError)
+	}
+	return time.Duration(shr.RealtimeStats.SecondsBehindMaster) * time.Second, nil
 }
 
 // GetMatchingTablets returns a list of TabletInfo for tablets
@@ -236,19 +321,30 @@ func init() {
 type tabletPickerStats struct {
 	mu                 sync.Mutex
 	noTabletFoundError *stats.CountersWithMultiLabels
+	laggingTablets     *stats.CountersWithMultiLabels
 }
 
 func newTabletPickerStats() *tabletPickerStats {
 	tpStats := &tabletPickerStats{}
 	tpStats.noTabletFoundError = stats.NewCountersWithMultiLabels("TabletPickerNoTabletFoundErrorCount", "", []string{"cells", "keyspace", "shard", "types"})
+	tpStats.laggingTablets = stats.NewCountersWithMultiLabels("TabletPickerLaggingTabletsCount", "Number of times all the matching tablets were above the maximum replication lag", []string{"cells", "keyspace", "shard", "types"})
 	return tpStats
 }
 
+func (tp *TabletPicker) statsLabels() []string {
+	cells := strings.Join(tp.cells, "_")
+	tabletTypes := strings.Join(topoproto.MakeStringTypeList(tp.tabletTypes), "_")
+	return []string{cells, tp.keyspace, tp.shard, tabletTypes}
+}
+
 func (tp *TabletPicker) incNoTabletFoundStat() {
 	globalTPStats.mu.Lock()
 	defer globalTPStats.mu.Unlock()
-	cells := strings.Join(tp.cells, "_")
-	tabletTypes := strings.Join(topoproto.MakeStringTypeList(tp.tabletTypes), "_")
-	labels := []string{cells, tp.keyspace, tp.shard, tabletTypes}
-	globalTPStats.noTabletFoundError.Add(labels, 1)
+	globalTPStats.noTabletFoundError.Add(tp.statsLabels(), 1)
+}
+
+func (tp *TabletPicker) incLaggingTabletStat() {
+	globalTPStats.mu.Lock()
+	defer globalTPStats.mu.Unlock()
+	globalTPStats.laggingTablets.Add(tp.statsLabels(), 1)
 }
@@ -332,6 +332,51 @@ func TestPickError(t *testing.T) {
 	require.Greater(t, globalTPStats.noTabletFoundError.Counts()["cell.ks.0.replica"], int64(0))
 }
 
+func TestPickMaxReplicationLag(t *testing.T) {
+	te := newPickerTestEnv(t, []string{"cell"})
+	lagging := addTablet(te, 100, topodatapb.TabletType_RDONLY, "cell", true, true)
+	defer deleteTablet(te, lagging)
+	setReplicationLag(te, lagging, 60)
+	want := addTablet(te, 101, topodatapb.TabletType_RDONLY, "cell", true, true)
+	defer deleteTablet(te, want)
+	setReplicationLag(te, want, 5)
+
+	tp, err := NewTabletPicker(te.topoServ, te.cells, te.keyspace, te.shard, "rdonly")
+	require.NoError(t, err)
+	tp.SetMaxReplicationLag(10*time.Second, false)
+	for i := 0; i < 10; i++ {
+		tablet, err := tp.PickForStreaming(context.Background())
+		require.NoError(t, err)
+		assert.True(t, proto.Equal(want, tablet), "Pick: %v, want %v", tablet, want)
+	}
+
+	// All the tablets are lagging.
+	setReplicationLag(te, want, 30)
+	_, err = tp.PickForStreaming(context.Background())
+	assert.EqualError(t, err, "no tablet of shard ks.0 in cells [cell] with tablet types [rdonly] has a replication lag below 10s")
+	assert.Greater(t, globalTPStats.laggingTablets.Counts()["cell.ks.0.rdonly"], int64(0))
+
+	// The picker waits for a tablet to catch up.
+	delay := GetTabletPickerRetryDelay()
+	defer func() {
+		SetTabletPickerRetryDelay(delay)
+	}()
+	SetTabletPickerRetryDelay(11 * time.Millisecond)
+	tp.SetMaxReplicationLag(10*time.Second, true)
+	result := make(chan *topodatapb.Tablet)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		tablet, err := tp.PickForStreaming(ctx)
+		assert.NoError(t, err)
+		result <- tablet
+	}()
+	time.Sleep(30 * time.Millisecond)
+	setReplicationLag(te, want, 0)
+	got := <-result
+	assert.True(t, proto.Equal(want, got), "Pick: %v, want %v", got, want)
+}
+
 type pickerTestEnv struct {
 	t        *testing.T
 	keyspace string
@@ -395,6 +440,20 @@ func addTablet(te *pickerTestEnv, id int, tabletType topodatapb.TabletType, cell
 	return tablet
 }
 
+// setReplicationLag makes the health stream of the tablet report a
+// replication lag of lagSeconds.
+func setReplicationLag(te *pickerTestEnv, tablet *topodatapb.Tablet, lagSeconds uint32) {
+	_ = createFixedHealthConn(tablet, &querypb.StreamHealthResponse{
+		Serving: true,
+		Target: &querypb.Target{
+			Keyspace:   te.keyspace,
+			Shard:      te.shard,
+			TabletType: tablet.Type,
+		},
+		RealtimeStats: &querypb.RealtimeStats{SecondsBehindMaster: lagSeconds},
+	})
+}
+
 func deleteTablet(te *pickerTestEnv, tablet *topodatapb.Tablet) {
 
 	if tablet == nil {
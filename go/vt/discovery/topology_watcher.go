@@ -45,11 +45,12 @@ const (
 	topologyWatcherOpAddTablet     = "AddTablet"
 	topologyWatcherOpRemoveTablet  = "RemoveTablet"
 	topologyWatcherOpReplaceTablet = "ReplaceTablet"
+	topologyWatcherOpUpdateTablet  = "UpdateTablet"
 )
 
 var (
 	topologyWatcherOperations = stats.NewCountersWithSingleLabel("TopologyWatcherOperations", "Topology watcher operation counts",
-		"Operation", topologyWatcherOpListTablets, topologyWatcherOpGetTablet, topologyWatcherOpAddTablet, topologyWatcherOpRemoveTablet, topologyWatcherOpReplaceTablet)
+		"Operation", topologyWatcherOpListTablets, topologyWatcherOpGetTablet, topologyWatcherOpAddTablet, topologyWatcherOpRemoveTablet, topologyWatcherOpReplaceTablet, topologyWatcherOpUpdateTablet)
 	topologyWatcherErrors = stats.NewCountersWithSingleLabel("TopologyWatcherErrors", "Topology watcher error counts",
 		"Operation", topologyWatcherOpListTablets, topologyWatcherOpGetTablet)
 )
@@ -145,6 +146,18 @@ func (tw *TopologyWatcher) Stop() {
 	tw.wg.Wait()
 }
 
+// RemoveAllTablets removes all the tablets of the watcher from its
+// TabletRecorder. It must be called after Stop.
+func (tw *TopologyWatcher) RemoveAllTablets() {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	for _, val := range tw.tablets {
+		tw.tabletRecorder.RemoveTablet(val.tablet)
+		topologyWatcherOperations.Add(topologyWatcherOpRemoveTablet, 1)
+	}
+	tw.tablets = make(map[string]*tabletInfo)
+}
+
 func (tw *TopologyWatcher) loadTablets() {
 	var wg sync.WaitGroup
 	newTablets := make(map[string]*tabletInfo)
@@ -228,6 +241,13 @@ func (tw *TopologyWatcher) loadTablets() {
 				// a different address key.
 				tw.tabletRecorder.ReplaceTablet(val.tablet, newVal.tablet)
 				topologyWatcherOperations.Add(topologyWatcherOpReplaceTablet, 1)
+			} else if topoproto.IsTabletDrained(val.tablet) != topoproto.IsTabletDrained(newVal.tablet) ||
+				topoproto.TabletTrafficWeight(val.tablet) != topoproto.TabletTrafficWeight(newVal.tablet) {
+				// The tablet was drained or undrained, or its traffic
+				// weight changed: keep its connection so that in-flight
+				// queries can finish.
+				tw.tabletRecorder.UpdateTablet(newVal.tablet)
+				topologyWatcherOperations.Add(topologyWatcherOpUpdateTablet, 1)
 			}
 		}
 	}
@@ -0,0 +1,343 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package eventlog logs structured events: events which have a type and
+fields, instead of free-form messages. It is used in the hot paths, e.g.
+the gateway retries or the healthcheck flaps, where a large or unstable
+cluster can log the same warning thousands of times.
+
+The events are written to the sinks of -structured_log_sinks: glog, syslog
+or a file of JSON lines. They are also streamed as JSON on /debug/structured_events.
+
+Each event type can be sampled, and rate limited per source of the events,
+e.g. per tablet, so a flapping tablet doesn't hide the events of the others.
+The events dropped by the rate limit are counted, and the count is reported
+with the next event of the same source which is logged.
+*/
+package eventlog
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/flagutil"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/streamlog"
+	"vitess.io/vitess/go/vt/log"
+)
+
+var (
+	sinksFlag        = flagutil.StringListValue{"glog"}
+	rateLimitsFlag   flagutil.StringMapValue
+	sampleRatesFlag  flagutil.StringMapValue
+	defaultRateLimit = flag.String("structured_log_default_rate_limit", "20/1m", "The maximum number of structured events of a type logged per source, e.g. per tablet, as <count>/<interval>, for the types without a -structured_log_rate_limits. Empty means no limit.")
+
+	eventsLogged     = stats.NewCountersWithMultiLabels("StructuredEvents", "Number of structured events logged, by type and severity", []string{"Type", "Severity"})
+	eventsSuppressed = stats.NewCountersWithSingleLabel("StructuredEventsSuppressed", "Number of structured events dropped by the sampling or the rate limits, by type", "Type")
+
+	// streamer streams the events on /debug/structured_events.
+	streamer = streamlog.New("StructuredEvents", 20)
+)
+
+func init() {
+	flag.Var(&sinksFlag, "structured_log_sinks", "Comma separated list of the sinks of the structured events: glog, syslog, or json:<file> to append them to a file as JSON lines.")
+	flag.Var(&rateLimitsFlag, "structured_log_rate_limits", "Comma separated list of <event type>:<count>/<interval> limiting the number of structured events of a type logged per source, e.g. healthcheck_serving_change:5/1m. 0 drops them all.")
+	flag.Var(&sampleRatesFlag, "structured_log_sample_rates", "Comma separated list of <event type>:<rate> sampling the structured events of a type before the rate limits, e.g. gateway_retry:0.01 logs 1% of them.")
+
+	streamer.ServeLogs("/debug/structured_events", func(out io.Writer, params url.Values, message interface{}) error {
+		data, err := json.Marshal(message)
+		if err != nil {
+			return err
+		}
+		_, err = out.Write(append(data, '\n'))
+		return err
+	})
+}
+
+// Severity is the severity of an event.
+type Severity int
+
+// The severities of the events.
+const (
+	Info Severity = iota
+	Warning
+	Error
+)
+
+// String is part of the fmt.Stringer interface.
+func (s Severity) String() string {
+	switch s {
+	case Info:
+		return "INFO"
+	case Warning:
+		return "WARNING"
+	case Error:
+		return "ERROR"
+	}
+	return strconv.Itoa(int(s))
+}
+
+// MarshalText is part of the encoding.TextMarshaler interface.
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// Fields are the structured data of an event.
+type Fields map[string]interface{}
+
+// Event is a structured event.
+type Event struct {
+	Time     time.Time `json:"time"`
+	Type     string    `json:"type"`
+	Severity Severity  `json:"severity"`
+	// Source identifies what the event is about, e.g. a tablet alias.
+	// The rate limits apply per source.
+	Source  string `json:"source,omitempty"`
+	Message string `json:"message"`
+	Fields  Fields `json:"fields,omitempty"`
+	// Suppressed is the number of events of the same type and source
+	// dropped by the rate limit since the previous one was logged.
+	Suppressed int64 `json:"suppressed,omitempty"`
+}
+
+// String formats the event as a single line of text, for glog and
+// syslog.
+func (ev *Event) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: %s", ev.Type, ev.Message)
+	keys := make([]string, 0, len(ev.Fields))
+	for key := range ev.Fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Fprintf(&b, " %s=%v", key, ev.Fields[key])
+	}
+	if ev.Suppressed > 0 {
+		fmt.Fprintf(&b, " (%d similar events suppressed)", ev.Suppressed)
+	}
+	return b.String()
+}
+
+// Infof logs an INFO event of the given type and source.
+func Infof(eventType, source string, fields Fields, format string, args ...interface{}) {
+	getLogger().log(Info, eventType, source, fields, format, args...)
+}
+
+// Warningf logs a WARNING event of the given type and source.
+func Warningf(eventType, source string, fields Fields, format string, args ...interface{}) {
+	getLogger().log(Warning, eventType, source, fields, format, args...)
+}
+
+// Errorf logs an ERROR event of the given type and source.
+func Errorf(eventType, source string, fields Fields, format string, args ...interface{}) {
+	getLogger().log(Error, eventType, source, fields, format, args...)
+}
+
+// rateLimit allows count events per interval.
+type rateLimit struct {
+	count    int
+	interval time.Duration
+}
+
+// parseRateLimit parses a <count>/<interval> rate limit. Empty means no
+// limit, which is returned as nil.
+func parseRateLimit(value string) (*rateLimit, error) {
+	if value == "" {
+		return nil, nil
+	}
+	parts := strings.SplitN(value, "/", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid rate limit %q: it must be <count>/<interval>", value)
+	}
+	count, err := strconv.Atoi(parts[0])
+	if err != nil || count < 0 {
+		return nil, fmt.Errorf("invalid count of rate limit %q", value)
+	}
+	interval, err := time.ParseDuration(parts[1])
+	if err != nil || interval <= 0 {
+		return nil, fmt.Errorf("invalid interval of rate limit %q", value)
+	}
+	return &rateLimit{count: count, interval: interval}, nil
+}
+
+// limiterKey identifies the events rate limited together.
+type limiterKey struct {
+	eventType, source string
+}
+
+// limiter counts the events of a type and source in the current window
+// of the rate limit.
+type limiter struct {
+	windowStart time.Time
+	logged      int
+	suppressed  int64
+}
+
+// maxLimiters bounds the number of limiters: if the sources are too many,
+// e.g. because they are not meant to be sources, the limiters are reset.
+const maxLimiters = 10000
+
+// logger routes the events to the sinks, after the sampling and the rate
+// limits.
+type logger struct {
+	sinks        []Sink
+	defaultLimit *rateLimit
+	limits       map[string]*rateLimit
+	sampleRates  map[string]float64
+	now          func() time.Time
+
+	mu       sync.Mutex
+	limiters map[limiterKey]*limiter
+}
+
+var (
+	defaultLogger *logger
+	initOnce      sync.Once
+)
+
+// getLogger returns the logger configured by the flags. The flags are
+// read on the first event, once they are parsed.
+func getLogger() *logger {
+	initOnce.Do(func() {
+		l, err := newLoggerFromFlags()
+		if err != nil {
+			log.Errorf("Invalid structured log configuration, logging the structured events to glog without limits: %v", err)
+			l = newLogger([]Sink{glogSink{}}, nil, nil, nil)
+		}
+		defaultLogger = l
+	})
+	return defaultLogger
+}
+
+func newLoggerFromFlags() (*logger, error) {
+	var sinks []Sink
+	for _, name := range sinksFlag {
+		sink, err := newSink(name)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+	defaultLimit, err := parseRateLimit(*defaultRateLimit)
+	if err != nil {
+		return nil, err
+	}
+	limits := make(map[string]*rateLimit)
+	for eventType, value := range rateLimitsFlag {
+		limit, err := parseRateLimit(value)
+		if err != nil {
+			return nil, fmt.Errorf("event type %v: %v", eventType, err)
+		}
+		limits[eventType] = limit
+	}
+	sampleRates := make(map[string]float64)
+	for eventType, value := range sampleRatesFlag {
+		rate, err := strconv.ParseFloat(value, 64)
+		if err != nil || rate < 0 || rate > 1 {
+			return nil, fmt.Errorf("invalid sample rate %q of event type %v: it must be between 0 and 1", value, eventType)
+		}
+		sampleRates[eventType] = rate
+	}
+	return newLogger(sinks, defaultLimit, limits, sampleRates), nil
+}
+
+func newLogger(sinks []Sink, defaultLimit *rateLimit, limits map[string]*rateLimit, sampleRates map[string]float64) *logger {
+	return &logger{
+		sinks:        sinks,
+		defaultLimit: defaultLimit,
+		limits:       limits,
+		sampleRates:  sampleRates,
+		now:          time.Now,
+		limiters:     make(map[limiterKey]*limiter),
+	}
+}
+
+func (l *logger) log(severity Severity, eventType, source string, fields Fields, format string, args ...interface{}) {
+	if rate, ok := l.sampleRates[eventType]; ok && rand.Float64() >= rate {
+		eventsSuppressed.Add(eventType, 1)
+		return
+	}
+	now := l.now()
+	suppressed, ok := l.allow(eventType, source, now)
+	if !ok {
+		eventsSuppressed.Add(eventType, 1)
+		return
+	}
+	ev := &Event{
+		Time:       now,
+		Type:       eventType,
+		Severity:   severity,
+		Source:     source,
+		Message:    fmt.Sprintf(format, args...),
+		Fields:     fields,
+		Suppressed: suppressed,
+	}
+	eventsLogged.Add([]string{eventType, severity.String()}, 1)
+	for _, sink := range l.sinks {
+		if err := sink.Write(ev); err != nil {
+			log.Errorf("Cannot write structured event %v: %v", ev.Type, err)
+		}
+	}
+	streamer.Send(ev)
+}
+
+// allow applies the rate limit of the event type to the source. It
+// returns whether the event can be logged, and if so, how many were
+// suppressed before it.
+func (l *logger) allow(eventType, source string, now time.Time) (int64, bool) {
+	limit, ok := l.limits[eventType]
+	if !ok {
+		limit = l.defaultLimit
+	}
+	if limit == nil {
+		return 0, true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	key := limiterKey{eventType: eventType, source: source}
+	lim, ok := l.limiters[key]
+	if !ok {
+		if len(l.limiters) >= maxLimiters {
+			l.limiters = make(map[limiterKey]*limiter)
+		}
+		lim = &limiter{windowStart: now}
+		l.limiters[key] = lim
+	}
+	if now.Sub(lim.windowStart) >= limit.interval {
+		lim.windowStart = now
+		lim.logged = 0
+	}
+	if lim.logged >= limit.count {
+		lim.suppressed++
+		return 0, false
+	}
+	lim.logged++
+	suppressed := lim.suppressed
+	lim.suppressed = 0
+	return suppressed, true
+}
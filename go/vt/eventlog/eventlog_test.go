@@ -0,0 +1,125 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventlog
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSink struct {
+	events []*Event
+}
+
+func (s *fakeSink) Write(ev *Event) error {
+	s.events = append(s.events, ev)
+	return nil
+}
+
+func TestParseRateLimit(t *testing.T) {
+	limit, err := parseRateLimit("5/1m")
+	require.NoError(t, err)
+	assert.Equal(t, &rateLimit{count: 5, interval: time.Minute}, limit)
+
+	limit, err = parseRateLimit("")
+	require.NoError(t, err)
+	assert.Nil(t, limit)
+
+	for _, value := range []string{"5", "x/1m", "-1/1m", "5/x", "5/0s"} {
+		_, err := parseRateLimit(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestRateLimits(t *testing.T) {
+	sink := &fakeSink{}
+	l := newLogger([]Sink{sink}, &rateLimit{count: 2, interval: time.Minute}, map[string]*rateLimit{
+		"unlimited": nil,
+		"dropped":   {count: 0, interval: time.Minute},
+	}, nil)
+	now := time.Now()
+	l.now = func() time.Time { return now }
+
+	for i := 0; i < 5; i++ {
+		l.log(Warning, "flap", "tablet1", Fields{"i": i}, "flap %d", i)
+	}
+	// Another source has its own limit.
+	l.log(Warning, "flap", "tablet2", nil, "flap")
+	for i := 0; i < 5; i++ {
+		l.log(Info, "unlimited", "", nil, "unlimited")
+		l.log(Info, "dropped", "", nil, "dropped")
+	}
+	require.Len(t, sink.events, 8)
+	assert.Equal(t, "flap 0", sink.events[0].Message)
+	assert.Equal(t, "flap 1", sink.events[1].Message)
+	assert.Equal(t, "tablet2", sink.events[2].Source)
+
+	// In the next window, the suppressed events are reported.
+	now = now.Add(time.Minute)
+	sink.events = nil
+	l.log(Warning, "flap", "tablet1", nil, "flap again")
+	require.Len(t, sink.events, 1)
+	assert.EqualValues(t, 3, sink.events[0].Suppressed)
+	assert.Equal(t, "flap: flap again (3 similar events suppressed)", sink.events[0].String())
+}
+
+func TestSampleRates(t *testing.T) {
+	sink := &fakeSink{}
+	l := newLogger([]Sink{sink}, nil, nil, map[string]float64{"never": 0, "always": 1})
+	for i := 0; i < 10; i++ {
+		l.log(Info, "never", "", nil, "never")
+		l.log(Info, "always", "", nil, "always")
+	}
+	assert.Len(t, sink.events, 10)
+}
+
+func TestEventString(t *testing.T) {
+	ev := &Event{Type: "gateway_retry", Message: "retrying", Fields: Fields{"shard": "-80", "keyspace": "ks"}}
+	assert.Equal(t, "gateway_retry: retrying keyspace=ks shard=-80", ev.String())
+}
+
+func TestJSONFileSink(t *testing.T) {
+	dir, err := ioutil.TempDir("", "eventlog")
+	require.NoError(t, err)
+	file := path.Join(dir, "events.json")
+
+	sink, err := newSink("json:" + file)
+	require.NoError(t, err)
+	l := newLogger([]Sink{sink}, nil, nil, nil)
+	l.log(Error, "schema_reload_error", "", Fields{"error": "boom"}, "reload failed")
+	l.log(Info, "other", "src", nil, "other")
+
+	data, err := ioutil.ReadFile(file)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 2)
+	var ev map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &ev))
+	assert.Equal(t, "schema_reload_error", ev["type"])
+	assert.Equal(t, "ERROR", ev["severity"])
+	assert.Equal(t, map[string]interface{}{"error": "boom"}, ev["fields"])
+
+	_, err = newSink("unknown")
+	assert.Error(t, err)
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventlog
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/syslog"
+	"os"
+	"strings"
+	"sync"
+
+	"vitess.io/vitess/go/vt/log"
+)
+
+// Sink writes the structured events somewhere.
+type Sink interface {
+	// Write writes an event. It is called synchronously by Infof,
+	// Warningf and Errorf, so it must be fast.
+	Write(ev *Event) error
+}
+
+// newSink creates the sink of a -structured_log_sinks entry.
+func newSink(name string) (Sink, error) {
+	switch {
+	case name == "glog":
+		return glogSink{}, nil
+	case name == "syslog":
+		writer, err := syslog.New(syslog.LOG_INFO|syslog.LOG_USER, os.Args[0])
+		if err != nil {
+			return nil, fmt.Errorf("cannot connect to syslog: %v", err)
+		}
+		return &syslogSink{writer: writer}, nil
+	case strings.HasPrefix(name, "json:"):
+		return newJSONFileSink(strings.TrimPrefix(name, "json:"))
+	}
+	return nil, fmt.Errorf("unknown structured log sink %q: it must be glog, syslog or json:<file>", name)
+}
+
+// glogCallerDepth is the depth of the caller of Infof, Warningf or
+// Errorf from glogSink.Write, so glog reports the line of the caller.
+const glogCallerDepth = 3
+
+// glogSink writes the events to glog, as text.
+type glogSink struct{}
+
+// Write is part of the Sink interface.
+func (glogSink) Write(ev *Event) error {
+	switch ev.Severity {
+	case Error:
+		log.ErrorDepth(glogCallerDepth, ev.String())
+	case Warning:
+		log.WarningDepth(glogCallerDepth, ev.String())
+	default:
+		log.InfoDepth(glogCallerDepth, ev.String())
+	}
+	return nil
+}
+
+// syslogSink writes the events to syslog, as text.
+type syslogSink struct {
+	writer *syslog.Writer
+}
+
+// Write is part of the Sink interface.
+func (s *syslogSink) Write(ev *Event) error {
+	switch ev.Severity {
+	case Error:
+		return s.writer.Err(ev.String())
+	case Warning:
+		return s.writer.Warning(ev.String())
+	default:
+		return s.writer.Info(ev.String())
+	}
+}
+
+// jsonFileSink appends the events to a file, as JSON lines.
+type jsonFileSink struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+func newJSONFileSink(path string) (*jsonFileSink, error) {
+	if path == "" {
+		return nil, fmt.Errorf("the file of the json structured log sink is missing")
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &jsonFileSink{file: file}, nil
+}
+
+// Write is part of the Sink interface.
+func (s *jsonFileSink) Write(ev *Event) error {
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.file.Write(append(data, '\n'))
+	return err
+}
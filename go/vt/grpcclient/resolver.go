@@ -0,0 +1,237 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcclient
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/resolver"
+
+	"vitess.io/vitess/go/netutil"
+	"vitess.io/vitess/go/vt/log"
+)
+
+// This file implements the resolution of the hostnames of the tablets by
+// a pluggable HostResolver, so that the hostnames in the topo can be
+// service names, e.g. of Kubernetes services, resolved when the tablets
+// are dialed rather than rewritten in the topo. The resolved addresses are
+// kept for their TTL, and resolved again once it expires, or when gRPC
+// fails to connect to them.
+
+var (
+	tabletHostnameResolver = flag.String("tablet_hostname_resolver", "", "if set, the hostnames of the tablets are resolved by this resolver when they are dialed, e.g. 'dns', and resolved again when their TTL expires or a connection to them fails")
+	tabletHostnameDNSTTL   = flag.Duration("tablet_hostname_dns_ttl", 30*time.Second, "how long the addresses resolved by the 'dns' tablet hostname resolver are kept")
+)
+
+const (
+	// tabletScheme is the gRPC scheme of the targets whose hostname is
+	// resolved by the HostResolver.
+	tabletScheme = "vttablet"
+	// resolveTimeout bounds a resolution.
+	resolveTimeout = 10 * time.Second
+	// minResolveInterval is the minimum interval between the resolutions of
+	// a hostname, so that failing connections don't flood the resolver.
+	minResolveInterval = 1 * time.Second
+	// resolveRetryInterval is the interval at which a failed resolution is
+	// retried, unless gRPC asks for it earlier.
+	resolveRetryInterval = 5 * time.Second
+)
+
+// HostResolver resolves the hostname of a tablet into the addresses to
+// connect to.
+type HostResolver interface {
+	// ResolveHost returns the IP addresses of the host, and how long
+	// they can be kept.
+	ResolveHost(ctx context.Context, host string) ([]string, time.Duration, error)
+}
+
+var (
+	hostResolversMu sync.Mutex
+	hostResolvers   = make(map[string]HostResolver)
+)
+
+// RegisterHostResolver registers a HostResolver, which is used if the
+// -tablet_hostname_resolver flag is set to its name.
+func RegisterHostResolver(name string, hr HostResolver) {
+	hostResolversMu.Lock()
+	defer hostResolversMu.Unlock()
+	if _, ok := hostResolvers[name]; ok {
+		log.Fatalf("HostResolver %s already exists", name)
+	}
+	hostResolvers[name] = hr
+}
+
+func getHostResolver(name string) (HostResolver, error) {
+	hostResolversMu.Lock()
+	defer hostResolversMu.Unlock()
+	hr, ok := hostResolvers[name]
+	if !ok {
+		return nil, fmt.Errorf("no tablet hostname resolver %q", name)
+	}
+	return hr, nil
+}
+
+// TabletTarget returns the target to dial for a port of a tablet. If the
+// -tablet_hostname_resolver flag is set, its hostname is resolved by the
+// HostResolver, otherwise it is dialed as is.
+func TabletTarget(hostname string, port int32) string {
+	addr := netutil.JoinHostPort(hostname, port)
+	if *tabletHostnameResolver == "" {
+		return addr
+	}
+	return tabletScheme + ":///" + addr
+}
+
+// dnsHostResolver resolves the hostnames with the DNS, and keeps the
+// addresses for -tablet_hostname_dns_ttl.
+type dnsHostResolver struct{}
+
+func (dnsHostResolver) ResolveHost(ctx context.Context, host string) ([]string, time.Duration, error) {
+	addrs, err := net.DefaultResolver.LookupHost(ctx, host)
+	return addrs, *tabletHostnameDNSTTL, err
+}
+
+// tabletResolverBuilder builds the gRPC resolvers of the tablet targets.
+type tabletResolverBuilder struct{}
+
+func (tabletResolverBuilder) Build(target resolver.Target, cc resolver.ClientConn, opts resolver.BuildOptions) (resolver.Resolver, error) {
+	hr, err := getHostResolver(*tabletHostnameResolver)
+	if err != nil {
+		return nil, err
+	}
+	host, port, err := netutil.SplitHostPort(target.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return newTabletResolver(hr, host, int32(port), cc), nil
+}
+
+func (tabletResolverBuilder) Scheme() string {
+	return tabletScheme
+}
+
+// tabletResolver resolves the hostname of a tablet target in the
+// background, and updates the gRPC connection with its addresses.
+type tabletResolver struct {
+	hr   HostResolver
+	host string
+	port int32
+	cc   resolver.ClientConn
+
+	ctx        context.Context
+	cancel     context.CancelFunc
+	resolveNow chan struct{}
+	wg         sync.WaitGroup
+}
+
+func newTabletResolver(hr HostResolver, host string, port int32, cc resolver.ClientConn) *tabletResolver {
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &tabletResolver{
+		hr:         hr,
+		host:       host,
+		port:       port,
+		cc:         cc,
+		ctx:        ctx,
+		cancel:     cancel,
+		resolveNow: make(chan struct{}, 1),
+	}
+	r.wg.Add(1)
+	go r.watch()
+	return r
+}
+
+// watch resolves the hostname, then again when the addresses expire or
+// gRPC asks for it, until the resolver is closed.
+func (r *tabletResolver) watch() {
+	defer r.wg.Done()
+	for {
+		start := time.Now()
+		ttl := r.resolve()
+
+		timer := time.NewTimer(ttl)
+		select {
+		case <-r.ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		case <-r.resolveNow:
+			timer.Stop()
+		}
+		if wait := minResolveInterval - time.Since(start); wait > 0 {
+			select {
+			case <-r.ctx.Done():
+				return
+			case <-time.After(wait):
+			}
+		}
+	}
+}
+
+// resolve resolves the hostname, updates the gRPC connection with its
+// addresses, and returns when to resolve it again.
+func (r *tabletResolver) resolve() time.Duration {
+	ctx, cancel := context.WithTimeout(r.ctx, resolveTimeout)
+	defer cancel()
+	ips, ttl, err := r.hr.ResolveHost(ctx, r.host)
+	if err == nil && len(ips) == 0 {
+		err = fmt.Errorf("no address for tablet hostname %v", r.host)
+	}
+	if err != nil {
+		if r.ctx.Err() == nil {
+			log.Warningf("Cannot resolve tablet hostname %v: %v", r.host, err)
+			r.cc.ReportError(err)
+		}
+		return resolveRetryInterval
+	}
+
+	addrs := make([]resolver.Address, len(ips))
+	for i, ip := range ips {
+		// The hostname remains the authority of the connection, e.g. to
+		// verify the certificate of the tablet.
+		addrs[i] = resolver.Address{Addr: netutil.JoinHostPort(ip, r.port), ServerName: r.host}
+	}
+	r.cc.UpdateState(resolver.State{Addresses: addrs})
+	if ttl < minResolveInterval {
+		ttl = minResolveInterval
+	}
+	return ttl
+}
+
+// ResolveNow is part of the resolver.Resolver interface. gRPC calls it
+// when a connection fails.
+func (r *tabletResolver) ResolveNow(resolver.ResolveNowOptions) {
+	select {
+	case r.resolveNow <- struct{}{}:
+	default:
+	}
+}
+
+// Close is part of the resolver.Resolver interface.
+func (r *tabletResolver) Close() {
+	r.cancel()
+	r.wg.Wait()
+}
+
+func init() {
+	RegisterHostResolver("dns", dnsHostResolver{})
+	resolver.Register(tabletResolverBuilder{})
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcclient
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/resolver"
+)
+
+// fakeHostResolver returns the addresses of its hosts, and counts its
+// resolutions.
+type fakeHostResolver struct {
+	mu    sync.Mutex
+	addrs map[string][]string
+	calls int
+}
+
+func (f *fakeHostResolver) ResolveHost(ctx context.Context, host string) ([]string, time.Duration, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	addrs, ok := f.addrs[host]
+	if !ok {
+		return nil, 0, fmt.Errorf("unknown host %v", host)
+	}
+	return addrs, time.Hour, nil
+}
+
+func (f *fakeHostResolver) set(host string, addrs ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.addrs[host] = addrs
+}
+
+// fakeClientConn records the states and the errors of a resolver.
+type fakeClientConn struct {
+	resolver.ClientConn
+	states chan resolver.State
+	errors chan error
+}
+
+func (cc *fakeClientConn) UpdateState(state resolver.State) {
+	cc.states <- state
+}
+
+func (cc *fakeClientConn) ReportError(err error) {
+	cc.errors <- err
+}
+
+func TestTabletTarget(t *testing.T) {
+	if got, want := TabletTarget("tablet-0", 15991), "tablet-0:15991"; got != want {
+		t.Errorf("TabletTarget() = %v, want %v", got, want)
+	}
+
+	*tabletHostnameResolver = "dns"
+	defer func() { *tabletHostnameResolver = "" }()
+	if got, want := TabletTarget("tablet-0", 15991), "vttablet:///tablet-0:15991"; got != want {
+		t.Errorf("TabletTarget() = %v, want %v", got, want)
+	}
+}
+
+func TestTabletResolver(t *testing.T) {
+	hr := &fakeHostResolver{addrs: map[string][]string{"tablet-0": {"10.0.0.1", "10.0.0.2"}}}
+	cc := &fakeClientConn{states: make(chan resolver.State, 10), errors: make(chan error, 10)}
+	r := newTabletResolver(hr, "tablet-0", 15991, cc)
+	defer r.Close()
+
+	state := <-cc.states
+	if len(state.Addresses) != 2 || state.Addresses[0].Addr != "10.0.0.1:15991" || state.Addresses[1].Addr != "10.0.0.2:15991" {
+		t.Fatalf("got addresses %v, want 10.0.0.1:15991 and 10.0.0.2:15991", state.Addresses)
+	}
+	if state.Addresses[0].ServerName != "tablet-0" {
+		t.Errorf("got server name %v, want tablet-0", state.Addresses[0].ServerName)
+	}
+
+	// A failed connection makes the hostname resolved again, before its
+	// TTL expires.
+	hr.set("tablet-0", "10.0.0.3")
+	r.ResolveNow(resolver.ResolveNowOptions{})
+	select {
+	case state = <-cc.states:
+	case <-time.After(10 * time.Second):
+		t.Fatal("the hostname wasn't resolved again")
+	}
+	if len(state.Addresses) != 1 || state.Addresses[0].Addr != "10.0.0.3:15991" {
+		t.Errorf("got addresses %v, want 10.0.0.3:15991", state.Addresses)
+	}
+}
+
+func TestTabletResolverError(t *testing.T) {
+	hr := &fakeHostResolver{addrs: map[string][]string{}}
+	cc := &fakeClientConn{states: make(chan resolver.State, 10), errors: make(chan error, 10)}
+	r := newTabletResolver(hr, "tablet-0", 15991, cc)
+	defer r.Close()
+
+	if err := <-cc.errors; err.Error() != "unknown host tablet-0" {
+		t.Errorf("got error %v, want unknown host tablet-0", err)
+	}
+}
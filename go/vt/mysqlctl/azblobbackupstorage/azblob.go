@@ -36,6 +36,7 @@ import (
 	"vitess.io/vitess/go/vt/concurrency"
 	"vitess.io/vitess/go/vt/log"
 	"vitess.io/vitess/go/vt/mysqlctl/backupstorage"
+	"vitess.io/vitess/go/vt/secrets"
 )
 
 var (
@@ -43,7 +44,7 @@ var (
 	accountName = flag.String("azblob_backup_account_name", "", "Azure Storage Account name for backups; if this flag is unset, the environment variable VT_AZBLOB_ACCOUNT_NAME will be used")
 
 	// This is the private access key
-	accountKeyFile = flag.String("azblob_backup_account_key_file", "", "Path to a file containing the Azure Storage account key; if this flag is unset, the environment variable VT_AZBLOB_ACCOUNT_KEY will be used as the key itself (NOT a file path)")
+	accountKeyFile = flag.String("azblob_backup_account_key_file", "", "Path to a file containing the Azure Storage account key or a secret:// reference to it; if this flag is unset, the environment variable VT_AZBLOB_ACCOUNT_KEY will be used as the key itself (NOT a file path)")
 
 	// This is the name of the container that will store the backups
 	containerName = flag.String("azblob_backup_container_name", "", "Azure Blob Container Name")
@@ -82,6 +83,14 @@ func azInternalCredentials() (string, string, error) {
 		actKey = os.Getenv("VT_AZBLOB_ACCOUNT_KEY")
 	}
 
+	// The key may be a secret:// reference. It is resolved (through the
+	// secrets cache) for every operation, so a rotated key is picked up by
+	// the next backup or restore.
+	actKey, err := secrets.Resolve(context.Background(), strings.TrimSpace(actKey))
+	if err != nil {
+		return "", "", err
+	}
+
 	if actName == "" || actKey == "" {
 		return "", "", fmt.Errorf("Azure Storage Account credentials not found in command-line flags or environment variables")
 	}
@@ -44,6 +44,8 @@ const (
 	backupInnodbDataHomeDir     = "InnoDBData"
 	backupInnodbLogGroupHomeDir = "InnoDBLog"
 	backupData                  = "Data"
+	// the base of the binary logs of the incremental backups
+	backupBinlogDir = "BinLog"
 
 	// backupManifestFileName is the MANIFEST file name within a backup.
 	backupManifestFileName = "MANIFEST"
@@ -116,7 +118,17 @@ func Backup(ctx context.Context, params BackupParams) error {
 	}
 
 	// Take the backup, and either AbortBackup or EndBackup.
-	usable, err := be.ExecuteBackup(ctx, params, bh)
+	var usable bool
+	if params.IncrementalFromPos.IsZero() {
+		usable, err = be.ExecuteBackup(ctx, params, bh)
+	} else {
+		ibe, ok := be.(IncrementalBackupEngine)
+		if !ok {
+			bh.AbortBackup(ctx)
+			return vterrors.Errorf(vtrpc.Code_UNIMPLEMENTED, "backup engine %v doesn't support incremental backups", *backupEngineImplementation)
+		}
+		usable, err = ibe.ExecuteIncrementalBackup(ctx, params, bh)
+	}
 	logger := params.Logger
 	var finishErr error
 	if usable {
@@ -308,6 +320,14 @@ func Restore(ctx context.Context, params RestoreParams) (*BackupManifest, error)
 	if err != nil {
 		return nil, err
 	}
+	// For a restore to a point in time, check the incremental backups
+	// reach it before restoring anything.
+	var increments []*IncrementalBackup
+	if params.IsPointInTime() {
+		if increments, err = FindIncrementalBackupsToRestore(ctx, params, bh, bhs); err != nil {
+			return nil, err
+		}
+	}
 
 	re, err := GetRestoreEngine(ctx, bh)
 	if err != nil {
@@ -362,6 +382,12 @@ func Restore(ctx context.Context, params RestoreParams) (*BackupManifest, error)
 		return nil, err
 	}
 
+	if params.IsPointInTime() {
+		if manifest, err = restoreIncrementalBackups(ctx, params, manifest, increments); err != nil {
+			return nil, err
+		}
+	}
+
 	if err = removeStateFile(params.Cnf); err != nil {
 		return nil, err
 	}
@@ -66,6 +66,10 @@ type BackupParams struct {
 	TabletAlias string
 	// BackupTime is the time at which the backup is being started
 	BackupTime time.Time
+	// IncrementalFromPos, if set, makes the backup an incremental backup:
+	// it only holds the binary logs since this position, which is the
+	// position of the full or incremental backup it is layered on.
+	IncrementalFromPos mysql.Position
 }
 
 // RestoreParams is the struct that holds all params passed to ExecuteRestore
@@ -92,6 +96,26 @@ type RestoreParams struct {
 	// StartTime: if non-zero, look for a backup that was taken at or before this time
 	// Otherwise, find the most recent backup
 	StartTime time.Time
+	// RestoreToPos and RestoreToTimestamp, if set, restore to a point in
+	// time: the most recent full backup before it is restored, then the
+	// binary logs of the incremental backups are replayed up to it. Only
+	// one of them should be set.
+	RestoreToPos       mysql.Position
+	RestoreToTimestamp time.Time
+}
+
+// IsPointInTime returns true if the restore is to a point in time.
+func (p *RestoreParams) IsPointInTime() bool {
+	return !p.RestoreToPos.IsZero() || !p.RestoreToTimestamp.IsZero()
+}
+
+// IncrementalBackupEngine is implemented by the backup engines which can
+// take incremental backups.
+type IncrementalBackupEngine interface {
+	// ExecuteIncrementalBackup backs up the binary logs since
+	// params.IncrementalFromPos. Like ExecuteBackup, it returns whether
+	// the backup is usable, and an overall error.
+	ExecuteIncrementalBackup(ctx context.Context, params BackupParams, bh backupstorage.BackupHandle) (bool, error)
 }
 
 // RestoreEngine is the interface to restore a backup with a given engine.
@@ -188,16 +212,28 @@ type BackupManifest struct {
 	// FinishedTime is the time (in RFC 3339 format, UTC) at which the backup finished, if known.
 	// Some backups may not set this field if they were created before the field was added.
 	FinishedTime string
+
+	// Incremental is true if the backup is an incremental backup, which
+	// holds the binary logs from FromPosition up to Position. It can only
+	// be restored on top of a backup whose position is at least
+	// FromPosition.
+	Incremental  bool
+	FromPosition mysql.Position
 }
 
 // FindBackupToRestore returns a selected candidate backup to be restored.
-// It returns the most recent backup that is complete, meaning it has a valid
-// MANIFEST file.
+// It returns the most recent full backup that is complete, meaning it has a
+// valid MANIFEST file. For a restore to a point in time, the backup must be
+// before that point.
 func FindBackupToRestore(ctx context.Context, params RestoreParams, bhs []backupstorage.BackupHandle) (backupstorage.BackupHandle, error) {
 	var bh backupstorage.BackupHandle
 	var index int
 	// if a StartTime is provided in params, then find a backup that was taken at or before that time
-	checkBackupTime := !params.StartTime.IsZero()
+	startTime := params.StartTime
+	if startTime.IsZero() {
+		startTime = params.RestoreToTimestamp
+	}
+	checkBackupTime := !startTime.IsZero()
 	backupDir := GetBackupDir(params.Keyspace, params.Shard)
 
 	for index = len(bhs) - 1; index >= 0; index-- {
@@ -208,6 +244,14 @@ func FindBackupToRestore(ctx context.Context, params RestoreParams, bhs []backup
 			params.Logger.Warningf("Possibly incomplete backup %v in directory %v on BackupStorage: can't read MANIFEST: %v)", bh.Name(), backupDir, err)
 			continue
 		}
+		if bm.Incremental {
+			// The incremental backups are only restored on top of a full
+			// backup.
+			continue
+		}
+		if !params.RestoreToPos.IsZero() && !params.RestoreToPos.AtLeast(bm.Position) {
+			continue
+		}
 
 		var backupTime time.Time
 		if checkBackupTime {
@@ -217,14 +261,17 @@ func FindBackupToRestore(ctx context.Context, params RestoreParams, bhs []backup
 				continue
 			}
 		}
-		if !checkBackupTime /* not snapshot */ || backupTime.Equal(params.StartTime) || backupTime.Before(params.StartTime) {
+		if !checkBackupTime /* not snapshot */ || backupTime.Equal(startTime) || backupTime.Before(startTime) {
 			params.Logger.Infof("Restore: found backup %v %v to restore", bh.Directory(), bh.Name())
 			break
 		}
 	}
 	if index < 0 {
 		if checkBackupTime {
-			params.Logger.Errorf("No valid backup found before time %v", params.StartTime.Format(BackupTimestampFormat))
+			params.Logger.Errorf("No valid backup found before time %v", startTime.Format(BackupTimestampFormat))
+		}
+		if !params.RestoreToPos.IsZero() {
+			params.Logger.Errorf("No valid backup found before position %v", params.RestoreToPos)
 		}
 		// There is at least one attempted backup, but none could be read.
 		// This implies there is data we ought to have, so it's not safe to start
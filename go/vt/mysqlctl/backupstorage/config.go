@@ -0,0 +1,64 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backupstorage
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"vitess.io/vitess/go/vt/topo"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+var (
+	configMu sync.Mutex
+	// configTopoServer is the topo server the backup storage
+	// configurations of the keyspaces are read from.
+	configTopoServer *topo.Server
+)
+
+// SetTopoServer sets the topo server from which the implementations read
+// the backup storage configuration of the keyspaces. Until it is set, only
+// their flags apply.
+func SetTopoServer(ts *topo.Server) {
+	configMu.Lock()
+	defer configMu.Unlock()
+	configTopoServer = ts
+}
+
+// KeyspaceConfig returns the backup storage configuration of the keyspace
+// of the backup directory, as returned by mysqlctl.GetBackupDir, or nil if
+// it has none or the topo server isn't set.
+func KeyspaceConfig(ctx context.Context, dir string) (*topodatapb.BackupStorageConfig, error) {
+	configMu.Lock()
+	ts := configTopoServer
+	configMu.Unlock()
+	keyspace := strings.SplitN(strings.Trim(dir, "/"), "/", 2)[0]
+	if ts == nil || keyspace == "" {
+		return nil, nil
+	}
+	ki, err := ts.GetKeyspace(ctx, keyspace)
+	if topo.IsErrType(err, topo.NoNode) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ki.BackupStorageConfig, nil
+}
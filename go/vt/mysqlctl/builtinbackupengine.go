@@ -89,6 +89,9 @@ type FileEntry struct {
 	// - backupInnodbDataHomeDir for files that go into Mycnf.InnodbDataHomeDir
 	// - backupInnodbLogGroupHomeDir for files that go into Mycnf.InnodbLogGroupHomeDir
 	// - backupData for files that go into Mycnf.DataDir
+	// - backupBinlogDir for the binary logs of the incremental backups,
+	//   which are read from the directory of Mycnf.BinLogPath and restored
+	//   to a temporary directory to be replayed
 	Base string
 
 	// Name is the file name, relative to Base
@@ -109,6 +112,11 @@ func (fe *FileEntry) open(cnf *Mycnf, readOnly bool) (*os.File, error) {
 		root = cnf.InnodbLogGroupHomeDir
 	case backupData:
 		root = cnf.DataDir
+	case backupBinlogDir:
+		root = path.Dir(cnf.BinLogPath)
+		if !readOnly {
+			root = binlogRestoreDir(cnf)
+		}
 	default:
 		return nil, vterrors.Errorf(vtrpc.Code_UNKNOWN, "unknown base: %v", fe.Base)
 	}
@@ -275,7 +283,7 @@ func (be *BuiltinBackupEngine) ExecuteBackup(ctx context.Context, params BackupP
 }
 
 // backupFiles finds the list of files to backup, and creates the backup.
-func (be *BuiltinBackupEngine) backupFiles(ctx context.Context, params BackupParams, bh backupstorage.BackupHandle, replicationPosition mysql.Position) (finalErr error) {
+func (be *BuiltinBackupEngine) backupFiles(ctx context.Context, params BackupParams, bh backupstorage.BackupHandle, replicationPosition mysql.Position) error {
 
 	// Get the files to backup.
 	// We don't care about totalSize because we add each file separately.
@@ -285,6 +293,29 @@ func (be *BuiltinBackupEngine) backupFiles(ctx context.Context, params BackupPar
 	}
 	params.Logger.Infof("found %v files to backup", len(fes))
 
+	if err := be.backupFileEntries(ctx, params, bh, fes); err != nil {
+		return err
+	}
+
+	return writeBuiltinManifest(ctx, bh, &builtinBackupManifest{
+		// Common base fields
+		BackupManifest: BackupManifest{
+			BackupMethod: builtinBackupEngineName,
+			Position:     replicationPosition,
+			BackupTime:   params.BackupTime.UTC().Format(time.RFC3339),
+			FinishedTime: time.Now().UTC().Format(time.RFC3339),
+		},
+
+		// Builtin-specific fields
+		FileEntries:   fes,
+		TransformHook: *backupStorageHook,
+		SkipCompress:  !*backupStorageCompress,
+	})
+}
+
+// backupFileEntries backs up the files, and records their hashes in the
+// entries.
+func (be *BuiltinBackupEngine) backupFileEntries(ctx context.Context, params BackupParams, bh backupstorage.BackupHandle, fes []FileEntry) error {
 	// Backup with the provided concurrency.
 	sema := sync2.NewSemaphore(params.Concurrency, 0)
 	wg := sync.WaitGroup{}
@@ -320,7 +351,11 @@ func (be *BuiltinBackupEngine) backupFiles(ctx context.Context, params BackupPar
 	if bh.HasErrors() {
 		return bh.Error()
 	}
+	return nil
+}
 
+// writeBuiltinManifest writes the MANIFEST of the backup.
+func writeBuiltinManifest(ctx context.Context, bh backupstorage.BackupHandle, bm *builtinBackupManifest) (finalErr error) {
 	// open the MANIFEST
 	wc, err := bh.AddFile(ctx, backupManifestFileName, backupstorage.FileSizeUnknown)
 	if err != nil {
@@ -333,20 +368,6 @@ func (be *BuiltinBackupEngine) backupFiles(ctx context.Context, params BackupPar
 	}()
 
 	// JSON-encode and write the MANIFEST
-	bm := &builtinBackupManifest{
-		// Common base fields
-		BackupManifest: BackupManifest{
-			BackupMethod: builtinBackupEngineName,
-			Position:     replicationPosition,
-			BackupTime:   params.BackupTime.UTC().Format(time.RFC3339),
-			FinishedTime: time.Now().UTC().Format(time.RFC3339),
-		},
-
-		// Builtin-specific fields
-		FileEntries:   fes,
-		TransformHook: *backupStorageHook,
-		SkipCompress:  !*backupStorageCompress,
-	}
 	data, err := json.MarshalIndent(bm, "", "  ")
 	if err != nil {
 		return vterrors.Wrapf(err, "cannot JSON encode %v", backupManifestFileName)
@@ -466,6 +487,9 @@ func (be *BuiltinBackupEngine) ExecuteRestore(ctx context.Context, params Restor
 	if err := getBackupManifestInto(ctx, bh, &bm); err != nil {
 		return nil, err
 	}
+	if bm.Incremental {
+		return be.executeIncrementalRestore(ctx, params, bh, bm)
+	}
 
 	// mark restore as in progress
 	if err := createStateFile(params.Cnf); err != nil {
@@ -0,0 +1,292 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqlctl
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/mysqlctl/backupstorage"
+	"vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+// This file implements the incremental backups: backups of the binary
+// logs of a tablet since the position of a previous full or incremental
+// backup of its shard. They are stored next to the full backups, and let
+// the shard be restored to any point in time they cover: the most recent
+// full backup before it is restored, then the binary logs of the
+// incremental backups are replayed up to it. They require MySQL with
+// GTIDs, and are always taken and restored by the builtin engine.
+
+// IncrementalBackup is an incremental backup of a shard, with its
+// manifest.
+type IncrementalBackup struct {
+	Handle   backupstorage.BackupHandle
+	Manifest *BackupManifest
+}
+
+// binlogFile is a binary log of mysqld, with the GTIDs executed before
+// it.
+type binlogFile struct {
+	name          string
+	previousGTIDs mysql.Position
+}
+
+// binlogRestoreDir is the directory where the binary logs of the
+// incremental backups are restored before they are replayed.
+func binlogRestoreDir(cnf *Mycnf) string {
+	return path.Join(cnf.TabletDir(), "restore_binlogs")
+}
+
+// ExecuteIncrementalBackup is part of the IncrementalBackupEngine
+// interface. It rotates the binary logs, and backs up the closed ones
+// which hold the transactions since params.IncrementalFromPos. mysqld
+// keeps running.
+func (be *BuiltinBackupEngine) ExecuteIncrementalBackup(ctx context.Context, params BackupParams, bh backupstorage.BackupHandle) (bool, error) {
+	params.Logger.Infof("Incremental backup from %v, Hook: %v, Compress: %v", params.IncrementalFromPos, *backupStorageHook, *backupStorageCompress)
+
+	if err := params.Mysqld.ExecuteSuperQueryList(ctx, []string{"FLUSH BINARY LOGS"}); err != nil {
+		return false, vterrors.Wrap(err, "can't rotate the binary logs")
+	}
+	binlogs, err := listBinlogs(ctx, params.Mysqld)
+	if err != nil {
+		return false, err
+	}
+	names, fromPos, toPos, err := chooseIncrementalBinlogs(binlogs, params.IncrementalFromPos)
+	if err != nil {
+		return false, err
+	}
+	params.Logger.Infof("backing up %v binary logs, from position %v to %v", len(names), fromPos, toPos)
+
+	fes := make([]FileEntry, len(names))
+	for i, name := range names {
+		fes[i] = FileEntry{Base: backupBinlogDir, Name: name}
+	}
+	if err := be.backupFileEntries(ctx, params, bh, fes); err != nil {
+		return false, err
+	}
+	err = writeBuiltinManifest(ctx, bh, &builtinBackupManifest{
+		BackupManifest: BackupManifest{
+			BackupMethod: builtinBackupEngineName,
+			Position:     toPos,
+			BackupTime:   params.BackupTime.UTC().Format(time.RFC3339),
+			FinishedTime: time.Now().UTC().Format(time.RFC3339),
+			Incremental:  true,
+			FromPosition: fromPos,
+		},
+		FileEntries:   fes,
+		TransformHook: *backupStorageHook,
+		SkipCompress:  !*backupStorageCompress,
+	})
+	return err == nil, err
+}
+
+// listBinlogs returns the binary logs of mysqld, oldest first.
+func listBinlogs(ctx context.Context, mysqld MysqlDaemon) ([]binlogFile, error) {
+	qr, err := mysqld.FetchSuperQuery(ctx, "SHOW BINARY LOGS")
+	if err != nil {
+		return nil, vterrors.Wrap(err, "can't list the binary logs")
+	}
+	binlogs := make([]binlogFile, 0, len(qr.Rows))
+	for _, row := range qr.Rows {
+		name := row[0].ToString()
+		previousGTIDs, err := binlogPreviousGTIDs(ctx, mysqld, name)
+		if err != nil {
+			return nil, err
+		}
+		binlogs = append(binlogs, binlogFile{name: name, previousGTIDs: previousGTIDs})
+	}
+	return binlogs, nil
+}
+
+// binlogPreviousGTIDs returns the GTIDs executed before a binary log, from
+// its Previous_gtids event.
+func binlogPreviousGTIDs(ctx context.Context, mysqld MysqlDaemon, name string) (mysql.Position, error) {
+	qr, err := mysqld.FetchSuperQuery(ctx, fmt.Sprintf("SHOW BINLOG EVENTS IN %s LIMIT 2", sqltypes.EncodeStringSQL(name)))
+	if err != nil {
+		return mysql.Position{}, vterrors.Wrapf(err, "can't read binary log %v", name)
+	}
+	for _, row := range qr.Rows {
+		// The columns are Log_name, Pos, Event_type, Server_id,
+		// End_log_pos and Info.
+		if len(row) < 6 || row[2].ToString() != "Previous_gtids" {
+			continue
+		}
+		return mysql.ParsePosition(mysql.Mysql56FlavorID, strings.Join(strings.Fields(row[5].ToString()), ""))
+	}
+	return mysql.Position{}, vterrors.Errorf(vtrpc.Code_FAILED_PRECONDITION, "binary log %v has no Previous_gtids event: incremental backups need MySQL with GTIDs", name)
+}
+
+// chooseIncrementalBinlogs returns the binary logs which hold the
+// transactions since fromPos, the position of the first transaction they
+// hold, and the position after the last one. The last binary log is the
+// one mysqld writes to, so it's never backed up.
+func chooseIncrementalBinlogs(binlogs []binlogFile, fromPos mysql.Position) ([]string, mysql.Position, mysql.Position, error) {
+	if len(binlogs) < 2 {
+		return nil, mysql.Position{}, mysql.Position{}, vterrors.Errorf(vtrpc.Code_FAILED_PRECONDITION, "no closed binary log to back up")
+	}
+	last := len(binlogs) - 1
+	toPos := binlogs[last].previousGTIDs
+	if fromPos.AtLeast(toPos) {
+		return nil, mysql.Position{}, mysql.Position{}, vterrors.Errorf(vtrpc.Code_FAILED_PRECONDITION, "no transaction to back up since position %v", fromPos)
+	}
+	start := -1
+	for i := 0; i < last; i++ {
+		if fromPos.AtLeast(binlogs[i].previousGTIDs) {
+			start = i
+		}
+	}
+	if start < 0 {
+		return nil, mysql.Position{}, mysql.Position{}, vterrors.Errorf(vtrpc.Code_FAILED_PRECONDITION, "the binary logs since position %v were purged: the first one, %v, starts at %v", fromPos, binlogs[0].name, binlogs[0].previousGTIDs)
+	}
+	names := make([]string, 0, last-start)
+	for _, binlog := range binlogs[start:last] {
+		names = append(names, binlog.name)
+	}
+	return names, binlogs[start].previousGTIDs, toPos, nil
+}
+
+// executeIncrementalRestore replays the binary logs of an incremental
+// backup on the running mysqld, up to the point in time of the restore.
+func (be *BuiltinBackupEngine) executeIncrementalRestore(ctx context.Context, params RestoreParams, bh backupstorage.BackupHandle, bm builtinBackupManifest) (*BackupManifest, error) {
+	dir := binlogRestoreDir(params.Cnf)
+	if err := os.RemoveAll(dir); err != nil {
+		return nil, vterrors.Wrapf(err, "can't clean up %v", dir)
+	}
+	defer os.RemoveAll(dir)
+
+	params.Logger.Infof("Restore: copying %v binary logs of incremental backup %v", len(bm.FileEntries), bh.Name())
+	if err := be.restoreFiles(ctx, params, bh, bm); err != nil {
+		return nil, vterrors.Wrap(err, "failed to restore binary logs")
+	}
+	files := make([]string, len(bm.FileEntries))
+	for i, fe := range bm.FileEntries {
+		files[i] = path.Join(dir, fe.Name)
+	}
+	params.Logger.Infof("Restore: replaying the binary logs from position %v to %v", bm.FromPosition, bm.Position)
+	if err := params.Mysqld.ApplyBinlogFiles(ctx, files, params.RestoreToPos, params.RestoreToTimestamp); err != nil {
+		return nil, vterrors.Wrap(err, "failed to replay binary logs")
+	}
+	return &bm.BackupManifest, nil
+}
+
+// FindLatestBackupPosition returns the position of the most recent
+// complete backup of the shard, full or incremental, which the next
+// incremental backup is layered on. It returns ErrNoBackup if there is
+// none.
+func FindLatestBackupPosition(ctx context.Context, keyspace, shard string) (mysql.Position, error) {
+	bs, err := backupstorage.GetBackupStorage()
+	if err != nil {
+		return mysql.Position{}, err
+	}
+	defer bs.Close()
+	bhs, err := bs.ListBackups(ctx, GetBackupDir(keyspace, shard))
+	if err != nil {
+		return mysql.Position{}, vterrors.Wrap(err, "ListBackups failed")
+	}
+	for i := len(bhs) - 1; i >= 0; i-- {
+		if bm, err := GetBackupManifest(ctx, bhs[i]); err == nil {
+			return bm.Position, nil
+		}
+	}
+	return mysql.Position{}, ErrNoBackup
+}
+
+// FindIncrementalBackupsToRestore returns the incremental backups to
+// replay on top of the full backup bh to restore to the point in time of
+// params, in order.
+func FindIncrementalBackupsToRestore(ctx context.Context, params RestoreParams, bh backupstorage.BackupHandle, bhs []backupstorage.BackupHandle) ([]*IncrementalBackup, error) {
+	base, err := GetBackupManifest(ctx, bh)
+	if err != nil {
+		return nil, err
+	}
+	var backups []*IncrementalBackup
+	for _, ibh := range bhs {
+		bm, err := GetBackupManifest(ctx, ibh)
+		if err != nil || !bm.Incremental {
+			continue
+		}
+		backups = append(backups, &IncrementalBackup{Handle: ibh, Manifest: bm})
+	}
+	return incrementalRestorePath(base, backups, params.RestoreToPos, params.RestoreToTimestamp)
+}
+
+// incrementalRestorePath returns the incremental backups which take a
+// restore of the base backup to toPos, or to toTime if toPos isn't set,
+// in order. At each step, the backup which can be replayed and goes the
+// furthest is chosen. The backups taken at toTime or after hold all the
+// transactions before it.
+func incrementalRestorePath(base *BackupManifest, backups []*IncrementalBackup, toPos mysql.Position, toTime time.Time) ([]*IncrementalBackup, error) {
+	pos := base.Position
+	lastTime, _ := time.Parse(time.RFC3339, base.BackupTime)
+	var increments []*IncrementalBackup
+	for {
+		if !toPos.IsZero() && pos.AtLeast(toPos) || toPos.IsZero() && !lastTime.Before(toTime) {
+			return increments, nil
+		}
+		var next *IncrementalBackup
+		for _, backup := range backups {
+			if !pos.AtLeast(backup.Manifest.FromPosition) || pos.AtLeast(backup.Manifest.Position) {
+				continue
+			}
+			if next == nil || backup.Manifest.Position.AtLeast(next.Manifest.Position) {
+				next = backup
+			}
+		}
+		if next == nil {
+			if !toPos.IsZero() {
+				return nil, vterrors.Errorf(vtrpc.Code_FAILED_PRECONDITION, "no incremental backup goes from position %v toward %v", pos, toPos)
+			}
+			return nil, vterrors.Errorf(vtrpc.Code_FAILED_PRECONDITION, "no incremental backup goes from position %v toward %v: the last one was taken at %v", pos, toTime.UTC().Format(time.RFC3339), lastTime.UTC().Format(time.RFC3339))
+		}
+		increments = append(increments, next)
+		pos = next.Manifest.Position
+		if t, err := time.Parse(time.RFC3339, next.Manifest.BackupTime); err == nil && t.After(lastTime) {
+			lastTime = t
+		}
+	}
+}
+
+// restoreIncrementalBackups replays the incremental backups on top of the
+// restored full backup, and returns its manifest with the position
+// reached.
+func restoreIncrementalBackups(ctx context.Context, params RestoreParams, manifest *BackupManifest, backups []*IncrementalBackup) (*BackupManifest, error) {
+	for _, backup := range backups {
+		re, err := GetRestoreEngine(ctx, backup.Handle)
+		if err != nil {
+			return nil, vterrors.Wrap(err, "Failed to find restore engine")
+		}
+		if _, err := re.ExecuteRestore(ctx, params, backup.Handle); err != nil {
+			return nil, vterrors.Wrapf(err, "can't restore incremental backup %v", backup.Handle.Name())
+		}
+	}
+	pos, err := params.Mysqld.PrimaryPosition()
+	if err != nil {
+		return nil, vterrors.Wrap(err, "can't get the restored position")
+	}
+	params.Logger.Infof("Restore: restored %v incremental backups, up to position %v", len(backups), pos)
+	restored := *manifest
+	restored.Position = pos
+	return &restored, nil
+}
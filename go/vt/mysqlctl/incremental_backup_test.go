@@ -0,0 +1,105 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqlctl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql"
+)
+
+const testUUID = "00010203-0405-0607-0809-0a0b0c0d0e0f"
+
+func testPosition(t *testing.T, gtids string) mysql.Position {
+	t.Helper()
+	pos, err := mysql.ParsePosition(mysql.Mysql56FlavorID, testUUID+":"+gtids)
+	require.NoError(t, err)
+	return pos
+}
+
+func TestChooseIncrementalBinlogs(t *testing.T) {
+	binlogs := []binlogFile{
+		{name: "bin.000003", previousGTIDs: testPosition(t, "1-10")},
+		{name: "bin.000004", previousGTIDs: testPosition(t, "1-20")},
+		{name: "bin.000005", previousGTIDs: testPosition(t, "1-30")},
+		{name: "bin.000006", previousGTIDs: testPosition(t, "1-40")},
+	}
+
+	names, fromPos, toPos, err := chooseIncrementalBinlogs(binlogs, testPosition(t, "1-25"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bin.000004", "bin.000005"}, names)
+	assert.Equal(t, testPosition(t, "1-20"), fromPos)
+	assert.Equal(t, testPosition(t, "1-40"), toPos)
+
+	// The binary log being written isn't backed up.
+	names, _, _, err = chooseIncrementalBinlogs(binlogs, testPosition(t, "1-30"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bin.000005"}, names)
+
+	_, _, _, err = chooseIncrementalBinlogs(binlogs, testPosition(t, "1-40"))
+	assert.EqualError(t, err, "no transaction to back up since position "+testUUID+":1-40")
+	_, _, _, err = chooseIncrementalBinlogs(binlogs, testPosition(t, "1-5"))
+	assert.Contains(t, err.Error(), "the binary logs since position "+testUUID+":1-5 were purged")
+	_, _, _, err = chooseIncrementalBinlogs(binlogs[:1], testPosition(t, "1-5"))
+	assert.EqualError(t, err, "no closed binary log to back up")
+}
+
+func TestIncrementalRestorePath(t *testing.T) {
+	at := func(hour int) string {
+		return time.Date(2021, time.April, 1, hour, 0, 0, 0, time.UTC).Format(time.RFC3339)
+	}
+	base := &BackupManifest{Position: testPosition(t, "1-10"), BackupTime: at(0)}
+	backup := func(from, to string, hour int) *IncrementalBackup {
+		return &IncrementalBackup{Manifest: &BackupManifest{
+			Incremental:  true,
+			FromPosition: testPosition(t, from),
+			Position:     testPosition(t, to),
+			BackupTime:   at(hour),
+		}}
+	}
+	backups := []*IncrementalBackup{
+		backup("1-5", "1-20", 1),
+		backup("1-15", "1-30", 2),
+		backup("1-20", "1-40", 3),
+		backup("1-8", "1-35", 4),
+		backup("1-40", "1-50", 5),
+	}
+
+	path, err := incrementalRestorePath(base, backups, testPosition(t, "1-45"), time.Time{})
+	require.NoError(t, err)
+	assert.Equal(t, []*IncrementalBackup{backups[3], backups[2], backups[4]}, path)
+
+	path, err = incrementalRestorePath(base, backups, testPosition(t, "1-10"), time.Time{})
+	require.NoError(t, err)
+	assert.Empty(t, path)
+
+	_, err = incrementalRestorePath(base, backups, testPosition(t, "1-60"), time.Time{})
+	assert.EqualError(t, err, "no incremental backup goes from position "+testUUID+":1-50 toward "+testUUID+":1-60")
+
+	// The backups taken at the time or after hold all the transactions
+	// before it.
+	path, err = incrementalRestorePath(base, backups, mysql.Position{}, time.Date(2021, time.April, 1, 3, 30, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, []*IncrementalBackup{backups[3]}, path)
+
+	_, err = incrementalRestorePath(base, backups, mysql.Position{}, time.Date(2021, time.April, 1, 6, 0, 0, 0, time.UTC))
+	assert.EqualError(t, err, "no incremental backup goes from position "+testUUID+":1-50 toward 2021-04-01T06:00:00Z: the last one was taken at 2021-04-01T05:00:00Z")
+}
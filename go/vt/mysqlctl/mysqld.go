@@ -46,12 +46,14 @@ import (
 	"context"
 
 	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/sqlescape"
 	"vitess.io/vitess/go/vt/dbconfigs"
 	"vitess.io/vitess/go/vt/dbconnpool"
 	vtenv "vitess.io/vitess/go/vt/env"
 	"vitess.io/vitess/go/vt/hook"
 	"vitess.io/vitess/go/vt/log"
 	"vitess.io/vitess/go/vt/mysqlctl/mysqlctlclient"
+	"vitess.io/vitess/go/vt/secrets"
 )
 
 var (
@@ -92,6 +94,10 @@ type Mysqld struct {
 
 	capabilities capabilitySet
 
+	// unwatchSecrets unregisters the rotation callbacks of the passwords
+	// of the db users. It is called by Close.
+	unwatchSecrets []func()
+
 	// mutex protects the fields below.
 	mutex         sync.Mutex
 	onTermFuncs   []func()
@@ -105,6 +111,19 @@ func NewMysqld(dbcfgs *dbconfigs.DBConfigs) *Mysqld {
 		dbcfgs: dbcfgs,
 	}
 
+	// Passwords given as secret:// references are resolved when connecting.
+	// Resolve the ones of all the users once here so a bad reference fails
+	// at startup, and watch them so the cache follows rotations: new
+	// connections then use the rotated passwords, open ones stay
+	// authenticated.
+	for name, uc := range dbcfgs.UserConfigs() {
+		name := name
+		secrets.MustResolve(uc.Password)
+		result.unwatchSecrets = append(result.unwatchSecrets, secrets.OnRotate(uc.Password, func(string) {
+			log.Infof("db_%v_password secret rotated, new connections will use it", name)
+		}))
+	}
+
 	// Create and open the connection pool for dba access.
 	result.dbaPool = dbconnpool.NewConnectionPool("DbaConnPool", *dbaPoolSize, *dbaIdleTimeout, *poolDynamicHostnameResolution)
 	result.dbaPool.Open(dbcfgs.DbaWithDB())
@@ -692,6 +711,19 @@ func (mysqld *Mysqld) Init(ctx context.Context, cnf *Mycnf, initDBSQLFile string
 	return nil
 }
 
+// InitDatabases creates the databases of the tablets sharing an already
+// initialized mysqld. The tablets create their sidecar tables themselves.
+func (mysqld *Mysqld) InitDatabases(ctx context.Context, dbNames []string) error {
+	if len(dbNames) == 0 {
+		return fmt.Errorf("no database to create")
+	}
+	var queries []string
+	for _, dbName := range dbNames {
+		queries = append(queries, "CREATE DATABASE IF NOT EXISTS "+sqlescape.EscapeID(dbName))
+	}
+	return mysqld.ExecuteSuperQueryList(ctx, queries)
+}
+
 // For debugging purposes show the last few lines of the MySQL error log.
 // Return a suggestion (string) if the file is non regular or can not be opened.
 // This helps prevent cases where the error log is symlinked to /dev/stderr etc,
@@ -1047,6 +1079,63 @@ func (mysqld *Mysqld) executeMysqlScript(connParams *mysql.ConnParams, sql io.Re
 	return nil
 }
 
+// ApplyBinlogFiles replays binary log files on mysqld, by piping the
+// output of the mysqlbinlog command line tool to the mysql one, with the
+// dba credentials. If restoreToPos is set, only its transactions are
+// replayed, and if restoreToTimestamp is set, only the events before it.
+func (mysqld *Mysqld) ApplyBinlogFiles(ctx context.Context, binlogFiles []string, restoreToPos mysql.Position, restoreToTimestamp time.Time) error {
+	dir, err := vtenv.VtMysqlRoot()
+	if err != nil {
+		return err
+	}
+	name, err := binaryPath(dir, "mysqlbinlog")
+	if err != nil {
+		return err
+	}
+	var args []string
+	if !restoreToPos.IsZero() {
+		args = append(args, "--include-gtids="+restoreToPos.GTIDSet.String())
+	}
+	if !restoreToTimestamp.IsZero() {
+		// mysqlbinlog reads the time in the local time zone.
+		args = append(args, "--stop-datetime="+restoreToTimestamp.Local().Format("2006-01-02 15:04:05"))
+	}
+	args = append(args, binlogFiles...)
+	env, err := buildLdPaths()
+	if err != nil {
+		return err
+	}
+	params, err := mysqld.dbcfgs.DbaConnector().MysqlParams()
+	if err != nil {
+		return err
+	}
+
+	// mysqlbinlog is killed if mysql fails, so it doesn't block on the
+	// pipe.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	log.Infof("ApplyBinlogFiles: %v %v", name, args)
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Env = env
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	applyErr := mysqld.executeMysqlScript(params, stdout)
+	if applyErr != nil {
+		cancel()
+	}
+	if err := cmd.Wait(); err != nil && applyErr == nil {
+		return fmt.Errorf("%v: %v, output: %v", name, err, stderr.String())
+	}
+	return applyErr
+}
+
 // defaultsExtraFile returns the filename for a temporary config file
 // that contains the user, password and socket file to connect to
 // mysqld.  We write a temporary config file so the password is never
@@ -1116,6 +1205,10 @@ func (mysqld *Mysqld) Close() {
 	if mysqld.appPool != nil {
 		mysqld.appPool.Close()
 	}
+	for _, unwatch := range mysqld.unwatchSecrets {
+		unwatch()
+	}
+	mysqld.unwatchSecrets = nil
 }
 
 // OnTerm registers a function to be called if mysqld terminates for any
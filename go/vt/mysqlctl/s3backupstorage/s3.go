@@ -16,6 +16,9 @@ limitations under the License.
 
 // Package s3backupstorage implements the BackupStorage interface for AWS S3.
 //
+// The flags can be overridden for the backups of a keyspace by its backup
+// storage config in the topo, see topodatapb.BackupStorageConfig_S3.
+//
 // AWS access credentials are configured via standard AWS means, such as:
 // - AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY environment variables
 // - credentials file at ~/.aws/credentials
@@ -49,8 +52,12 @@ import (
 	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 
+	"vitess.io/vitess/go/protoutil"
 	"vitess.io/vitess/go/vt/concurrency"
 	"vitess.io/vitess/go/vt/mysqlctl/backupstorage"
+	"vitess.io/vitess/go/vt/secrets"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 )
 
 var (
@@ -60,6 +67,10 @@ var (
 	// AWS request retries
 	retryCount = flag.Int("s3_backup_aws_retries", -1, "AWS request retries")
 
+	// backoff between the AWS request retries
+	minRetryDelay = flag.Duration("s3_backup_aws_min_retry_delay", 0, "minimum delay before retrying a failed AWS request, 0 for the AWS default")
+	maxRetryDelay = flag.Duration("s3_backup_aws_max_retry_delay", 0, "maximum delay before retrying a failed AWS request, 0 for the AWS default")
+
 	// AWS endpoint, defaults to amazonaws.com but appliances may use a different location
 	endpoint = flag.String("s3_backup_aws_endpoint", "", "endpoint of the S3 backend (region must be provided)")
 
@@ -78,7 +89,14 @@ var (
 	requiredLogLevel = flag.String("s3_backup_log_level", "LogOff", "determine the S3 loglevel to use from LogOff, LogDebug, LogDebugWithSigning, LogDebugWithHTTPBody, LogDebugWithRequestRetries, LogDebugWithRequestErrors")
 
 	// sse is the server-side encryption algorithm used when storing this object in S3
-	sse = flag.String("s3_backup_server_side_encryption", "", "server-side encryption algorithm (e.g., AES256, aws:kms, sse_c:/path/to/key/file)")
+	sse = flag.String("s3_backup_server_side_encryption", "", "server-side encryption algorithm (e.g., AES256, aws:kms, sse_c:/path/to/key/file, sse_c:secret://...)")
+
+	// sseKMSKeyID is the KMS key of the aws:kms server-side encryption
+	sseKMSKeyID = flag.String("s3_backup_sse_kms_key_id", "", "KMS key of the aws:kms server-side encryption, instead of the default key of the bucket")
+
+	// multipart upload tuning
+	uploadPartSize    = flag.Int64("s3_backup_upload_part_size", 0, "size in bytes of the parts of the multipart uploads, 0 for the default of 5MiB. It is raised for the files which would otherwise need more parts than S3 allows")
+	uploadConcurrency = flag.Int("s3_backup_upload_concurrency", s3manager.DefaultUploadConcurrency, "number of parts of a file uploaded at the same time")
 
 	// path component delimiter
 	delimiter = "/"
@@ -94,6 +112,7 @@ const sseCustomerPrefix = "sse_c:"
 type S3BackupHandle struct {
 	client    s3iface.S3API
 	bs        *S3BackupStorage
+	settings  *s3Settings
 	dir       string
 	name      string
 	readOnly  bool
@@ -134,6 +153,9 @@ func (bh *S3BackupHandle) AddFile(ctx context.Context, filename string, filesize
 
 	// Calculate s3 upload part size using the source filesize
 	partSizeBytes := s3manager.DefaultUploadPartSize
+	if bh.settings.partSize > 0 {
+		partSizeBytes = bh.settings.partSize
+	}
 	if filesize > 0 {
 		minimumPartSize := float64(filesize) / float64(s3manager.MaxUploadParts)
 		// Round up to ensure large enough partsize
@@ -150,17 +172,23 @@ func (bh *S3BackupHandle) AddFile(ctx context.Context, filename string, filesize
 		defer bh.waitGroup.Done()
 		uploader := s3manager.NewUploaderWithClient(bh.client, func(u *s3manager.Uploader) {
 			u.PartSize = partSizeBytes
+			if bh.settings.uploadConcurrency > 0 {
+				u.Concurrency = bh.settings.uploadConcurrency
+			}
+			u.RequestOptions = bh.settings.requestOptions
 		})
-		object := objName(bh.dir, bh.name, filename)
+		object := bh.settings.objName(bh.dir, bh.name, filename)
+		sse := &bh.settings.sse
 
 		_, err := uploader.Upload(&s3manager.UploadInput{
-			Bucket:               bucket,
+			Bucket:               &bh.settings.bucket,
 			Key:                  object,
 			Body:                 reader,
-			ServerSideEncryption: bh.bs.s3SSE.awsAlg,
-			SSECustomerAlgorithm: bh.bs.s3SSE.customerAlg,
-			SSECustomerKey:       bh.bs.s3SSE.customerKey,
-			SSECustomerKeyMD5:    bh.bs.s3SSE.customerMd5,
+			ServerSideEncryption: sse.awsAlg,
+			SSEKMSKeyId:          sse.kmsKeyID,
+			SSECustomerAlgorithm: sse.customerAlg,
+			SSECustomerKey:       sse.customerKey,
+			SSECustomerKeyMD5:    sse.customerMd5,
 		})
 		if err != nil {
 			reader.CloseWithError(err)
@@ -193,14 +221,15 @@ func (bh *S3BackupHandle) ReadFile(ctx context.Context, filename string) (io.Rea
 	if !bh.readOnly {
 		return nil, fmt.Errorf("ReadFile cannot be called on read-write backup")
 	}
-	object := objName(bh.dir, bh.name, filename)
-	out, err := bh.client.GetObject(&s3.GetObjectInput{
-		Bucket:               bucket,
+	object := bh.settings.objName(bh.dir, bh.name, filename)
+	sse := &bh.settings.sse
+	out, err := bh.client.GetObjectWithContext(aws.BackgroundContext(), &s3.GetObjectInput{
+		Bucket:               &bh.settings.bucket,
 		Key:                  object,
-		SSECustomerAlgorithm: bh.bs.s3SSE.customerAlg,
-		SSECustomerKey:       bh.bs.s3SSE.customerKey,
-		SSECustomerKeyMD5:    bh.bs.s3SSE.customerMd5,
-	})
+		SSECustomerAlgorithm: sse.customerAlg,
+		SSECustomerKey:       sse.customerKey,
+		SSECustomerKeyMD5:    sse.customerMd5,
+	}, bh.settings.requestOptions...)
 	if err != nil {
 		return nil, err
 	}
@@ -211,20 +240,44 @@ var _ backupstorage.BackupHandle = (*S3BackupHandle)(nil)
 
 type S3ServerSideEncryption struct {
 	awsAlg      *string
+	kmsKeyID    *string
 	customerAlg *string
 	customerKey *string
 	customerMd5 *string
 }
 
 func (s3ServerSideEncryption *S3ServerSideEncryption) init() error {
+	return s3ServerSideEncryption.initWith(*sse, *sseKMSKeyID)
+}
+
+// initWith initializes the server-side encryption with the algorithm,
+// in the format of -s3_backup_server_side_encryption, and the KMS key of
+// the aws:kms algorithm, if any.
+func (s3ServerSideEncryption *S3ServerSideEncryption) initWith(sse, kmsKeyID string) error {
 	s3ServerSideEncryption.reset()
 
-	if strings.HasPrefix(*sse, sseCustomerPrefix) {
-		sseCustomerKeyFile := strings.TrimPrefix(*sse, sseCustomerPrefix)
-		base64CodedKey, err := ioutil.ReadFile(sseCustomerKeyFile)
-		if err != nil {
-			log.Errorf(err.Error())
-			return err
+	if kmsKeyID != "" && sse != s3.ServerSideEncryptionAwsKms {
+		return fmt.Errorf("an SSE-KMS key requires the %v server-side encryption, not %q", s3.ServerSideEncryptionAwsKms, sse)
+	}
+	if strings.HasPrefix(sse, sseCustomerPrefix) {
+		sseCustomerKeyFile := strings.TrimPrefix(sse, sseCustomerPrefix)
+		var base64CodedKey []byte
+		if secrets.IsRef(sseCustomerKeyFile) {
+			ctx, cancel := context.WithTimeout(context.Background(), secrets.ResolveTimeout())
+			key, err := secrets.Resolve(ctx, sseCustomerKeyFile)
+			cancel()
+			if err != nil {
+				log.Errorf(err.Error())
+				return err
+			}
+			base64CodedKey = []byte(key)
+		} else {
+			var err error
+			base64CodedKey, err = ioutil.ReadFile(sseCustomerKeyFile)
+			if err != nil {
+				log.Errorf(err.Error())
+				return err
+			}
 		}
 
 		decodedKey, err := base64.StdEncoding.DecodeString(string(base64CodedKey))
@@ -236,51 +289,182 @@ func (s3ServerSideEncryption *S3ServerSideEncryption) init() error {
 		s3ServerSideEncryption.customerAlg = aws.String("AES256")
 		s3ServerSideEncryption.customerKey = aws.String(string(decodedKey))
 		s3ServerSideEncryption.customerMd5 = aws.String(base64.StdEncoding.EncodeToString(md5Hash[:]))
-	} else if *sse != "" {
-		s3ServerSideEncryption.awsAlg = sse
+	} else if sse != "" {
+		s3ServerSideEncryption.awsAlg = aws.String(sse)
+		if kmsKeyID != "" {
+			s3ServerSideEncryption.kmsKeyID = aws.String(kmsKeyID)
+		}
 	}
 	return nil
 }
 
 func (s3ServerSideEncryption *S3ServerSideEncryption) reset() {
 	s3ServerSideEncryption.awsAlg = nil
+	s3ServerSideEncryption.kmsKeyID = nil
 	s3ServerSideEncryption.customerAlg = nil
 	s3ServerSideEncryption.customerKey = nil
 	s3ServerSideEncryption.customerMd5 = nil
 }
 
+// s3Settings are the settings of the operations on the backups of a
+// keyspace: the flags, overridden by its backup storage config.
+type s3Settings struct {
+	bucket            string
+	root              string
+	sse               S3ServerSideEncryption
+	partSize          int64
+	uploadConcurrency int
+	requestOptions    []request.Option
+}
+
+func newS3Settings(config *topodatapb.BackupStorageConfig_S3) (*s3Settings, error) {
+	if config == nil {
+		config = &topodatapb.BackupStorageConfig_S3{}
+	}
+	s := &s3Settings{
+		bucket:            *bucket,
+		root:              *root,
+		partSize:          *uploadPartSize,
+		uploadConcurrency: *uploadConcurrency,
+	}
+	if config.Bucket != "" {
+		s.bucket = config.Bucket
+	}
+	if config.Root != "" {
+		s.root = config.Root
+	}
+	if config.UploadPartSize > 0 {
+		s.partSize = config.UploadPartSize
+	}
+	if config.UploadConcurrency > 0 {
+		s.uploadConcurrency = int(config.UploadConcurrency)
+	}
+	if len(s.bucket) == 0 {
+		return nil, fmt.Errorf("-s3_backup_storage_bucket or the bucket of the backup storage config of the keyspace required")
+	}
+
+	alg, kmsKeyID := *sse, *sseKMSKeyID
+	if config.ServerSideEncryption != "" {
+		alg, kmsKeyID = config.ServerSideEncryption, ""
+	}
+	if config.SseKmsKeyId != "" {
+		kmsKeyID = config.SseKmsKeyId
+	}
+	if err := s.sse.initWith(alg, kmsKeyID); err != nil {
+		return nil, err
+	}
+
+	retries, minDelay, maxDelay := *retryCount, *minRetryDelay, *maxRetryDelay
+	switch {
+	case config.Retries > 0:
+		retries = int(config.Retries)
+	case config.Retries < 0:
+		retries = 0
+	}
+	if d, ok, err := protoutil.DurationFromProto(config.MinRetryDelay); err != nil {
+		return nil, fmt.Errorf("invalid min_retry_delay: %v", err)
+	} else if ok {
+		minDelay = d
+	}
+	if d, ok, err := protoutil.DurationFromProto(config.MaxRetryDelay); err != nil {
+		return nil, fmt.Errorf("invalid max_retry_delay: %v", err)
+	} else if ok {
+		maxDelay = d
+	}
+	if retries >= 0 || minDelay > 0 || maxDelay > 0 {
+		if retries < 0 {
+			retries = client.DefaultRetryerMaxNumRetries
+		}
+		retryer := &ClosedConnectionRetryer{
+			awsRetryer: &client.DefaultRetryer{
+				NumMaxRetries: retries,
+				MinRetryDelay: minDelay,
+				MaxRetryDelay: maxDelay,
+			},
+		}
+		s.requestOptions = append(s.requestOptions, func(r *request.Request) {
+			r.Retryer = retryer
+		})
+	}
+	return s, nil
+}
+
+// objName returns the name of the object of the parts, under the root.
+func (s *s3Settings) objName(parts ...string) *string {
+	res := ""
+	if s.root != "" {
+		res += s.root + delimiter
+	}
+	res += strings.Join(parts, delimiter)
+	return &res
+}
+
 // S3BackupStorage implements the backupstorage.BackupStorage interface.
 type S3BackupStorage struct {
 	_client *s3.S3
 	mu      sync.Mutex
-	s3SSE   S3ServerSideEncryption
+	// checkedBuckets are the buckets known to exist.
+	checkedBuckets map[string]bool
+}
+
+// settings returns the settings of the operations on the backups of the
+// directory, and checks their bucket exists.
+func (bs *S3BackupStorage) settings(ctx context.Context, c *s3.S3, dir string) (*s3Settings, error) {
+	config, err := backupstorage.KeyspaceConfig(ctx, dir)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the backup storage config of %v: %v", dir, err)
+	}
+	s, err := newS3Settings(config.GetS3())
+	if err != nil {
+		return nil, err
+	}
+
+	bs.mu.Lock()
+	checked := bs.checkedBuckets[s.bucket]
+	bs.mu.Unlock()
+	if !checked {
+		if _, err := c.HeadBucketWithContext(ctx, &s3.HeadBucketInput{Bucket: &s.bucket}, s.requestOptions...); err != nil {
+			return nil, err
+		}
+		bs.mu.Lock()
+		if bs.checkedBuckets == nil {
+			bs.checkedBuckets = make(map[string]bool)
+		}
+		bs.checkedBuckets[s.bucket] = true
+		bs.mu.Unlock()
+	}
+	return s, nil
 }
 
 // ListBackups is part of the backupstorage.BackupStorage interface.
 func (bs *S3BackupStorage) ListBackups(ctx context.Context, dir string) ([]backupstorage.BackupHandle, error) {
-	log.Infof("ListBackups: [s3] dir: %v, bucket: %v", dir, *bucket)
 	c, err := bs.client()
 	if err != nil {
 		return nil, err
 	}
+	s, err := bs.settings(ctx, c, dir)
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("ListBackups: [s3] dir: %v, bucket: %v", dir, s.bucket)
 
 	var searchPrefix *string
 	if dir == "/" {
-		searchPrefix = objName("")
+		searchPrefix = s.objName("")
 	} else {
-		searchPrefix = objName(dir, "")
+		searchPrefix = s.objName(dir, "")
 	}
 	log.Infof("objName: %v", searchPrefix)
 
 	query := &s3.ListObjectsV2Input{
-		Bucket:    bucket,
+		Bucket:    &s.bucket,
 		Delimiter: &delimiter,
 		Prefix:    searchPrefix,
 	}
 
 	var subdirs []string
 	for {
-		objs, err := c.ListObjectsV2(query)
+		objs, err := c.ListObjectsV2WithContext(ctx, query, s.requestOptions...)
 		if err != nil {
 			return nil, err
 		}
@@ -304,6 +488,7 @@ func (bs *S3BackupStorage) ListBackups(ctx context.Context, dir string) ([]backu
 		result = append(result, &S3BackupHandle{
 			client:   c,
 			bs:       bs,
+			settings: s,
 			dir:      dir,
 			name:     subdir,
 			readOnly: true,
@@ -314,15 +499,20 @@ func (bs *S3BackupStorage) ListBackups(ctx context.Context, dir string) ([]backu
 
 // StartBackup is part of the backupstorage.BackupStorage interface.
 func (bs *S3BackupStorage) StartBackup(ctx context.Context, dir, name string) (backupstorage.BackupHandle, error) {
-	log.Infof("StartBackup: [s3] dir: %v, name: %v, bucket: %v", dir, name, *bucket)
 	c, err := bs.client()
 	if err != nil {
 		return nil, err
 	}
+	s, err := bs.settings(ctx, c, dir)
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("StartBackup: [s3] dir: %v, name: %v, bucket: %v", dir, name, s.bucket)
 
 	return &S3BackupHandle{
 		client:   c,
 		bs:       bs,
+		settings: s,
 		dir:      dir,
 		name:     name,
 		readOnly: false,
@@ -331,20 +521,23 @@ func (bs *S3BackupStorage) StartBackup(ctx context.Context, dir, name string) (b
 
 // RemoveBackup is part of the backupstorage.BackupStorage interface.
 func (bs *S3BackupStorage) RemoveBackup(ctx context.Context, dir, name string) error {
-	log.Infof("RemoveBackup: [s3] dir: %v, name: %v, bucket: %v", dir, name, *bucket)
-
 	c, err := bs.client()
 	if err != nil {
 		return err
 	}
+	s, err := bs.settings(ctx, c, dir)
+	if err != nil {
+		return err
+	}
+	log.Infof("RemoveBackup: [s3] dir: %v, name: %v, bucket: %v", dir, name, s.bucket)
 
 	query := &s3.ListObjectsV2Input{
-		Bucket: bucket,
-		Prefix: objName(dir, name),
+		Bucket: &s.bucket,
+		Prefix: s.objName(dir, name),
 	}
 
 	for {
-		objs, err := c.ListObjectsV2(query)
+		objs, err := c.ListObjectsV2WithContext(ctx, query, s.requestOptions...)
 		if err != nil {
 			return err
 		}
@@ -357,13 +550,13 @@ func (bs *S3BackupStorage) RemoveBackup(ctx context.Context, dir, name string) e
 		}
 
 		quiet := true // return less in the Delete response
-		out, err := c.DeleteObjects(&s3.DeleteObjectsInput{
-			Bucket: bucket,
+		out, err := c.DeleteObjectsWithContext(ctx, &s3.DeleteObjectsInput{
+			Bucket: &s.bucket,
 			Delete: &s3.Delete{
 				Objects: objIds,
 				Quiet:   &quiet,
 			},
-		})
+		}, s.requestOptions...)
 
 		if err != nil {
 			return err
@@ -388,7 +581,7 @@ func (bs *S3BackupStorage) Close() error {
 	bs.mu.Lock()
 	defer bs.mu.Unlock()
 	bs._client = nil
-	bs.s3SSE.reset()
+	bs.checkedBuckets = nil
 	return nil
 }
 
@@ -419,6 +612,8 @@ func (bs *S3BackupStorage) client() (*s3.S3, error) {
 			return nil, err
 		}
 
+		// The retries are set on each request, as they can be
+		// configured per keyspace.
 		awsConfig := aws.Config{
 			HTTPClient:       httpClient,
 			LogLevel:         logLevel,
@@ -427,40 +622,11 @@ func (bs *S3BackupStorage) client() (*s3.S3, error) {
 			S3ForcePathStyle: aws.Bool(*forcePath),
 		}
 
-		if *retryCount >= 0 {
-			awsConfig = *request.WithRetryer(&awsConfig, &ClosedConnectionRetryer{
-				awsRetryer: &client.DefaultRetryer{
-					NumMaxRetries: *retryCount,
-				},
-			})
-		}
-
 		bs._client = s3.New(session, &awsConfig)
-
-		if len(*bucket) == 0 {
-			return nil, fmt.Errorf("-s3_backup_storage_bucket required")
-		}
-
-		if _, err := bs._client.HeadBucket(&s3.HeadBucketInput{Bucket: bucket}); err != nil {
-			return nil, err
-		}
-
-		if err := bs.s3SSE.init(); err != nil {
-			return nil, err
-		}
 	}
 	return bs._client, nil
 }
 
-func objName(parts ...string) *string {
-	res := ""
-	if *root != "" {
-		res += *root + delimiter
-	}
-	res += strings.Join(parts, delimiter)
-	return &res
-}
-
 func init() {
 	backupstorage.BackupStorageMap["s3"] = &S3BackupStorage{}
 
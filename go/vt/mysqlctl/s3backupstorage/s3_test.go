@@ -5,17 +5,26 @@ import (
 	"crypto/rand"
 	"encoding/base64"
 	"errors"
+	"flag"
 	"io/ioutil"
+	"math"
 	"net/http"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/client"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/protoutil"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	"vitess.io/vitess/go/vt/proto/vttime"
 )
 
 type s3ErrorClient struct{ s3iface.S3API }
@@ -30,7 +39,7 @@ func (s3errclient *s3ErrorClient) PutObjectRequest(in *s3.PutObjectInput) (*requ
 }
 
 func TestAddFileError(t *testing.T) {
-	bh := &S3BackupHandle{client: &s3ErrorClient{}, bs: &S3BackupStorage{}, readOnly: false}
+	bh := &S3BackupHandle{client: &s3ErrorClient{}, bs: &S3BackupStorage{}, settings: &s3Settings{}, readOnly: false}
 
 	wc, err := bh.AddFile(aws.BackgroundContext(), "somefile", 100000)
 	require.NoErrorf(t, err, "AddFile() expected no error, got %s", err)
@@ -98,6 +107,18 @@ func TestSSECustomerFileNotFound(t *testing.T) {
 	require.Errorf(t, err, "init() expected to fail")
 }
 
+func TestSSECustomerSecretTimeout(t *testing.T) {
+	defer flag.Set("secrets_resolve_timeout", flag.Lookup("secrets_resolve_timeout").Value.String())
+	flag.Set("secrets_resolve_timeout", "100ms")
+
+	sse = aws.String(sseCustomerPrefix + "secret://exec/sleep 10")
+	sseData := S3ServerSideEncryption{}
+	start := time.Now()
+	err := sseData.init()
+	require.Errorf(t, err, "init() expected to fail")
+	assert.Less(t, int64(time.Since(start)), int64(5*time.Second), "init() expected to give up on the secret resolution")
+}
+
 func TestSSECustomerFileBinaryKey(t *testing.T) {
 	tempFile, err := ioutil.TempFile("", "filename")
 	require.NoErrorf(t, err, "TempFile() expected to succeed")
@@ -165,3 +186,69 @@ func TestSSECustomerFileBase64Key(t *testing.T) {
 	assert.Nil(t, sseData.customerKey, "customerKey expected to be nil")
 	assert.Nil(t, sseData.customerMd5, "customerMd5 expected to be nil")
 }
+
+func TestSSEAwsKMSKeyID(t *testing.T) {
+	sseData := S3ServerSideEncryption{}
+	err := sseData.initWith("aws:kms", "some-key")
+	require.NoErrorf(t, err, "initWith() expected to succeed")
+	assert.Equal(t, aws.String("aws:kms"), sseData.awsAlg, "awsAlg expected to be aws:kms")
+	assert.Equal(t, aws.String("some-key"), sseData.kmsKeyID, "kmsKeyID expected to be some-key")
+
+	sseData.reset()
+	assert.Nil(t, sseData.kmsKeyID, "kmsKeyID expected to be nil")
+
+	err = sseData.initWith("AES256", "some-key")
+	require.Errorf(t, err, "initWith() expected to fail with a KMS key and AES256")
+}
+
+func TestNewS3Settings(t *testing.T) {
+	defer func(b, r, s string) { *bucket, *root, *sse = b, r, s }(*bucket, *root, *sse)
+	*bucket, *root, *sse = "flag-bucket", "flag-root", ""
+
+	s, err := newS3Settings(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "flag-bucket", s.bucket)
+	assert.Equal(t, "flag-root/ks/0/backup/file", *s.objName("ks/0", "backup", "file"))
+	assert.Nil(t, s.sse.awsAlg)
+	assert.Empty(t, s.requestOptions)
+
+	s, err = newS3Settings(&topodatapb.BackupStorageConfig_S3{
+		Bucket:               "ks-bucket",
+		Root:                 "ks-root",
+		ServerSideEncryption: "aws:kms",
+		SseKmsKeyId:          "ks-key",
+		UploadPartSize:       64 * 1024 * 1024,
+		UploadConcurrency:    8,
+		Retries:              5,
+		MaxRetryDelay:        protoutil.DurationToProto(10 * time.Second),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "ks-bucket", s.bucket)
+	assert.Equal(t, "ks-root/ks/0/backup/file", *s.objName("ks/0", "backup", "file"))
+	assert.Equal(t, aws.String("aws:kms"), s.sse.awsAlg)
+	assert.Equal(t, aws.String("ks-key"), s.sse.kmsKeyID)
+	assert.EqualValues(t, 64*1024*1024, s.partSize)
+	assert.Equal(t, 8, s.uploadConcurrency)
+	require.Len(t, s.requestOptions, 1)
+	r := &request.Request{}
+	s.requestOptions[0](r)
+	retryer := r.Retryer.(*ClosedConnectionRetryer).awsRetryer.(*client.DefaultRetryer)
+	assert.Equal(t, 5, retryer.NumMaxRetries)
+	assert.Equal(t, 10*time.Second, retryer.MaxRetryDelay)
+
+	// A negative number of retries disables them.
+	s, err = newS3Settings(&topodatapb.BackupStorageConfig_S3{Retries: -1})
+	require.NoError(t, err)
+	require.Len(t, s.requestOptions, 1)
+	r = &request.Request{}
+	s.requestOptions[0](r)
+	retryer = r.Retryer.(*ClosedConnectionRetryer).awsRetryer.(*client.DefaultRetryer)
+	assert.Equal(t, 0, retryer.NumMaxRetries)
+
+	_, err = newS3Settings(&topodatapb.BackupStorageConfig_S3{MinRetryDelay: &vttime.Duration{Seconds: math.MaxInt64}})
+	assert.Error(t, err)
+
+	*bucket = ""
+	_, err = newS3Settings(nil)
+	assert.Error(t, err)
+}
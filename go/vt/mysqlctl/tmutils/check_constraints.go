@@ -0,0 +1,186 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tmutils
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// This file contains helper methods to deal with the CHECK constraints of
+// tables. MySQL enforces them from 8.0.16 on, and only parses and ignores
+// them before. Their names are unique in a schema, not only in a table.
+
+var (
+	checkConstraintRegexp = regexp.MustCompile("(?is)^\\s*(CONSTRAINT\\s+(`[^`]*`|\\w+)\\s+)?CHECK\\s*\\(")
+	notEnforcedRegexp     = regexp.MustCompile(`(?i)\bNOT\s+ENFORCED\b`)
+)
+
+// CheckConstraint is a CHECK constraint of a table.
+type CheckConstraint struct {
+	// Name is the name of the constraint. It is empty if the constraint
+	// doesn't have an explicit name.
+	Name string
+	// Expression is the normalized expression of the constraint.
+	Expression string
+	// Enforced is false for the constraints defined as NOT ENFORCED.
+	Enforced bool
+}
+
+// String returns the constraint as in a CREATE TABLE statement.
+func (cc *CheckConstraint) String() string {
+	s := fmt.Sprintf("CONSTRAINT %v CHECK (%v)", cc.Name, cc.Expression)
+	if !cc.Enforced {
+		s += " NOT ENFORCED"
+	}
+	return s
+}
+
+// ParseCheckConstraints returns the CHECK constraints of a table from its
+// CREATE TABLE statement, in order.
+func ParseCheckConstraints(createTable string) ([]*CheckConstraint, error) {
+	_, definitions, _, err := splitTableDefinitions(createTable)
+	if err != nil {
+		return nil, err
+	}
+	var constraints []*CheckConstraint
+	for _, definition := range definitions {
+		cc, err := parseCheckConstraint(definition)
+		if err != nil {
+			return nil, err
+		}
+		if cc != nil {
+			constraints = append(constraints, cc)
+		}
+	}
+	return constraints, nil
+}
+
+// RewriteCheckConstraints returns the CREATE TABLE statement of a table
+// with its named CHECK constraints renamed to the name returned by
+// rename, or removed if it returns an empty name. The rest of the
+// statement is unchanged.
+func RewriteCheckConstraints(createTable string, rename func(name string) string) (string, error) {
+	head, definitions, tail, err := splitTableDefinitions(createTable)
+	if err != nil {
+		return "", err
+	}
+	rewritten := make([]string, 0, len(definitions))
+	for _, definition := range definitions {
+		cc, err := parseCheckConstraint(definition)
+		if err != nil {
+			return "", err
+		}
+		if cc == nil || cc.Name == "" {
+			rewritten = append(rewritten, definition)
+			continue
+		}
+		name := rename(cc.Name)
+		if name == "" {
+			continue
+		}
+		match := checkConstraintRegexp.FindStringSubmatchIndex(definition)
+		rewritten = append(rewritten, definition[:match[4]]+"`"+name+"`"+definition[match[5]:])
+	}
+	return head + "(" + strings.Join(rewritten, ",") + ")" + tail, nil
+}
+
+// parseCheckConstraint parses a table definition. It returns nil if it
+// isn't a CHECK constraint.
+func parseCheckConstraint(definition string) (*CheckConstraint, error) {
+	match := checkConstraintRegexp.FindStringSubmatchIndex(definition)
+	if match == nil {
+		return nil, nil
+	}
+	expression, rest, err := readParenthesized(definition[match[1]-1:])
+	if err != nil {
+		return nil, fmt.Errorf("bad check constraint: %v: %v", definition, err)
+	}
+	cc := &CheckConstraint{
+		Expression: normalizeExpression(expression),
+		Enforced:   !notEnforcedRegexp.MatchString(rest),
+	}
+	if match[4] != -1 {
+		cc.Name = strings.Trim(definition[match[4]:match[5]], "`")
+	}
+	return cc, nil
+}
+
+// diffCheckConstraints returns the differences between the CHECK
+// constraints of two CREATE TABLE statements of a table.
+func diffCheckConstraints(leftName, left, rightName, right string) []string {
+	leftConstraints, leftErr := ParseCheckConstraints(left)
+	rightConstraints, rightErr := ParseCheckConstraints(right)
+	if leftErr != nil || rightErr != nil {
+		return nil
+	}
+	byName := func(constraints []*CheckConstraint) map[string]*CheckConstraint {
+		m := make(map[string]*CheckConstraint, len(constraints))
+		for _, cc := range constraints {
+			m[cc.Name] = cc
+		}
+		return m
+	}
+	leftByName := byName(leftConstraints)
+	rightByName := byName(rightConstraints)
+
+	var diffs []string
+	missing := func(name string, constraints map[string]*CheckConstraint, others []*CheckConstraint) {
+		var names []string
+		for _, cc := range others {
+			if _, ok := constraints[cc.Name]; !ok {
+				names = append(names, cc.Name)
+			}
+		}
+		if len(names) == 0 {
+			return
+		}
+		sort.Strings(names)
+		diff := fmt.Sprintf("%v is missing the check constraints %v", name, strings.Join(names, ", "))
+		if len(constraints) == 0 {
+			diff += " (MySQL ignores check constraints before 8.0.16)"
+		}
+		diffs = append(diffs, diff)
+	}
+	missing(leftName, leftByName, rightConstraints)
+	missing(rightName, rightByName, leftConstraints)
+	for _, cc := range leftConstraints {
+		if other, ok := rightByName[cc.Name]; ok && *cc != *other {
+			diffs = append(diffs, fmt.Sprintf("check constraint %v differs: %v: %v, %v: %v", cc.Name, leftName, cc, rightName, other))
+		}
+	}
+	return diffs
+}
+
+// stripCheckConstraints returns the CREATE TABLE statement of a table
+// without its CHECK constraints, or the statement as is if it can't be
+// parsed.
+func stripCheckConstraints(createTable string) string {
+	head, definitions, tail, err := splitTableDefinitions(createTable)
+	if err != nil {
+		return createTable
+	}
+	kept := make([]string, 0, len(definitions))
+	for _, definition := range definitions {
+		if cc, err := parseCheckConstraint(definition); err != nil || cc == nil {
+			kept = append(kept, strings.TrimRight(definition, " \t\n"))
+		}
+	}
+	return head + "(" + strings.Join(kept, ",") + ")" + strings.TrimLeft(tail, " \t\n")
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tmutils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+)
+
+const checkTable = "CREATE TABLE `t` (\n" +
+	"  `id` bigint NOT NULL,\n" +
+	"  `price` int DEFAULT NULL,\n" +
+	"  PRIMARY KEY (`id`),\n" +
+	"  CONSTRAINT `price_positive` CHECK ((`price` > 0)),\n" +
+	"  CONSTRAINT `t_chk_1` CHECK ((`price` < 1000)) /*!80016 NOT ENFORCED */\n" +
+	") ENGINE=InnoDB"
+
+func TestParseCheckConstraints(t *testing.T) {
+	constraints, err := ParseCheckConstraints(checkTable)
+	require.NoError(t, err)
+	assert.Equal(t, []*CheckConstraint{
+		{Name: "price_positive", Expression: "price > 0", Enforced: true},
+		{Name: "t_chk_1", Expression: "price < 1000"},
+	}, constraints)
+	assert.Equal(t, "CONSTRAINT t_chk_1 CHECK (price < 1000) NOT ENFORCED", constraints[1].String())
+
+	constraints, err = ParseCheckConstraints(partitionedTable)
+	require.NoError(t, err)
+	assert.Empty(t, constraints)
+}
+
+func TestRewriteCheckConstraints(t *testing.T) {
+	rewritten, err := RewriteCheckConstraints(checkTable, func(name string) string {
+		if name == "price_positive" {
+			return ""
+		}
+		return name + "_new"
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "CREATE TABLE `t` (\n"+
+		"  `id` bigint NOT NULL,\n"+
+		"  `price` int DEFAULT NULL,\n"+
+		"  PRIMARY KEY (`id`),\n"+
+		"  CONSTRAINT `t_chk_1_new` CHECK ((`price` < 1000)) /*!80016 NOT ENFORCED */\n"+
+		") ENGINE=InnoDB", rewritten)
+
+	_, err = RewriteCheckConstraints("CREATE TABLE `t`", strings.ToUpper)
+	assert.Error(t, err)
+}
+
+func TestSchemaDiffCheckConstraints(t *testing.T) {
+	sd := func(schema string) *tabletmanagerdatapb.SchemaDefinition {
+		return &tabletmanagerdatapb.SchemaDefinition{
+			TableDefinitions: []*tabletmanagerdatapb.TableDefinition{{Name: "t", Schema: schema, Type: TableBaseTable}},
+		}
+	}
+	// MySQL 5.7 doesn't keep the check constraints.
+	ignored := "CREATE TABLE `t` (\n" +
+		"  `id` bigint NOT NULL,\n" +
+		"  `price` int DEFAULT NULL,\n" +
+		"  PRIMARY KEY (`id`)\n" +
+		") ENGINE=InnoDB"
+	assert.Equal(t, []string{
+		"check constraints differ on table t: sd2 is missing the check constraints price_positive, t_chk_1 (MySQL ignores check constraints before 8.0.16)",
+	}, DiffSchemaToArray("sd1", sd(checkTable), "sd2", sd(ignored)))
+
+	changed := strings.Replace(checkTable, "`price` > 0", "`price` >= 0", 1)
+	assert.Equal(t, []string{
+		"check constraints differ on table t: check constraint price_positive differs: sd1: CONSTRAINT price_positive CHECK (price > 0), sd2: CONSTRAINT price_positive CHECK (price >= 0)",
+	}, DiffSchemaToArray("sd1", sd(checkTable), "sd2", sd(changed)))
+
+	// Other differences are reported as before.
+	assert.Len(t, DiffSchemaToArray("sd1", sd(checkTable), "sd2", sd(strings.Replace(ignored, "bigint", "int", 1))), 1)
+}
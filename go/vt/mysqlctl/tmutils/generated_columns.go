@@ -0,0 +1,248 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tmutils
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// This file contains helper methods to deal with the expressions of the
+// generated columns and of the functional indexes of tables. MySQL
+// versions render them differently in SHOW CREATE TABLE, e.g. with or
+// without quoted identifiers, charset introducers or extra parenthesis,
+// so they are normalized before two schemas are compared.
+
+var (
+	generatedColumnRegexp = regexp.MustCompile(`(?i)\s(GENERATED\s+ALWAYS\s+)?AS\s*\(`)
+	storedColumnRegexp    = regexp.MustCompile(`(?i)\b(STORED|PERSISTENT)\b`)
+	indexRegexp           = regexp.MustCompile("(?i)^(PRIMARY\\s+KEY|(UNIQUE\\s+|FULLTEXT\\s+|SPATIAL\\s+)?(KEY|INDEX))\\s*(`[^`]*`|\\w+)?\\s*\\(")
+	constraintKeywords    = []string{"PRIMARY", "UNIQUE", "KEY", "INDEX", "FULLTEXT", "SPATIAL", "CONSTRAINT", "FOREIGN", "CHECK"}
+)
+
+// GeneratedColumn is a generated column of a table.
+type GeneratedColumn struct {
+	// Name is the name of the column, in lower case.
+	Name string
+	// Expression is the normalized generation expression.
+	Expression string
+	// Stored is true for the stored columns, and false for the virtual
+	// ones.
+	Stored bool
+}
+
+// ParseGeneratedColumns returns the generated columns of a table from its
+// CREATE TABLE statement, by name in lower case.
+func ParseGeneratedColumns(createTable string) (map[string]*GeneratedColumn, error) {
+	_, definitions, _, err := splitTableDefinitions(createTable)
+	if err != nil {
+		return nil, err
+	}
+	columns := make(map[string]*GeneratedColumn)
+	for _, definition := range definitions {
+		gc, _, err := parseGeneratedColumn(definition)
+		if err != nil {
+			return nil, err
+		}
+		if gc != nil {
+			columns[gc.Name] = gc
+		}
+	}
+	return columns, nil
+}
+
+// NormalizeTableExpressions returns the CREATE TABLE statement of a table
+// with the expressions of its generated columns and of its functional
+// index parts normalized. The rest of the statement is unchanged. The
+// statement is returned as is if it can't be parsed.
+func NormalizeTableExpressions(createTable string) string {
+	head, definitions, tail, err := splitTableDefinitions(createTable)
+	if err != nil {
+		return createTable
+	}
+	for i, definition := range definitions {
+		if _, normalized, err := parseGeneratedColumn(definition); err == nil && normalized != "" {
+			definitions[i] = normalized
+			continue
+		}
+		if normalized, err := normalizeIndex(definition); err == nil && normalized != "" {
+			definitions[i] = normalized
+		}
+	}
+	return head + "(" + strings.Join(definitions, ",") + ")" + tail
+}
+
+// splitTableDefinitions splits a CREATE TABLE statement into the text
+// before the parenthesis of its definitions, its column and index
+// definitions, and the text after them.
+func splitTableDefinitions(createTable string) (head string, definitions []string, tail string, err error) {
+	start := strings.Index(createTable, "(")
+	if start == -1 {
+		return "", nil, "", fmt.Errorf("no definitions in: %v", createTable)
+	}
+	inside, _, err := readParenthesized(createTable[start:])
+	if err != nil {
+		return "", nil, "", err
+	}
+	end := start + 1 + len(inside)
+	return createTable[:start], splitTopLevel(inside), createTable[end+1:], nil
+}
+
+// parseGeneratedColumn parses a column definition. It returns nil if the
+// column isn't generated, else the column and its definition with the
+// generation expression normalized.
+func parseGeneratedColumn(definition string) (*GeneratedColumn, string, error) {
+	trimmed := strings.TrimSpace(definition)
+	name := ""
+	if trimmed == "" {
+		return nil, "", nil
+	}
+	if strings.HasPrefix(trimmed, "`") {
+		end := strings.Index(trimmed[1:], "`")
+		if end == -1 {
+			return nil, "", fmt.Errorf("bad column definition: %v", definition)
+		}
+		name = trimmed[1 : end+1]
+	} else {
+		name = strings.Fields(trimmed)[0]
+		for _, keyword := range constraintKeywords {
+			if strings.EqualFold(name, keyword) {
+				return nil, "", nil
+			}
+		}
+	}
+	loc := generatedColumnRegexp.FindStringIndex(definition)
+	if loc == nil {
+		return nil, "", nil
+	}
+	expression, rest, err := readParenthesized(definition[loc[1]-1:])
+	if err != nil {
+		return nil, "", fmt.Errorf("bad generated column %v: %v", name, err)
+	}
+	gc := &GeneratedColumn{
+		Name:       strings.ToLower(name),
+		Expression: normalizeExpression(expression),
+		Stored:     storedColumnRegexp.MatchString(rest),
+	}
+	normalized := definition[:loc[0]] + " GENERATED ALWAYS AS (" + gc.Expression + ")"
+	if rest != "" {
+		normalized += " " + rest
+	}
+	return gc, normalized, nil
+}
+
+// normalizeIndex returns the definition of an index with the expressions
+// of its functional key parts normalized, or "" if it isn't an index with
+// functional key parts.
+func normalizeIndex(definition string) (string, error) {
+	trimmed := strings.TrimSpace(definition)
+	loc := indexRegexp.FindStringIndex(trimmed)
+	if loc == nil {
+		return "", nil
+	}
+	keyParts, rest, err := readParenthesized(trimmed[loc[1]-1:])
+	if err != nil {
+		return "", err
+	}
+	functional := false
+	parts := splitTopLevel(keyParts)
+	for i, part := range parts {
+		part = strings.TrimSpace(part)
+		if !strings.HasPrefix(part, "(") {
+			parts[i] = part
+			continue
+		}
+		expression, order, err := readParenthesized(part)
+		if err != nil {
+			return "", err
+		}
+		functional = true
+		parts[i] = "(" + normalizeExpression(expression) + ")"
+		if order != "" {
+			parts[i] += " " + strings.ToUpper(order)
+		}
+	}
+	if !functional {
+		return "", nil
+	}
+	normalized := trimmed[:loc[1]] + strings.Join(parts, ",") + ")"
+	if rest != "" {
+		normalized += " " + rest
+	}
+	return normalized, nil
+}
+
+// normalizeExpression makes the formatting of an expression canonical:
+// without quoted identifiers nor charset introducers, in lower case outside
+// of string literals, with single spaces and none around parenthesis and
+// commas, and without enclosing parenthesis.
+func normalizeExpression(expression string) string {
+	var b strings.Builder
+	var quote byte
+	space := false
+	write := func(s string) {
+		if space && b.Len() > 0 && !strings.ContainsAny(s[:1], "(),") && !strings.HasSuffix(b.String(), "(") && !strings.HasSuffix(b.String(), ",") {
+			b.WriteByte(' ')
+		}
+		space = false
+		b.WriteString(s)
+	}
+	for i := 0; i < len(expression); i++ {
+		c := expression[i]
+		switch {
+		case quote != 0:
+			b.WriteByte(c)
+			if c == '\\' && i+1 < len(expression) {
+				i++
+				b.WriteByte(expression[i])
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			write(string(c))
+			quote = c
+		case c == '`':
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			space = true
+		case c == '_' && (i == 0 || !isIdentifierByte(expression[i-1])):
+			end := i + 1
+			for end < len(expression) && isIdentifierByte(expression[end]) {
+				end++
+			}
+			if end == len(expression) || expression[end] != '\'' {
+				write(strings.ToLower(expression[i:end]))
+			}
+			i = end - 1
+		default:
+			write(strings.ToLower(string(c)))
+		}
+	}
+	s := b.String()
+	for strings.HasPrefix(s, "(") {
+		inside, rest, err := readParenthesized(s)
+		if err != nil || rest != "" {
+			break
+		}
+		s = inside
+	}
+	return s
+}
+
+func isIdentifierByte(c byte) bool {
+	return c == '_' || c == '$' || (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
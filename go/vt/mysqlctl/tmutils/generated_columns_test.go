@@ -0,0 +1,97 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tmutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+)
+
+// generatedTable57 and generatedTable80 are the same table, as rendered
+// by MySQL 5.7 and 8.0.
+const (
+	generatedTable57 = "CREATE TABLE `t` (\n" +
+		"  `id` bigint NOT NULL,\n" +
+		"  `doc` json DEFAULT NULL,\n" +
+		"  `doc_id` bigint GENERATED ALWAYS AS (json_extract(`doc`,'$.id')) STORED NOT NULL,\n" +
+		"  `Name` varchar(64) GENERATED ALWAYS AS (json_unquote(json_extract(`doc`,'$.Name'))) VIRTUAL,\n" +
+		"  PRIMARY KEY (`id`),\n" +
+		"  KEY `name_idx` ((lower(`Name`)) DESC, `id`)\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4"
+	generatedTable80 = "CREATE TABLE `t` (\n" +
+		"  `id` bigint NOT NULL,\n" +
+		"  `doc` json DEFAULT NULL,\n" +
+		"  `doc_id` bigint GENERATED ALWAYS AS ((json_extract(`doc`,_utf8mb4'$.id'))) STORED NOT NULL,\n" +
+		"  `Name` varchar(64) GENERATED ALWAYS AS (JSON_UNQUOTE(json_extract(`doc`, _utf8mb4'$.Name'))) VIRTUAL,\n" +
+		"  PRIMARY KEY (`id`),\n" +
+		"  KEY `name_idx` ((lower( `Name` )) desc, `id`)\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4"
+)
+
+func TestParseGeneratedColumns(t *testing.T) {
+	columns, err := ParseGeneratedColumns(generatedTable80)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]*GeneratedColumn{
+		"doc_id": {Name: "doc_id", Expression: "json_extract(doc,'$.id')", Stored: true},
+		"name":   {Name: "name", Expression: "json_unquote(json_extract(doc,'$.Name'))"},
+	}, columns)
+
+	columns, err = ParseGeneratedColumns(partitionedTable)
+	require.NoError(t, err)
+	assert.Empty(t, columns)
+
+	_, err = ParseGeneratedColumns("CREATE TABLE `t` (`a` int GENERATED ALWAYS AS (`b` + 1")
+	assert.Error(t, err)
+}
+
+func TestNormalizeTableExpressions(t *testing.T) {
+	assert.Equal(t, NormalizeTableExpressions(generatedTable57), NormalizeTableExpressions(generatedTable80))
+	assert.Equal(t, partitionedTable, NormalizeTableExpressions(partitionedTable))
+
+	// The string literals and the order of the key parts are kept.
+	assert.NotEqual(t, NormalizeTableExpressions(generatedTable57), NormalizeTableExpressions(
+		"CREATE TABLE `t` (`a` int GENERATED ALWAYS AS (json_extract(`doc`,'$.ID')) STORED NOT NULL)"))
+	assert.NotEqual(t,
+		NormalizeTableExpressions("CREATE TABLE `t` (`a` int, KEY `k` ((`a` + 1)))"),
+		NormalizeTableExpressions("CREATE TABLE `t` (`a` int, KEY `k` ((`a` + 1) DESC))"))
+	assert.Equal(t, "a + 1", normalizeExpression("((`a`  +\n1))"))
+	assert.Equal(t, "concat(a,' _x ')", normalizeExpression("CONCAT(`a`, _latin1' _x ')"))
+}
+
+func TestSchemaDiffGeneratedColumns(t *testing.T) {
+	sd := func(schema string) *tabletmanagerdatapb.SchemaDefinition {
+		return &tabletmanagerdatapb.SchemaDefinition{
+			TableDefinitions: []*tabletmanagerdatapb.TableDefinition{{Name: "t", Schema: schema, Type: TableBaseTable}},
+		}
+	}
+	assert.Empty(t, DiffSchemaToArray("sd1", sd(generatedTable57), "sd2", sd(generatedTable80)))
+
+	virtual := sd(generatedTable80)
+	virtual.TableDefinitions[0].Schema = "CREATE TABLE `t` (\n" +
+		"  `id` bigint NOT NULL,\n" +
+		"  `doc` json DEFAULT NULL,\n" +
+		"  `doc_id` bigint GENERATED ALWAYS AS ((json_extract(`doc`,_utf8mb4'$.id'))) VIRTUAL NOT NULL,\n" +
+		"  `Name` varchar(64) GENERATED ALWAYS AS (JSON_UNQUOTE(json_extract(`doc`, _utf8mb4'$.Name'))) VIRTUAL,\n" +
+		"  PRIMARY KEY (`id`),\n" +
+		"  KEY `name_idx` ((lower( `Name` )) desc, `id`)\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4"
+	assert.Len(t, DiffSchemaToArray("sd1", sd(generatedTable57), "sd2", virtual), 1)
+}
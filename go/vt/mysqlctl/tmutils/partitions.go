@@ -0,0 +1,294 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tmutils
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+)
+
+// This file contains helper methods to deal with the partitioning of
+// tables, as in the PARTITION BY clause of their CREATE TABLE statement.
+// The clause is compared structurally, so that e.g. the version comment
+// or the formatting MySQL uses for it don't make two schemas differ.
+
+var (
+	partitionByRegexp      = regexp.MustCompile(`(?is)(/\*!\d+\s*)?\bPARTITION\s+BY\s+`)
+	partitioningTypeRegexp = regexp.MustCompile(`(?i)^(LINEAR\s+)?(RANGE|LIST|HASH|KEY)(\s+COLUMNS)?\s*(ALGORITHM\s*=\s*\d+\s*)?`)
+	partitionsCountRegexp  = regexp.MustCompile(`(?i)^PARTITIONS\s+(\d+)\s*`)
+	partitionEngineRegexp  = regexp.MustCompile(`(?i)\s*(STORAGE\s+)?ENGINE\s*=?\s*\w+`)
+	whitespaceRegexp       = regexp.MustCompile(`\s+`)
+)
+
+// TablePartitioning is the partitioning of a table.
+type TablePartitioning struct {
+	// Type is the partitioning type, e.g. RANGE, LIST COLUMNS or
+	// LINEAR HASH.
+	Type string
+	// Expression is the partitioning expression, or the list of
+	// columns for the COLUMNS and KEY types.
+	Expression string
+	// Columns are the columns used by Expression. It is empty for KEY
+	// partitioning on the primary key.
+	Columns []string
+	// Count is the number of partitions given by PARTITIONS, if any.
+	Count string
+	// Subpartitioning is the SUBPARTITION BY clause, if any.
+	Subpartitioning string
+	// Partitions are the partitions defined explicitly, in order.
+	Partitions []*PartitionDefinition
+}
+
+// PartitionDefinition is a partition of a table.
+type PartitionDefinition struct {
+	Name string
+	// Values is the rest of the definition, e.g. VALUES LESS THAN (10),
+	// without the storage engine.
+	Values string
+}
+
+// SplitTablePartitioning splits a CREATE TABLE statement into the
+// definition of the table and its PARTITION BY clause, which is empty if
+// the table isn't partitioned.
+func SplitTablePartitioning(createTable string) (table, partitioning string) {
+	loc := partitionByRegexp.FindStringIndex(createTable)
+	if loc == nil {
+		return createTable, ""
+	}
+	table = strings.TrimSpace(createTable[:loc[0]])
+	partitioning = strings.TrimSpace(createTable[loc[0]:])
+	if strings.HasPrefix(partitioning, "/*!") {
+		partitioning = strings.TrimSpace(strings.TrimSuffix(partitioning, "*/"))
+		partitioning = partitioning[strings.IndexAny(partitioning, " \t\n"):]
+	}
+	return table, strings.TrimSpace(partitioning)
+}
+
+// ParseTablePartitioning parses the partitioning of a table from its
+// CREATE TABLE statement. It returns nil if the table isn't partitioned.
+func ParseTablePartitioning(createTable string) (*TablePartitioning, error) {
+	_, clause := SplitTablePartitioning(createTable)
+	if clause == "" {
+		return nil, nil
+	}
+	clause = whitespaceRegexp.ReplaceAllString(clause, " ")
+	rest := clause[partitionByRegexp.FindStringIndex(clause)[1]:]
+
+	match := partitioningTypeRegexp.FindStringSubmatch(rest)
+	if match == nil {
+		return nil, fmt.Errorf("unknown partitioning type in: %v", clause)
+	}
+	tp := &TablePartitioning{
+		Type: strings.ToUpper(strings.TrimSpace(match[1] + match[2] + match[3])),
+	}
+	tp.Type = whitespaceRegexp.ReplaceAllString(tp.Type, " ")
+	rest = rest[len(match[0]):]
+
+	expression, rest, err := readParenthesized(rest)
+	if err != nil {
+		return nil, fmt.Errorf("bad partitioning expression in %v: %v", clause, err)
+	}
+	tp.Expression = normalizePartitioningText(expression)
+	if tp.Columns, err = partitioningColumns(tp.Type, expression); err != nil {
+		return nil, fmt.Errorf("bad partitioning expression in %v: %v", clause, err)
+	}
+
+	if match := partitionsCountRegexp.FindStringSubmatch(rest); match != nil {
+		tp.Count = match[1]
+		rest = rest[len(match[0]):]
+	}
+	if strings.HasPrefix(strings.ToUpper(rest), "SUBPARTITION BY") {
+		end := strings.Index(strings.ToUpper(rest), "(PARTITION")
+		if end == -1 {
+			end = len(rest)
+		}
+		tp.Subpartitioning = normalizePartitioningText(rest[:end])
+		rest = rest[end:]
+	}
+	if rest == "" {
+		return tp, nil
+	}
+
+	definitions, rest, err := readParenthesized(rest)
+	if err != nil || rest != "" {
+		return nil, fmt.Errorf("bad partition definitions in: %v", clause)
+	}
+	for _, definition := range splitTopLevel(definitions) {
+		fields := strings.SplitN(strings.TrimSpace(definition), " ", 3)
+		if len(fields) < 2 || !strings.EqualFold(fields[0], "PARTITION") {
+			return nil, fmt.Errorf("bad partition definition %v in: %v", definition, clause)
+		}
+		pd := &PartitionDefinition{Name: strings.Trim(fields[1], "`")}
+		if len(fields) == 3 {
+			pd.Values = normalizePartitioningText(partitionEngineRegexp.ReplaceAllString(fields[2], ""))
+		}
+		tp.Partitions = append(tp.Partitions, pd)
+	}
+	return tp, nil
+}
+
+// DiffTablePartitioning returns the differences between two
+// partitionings of a table, which can be nil if the table isn't
+// partitioned.
+func DiffTablePartitioning(leftName string, left *TablePartitioning, rightName string, right *TablePartitioning) []string {
+	switch {
+	case left == nil && right == nil:
+		return nil
+	case left == nil:
+		return []string{fmt.Sprintf("%v is not partitioned, %v is partitioned by %v", leftName, rightName, right)}
+	case right == nil:
+		return []string{fmt.Sprintf("%v is partitioned by %v, %v is not partitioned", leftName, left, rightName)}
+	}
+
+	var diffs []string
+	if left.Type != right.Type || left.Expression != right.Expression || left.Subpartitioning != right.Subpartitioning {
+		diffs = append(diffs, fmt.Sprintf("%v is partitioned by %v, %v is partitioned by %v", leftName, left, rightName, right))
+	}
+	if left.Count != right.Count && len(left.Partitions) == 0 && len(right.Partitions) == 0 {
+		diffs = append(diffs, fmt.Sprintf("%v has %v partitions, %v has %v", leftName, left.Count, rightName, right.Count))
+	}
+	rightPartitions := make(map[string]*PartitionDefinition, len(right.Partitions))
+	for _, pd := range right.Partitions {
+		rightPartitions[pd.Name] = pd
+	}
+	leftPartitions := make(map[string]bool, len(left.Partitions))
+	for _, pd := range left.Partitions {
+		leftPartitions[pd.Name] = true
+		other, ok := rightPartitions[pd.Name]
+		switch {
+		case !ok:
+			diffs = append(diffs, fmt.Sprintf("%v has an extra partition %v", leftName, pd.Name))
+		case pd.Values != other.Values:
+			diffs = append(diffs, fmt.Sprintf("partition %v differs: %v: %v, %v: %v", pd.Name, leftName, pd.Values, rightName, other.Values))
+		}
+	}
+	for _, pd := range right.Partitions {
+		if !leftPartitions[pd.Name] {
+			diffs = append(diffs, fmt.Sprintf("%v has an extra partition %v", rightName, pd.Name))
+		}
+	}
+	return diffs
+}
+
+// String returns the partitioning type and expression, e.g.
+// RANGE (year(created)).
+func (tp *TablePartitioning) String() string {
+	return fmt.Sprintf("%v (%v)", tp.Type, tp.Expression)
+}
+
+// readParenthesized reads the text between the parenthesis which starts
+// s, and returns it with the rest of s.
+func readParenthesized(s string) (inside, rest string, err error) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "(") {
+		return "", "", fmt.Errorf("expected ( at: %v", s)
+	}
+	depth := 0
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth == 0 {
+				return s[1:i], strings.TrimSpace(s[i+1:]), nil
+			}
+		}
+	}
+	return "", "", fmt.Errorf("unbalanced parenthesis in: %v", s)
+}
+
+// splitTopLevel splits s on the commas which are not between parenthesis
+// or quotes.
+func splitTopLevel(s string) []string {
+	var parts []string
+	depth := 0
+	var quote byte
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case c == ',' && depth == 0:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// normalizePartitioningText makes the formatting of a part of the
+// partitioning clause canonical: without quoted identifiers, in
+// lower case, with single spaces and none around parenthesis and commas.
+func normalizePartitioningText(s string) string {
+	s = strings.ToLower(strings.ReplaceAll(s, "`", ""))
+	s = whitespaceRegexp.ReplaceAllString(strings.TrimSpace(s), " ")
+	for _, sep := range []string{"(", ")", ","} {
+		s = strings.ReplaceAll(s, " "+sep, sep)
+		s = strings.ReplaceAll(s, sep+" ", sep)
+	}
+	return s
+}
+
+// partitioningColumns returns the columns used by the partitioning
+// expression.
+func partitioningColumns(partitioningType, expression string) ([]string, error) {
+	if strings.HasSuffix(partitioningType, "COLUMNS") || strings.HasSuffix(partitioningType, "KEY") {
+		var columns []string
+		for _, column := range splitTopLevel(expression) {
+			if column = strings.Trim(strings.TrimSpace(column), "`"); column != "" {
+				columns = append(columns, strings.ToLower(column))
+			}
+		}
+		return columns, nil
+	}
+	// The columns of the expression are the only ones of the statement.
+	stmt, err := sqlparser.Parse("select " + expression + " from dual")
+	if err != nil {
+		return nil, err
+	}
+	var columns []string
+	seen := make(map[string]bool)
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		if col, ok := node.(*sqlparser.ColName); ok {
+			if name := col.Name.Lowered(); !seen[name] {
+				seen[name] = true
+				columns = append(columns, name)
+			}
+		}
+		return true, nil
+	}, stmt)
+	return columns, nil
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tmutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+)
+
+const partitionedTable = "CREATE TABLE `t` (\n" +
+	"  `id` bigint NOT NULL,\n" +
+	"  `created` datetime NOT NULL,\n" +
+	"  PRIMARY KEY (`id`,`created`)\n" +
+	") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4"
+
+func TestParseTablePartitioning(t *testing.T) {
+	tp, err := ParseTablePartitioning(partitionedTable)
+	require.NoError(t, err)
+	assert.Nil(t, tp)
+
+	tp, err = ParseTablePartitioning(partitionedTable + "\n/*!50100 PARTITION BY RANGE (year(`created`))\n" +
+		"(PARTITION p2020 VALUES LESS THAN (2021) ENGINE = InnoDB,\n" +
+		" PARTITION pmax VALUES LESS THAN MAXVALUE ENGINE = InnoDB) */")
+	require.NoError(t, err)
+	assert.Equal(t, "RANGE", tp.Type)
+	assert.Equal(t, "year(created)", tp.Expression)
+	assert.Equal(t, []string{"created"}, tp.Columns)
+	require.Len(t, tp.Partitions, 2)
+	assert.Equal(t, &PartitionDefinition{Name: "p2020", Values: "values less than(2021)"}, tp.Partitions[0])
+	assert.Equal(t, &PartitionDefinition{Name: "pmax", Values: "values less than maxvalue"}, tp.Partitions[1])
+
+	tp, err = ParseTablePartitioning(partitionedTable + " partition by linear key algorithm=2 (id, created) partitions 4")
+	require.NoError(t, err)
+	assert.Equal(t, "LINEAR KEY", tp.Type)
+	assert.Equal(t, []string{"id", "created"}, tp.Columns)
+	assert.Equal(t, "4", tp.Count)
+	assert.Empty(t, tp.Partitions)
+
+	_, err = ParseTablePartitioning(partitionedTable + " PARTITION BY SOMETHING (id)")
+	assert.Error(t, err)
+}
+
+func TestDiffTablePartitioning(t *testing.T) {
+	// The same partitioning, written by MySQL and by hand.
+	left := partitionedTable + "\n/*!50500 PARTITION BY RANGE  COLUMNS(`created`)\n" +
+		"(PARTITION p2020 VALUES LESS THAN ('2021-01-01') ENGINE = InnoDB,\n" +
+		" PARTITION p2021 VALUES LESS THAN ('2022-01-01') ENGINE = InnoDB) */"
+	right := partitionedTable + "\nPARTITION BY RANGE COLUMNS (created) (" +
+		"PARTITION p2020 VALUES LESS THAN ( '2021-01-01' ), " +
+		"PARTITION p2021 VALUES LESS THAN ( '2022-01-01' ))"
+	testDiff(t, schemaWithTable(left), schemaWithTable(right), "left", "right", nil)
+
+	// One more partition, and one which differs.
+	right = partitionedTable + "\nPARTITION BY RANGE COLUMNS (created) (" +
+		"PARTITION p2020 VALUES LESS THAN ('2020-07-01'), " +
+		"PARTITION p2021 VALUES LESS THAN ('2022-01-01'), " +
+		"PARTITION p2022 VALUES LESS THAN ('2023-01-01'))"
+	testDiff(t, schemaWithTable(left), schemaWithTable(right), "left", "right", []string{
+		"partitioning differs on table t: partition p2020 differs: left: values less than('2021-01-01'), right: values less than('2020-07-01'); right has an extra partition p2022",
+	})
+
+	// Another partitioning.
+	right = partitionedTable + "\nPARTITION BY HASH (id) PARTITIONS 4"
+	testDiff(t, schemaWithTable(left), schemaWithTable(right), "left", "right", []string{
+		"partitioning differs on table t: left is partitioned by RANGE COLUMNS (created), right is partitioned by HASH (id); left has an extra partition p2020; left has an extra partition p2021",
+	})
+
+	testDiff(t, schemaWithTable(partitionedTable), schemaWithTable(right), "left", "right", []string{
+		"partitioning differs on table t: left is not partitioned, right is partitioned by HASH (id)",
+	})
+}
+
+func schemaWithTable(createTable string) *tabletmanagerdatapb.SchemaDefinition {
+	return &tabletmanagerdatapb.SchemaDefinition{
+		TableDefinitions: []*tabletmanagerdatapb.TableDefinition{{
+			Name:   "t",
+			Schema: createTable,
+			Type:   TableBaseTable,
+		}},
+	}
+}
@@ -279,7 +279,7 @@ func DiffSchema(leftName string, left *tabletmanagerdatapb.SchemaDefinition, rig
 
 		// same name, let's see content
 		if left.TableDefinitions[leftIndex].Schema != right.TableDefinitions[rightIndex].Schema {
-			er.RecordError(fmt.Errorf("schemas differ on table %v:\n%s: %v\n differs from:\n%s: %v", left.TableDefinitions[leftIndex].Name, leftName, left.TableDefinitions[leftIndex].Schema, rightName, right.TableDefinitions[rightIndex].Schema))
+			diffTableSchema(leftName, left.TableDefinitions[leftIndex], rightName, right.TableDefinitions[rightIndex], er)
 		}
 
 		if left.TableDefinitions[leftIndex].Type != right.TableDefinitions[rightIndex].Type {
@@ -310,6 +310,34 @@ func DiffSchema(leftName string, left *tabletmanagerdatapb.SchemaDefinition, rig
 	}
 }
 
+// diffTableSchema reports the differences between two schemas of a
+// table. The expressions of their generated columns and functional
+// indexes are normalized. If only their CHECK constraints or their
+// partitioning differ, they are compared structurally, and the
+// constraints or partitions which differ are reported.
+func diffTableSchema(leftName string, left *tabletmanagerdatapb.TableDefinition, rightName string, right *tabletmanagerdatapb.TableDefinition, er concurrency.ErrorRecorder) {
+	leftTable, _ := SplitTablePartitioning(left.Schema)
+	rightTable, _ := SplitTablePartitioning(right.Schema)
+	leftTable = NormalizeTableExpressions(leftTable)
+	rightTable = NormalizeTableExpressions(rightTable)
+	leftPartitioning, leftErr := ParseTablePartitioning(left.Schema)
+	rightPartitioning, rightErr := ParseTablePartitioning(right.Schema)
+	tablesDiffer := leftTable != rightTable
+	if tablesDiffer && stripCheckConstraints(leftTable) == stripCheckConstraints(rightTable) {
+		if diffs := diffCheckConstraints(leftName, leftTable, rightName, rightTable); len(diffs) > 0 {
+			er.RecordError(fmt.Errorf("check constraints differ on table %v: %v", left.Name, strings.Join(diffs, "; ")))
+		}
+		tablesDiffer = false
+	}
+	if tablesDiffer || leftErr != nil || rightErr != nil {
+		er.RecordError(fmt.Errorf("schemas differ on table %v:\n%s: %v\n differs from:\n%s: %v", left.Name, leftName, left.Schema, rightName, right.Schema))
+		return
+	}
+	if diffs := DiffTablePartitioning(leftName, leftPartitioning, rightName, rightPartitioning); len(diffs) > 0 {
+		er.RecordError(fmt.Errorf("partitioning differs on table %v: %v", left.Name, strings.Join(diffs, "; ")))
+	}
+}
+
 // DiffSchemaToArray diffs two schemas and return the schema diffs if there is any.
 func DiffSchemaToArray(leftName string, left *tabletmanagerdatapb.SchemaDefinition, rightName string, right *tabletmanagerdatapb.SchemaDefinition) (result []string) {
 	er := concurrency.AllErrorRecorder{}
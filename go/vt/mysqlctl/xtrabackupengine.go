@@ -819,6 +819,13 @@ func stripeReader(readers []io.Reader, blockSize int64) io.Reader {
 	return reader
 }
 
+// ExecuteIncrementalBackup is part of the IncrementalBackupEngine
+// interface. The incremental backups only hold binary logs, so they are
+// taken by the builtin engine, which restores them too.
+func (be *XtrabackupEngine) ExecuteIncrementalBackup(ctx context.Context, params BackupParams, bh backupstorage.BackupHandle) (bool, error) {
+	return (&BuiltinBackupEngine{}).ExecuteIncrementalBackup(ctx, params, bh)
+}
+
 // ShouldDrainForBackup satisfies the BackupEngine interface
 // xtrabackup can run while tablet is serving, hence false
 func (be *XtrabackupEngine) ShouldDrainForBackup() bool {
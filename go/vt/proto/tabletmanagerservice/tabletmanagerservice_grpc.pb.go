@@ -43,6 +43,9 @@ type TabletManagerClient interface {
 	UnlockTables(ctx context.Context, in *tabletmanagerdata.UnlockTablesRequest, opts ...grpc.CallOption) (*tabletmanagerdata.UnlockTablesResponse, error)
 	ExecuteQuery(ctx context.Context, in *tabletmanagerdata.ExecuteQueryRequest, opts ...grpc.CallOption) (*tabletmanagerdata.ExecuteQueryResponse, error)
 	ExecuteFetchAsDba(ctx context.Context, in *tabletmanagerdata.ExecuteFetchAsDbaRequest, opts ...grpc.CallOption) (*tabletmanagerdata.ExecuteFetchAsDbaResponse, error)
+	// ExecuteFetchAsDbaMulti runs several queries as ExecuteFetchAsDba, in a
+	// single round trip.
+	ExecuteFetchAsDbaMulti(ctx context.Context, in *tabletmanagerdata.ExecuteFetchAsDbaMultiRequest, opts ...grpc.CallOption) (*tabletmanagerdata.ExecuteFetchAsDbaMultiResponse, error)
 	ExecuteFetchAsAllPrivs(ctx context.Context, in *tabletmanagerdata.ExecuteFetchAsAllPrivsRequest, opts ...grpc.CallOption) (*tabletmanagerdata.ExecuteFetchAsAllPrivsResponse, error)
 	ExecuteFetchAsApp(ctx context.Context, in *tabletmanagerdata.ExecuteFetchAsAppRequest, opts ...grpc.CallOption) (*tabletmanagerdata.ExecuteFetchAsAppResponse, error)
 	// ReplicationStatus returns the current replication status.
@@ -269,6 +272,15 @@ func (c *tabletManagerClient) ExecuteFetchAsDba(ctx context.Context, in *tabletm
 	return out, nil
 }
 
+func (c *tabletManagerClient) ExecuteFetchAsDbaMulti(ctx context.Context, in *tabletmanagerdata.ExecuteFetchAsDbaMultiRequest, opts ...grpc.CallOption) (*tabletmanagerdata.ExecuteFetchAsDbaMultiResponse, error) {
+	out := new(tabletmanagerdata.ExecuteFetchAsDbaMultiResponse)
+	err := c.cc.Invoke(ctx, "/tabletmanagerservice.TabletManager/ExecuteFetchAsDbaMulti", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *tabletManagerClient) ExecuteFetchAsAllPrivs(ctx context.Context, in *tabletmanagerdata.ExecuteFetchAsAllPrivsRequest, opts ...grpc.CallOption) (*tabletmanagerdata.ExecuteFetchAsAllPrivsResponse, error) {
 	out := new(tabletmanagerdata.ExecuteFetchAsAllPrivsResponse)
 	err := c.cc.Invoke(ctx, "/tabletmanagerservice.TabletManager/ExecuteFetchAsAllPrivs", in, out, opts...)
@@ -586,6 +598,9 @@ type TabletManagerServer interface {
 	UnlockTables(context.Context, *tabletmanagerdata.UnlockTablesRequest) (*tabletmanagerdata.UnlockTablesResponse, error)
 	ExecuteQuery(context.Context, *tabletmanagerdata.ExecuteQueryRequest) (*tabletmanagerdata.ExecuteQueryResponse, error)
 	ExecuteFetchAsDba(context.Context, *tabletmanagerdata.ExecuteFetchAsDbaRequest) (*tabletmanagerdata.ExecuteFetchAsDbaResponse, error)
+	// ExecuteFetchAsDbaMulti runs several queries as ExecuteFetchAsDba, in a
+	// single round trip.
+	ExecuteFetchAsDbaMulti(context.Context, *tabletmanagerdata.ExecuteFetchAsDbaMultiRequest) (*tabletmanagerdata.ExecuteFetchAsDbaMultiResponse, error)
 	ExecuteFetchAsAllPrivs(context.Context, *tabletmanagerdata.ExecuteFetchAsAllPrivsRequest) (*tabletmanagerdata.ExecuteFetchAsAllPrivsResponse, error)
 	ExecuteFetchAsApp(context.Context, *tabletmanagerdata.ExecuteFetchAsAppRequest) (*tabletmanagerdata.ExecuteFetchAsAppResponse, error)
 	// ReplicationStatus returns the current replication status.
@@ -701,6 +716,9 @@ func (UnimplementedTabletManagerServer) ExecuteQuery(context.Context, *tabletman
 func (UnimplementedTabletManagerServer) ExecuteFetchAsDba(context.Context, *tabletmanagerdata.ExecuteFetchAsDbaRequest) (*tabletmanagerdata.ExecuteFetchAsDbaResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ExecuteFetchAsDba not implemented")
 }
+func (UnimplementedTabletManagerServer) ExecuteFetchAsDbaMulti(context.Context, *tabletmanagerdata.ExecuteFetchAsDbaMultiRequest) (*tabletmanagerdata.ExecuteFetchAsDbaMultiResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExecuteFetchAsDbaMulti not implemented")
+}
 func (UnimplementedTabletManagerServer) ExecuteFetchAsAllPrivs(context.Context, *tabletmanagerdata.ExecuteFetchAsAllPrivsRequest) (*tabletmanagerdata.ExecuteFetchAsAllPrivsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ExecuteFetchAsAllPrivs not implemented")
 }
@@ -1119,6 +1137,24 @@ func _TabletManager_ExecuteFetchAsDba_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TabletManager_ExecuteFetchAsDbaMulti_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(tabletmanagerdata.ExecuteFetchAsDbaMultiRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TabletManagerServer).ExecuteFetchAsDbaMulti(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tabletmanagerservice.TabletManager/ExecuteFetchAsDbaMulti",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TabletManagerServer).ExecuteFetchAsDbaMulti(ctx, req.(*tabletmanagerdata.ExecuteFetchAsDbaMultiRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TabletManager_ExecuteFetchAsAllPrivs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(tabletmanagerdata.ExecuteFetchAsAllPrivsRequest)
 	if err := dec(in); err != nil {
@@ -1690,6 +1726,10 @@ var TabletManager_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ExecuteFetchAsDba",
 			Handler:    _TabletManager_ExecuteFetchAsDba_Handler,
 		},
+		{
+			MethodName: "ExecuteFetchAsDbaMulti",
+			Handler:    _TabletManager_ExecuteFetchAsDbaMulti_Handler,
+		},
 		{
 			MethodName: "ExecuteFetchAsAllPrivs",
 			Handler:    _TabletManager_ExecuteFetchAsAllPrivs_Handler,
@@ -677,6 +677,31 @@ type Keyspace struct {
 	// keyspaces which tells us what point in time
 	// the snapshot is of
 	SnapshotTime *vttime.Time `protobuf:"bytes,7,opt,name=snapshot_time,json=snapshotTime,proto3" json:"snapshot_time,omitempty"`
+	// query_pins are the queries the vttablets of the keyspace replace by
+	// another query, e.g. to force an index when the MySQL optimizer picks
+	// a bad plan.
+	QueryPins []*QueryPin `protobuf:"bytes,8,rep,name=query_pins,json=queryPins,proto3" json:"query_pins,omitempty"`
+	// mysql_users are the MySQL users declared for the tablets of the
+	// keyspace, which vtctl applies to their MySQL instances. The users of
+	// the MySQL instances which aren't declared are left alone.
+	MysqlUsers []*MySQLUser `protobuf:"bytes,9,rep,name=mysql_users,json=mysqlUsers,proto3" json:"mysql_users,omitempty"`
+	// cdc_sinks are the CDC sinks of the keyspace, which the CDC sink
+	// service runs.
+	CdcSinks []*CDCSink `protobuf:"bytes,10,rep,name=cdc_sinks,json=cdcSinks,proto3" json:"cdc_sinks,omitempty"`
+	// cell_serving_overrides are the serving overrides of the cells of the
+	// keyspace, by cell.
+	CellServingOverrides map[string]*CellServingOverride `protobuf:"bytes,11,rep,name=cell_serving_overrides,json=cellServingOverrides,proto3" json:"cell_serving_overrides,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// backup_storage_config overrides the flags of the backup storage
+	// implementations for the backups of the shards of the keyspace.
+	BackupStorageConfig *BackupStorageConfig `protobuf:"bytes,12,opt,name=backup_storage_config,json=backupStorageConfig,proto3" json:"backup_storage_config,omitempty"`
+	// replication_lag_thresholds are the replication lag thresholds which
+	// the vtgates apply instead of their flags when they select the
+	// replicas of the keyspace to route queries to.
+	ReplicationLagThresholds *ReplicationLagThresholds `protobuf:"bytes,13,opt,name=replication_lag_thresholds,json=replicationLagThresholds,proto3" json:"replication_lag_thresholds,omitempty"`
+	// serving_flags describe the operations in progress on the keyspace,
+	// e.g. resharding_in_progress, which the vtgates expose to their
+	// clients. They are sorted, in lower case and without duplicates.
+	ServingFlags []string `protobuf:"bytes,14,rep,name=serving_flags,json=servingFlags,proto3" json:"serving_flags,omitempty"`
 }
 
 func (x *Keyspace) Reset() {
@@ -753,20 +778,69 @@ func (x *Keyspace) GetSnapshotTime() *vttime.Time {
 	return nil
 }
 
-// ShardReplication describes the MySQL replication relationships
-// whithin a cell.
-type ShardReplication struct {
+func (x *Keyspace) GetQueryPins() []*QueryPin {
+	if x != nil {
+		return x.QueryPins
+	}
+	return nil
+}
+
+func (x *Keyspace) GetMysqlUsers() []*MySQLUser {
+	if x != nil {
+		return x.MysqlUsers
+	}
+	return nil
+}
+
+func (x *Keyspace) GetCdcSinks() []*CDCSink {
+	if x != nil {
+		return x.CdcSinks
+	}
+	return nil
+}
+
+func (x *Keyspace) GetCellServingOverrides() map[string]*CellServingOverride {
+	if x != nil {
+		return x.CellServingOverrides
+	}
+	return nil
+}
+
+func (x *Keyspace) GetBackupStorageConfig() *BackupStorageConfig {
+	if x != nil {
+		return x.BackupStorageConfig
+	}
+	return nil
+}
+
+func (x *Keyspace) GetReplicationLagThresholds() *ReplicationLagThresholds {
+	if x != nil {
+		return x.ReplicationLagThresholds
+	}
+	return nil
+}
+
+func (x *Keyspace) GetServingFlags() []string {
+	if x != nil {
+		return x.ServingFlags
+	}
+	return nil
+}
+
+// BackupStorageConfig is the backup storage configuration of a keyspace. The
+// backup storage reads it on each operation, so that it applies without
+// restarting the tablets. Each backup storage implementation has its own
+// section.
+type BackupStorageConfig struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Note there can be only one Node in this array
-	// for a given tablet.
-	Nodes []*ShardReplication_Node `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	S3 *BackupStorageConfig_S3 `protobuf:"bytes,1,opt,name=s3,proto3" json:"s3,omitempty"`
 }
 
-func (x *ShardReplication) Reset() {
-	*x = ShardReplication{}
+func (x *BackupStorageConfig) Reset() {
+	*x = BackupStorageConfig{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_topodata_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -774,13 +848,13 @@ func (x *ShardReplication) Reset() {
 	}
 }
 
-func (x *ShardReplication) String() string {
+func (x *BackupStorageConfig) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ShardReplication) ProtoMessage() {}
+func (*BackupStorageConfig) ProtoMessage() {}
 
-func (x *ShardReplication) ProtoReflect() protoreflect.Message {
+func (x *BackupStorageConfig) ProtoReflect() protoreflect.Message {
 	mi := &file_topodata_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -792,31 +866,34 @@ func (x *ShardReplication) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ShardReplication.ProtoReflect.Descriptor instead.
-func (*ShardReplication) Descriptor() ([]byte, []int) {
+// Deprecated: Use BackupStorageConfig.ProtoReflect.Descriptor instead.
+func (*BackupStorageConfig) Descriptor() ([]byte, []int) {
 	return file_topodata_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *ShardReplication) GetNodes() []*ShardReplication_Node {
+func (x *BackupStorageConfig) GetS3() *BackupStorageConfig_S3 {
 	if x != nil {
-		return x.Nodes
+		return x.S3
 	}
 	return nil
 }
 
-// ShardReference is used as a pointer from a SrvKeyspace to a Shard
-type ShardReference struct {
+// ReplicationLagThresholds are the replication lag thresholds of a
+// keyspace.
+type ReplicationLagThresholds struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Copied from Shard.
-	Name     string    `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	KeyRange *KeyRange `protobuf:"bytes,2,opt,name=key_range,json=keyRange,proto3" json:"key_range,omitempty"` // Disable query serving in this shard
+	// default are the thresholds of all the tablet types of the keyspace.
+	Default *ReplicationLagThreshold `protobuf:"bytes,1,opt,name=default,proto3" json:"default,omitempty"`
+	// tablet_types are the thresholds of the given tablet types, in lower
+	// case, e.g. "rdonly". They override default.
+	TabletTypes map[string]*ReplicationLagThreshold `protobuf:"bytes,2,rep,name=tablet_types,json=tabletTypes,proto3" json:"tablet_types,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
-func (x *ShardReference) Reset() {
-	*x = ShardReference{}
+func (x *ReplicationLagThresholds) Reset() {
+	*x = ReplicationLagThresholds{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_topodata_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -824,13 +901,13 @@ func (x *ShardReference) Reset() {
 	}
 }
 
-func (x *ShardReference) String() string {
+func (x *ReplicationLagThresholds) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ShardReference) ProtoMessage() {}
+func (*ReplicationLagThresholds) ProtoMessage() {}
 
-func (x *ShardReference) ProtoReflect() protoreflect.Message {
+func (x *ReplicationLagThresholds) ProtoReflect() protoreflect.Message {
 	mi := &file_topodata_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -842,40 +919,44 @@ func (x *ShardReference) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ShardReference.ProtoReflect.Descriptor instead.
-func (*ShardReference) Descriptor() ([]byte, []int) {
+// Deprecated: Use ReplicationLagThresholds.ProtoReflect.Descriptor instead.
+func (*ReplicationLagThresholds) Descriptor() ([]byte, []int) {
 	return file_topodata_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *ShardReference) GetName() string {
+func (x *ReplicationLagThresholds) GetDefault() *ReplicationLagThreshold {
 	if x != nil {
-		return x.Name
+		return x.Default
 	}
-	return ""
+	return nil
 }
 
-func (x *ShardReference) GetKeyRange() *KeyRange {
+func (x *ReplicationLagThresholds) GetTabletTypes() map[string]*ReplicationLagThreshold {
 	if x != nil {
-		return x.KeyRange
+		return x.TabletTypes
 	}
 	return nil
 }
 
-// ShardTabletControl is used as a pointer from a SrvKeyspace to a Shard
-type ShardTabletControl struct {
+// ReplicationLagThreshold are the replication lag thresholds of the tablets
+// of a tablet type.
+type ReplicationLagThreshold struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Copied from Shard.
-	Name     string    `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	KeyRange *KeyRange `protobuf:"bytes,2,opt,name=key_range,json=keyRange,proto3" json:"key_range,omitempty"`
-	// Disable query serving in this shard
-	QueryServiceDisabled bool `protobuf:"varint,3,opt,name=query_service_disabled,json=queryServiceDisabled,proto3" json:"query_service_disabled,omitempty"`
+	// soft_seconds is the lag above which tablets are only used to keep
+	// -min_number_serving_vttablets tablets serving. 0 means it is not set,
+	// and -discovery_low_replication_lag applies.
+	SoftSeconds int64 `protobuf:"varint,1,opt,name=soft_seconds,json=softSeconds,proto3" json:"soft_seconds,omitempty"`
+	// hard_seconds is the lag above which tablets are never used. 0 means it
+	// is not set, and -discovery_high_replication_lag_minimum_serving
+	// applies.
+	HardSeconds int64 `protobuf:"varint,2,opt,name=hard_seconds,json=hardSeconds,proto3" json:"hard_seconds,omitempty"`
 }
 
-func (x *ShardTabletControl) Reset() {
-	*x = ShardTabletControl{}
+func (x *ReplicationLagThreshold) Reset() {
+	*x = ReplicationLagThreshold{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_topodata_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -883,13 +964,13 @@ func (x *ShardTabletControl) Reset() {
 	}
 }
 
-func (x *ShardTabletControl) String() string {
+func (x *ReplicationLagThreshold) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ShardTabletControl) ProtoMessage() {}
+func (*ReplicationLagThreshold) ProtoMessage() {}
 
-func (x *ShardTabletControl) ProtoReflect() protoreflect.Message {
+func (x *ReplicationLagThreshold) ProtoReflect() protoreflect.Message {
 	mi := &file_topodata_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -901,48 +982,42 @@ func (x *ShardTabletControl) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ShardTabletControl.ProtoReflect.Descriptor instead.
-func (*ShardTabletControl) Descriptor() ([]byte, []int) {
+// Deprecated: Use ReplicationLagThreshold.ProtoReflect.Descriptor instead.
+func (*ReplicationLagThreshold) Descriptor() ([]byte, []int) {
 	return file_topodata_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *ShardTabletControl) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-func (x *ShardTabletControl) GetKeyRange() *KeyRange {
+func (x *ReplicationLagThreshold) GetSoftSeconds() int64 {
 	if x != nil {
-		return x.KeyRange
+		return x.SoftSeconds
 	}
-	return nil
+	return 0
 }
 
-func (x *ShardTabletControl) GetQueryServiceDisabled() bool {
+func (x *ReplicationLagThreshold) GetHardSeconds() int64 {
 	if x != nil {
-		return x.QueryServiceDisabled
+		return x.HardSeconds
 	}
-	return false
+	return 0
 }
 
-// SrvKeyspace is a rollup node for the keyspace itself.
-type SrvKeyspace struct {
+// CellServingOverride overrides the serving of a keyspace by the tablets of
+// a cell. A cell marked as "do not serve" is excluded from the cross-cell
+// routing of the vtgates of the other cells, whatever the health of its
+// tablets, so a misbehaving region can be isolated quickly.
+type CellServingOverride struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// The partitions this keyspace is serving, per tablet type.
-	Partitions []*SrvKeyspace_KeyspacePartition `protobuf:"bytes,1,rep,name=partitions,proto3" json:"partitions,omitempty"`
-	// copied from Keyspace
-	ShardingColumnName string                    `protobuf:"bytes,2,opt,name=sharding_column_name,json=shardingColumnName,proto3" json:"sharding_column_name,omitempty"`
-	ShardingColumnType KeyspaceIdType            `protobuf:"varint,3,opt,name=sharding_column_type,json=shardingColumnType,proto3,enum=topodata.KeyspaceIdType" json:"sharding_column_type,omitempty"`
-	ServedFrom         []*SrvKeyspace_ServedFrom `protobuf:"bytes,4,rep,name=served_from,json=servedFrom,proto3" json:"served_from,omitempty"`
+	DoNotServe bool `protobuf:"varint,1,opt,name=do_not_serve,json=doNotServe,proto3" json:"do_not_serve,omitempty"`
+	// reason is why the cell must not serve the keyspace, logged by the
+	// vtgates.
+	Reason string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
 }
 
-func (x *SrvKeyspace) Reset() {
-	*x = SrvKeyspace{}
+func (x *CellServingOverride) Reset() {
+	*x = CellServingOverride{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_topodata_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -950,13 +1025,13 @@ func (x *SrvKeyspace) Reset() {
 	}
 }
 
-func (x *SrvKeyspace) String() string {
+func (x *CellServingOverride) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SrvKeyspace) ProtoMessage() {}
+func (*CellServingOverride) ProtoMessage() {}
 
-func (x *SrvKeyspace) ProtoReflect() protoreflect.Message {
+func (x *CellServingOverride) ProtoReflect() protoreflect.Message {
 	mi := &file_topodata_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -968,59 +1043,45 @@ func (x *SrvKeyspace) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SrvKeyspace.ProtoReflect.Descriptor instead.
-func (*SrvKeyspace) Descriptor() ([]byte, []int) {
+// Deprecated: Use CellServingOverride.ProtoReflect.Descriptor instead.
+func (*CellServingOverride) Descriptor() ([]byte, []int) {
 	return file_topodata_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *SrvKeyspace) GetPartitions() []*SrvKeyspace_KeyspacePartition {
+func (x *CellServingOverride) GetDoNotServe() bool {
 	if x != nil {
-		return x.Partitions
+		return x.DoNotServe
 	}
-	return nil
+	return false
 }
 
-func (x *SrvKeyspace) GetShardingColumnName() string {
+func (x *CellServingOverride) GetReason() string {
 	if x != nil {
-		return x.ShardingColumnName
+		return x.Reason
 	}
 	return ""
 }
 
-func (x *SrvKeyspace) GetShardingColumnType() KeyspaceIdType {
-	if x != nil {
-		return x.ShardingColumnType
-	}
-	return KeyspaceIdType_UNSET
-}
-
-func (x *SrvKeyspace) GetServedFrom() []*SrvKeyspace_ServedFrom {
-	if x != nil {
-		return x.ServedFrom
-	}
-	return nil
-}
-
-// CellInfo contains information about a cell. CellInfo objects are
-// stored in the global topology server, and describe how to reach
-// local topology servers.
-type CellInfo struct {
+// QueryPin is a query pinned to a replacement query.
+type QueryPin struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// ServerAddress contains the address of the server for the cell.
-	// The syntax of this field is topology implementation specific.
-	// For instance, for Zookeeper, it is a comma-separated list of
-	// server addresses.
-	ServerAddress string `protobuf:"bytes,1,opt,name=server_address,json=serverAddress,proto3" json:"server_address,omitempty"`
-	// Root is the path to store data in. It is only used when talking
-	// to server_address.
-	Root string `protobuf:"bytes,2,opt,name=root,proto3" json:"root,omitempty"`
+	// query is the normalized query, as received by vttablet.
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// replacement is the query executed instead of query. It must take
+	// the same bind variables.
+	Replacement string `protobuf:"bytes,2,opt,name=replacement,proto3" json:"replacement,omitempty"`
+	// expires is the time after which the pin is ignored. The pin never
+	// expires if it is not set.
+	Expires *vttime.Time `protobuf:"bytes,3,opt,name=expires,proto3" json:"expires,omitempty"`
+	// comment explains why the query was pinned.
+	Comment string `protobuf:"bytes,4,opt,name=comment,proto3" json:"comment,omitempty"`
 }
 
-func (x *CellInfo) Reset() {
-	*x = CellInfo{}
+func (x *QueryPin) Reset() {
+	*x = QueryPin{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_topodata_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1028,13 +1089,13 @@ func (x *CellInfo) Reset() {
 	}
 }
 
-func (x *CellInfo) String() string {
+func (x *QueryPin) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CellInfo) ProtoMessage() {}
+func (*QueryPin) ProtoMessage() {}
 
-func (x *CellInfo) ProtoReflect() protoreflect.Message {
+func (x *QueryPin) ProtoReflect() protoreflect.Message {
 	mi := &file_topodata_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1046,37 +1107,64 @@ func (x *CellInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CellInfo.ProtoReflect.Descriptor instead.
-func (*CellInfo) Descriptor() ([]byte, []int) {
+// Deprecated: Use QueryPin.ProtoReflect.Descriptor instead.
+func (*QueryPin) Descriptor() ([]byte, []int) {
 	return file_topodata_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *CellInfo) GetServerAddress() string {
+func (x *QueryPin) GetQuery() string {
 	if x != nil {
-		return x.ServerAddress
+		return x.Query
 	}
 	return ""
 }
 
-func (x *CellInfo) GetRoot() string {
+func (x *QueryPin) GetReplacement() string {
 	if x != nil {
-		return x.Root
+		return x.Replacement
 	}
 	return ""
 }
 
-// CellsAlias
-type CellsAlias struct {
+func (x *QueryPin) GetExpires() *vttime.Time {
+	if x != nil {
+		return x.Expires
+	}
+	return nil
+}
+
+func (x *QueryPin) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+// MySQLUser is a MySQL user declared for the tablets of a keyspace, with
+// its privileges.
+type MySQLUser struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Cells that map to this alias
-	Cells []string `protobuf:"bytes,2,rep,name=cells,proto3" json:"cells,omitempty"`
-}
-
-func (x *CellsAlias) Reset() {
-	*x = CellsAlias{}
+	User string `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	Host string `protobuf:"bytes,2,opt,name=host,proto3" json:"host,omitempty"`
+	// auth_plugin and authentication_string are the authentication of the
+	// user, as stored in mysql.user: authentication_string is the hash of
+	// the password, never the password itself. If auth_plugin is empty,
+	// the authentication of the user isn't managed.
+	AuthPlugin           string `protobuf:"bytes,3,opt,name=auth_plugin,json=authPlugin,proto3" json:"auth_plugin,omitempty"`
+	AuthenticationString string `protobuf:"bytes,4,opt,name=authentication_string,json=authenticationString,proto3" json:"authentication_string,omitempty"`
+	// global_privileges are the privileges granted on *.*, e.g. "SELECT"
+	// or "REPLICATION CLIENT".
+	GlobalPrivileges []string `protobuf:"bytes,5,rep,name=global_privileges,json=globalPrivileges,proto3" json:"global_privileges,omitempty"`
+	// database_privileges are the privileges granted on databases, by
+	// database name.
+	DatabasePrivileges map[string]*MySQLUser_Privileges `protobuf:"bytes,6,rep,name=database_privileges,json=databasePrivileges,proto3" json:"database_privileges,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *MySQLUser) Reset() {
+	*x = MySQLUser{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_topodata_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1084,13 +1172,13 @@ func (x *CellsAlias) Reset() {
 	}
 }
 
-func (x *CellsAlias) String() string {
+func (x *MySQLUser) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CellsAlias) ProtoMessage() {}
+func (*MySQLUser) ProtoMessage() {}
 
-func (x *CellsAlias) ProtoReflect() protoreflect.Message {
+func (x *MySQLUser) ProtoReflect() protoreflect.Message {
 	mi := &file_topodata_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1102,30 +1190,74 @@ func (x *CellsAlias) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CellsAlias.ProtoReflect.Descriptor instead.
-func (*CellsAlias) Descriptor() ([]byte, []int) {
+// Deprecated: Use MySQLUser.ProtoReflect.Descriptor instead.
+func (*MySQLUser) Descriptor() ([]byte, []int) {
 	return file_topodata_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *CellsAlias) GetCells() []string {
+func (x *MySQLUser) GetUser() string {
 	if x != nil {
-		return x.Cells
+		return x.User
+	}
+	return ""
+}
+
+func (x *MySQLUser) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *MySQLUser) GetAuthPlugin() string {
+	if x != nil {
+		return x.AuthPlugin
+	}
+	return ""
+}
+
+func (x *MySQLUser) GetAuthenticationString() string {
+	if x != nil {
+		return x.AuthenticationString
+	}
+	return ""
+}
+
+func (x *MySQLUser) GetGlobalPrivileges() []string {
+	if x != nil {
+		return x.GlobalPrivileges
 	}
 	return nil
 }
 
-type TopoConfig struct {
+func (x *MySQLUser) GetDatabasePrivileges() map[string]*MySQLUser_Privileges {
+	if x != nil {
+		return x.DatabasePrivileges
+	}
+	return nil
+}
+
+// CDCSink publishes the row changes of tables of a keyspace to a topic.
+type CDCSink struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	TopoType string `protobuf:"bytes,1,opt,name=topo_type,json=topoType,proto3" json:"topo_type,omitempty"`
-	Server   string `protobuf:"bytes,2,opt,name=server,proto3" json:"server,omitempty"`
-	Root     string `protobuf:"bytes,3,opt,name=root,proto3" json:"root,omitempty"`
-}
-
-func (x *TopoConfig) Reset() {
-	*x = TopoConfig{}
+	// name identifies the sink in the keyspace. The offsets of the sink are
+	// stored under this name by the producer, so renaming a sink restarts
+	// it from the current position.
+	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Topic string `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`
+	// tables are the tables whose row changes are published, or all the
+	// tables of the keyspace if empty.
+	Tables []string `protobuf:"bytes,3,rep,name=tables,proto3" json:"tables,omitempty"`
+	// tablet_type is the type of the tablets to stream from, e.g.
+	// "replica". It defaults to master.
+	TabletType string `protobuf:"bytes,4,opt,name=tablet_type,json=tabletType,proto3" json:"tablet_type,omitempty"`
+}
+
+func (x *CDCSink) Reset() {
+	*x = CDCSink{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_topodata_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1133,13 +1265,13 @@ func (x *TopoConfig) Reset() {
 	}
 }
 
-func (x *TopoConfig) String() string {
+func (x *CDCSink) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TopoConfig) ProtoMessage() {}
+func (*CDCSink) ProtoMessage() {}
 
-func (x *TopoConfig) ProtoReflect() protoreflect.Message {
+func (x *CDCSink) ProtoReflect() protoreflect.Message {
 	mi := &file_topodata_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1151,42 +1283,53 @@ func (x *TopoConfig) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TopoConfig.ProtoReflect.Descriptor instead.
-func (*TopoConfig) Descriptor() ([]byte, []int) {
+// Deprecated: Use CDCSink.ProtoReflect.Descriptor instead.
+func (*CDCSink) Descriptor() ([]byte, []int) {
 	return file_topodata_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *TopoConfig) GetTopoType() string {
+func (x *CDCSink) GetName() string {
 	if x != nil {
-		return x.TopoType
+		return x.Name
 	}
 	return ""
 }
 
-func (x *TopoConfig) GetServer() string {
+func (x *CDCSink) GetTopic() string {
 	if x != nil {
-		return x.Server
+		return x.Topic
 	}
 	return ""
 }
 
-func (x *TopoConfig) GetRoot() string {
+func (x *CDCSink) GetTables() []string {
 	if x != nil {
-		return x.Root
+		return x.Tables
+	}
+	return nil
+}
+
+func (x *CDCSink) GetTabletType() string {
+	if x != nil {
+		return x.TabletType
 	}
 	return ""
 }
 
-type ExternalVitessCluster struct {
+// ShardReplication describes the MySQL replication relationships
+// whithin a cell.
+type ShardReplication struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	TopoConfig *TopoConfig `protobuf:"bytes,1,opt,name=topo_config,json=topoConfig,proto3" json:"topo_config,omitempty"`
+	// Note there can be only one Node in this array
+	// for a given tablet.
+	Nodes []*ShardReplication_Node `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
 }
 
-func (x *ExternalVitessCluster) Reset() {
-	*x = ExternalVitessCluster{}
+func (x *ShardReplication) Reset() {
+	*x = ShardReplication{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_topodata_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1194,13 +1337,13 @@ func (x *ExternalVitessCluster) Reset() {
 	}
 }
 
-func (x *ExternalVitessCluster) String() string {
+func (x *ShardReplication) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ExternalVitessCluster) ProtoMessage() {}
+func (*ShardReplication) ProtoMessage() {}
 
-func (x *ExternalVitessCluster) ProtoReflect() protoreflect.Message {
+func (x *ShardReplication) ProtoReflect() protoreflect.Message {
 	mi := &file_topodata_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1212,44 +1355,650 @@ func (x *ExternalVitessCluster) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ExternalVitessCluster.ProtoReflect.Descriptor instead.
-func (*ExternalVitessCluster) Descriptor() ([]byte, []int) {
+// Deprecated: Use ShardReplication.ProtoReflect.Descriptor instead.
+func (*ShardReplication) Descriptor() ([]byte, []int) {
 	return file_topodata_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *ExternalVitessCluster) GetTopoConfig() *TopoConfig {
+func (x *ShardReplication) GetNodes() []*ShardReplication_Node {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+// ShardReference is used as a pointer from a SrvKeyspace to a Shard
+type ShardReference struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Copied from Shard.
+	Name     string    `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	KeyRange *KeyRange `protobuf:"bytes,2,opt,name=key_range,json=keyRange,proto3" json:"key_range,omitempty"` // Disable query serving in this shard
+}
+
+func (x *ShardReference) Reset() {
+	*x = ShardReference{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_topodata_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ShardReference) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShardReference) ProtoMessage() {}
+
+func (x *ShardReference) ProtoReflect() protoreflect.Message {
+	mi := &file_topodata_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShardReference.ProtoReflect.Descriptor instead.
+func (*ShardReference) Descriptor() ([]byte, []int) {
+	return file_topodata_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ShardReference) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ShardReference) GetKeyRange() *KeyRange {
+	if x != nil {
+		return x.KeyRange
+	}
+	return nil
+}
+
+// ShardTabletControl is used as a pointer from a SrvKeyspace to a Shard
+type ShardTabletControl struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Copied from Shard.
+	Name     string    `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	KeyRange *KeyRange `protobuf:"bytes,2,opt,name=key_range,json=keyRange,proto3" json:"key_range,omitempty"`
+	// Disable query serving in this shard
+	QueryServiceDisabled bool `protobuf:"varint,3,opt,name=query_service_disabled,json=queryServiceDisabled,proto3" json:"query_service_disabled,omitempty"`
+}
+
+func (x *ShardTabletControl) Reset() {
+	*x = ShardTabletControl{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_topodata_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ShardTabletControl) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShardTabletControl) ProtoMessage() {}
+
+func (x *ShardTabletControl) ProtoReflect() protoreflect.Message {
+	mi := &file_topodata_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShardTabletControl.ProtoReflect.Descriptor instead.
+func (*ShardTabletControl) Descriptor() ([]byte, []int) {
+	return file_topodata_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ShardTabletControl) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ShardTabletControl) GetKeyRange() *KeyRange {
+	if x != nil {
+		return x.KeyRange
+	}
+	return nil
+}
+
+func (x *ShardTabletControl) GetQueryServiceDisabled() bool {
+	if x != nil {
+		return x.QueryServiceDisabled
+	}
+	return false
+}
+
+// SrvKeyspace is a rollup node for the keyspace itself.
+type SrvKeyspace struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The partitions this keyspace is serving, per tablet type.
+	Partitions []*SrvKeyspace_KeyspacePartition `protobuf:"bytes,1,rep,name=partitions,proto3" json:"partitions,omitempty"`
+	// copied from Keyspace
+	ShardingColumnName string                    `protobuf:"bytes,2,opt,name=sharding_column_name,json=shardingColumnName,proto3" json:"sharding_column_name,omitempty"`
+	ShardingColumnType KeyspaceIdType            `protobuf:"varint,3,opt,name=sharding_column_type,json=shardingColumnType,proto3,enum=topodata.KeyspaceIdType" json:"sharding_column_type,omitempty"`
+	ServedFrom         []*SrvKeyspace_ServedFrom `protobuf:"bytes,4,rep,name=served_from,json=servedFrom,proto3" json:"served_from,omitempty"`
+}
+
+func (x *SrvKeyspace) Reset() {
+	*x = SrvKeyspace{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_topodata_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SrvKeyspace) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SrvKeyspace) ProtoMessage() {}
+
+func (x *SrvKeyspace) ProtoReflect() protoreflect.Message {
+	mi := &file_topodata_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SrvKeyspace.ProtoReflect.Descriptor instead.
+func (*SrvKeyspace) Descriptor() ([]byte, []int) {
+	return file_topodata_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SrvKeyspace) GetPartitions() []*SrvKeyspace_KeyspacePartition {
+	if x != nil {
+		return x.Partitions
+	}
+	return nil
+}
+
+func (x *SrvKeyspace) GetShardingColumnName() string {
+	if x != nil {
+		return x.ShardingColumnName
+	}
+	return ""
+}
+
+func (x *SrvKeyspace) GetShardingColumnType() KeyspaceIdType {
+	if x != nil {
+		return x.ShardingColumnType
+	}
+	return KeyspaceIdType_UNSET
+}
+
+func (x *SrvKeyspace) GetServedFrom() []*SrvKeyspace_ServedFrom {
+	if x != nil {
+		return x.ServedFrom
+	}
+	return nil
+}
+
+// CellInfo contains information about a cell. CellInfo objects are
+// stored in the global topology server, and describe how to reach
+// local topology servers.
+type CellInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// ServerAddress contains the address of the server for the cell.
+	// The syntax of this field is topology implementation specific.
+	// For instance, for Zookeeper, it is a comma-separated list of
+	// server addresses.
+	ServerAddress string `protobuf:"bytes,1,opt,name=server_address,json=serverAddress,proto3" json:"server_address,omitempty"`
+	// Root is the path to store data in. It is only used when talking
+	// to server_address.
+	Root string `protobuf:"bytes,2,opt,name=root,proto3" json:"root,omitempty"`
+}
+
+func (x *CellInfo) Reset() {
+	*x = CellInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_topodata_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CellInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CellInfo) ProtoMessage() {}
+
+func (x *CellInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_topodata_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CellInfo.ProtoReflect.Descriptor instead.
+func (*CellInfo) Descriptor() ([]byte, []int) {
+	return file_topodata_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CellInfo) GetServerAddress() string {
+	if x != nil {
+		return x.ServerAddress
+	}
+	return ""
+}
+
+func (x *CellInfo) GetRoot() string {
+	if x != nil {
+		return x.Root
+	}
+	return ""
+}
+
+// CellsAlias
+type CellsAlias struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Cells that map to this alias
+	Cells []string `protobuf:"bytes,2,rep,name=cells,proto3" json:"cells,omitempty"`
+}
+
+func (x *CellsAlias) Reset() {
+	*x = CellsAlias{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_topodata_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CellsAlias) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CellsAlias) ProtoMessage() {}
+
+func (x *CellsAlias) ProtoReflect() protoreflect.Message {
+	mi := &file_topodata_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CellsAlias.ProtoReflect.Descriptor instead.
+func (*CellsAlias) Descriptor() ([]byte, []int) {
+	return file_topodata_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CellsAlias) GetCells() []string {
+	if x != nil {
+		return x.Cells
+	}
+	return nil
+}
+
+type TopoConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TopoType string `protobuf:"bytes,1,opt,name=topo_type,json=topoType,proto3" json:"topo_type,omitempty"`
+	Server   string `protobuf:"bytes,2,opt,name=server,proto3" json:"server,omitempty"`
+	Root     string `protobuf:"bytes,3,opt,name=root,proto3" json:"root,omitempty"`
+}
+
+func (x *TopoConfig) Reset() {
+	*x = TopoConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_topodata_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TopoConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopoConfig) ProtoMessage() {}
+
+func (x *TopoConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_topodata_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopoConfig.ProtoReflect.Descriptor instead.
+func (*TopoConfig) Descriptor() ([]byte, []int) {
+	return file_topodata_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *TopoConfig) GetTopoType() string {
+	if x != nil {
+		return x.TopoType
+	}
+	return ""
+}
+
+func (x *TopoConfig) GetServer() string {
+	if x != nil {
+		return x.Server
+	}
+	return ""
+}
+
+func (x *TopoConfig) GetRoot() string {
+	if x != nil {
+		return x.Root
+	}
+	return ""
+}
+
+type ExternalVitessCluster struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TopoConfig *TopoConfig `protobuf:"bytes,1,opt,name=topo_config,json=topoConfig,proto3" json:"topo_config,omitempty"`
+}
+
+func (x *ExternalVitessCluster) Reset() {
+	*x = ExternalVitessCluster{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_topodata_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExternalVitessCluster) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExternalVitessCluster) ProtoMessage() {}
+
+func (x *ExternalVitessCluster) ProtoReflect() protoreflect.Message {
+	mi := &file_topodata_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExternalVitessCluster.ProtoReflect.Descriptor instead.
+func (*ExternalVitessCluster) Descriptor() ([]byte, []int) {
+	return file_topodata_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ExternalVitessCluster) GetTopoConfig() *TopoConfig {
 	if x != nil {
 		return x.TopoConfig
 	}
-	return nil
+	return nil
+}
+
+// ExternalClusters
+type ExternalClusters struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VitessCluster []*ExternalVitessCluster `protobuf:"bytes,1,rep,name=vitess_cluster,json=vitessCluster,proto3" json:"vitess_cluster,omitempty"`
+}
+
+func (x *ExternalClusters) Reset() {
+	*x = ExternalClusters{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_topodata_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExternalClusters) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExternalClusters) ProtoMessage() {}
+
+func (x *ExternalClusters) ProtoReflect() protoreflect.Message {
+	mi := &file_topodata_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExternalClusters.ProtoReflect.Descriptor instead.
+func (*ExternalClusters) Descriptor() ([]byte, []int) {
+	return file_topodata_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ExternalClusters) GetVitessCluster() []*ExternalVitessCluster {
+	if x != nil {
+		return x.VitessCluster
+	}
+	return nil
+}
+
+// VtGate is a vtgate registered in the topology of its cell, so that
+// vtctld can discover it.
+type VtGate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hostname string `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	// port is the port of the web server of the vtgate.
+	Port int32 `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
+	// grpc_port is the port of the gRPC server of the vtgate, if any.
+	GrpcPort int32 `protobuf:"varint,3,opt,name=grpc_port,json=grpcPort,proto3" json:"grpc_port,omitempty"`
+}
+
+func (x *VtGate) Reset() {
+	*x = VtGate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_topodata_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VtGate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VtGate) ProtoMessage() {}
+
+func (x *VtGate) ProtoReflect() protoreflect.Message {
+	mi := &file_topodata_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VtGate.ProtoReflect.Descriptor instead.
+func (*VtGate) Descriptor() ([]byte, []int) {
+	return file_topodata_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *VtGate) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *VtGate) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *VtGate) GetGrpcPort() int32 {
+	if x != nil {
+		return x.GrpcPort
+	}
+	return 0
+}
+
+// WorkflowThrottling are the rate limits of a vreplication workflow on each
+// of its target primary tablets. A zero value means no limit.
+type WorkflowThrottling struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// rows_per_second is the maximum number of rows copied per second.
+	RowsPerSecond int64 `protobuf:"varint,1,opt,name=rows_per_second,json=rowsPerSecond,proto3" json:"rows_per_second,omitempty"`
+	// bytes_per_second is the maximum number of bytes copied per second.
+	BytesPerSecond int64 `protobuf:"varint,2,opt,name=bytes_per_second,json=bytesPerSecond,proto3" json:"bytes_per_second,omitempty"`
+	// max_concurrent_copies is the maximum number of streams which copy rows
+	// at the same time.
+	MaxConcurrentCopies int64 `protobuf:"varint,3,opt,name=max_concurrent_copies,json=maxConcurrentCopies,proto3" json:"max_concurrent_copies,omitempty"`
+}
+
+func (x *WorkflowThrottling) Reset() {
+	*x = WorkflowThrottling{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_topodata_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-// ExternalClusters
-type ExternalClusters struct {
+func (x *WorkflowThrottling) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkflowThrottling) ProtoMessage() {}
+
+func (x *WorkflowThrottling) ProtoReflect() protoreflect.Message {
+	mi := &file_topodata_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkflowThrottling.ProtoReflect.Descriptor instead.
+func (*WorkflowThrottling) Descriptor() ([]byte, []int) {
+	return file_topodata_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *WorkflowThrottling) GetRowsPerSecond() int64 {
+	if x != nil {
+		return x.RowsPerSecond
+	}
+	return 0
+}
+
+func (x *WorkflowThrottling) GetBytesPerSecond() int64 {
+	if x != nil {
+		return x.BytesPerSecond
+	}
+	return 0
+}
+
+func (x *WorkflowThrottling) GetMaxConcurrentCopies() int64 {
+	if x != nil {
+		return x.MaxConcurrentCopies
+	}
+	return 0
+}
+
+// KeyspaceWorkflowThrottling is the throttling of the vreplication
+// workflows of a keyspace, stored in the global topo.
+type KeyspaceWorkflowThrottling struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	VitessCluster []*ExternalVitessCluster `protobuf:"bytes,1,rep,name=vitess_cluster,json=vitessCluster,proto3" json:"vitess_cluster,omitempty"`
+	// workflows are the throttling of the workflows, by workflow name.
+	Workflows map[string]*WorkflowThrottling `protobuf:"bytes,1,rep,name=workflows,proto3" json:"workflows,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
-func (x *ExternalClusters) Reset() {
-	*x = ExternalClusters{}
+func (x *KeyspaceWorkflowThrottling) Reset() {
+	*x = KeyspaceWorkflowThrottling{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_topodata_proto_msgTypes[13]
+		mi := &file_topodata_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ExternalClusters) String() string {
+func (x *KeyspaceWorkflowThrottling) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ExternalClusters) ProtoMessage() {}
+func (*KeyspaceWorkflowThrottling) ProtoMessage() {}
 
-func (x *ExternalClusters) ProtoReflect() protoreflect.Message {
-	mi := &file_topodata_proto_msgTypes[13]
+func (x *KeyspaceWorkflowThrottling) ProtoReflect() protoreflect.Message {
+	mi := &file_topodata_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1260,18 +2009,155 @@ func (x *ExternalClusters) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ExternalClusters.ProtoReflect.Descriptor instead.
-func (*ExternalClusters) Descriptor() ([]byte, []int) {
-	return file_topodata_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use KeyspaceWorkflowThrottling.ProtoReflect.Descriptor instead.
+func (*KeyspaceWorkflowThrottling) Descriptor() ([]byte, []int) {
+	return file_topodata_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *ExternalClusters) GetVitessCluster() []*ExternalVitessCluster {
+func (x *KeyspaceWorkflowThrottling) GetWorkflows() map[string]*WorkflowThrottling {
 	if x != nil {
-		return x.VitessCluster
+		return x.Workflows
+	}
+	return nil
+}
+
+// BackupSchedule backs up all the shards of a keyspace periodically, and
+// removes the backups which are out of its retention. It is stored in the
+// global topo, next to the VSchema of the keyspace.
+type BackupSchedule struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// cron is the cron expression of the schedule, in the local time of
+	// vtctld.
+	Cron string `protobuf:"bytes,1,opt,name=cron,proto3" json:"cron,omitempty"`
+	// concurrency is the number of shards backed up at the same time.
+	Concurrency uint32 `protobuf:"varint,2,opt,name=concurrency,proto3" json:"concurrency,omitempty"`
+	// backup_concurrency is the number of compression/checksum jobs of the
+	// backup of a shard.
+	BackupConcurrency uint32 `protobuf:"varint,3,opt,name=backup_concurrency,json=backupConcurrency,proto3" json:"backup_concurrency,omitempty"`
+	// allow_primary allows backing up a shard on its primary if it has no
+	// other tablet to back up.
+	AllowPrimary bool `protobuf:"varint,4,opt,name=allow_primary,json=allowPrimary,proto3" json:"allow_primary,omitempty"`
+	// keep_last is the number of the most recent backups of each shard which
+	// are kept. max_age is the age after which the other backups are
+	// removed. If neither is set, no backup is removed.
+	KeepLast   uint32           `protobuf:"varint,5,opt,name=keep_last,json=keepLast,proto3" json:"keep_last,omitempty"`
+	MaxAge     *vttime.Duration `protobuf:"bytes,6,opt,name=max_age,json=maxAge,proto3" json:"max_age,omitempty"`
+	CreateTime *vttime.Time     `protobuf:"bytes,7,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	// last_scheduled_time is the last time a run was due. The next run is
+	// due at the first time matching cron after it, or after create_time if
+	// there was no run yet.
+	LastScheduledTime *vttime.Time `protobuf:"bytes,8,opt,name=last_scheduled_time,json=lastScheduledTime,proto3" json:"last_scheduled_time,omitempty"`
+	// last_run_time is when the last run ended, and last_error its error, if
+	// any.
+	LastRunTime *vttime.Time `protobuf:"bytes,9,opt,name=last_run_time,json=lastRunTime,proto3" json:"last_run_time,omitempty"`
+	LastError   string       `protobuf:"bytes,10,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+}
+
+func (x *BackupSchedule) Reset() {
+	*x = BackupSchedule{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_topodata_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BackupSchedule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackupSchedule) ProtoMessage() {}
+
+func (x *BackupSchedule) ProtoReflect() protoreflect.Message {
+	mi := &file_topodata_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackupSchedule.ProtoReflect.Descriptor instead.
+func (*BackupSchedule) Descriptor() ([]byte, []int) {
+	return file_topodata_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *BackupSchedule) GetCron() string {
+	if x != nil {
+		return x.Cron
+	}
+	return ""
+}
+
+func (x *BackupSchedule) GetConcurrency() uint32 {
+	if x != nil {
+		return x.Concurrency
+	}
+	return 0
+}
+
+func (x *BackupSchedule) GetBackupConcurrency() uint32 {
+	if x != nil {
+		return x.BackupConcurrency
+	}
+	return 0
+}
+
+func (x *BackupSchedule) GetAllowPrimary() bool {
+	if x != nil {
+		return x.AllowPrimary
+	}
+	return false
+}
+
+func (x *BackupSchedule) GetKeepLast() uint32 {
+	if x != nil {
+		return x.KeepLast
+	}
+	return 0
+}
+
+func (x *BackupSchedule) GetMaxAge() *vttime.Duration {
+	if x != nil {
+		return x.MaxAge
+	}
+	return nil
+}
+
+func (x *BackupSchedule) GetCreateTime() *vttime.Time {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *BackupSchedule) GetLastScheduledTime() *vttime.Time {
+	if x != nil {
+		return x.LastScheduledTime
+	}
+	return nil
+}
+
+func (x *BackupSchedule) GetLastRunTime() *vttime.Time {
+	if x != nil {
+		return x.LastRunTime
 	}
 	return nil
 }
 
+func (x *BackupSchedule) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
 // ServedType is an entry in the served_types
 type Shard_ServedType struct {
 	state         protoimpl.MessageState
@@ -1285,7 +2171,7 @@ type Shard_ServedType struct {
 func (x *Shard_ServedType) Reset() {
 	*x = Shard_ServedType{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_topodata_proto_msgTypes[16]
+		mi := &file_topodata_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1298,7 +2184,7 @@ func (x *Shard_ServedType) String() string {
 func (*Shard_ServedType) ProtoMessage() {}
 
 func (x *Shard_ServedType) ProtoReflect() protoreflect.Message {
-	mi := &file_topodata_proto_msgTypes[16]
+	mi := &file_topodata_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1351,7 +2237,7 @@ type Shard_SourceShard struct {
 func (x *Shard_SourceShard) Reset() {
 	*x = Shard_SourceShard{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_topodata_proto_msgTypes[17]
+		mi := &file_topodata_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1364,7 +2250,7 @@ func (x *Shard_SourceShard) String() string {
 func (*Shard_SourceShard) ProtoMessage() {}
 
 func (x *Shard_SourceShard) ProtoReflect() protoreflect.Message {
-	mi := &file_topodata_proto_msgTypes[17]
+	mi := &file_topodata_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1433,20 +2319,181 @@ type Shard_TabletControl struct {
 func (x *Shard_TabletControl) Reset() {
 	*x = Shard_TabletControl{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_topodata_proto_msgTypes[18]
+		mi := &file_topodata_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Shard_TabletControl) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Shard_TabletControl) ProtoMessage() {}
+
+func (x *Shard_TabletControl) ProtoReflect() protoreflect.Message {
+	mi := &file_topodata_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Shard_TabletControl.ProtoReflect.Descriptor instead.
+func (*Shard_TabletControl) Descriptor() ([]byte, []int) {
+	return file_topodata_proto_rawDescGZIP(), []int{3, 2}
+}
+
+func (x *Shard_TabletControl) GetTabletType() TabletType {
+	if x != nil {
+		return x.TabletType
+	}
+	return TabletType_UNKNOWN
+}
+
+func (x *Shard_TabletControl) GetCells() []string {
+	if x != nil {
+		return x.Cells
+	}
+	return nil
+}
+
+func (x *Shard_TabletControl) GetBlacklistedTables() []string {
+	if x != nil {
+		return x.BlacklistedTables
+	}
+	return nil
+}
+
+func (x *Shard_TabletControl) GetFrozen() bool {
+	if x != nil {
+		return x.Frozen
+	}
+	return false
+}
+
+// ServedFrom indicates a relationship between a TabletType and the
+// keyspace name that's serving it.
+type Keyspace_ServedFrom struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the tablet type (key for the map)
+	TabletType TabletType `protobuf:"varint,1,opt,name=tablet_type,json=tabletType,proto3,enum=topodata.TabletType" json:"tablet_type,omitempty"`
+	// the cells to limit this to
+	Cells []string `protobuf:"bytes,2,rep,name=cells,proto3" json:"cells,omitempty"`
+	// the keyspace name that's serving it
+	Keyspace string `protobuf:"bytes,3,opt,name=keyspace,proto3" json:"keyspace,omitempty"`
+}
+
+func (x *Keyspace_ServedFrom) Reset() {
+	*x = Keyspace_ServedFrom{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_topodata_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Keyspace_ServedFrom) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Keyspace_ServedFrom) ProtoMessage() {}
+
+func (x *Keyspace_ServedFrom) ProtoReflect() protoreflect.Message {
+	mi := &file_topodata_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Keyspace_ServedFrom.ProtoReflect.Descriptor instead.
+func (*Keyspace_ServedFrom) Descriptor() ([]byte, []int) {
+	return file_topodata_proto_rawDescGZIP(), []int{4, 0}
+}
+
+func (x *Keyspace_ServedFrom) GetTabletType() TabletType {
+	if x != nil {
+		return x.TabletType
+	}
+	return TabletType_UNKNOWN
+}
+
+func (x *Keyspace_ServedFrom) GetCells() []string {
+	if x != nil {
+		return x.Cells
+	}
+	return nil
+}
+
+func (x *Keyspace_ServedFrom) GetKeyspace() string {
+	if x != nil {
+		return x.Keyspace
+	}
+	return ""
+}
+
+// S3 is the configuration of the s3 backup storage, for S3 and the
+// S3-compatible object stores. The fields which aren't set default to the
+// -s3_backup_* flags.
+type BackupStorageConfig_S3 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Bucket string `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// root is the prefix of the names of the objects of the backups.
+	Root string `protobuf:"bytes,2,opt,name=root,proto3" json:"root,omitempty"`
+	// server_side_encryption is the server-side encryption of the objects,
+	// e.g. AES256, aws:kms or sse_c:<key file or secret reference>.
+	ServerSideEncryption string `protobuf:"bytes,3,opt,name=server_side_encryption,json=serverSideEncryption,proto3" json:"server_side_encryption,omitempty"`
+	// sse_kms_key_id is the KMS key of the aws:kms encryption. The default
+	// key of the bucket is used if it isn't set.
+	SseKmsKeyId string `protobuf:"bytes,4,opt,name=sse_kms_key_id,json=sseKmsKeyId,proto3" json:"sse_kms_key_id,omitempty"`
+	// upload_part_size is the size of the parts of the multipart uploads, in
+	// bytes. It is raised for the large files which would otherwise need
+	// more parts than S3 allows.
+	UploadPartSize int64 `protobuf:"varint,5,opt,name=upload_part_size,json=uploadPartSize,proto3" json:"upload_part_size,omitempty"`
+	// upload_concurrency is the number of parts of a file uploaded at the
+	// same time.
+	UploadConcurrency int32 `protobuf:"varint,6,opt,name=upload_concurrency,json=uploadConcurrency,proto3" json:"upload_concurrency,omitempty"`
+	// retries is the number of retries of the failed requests, if positive.
+	// A negative number disables the retries.
+	Retries int32 `protobuf:"varint,7,opt,name=retries,proto3" json:"retries,omitempty"`
+	// min_retry_delay and max_retry_delay bound the exponential backoff
+	// between the retries.
+	MinRetryDelay *vttime.Duration `protobuf:"bytes,8,opt,name=min_retry_delay,json=minRetryDelay,proto3" json:"min_retry_delay,omitempty"`
+	MaxRetryDelay *vttime.Duration `protobuf:"bytes,9,opt,name=max_retry_delay,json=maxRetryDelay,proto3" json:"max_retry_delay,omitempty"`
+}
+
+func (x *BackupStorageConfig_S3) Reset() {
+	*x = BackupStorageConfig_S3{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_topodata_proto_msgTypes[32]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Shard_TabletControl) String() string {
+func (x *BackupStorageConfig_S3) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Shard_TabletControl) ProtoMessage() {}
+func (*BackupStorageConfig_S3) ProtoMessage() {}
 
-func (x *Shard_TabletControl) ProtoReflect() protoreflect.Message {
-	mi := &file_topodata_proto_msgTypes[18]
+func (x *BackupStorageConfig_S3) ProtoReflect() protoreflect.Message {
+	mi := &file_topodata_proto_msgTypes[32]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1457,71 +2504,99 @@ func (x *Shard_TabletControl) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Shard_TabletControl.ProtoReflect.Descriptor instead.
-func (*Shard_TabletControl) Descriptor() ([]byte, []int) {
-	return file_topodata_proto_rawDescGZIP(), []int{3, 2}
+// Deprecated: Use BackupStorageConfig_S3.ProtoReflect.Descriptor instead.
+func (*BackupStorageConfig_S3) Descriptor() ([]byte, []int) {
+	return file_topodata_proto_rawDescGZIP(), []int{5, 0}
 }
 
-func (x *Shard_TabletControl) GetTabletType() TabletType {
+func (x *BackupStorageConfig_S3) GetBucket() string {
 	if x != nil {
-		return x.TabletType
+		return x.Bucket
 	}
-	return TabletType_UNKNOWN
+	return ""
 }
 
-func (x *Shard_TabletControl) GetCells() []string {
+func (x *BackupStorageConfig_S3) GetRoot() string {
 	if x != nil {
-		return x.Cells
+		return x.Root
 	}
-	return nil
+	return ""
 }
 
-func (x *Shard_TabletControl) GetBlacklistedTables() []string {
+func (x *BackupStorageConfig_S3) GetServerSideEncryption() string {
 	if x != nil {
-		return x.BlacklistedTables
+		return x.ServerSideEncryption
+	}
+	return ""
+}
+
+func (x *BackupStorageConfig_S3) GetSseKmsKeyId() string {
+	if x != nil {
+		return x.SseKmsKeyId
+	}
+	return ""
+}
+
+func (x *BackupStorageConfig_S3) GetUploadPartSize() int64 {
+	if x != nil {
+		return x.UploadPartSize
+	}
+	return 0
+}
+
+func (x *BackupStorageConfig_S3) GetUploadConcurrency() int32 {
+	if x != nil {
+		return x.UploadConcurrency
+	}
+	return 0
+}
+
+func (x *BackupStorageConfig_S3) GetRetries() int32 {
+	if x != nil {
+		return x.Retries
+	}
+	return 0
+}
+
+func (x *BackupStorageConfig_S3) GetMinRetryDelay() *vttime.Duration {
+	if x != nil {
+		return x.MinRetryDelay
 	}
 	return nil
 }
 
-func (x *Shard_TabletControl) GetFrozen() bool {
+func (x *BackupStorageConfig_S3) GetMaxRetryDelay() *vttime.Duration {
 	if x != nil {
-		return x.Frozen
+		return x.MaxRetryDelay
 	}
-	return false
+	return nil
 }
 
-// ServedFrom indicates a relationship between a TabletType and the
-// keyspace name that's serving it.
-type Keyspace_ServedFrom struct {
+type MySQLUser_Privileges struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// the tablet type (key for the map)
-	TabletType TabletType `protobuf:"varint,1,opt,name=tablet_type,json=tabletType,proto3,enum=topodata.TabletType" json:"tablet_type,omitempty"`
-	// the cells to limit this to
-	Cells []string `protobuf:"bytes,2,rep,name=cells,proto3" json:"cells,omitempty"`
-	// the keyspace name that's serving it
-	Keyspace string `protobuf:"bytes,3,opt,name=keyspace,proto3" json:"keyspace,omitempty"`
+	Privileges []string `protobuf:"bytes,1,rep,name=privileges,proto3" json:"privileges,omitempty"`
 }
 
-func (x *Keyspace_ServedFrom) Reset() {
-	*x = Keyspace_ServedFrom{}
+func (x *MySQLUser_Privileges) Reset() {
+	*x = MySQLUser_Privileges{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_topodata_proto_msgTypes[19]
+		mi := &file_topodata_proto_msgTypes[34]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Keyspace_ServedFrom) String() string {
+func (x *MySQLUser_Privileges) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Keyspace_ServedFrom) ProtoMessage() {}
+func (*MySQLUser_Privileges) ProtoMessage() {}
 
-func (x *Keyspace_ServedFrom) ProtoReflect() protoreflect.Message {
-	mi := &file_topodata_proto_msgTypes[19]
+func (x *MySQLUser_Privileges) ProtoReflect() protoreflect.Message {
+	mi := &file_topodata_proto_msgTypes[34]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1532,32 +2607,18 @@ func (x *Keyspace_ServedFrom) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Keyspace_ServedFrom.ProtoReflect.Descriptor instead.
-func (*Keyspace_ServedFrom) Descriptor() ([]byte, []int) {
-	return file_topodata_proto_rawDescGZIP(), []int{4, 0}
-}
-
-func (x *Keyspace_ServedFrom) GetTabletType() TabletType {
-	if x != nil {
-		return x.TabletType
-	}
-	return TabletType_UNKNOWN
+// Deprecated: Use MySQLUser_Privileges.ProtoReflect.Descriptor instead.
+func (*MySQLUser_Privileges) Descriptor() ([]byte, []int) {
+	return file_topodata_proto_rawDescGZIP(), []int{10, 0}
 }
 
-func (x *Keyspace_ServedFrom) GetCells() []string {
+func (x *MySQLUser_Privileges) GetPrivileges() []string {
 	if x != nil {
-		return x.Cells
+		return x.Privileges
 	}
 	return nil
 }
 
-func (x *Keyspace_ServedFrom) GetKeyspace() string {
-	if x != nil {
-		return x.Keyspace
-	}
-	return ""
-}
-
 // Node describes a tablet instance within the cell
 type ShardReplication_Node struct {
 	state         protoimpl.MessageState
@@ -1570,7 +2631,7 @@ type ShardReplication_Node struct {
 func (x *ShardReplication_Node) Reset() {
 	*x = ShardReplication_Node{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_topodata_proto_msgTypes[20]
+		mi := &file_topodata_proto_msgTypes[36]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1583,7 +2644,7 @@ func (x *ShardReplication_Node) String() string {
 func (*ShardReplication_Node) ProtoMessage() {}
 
 func (x *ShardReplication_Node) ProtoReflect() protoreflect.Message {
-	mi := &file_topodata_proto_msgTypes[20]
+	mi := &file_topodata_proto_msgTypes[36]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1596,7 +2657,7 @@ func (x *ShardReplication_Node) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ShardReplication_Node.ProtoReflect.Descriptor instead.
 func (*ShardReplication_Node) Descriptor() ([]byte, []int) {
-	return file_topodata_proto_rawDescGZIP(), []int{5, 0}
+	return file_topodata_proto_rawDescGZIP(), []int{12, 0}
 }
 
 func (x *ShardReplication_Node) GetTabletAlias() *TabletAlias {
@@ -1622,7 +2683,7 @@ type SrvKeyspace_KeyspacePartition struct {
 func (x *SrvKeyspace_KeyspacePartition) Reset() {
 	*x = SrvKeyspace_KeyspacePartition{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_topodata_proto_msgTypes[21]
+		mi := &file_topodata_proto_msgTypes[37]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1635,7 +2696,7 @@ func (x *SrvKeyspace_KeyspacePartition) String() string {
 func (*SrvKeyspace_KeyspacePartition) ProtoMessage() {}
 
 func (x *SrvKeyspace_KeyspacePartition) ProtoReflect() protoreflect.Message {
-	mi := &file_topodata_proto_msgTypes[21]
+	mi := &file_topodata_proto_msgTypes[37]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1648,7 +2709,7 @@ func (x *SrvKeyspace_KeyspacePartition) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SrvKeyspace_KeyspacePartition.ProtoReflect.Descriptor instead.
 func (*SrvKeyspace_KeyspacePartition) Descriptor() ([]byte, []int) {
-	return file_topodata_proto_rawDescGZIP(), []int{8, 0}
+	return file_topodata_proto_rawDescGZIP(), []int{15, 0}
 }
 
 func (x *SrvKeyspace_KeyspacePartition) GetServedType() TabletType {
@@ -1688,7 +2749,7 @@ type SrvKeyspace_ServedFrom struct {
 func (x *SrvKeyspace_ServedFrom) Reset() {
 	*x = SrvKeyspace_ServedFrom{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_topodata_proto_msgTypes[22]
+		mi := &file_topodata_proto_msgTypes[38]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1701,7 +2762,7 @@ func (x *SrvKeyspace_ServedFrom) String() string {
 func (*SrvKeyspace_ServedFrom) ProtoMessage() {}
 
 func (x *SrvKeyspace_ServedFrom) ProtoReflect() protoreflect.Message {
-	mi := &file_topodata_proto_msgTypes[22]
+	mi := &file_topodata_proto_msgTypes[38]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1714,7 +2775,7 @@ func (x *SrvKeyspace_ServedFrom) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SrvKeyspace_ServedFrom.ProtoReflect.Descriptor instead.
 func (*SrvKeyspace_ServedFrom) Descriptor() ([]byte, []int) {
-	return file_topodata_proto_rawDescGZIP(), []int{8, 1}
+	return file_topodata_proto_rawDescGZIP(), []int{15, 1}
 }
 
 func (x *SrvKeyspace_ServedFrom) GetTabletType() TabletType {
@@ -1836,7 +2897,7 @@ var file_topodata_proto_rawDesc = []byte{
 	0x52, 0x11, 0x62, 0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x64, 0x54, 0x61, 0x62,
 	0x6c, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x72, 0x6f, 0x7a, 0x65, 0x6e, 0x18, 0x05, 0x20,
 	0x01, 0x28, 0x08, 0x52, 0x06, 0x66, 0x72, 0x6f, 0x7a, 0x65, 0x6e, 0x4a, 0x04, 0x08, 0x03, 0x10,
-	0x04, 0x4a, 0x04, 0x08, 0x05, 0x10, 0x06, 0x22, 0xdc, 0x03, 0x0a, 0x08, 0x4b, 0x65, 0x79, 0x73,
+	0x04, 0x4a, 0x04, 0x08, 0x05, 0x10, 0x06, 0x22, 0x9b, 0x08, 0x0a, 0x08, 0x4b, 0x65, 0x79, 0x73,
 	0x70, 0x61, 0x63, 0x65, 0x12, 0x30, 0x0a, 0x14, 0x73, 0x68, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67,
 	0x5f, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
 	0x28, 0x09, 0x52, 0x12, 0x73, 0x68, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x6c, 0x75,
@@ -1858,119 +2919,308 @@ var file_topodata_proto_rawDesc = []byte{
 	0x79, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x31, 0x0a, 0x0d, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68,
 	0x6f, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e,
 	0x76, 0x74, 0x74, 0x69, 0x6d, 0x65, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x52, 0x0c, 0x73, 0x6e, 0x61,
-	0x70, 0x73, 0x68, 0x6f, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x1a, 0x75, 0x0a, 0x0a, 0x53, 0x65, 0x72,
-	0x76, 0x65, 0x64, 0x46, 0x72, 0x6f, 0x6d, 0x12, 0x35, 0x0a, 0x0b, 0x74, 0x61, 0x62, 0x6c, 0x65,
-	0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x74,
-	0x6f, 0x70, 0x6f, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x74, 0x54, 0x79,
-	0x70, 0x65, 0x52, 0x0a, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x14,
-	0x0a, 0x05, 0x63, 0x65, 0x6c, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x63,
-	0x65, 0x6c, 0x6c, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x6b, 0x65, 0x79, 0x73, 0x70, 0x61, 0x63, 0x65,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6b, 0x65, 0x79, 0x73, 0x70, 0x61, 0x63, 0x65,
-	0x4a, 0x04, 0x08, 0x03, 0x10, 0x04, 0x22, 0x8b, 0x01, 0x0a, 0x10, 0x53, 0x68, 0x61, 0x72, 0x64,
-	0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x35, 0x0a, 0x05, 0x6e,
-	0x6f, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x74, 0x6f, 0x70,
-	0x6f, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x53, 0x68, 0x61, 0x72, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x69,
-	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x05, 0x6e, 0x6f, 0x64,
-	0x65, 0x73, 0x1a, 0x40, 0x0a, 0x04, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x38, 0x0a, 0x0c, 0x74, 0x61,
-	0x62, 0x6c, 0x65, 0x74, 0x5f, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x15, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x54, 0x61, 0x62, 0x6c,
-	0x65, 0x74, 0x41, 0x6c, 0x69, 0x61, 0x73, 0x52, 0x0b, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x74, 0x41,
-	0x6c, 0x69, 0x61, 0x73, 0x22, 0x55, 0x0a, 0x0e, 0x53, 0x68, 0x61, 0x72, 0x64, 0x52, 0x65, 0x66,
-	0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2f, 0x0a, 0x09, 0x6b, 0x65,
-	0x79, 0x5f, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e,
-	0x74, 0x6f, 0x70, 0x6f, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x4b, 0x65, 0x79, 0x52, 0x61, 0x6e, 0x67,
-	0x65, 0x52, 0x08, 0x6b, 0x65, 0x79, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x22, 0x8f, 0x01, 0x0a, 0x12,
-	0x53, 0x68, 0x61, 0x72, 0x64, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x72,
-	0x6f, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2f, 0x0a, 0x09, 0x6b, 0x65, 0x79, 0x5f, 0x72, 0x61,
-	0x6e, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x74, 0x6f, 0x70, 0x6f,
-	0x64, 0x61, 0x74, 0x61, 0x2e, 0x4b, 0x65, 0x79, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x08, 0x6b,
-	0x65, 0x79, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x34, 0x0a, 0x16, 0x71, 0x75, 0x65, 0x72, 0x79,
-	0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65,
-	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x14, 0x71, 0x75, 0x65, 0x72, 0x79, 0x53, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x22, 0xe2, 0x04,
-	0x0a, 0x0b, 0x53, 0x72, 0x76, 0x4b, 0x65, 0x79, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x47, 0x0a,
-	0x0a, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x27, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x53, 0x72, 0x76,
-	0x4b, 0x65, 0x79, 0x73, 0x70, 0x61, 0x63, 0x65, 0x2e, 0x4b, 0x65, 0x79, 0x73, 0x70, 0x61, 0x63,
-	0x65, 0x50, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x70, 0x61, 0x72, 0x74,
-	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x30, 0x0a, 0x14, 0x73, 0x68, 0x61, 0x72, 0x64, 0x69,
-	0x6e, 0x67, 0x5f, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x73, 0x68, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x43, 0x6f,
-	0x6c, 0x75, 0x6d, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x4a, 0x0a, 0x14, 0x73, 0x68, 0x61, 0x72,
-	0x64, 0x69, 0x6e, 0x67, 0x5f, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x5f, 0x74, 0x79, 0x70, 0x65,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x64, 0x61, 0x74,
-	0x61, 0x2e, 0x4b, 0x65, 0x79, 0x73, 0x70, 0x61, 0x63, 0x65, 0x49, 0x64, 0x54, 0x79, 0x70, 0x65,
-	0x52, 0x12, 0x73, 0x68, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e,
-	0x54, 0x79, 0x70, 0x65, 0x12, 0x41, 0x0a, 0x0b, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x5f, 0x66,
-	0x72, 0x6f, 0x6d, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x74, 0x6f, 0x70, 0x6f,
-	0x64, 0x61, 0x74, 0x61, 0x2e, 0x53, 0x72, 0x76, 0x4b, 0x65, 0x79, 0x73, 0x70, 0x61, 0x63, 0x65,
-	0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x64, 0x46, 0x72, 0x6f, 0x6d, 0x52, 0x0a, 0x73, 0x65, 0x72,
-	0x76, 0x65, 0x64, 0x46, 0x72, 0x6f, 0x6d, 0x1a, 0xe1, 0x01, 0x0a, 0x11, 0x4b, 0x65, 0x79, 0x73,
-	0x70, 0x61, 0x63, 0x65, 0x50, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x35, 0x0a,
-	0x0b, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0e, 0x32, 0x14, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x54, 0x61,
-	0x62, 0x6c, 0x65, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0a, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64,
-	0x54, 0x79, 0x70, 0x65, 0x12, 0x43, 0x0a, 0x10, 0x73, 0x68, 0x61, 0x72, 0x64, 0x5f, 0x72, 0x65,
-	0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18,
-	0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x53, 0x68, 0x61, 0x72, 0x64, 0x52,
-	0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x0f, 0x73, 0x68, 0x61, 0x72, 0x64, 0x52,
-	0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x50, 0x0a, 0x15, 0x73, 0x68, 0x61,
-	0x72, 0x64, 0x5f, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x74, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f,
-	0x6c, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x64,
-	0x61, 0x74, 0x61, 0x2e, 0x53, 0x68, 0x61, 0x72, 0x64, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x74, 0x43,
-	0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x52, 0x13, 0x73, 0x68, 0x61, 0x72, 0x64, 0x54, 0x61, 0x62,
-	0x6c, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x73, 0x1a, 0x5f, 0x0a, 0x0a, 0x53,
-	0x65, 0x72, 0x76, 0x65, 0x64, 0x46, 0x72, 0x6f, 0x6d, 0x12, 0x35, 0x0a, 0x0b, 0x74, 0x61, 0x62,
-	0x6c, 0x65, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14,
-	0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x74,
-	0x54, 0x79, 0x70, 0x65, 0x52, 0x0a, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x74, 0x54, 0x79, 0x70, 0x65,
-	0x12, 0x1a, 0x0a, 0x08, 0x6b, 0x65, 0x79, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x08, 0x6b, 0x65, 0x79, 0x73, 0x70, 0x61, 0x63, 0x65, 0x4a, 0x04, 0x08, 0x05,
-	0x10, 0x06, 0x22, 0x4b, 0x0a, 0x08, 0x43, 0x65, 0x6c, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x25,
-	0x0a, 0x0e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x41, 0x64,
-	0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x6f, 0x74, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x6f, 0x6f, 0x74, 0x4a, 0x04, 0x08, 0x03, 0x10, 0x04, 0x22,
-	0x22, 0x0a, 0x0a, 0x43, 0x65, 0x6c, 0x6c, 0x73, 0x41, 0x6c, 0x69, 0x61, 0x73, 0x12, 0x14, 0x0a,
-	0x05, 0x63, 0x65, 0x6c, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x63, 0x65,
-	0x6c, 0x6c, 0x73, 0x22, 0x55, 0x0a, 0x0a, 0x54, 0x6f, 0x70, 0x6f, 0x43, 0x6f, 0x6e, 0x66, 0x69,
-	0x67, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x6f, 0x70, 0x6f, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x6f, 0x70, 0x6f, 0x54, 0x79, 0x70, 0x65, 0x12, 0x16,
-	0x0a, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
-	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x6f, 0x74, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x6f, 0x6f, 0x74, 0x22, 0x4e, 0x0a, 0x15, 0x45, 0x78,
-	0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x56, 0x69, 0x74, 0x65, 0x73, 0x73, 0x43, 0x6c, 0x75, 0x73,
-	0x74, 0x65, 0x72, 0x12, 0x35, 0x0a, 0x0b, 0x74, 0x6f, 0x70, 0x6f, 0x5f, 0x63, 0x6f, 0x6e, 0x66,
-	0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x64,
-	0x61, 0x74, 0x61, 0x2e, 0x54, 0x6f, 0x70, 0x6f, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x0a,
-	0x74, 0x6f, 0x70, 0x6f, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22, 0x5a, 0x0a, 0x10, 0x45, 0x78,
-	0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x73, 0x12, 0x46,
-	0x0a, 0x0e, 0x76, 0x69, 0x74, 0x65, 0x73, 0x73, 0x5f, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72,
-	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x64, 0x61, 0x74,
-	0x61, 0x2e, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x56, 0x69, 0x74, 0x65, 0x73, 0x73,
-	0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x52, 0x0d, 0x76, 0x69, 0x74, 0x65, 0x73, 0x73, 0x43,
-	0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x2a, 0x28, 0x0a, 0x0c, 0x4b, 0x65, 0x79, 0x73, 0x70, 0x61,
-	0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0a, 0x0a, 0x06, 0x4e, 0x4f, 0x52, 0x4d, 0x41, 0x4c,
-	0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x53, 0x4e, 0x41, 0x50, 0x53, 0x48, 0x4f, 0x54, 0x10, 0x01,
-	0x2a, 0x32, 0x0a, 0x0e, 0x4b, 0x65, 0x79, 0x73, 0x70, 0x61, 0x63, 0x65, 0x49, 0x64, 0x54, 0x79,
-	0x70, 0x65, 0x12, 0x09, 0x0a, 0x05, 0x55, 0x4e, 0x53, 0x45, 0x54, 0x10, 0x00, 0x12, 0x0a, 0x0a,
-	0x06, 0x55, 0x49, 0x4e, 0x54, 0x36, 0x34, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x42, 0x59, 0x54,
-	0x45, 0x53, 0x10, 0x02, 0x2a, 0x90, 0x01, 0x0a, 0x0a, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x74, 0x54,
-	0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00,
-	0x12, 0x0a, 0x0a, 0x06, 0x4d, 0x41, 0x53, 0x54, 0x45, 0x52, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07,
-	0x52, 0x45, 0x50, 0x4c, 0x49, 0x43, 0x41, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x52, 0x44, 0x4f,
-	0x4e, 0x4c, 0x59, 0x10, 0x03, 0x12, 0x09, 0x0a, 0x05, 0x42, 0x41, 0x54, 0x43, 0x48, 0x10, 0x03,
-	0x12, 0x09, 0x0a, 0x05, 0x53, 0x50, 0x41, 0x52, 0x45, 0x10, 0x04, 0x12, 0x10, 0x0a, 0x0c, 0x45,
-	0x58, 0x50, 0x45, 0x52, 0x49, 0x4d, 0x45, 0x4e, 0x54, 0x41, 0x4c, 0x10, 0x05, 0x12, 0x0a, 0x0a,
-	0x06, 0x42, 0x41, 0x43, 0x4b, 0x55, 0x50, 0x10, 0x06, 0x12, 0x0b, 0x0a, 0x07, 0x52, 0x45, 0x53,
-	0x54, 0x4f, 0x52, 0x45, 0x10, 0x07, 0x12, 0x0b, 0x0a, 0x07, 0x44, 0x52, 0x41, 0x49, 0x4e, 0x45,
-	0x44, 0x10, 0x08, 0x1a, 0x02, 0x10, 0x01, 0x42, 0x38, 0x0a, 0x0f, 0x69, 0x6f, 0x2e, 0x76, 0x69,
-	0x74, 0x65, 0x73, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x5a, 0x25, 0x76, 0x69, 0x74, 0x65,
-	0x73, 0x73, 0x2e, 0x69, 0x6f, 0x2f, 0x76, 0x69, 0x74, 0x65, 0x73, 0x73, 0x2f, 0x67, 0x6f, 0x2f,
-	0x76, 0x74, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x74, 0x6f, 0x70, 0x6f, 0x64, 0x61, 0x74,
-	0x61, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x70, 0x73, 0x68, 0x6f, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x31, 0x0a, 0x0a, 0x71, 0x75, 0x65,
+	0x72, 0x79, 0x5f, 0x70, 0x69, 0x6e, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e,
+	0x74, 0x6f, 0x70, 0x6f, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x50, 0x69,
+	0x6e, 0x52, 0x09, 0x71, 0x75, 0x65, 0x72, 0x79, 0x50, 0x69, 0x6e, 0x73, 0x12, 0x34, 0x0a, 0x0b,
+	0x6d, 0x79, 0x73, 0x71, 0x6c, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x4d, 0x79, 0x53,
+	0x51, 0x4c, 0x55, 0x73, 0x65, 0x72, 0x52, 0x0a, 0x6d, 0x79, 0x73, 0x71, 0x6c, 0x55, 0x73, 0x65,
+	0x72, 0x73, 0x12, 0x2e, 0x0a, 0x09, 0x63, 0x64, 0x63, 0x5f, 0x73, 0x69, 0x6e, 0x6b, 0x73, 0x18,
+	0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x64, 0x61, 0x74, 0x61,
+	0x2e, 0x43, 0x44, 0x43, 0x53, 0x69, 0x6e, 0x6b, 0x52, 0x08, 0x63, 0x64, 0x63, 0x53, 0x69, 0x6e,
+	0x6b, 0x73, 0x12, 0x62, 0x0a, 0x16, 0x63, 0x65, 0x6c, 0x6c, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x6e, 0x67, 0x5f, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x73, 0x18, 0x0b, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x4b, 0x65,
+	0x79, 0x73, 0x70, 0x61, 0x63, 0x65, 0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x6e, 0x67, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x14, 0x63, 0x65, 0x6c, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x4f, 0x76, 0x65,
+	0x72, 0x72, 0x69, 0x64, 0x65, 0x73, 0x12, 0x51, 0x0a, 0x15, 0x62, 0x61, 0x63, 0x6b, 0x75, 0x70,
+	0x5f, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18,
+	0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x64, 0x61, 0x74, 0x61,
+	0x2e, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x52, 0x13, 0x62, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x53, 0x74, 0x6f, 0x72,
+	0x61, 0x67, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x60, 0x0a, 0x1a, 0x72, 0x65, 0x70,
+	0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6c, 0x61, 0x67, 0x5f, 0x74, 0x68, 0x72,
+	0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x73, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e,
+	0x74, 0x6f, 0x70, 0x6f, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x61, 0x67, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64,
+	0x73, 0x52, 0x18, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x61,
+	0x67, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x5f, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x18, 0x0e, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0c, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x46, 0x6c, 0x61, 0x67, 0x73,
+	0x1a, 0x75, 0x0a, 0x0a, 0x53, 0x65, 0x72, 0x76, 0x65, 0x64, 0x46, 0x72, 0x6f, 0x6d, 0x12, 0x35,
+	0x0a, 0x0b, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x54,
+	0x61, 0x62, 0x6c, 0x65, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0a, 0x74, 0x61, 0x62, 0x6c, 0x65,
+	0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x65, 0x6c, 0x6c, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x63, 0x65, 0x6c, 0x6c, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x6b,
+	0x65, 0x79, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6b,
+	0x65, 0x79, 0x73, 0x70, 0x61, 0x63, 0x65, 0x1a, 0x66, 0x0a, 0x19, 0x43, 0x65, 0x6c, 0x6c, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x33, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x64, 0x61, 0x74, 0x61,
+	0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x4f, 0x76, 0x65, 0x72,
+	0x72, 0x69, 0x64, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x4a,
+	0x04, 0x08, 0x03, 0x10, 0x04, 0x22, 0xbc, 0x03, 0x0a, 0x13, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70,
+	0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x30, 0x0a,
+	0x02, 0x73, 0x33, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x74, 0x6f, 0x70, 0x6f,
+	0x64, 0x61, 0x74, 0x61, 0x2e, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x53, 0x74, 0x6f, 0x72, 0x61,
+	0x67, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x53, 0x33, 0x52, 0x02, 0x73, 0x33, 0x1a,
+	0xf2, 0x02, 0x0a, 0x02, 0x53, 0x33, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x72, 0x6f, 0x6f, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x6f,
+	0x6f, 0x74, 0x12, 0x34, 0x0a, 0x16, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x73, 0x69, 0x64,
+	0x65, 0x5f, 0x65, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x14, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x69, 0x64, 0x65, 0x45, 0x6e,
+	0x63, 0x72, 0x79, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x0e, 0x73, 0x73, 0x65, 0x5f,
+	0x6b, 0x6d, 0x73, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x73, 0x73, 0x65, 0x4b, 0x6d, 0x73, 0x4b, 0x65, 0x79, 0x49, 0x64, 0x12, 0x28, 0x0a,
+	0x10, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x70, 0x61, 0x72, 0x74, 0x5f, 0x73, 0x69, 0x7a,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x50,
+	0x61, 0x72, 0x74, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x2d, 0x0a, 0x12, 0x75, 0x70, 0x6c, 0x6f, 0x61,
+	0x64, 0x5f, 0x63, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x11, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6e, 0x63, 0x75,
+	0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65,
+	0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x73,
+	0x12, 0x38, 0x0a, 0x0f, 0x6d, 0x69, 0x6e, 0x5f, 0x72, 0x65, 0x74, 0x72, 0x79, 0x5f, 0x64, 0x65,
+	0x6c, 0x61, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x76, 0x74, 0x74, 0x69,
+	0x6d, 0x65, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0d, 0x6d, 0x69, 0x6e,
+	0x52, 0x65, 0x74, 0x72, 0x79, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x12, 0x38, 0x0a, 0x0f, 0x6d, 0x61,
+	0x78, 0x5f, 0x72, 0x65, 0x74, 0x72, 0x79, 0x5f, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x76, 0x74, 0x74, 0x69, 0x6d, 0x65, 0x2e, 0x44, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0d, 0x6d, 0x61, 0x78, 0x52, 0x65, 0x74, 0x72, 0x79, 0x44,
+	0x65, 0x6c, 0x61, 0x79, 0x22, 0x92, 0x02, 0x0a, 0x18, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x61, 0x67, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64,
+	0x73, 0x12, 0x3b, 0x0a, 0x07, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x21, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x52, 0x65,
+	0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x61, 0x67, 0x54, 0x68, 0x72, 0x65,
+	0x73, 0x68, 0x6f, 0x6c, 0x64, 0x52, 0x07, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x12, 0x56,
+	0x0a, 0x0c, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x64, 0x61, 0x74, 0x61, 0x2e,
+	0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x61, 0x67, 0x54, 0x68,
+	0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x73, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x74, 0x54,
+	0x79, 0x70, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x74, 0x61, 0x62, 0x6c, 0x65,
+	0x74, 0x54, 0x79, 0x70, 0x65, 0x73, 0x1a, 0x61, 0x0a, 0x10, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x74,
+	0x54, 0x79, 0x70, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x37, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x74, 0x6f,
+	0x70, 0x6f, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x4c, 0x61, 0x67, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x5f, 0x0a, 0x17, 0x52, 0x65, 0x70,
+	0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x61, 0x67, 0x54, 0x68, 0x72, 0x65, 0x73,
+	0x68, 0x6f, 0x6c, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x6f, 0x66, 0x74, 0x5f, 0x73, 0x65, 0x63,
+	0x6f, 0x6e, 0x64, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x73, 0x6f, 0x66, 0x74,
+	0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x68, 0x61, 0x72, 0x64, 0x5f,
+	0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x68,
+	0x61, 0x72, 0x64, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x22, 0x4f, 0x0a, 0x13, 0x43, 0x65,
+	0x6c, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64,
+	0x65, 0x12, 0x20, 0x0a, 0x0c, 0x64, 0x6f, 0x5f, 0x6e, 0x6f, 0x74, 0x5f, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x64, 0x6f, 0x4e, 0x6f, 0x74, 0x53, 0x65,
+	0x72, 0x76, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x84, 0x01, 0x0a, 0x08,
+	0x51, 0x75, 0x65, 0x72, 0x79, 0x50, 0x69, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12, 0x20,
+	0x0a, 0x0b, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x12, 0x26, 0x0a, 0x07, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0c, 0x2e, 0x76, 0x74, 0x74, 0x69, 0x6d, 0x65, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x52,
+	0x07, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d,
+	0x65, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65,
+	0x6e, 0x74, 0x22, 0xa9, 0x03, 0x0a, 0x09, 0x4d, 0x79, 0x53, 0x51, 0x4c, 0x55, 0x73, 0x65, 0x72,
+	0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x75, 0x73, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x75, 0x74, 0x68,
+	0x5f, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x61,
+	0x75, 0x74, 0x68, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x12, 0x33, 0x0a, 0x15, 0x61, 0x75, 0x74,
+	0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x74, 0x72, 0x69,
+	0x6e, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x14, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e,
+	0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x2b,
+	0x0a, 0x11, 0x67, 0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x5f, 0x70, 0x72, 0x69, 0x76, 0x69, 0x6c, 0x65,
+	0x67, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x10, 0x67, 0x6c, 0x6f, 0x62, 0x61,
+	0x6c, 0x50, 0x72, 0x69, 0x76, 0x69, 0x6c, 0x65, 0x67, 0x65, 0x73, 0x12, 0x5c, 0x0a, 0x13, 0x64,
+	0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x70, 0x72, 0x69, 0x76, 0x69, 0x6c, 0x65, 0x67,
+	0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x64,
+	0x61, 0x74, 0x61, 0x2e, 0x4d, 0x79, 0x53, 0x51, 0x4c, 0x55, 0x73, 0x65, 0x72, 0x2e, 0x44, 0x61,
+	0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x50, 0x72, 0x69, 0x76, 0x69, 0x6c, 0x65, 0x67, 0x65, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x12, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x50,
+	0x72, 0x69, 0x76, 0x69, 0x6c, 0x65, 0x67, 0x65, 0x73, 0x1a, 0x2c, 0x0a, 0x0a, 0x50, 0x72, 0x69,
+	0x76, 0x69, 0x6c, 0x65, 0x67, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x72, 0x69, 0x76, 0x69,
+	0x6c, 0x65, 0x67, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x72, 0x69,
+	0x76, 0x69, 0x6c, 0x65, 0x67, 0x65, 0x73, 0x1a, 0x65, 0x0a, 0x17, 0x44, 0x61, 0x74, 0x61, 0x62,
+	0x61, 0x73, 0x65, 0x50, 0x72, 0x69, 0x76, 0x69, 0x6c, 0x65, 0x67, 0x65, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x34, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x4d,
+	0x79, 0x53, 0x51, 0x4c, 0x55, 0x73, 0x65, 0x72, 0x2e, 0x50, 0x72, 0x69, 0x76, 0x69, 0x6c, 0x65,
+	0x67, 0x65, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x6c,
+	0x0a, 0x07, 0x43, 0x44, 0x43, 0x53, 0x69, 0x6e, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f,
+	0x70, 0x69, 0x63, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x06, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x74,
+	0x61, 0x62, 0x6c, 0x65, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x74, 0x54, 0x79, 0x70, 0x65, 0x22, 0x8b, 0x01, 0x0a,
+	0x10, 0x53, 0x68, 0x61, 0x72, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x35, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1f, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x53, 0x68, 0x61, 0x72,
+	0x64, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x4e, 0x6f, 0x64,
+	0x65, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x1a, 0x40, 0x0a, 0x04, 0x4e, 0x6f, 0x64, 0x65,
+	0x12, 0x38, 0x0a, 0x0c, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x74, 0x5f, 0x61, 0x6c, 0x69, 0x61, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x64, 0x61, 0x74,
+	0x61, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x74, 0x41, 0x6c, 0x69, 0x61, 0x73, 0x52, 0x0b, 0x74,
+	0x61, 0x62, 0x6c, 0x65, 0x74, 0x41, 0x6c, 0x69, 0x61, 0x73, 0x22, 0x55, 0x0a, 0x0e, 0x53, 0x68,
+	0x61, 0x72, 0x64, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x2f, 0x0a, 0x09, 0x6b, 0x65, 0x79, 0x5f, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x4b,
+	0x65, 0x79, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x08, 0x6b, 0x65, 0x79, 0x52, 0x61, 0x6e, 0x67,
+	0x65, 0x22, 0x8f, 0x01, 0x0a, 0x12, 0x53, 0x68, 0x61, 0x72, 0x64, 0x54, 0x61, 0x62, 0x6c, 0x65,
+	0x74, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2f, 0x0a, 0x09,
+	0x6b, 0x65, 0x79, 0x5f, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x12, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x4b, 0x65, 0x79, 0x52, 0x61,
+	0x6e, 0x67, 0x65, 0x52, 0x08, 0x6b, 0x65, 0x79, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x34, 0x0a,
+	0x16, 0x71, 0x75, 0x65, 0x72, 0x79, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x64,
+	0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x14, 0x71,
+	0x75, 0x65, 0x72, 0x79, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x44, 0x69, 0x73, 0x61, 0x62,
+	0x6c, 0x65, 0x64, 0x22, 0xe2, 0x04, 0x0a, 0x0b, 0x53, 0x72, 0x76, 0x4b, 0x65, 0x79, 0x73, 0x70,
+	0x61, 0x63, 0x65, 0x12, 0x47, 0x0a, 0x0a, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x64, 0x61,
+	0x74, 0x61, 0x2e, 0x53, 0x72, 0x76, 0x4b, 0x65, 0x79, 0x73, 0x70, 0x61, 0x63, 0x65, 0x2e, 0x4b,
+	0x65, 0x79, 0x73, 0x70, 0x61, 0x63, 0x65, 0x50, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x0a, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x30, 0x0a, 0x14,
+	0x73, 0x68, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x5f,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x73, 0x68, 0x61, 0x72,
+	0x64, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x4a,
+	0x0a, 0x14, 0x73, 0x68, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x63, 0x6f, 0x6c, 0x75, 0x6d,
+	0x6e, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x74,
+	0x6f, 0x70, 0x6f, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x4b, 0x65, 0x79, 0x73, 0x70, 0x61, 0x63, 0x65,
+	0x49, 0x64, 0x54, 0x79, 0x70, 0x65, 0x52, 0x12, 0x73, 0x68, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67,
+	0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x41, 0x0a, 0x0b, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x64, 0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x20, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x53, 0x72, 0x76, 0x4b, 0x65,
+	0x79, 0x73, 0x70, 0x61, 0x63, 0x65, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x64, 0x46, 0x72, 0x6f,
+	0x6d, 0x52, 0x0a, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x46, 0x72, 0x6f, 0x6d, 0x1a, 0xe1, 0x01,
+	0x0a, 0x11, 0x4b, 0x65, 0x79, 0x73, 0x70, 0x61, 0x63, 0x65, 0x50, 0x61, 0x72, 0x74, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x35, 0x0a, 0x0b, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x5f, 0x74, 0x79,
+	0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x64,
+	0x61, 0x74, 0x61, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0a,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x54, 0x79, 0x70, 0x65, 0x12, 0x43, 0x0a, 0x10, 0x73, 0x68,
+	0x61, 0x72, 0x64, 0x5f, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x64, 0x61, 0x74, 0x61, 0x2e,
+	0x53, 0x68, 0x61, 0x72, 0x64, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x0f,
+	0x73, 0x68, 0x61, 0x72, 0x64, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x12,
+	0x50, 0x0a, 0x15, 0x73, 0x68, 0x61, 0x72, 0x64, 0x5f, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x74, 0x5f,
+	0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c,
+	0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x53, 0x68, 0x61, 0x72, 0x64, 0x54,
+	0x61, 0x62, 0x6c, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x52, 0x13, 0x73, 0x68,
+	0x61, 0x72, 0x64, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c,
+	0x73, 0x1a, 0x5f, 0x0a, 0x0a, 0x53, 0x65, 0x72, 0x76, 0x65, 0x64, 0x46, 0x72, 0x6f, 0x6d, 0x12,
+	0x35, 0x0a, 0x0b, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x64, 0x61, 0x74, 0x61, 0x2e,
+	0x54, 0x61, 0x62, 0x6c, 0x65, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0a, 0x74, 0x61, 0x62, 0x6c,
+	0x65, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6b, 0x65, 0x79, 0x73, 0x70, 0x61,
+	0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6b, 0x65, 0x79, 0x73, 0x70, 0x61,
+	0x63, 0x65, 0x4a, 0x04, 0x08, 0x05, 0x10, 0x06, 0x22, 0x4b, 0x0a, 0x08, 0x43, 0x65, 0x6c, 0x6c,
+	0x49, 0x6e, 0x66, 0x6f, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x61,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x72,
+	0x6f, 0x6f, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x6f, 0x6f, 0x74, 0x4a,
+	0x04, 0x08, 0x03, 0x10, 0x04, 0x22, 0x22, 0x0a, 0x0a, 0x43, 0x65, 0x6c, 0x6c, 0x73, 0x41, 0x6c,
+	0x69, 0x61, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x65, 0x6c, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x05, 0x63, 0x65, 0x6c, 0x6c, 0x73, 0x22, 0x55, 0x0a, 0x0a, 0x54, 0x6f, 0x70,
+	0x6f, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x6f, 0x70, 0x6f, 0x5f,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x6f, 0x70, 0x6f,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04,
+	0x72, 0x6f, 0x6f, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x6f, 0x6f, 0x74,
+	0x22, 0x4e, 0x0a, 0x15, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x56, 0x69, 0x74, 0x65,
+	0x73, 0x73, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x12, 0x35, 0x0a, 0x0b, 0x74, 0x6f, 0x70,
+	0x6f, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14,
+	0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x54, 0x6f, 0x70, 0x6f, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x52, 0x0a, 0x74, 0x6f, 0x70, 0x6f, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x22, 0x5a, 0x0a, 0x10, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x43, 0x6c, 0x75, 0x73,
+	0x74, 0x65, 0x72, 0x73, 0x12, 0x46, 0x0a, 0x0e, 0x76, 0x69, 0x74, 0x65, 0x73, 0x73, 0x5f, 0x63,
+	0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x74,
+	0x6f, 0x70, 0x6f, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c,
+	0x56, 0x69, 0x74, 0x65, 0x73, 0x73, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x52, 0x0d, 0x76,
+	0x69, 0x74, 0x65, 0x73, 0x73, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x22, 0x55, 0x0a, 0x06,
+	0x56, 0x74, 0x47, 0x61, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x70,
+	0x6f, 0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x67, 0x72, 0x70, 0x63, 0x50,
+	0x6f, 0x72, 0x74, 0x22, 0x9a, 0x01, 0x0a, 0x12, 0x57, 0x6f, 0x72, 0x6b, 0x66, 0x6c, 0x6f, 0x77,
+	0x54, 0x68, 0x72, 0x6f, 0x74, 0x74, 0x6c, 0x69, 0x6e, 0x67, 0x12, 0x26, 0x0a, 0x0f, 0x72, 0x6f,
+	0x77, 0x73, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0d, 0x72, 0x6f, 0x77, 0x73, 0x50, 0x65, 0x72, 0x53, 0x65, 0x63, 0x6f,
+	0x6e, 0x64, 0x12, 0x28, 0x0a, 0x10, 0x62, 0x79, 0x74, 0x65, 0x73, 0x5f, 0x70, 0x65, 0x72, 0x5f,
+	0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x62, 0x79,
+	0x74, 0x65, 0x73, 0x50, 0x65, 0x72, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x12, 0x32, 0x0a, 0x15,
+	0x6d, 0x61, 0x78, 0x5f, 0x63, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x63,
+	0x6f, 0x70, 0x69, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x13, 0x6d, 0x61, 0x78,
+	0x43, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x70, 0x69, 0x65, 0x73,
+	0x22, 0xcb, 0x01, 0x0a, 0x1a, 0x4b, 0x65, 0x79, 0x73, 0x70, 0x61, 0x63, 0x65, 0x57, 0x6f, 0x72,
+	0x6b, 0x66, 0x6c, 0x6f, 0x77, 0x54, 0x68, 0x72, 0x6f, 0x74, 0x74, 0x6c, 0x69, 0x6e, 0x67, 0x12,
+	0x51, 0x0a, 0x09, 0x77, 0x6f, 0x72, 0x6b, 0x66, 0x6c, 0x6f, 0x77, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x33, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x4b, 0x65,
+	0x79, 0x73, 0x70, 0x61, 0x63, 0x65, 0x57, 0x6f, 0x72, 0x6b, 0x66, 0x6c, 0x6f, 0x77, 0x54, 0x68,
+	0x72, 0x6f, 0x74, 0x74, 0x6c, 0x69, 0x6e, 0x67, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x66, 0x6c, 0x6f,
+	0x77, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x77, 0x6f, 0x72, 0x6b, 0x66, 0x6c, 0x6f,
+	0x77, 0x73, 0x1a, 0x5a, 0x0a, 0x0e, 0x57, 0x6f, 0x72, 0x6b, 0x66, 0x6c, 0x6f, 0x77, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x32, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x64, 0x61, 0x74, 0x61,
+	0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x66, 0x6c, 0x6f, 0x77, 0x54, 0x68, 0x72, 0x6f, 0x74, 0x74, 0x6c,
+	0x69, 0x6e, 0x67, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xa0,
+	0x03, 0x0a, 0x0e, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x72, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x63, 0x72, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72,
+	0x65, 0x6e, 0x63, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x63,
+	0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x2d, 0x0a, 0x12, 0x62, 0x61, 0x63, 0x6b, 0x75,
+	0x70, 0x5f, 0x63, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x11, 0x62, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x43, 0x6f, 0x6e, 0x63, 0x75,
+	0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x23, 0x0a, 0x0d, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f,
+	0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x61,
+	0x6c, 0x6c, 0x6f, 0x77, 0x50, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x1b, 0x0a, 0x09, 0x6b,
+	0x65, 0x65, 0x70, 0x5f, 0x6c, 0x61, 0x73, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08,
+	0x6b, 0x65, 0x65, 0x70, 0x4c, 0x61, 0x73, 0x74, 0x12, 0x29, 0x0a, 0x07, 0x6d, 0x61, 0x78, 0x5f,
+	0x61, 0x67, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x76, 0x74, 0x74, 0x69,
+	0x6d, 0x65, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x6d, 0x61, 0x78,
+	0x41, 0x67, 0x65, 0x12, 0x2d, 0x0a, 0x0b, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x69,
+	0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x76, 0x74, 0x74, 0x69, 0x6d,
+	0x65, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x52, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x69,
+	0x6d, 0x65, 0x12, 0x3c, 0x0a, 0x13, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x64,
+	0x75, 0x6c, 0x65, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0c, 0x2e, 0x76, 0x74, 0x74, 0x69, 0x6d, 0x65, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x52, 0x11, 0x6c,
+	0x61, 0x73, 0x74, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x54, 0x69, 0x6d, 0x65,
+	0x12, 0x30, 0x0a, 0x0d, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x72, 0x75, 0x6e, 0x5f, 0x74, 0x69, 0x6d,
+	0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x76, 0x74, 0x74, 0x69, 0x6d, 0x65,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x52, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x52, 0x75, 0x6e, 0x54, 0x69,
+	0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x45, 0x72, 0x72, 0x6f,
+	0x72, 0x2a, 0x28, 0x0a, 0x0c, 0x4b, 0x65, 0x79, 0x73, 0x70, 0x61, 0x63, 0x65, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x0a, 0x0a, 0x06, 0x4e, 0x4f, 0x52, 0x4d, 0x41, 0x4c, 0x10, 0x00, 0x12, 0x0c, 0x0a,
+	0x08, 0x53, 0x4e, 0x41, 0x50, 0x53, 0x48, 0x4f, 0x54, 0x10, 0x01, 0x2a, 0x32, 0x0a, 0x0e, 0x4b,
+	0x65, 0x79, 0x73, 0x70, 0x61, 0x63, 0x65, 0x49, 0x64, 0x54, 0x79, 0x70, 0x65, 0x12, 0x09, 0x0a,
+	0x05, 0x55, 0x4e, 0x53, 0x45, 0x54, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x55, 0x49, 0x4e, 0x54,
+	0x36, 0x34, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x42, 0x59, 0x54, 0x45, 0x53, 0x10, 0x02, 0x2a,
+	0x90, 0x01, 0x0a, 0x0a, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b,
+	0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x4d,
+	0x41, 0x53, 0x54, 0x45, 0x52, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x52, 0x45, 0x50, 0x4c, 0x49,
+	0x43, 0x41, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x52, 0x44, 0x4f, 0x4e, 0x4c, 0x59, 0x10, 0x03,
+	0x12, 0x09, 0x0a, 0x05, 0x42, 0x41, 0x54, 0x43, 0x48, 0x10, 0x03, 0x12, 0x09, 0x0a, 0x05, 0x53,
+	0x50, 0x41, 0x52, 0x45, 0x10, 0x04, 0x12, 0x10, 0x0a, 0x0c, 0x45, 0x58, 0x50, 0x45, 0x52, 0x49,
+	0x4d, 0x45, 0x4e, 0x54, 0x41, 0x4c, 0x10, 0x05, 0x12, 0x0a, 0x0a, 0x06, 0x42, 0x41, 0x43, 0x4b,
+	0x55, 0x50, 0x10, 0x06, 0x12, 0x0b, 0x0a, 0x07, 0x52, 0x45, 0x53, 0x54, 0x4f, 0x52, 0x45, 0x10,
+	0x07, 0x12, 0x0b, 0x0a, 0x07, 0x44, 0x52, 0x41, 0x49, 0x4e, 0x45, 0x44, 0x10, 0x08, 0x1a, 0x02,
+	0x10, 0x01, 0x42, 0x38, 0x0a, 0x0f, 0x69, 0x6f, 0x2e, 0x76, 0x69, 0x74, 0x65, 0x73, 0x73, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x5a, 0x25, 0x76, 0x69, 0x74, 0x65, 0x73, 0x73, 0x2e, 0x69, 0x6f,
+	0x2f, 0x76, 0x69, 0x74, 0x65, 0x73, 0x73, 0x2f, 0x67, 0x6f, 0x2f, 0x76, 0x74, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2f, 0x74, 0x6f, 0x70, 0x6f, 0x64, 0x61, 0x74, 0x61, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1986,7 +3236,7 @@ func file_topodata_proto_rawDescGZIP() []byte {
 }
 
 var file_topodata_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_topodata_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_topodata_proto_msgTypes = make([]protoimpl.MessageInfo, 40)
 var file_topodata_proto_goTypes = []interface{}{
 	(KeyspaceType)(0),                     // 0: topodata.KeyspaceType
 	(KeyspaceIdType)(0),                   // 1: topodata.KeyspaceIdType
@@ -1996,65 +3246,105 @@ var file_topodata_proto_goTypes = []interface{}{
 	(*Tablet)(nil),                        // 5: topodata.Tablet
 	(*Shard)(nil),                         // 6: topodata.Shard
 	(*Keyspace)(nil),                      // 7: topodata.Keyspace
-	(*ShardReplication)(nil),              // 8: topodata.ShardReplication
-	(*ShardReference)(nil),                // 9: topodata.ShardReference
-	(*ShardTabletControl)(nil),            // 10: topodata.ShardTabletControl
-	(*SrvKeyspace)(nil),                   // 11: topodata.SrvKeyspace
-	(*CellInfo)(nil),                      // 12: topodata.CellInfo
-	(*CellsAlias)(nil),                    // 13: topodata.CellsAlias
-	(*TopoConfig)(nil),                    // 14: topodata.TopoConfig
-	(*ExternalVitessCluster)(nil),         // 15: topodata.ExternalVitessCluster
-	(*ExternalClusters)(nil),              // 16: topodata.ExternalClusters
-	nil,                                   // 17: topodata.Tablet.PortMapEntry
-	nil,                                   // 18: topodata.Tablet.TagsEntry
-	(*Shard_ServedType)(nil),              // 19: topodata.Shard.ServedType
-	(*Shard_SourceShard)(nil),             // 20: topodata.Shard.SourceShard
-	(*Shard_TabletControl)(nil),           // 21: topodata.Shard.TabletControl
-	(*Keyspace_ServedFrom)(nil),           // 22: topodata.Keyspace.ServedFrom
-	(*ShardReplication_Node)(nil),         // 23: topodata.ShardReplication.Node
-	(*SrvKeyspace_KeyspacePartition)(nil), // 24: topodata.SrvKeyspace.KeyspacePartition
-	(*SrvKeyspace_ServedFrom)(nil),        // 25: topodata.SrvKeyspace.ServedFrom
-	(*vttime.Time)(nil),                   // 26: vttime.Time
+	(*BackupStorageConfig)(nil),           // 8: topodata.BackupStorageConfig
+	(*ReplicationLagThresholds)(nil),      // 9: topodata.ReplicationLagThresholds
+	(*ReplicationLagThreshold)(nil),       // 10: topodata.ReplicationLagThreshold
+	(*CellServingOverride)(nil),           // 11: topodata.CellServingOverride
+	(*QueryPin)(nil),                      // 12: topodata.QueryPin
+	(*MySQLUser)(nil),                     // 13: topodata.MySQLUser
+	(*CDCSink)(nil),                       // 14: topodata.CDCSink
+	(*ShardReplication)(nil),              // 15: topodata.ShardReplication
+	(*ShardReference)(nil),                // 16: topodata.ShardReference
+	(*ShardTabletControl)(nil),            // 17: topodata.ShardTabletControl
+	(*SrvKeyspace)(nil),                   // 18: topodata.SrvKeyspace
+	(*CellInfo)(nil),                      // 19: topodata.CellInfo
+	(*CellsAlias)(nil),                    // 20: topodata.CellsAlias
+	(*TopoConfig)(nil),                    // 21: topodata.TopoConfig
+	(*ExternalVitessCluster)(nil),         // 22: topodata.ExternalVitessCluster
+	(*ExternalClusters)(nil),              // 23: topodata.ExternalClusters
+	(*VtGate)(nil),                        // 24: topodata.VtGate
+	(*WorkflowThrottling)(nil),            // 25: topodata.WorkflowThrottling
+	(*KeyspaceWorkflowThrottling)(nil),    // 26: topodata.KeyspaceWorkflowThrottling
+	(*BackupSchedule)(nil),                // 27: topodata.BackupSchedule
+	nil,                                   // 28: topodata.Tablet.PortMapEntry
+	nil,                                   // 29: topodata.Tablet.TagsEntry
+	(*Shard_ServedType)(nil),              // 30: topodata.Shard.ServedType
+	(*Shard_SourceShard)(nil),             // 31: topodata.Shard.SourceShard
+	(*Shard_TabletControl)(nil),           // 32: topodata.Shard.TabletControl
+	(*Keyspace_ServedFrom)(nil),           // 33: topodata.Keyspace.ServedFrom
+	nil,                                   // 34: topodata.Keyspace.CellServingOverridesEntry
+	(*BackupStorageConfig_S3)(nil),        // 35: topodata.BackupStorageConfig.S3
+	nil,                                   // 36: topodata.ReplicationLagThresholds.TabletTypesEntry
+	(*MySQLUser_Privileges)(nil),          // 37: topodata.MySQLUser.Privileges
+	nil,                                   // 38: topodata.MySQLUser.DatabasePrivilegesEntry
+	(*ShardReplication_Node)(nil),         // 39: topodata.ShardReplication.Node
+	(*SrvKeyspace_KeyspacePartition)(nil), // 40: topodata.SrvKeyspace.KeyspacePartition
+	(*SrvKeyspace_ServedFrom)(nil),        // 41: topodata.SrvKeyspace.ServedFrom
+	nil,                                   // 42: topodata.KeyspaceWorkflowThrottling.WorkflowsEntry
+	(*vttime.Time)(nil),                   // 43: vttime.Time
+	(*vttime.Duration)(nil),               // 44: vttime.Duration
 }
 var file_topodata_proto_depIdxs = []int32{
 	4,  // 0: topodata.Tablet.alias:type_name -> topodata.TabletAlias
-	17, // 1: topodata.Tablet.port_map:type_name -> topodata.Tablet.PortMapEntry
+	28, // 1: topodata.Tablet.port_map:type_name -> topodata.Tablet.PortMapEntry
 	3,  // 2: topodata.Tablet.key_range:type_name -> topodata.KeyRange
 	2,  // 3: topodata.Tablet.type:type_name -> topodata.TabletType
-	18, // 4: topodata.Tablet.tags:type_name -> topodata.Tablet.TagsEntry
-	26, // 5: topodata.Tablet.master_term_start_time:type_name -> vttime.Time
+	29, // 4: topodata.Tablet.tags:type_name -> topodata.Tablet.TagsEntry
+	43, // 5: topodata.Tablet.master_term_start_time:type_name -> vttime.Time
 	4,  // 6: topodata.Shard.master_alias:type_name -> topodata.TabletAlias
-	26, // 7: topodata.Shard.master_term_start_time:type_name -> vttime.Time
+	43, // 7: topodata.Shard.master_term_start_time:type_name -> vttime.Time
 	3,  // 8: topodata.Shard.key_range:type_name -> topodata.KeyRange
-	19, // 9: topodata.Shard.served_types:type_name -> topodata.Shard.ServedType
-	20, // 10: topodata.Shard.source_shards:type_name -> topodata.Shard.SourceShard
-	21, // 11: topodata.Shard.tablet_controls:type_name -> topodata.Shard.TabletControl
+	30, // 9: topodata.Shard.served_types:type_name -> topodata.Shard.ServedType
+	31, // 10: topodata.Shard.source_shards:type_name -> topodata.Shard.SourceShard
+	32, // 11: topodata.Shard.tablet_controls:type_name -> topodata.Shard.TabletControl
 	1,  // 12: topodata.Keyspace.sharding_column_type:type_name -> topodata.KeyspaceIdType
-	22, // 13: topodata.Keyspace.served_froms:type_name -> topodata.Keyspace.ServedFrom
+	33, // 13: topodata.Keyspace.served_froms:type_name -> topodata.Keyspace.ServedFrom
 	0,  // 14: topodata.Keyspace.keyspace_type:type_name -> topodata.KeyspaceType
-	26, // 15: topodata.Keyspace.snapshot_time:type_name -> vttime.Time
-	23, // 16: topodata.ShardReplication.nodes:type_name -> topodata.ShardReplication.Node
-	3,  // 17: topodata.ShardReference.key_range:type_name -> topodata.KeyRange
-	3,  // 18: topodata.ShardTabletControl.key_range:type_name -> topodata.KeyRange
-	24, // 19: topodata.SrvKeyspace.partitions:type_name -> topodata.SrvKeyspace.KeyspacePartition
-	1,  // 20: topodata.SrvKeyspace.sharding_column_type:type_name -> topodata.KeyspaceIdType
-	25, // 21: topodata.SrvKeyspace.served_from:type_name -> topodata.SrvKeyspace.ServedFrom
-	14, // 22: topodata.ExternalVitessCluster.topo_config:type_name -> topodata.TopoConfig
-	15, // 23: topodata.ExternalClusters.vitess_cluster:type_name -> topodata.ExternalVitessCluster
-	2,  // 24: topodata.Shard.ServedType.tablet_type:type_name -> topodata.TabletType
-	3,  // 25: topodata.Shard.SourceShard.key_range:type_name -> topodata.KeyRange
-	2,  // 26: topodata.Shard.TabletControl.tablet_type:type_name -> topodata.TabletType
-	2,  // 27: topodata.Keyspace.ServedFrom.tablet_type:type_name -> topodata.TabletType
-	4,  // 28: topodata.ShardReplication.Node.tablet_alias:type_name -> topodata.TabletAlias
-	2,  // 29: topodata.SrvKeyspace.KeyspacePartition.served_type:type_name -> topodata.TabletType
-	9,  // 30: topodata.SrvKeyspace.KeyspacePartition.shard_references:type_name -> topodata.ShardReference
-	10, // 31: topodata.SrvKeyspace.KeyspacePartition.shard_tablet_controls:type_name -> topodata.ShardTabletControl
-	2,  // 32: topodata.SrvKeyspace.ServedFrom.tablet_type:type_name -> topodata.TabletType
-	33, // [33:33] is the sub-list for method output_type
-	33, // [33:33] is the sub-list for method input_type
-	33, // [33:33] is the sub-list for extension type_name
-	33, // [33:33] is the sub-list for extension extendee
-	0,  // [0:33] is the sub-list for field type_name
+	43, // 15: topodata.Keyspace.snapshot_time:type_name -> vttime.Time
+	12, // 16: topodata.Keyspace.query_pins:type_name -> topodata.QueryPin
+	13, // 17: topodata.Keyspace.mysql_users:type_name -> topodata.MySQLUser
+	14, // 18: topodata.Keyspace.cdc_sinks:type_name -> topodata.CDCSink
+	34, // 19: topodata.Keyspace.cell_serving_overrides:type_name -> topodata.Keyspace.CellServingOverridesEntry
+	8,  // 20: topodata.Keyspace.backup_storage_config:type_name -> topodata.BackupStorageConfig
+	9,  // 21: topodata.Keyspace.replication_lag_thresholds:type_name -> topodata.ReplicationLagThresholds
+	35, // 22: topodata.BackupStorageConfig.s3:type_name -> topodata.BackupStorageConfig.S3
+	10, // 23: topodata.ReplicationLagThresholds.default:type_name -> topodata.ReplicationLagThreshold
+	36, // 24: topodata.ReplicationLagThresholds.tablet_types:type_name -> topodata.ReplicationLagThresholds.TabletTypesEntry
+	43, // 25: topodata.QueryPin.expires:type_name -> vttime.Time
+	38, // 26: topodata.MySQLUser.database_privileges:type_name -> topodata.MySQLUser.DatabasePrivilegesEntry
+	39, // 27: topodata.ShardReplication.nodes:type_name -> topodata.ShardReplication.Node
+	3,  // 28: topodata.ShardReference.key_range:type_name -> topodata.KeyRange
+	3,  // 29: topodata.ShardTabletControl.key_range:type_name -> topodata.KeyRange
+	40, // 30: topodata.SrvKeyspace.partitions:type_name -> topodata.SrvKeyspace.KeyspacePartition
+	1,  // 31: topodata.SrvKeyspace.sharding_column_type:type_name -> topodata.KeyspaceIdType
+	41, // 32: topodata.SrvKeyspace.served_from:type_name -> topodata.SrvKeyspace.ServedFrom
+	21, // 33: topodata.ExternalVitessCluster.topo_config:type_name -> topodata.TopoConfig
+	22, // 34: topodata.ExternalClusters.vitess_cluster:type_name -> topodata.ExternalVitessCluster
+	42, // 35: topodata.KeyspaceWorkflowThrottling.workflows:type_name -> topodata.KeyspaceWorkflowThrottling.WorkflowsEntry
+	44, // 36: topodata.BackupSchedule.max_age:type_name -> vttime.Duration
+	43, // 37: topodata.BackupSchedule.create_time:type_name -> vttime.Time
+	43, // 38: topodata.BackupSchedule.last_scheduled_time:type_name -> vttime.Time
+	43, // 39: topodata.BackupSchedule.last_run_time:type_name -> vttime.Time
+	2,  // 40: topodata.Shard.ServedType.tablet_type:type_name -> topodata.TabletType
+	3,  // 41: topodata.Shard.SourceShard.key_range:type_name -> topodata.KeyRange
+	2,  // 42: topodata.Shard.TabletControl.tablet_type:type_name -> topodata.TabletType
+	2,  // 43: topodata.Keyspace.ServedFrom.tablet_type:type_name -> topodata.TabletType
+	11, // 44: topodata.Keyspace.CellServingOverridesEntry.value:type_name -> topodata.CellServingOverride
+	44, // 45: topodata.BackupStorageConfig.S3.min_retry_delay:type_name -> vttime.Duration
+	44, // 46: topodata.BackupStorageConfig.S3.max_retry_delay:type_name -> vttime.Duration
+	10, // 47: topodata.ReplicationLagThresholds.TabletTypesEntry.value:type_name -> topodata.ReplicationLagThreshold
+	37, // 48: topodata.MySQLUser.DatabasePrivilegesEntry.value:type_name -> topodata.MySQLUser.Privileges
+	4,  // 49: topodata.ShardReplication.Node.tablet_alias:type_name -> topodata.TabletAlias
+	2,  // 50: topodata.SrvKeyspace.KeyspacePartition.served_type:type_name -> topodata.TabletType
+	16, // 51: topodata.SrvKeyspace.KeyspacePartition.shard_references:type_name -> topodata.ShardReference
+	17, // 52: topodata.SrvKeyspace.KeyspacePartition.shard_tablet_controls:type_name -> topodata.ShardTabletControl
+	2,  // 53: topodata.SrvKeyspace.ServedFrom.tablet_type:type_name -> topodata.TabletType
+	25, // 54: topodata.KeyspaceWorkflowThrottling.WorkflowsEntry.value:type_name -> topodata.WorkflowThrottling
+	55, // [55:55] is the sub-list for method output_type
+	55, // [55:55] is the sub-list for method input_type
+	55, // [55:55] is the sub-list for extension type_name
+	55, // [55:55] is the sub-list for extension extendee
+	0,  // [0:55] is the sub-list for field type_name
 }
 
 func init() { file_topodata_proto_init() }
@@ -2124,7 +3414,7 @@ func file_topodata_proto_init() {
 			}
 		}
 		file_topodata_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ShardReplication); i {
+			switch v := v.(*BackupStorageConfig); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2136,7 +3426,7 @@ func file_topodata_proto_init() {
 			}
 		}
 		file_topodata_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ShardReference); i {
+			switch v := v.(*ReplicationLagThresholds); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2148,7 +3438,7 @@ func file_topodata_proto_init() {
 			}
 		}
 		file_topodata_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ShardTabletControl); i {
+			switch v := v.(*ReplicationLagThreshold); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2160,7 +3450,7 @@ func file_topodata_proto_init() {
 			}
 		}
 		file_topodata_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SrvKeyspace); i {
+			switch v := v.(*CellServingOverride); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2172,7 +3462,7 @@ func file_topodata_proto_init() {
 			}
 		}
 		file_topodata_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CellInfo); i {
+			switch v := v.(*QueryPin); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2184,7 +3474,7 @@ func file_topodata_proto_init() {
 			}
 		}
 		file_topodata_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CellsAlias); i {
+			switch v := v.(*MySQLUser); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2196,7 +3486,7 @@ func file_topodata_proto_init() {
 			}
 		}
 		file_topodata_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TopoConfig); i {
+			switch v := v.(*CDCSink); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2208,7 +3498,7 @@ func file_topodata_proto_init() {
 			}
 		}
 		file_topodata_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ExternalVitessCluster); i {
+			switch v := v.(*ShardReplication); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2220,7 +3510,31 @@ func file_topodata_proto_init() {
 			}
 		}
 		file_topodata_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ExternalClusters); i {
+			switch v := v.(*ShardReference); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_topodata_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ShardTabletControl); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_topodata_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SrvKeyspace); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2232,7 +3546,7 @@ func file_topodata_proto_init() {
 			}
 		}
 		file_topodata_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Shard_ServedType); i {
+			switch v := v.(*CellInfo); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2244,7 +3558,7 @@ func file_topodata_proto_init() {
 			}
 		}
 		file_topodata_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Shard_SourceShard); i {
+			switch v := v.(*CellsAlias); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2256,7 +3570,7 @@ func file_topodata_proto_init() {
 			}
 		}
 		file_topodata_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Shard_TabletControl); i {
+			switch v := v.(*TopoConfig); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2268,7 +3582,7 @@ func file_topodata_proto_init() {
 			}
 		}
 		file_topodata_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Keyspace_ServedFrom); i {
+			switch v := v.(*ExternalVitessCluster); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2280,7 +3594,7 @@ func file_topodata_proto_init() {
 			}
 		}
 		file_topodata_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ShardReplication_Node); i {
+			switch v := v.(*ExternalClusters); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2292,7 +3606,7 @@ func file_topodata_proto_init() {
 			}
 		}
 		file_topodata_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SrvKeyspace_KeyspacePartition); i {
+			switch v := v.(*VtGate); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2304,6 +3618,138 @@ func file_topodata_proto_init() {
 			}
 		}
 		file_topodata_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WorkflowThrottling); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_topodata_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeyspaceWorkflowThrottling); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_topodata_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BackupSchedule); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_topodata_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Shard_ServedType); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_topodata_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Shard_SourceShard); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_topodata_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Shard_TabletControl); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_topodata_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Keyspace_ServedFrom); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_topodata_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BackupStorageConfig_S3); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_topodata_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MySQLUser_Privileges); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_topodata_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ShardReplication_Node); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_topodata_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SrvKeyspace_KeyspacePartition); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_topodata_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SrvKeyspace_ServedFrom); i {
 			case 0:
 				return &v.state
@@ -2322,7 +3768,7 @@ func file_topodata_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_topodata_proto_rawDesc,
 			NumEnums:      3,
-			NumMessages:   23,
+			NumMessages:   40,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -132,6 +132,11 @@ var Vtctl_ServiceDesc = grpc.ServiceDesc{
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type VtctldClient interface {
+	// AcquireKeyspaceLock locks a keyspace, or a shard, in the topology on
+	// behalf of an external tool, so it can coordinate with the operations of
+	// Vitess which take the same lock. The vtctld holds the lock until it is
+	// released or its TTL expires, and loses it if it restarts.
+	AcquireKeyspaceLock(ctx context.Context, in *vtctldata.AcquireKeyspaceLockRequest, opts ...grpc.CallOption) (*vtctldata.AcquireKeyspaceLockResponse, error)
 	// AddCellInfo registers a local topology service in a new cell by creating
 	// the CellInfo with the provided parameters.
 	AddCellInfo(ctx context.Context, in *vtctldata.AddCellInfoRequest, opts ...grpc.CallOption) (*vtctldata.AddCellInfoResponse, error)
@@ -142,24 +147,62 @@ type VtctldClient interface {
 	// cells within the group (alias). Only primary traffic can be routed across
 	// cells not in the same group (alias).
 	AddCellsAlias(ctx context.Context, in *vtctldata.AddCellsAliasRequest, opts ...grpc.CallOption) (*vtctldata.AddCellsAliasResponse, error)
+	// AdvanceGCTable moves the HOLD or EVAC tables of a keyspace with a UUID
+	// to their next state, the next time the table GC checks its tables.
+	AdvanceGCTable(ctx context.Context, in *vtctldata.AdvanceGCTableRequest, opts ...grpc.CallOption) (*vtctldata.AdvanceGCTableResponse, error)
+	// ApplyMySQLUsers makes the MySQL users of the primaries of a keyspace match
+	// the declared ones, and waits for the replicas to converge through
+	// replication.
+	ApplyMySQLUsers(ctx context.Context, in *vtctldata.ApplyMySQLUsersRequest, opts ...grpc.CallOption) (*vtctldata.ApplyMySQLUsersResponse, error)
 	// ApplyRoutingRules applies the VSchema routing rules.
 	ApplyRoutingRules(ctx context.Context, in *vtctldata.ApplyRoutingRulesRequest, opts ...grpc.CallOption) (*vtctldata.ApplyRoutingRulesResponse, error)
+	// ApplyRoutingRulesBatch atomically sets the routing rules of some tables,
+	// and deletes the ones of others. The new rules are validated, and only
+	// saved if the current ones are at the expected version. It returns the
+	// changes, and the queries in the plan cache of the given vtgates whose
+	// routing changes.
+	ApplyRoutingRulesBatch(ctx context.Context, in *vtctldata.ApplyRoutingRulesBatchRequest, opts ...grpc.CallOption) (*vtctldata.ApplyRoutingRulesBatchResponse, error)
 	// ApplyVSchema applies a vschema to a keyspace.
 	ApplyVSchema(ctx context.Context, in *vtctldata.ApplyVSchemaRequest, opts ...grpc.CallOption) (*vtctldata.ApplyVSchemaResponse, error)
+	// ApproveMigration approves an online DDL migration pending approval on all
+	// the shards of its keyspace, which then queue it.
+	ApproveMigration(ctx context.Context, in *vtctldata.ApproveMigrationRequest, opts ...grpc.CallOption) (*vtctldata.ApproveMigrationResponse, error)
+	// BackupKeyspace backs up every shard of a keyspace, each from its most
+	// up-to-date replica, and returns the finished run.
+	BackupKeyspace(ctx context.Context, in *vtctldata.BackupKeyspaceRequest, opts ...grpc.CallOption) (*vtctldata.BackupKeyspaceResponse, error)
+	// BrowseTopology returns a node of the topo: its decoded contents and
+	// version when it is a file, or its children. The paths it can read are
+	// restricted by -topo_browse_allowed_paths.
+	BrowseTopology(ctx context.Context, in *vtctldata.BrowseTopologyRequest, opts ...grpc.CallOption) (*vtctldata.BrowseTopologyResponse, error)
 	// ChangeTabletType changes the db type for the specified tablet, if possible.
 	// This is used primarily to arrange replicas, and it will not convert a
 	// primary. For that, use InitShardPrimary.
 	//
 	// NOTE: This command automatically updates the serving graph.
 	ChangeTabletType(ctx context.Context, in *vtctldata.ChangeTabletTypeRequest, opts ...grpc.CallOption) (*vtctldata.ChangeTabletTypeResponse, error)
+	// CheckMySQLUsers compares the MySQL users of the tablets of a keyspace with
+	// the declared ones, and returns the drift of each tablet.
+	CheckMySQLUsers(ctx context.Context, in *vtctldata.CheckMySQLUsersRequest, opts ...grpc.CallOption) (*vtctldata.CheckMySQLUsersResponse, error)
 	// CreateKeyspace creates the specified keyspace in the topology. For a
 	// SNAPSHOT keyspace, the request must specify the name of a base keyspace,
 	// as well as a snapshot time.
 	CreateKeyspace(ctx context.Context, in *vtctldata.CreateKeyspaceRequest, opts ...grpc.CallOption) (*vtctldata.CreateKeyspaceResponse, error)
 	// CreateShard creates the specified shard in the topology.
 	CreateShard(ctx context.Context, in *vtctldata.CreateShardRequest, opts ...grpc.CallOption) (*vtctldata.CreateShardResponse, error)
+	// DecommissionTablet checks that a tablet isn't the primary or the last
+	// replica of its shard, nor the source of vreplication streams, then drains
+	// it, stops its replication and deletes it from the topology. It refuses
+	// with the reasons otherwise.
+	DecommissionTablet(ctx context.Context, in *vtctldata.DecommissionTabletRequest, opts ...grpc.CallOption) (*vtctldata.DecommissionTabletResponse, error)
+	// DeleteBackupSchedule deletes the backup schedule of a keyspace.
+	DeleteBackupSchedule(ctx context.Context, in *vtctldata.DeleteBackupScheduleRequest, opts ...grpc.CallOption) (*vtctldata.DeleteBackupScheduleResponse, error)
+	// DeleteBackupStorageConfig deletes the backup storage config of a
+	// keyspace, so that the backup storage flags of the tablets apply again.
+	DeleteBackupStorageConfig(ctx context.Context, in *vtctldata.DeleteBackupStorageConfigRequest, opts ...grpc.CallOption) (*vtctldata.DeleteBackupStorageConfigResponse, error)
 	// DeleteCellInfo deletes the CellInfo for the provided cell. The cell cannot
-	// be referenced by any Shard record in the topology.
+	// have SrvKeyspace or tablet records, unless Force is set, and cannot be
+	// referenced by any CellsAlias. With Drain, the SrvKeyspace records of the
+	// cell are deleted first.
 	DeleteCellInfo(ctx context.Context, in *vtctldata.DeleteCellInfoRequest, opts ...grpc.CallOption) (*vtctldata.DeleteCellInfoResponse, error)
 	// DeleteCellsAlias deletes the CellsAlias for the provided alias.
 	DeleteCellsAlias(ctx context.Context, in *vtctldata.DeleteCellsAliasRequest, opts ...grpc.CallOption) (*vtctldata.DeleteCellsAliasResponse, error)
@@ -175,27 +218,58 @@ type VtctldClient interface {
 	DeleteShards(ctx context.Context, in *vtctldata.DeleteShardsRequest, opts ...grpc.CallOption) (*vtctldata.DeleteShardsResponse, error)
 	// DeleteTablets deletes one or more tablets from the topology.
 	DeleteTablets(ctx context.Context, in *vtctldata.DeleteTabletsRequest, opts ...grpc.CallOption) (*vtctldata.DeleteTabletsResponse, error)
+	// DrainTablet drains a tablet: the gateways stop sending it new queries,
+	// but let the in-flight ones finish. A master cannot be drained.
+	DrainTablet(ctx context.Context, in *vtctldata.DrainTabletRequest, opts ...grpc.CallOption) (*vtctldata.DrainTabletResponse, error)
 	// EmergencyReparentShard reparents the shard to the new primary. It assumes
-	// the old primary is dead or otherwise not responding.
+	// the old primary is dead or otherwise not responding. The candidates for
+	// promotion can be constrained, and a dry run only elects the new primary.
 	EmergencyReparentShard(ctx context.Context, in *vtctldata.EmergencyReparentShardRequest, opts ...grpc.CallOption) (*vtctldata.EmergencyReparentShardResponse, error)
+	// FenceShard stops the writes of a shard: the vtgates stop sending queries
+	// to its primary, and the primary becomes read-only. It returns the fence,
+	// with the final replication position of the primary.
+	FenceShard(ctx context.Context, in *vtctldata.FenceShardRequest, opts ...grpc.CallOption) (*vtctldata.FenceShardResponse, error)
 	// FindAllShardsInKeyspace returns a map of shard names to shard references
 	// for a given keyspace.
 	FindAllShardsInKeyspace(ctx context.Context, in *vtctldata.FindAllShardsInKeyspaceRequest, opts ...grpc.CallOption) (*vtctldata.FindAllShardsInKeyspaceResponse, error)
+	// GetBackupSchedule returns the backup schedule of a keyspace.
+	GetBackupSchedule(ctx context.Context, in *vtctldata.GetBackupScheduleRequest, opts ...grpc.CallOption) (*vtctldata.GetBackupScheduleResponse, error)
 	// GetBackups returns all the backups for a shard.
 	GetBackups(ctx context.Context, in *vtctldata.GetBackupsRequest, opts ...grpc.CallOption) (*vtctldata.GetBackupsResponse, error)
+	// GetBackupStorageConfig returns the backup storage config of a keyspace.
+	GetBackupStorageConfig(ctx context.Context, in *vtctldata.GetBackupStorageConfigRequest, opts ...grpc.CallOption) (*vtctldata.GetBackupStorageConfigResponse, error)
+	// GetCDCSinks returns the CDC sinks of a keyspace.
+	GetCDCSinks(ctx context.Context, in *vtctldata.GetCDCSinksRequest, opts ...grpc.CallOption) (*vtctldata.GetCDCSinksResponse, error)
 	// GetCellInfo returns the information for a cell.
 	GetCellInfo(ctx context.Context, in *vtctldata.GetCellInfoRequest, opts ...grpc.CallOption) (*vtctldata.GetCellInfoResponse, error)
 	// GetCellInfoNames returns all the cells for which we have a CellInfo object,
 	// meaning we have a topology service registered.
 	GetCellInfoNames(ctx context.Context, in *vtctldata.GetCellInfoNamesRequest, opts ...grpc.CallOption) (*vtctldata.GetCellInfoNamesResponse, error)
+	// GetCellServingOverride returns the serving overrides of the cells of a
+	// keyspace.
+	GetCellServingOverride(ctx context.Context, in *vtctldata.GetCellServingOverrideRequest, opts ...grpc.CallOption) (*vtctldata.GetCellServingOverrideResponse, error)
 	// GetCellsAliases returns a mapping of cell alias to cells identified by that
 	// alias.
 	GetCellsAliases(ctx context.Context, in *vtctldata.GetCellsAliasesRequest, opts ...grpc.CallOption) (*vtctldata.GetCellsAliasesResponse, error)
+	// GetGCTables returns the tables garbage collected by the primaries of a
+	// keyspace, by state.
+	GetGCTables(ctx context.Context, in *vtctldata.GetGCTablesRequest, opts ...grpc.CallOption) (*vtctldata.GetGCTablesResponse, error)
+	// GetKeyspaceBackupRuns returns the keyspace backup runs this vtctld
+	// started, including the running ones.
+	GetKeyspaceBackupRuns(ctx context.Context, in *vtctldata.GetKeyspaceBackupRunsRequest, opts ...grpc.CallOption) (*vtctldata.GetKeyspaceBackupRunsResponse, error)
 	// GetKeyspace reads the given keyspace from the topo and returns it.
 	GetKeyspace(ctx context.Context, in *vtctldata.GetKeyspaceRequest, opts ...grpc.CallOption) (*vtctldata.GetKeyspaceResponse, error)
 	// GetKeyspaces returns the keyspace struct of all keyspaces in the topo.
 	GetKeyspaces(ctx context.Context, in *vtctldata.GetKeyspacesRequest, opts ...grpc.CallOption) (*vtctldata.GetKeyspacesResponse, error)
-	// GetRoutingRules returns the VSchema routing rules.
+	// GetMySQLUsers returns the MySQL users declared for the tablets of a
+	// keyspace.
+	GetMySQLUsers(ctx context.Context, in *vtctldata.GetMySQLUsersRequest, opts ...grpc.CallOption) (*vtctldata.GetMySQLUsersResponse, error)
+	// GetOnlineDDLProgress returns the progress of an online DDL migration on
+	// each shard of its keyspace, and aggregated over them: the rows copied,
+	// the ETA, whether the throttler pushes back on it, and for the online
+	// strategy the lag of its vreplication streams.
+	GetOnlineDDLProgress(ctx context.Context, in *vtctldata.GetOnlineDDLProgressRequest, opts ...grpc.CallOption) (*vtctldata.GetOnlineDDLProgressResponse, error)
+	// GetRoutingRules returns the VSchema routing rules, with their version.
 	GetRoutingRules(ctx context.Context, in *vtctldata.GetRoutingRulesRequest, opts ...grpc.CallOption) (*vtctldata.GetRoutingRulesResponse, error)
 	// GetSchema returns the schema for a tablet, or just the schema for the
 	// specified tables in that tablet.
@@ -205,6 +279,18 @@ type VtctldClient interface {
 	// GetSrvKeyspaces returns the SrvKeyspaces for a keyspace in one or more
 	// cells.
 	GetSrvKeyspaces(ctx context.Context, in *vtctldata.GetSrvKeyspacesRequest, opts ...grpc.CallOption) (*vtctldata.GetSrvKeyspacesResponse, error)
+	// GetShardFence returns the fence of a fenced shard.
+	GetShardFence(ctx context.Context, in *vtctldata.GetShardFenceRequest, opts ...grpc.CallOption) (*vtctldata.GetShardFenceResponse, error)
+	// GetShardReplicationGraph returns the live MySQL replication topology of a
+	// shard: which tablet each tablet replicates from, the state of its
+	// replication threads and its lag, and how it differs from the topo.
+	GetShardReplicationGraph(ctx context.Context, in *vtctldata.GetShardReplicationGraphRequest, opts ...grpc.CallOption) (*vtctldata.GetShardReplicationGraphResponse, error)
+	// GetShardServingTimeline returns the serving state transitions of the
+	// tablets of a shard.
+	GetShardServingTimeline(ctx context.Context, in *vtctldata.GetShardServingTimelineRequest, opts ...grpc.CallOption) (*vtctldata.GetShardServingTimelineResponse, error)
+	// GetSrvKeyspaceWithVersion returns the SrvKeyspace for a keyspace in a
+	// cell, with its version, to edit it with UpdateSrvKeyspace.
+	GetSrvKeyspaceWithVersion(ctx context.Context, in *vtctldata.GetSrvKeyspaceWithVersionRequest, opts ...grpc.CallOption) (*vtctldata.GetSrvKeyspaceWithVersionResponse, error)
 	// GetSrvVSchema returns the SrvVSchema for a cell.
 	GetSrvVSchema(ctx context.Context, in *vtctldata.GetSrvVSchemaRequest, opts ...grpc.CallOption) (*vtctldata.GetSrvVSchemaResponse, error)
 	// GetSrvVSchemas returns a mapping from cell name to SrvVSchema for all cells,
@@ -212,10 +298,23 @@ type VtctldClient interface {
 	GetSrvVSchemas(ctx context.Context, in *vtctldata.GetSrvVSchemasRequest, opts ...grpc.CallOption) (*vtctldata.GetSrvVSchemasResponse, error)
 	// GetTablet returns information about a tablet.
 	GetTablet(ctx context.Context, in *vtctldata.GetTabletRequest, opts ...grpc.CallOption) (*vtctldata.GetTabletResponse, error)
+	// GetTabletHealthHistory returns the health transitions of the tablets
+	// recorded by the healthcheck of vtctld, which requires vtctld to run with
+	// -enable_realtime_stats.
+	GetTabletHealthHistory(ctx context.Context, in *vtctldata.GetTabletHealthHistoryRequest, opts ...grpc.CallOption) (*vtctldata.GetTabletHealthHistoryResponse, error)
 	// GetTablets returns tablets, optionally filtered by keyspace and shard.
 	GetTablets(ctx context.Context, in *vtctldata.GetTabletsRequest, opts ...grpc.CallOption) (*vtctldata.GetTabletsResponse, error)
 	// GetVSchema returns the vschema for a keyspace.
 	GetVSchema(ctx context.Context, in *vtctldata.GetVSchemaRequest, opts ...grpc.CallOption) (*vtctldata.GetVSchemaResponse, error)
+	// GetWorkflowAuditLog returns the audit log of a workflow of the workflow
+	// manager, including after its deletion.
+	GetWorkflowAuditLog(ctx context.Context, in *vtctldata.GetWorkflowAuditLogRequest, opts ...grpc.CallOption) (*vtctldata.GetWorkflowAuditLogResponse, error)
+	// GetWorkflowProgress returns the progress of a vreplication workflow,
+	// e.g. MoveTables or Reshard: for each stream, the rows copied against the
+	// rows estimated from the source tables, the copy throughput, the
+	// replication lag and the last error, and their aggregates over the
+	// workflow.
+	GetWorkflowProgress(ctx context.Context, in *vtctldata.GetWorkflowProgressRequest, opts ...grpc.CallOption) (*vtctldata.GetWorkflowProgressResponse, error)
 	// GetWorkflows returns a list of workflows for the given keyspace.
 	GetWorkflows(ctx context.Context, in *vtctldata.GetWorkflowsRequest, opts ...grpc.CallOption) (*vtctldata.GetWorkflowsResponse, error)
 	// InitShardPrimary sets the initial primary for a shard. Will make all other
@@ -225,6 +324,19 @@ type VtctldClient interface {
 	// PlannedReparentShard or EmergencyReparentShard should be used in those
 	// cases instead.
 	InitShardPrimary(ctx context.Context, in *vtctldata.InitShardPrimaryRequest, opts ...grpc.CallOption) (*vtctldata.InitShardPrimaryResponse, error)
+	// ListKeyspaceBackups returns the backups of every shard of a keyspace.
+	ListKeyspaceBackups(ctx context.Context, in *vtctldata.ListKeyspaceBackupsRequest, opts ...grpc.CallOption) (*vtctldata.ListKeyspaceBackupsResponse, error)
+	// ListLocks returns the keyspace and shard locks the vtctld holds on behalf
+	// of external tools.
+	ListLocks(ctx context.Context, in *vtctldata.ListLocksRequest, opts ...grpc.CallOption) (*vtctldata.ListLocksResponse, error)
+	// ListVDiffResults returns the stored results of the VDiffs of a workflow,
+	// from the newest, including those run by a schedule of the vdiff workflow.
+	ListVDiffResults(ctx context.Context, in *vtctldata.ListVDiffResultsRequest, opts ...grpc.CallOption) (*vtctldata.ListVDiffResultsResponse, error)
+	// PlanReparentShard plans a PlannedReparentShard or an
+	// EmergencyReparentShard without changing anything: it ranks the candidates
+	// for promotion, and returns the tablet which would be promoted, the tablets
+	// which would be repointed to it and the expected write downtime.
+	PlanReparentShard(ctx context.Context, in *vtctldata.PlanReparentShardRequest, opts ...grpc.CallOption) (*vtctldata.PlanReparentShardResponse, error)
 	// PlannedReparentShard reparents the shard to the new primary, or away from
 	// an old primary. Both the old and new primaries need to be reachable and
 	// running.
@@ -233,14 +345,34 @@ type VtctldClient interface {
 	// current shard primary is in for promotion unless NewPrimary is explicitly
 	// provided in the request.
 	PlannedReparentShard(ctx context.Context, in *vtctldata.PlannedReparentShardRequest, opts ...grpc.CallOption) (*vtctldata.PlannedReparentShardResponse, error)
+	// PreviewDeclarativeSchema returns the tables to create, alter and drop to
+	// converge the schema of a keyspace to the CREATE TABLE statements of all
+	// its tables, without applying them.
+	PreviewDeclarativeSchema(ctx context.Context, in *vtctldata.PreviewDeclarativeSchemaRequest, opts ...grpc.CallOption) (*vtctldata.PreviewDeclarativeSchemaResponse, error)
+	// PruneBackups removes the backups of a keyspace that are beyond the given
+	// retention.
+	PruneBackups(ctx context.Context, in *vtctldata.PruneBackupsRequest, opts ...grpc.CallOption) (*vtctldata.PruneBackupsResponse, error)
+	// RebuildKeyspaceGraph rebuilds the per-cell SrvKeyspace objects of a
+	// keyspace from its global shard records, and reports how it went in
+	// each cell.
+	RebuildKeyspaceGraph(ctx context.Context, in *vtctldata.RebuildKeyspaceGraphRequest, opts ...grpc.CallOption) (*vtctldata.RebuildKeyspaceGraphResponse, error)
 	// RebuildVSchemaGraph rebuilds the per-cell SrvVSchema from the global
 	// VSchema objects in the provided cells (or all cells in the topo none
 	// provided).
 	RebuildVSchemaGraph(ctx context.Context, in *vtctldata.RebuildVSchemaGraphRequest, opts ...grpc.CallOption) (*vtctldata.RebuildVSchemaGraphResponse, error)
+	// RecommendTabletTypeChange lists the tablets of a shard and cell which
+	// can be changed from one type to another with ChangeTabletType, the ones
+	// with the least impact on the serving tablets first.
+	RecommendTabletTypeChange(ctx context.Context, in *vtctldata.RecommendTabletTypeChangeRequest, opts ...grpc.CallOption) (*vtctldata.RecommendTabletTypeChangeResponse, error)
 	// RefreshState reloads the tablet record on the specified tablet.
 	RefreshState(ctx context.Context, in *vtctldata.RefreshStateRequest, opts ...grpc.CallOption) (*vtctldata.RefreshStateResponse, error)
 	// RefreshStateByShard calls RefreshState on all the tablets in the given shard.
 	RefreshStateByShard(ctx context.Context, in *vtctldata.RefreshStateByShardRequest, opts ...grpc.CallOption) (*vtctldata.RefreshStateByShardResponse, error)
+	// RejectMigration rejects an online DDL migration pending approval on all
+	// the shards of its keyspace, which then cancel it.
+	RejectMigration(ctx context.Context, in *vtctldata.RejectMigrationRequest, opts ...grpc.CallOption) (*vtctldata.RejectMigrationResponse, error)
+	// ReleaseLock releases a lock acquired with AcquireKeyspaceLock.
+	ReleaseLock(ctx context.Context, in *vtctldata.ReleaseLockRequest, opts ...grpc.CallOption) (*vtctldata.ReleaseLockResponse, error)
 	// RemoveKeyspaceCell removes the specified cell from the Cells list for all
 	// shards in the specified keyspace, as well as from the SrvKeyspace for that
 	// keyspace in that cell.
@@ -252,12 +384,57 @@ type VtctldClient interface {
 	// only works if the current replica position matches the last known reparent
 	// action.
 	ReparentTablet(ctx context.Context, in *vtctldata.ReparentTabletRequest, opts ...grpc.CallOption) (*vtctldata.ReparentTabletResponse, error)
+	// RestoreGCTable renames the HOLD tables of a keyspace with a UUID back to
+	// a regular table.
+	RestoreGCTable(ctx context.Context, in *vtctldata.RestoreGCTableRequest, opts ...grpc.CallOption) (*vtctldata.RestoreGCTableResponse, error)
+	// RestoreToPointInTime restores a shard to a GTID position or to a time on
+	// a new tablet, started by the provision_pitr_tablet hook: the tablet
+	// restores the most recent full backup before the point in time, then
+	// replays the binary logs of the incremental backups up to it.
+	RestoreToPointInTime(ctx context.Context, in *vtctldata.RestoreToPointInTimeRequest, opts ...grpc.CallOption) (*vtctldata.RestoreToPointInTimeResponse, error)
+	// RevertMigration submits a migration reverting a complete online DDL
+	// migration, once it checked that the artifacts of the migration are still
+	// kept on all the shards.
+	RevertMigration(ctx context.Context, in *vtctldata.RevertMigrationRequest, opts ...grpc.CallOption) (*vtctldata.RevertMigrationResponse, error)
+	// SeedTable inserts the rows of the seed source declared in the VSchema
+	// for a table on the primaries of its shards, routed with the primary
+	// vindex of the table. The rows whose keys already exist are skipped, so
+	// a table can be seeded again.
+	SeedTable(ctx context.Context, in *vtctldata.SeedTableRequest, opts ...grpc.CallOption) (*vtctldata.SeedTableResponse, error)
+	// SetBackupSchedule creates or replaces the backup schedule of a keyspace.
+	SetBackupSchedule(ctx context.Context, in *vtctldata.SetBackupScheduleRequest, opts ...grpc.CallOption) (*vtctldata.SetBackupScheduleResponse, error)
+	// SetBackupStorageConfig sets the backup storage config of a keyspace,
+	// which overrides the backup storage flags of the tablets for the backups
+	// of its shards.
+	SetBackupStorageConfig(ctx context.Context, in *vtctldata.SetBackupStorageConfigRequest, opts ...grpc.CallOption) (*vtctldata.SetBackupStorageConfigResponse, error)
+	// SetCDCSinks declares the CDC sinks of a keyspace. The CDC sink service
+	// restarts the sinks which changed.
+	SetCDCSinks(ctx context.Context, in *vtctldata.SetCDCSinksRequest, opts ...grpc.CallOption) (*vtctldata.SetCDCSinksResponse, error)
+	// SetCellServingOverride sets or removes the serving override of a cell
+	// for a keyspace. The vtgates of the other cells started with
+	// -enable_keyspace_cell_serving_overrides don't route the queries of the
+	// keyspace to the tablets of a cell marked as "do not serve".
+	SetCellServingOverride(ctx context.Context, in *vtctldata.SetCellServingOverrideRequest, opts ...grpc.CallOption) (*vtctldata.SetCellServingOverrideResponse, error)
+	// SetGCTablesRetention sets when the HOLD or EVAC tables of a keyspace
+	// move to their next state.
+	SetGCTablesRetention(ctx context.Context, in *vtctldata.SetGCTablesRetentionRequest, opts ...grpc.CallOption) (*vtctldata.SetGCTablesRetentionResponse, error)
+	// SetMySQLUsers declares the MySQL users of the tablets of a keyspace, with
+	// their authentication and their privileges. The MySQL instances are only
+	// changed by ApplyMySQLUsers.
+	SetMySQLUsers(ctx context.Context, in *vtctldata.SetMySQLUsersRequest, opts ...grpc.CallOption) (*vtctldata.SetMySQLUsersResponse, error)
 	// ShardReplicationPositions returns the replication position of each tablet
 	// in a shard. This RPC makes a best-effort to return partial results. For
 	// example, if one tablet in the shard graph is unreachable, then
 	// ShardReplicationPositions will return non-error, and include valid results
 	// for the reachable tablets.
 	ShardReplicationPositions(ctx context.Context, in *vtctldata.ShardReplicationPositionsRequest, opts ...grpc.CallOption) (*vtctldata.ShardReplicationPositionsResponse, error)
+	// SplitReads routes a percentage of the reads of the tables of a
+	// MoveTables workflow to its target keyspace, so the reads can be switched
+	// in steps, e.g. 1%, 10%, 50%, then 100%.
+	SplitReads(ctx context.Context, in *vtctldata.SplitReadsRequest, opts ...grpc.CallOption) (*vtctldata.SplitReadsResponse, error)
+	// StartHostMaintenance creates and starts a host_maintenance workflow
+	// which cordons, or uncordons, the tablets of a host.
+	StartHostMaintenance(ctx context.Context, in *vtctldata.StartHostMaintenanceRequest, opts ...grpc.CallOption) (*vtctldata.StartHostMaintenanceResponse, error)
 	// TabletExternallyReparented changes metadata in the topology server to
 	// acknowledge a shard primary change performed by an external tool (e.g.
 	// orchestrator).
@@ -265,6 +442,10 @@ type VtctldClient interface {
 	// See the Reparenting guide for more information:
 	// https://vitess.io/docs/user-guides/configuration-advanced/reparenting/#external-reparenting.
 	TabletExternallyReparented(ctx context.Context, in *vtctldata.TabletExternallyReparentedRequest, opts ...grpc.CallOption) (*vtctldata.TabletExternallyReparentedResponse, error)
+	// UndrainTablet undrains a tablet, so the gateways send it queries again.
+	UndrainTablet(ctx context.Context, in *vtctldata.UndrainTabletRequest, opts ...grpc.CallOption) (*vtctldata.UndrainTabletResponse, error)
+	// UnfenceShard lets a fenced shard accept writes again.
+	UnfenceShard(ctx context.Context, in *vtctldata.UnfenceShardRequest, opts ...grpc.CallOption) (*vtctldata.UnfenceShardResponse, error)
 	// UpdateCellInfo updates the content of a CellInfo with the provided
 	// parameters. Empty values are ignored. If the cell does not exist, the
 	// CellInfo will be created.
@@ -273,6 +454,48 @@ type VtctldClient interface {
 	// parameters. Empty values are ignored. If the alias does not exist, the
 	// CellsAlias will be created.
 	UpdateCellsAlias(ctx context.Context, in *vtctldata.UpdateCellsAliasRequest, opts ...grpc.CallOption) (*vtctldata.UpdateCellsAliasResponse, error)
+	// UpdateSrvKeyspace replaces the SrvKeyspace of a keyspace in a cell, e.g.
+	// during a manual recovery, if it is still at the expected version. Its
+	// partitions are validated first, and the keyspace is locked during the
+	// update.
+	UpdateSrvKeyspace(ctx context.Context, in *vtctldata.UpdateSrvKeyspaceRequest, opts ...grpc.CallOption) (*vtctldata.UpdateSrvKeyspaceResponse, error)
+	// UpdateWorkflowThrottling changes the rate limits of the copy phase of a
+	// vreplication workflow on each of its target primaries, without restarting
+	// its streams.
+	UpdateWorkflowThrottling(ctx context.Context, in *vtctldata.UpdateWorkflowThrottlingRequest, opts ...grpc.CallOption) (*vtctldata.UpdateWorkflowThrottlingResponse, error)
+	// ValidateConfig compares some MySQL global variables and vttablet flags
+	// of the tablets of a keyspace with a golden config or with the majority,
+	// and returns the drifts of each tablet.
+	ValidateConfig(ctx context.Context, in *vtctldata.ValidateConfigRequest, opts ...grpc.CallOption) (*vtctldata.ValidateConfigResponse, error)
+	// ValidatePermissions compares the permissions of the tablets of a keyspace
+	// with the ones of the primary of its first shard, and returns the
+	// differences of each tablet.
+	ValidatePermissions(ctx context.Context, in *vtctldata.ValidatePermissionsRequest, opts ...grpc.CallOption) (*vtctldata.ValidatePermissionsResponse, error)
+	// ValidateRoutingRules checks the routing rules against the current
+	// VSchemas, the way vtgate builds them.
+	ValidateRoutingRules(ctx context.Context, in *vtctldata.ValidateRoutingRulesRequest, opts ...grpc.CallOption) (*vtctldata.ValidateRoutingRulesResponse, error)
+	// ValidateSrvKeyspace checks the partitions of a SrvKeyspace: each served
+	// type must have a single partition, whose shards cover the whole key space
+	// without gaps or overlaps.
+	ValidateSrvKeyspace(ctx context.Context, in *vtctldata.ValidateSrvKeyspaceRequest, opts ...grpc.CallOption) (*vtctldata.ValidateSrvKeyspaceResponse, error)
+	// ValidateVersion returns the version each tablet of a keyspace runs, and
+	// whether they all run the same one.
+	ValidateVersion(ctx context.Context, in *vtctldata.ValidateVersionRequest, opts ...grpc.CallOption) (*vtctldata.ValidateVersionResponse, error)
+	// VerifyReparentShard checks that a shard is sound after a reparent: its
+	// shard record points to a MASTER tablet, and its replicas replicate from
+	// it with both replication threads running.
+	VerifyReparentShard(ctx context.Context, in *vtctldata.VerifyReparentShardRequest, opts ...grpc.CallOption) (*vtctldata.VerifyReparentShardResponse, error)
+	// VerifyReverseReplication verifies that the reverse workflow of a
+	// workflow whose writes were switched exists, is running and has caught
+	// up, and optionally compares sampled checksums of the moved tables between
+	// the source and the target. It doesn't fail when the verification does:
+	// the response reports why.
+	VerifyReverseReplication(ctx context.Context, in *vtctldata.VerifyReverseReplicationRequest, opts ...grpc.CallOption) (*vtctldata.VerifyReverseReplicationResponse, error)
+	// WatchTopology waits for a change of a subtree of the topo, i.e. a node
+	// and all its descendants, from the version returned by the previous watch.
+	// The subtree is read periodically, so it also sees the created and deleted
+	// nodes.
+	WatchTopology(ctx context.Context, in *vtctldata.WatchTopologyRequest, opts ...grpc.CallOption) (*vtctldata.WatchTopologyResponse, error)
 }
 
 type vtctldClient struct {
@@ -283,6 +506,15 @@ func NewVtctldClient(cc grpc.ClientConnInterface) VtctldClient {
 	return &vtctldClient{cc}
 }
 
+func (c *vtctldClient) AcquireKeyspaceLock(ctx context.Context, in *vtctldata.AcquireKeyspaceLockRequest, opts ...grpc.CallOption) (*vtctldata.AcquireKeyspaceLockResponse, error) {
+	out := new(vtctldata.AcquireKeyspaceLockResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/AcquireKeyspaceLock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *vtctldClient) AddCellInfo(ctx context.Context, in *vtctldata.AddCellInfoRequest, opts ...grpc.CallOption) (*vtctldata.AddCellInfoResponse, error) {
 	out := new(vtctldata.AddCellInfoResponse)
 	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/AddCellInfo", in, out, opts...)
@@ -301,6 +533,24 @@ func (c *vtctldClient) AddCellsAlias(ctx context.Context, in *vtctldata.AddCells
 	return out, nil
 }
 
+func (c *vtctldClient) AdvanceGCTable(ctx context.Context, in *vtctldata.AdvanceGCTableRequest, opts ...grpc.CallOption) (*vtctldata.AdvanceGCTableResponse, error) {
+	out := new(vtctldata.AdvanceGCTableResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/AdvanceGCTable", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vtctldClient) ApplyMySQLUsers(ctx context.Context, in *vtctldata.ApplyMySQLUsersRequest, opts ...grpc.CallOption) (*vtctldata.ApplyMySQLUsersResponse, error) {
+	out := new(vtctldata.ApplyMySQLUsersResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/ApplyMySQLUsers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *vtctldClient) ApplyRoutingRules(ctx context.Context, in *vtctldata.ApplyRoutingRulesRequest, opts ...grpc.CallOption) (*vtctldata.ApplyRoutingRulesResponse, error) {
 	out := new(vtctldata.ApplyRoutingRulesResponse)
 	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/ApplyRoutingRules", in, out, opts...)
@@ -310,6 +560,15 @@ func (c *vtctldClient) ApplyRoutingRules(ctx context.Context, in *vtctldata.Appl
 	return out, nil
 }
 
+func (c *vtctldClient) ApplyRoutingRulesBatch(ctx context.Context, in *vtctldata.ApplyRoutingRulesBatchRequest, opts ...grpc.CallOption) (*vtctldata.ApplyRoutingRulesBatchResponse, error) {
+	out := new(vtctldata.ApplyRoutingRulesBatchResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/ApplyRoutingRulesBatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *vtctldClient) ApplyVSchema(ctx context.Context, in *vtctldata.ApplyVSchemaRequest, opts ...grpc.CallOption) (*vtctldata.ApplyVSchemaResponse, error) {
 	out := new(vtctldata.ApplyVSchemaResponse)
 	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/ApplyVSchema", in, out, opts...)
@@ -319,6 +578,33 @@ func (c *vtctldClient) ApplyVSchema(ctx context.Context, in *vtctldata.ApplyVSch
 	return out, nil
 }
 
+func (c *vtctldClient) ApproveMigration(ctx context.Context, in *vtctldata.ApproveMigrationRequest, opts ...grpc.CallOption) (*vtctldata.ApproveMigrationResponse, error) {
+	out := new(vtctldata.ApproveMigrationResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/ApproveMigration", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vtctldClient) BackupKeyspace(ctx context.Context, in *vtctldata.BackupKeyspaceRequest, opts ...grpc.CallOption) (*vtctldata.BackupKeyspaceResponse, error) {
+	out := new(vtctldata.BackupKeyspaceResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/BackupKeyspace", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vtctldClient) BrowseTopology(ctx context.Context, in *vtctldata.BrowseTopologyRequest, opts ...grpc.CallOption) (*vtctldata.BrowseTopologyResponse, error) {
+	out := new(vtctldata.BrowseTopologyResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/BrowseTopology", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *vtctldClient) ChangeTabletType(ctx context.Context, in *vtctldata.ChangeTabletTypeRequest, opts ...grpc.CallOption) (*vtctldata.ChangeTabletTypeResponse, error) {
 	out := new(vtctldata.ChangeTabletTypeResponse)
 	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/ChangeTabletType", in, out, opts...)
@@ -328,6 +614,15 @@ func (c *vtctldClient) ChangeTabletType(ctx context.Context, in *vtctldata.Chang
 	return out, nil
 }
 
+func (c *vtctldClient) CheckMySQLUsers(ctx context.Context, in *vtctldata.CheckMySQLUsersRequest, opts ...grpc.CallOption) (*vtctldata.CheckMySQLUsersResponse, error) {
+	out := new(vtctldata.CheckMySQLUsersResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/CheckMySQLUsers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *vtctldClient) CreateKeyspace(ctx context.Context, in *vtctldata.CreateKeyspaceRequest, opts ...grpc.CallOption) (*vtctldata.CreateKeyspaceResponse, error) {
 	out := new(vtctldata.CreateKeyspaceResponse)
 	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/CreateKeyspace", in, out, opts...)
@@ -346,6 +641,33 @@ func (c *vtctldClient) CreateShard(ctx context.Context, in *vtctldata.CreateShar
 	return out, nil
 }
 
+func (c *vtctldClient) DecommissionTablet(ctx context.Context, in *vtctldata.DecommissionTabletRequest, opts ...grpc.CallOption) (*vtctldata.DecommissionTabletResponse, error) {
+	out := new(vtctldata.DecommissionTabletResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/DecommissionTablet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vtctldClient) DeleteBackupSchedule(ctx context.Context, in *vtctldata.DeleteBackupScheduleRequest, opts ...grpc.CallOption) (*vtctldata.DeleteBackupScheduleResponse, error) {
+	out := new(vtctldata.DeleteBackupScheduleResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/DeleteBackupSchedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vtctldClient) DeleteBackupStorageConfig(ctx context.Context, in *vtctldata.DeleteBackupStorageConfigRequest, opts ...grpc.CallOption) (*vtctldata.DeleteBackupStorageConfigResponse, error) {
+	out := new(vtctldata.DeleteBackupStorageConfigResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/DeleteBackupStorageConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *vtctldClient) DeleteCellInfo(ctx context.Context, in *vtctldata.DeleteCellInfoRequest, opts ...grpc.CallOption) (*vtctldata.DeleteCellInfoResponse, error) {
 	out := new(vtctldata.DeleteCellInfoResponse)
 	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/DeleteCellInfo", in, out, opts...)
@@ -391,6 +713,15 @@ func (c *vtctldClient) DeleteTablets(ctx context.Context, in *vtctldata.DeleteTa
 	return out, nil
 }
 
+func (c *vtctldClient) DrainTablet(ctx context.Context, in *vtctldata.DrainTabletRequest, opts ...grpc.CallOption) (*vtctldata.DrainTabletResponse, error) {
+	out := new(vtctldata.DrainTabletResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/DrainTablet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *vtctldClient) EmergencyReparentShard(ctx context.Context, in *vtctldata.EmergencyReparentShardRequest, opts ...grpc.CallOption) (*vtctldata.EmergencyReparentShardResponse, error) {
 	out := new(vtctldata.EmergencyReparentShardResponse)
 	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/EmergencyReparentShard", in, out, opts...)
@@ -400,6 +731,15 @@ func (c *vtctldClient) EmergencyReparentShard(ctx context.Context, in *vtctldata
 	return out, nil
 }
 
+func (c *vtctldClient) FenceShard(ctx context.Context, in *vtctldata.FenceShardRequest, opts ...grpc.CallOption) (*vtctldata.FenceShardResponse, error) {
+	out := new(vtctldata.FenceShardResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/FenceShard", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *vtctldClient) FindAllShardsInKeyspace(ctx context.Context, in *vtctldata.FindAllShardsInKeyspaceRequest, opts ...grpc.CallOption) (*vtctldata.FindAllShardsInKeyspaceResponse, error) {
 	out := new(vtctldata.FindAllShardsInKeyspaceResponse)
 	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/FindAllShardsInKeyspace", in, out, opts...)
@@ -409,6 +749,15 @@ func (c *vtctldClient) FindAllShardsInKeyspace(ctx context.Context, in *vtctldat
 	return out, nil
 }
 
+func (c *vtctldClient) GetBackupSchedule(ctx context.Context, in *vtctldata.GetBackupScheduleRequest, opts ...grpc.CallOption) (*vtctldata.GetBackupScheduleResponse, error) {
+	out := new(vtctldata.GetBackupScheduleResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/GetBackupSchedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *vtctldClient) GetBackups(ctx context.Context, in *vtctldata.GetBackupsRequest, opts ...grpc.CallOption) (*vtctldata.GetBackupsResponse, error) {
 	out := new(vtctldata.GetBackupsResponse)
 	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/GetBackups", in, out, opts...)
@@ -418,6 +767,24 @@ func (c *vtctldClient) GetBackups(ctx context.Context, in *vtctldata.GetBackupsR
 	return out, nil
 }
 
+func (c *vtctldClient) GetBackupStorageConfig(ctx context.Context, in *vtctldata.GetBackupStorageConfigRequest, opts ...grpc.CallOption) (*vtctldata.GetBackupStorageConfigResponse, error) {
+	out := new(vtctldata.GetBackupStorageConfigResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/GetBackupStorageConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vtctldClient) GetCDCSinks(ctx context.Context, in *vtctldata.GetCDCSinksRequest, opts ...grpc.CallOption) (*vtctldata.GetCDCSinksResponse, error) {
+	out := new(vtctldata.GetCDCSinksResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/GetCDCSinks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *vtctldClient) GetCellInfo(ctx context.Context, in *vtctldata.GetCellInfoRequest, opts ...grpc.CallOption) (*vtctldata.GetCellInfoResponse, error) {
 	out := new(vtctldata.GetCellInfoResponse)
 	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/GetCellInfo", in, out, opts...)
@@ -436,6 +803,15 @@ func (c *vtctldClient) GetCellInfoNames(ctx context.Context, in *vtctldata.GetCe
 	return out, nil
 }
 
+func (c *vtctldClient) GetCellServingOverride(ctx context.Context, in *vtctldata.GetCellServingOverrideRequest, opts ...grpc.CallOption) (*vtctldata.GetCellServingOverrideResponse, error) {
+	out := new(vtctldata.GetCellServingOverrideResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/GetCellServingOverride", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *vtctldClient) GetCellsAliases(ctx context.Context, in *vtctldata.GetCellsAliasesRequest, opts ...grpc.CallOption) (*vtctldata.GetCellsAliasesResponse, error) {
 	out := new(vtctldata.GetCellsAliasesResponse)
 	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/GetCellsAliases", in, out, opts...)
@@ -445,6 +821,24 @@ func (c *vtctldClient) GetCellsAliases(ctx context.Context, in *vtctldata.GetCel
 	return out, nil
 }
 
+func (c *vtctldClient) GetGCTables(ctx context.Context, in *vtctldata.GetGCTablesRequest, opts ...grpc.CallOption) (*vtctldata.GetGCTablesResponse, error) {
+	out := new(vtctldata.GetGCTablesResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/GetGCTables", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vtctldClient) GetKeyspaceBackupRuns(ctx context.Context, in *vtctldata.GetKeyspaceBackupRunsRequest, opts ...grpc.CallOption) (*vtctldata.GetKeyspaceBackupRunsResponse, error) {
+	out := new(vtctldata.GetKeyspaceBackupRunsResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/GetKeyspaceBackupRuns", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *vtctldClient) GetKeyspace(ctx context.Context, in *vtctldata.GetKeyspaceRequest, opts ...grpc.CallOption) (*vtctldata.GetKeyspaceResponse, error) {
 	out := new(vtctldata.GetKeyspaceResponse)
 	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/GetKeyspace", in, out, opts...)
@@ -463,6 +857,24 @@ func (c *vtctldClient) GetKeyspaces(ctx context.Context, in *vtctldata.GetKeyspa
 	return out, nil
 }
 
+func (c *vtctldClient) GetMySQLUsers(ctx context.Context, in *vtctldata.GetMySQLUsersRequest, opts ...grpc.CallOption) (*vtctldata.GetMySQLUsersResponse, error) {
+	out := new(vtctldata.GetMySQLUsersResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/GetMySQLUsers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vtctldClient) GetOnlineDDLProgress(ctx context.Context, in *vtctldata.GetOnlineDDLProgressRequest, opts ...grpc.CallOption) (*vtctldata.GetOnlineDDLProgressResponse, error) {
+	out := new(vtctldata.GetOnlineDDLProgressResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/GetOnlineDDLProgress", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *vtctldClient) GetRoutingRules(ctx context.Context, in *vtctldata.GetRoutingRulesRequest, opts ...grpc.CallOption) (*vtctldata.GetRoutingRulesResponse, error) {
 	out := new(vtctldata.GetRoutingRulesResponse)
 	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/GetRoutingRules", in, out, opts...)
@@ -499,6 +911,42 @@ func (c *vtctldClient) GetSrvKeyspaces(ctx context.Context, in *vtctldata.GetSrv
 	return out, nil
 }
 
+func (c *vtctldClient) GetShardFence(ctx context.Context, in *vtctldata.GetShardFenceRequest, opts ...grpc.CallOption) (*vtctldata.GetShardFenceResponse, error) {
+	out := new(vtctldata.GetShardFenceResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/GetShardFence", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vtctldClient) GetShardReplicationGraph(ctx context.Context, in *vtctldata.GetShardReplicationGraphRequest, opts ...grpc.CallOption) (*vtctldata.GetShardReplicationGraphResponse, error) {
+	out := new(vtctldata.GetShardReplicationGraphResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/GetShardReplicationGraph", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vtctldClient) GetShardServingTimeline(ctx context.Context, in *vtctldata.GetShardServingTimelineRequest, opts ...grpc.CallOption) (*vtctldata.GetShardServingTimelineResponse, error) {
+	out := new(vtctldata.GetShardServingTimelineResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/GetShardServingTimeline", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vtctldClient) GetSrvKeyspaceWithVersion(ctx context.Context, in *vtctldata.GetSrvKeyspaceWithVersionRequest, opts ...grpc.CallOption) (*vtctldata.GetSrvKeyspaceWithVersionResponse, error) {
+	out := new(vtctldata.GetSrvKeyspaceWithVersionResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/GetSrvKeyspaceWithVersion", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *vtctldClient) GetSrvVSchema(ctx context.Context, in *vtctldata.GetSrvVSchemaRequest, opts ...grpc.CallOption) (*vtctldata.GetSrvVSchemaResponse, error) {
 	out := new(vtctldata.GetSrvVSchemaResponse)
 	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/GetSrvVSchema", in, out, opts...)
@@ -526,6 +974,15 @@ func (c *vtctldClient) GetTablet(ctx context.Context, in *vtctldata.GetTabletReq
 	return out, nil
 }
 
+func (c *vtctldClient) GetTabletHealthHistory(ctx context.Context, in *vtctldata.GetTabletHealthHistoryRequest, opts ...grpc.CallOption) (*vtctldata.GetTabletHealthHistoryResponse, error) {
+	out := new(vtctldata.GetTabletHealthHistoryResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/GetTabletHealthHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *vtctldClient) GetTablets(ctx context.Context, in *vtctldata.GetTabletsRequest, opts ...grpc.CallOption) (*vtctldata.GetTabletsResponse, error) {
 	out := new(vtctldata.GetTabletsResponse)
 	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/GetTablets", in, out, opts...)
@@ -544,6 +1001,24 @@ func (c *vtctldClient) GetVSchema(ctx context.Context, in *vtctldata.GetVSchemaR
 	return out, nil
 }
 
+func (c *vtctldClient) GetWorkflowAuditLog(ctx context.Context, in *vtctldata.GetWorkflowAuditLogRequest, opts ...grpc.CallOption) (*vtctldata.GetWorkflowAuditLogResponse, error) {
+	out := new(vtctldata.GetWorkflowAuditLogResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/GetWorkflowAuditLog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vtctldClient) GetWorkflowProgress(ctx context.Context, in *vtctldata.GetWorkflowProgressRequest, opts ...grpc.CallOption) (*vtctldata.GetWorkflowProgressResponse, error) {
+	out := new(vtctldata.GetWorkflowProgressResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/GetWorkflowProgress", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *vtctldClient) GetWorkflows(ctx context.Context, in *vtctldata.GetWorkflowsRequest, opts ...grpc.CallOption) (*vtctldata.GetWorkflowsResponse, error) {
 	out := new(vtctldata.GetWorkflowsResponse)
 	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/GetWorkflows", in, out, opts...)
@@ -562,6 +1037,42 @@ func (c *vtctldClient) InitShardPrimary(ctx context.Context, in *vtctldata.InitS
 	return out, nil
 }
 
+func (c *vtctldClient) ListKeyspaceBackups(ctx context.Context, in *vtctldata.ListKeyspaceBackupsRequest, opts ...grpc.CallOption) (*vtctldata.ListKeyspaceBackupsResponse, error) {
+	out := new(vtctldata.ListKeyspaceBackupsResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/ListKeyspaceBackups", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vtctldClient) ListLocks(ctx context.Context, in *vtctldata.ListLocksRequest, opts ...grpc.CallOption) (*vtctldata.ListLocksResponse, error) {
+	out := new(vtctldata.ListLocksResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/ListLocks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vtctldClient) ListVDiffResults(ctx context.Context, in *vtctldata.ListVDiffResultsRequest, opts ...grpc.CallOption) (*vtctldata.ListVDiffResultsResponse, error) {
+	out := new(vtctldata.ListVDiffResultsResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/ListVDiffResults", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vtctldClient) PlanReparentShard(ctx context.Context, in *vtctldata.PlanReparentShardRequest, opts ...grpc.CallOption) (*vtctldata.PlanReparentShardResponse, error) {
+	out := new(vtctldata.PlanReparentShardResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/PlanReparentShard", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *vtctldClient) PlannedReparentShard(ctx context.Context, in *vtctldata.PlannedReparentShardRequest, opts ...grpc.CallOption) (*vtctldata.PlannedReparentShardResponse, error) {
 	out := new(vtctldata.PlannedReparentShardResponse)
 	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/PlannedReparentShard", in, out, opts...)
@@ -571,6 +1082,33 @@ func (c *vtctldClient) PlannedReparentShard(ctx context.Context, in *vtctldata.P
 	return out, nil
 }
 
+func (c *vtctldClient) PreviewDeclarativeSchema(ctx context.Context, in *vtctldata.PreviewDeclarativeSchemaRequest, opts ...grpc.CallOption) (*vtctldata.PreviewDeclarativeSchemaResponse, error) {
+	out := new(vtctldata.PreviewDeclarativeSchemaResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/PreviewDeclarativeSchema", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vtctldClient) PruneBackups(ctx context.Context, in *vtctldata.PruneBackupsRequest, opts ...grpc.CallOption) (*vtctldata.PruneBackupsResponse, error) {
+	out := new(vtctldata.PruneBackupsResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/PruneBackups", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vtctldClient) RebuildKeyspaceGraph(ctx context.Context, in *vtctldata.RebuildKeyspaceGraphRequest, opts ...grpc.CallOption) (*vtctldata.RebuildKeyspaceGraphResponse, error) {
+	out := new(vtctldata.RebuildKeyspaceGraphResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/RebuildKeyspaceGraph", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *vtctldClient) RebuildVSchemaGraph(ctx context.Context, in *vtctldata.RebuildVSchemaGraphRequest, opts ...grpc.CallOption) (*vtctldata.RebuildVSchemaGraphResponse, error) {
 	out := new(vtctldata.RebuildVSchemaGraphResponse)
 	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/RebuildVSchemaGraph", in, out, opts...)
@@ -580,6 +1118,15 @@ func (c *vtctldClient) RebuildVSchemaGraph(ctx context.Context, in *vtctldata.Re
 	return out, nil
 }
 
+func (c *vtctldClient) RecommendTabletTypeChange(ctx context.Context, in *vtctldata.RecommendTabletTypeChangeRequest, opts ...grpc.CallOption) (*vtctldata.RecommendTabletTypeChangeResponse, error) {
+	out := new(vtctldata.RecommendTabletTypeChangeResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/RecommendTabletTypeChange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *vtctldClient) RefreshState(ctx context.Context, in *vtctldata.RefreshStateRequest, opts ...grpc.CallOption) (*vtctldata.RefreshStateResponse, error) {
 	out := new(vtctldata.RefreshStateResponse)
 	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/RefreshState", in, out, opts...)
@@ -598,6 +1145,24 @@ func (c *vtctldClient) RefreshStateByShard(ctx context.Context, in *vtctldata.Re
 	return out, nil
 }
 
+func (c *vtctldClient) RejectMigration(ctx context.Context, in *vtctldata.RejectMigrationRequest, opts ...grpc.CallOption) (*vtctldata.RejectMigrationResponse, error) {
+	out := new(vtctldata.RejectMigrationResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/RejectMigration", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vtctldClient) ReleaseLock(ctx context.Context, in *vtctldata.ReleaseLockRequest, opts ...grpc.CallOption) (*vtctldata.ReleaseLockResponse, error) {
+	out := new(vtctldata.ReleaseLockResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/ReleaseLock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *vtctldClient) RemoveKeyspaceCell(ctx context.Context, in *vtctldata.RemoveKeyspaceCellRequest, opts ...grpc.CallOption) (*vtctldata.RemoveKeyspaceCellResponse, error) {
 	out := new(vtctldata.RemoveKeyspaceCellResponse)
 	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/RemoveKeyspaceCell", in, out, opts...)
@@ -625,74 +1190,333 @@ func (c *vtctldClient) ReparentTablet(ctx context.Context, in *vtctldata.Reparen
 	return out, nil
 }
 
-func (c *vtctldClient) ShardReplicationPositions(ctx context.Context, in *vtctldata.ShardReplicationPositionsRequest, opts ...grpc.CallOption) (*vtctldata.ShardReplicationPositionsResponse, error) {
-	out := new(vtctldata.ShardReplicationPositionsResponse)
-	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/ShardReplicationPositions", in, out, opts...)
+func (c *vtctldClient) RestoreGCTable(ctx context.Context, in *vtctldata.RestoreGCTableRequest, opts ...grpc.CallOption) (*vtctldata.RestoreGCTableResponse, error) {
+	out := new(vtctldata.RestoreGCTableResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/RestoreGCTable", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *vtctldClient) TabletExternallyReparented(ctx context.Context, in *vtctldata.TabletExternallyReparentedRequest, opts ...grpc.CallOption) (*vtctldata.TabletExternallyReparentedResponse, error) {
-	out := new(vtctldata.TabletExternallyReparentedResponse)
-	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/TabletExternallyReparented", in, out, opts...)
+func (c *vtctldClient) RestoreToPointInTime(ctx context.Context, in *vtctldata.RestoreToPointInTimeRequest, opts ...grpc.CallOption) (*vtctldata.RestoreToPointInTimeResponse, error) {
+	out := new(vtctldata.RestoreToPointInTimeResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/RestoreToPointInTime", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *vtctldClient) UpdateCellInfo(ctx context.Context, in *vtctldata.UpdateCellInfoRequest, opts ...grpc.CallOption) (*vtctldata.UpdateCellInfoResponse, error) {
-	out := new(vtctldata.UpdateCellInfoResponse)
-	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/UpdateCellInfo", in, out, opts...)
+func (c *vtctldClient) RevertMigration(ctx context.Context, in *vtctldata.RevertMigrationRequest, opts ...grpc.CallOption) (*vtctldata.RevertMigrationResponse, error) {
+	out := new(vtctldata.RevertMigrationResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/RevertMigration", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *vtctldClient) UpdateCellsAlias(ctx context.Context, in *vtctldata.UpdateCellsAliasRequest, opts ...grpc.CallOption) (*vtctldata.UpdateCellsAliasResponse, error) {
-	out := new(vtctldata.UpdateCellsAliasResponse)
-	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/UpdateCellsAlias", in, out, opts...)
+func (c *vtctldClient) SeedTable(ctx context.Context, in *vtctldata.SeedTableRequest, opts ...grpc.CallOption) (*vtctldata.SeedTableResponse, error) {
+	out := new(vtctldata.SeedTableResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/SeedTable", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// VtctldServer is the server API for Vtctld service.
-// All implementations must embed UnimplementedVtctldServer
-// for forward compatibility
-type VtctldServer interface {
-	// AddCellInfo registers a local topology service in a new cell by creating
-	// the CellInfo with the provided parameters.
-	AddCellInfo(context.Context, *vtctldata.AddCellInfoRequest) (*vtctldata.AddCellInfoResponse, error)
-	// AddCellsAlias defines a group of cells that can be referenced by a single
-	// name (the alias).
-	//
-	// When routing query traffic, replica/rdonly traffic can be routed across
-	// cells within the group (alias). Only primary traffic can be routed across
-	// cells not in the same group (alias).
-	AddCellsAlias(context.Context, *vtctldata.AddCellsAliasRequest) (*vtctldata.AddCellsAliasResponse, error)
-	// ApplyRoutingRules applies the VSchema routing rules.
-	ApplyRoutingRules(context.Context, *vtctldata.ApplyRoutingRulesRequest) (*vtctldata.ApplyRoutingRulesResponse, error)
+func (c *vtctldClient) SetBackupSchedule(ctx context.Context, in *vtctldata.SetBackupScheduleRequest, opts ...grpc.CallOption) (*vtctldata.SetBackupScheduleResponse, error) {
+	out := new(vtctldata.SetBackupScheduleResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/SetBackupSchedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vtctldClient) SetBackupStorageConfig(ctx context.Context, in *vtctldata.SetBackupStorageConfigRequest, opts ...grpc.CallOption) (*vtctldata.SetBackupStorageConfigResponse, error) {
+	out := new(vtctldata.SetBackupStorageConfigResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/SetBackupStorageConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vtctldClient) SetCDCSinks(ctx context.Context, in *vtctldata.SetCDCSinksRequest, opts ...grpc.CallOption) (*vtctldata.SetCDCSinksResponse, error) {
+	out := new(vtctldata.SetCDCSinksResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/SetCDCSinks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vtctldClient) SetCellServingOverride(ctx context.Context, in *vtctldata.SetCellServingOverrideRequest, opts ...grpc.CallOption) (*vtctldata.SetCellServingOverrideResponse, error) {
+	out := new(vtctldata.SetCellServingOverrideResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/SetCellServingOverride", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vtctldClient) SetGCTablesRetention(ctx context.Context, in *vtctldata.SetGCTablesRetentionRequest, opts ...grpc.CallOption) (*vtctldata.SetGCTablesRetentionResponse, error) {
+	out := new(vtctldata.SetGCTablesRetentionResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/SetGCTablesRetention", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vtctldClient) SetMySQLUsers(ctx context.Context, in *vtctldata.SetMySQLUsersRequest, opts ...grpc.CallOption) (*vtctldata.SetMySQLUsersResponse, error) {
+	out := new(vtctldata.SetMySQLUsersResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/SetMySQLUsers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vtctldClient) ShardReplicationPositions(ctx context.Context, in *vtctldata.ShardReplicationPositionsRequest, opts ...grpc.CallOption) (*vtctldata.ShardReplicationPositionsResponse, error) {
+	out := new(vtctldata.ShardReplicationPositionsResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/ShardReplicationPositions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vtctldClient) SplitReads(ctx context.Context, in *vtctldata.SplitReadsRequest, opts ...grpc.CallOption) (*vtctldata.SplitReadsResponse, error) {
+	out := new(vtctldata.SplitReadsResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/SplitReads", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vtctldClient) StartHostMaintenance(ctx context.Context, in *vtctldata.StartHostMaintenanceRequest, opts ...grpc.CallOption) (*vtctldata.StartHostMaintenanceResponse, error) {
+	out := new(vtctldata.StartHostMaintenanceResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/StartHostMaintenance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vtctldClient) TabletExternallyReparented(ctx context.Context, in *vtctldata.TabletExternallyReparentedRequest, opts ...grpc.CallOption) (*vtctldata.TabletExternallyReparentedResponse, error) {
+	out := new(vtctldata.TabletExternallyReparentedResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/TabletExternallyReparented", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vtctldClient) UndrainTablet(ctx context.Context, in *vtctldata.UndrainTabletRequest, opts ...grpc.CallOption) (*vtctldata.UndrainTabletResponse, error) {
+	out := new(vtctldata.UndrainTabletResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/UndrainTablet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vtctldClient) UnfenceShard(ctx context.Context, in *vtctldata.UnfenceShardRequest, opts ...grpc.CallOption) (*vtctldata.UnfenceShardResponse, error) {
+	out := new(vtctldata.UnfenceShardResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/UnfenceShard", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vtctldClient) UpdateCellInfo(ctx context.Context, in *vtctldata.UpdateCellInfoRequest, opts ...grpc.CallOption) (*vtctldata.UpdateCellInfoResponse, error) {
+	out := new(vtctldata.UpdateCellInfoResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/UpdateCellInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vtctldClient) UpdateCellsAlias(ctx context.Context, in *vtctldata.UpdateCellsAliasRequest, opts ...grpc.CallOption) (*vtctldata.UpdateCellsAliasResponse, error) {
+	out := new(vtctldata.UpdateCellsAliasResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/UpdateCellsAlias", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vtctldClient) UpdateSrvKeyspace(ctx context.Context, in *vtctldata.UpdateSrvKeyspaceRequest, opts ...grpc.CallOption) (*vtctldata.UpdateSrvKeyspaceResponse, error) {
+	out := new(vtctldata.UpdateSrvKeyspaceResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/UpdateSrvKeyspace", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vtctldClient) UpdateWorkflowThrottling(ctx context.Context, in *vtctldata.UpdateWorkflowThrottlingRequest, opts ...grpc.CallOption) (*vtctldata.UpdateWorkflowThrottlingResponse, error) {
+	out := new(vtctldata.UpdateWorkflowThrottlingResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/UpdateWorkflowThrottling", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vtctldClient) ValidateConfig(ctx context.Context, in *vtctldata.ValidateConfigRequest, opts ...grpc.CallOption) (*vtctldata.ValidateConfigResponse, error) {
+	out := new(vtctldata.ValidateConfigResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/ValidateConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vtctldClient) ValidatePermissions(ctx context.Context, in *vtctldata.ValidatePermissionsRequest, opts ...grpc.CallOption) (*vtctldata.ValidatePermissionsResponse, error) {
+	out := new(vtctldata.ValidatePermissionsResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/ValidatePermissions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vtctldClient) ValidateRoutingRules(ctx context.Context, in *vtctldata.ValidateRoutingRulesRequest, opts ...grpc.CallOption) (*vtctldata.ValidateRoutingRulesResponse, error) {
+	out := new(vtctldata.ValidateRoutingRulesResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/ValidateRoutingRules", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vtctldClient) ValidateSrvKeyspace(ctx context.Context, in *vtctldata.ValidateSrvKeyspaceRequest, opts ...grpc.CallOption) (*vtctldata.ValidateSrvKeyspaceResponse, error) {
+	out := new(vtctldata.ValidateSrvKeyspaceResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/ValidateSrvKeyspace", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vtctldClient) ValidateVersion(ctx context.Context, in *vtctldata.ValidateVersionRequest, opts ...grpc.CallOption) (*vtctldata.ValidateVersionResponse, error) {
+	out := new(vtctldata.ValidateVersionResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/ValidateVersion", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vtctldClient) VerifyReparentShard(ctx context.Context, in *vtctldata.VerifyReparentShardRequest, opts ...grpc.CallOption) (*vtctldata.VerifyReparentShardResponse, error) {
+	out := new(vtctldata.VerifyReparentShardResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/VerifyReparentShard", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vtctldClient) VerifyReverseReplication(ctx context.Context, in *vtctldata.VerifyReverseReplicationRequest, opts ...grpc.CallOption) (*vtctldata.VerifyReverseReplicationResponse, error) {
+	out := new(vtctldata.VerifyReverseReplicationResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/VerifyReverseReplication", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vtctldClient) WatchTopology(ctx context.Context, in *vtctldata.WatchTopologyRequest, opts ...grpc.CallOption) (*vtctldata.WatchTopologyResponse, error) {
+	out := new(vtctldata.WatchTopologyResponse)
+	err := c.cc.Invoke(ctx, "/vtctlservice.Vtctld/WatchTopology", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VtctldServer is the server API for Vtctld service.
+// All implementations must embed UnimplementedVtctldServer
+// for forward compatibility
+type VtctldServer interface {
+	// AcquireKeyspaceLock locks a keyspace, or a shard, in the topology on
+	// behalf of an external tool, so it can coordinate with the operations of
+	// Vitess which take the same lock. The vtctld holds the lock until it is
+	// released or its TTL expires, and loses it if it restarts.
+	AcquireKeyspaceLock(context.Context, *vtctldata.AcquireKeyspaceLockRequest) (*vtctldata.AcquireKeyspaceLockResponse, error)
+	// AddCellInfo registers a local topology service in a new cell by creating
+	// the CellInfo with the provided parameters.
+	AddCellInfo(context.Context, *vtctldata.AddCellInfoRequest) (*vtctldata.AddCellInfoResponse, error)
+	// AddCellsAlias defines a group of cells that can be referenced by a single
+	// name (the alias).
+	//
+	// When routing query traffic, replica/rdonly traffic can be routed across
+	// cells within the group (alias). Only primary traffic can be routed across
+	// cells not in the same group (alias).
+	AddCellsAlias(context.Context, *vtctldata.AddCellsAliasRequest) (*vtctldata.AddCellsAliasResponse, error)
+	// AdvanceGCTable moves the HOLD or EVAC tables of a keyspace with a UUID
+	// to their next state, the next time the table GC checks its tables.
+	AdvanceGCTable(context.Context, *vtctldata.AdvanceGCTableRequest) (*vtctldata.AdvanceGCTableResponse, error)
+	// ApplyMySQLUsers makes the MySQL users of the primaries of a keyspace match
+	// the declared ones, and waits for the replicas to converge through
+	// replication.
+	ApplyMySQLUsers(context.Context, *vtctldata.ApplyMySQLUsersRequest) (*vtctldata.ApplyMySQLUsersResponse, error)
+	// ApplyRoutingRules applies the VSchema routing rules.
+	ApplyRoutingRules(context.Context, *vtctldata.ApplyRoutingRulesRequest) (*vtctldata.ApplyRoutingRulesResponse, error)
+	// ApplyRoutingRulesBatch atomically sets the routing rules of some tables,
+	// and deletes the ones of others. The new rules are validated, and only
+	// saved if the current ones are at the expected version. It returns the
+	// changes, and the queries in the plan cache of the given vtgates whose
+	// routing changes.
+	ApplyRoutingRulesBatch(context.Context, *vtctldata.ApplyRoutingRulesBatchRequest) (*vtctldata.ApplyRoutingRulesBatchResponse, error)
 	// ApplyVSchema applies a vschema to a keyspace.
 	ApplyVSchema(context.Context, *vtctldata.ApplyVSchemaRequest) (*vtctldata.ApplyVSchemaResponse, error)
+	// ApproveMigration approves an online DDL migration pending approval on all
+	// the shards of its keyspace, which then queue it.
+	ApproveMigration(context.Context, *vtctldata.ApproveMigrationRequest) (*vtctldata.ApproveMigrationResponse, error)
+	// BackupKeyspace backs up every shard of a keyspace, each from its most
+	// up-to-date replica, and returns the finished run.
+	BackupKeyspace(context.Context, *vtctldata.BackupKeyspaceRequest) (*vtctldata.BackupKeyspaceResponse, error)
+	// BrowseTopology returns a node of the topo: its decoded contents and
+	// version when it is a file, or its children. The paths it can read are
+	// restricted by -topo_browse_allowed_paths.
+	BrowseTopology(context.Context, *vtctldata.BrowseTopologyRequest) (*vtctldata.BrowseTopologyResponse, error)
 	// ChangeTabletType changes the db type for the specified tablet, if possible.
 	// This is used primarily to arrange replicas, and it will not convert a
 	// primary. For that, use InitShardPrimary.
 	//
 	// NOTE: This command automatically updates the serving graph.
 	ChangeTabletType(context.Context, *vtctldata.ChangeTabletTypeRequest) (*vtctldata.ChangeTabletTypeResponse, error)
+	// CheckMySQLUsers compares the MySQL users of the tablets of a keyspace with
+	// the declared ones, and returns the drift of each tablet.
+	CheckMySQLUsers(context.Context, *vtctldata.CheckMySQLUsersRequest) (*vtctldata.CheckMySQLUsersResponse, error)
 	// CreateKeyspace creates the specified keyspace in the topology. For a
 	// SNAPSHOT keyspace, the request must specify the name of a base keyspace,
 	// as well as a snapshot time.
 	CreateKeyspace(context.Context, *vtctldata.CreateKeyspaceRequest) (*vtctldata.CreateKeyspaceResponse, error)
 	// CreateShard creates the specified shard in the topology.
 	CreateShard(context.Context, *vtctldata.CreateShardRequest) (*vtctldata.CreateShardResponse, error)
+	// DecommissionTablet checks that a tablet isn't the primary or the last
+	// replica of its shard, nor the source of vreplication streams, then drains
+	// it, stops its replication and deletes it from the topology. It refuses
+	// with the reasons otherwise.
+	DecommissionTablet(context.Context, *vtctldata.DecommissionTabletRequest) (*vtctldata.DecommissionTabletResponse, error)
+	// DeleteBackupSchedule deletes the backup schedule of a keyspace.
+	DeleteBackupSchedule(context.Context, *vtctldata.DeleteBackupScheduleRequest) (*vtctldata.DeleteBackupScheduleResponse, error)
+	// DeleteBackupStorageConfig deletes the backup storage config of a
+	// keyspace, so that the backup storage flags of the tablets apply again.
+	DeleteBackupStorageConfig(context.Context, *vtctldata.DeleteBackupStorageConfigRequest) (*vtctldata.DeleteBackupStorageConfigResponse, error)
 	// DeleteCellInfo deletes the CellInfo for the provided cell. The cell cannot
-	// be referenced by any Shard record in the topology.
+	// have SrvKeyspace or tablet records, unless Force is set, and cannot be
+	// referenced by any CellsAlias. With Drain, the SrvKeyspace records of the
+	// cell are deleted first.
 	DeleteCellInfo(context.Context, *vtctldata.DeleteCellInfoRequest) (*vtctldata.DeleteCellInfoResponse, error)
 	// DeleteCellsAlias deletes the CellsAlias for the provided alias.
 	DeleteCellsAlias(context.Context, *vtctldata.DeleteCellsAliasRequest) (*vtctldata.DeleteCellsAliasResponse, error)
@@ -708,27 +1532,58 @@ type VtctldServer interface {
 	DeleteShards(context.Context, *vtctldata.DeleteShardsRequest) (*vtctldata.DeleteShardsResponse, error)
 	// DeleteTablets deletes one or more tablets from the topology.
 	DeleteTablets(context.Context, *vtctldata.DeleteTabletsRequest) (*vtctldata.DeleteTabletsResponse, error)
+	// DrainTablet drains a tablet: the gateways stop sending it new queries,
+	// but let the in-flight ones finish. A master cannot be drained.
+	DrainTablet(context.Context, *vtctldata.DrainTabletRequest) (*vtctldata.DrainTabletResponse, error)
 	// EmergencyReparentShard reparents the shard to the new primary. It assumes
-	// the old primary is dead or otherwise not responding.
+	// the old primary is dead or otherwise not responding. The candidates for
+	// promotion can be constrained, and a dry run only elects the new primary.
 	EmergencyReparentShard(context.Context, *vtctldata.EmergencyReparentShardRequest) (*vtctldata.EmergencyReparentShardResponse, error)
+	// FenceShard stops the writes of a shard: the vtgates stop sending queries
+	// to its primary, and the primary becomes read-only. It returns the fence,
+	// with the final replication position of the primary.
+	FenceShard(context.Context, *vtctldata.FenceShardRequest) (*vtctldata.FenceShardResponse, error)
 	// FindAllShardsInKeyspace returns a map of shard names to shard references
 	// for a given keyspace.
 	FindAllShardsInKeyspace(context.Context, *vtctldata.FindAllShardsInKeyspaceRequest) (*vtctldata.FindAllShardsInKeyspaceResponse, error)
+	// GetBackupSchedule returns the backup schedule of a keyspace.
+	GetBackupSchedule(context.Context, *vtctldata.GetBackupScheduleRequest) (*vtctldata.GetBackupScheduleResponse, error)
 	// GetBackups returns all the backups for a shard.
 	GetBackups(context.Context, *vtctldata.GetBackupsRequest) (*vtctldata.GetBackupsResponse, error)
+	// GetBackupStorageConfig returns the backup storage config of a keyspace.
+	GetBackupStorageConfig(context.Context, *vtctldata.GetBackupStorageConfigRequest) (*vtctldata.GetBackupStorageConfigResponse, error)
+	// GetCDCSinks returns the CDC sinks of a keyspace.
+	GetCDCSinks(context.Context, *vtctldata.GetCDCSinksRequest) (*vtctldata.GetCDCSinksResponse, error)
 	// GetCellInfo returns the information for a cell.
 	GetCellInfo(context.Context, *vtctldata.GetCellInfoRequest) (*vtctldata.GetCellInfoResponse, error)
 	// GetCellInfoNames returns all the cells for which we have a CellInfo object,
 	// meaning we have a topology service registered.
 	GetCellInfoNames(context.Context, *vtctldata.GetCellInfoNamesRequest) (*vtctldata.GetCellInfoNamesResponse, error)
+	// GetCellServingOverride returns the serving overrides of the cells of a
+	// keyspace.
+	GetCellServingOverride(context.Context, *vtctldata.GetCellServingOverrideRequest) (*vtctldata.GetCellServingOverrideResponse, error)
 	// GetCellsAliases returns a mapping of cell alias to cells identified by that
 	// alias.
 	GetCellsAliases(context.Context, *vtctldata.GetCellsAliasesRequest) (*vtctldata.GetCellsAliasesResponse, error)
+	// GetGCTables returns the tables garbage collected by the primaries of a
+	// keyspace, by state.
+	GetGCTables(context.Context, *vtctldata.GetGCTablesRequest) (*vtctldata.GetGCTablesResponse, error)
+	// GetKeyspaceBackupRuns returns the keyspace backup runs this vtctld
+	// started, including the running ones.
+	GetKeyspaceBackupRuns(context.Context, *vtctldata.GetKeyspaceBackupRunsRequest) (*vtctldata.GetKeyspaceBackupRunsResponse, error)
 	// GetKeyspace reads the given keyspace from the topo and returns it.
 	GetKeyspace(context.Context, *vtctldata.GetKeyspaceRequest) (*vtctldata.GetKeyspaceResponse, error)
 	// GetKeyspaces returns the keyspace struct of all keyspaces in the topo.
 	GetKeyspaces(context.Context, *vtctldata.GetKeyspacesRequest) (*vtctldata.GetKeyspacesResponse, error)
-	// GetRoutingRules returns the VSchema routing rules.
+	// GetMySQLUsers returns the MySQL users declared for the tablets of a
+	// keyspace.
+	GetMySQLUsers(context.Context, *vtctldata.GetMySQLUsersRequest) (*vtctldata.GetMySQLUsersResponse, error)
+	// GetOnlineDDLProgress returns the progress of an online DDL migration on
+	// each shard of its keyspace, and aggregated over them: the rows copied,
+	// the ETA, whether the throttler pushes back on it, and for the online
+	// strategy the lag of its vreplication streams.
+	GetOnlineDDLProgress(context.Context, *vtctldata.GetOnlineDDLProgressRequest) (*vtctldata.GetOnlineDDLProgressResponse, error)
+	// GetRoutingRules returns the VSchema routing rules, with their version.
 	GetRoutingRules(context.Context, *vtctldata.GetRoutingRulesRequest) (*vtctldata.GetRoutingRulesResponse, error)
 	// GetSchema returns the schema for a tablet, or just the schema for the
 	// specified tables in that tablet.
@@ -738,6 +1593,18 @@ type VtctldServer interface {
 	// GetSrvKeyspaces returns the SrvKeyspaces for a keyspace in one or more
 	// cells.
 	GetSrvKeyspaces(context.Context, *vtctldata.GetSrvKeyspacesRequest) (*vtctldata.GetSrvKeyspacesResponse, error)
+	// GetShardFence returns the fence of a fenced shard.
+	GetShardFence(context.Context, *vtctldata.GetShardFenceRequest) (*vtctldata.GetShardFenceResponse, error)
+	// GetShardReplicationGraph returns the live MySQL replication topology of a
+	// shard: which tablet each tablet replicates from, the state of its
+	// replication threads and its lag, and how it differs from the topo.
+	GetShardReplicationGraph(context.Context, *vtctldata.GetShardReplicationGraphRequest) (*vtctldata.GetShardReplicationGraphResponse, error)
+	// GetShardServingTimeline returns the serving state transitions of the
+	// tablets of a shard.
+	GetShardServingTimeline(context.Context, *vtctldata.GetShardServingTimelineRequest) (*vtctldata.GetShardServingTimelineResponse, error)
+	// GetSrvKeyspaceWithVersion returns the SrvKeyspace for a keyspace in a
+	// cell, with its version, to edit it with UpdateSrvKeyspace.
+	GetSrvKeyspaceWithVersion(context.Context, *vtctldata.GetSrvKeyspaceWithVersionRequest) (*vtctldata.GetSrvKeyspaceWithVersionResponse, error)
 	// GetSrvVSchema returns the SrvVSchema for a cell.
 	GetSrvVSchema(context.Context, *vtctldata.GetSrvVSchemaRequest) (*vtctldata.GetSrvVSchemaResponse, error)
 	// GetSrvVSchemas returns a mapping from cell name to SrvVSchema for all cells,
@@ -745,10 +1612,23 @@ type VtctldServer interface {
 	GetSrvVSchemas(context.Context, *vtctldata.GetSrvVSchemasRequest) (*vtctldata.GetSrvVSchemasResponse, error)
 	// GetTablet returns information about a tablet.
 	GetTablet(context.Context, *vtctldata.GetTabletRequest) (*vtctldata.GetTabletResponse, error)
+	// GetTabletHealthHistory returns the health transitions of the tablets
+	// recorded by the healthcheck of vtctld, which requires vtctld to run with
+	// -enable_realtime_stats.
+	GetTabletHealthHistory(context.Context, *vtctldata.GetTabletHealthHistoryRequest) (*vtctldata.GetTabletHealthHistoryResponse, error)
 	// GetTablets returns tablets, optionally filtered by keyspace and shard.
 	GetTablets(context.Context, *vtctldata.GetTabletsRequest) (*vtctldata.GetTabletsResponse, error)
 	// GetVSchema returns the vschema for a keyspace.
 	GetVSchema(context.Context, *vtctldata.GetVSchemaRequest) (*vtctldata.GetVSchemaResponse, error)
+	// GetWorkflowAuditLog returns the audit log of a workflow of the workflow
+	// manager, including after its deletion.
+	GetWorkflowAuditLog(context.Context, *vtctldata.GetWorkflowAuditLogRequest) (*vtctldata.GetWorkflowAuditLogResponse, error)
+	// GetWorkflowProgress returns the progress of a vreplication workflow,
+	// e.g. MoveTables or Reshard: for each stream, the rows copied against the
+	// rows estimated from the source tables, the copy throughput, the
+	// replication lag and the last error, and their aggregates over the
+	// workflow.
+	GetWorkflowProgress(context.Context, *vtctldata.GetWorkflowProgressRequest) (*vtctldata.GetWorkflowProgressResponse, error)
 	// GetWorkflows returns a list of workflows for the given keyspace.
 	GetWorkflows(context.Context, *vtctldata.GetWorkflowsRequest) (*vtctldata.GetWorkflowsResponse, error)
 	// InitShardPrimary sets the initial primary for a shard. Will make all other
@@ -758,6 +1638,19 @@ type VtctldServer interface {
 	// PlannedReparentShard or EmergencyReparentShard should be used in those
 	// cases instead.
 	InitShardPrimary(context.Context, *vtctldata.InitShardPrimaryRequest) (*vtctldata.InitShardPrimaryResponse, error)
+	// ListKeyspaceBackups returns the backups of every shard of a keyspace.
+	ListKeyspaceBackups(context.Context, *vtctldata.ListKeyspaceBackupsRequest) (*vtctldata.ListKeyspaceBackupsResponse, error)
+	// ListLocks returns the keyspace and shard locks the vtctld holds on behalf
+	// of external tools.
+	ListLocks(context.Context, *vtctldata.ListLocksRequest) (*vtctldata.ListLocksResponse, error)
+	// ListVDiffResults returns the stored results of the VDiffs of a workflow,
+	// from the newest, including those run by a schedule of the vdiff workflow.
+	ListVDiffResults(context.Context, *vtctldata.ListVDiffResultsRequest) (*vtctldata.ListVDiffResultsResponse, error)
+	// PlanReparentShard plans a PlannedReparentShard or an
+	// EmergencyReparentShard without changing anything: it ranks the candidates
+	// for promotion, and returns the tablet which would be promoted, the tablets
+	// which would be repointed to it and the expected write downtime.
+	PlanReparentShard(context.Context, *vtctldata.PlanReparentShardRequest) (*vtctldata.PlanReparentShardResponse, error)
 	// PlannedReparentShard reparents the shard to the new primary, or away from
 	// an old primary. Both the old and new primaries need to be reachable and
 	// running.
@@ -766,14 +1659,34 @@ type VtctldServer interface {
 	// current shard primary is in for promotion unless NewPrimary is explicitly
 	// provided in the request.
 	PlannedReparentShard(context.Context, *vtctldata.PlannedReparentShardRequest) (*vtctldata.PlannedReparentShardResponse, error)
+	// PreviewDeclarativeSchema returns the tables to create, alter and drop to
+	// converge the schema of a keyspace to the CREATE TABLE statements of all
+	// its tables, without applying them.
+	PreviewDeclarativeSchema(context.Context, *vtctldata.PreviewDeclarativeSchemaRequest) (*vtctldata.PreviewDeclarativeSchemaResponse, error)
+	// PruneBackups removes the backups of a keyspace that are beyond the given
+	// retention.
+	PruneBackups(context.Context, *vtctldata.PruneBackupsRequest) (*vtctldata.PruneBackupsResponse, error)
+	// RebuildKeyspaceGraph rebuilds the per-cell SrvKeyspace objects of a
+	// keyspace from its global shard records, and reports how it went in
+	// each cell.
+	RebuildKeyspaceGraph(context.Context, *vtctldata.RebuildKeyspaceGraphRequest) (*vtctldata.RebuildKeyspaceGraphResponse, error)
 	// RebuildVSchemaGraph rebuilds the per-cell SrvVSchema from the global
 	// VSchema objects in the provided cells (or all cells in the topo none
 	// provided).
 	RebuildVSchemaGraph(context.Context, *vtctldata.RebuildVSchemaGraphRequest) (*vtctldata.RebuildVSchemaGraphResponse, error)
+	// RecommendTabletTypeChange lists the tablets of a shard and cell which
+	// can be changed from one type to another with ChangeTabletType, the ones
+	// with the least impact on the serving tablets first.
+	RecommendTabletTypeChange(context.Context, *vtctldata.RecommendTabletTypeChangeRequest) (*vtctldata.RecommendTabletTypeChangeResponse, error)
 	// RefreshState reloads the tablet record on the specified tablet.
 	RefreshState(context.Context, *vtctldata.RefreshStateRequest) (*vtctldata.RefreshStateResponse, error)
 	// RefreshStateByShard calls RefreshState on all the tablets in the given shard.
 	RefreshStateByShard(context.Context, *vtctldata.RefreshStateByShardRequest) (*vtctldata.RefreshStateByShardResponse, error)
+	// RejectMigration rejects an online DDL migration pending approval on all
+	// the shards of its keyspace, which then cancel it.
+	RejectMigration(context.Context, *vtctldata.RejectMigrationRequest) (*vtctldata.RejectMigrationResponse, error)
+	// ReleaseLock releases a lock acquired with AcquireKeyspaceLock.
+	ReleaseLock(context.Context, *vtctldata.ReleaseLockRequest) (*vtctldata.ReleaseLockResponse, error)
 	// RemoveKeyspaceCell removes the specified cell from the Cells list for all
 	// shards in the specified keyspace, as well as from the SrvKeyspace for that
 	// keyspace in that cell.
@@ -785,12 +1698,57 @@ type VtctldServer interface {
 	// only works if the current replica position matches the last known reparent
 	// action.
 	ReparentTablet(context.Context, *vtctldata.ReparentTabletRequest) (*vtctldata.ReparentTabletResponse, error)
+	// RestoreGCTable renames the HOLD tables of a keyspace with a UUID back to
+	// a regular table.
+	RestoreGCTable(context.Context, *vtctldata.RestoreGCTableRequest) (*vtctldata.RestoreGCTableResponse, error)
+	// RestoreToPointInTime restores a shard to a GTID position or to a time on
+	// a new tablet, started by the provision_pitr_tablet hook: the tablet
+	// restores the most recent full backup before the point in time, then
+	// replays the binary logs of the incremental backups up to it.
+	RestoreToPointInTime(context.Context, *vtctldata.RestoreToPointInTimeRequest) (*vtctldata.RestoreToPointInTimeResponse, error)
+	// RevertMigration submits a migration reverting a complete online DDL
+	// migration, once it checked that the artifacts of the migration are still
+	// kept on all the shards.
+	RevertMigration(context.Context, *vtctldata.RevertMigrationRequest) (*vtctldata.RevertMigrationResponse, error)
+	// SeedTable inserts the rows of the seed source declared in the VSchema
+	// for a table on the primaries of its shards, routed with the primary
+	// vindex of the table. The rows whose keys already exist are skipped, so
+	// a table can be seeded again.
+	SeedTable(context.Context, *vtctldata.SeedTableRequest) (*vtctldata.SeedTableResponse, error)
+	// SetBackupSchedule creates or replaces the backup schedule of a keyspace.
+	SetBackupSchedule(context.Context, *vtctldata.SetBackupScheduleRequest) (*vtctldata.SetBackupScheduleResponse, error)
+	// SetBackupStorageConfig sets the backup storage config of a keyspace,
+	// which overrides the backup storage flags of the tablets for the backups
+	// of its shards.
+	SetBackupStorageConfig(context.Context, *vtctldata.SetBackupStorageConfigRequest) (*vtctldata.SetBackupStorageConfigResponse, error)
+	// SetCDCSinks declares the CDC sinks of a keyspace. The CDC sink service
+	// restarts the sinks which changed.
+	SetCDCSinks(context.Context, *vtctldata.SetCDCSinksRequest) (*vtctldata.SetCDCSinksResponse, error)
+	// SetCellServingOverride sets or removes the serving override of a cell
+	// for a keyspace. The vtgates of the other cells started with
+	// -enable_keyspace_cell_serving_overrides don't route the queries of the
+	// keyspace to the tablets of a cell marked as "do not serve".
+	SetCellServingOverride(context.Context, *vtctldata.SetCellServingOverrideRequest) (*vtctldata.SetCellServingOverrideResponse, error)
+	// SetGCTablesRetention sets when the HOLD or EVAC tables of a keyspace
+	// move to their next state.
+	SetGCTablesRetention(context.Context, *vtctldata.SetGCTablesRetentionRequest) (*vtctldata.SetGCTablesRetentionResponse, error)
+	// SetMySQLUsers declares the MySQL users of the tablets of a keyspace, with
+	// their authentication and their privileges. The MySQL instances are only
+	// changed by ApplyMySQLUsers.
+	SetMySQLUsers(context.Context, *vtctldata.SetMySQLUsersRequest) (*vtctldata.SetMySQLUsersResponse, error)
 	// ShardReplicationPositions returns the replication position of each tablet
 	// in a shard. This RPC makes a best-effort to return partial results. For
 	// example, if one tablet in the shard graph is unreachable, then
 	// ShardReplicationPositions will return non-error, and include valid results
 	// for the reachable tablets.
 	ShardReplicationPositions(context.Context, *vtctldata.ShardReplicationPositionsRequest) (*vtctldata.ShardReplicationPositionsResponse, error)
+	// SplitReads routes a percentage of the reads of the tables of a
+	// MoveTables workflow to its target keyspace, so the reads can be switched
+	// in steps, e.g. 1%, 10%, 50%, then 100%.
+	SplitReads(context.Context, *vtctldata.SplitReadsRequest) (*vtctldata.SplitReadsResponse, error)
+	// StartHostMaintenance creates and starts a host_maintenance workflow
+	// which cordons, or uncordons, the tablets of a host.
+	StartHostMaintenance(context.Context, *vtctldata.StartHostMaintenanceRequest) (*vtctldata.StartHostMaintenanceResponse, error)
 	// TabletExternallyReparented changes metadata in the topology server to
 	// acknowledge a shard primary change performed by an external tool (e.g.
 	// orchestrator).
@@ -798,6 +1756,10 @@ type VtctldServer interface {
 	// See the Reparenting guide for more information:
 	// https://vitess.io/docs/user-guides/configuration-advanced/reparenting/#external-reparenting.
 	TabletExternallyReparented(context.Context, *vtctldata.TabletExternallyReparentedRequest) (*vtctldata.TabletExternallyReparentedResponse, error)
+	// UndrainTablet undrains a tablet, so the gateways send it queries again.
+	UndrainTablet(context.Context, *vtctldata.UndrainTabletRequest) (*vtctldata.UndrainTabletResponse, error)
+	// UnfenceShard lets a fenced shard accept writes again.
+	UnfenceShard(context.Context, *vtctldata.UnfenceShardRequest) (*vtctldata.UnfenceShardResponse, error)
 	// UpdateCellInfo updates the content of a CellInfo with the provided
 	// parameters. Empty values are ignored. If the cell does not exist, the
 	// CellInfo will be created.
@@ -806,6 +1768,48 @@ type VtctldServer interface {
 	// parameters. Empty values are ignored. If the alias does not exist, the
 	// CellsAlias will be created.
 	UpdateCellsAlias(context.Context, *vtctldata.UpdateCellsAliasRequest) (*vtctldata.UpdateCellsAliasResponse, error)
+	// UpdateSrvKeyspace replaces the SrvKeyspace of a keyspace in a cell, e.g.
+	// during a manual recovery, if it is still at the expected version. Its
+	// partitions are validated first, and the keyspace is locked during the
+	// update.
+	UpdateSrvKeyspace(context.Context, *vtctldata.UpdateSrvKeyspaceRequest) (*vtctldata.UpdateSrvKeyspaceResponse, error)
+	// UpdateWorkflowThrottling changes the rate limits of the copy phase of a
+	// vreplication workflow on each of its target primaries, without restarting
+	// its streams.
+	UpdateWorkflowThrottling(context.Context, *vtctldata.UpdateWorkflowThrottlingRequest) (*vtctldata.UpdateWorkflowThrottlingResponse, error)
+	// ValidateConfig compares some MySQL global variables and vttablet flags
+	// of the tablets of a keyspace with a golden config or with the majority,
+	// and returns the drifts of each tablet.
+	ValidateConfig(context.Context, *vtctldata.ValidateConfigRequest) (*vtctldata.ValidateConfigResponse, error)
+	// ValidatePermissions compares the permissions of the tablets of a keyspace
+	// with the ones of the primary of its first shard, and returns the
+	// differences of each tablet.
+	ValidatePermissions(context.Context, *vtctldata.ValidatePermissionsRequest) (*vtctldata.ValidatePermissionsResponse, error)
+	// ValidateRoutingRules checks the routing rules against the current
+	// VSchemas, the way vtgate builds them.
+	ValidateRoutingRules(context.Context, *vtctldata.ValidateRoutingRulesRequest) (*vtctldata.ValidateRoutingRulesResponse, error)
+	// ValidateSrvKeyspace checks the partitions of a SrvKeyspace: each served
+	// type must have a single partition, whose shards cover the whole key space
+	// without gaps or overlaps.
+	ValidateSrvKeyspace(context.Context, *vtctldata.ValidateSrvKeyspaceRequest) (*vtctldata.ValidateSrvKeyspaceResponse, error)
+	// ValidateVersion returns the version each tablet of a keyspace runs, and
+	// whether they all run the same one.
+	ValidateVersion(context.Context, *vtctldata.ValidateVersionRequest) (*vtctldata.ValidateVersionResponse, error)
+	// VerifyReparentShard checks that a shard is sound after a reparent: its
+	// shard record points to a MASTER tablet, and its replicas replicate from
+	// it with both replication threads running.
+	VerifyReparentShard(context.Context, *vtctldata.VerifyReparentShardRequest) (*vtctldata.VerifyReparentShardResponse, error)
+	// VerifyReverseReplication verifies that the reverse workflow of a
+	// workflow whose writes were switched exists, is running and has caught
+	// up, and optionally compares sampled checksums of the moved tables between
+	// the source and the target. It doesn't fail when the verification does:
+	// the response reports why.
+	VerifyReverseReplication(context.Context, *vtctldata.VerifyReverseReplicationRequest) (*vtctldata.VerifyReverseReplicationResponse, error)
+	// WatchTopology waits for a change of a subtree of the topo, i.e. a node
+	// and all its descendants, from the version returned by the previous watch.
+	// The subtree is read periodically, so it also sees the created and deleted
+	// nodes.
+	WatchTopology(context.Context, *vtctldata.WatchTopologyRequest) (*vtctldata.WatchTopologyResponse, error)
 	mustEmbedUnimplementedVtctldServer()
 }
 
@@ -813,27 +1817,60 @@ type VtctldServer interface {
 type UnimplementedVtctldServer struct {
 }
 
+func (UnimplementedVtctldServer) AcquireKeyspaceLock(context.Context, *vtctldata.AcquireKeyspaceLockRequest) (*vtctldata.AcquireKeyspaceLockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcquireKeyspaceLock not implemented")
+}
 func (UnimplementedVtctldServer) AddCellInfo(context.Context, *vtctldata.AddCellInfoRequest) (*vtctldata.AddCellInfoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddCellInfo not implemented")
 }
 func (UnimplementedVtctldServer) AddCellsAlias(context.Context, *vtctldata.AddCellsAliasRequest) (*vtctldata.AddCellsAliasResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddCellsAlias not implemented")
 }
+func (UnimplementedVtctldServer) AdvanceGCTable(context.Context, *vtctldata.AdvanceGCTableRequest) (*vtctldata.AdvanceGCTableResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdvanceGCTable not implemented")
+}
+func (UnimplementedVtctldServer) ApplyMySQLUsers(context.Context, *vtctldata.ApplyMySQLUsersRequest) (*vtctldata.ApplyMySQLUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApplyMySQLUsers not implemented")
+}
 func (UnimplementedVtctldServer) ApplyRoutingRules(context.Context, *vtctldata.ApplyRoutingRulesRequest) (*vtctldata.ApplyRoutingRulesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ApplyRoutingRules not implemented")
 }
+func (UnimplementedVtctldServer) ApplyRoutingRulesBatch(context.Context, *vtctldata.ApplyRoutingRulesBatchRequest) (*vtctldata.ApplyRoutingRulesBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApplyRoutingRulesBatch not implemented")
+}
 func (UnimplementedVtctldServer) ApplyVSchema(context.Context, *vtctldata.ApplyVSchemaRequest) (*vtctldata.ApplyVSchemaResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ApplyVSchema not implemented")
 }
+func (UnimplementedVtctldServer) ApproveMigration(context.Context, *vtctldata.ApproveMigrationRequest) (*vtctldata.ApproveMigrationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveMigration not implemented")
+}
+func (UnimplementedVtctldServer) BackupKeyspace(context.Context, *vtctldata.BackupKeyspaceRequest) (*vtctldata.BackupKeyspaceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BackupKeyspace not implemented")
+}
+func (UnimplementedVtctldServer) BrowseTopology(context.Context, *vtctldata.BrowseTopologyRequest) (*vtctldata.BrowseTopologyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BrowseTopology not implemented")
+}
 func (UnimplementedVtctldServer) ChangeTabletType(context.Context, *vtctldata.ChangeTabletTypeRequest) (*vtctldata.ChangeTabletTypeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ChangeTabletType not implemented")
 }
+func (UnimplementedVtctldServer) CheckMySQLUsers(context.Context, *vtctldata.CheckMySQLUsersRequest) (*vtctldata.CheckMySQLUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckMySQLUsers not implemented")
+}
 func (UnimplementedVtctldServer) CreateKeyspace(context.Context, *vtctldata.CreateKeyspaceRequest) (*vtctldata.CreateKeyspaceResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateKeyspace not implemented")
 }
 func (UnimplementedVtctldServer) CreateShard(context.Context, *vtctldata.CreateShardRequest) (*vtctldata.CreateShardResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateShard not implemented")
 }
+func (UnimplementedVtctldServer) DecommissionTablet(context.Context, *vtctldata.DecommissionTabletRequest) (*vtctldata.DecommissionTabletResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DecommissionTablet not implemented")
+}
+func (UnimplementedVtctldServer) DeleteBackupSchedule(context.Context, *vtctldata.DeleteBackupScheduleRequest) (*vtctldata.DeleteBackupScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteBackupSchedule not implemented")
+}
+func (UnimplementedVtctldServer) DeleteBackupStorageConfig(context.Context, *vtctldata.DeleteBackupStorageConfigRequest) (*vtctldata.DeleteBackupStorageConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteBackupStorageConfig not implemented")
+}
 func (UnimplementedVtctldServer) DeleteCellInfo(context.Context, *vtctldata.DeleteCellInfoRequest) (*vtctldata.DeleteCellInfoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteCellInfo not implemented")
 }
@@ -849,30 +1886,60 @@ func (UnimplementedVtctldServer) DeleteShards(context.Context, *vtctldata.Delete
 func (UnimplementedVtctldServer) DeleteTablets(context.Context, *vtctldata.DeleteTabletsRequest) (*vtctldata.DeleteTabletsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteTablets not implemented")
 }
+func (UnimplementedVtctldServer) DrainTablet(context.Context, *vtctldata.DrainTabletRequest) (*vtctldata.DrainTabletResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DrainTablet not implemented")
+}
 func (UnimplementedVtctldServer) EmergencyReparentShard(context.Context, *vtctldata.EmergencyReparentShardRequest) (*vtctldata.EmergencyReparentShardResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method EmergencyReparentShard not implemented")
 }
+func (UnimplementedVtctldServer) FenceShard(context.Context, *vtctldata.FenceShardRequest) (*vtctldata.FenceShardResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FenceShard not implemented")
+}
 func (UnimplementedVtctldServer) FindAllShardsInKeyspace(context.Context, *vtctldata.FindAllShardsInKeyspaceRequest) (*vtctldata.FindAllShardsInKeyspaceResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method FindAllShardsInKeyspace not implemented")
 }
+func (UnimplementedVtctldServer) GetBackupSchedule(context.Context, *vtctldata.GetBackupScheduleRequest) (*vtctldata.GetBackupScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBackupSchedule not implemented")
+}
 func (UnimplementedVtctldServer) GetBackups(context.Context, *vtctldata.GetBackupsRequest) (*vtctldata.GetBackupsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetBackups not implemented")
 }
+func (UnimplementedVtctldServer) GetBackupStorageConfig(context.Context, *vtctldata.GetBackupStorageConfigRequest) (*vtctldata.GetBackupStorageConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBackupStorageConfig not implemented")
+}
+func (UnimplementedVtctldServer) GetCDCSinks(context.Context, *vtctldata.GetCDCSinksRequest) (*vtctldata.GetCDCSinksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCDCSinks not implemented")
+}
 func (UnimplementedVtctldServer) GetCellInfo(context.Context, *vtctldata.GetCellInfoRequest) (*vtctldata.GetCellInfoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetCellInfo not implemented")
 }
 func (UnimplementedVtctldServer) GetCellInfoNames(context.Context, *vtctldata.GetCellInfoNamesRequest) (*vtctldata.GetCellInfoNamesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetCellInfoNames not implemented")
 }
+func (UnimplementedVtctldServer) GetCellServingOverride(context.Context, *vtctldata.GetCellServingOverrideRequest) (*vtctldata.GetCellServingOverrideResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCellServingOverride not implemented")
+}
 func (UnimplementedVtctldServer) GetCellsAliases(context.Context, *vtctldata.GetCellsAliasesRequest) (*vtctldata.GetCellsAliasesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetCellsAliases not implemented")
 }
+func (UnimplementedVtctldServer) GetGCTables(context.Context, *vtctldata.GetGCTablesRequest) (*vtctldata.GetGCTablesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGCTables not implemented")
+}
+func (UnimplementedVtctldServer) GetKeyspaceBackupRuns(context.Context, *vtctldata.GetKeyspaceBackupRunsRequest) (*vtctldata.GetKeyspaceBackupRunsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetKeyspaceBackupRuns not implemented")
+}
 func (UnimplementedVtctldServer) GetKeyspace(context.Context, *vtctldata.GetKeyspaceRequest) (*vtctldata.GetKeyspaceResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetKeyspace not implemented")
 }
 func (UnimplementedVtctldServer) GetKeyspaces(context.Context, *vtctldata.GetKeyspacesRequest) (*vtctldata.GetKeyspacesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetKeyspaces not implemented")
 }
+func (UnimplementedVtctldServer) GetMySQLUsers(context.Context, *vtctldata.GetMySQLUsersRequest) (*vtctldata.GetMySQLUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMySQLUsers not implemented")
+}
+func (UnimplementedVtctldServer) GetOnlineDDLProgress(context.Context, *vtctldata.GetOnlineDDLProgressRequest) (*vtctldata.GetOnlineDDLProgressResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOnlineDDLProgress not implemented")
+}
 func (UnimplementedVtctldServer) GetRoutingRules(context.Context, *vtctldata.GetRoutingRulesRequest) (*vtctldata.GetRoutingRulesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetRoutingRules not implemented")
 }
@@ -885,6 +1952,18 @@ func (UnimplementedVtctldServer) GetShard(context.Context, *vtctldata.GetShardRe
 func (UnimplementedVtctldServer) GetSrvKeyspaces(context.Context, *vtctldata.GetSrvKeyspacesRequest) (*vtctldata.GetSrvKeyspacesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetSrvKeyspaces not implemented")
 }
+func (UnimplementedVtctldServer) GetShardFence(context.Context, *vtctldata.GetShardFenceRequest) (*vtctldata.GetShardFenceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetShardFence not implemented")
+}
+func (UnimplementedVtctldServer) GetShardReplicationGraph(context.Context, *vtctldata.GetShardReplicationGraphRequest) (*vtctldata.GetShardReplicationGraphResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetShardReplicationGraph not implemented")
+}
+func (UnimplementedVtctldServer) GetShardServingTimeline(context.Context, *vtctldata.GetShardServingTimelineRequest) (*vtctldata.GetShardServingTimelineResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetShardServingTimeline not implemented")
+}
+func (UnimplementedVtctldServer) GetSrvKeyspaceWithVersion(context.Context, *vtctldata.GetSrvKeyspaceWithVersionRequest) (*vtctldata.GetSrvKeyspaceWithVersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSrvKeyspaceWithVersion not implemented")
+}
 func (UnimplementedVtctldServer) GetSrvVSchema(context.Context, *vtctldata.GetSrvVSchemaRequest) (*vtctldata.GetSrvVSchemaResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetSrvVSchema not implemented")
 }
@@ -894,30 +1973,69 @@ func (UnimplementedVtctldServer) GetSrvVSchemas(context.Context, *vtctldata.GetS
 func (UnimplementedVtctldServer) GetTablet(context.Context, *vtctldata.GetTabletRequest) (*vtctldata.GetTabletResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTablet not implemented")
 }
+func (UnimplementedVtctldServer) GetTabletHealthHistory(context.Context, *vtctldata.GetTabletHealthHistoryRequest) (*vtctldata.GetTabletHealthHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTabletHealthHistory not implemented")
+}
 func (UnimplementedVtctldServer) GetTablets(context.Context, *vtctldata.GetTabletsRequest) (*vtctldata.GetTabletsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTablets not implemented")
 }
 func (UnimplementedVtctldServer) GetVSchema(context.Context, *vtctldata.GetVSchemaRequest) (*vtctldata.GetVSchemaResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetVSchema not implemented")
 }
+func (UnimplementedVtctldServer) GetWorkflowAuditLog(context.Context, *vtctldata.GetWorkflowAuditLogRequest) (*vtctldata.GetWorkflowAuditLogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWorkflowAuditLog not implemented")
+}
+func (UnimplementedVtctldServer) GetWorkflowProgress(context.Context, *vtctldata.GetWorkflowProgressRequest) (*vtctldata.GetWorkflowProgressResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWorkflowProgress not implemented")
+}
 func (UnimplementedVtctldServer) GetWorkflows(context.Context, *vtctldata.GetWorkflowsRequest) (*vtctldata.GetWorkflowsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetWorkflows not implemented")
 }
 func (UnimplementedVtctldServer) InitShardPrimary(context.Context, *vtctldata.InitShardPrimaryRequest) (*vtctldata.InitShardPrimaryResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method InitShardPrimary not implemented")
 }
+func (UnimplementedVtctldServer) ListKeyspaceBackups(context.Context, *vtctldata.ListKeyspaceBackupsRequest) (*vtctldata.ListKeyspaceBackupsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListKeyspaceBackups not implemented")
+}
+func (UnimplementedVtctldServer) ListLocks(context.Context, *vtctldata.ListLocksRequest) (*vtctldata.ListLocksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLocks not implemented")
+}
+func (UnimplementedVtctldServer) ListVDiffResults(context.Context, *vtctldata.ListVDiffResultsRequest) (*vtctldata.ListVDiffResultsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListVDiffResults not implemented")
+}
+func (UnimplementedVtctldServer) PlanReparentShard(context.Context, *vtctldata.PlanReparentShardRequest) (*vtctldata.PlanReparentShardResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlanReparentShard not implemented")
+}
 func (UnimplementedVtctldServer) PlannedReparentShard(context.Context, *vtctldata.PlannedReparentShardRequest) (*vtctldata.PlannedReparentShardResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PlannedReparentShard not implemented")
 }
+func (UnimplementedVtctldServer) PreviewDeclarativeSchema(context.Context, *vtctldata.PreviewDeclarativeSchemaRequest) (*vtctldata.PreviewDeclarativeSchemaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreviewDeclarativeSchema not implemented")
+}
+func (UnimplementedVtctldServer) PruneBackups(context.Context, *vtctldata.PruneBackupsRequest) (*vtctldata.PruneBackupsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PruneBackups not implemented")
+}
+func (UnimplementedVtctldServer) RebuildKeyspaceGraph(context.Context, *vtctldata.RebuildKeyspaceGraphRequest) (*vtctldata.RebuildKeyspaceGraphResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RebuildKeyspaceGraph not implemented")
+}
 func (UnimplementedVtctldServer) RebuildVSchemaGraph(context.Context, *vtctldata.RebuildVSchemaGraphRequest) (*vtctldata.RebuildVSchemaGraphResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RebuildVSchemaGraph not implemented")
 }
+func (UnimplementedVtctldServer) RecommendTabletTypeChange(context.Context, *vtctldata.RecommendTabletTypeChangeRequest) (*vtctldata.RecommendTabletTypeChangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecommendTabletTypeChange not implemented")
+}
 func (UnimplementedVtctldServer) RefreshState(context.Context, *vtctldata.RefreshStateRequest) (*vtctldata.RefreshStateResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RefreshState not implemented")
 }
 func (UnimplementedVtctldServer) RefreshStateByShard(context.Context, *vtctldata.RefreshStateByShardRequest) (*vtctldata.RefreshStateByShardResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RefreshStateByShard not implemented")
 }
+func (UnimplementedVtctldServer) RejectMigration(context.Context, *vtctldata.RejectMigrationRequest) (*vtctldata.RejectMigrationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RejectMigration not implemented")
+}
+func (UnimplementedVtctldServer) ReleaseLock(context.Context, *vtctldata.ReleaseLockRequest) (*vtctldata.ReleaseLockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseLock not implemented")
+}
 func (UnimplementedVtctldServer) RemoveKeyspaceCell(context.Context, *vtctldata.RemoveKeyspaceCellRequest) (*vtctldata.RemoveKeyspaceCellResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveKeyspaceCell not implemented")
 }
@@ -927,18 +2045,90 @@ func (UnimplementedVtctldServer) RemoveShardCell(context.Context, *vtctldata.Rem
 func (UnimplementedVtctldServer) ReparentTablet(context.Context, *vtctldata.ReparentTabletRequest) (*vtctldata.ReparentTabletResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ReparentTablet not implemented")
 }
+func (UnimplementedVtctldServer) RestoreGCTable(context.Context, *vtctldata.RestoreGCTableRequest) (*vtctldata.RestoreGCTableResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreGCTable not implemented")
+}
+func (UnimplementedVtctldServer) RestoreToPointInTime(context.Context, *vtctldata.RestoreToPointInTimeRequest) (*vtctldata.RestoreToPointInTimeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreToPointInTime not implemented")
+}
+func (UnimplementedVtctldServer) RevertMigration(context.Context, *vtctldata.RevertMigrationRequest) (*vtctldata.RevertMigrationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevertMigration not implemented")
+}
+func (UnimplementedVtctldServer) SeedTable(context.Context, *vtctldata.SeedTableRequest) (*vtctldata.SeedTableResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SeedTable not implemented")
+}
+func (UnimplementedVtctldServer) SetBackupSchedule(context.Context, *vtctldata.SetBackupScheduleRequest) (*vtctldata.SetBackupScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetBackupSchedule not implemented")
+}
+func (UnimplementedVtctldServer) SetBackupStorageConfig(context.Context, *vtctldata.SetBackupStorageConfigRequest) (*vtctldata.SetBackupStorageConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetBackupStorageConfig not implemented")
+}
+func (UnimplementedVtctldServer) SetCDCSinks(context.Context, *vtctldata.SetCDCSinksRequest) (*vtctldata.SetCDCSinksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetCDCSinks not implemented")
+}
+func (UnimplementedVtctldServer) SetCellServingOverride(context.Context, *vtctldata.SetCellServingOverrideRequest) (*vtctldata.SetCellServingOverrideResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetCellServingOverride not implemented")
+}
+func (UnimplementedVtctldServer) SetGCTablesRetention(context.Context, *vtctldata.SetGCTablesRetentionRequest) (*vtctldata.SetGCTablesRetentionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetGCTablesRetention not implemented")
+}
+func (UnimplementedVtctldServer) SetMySQLUsers(context.Context, *vtctldata.SetMySQLUsersRequest) (*vtctldata.SetMySQLUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetMySQLUsers not implemented")
+}
 func (UnimplementedVtctldServer) ShardReplicationPositions(context.Context, *vtctldata.ShardReplicationPositionsRequest) (*vtctldata.ShardReplicationPositionsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ShardReplicationPositions not implemented")
 }
+func (UnimplementedVtctldServer) SplitReads(context.Context, *vtctldata.SplitReadsRequest) (*vtctldata.SplitReadsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SplitReads not implemented")
+}
+func (UnimplementedVtctldServer) StartHostMaintenance(context.Context, *vtctldata.StartHostMaintenanceRequest) (*vtctldata.StartHostMaintenanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartHostMaintenance not implemented")
+}
 func (UnimplementedVtctldServer) TabletExternallyReparented(context.Context, *vtctldata.TabletExternallyReparentedRequest) (*vtctldata.TabletExternallyReparentedResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method TabletExternallyReparented not implemented")
 }
+func (UnimplementedVtctldServer) UndrainTablet(context.Context, *vtctldata.UndrainTabletRequest) (*vtctldata.UndrainTabletResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UndrainTablet not implemented")
+}
+func (UnimplementedVtctldServer) UnfenceShard(context.Context, *vtctldata.UnfenceShardRequest) (*vtctldata.UnfenceShardResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnfenceShard not implemented")
+}
 func (UnimplementedVtctldServer) UpdateCellInfo(context.Context, *vtctldata.UpdateCellInfoRequest) (*vtctldata.UpdateCellInfoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateCellInfo not implemented")
 }
 func (UnimplementedVtctldServer) UpdateCellsAlias(context.Context, *vtctldata.UpdateCellsAliasRequest) (*vtctldata.UpdateCellsAliasResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateCellsAlias not implemented")
 }
+func (UnimplementedVtctldServer) UpdateSrvKeyspace(context.Context, *vtctldata.UpdateSrvKeyspaceRequest) (*vtctldata.UpdateSrvKeyspaceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSrvKeyspace not implemented")
+}
+func (UnimplementedVtctldServer) UpdateWorkflowThrottling(context.Context, *vtctldata.UpdateWorkflowThrottlingRequest) (*vtctldata.UpdateWorkflowThrottlingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateWorkflowThrottling not implemented")
+}
+func (UnimplementedVtctldServer) ValidateConfig(context.Context, *vtctldata.ValidateConfigRequest) (*vtctldata.ValidateConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateConfig not implemented")
+}
+func (UnimplementedVtctldServer) ValidatePermissions(context.Context, *vtctldata.ValidatePermissionsRequest) (*vtctldata.ValidatePermissionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidatePermissions not implemented")
+}
+func (UnimplementedVtctldServer) ValidateRoutingRules(context.Context, *vtctldata.ValidateRoutingRulesRequest) (*vtctldata.ValidateRoutingRulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateRoutingRules not implemented")
+}
+func (UnimplementedVtctldServer) ValidateSrvKeyspace(context.Context, *vtctldata.ValidateSrvKeyspaceRequest) (*vtctldata.ValidateSrvKeyspaceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateSrvKeyspace not implemented")
+}
+func (UnimplementedVtctldServer) ValidateVersion(context.Context, *vtctldata.ValidateVersionRequest) (*vtctldata.ValidateVersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateVersion not implemented")
+}
+func (UnimplementedVtctldServer) VerifyReparentShard(context.Context, *vtctldata.VerifyReparentShardRequest) (*vtctldata.VerifyReparentShardResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyReparentShard not implemented")
+}
+func (UnimplementedVtctldServer) VerifyReverseReplication(context.Context, *vtctldata.VerifyReverseReplicationRequest) (*vtctldata.VerifyReverseReplicationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyReverseReplication not implemented")
+}
+func (UnimplementedVtctldServer) WatchTopology(context.Context, *vtctldata.WatchTopologyRequest) (*vtctldata.WatchTopologyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WatchTopology not implemented")
+}
 func (UnimplementedVtctldServer) mustEmbedUnimplementedVtctldServer() {}
 
 // UnsafeVtctldServer may be embedded to opt out of forward compatibility for this service.
@@ -952,6 +2142,24 @@ func RegisterVtctldServer(s grpc.ServiceRegistrar, srv VtctldServer) {
 	s.RegisterService(&Vtctld_ServiceDesc, srv)
 }
 
+func _Vtctld_AcquireKeyspaceLock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.AcquireKeyspaceLockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).AcquireKeyspaceLock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/AcquireKeyspaceLock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).AcquireKeyspaceLock(ctx, req.(*vtctldata.AcquireKeyspaceLockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Vtctld_AddCellInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(vtctldata.AddCellInfoRequest)
 	if err := dec(in); err != nil {
@@ -988,35 +2196,89 @@ func _Vtctld_AddCellsAlias_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Vtctld_ApplyRoutingRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(vtctldata.ApplyRoutingRulesRequest)
+func _Vtctld_AdvanceGCTable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.AdvanceGCTableRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(VtctldServer).ApplyRoutingRules(ctx, in)
+		return srv.(VtctldServer).AdvanceGCTable(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/vtctlservice.Vtctld/ApplyRoutingRules",
+		FullMethod: "/vtctlservice.Vtctld/AdvanceGCTable",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VtctldServer).ApplyRoutingRules(ctx, req.(*vtctldata.ApplyRoutingRulesRequest))
+		return srv.(VtctldServer).AdvanceGCTable(ctx, req.(*vtctldata.AdvanceGCTableRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Vtctld_ApplyVSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(vtctldata.ApplyVSchemaRequest)
+func _Vtctld_ApplyMySQLUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.ApplyMySQLUsersRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(VtctldServer).ApplyVSchema(ctx, in)
+		return srv.(VtctldServer).ApplyMySQLUsers(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/vtctlservice.Vtctld/ApplyVSchema",
+		FullMethod: "/vtctlservice.Vtctld/ApplyMySQLUsers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).ApplyMySQLUsers(ctx, req.(*vtctldata.ApplyMySQLUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vtctld_ApplyRoutingRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.ApplyRoutingRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).ApplyRoutingRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/ApplyRoutingRules",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).ApplyRoutingRules(ctx, req.(*vtctldata.ApplyRoutingRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vtctld_ApplyRoutingRulesBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.ApplyRoutingRulesBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).ApplyRoutingRulesBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/ApplyRoutingRulesBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).ApplyRoutingRulesBatch(ctx, req.(*vtctldata.ApplyRoutingRulesBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vtctld_ApplyVSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.ApplyVSchemaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).ApplyVSchema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/ApplyVSchema",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(VtctldServer).ApplyVSchema(ctx, req.(*vtctldata.ApplyVSchemaRequest))
@@ -1024,6 +2286,60 @@ func _Vtctld_ApplyVSchema_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Vtctld_ApproveMigration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.ApproveMigrationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).ApproveMigration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/ApproveMigration",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).ApproveMigration(ctx, req.(*vtctldata.ApproveMigrationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vtctld_BackupKeyspace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.BackupKeyspaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).BackupKeyspace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/BackupKeyspace",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).BackupKeyspace(ctx, req.(*vtctldata.BackupKeyspaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vtctld_BrowseTopology_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.BrowseTopologyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).BrowseTopology(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/BrowseTopology",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).BrowseTopology(ctx, req.(*vtctldata.BrowseTopologyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Vtctld_ChangeTabletType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(vtctldata.ChangeTabletTypeRequest)
 	if err := dec(in); err != nil {
@@ -1042,6 +2358,24 @@ func _Vtctld_ChangeTabletType_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Vtctld_CheckMySQLUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.CheckMySQLUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).CheckMySQLUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/CheckMySQLUsers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).CheckMySQLUsers(ctx, req.(*vtctldata.CheckMySQLUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Vtctld_CreateKeyspace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(vtctldata.CreateKeyspaceRequest)
 	if err := dec(in); err != nil {
@@ -1078,6 +2412,60 @@ func _Vtctld_CreateShard_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Vtctld_DecommissionTablet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.DecommissionTabletRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).DecommissionTablet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/DecommissionTablet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).DecommissionTablet(ctx, req.(*vtctldata.DecommissionTabletRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vtctld_DeleteBackupSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.DeleteBackupScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).DeleteBackupSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/DeleteBackupSchedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).DeleteBackupSchedule(ctx, req.(*vtctldata.DeleteBackupScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vtctld_DeleteBackupStorageConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.DeleteBackupStorageConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).DeleteBackupStorageConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/DeleteBackupStorageConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).DeleteBackupStorageConfig(ctx, req.(*vtctldata.DeleteBackupStorageConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Vtctld_DeleteCellInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(vtctldata.DeleteCellInfoRequest)
 	if err := dec(in); err != nil {
@@ -1168,6 +2556,24 @@ func _Vtctld_DeleteTablets_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Vtctld_DrainTablet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.DrainTabletRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).DrainTablet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/DrainTablet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).DrainTablet(ctx, req.(*vtctldata.DrainTabletRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Vtctld_EmergencyReparentShard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(vtctldata.EmergencyReparentShardRequest)
 	if err := dec(in); err != nil {
@@ -1186,6 +2592,24 @@ func _Vtctld_EmergencyReparentShard_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Vtctld_FenceShard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.FenceShardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).FenceShard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/FenceShard",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).FenceShard(ctx, req.(*vtctldata.FenceShardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Vtctld_FindAllShardsInKeyspace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(vtctldata.FindAllShardsInKeyspaceRequest)
 	if err := dec(in); err != nil {
@@ -1204,6 +2628,24 @@ func _Vtctld_FindAllShardsInKeyspace_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Vtctld_GetBackupSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.GetBackupScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).GetBackupSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/GetBackupSchedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).GetBackupSchedule(ctx, req.(*vtctldata.GetBackupScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Vtctld_GetBackups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(vtctldata.GetBackupsRequest)
 	if err := dec(in); err != nil {
@@ -1222,6 +2664,42 @@ func _Vtctld_GetBackups_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Vtctld_GetBackupStorageConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.GetBackupStorageConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).GetBackupStorageConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/GetBackupStorageConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).GetBackupStorageConfig(ctx, req.(*vtctldata.GetBackupStorageConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vtctld_GetCDCSinks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.GetCDCSinksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).GetCDCSinks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/GetCDCSinks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).GetCDCSinks(ctx, req.(*vtctldata.GetCDCSinksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Vtctld_GetCellInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(vtctldata.GetCellInfoRequest)
 	if err := dec(in); err != nil {
@@ -1258,6 +2736,24 @@ func _Vtctld_GetCellInfoNames_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Vtctld_GetCellServingOverride_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.GetCellServingOverrideRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).GetCellServingOverride(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/GetCellServingOverride",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).GetCellServingOverride(ctx, req.(*vtctldata.GetCellServingOverrideRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Vtctld_GetCellsAliases_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(vtctldata.GetCellsAliasesRequest)
 	if err := dec(in); err != nil {
@@ -1276,6 +2772,42 @@ func _Vtctld_GetCellsAliases_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Vtctld_GetGCTables_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.GetGCTablesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).GetGCTables(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/GetGCTables",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).GetGCTables(ctx, req.(*vtctldata.GetGCTablesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vtctld_GetKeyspaceBackupRuns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.GetKeyspaceBackupRunsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).GetKeyspaceBackupRuns(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/GetKeyspaceBackupRuns",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).GetKeyspaceBackupRuns(ctx, req.(*vtctldata.GetKeyspaceBackupRunsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Vtctld_GetKeyspace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(vtctldata.GetKeyspaceRequest)
 	if err := dec(in); err != nil {
@@ -1304,406 +2836,1180 @@ func _Vtctld_GetKeyspaces_Handler(srv interface{}, ctx context.Context, dec func
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/vtctlservice.Vtctld/GetKeyspaces",
+		FullMethod: "/vtctlservice.Vtctld/GetKeyspaces",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).GetKeyspaces(ctx, req.(*vtctldata.GetKeyspacesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vtctld_GetMySQLUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.GetMySQLUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).GetMySQLUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/GetMySQLUsers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).GetMySQLUsers(ctx, req.(*vtctldata.GetMySQLUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vtctld_GetOnlineDDLProgress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.GetOnlineDDLProgressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).GetOnlineDDLProgress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/GetOnlineDDLProgress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).GetOnlineDDLProgress(ctx, req.(*vtctldata.GetOnlineDDLProgressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vtctld_GetRoutingRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.GetRoutingRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).GetRoutingRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/GetRoutingRules",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).GetRoutingRules(ctx, req.(*vtctldata.GetRoutingRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vtctld_GetSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.GetSchemaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).GetSchema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/GetSchema",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).GetSchema(ctx, req.(*vtctldata.GetSchemaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vtctld_GetShard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.GetShardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).GetShard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/GetShard",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).GetShard(ctx, req.(*vtctldata.GetShardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vtctld_GetSrvKeyspaces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.GetSrvKeyspacesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).GetSrvKeyspaces(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/GetSrvKeyspaces",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).GetSrvKeyspaces(ctx, req.(*vtctldata.GetSrvKeyspacesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vtctld_GetShardFence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.GetShardFenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).GetShardFence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/GetShardFence",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).GetShardFence(ctx, req.(*vtctldata.GetShardFenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vtctld_GetShardReplicationGraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.GetShardReplicationGraphRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).GetShardReplicationGraph(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/GetShardReplicationGraph",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).GetShardReplicationGraph(ctx, req.(*vtctldata.GetShardReplicationGraphRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vtctld_GetShardServingTimeline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.GetShardServingTimelineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).GetShardServingTimeline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/GetShardServingTimeline",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).GetShardServingTimeline(ctx, req.(*vtctldata.GetShardServingTimelineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vtctld_GetSrvKeyspaceWithVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.GetSrvKeyspaceWithVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).GetSrvKeyspaceWithVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/GetSrvKeyspaceWithVersion",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).GetSrvKeyspaceWithVersion(ctx, req.(*vtctldata.GetSrvKeyspaceWithVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vtctld_GetSrvVSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.GetSrvVSchemaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).GetSrvVSchema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/GetSrvVSchema",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).GetSrvVSchema(ctx, req.(*vtctldata.GetSrvVSchemaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vtctld_GetSrvVSchemas_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.GetSrvVSchemasRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).GetSrvVSchemas(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/GetSrvVSchemas",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).GetSrvVSchemas(ctx, req.(*vtctldata.GetSrvVSchemasRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vtctld_GetTablet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.GetTabletRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).GetTablet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/GetTablet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).GetTablet(ctx, req.(*vtctldata.GetTabletRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vtctld_GetTabletHealthHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.GetTabletHealthHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).GetTabletHealthHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/GetTabletHealthHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).GetTabletHealthHistory(ctx, req.(*vtctldata.GetTabletHealthHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vtctld_GetTablets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.GetTabletsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).GetTablets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/GetTablets",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).GetTablets(ctx, req.(*vtctldata.GetTabletsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vtctld_GetVSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.GetVSchemaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).GetVSchema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/GetVSchema",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).GetVSchema(ctx, req.(*vtctldata.GetVSchemaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vtctld_GetWorkflowAuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.GetWorkflowAuditLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).GetWorkflowAuditLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/GetWorkflowAuditLog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).GetWorkflowAuditLog(ctx, req.(*vtctldata.GetWorkflowAuditLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vtctld_GetWorkflowProgress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.GetWorkflowProgressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).GetWorkflowProgress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/GetWorkflowProgress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).GetWorkflowProgress(ctx, req.(*vtctldata.GetWorkflowProgressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vtctld_GetWorkflows_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.GetWorkflowsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).GetWorkflows(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/GetWorkflows",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).GetWorkflows(ctx, req.(*vtctldata.GetWorkflowsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vtctld_InitShardPrimary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.InitShardPrimaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).InitShardPrimary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/InitShardPrimary",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).InitShardPrimary(ctx, req.(*vtctldata.InitShardPrimaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vtctld_ListKeyspaceBackups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.ListKeyspaceBackupsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).ListKeyspaceBackups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/ListKeyspaceBackups",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).ListKeyspaceBackups(ctx, req.(*vtctldata.ListKeyspaceBackupsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vtctld_ListLocks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.ListLocksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).ListLocks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/ListLocks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).ListLocks(ctx, req.(*vtctldata.ListLocksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vtctld_ListVDiffResults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.ListVDiffResultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).ListVDiffResults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/ListVDiffResults",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).ListVDiffResults(ctx, req.(*vtctldata.ListVDiffResultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vtctld_PlanReparentShard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.PlanReparentShardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).PlanReparentShard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/PlanReparentShard",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).PlanReparentShard(ctx, req.(*vtctldata.PlanReparentShardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vtctld_PlannedReparentShard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.PlannedReparentShardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).PlannedReparentShard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/PlannedReparentShard",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).PlannedReparentShard(ctx, req.(*vtctldata.PlannedReparentShardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vtctld_PreviewDeclarativeSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.PreviewDeclarativeSchemaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).PreviewDeclarativeSchema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/PreviewDeclarativeSchema",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).PreviewDeclarativeSchema(ctx, req.(*vtctldata.PreviewDeclarativeSchemaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vtctld_PruneBackups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.PruneBackupsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).PruneBackups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/PruneBackups",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).PruneBackups(ctx, req.(*vtctldata.PruneBackupsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vtctld_RebuildKeyspaceGraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.RebuildKeyspaceGraphRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).RebuildKeyspaceGraph(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/RebuildKeyspaceGraph",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).RebuildKeyspaceGraph(ctx, req.(*vtctldata.RebuildKeyspaceGraphRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vtctld_RebuildVSchemaGraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.RebuildVSchemaGraphRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).RebuildVSchemaGraph(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/RebuildVSchemaGraph",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).RebuildVSchemaGraph(ctx, req.(*vtctldata.RebuildVSchemaGraphRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vtctld_RecommendTabletTypeChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.RecommendTabletTypeChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).RecommendTabletTypeChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/RecommendTabletTypeChange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).RecommendTabletTypeChange(ctx, req.(*vtctldata.RecommendTabletTypeChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vtctld_RefreshState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.RefreshStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).RefreshState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/RefreshState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).RefreshState(ctx, req.(*vtctldata.RefreshStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vtctld_RefreshStateByShard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.RefreshStateByShardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).RefreshStateByShard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/RefreshStateByShard",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).RefreshStateByShard(ctx, req.(*vtctldata.RefreshStateByShardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vtctld_RejectMigration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.RejectMigrationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).RejectMigration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/RejectMigration",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).RejectMigration(ctx, req.(*vtctldata.RejectMigrationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vtctld_ReleaseLock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.ReleaseLockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).ReleaseLock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/ReleaseLock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).ReleaseLock(ctx, req.(*vtctldata.ReleaseLockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vtctld_RemoveKeyspaceCell_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.RemoveKeyspaceCellRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).RemoveKeyspaceCell(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/RemoveKeyspaceCell",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).RemoveKeyspaceCell(ctx, req.(*vtctldata.RemoveKeyspaceCellRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vtctld_RemoveShardCell_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.RemoveShardCellRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).RemoveShardCell(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/RemoveShardCell",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).RemoveShardCell(ctx, req.(*vtctldata.RemoveShardCellRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vtctld_ReparentTablet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.ReparentTabletRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).ReparentTablet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/ReparentTablet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).ReparentTablet(ctx, req.(*vtctldata.ReparentTabletRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vtctld_RestoreGCTable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.RestoreGCTableRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).RestoreGCTable(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/RestoreGCTable",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).RestoreGCTable(ctx, req.(*vtctldata.RestoreGCTableRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vtctld_RestoreToPointInTime_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.RestoreToPointInTimeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).RestoreToPointInTime(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/RestoreToPointInTime",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).RestoreToPointInTime(ctx, req.(*vtctldata.RestoreToPointInTimeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vtctld_RevertMigration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.RevertMigrationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).RevertMigration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/RevertMigration",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).RevertMigration(ctx, req.(*vtctldata.RevertMigrationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vtctld_SeedTable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.SeedTableRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).SeedTable(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/SeedTable",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).SeedTable(ctx, req.(*vtctldata.SeedTableRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vtctld_SetBackupSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.SetBackupScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).SetBackupSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/SetBackupSchedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VtctldServer).SetBackupSchedule(ctx, req.(*vtctldata.SetBackupScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vtctld_SetBackupStorageConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.SetBackupStorageConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VtctldServer).SetBackupStorageConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtctlservice.Vtctld/SetBackupStorageConfig",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VtctldServer).GetKeyspaces(ctx, req.(*vtctldata.GetKeyspacesRequest))
+		return srv.(VtctldServer).SetBackupStorageConfig(ctx, req.(*vtctldata.SetBackupStorageConfigRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Vtctld_GetRoutingRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(vtctldata.GetRoutingRulesRequest)
+func _Vtctld_SetCDCSinks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.SetCDCSinksRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(VtctldServer).GetRoutingRules(ctx, in)
+		return srv.(VtctldServer).SetCDCSinks(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/vtctlservice.Vtctld/GetRoutingRules",
+		FullMethod: "/vtctlservice.Vtctld/SetCDCSinks",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VtctldServer).GetRoutingRules(ctx, req.(*vtctldata.GetRoutingRulesRequest))
+		return srv.(VtctldServer).SetCDCSinks(ctx, req.(*vtctldata.SetCDCSinksRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Vtctld_GetSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(vtctldata.GetSchemaRequest)
+func _Vtctld_SetCellServingOverride_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.SetCellServingOverrideRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(VtctldServer).GetSchema(ctx, in)
+		return srv.(VtctldServer).SetCellServingOverride(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/vtctlservice.Vtctld/GetSchema",
+		FullMethod: "/vtctlservice.Vtctld/SetCellServingOverride",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VtctldServer).GetSchema(ctx, req.(*vtctldata.GetSchemaRequest))
+		return srv.(VtctldServer).SetCellServingOverride(ctx, req.(*vtctldata.SetCellServingOverrideRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Vtctld_GetShard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(vtctldata.GetShardRequest)
+func _Vtctld_SetGCTablesRetention_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.SetGCTablesRetentionRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(VtctldServer).GetShard(ctx, in)
+		return srv.(VtctldServer).SetGCTablesRetention(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/vtctlservice.Vtctld/GetShard",
+		FullMethod: "/vtctlservice.Vtctld/SetGCTablesRetention",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VtctldServer).GetShard(ctx, req.(*vtctldata.GetShardRequest))
+		return srv.(VtctldServer).SetGCTablesRetention(ctx, req.(*vtctldata.SetGCTablesRetentionRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Vtctld_GetSrvKeyspaces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(vtctldata.GetSrvKeyspacesRequest)
+func _Vtctld_SetMySQLUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.SetMySQLUsersRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(VtctldServer).GetSrvKeyspaces(ctx, in)
+		return srv.(VtctldServer).SetMySQLUsers(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/vtctlservice.Vtctld/GetSrvKeyspaces",
+		FullMethod: "/vtctlservice.Vtctld/SetMySQLUsers",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VtctldServer).GetSrvKeyspaces(ctx, req.(*vtctldata.GetSrvKeyspacesRequest))
+		return srv.(VtctldServer).SetMySQLUsers(ctx, req.(*vtctldata.SetMySQLUsersRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Vtctld_GetSrvVSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(vtctldata.GetSrvVSchemaRequest)
+func _Vtctld_ShardReplicationPositions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.ShardReplicationPositionsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(VtctldServer).GetSrvVSchema(ctx, in)
+		return srv.(VtctldServer).ShardReplicationPositions(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/vtctlservice.Vtctld/GetSrvVSchema",
+		FullMethod: "/vtctlservice.Vtctld/ShardReplicationPositions",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VtctldServer).GetSrvVSchema(ctx, req.(*vtctldata.GetSrvVSchemaRequest))
+		return srv.(VtctldServer).ShardReplicationPositions(ctx, req.(*vtctldata.ShardReplicationPositionsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Vtctld_GetSrvVSchemas_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(vtctldata.GetSrvVSchemasRequest)
+func _Vtctld_SplitReads_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.SplitReadsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(VtctldServer).GetSrvVSchemas(ctx, in)
+		return srv.(VtctldServer).SplitReads(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/vtctlservice.Vtctld/GetSrvVSchemas",
+		FullMethod: "/vtctlservice.Vtctld/SplitReads",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VtctldServer).GetSrvVSchemas(ctx, req.(*vtctldata.GetSrvVSchemasRequest))
+		return srv.(VtctldServer).SplitReads(ctx, req.(*vtctldata.SplitReadsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Vtctld_GetTablet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(vtctldata.GetTabletRequest)
+func _Vtctld_StartHostMaintenance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.StartHostMaintenanceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(VtctldServer).GetTablet(ctx, in)
+		return srv.(VtctldServer).StartHostMaintenance(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/vtctlservice.Vtctld/GetTablet",
+		FullMethod: "/vtctlservice.Vtctld/StartHostMaintenance",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VtctldServer).GetTablet(ctx, req.(*vtctldata.GetTabletRequest))
+		return srv.(VtctldServer).StartHostMaintenance(ctx, req.(*vtctldata.StartHostMaintenanceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Vtctld_GetTablets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(vtctldata.GetTabletsRequest)
+func _Vtctld_TabletExternallyReparented_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.TabletExternallyReparentedRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(VtctldServer).GetTablets(ctx, in)
+		return srv.(VtctldServer).TabletExternallyReparented(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/vtctlservice.Vtctld/GetTablets",
+		FullMethod: "/vtctlservice.Vtctld/TabletExternallyReparented",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VtctldServer).GetTablets(ctx, req.(*vtctldata.GetTabletsRequest))
+		return srv.(VtctldServer).TabletExternallyReparented(ctx, req.(*vtctldata.TabletExternallyReparentedRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Vtctld_GetVSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(vtctldata.GetVSchemaRequest)
+func _Vtctld_UndrainTablet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.UndrainTabletRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(VtctldServer).GetVSchema(ctx, in)
+		return srv.(VtctldServer).UndrainTablet(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/vtctlservice.Vtctld/GetVSchema",
+		FullMethod: "/vtctlservice.Vtctld/UndrainTablet",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VtctldServer).GetVSchema(ctx, req.(*vtctldata.GetVSchemaRequest))
+		return srv.(VtctldServer).UndrainTablet(ctx, req.(*vtctldata.UndrainTabletRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Vtctld_GetWorkflows_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(vtctldata.GetWorkflowsRequest)
+func _Vtctld_UnfenceShard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.UnfenceShardRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(VtctldServer).GetWorkflows(ctx, in)
+		return srv.(VtctldServer).UnfenceShard(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/vtctlservice.Vtctld/GetWorkflows",
+		FullMethod: "/vtctlservice.Vtctld/UnfenceShard",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VtctldServer).GetWorkflows(ctx, req.(*vtctldata.GetWorkflowsRequest))
+		return srv.(VtctldServer).UnfenceShard(ctx, req.(*vtctldata.UnfenceShardRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Vtctld_InitShardPrimary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(vtctldata.InitShardPrimaryRequest)
+func _Vtctld_UpdateCellInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.UpdateCellInfoRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(VtctldServer).InitShardPrimary(ctx, in)
+		return srv.(VtctldServer).UpdateCellInfo(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/vtctlservice.Vtctld/InitShardPrimary",
+		FullMethod: "/vtctlservice.Vtctld/UpdateCellInfo",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VtctldServer).InitShardPrimary(ctx, req.(*vtctldata.InitShardPrimaryRequest))
+		return srv.(VtctldServer).UpdateCellInfo(ctx, req.(*vtctldata.UpdateCellInfoRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Vtctld_PlannedReparentShard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(vtctldata.PlannedReparentShardRequest)
+func _Vtctld_UpdateCellsAlias_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.UpdateCellsAliasRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(VtctldServer).PlannedReparentShard(ctx, in)
+		return srv.(VtctldServer).UpdateCellsAlias(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/vtctlservice.Vtctld/PlannedReparentShard",
+		FullMethod: "/vtctlservice.Vtctld/UpdateCellsAlias",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VtctldServer).PlannedReparentShard(ctx, req.(*vtctldata.PlannedReparentShardRequest))
+		return srv.(VtctldServer).UpdateCellsAlias(ctx, req.(*vtctldata.UpdateCellsAliasRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Vtctld_RebuildVSchemaGraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(vtctldata.RebuildVSchemaGraphRequest)
+func _Vtctld_UpdateSrvKeyspace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.UpdateSrvKeyspaceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(VtctldServer).RebuildVSchemaGraph(ctx, in)
+		return srv.(VtctldServer).UpdateSrvKeyspace(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/vtctlservice.Vtctld/RebuildVSchemaGraph",
+		FullMethod: "/vtctlservice.Vtctld/UpdateSrvKeyspace",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VtctldServer).RebuildVSchemaGraph(ctx, req.(*vtctldata.RebuildVSchemaGraphRequest))
+		return srv.(VtctldServer).UpdateSrvKeyspace(ctx, req.(*vtctldata.UpdateSrvKeyspaceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Vtctld_RefreshState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(vtctldata.RefreshStateRequest)
+func _Vtctld_UpdateWorkflowThrottling_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.UpdateWorkflowThrottlingRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(VtctldServer).RefreshState(ctx, in)
+		return srv.(VtctldServer).UpdateWorkflowThrottling(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/vtctlservice.Vtctld/RefreshState",
+		FullMethod: "/vtctlservice.Vtctld/UpdateWorkflowThrottling",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VtctldServer).RefreshState(ctx, req.(*vtctldata.RefreshStateRequest))
+		return srv.(VtctldServer).UpdateWorkflowThrottling(ctx, req.(*vtctldata.UpdateWorkflowThrottlingRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Vtctld_RefreshStateByShard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(vtctldata.RefreshStateByShardRequest)
+func _Vtctld_ValidateConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.ValidateConfigRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(VtctldServer).RefreshStateByShard(ctx, in)
+		return srv.(VtctldServer).ValidateConfig(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/vtctlservice.Vtctld/RefreshStateByShard",
+		FullMethod: "/vtctlservice.Vtctld/ValidateConfig",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VtctldServer).RefreshStateByShard(ctx, req.(*vtctldata.RefreshStateByShardRequest))
+		return srv.(VtctldServer).ValidateConfig(ctx, req.(*vtctldata.ValidateConfigRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Vtctld_RemoveKeyspaceCell_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(vtctldata.RemoveKeyspaceCellRequest)
+func _Vtctld_ValidatePermissions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.ValidatePermissionsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(VtctldServer).RemoveKeyspaceCell(ctx, in)
+		return srv.(VtctldServer).ValidatePermissions(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/vtctlservice.Vtctld/RemoveKeyspaceCell",
+		FullMethod: "/vtctlservice.Vtctld/ValidatePermissions",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VtctldServer).RemoveKeyspaceCell(ctx, req.(*vtctldata.RemoveKeyspaceCellRequest))
+		return srv.(VtctldServer).ValidatePermissions(ctx, req.(*vtctldata.ValidatePermissionsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Vtctld_RemoveShardCell_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(vtctldata.RemoveShardCellRequest)
+func _Vtctld_ValidateRoutingRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.ValidateRoutingRulesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(VtctldServer).RemoveShardCell(ctx, in)
+		return srv.(VtctldServer).ValidateRoutingRules(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/vtctlservice.Vtctld/RemoveShardCell",
+		FullMethod: "/vtctlservice.Vtctld/ValidateRoutingRules",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VtctldServer).RemoveShardCell(ctx, req.(*vtctldata.RemoveShardCellRequest))
+		return srv.(VtctldServer).ValidateRoutingRules(ctx, req.(*vtctldata.ValidateRoutingRulesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Vtctld_ReparentTablet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(vtctldata.ReparentTabletRequest)
+func _Vtctld_ValidateSrvKeyspace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.ValidateSrvKeyspaceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(VtctldServer).ReparentTablet(ctx, in)
+		return srv.(VtctldServer).ValidateSrvKeyspace(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/vtctlservice.Vtctld/ReparentTablet",
+		FullMethod: "/vtctlservice.Vtctld/ValidateSrvKeyspace",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VtctldServer).ReparentTablet(ctx, req.(*vtctldata.ReparentTabletRequest))
+		return srv.(VtctldServer).ValidateSrvKeyspace(ctx, req.(*vtctldata.ValidateSrvKeyspaceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Vtctld_ShardReplicationPositions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(vtctldata.ShardReplicationPositionsRequest)
+func _Vtctld_ValidateVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.ValidateVersionRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(VtctldServer).ShardReplicationPositions(ctx, in)
+		return srv.(VtctldServer).ValidateVersion(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/vtctlservice.Vtctld/ShardReplicationPositions",
+		FullMethod: "/vtctlservice.Vtctld/ValidateVersion",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VtctldServer).ShardReplicationPositions(ctx, req.(*vtctldata.ShardReplicationPositionsRequest))
+		return srv.(VtctldServer).ValidateVersion(ctx, req.(*vtctldata.ValidateVersionRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Vtctld_TabletExternallyReparented_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(vtctldata.TabletExternallyReparentedRequest)
+func _Vtctld_VerifyReparentShard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.VerifyReparentShardRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(VtctldServer).TabletExternallyReparented(ctx, in)
+		return srv.(VtctldServer).VerifyReparentShard(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/vtctlservice.Vtctld/TabletExternallyReparented",
+		FullMethod: "/vtctlservice.Vtctld/VerifyReparentShard",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VtctldServer).TabletExternallyReparented(ctx, req.(*vtctldata.TabletExternallyReparentedRequest))
+		return srv.(VtctldServer).VerifyReparentShard(ctx, req.(*vtctldata.VerifyReparentShardRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Vtctld_UpdateCellInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(vtctldata.UpdateCellInfoRequest)
+func _Vtctld_VerifyReverseReplication_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.VerifyReverseReplicationRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(VtctldServer).UpdateCellInfo(ctx, in)
+		return srv.(VtctldServer).VerifyReverseReplication(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/vtctlservice.Vtctld/UpdateCellInfo",
+		FullMethod: "/vtctlservice.Vtctld/VerifyReverseReplication",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VtctldServer).UpdateCellInfo(ctx, req.(*vtctldata.UpdateCellInfoRequest))
+		return srv.(VtctldServer).VerifyReverseReplication(ctx, req.(*vtctldata.VerifyReverseReplicationRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Vtctld_UpdateCellsAlias_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(vtctldata.UpdateCellsAliasRequest)
+func _Vtctld_WatchTopology_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtctldata.WatchTopologyRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(VtctldServer).UpdateCellsAlias(ctx, in)
+		return srv.(VtctldServer).WatchTopology(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/vtctlservice.Vtctld/UpdateCellsAlias",
+		FullMethod: "/vtctlservice.Vtctld/WatchTopology",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VtctldServer).UpdateCellsAlias(ctx, req.(*vtctldata.UpdateCellsAliasRequest))
+		return srv.(VtctldServer).WatchTopology(ctx, req.(*vtctldata.WatchTopologyRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -1715,6 +4021,10 @@ var Vtctld_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "vtctlservice.Vtctld",
 	HandlerType: (*VtctldServer)(nil),
 	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AcquireKeyspaceLock",
+			Handler:    _Vtctld_AcquireKeyspaceLock_Handler,
+		},
 		{
 			MethodName: "AddCellInfo",
 			Handler:    _Vtctld_AddCellInfo_Handler,
@@ -1723,18 +4033,46 @@ var Vtctld_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "AddCellsAlias",
 			Handler:    _Vtctld_AddCellsAlias_Handler,
 		},
+		{
+			MethodName: "AdvanceGCTable",
+			Handler:    _Vtctld_AdvanceGCTable_Handler,
+		},
+		{
+			MethodName: "ApplyMySQLUsers",
+			Handler:    _Vtctld_ApplyMySQLUsers_Handler,
+		},
 		{
 			MethodName: "ApplyRoutingRules",
 			Handler:    _Vtctld_ApplyRoutingRules_Handler,
 		},
+		{
+			MethodName: "ApplyRoutingRulesBatch",
+			Handler:    _Vtctld_ApplyRoutingRulesBatch_Handler,
+		},
 		{
 			MethodName: "ApplyVSchema",
 			Handler:    _Vtctld_ApplyVSchema_Handler,
 		},
+		{
+			MethodName: "ApproveMigration",
+			Handler:    _Vtctld_ApproveMigration_Handler,
+		},
+		{
+			MethodName: "BackupKeyspace",
+			Handler:    _Vtctld_BackupKeyspace_Handler,
+		},
+		{
+			MethodName: "BrowseTopology",
+			Handler:    _Vtctld_BrowseTopology_Handler,
+		},
 		{
 			MethodName: "ChangeTabletType",
 			Handler:    _Vtctld_ChangeTabletType_Handler,
 		},
+		{
+			MethodName: "CheckMySQLUsers",
+			Handler:    _Vtctld_CheckMySQLUsers_Handler,
+		},
 		{
 			MethodName: "CreateKeyspace",
 			Handler:    _Vtctld_CreateKeyspace_Handler,
@@ -1743,6 +4081,18 @@ var Vtctld_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateShard",
 			Handler:    _Vtctld_CreateShard_Handler,
 		},
+		{
+			MethodName: "DecommissionTablet",
+			Handler:    _Vtctld_DecommissionTablet_Handler,
+		},
+		{
+			MethodName: "DeleteBackupSchedule",
+			Handler:    _Vtctld_DeleteBackupSchedule_Handler,
+		},
+		{
+			MethodName: "DeleteBackupStorageConfig",
+			Handler:    _Vtctld_DeleteBackupStorageConfig_Handler,
+		},
 		{
 			MethodName: "DeleteCellInfo",
 			Handler:    _Vtctld_DeleteCellInfo_Handler,
@@ -1763,18 +4113,38 @@ var Vtctld_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteTablets",
 			Handler:    _Vtctld_DeleteTablets_Handler,
 		},
+		{
+			MethodName: "DrainTablet",
+			Handler:    _Vtctld_DrainTablet_Handler,
+		},
 		{
 			MethodName: "EmergencyReparentShard",
 			Handler:    _Vtctld_EmergencyReparentShard_Handler,
 		},
+		{
+			MethodName: "FenceShard",
+			Handler:    _Vtctld_FenceShard_Handler,
+		},
 		{
 			MethodName: "FindAllShardsInKeyspace",
 			Handler:    _Vtctld_FindAllShardsInKeyspace_Handler,
 		},
+		{
+			MethodName: "GetBackupSchedule",
+			Handler:    _Vtctld_GetBackupSchedule_Handler,
+		},
 		{
 			MethodName: "GetBackups",
 			Handler:    _Vtctld_GetBackups_Handler,
 		},
+		{
+			MethodName: "GetBackupStorageConfig",
+			Handler:    _Vtctld_GetBackupStorageConfig_Handler,
+		},
+		{
+			MethodName: "GetCDCSinks",
+			Handler:    _Vtctld_GetCDCSinks_Handler,
+		},
 		{
 			MethodName: "GetCellInfo",
 			Handler:    _Vtctld_GetCellInfo_Handler,
@@ -1783,10 +4153,22 @@ var Vtctld_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetCellInfoNames",
 			Handler:    _Vtctld_GetCellInfoNames_Handler,
 		},
+		{
+			MethodName: "GetCellServingOverride",
+			Handler:    _Vtctld_GetCellServingOverride_Handler,
+		},
 		{
 			MethodName: "GetCellsAliases",
 			Handler:    _Vtctld_GetCellsAliases_Handler,
 		},
+		{
+			MethodName: "GetGCTables",
+			Handler:    _Vtctld_GetGCTables_Handler,
+		},
+		{
+			MethodName: "GetKeyspaceBackupRuns",
+			Handler:    _Vtctld_GetKeyspaceBackupRuns_Handler,
+		},
 		{
 			MethodName: "GetKeyspace",
 			Handler:    _Vtctld_GetKeyspace_Handler,
@@ -1795,6 +4177,14 @@ var Vtctld_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetKeyspaces",
 			Handler:    _Vtctld_GetKeyspaces_Handler,
 		},
+		{
+			MethodName: "GetMySQLUsers",
+			Handler:    _Vtctld_GetMySQLUsers_Handler,
+		},
+		{
+			MethodName: "GetOnlineDDLProgress",
+			Handler:    _Vtctld_GetOnlineDDLProgress_Handler,
+		},
 		{
 			MethodName: "GetRoutingRules",
 			Handler:    _Vtctld_GetRoutingRules_Handler,
@@ -1811,6 +4201,22 @@ var Vtctld_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetSrvKeyspaces",
 			Handler:    _Vtctld_GetSrvKeyspaces_Handler,
 		},
+		{
+			MethodName: "GetShardFence",
+			Handler:    _Vtctld_GetShardFence_Handler,
+		},
+		{
+			MethodName: "GetShardReplicationGraph",
+			Handler:    _Vtctld_GetShardReplicationGraph_Handler,
+		},
+		{
+			MethodName: "GetShardServingTimeline",
+			Handler:    _Vtctld_GetShardServingTimeline_Handler,
+		},
+		{
+			MethodName: "GetSrvKeyspaceWithVersion",
+			Handler:    _Vtctld_GetSrvKeyspaceWithVersion_Handler,
+		},
 		{
 			MethodName: "GetSrvVSchema",
 			Handler:    _Vtctld_GetSrvVSchema_Handler,
@@ -1823,6 +4229,10 @@ var Vtctld_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetTablet",
 			Handler:    _Vtctld_GetTablet_Handler,
 		},
+		{
+			MethodName: "GetTabletHealthHistory",
+			Handler:    _Vtctld_GetTabletHealthHistory_Handler,
+		},
 		{
 			MethodName: "GetTablets",
 			Handler:    _Vtctld_GetTablets_Handler,
@@ -1831,6 +4241,14 @@ var Vtctld_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetVSchema",
 			Handler:    _Vtctld_GetVSchema_Handler,
 		},
+		{
+			MethodName: "GetWorkflowAuditLog",
+			Handler:    _Vtctld_GetWorkflowAuditLog_Handler,
+		},
+		{
+			MethodName: "GetWorkflowProgress",
+			Handler:    _Vtctld_GetWorkflowProgress_Handler,
+		},
 		{
 			MethodName: "GetWorkflows",
 			Handler:    _Vtctld_GetWorkflows_Handler,
@@ -1839,14 +4257,46 @@ var Vtctld_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "InitShardPrimary",
 			Handler:    _Vtctld_InitShardPrimary_Handler,
 		},
+		{
+			MethodName: "ListKeyspaceBackups",
+			Handler:    _Vtctld_ListKeyspaceBackups_Handler,
+		},
+		{
+			MethodName: "ListLocks",
+			Handler:    _Vtctld_ListLocks_Handler,
+		},
+		{
+			MethodName: "ListVDiffResults",
+			Handler:    _Vtctld_ListVDiffResults_Handler,
+		},
+		{
+			MethodName: "PlanReparentShard",
+			Handler:    _Vtctld_PlanReparentShard_Handler,
+		},
 		{
 			MethodName: "PlannedReparentShard",
 			Handler:    _Vtctld_PlannedReparentShard_Handler,
 		},
+		{
+			MethodName: "PreviewDeclarativeSchema",
+			Handler:    _Vtctld_PreviewDeclarativeSchema_Handler,
+		},
+		{
+			MethodName: "PruneBackups",
+			Handler:    _Vtctld_PruneBackups_Handler,
+		},
+		{
+			MethodName: "RebuildKeyspaceGraph",
+			Handler:    _Vtctld_RebuildKeyspaceGraph_Handler,
+		},
 		{
 			MethodName: "RebuildVSchemaGraph",
 			Handler:    _Vtctld_RebuildVSchemaGraph_Handler,
 		},
+		{
+			MethodName: "RecommendTabletTypeChange",
+			Handler:    _Vtctld_RecommendTabletTypeChange_Handler,
+		},
 		{
 			MethodName: "RefreshState",
 			Handler:    _Vtctld_RefreshState_Handler,
@@ -1855,6 +4305,14 @@ var Vtctld_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RefreshStateByShard",
 			Handler:    _Vtctld_RefreshStateByShard_Handler,
 		},
+		{
+			MethodName: "RejectMigration",
+			Handler:    _Vtctld_RejectMigration_Handler,
+		},
+		{
+			MethodName: "ReleaseLock",
+			Handler:    _Vtctld_ReleaseLock_Handler,
+		},
 		{
 			MethodName: "RemoveKeyspaceCell",
 			Handler:    _Vtctld_RemoveKeyspaceCell_Handler,
@@ -1867,14 +4325,70 @@ var Vtctld_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ReparentTablet",
 			Handler:    _Vtctld_ReparentTablet_Handler,
 		},
+		{
+			MethodName: "RestoreGCTable",
+			Handler:    _Vtctld_RestoreGCTable_Handler,
+		},
+		{
+			MethodName: "RestoreToPointInTime",
+			Handler:    _Vtctld_RestoreToPointInTime_Handler,
+		},
+		{
+			MethodName: "RevertMigration",
+			Handler:    _Vtctld_RevertMigration_Handler,
+		},
+		{
+			MethodName: "SeedTable",
+			Handler:    _Vtctld_SeedTable_Handler,
+		},
+		{
+			MethodName: "SetBackupSchedule",
+			Handler:    _Vtctld_SetBackupSchedule_Handler,
+		},
+		{
+			MethodName: "SetBackupStorageConfig",
+			Handler:    _Vtctld_SetBackupStorageConfig_Handler,
+		},
+		{
+			MethodName: "SetCDCSinks",
+			Handler:    _Vtctld_SetCDCSinks_Handler,
+		},
+		{
+			MethodName: "SetCellServingOverride",
+			Handler:    _Vtctld_SetCellServingOverride_Handler,
+		},
+		{
+			MethodName: "SetGCTablesRetention",
+			Handler:    _Vtctld_SetGCTablesRetention_Handler,
+		},
+		{
+			MethodName: "SetMySQLUsers",
+			Handler:    _Vtctld_SetMySQLUsers_Handler,
+		},
 		{
 			MethodName: "ShardReplicationPositions",
 			Handler:    _Vtctld_ShardReplicationPositions_Handler,
 		},
+		{
+			MethodName: "SplitReads",
+			Handler:    _Vtctld_SplitReads_Handler,
+		},
+		{
+			MethodName: "StartHostMaintenance",
+			Handler:    _Vtctld_StartHostMaintenance_Handler,
+		},
 		{
 			MethodName: "TabletExternallyReparented",
 			Handler:    _Vtctld_TabletExternallyReparented_Handler,
 		},
+		{
+			MethodName: "UndrainTablet",
+			Handler:    _Vtctld_UndrainTablet_Handler,
+		},
+		{
+			MethodName: "UnfenceShard",
+			Handler:    _Vtctld_UnfenceShard_Handler,
+		},
 		{
 			MethodName: "UpdateCellInfo",
 			Handler:    _Vtctld_UpdateCellInfo_Handler,
@@ -1883,6 +4397,46 @@ var Vtctld_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateCellsAlias",
 			Handler:    _Vtctld_UpdateCellsAlias_Handler,
 		},
+		{
+			MethodName: "UpdateSrvKeyspace",
+			Handler:    _Vtctld_UpdateSrvKeyspace_Handler,
+		},
+		{
+			MethodName: "UpdateWorkflowThrottling",
+			Handler:    _Vtctld_UpdateWorkflowThrottling_Handler,
+		},
+		{
+			MethodName: "ValidateConfig",
+			Handler:    _Vtctld_ValidateConfig_Handler,
+		},
+		{
+			MethodName: "ValidatePermissions",
+			Handler:    _Vtctld_ValidatePermissions_Handler,
+		},
+		{
+			MethodName: "ValidateRoutingRules",
+			Handler:    _Vtctld_ValidateRoutingRules_Handler,
+		},
+		{
+			MethodName: "ValidateSrvKeyspace",
+			Handler:    _Vtctld_ValidateSrvKeyspace_Handler,
+		},
+		{
+			MethodName: "ValidateVersion",
+			Handler:    _Vtctld_ValidateVersion_Handler,
+		},
+		{
+			MethodName: "VerifyReparentShard",
+			Handler:    _Vtctld_VerifyReparentShard_Handler,
+		},
+		{
+			MethodName: "VerifyReverseReplication",
+			Handler:    _Vtctld_VerifyReverseReplication_Handler,
+		},
+		{
+			MethodName: "WatchTopology",
+			Handler:    _Vtctld_WatchTopology_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "vtctlservice.proto",
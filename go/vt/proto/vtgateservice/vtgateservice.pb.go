@@ -44,7 +44,7 @@ var file_vtgateservice_proto_rawDesc = []byte{
 	0x0a, 0x13, 0x76, 0x74, 0x67, 0x61, 0x74, 0x65, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0d, 0x76, 0x74, 0x67, 0x61, 0x74, 0x65, 0x73, 0x65, 0x72,
 	0x76, 0x69, 0x63, 0x65, 0x1a, 0x0c, 0x76, 0x74, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x32, 0x8f, 0x04, 0x0a, 0x06, 0x56, 0x69, 0x74, 0x65, 0x73, 0x73, 0x12, 0x3c, 0x0a,
+	0x74, 0x6f, 0x32, 0xe5, 0x04, 0x0a, 0x06, 0x56, 0x69, 0x74, 0x65, 0x73, 0x73, 0x12, 0x3c, 0x0a,
 	0x07, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x12, 0x16, 0x2e, 0x76, 0x74, 0x67, 0x61, 0x74,
 	0x65, 0x2e, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
 	0x1a, 0x17, 0x2e, 0x76, 0x74, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74,
@@ -77,11 +77,17 @@ var file_vtgateservice_proto_rawDesc = []byte{
 	0x2e, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71,
 	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x76, 0x74, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x43, 0x6c,
 	0x6f, 0x73, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0x00, 0x42, 0x42, 0x0a, 0x14, 0x69, 0x6f, 0x2e, 0x76, 0x69, 0x74, 0x65, 0x73,
-	0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5a, 0x2a, 0x76, 0x69,
-	0x74, 0x65, 0x73, 0x73, 0x2e, 0x69, 0x6f, 0x2f, 0x76, 0x69, 0x74, 0x65, 0x73, 0x73, 0x2f, 0x67,
-	0x6f, 0x2f, 0x76, 0x74, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x76, 0x74, 0x67, 0x61, 0x74,
-	0x65, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x54, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x42, 0x75, 0x66, 0x66, 0x65,
+	0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1e, 0x2e, 0x76, 0x74, 0x67, 0x61, 0x74, 0x65,
+	0x2e, 0x47, 0x65, 0x74, 0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x76, 0x74, 0x67, 0x61, 0x74, 0x65,
+	0x2e, 0x47, 0x65, 0x74, 0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x42, 0x0a, 0x14, 0x69, 0x6f,
+	0x2e, 0x76, 0x69, 0x74, 0x65, 0x73, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x67, 0x72,
+	0x70, 0x63, 0x5a, 0x2a, 0x76, 0x69, 0x74, 0x65, 0x73, 0x73, 0x2e, 0x69, 0x6f, 0x2f, 0x76, 0x69,
+	0x74, 0x65, 0x73, 0x73, 0x2f, 0x67, 0x6f, 0x2f, 0x76, 0x74, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2f, 0x76, 0x74, 0x67, 0x61, 0x74, 0x65, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var file_vtgateservice_proto_goTypes = []interface{}{
@@ -92,13 +98,15 @@ var file_vtgateservice_proto_goTypes = []interface{}{
 	(*vtgate.VStreamRequest)(nil),             // 4: vtgate.VStreamRequest
 	(*vtgate.PrepareRequest)(nil),             // 5: vtgate.PrepareRequest
 	(*vtgate.CloseSessionRequest)(nil),        // 6: vtgate.CloseSessionRequest
-	(*vtgate.ExecuteResponse)(nil),            // 7: vtgate.ExecuteResponse
-	(*vtgate.ExecuteBatchResponse)(nil),       // 8: vtgate.ExecuteBatchResponse
-	(*vtgate.StreamExecuteResponse)(nil),      // 9: vtgate.StreamExecuteResponse
-	(*vtgate.ResolveTransactionResponse)(nil), // 10: vtgate.ResolveTransactionResponse
-	(*vtgate.VStreamResponse)(nil),            // 11: vtgate.VStreamResponse
-	(*vtgate.PrepareResponse)(nil),            // 12: vtgate.PrepareResponse
-	(*vtgate.CloseSessionResponse)(nil),       // 13: vtgate.CloseSessionResponse
+	(*vtgate.GetBufferStatusRequest)(nil),     // 7: vtgate.GetBufferStatusRequest
+	(*vtgate.ExecuteResponse)(nil),            // 8: vtgate.ExecuteResponse
+	(*vtgate.ExecuteBatchResponse)(nil),       // 9: vtgate.ExecuteBatchResponse
+	(*vtgate.StreamExecuteResponse)(nil),      // 10: vtgate.StreamExecuteResponse
+	(*vtgate.ResolveTransactionResponse)(nil), // 11: vtgate.ResolveTransactionResponse
+	(*vtgate.VStreamResponse)(nil),            // 12: vtgate.VStreamResponse
+	(*vtgate.PrepareResponse)(nil),            // 13: vtgate.PrepareResponse
+	(*vtgate.CloseSessionResponse)(nil),       // 14: vtgate.CloseSessionResponse
+	(*vtgate.GetBufferStatusResponse)(nil),    // 15: vtgate.GetBufferStatusResponse
 }
 var file_vtgateservice_proto_depIdxs = []int32{
 	0,  // 0: vtgateservice.Vitess.Execute:input_type -> vtgate.ExecuteRequest
@@ -108,15 +116,17 @@ var file_vtgateservice_proto_depIdxs = []int32{
 	4,  // 4: vtgateservice.Vitess.VStream:input_type -> vtgate.VStreamRequest
 	5,  // 5: vtgateservice.Vitess.Prepare:input_type -> vtgate.PrepareRequest
 	6,  // 6: vtgateservice.Vitess.CloseSession:input_type -> vtgate.CloseSessionRequest
-	7,  // 7: vtgateservice.Vitess.Execute:output_type -> vtgate.ExecuteResponse
-	8,  // 8: vtgateservice.Vitess.ExecuteBatch:output_type -> vtgate.ExecuteBatchResponse
-	9,  // 9: vtgateservice.Vitess.StreamExecute:output_type -> vtgate.StreamExecuteResponse
-	10, // 10: vtgateservice.Vitess.ResolveTransaction:output_type -> vtgate.ResolveTransactionResponse
-	11, // 11: vtgateservice.Vitess.VStream:output_type -> vtgate.VStreamResponse
-	12, // 12: vtgateservice.Vitess.Prepare:output_type -> vtgate.PrepareResponse
-	13, // 13: vtgateservice.Vitess.CloseSession:output_type -> vtgate.CloseSessionResponse
-	7,  // [7:14] is the sub-list for method output_type
-	0,  // [0:7] is the sub-list for method input_type
+	7,  // 7: vtgateservice.Vitess.GetBufferStatus:input_type -> vtgate.GetBufferStatusRequest
+	8,  // 8: vtgateservice.Vitess.Execute:output_type -> vtgate.ExecuteResponse
+	9,  // 9: vtgateservice.Vitess.ExecuteBatch:output_type -> vtgate.ExecuteBatchResponse
+	10, // 10: vtgateservice.Vitess.StreamExecute:output_type -> vtgate.StreamExecuteResponse
+	11, // 11: vtgateservice.Vitess.ResolveTransaction:output_type -> vtgate.ResolveTransactionResponse
+	12, // 12: vtgateservice.Vitess.VStream:output_type -> vtgate.VStreamResponse
+	13, // 13: vtgateservice.Vitess.Prepare:output_type -> vtgate.PrepareResponse
+	14, // 14: vtgateservice.Vitess.CloseSession:output_type -> vtgate.CloseSessionResponse
+	15, // 15: vtgateservice.Vitess.GetBufferStatus:output_type -> vtgate.GetBufferStatusResponse
+	8,  // [8:16] is the sub-list for method output_type
+	0,  // [0:8] is the sub-list for method input_type
 	0,  // [0:0] is the sub-list for extension type_name
 	0,  // [0:0] is the sub-list for extension extendee
 	0,  // [0:0] is the sub-list for field type_name
@@ -46,6 +46,10 @@ type VitessClient interface {
 	// This has the same effect as if a "rollback" statement was executed,
 	// but does not affect the query statistics.
 	CloseSession(ctx context.Context, in *vtgate.CloseSessionRequest, opts ...grpc.CallOption) (*vtgate.CloseSessionResponse, error)
+	// GetBufferStatus returns the state of the MASTER failover buffer, so that
+	// failover automation can wait for it to drain before declaring a
+	// reparent complete.
+	GetBufferStatus(ctx context.Context, in *vtgate.GetBufferStatusRequest, opts ...grpc.CallOption) (*vtgate.GetBufferStatusResponse, error)
 }
 
 type vitessClient struct {
@@ -165,6 +169,15 @@ func (c *vitessClient) CloseSession(ctx context.Context, in *vtgate.CloseSession
 	return out, nil
 }
 
+func (c *vitessClient) GetBufferStatus(ctx context.Context, in *vtgate.GetBufferStatusRequest, opts ...grpc.CallOption) (*vtgate.GetBufferStatusResponse, error) {
+	out := new(vtgate.GetBufferStatusResponse)
+	err := c.cc.Invoke(ctx, "/vtgateservice.Vitess/GetBufferStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // VitessServer is the server API for Vitess service.
 // All implementations must embed UnimplementedVitessServer
 // for forward compatibility
@@ -196,6 +209,10 @@ type VitessServer interface {
 	// This has the same effect as if a "rollback" statement was executed,
 	// but does not affect the query statistics.
 	CloseSession(context.Context, *vtgate.CloseSessionRequest) (*vtgate.CloseSessionResponse, error)
+	// GetBufferStatus returns the state of the MASTER failover buffer, so that
+	// failover automation can wait for it to drain before declaring a
+	// reparent complete.
+	GetBufferStatus(context.Context, *vtgate.GetBufferStatusRequest) (*vtgate.GetBufferStatusResponse, error)
 	mustEmbedUnimplementedVitessServer()
 }
 
@@ -224,6 +241,9 @@ func (UnimplementedVitessServer) Prepare(context.Context, *vtgate.PrepareRequest
 func (UnimplementedVitessServer) CloseSession(context.Context, *vtgate.CloseSessionRequest) (*vtgate.CloseSessionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CloseSession not implemented")
 }
+func (UnimplementedVitessServer) GetBufferStatus(context.Context, *vtgate.GetBufferStatusRequest) (*vtgate.GetBufferStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBufferStatus not implemented")
+}
 func (UnimplementedVitessServer) mustEmbedUnimplementedVitessServer() {}
 
 // UnsafeVitessServer may be embedded to opt out of forward compatibility for this service.
@@ -369,6 +389,24 @@ func _Vitess_CloseSession_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Vitess_GetBufferStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtgate.GetBufferStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VitessServer).GetBufferStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtgateservice.Vitess/GetBufferStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VitessServer).GetBufferStatus(ctx, req.(*vtgate.GetBufferStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Vitess_ServiceDesc is the grpc.ServiceDesc for Vitess service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -396,6 +434,10 @@ var Vitess_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CloseSession",
 			Handler:    _Vitess_CloseSession_Handler,
 		},
+		{
+			MethodName: "GetBufferStatus",
+			Handler:    _Vitess_GetBufferStatus_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -19,6 +19,8 @@ package schema
 import (
 	"fmt"
 	"regexp"
+	"strings"
+	"time"
 
 	"github.com/google/shlex"
 )
@@ -33,6 +35,8 @@ const (
 	singletonFlag         = "singleton"
 	singletonContextFlag  = "singleton-context"
 	vreplicationTestSuite = "vreplication-test-suite"
+	retainArtifactsFlag   = "retain-artifacts"
+	requireApprovalFlag   = "require-approval"
 )
 
 // DDLStrategy suggests how an ALTER TABLE should run (e.g. "direct", "online", "gh-ost" or "pt-osc")
@@ -90,6 +94,9 @@ func ParseDDLStrategy(strategyVariable string) (*DDLStrategySetting, error) {
 	default:
 		return nil, fmt.Errorf("Unknown online DDL strategy: '%v'", strategy)
 	}
+	if _, err := setting.RetainArtifactsDuration(); err != nil {
+		return nil, err
+	}
 	return setting, nil
 }
 
@@ -104,6 +111,23 @@ func isFlag(s string, name string) bool {
 	return false
 }
 
+// flagValue returns the value of the given string when it is a CLI flag of
+// the given name with a value, e.g. -name=value
+func flagValue(s string, name string) (value string, ok bool) {
+	for _, prefix := range []string{fmt.Sprintf("-%s=", name), fmt.Sprintf("--%s=", name)} {
+		if strings.HasPrefix(s, prefix) {
+			return strings.TrimPrefix(s, prefix), true
+		}
+	}
+	return "", false
+}
+
+// isFlagWithValue returns true when the given string is a CLI flag of the given name with a value
+func isFlagWithValue(s string, name string) bool {
+	_, ok := flagValue(s, name)
+	return ok
+}
+
 // hasFlag returns true when Options include named flag
 func (setting *DDLStrategySetting) hasFlag(name string) bool {
 	opts, _ := shlex.Split(setting.Options)
@@ -135,6 +159,31 @@ func (setting *DDLStrategySetting) IsVreplicationTestSuite() bool {
 	return setting.hasFlag(vreplicationTestSuite)
 }
 
+// IsRequireApproval checks if strategy options include -require-approval: the migration
+// then waits in pending-approval status until it's approved or rejected
+func (setting *DDLStrategySetting) IsRequireApproval() bool {
+	return setting.hasFlag(requireApprovalFlag)
+}
+
+// RetainArtifactsDuration returns the duration of -retain-artifacts=<duration>, for which the
+// artifacts of the migration, such as the table before an ALTER, are kept after it completes.
+// The migration can be reverted as long as they're kept. It returns zero when the option isn't set.
+func (setting *DDLStrategySetting) RetainArtifactsDuration() (time.Duration, error) {
+	opts, _ := shlex.Split(setting.Options)
+	for _, opt := range opts {
+		value, ok := flagValue(opt, retainArtifactsFlag)
+		if !ok {
+			continue
+		}
+		d, err := time.ParseDuration(value)
+		if err != nil || d <= 0 {
+			return 0, fmt.Errorf("invalid -%s duration: '%s'", retainArtifactsFlag, value)
+		}
+		return d, nil
+	}
+	return 0, nil
+}
+
 // RuntimeOptions returns the options used as runtime flags for given strategy, removing any internal hint options
 func (setting *DDLStrategySetting) RuntimeOptions() []string {
 	opts, _ := shlex.Split(setting.Options)
@@ -146,6 +195,8 @@ func (setting *DDLStrategySetting) RuntimeOptions() []string {
 		case isFlag(opt, singletonFlag):
 		case isFlag(opt, singletonContextFlag):
 		case isFlag(opt, vreplicationTestSuite):
+		case isFlagWithValue(opt, retainArtifactsFlag):
+		case isFlag(opt, requireApprovalFlag):
 		default:
 			validOpts = append(validOpts, opt)
 		}
@@ -19,6 +19,7 @@ package schema
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -41,6 +42,8 @@ func TestParseDDLStrategy(t *testing.T) {
 		options          string
 		isDeclarative    bool
 		isSingleton      bool
+		requireApproval  bool
+		retainArtifacts  time.Duration
 		runtimeOptions   string
 		err              error
 	}{
@@ -90,6 +93,28 @@ func TestParseDDLStrategy(t *testing.T) {
 			runtimeOptions:   "",
 			isSingleton:      true,
 		},
+		{
+			strategyVariable: "online -retain-artifacts=72h -singleton",
+			strategy:         DDLStrategyOnline,
+			options:          "-retain-artifacts=72h -singleton",
+			runtimeOptions:   "",
+			isSingleton:      true,
+			retainArtifacts:  72 * time.Hour,
+		},
+		{
+			strategyVariable: "gh-ost --retain-artifacts=90m --max-load=Threads_running=100",
+			strategy:         DDLStrategyGhost,
+			options:          "--retain-artifacts=90m --max-load=Threads_running=100",
+			runtimeOptions:   "--max-load=Threads_running=100",
+			retainArtifacts:  90 * time.Minute,
+		},
+		{
+			strategyVariable: "online -require-approval",
+			strategy:         DDLStrategyOnline,
+			options:          "-require-approval",
+			runtimeOptions:   "",
+			requireApproval:  true,
+		},
 	}
 	for _, ts := range tt {
 		setting, err := ParseDDLStrategy(ts.strategyVariable)
@@ -98,6 +123,10 @@ func TestParseDDLStrategy(t *testing.T) {
 		assert.Equal(t, ts.options, setting.Options)
 		assert.Equal(t, ts.isDeclarative, setting.IsDeclarative())
 		assert.Equal(t, ts.isSingleton, setting.IsSingleton())
+		assert.Equal(t, ts.requireApproval, setting.IsRequireApproval())
+		retainArtifacts, err := setting.RetainArtifactsDuration()
+		assert.NoError(t, err)
+		assert.Equal(t, ts.retainArtifacts, retainArtifacts)
 
 		runtimeOptions := strings.Join(setting.RuntimeOptions(), " ")
 		assert.Equal(t, ts.runtimeOptions, runtimeOptions)
@@ -106,4 +135,8 @@ func TestParseDDLStrategy(t *testing.T) {
 		_, err := ParseDDLStrategy("other")
 		assert.Error(t, err)
 	}
+	for _, strategyVariable := range []string{"online -retain-artifacts=forever", "online -retain-artifacts=0s", "online -retain-artifacts=-1h"} {
+		_, err := ParseDDLStrategy(strategyVariable)
+		assert.Error(t, err, strategyVariable)
+	}
 }
@@ -100,13 +100,14 @@ func MigrationJobsKeyspaceShardPath(keyspace, shard string) string {
 type OnlineDDLStatus string
 
 const (
-	OnlineDDLStatusRequested OnlineDDLStatus = "requested"
-	OnlineDDLStatusCancelled OnlineDDLStatus = "cancelled"
-	OnlineDDLStatusQueued    OnlineDDLStatus = "queued"
-	OnlineDDLStatusReady     OnlineDDLStatus = "ready"
-	OnlineDDLStatusRunning   OnlineDDLStatus = "running"
-	OnlineDDLStatusComplete  OnlineDDLStatus = "complete"
-	OnlineDDLStatusFailed    OnlineDDLStatus = "failed"
+	OnlineDDLStatusRequested       OnlineDDLStatus = "requested"
+	OnlineDDLStatusCancelled       OnlineDDLStatus = "cancelled"
+	OnlineDDLStatusPendingApproval OnlineDDLStatus = "pending-approval"
+	OnlineDDLStatusQueued          OnlineDDLStatus = "queued"
+	OnlineDDLStatusReady           OnlineDDLStatus = "ready"
+	OnlineDDLStatusRunning         OnlineDDLStatus = "running"
+	OnlineDDLStatusComplete        OnlineDDLStatus = "complete"
+	OnlineDDLStatusFailed          OnlineDDLStatus = "failed"
 )
 
 // OnlineDDL encapsulates the relevant information in an online schema change request
@@ -416,6 +417,33 @@ func (onlineDDL *OnlineDDL) GetRevertUUID() (uuid string, err error) {
 	return "", vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "not a Revert DDL: '%s'", onlineDDL.SQL)
 }
 
+// IsPartitionMaintenance returns true if the migration only adds or drops
+// partitions of a table. MySQL runs these operations on the partitions
+// themselves, so they are executed directly instead of copying the table.
+func (onlineDDL *OnlineDDL) IsPartitionMaintenance() bool {
+	stmt, err := sqlparser.Parse(onlineDDL.SQL)
+	if err != nil {
+		return false
+	}
+	alterTable, ok := stmt.(*sqlparser.AlterTable)
+	if !ok || alterTable.PartitionSpec == nil {
+		return false
+	}
+	switch alterTable.PartitionSpec.Action {
+	case sqlparser.AddAction, sqlparser.DropAction:
+	default:
+		return false
+	}
+	for _, option := range alterTable.AlterOptions {
+		switch option.(type) {
+		case sqlparser.AlgorithmValue, *sqlparser.LockOption:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
 // ToString returns a simple string representation of this instance
 func (onlineDDL *OnlineDDL) ToString() string {
 	return fmt.Sprintf("OnlineDDL: keyspace=%s, table=%s, sql=%s", onlineDDL.Keyspace, onlineDDL.Table, onlineDDL.SQL)
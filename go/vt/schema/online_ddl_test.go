@@ -175,6 +175,45 @@ func TestGetRevertUUID(t *testing.T) {
 	}
 }
 
+func TestIsPartitionMaintenance(t *testing.T) {
+	tt := []struct {
+		statement string
+		expect    bool
+	}{
+		{
+			statement: "alter table t add partition (partition p3 values less than (30))",
+			expect:    true,
+		},
+		{
+			statement: "alter table t algorithm = inplace, lock none, add partition (partition p3 values less than (30))",
+			expect:    true,
+		},
+		{
+			statement: "alter table t drop partition p1, p2",
+			expect:    true,
+		},
+		{
+			statement: "alter table t reorganize partition p3 into (partition p3 values less than (25), partition p4 values less than (30))",
+		},
+		{
+			statement: "alter table t truncate partition p1",
+		},
+		{
+			statement: "alter table t drop column c",
+		},
+		{
+			statement: "create table t (id int primary key)",
+		},
+	}
+	for _, ts := range tt {
+		t.Run(ts.statement, func(t *testing.T) {
+			onlineDDL, err := NewOnlineDDL("test_ks", "t", ts.statement, NewDDLStrategySetting(DDLStrategyOnline, ""), "")
+			require.NoError(t, err)
+			assert.Equal(t, ts.expect, onlineDDL.IsPartitionMaintenance())
+		})
+	}
+}
+
 func TestNewOnlineDDL(t *testing.T) {
 	migrationContext := "354b-11eb-82cd-f875a4d24e90"
 	tt := []struct {
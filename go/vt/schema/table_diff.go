@@ -0,0 +1,284 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+)
+
+// integerTypes are the column types whose display width MySQL adds to
+// SHOW CREATE TABLE.
+var integerTypes = map[string]bool{
+	"tinyint":   true,
+	"smallint":  true,
+	"mediumint": true,
+	"int":       true,
+	"integer":   true,
+	"bigint":    true,
+}
+
+// DiffCreateTable returns the ALTER TABLE statement which turns the table
+// created by current into the table created by desired, or nil if they're
+// equivalent. current is typically the SHOW CREATE TABLE of a tablet, so
+// the comparison ignores what MySQL adds to it: the display widths of the
+// integers, the DEFAULT NULL of the nullable columns, the charsets,
+// collations and table options desired doesn't specify, and the
+// AUTO_INCREMENT counter.
+// The new columns are added after their preceding column, or last for the
+// first column, but the existing columns aren't reordered. A renamed column
+// is dropped and added.
+func DiffCreateTable(current, desired *sqlparser.CreateTable) (*sqlparser.AlterTable, error) {
+	tableName := desired.Table.Name.String()
+	if current.TableSpec == nil || desired.TableSpec == nil {
+		return nil, fmt.Errorf("cannot compare the CREATE TABLE ... LIKE statements of table %v", tableName)
+	}
+	for _, spec := range []*sqlparser.TableSpec{current.TableSpec, desired.TableSpec} {
+		var noKey sqlparser.ColumnKeyOption
+		for _, column := range spec.Columns {
+			if column.Type.Options != nil && column.Type.Options.KeyOpt != noKey {
+				return nil, fmt.Errorf("the key of column %v of table %v must be declared as an index of the table", column.Name.String(), tableName)
+			}
+		}
+	}
+	if canonicalConstraints(current.TableSpec) != canonicalConstraints(desired.TableSpec) {
+		return nil, fmt.Errorf("cannot compare the constraints of table %v, they must be altered explicitly", tableName)
+	}
+
+	alter := &sqlparser.AlterTable{
+		Table: sqlparser.TableName{Name: desired.Table.Name},
+	}
+
+	// The keys are dropped first, s.t. they don't refer to the dropped
+	// columns, and added last, s.t. they can refer to the added columns.
+	currentIndexNames, currentIndexes := indexesByName(current.TableSpec)
+	desiredIndexNames, desiredIndexes := indexesByName(desired.TableSpec)
+	for _, name := range currentIndexNames {
+		desiredIndex, ok := desiredIndexes[name]
+		if ok && canonicalIndex(desiredIndex) == canonicalIndex(currentIndexes[name]) {
+			continue
+		}
+		if currentIndexes[name].Info.Primary {
+			alter.AlterOptions = append(alter.AlterOptions, &sqlparser.DropKey{Type: sqlparser.PrimaryKeyType})
+		} else {
+			alter.AlterOptions = append(alter.AlterOptions, &sqlparser.DropKey{Type: sqlparser.NormalKeyType, Name: currentIndexes[name].Info.Name})
+		}
+	}
+
+	currentPK := primaryKeyColumns(current.TableSpec)
+	desiredPK := primaryKeyColumns(desired.TableSpec)
+	currentColumns := make(map[string]*sqlparser.ColumnDefinition)
+	for _, column := range current.TableSpec.Columns {
+		currentColumns[column.Name.Lowered()] = column
+	}
+	desiredColumns := make(map[string]bool)
+	for _, column := range desired.TableSpec.Columns {
+		desiredColumns[column.Name.Lowered()] = true
+	}
+	for _, column := range current.TableSpec.Columns {
+		if !desiredColumns[column.Name.Lowered()] {
+			alter.AlterOptions = append(alter.AlterOptions, &sqlparser.DropColumn{Name: &sqlparser.ColName{Name: column.Name}})
+		}
+	}
+	for i, column := range desired.TableSpec.Columns {
+		currentColumn, ok := currentColumns[column.Name.Lowered()]
+		if !ok {
+			addColumn := &sqlparser.AddColumns{Columns: []*sqlparser.ColumnDefinition{column}}
+			if i > 0 {
+				addColumn.After = &sqlparser.ColName{Name: desired.TableSpec.Columns[i-1].Name}
+			}
+			alter.AlterOptions = append(alter.AlterOptions, addColumn)
+			continue
+		}
+		if canonicalColumn(currentColumn, column, currentPK) != canonicalColumn(column, column, desiredPK) {
+			alter.AlterOptions = append(alter.AlterOptions, &sqlparser.ModifyColumn{NewColDefinition: column})
+		}
+	}
+
+	for _, name := range desiredIndexNames {
+		currentIndex, ok := currentIndexes[name]
+		if ok && canonicalIndex(currentIndex) == canonicalIndex(desiredIndexes[name]) {
+			continue
+		}
+		alter.AlterOptions = append(alter.AlterOptions, &sqlparser.AddIndexDefinition{IndexDefinition: desiredIndexes[name]})
+	}
+
+	if options := diffTableOptions(current.TableSpec.Options, desired.TableSpec.Options); len(options) > 0 {
+		alter.AlterOptions = append(alter.AlterOptions, options)
+	}
+
+	if len(alter.AlterOptions) == 0 {
+		return nil, nil
+	}
+	return alter, nil
+}
+
+// canonicalConstraints returns the sorted constraints of the table.
+func canonicalConstraints(spec *sqlparser.TableSpec) string {
+	var constraints []string
+	for _, constraint := range spec.Constraints {
+		constraints = append(constraints, strings.ToLower(sqlparser.String(constraint)))
+	}
+	sort.Strings(constraints)
+	return strings.Join(constraints, ",")
+}
+
+// primaryKeyColumns returns the lowered names of the columns of the
+// primary key of the table.
+func primaryKeyColumns(spec *sqlparser.TableSpec) map[string]bool {
+	columns := make(map[string]bool)
+	for _, index := range spec.Indexes {
+		if !index.Info.Primary {
+			continue
+		}
+		for _, column := range index.Columns {
+			columns[column.Column.Lowered()] = true
+		}
+	}
+	return columns
+}
+
+// canonicalColumn formats the column the way MySQL shows it. The charset
+// and collation of the column are only compared if reference specifies
+// them.
+func canonicalColumn(column, reference *sqlparser.ColumnDefinition, primaryKey map[string]bool) string {
+	columnType := column.Type
+	options := sqlparser.ColumnTypeOptions{}
+	if columnType.Options != nil {
+		options = *columnType.Options
+	}
+	columnType.Options = &options
+
+	columnType.Type = strings.ToLower(columnType.Type)
+	if columnType.Type == "integer" {
+		columnType.Type = "int"
+	}
+	if integerTypes[columnType.Type] && !columnType.Zerofill {
+		columnType.Length = nil
+	}
+	if reference.Type.Charset == "" {
+		columnType.Charset = ""
+	}
+	if reference.Type.Collate == "" {
+		columnType.Collate = ""
+	}
+	// The columns of the primary key are NOT NULL, the other ones are
+	// nullable unless specified.
+	null := !primaryKey[column.Name.Lowered()] && (options.Null == nil || *options.Null)
+	options.Null = &null
+	switch value := options.Default.(type) {
+	case *sqlparser.NullVal:
+		options.Default = nil
+	case *sqlparser.Literal:
+		// MySQL quotes the numeric defaults.
+		options.Default = sqlparser.NewStrLiteral(value.Val)
+	}
+
+	return sqlparser.String(&sqlparser.ColumnDefinition{Name: column.Name, Type: columnType})
+}
+
+// indexesByName returns the names of the indexes of the table in their
+// order, and the indexes by name. As in MySQL, the primary key is named
+// PRIMARY, and an unnamed index is named after its first column.
+func indexesByName(spec *sqlparser.TableSpec) ([]string, map[string]*sqlparser.IndexDefinition) {
+	var names []string
+	indexes := make(map[string]*sqlparser.IndexDefinition)
+	for _, index := range spec.Indexes {
+		name := index.Info.Name.String()
+		switch {
+		case index.Info.Primary:
+			name = "PRIMARY"
+		case name == "" && len(index.Columns) > 0:
+			name = index.Columns[0].Column.String()
+			for i := 2; indexes[strings.ToLower(name)] != nil; i++ {
+				name = fmt.Sprintf("%v_%v", index.Columns[0].Column.String(), i)
+			}
+			// The name is added, s.t. the index is added with it.
+			info := *index.Info
+			info.Name = sqlparser.NewColIdent(name)
+			index = &sqlparser.IndexDefinition{Info: &info, Columns: index.Columns, Options: index.Options}
+		}
+		names = append(names, strings.ToLower(name))
+		indexes[strings.ToLower(name)] = index
+	}
+	return names, indexes
+}
+
+// canonicalIndex formats the index the way MySQL shows it.
+func canonicalIndex(index *sqlparser.IndexDefinition) string {
+	info := *index.Info
+	info.ConstraintName = sqlparser.ColIdent{}
+	switch {
+	case info.Primary:
+		info.Type = "primary key"
+	case info.Unique:
+		info.Type = "unique key"
+	case info.Fulltext:
+		info.Type = "fulltext key"
+	case info.Spatial:
+		info.Type = "spatial key"
+	default:
+		info.Type = "key"
+	}
+	return strings.ToLower(sqlparser.String(&sqlparser.IndexDefinition{Info: &info, Columns: index.Columns, Options: index.Options}))
+}
+
+// tableOptionKey returns the name of a table option, with its synonyms
+// mapped to the same name.
+func tableOptionKey(option *sqlparser.TableOption) string {
+	name := strings.TrimPrefix(strings.ToLower(option.Name), "default ")
+	switch name {
+	case "character set", "charset":
+		return "charset"
+	}
+	return name
+}
+
+// tableOptionValue returns the canonical value of a table option.
+func tableOptionValue(option *sqlparser.TableOption) string {
+	switch {
+	case option.String != "":
+		return strings.Trim(strings.ToLower(option.String), "'\"")
+	case option.Value != nil:
+		return strings.ToLower(option.Value.Val)
+	}
+	return strings.ToLower(sqlparser.String(option.Tables))
+}
+
+// diffTableOptions returns the options of desired which current doesn't
+// have with the same value. The AUTO_INCREMENT counter is ignored.
+func diffTableOptions(current, desired sqlparser.TableOptions) sqlparser.TableOptions {
+	currentValues := make(map[string]string)
+	for _, option := range current {
+		currentValues[tableOptionKey(option)] = tableOptionValue(option)
+	}
+	var options sqlparser.TableOptions
+	for _, option := range desired {
+		key := tableOptionKey(option)
+		if key == "auto_increment" {
+			continue
+		}
+		if value, ok := currentValues[key]; ok && value == tableOptionValue(option) {
+			continue
+		}
+		options = append(options, option)
+	}
+	return options
+}
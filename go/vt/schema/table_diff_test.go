@@ -0,0 +1,88 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+)
+
+func parseCreateTable(t *testing.T, sql string) *sqlparser.CreateTable {
+	stmt, err := sqlparser.Parse(sql)
+	require.NoError(t, err)
+	create, ok := stmt.(*sqlparser.CreateTable)
+	require.True(t, ok, sql)
+	return create
+}
+
+func TestDiffCreateTable(t *testing.T) {
+	tcases := []struct {
+		current string
+		desired string
+		alter   string
+	}{{
+		// What MySQL adds to SHOW CREATE TABLE isn't a difference.
+		current: "CREATE TABLE `t1` (`id` int(11) NOT NULL AUTO_INCREMENT, `name` varchar(64) COLLATE utf8mb4_bin DEFAULT NULL, `n` int(11) DEFAULT '0', PRIMARY KEY (`id`), KEY `name_idx` (`name`)) ENGINE=InnoDB AUTO_INCREMENT=12 DEFAULT CHARSET=utf8mb4",
+		desired: "create table t1 (id int auto_increment, name varchar(64), n int default 0, primary key (id), key name_idx (name)) engine=innodb",
+	}, {
+		current: "create table t1 (id int, a int, b varchar(10), c int, primary key (id), key a_idx (a), key c (c))",
+		desired: "create table t1 (id int, a bigint, b varchar(10), d int, primary key (id), key a_idx (a, b), key (d)) comment 'x'",
+		alter:   "alter table t1 drop key a_idx, drop key c, drop column c, modify column a bigint, add column d int after b, add key a_idx (a, b), add key d (d), comment 'x'",
+	}, {
+		current: "create table t1 (id int, primary key (id))",
+		desired: "create table t1 (id int, id2 int, primary key (id, id2))",
+		alter:   "alter table t1 drop primary key, add column id2 int after id, add primary key (id, id2)",
+	}}
+	for _, tcase := range tcases {
+		alter, err := DiffCreateTable(parseCreateTable(t, tcase.current), parseCreateTable(t, tcase.desired))
+		require.NoError(t, err, tcase.desired)
+		if tcase.alter == "" {
+			assert.Nil(t, alter, tcase.desired)
+			continue
+		}
+		require.NotNil(t, alter, tcase.desired)
+		assert.Equal(t, tcase.alter, sqlparser.String(alter))
+	}
+}
+
+func TestDiffCreateTableErrors(t *testing.T) {
+	tcases := []struct {
+		current string
+		desired string
+		err     string
+	}{{
+		current: "create table t1 (id int)",
+		desired: "create table t1 like t2",
+		err:     "cannot compare the CREATE TABLE ... LIKE statements of table t1",
+	}, {
+		current: "create table t1 (id int, primary key (id))",
+		desired: "create table t1 (id int primary key)",
+		err:     "the key of column id of table t1 must be declared as an index of the table",
+	}, {
+		current: "create table t1 (id int, p int, primary key (id))",
+		desired: "create table t1 (id int, p int, primary key (id), constraint fk foreign key (p) references t2 (id))",
+		err:     "cannot compare the constraints of table t1, they must be altered explicitly",
+	}}
+	for _, tcase := range tcases {
+		_, err := DiffCreateTable(parseCreateTable(t, tcase.current), parseCreateTable(t, tcase.desired))
+		assert.EqualError(t, err, tcase.err, tcase.desired)
+	}
+}
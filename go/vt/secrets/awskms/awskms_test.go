@@ -0,0 +1,80 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awskms
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/secrets"
+)
+
+// fakeKMS "decrypts" by reversing the ciphertext.
+type fakeKMS struct {
+	kmsiface.KMSAPI
+	err error
+}
+
+func (f *fakeKMS) DecryptWithContext(ctx aws.Context, in *kms.DecryptInput, opts ...request.Option) (*kms.DecryptOutput, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	out := make([]byte, len(in.CiphertextBlob))
+	for i, b := range in.CiphertextBlob {
+		out[len(out)-1-i] = b
+	}
+	return &kms.DecryptOutput{Plaintext: out}, nil
+}
+
+func TestResolve(t *testing.T) {
+	dir, err := ioutil.TempDir("", "awskms")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	rawFile := path.Join(dir, "raw")
+	require.NoError(t, ioutil.WriteFile(rawFile, []byte("terces"), 0600))
+	b64File := path.Join(dir, "b64")
+	require.NoError(t, ioutil.WriteFile(b64File, []byte(base64.StdEncoding.EncodeToString([]byte("}\"terces\":\"drowssap\"{"))+"\n"), 0600))
+
+	r := &Resolver{_client: &fakeKMS{}}
+	got, err := r.Resolve(context.Background(), &secrets.Ref{Resolver: "awskms", Path: rawFile})
+	require.NoError(t, err)
+	assert.Equal(t, "secret", string(got))
+
+	got, err = r.Resolve(context.Background(), &secrets.Ref{Resolver: "awskms", Path: b64File, Key: "password"})
+	require.NoError(t, err)
+	assert.Equal(t, `{"password":"secret"}`, string(got))
+
+	_, err = r.Resolve(context.Background(), &secrets.Ref{Resolver: "awskms", Path: path.Join(dir, "missing")})
+	assert.Error(t, err)
+
+	r = &Resolver{_client: &fakeKMS{err: errors.New("access denied")}}
+	_, err = r.Resolve(context.Background(), &secrets.Ref{Resolver: "awskms", Path: rawFile})
+	assert.EqualError(t, err, "kms decrypt of "+rawFile+" failed: access denied")
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secrets
+
+// This file contains the built-in resolvers. Other backends (Vault, cloud
+// KMS, ...) are meant to be linked in as plugins calling RegisterResolver.
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// envResolver reads a secret from an environment variable:
+// secret://env/MYSQL_PASSWORD
+func envResolver(ctx context.Context, ref *Ref) ([]byte, error) {
+	value, ok := os.LookupEnv(ref.Path)
+	if !ok {
+		return nil, fmt.Errorf("environment variable %v is not set", ref.Path)
+	}
+	return []byte(value), nil
+}
+
+// fileResolver reads a secret from a file. The path is relative to the
+// current directory unless it starts with a '/':
+// secret://file//etc/vitess/creds.json#password
+func fileResolver(ctx context.Context, ref *Ref) ([]byte, error) {
+	return ioutil.ReadFile(ref.Path)
+}
+
+// execResolver runs a command and uses its standard output as the secret.
+// The path is split on spaces into the command and its arguments:
+// secret://exec/get-secret --name db
+func execResolver(ctx context.Context, ref *Ref) ([]byte, error) {
+	args := strings.Fields(ref.Path)
+	if len(args) == 0 {
+		return nil, fmt.Errorf("empty command")
+	}
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("command %v failed: %v", args[0], err)
+	}
+	return out, nil
+}
+
+func init() {
+	RegisterResolver("env", ResolverFunc(envResolver))
+	RegisterResolver("file", ResolverFunc(fileResolver))
+	RegisterResolver("exec", ResolverFunc(execResolver))
+}
@@ -0,0 +1,333 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package secrets provides a pluggable way to resolve credentials that are
+// passed to Vitess components through flags or configuration files.
+//
+// Any credential value may be given as a secret reference of the form:
+//
+//	secret://<resolver>/<path>[#<key>]
+//
+// in which case the named Resolver is asked for the actual value. When a key
+// is given, the resolved value is parsed as a JSON object and the value of
+// that key is returned. Values that are not secret references are returned
+// unchanged, so existing plain-text configurations keep working.
+//
+// Resolved values are cached for -secrets_cache_ttl. Components that hold on
+// to a credential (for instance, an open topo connection) can register a
+// callback with OnRotate to be notified when the underlying value changes.
+// Binaries can call HandleSIGHUP so that sending SIGHUP to the process drops
+// the cache and refreshes the watched references.
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"vitess.io/vitess/go/vt/log"
+)
+
+// Scheme is the prefix identifying a value as a secret reference.
+const Scheme = "secret://"
+
+var (
+	cacheTTL        = flag.Duration("secrets_cache_ttl", 5*time.Minute, "How long resolved secret:// references are cached before being resolved again")
+	refreshInterval = flag.Duration("secrets_refresh_interval", time.Minute, "How often secret:// references with rotation callbacks are re-resolved to detect rotation")
+	resolveTimeout  = flag.Duration("secrets_resolve_timeout", 10*time.Second, "Timeout for a single secret resolution")
+)
+
+// Ref is a parsed secret reference.
+type Ref struct {
+	// Resolver is the name of the Resolver to use.
+	Resolver string
+	// Path is the resolver-specific location of the secret.
+	Path string
+	// Key, if set, selects a single field of a JSON-encoded secret.
+	Key string
+}
+
+// String returns the reference in its secret:// form.
+func (r *Ref) String() string {
+	s := Scheme + r.Resolver + "/" + r.Path
+	if r.Key != "" {
+		s += "#" + r.Key
+	}
+	return s
+}
+
+// IsRef returns true if value is a secret reference.
+func IsRef(value string) bool {
+	return strings.HasPrefix(value, Scheme)
+}
+
+// ParseRef parses a secret reference.
+func ParseRef(value string) (*Ref, error) {
+	if !IsRef(value) {
+		return nil, fmt.Errorf("not a secret reference: missing %v prefix", Scheme)
+	}
+	rest := strings.TrimPrefix(value, Scheme)
+	ref := &Ref{}
+	if i := strings.LastIndex(rest, "#"); i >= 0 {
+		ref.Key = rest[i+1:]
+		rest = rest[:i]
+	}
+	parts := strings.SplitN(rest, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("invalid secret reference %v: expected %v<resolver>/<path>[#<key>]", value, Scheme)
+	}
+	ref.Resolver = parts[0]
+	ref.Path = parts[1]
+	return ref, nil
+}
+
+// Resolver is the interface implemented by secret backends. Implementations
+// must be safe for concurrent use.
+type Resolver interface {
+	// Resolve returns the raw secret stored at ref.Path. Key extraction
+	// is handled by the caller.
+	Resolve(ctx context.Context, ref *Ref) ([]byte, error)
+}
+
+// ResolverFunc adapts a function to the Resolver interface.
+type ResolverFunc func(ctx context.Context, ref *Ref) ([]byte, error)
+
+// Resolve is part of the Resolver interface.
+func (f ResolverFunc) Resolve(ctx context.Context, ref *Ref) ([]byte, error) {
+	return f(ctx, ref)
+}
+
+type cacheEntry struct {
+	value   string
+	expires time.Time
+}
+
+var (
+	mu        sync.Mutex
+	resolvers = make(map[string]Resolver)
+	cache     = make(map[string]*cacheEntry)
+	callbacks = make(map[string][]*rotateCallback)
+	// lastSeen holds the last resolved value of every reference, it is
+	// not affected by FlushCache so rotations can still be detected.
+	lastSeen = make(map[string]string)
+
+	watcherOnce sync.Once
+)
+
+// RegisterResolver registers a Resolver under the given name.
+// If a Resolver with that name already exists, it log.Fatals out.
+// Call this in the 'init' function of your plugin.
+func RegisterResolver(name string, resolver Resolver) {
+	mu.Lock()
+	defer mu.Unlock()
+	if resolvers[name] != nil {
+		log.Fatalf("Duplicate secrets.Resolver registration for %v", name)
+	}
+	resolvers[name] = resolver
+}
+
+// Resolve returns the value of a credential. If value is a secret reference,
+// it is resolved (or served from cache), otherwise value is returned as is.
+func Resolve(ctx context.Context, value string) (string, error) {
+	if !IsRef(value) {
+		return value, nil
+	}
+
+	mu.Lock()
+	if e, ok := cache[value]; ok && time.Now().Before(e.expires) {
+		mu.Unlock()
+		return e.value, nil
+	}
+	mu.Unlock()
+
+	resolved, err := resolve(ctx, value)
+	if err != nil {
+		return "", err
+	}
+
+	store(value, resolved)
+	return resolved, nil
+}
+
+// ResolveTimeout returns the timeout of a single secret resolution, see
+// -secrets_resolve_timeout.
+func ResolveTimeout() time.Duration {
+	return *resolveTimeout
+}
+
+// MustResolve is like Resolve, but exits the process on error. It is meant
+// to be used while processing flags at startup.
+func MustResolve(value string) string {
+	ctx, cancel := context.WithTimeout(context.Background(), *resolveTimeout)
+	defer cancel()
+	resolved, err := Resolve(ctx, value)
+	if err != nil {
+		log.Exitf("cannot resolve secret: %v", err)
+	}
+	return resolved
+}
+
+// rotateCallback wraps a callback registered with OnRotate, so it can be
+// told apart from the other callbacks of the same value when unregistered.
+type rotateCallback struct {
+	fn func(newValue string)
+}
+
+// OnRotate registers a callback that is invoked with the new value whenever
+// the secret reference value resolves to something different than before.
+// It returns a function which unregisters the callback. It is a no-op for
+// values that are not secret references.
+func OnRotate(value string, callback func(newValue string)) (unregister func()) {
+	if !IsRef(value) {
+		return func() {}
+	}
+	cb := &rotateCallback{fn: callback}
+	mu.Lock()
+	callbacks[value] = append(callbacks[value], cb)
+	mu.Unlock()
+
+	watcherOnce.Do(func() {
+		go func() {
+			for range time.Tick(*refreshInterval) {
+				ctx, cancel := context.WithTimeout(context.Background(), *resolveTimeout)
+				Refresh(ctx)
+				cancel()
+			}
+		}()
+	})
+
+	return func() {
+		mu.Lock()
+		defer mu.Unlock()
+		cbs := callbacks[value]
+		for i, other := range cbs {
+			if other == cb {
+				cbs = append(cbs[:i:i], cbs[i+1:]...)
+				break
+			}
+		}
+		if len(cbs) == 0 {
+			delete(callbacks, value)
+			return
+		}
+		callbacks[value] = cbs
+	}
+}
+
+// Refresh re-resolves all references that have rotation callbacks,
+// bypassing the cache, and invokes the callbacks of those that changed.
+func Refresh(ctx context.Context) {
+	mu.Lock()
+	values := make([]string, 0, len(callbacks))
+	for value := range callbacks {
+		values = append(values, value)
+	}
+	mu.Unlock()
+
+	for _, value := range values {
+		resolved, err := resolve(ctx, value)
+		if err != nil {
+			log.Warningf("cannot refresh secret %v: %v", value, err)
+			continue
+		}
+
+		store(value, resolved)
+	}
+}
+
+// store caches a resolved value, and invokes the rotation callbacks
+// if it differs from the previously resolved one.
+func store(value, resolved string) {
+	mu.Lock()
+	old, hadOld := lastSeen[value]
+	cache[value] = &cacheEntry{value: resolved, expires: time.Now().Add(*cacheTTL)}
+	lastSeen[value] = resolved
+	cbs := callbacks[value]
+	mu.Unlock()
+
+	if !hadOld || old == resolved {
+		return
+	}
+	for _, cb := range cbs {
+		cb.fn(resolved)
+	}
+}
+
+// FlushCache drops all cached secret values.
+func FlushCache() {
+	mu.Lock()
+	defer mu.Unlock()
+	cache = make(map[string]*cacheEntry)
+}
+
+func resolve(ctx context.Context, value string) (string, error) {
+	ref, err := ParseRef(value)
+	if err != nil {
+		return "", err
+	}
+
+	mu.Lock()
+	resolver, ok := resolvers[ref.Resolver]
+	mu.Unlock()
+	if !ok {
+		return "", fmt.Errorf("unknown secret resolver %q in %v", ref.Resolver, value)
+	}
+
+	data, err := resolver.Resolve(ctx, ref)
+	if err != nil {
+		return "", fmt.Errorf("cannot resolve %v: %v", value, err)
+	}
+	if ref.Key == "" {
+		return strings.TrimRight(string(data), "\r\n"), nil
+	}
+
+	fields := make(map[string]interface{})
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return "", fmt.Errorf("cannot parse %v as a JSON object: %v", value, err)
+	}
+	field, ok := fields[ref.Key]
+	if !ok {
+		return "", fmt.Errorf("key %q not found in %v", ref.Key, value)
+	}
+	if s, ok := field.(string); ok {
+		return s, nil
+	}
+	return fmt.Sprintf("%v", field), nil
+}
+
+// HandleSIGHUP makes the process drop the secret cache and refresh all
+// references with rotation callbacks when it receives SIGHUP. It is meant to
+// be called from main by binaries that want an operator-triggered rotation.
+func HandleSIGHUP() {
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGHUP)
+	go func() {
+		for range sigChan {
+			log.Infof("received SIGHUP, flushing the secrets cache")
+			FlushCache()
+			ctx, cancel := context.WithTimeout(context.Background(), *resolveTimeout)
+			Refresh(ctx)
+			cancel()
+		}
+	}()
+}
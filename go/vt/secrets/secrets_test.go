@@ -0,0 +1,232 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secrets
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRef(t *testing.T) {
+	testcases := []struct {
+		in  string
+		out *Ref
+		err string
+	}{{
+		in:  "secret://env/DB_PASS",
+		out: &Ref{Resolver: "env", Path: "DB_PASS"},
+	}, {
+		in:  "secret://vault/secret/data/prod#password",
+		out: &Ref{Resolver: "vault", Path: "secret/data/prod", Key: "password"},
+	}, {
+		in:  "secret://file//etc/creds.json#user",
+		out: &Ref{Resolver: "file", Path: "/etc/creds.json", Key: "user"},
+	}, {
+		in:  "plain",
+		err: "not a secret reference",
+	}, {
+		in:  "secret://env",
+		err: "invalid secret reference",
+	}}
+	for _, tc := range testcases {
+		t.Run(tc.in, func(t *testing.T) {
+			ref, err := ParseRef(tc.in)
+			if tc.err != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.out, ref)
+			assert.Equal(t, tc.in, ref.String())
+		})
+	}
+}
+
+func TestResolvePlainValue(t *testing.T) {
+	got, err := Resolve(context.Background(), "hunter2")
+	require.NoError(t, err)
+	assert.Equal(t, "hunter2", got)
+}
+
+func TestResolveBuiltins(t *testing.T) {
+	ctx := context.Background()
+	defer FlushCache()
+
+	os.Setenv("SECRETS_TEST_PASS", "envpass")
+	defer os.Unsetenv("SECRETS_TEST_PASS")
+	got, err := Resolve(ctx, "secret://env/SECRETS_TEST_PASS")
+	require.NoError(t, err)
+	assert.Equal(t, "envpass", got)
+
+	dir, err := ioutil.TempDir("", "secrets_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	file := path.Join(dir, "creds.json")
+	require.NoError(t, ioutil.WriteFile(file, []byte(`{"user":"vt_app","password":"filepass","port":3306}`), 0600))
+
+	got, err = Resolve(ctx, "secret://file/"+file+"#password")
+	require.NoError(t, err)
+	assert.Equal(t, "filepass", got)
+	got, err = Resolve(ctx, "secret://file/"+file+"#port")
+	require.NoError(t, err)
+	assert.Equal(t, "3306", got)
+	_, err = Resolve(ctx, "secret://file/"+file+"#missing")
+	assert.Error(t, err)
+
+	got, err = Resolve(ctx, "secret://exec/echo execpass")
+	require.NoError(t, err)
+	assert.Equal(t, "execpass", got)
+
+	_, err = Resolve(ctx, "secret://nosuchresolver/x")
+	assert.Error(t, err)
+}
+
+func TestCacheAndRotation(t *testing.T) {
+	ctx := context.Background()
+	defer FlushCache()
+
+	var mu sync.Mutex
+	current := "v1"
+	calls := 0
+	RegisterResolver("rotating", ResolverFunc(func(ctx context.Context, ref *Ref) ([]byte, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		return []byte(current), nil
+	}))
+
+	ref := "secret://rotating/x"
+	var rotated []string
+	OnRotate(ref, func(newValue string) {
+		rotated = append(rotated, newValue)
+	})
+
+	got, err := Resolve(ctx, ref)
+	require.NoError(t, err)
+	assert.Equal(t, "v1", got)
+	_, err = Resolve(ctx, ref)
+	require.NoError(t, err)
+	assert.Equal(t, 1, calls, "second Resolve should be served from cache")
+
+	// Refresh without a change doesn't notify.
+	Refresh(ctx)
+	assert.Empty(t, rotated)
+
+	mu.Lock()
+	current = "v2"
+	mu.Unlock()
+
+	// The cached value is still served until it is refreshed.
+	got, err = Resolve(ctx, ref)
+	require.NoError(t, err)
+	assert.Equal(t, "v1", got)
+
+	Refresh(ctx)
+	assert.Equal(t, []string{"v2"}, rotated)
+	got, err = Resolve(ctx, ref)
+	require.NoError(t, err)
+	assert.Equal(t, "v2", got)
+}
+
+func TestOnRotateUnregister(t *testing.T) {
+	ctx := context.Background()
+	defer FlushCache()
+
+	var mu sync.Mutex
+	current := "v1"
+	RegisterResolver("unregistered", ResolverFunc(func(ctx context.Context, ref *Ref) ([]byte, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		return []byte(current), nil
+	}))
+
+	ref := "secret://unregistered/x"
+	var rotated, kept []string
+	unregister := OnRotate(ref, func(newValue string) {
+		rotated = append(rotated, newValue)
+	})
+	unregisterKept := OnRotate(ref, func(newValue string) {
+		kept = append(kept, newValue)
+	})
+	defer unregisterKept()
+
+	_, err := Resolve(ctx, ref)
+	require.NoError(t, err)
+
+	unregister()
+	// Unregistering twice is harmless.
+	unregister()
+
+	mu.Lock()
+	current = "v2"
+	mu.Unlock()
+
+	Refresh(ctx)
+	assert.Empty(t, rotated)
+	assert.Equal(t, []string{"v2"}, kept)
+
+	// Values which aren't references can be unregistered too.
+	OnRotate("plain", func(string) {})()
+}
+
+func TestHandleSIGHUP(t *testing.T) {
+	ctx := context.Background()
+	defer FlushCache()
+
+	var mu sync.Mutex
+	current := "v1"
+	RegisterResolver("sighup", ResolverFunc(func(ctx context.Context, ref *Ref) ([]byte, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		return []byte(current), nil
+	}))
+
+	ref := "secret://sighup/x"
+	rotated := make(chan string, 1)
+	OnRotate(ref, func(newValue string) {
+		rotated <- newValue
+	})
+	got, err := Resolve(ctx, ref)
+	require.NoError(t, err)
+	assert.Equal(t, "v1", got)
+
+	mu.Lock()
+	current = "v2"
+	mu.Unlock()
+
+	HandleSIGHUP()
+	require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGHUP))
+	select {
+	case got := <-rotated:
+		assert.Equal(t, "v2", got)
+	case <-time.After(10 * time.Second):
+		t.Fatal("SIGHUP did not refresh the secret")
+	}
+	got, err = Resolve(ctx, ref)
+	require.NoError(t, err)
+	assert.Equal(t, "v2", got)
+}
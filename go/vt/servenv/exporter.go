@@ -514,6 +514,36 @@ func (e *Exporter) NewRates(name string, singleCountVar multiCountVar, samples i
 	return rates
 }
 
+// NewWindowRates creates a name-spaced equivalent for stats.NewWindowRates.
+// The function currently just returns an unexported variable.
+func (e *Exporter) NewWindowRates(name string, singleCountVar multiCountVar, windows []time.Duration, interval time.Duration) *stats.WindowRates {
+	if e.name == "" || name == "" {
+		v := stats.NewWindowRates(name, singleCountVar, windows, interval)
+		addUnnamedExport(name, v)
+		return v
+	}
+
+	exporterMu.Lock()
+	defer exporterMu.Unlock()
+
+	if v, ok := unnamedExports[name]; ok {
+		return v.(*stats.WindowRates)
+	}
+
+	ov, ok := exportedOtherStatsVars[name]
+	if !ok {
+		ov = expvar.NewMap(name)
+		exportedOtherStatsVars[name] = ov
+	}
+	if lvar := ov.Get(e.name); lvar != nil {
+		return lvar.(*stats.WindowRates)
+	}
+
+	rates := stats.NewWindowRates("", singleCountVar, windows, interval)
+	ov.Set(e.name, rates)
+	return rates
+}
+
 // NewHistogram creates a name-spaced equivalent for stats.NewHistogram.
 // The function currently just returns an unexported variable.
 func (e *Exporter) NewHistogram(name, help string, cutoffs []int64) *stats.Histogram {
@@ -18,6 +18,8 @@ package servenv
 
 import (
 	"bytes"
+	"encoding/json"
+	"flag"
 	"fmt"
 	"html"
 	"html/template"
@@ -33,6 +35,7 @@ import (
 	"time"
 
 	"vitess.io/vitess/go/acl"
+	"vitess.io/vitess/go/stats"
 	"vitess.io/vitess/go/vt/log"
 )
 
@@ -143,6 +146,8 @@ func newStatusPage(name string) *statusPage {
 		// Debug profiles are only supported for the top level status page.
 		registerDebugBlockProfileRate()
 		registerDebugMutexProfileFraction()
+		registerDebugRateWindows()
+		registerDebugFlags()
 	} else {
 		http.HandleFunc("/"+name+StatusURLPath(), sp.statusHandler)
 	}
@@ -305,6 +310,78 @@ func registerDebugMutexProfileFraction() {
 	})
 }
 
+// registerDebugRateWindows shows the rates of the key metrics over the last
+// minute, 5 minutes and hour as JSON. The name parameter restricts them to
+// a single metric.
+func registerDebugRateWindows() {
+	http.HandleFunc("/debug/rate_windows", func(w http.ResponseWriter, r *http.Request) {
+		if err := acl.CheckAccessHTTP(r, acl.DEBUGGING); err != nil {
+			acl.SendError(w, err)
+			return
+		}
+
+		rates := stats.GetAllWindowRates()
+		if name := r.FormValue("name"); name != "" {
+			rates = map[string]map[string]map[string]float64{name: rates[name]}
+		}
+		data, err := json.MarshalIndent(rates, "", "  ")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(data)
+	})
+}
+
+// registerDebugFlags shows the values of the flags of the process as JSON,
+// so that they can be compared across processes. The name parameters
+// restrict them to the given flags. The values of the flags whose name
+// contains "password" or "secret" are redacted.
+func registerDebugFlags() {
+	http.HandleFunc("/debug/flags", func(w http.ResponseWriter, r *http.Request) {
+		if err := acl.CheckAccessHTTP(r, acl.DEBUGGING); err != nil {
+			acl.SendError(w, err)
+			return
+		}
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		data, err := json.MarshalIndent(flagValues(r.Form["name"]), "", "  ")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(data)
+	})
+}
+
+// flagValues returns the values of the given flags, or of all the flags if
+// names is empty. The unknown flags are skipped.
+func flagValues(names []string) map[string]string {
+	values := make(map[string]string)
+	add := func(f *flag.Flag) {
+		value := f.Value.String()
+		if lower := strings.ToLower(f.Name); strings.Contains(lower, "password") || strings.Contains(lower, "secret") {
+			value = "****"
+		}
+		values[f.Name] = value
+	}
+	if len(names) == 0 {
+		flag.VisitAll(add)
+		return values
+	}
+	for _, name := range names {
+		if f := flag.Lookup(name); f != nil {
+			add(f)
+		}
+	}
+	return values
+}
+
 func init() {
 	var err error
 	hostname, err = os.Hostname()
@@ -17,6 +17,7 @@ limitations under the License.
 package servenv
 
 import (
+	"flag"
 	"html/template"
 	"io/ioutil"
 	"net/http"
@@ -25,6 +26,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -105,3 +107,14 @@ func TestNamedStatus(t *testing.T) {
 	}
 	t.Logf("body: \n%s", body)
 }
+
+func TestFlagValues(t *testing.T) {
+	flag.String("test_flag_values_mode", "strict", "")
+	flag.String("test_flag_values_password", "hunter2", "")
+
+	assert.Equal(t, map[string]string{
+		"test_flag_values_mode":     "strict",
+		"test_flag_values_password": "****",
+	}, flagValues([]string{"test_flag_values_mode", "test_flag_values_password", "unknown"}))
+	assert.Equal(t, "strict", flagValues(nil)["test_flag_values_mode"])
+}
@@ -351,8 +351,11 @@ func (er *expressionRewriter) sysVarRewrite(cursor *Cursor, node *ColName) {
 	lowered := node.Name.Lowered()
 	switch lowered {
 	case sysvars.Autocommit.Name,
+		sysvars.ChecksumKeyspaces.Name,
 		sysvars.ClientFoundRows.Name,
+		sysvars.Consistency.Name,
 		sysvars.DDLStrategy.Name,
+		sysvars.KeyspaceFlags.Name,
 		sysvars.TransactionMode.Name,
 		sysvars.ReadAfterWriteGTID.Name,
 		sysvars.ReadAfterWriteTimeOut.Name,
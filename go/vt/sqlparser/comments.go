@@ -371,3 +371,23 @@ func IgnoreMaxMaxMemoryRowsDirective(stmt Statement) bool {
 		return false
 	}
 }
+
+// QueryTimeoutDirective returns the timeout set by the query timeout
+// directive, in milliseconds, or 0 if it is not set.
+func QueryTimeoutDirective(stmt Statement) int {
+	var comments Comments
+	switch stmt := stmt.(type) {
+	case *Select:
+		comments = stmt.Comments
+	case *Insert:
+		comments = stmt.Comments
+	case *Update:
+		comments = stmt.Comments
+	case *Delete:
+		comments = stmt.Comments
+	default:
+		return 0
+	}
+	timeout, _ := ExtractCommentDirectives(comments)[DirectiveQueryTimeout].(int)
+	return timeout
+}
@@ -469,3 +469,25 @@ func TestIgnoreMaxMaxMemoryRowsDirective(t *testing.T) {
 		})
 	}
 }
+
+func TestQueryTimeoutDirective(t *testing.T) {
+	testCases := []struct {
+		query    string
+		expected int
+	}{
+		{"select /*vt+ QUERY_TIMEOUT_MS=100 */ * from users", 100},
+		{"select * from users", 0},
+		{"insert /*vt+ QUERY_TIMEOUT_MS=100 */ into user(id) values (1), (2)", 100},
+		{"update /*vt+ QUERY_TIMEOUT_MS=100 */ users set name=1", 100},
+		{"delete /*vt+ QUERY_TIMEOUT_MS=100 */ from users", 100},
+		{"select /*vt+ QUERY_TIMEOUT_MS=abc */ * from users", 0},
+		{"show /*vt+ QUERY_TIMEOUT_MS=100 */ create table users", 0},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.query, func(t *testing.T) {
+			stmt, _ := Parse(test.query)
+			assert.Equal(t, test.expected, QueryTimeoutDirective(stmt))
+		})
+	}
+}
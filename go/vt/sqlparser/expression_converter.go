@@ -55,6 +55,10 @@ func Convert(e Expr) (evalengine.Expr, error) {
 			op = &evalengine.Multiplication{}
 		case DivOp:
 			op = &evalengine.Division{}
+		case JSONExtractOp:
+			op = &evalengine.JSONExtract{}
+		case JSONUnquoteExtractOp:
+			op = &evalengine.JSONUnquoteExtract{}
 		default:
 			return nil, ErrExprNotSupported
 		}
@@ -71,7 +75,41 @@ func Convert(e Expr) (evalengine.Expr, error) {
 			Left:  left,
 			Right: right,
 		}, nil
+	case *FuncExpr:
+		return convertJSONFunc(node)
+	}
+	return nil, ErrExprNotSupported
+}
 
+// convertJSONFunc converts JSON_EXTRACT with a single path, and
+// JSON_UNQUOTE of it, which are the same as the -> and ->> operators.
+func convertJSONFunc(node *FuncExpr) (evalengine.Expr, error) {
+	if !node.Qualifier.IsEmpty() || node.Distinct {
+		return nil, ErrExprNotSupported
+	}
+	args := make([]Expr, 0, len(node.Exprs))
+	for _, e := range node.Exprs {
+		aliased, ok := e.(*AliasedExpr)
+		if !ok {
+			return nil, ErrExprNotSupported
+		}
+		args = append(args, aliased.Expr)
+	}
+	switch {
+	case node.Name.EqualString("json_extract") && len(args) == 2:
+		return Convert(&BinaryExpr{Operator: JSONExtractOp, Left: args[0], Right: args[1]})
+	case node.Name.EqualString("json_unquote") && len(args) == 1:
+		inner, ok := args[0].(*FuncExpr)
+		if !ok || !inner.Name.EqualString("json_extract") {
+			return nil, ErrExprNotSupported
+		}
+		extract, err := convertJSONFunc(inner)
+		if err != nil {
+			return nil, err
+		}
+		op := extract.(*evalengine.BinaryOp)
+		op.Expr = &evalengine.JSONUnquoteExtract{}
+		return op, nil
 	}
 	return nil, ErrExprNotSupported
 }
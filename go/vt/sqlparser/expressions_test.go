@@ -70,6 +70,18 @@ func TestEvaluate(t *testing.T) {
 	}, {
 		expression: ":float_bind_variable",
 		expected:   sqltypes.NewFloat64(2.2),
+	}, {
+		expression: `json_extract('{"a": {"b": [1, "x"]}}', '$.a.b[1]')`,
+		expected:   sqltypes.MakeTrusted(sqltypes.TypeJSON, []byte(`"x"`)),
+	}, {
+		expression: `json_unquote(json_extract('{"a": {"b": [1, "x"]}}', '$.a.b[1]'))`,
+		expected:   sqltypes.NewVarChar("x"),
+	}, {
+		expression: `json_extract('{"a": {"b": [1, "x"]}}', '$.a')`,
+		expected:   sqltypes.MakeTrusted(sqltypes.TypeJSON, []byte(`{"b":[1,"x"]}`)),
+	}, {
+		expression: `json_extract('{"a": 1}', '$.c')`,
+		expected:   sqltypes.NULL,
 	}}
 
 	for _, test := range tests {
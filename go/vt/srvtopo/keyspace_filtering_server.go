@@ -115,3 +115,17 @@ func (ksf keyspaceFilteringServer) WatchSrvVSchema(
 
 	ksf.server.WatchSrvVSchema(ctx, cell, filteringCallback)
 }
+
+func (ksf keyspaceFilteringServer) WatchSrvKeyspace(
+	ctx context.Context,
+	cell,
+	keyspace string,
+	callback func(*topodatapb.SrvKeyspace, error) bool,
+) {
+	if !ksf.selectKeyspaces[keyspace] {
+		callback(nil, topo.NewError(topo.NoNode, keyspace))
+		return
+	}
+
+	ksf.server.WatchSrvKeyspace(ctx, cell, keyspace, callback)
+}
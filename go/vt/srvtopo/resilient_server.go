@@ -583,6 +583,83 @@ func (server *ResilientServer) WatchSrvVSchema(ctx context.Context, cell string,
 	wg.Wait()
 }
 
+var watchSrvKeyspaceSleepTime = 5 * time.Second
+
+// WatchSrvKeyspace is part of the srvtopo.Server interface.
+//
+// Each new value is also stored in the cache, so GetSrvKeyspace returns
+// it right away even if the watch of the cache entry is not running.
+func (server *ResilientServer) WatchSrvKeyspace(ctx context.Context, cell, keyspace string, callback func(*topodatapb.SrvKeyspace, error) bool) {
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+
+	go func() {
+		defer func() {
+			if err := recover(); err != nil {
+				log.Errorf("WatchSrvKeyspace uncaught panic, cell :%v, keyspace :%v, err :%v)", cell, keyspace, err)
+			}
+		}()
+
+		foundFirstValue := false
+		notify := func(value *topodatapb.SrvKeyspace, err error) bool {
+			if err == nil {
+				server.updateSrvKeyspaceEntry(cell, keyspace, value)
+			}
+			keepWatching := callback(value, err)
+			if !foundFirstValue {
+				foundFirstValue = true
+				wg.Done()
+			}
+			return keepWatching
+		}
+
+		for {
+			watchCtx, cancel := context.WithCancel(ctx)
+			current, changes, _ := server.topoServer.WatchSrvKeyspace(watchCtx, cell, keyspace)
+			if !notify(current.Value, current.Err) {
+				cancel()
+				return
+			}
+			if current.Err != nil {
+				log.Warningf("Error watching SrvKeyspace for %v/%v (will wait 5s before retrying): %v", cell, keyspace, current.Err)
+			} else {
+				for c := range changes {
+					// Note we forward topo.ErrNoNode as is.
+					if !notify(c.Value, c.Err) {
+						cancel()
+						return
+					}
+					if c.Err != nil {
+						log.Warningf("Error while watching SrvKeyspace for %v/%v (will wait 5s before retrying): %v", cell, keyspace, c.Err)
+						break
+					}
+				}
+			}
+			cancel()
+
+			// Sleep a bit before trying again.
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(watchSrvKeyspaceSleepTime):
+			}
+		}
+	}()
+
+	// Wait for the first value to have been processed.
+	wg.Wait()
+}
+
+// updateSrvKeyspaceEntry stores a value obtained by WatchSrvKeyspace in
+// the cache.
+func (server *ResilientServer) updateSrvKeyspaceEntry(cell, keyspace string, value *topodatapb.SrvKeyspace) {
+	entry := server.getSrvKeyspaceEntry(cell, keyspace)
+	entry.mutex.Lock()
+	defer entry.mutex.Unlock()
+	entry.value = value
+	entry.lastValueTime = time.Now()
+}
+
 // The next few structures and methods are used to get a displayable
 // version of the cache in a status page.
 
@@ -487,6 +487,94 @@ func TestWatchSrvVSchema(t *testing.T) {
 	}
 }
 
+func TestWatchSrvKeyspace(t *testing.T) {
+	watchSrvKeyspaceSleepTime = 10 * time.Millisecond
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ts := memorytopo.NewServer("test_cell")
+	rs := NewResilientServer(ts, "TestWatchSrvKeyspace")
+
+	// mu protects watchValue, watchErr and keepWatching.
+	mu := sync.Mutex{}
+	var watchValue *topodatapb.SrvKeyspace
+	var watchErr error
+	keepWatching := true
+	rs.WatchSrvKeyspace(ctx, "test_cell", "test_ks", func(v *topodatapb.SrvKeyspace, e error) bool {
+		mu.Lock()
+		defer mu.Unlock()
+		watchValue = v
+		watchErr = e
+		return keepWatching
+	})
+	get := func() (*topodatapb.SrvKeyspace, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		return watchValue, watchErr
+	}
+	waitFor := func(want *topodatapb.SrvKeyspace) {
+		t.Helper()
+		start := time.Now()
+		for {
+			if v, err := get(); err == nil && proto.Equal(want, v) {
+				return
+			}
+			if time.Since(start) > 5*time.Second {
+				t.Fatalf("timed out waiting for SrvKeyspace %v", want)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	// WatchSrvKeyspace won't return until it gets the initial value,
+	// which is not there, so we should get watchErr=topo.ErrNoNode.
+	if _, err := get(); !topo.IsErrType(err, topo.NoNode) {
+		t.Fatalf("WatchSrvKeyspace didn't return topo.ErrNoNode at first, but got: %v", err)
+	}
+
+	// Save a value, wait for it. It must be cached as well.
+	newValue := &topodatapb.SrvKeyspace{
+		Partitions: []*topodatapb.SrvKeyspace_KeyspacePartition{{
+			ServedType:      topodatapb.TabletType_MASTER,
+			ShardReferences: []*topodatapb.ShardReference{{Name: "0"}},
+		}},
+	}
+	if err := ts.UpdateSrvKeyspace(ctx, "test_cell", "test_ks", newValue); err != nil {
+		t.Fatalf("UpdateSrvKeyspace failed: %v", err)
+	}
+	waitFor(newValue)
+	entry := rs.getSrvKeyspaceEntry("test_cell", "test_ks")
+	entry.mutex.RLock()
+	cached := entry.value
+	entry.mutex.RUnlock()
+	if !proto.Equal(newValue, cached) {
+		t.Errorf("cached SrvKeyspace = %v, want %v", cached, newValue)
+	}
+
+	// Stop watching at the next value.
+	mu.Lock()
+	keepWatching = false
+	mu.Unlock()
+	updatedValue := &topodatapb.SrvKeyspace{
+		Partitions: []*topodatapb.SrvKeyspace_KeyspacePartition{{
+			ServedType:      topodatapb.TabletType_MASTER,
+			ShardReferences: []*topodatapb.ShardReference{{Name: "-80"}, {Name: "80-"}},
+		}},
+	}
+	if err := ts.UpdateSrvKeyspace(ctx, "test_cell", "test_ks", updatedValue); err != nil {
+		t.Fatalf("UpdateSrvKeyspace failed: %v", err)
+	}
+	waitFor(updatedValue)
+
+	// The watch stopped, so the following values are not seen.
+	if err := ts.UpdateSrvKeyspace(ctx, "test_cell", "test_ks", newValue); err != nil {
+		t.Fatalf("UpdateSrvKeyspace failed: %v", err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if v, _ := get(); !proto.Equal(updatedValue, v) {
+		t.Errorf("WatchSrvKeyspace didn't stop, got %v", v)
+	}
+}
+
 func TestGetSrvKeyspaceNames(t *testing.T) {
 	ts, factory := memorytopo.NewServerAndFactory("test_cell")
 	*srvTopoCacheTTL = 100 * time.Millisecond
@@ -46,4 +46,10 @@ type Server interface {
 	// the provided cell.  It will call the callback when
 	// a new value or an error occurs.
 	WatchSrvVSchema(ctx context.Context, cell string, callback func(*vschemapb.SrvVSchema, error))
+
+	// WatchSrvKeyspace starts watching the SrvKeyspace object for
+	// the provided cell and keyspace. It will call the callback when
+	// a new value or an error occurs, until the callback returns false
+	// or the context is canceled.
+	WatchSrvKeyspace(ctx context.Context, cell, keyspace string, callback func(*topodatapb.SrvKeyspace, error) bool)
 }
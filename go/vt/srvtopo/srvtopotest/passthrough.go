@@ -63,3 +63,8 @@ func (srv *PassthroughSrvTopoServer) GetSrvKeyspace(ctx context.Context, cell, k
 func (srv *PassthroughSrvTopoServer) WatchSrvVSchema(ctx context.Context, cell string, callback func(*vschemapb.SrvVSchema, error)) {
 	callback(srv.WatchedSrvVSchema, srv.WatchedSrvVSchemaError)
 }
+
+// WatchSrvKeyspace implements srvtopo.Server
+func (srv *PassthroughSrvTopoServer) WatchSrvKeyspace(ctx context.Context, cell, keyspace string, callback func(*topodatapb.SrvKeyspace, error) bool) {
+	callback(srv.SrvKeyspace, srv.SrvKeyspaceError)
+}
@@ -66,6 +66,11 @@ var (
 	ReadAfterWriteGTID    = SystemVariable{Name: "read_after_write_gtid"}
 	ReadAfterWriteTimeOut = SystemVariable{Name: "read_after_write_timeout"}
 	SessionTrackGTIDs     = SystemVariable{Name: "session_track_gtids", IdentifierAsString: true}
+	Consistency           = SystemVariable{Name: "vitess_consistency", IdentifierAsString: true}
+	ChecksumKeyspaces     = SystemVariable{Name: "vitess_checksum_keyspaces", IdentifierAsString: true}
+
+	// KeyspaceFlags holds the serving flags of the keyspace of the session.
+	KeyspaceFlags = SystemVariable{Name: "vitess_keyspace_flags"}
 
 	VitessAware = []SystemVariable{
 		Autocommit,
@@ -84,9 +89,12 @@ var (
 		ReadAfterWriteGTID,
 		ReadAfterWriteTimeOut,
 		SessionTrackGTIDs,
+		Consistency,
+		ChecksumKeyspaces,
 	}
 
 	ReadOnly = []SystemVariable{
+		KeyspaceFlags,
 		Socket,
 		Version,
 		VersionComment,
@@ -37,3 +37,20 @@ func (cached *ACLResult) CachedSize(alloc bool) int64 {
 	size += int64(len(cached.GroupName))
 	return size
 }
+
+func (cached *Quota) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(48)
+	}
+	// field GroupName string
+	size += int64(len(cached.GroupName))
+	// field Users vitess.io/vitess/go/vt/tableacl/acl.ACL
+	if cc, ok := cached.Users.(cachedObject); ok {
+		size += cc.CachedSize(true)
+	}
+	return size
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tableacl
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"sync"
+
+	"vitess.io/vitess/go/json2"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/tableacl/acl"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+// QuotaConfig is the configuration of the per-table quotas.
+type QuotaConfig struct {
+	TableGroups []*QuotaGroup `json:"table_groups"`
+}
+
+// QuotaGroup limits the queries of some callers on a group of tables.
+// Users contains the callers and caller groups the limits apply to, in
+// the same format as the readers of a table ACL. If it's empty, the
+// limits apply to all the callers. A limit of 0 means no limit.
+type QuotaGroup struct {
+	Name                 string   `json:"name"`
+	TableNamesOrPrefixes []string `json:"table_names_or_prefixes"`
+	Users                []string `json:"users"`
+	// MaxRows is the maximum number of rows a query can read from
+	// MySQL. The rows examined by MySQL aren't known to the tablet, but a
+	// query can't read more rows than it scans.
+	MaxRows int64 `json:"max_rows"`
+	// MaxResultBytes is the maximum size of the rows a query can read
+	// from MySQL.
+	MaxResultBytes int64 `json:"max_result_bytes"`
+}
+
+// Quota is the runtime part of a QuotaGroup for a table.
+type Quota struct {
+	GroupName      string
+	Users          acl.ACL
+	MaxRows        int64
+	MaxResultBytes int64
+}
+
+// AppliesTo returns true if the quota limits the queries of the caller.
+func (q *Quota) AppliesTo(callerID *querypb.VTGateCallerID) bool {
+	if q.Users == nil {
+		return true
+	}
+	return callerID != nil && q.Users.IsMember(callerID)
+}
+
+type quotaEntry struct {
+	tableNameOrPrefix string
+	quota             *Quota
+}
+
+type tableQuotas struct {
+	mu      sync.RWMutex
+	entries []quotaEntry
+	// callback is executed on successful reload.
+	callback func()
+}
+
+// currentTableQuotas stores the current per-table quotas.
+var currentTableQuotas tableQuotas
+
+// InitQuotas initiates the per-table quotas from a json file, which looks
+// like this:
+//
+//	{
+//	  "table_groups": [
+//	    {
+//	      "name": "dumps",
+//	      "table_names_or_prefixes": ["big_%"],
+//	      "users": ["client1"],
+//	      "max_rows": 10000,
+//	      "max_result_bytes": 16777216
+//	    }
+//	  ]
+//	}
+//
+// Unlike for table ACLs, the groups can overlap: all the quotas of a table
+// that apply to a caller are enforced.
+func InitQuotas(configFile string, cb func()) error {
+	currentTableQuotas.setCallback(cb)
+	if configFile == "" {
+		return nil
+	}
+	data, err := ioutil.ReadFile(configFile)
+	if err != nil {
+		log.Infof("unable to read table quota config file: %v  Error: %v", configFile, err)
+		return err
+	}
+	config := &QuotaConfig{}
+	if err := json2.Unmarshal(data, config); err != nil {
+		return fmt.Errorf("unable to unmarshal table quota data: %v", err)
+	}
+	return SetQuotas(config)
+}
+
+func (tq *tableQuotas) setCallback(callback func()) {
+	tq.mu.Lock()
+	defer tq.mu.Unlock()
+	tq.callback = callback
+}
+
+// SetQuotas sets the per-table quotas.
+func SetQuotas(config *QuotaConfig) error {
+	return currentTableQuotas.set(config)
+}
+
+func (tq *tableQuotas) set(config *QuotaConfig) error {
+	entries, err := loadQuotas(config)
+	if err != nil {
+		return err
+	}
+	tq.mu.Lock()
+	tq.entries = entries
+	callback := tq.callback
+	tq.mu.Unlock()
+	if callback != nil {
+		callback()
+	}
+	return nil
+}
+
+func loadQuotas(config *QuotaConfig) ([]quotaEntry, error) {
+	var factory acl.Factory
+	var entries []quotaEntry
+	for _, group := range config.TableGroups {
+		if group.MaxRows < 0 || group.MaxResultBytes < 0 {
+			return nil, fmt.Errorf("table quota group %q: limits cannot be negative", group.Name)
+		}
+		quota := &Quota{
+			GroupName:      group.Name,
+			MaxRows:        group.MaxRows,
+			MaxResultBytes: group.MaxResultBytes,
+		}
+		if len(group.Users) > 0 {
+			// The users are matched by the ACL implementation of the table
+			// ACLs, which must be registered even without table ACLs.
+			var err error
+			if factory == nil {
+				if factory, err = GetCurrentACLFactory(); err != nil {
+					return nil, fmt.Errorf("table quota group %q: cannot match its users: %v", group.Name, err)
+				}
+			}
+			if quota.Users, err = factory.New(group.Users); err != nil {
+				return nil, err
+			}
+		}
+		for _, name := range group.TableNamesOrPrefixes {
+			if strings.Contains(strings.TrimSuffix(name, "%"), "%") {
+				return nil, fmt.Errorf("got: %s, '%%' means this entry is a prefix and should not appear in the middle of name or prefix", name)
+			}
+			entries = append(entries, quotaEntry{tableNameOrPrefix: name, quota: quota})
+		}
+	}
+	return entries, nil
+}
+
+// Quotas returns the quotas of a table.
+func Quotas(table string) []*Quota {
+	return currentTableQuotas.quotas(table)
+}
+
+func (tq *tableQuotas) quotas(table string) []*Quota {
+	tq.mu.RLock()
+	defer tq.mu.RUnlock()
+	var quotas []*Quota
+	for _, entry := range tq.entries {
+		val := entry.tableNameOrPrefix
+		if table == val || (strings.HasSuffix(val, "%") && strings.HasPrefix(table, val[:len(val)-1])) {
+			quotas = append(quotas, entry.quota)
+		}
+	}
+	return quotas
+}
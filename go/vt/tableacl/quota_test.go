@@ -0,0 +1,89 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tableacl
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/tableacl/acl"
+	"vitess.io/vitess/go/vt/tableacl/simpleacl"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+var quotaJSON = `{
+  "table_groups": [
+    {
+      "name": "dumps",
+      "table_names_or_prefixes": ["big_%", "events"],
+      "users": ["reporting"],
+      "max_rows": 100
+    },
+    {
+      "name": "all",
+      "table_names_or_prefixes": ["big_table"],
+      "max_result_bytes": 1024
+    }
+  ]
+}`
+
+func TestQuotas(t *testing.T) {
+	acls = make(map[string]acl.Factory)
+	defaultACL = ""
+
+	// The users of the quotas are matched by the registered ACL
+	// implementation.
+	require.Error(t, SetQuotas(&QuotaConfig{TableGroups: []*QuotaGroup{{Name: "users", Users: []string{"app"}}}}))
+	Register("simpleacl", &simpleacl.Factory{})
+
+	f, err := ioutil.TempFile("", "quota")
+	require.NoError(t, err)
+	defer os.Remove(f.Name())
+	_, err = f.WriteString(quotaJSON)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	reloaded := false
+	require.NoError(t, InitQuotas(f.Name(), func() { reloaded = true }))
+	defer SetQuotas(&QuotaConfig{})
+	assert.True(t, reloaded)
+
+	reporting := &querypb.VTGateCallerID{Username: "reporting"}
+	app := &querypb.VTGateCallerID{Username: "app"}
+
+	quotas := Quotas("big_table")
+	require.Len(t, quotas, 2)
+	assert.Equal(t, "dumps", quotas[0].GroupName)
+	assert.EqualValues(t, 100, quotas[0].MaxRows)
+	assert.True(t, quotas[0].AppliesTo(reporting))
+	assert.False(t, quotas[0].AppliesTo(app))
+	assert.False(t, quotas[0].AppliesTo(nil))
+	assert.EqualValues(t, 1024, quotas[1].MaxResultBytes)
+	assert.True(t, quotas[1].AppliesTo(app))
+
+	assert.Len(t, Quotas("events"), 1)
+	assert.Empty(t, Quotas("other"))
+
+	assert.Error(t, SetQuotas(&QuotaConfig{TableGroups: []*QuotaGroup{{Name: "bad", TableNamesOrPrefixes: []string{"a%b"}}}}))
+	assert.Error(t, SetQuotas(&QuotaConfig{TableGroups: []*QuotaGroup{{Name: "bad", MaxRows: -1}}}))
+	assert.Error(t, InitQuotas("/invalid_file_path", nil))
+}
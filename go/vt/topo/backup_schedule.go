@@ -0,0 +1,102 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topo
+
+import (
+	"context"
+	"path"
+
+	"google.golang.org/protobuf/proto"
+
+	"vitess.io/vitess/go/vt/vterrors"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// This file contains the methods to store the backup schedule of a
+// keyspace, which vtctld runs. It is stored as a topodatapb.BackupSchedule
+// next to the VSchema of the keyspace.
+
+// GetBackupSchedule returns the backup schedule of the keyspace, or nil if
+// it has none.
+func (ts *Server) GetBackupSchedule(ctx context.Context, keyspace string) (*topodatapb.BackupSchedule, error) {
+	schedule, _, err := ts.getBackupSchedule(ctx, keyspace)
+	if IsErrType(err, NoNode) {
+		return nil, nil
+	}
+	return schedule, err
+}
+
+func (ts *Server) getBackupSchedule(ctx context.Context, keyspace string) (*topodatapb.BackupSchedule, Version, error) {
+	nodePath := path.Join(KeyspacesPath, keyspace, BackupScheduleFile)
+	data, version, err := ts.globalCell.Get(ctx, nodePath)
+	if err != nil {
+		return nil, nil, err
+	}
+	schedule := &topodatapb.BackupSchedule{}
+	if err := proto.Unmarshal(data, schedule); err != nil {
+		return nil, nil, vterrors.Wrapf(err, "bad backup schedule data for keyspace %v", keyspace)
+	}
+	return schedule, version, nil
+}
+
+// SaveBackupSchedule creates or replaces the backup schedule of the
+// keyspace.
+func (ts *Server) SaveBackupSchedule(ctx context.Context, keyspace string, schedule *topodatapb.BackupSchedule) error {
+	data, err := proto.Marshal(schedule)
+	if err != nil {
+		return err
+	}
+	nodePath := path.Join(KeyspacesPath, keyspace, BackupScheduleFile)
+	_, err = ts.globalCell.Update(ctx, nodePath, data, nil)
+	return err
+}
+
+// UpdateBackupScheduleFields reads the backup schedule of the keyspace,
+// applies the update function and saves it, retrying on concurrent
+// updates. If the update function returns an error of type
+// NoUpdateNeeded, nothing is saved and that error is returned, so that
+// the callers racing for the same change know whether they made it.
+func (ts *Server) UpdateBackupScheduleFields(ctx context.Context, keyspace string, update func(*topodatapb.BackupSchedule) error) (*topodatapb.BackupSchedule, error) {
+	nodePath := path.Join(KeyspacesPath, keyspace, BackupScheduleFile)
+	for {
+		schedule, version, err := ts.getBackupSchedule(ctx, keyspace)
+		if err != nil {
+			return nil, err
+		}
+		if err := update(schedule); err != nil {
+			return schedule, err
+		}
+		data, err := proto.Marshal(schedule)
+		if err != nil {
+			return nil, err
+		}
+		if _, err = ts.globalCell.Update(ctx, nodePath, data, version); !IsErrType(err, BadVersion) {
+			return schedule, err
+		}
+	}
+}
+
+// DeleteBackupSchedule removes the backup schedule of the keyspace, if
+// any. The backups are kept.
+func (ts *Server) DeleteBackupSchedule(ctx context.Context, keyspace string) error {
+	nodePath := path.Join(KeyspacesPath, keyspace, BackupScheduleFile)
+	if err := ts.globalCell.Delete(ctx, nodePath, nil); err != nil && !IsErrType(err, NoNode) {
+		return err
+	}
+	return nil
+}
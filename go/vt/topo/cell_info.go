@@ -18,7 +18,9 @@ package topo
 
 import (
 	"context"
+	"fmt"
 	"path"
+	"sort"
 	"strings"
 
 	"google.golang.org/protobuf/proto"
@@ -82,6 +84,14 @@ func (ts *Server) GetCellInfo(ctx context.Context, cell string, strongRead bool)
 
 // CreateCellInfo creates a new CellInfo with the provided content.
 func (ts *Server) CreateCellInfo(ctx context.Context, cell string, ci *topodatapb.CellInfo) error {
+	// A cell can't have the name of a CellsAlias, the cell lists with
+	// that name would be ambiguous.
+	if _, err := ts.GetCellsAlias(ctx, cell, true /*strongRead*/); err == nil {
+		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "cell %v has the name of a cells alias", cell)
+	} else if !IsErrType(err, NoNode) {
+		return err
+	}
+
 	// Pack the content.
 	contents, err := proto.Marshal(ci)
 	if err != nil {
@@ -138,14 +148,45 @@ func (ts *Server) UpdateCellInfoFields(ctx context.Context, cell string, update
 }
 
 // DeleteCellInfo deletes the specified CellInfo.
-// We first try to make sure no Shard record points to the cell,
-// but we'll continue regardless if 'force' is true.
+// It refuses to delete a cell referenced by a CellsAlias, even with
+// 'force', as the alias would then contain a cell which doesn't exist.
+// We also try to make sure the cell has no SrvKeyspace and no tablet, but
+// we'll continue regardless if 'force' is true.
 func (ts *Server) DeleteCellInfo(ctx context.Context, cell string, force bool) error {
+	aliases, err := ts.GetCellsAliases(ctx, true /*strongRead*/)
+	if err != nil {
+		return vterrors.Wrap(err, "can't list the cells aliases")
+	}
+	var referencingAliases []string
+	for name, alias := range aliases {
+		if InCellList(cell, alias.Cells) {
+			referencingAliases = append(referencingAliases, name)
+		}
+	}
+	if len(referencingAliases) != 0 {
+		sort.Strings(referencingAliases)
+		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "cell %v is referenced by the cells aliases %v. Before deleting, remove it from them with UpdateCellsAlias.", cell, strings.Join(referencingAliases, ", "))
+	}
+
+	tablets, err := ts.GetTabletsByCell(ctx, cell)
+	switch {
+	case err == nil:
+		if len(tablets) != 0 && !force {
+			return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "cell %v still has %v tablets. Before deleting, delete them with DeleteTablet, or use -force to continue anyway.", cell, len(tablets))
+		}
+	case IsErrType(err, NoNode):
+		// Nothing to do.
+	default:
+		if !force {
+			return vterrors.Wrap(err, "can't list the tablets in the cell; use -force flag to continue anyway (e.g. if cell-local topo was already permanently shut down)")
+		}
+	}
+
 	srvKeyspaces, err := ts.GetSrvKeyspaceNames(ctx, cell)
 	switch {
 	case err == nil:
 		if len(srvKeyspaces) != 0 && !force {
-			return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "cell %v has serving keyspaces. Before deleting, delete keyspace with DeleteKeyspace, or use -force to continue anyway.", cell)
+			return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "cell %v has serving keyspaces. Before deleting, delete keyspace with DeleteKeyspace, drain the cell, or use -force to continue anyway.", cell)
 		}
 	case IsErrType(err, NoNode):
 		// Nothing to do.
@@ -159,6 +200,47 @@ func (ts *Server) DeleteCellInfo(ctx context.Context, cell string, force bool) e
 	return ts.globalCell.Delete(ctx, filePath, nil)
 }
 
+// DrainCell removes the cell from the serving graph of all the keyspaces,
+// by deleting their SrvKeyspace in the cell, so the vtgates of the cell
+// stop serving them. It is meant to be run before DeleteCellInfo. It
+// returns the keyspaces drained, even on error.
+func (ts *Server) DrainCell(ctx context.Context, cell string) ([]string, error) {
+	keyspaces, err := ts.GetSrvKeyspaceNames(ctx, cell)
+	if err != nil {
+		if IsErrType(err, NoNode) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var drained []string
+	for _, keyspace := range keyspaces {
+		if err := ts.drainKeyspaceCell(ctx, cell, keyspace); err != nil {
+			return drained, vterrors.Wrapf(err, "can't drain keyspace %v from cell %v", keyspace, cell)
+		}
+		drained = append(drained, keyspace)
+	}
+	return drained, nil
+}
+
+func (ts *Server) drainKeyspaceCell(ctx context.Context, cell, keyspace string) (err error) {
+	// Lock the keyspace, so a rebuild of its serving graph doesn't
+	// recreate the SrvKeyspace meanwhile.
+	// A SrvKeyspace left over by a deleted keyspace is just deleted.
+	lockCtx, unlock, lockErr := ts.LockKeyspace(ctx, keyspace, fmt.Sprintf("DrainCell(%v)", cell))
+	switch {
+	case lockErr == nil:
+		ctx = lockCtx
+		defer unlock(&err)
+	case !IsErrType(lockErr, NoNode):
+		return lockErr
+	}
+
+	if err := ts.DeleteSrvKeyspace(ctx, cell, keyspace); err != nil && !IsErrType(err, NoNode) {
+		return err
+	}
+	return nil
+}
+
 // GetKnownCells returns the list of known cells.
 // For now, it just lists the 'cells' directory in the global topology server.
 // TODO(alainjobart) once the cell map is migrated to this generic
@@ -63,7 +63,7 @@ type consulMasterParticipation struct {
 func (mp *consulMasterParticipation) WaitForMastership() (context.Context, error) {
 
 	electionPath := path.Join(mp.s.root, electionsPath, mp.name)
-	l, err := mp.s.client.LockOpts(&api.LockOptions{
+	l, err := mp.s.getClient().LockOpts(&api.LockOptions{
 		Key:   electionPath,
 		Value: []byte(mp.id),
 	})
@@ -124,7 +124,7 @@ func (mp *consulMasterParticipation) Stop() {
 // GetCurrentMasterID is part of the topo.MasterParticipation interface
 func (mp *consulMasterParticipation) GetCurrentMasterID(ctx context.Context) (string, error) {
 	electionPath := path.Join(mp.s.root, electionsPath, mp.name)
-	pair, _, err := mp.s.kv.Get(electionPath, nil)
+	pair, _, err := mp.s.getKV().Get(electionPath, nil)
 	if err != nil {
 		return "", err
 	}
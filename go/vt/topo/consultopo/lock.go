@@ -52,7 +52,7 @@ func (s *Server) Lock(ctx context.Context, dirPath, contents string) (topo.LockD
 	lockPath := path.Join(s.root, dirPath, locksFilename)
 
 	// Build the lock structure.
-	l, err := s.client.LockOpts(&api.LockOptions{
+	l, err := s.getClient().LockOpts(&api.LockOptions{
 		Key:   lockPath,
 		Value: []byte(contents),
 	})
@@ -20,6 +20,7 @@ Package consultopo implements topo.Server with consul as the backend.
 package consultopo
 
 import (
+	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
@@ -31,6 +32,7 @@ import (
 	"vitess.io/vitess/go/vt/vterrors"
 
 	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/secrets"
 	"vitess.io/vitess/go/vt/topo"
 )
 
@@ -41,6 +43,7 @@ var (
 // ClientAuthCred credential to use for consul clusters
 type ClientAuthCred struct {
 	// ACLToken when provided, the client will use this token when making requests to the Consul server.
+	// It may be a secret:// reference.
 	ACLToken string `json:"acl_token,omitempty"`
 }
 
@@ -81,6 +84,9 @@ func getClientCreds() (creds map[string]*ClientAuthCred, err error) {
 
 // Server is the implementation of topo.Server for consul.
 type Server struct {
+	// clientMu protects client and kv, which are replaced when the
+	// acl_token secret rotates. Use getClient and getKV.
+	clientMu sync.RWMutex
 	// client is the consul api client.
 	client *api.Client
 	kv     *api.KV
@@ -88,6 +94,10 @@ type Server struct {
 	// root is the root path for this client.
 	root string
 
+	// unwatchACLToken unregisters the rotation callback of the acl_token.
+	// It is called by Close.
+	unwatchACLToken func()
+
 	// mu protects the following fields.
 	mu sync.Mutex
 	// locks is a map of *lockInstance structures.
@@ -112,9 +122,17 @@ func NewServer(cell, serverAddr, root string) (*Server, error) {
 	}
 	cfg := api.DefaultConfig()
 	cfg.Address = serverAddr
+	aclToken := ""
 	if creds != nil {
 		if creds[cell] != nil {
-			cfg.Token = creds[cell].ACLToken
+			aclToken = creds[cell].ACLToken
+			ctx, cancel := context.WithTimeout(context.Background(), secrets.ResolveTimeout())
+			token, err := secrets.Resolve(ctx, aclToken)
+			cancel()
+			if err != nil {
+				return nil, vterrors.Wrapf(err, "Failed to resolve acl_token for cell %v", cell)
+			}
+			cfg.Token = token
 		} else {
 			log.Warningf("Client auth not configured for cell: %v", cell)
 		}
@@ -125,20 +143,60 @@ func NewServer(cell, serverAddr, root string) (*Server, error) {
 		return nil, err
 	}
 
-	return &Server{
+	s := &Server{
 		client: client,
 		kv:     client.KV(),
 		root:   root,
 		locks:  make(map[string]*lockInstance),
-	}, nil
+	}
+
+	// The api client cannot change its token, so a new client is used
+	// when it rotates. Locks already held keep using their client.
+	s.unwatchACLToken = secrets.OnRotate(aclToken, func(token string) {
+		rotatedCfg := *cfg
+		rotatedCfg.Token = token
+		client, err := api.NewClient(&rotatedCfg)
+		if err != nil {
+			log.Errorf("Failed to create a consul client with the rotated acl_token for cell %v: %v", cell, err)
+			return
+		}
+		s.clientMu.Lock()
+		defer s.clientMu.Unlock()
+		if s.client == nil {
+			// The server was closed.
+			return
+		}
+		log.Infof("acl_token rotated for cell %v", cell)
+		s.client = client
+		s.kv = client.KV()
+	})
+
+	return s, nil
+}
+
+// getClient returns the current consul api client.
+func (s *Server) getClient() *api.Client {
+	s.clientMu.RLock()
+	defer s.clientMu.RUnlock()
+	return s.client
+}
+
+// getKV returns the KV of the current consul api client.
+func (s *Server) getKV() *api.KV {
+	s.clientMu.RLock()
+	defer s.clientMu.RUnlock()
+	return s.kv
 }
 
 // Close implements topo.Server.Close.
 // It will nil out the global and cells fields, so any attempt to
 // re-use this server will panic.
 func (s *Server) Close() {
+	s.unwatchACLToken()
+	s.clientMu.Lock()
 	s.client = nil
 	s.kv = nil
+	s.clientMu.Unlock()
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.locks = nil
@@ -36,7 +36,7 @@ var (
 func (s *Server) Watch(ctx context.Context, filePath string) (*topo.WatchData, <-chan *topo.WatchData, topo.CancelFunc) {
 	// Initial get.
 	nodePath := path.Join(s.root, filePath)
-	pair, _, err := s.kv.Get(nodePath, nil)
+	pair, _, err := s.getKV().Get(nodePath, nil)
 	if err != nil {
 		return &topo.WatchData{Err: err}, nil, nil
 	}
@@ -85,7 +85,7 @@ func (s *Server) Watch(ctx context.Context, filePath string) (*topo.WatchData, <
 			cancelGetCtx()
 			getCtx, cancelGetCtx = context.WithTimeout(watchCtx, 2*opts.WaitTime)
 
-			pair, _, err = s.kv.Get(nodePath, opts.WithContext(getCtx))
+			pair, _, err = s.getKV().Get(nodePath, opts.WithContext(getCtx))
 			if err != nil {
 				// Serious error or context timeout/cancelled.
 				notifications <- &topo.WatchData{
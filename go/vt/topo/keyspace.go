@@ -294,6 +294,18 @@ func (ts *Server) DeleteKeyspace(ctx context.Context, keyspace string) error {
 		return err
 	}
 
+	if err := ts.DeleteWorkflowThrottling(ctx, keyspace); err != nil {
+		return err
+	}
+
+	if err := ts.DeleteVDiffResults(ctx, keyspace); err != nil {
+		return err
+	}
+
+	if err := ts.DeleteBackupSchedule(ctx, keyspace); err != nil {
+		return err
+	}
+
 	event.Dispatch(&events.KeyspaceChange{
 		KeyspaceName: keyspace,
 		Keyspace:     nil,
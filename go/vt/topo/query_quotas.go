@@ -0,0 +1,134 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+// This file contains the methods to store the query quotas of the
+// callers, which vtgate enforces on their queries. They are stored as
+// JSON in the global cell.
+
+// QueryQuotas are the query quotas of the callers. The caller of a query
+// is the user of its immediate caller ID, e.g. the MySQL user.
+type QueryQuotas struct {
+	// Default is the quota of the callers which don't have one in Users.
+	Default *QueryQuota `json:"default,omitempty"`
+	// Users are the quotas of the given callers.
+	Users map[string]*QueryQuota `json:"users,omitempty"`
+}
+
+// QueryQuota are the limits of a caller. A limit of 0 is not enforced.
+type QueryQuota struct {
+	// QPS is the number of queries per second. Bursts of up to one
+	// second of queries are allowed.
+	QPS float64 `json:"qps,omitempty"`
+	// ConcurrentQueries is the number of queries running at once.
+	ConcurrentQueries int `json:"concurrent_queries,omitempty"`
+	// RowsPerMinute is the number of rows returned per minute. The query
+	// which exceeds it isn't interrupted, but the next ones are rejected
+	// until the end of the minute.
+	RowsPerMinute int64 `json:"rows_per_minute,omitempty"`
+	// ScatterQueriesPerMinute is the number of queries per minute which
+	// are sent to all the shards of a keyspace.
+	ScatterQueriesPerMinute int64 `json:"scatter_queries_per_minute,omitempty"`
+}
+
+// IsEmpty returns true if no limit is set.
+func (qq *QueryQuota) IsEmpty() bool {
+	return qq == nil || (qq.QPS == 0 && qq.ConcurrentQueries == 0 && qq.RowsPerMinute == 0 && qq.ScatterQueriesPerMinute == 0)
+}
+
+// IsEmpty returns true if no quota is set.
+func (qq *QueryQuotas) IsEmpty() bool {
+	if qq == nil || !qq.Default.IsEmpty() {
+		return qq == nil
+	}
+	for _, quota := range qq.Users {
+		if !quota.IsEmpty() {
+			return false
+		}
+	}
+	return true
+}
+
+// Validate returns an error if a limit is negative.
+func (qq *QueryQuotas) Validate() error {
+	check := func(name string, quota *QueryQuota) error {
+		if quota == nil {
+			return nil
+		}
+		if quota.QPS < 0 || quota.ConcurrentQueries < 0 || quota.RowsPerMinute < 0 || quota.ScatterQueriesPerMinute < 0 {
+			return fmt.Errorf("invalid query quota for %v: the limits can't be negative", name)
+		}
+		return nil
+	}
+	if err := check("the default", qq.Default); err != nil {
+		return err
+	}
+	for user, quota := range qq.Users {
+		if err := check("user "+user, quota); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetQueryQuotas returns the query quotas, or nil if none is set.
+func (ts *Server) GetQueryQuotas(ctx context.Context) (*QueryQuotas, error) {
+	data, _, err := ts.globalCell.Get(ctx, QueryQuotasFile)
+	switch {
+	case IsErrType(err, NoNode):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+	qq := &QueryQuotas{}
+	if err := json.Unmarshal(data, qq); err != nil {
+		return nil, vterrors.Wrapf(err, "bad query quotas data: %q", data)
+	}
+	return qq, nil
+}
+
+// SaveQueryQuotas replaces the query quotas. Empty quotas remove them.
+func (ts *Server) SaveQueryQuotas(ctx context.Context, qq *QueryQuotas) error {
+	if qq.IsEmpty() {
+		return ts.DeleteQueryQuotas(ctx)
+	}
+	if err := qq.Validate(); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(qq, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = ts.globalCell.Update(ctx, QueryQuotasFile, data, nil)
+	return err
+}
+
+// DeleteQueryQuotas removes the query quotas, if any.
+func (ts *Server) DeleteQueryQuotas(ctx context.Context) error {
+	if err := ts.globalCell.Delete(ctx, QueryQuotasFile, nil); err != nil && !IsErrType(err, NoNode) {
+		return err
+	}
+	return nil
+}
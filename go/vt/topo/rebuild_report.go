@@ -0,0 +1,182 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topo
+
+import (
+	"sort"
+
+	"google.golang.org/protobuf/proto"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+)
+
+// RebuildReport describes the rebuild of a serving graph object, a
+// SrvKeyspace or the SrvVSchema, in each cell, so the partial rebuilds
+// can be detected and retried.
+type RebuildReport struct {
+	// Keyspace is the keyspace of the rebuilt SrvKeyspace, empty for the
+	// SrvVSchema.
+	Keyspace string               `json:"keyspace,omitempty"`
+	Cells    []*CellRebuildStatus `json:"cells"`
+}
+
+// CellRebuildStatus describes the rebuild of a serving graph object in a
+// cell.
+type CellRebuildStatus struct {
+	Cell string `json:"cell"`
+	// Error is set if the object couldn't be saved in the cell.
+	Error string `json:"error,omitempty"`
+	// PreviousVersion is the version of the object before the rebuild,
+	// empty if it didn't exist.
+	PreviousVersion string `json:"previous_version,omitempty"`
+	// NewVersion is the version of the object after the rebuild.
+	NewVersion string `json:"new_version,omitempty"`
+	// Changed is true if the rebuild changed the object.
+	Changed bool `json:"changed"`
+	// PartitionChanges lists the changed partitions of a SrvKeyspace.
+	PartitionChanges []*PartitionChange `json:"partition_changes,omitempty"`
+	// VSchemaChanges lists the changes of the SrvVSchema.
+	VSchemaChanges []string `json:"vschema_changes,omitempty"`
+}
+
+// PartitionChange describes the change of the partition of a tablet type
+// in a SrvKeyspace.
+type PartitionChange struct {
+	ServedType    topodatapb.TabletType `json:"served_type"`
+	AddedShards   []string              `json:"added_shards,omitempty"`
+	RemovedShards []string              `json:"removed_shards,omitempty"`
+	// TabletControlsChanged is true if the shard tablet controls of the
+	// partition changed, e.g. its query service was enabled or disabled.
+	TabletControlsChanged bool `json:"tablet_controls_changed,omitempty"`
+}
+
+// FailedCells returns the cells where the rebuild failed.
+func (r *RebuildReport) FailedCells() []string {
+	var cells []string
+	for _, status := range r.Cells {
+		if status.Error != "" {
+			cells = append(cells, status.Cell)
+		}
+	}
+	return cells
+}
+
+// DiffSrvKeyspacePartitions returns the changes of the partitions of a
+// SrvKeyspace. previous is nil if it didn't exist.
+func DiffSrvKeyspacePartitions(previous, current *topodatapb.SrvKeyspace) []*PartitionChange {
+	var changes []*PartitionChange
+	for _, tabletType := range servedTypesOf(previous, current) {
+		before := partitionOf(previous, tabletType)
+		after := partitionOf(current, tabletType)
+		change := &PartitionChange{ServedType: tabletType}
+		beforeShards := shardReferenceNames(before)
+		afterShards := shardReferenceNames(after)
+		for name := range afterShards {
+			if !beforeShards[name] {
+				change.AddedShards = append(change.AddedShards, name)
+			}
+		}
+		for name := range beforeShards {
+			if !afterShards[name] {
+				change.RemovedShards = append(change.RemovedShards, name)
+			}
+		}
+		change.TabletControlsChanged = !shardTabletControlsEqual(before.GetShardTabletControls(), after.GetShardTabletControls())
+		if len(change.AddedShards) == 0 && len(change.RemovedShards) == 0 && !change.TabletControlsChanged {
+			continue
+		}
+		sort.Strings(change.AddedShards)
+		sort.Strings(change.RemovedShards)
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// servedTypesOf returns the served types of the partitions of the
+// SrvKeyspaces, in order.
+func servedTypesOf(srvKeyspaces ...*topodatapb.SrvKeyspace) []topodatapb.TabletType {
+	set := make(map[topodatapb.TabletType]bool)
+	for _, srvKeyspace := range srvKeyspaces {
+		for _, partition := range srvKeyspace.GetPartitions() {
+			set[partition.ServedType] = true
+		}
+	}
+	tabletTypes := make([]topodatapb.TabletType, 0, len(set))
+	for tabletType := range set {
+		tabletTypes = append(tabletTypes, tabletType)
+	}
+	sort.Slice(tabletTypes, func(i, j int) bool { return tabletTypes[i] < tabletTypes[j] })
+	return tabletTypes
+}
+
+// partitionOf returns the partition of a tablet type of a SrvKeyspace,
+// which may be nil.
+func partitionOf(srvKeyspace *topodatapb.SrvKeyspace, tabletType topodatapb.TabletType) *topodatapb.SrvKeyspace_KeyspacePartition {
+	for _, partition := range srvKeyspace.GetPartitions() {
+		if partition.ServedType == tabletType {
+			return partition
+		}
+	}
+	return nil
+}
+
+func shardReferenceNames(partition *topodatapb.SrvKeyspace_KeyspacePartition) map[string]bool {
+	names := make(map[string]bool)
+	for _, shardReference := range partition.GetShardReferences() {
+		names[shardReference.Name] = true
+	}
+	return names
+}
+
+func shardTabletControlsEqual(a, b []*topodatapb.ShardTabletControl) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !proto.Equal(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// diffSrvVSchemas describes the changes of a SrvVSchema: the keyspaces
+// whose vschema was added, removed or changed, and the routing rules.
+// previous is nil if it didn't exist.
+func diffSrvVSchemas(previous, current *vschemapb.SrvVSchema) []string {
+	var changes []string
+	for keyspace, vschema := range current.GetKeyspaces() {
+		previousVSchema, ok := previous.GetKeyspaces()[keyspace]
+		switch {
+		case !ok:
+			changes = append(changes, "added keyspace "+keyspace)
+		case !proto.Equal(previousVSchema, vschema):
+			changes = append(changes, "changed keyspace "+keyspace)
+		}
+	}
+	for keyspace := range previous.GetKeyspaces() {
+		if _, ok := current.GetKeyspaces()[keyspace]; !ok {
+			changes = append(changes, "removed keyspace "+keyspace)
+		}
+	}
+	sort.Strings(changes)
+	if !proto.Equal(previous.GetRoutingRules(), current.GetRoutingRules()) {
+		changes = append(changes, "changed routing rules")
+	}
+	return changes
+}
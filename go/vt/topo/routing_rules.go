@@ -0,0 +1,93 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topo
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/proto"
+
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+)
+
+// This file contains the methods to update the routing rules with
+// compare-and-swap semantics, so concurrent updates don't overwrite each
+// other.
+
+// GetRoutingRulesWithVersion returns the routing rules, and their version
+// in the topo. The version is nil if there are no routing rules.
+func (ts *Server) GetRoutingRulesWithVersion(ctx context.Context) (*vschemapb.RoutingRules, Version, error) {
+	rr := &vschemapb.RoutingRules{}
+	data, version, err := ts.globalCell.Get(ctx, RoutingRulesFile)
+	if err != nil {
+		if IsErrType(err, NoNode) {
+			return rr, nil, nil
+		}
+		return nil, nil, err
+	}
+	if err := proto.Unmarshal(data, rr); err != nil {
+		return nil, nil, vterrors.Wrapf(err, "bad routing rules data: %q", data)
+	}
+	return rr, version, nil
+}
+
+// UpdateRoutingRulesIfVersion saves the routing rules, only if their
+// current version is expectedVersion, as returned by
+// GetRoutingRulesWithVersion. It returns a BadVersion error otherwise. An
+// empty expectedVersion means there must be no routing rules yet, and
+// fails with a NodeExists error if there are.
+func (ts *Server) UpdateRoutingRulesIfVersion(ctx context.Context, routingRules *vschemapb.RoutingRules, expectedVersion string) error {
+	data, err := proto.Marshal(routingRules)
+	if err != nil {
+		return err
+	}
+	if expectedVersion == "" {
+		if len(data) == 0 {
+			// Nothing to save, but the precondition still applies.
+			if _, _, err := ts.globalCell.Get(ctx, RoutingRulesFile); !IsErrType(err, NoNode) {
+				if err == nil {
+					return NewError(NodeExists, RoutingRulesFile)
+				}
+				return err
+			}
+			return nil
+		}
+		_, err = ts.globalCell.Create(ctx, RoutingRulesFile, data)
+		return err
+	}
+
+	// The version can only be compared as a string, so the update is made
+	// with the version read, which fails if the rules changed since then.
+	_, version, err := ts.globalCell.Get(ctx, RoutingRulesFile)
+	if err != nil {
+		if IsErrType(err, NoNode) {
+			return NewError(BadVersion, RoutingRulesFile)
+		}
+		return err
+	}
+	if version.String() != expectedVersion {
+		return NewError(BadVersion, RoutingRulesFile)
+	}
+	if len(data) == 0 {
+		// No rules, remove them, as SaveRoutingRules does.
+		return ts.globalCell.Delete(ctx, RoutingRulesFile, version)
+	}
+	_, err = ts.globalCell.Update(ctx, RoutingRulesFile, data, version)
+	return err
+}
@@ -77,6 +77,10 @@ const (
 	SrvKeyspaceFile      = "SrvKeyspace"
 	RoutingRulesFile     = "RoutingRules"
 	ExternalClustersFile = "ExternalClusters"
+	QueryQuotasFile      = "QueryQuotas"
+	WorkflowThrottleFile = "WorkflowThrottling"
+	BackupScheduleFile   = "BackupSchedule"
+	VtGateFile           = "VtGate"
 )
 
 // Path for all object types.
@@ -86,6 +90,7 @@ const (
 	KeyspacesPath    = "keyspaces"
 	ShardsPath       = "shards"
 	TabletsPath      = "tablets"
+	VtGatesPath      = "vtgates"
 	MetadataPath     = "metadata"
 
 	ExternalClusterMySQL  = "mysql"
@@ -0,0 +1,68 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topo
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// This file contains the names of the serving flags of a keyspace, stored
+// in its keyspace record. They describe the operations in progress on the
+// keyspace, and vtgate exposes them to its clients, so applications can
+// adapt, e.g. defer their writes during a maintenance.
+
+// Well-known serving flags. Any other name matching servingFlagRegexp is
+// valid too.
+const (
+	// ServingFlagReshardingInProgress means the keyspace is being
+	// resharded, and its writes may be buffered or fail for a few seconds
+	// when they are switched.
+	ServingFlagReshardingInProgress = "resharding_in_progress"
+	// ServingFlagReadOnlyMaintenance means the keyspace doesn't accept
+	// writes for now.
+	ServingFlagReadOnlyMaintenance = "read_only_maintenance"
+)
+
+var servingFlagRegexp = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// ParseServingFlags parses a comma separated list of serving flags. It
+// returns them sorted and without duplicates.
+func ParseServingFlags(value string) ([]string, error) {
+	set := make(map[string]bool)
+	for _, flag := range strings.Split(value, ",") {
+		flag = strings.ToLower(strings.TrimSpace(flag))
+		if flag == "" {
+			continue
+		}
+		if !servingFlagRegexp.MatchString(flag) {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid serving flag %q: it must be made of lower case letters, digits and underscores", flag)
+		}
+		set[flag] = true
+	}
+	flags := make([]string, 0, len(set))
+	for flag := range set {
+		flags = append(flags, flag)
+	}
+	sort.Strings(flags)
+	return flags, nil
+}
@@ -585,18 +585,24 @@ func (ts *Server) MigrateServedType(ctx context.Context, keyspace string, shards
 
 // UpdateSrvKeyspace saves a new SrvKeyspace. It is a blind write.
 func (ts *Server) UpdateSrvKeyspace(ctx context.Context, cell, keyspace string, srvKeyspace *topodatapb.SrvKeyspace) error {
+	_, err := ts.UpdateSrvKeyspaceWithVersion(ctx, cell, keyspace, srvKeyspace)
+	return err
+}
+
+// UpdateSrvKeyspaceWithVersion saves a new SrvKeyspace, and returns its
+// version. It is a blind write.
+func (ts *Server) UpdateSrvKeyspaceWithVersion(ctx context.Context, cell, keyspace string, srvKeyspace *topodatapb.SrvKeyspace) (Version, error) {
 	conn, err := ts.ConnForCell(ctx, cell)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	nodePath := srvKeyspaceFileName(keyspace)
 	data, err := proto.Marshal(srvKeyspace)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	_, err = conn.Update(ctx, nodePath, data, nil)
-	return err
+	return conn.Update(ctx, nodePath, data, nil)
 }
 
 // DeleteSrvKeyspace deletes a SrvKeyspace.
@@ -634,21 +640,28 @@ func (ts *Server) GetSrvKeyspaceAllCells(ctx context.Context, keyspace string) (
 
 // GetSrvKeyspace returns the SrvKeyspace for a cell/keyspace.
 func (ts *Server) GetSrvKeyspace(ctx context.Context, cell, keyspace string) (*topodatapb.SrvKeyspace, error) {
+	srvKeyspace, _, err := ts.GetSrvKeyspaceWithVersion(ctx, cell, keyspace)
+	return srvKeyspace, err
+}
+
+// GetSrvKeyspaceWithVersion returns the SrvKeyspace for a cell/keyspace,
+// and its version.
+func (ts *Server) GetSrvKeyspaceWithVersion(ctx context.Context, cell, keyspace string) (*topodatapb.SrvKeyspace, Version, error) {
 	conn, err := ts.ConnForCell(ctx, cell)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	nodePath := srvKeyspaceFileName(keyspace)
-	data, _, err := conn.Get(ctx, nodePath)
+	data, version, err := conn.Get(ctx, nodePath)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	srvKeyspace := &topodatapb.SrvKeyspace{}
 	if err := proto.Unmarshal(data, srvKeyspace); err != nil {
-		return nil, vterrors.Wrapf(err, "SrvKeyspace unmarshal failed: %v", data)
+		return nil, nil, vterrors.Wrapf(err, "SrvKeyspace unmarshal failed: %v", data)
 	}
-	return srvKeyspace, nil
+	return srvKeyspace, version, nil
 }
 
 // OrderAndCheckPartitions will re-order the partition list, and check
@@ -0,0 +1,159 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topo
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+
+	"google.golang.org/protobuf/proto"
+
+	"vitess.io/vitess/go/vt/concurrency"
+	"vitess.io/vitess/go/vt/key"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// This file contains the methods to edit a SrvKeyspace by hand, e.g.
+// during a manual recovery, instead of writing it to the topo directly.
+
+// ValidateSrvKeyspacePartitions checks the partitions of a SrvKeyspace
+// before it is saved: each served type has a single partition, whose
+// shards cover the whole key space, without gaps or overlaps, and whose
+// tablet controls refer to its shards. It returns all the problems found.
+func ValidateSrvKeyspacePartitions(srvKeyspace *topodatapb.SrvKeyspace) error {
+	rec := concurrency.AllErrorRecorder{}
+	servedTypes := make(map[topodatapb.TabletType]bool)
+	for _, partition := range srvKeyspace.GetPartitions() {
+		servedType := partition.ServedType
+		if servedType == topodatapb.TabletType_UNKNOWN {
+			rec.RecordError(fmt.Errorf("partition without a served type"))
+		}
+		if servedTypes[servedType] {
+			rec.RecordError(fmt.Errorf("more than one partition for %v", servedType))
+		}
+		servedTypes[servedType] = true
+		validatePartitionShards(partition, &rec)
+	}
+	return rec.Error()
+}
+
+func validatePartitionShards(partition *topodatapb.SrvKeyspace_KeyspacePartition, rec *concurrency.AllErrorRecorder) {
+	servedType := partition.ServedType
+	if len(partition.ShardReferences) == 0 {
+		rec.RecordError(fmt.Errorf("partition for %v has no shards", servedType))
+		return
+	}
+
+	names := make(map[string]bool)
+	var ranged []*topodatapb.ShardReference
+	for _, ref := range partition.ShardReferences {
+		if ref.Name == "" {
+			rec.RecordError(fmt.Errorf("partition for %v has a shard without a name", servedType))
+		} else if names[ref.Name] {
+			rec.RecordError(fmt.Errorf("partition for %v has shard %v more than once", servedType, ref.Name))
+		}
+		names[ref.Name] = true
+		if ref.KeyRange != nil {
+			ranged = append(ranged, ref)
+		}
+	}
+	for _, control := range partition.ShardTabletControls {
+		if !names[control.Name] {
+			rec.RecordError(fmt.Errorf("partition for %v has a tablet control for shard %v, which is not in the partition", servedType, control.Name))
+		}
+	}
+
+	// Without key ranges, it is the custom sharding case, with one key
+	// range per shard, or an unsharded keyspace.
+	if len(ranged) == 0 {
+		return
+	}
+	if len(ranged) != len(partition.ShardReferences) {
+		rec.RecordError(fmt.Errorf("partition for %v mixes shards with and without key ranges", servedType))
+		return
+	}
+
+	// The key ranges are checked in order, without modifying the
+	// partition.
+	sort.SliceStable(ranged, func(i, j int) bool {
+		return bytes.Compare(ranged[i].KeyRange.Start, ranged[j].KeyRange.Start) < 0
+	})
+	for _, ref := range ranged {
+		kr := ref.KeyRange
+		if len(kr.End) != 0 && bytes.Compare(kr.Start, kr.End) >= 0 {
+			rec.RecordError(fmt.Errorf("partition for %v has shard %v with invalid key range %v", servedType, ref.Name, key.KeyRangeString(kr)))
+		}
+	}
+	if first := ranged[0]; len(first.KeyRange.Start) != 0 {
+		rec.RecordError(fmt.Errorf("partition for %v has a gap: no shard starts at the min key, the first one is %v", servedType, first.Name))
+	}
+	for i := 0; i < len(ranged)-1; i++ {
+		curr, next := ranged[i], ranged[i+1]
+		if len(curr.KeyRange.End) == 0 {
+			rec.RecordError(fmt.Errorf("partition for %v has overlapping shards %v and %v", servedType, curr.Name, next.Name))
+			continue
+		}
+		switch bytes.Compare(curr.KeyRange.End, next.KeyRange.Start) {
+		case 1:
+			rec.RecordError(fmt.Errorf("partition for %v has overlapping shards %v and %v", servedType, curr.Name, next.Name))
+		case -1:
+			rec.RecordError(fmt.Errorf("partition for %v has a gap between shards %v and %v", servedType, curr.Name, next.Name))
+		}
+	}
+	if last := ranged[len(ranged)-1]; len(last.KeyRange.End) != 0 {
+		rec.RecordError(fmt.Errorf("partition for %v has a gap: no shard ends at the max key, the last one is %v", servedType, last.Name))
+	}
+}
+
+// UpdateSrvKeyspaceIfVersion validates and saves a SrvKeyspace, only if
+// its current version in the cell is expectedVersion, as returned by
+// GetSrvKeyspaceWithVersion. It returns a BadVersion error otherwise. An
+// empty expectedVersion creates the SrvKeyspace, and fails with a
+// NodeExists error if it exists. The SrvKeyspace is saved as given, its
+// partitions are not sorted.
+func (ts *Server) UpdateSrvKeyspaceIfVersion(ctx context.Context, cell, keyspace string, srvKeyspace *topodatapb.SrvKeyspace, expectedVersion string) (Version, error) {
+	if err := ValidateSrvKeyspacePartitions(srvKeyspace); err != nil {
+		return nil, fmt.Errorf("invalid SrvKeyspace for %v in cell %v: %v", keyspace, cell, err)
+	}
+	conn, err := ts.ConnForCell(ctx, cell)
+	if err != nil {
+		return nil, err
+	}
+	nodePath := srvKeyspaceFileName(keyspace)
+	data, err := proto.Marshal(srvKeyspace)
+	if err != nil {
+		return nil, err
+	}
+	if expectedVersion == "" {
+		return conn.Create(ctx, nodePath, data)
+	}
+
+	// The version can only be compared as a string, so the update is
+	// made with the version read, which fails if the SrvKeyspace changed
+	// since then.
+	_, version, err := conn.Get(ctx, nodePath)
+	if err != nil {
+		return nil, err
+	}
+	if version.String() != expectedVersion {
+		return nil, NewError(BadVersion, nodePath)
+	}
+	return conn.Update(ctx, nodePath, data, version)
+}
@@ -97,37 +97,50 @@ func (ts *Server) WatchSrvVSchema(ctx context.Context, cell string) (*WatchSrvVS
 
 // UpdateSrvVSchema updates the SrvVSchema file for a cell.
 func (ts *Server) UpdateSrvVSchema(ctx context.Context, cell string, srvVSchema *vschemapb.SrvVSchema) error {
+	_, err := ts.UpdateSrvVSchemaWithVersion(ctx, cell, srvVSchema)
+	return err
+}
+
+// UpdateSrvVSchemaWithVersion updates the SrvVSchema file for a cell, and
+// returns its version.
+func (ts *Server) UpdateSrvVSchemaWithVersion(ctx context.Context, cell string, srvVSchema *vschemapb.SrvVSchema) (Version, error) {
 	conn, err := ts.ConnForCell(ctx, cell)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	nodePath := SrvVSchemaFile
 	data, err := proto.Marshal(srvVSchema)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	_, err = conn.Update(ctx, nodePath, data, nil)
-	return err
+	return conn.Update(ctx, nodePath, data, nil)
 }
 
 // GetSrvVSchema returns the SrvVSchema for a cell.
 func (ts *Server) GetSrvVSchema(ctx context.Context, cell string) (*vschemapb.SrvVSchema, error) {
+	srvVSchema, _, err := ts.GetSrvVSchemaWithVersion(ctx, cell)
+	return srvVSchema, err
+}
+
+// GetSrvVSchemaWithVersion returns the SrvVSchema for a cell, and its
+// version.
+func (ts *Server) GetSrvVSchemaWithVersion(ctx context.Context, cell string) (*vschemapb.SrvVSchema, Version, error) {
 	conn, err := ts.ConnForCell(ctx, cell)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	nodePath := SrvVSchemaFile
-	data, _, err := conn.Get(ctx, nodePath)
+	data, version, err := conn.Get(ctx, nodePath)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	srvVSchema := &vschemapb.SrvVSchema{}
 	if err := proto.Unmarshal(data, srvVSchema); err != nil {
-		return nil, vterrors.Wrapf(err, "SrvVSchema unmarshal failed: %v", data)
+		return nil, nil, vterrors.Wrapf(err, "SrvVSchema unmarshal failed: %v", data)
 	}
-	return srvVSchema, nil
+	return srvVSchema, version, nil
 }
 
 // DeleteSrvVSchema deletes the SrvVSchema file for a cell.
@@ -144,19 +157,28 @@ func (ts *Server) DeleteSrvVSchema(ctx context.Context, cell string) error {
 // RebuildVSchema rebuilds the SrvVSchema for the provided cell list
 // (or all cells if cell list is empty).
 func (ts *Server) RebuildSrvVSchema(ctx context.Context, cells []string) error {
+	_, err := ts.RebuildSrvVSchemaWithReport(ctx, cells)
+	return err
+}
+
+// RebuildSrvVSchemaWithReport rebuilds the SrvVSchema like
+// RebuildSrvVSchema, and reports how it went in each cell. If the
+// SrvVSchema couldn't be built, it only returns an error. Otherwise the
+// report is returned along with the errors of the cells, if any.
+func (ts *Server) RebuildSrvVSchemaWithReport(ctx context.Context, cells []string) (*RebuildReport, error) {
 	// get the actual list of cells
 	if len(cells) == 0 {
 		var err error
 		cells, err = ts.GetKnownCells(ctx)
 		if err != nil {
-			return fmt.Errorf("GetKnownCells failed: %v", err)
+			return nil, fmt.Errorf("GetKnownCells failed: %v", err)
 		}
 	}
 
 	// get the keyspaces
 	keyspaces, err := ts.GetKeyspaces(ctx)
 	if err != nil {
-		return fmt.Errorf("GetKeyspaces failed: %v", err)
+		return nil, fmt.Errorf("GetKeyspaces failed: %v", err)
 	}
 
 	// build the SrvVSchema in parallel, protected by mu
@@ -189,29 +211,53 @@ func (ts *Server) RebuildSrvVSchema(ctx context.Context, cells []string) error {
 	}
 	wg.Wait()
 	if finalErr != nil {
-		return finalErr
+		return nil, finalErr
 	}
 
 	rr, err := ts.GetRoutingRules(ctx)
 	if err != nil {
-		return fmt.Errorf("GetRoutingRules failed: %v", err)
+		return nil, fmt.Errorf("GetRoutingRules failed: %v", err)
 	}
 	srvVSchema.RoutingRules = rr
 
 	// now save the SrvVSchema in all cells in parallel
-	for _, cell := range cells {
+	report := &RebuildReport{Cells: make([]*CellRebuildStatus, len(cells))}
+	for i, cell := range cells {
+		status := &CellRebuildStatus{Cell: cell}
+		report.Cells[i] = status
 		wg.Add(1)
-		go func(cell string) {
+		go func(cell string, status *CellRebuildStatus) {
 			defer wg.Done()
-			if err := ts.UpdateSrvVSchema(ctx, cell, srvVSchema); err != nil {
+			previous, previousVersion, err := ts.GetSrvVSchemaWithVersion(ctx, cell)
+			previousKnown := true
+			switch {
+			case err == nil:
+				status.PreviousVersion = previousVersion.String()
+			case IsErrType(err, NoNode):
+				// It's created.
+			default:
+				// It's overwritten anyway, only the diff is lost.
+				log.Warningf("%v: GetSrvVSchema(%v) failed", err, cell)
+				previousKnown = false
+			}
+
+			version, err := ts.UpdateSrvVSchemaWithVersion(ctx, cell, srvVSchema)
+			if err != nil {
 				log.Errorf("%v: UpdateSrvVSchema(%v) failed", err, cell)
+				status.Error = err.Error()
 				mu.Lock()
 				finalErr = err
 				mu.Unlock()
+				return
+			}
+			status.NewVersion = version.String()
+			if previousKnown {
+				status.Changed = !proto.Equal(previous, srvVSchema)
+				status.VSchemaChanges = diffSrvVSchemas(previous, srvVSchema)
 			}
-		}(cell)
+		}(cell, status)
 	}
 	wg.Wait()
 
-	return finalErr
+	return report, finalErr
 }
@@ -19,6 +19,9 @@ package topoproto
 import (
 	"fmt"
 	"strings"
+	"time"
+
+	"vitess.io/vitess/go/vt/logutil"
 
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 )
@@ -64,3 +67,26 @@ func KeyspaceIDTypeString(kidType topodatapb.KeyspaceIdType) string {
 func KeyspaceIDTypeLString(kidType topodatapb.KeyspaceIdType) string {
 	return strings.ToLower(KeyspaceIDTypeString(kidType))
 }
+
+// QueryPinExpired returns true if the query pin expired at time now.
+func QueryPinExpired(pin *topodatapb.QueryPin, now time.Time) bool {
+	return pin.Expires != nil && !now.Before(logutil.ProtoToTime(pin.Expires))
+}
+
+// ReplicationLagThresholdsEmpty returns true if no replication lag
+// threshold is set.
+func ReplicationLagThresholdsEmpty(rt *topodatapb.ReplicationLagThresholds) bool {
+	return rt == nil || (rt.Default == nil && len(rt.TabletTypes) == 0)
+}
+
+// ReplicationLagThresholdForTabletType returns the replication lag
+// thresholds of a tablet type, in lower case, or nil if none is set.
+func ReplicationLagThresholdForTabletType(rt *topodatapb.ReplicationLagThresholds, tabletType string) *topodatapb.ReplicationLagThreshold {
+	if rt == nil {
+		return nil
+	}
+	if threshold, ok := rt.TabletTypes[tabletType]; ok {
+		return threshold
+	}
+	return rt.Default
+}
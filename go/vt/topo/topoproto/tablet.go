@@ -41,6 +41,21 @@ import (
 const (
 	// VtDbPrefix + keyspace is the default name for databases.
 	VtDbPrefix = "vt_"
+
+	// DrainedTabletTag is the tablet tag set on drained tablets. The
+	// gateways don't send new queries to drained tablets, but let the
+	// in-flight ones finish.
+	DrainedTabletTag = "drained"
+
+	// TrafficWeightTabletTag is the tablet tag holding the traffic weight
+	// of a tablet, from 0 to MaxTabletTrafficWeight. The gateways send a
+	// share of the queries of a target to a tablet proportional to its
+	// weight, e.g. to ramp up the traffic of a canary tablet.
+	TrafficWeightTabletTag = "traffic_weight"
+
+	// MaxTabletTrafficWeight is the weight of the tablets without a
+	// traffic weight tag.
+	MaxTabletTrafficWeight = 100
 )
 
 // cache the conversion from tablet type enum to lower case string.
@@ -218,6 +233,54 @@ func MakeStringTypeList(types []topodatapb.TabletType) []string {
 	return strs
 }
 
+// IsTabletDrained returns true if the tablet is drained.
+func IsTabletDrained(tablet *topodatapb.Tablet) bool {
+	return tablet.GetTags()[DrainedTabletTag] == "true"
+}
+
+// SetTabletDrained sets or clears the drained tag of the tablet.
+func SetTabletDrained(tablet *topodatapb.Tablet, drained bool) {
+	if !drained {
+		delete(tablet.Tags, DrainedTabletTag)
+		return
+	}
+	if tablet.Tags == nil {
+		tablet.Tags = make(map[string]string)
+	}
+	tablet.Tags[DrainedTabletTag] = "true"
+}
+
+// TabletTrafficWeight returns the traffic weight of the tablet. Tablets
+// without a valid weight tag have the maximum weight.
+func TabletTrafficWeight(tablet *topodatapb.Tablet) int {
+	value, ok := tablet.GetTags()[TrafficWeightTabletTag]
+	if !ok {
+		return MaxTabletTrafficWeight
+	}
+	weight, err := strconv.Atoi(value)
+	if err != nil || weight < 0 || weight > MaxTabletTrafficWeight {
+		return MaxTabletTrafficWeight
+	}
+	return weight
+}
+
+// SetTabletTrafficWeight sets the traffic weight tag of the tablet. The
+// maximum weight clears the tag.
+func SetTabletTrafficWeight(tablet *topodatapb.Tablet, weight int) error {
+	if weight < 0 || weight > MaxTabletTrafficWeight {
+		return fmt.Errorf("invalid traffic weight %v, must be between 0 and %v", weight, MaxTabletTrafficWeight)
+	}
+	if weight == MaxTabletTrafficWeight {
+		delete(tablet.Tags, TrafficWeightTabletTag)
+		return nil
+	}
+	if tablet.Tags == nil {
+		tablet.Tags = make(map[string]string)
+	}
+	tablet.Tags[TrafficWeightTabletTag] = strconv.Itoa(weight)
+	return nil
+}
+
 // MysqlAddr returns the host:port of the mysql server.
 func MysqlAddr(tablet *topodatapb.Tablet) string {
 	return netutil.JoinHostPort(tablet.MysqlHostname, tablet.MysqlPort)
@@ -70,3 +70,50 @@ func TestParseTabletAlias(t *testing.T) {
 		}
 	}
 }
+
+func TestSetTabletDrained(t *testing.T) {
+	tablet := &topodatapb.Tablet{}
+	if IsTabletDrained(tablet) {
+		t.Fatalf("IsTabletDrained(%v) = true, want false", tablet)
+	}
+	SetTabletDrained(tablet, true)
+	if !IsTabletDrained(tablet) {
+		t.Fatalf("IsTabletDrained(%v) = false, want true", tablet)
+	}
+	SetTabletDrained(tablet, false)
+	if IsTabletDrained(tablet) || len(tablet.Tags) != 0 {
+		t.Fatalf("SetTabletDrained(false) did not clear the tag: %v", tablet)
+	}
+	// Clearing the tag of a tablet without tags is a no-op.
+	SetTabletDrained(&topodatapb.Tablet{}, false)
+}
+
+func TestTabletTrafficWeight(t *testing.T) {
+	tablet := &topodatapb.Tablet{}
+	if got := TabletTrafficWeight(tablet); got != MaxTabletTrafficWeight {
+		t.Fatalf("TabletTrafficWeight(%v) = %v, want %v", tablet, got, MaxTabletTrafficWeight)
+	}
+	for _, weight := range []int{0, 1, 50} {
+		if err := SetTabletTrafficWeight(tablet, weight); err != nil {
+			t.Fatalf("SetTabletTrafficWeight(%v) failed: %v", weight, err)
+		}
+		if got := TabletTrafficWeight(tablet); got != weight {
+			t.Fatalf("TabletTrafficWeight(%v) = %v, want %v", tablet, got, weight)
+		}
+	}
+	if err := SetTabletTrafficWeight(tablet, MaxTabletTrafficWeight); err != nil || len(tablet.Tags) != 0 {
+		t.Fatalf("SetTabletTrafficWeight(%v) did not clear the tag: %v, %v", MaxTabletTrafficWeight, tablet, err)
+	}
+	for _, weight := range []int{-1, 101} {
+		if err := SetTabletTrafficWeight(tablet, weight); err == nil {
+			t.Fatalf("SetTabletTrafficWeight(%v) succeeded, want an error", weight)
+		}
+	}
+	// Invalid tags are ignored.
+	for _, value := range []string{"", "x", "-1", "101"} {
+		tablet.Tags = map[string]string{TrafficWeightTabletTag: value}
+		if got := TabletTrafficWeight(tablet); got != MaxTabletTrafficWeight {
+			t.Fatalf("TabletTrafficWeight(%v) = %v, want %v", tablet, got, MaxTabletTrafficWeight)
+		}
+	}
+}
@@ -226,3 +226,42 @@ func TestExpandCells(t *testing.T) {
 		}
 	})
 }
+
+func TestDeleteCellInfoSafetyChecks(t *testing.T) {
+	ctx := context.Background()
+	ts := memorytopo.NewServer("cell1", "cell2")
+
+	// A cell can't have the name of a cells alias.
+	require.NoError(t, ts.CreateCellsAlias(ctx, "region1", &topodatapb.CellsAlias{Cells: []string{"cell1"}}))
+	err := ts.CreateCellInfo(ctx, "region1", &topodatapb.CellInfo{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "has the name of a cells alias")
+
+	// A cell referenced by a cells alias can't be deleted, even with force.
+	err = ts.DeleteCellInfo(ctx, "cell1", true)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "region1")
+	require.NoError(t, ts.DeleteCellsAlias(ctx, "region1"))
+
+	// A cell with tablets can only be deleted with force.
+	require.NoError(t, ts.CreateTablet(ctx, &topodatapb.Tablet{
+		Alias:    &topodatapb.TabletAlias{Cell: "cell2", Uid: 1},
+		Keyspace: "ks",
+		Shard:    "0",
+	}))
+	err = ts.DeleteCellInfo(ctx, "cell2", false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "still has 1 tablets")
+	require.NoError(t, ts.DeleteCellInfo(ctx, "cell2", true))
+
+	// Draining a cell removes it from the serving graph of the keyspaces,
+	// so it can be deleted.
+	require.NoError(t, ts.CreateKeyspace(ctx, "ks", &topodatapb.Keyspace{}))
+	require.NoError(t, ts.UpdateSrvKeyspace(ctx, "cell1", "ks", &topodatapb.SrvKeyspace{}))
+	require.NoError(t, ts.UpdateSrvKeyspace(ctx, "cell1", "deleted_ks", &topodatapb.SrvKeyspace{}))
+	require.Error(t, ts.DeleteCellInfo(ctx, "cell1", false))
+	drained, err := ts.DrainCell(ctx, "cell1")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"deleted_ks", "ks"}, drained)
+	require.NoError(t, ts.DeleteCellInfo(ctx, "cell1", false))
+}
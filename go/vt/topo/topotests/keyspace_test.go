@@ -0,0 +1,70 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topotests
+
+import (
+	"context"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	workflowpb "vitess.io/vitess/go/vt/proto/workflow"
+)
+
+// This file tests the Keyspace part of the topo.Server API.
+
+// TestDeleteKeyspace checks that DeleteKeyspace removes all the
+// per-keyspace files, s.t. a keyspace recreated with the same name
+// doesn't inherit them.
+func TestDeleteKeyspace(t *testing.T) {
+	ctx := context.Background()
+	ts := memorytopo.NewServer("cell1")
+	keyspace := "ks"
+
+	require.NoError(t, ts.CreateKeyspace(ctx, keyspace, &topodatapb.Keyspace{}))
+	require.NoError(t, ts.SaveVSchema(ctx, keyspace, &vschemapb.Keyspace{}))
+	require.NoError(t, ts.UpdateWorkflowThrottling(ctx, keyspace, "wf", &topodatapb.WorkflowThrottling{RowsPerSecond: 10}))
+	for _, workflow := range []string{"wf1", "wf2"} {
+		require.NoError(t, ts.AppendVDiffResult(ctx, keyspace, workflow, &workflowpb.VDiffResult{}, 0))
+	}
+	require.NoError(t, ts.SaveBackupSchedule(ctx, keyspace, &topodatapb.BackupSchedule{Cron: "0 3 * * *"}))
+
+	require.NoError(t, ts.DeleteKeyspace(ctx, keyspace))
+
+	conn, err := ts.ConnForCell(ctx, topo.GlobalCell)
+	require.NoError(t, err)
+	entries, err := conn.ListDir(ctx, path.Join(topo.KeyspacesPath, keyspace), false /*full*/)
+	if !topo.IsErrType(err, topo.NoNode) {
+		require.NoError(t, err)
+		require.Empty(t, entries, "files left behind by DeleteKeyspace")
+	}
+
+	schedule, err := ts.GetBackupSchedule(ctx, keyspace)
+	require.NoError(t, err)
+	assert.Nil(t, schedule, "backup schedule left behind by DeleteKeyspace")
+
+	keyspaces, err := ts.GetKeyspaces(ctx)
+	require.NoError(t, err)
+	assert.NotContains(t, keyspaces, keyspace)
+}
@@ -1240,3 +1240,98 @@ func TestValidateSrvKeyspace(t *testing.T) {
 	err = ts.ValidateSrvKeyspace(ctx, keyspace, "")
 	require.EqualError(t, err, errMsg)
 }
+
+func TestValidateSrvKeyspacePartitions(t *testing.T) {
+	keyRange := func(spec string) *topodatapb.KeyRange {
+		t.Helper()
+		kr, err := key.ParseShardingSpec(spec)
+		require.NoError(t, err)
+		return kr[0]
+	}
+	partition := func(servedType topodatapb.TabletType, shards ...string) *topodatapb.SrvKeyspace_KeyspacePartition {
+		p := &topodatapb.SrvKeyspace_KeyspacePartition{ServedType: servedType}
+		for _, shard := range shards {
+			p.ShardReferences = append(p.ShardReferences, &topodatapb.ShardReference{Name: shard, KeyRange: keyRange(shard)})
+		}
+		return p
+	}
+
+	testcases := []struct {
+		name       string
+		partitions []*topodatapb.SrvKeyspace_KeyspacePartition
+		err        string
+	}{{
+		name:       "valid",
+		partitions: []*topodatapb.SrvKeyspace_KeyspacePartition{partition(topodatapb.TabletType_MASTER, "80-", "-80"), partition(topodatapb.TabletType_REPLICA, "-")},
+	}, {
+		name:       "unsharded",
+		partitions: []*topodatapb.SrvKeyspace_KeyspacePartition{{ServedType: topodatapb.TabletType_MASTER, ShardReferences: []*topodatapb.ShardReference{{Name: "0"}}}},
+	}, {
+		name:       "gap",
+		partitions: []*topodatapb.SrvKeyspace_KeyspacePartition{partition(topodatapb.TabletType_MASTER, "-40", "80-")},
+		err:        "partition for MASTER has a gap between shards -40 and 80-",
+	}, {
+		name:       "overlap",
+		partitions: []*topodatapb.SrvKeyspace_KeyspacePartition{partition(topodatapb.TabletType_MASTER, "-80", "40-")},
+		err:        "partition for MASTER has overlapping shards -80 and 40-",
+	}, {
+		name:       "missing ends",
+		partitions: []*topodatapb.SrvKeyspace_KeyspacePartition{partition(topodatapb.TabletType_MASTER, "40-80")},
+		err:        "partition for MASTER has a gap: no shard starts at the min key, the first one is 40-80;partition for MASTER has a gap: no shard ends at the max key, the last one is 40-80",
+	}, {
+		name:       "duplicate served type",
+		partitions: []*topodatapb.SrvKeyspace_KeyspacePartition{partition(topodatapb.TabletType_MASTER, "-"), partition(topodatapb.TabletType_MASTER, "-")},
+		err:        "more than one partition for MASTER",
+	}, {
+		name: "tablet control of another shard",
+		partitions: []*topodatapb.SrvKeyspace_KeyspacePartition{{
+			ServedType:          topodatapb.TabletType_MASTER,
+			ShardReferences:     []*topodatapb.ShardReference{{Name: "0"}},
+			ShardTabletControls: []*topodatapb.ShardTabletControl{{Name: "1", QueryServiceDisabled: true}},
+		}},
+		err: "partition for MASTER has a tablet control for shard 1, which is not in the partition",
+	}}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := topo.ValidateSrvKeyspacePartitions(&topodatapb.SrvKeyspace{Partitions: tc.partitions})
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestUpdateSrvKeyspaceIfVersion(t *testing.T) {
+	ctx := context.Background()
+	ts := memorytopo.NewServer("cell1")
+	srvKeyspace := &topodatapb.SrvKeyspace{
+		Partitions: []*topodatapb.SrvKeyspace_KeyspacePartition{{
+			ServedType:      topodatapb.TabletType_MASTER,
+			ShardReferences: []*topodatapb.ShardReference{{Name: "0"}},
+		}},
+	}
+
+	version, err := ts.UpdateSrvKeyspaceIfVersion(ctx, "cell1", "ks1", srvKeyspace, "")
+	require.NoError(t, err)
+	_, err = ts.UpdateSrvKeyspaceIfVersion(ctx, "cell1", "ks1", srvKeyspace, "")
+	require.True(t, topo.IsErrType(err, topo.NodeExists), "%v", err)
+
+	srvKeyspace.Partitions[0].ShardTabletControls = []*topodatapb.ShardTabletControl{{Name: "0", QueryServiceDisabled: true}}
+	newVersion, err := ts.UpdateSrvKeyspaceIfVersion(ctx, "cell1", "ks1", srvKeyspace, version.String())
+	require.NoError(t, err)
+	got, gotVersion, err := ts.GetSrvKeyspaceWithVersion(ctx, "cell1", "ks1")
+	require.NoError(t, err)
+	require.True(t, proto.Equal(srvKeyspace, got), "got %v, want %v", got, srvKeyspace)
+	require.Equal(t, newVersion.String(), gotVersion.String())
+
+	// The SrvKeyspace changed since the first version.
+	_, err = ts.UpdateSrvKeyspaceIfVersion(ctx, "cell1", "ks1", srvKeyspace, version.String())
+	require.True(t, topo.IsErrType(err, topo.BadVersion), "%v", err)
+
+	// An invalid SrvKeyspace is not saved.
+	srvKeyspace.Partitions[0].ShardReferences = nil
+	_, err = ts.UpdateSrvKeyspaceIfVersion(ctx, "cell1", "ks1", srvKeyspace, newVersion.String())
+	require.EqualError(t, err, "invalid SrvKeyspace for ks1 in cell cell1: partition for MASTER has no shards")
+}
@@ -18,6 +18,8 @@ package topotests
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
 
 	"context"
@@ -186,3 +188,49 @@ func TestRebuildVSchema(t *testing.T) {
 		}
 	}
 }
+
+func TestRebuildVSchemaWithReport(t *testing.T) {
+	ctx := context.Background()
+	cells := []string{"cell1", "cell2"}
+	ts := memorytopo.NewServer(cells...)
+	if err := ts.CreateKeyspace(ctx, "ks1", &topodatapb.Keyspace{}); err != nil {
+		t.Fatalf("CreateKeyspace(ks1) failed: %v", err)
+	}
+
+	// Only cell1 has a SrvVSchema.
+	if err := ts.RebuildSrvVSchema(ctx, []string{"cell1"}); err != nil {
+		t.Fatalf("RebuildVSchema failed: %v", err)
+	}
+	if err := ts.SaveVSchema(ctx, "ks1", &vschemapb.Keyspace{Sharded: true}); err != nil {
+		t.Fatalf("SaveVSchema(ks1) failed: %v", err)
+	}
+	report, err := ts.RebuildSrvVSchemaWithReport(ctx, cells)
+	require.NoError(t, err)
+	require.Len(t, report.Cells, 2)
+	assert.Empty(t, report.FailedCells())
+
+	cell1, cell2 := report.Cells[0], report.Cells[1]
+	assert.Equal(t, "cell1", cell1.Cell)
+	assert.NotEmpty(t, cell1.PreviousVersion)
+	assert.NotEqual(t, cell1.PreviousVersion, cell1.NewVersion)
+	assert.True(t, cell1.Changed)
+	assert.Equal(t, []string{"changed keyspace ks1"}, cell1.VSchemaChanges)
+	assert.Equal(t, "cell2", cell2.Cell)
+	assert.Empty(t, cell2.PreviousVersion)
+	assert.NotEmpty(t, cell2.NewVersion)
+	assert.True(t, cell2.Changed)
+	assert.Equal(t, []string{"added keyspace ks1", "changed routing rules"}, cell2.VSchemaChanges)
+
+	// Nothing changes.
+	report, err = ts.RebuildSrvVSchemaWithReport(ctx, []string{"cell1"})
+	require.NoError(t, err)
+	assert.Equal(t, cell1.NewVersion, report.Cells[0].PreviousVersion)
+	assert.False(t, report.Cells[0].Changed)
+	assert.Empty(t, report.Cells[0].VSchemaChanges)
+
+	// An unknown cell fails, the others are rebuilt.
+	report, err = ts.RebuildSrvVSchemaWithReport(ctx, []string{"cell1", "cell3"})
+	require.Error(t, err)
+	assert.Equal(t, []string{"cell3"}, report.FailedCells())
+	assert.NotEmpty(t, report.Cells[0].NewVersion)
+}
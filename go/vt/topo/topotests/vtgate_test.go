@@ -0,0 +1,67 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topotests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/test/utils"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// This file tests the VtGate registry part of the topo.Server API.
+
+func TestVtGates(t *testing.T) {
+	ctx := context.Background()
+	ts := memorytopo.NewServer("zone1", "zone2")
+
+	vtgates, err := ts.GetVtGates(ctx, "zone1")
+	require.NoError(t, err)
+	assert.Empty(t, vtgates)
+
+	vtgate1 := &topodatapb.VtGate{Hostname: "host1", Port: 15001, GrpcPort: 15991}
+	vtgate2 := &topodatapb.VtGate{Hostname: "host2", Port: 15001}
+	require.NoError(t, ts.RegisterVtGate(ctx, "zone1", vtgate2))
+	require.NoError(t, ts.RegisterVtGate(ctx, "zone1", vtgate1))
+	require.NoError(t, ts.RegisterVtGate(ctx, "zone2", &topodatapb.VtGate{Hostname: "host3", Port: 15001}))
+
+	vtgates, err = ts.GetVtGates(ctx, "zone1")
+	require.NoError(t, err)
+	utils.MustMatch(t, []*topodatapb.VtGate{vtgate1, vtgate2}, vtgates)
+
+	// Registering again replaces the registration.
+	vtgate1.GrpcPort = 15992
+	require.NoError(t, ts.RegisterVtGate(ctx, "zone1", vtgate1))
+	vtgates, err = ts.GetVtGates(ctx, "zone1")
+	require.NoError(t, err)
+	utils.MustMatch(t, []*topodatapb.VtGate{vtgate1, vtgate2}, vtgates)
+
+	require.NoError(t, ts.UnregisterVtGate(ctx, "zone1", "host2:15001"))
+	vtgates, err = ts.GetVtGates(ctx, "zone1")
+	require.NoError(t, err)
+	utils.MustMatch(t, []*topodatapb.VtGate{vtgate1}, vtgates)
+
+	err = ts.UnregisterVtGate(ctx, "zone1", "host2:15001")
+	assert.True(t, topo.IsErrType(err, topo.NoNode), "unexpected error: %v", err)
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topo
+
+import (
+	"context"
+	"path"
+
+	"google.golang.org/protobuf/proto"
+
+	"vitess.io/vitess/go/vt/vterrors"
+
+	workflowpb "vitess.io/vitess/go/vt/proto/workflow"
+)
+
+// This file contains the methods to store the results of the VDiffs of
+// the vreplication workflows of a keyspace, s.t. the VDiffs run on a
+// schedule can be reviewed later, and an incremental VDiff knows when the
+// previous one ran. The results of a workflow are stored as a
+// workflowpb.VDiffResults in the global topo, from the oldest.
+
+const vdiffResultsPath = "vdiff_results"
+
+func pathForVDiffResults(keyspace, workflow string) string {
+	return path.Join(KeyspacesPath, keyspace, vdiffResultsPath, workflow)
+}
+
+// GetVDiffResults returns the stored results of the VDiffs of the
+// workflow, from the oldest. It returns no results if there are none.
+func (ts *Server) GetVDiffResults(ctx context.Context, keyspace, workflow string) ([]*workflowpb.VDiffResult, error) {
+	results, _, err := ts.getVDiffResults(ctx, keyspace, workflow)
+	if IsErrType(err, NoNode) {
+		return nil, nil
+	}
+	return results.GetResults(), err
+}
+
+func (ts *Server) getVDiffResults(ctx context.Context, keyspace, workflow string) (*workflowpb.VDiffResults, Version, error) {
+	data, version, err := ts.globalCell.Get(ctx, pathForVDiffResults(keyspace, workflow))
+	if err != nil {
+		return nil, nil, err
+	}
+	results := &workflowpb.VDiffResults{}
+	if err := proto.Unmarshal(data, results); err != nil {
+		return nil, nil, vterrors.Wrapf(err, "bad vdiff results data for workflow %v.%v", keyspace, workflow)
+	}
+	return results, version, nil
+}
+
+// AppendVDiffResult appends the result of a VDiff to the results of the
+// workflow, keeping at most maxResults of them. It retries if the results
+// are appended to concurrently.
+func (ts *Server) AppendVDiffResult(ctx context.Context, keyspace, workflow string, result *workflowpb.VDiffResult, maxResults int) error {
+	nodePath := pathForVDiffResults(keyspace, workflow)
+	for {
+		results, version, err := ts.getVDiffResults(ctx, keyspace, workflow)
+		if err != nil && !IsErrType(err, NoNode) {
+			return err
+		}
+		if results == nil {
+			results = &workflowpb.VDiffResults{}
+		}
+		results.Results = append(results.Results, result)
+		if maxResults > 0 && len(results.Results) > maxResults {
+			results.Results = results.Results[len(results.Results)-maxResults:]
+		}
+		data, err := proto.Marshal(results)
+		if err != nil {
+			return err
+		}
+
+		if version == nil {
+			_, err = ts.globalCell.Create(ctx, nodePath, data)
+		} else {
+			_, err = ts.globalCell.Update(ctx, nodePath, data, version)
+		}
+		if IsErrType(err, NodeExists) || IsErrType(err, BadVersion) {
+			continue
+		}
+		return err
+	}
+}
+
+// DeleteVDiffResults removes the stored results of the VDiffs of all the
+// workflows of the keyspace, if any.
+func (ts *Server) DeleteVDiffResults(ctx context.Context, keyspace string) error {
+	entries, err := ts.globalCell.ListDir(ctx, path.Join(KeyspacesPath, keyspace, vdiffResultsPath), false /*full*/)
+	switch {
+	case IsErrType(err, NoNode):
+		return nil
+	case err != nil:
+		return err
+	}
+	for _, entry := range entries {
+		if err := ts.globalCell.Delete(ctx, pathForVDiffResults(keyspace, entry.Name), nil); err != nil && !IsErrType(err, NoNode) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topo
+
+import (
+	"context"
+	"path"
+
+	"google.golang.org/protobuf/proto"
+
+	"vitess.io/vitess/go/netutil"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// This file contains the registry of the vtgates of a cell. A vtgate
+// registers itself in its cell when it starts and unregisters when it
+// stops, so that vtctld can reach the vtgates of a cell without being
+// told their addresses. A vtgate which didn't stop cleanly stays
+// registered until it is restarted on the same host and port.
+
+// VtGateAddr returns the host:port of the web server of a vtgate, which
+// also names it in the registry of its cell.
+func VtGateAddr(vtgate *topodatapb.VtGate) string {
+	return netutil.JoinHostPort(vtgate.Hostname, vtgate.Port)
+}
+
+func pathForVtGate(addr string) string {
+	return path.Join(VtGatesPath, addr, VtGateFile)
+}
+
+// RegisterVtGate registers a vtgate in a cell, replacing the previous
+// registration of the same host:port if any.
+func (ts *Server) RegisterVtGate(ctx context.Context, cell string, vtgate *topodatapb.VtGate) error {
+	conn, err := ts.ConnForCell(ctx, cell)
+	if err != nil {
+		return err
+	}
+	data, err := proto.Marshal(vtgate)
+	if err != nil {
+		return err
+	}
+	_, err = conn.Update(ctx, pathForVtGate(VtGateAddr(vtgate)), data, nil)
+	return err
+}
+
+// UnregisterVtGate removes the registration of the vtgate at addr in a
+// cell.
+func (ts *Server) UnregisterVtGate(ctx context.Context, cell, addr string) error {
+	conn, err := ts.ConnForCell(ctx, cell)
+	if err != nil {
+		return err
+	}
+	return conn.Delete(ctx, pathForVtGate(addr), nil)
+}
+
+// GetVtGates returns the vtgates registered in a cell, sorted by address.
+func (ts *Server) GetVtGates(ctx context.Context, cell string) ([]*topodatapb.VtGate, error) {
+	conn, err := ts.ConnForCell(ctx, cell)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := conn.ListDir(ctx, VtGatesPath, false /*full*/)
+	if err != nil {
+		if IsErrType(err, NoNode) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	vtgates := make([]*topodatapb.VtGate, 0, len(entries))
+	for _, addr := range DirEntriesToStringArray(entries) {
+		data, _, err := conn.Get(ctx, pathForVtGate(addr))
+		if err != nil {
+			if IsErrType(err, NoNode) {
+				// The vtgate unregistered since the listing.
+				continue
+			}
+			return nil, err
+		}
+		vtgate := &topodatapb.VtGate{}
+		if err := proto.Unmarshal(data, vtgate); err != nil {
+			return nil, vterrors.Wrapf(err, "VtGate unmarshal failed: %v", data)
+		}
+		vtgates = append(vtgates, vtgate)
+	}
+	return vtgates, nil
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topo
+
+import (
+	"context"
+	"path"
+
+	"google.golang.org/protobuf/proto"
+
+	"vitess.io/vitess/go/vt/vterrors"
+
+	workflowpb "vitess.io/vitess/go/vt/proto/workflow"
+)
+
+// This file contains the methods to store the audit log of the workflows,
+// apart from the workflows so it survives their deletion.
+
+const workflowAuditPath = "workflow_audit"
+
+func pathForWorkflowAuditLog(uuid string) string {
+	return path.Join(workflowAuditPath, uuid)
+}
+
+// GetWorkflowAuditLog returns the audit log of the workflow, or an empty
+// log if there is none.
+func (ts *Server) GetWorkflowAuditLog(ctx context.Context, uuid string) (*workflowpb.WorkflowAuditLog, error) {
+	auditLog, _, err := ts.getWorkflowAuditLog(ctx, uuid)
+	if IsErrType(err, NoNode) {
+		return &workflowpb.WorkflowAuditLog{}, nil
+	}
+	return auditLog, err
+}
+
+func (ts *Server) getWorkflowAuditLog(ctx context.Context, uuid string) (*workflowpb.WorkflowAuditLog, Version, error) {
+	data, version, err := ts.globalCell.Get(ctx, pathForWorkflowAuditLog(uuid))
+	if err != nil {
+		return nil, nil, err
+	}
+	auditLog := &workflowpb.WorkflowAuditLog{}
+	if err := proto.Unmarshal(data, auditLog); err != nil {
+		return nil, nil, vterrors.Wrapf(err, "bad audit log data for workflow %v", uuid)
+	}
+	return auditLog, version, nil
+}
+
+// AppendWorkflowAuditEntry appends the entry to the audit log of the
+// workflow. It retries if the log is appended to concurrently.
+func (ts *Server) AppendWorkflowAuditEntry(ctx context.Context, uuid string, entry *workflowpb.WorkflowAuditEntry) error {
+	nodePath := pathForWorkflowAuditLog(uuid)
+	for {
+		auditLog, version, err := ts.getWorkflowAuditLog(ctx, uuid)
+		switch {
+		case IsErrType(err, NoNode):
+			auditLog = &workflowpb.WorkflowAuditLog{}
+		case err != nil:
+			return err
+		}
+		auditLog.Entries = append(auditLog.Entries, entry)
+		data, err := proto.Marshal(auditLog)
+		if err != nil {
+			return err
+		}
+
+		if version == nil {
+			_, err = ts.globalCell.Create(ctx, nodePath, data)
+		} else {
+			_, err = ts.globalCell.Update(ctx, nodePath, data, version)
+		}
+		if IsErrType(err, NodeExists) || IsErrType(err, BadVersion) {
+			continue
+		}
+		return err
+	}
+}
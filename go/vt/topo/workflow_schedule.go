@@ -0,0 +1,114 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topo
+
+import (
+	"context"
+	"path"
+
+	"google.golang.org/protobuf/proto"
+
+	"vitess.io/vitess/go/vt/vterrors"
+
+	workflowpb "vitess.io/vitess/go/vt/proto/workflow"
+)
+
+// This file provides the utility methods to save / retrieve the
+// schedules of the workflows in the topology global cell.
+
+const (
+	workflowSchedulesPath = "workflow_schedules"
+)
+
+func pathForWorkflowSchedule(name string) string {
+	return path.Join(workflowSchedulesPath, name)
+}
+
+// GetWorkflowScheduleNames returns the names of the workflow schedules,
+// sorted.
+func (ts *Server) GetWorkflowScheduleNames(ctx context.Context) ([]string, error) {
+	entries, err := ts.globalCell.ListDir(ctx, workflowSchedulesPath, false /*full*/)
+	switch {
+	case IsErrType(err, NoNode):
+		return nil, nil
+	case err == nil:
+		return DirEntriesToStringArray(entries), nil
+	default:
+		return nil, err
+	}
+}
+
+// GetWorkflowSchedule returns the workflow schedule with the given name.
+func (ts *Server) GetWorkflowSchedule(ctx context.Context, name string) (*workflowpb.WorkflowSchedule, error) {
+	schedule, _, err := ts.getWorkflowSchedule(ctx, name)
+	return schedule, err
+}
+
+func (ts *Server) getWorkflowSchedule(ctx context.Context, name string) (*workflowpb.WorkflowSchedule, Version, error) {
+	data, version, err := ts.globalCell.Get(ctx, pathForWorkflowSchedule(name))
+	if err != nil {
+		return nil, nil, err
+	}
+	schedule := &workflowpb.WorkflowSchedule{}
+	if err := proto.Unmarshal(data, schedule); err != nil {
+		return nil, nil, vterrors.Wrapf(err, "bad workflow schedule data for %v", name)
+	}
+	return schedule, version, nil
+}
+
+// CreateWorkflowSchedule creates the workflow schedule. It fails if a
+// schedule with the same name exists.
+func (ts *Server) CreateWorkflowSchedule(ctx context.Context, schedule *workflowpb.WorkflowSchedule) error {
+	data, err := proto.Marshal(schedule)
+	if err != nil {
+		return err
+	}
+	_, err = ts.globalCell.Create(ctx, pathForWorkflowSchedule(schedule.Name), data)
+	return err
+}
+
+// UpdateWorkflowScheduleFields reads the workflow schedule, applies the
+// update function and saves it, retrying on concurrent updates. If the
+// update function returns an error of type NoUpdateNeeded, nothing is
+// saved.
+func (ts *Server) UpdateWorkflowScheduleFields(ctx context.Context, name string, update func(*workflowpb.WorkflowSchedule) error) (*workflowpb.WorkflowSchedule, error) {
+	for {
+		schedule, version, err := ts.getWorkflowSchedule(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		if err := update(schedule); err != nil {
+			if IsErrType(err, NoUpdateNeeded) {
+				return schedule, nil
+			}
+			return nil, err
+		}
+		data, err := proto.Marshal(schedule)
+		if err != nil {
+			return nil, err
+		}
+		if _, err = ts.globalCell.Update(ctx, pathForWorkflowSchedule(name), data, version); !IsErrType(err, BadVersion) {
+			return schedule, err
+		}
+	}
+}
+
+// DeleteWorkflowSchedule deletes the workflow schedule. The workflows it
+// created are kept.
+func (ts *Server) DeleteWorkflowSchedule(ctx context.Context, name string) error {
+	return ts.globalCell.Delete(ctx, pathForWorkflowSchedule(name), nil)
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topo
+
+import (
+	"context"
+	"encoding/json"
+	"path"
+	"time"
+
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+// This file contains the methods to share the state of the running
+// workflows between the vtctlds. The workflow manager running a workflow
+// saves its progress periodically, so any vtctld can report it, and the
+// vtctld which takes over the workflow manager knows which one ran it.
+
+const workflowRunStatePath = "workflow_state"
+
+// WorkflowRunState is the state of a workflow, as last saved by the
+// workflow manager running it.
+type WorkflowRunState struct {
+	UUID        string `json:"uuid"`
+	FactoryName string `json:"factory_name"`
+	Name        string `json:"name"`
+	// Owner is the ID of the workflow manager running the workflow,
+	// i.e. the host:port of its vtctld.
+	Owner string `json:"owner"`
+	// State is the workflowpb.WorkflowState of the workflow.
+	State           string `json:"state"`
+	Progress        int    `json:"progress"`
+	ProgressMessage string `json:"progress_message,omitempty"`
+	Message         string `json:"message,omitempty"`
+	Error           string `json:"error,omitempty"`
+	// Heartbeat is when the state was saved.
+	Heartbeat time.Time `json:"heartbeat"`
+}
+
+func pathForWorkflowRunState(uuid string) string {
+	return path.Join(workflowRunStatePath, uuid)
+}
+
+// GetWorkflowRunState returns the state of a workflow.
+func (ts *Server) GetWorkflowRunState(ctx context.Context, uuid string) (*WorkflowRunState, error) {
+	data, _, err := ts.globalCell.Get(ctx, pathForWorkflowRunState(uuid))
+	if err != nil {
+		return nil, err
+	}
+	state := &WorkflowRunState{}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, vterrors.Wrapf(err, "bad state data for workflow %v", uuid)
+	}
+	return state, nil
+}
+
+// GetWorkflowRunStates returns the states of all the workflows, sorted by
+// uuid.
+func (ts *Server) GetWorkflowRunStates(ctx context.Context) ([]*WorkflowRunState, error) {
+	entries, err := ts.globalCell.ListDir(ctx, workflowRunStatePath, false /*full*/)
+	switch {
+	case IsErrType(err, NoNode):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+	var states []*WorkflowRunState
+	for _, uuid := range DirEntriesToStringArray(entries) {
+		state, err := ts.GetWorkflowRunState(ctx, uuid)
+		if err != nil {
+			if IsErrType(err, NoNode) {
+				// Deleted meanwhile.
+				continue
+			}
+			return nil, err
+		}
+		states = append(states, state)
+	}
+	return states, nil
+}
+
+// SaveWorkflowRunState saves the state of a workflow. It is a blind
+// write: only the workflow manager running the workflow saves it.
+func (ts *Server) SaveWorkflowRunState(ctx context.Context, state *WorkflowRunState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = ts.globalCell.Update(ctx, pathForWorkflowRunState(state.UUID), data, nil)
+	return err
+}
+
+// DeleteWorkflowRunState deletes the state of a workflow. It doesn't fail
+// if there is none.
+func (ts *Server) DeleteWorkflowRunState(ctx context.Context, uuid string) error {
+	err := ts.globalCell.Delete(ctx, pathForWorkflowRunState(uuid), nil)
+	if IsErrType(err, NoNode) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topo
+
+import (
+	"context"
+	"path"
+
+	"google.golang.org/protobuf/proto"
+
+	"vitess.io/vitess/go/vt/vterrors"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// This file contains the methods to store the throttling of the
+// vreplication workflows of a keyspace. The primary tablets of the
+// keyspace reload it periodically, and apply it to the copy phase of the
+// streams of the workflows without restarting them. It is stored as a
+// topodatapb.KeyspaceWorkflowThrottling in the global topo.
+
+// workflowThrottlingEmpty returns true if the workflow is not throttled.
+func workflowThrottlingEmpty(wt *topodatapb.WorkflowThrottling) bool {
+	return wt == nil || (wt.RowsPerSecond <= 0 && wt.BytesPerSecond <= 0 && wt.MaxConcurrentCopies <= 0)
+}
+
+// GetWorkflowThrottling returns the throttling of the workflows of the
+// keyspace, by workflow name.
+func (ts *Server) GetWorkflowThrottling(ctx context.Context, keyspace string) (map[string]*topodatapb.WorkflowThrottling, error) {
+	kwt, _, err := ts.getWorkflowThrottling(ctx, keyspace)
+	return kwt.GetWorkflows(), err
+}
+
+func (ts *Server) getWorkflowThrottling(ctx context.Context, keyspace string) (*topodatapb.KeyspaceWorkflowThrottling, Version, error) {
+	nodePath := path.Join(KeyspacesPath, keyspace, WorkflowThrottleFile)
+	data, version, err := ts.globalCell.Get(ctx, nodePath)
+	switch {
+	case IsErrType(err, NoNode):
+		return nil, nil, nil
+	case err != nil:
+		return nil, nil, err
+	}
+	kwt := &topodatapb.KeyspaceWorkflowThrottling{}
+	if err := proto.Unmarshal(data, kwt); err != nil {
+		return nil, nil, vterrors.Wrapf(err, "bad workflow throttling data for keyspace %v", keyspace)
+	}
+	return kwt, version, nil
+}
+
+// UpdateWorkflowThrottling sets the throttling of a workflow of the
+// keyspace. An empty throttling removes it. The other workflows of the
+// keyspace are left unchanged.
+func (ts *Server) UpdateWorkflowThrottling(ctx context.Context, keyspace, workflow string, throttling *topodatapb.WorkflowThrottling) error {
+	kwt, version, err := ts.getWorkflowThrottling(ctx, keyspace)
+	if err != nil {
+		return err
+	}
+	if kwt == nil {
+		kwt = &topodatapb.KeyspaceWorkflowThrottling{}
+	}
+	if kwt.Workflows == nil {
+		kwt.Workflows = make(map[string]*topodatapb.WorkflowThrottling)
+	}
+	if workflowThrottlingEmpty(throttling) {
+		delete(kwt.Workflows, workflow)
+	} else {
+		kwt.Workflows[workflow] = throttling
+	}
+
+	nodePath := path.Join(KeyspacesPath, keyspace, WorkflowThrottleFile)
+	if len(kwt.Workflows) == 0 {
+		if version == nil {
+			return nil
+		}
+		if err := ts.globalCell.Delete(ctx, nodePath, version); err != nil && !IsErrType(err, NoNode) {
+			return err
+		}
+		return nil
+	}
+	data, err := proto.Marshal(kwt)
+	if err != nil {
+		return err
+	}
+	if version == nil {
+		_, err = ts.globalCell.Create(ctx, nodePath, data)
+		return err
+	}
+	_, err = ts.globalCell.Update(ctx, nodePath, data, version)
+	return err
+}
+
+// DeleteWorkflowThrottling removes the throttling of all the workflows of
+// the keyspace, if any.
+func (ts *Server) DeleteWorkflowThrottling(ctx context.Context, keyspace string) error {
+	nodePath := path.Join(KeyspacesPath, keyspace, WorkflowThrottleFile)
+	if err := ts.globalCell.Delete(ctx, nodePath, nil); err != nil && !IsErrType(err, NoNode) {
+		return err
+	}
+	return nil
+}
@@ -34,6 +34,7 @@ import (
 
 	"vitess.io/vitess/go/sync2"
 	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/secrets"
 )
 
 const (
@@ -58,7 +59,7 @@ var (
 	certPath = flag.String("topo_zk_tls_cert", "", "the cert to use to connect to the zk topo server, requires topo_zk_tls_key, enables TLS")
 	keyPath  = flag.String("topo_zk_tls_key", "", "the key to use to connect to the zk topo server, enables TLS")
 	caPath   = flag.String("topo_zk_tls_ca", "", "the server ca to use to validate servers when connecting to the zk topo server")
-	authFile = flag.String("topo_zk_auth_file", "", "auth to use when connecting to the zk topo server, file contents should be <scheme>:<auth>, e.g., digest:user:pass")
+	authFile = flag.String("topo_zk_auth_file", "", "auth to use when connecting to the zk topo server, file contents should be <scheme>:<auth>, e.g., digest:user:pass, or a secret:// reference resolving to it")
 )
 
 // Time returns a time.Time from a ZK int64 milliseconds since Epoch time.
@@ -86,9 +87,15 @@ type ZkConn struct {
 	// sem protects concurrent calls to Zookeeper.
 	sem *sync2.Semaphore
 
+	// rotateOnce registers the auth rotation callback once.
+	rotateOnce sync.Once
+
 	// mu protects the following fields.
 	mu   sync.Mutex
 	conn *zk.Conn
+	// unwatchAuth unregisters the auth rotation callback. It is called
+	// by Close.
+	unwatchAuth func()
 }
 
 // Connect to the Zookeeper servers specified in addr
@@ -209,6 +216,10 @@ func (c *ZkConn) AddAuth(ctx context.Context, scheme string, auth []byte) error
 func (c *ZkConn) Close() error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	if c.unwatchAuth != nil {
+		c.unwatchAuth()
+		c.unwatchAuth = nil
+	}
 	if c.conn != nil {
 		c.conn.Close()
 	}
@@ -286,7 +297,9 @@ func (c *ZkConn) getConn(ctx context.Context) (*zk.Conn, error) {
 	return c.conn, nil
 }
 
-// maybeAddAuth calls AddAuth if the `-topo_zk_auth_file` flag was specified
+// maybeAddAuth calls AddAuth if the `-topo_zk_auth_file` flag was specified.
+// If the file holds a secret reference, the new auth is added to the current
+// connection when the secret rotates. It must be called with c.mu held.
 func (c *ZkConn) maybeAddAuth(ctx context.Context) {
 	if *authFile == "" {
 		return
@@ -296,16 +309,36 @@ func (c *ZkConn) maybeAddAuth(ctx context.Context) {
 		log.Errorf("failed to read topo_zk_auth_file: %v", err)
 		return
 	}
-	authInfo := strings.TrimRight(string(authInfoBytes), "\n")
+	authRef := strings.TrimRight(string(authInfoBytes), "\n")
+	authInfo, err := secrets.Resolve(ctx, authRef)
+	if err != nil {
+		log.Errorf("failed to resolve topo_zk_auth_file contents: %v", err)
+		return
+	}
+	c.addAuth(authInfo)
+
+	c.rotateOnce.Do(func() {
+		c.unwatchAuth = secrets.OnRotate(authRef, func(authInfo string) {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			if c.conn != nil {
+				log.Infof("topo_zk_auth_file secret rotated, adding the new auth")
+				c.addAuth(authInfo)
+			}
+		})
+	})
+}
+
+// addAuth adds the <scheme>:<auth> authInfo to the current connection.
+// It must be called with c.mu held.
+func (c *ZkConn) addAuth(authInfo string) {
 	authInfoParts := strings.SplitN(authInfo, ":", 2)
 	if len(authInfoParts) != 2 {
-		log.Errorf("failed to parse topo_zk_auth_file contents, expected format <scheme>:<auth> but saw: %s", authInfo)
+		log.Errorf("failed to parse topo_zk_auth_file contents, expected format <scheme>:<auth>")
 		return
 	}
-	err = c.conn.AddAuth(authInfoParts[0], []byte(authInfoParts[1]))
-	if err != nil {
+	if err := c.conn.AddAuth(authInfoParts[0], []byte(authInfoParts[1])); err != nil {
 		log.Errorf("failed to add auth from topo_zk_auth_file: %v", err)
-		return
 	}
 }
 
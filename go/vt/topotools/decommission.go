@@ -0,0 +1,186 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topotools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/encoding/prototext"
+
+	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vttablet/tmclient"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// VReplicationSourcesGetter returns the source tablet of each vreplication
+// stream running on a tablet, by stream id. The sources are tablet aliases
+// in the text format, empty if a stream has no current source.
+type VReplicationSourcesGetter func(ctx context.Context, tablet *topodatapb.Tablet) (map[string]string, error)
+
+// GetVReplicationSourcesFromDebugVars is a VReplicationSourcesGetter which
+// reads the VReplicationSourceTablet variable of the /debug/vars page of
+// the tablet.
+func GetVReplicationSourcesFromDebugVars(ctx context.Context, tablet *topodatapb.Tablet) (map[string]string, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", "http://"+topo.NewTabletInfo(tablet, nil).Addr()+"/debug/vars", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var vars struct {
+		VReplicationSourceTablet map[string]string
+	}
+	if err := json.Unmarshal(body, &vars); err != nil {
+		return nil, err
+	}
+	return vars.VReplicationSourceTablet, nil
+}
+
+// DecommissionTablet removes a tablet which isn't needed anymore: it
+// checks that nothing depends on it, drains it from the gateways, waits
+// drainGrace for its in-flight queries, stops its replication and deletes
+// its record. It refuses with the reasons otherwise.
+func DecommissionTablet(ctx context.Context, ts *topo.Server, tmc tmclient.TabletManagerClient, logger logutil.Logger, tabletAlias *topodatapb.TabletAlias, drainGrace time.Duration, getSources VReplicationSourcesGetter) error {
+	alias := topoproto.TabletAliasString(tabletAlias)
+	ti, err := ts.GetTablet(ctx, tabletAlias)
+	if err != nil {
+		return err
+	}
+	reasons, err := DecommissionBlockers(ctx, ts, ti, getSources)
+	if err != nil {
+		return err
+	}
+	if len(reasons) > 0 {
+		return fmt.Errorf("cannot decommission tablet %v: %v", alias, strings.Join(reasons, "; "))
+	}
+
+	logger.Infof("Draining tablet %v", alias)
+	if err := SetTabletDrained(ctx, ts, tabletAlias, true); err != nil {
+		return err
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(drainGrace):
+	}
+
+	logger.Infof("Stopping the replication of tablet %v", alias)
+	if err := tmc.StopReplication(ctx, ti.Tablet); err != nil {
+		return fmt.Errorf("cannot stop the replication of tablet %v, it is left drained: %v", alias, err)
+	}
+
+	logger.Infof("Deleting tablet %v", alias)
+	return DeleteTablet(ctx, ts, ti.Tablet)
+}
+
+// DecommissionBlockers returns why the tablet cannot be decommissioned:
+// it is the master of its shard, it is the last replica the master can
+// acknowledge its transactions with, or vreplication streams are
+// streaming from it.
+func DecommissionBlockers(ctx context.Context, ts *topo.Server, ti *topo.TabletInfo, getSources VReplicationSourcesGetter) ([]string, error) {
+	var reasons []string
+	si, err := ts.GetShard(ctx, ti.Keyspace, ti.Shard)
+	if err != nil {
+		return nil, err
+	}
+	if ti.Type == topodatapb.TabletType_MASTER || topoproto.TabletAliasEqual(si.MasterAlias, ti.Alias) {
+		reasons = append(reasons, fmt.Sprintf("it is the master of %v/%v, reparent the shard first", ti.Keyspace, ti.Shard))
+	}
+
+	if ti.Type == topodatapb.TabletType_REPLICA {
+		tabletMap, err := ts.GetTabletMapForShard(ctx, ti.Keyspace, ti.Shard)
+		if err != nil {
+			return nil, err
+		}
+		replicas := 0
+		for _, other := range tabletMap {
+			if other.Type == topodatapb.TabletType_REPLICA && !topoproto.TabletAliasEqual(other.Alias, ti.Alias) {
+				replicas++
+			}
+		}
+		if replicas == 0 {
+			reasons = append(reasons, fmt.Sprintf("it is the last replica of %v/%v, the master would have no replica to provide durability", ti.Keyspace, ti.Shard))
+		}
+	}
+
+	streams, err := vreplicationStreamsFrom(ctx, ts, ti.Alias, getSources)
+	if err != nil {
+		return nil, err
+	}
+	if len(streams) > 0 {
+		reasons = append(reasons, fmt.Sprintf("vreplication streams %v are streaming from it", strings.Join(streams, ", ")))
+	}
+	return reasons, nil
+}
+
+// vreplicationStreamsFrom returns the vreplication streams, on the masters
+// of all the keyspaces, whose current source is the tablet.
+func vreplicationStreamsFrom(ctx context.Context, ts *topo.Server, tabletAlias *topodatapb.TabletAlias, getSources VReplicationSourcesGetter) ([]string, error) {
+	keyspaces, err := ts.GetKeyspaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var streams []string
+	for _, keyspace := range keyspaces {
+		shards, err := ts.FindAllShardsInKeyspace(ctx, keyspace)
+		if err != nil {
+			return nil, err
+		}
+		for _, si := range shards {
+			if si.MasterAlias == nil {
+				continue
+			}
+			master, err := ts.GetTablet(ctx, si.MasterAlias)
+			if err != nil {
+				return nil, err
+			}
+			sources, err := getSources(ctx, master.Tablet)
+			if err != nil {
+				return nil, fmt.Errorf("cannot get the vreplication streams of %v: %v", master.AliasString(), err)
+			}
+			for id, source := range sources {
+				sourceAlias := &topodatapb.TabletAlias{}
+				if source == "" || prototext.Unmarshal([]byte(source), sourceAlias) != nil {
+					continue
+				}
+				if topoproto.TabletAliasEqual(sourceAlias, tabletAlias) {
+					streams = append(streams, fmt.Sprintf("%v/%v", master.AliasString(), id))
+				}
+			}
+		}
+	}
+	sort.Strings(streams)
+	return streams, nil
+}
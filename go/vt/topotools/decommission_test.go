@@ -0,0 +1,84 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topotools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/prototext"
+
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func TestDecommissionBlockers(t *testing.T) {
+	ctx := context.Background()
+	ts := memorytopo.NewServer("cell1")
+
+	require.NoError(t, ts.CreateKeyspace(ctx, "ks", &topodatapb.Keyspace{}))
+	require.NoError(t, ts.CreateShard(ctx, "ks", "0"))
+	tablet := func(uid uint32, tabletType topodatapb.TabletType) *topodatapb.Tablet {
+		tablet := &topodatapb.Tablet{
+			Alias:    &topodatapb.TabletAlias{Cell: "cell1", Uid: uid},
+			Hostname: "localhost",
+			Keyspace: "ks",
+			Shard:    "0",
+			Type:     tabletType,
+			PortMap:  map[string]int32{"vt": int32(uid)},
+		}
+		require.NoError(t, ts.CreateTablet(ctx, tablet))
+		return tablet
+	}
+	master := tablet(100, topodatapb.TabletType_MASTER)
+	replica := tablet(101, topodatapb.TabletType_REPLICA)
+	rdonly := tablet(102, topodatapb.TabletType_RDONLY)
+	_, err := ts.UpdateShardFields(ctx, "ks", "0", func(si *topo.ShardInfo) error {
+		si.MasterAlias = master.Alias
+		return nil
+	})
+	require.NoError(t, err)
+
+	// The rdonly tablet is the source of a stream of the master.
+	source, err := prototext.Marshal(rdonly.Alias)
+	require.NoError(t, err)
+	getSources := func(ctx context.Context, tablet *topodatapb.Tablet) (map[string]string, error) {
+		if tablet.Alias.Uid != 100 {
+			t.Fatalf("unexpected vreplication streams of %v", tablet.Alias)
+		}
+		return map[string]string{"1": string(source), "2": ""}, nil
+	}
+
+	blockers := func(tablet *topodatapb.Tablet) []string {
+		ti, err := ts.GetTablet(ctx, tablet.Alias)
+		require.NoError(t, err)
+		reasons, err := DecommissionBlockers(ctx, ts, ti, getSources)
+		require.NoError(t, err)
+		return reasons
+	}
+	assert.Equal(t, []string{"it is the master of ks/0, reparent the shard first"}, blockers(master))
+	assert.Equal(t, []string{"it is the last replica of ks/0, the master would have no replica to provide durability"}, blockers(replica))
+	assert.Equal(t, []string{"vreplication streams cell1-0000000100/1 are streaming from it"}, blockers(rdonly))
+
+	// A replica can be decommissioned when another one is left.
+	tablet(103, topodatapb.TabletType_REPLICA)
+	assert.Empty(t, blockers(replica))
+}
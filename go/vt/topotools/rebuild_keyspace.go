@@ -22,6 +22,8 @@ import (
 
 	"context"
 
+	"google.golang.org/protobuf/proto"
+
 	"vitess.io/vitess/go/vt/concurrency"
 	"vitess.io/vitess/go/vt/logutil"
 	"vitess.io/vitess/go/vt/topo"
@@ -41,6 +43,18 @@ func RebuildKeyspace(ctx context.Context, log logutil.Logger, ts *topo.Server, k
 	return RebuildKeyspaceLocked(ctx, log, ts, keyspace, cells, allowPartial)
 }
 
+// RebuildKeyspaceWithReport rebuilds the serving graph data like
+// RebuildKeyspace, and reports how it went in each cell.
+func RebuildKeyspaceWithReport(ctx context.Context, log logutil.Logger, ts *topo.Server, keyspace string, cells []string, allowPartial bool) (report *topo.RebuildReport, err error) {
+	ctx, unlock, lockErr := ts.LockKeyspace(ctx, keyspace, "RebuildKeyspace")
+	if lockErr != nil {
+		return nil, lockErr
+	}
+	defer unlock(&err)
+
+	return RebuildKeyspaceLockedWithReport(ctx, log, ts, keyspace, cells, allowPartial)
+}
+
 // RebuildKeyspaceLocked should only be used with an action lock on the keyspace
 // - otherwise the consistency of the serving graph data can't be
 // guaranteed.
@@ -48,26 +62,35 @@ func RebuildKeyspace(ctx context.Context, log logutil.Logger, ts *topo.Server, k
 // Take data from the global keyspace and rebuild the local serving
 // copies in each cell.
 func RebuildKeyspaceLocked(ctx context.Context, log logutil.Logger, ts *topo.Server, keyspace string, cells []string, allowPartial bool) error {
+	_, err := RebuildKeyspaceLockedWithReport(ctx, log, ts, keyspace, cells, allowPartial)
+	return err
+}
+
+// RebuildKeyspaceLockedWithReport is RebuildKeyspaceLocked, and reports how
+// the rebuild went in each cell. If the serving graph data couldn't be
+// built, it only returns an error. Otherwise the report is returned along
+// with the errors of the cells, if any.
+func RebuildKeyspaceLockedWithReport(ctx context.Context, log logutil.Logger, ts *topo.Server, keyspace string, cells []string, allowPartial bool) (*topo.RebuildReport, error) {
 	if err := topo.CheckKeyspaceLocked(ctx, keyspace); err != nil {
-		return err
+		return nil, err
 	}
 
 	ki, err := ts.GetKeyspace(ctx, keyspace)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// The caller intents to update all cells in this case
 	if len(cells) == 0 {
 		cells, err = ts.GetCellInfoNames(ctx)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 
 	shards, err := ts.FindAllShardsInKeyspace(ctx, keyspace)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// This is safe to rebuild as long there are not srvKeyspaces with tablet controls set.
@@ -77,22 +100,29 @@ func RebuildKeyspaceLocked(ctx context.Context, log logutil.Logger, ts *topo.Ser
 	// srvKeyspaceMap is a map:
 	//   key: cell
 	//   value: topo.SrvKeyspace object being built
+	//
+	// previousMap and previousVersions hold the existing SrvKeyspaces, for
+	// the report.
 	srvKeyspaceMap := make(map[string]*topodatapb.SrvKeyspace)
+	previousMap := make(map[string]*topodatapb.SrvKeyspace)
+	previousVersions := make(map[string]topo.Version)
 	for _, cell := range cells {
-		srvKeyspace, err := ts.GetSrvKeyspace(ctx, cell, keyspace)
+		srvKeyspace, version, err := ts.GetSrvKeyspaceWithVersion(ctx, cell, keyspace)
 		switch {
 		case err == nil:
 			for _, partition := range srvKeyspace.GetPartitions() {
 				for _, shardTabletControl := range partition.GetShardTabletControls() {
 					if shardTabletControl.QueryServiceDisabled {
-						return fmt.Errorf("can't rebuild serving keyspace while a migration is on going. TabletControls is set for partition %v", partition)
+						return nil, fmt.Errorf("can't rebuild serving keyspace while a migration is on going. TabletControls is set for partition %v", partition)
 					}
 				}
 			}
+			previousMap[cell] = srvKeyspace
+			previousVersions[cell] = version
 		case topo.IsErrType(err, topo.NoNode):
 			// NOOP
 		default:
-			return err
+			return nil, err
 		}
 		srvKeyspaceMap[cell] = &topodatapb.SrvKeyspace{
 			ShardingColumnName: ki.ShardingColumnName,
@@ -136,23 +166,40 @@ func RebuildKeyspaceLocked(ctx context.Context, log logutil.Logger, ts *topo.Ser
 		if !(ki.KeyspaceType == topodatapb.KeyspaceType_SNAPSHOT && allowPartial) {
 			// skip this check for SNAPSHOT keyspaces so that incomplete keyspaces can still serve
 			if err := topo.OrderAndCheckPartitions(cell, srvKeyspace); err != nil {
-				return err
+				return nil, err
 			}
 		}
 
 	}
 	// And then finally save the keyspace objects, in parallel.
+	report := &topo.RebuildReport{Keyspace: keyspace}
 	rec := concurrency.AllErrorRecorder{}
 	wg := sync.WaitGroup{}
-	for cell, srvKeyspace := range srvKeyspaceMap {
+	for _, cell := range cells {
+		srvKeyspace := srvKeyspaceMap[cell]
+		status := &topo.CellRebuildStatus{Cell: cell}
+		if version := previousVersions[cell]; version != nil {
+			status.PreviousVersion = version.String()
+		}
+		report.Cells = append(report.Cells, status)
 		wg.Add(1)
-		go func(cell string, srvKeyspace *topodatapb.SrvKeyspace) {
+		go func(cell string, srvKeyspace *topodatapb.SrvKeyspace, status *topo.CellRebuildStatus) {
 			defer wg.Done()
-			if err := ts.UpdateSrvKeyspace(ctx, cell, keyspace, srvKeyspace); err != nil {
-				rec.RecordError(fmt.Errorf("writing serving data failed: %v", err))
+			version, err := ts.UpdateSrvKeyspaceWithVersion(ctx, cell, keyspace, srvKeyspace)
+			if err != nil {
+				err = fmt.Errorf("writing serving data failed: %v", err)
+				status.Error = err.Error()
+				rec.RecordError(err)
+				return
 			}
-		}(cell, srvKeyspace)
+			status.NewVersion = version.String()
+			status.Changed = !proto.Equal(previousMap[cell], srvKeyspace)
+			status.PartitionChanges = topo.DiffSrvKeyspacePartitions(previousMap[cell], srvKeyspace)
+		}(cell, srvKeyspace, status)
 	}
 	wg.Wait()
-	return rec.Error()
+	if failed := report.FailedCells(); len(failed) > 0 {
+		log.Warningf("Rebuilding the serving graph of keyspace %v failed in cells %v", keyspace, failed)
+	}
+	return report, rec.Error()
 }
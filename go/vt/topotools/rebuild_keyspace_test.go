@@ -0,0 +1,108 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topotools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func TestRebuildKeyspaceWithReport(t *testing.T) {
+	ctx := context.Background()
+	cells := []string{"cell1", "cell2"}
+	ts := memorytopo.NewServer(cells...)
+	logger := logutil.NewMemoryLogger()
+
+	require.NoError(t, ts.CreateKeyspace(ctx, "ks", &topodatapb.Keyspace{}))
+	require.NoError(t, ts.CreateShard(ctx, "ks", "0"))
+
+	report, err := RebuildKeyspaceWithReport(ctx, logger, ts, "ks", cells, false)
+	require.NoError(t, err)
+	assert.Equal(t, "ks", report.Keyspace)
+	require.Len(t, report.Cells, 2)
+	for i, status := range report.Cells {
+		assert.Equal(t, cells[i], status.Cell)
+		assert.Empty(t, status.Error)
+		assert.Empty(t, status.PreviousVersion)
+		assert.NotEmpty(t, status.NewVersion)
+		assert.True(t, status.Changed)
+		assert.Equal(t, []*topo.PartitionChange{
+			{ServedType: topodatapb.TabletType_MASTER, AddedShards: []string{"0"}},
+			{ServedType: topodatapb.TabletType_REPLICA, AddedShards: []string{"0"}},
+			{ServedType: topodatapb.TabletType_RDONLY, AddedShards: []string{"0"}},
+		}, status.PartitionChanges)
+	}
+	firstVersion := report.Cells[0].NewVersion
+
+	// Nothing changes.
+	report, err = RebuildKeyspaceWithReport(ctx, logger, ts, "ks", []string{"cell1"}, false)
+	require.NoError(t, err)
+	require.Len(t, report.Cells, 1)
+	assert.Equal(t, firstVersion, report.Cells[0].PreviousVersion)
+	assert.NotEqual(t, firstVersion, report.Cells[0].NewVersion)
+	assert.False(t, report.Cells[0].Changed)
+	assert.Empty(t, report.Cells[0].PartitionChanges)
+
+	// The keyspace is split in two shards.
+	require.NoError(t, ts.CreateShard(ctx, "ks", "-80"))
+	require.NoError(t, ts.CreateShard(ctx, "ks", "80-"))
+	for shard, serving := range map[string]bool{"0": false, "-80": true, "80-": true} {
+		serving := serving
+		_, err = ts.UpdateShardFields(ctx, "ks", shard, func(si *topo.ShardInfo) error {
+			si.IsMasterServing = serving
+			return nil
+		})
+		require.NoError(t, err)
+	}
+	report, err = RebuildKeyspaceWithReport(ctx, logger, ts, "ks", []string{"cell1"}, false)
+	require.NoError(t, err)
+	assert.True(t, report.Cells[0].Changed)
+	assert.Equal(t, []*topo.PartitionChange{
+		{ServedType: topodatapb.TabletType_MASTER, AddedShards: []string{"-80", "80-"}, RemovedShards: []string{"0"}},
+		{ServedType: topodatapb.TabletType_REPLICA, AddedShards: []string{"-80", "80-"}, RemovedShards: []string{"0"}},
+		{ServedType: topodatapb.TabletType_RDONLY, AddedShards: []string{"-80", "80-"}, RemovedShards: []string{"0"}},
+	}, report.Cells[0].PartitionChanges)
+}
+
+func TestDiffSrvKeyspacePartitions(t *testing.T) {
+	previous := &topodatapb.SrvKeyspace{
+		Partitions: []*topodatapb.SrvKeyspace_KeyspacePartition{{
+			ServedType:      topodatapb.TabletType_MASTER,
+			ShardReferences: []*topodatapb.ShardReference{{Name: "0"}},
+		}},
+	}
+	current := &topodatapb.SrvKeyspace{
+		Partitions: []*topodatapb.SrvKeyspace_KeyspacePartition{{
+			ServedType:          topodatapb.TabletType_MASTER,
+			ShardReferences:     []*topodatapb.ShardReference{{Name: "0"}},
+			ShardTabletControls: []*topodatapb.ShardTabletControl{{Name: "0", QueryServiceDisabled: true}},
+		}},
+	}
+	assert.Equal(t, []*topo.PartitionChange{
+		{ServedType: topodatapb.TabletType_MASTER, TabletControlsChanged: true},
+	}, topo.DiffSrvKeyspacePartitions(previous, current))
+	assert.Empty(t, topo.DiffSrvKeyspacePartitions(current, current))
+}
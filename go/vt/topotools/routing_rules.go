@@ -18,9 +18,19 @@ package topotools
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
 
+	"google.golang.org/protobuf/proto"
+
+	"vitess.io/vitess/go/vt/concurrency"
 	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
 
 	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
 )
@@ -43,16 +53,275 @@ func GetRoutingRules(ctx context.Context, ts *topo.Server) (map[string][]string,
 
 // SaveRoutingRules converts a mapping of fromTable=>[]toTables into a
 // vschemapb.RoutingRules protobuf message and saves it in the topology.
+// The weights of the current rules whose toTables don't change are kept,
+// so the reads split by SplitReads stay split when other rules change.
 func SaveRoutingRules(ctx context.Context, ts *topo.Server, rules map[string][]string) error {
 	log.Infof("Saving routing rules %v\n", rules)
 
+	current, err := ts.GetRoutingRules(ctx)
+	if err != nil {
+		return err
+	}
+	weighted := make(map[string]*vschemapb.RoutingRule)
+	for _, rule := range current.Rules {
+		if len(rule.Weights) > 0 {
+			weighted[rule.FromTable] = rule
+		}
+	}
+
 	rrs := &vschemapb.RoutingRules{Rules: make([]*vschemapb.RoutingRule, 0, len(rules))}
 	for from, to := range rules {
-		rrs.Rules = append(rrs.Rules, &vschemapb.RoutingRule{
+		rule := &vschemapb.RoutingRule{
 			FromTable: from,
 			ToTables:  to,
-		})
+		}
+		if old, ok := weighted[from]; ok && sameTables(old.ToTables, to) {
+			rule.Weights = old.Weights
+		}
+		rrs.Rules = append(rrs.Rules, rule)
 	}
 
 	return ts.SaveRoutingRules(ctx, rrs)
 }
+
+// sameTables returns true if both rules route to the same tables, in the
+// same order.
+func sameTables(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// RoutingRuleChange is the change of the rule of a table in an update of
+// the routing rules.
+type RoutingRuleChange struct {
+	FromTable string `json:"from_table"`
+	// OldToTables is empty if the rule is added, and NewToTables if it
+	// is deleted.
+	OldToTables []string `json:"old_to_tables,omitempty"`
+	NewToTables []string `json:"new_to_tables,omitempty"`
+}
+
+// DiffRoutingRules returns the changes from the old routing rules to the
+// new ones, sorted by table.
+func DiffRoutingRules(oldRules, newRules *vschemapb.RoutingRules) []*RoutingRuleChange {
+	oldByTable := routingRulesByTable(oldRules)
+	newByTable := routingRulesByTable(newRules)
+	var changes []*RoutingRuleChange
+	for table, oldRule := range oldByTable {
+		newRule, ok := newByTable[table]
+		if !ok {
+			changes = append(changes, &RoutingRuleChange{FromTable: table, OldToTables: oldRule.ToTables})
+			continue
+		}
+		if !proto.Equal(oldRule, newRule) {
+			changes = append(changes, &RoutingRuleChange{FromTable: table, OldToTables: oldRule.ToTables, NewToTables: newRule.ToTables})
+		}
+	}
+	for table, newRule := range newByTable {
+		if _, ok := oldByTable[table]; !ok {
+			changes = append(changes, &RoutingRuleChange{FromTable: table, NewToTables: newRule.ToTables})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].FromTable < changes[j].FromTable
+	})
+	return changes
+}
+
+func routingRulesByTable(rr *vschemapb.RoutingRules) map[string]*vschemapb.RoutingRule {
+	byTable := make(map[string]*vschemapb.RoutingRule)
+	for _, rule := range rr.GetRules() {
+		byTable[rule.FromTable] = rule
+	}
+	return byTable
+}
+
+// ValidateRoutingRules checks the routing rules against the current
+// VSchemas, the way vtgate builds them: each table has a single rule,
+// whose targets are qualified tables which exist. It returns all the
+// problems found.
+func ValidateRoutingRules(ctx context.Context, ts *topo.Server, rr *vschemapb.RoutingRules) error {
+	keyspaces, err := ts.GetKeyspaces(ctx)
+	if err != nil {
+		return err
+	}
+	srvVSchema := &vschemapb.SrvVSchema{
+		Keyspaces:    make(map[string]*vschemapb.Keyspace, len(keyspaces)),
+		RoutingRules: rr,
+	}
+	for _, keyspace := range keyspaces {
+		ks, err := ts.GetVSchema(ctx, keyspace)
+		switch {
+		case topo.IsErrType(err, topo.NoNode):
+			ks = &vschemapb.Keyspace{}
+		case err != nil:
+			return err
+		}
+		srvVSchema.Keyspaces[keyspace] = ks
+	}
+
+	rec := concurrency.AllErrorRecorder{}
+	for _, rule := range rr.GetRules() {
+		if rule.FromTable == "" {
+			rec.RecordError(fmt.Errorf("rule without a table"))
+		}
+	}
+	vschema := vindexes.BuildVSchema(srvVSchema)
+	var tables []string
+	for table, rule := range vschema.RoutingRules {
+		if rule.Error != nil {
+			tables = append(tables, table)
+		}
+	}
+	sort.Strings(tables)
+	for _, table := range tables {
+		rec.RecordError(fmt.Errorf("rule for table %v: %v", table, vschema.RoutingRules[table].Error))
+	}
+	return rec.Error()
+}
+
+// RoutingRulesUpdate describes an update of the routing rules.
+type RoutingRulesUpdate struct {
+	// Version is the version of the routing rules the update applies to.
+	Version      string                  `json:"version"`
+	RoutingRules *vschemapb.RoutingRules `json:"routing_rules"`
+	Changes      []*RoutingRuleChange    `json:"changes"`
+	DryRun       bool                    `json:"dry_run,omitempty"`
+}
+
+// ApplyRoutingRulesBatch atomically sets the rules of the tables in
+// rules, and deletes the rules of the tables in deletes, or replaces all
+// the rules with rules if replace is set. The new rules are validated,
+// then saved only if the current rules are at expectedVersion, as returned
+// by topo.GetRoutingRulesWithVersion, or didn't change since they were
+// read if expectedVersion is empty. The SrvVSchemas are not rebuilt.
+func ApplyRoutingRulesBatch(ctx context.Context, ts *topo.Server, rules []*vschemapb.RoutingRule, deletes []string, replace bool, expectedVersion string, dryRun bool) (*RoutingRulesUpdate, error) {
+	current, version, err := ts.GetRoutingRulesWithVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+	currentVersion := ""
+	if version != nil {
+		currentVersion = version.String()
+	}
+	if expectedVersion != "" && expectedVersion != currentVersion {
+		return nil, topo.NewError(topo.BadVersion, topo.RoutingRulesFile)
+	}
+
+	newRules := &vschemapb.RoutingRules{}
+	if !replace {
+		deleted := make(map[string]bool)
+		for _, table := range deletes {
+			deleted[table] = true
+		}
+		for _, rule := range rules {
+			deleted[rule.FromTable] = true
+		}
+		for _, rule := range current.Rules {
+			if !deleted[rule.FromTable] {
+				newRules.Rules = append(newRules.Rules, rule)
+			}
+		}
+	}
+	newRules.Rules = append(newRules.Rules, rules...)
+	if err := ValidateRoutingRules(ctx, ts, newRules); err != nil {
+		return nil, fmt.Errorf("invalid routing rules: %v", err)
+	}
+
+	update := &RoutingRulesUpdate{
+		Version:      currentVersion,
+		RoutingRules: newRules,
+		Changes:      DiffRoutingRules(current, newRules),
+		DryRun:       dryRun,
+	}
+	if dryRun || len(update.Changes) == 0 {
+		return update, nil
+	}
+	if err := ts.UpdateRoutingRulesIfVersion(ctx, newRules, currentVersion); err != nil {
+		return nil, err
+	}
+	return update, nil
+}
+
+// RoutingRulesAffectedQueries returns the queries, e.g. from the plan
+// cache of a vtgate, which reference a table whose routing rule changes.
+// The queries which can't be parsed are skipped.
+func RoutingRulesAffectedQueries(queries []string, changes []*RoutingRuleChange) []string {
+	qualified := make(map[string]bool)
+	unqualified := make(map[string]bool)
+	for _, change := range changes {
+		// The rules of a tablet type, e.g. t@replica, apply to the table.
+		table := change.FromTable
+		if i := strings.IndexByte(table, '@'); i >= 0 {
+			table = table[:i]
+		}
+		qualified[table] = true
+		if i := strings.IndexByte(table, '.'); i >= 0 {
+			table = table[i+1:]
+		}
+		unqualified[table] = true
+	}
+
+	var affected []string
+	for _, query := range queries {
+		stmt, err := sqlparser.Parse(query)
+		if err != nil {
+			continue
+		}
+		found := false
+		_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+			tableName, ok := node.(sqlparser.TableName)
+			if !ok || tableName.IsEmpty() {
+				return true, nil
+			}
+			// An unqualified table may be in the default keyspace of the
+			// session, so it matches the rules of the table in any
+			// keyspace.
+			if tableName.Qualifier.IsEmpty() {
+				found = found || unqualified[tableName.Name.String()]
+			} else {
+				found = found || qualified[tableName.Qualifier.String()+"."+tableName.Name.String()]
+			}
+			return !found, nil
+		}, stmt)
+		if found {
+			affected = append(affected, query)
+		}
+	}
+	return affected
+}
+
+// GetVtgateCachedQueries returns the queries in the plan cache of a
+// vtgate, from its /debug/query_plans page.
+func GetVtgateCachedQueries(ctx context.Context, addr string) ([]string, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("http://%v/debug/query_plans", addr), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %v", resp.Status)
+	}
+	var items []struct {
+		Key string
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&items); err != nil {
+		return nil, err
+	}
+	queries := make([]string, 0, len(items))
+	for _, item := range items {
+		queries = append(queries, item.Key)
+	}
+	return queries, nil
+}
@@ -24,7 +24,11 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/topo/memorytopo"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
 )
 
 func TestRoutingRulesRoundTrip(t *testing.T) {
@@ -66,3 +70,82 @@ func TestRoutingRulesErrors(t *testing.T) {
 		assert.Error(t, err, "expected error from GetRoutingRules, got rules=%v", rules)
 	})
 }
+
+func TestApplyRoutingRulesBatch(t *testing.T) {
+	ctx := context.Background()
+	ts := memorytopo.NewServer("cell1")
+	for _, ks := range []string{"ks1", "ks2"} {
+		require.NoError(t, ts.CreateKeyspace(ctx, ks, &topodatapb.Keyspace{}))
+		require.NoError(t, ts.SaveVSchema(ctx, ks, &vschemapb.Keyspace{
+			Tables: map[string]*vschemapb.Table{"t1": {}, "t2": {}},
+		}))
+	}
+
+	// Invalid rules are refused.
+	err := ValidateRoutingRules(ctx, ts, &vschemapb.RoutingRules{Rules: []*vschemapb.RoutingRule{
+		{FromTable: "t1", ToTables: []string{"t1"}},
+		{FromTable: "t2", ToTables: []string{"ks3.t2"}},
+		{FromTable: "t3", ToTables: []string{"ks1.t1"}},
+	}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "rule for table t1: table t1 must be qualified")
+	assert.Contains(t, err.Error(), "rule for table t2:")
+	assert.NotContains(t, err.Error(), "rule for table t3")
+
+	update, err := ApplyRoutingRulesBatch(ctx, ts, []*vschemapb.RoutingRule{
+		{FromTable: "t1", ToTables: []string{"ks1.t1"}},
+		{FromTable: "t2", ToTables: []string{"ks1.t2"}},
+	}, nil, false, "", false)
+	require.NoError(t, err)
+	assert.Equal(t, "", update.Version)
+	assert.Len(t, update.Changes, 2)
+	_, version, err := ts.GetRoutingRulesWithVersion(ctx)
+	require.NoError(t, err)
+
+	// A dry run reports the changes, without applying them.
+	update, err = ApplyRoutingRulesBatch(ctx, ts, []*vschemapb.RoutingRule{
+		{FromTable: "t1", ToTables: []string{"ks2.t1"}},
+	}, []string{"t2"}, false, version.String(), true)
+	require.NoError(t, err)
+	assert.Equal(t, []*RoutingRuleChange{
+		{FromTable: "t1", OldToTables: []string{"ks1.t1"}, NewToTables: []string{"ks2.t1"}},
+		{FromTable: "t2", OldToTables: []string{"ks1.t2"}},
+	}, update.Changes)
+	rr, _, err := ts.GetRoutingRulesWithVersion(ctx)
+	require.NoError(t, err)
+	assert.Len(t, rr.Rules, 2)
+
+	// The batch is applied atomically, at the expected version only.
+	_, err = ApplyRoutingRulesBatch(ctx, ts, []*vschemapb.RoutingRule{
+		{FromTable: "t1", ToTables: []string{"ks2.t1"}},
+	}, []string{"t2"}, false, version.String(), false)
+	require.NoError(t, err)
+	rr, _, err = ts.GetRoutingRulesWithVersion(ctx)
+	require.NoError(t, err)
+	require.Len(t, rr.Rules, 1)
+	assert.Equal(t, "t1", rr.Rules[0].FromTable)
+	assert.Equal(t, []string{"ks2.t1"}, rr.Rules[0].ToTables)
+	_, err = ApplyRoutingRulesBatch(ctx, ts, nil, []string{"t1"}, false, version.String(), false)
+	assert.True(t, topo.IsErrType(err, topo.BadVersion), "got %v, want BadVersion", err)
+}
+
+func TestRoutingRulesAffectedQueries(t *testing.T) {
+	changes := []*RoutingRuleChange{
+		{FromTable: "t1@replica", NewToTables: []string{"ks2.t1"}},
+		{FromTable: "ks1.t2", NewToTables: []string{"ks2.t2"}},
+	}
+	queries := []string{
+		"select * from t1 where id = :vtg1",
+		"select * from ks1.t1",
+		"select * from t2 join t3 on t2.id = t3.id",
+		"select * from ks1.t2",
+		"select * from ks3.t2",
+		"select * from t3",
+		"not a query",
+	}
+	assert.Equal(t, []string{
+		"select * from t1 where id = :vtg1",
+		"select * from t2 join t3 on t2.id = t3.id",
+		"select * from ks1.t2",
+	}, RoutingRulesAffectedQueries(queries, changes))
+}
@@ -87,6 +87,23 @@ func ChangeType(ctx context.Context, ts *topo.Server, tabletAlias *topodatapb.Ta
 	return result, nil
 }
 
+// SetTabletDrained drains or undrains a tablet. The gateways stop sending
+// new queries to a drained tablet, but let the in-flight ones finish. A
+// master cannot be drained.
+func SetTabletDrained(ctx context.Context, ts *topo.Server, tabletAlias *topodatapb.TabletAlias, drained bool) error {
+	_, err := ts.UpdateTabletFields(ctx, tabletAlias, func(tablet *topodatapb.Tablet) error {
+		if drained && tablet.Type == topodatapb.TabletType_MASTER {
+			return fmt.Errorf("cannot drain master tablet %v", topoproto.TabletAliasString(tabletAlias))
+		}
+		if topoproto.IsTabletDrained(tablet) == drained {
+			return topo.NewError(topo.NoUpdateNeeded, topoproto.TabletAliasString(tabletAlias))
+		}
+		topoproto.SetTabletDrained(tablet, drained)
+		return nil
+	})
+	return err
+}
+
 // CheckOwnership returns nil iff the Hostname and port match on oldTablet and
 // newTablet, which implies that no other tablet process has taken over the
 // record.
@@ -167,6 +167,11 @@ func (f *fakeVTGateService) ResolveTransaction(ctx context.Context, dtid string)
 	return nil
 }
 
+// GetBufferStatus is part of the VTGateService interface
+func (f *fakeVTGateService) GetBufferStatus(ctx context.Context) (*vtgatepb.GetBufferStatusResponse, error) {
+	return &vtgatepb.GetBufferStatusResponse{}, nil
+}
+
 func (f *fakeVTGateService) VStream(ctx context.Context, tabletType topodatapb.TabletType, vgtid *binlogdatapb.VGtid, filter *binlogdatapb.Filter, flags *vtgatepb.VStreamFlags, send func([]*binlogdatapb.VEvent) error) error {
 	return nil
 }
@@ -719,6 +719,10 @@ func (itmc *internalTabletManagerClient) ExecuteFetchAsDba(ctx context.Context,
 	return nil, fmt.Errorf("not implemented in vtcombo")
 }
 
+func (itmc *internalTabletManagerClient) ExecuteFetchAsDbaMulti(ctx context.Context, tablet *topodatapb.Tablet, usePool bool, queries [][]byte, maxRows int, disableBinlogs, reloadSchema bool) ([]*querypb.QueryResult, error) {
+	return nil, fmt.Errorf("not implemented in vtcombo")
+}
+
 func (itmc *internalTabletManagerClient) ExecuteFetchAsAllPrivs(ctx context.Context, tablet *topodatapb.Tablet, query []byte, maxRows int, reloadSchema bool) (*querypb.QueryResult, error) {
 	return nil, fmt.Errorf("not implemented in vtcombo")
 }
@@ -17,17 +17,23 @@ limitations under the License.
 package vtctl
 
 import (
-	"errors"
 	"flag"
 	"fmt"
 	"io"
+	"strings"
+	"time"
 
 	"context"
 
+	"vitess.io/vitess/go/json2"
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/protoutil"
 	"vitess.io/vitess/go/vt/logutil"
 	"vitess.io/vitess/go/vt/mysqlctl/backupstorage"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
 	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vtctl/backuputil"
 	"vitess.io/vitess/go/vt/wrangler"
 )
 
@@ -47,6 +53,62 @@ func init() {
 		commandRemoveBackup,
 		"<keyspace/shard> <backup name>",
 		"Removes a backup for the BackupStorage."})
+	addCommand("Shards", command{
+		"RestoreToPointInTime",
+		commandRestoreToPointInTime,
+		"[-restore_to_pos=<position>] [-restore_to_timestamp=<RFC 3339 time>] [-dry_run] <keyspace/shard>",
+		"Restores a shard to a GTID position or to a time on a new spare tablet, started by the provision_pitr_tablet hook: the most recent full backup before it is restored, then the binary logs of its incremental backups are replayed up to it. The tablet doesn't replicate. Prints the backups used as JSON."})
+
+	addCommand("Keyspaces", command{
+		"BackupKeyspace",
+		commandBackupKeyspace,
+		"[-concurrency=4] [-shard_concurrency=1] [-allow_master=false] [-shards=<shard>,...] <keyspace>",
+		"Backs up all the shards of a keyspace, each on its replica, rdonly or spare tablet the least behind its master, and prints the outcome of each shard as JSON."})
+	addCommand("Keyspaces", command{
+		"ListKeyspaceBackups",
+		commandListKeyspaceBackups,
+		"<keyspace>",
+		"Lists the backups of all the shards of a keyspace as JSON."})
+	addCommand("Keyspaces", command{
+		"GetKeyspaceBackupRuns",
+		commandGetKeyspaceBackupRuns,
+		"[<keyspace>]",
+		"Prints the progress of the running and the last keyspace backups of vtctld, e.g. the ones of the backup schedules, as JSON."})
+	addCommand("Keyspaces", command{
+		"PruneBackups",
+		commandPruneBackups,
+		"[-keep_last=<n>] [-max_age=<duration>] [-dry_run] <keyspace>",
+		"Removes the backups of the shards of a keyspace which are neither among the last ones nor younger than the max age, and prints them as JSON. The most recent backup of each shard is always kept."})
+	addCommand("Keyspaces", command{
+		"SetBackupSchedule",
+		commandSetBackupSchedule,
+		"-cron=<cron expression> [-concurrency=4] [-shard_concurrency=1] [-allow_master=false] [-keep_last=<n>] [-max_age=<duration>] <keyspace>",
+		"Sets the backup schedule of a keyspace: vtctld backs up all its shards whenever the cron expression matches, then removes the backups out of the retention."})
+	addCommand("Keyspaces", command{
+		"GetBackupSchedule",
+		commandGetBackupSchedule,
+		"<keyspace>",
+		"Prints the backup schedule of a keyspace and its next run as JSON."})
+	addCommand("Keyspaces", command{
+		"DeleteBackupSchedule",
+		commandDeleteBackupSchedule,
+		"<keyspace>",
+		"Deletes the backup schedule of a keyspace. Its backups are kept."})
+	addCommand("Keyspaces", command{
+		"SetBackupStorageConfig",
+		commandSetBackupStorageConfig,
+		"<keyspace> <config json>",
+		"Sets the backup storage config of a keyspace, which overrides the backup storage flags of the tablets for the backups of its shards, e.g. '{\"s3\": {\"bucket\": \"backups\", \"sse_kms_key_id\": \"<key>\"}}'."})
+	addCommand("Keyspaces", command{
+		"GetBackupStorageConfig",
+		commandGetBackupStorageConfig,
+		"<keyspace>",
+		"Prints the backup storage config of a keyspace as JSON."})
+	addCommand("Keyspaces", command{
+		"DeleteBackupStorageConfig",
+		commandDeleteBackupStorageConfig,
+		"<keyspace>",
+		"Deletes the backup storage config of a keyspace, so that the backup storage flags of the tablets apply again."})
 
 	addCommand("Tablets", command{
 		"Backup",
@@ -99,54 +161,11 @@ func commandBackupShard(ctx context.Context, wr *wrangler.Wrangler, subFlags *fl
 		return err
 	}
 
-	tablets, stats, err := wr.ShardReplicationStatuses(ctx, keyspace, shard)
-	if tablets == nil {
+	tabletForBackup, _, err := backuputil.ChooseBackupTablet(ctx, wr.TopoServer(), wr.TabletManagerClient(), keyspace, shard, *allowMaster)
+	if err != nil {
 		return err
 	}
 
-	var tabletForBackup *topodatapb.Tablet
-	var secondsBehind uint32
-
-	for i := range tablets {
-		// find a replica, rdonly or spare tablet type to run the backup on
-		switch tablets[i].Type {
-		case topodatapb.TabletType_REPLICA, topodatapb.TabletType_RDONLY, topodatapb.TabletType_SPARE:
-		default:
-			continue
-		}
-		// choose the first tablet as the baseline
-		if tabletForBackup == nil {
-			tabletForBackup = tablets[i].Tablet
-			secondsBehind = stats[i].SecondsBehindMaster
-			continue
-		}
-
-		// choose a new tablet if it is more up to date
-		if stats[i].SecondsBehindMaster < secondsBehind {
-			tabletForBackup = tablets[i].Tablet
-			secondsBehind = stats[i].SecondsBehindMaster
-		}
-	}
-
-	// if no other tablet is available and allowMaster is set to true
-	if tabletForBackup == nil && *allowMaster {
-	ChooseMaster:
-		for i := range tablets {
-			switch tablets[i].Type {
-			case topodatapb.TabletType_MASTER:
-				tabletForBackup = tablets[i].Tablet
-				secondsBehind = 0 //nolint
-				break ChooseMaster
-			default:
-				continue
-			}
-		}
-	}
-
-	if tabletForBackup == nil {
-		return errors.New("no tablet available for backup")
-	}
-
 	return execBackup(ctx, wr, tabletForBackup, *concurrency, *allowMaster)
 }
 
@@ -221,6 +240,240 @@ func commandRemoveBackup(ctx context.Context, wr *wrangler.Wrangler, subFlags *f
 	return bs.RemoveBackup(ctx, bucket, name)
 }
 
+func commandRestoreToPointInTime(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	restoreToPos := subFlags.String("restore_to_pos", "", "The GTID position to restore to, with its flavor, e.g. MySQL56/<uuid>:1-100")
+	restoreToTimestamp := subFlags.String("restore_to_timestamp", "", "The time to restore to, in RFC 3339 format, e.g. 2021-04-01T15:04:05Z")
+	dryRun := subFlags.Bool("dry_run", false, "Only print the backups which would be used")
+
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("the <keyspace/shard> argument is required for the RestoreToPointInTime command")
+	}
+	keyspace, shard, err := topoproto.ParseKeyspaceShard(subFlags.Arg(0))
+	if err != nil {
+		return err
+	}
+	var toPos mysql.Position
+	if *restoreToPos != "" {
+		if toPos, err = mysql.DecodePosition(*restoreToPos); err != nil {
+			return fmt.Errorf("invalid -restore_to_pos %q: %v", *restoreToPos, err)
+		}
+	}
+	var toTime time.Time
+	if *restoreToTimestamp != "" {
+		if toTime, err = time.Parse(time.RFC3339, *restoreToTimestamp); err != nil {
+			return fmt.Errorf("invalid -restore_to_timestamp %q: %v", *restoreToTimestamp, err)
+		}
+	}
+
+	plan, err := backuputil.RestoreToPointInTime(ctx, wr.Logger(), keyspace, shard, toPos, toTime, *dryRun)
+	if plan != nil {
+		if err := printJSON(wr.Logger(), plan); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+func commandBackupKeyspace(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	concurrency := subFlags.Int("concurrency", 4, "Specifies the number of compression/checksum jobs to run simultaneously for each shard")
+	shardConcurrency := subFlags.Int("shard_concurrency", 1, "Specifies the number of shards to back up simultaneously")
+	allowMaster := subFlags.Bool("allow_master", false, "Whether to back up a shard on its master if it has no other tablet to back up. Warning!! If you are using the builtin backup engine, this will shutdown your master mysql for as long as it takes to create a backup ")
+	shards := subFlags.String("shards", "", "Comma separated list of the shards to back up. Defaults to all the shards of the keyspace")
+
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("the <keyspace> argument is required for the BackupKeyspace command")
+	}
+
+	opts := backuputil.KeyspaceBackupOptions{
+		Concurrency:       *shardConcurrency,
+		BackupConcurrency: *concurrency,
+		AllowMaster:       *allowMaster,
+		Trigger:           "manual",
+	}
+	if *shards != "" {
+		opts.Shards = strings.Split(*shards, ",")
+	}
+	run, err := backuputil.BackupKeyspace(ctx, wr.TopoServer(), wr.TabletManagerClient(), wr.Logger(), subFlags.Arg(0), opts)
+	if run != nil {
+		if err := printJSON(wr.Logger(), run); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+func commandListKeyspaceBackups(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("the <keyspace> argument is required for the ListKeyspaceBackups command")
+	}
+
+	backups, err := backuputil.ListKeyspaceBackups(ctx, wr.TopoServer(), subFlags.Arg(0))
+	if err != nil {
+		return err
+	}
+	return printJSON(wr.Logger(), backups)
+}
+
+func commandGetKeyspaceBackupRuns(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() > 1 {
+		return fmt.Errorf("the GetKeyspaceBackupRuns command accepts at most the <keyspace> argument")
+	}
+
+	return printJSON(wr.Logger(), backuputil.KeyspaceBackupRuns(subFlags.Arg(0)))
+}
+
+func commandPruneBackups(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	keepLast := subFlags.Int("keep_last", 0, "Keep the given number of the most recent backups of each shard")
+	maxAge := subFlags.Duration("max_age", 0, "Keep the backups younger than the given duration, e.g. 720h")
+	dryRun := subFlags.Bool("dry_run", false, "Only print the backups which would be removed")
+
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("the <keyspace> argument is required for the PruneBackups command")
+	}
+
+	pruned, err := backuputil.PruneBackups(ctx, wr.TopoServer(), wr.Logger(), subFlags.Arg(0), backuputil.BackupRetention{KeepLast: *keepLast, MaxAge: *maxAge}, *dryRun)
+	if pruned != nil {
+		if err := printJSON(wr.Logger(), pruned); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+func commandSetBackupSchedule(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	cron := subFlags.String("cron", "", "The cron expression of the schedule, in the local time of vtctld, e.g. '0 3 * * *' or '@daily'")
+	concurrency := subFlags.Int("concurrency", 4, "Specifies the number of compression/checksum jobs to run simultaneously for each shard")
+	shardConcurrency := subFlags.Int("shard_concurrency", 1, "Specifies the number of shards to back up simultaneously")
+	allowMaster := subFlags.Bool("allow_master", false, "Whether to back up a shard on its master if it has no other tablet to back up")
+	keepLast := subFlags.Int("keep_last", 0, "After each successful run, keep the given number of the most recent backups of each shard")
+	maxAge := subFlags.Duration("max_age", 0, "After each successful run, keep the backups younger than the given duration, e.g. 720h")
+
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("the <keyspace> argument is required for the SetBackupSchedule command")
+	}
+	if *cron == "" {
+		return fmt.Errorf("the -cron flag is required for the SetBackupSchedule command")
+	}
+	if *keepLast < 0 {
+		return fmt.Errorf("invalid -keep_last %v: must be positive", *keepLast)
+	}
+	schedule := &topodatapb.BackupSchedule{
+		Cron:              *cron,
+		Concurrency:       uint32(*shardConcurrency),
+		BackupConcurrency: uint32(*concurrency),
+		AllowPrimary:      *allowMaster,
+		KeepLast:          uint32(*keepLast),
+		CreateTime:        protoutil.TimeToProto(time.Now()),
+	}
+	if *maxAge != 0 {
+		schedule.MaxAge = protoutil.DurationToProto(*maxAge)
+	}
+	return backuputil.SaveSchedule(ctx, wr.TopoServer(), subFlags.Arg(0), schedule)
+}
+
+func commandGetBackupSchedule(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("the <keyspace> argument is required for the GetBackupSchedule command")
+	}
+
+	status, err := backuputil.GetScheduleStatus(ctx, wr.TopoServer(), subFlags.Arg(0))
+	if err != nil {
+		return err
+	}
+	if status == nil {
+		return fmt.Errorf("keyspace %v has no backup schedule", subFlags.Arg(0))
+	}
+	return printJSON(wr.Logger(), &vtctldatapb.GetBackupScheduleResponse{
+		Schedule:    status.Schedule,
+		NextRunTime: protoutil.TimeToProto(status.NextRunTime),
+	})
+}
+
+func commandDeleteBackupSchedule(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("the <keyspace> argument is required for the DeleteBackupSchedule command")
+	}
+
+	return wr.TopoServer().DeleteBackupSchedule(ctx, subFlags.Arg(0))
+}
+
+func commandSetBackupStorageConfig(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 2 {
+		return fmt.Errorf("the <keyspace> and <config json> arguments are required for the SetBackupStorageConfig command")
+	}
+
+	config := &topodatapb.BackupStorageConfig{}
+	if err := json2.Unmarshal([]byte(subFlags.Arg(1)), config); err != nil {
+		return fmt.Errorf("invalid backup storage config %v: %v", subFlags.Arg(1), err)
+	}
+	_, err := wr.VtctldServer().SetBackupStorageConfig(ctx, &vtctldatapb.SetBackupStorageConfigRequest{
+		Keyspace: subFlags.Arg(0),
+		Config:   config,
+	})
+	return err
+}
+
+func commandGetBackupStorageConfig(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("the <keyspace> argument is required for the GetBackupStorageConfig command")
+	}
+
+	resp, err := wr.VtctldServer().GetBackupStorageConfig(ctx, &vtctldatapb.GetBackupStorageConfigRequest{
+		Keyspace: subFlags.Arg(0),
+	})
+	if err != nil {
+		return err
+	}
+	if resp.Config == nil {
+		return fmt.Errorf("keyspace %v has no backup storage config", subFlags.Arg(0))
+	}
+	return printJSON(wr.Logger(), resp.Config)
+}
+
+func commandDeleteBackupStorageConfig(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("the <keyspace> argument is required for the DeleteBackupStorageConfig command")
+	}
+
+	_, err := wr.VtctldServer().DeleteBackupStorageConfig(ctx, &vtctldatapb.DeleteBackupStorageConfigRequest{
+		Keyspace: subFlags.Arg(0),
+	})
+	return err
+}
+
 func commandRestoreFromBackup(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
 	if err := subFlags.Parse(args); err != nil {
 		return err
@@ -0,0 +1,512 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backuputil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/vt/concurrency"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/mysqlctl"
+	"vitess.io/vitess/go/vt/mysqlctl/backupstorage"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/topotools"
+	"vitess.io/vitess/go/vt/vttablet/tmclient"
+
+	logutilpb "vitess.io/vitess/go/vt/proto/logutil"
+	replicationdatapb "vitess.io/vitess/go/vt/proto/replicationdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// This file implements the backups of whole keyspaces: a tablet is chosen
+// in each shard, and the shards are backed up with a limited concurrency.
+// The runs are tracked in memory, so that the runs of this process, e.g.
+// the ones of the backup schedules of vtctld, can be monitored while they
+// progress.
+
+// The states of a keyspace backup run and of the backups of its shards.
+const (
+	BackupStatePending  = "pending"
+	BackupStateRunning  = "running"
+	BackupStateComplete = "complete"
+	BackupStateFailed   = "failed"
+)
+
+// maxBackupRuns is the number of finished keyspace backup runs which are
+// remembered.
+const maxBackupRuns = 100
+
+// KeyspaceBackupOptions are the options of BackupKeyspace.
+type KeyspaceBackupOptions struct {
+	// Shards are the shards to back up, or all the shards of the keyspace
+	// if empty.
+	Shards []string
+	// Concurrency is the number of shards backed up at the same time. It
+	// defaults to 1.
+	Concurrency int
+	// BackupConcurrency is the number of compression/checksum jobs of the
+	// backup of a shard. It defaults to 4.
+	BackupConcurrency int
+	// AllowMaster allows the backup of a shard on its master if it has no
+	// other tablet to back up.
+	AllowMaster bool
+	// Trigger describes what started the run, e.g. "manual" or
+	// "schedule".
+	Trigger string
+}
+
+// ShardBackupStatus is the progress of the backup of a shard.
+type ShardBackupStatus struct {
+	Shard               string                  `json:"shard"`
+	State               string                  `json:"state"`
+	Tablet              *topodatapb.TabletAlias `json:"tablet,omitempty"`
+	SecondsBehindMaster uint32                  `json:"seconds_behind_master"`
+	StartTime           time.Time               `json:"start_time"`
+	EndTime             time.Time               `json:"end_time"`
+	Error               string                  `json:"error,omitempty"`
+}
+
+// KeyspaceBackupRun is the progress of the backup of a keyspace.
+type KeyspaceBackupRun struct {
+	ID        string               `json:"id"`
+	Keyspace  string               `json:"keyspace"`
+	Trigger   string               `json:"trigger,omitempty"`
+	State     string               `json:"state"`
+	StartTime time.Time            `json:"start_time"`
+	EndTime   time.Time            `json:"end_time"`
+	Error     string               `json:"error,omitempty"`
+	Shards    []*ShardBackupStatus `json:"shards"`
+}
+
+// backupRunTracker remembers the running and the last finished keyspace
+// backup runs. The runs are only modified under its mutex, and returned
+// as copies.
+type backupRunTracker struct {
+	mu     sync.Mutex
+	nextID int64
+	runs   []*KeyspaceBackupRun
+}
+
+var backupRuns = &backupRunTracker{}
+
+func (t *backupRunTracker) start(keyspace, trigger string, shards []string) *KeyspaceBackupRun {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.nextID++
+	run := &KeyspaceBackupRun{
+		ID:        strconv.FormatInt(t.nextID, 10),
+		Keyspace:  keyspace,
+		Trigger:   trigger,
+		State:     BackupStateRunning,
+		StartTime: time.Now(),
+		Shards:    make([]*ShardBackupStatus, len(shards)),
+	}
+	for i, shard := range shards {
+		run.Shards[i] = &ShardBackupStatus{Shard: shard, State: BackupStatePending}
+	}
+	t.runs = append(t.runs, run)
+
+	// Forget the oldest finished runs.
+	finished := 0
+	for _, r := range t.runs {
+		if r.State != BackupStateRunning {
+			finished++
+		}
+	}
+	kept := t.runs[:0]
+	for _, r := range t.runs {
+		if finished > maxBackupRuns && r.State != BackupStateRunning {
+			finished--
+			continue
+		}
+		kept = append(kept, r)
+	}
+	t.runs = kept
+	return run
+}
+
+func (t *backupRunTracker) update(f func()) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	f()
+}
+
+func (t *backupRunTracker) snapshot(run *KeyspaceBackupRun) *KeyspaceBackupRun {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return copyBackupRun(run)
+}
+
+func copyBackupRun(run *KeyspaceBackupRun) *KeyspaceBackupRun {
+	c := *run
+	c.Shards = make([]*ShardBackupStatus, len(run.Shards))
+	for i, shard := range run.Shards {
+		s := *shard
+		c.Shards[i] = &s
+	}
+	return &c
+}
+
+// KeyspaceBackupRuns returns the keyspace backup runs of this process
+// which are running or among the last finished ones, oldest first. If
+// keyspace isn't empty, only its runs are returned.
+func KeyspaceBackupRuns(keyspace string) []*KeyspaceBackupRun {
+	backupRuns.mu.Lock()
+	defer backupRuns.mu.Unlock()
+	var runs []*KeyspaceBackupRun
+	for _, run := range backupRuns.runs {
+		if keyspace == "" || run.Keyspace == keyspace {
+			runs = append(runs, copyBackupRun(run))
+		}
+	}
+	return runs
+}
+
+// BackupKeyspace backs up the shards of the keyspace, each on the tablet
+// chosen by ChooseBackupTablet, and logs their progress prefixed with the
+// shard. The backups of the other shards go on if one fails. It returns
+// the finished run, and an error if any backup failed.
+func BackupKeyspace(ctx context.Context, ts *topo.Server, tmc tmclient.TabletManagerClient, logger logutil.Logger, keyspace string, opts KeyspaceBackupOptions) (*KeyspaceBackupRun, error) {
+	shards := opts.Shards
+	if len(shards) == 0 {
+		var err error
+		if shards, err = ts.GetShardNames(ctx, keyspace); err != nil {
+			return nil, err
+		}
+		sort.Strings(shards)
+	}
+	if len(shards) == 0 {
+		return nil, fmt.Errorf("keyspace %v has no shards", keyspace)
+	}
+	shardConcurrency := opts.Concurrency
+	if shardConcurrency <= 0 {
+		shardConcurrency = 1
+	}
+	if opts.BackupConcurrency <= 0 {
+		opts.BackupConcurrency = 4
+	}
+
+	run := backupRuns.start(keyspace, opts.Trigger, shards)
+	logger.Infof("Backing up %v shards of keyspace %v, %v at a time (run %v)", len(shards), keyspace, shardConcurrency, run.ID)
+
+	sem := make(chan struct{}, shardConcurrency)
+	var wg sync.WaitGroup
+	rec := concurrency.AllErrorRecorder{}
+	for i, shard := range shards {
+		wg.Add(1)
+		go func(status *ShardBackupStatus, shard string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			err := backupKeyspaceShard(ctx, ts, tmc, logger, keyspace, shard, status, opts)
+			backupRuns.update(func() {
+				status.EndTime = time.Now()
+				status.State = BackupStateComplete
+				if err != nil {
+					status.State = BackupStateFailed
+					status.Error = err.Error()
+				}
+			})
+			if err != nil {
+				logger.Errorf("%v/%v: backup failed: %v", keyspace, shard, err)
+				rec.RecordError(fmt.Errorf("%v/%v: %v", keyspace, shard, err))
+				return
+			}
+			logger.Infof("%v/%v: backup complete", keyspace, shard)
+		}(run.Shards[i], shard)
+	}
+	wg.Wait()
+
+	err := rec.Error()
+	backupRuns.update(func() {
+		run.EndTime = time.Now()
+		run.State = BackupStateComplete
+		if err != nil {
+			run.State = BackupStateFailed
+			run.Error = err.Error()
+		}
+	})
+	return backupRuns.snapshot(run), err
+}
+
+func backupKeyspaceShard(ctx context.Context, ts *topo.Server, tmc tmclient.TabletManagerClient, logger logutil.Logger, keyspace, shard string, status *ShardBackupStatus, opts KeyspaceBackupOptions) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	tablet, secondsBehindMaster, err := ChooseBackupTablet(ctx, ts, tmc, keyspace, shard, opts.AllowMaster)
+	if err != nil {
+		return err
+	}
+	alias := topoproto.TabletAliasString(tablet.Alias)
+	backupRuns.update(func() {
+		status.State = BackupStateRunning
+		status.Tablet = tablet.Alias
+		status.SecondsBehindMaster = secondsBehindMaster
+		status.StartTime = time.Now()
+	})
+	logger.Infof("%v/%v: backing up on tablet %v (%vs behind master)", keyspace, shard, alias, secondsBehindMaster)
+
+	stream, err := tmc.Backup(ctx, tablet, opts.BackupConcurrency, opts.AllowMaster)
+	if err != nil {
+		return err
+	}
+	for {
+		e, err := stream.Recv()
+		switch err {
+		case nil:
+			logShardBackupEvent(logger, keyspace, shard, e)
+		case io.EOF:
+			return nil
+		default:
+			return err
+		}
+	}
+}
+
+// logShardBackupEvent logs an event of the backup of a shard, prefixed
+// with the shard, so that the events of the concurrent backups can be
+// told apart.
+func logShardBackupEvent(logger logutil.Logger, keyspace, shard string, e *logutilpb.Event) {
+	switch e.Level {
+	case logutilpb.Level_WARNING:
+		logger.Warningf("%v/%v: %s", keyspace, shard, e.Value)
+	case logutilpb.Level_ERROR:
+		logger.Errorf("%v/%v: %s", keyspace, shard, e.Value)
+	default:
+		logger.Infof("%v/%v: %s", keyspace, shard, e.Value)
+	}
+}
+
+// ChooseBackupTablet returns the replica, rdonly or spare tablet of the
+// shard which is the least behind its master, and how far behind it is.
+// The tablets whose replication status is unknown are skipped. If there is
+// no such tablet and allowMaster is true, the master is returned.
+func ChooseBackupTablet(ctx context.Context, ts *topo.Server, tmc tmclient.TabletManagerClient, keyspace, shard string, allowMaster bool) (*topodatapb.Tablet, uint32, error) {
+	tablets, stats, err := shardReplicationStatuses(ctx, ts, tmc, keyspace, shard)
+	if err != nil {
+		return nil, 0, err
+	}
+	return chooseBackupTablet(tablets, stats, allowMaster)
+}
+
+// shardReplicationStatuses returns the tablets of the shard, and the
+// replication status of each replica tablet, or nil if it can't be
+// reached.
+func shardReplicationStatuses(ctx context.Context, ts *topo.Server, tmc tmclient.TabletManagerClient, keyspace, shard string) ([]*topo.TabletInfo, []*replicationdatapb.Status, error) {
+	tabletMap, err := ts.GetTabletMapForShard(ctx, keyspace, shard)
+	if err != nil {
+		return nil, nil, err
+	}
+	tablets := topotools.CopyMapValues(tabletMap, []*topo.TabletInfo{}).([]*topo.TabletInfo)
+
+	wg := sync.WaitGroup{}
+	stats := make([]*replicationdatapb.Status, len(tablets))
+	for i, ti := range tablets {
+		if !ti.IsReplicaType() {
+			continue
+		}
+		wg.Add(1)
+		go func(i int, ti *topo.TabletInfo) {
+			defer wg.Done()
+			status, err := tmc.ReplicationStatus(ctx, ti.Tablet)
+			if err != nil {
+				log.Warningf("ReplicationStatus(%v) failed: %v", ti.AliasString(), err)
+				return
+			}
+			stats[i] = status
+		}(i, ti)
+	}
+	wg.Wait()
+	return tablets, stats, nil
+}
+
+func chooseBackupTablet(tablets []*topo.TabletInfo, stats []*replicationdatapb.Status, allowMaster bool) (*topodatapb.Tablet, uint32, error) {
+	var tabletForBackup *topodatapb.Tablet
+	var secondsBehind uint32
+	for i, ti := range tablets {
+		switch ti.Type {
+		case topodatapb.TabletType_REPLICA, topodatapb.TabletType_RDONLY, topodatapb.TabletType_SPARE:
+		default:
+			continue
+		}
+		if stats[i] == nil {
+			continue
+		}
+		if tabletForBackup == nil || stats[i].SecondsBehindMaster < secondsBehind {
+			tabletForBackup = ti.Tablet
+			secondsBehind = stats[i].SecondsBehindMaster
+		}
+	}
+	if tabletForBackup == nil && allowMaster {
+		for _, ti := range tablets {
+			if ti.Type == topodatapb.TabletType_MASTER {
+				return ti.Tablet, 0, nil
+			}
+		}
+	}
+	if tabletForBackup == nil {
+		return nil, 0, errors.New("no tablet available for backup")
+	}
+	return tabletForBackup, secondsBehind, nil
+}
+
+// BackupInfo is a backup of a shard.
+type BackupInfo struct {
+	Name string `json:"name"`
+	// Time and Tablet are parsed from the name, and are empty if it
+	// doesn't follow the format of mysqlctl.Backup.
+	Time   time.Time               `json:"time"`
+	Tablet *topodatapb.TabletAlias `json:"tablet,omitempty"`
+}
+
+// ShardBackups are the backups of a shard, oldest first.
+type ShardBackups struct {
+	Shard   string        `json:"shard"`
+	Backups []*BackupInfo `json:"backups"`
+}
+
+// ListKeyspaceBackups returns the backups of all the shards of the
+// keyspace.
+func ListKeyspaceBackups(ctx context.Context, ts *topo.Server, keyspace string) ([]*ShardBackups, error) {
+	shards, err := ts.GetShardNames(ctx, keyspace)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(shards)
+	bs, err := backupstorage.GetBackupStorage()
+	if err != nil {
+		return nil, err
+	}
+	defer bs.Close()
+
+	result := make([]*ShardBackups, 0, len(shards))
+	for _, shard := range shards {
+		dir := mysqlctl.GetBackupDir(keyspace, shard)
+		bhs, err := bs.ListBackups(ctx, dir)
+		if err != nil {
+			return nil, fmt.Errorf("cannot list the backups of %v: %v", dir, err)
+		}
+		shardBackups := &ShardBackups{Shard: shard, Backups: make([]*BackupInfo, 0, len(bhs))}
+		for _, bh := range bhs {
+			info := &BackupInfo{Name: bh.Name()}
+			if backupTime, alias, err := mysqlctl.ParseBackupName(dir, bh.Name()); err == nil {
+				if backupTime != nil {
+					info.Time = *backupTime
+				}
+				info.Tablet = alias
+			}
+			shardBackups.Backups = append(shardBackups.Backups, info)
+		}
+		sort.SliceStable(shardBackups.Backups, func(i, j int) bool {
+			return shardBackups.Backups[i].Name < shardBackups.Backups[j].Name
+		})
+		result = append(result, shardBackups)
+	}
+	return result, nil
+}
+
+// BackupRetention is which backups of a shard are kept.
+type BackupRetention struct {
+	// KeepLast is the number of the most recent backups which are kept.
+	KeepLast int
+	// MaxAge is the age after which the other backups are removed.
+	MaxAge time.Duration
+}
+
+// IsZero returns true if the retention keeps all the backups.
+func (r BackupRetention) IsZero() bool {
+	return r.KeepLast <= 0 && r.MaxAge <= 0
+}
+
+// PruneBackups removes the backups of the shards of the keyspace which are
+// out of the retention, and returns them. With dryRun, they are only
+// returned.
+func PruneBackups(ctx context.Context, ts *topo.Server, logger logutil.Logger, keyspace string, retention BackupRetention, dryRun bool) ([]*ShardBackups, error) {
+	if retention.IsZero() {
+		return nil, fmt.Errorf("the retention must keep the last backups or the backups younger than an age")
+	}
+	shardBackups, err := ListKeyspaceBackups(ctx, ts, keyspace)
+	if err != nil {
+		return nil, err
+	}
+	bs, err := backupstorage.GetBackupStorage()
+	if err != nil {
+		return nil, err
+	}
+	defer bs.Close()
+
+	now := time.Now()
+	var pruned []*ShardBackups
+	for _, sb := range shardBackups {
+		backups := backupsToPrune(sb.Backups, retention, now)
+		if len(backups) == 0 {
+			continue
+		}
+		pruned = append(pruned, &ShardBackups{Shard: sb.Shard, Backups: backups})
+		if dryRun {
+			continue
+		}
+		dir := mysqlctl.GetBackupDir(keyspace, sb.Shard)
+		for _, backup := range backups {
+			logger.Infof("Removing backup %v/%v", dir, backup.Name)
+			if err := bs.RemoveBackup(ctx, dir, backup.Name); err != nil {
+				return pruned, fmt.Errorf("cannot remove backup %v/%v: %v", dir, backup.Name, err)
+			}
+		}
+	}
+	return pruned, nil
+}
+
+// backupsToPrune returns the backups which are out of the retention: the
+// ones older than MaxAge, if set, which aren't among the KeepLast most
+// recent ones. The most recent backup is always kept, so that the shard
+// can still be restored, and so are the backups whose time is unknown.
+func backupsToPrune(backups []*BackupInfo, retention BackupRetention, now time.Time) []*BackupInfo {
+	if retention.IsZero() {
+		return nil
+	}
+	var dated []*BackupInfo
+	for _, backup := range backups {
+		if !backup.Time.IsZero() {
+			dated = append(dated, backup)
+		}
+	}
+	sort.SliceStable(dated, func(i, j int) bool { return dated[i].Time.Before(dated[j].Time) })
+
+	keep := retention.KeepLast
+	if keep < 1 {
+		keep = 1
+	}
+	var pruned []*BackupInfo
+	for i := 0; i < len(dated)-keep; i++ {
+		if retention.MaxAge > 0 && now.Sub(dated[i].Time) <= retention.MaxAge {
+			continue
+		}
+		pruned = append(pruned, dated[i])
+	}
+	return pruned
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backuputil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/topo"
+
+	replicationdatapb "vitess.io/vitess/go/vt/proto/replicationdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func TestChooseBackupTablet(t *testing.T) {
+	tablet := func(uid uint32, tabletType topodatapb.TabletType) *topo.TabletInfo {
+		return &topo.TabletInfo{Tablet: &topodatapb.Tablet{Alias: &topodatapb.TabletAlias{Cell: "zone1", Uid: uid}, Type: tabletType}}
+	}
+	tablets := []*topo.TabletInfo{
+		tablet(100, topodatapb.TabletType_MASTER),
+		tablet(101, topodatapb.TabletType_REPLICA),
+		tablet(102, topodatapb.TabletType_RDONLY),
+		tablet(103, topodatapb.TabletType_REPLICA),
+	}
+	stats := []*replicationdatapb.Status{
+		{},
+		{SecondsBehindMaster: 30},
+		{SecondsBehindMaster: 2},
+		nil, // unreachable
+	}
+	chosen, lag, err := chooseBackupTablet(tablets, stats, false)
+	require.NoError(t, err)
+	assert.EqualValues(t, 102, chosen.Alias.Uid)
+	assert.EqualValues(t, 2, lag)
+
+	_, _, err = chooseBackupTablet(tablets[:1], stats[:1], false)
+	assert.EqualError(t, err, "no tablet available for backup")
+	chosen, _, err = chooseBackupTablet(tablets[:1], stats[:1], true)
+	require.NoError(t, err)
+	assert.EqualValues(t, 100, chosen.Alias.Uid)
+}
+
+func TestBackupsToPrune(t *testing.T) {
+	now := time.Date(2021, time.March, 31, 12, 0, 0, 0, time.UTC)
+	var backups []*BackupInfo
+	for day := 1; day <= 5; day++ {
+		backups = append(backups, &BackupInfo{Name: string(rune('0' + day)), Time: now.AddDate(0, 0, -day)})
+	}
+	backups = append(backups, &BackupInfo{Name: "manual"})
+	names := func(backups []*BackupInfo) []string {
+		var result []string
+		for _, backup := range backups {
+			result = append(result, backup.Name)
+		}
+		return result
+	}
+
+	assert.Empty(t, backupsToPrune(backups, BackupRetention{}, now))
+	assert.Equal(t, []string{"5", "4"}, names(backupsToPrune(backups, BackupRetention{KeepLast: 3}, now)))
+	assert.Equal(t, []string{"5", "4", "3"}, names(backupsToPrune(backups, BackupRetention{MaxAge: 60 * time.Hour}, now)))
+	assert.Equal(t, []string{"5"}, names(backupsToPrune(backups, BackupRetention{KeepLast: 4, MaxAge: 60 * time.Hour}, now)))
+	// The most recent backup is always kept.
+	assert.Equal(t, []string{"5", "4", "3", "2"}, names(backupsToPrune(backups, BackupRetention{MaxAge: time.Hour}, now)))
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backuputil
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/vt/hook"
+	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/mysqlctl"
+	"vitess.io/vitess/go/vt/mysqlctl/backupstorage"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// This file implements the point-in-time restores of a shard: a new
+// tablet restores the most recent full backup of the shard before the
+// point in time, then replays the binary logs of its incremental backups
+// up to it. The tablet is provisioned by the provision_pitr_tablet hook,
+// run on vtctld, and doesn't replicate, so that the data can be inspected
+// or copied out of it.
+
+const provisionPITRTabletHook = "provision_pitr_tablet"
+
+// PointInTimeRestorePlan describes how a shard is restored to a point in
+// time.
+type PointInTimeRestorePlan struct {
+	Keyspace           string
+	Shard              string
+	RestoreToPos       string `json:",omitempty"`
+	RestoreToTimestamp string `json:",omitempty"`
+	// Backup is the full backup which is restored, and the incremental
+	// backups are replayed on it, in order.
+	Backup             string
+	BackupTime         string
+	BackupPosition     string
+	IncrementalBackups []string `json:",omitempty"`
+}
+
+// PlanPointInTimeRestore returns the backups a restore of the shard to the
+// position, or to the time, would use. Exactly one of them must be set.
+func PlanPointInTimeRestore(ctx context.Context, logger logutil.Logger, keyspace, shard string, toPos mysql.Position, toTime time.Time) (*PointInTimeRestorePlan, error) {
+	if toPos.IsZero() == toTime.IsZero() {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "exactly one of the position and the time to restore to must be set")
+	}
+	if toTime.After(time.Now()) {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "cannot restore to %v, which is in the future", toTime.Format(time.RFC3339))
+	}
+
+	bs, err := backupstorage.GetBackupStorage()
+	if err != nil {
+		return nil, err
+	}
+	defer bs.Close()
+	dir := mysqlctl.GetBackupDir(keyspace, shard)
+	bhs, err := bs.ListBackups(ctx, dir)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list the backups of %v: %v", dir, err)
+	}
+	if len(bhs) == 0 {
+		return nil, mysqlctl.ErrNoBackup
+	}
+
+	params := mysqlctl.RestoreParams{
+		Logger:             logger,
+		Keyspace:           keyspace,
+		Shard:              shard,
+		RestoreToPos:       toPos,
+		RestoreToTimestamp: toTime,
+	}
+	bh, err := mysqlctl.FindBackupToRestore(ctx, params, bhs)
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := mysqlctl.GetBackupManifest(ctx, bh)
+	if err != nil {
+		return nil, err
+	}
+	increments, err := mysqlctl.FindIncrementalBackupsToRestore(ctx, params, bh, bhs)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &PointInTimeRestorePlan{
+		Keyspace:       keyspace,
+		Shard:          shard,
+		Backup:         bh.Name(),
+		BackupTime:     manifest.BackupTime,
+		BackupPosition: mysql.EncodePosition(manifest.Position),
+	}
+	if !toPos.IsZero() {
+		plan.RestoreToPos = mysql.EncodePosition(toPos)
+	}
+	if !toTime.IsZero() {
+		plan.RestoreToTimestamp = toTime.UTC().Format(time.RFC3339)
+	}
+	for _, increment := range increments {
+		plan.IncrementalBackups = append(plan.IncrementalBackups, increment.Handle.Name())
+	}
+	return plan, nil
+}
+
+// RestoreToPointInTime plans the restore of the shard to the position, or
+// to the time, then runs the provision_pitr_tablet hook to start the
+// tablet which restores it, unless dryRun is set.
+func RestoreToPointInTime(ctx context.Context, logger logutil.Logger, keyspace, shard string, toPos mysql.Position, toTime time.Time, dryRun bool) (*PointInTimeRestorePlan, error) {
+	plan, err := PlanPointInTimeRestore(ctx, logger, keyspace, shard, toPos, toTime)
+	if err != nil {
+		return nil, err
+	}
+	logger.Infof("Restoring %v/%v from backup %v, then %v incremental backups", keyspace, shard, plan.Backup, len(plan.IncrementalBackups))
+	if dryRun {
+		return plan, nil
+	}
+	return plan, runPITRHook(ctx, logger, plan)
+}
+
+// runPITRHook runs the provision_pitr_tablet hook for the plan. If the
+// hook doesn't exist, it logs how to start the tablet by hand.
+func runPITRHook(ctx context.Context, logger logutil.Logger, plan *PointInTimeRestorePlan) error {
+	env := map[string]string{
+		"KEYSPACE":             plan.Keyspace,
+		"SHARD":                plan.Shard,
+		"TABLET_TYPE":          strings.ToLower(topodatapb.TabletType_SPARE.String()),
+		"RESTORE_TO_POS":       plan.RestoreToPos,
+		"RESTORE_TO_TIMESTAMP": plan.RestoreToTimestamp,
+		"BACKUP":               plan.Backup,
+	}
+	hr := hook.NewHookWithEnv(provisionPITRTabletHook, nil, env).ExecuteContext(ctx)
+	switch hr.ExitStatus {
+	case hook.HOOK_SUCCESS:
+		return nil
+	case hook.HOOK_DOES_NOT_EXIST:
+		restoreFlag := "-restore_to_pos " + plan.RestoreToPos
+		if plan.RestoreToTimestamp != "" {
+			restoreFlag = "-restore_to_timestamp " + plan.RestoreToTimestamp
+		}
+		logger.Warningf("No %v hook: start a vttablet for %v/%v with -init_keyspace %v -init_shard %v -init_tablet_type %v -restore_from_backup %v", provisionPITRTabletHook, plan.Keyspace, plan.Shard, plan.Keyspace, plan.Shard, env["TABLET_TYPE"], restoreFlag)
+		return nil
+	default:
+		return fmt.Errorf("%v hook failed for %v/%v: %v", provisionPITRTabletHook, plan.Keyspace, plan.Shard, hr.String())
+	}
+}
@@ -0,0 +1,266 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package backuputil backs up whole keyspaces, and runs the backup
+// schedules of the keyspaces: it backs up all the shards of a keyspace
+// whenever the cron expression of its schedule matches, then removes the
+// backups which are out of its retention. It also plans and starts the
+// point-in-time restores of the shards.
+package backuputil
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/vttablet/tmclient"
+	"vitess.io/vitess/go/vt/workflow"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// ScheduleStatus is the backup schedule of a keyspace, with its next run.
+type ScheduleStatus struct {
+	Schedule    *topodatapb.BackupSchedule
+	Keyspace    string
+	NextRunTime time.Time
+}
+
+// ValidateSchedule checks the cron expression and the retention of the
+// schedule.
+func ValidateSchedule(schedule *topodatapb.BackupSchedule) error {
+	if _, err := workflow.NextCronTime(schedule.Cron, time.Now()); err != nil {
+		return err
+	}
+	_, err := Retention(schedule)
+	return err
+}
+
+// Retention returns the retention of the schedule.
+func Retention(schedule *topodatapb.BackupSchedule) (BackupRetention, error) {
+	retention := BackupRetention{KeepLast: int(schedule.KeepLast)}
+	maxAge, ok, err := protoutil.DurationFromProto(schedule.MaxAge)
+	if err != nil {
+		return retention, fmt.Errorf("invalid max_age: %v", err)
+	}
+	if ok {
+		if maxAge <= 0 {
+			return retention, fmt.Errorf("invalid max_age %v: must be a positive duration", maxAge)
+		}
+		retention.MaxAge = maxAge
+	}
+	return retention, nil
+}
+
+// SaveSchedule validates and saves the backup schedule of the keyspace. A
+// schedule which is replaced keeps its history, so that the runs it
+// already made aren't made again.
+func SaveSchedule(ctx context.Context, ts *topo.Server, keyspace string, schedule *topodatapb.BackupSchedule) error {
+	if _, err := ts.GetKeyspace(ctx, keyspace); err != nil {
+		return err
+	}
+	if err := ValidateSchedule(schedule); err != nil {
+		return err
+	}
+	existing, err := ts.GetBackupSchedule(ctx, keyspace)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		schedule.CreateTime = existing.CreateTime
+		schedule.LastScheduledTime = existing.LastScheduledTime
+		schedule.LastRunTime = existing.LastRunTime
+		schedule.LastError = existing.LastError
+	}
+	return ts.SaveBackupSchedule(ctx, keyspace, schedule)
+}
+
+// NextRun returns when the next run of the schedule is due.
+func NextRun(schedule *topodatapb.BackupSchedule) (time.Time, error) {
+	last := schedule.LastScheduledTime
+	if last == nil {
+		last = schedule.CreateTime
+	}
+	return workflow.NextCronTime(schedule.Cron, protoutil.TimeFromProto(last).In(time.Local))
+}
+
+// GetScheduleStatus returns the backup schedule of the keyspace with its
+// next run, or nil if it has none.
+func GetScheduleStatus(ctx context.Context, ts *topo.Server, keyspace string) (*ScheduleStatus, error) {
+	schedule, err := ts.GetBackupSchedule(ctx, keyspace)
+	if err != nil || schedule == nil {
+		return nil, err
+	}
+	status := &ScheduleStatus{Schedule: schedule, Keyspace: keyspace}
+	if status.NextRunTime, err = NextRun(schedule); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// Scheduler runs the backup schedules of all the keyspaces. Several
+// schedulers, e.g. in several vtctlds, can run at the same time: each due
+// run is claimed in the topology by a single one of them. A run is
+// skipped if the previous run of the keyspace by the same scheduler is
+// still running, and the runs missed while no scheduler was running are
+// coalesced into a single run.
+type Scheduler struct {
+	ts  *topo.Server
+	tmc tmclient.TabletManagerClient
+
+	mu      sync.Mutex
+	running map[string]bool
+	wg      sync.WaitGroup
+}
+
+// NewScheduler returns a new Scheduler.
+func NewScheduler(ts *topo.Server, tmc tmclient.TabletManagerClient) *Scheduler {
+	return &Scheduler{
+		ts:      ts,
+		tmc:     tmc,
+		running: make(map[string]bool),
+	}
+}
+
+// Run checks the schedules every interval, until ctx is done. The running
+// backups are canceled then.
+func (s *Scheduler) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			s.wg.Wait()
+			return
+		case <-ticker.C:
+		}
+		s.Check(ctx, time.Now())
+	}
+}
+
+// Check starts the runs of the schedules which are due at now, in the
+// background.
+func (s *Scheduler) Check(ctx context.Context, now time.Time) {
+	keyspaces, err := s.ts.GetKeyspaces(ctx)
+	if err != nil {
+		log.Warningf("Cannot get the keyspaces to check their backup schedules: %v", err)
+		return
+	}
+	for _, keyspace := range keyspaces {
+		schedule, claimed, err := s.claim(ctx, keyspace, now)
+		if err != nil {
+			log.Warningf("Cannot check the backup schedule of keyspace %v: %v", keyspace, err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+		if !s.setRunning(keyspace, true) {
+			log.Infof("Skipping the backup of keyspace %v due at %v, its previous backup is still running", keyspace, now)
+			continue
+		}
+		s.wg.Add(1)
+		go func(keyspace string, schedule *topodatapb.BackupSchedule) {
+			defer s.wg.Done()
+			defer s.setRunning(keyspace, false)
+			s.runSchedule(ctx, keyspace, schedule)
+		}(keyspace, schedule)
+	}
+}
+
+// claim records that the run of the schedule of the keyspace due at now
+// is taken care of, if there is one and no other scheduler claimed it.
+func (s *Scheduler) claim(ctx context.Context, keyspace string, now time.Time) (*topodatapb.BackupSchedule, bool, error) {
+	schedule, err := s.ts.GetBackupSchedule(ctx, keyspace)
+	if err != nil || schedule == nil {
+		return nil, false, err
+	}
+	schedule, err = s.ts.UpdateBackupScheduleFields(ctx, keyspace, func(schedule *topodatapb.BackupSchedule) error {
+		next, err := NextRun(schedule)
+		if err != nil {
+			return err
+		}
+		if next.IsZero() || next.After(now) {
+			return topo.NewError(topo.NoUpdateNeeded, keyspace)
+		}
+		schedule.LastScheduledTime = protoutil.TimeToProto(now)
+		return nil
+	})
+	switch {
+	case topo.IsErrType(err, topo.NoUpdateNeeded), topo.IsErrType(err, topo.NoNode):
+		return nil, false, nil
+	case err != nil:
+		return nil, false, err
+	}
+	return schedule, true, nil
+}
+
+// setRunning marks the keyspace as running or not, and returns false if
+// it already was in that state.
+func (s *Scheduler) setRunning(keyspace string, running bool) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.running[keyspace] == running {
+		return false
+	}
+	if running {
+		s.running[keyspace] = true
+	} else {
+		delete(s.running, keyspace)
+	}
+	return true
+}
+
+// runSchedule backs up the keyspace, then prunes its backups if they all
+// succeeded, and records the outcome in the schedule.
+func (s *Scheduler) runSchedule(ctx context.Context, keyspace string, schedule *topodatapb.BackupSchedule) {
+	logger := logutil.NewConsoleLogger()
+	log.Infof("Starting the scheduled backup of keyspace %v", keyspace)
+	_, runErr := BackupKeyspace(ctx, s.ts, s.tmc, logger, keyspace, KeyspaceBackupOptions{
+		Concurrency:       int(schedule.Concurrency),
+		BackupConcurrency: int(schedule.BackupConcurrency),
+		AllowMaster:       schedule.AllowPrimary,
+		Trigger:           "schedule",
+	})
+	if runErr == nil {
+		var retention BackupRetention
+		if retention, runErr = Retention(schedule); runErr == nil && !retention.IsZero() {
+			_, runErr = PruneBackups(ctx, s.ts, logger, keyspace, retention, false /* dryRun */)
+		}
+	}
+	if runErr != nil {
+		log.Warningf("Scheduled backup of keyspace %v failed: %v", keyspace, runErr)
+	} else {
+		log.Infof("Scheduled backup of keyspace %v complete", keyspace)
+	}
+
+	_, err := s.ts.UpdateBackupScheduleFields(ctx, keyspace, func(schedule *topodatapb.BackupSchedule) error {
+		schedule.LastRunTime = protoutil.TimeToProto(time.Now())
+		schedule.LastError = ""
+		if runErr != nil {
+			schedule.LastError = runErr.Error()
+		}
+		return nil
+	})
+	if err != nil && !topo.IsErrType(err, topo.NoNode) {
+		log.Warningf("Cannot record the scheduled backup of keyspace %v: %v", keyspace, err)
+	}
+}
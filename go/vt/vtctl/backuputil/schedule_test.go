@@ -0,0 +1,78 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backuputil
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func TestValidateSchedule(t *testing.T) {
+	assert.NoError(t, ValidateSchedule(&topodatapb.BackupSchedule{Cron: "@daily", KeepLast: 7, MaxAge: protoutil.DurationToProto(720 * time.Hour)}))
+	assert.Error(t, ValidateSchedule(&topodatapb.BackupSchedule{Cron: "@often"}))
+	assert.EqualError(t, ValidateSchedule(&topodatapb.BackupSchedule{Cron: "@daily", MaxAge: protoutil.DurationToProto(-time.Hour)}), "invalid max_age -1h0m0s: must be a positive duration")
+
+	retention, err := Retention(&topodatapb.BackupSchedule{KeepLast: 3, MaxAge: protoutil.DurationToProto(48 * time.Hour)})
+	require.NoError(t, err)
+	assert.Equal(t, BackupRetention{KeepLast: 3, MaxAge: 48 * time.Hour}, retention)
+}
+
+func TestSchedulerClaim(t *testing.T) {
+	ctx := context.Background()
+	ts := memorytopo.NewServer("zone1")
+	require.NoError(t, ts.CreateKeyspace(ctx, "ks", &topodatapb.Keyspace{}))
+	require.NoError(t, ts.CreateKeyspace(ctx, "other", &topodatapb.Keyspace{}))
+
+	created := time.Date(2021, time.March, 10, 13, 17, 0, 0, time.Local)
+	require.NoError(t, ts.SaveBackupSchedule(ctx, "ks", &topodatapb.BackupSchedule{Cron: "0 * * * *", CreateTime: protoutil.TimeToProto(created)}))
+	status, err := GetScheduleStatus(ctx, ts, "ks")
+	require.NoError(t, err)
+	assert.Equal(t, created.Add(43*time.Minute), status.NextRunTime)
+
+	s1 := NewScheduler(ts, nil)
+	s2 := NewScheduler(ts, nil)
+	_, claimed, err := s1.claim(ctx, "ks", created.Add(30*time.Minute))
+	require.NoError(t, err)
+	assert.False(t, claimed, "the run isn't due yet")
+
+	// Only one of the schedulers runs a due run.
+	due := created.Add(45 * time.Minute)
+	_, claimed, err = s1.claim(ctx, "ks", due)
+	require.NoError(t, err)
+	assert.True(t, claimed)
+	_, claimed, err = s2.claim(ctx, "ks", due)
+	require.NoError(t, err)
+	assert.False(t, claimed)
+
+	schedule, err := ts.GetBackupSchedule(ctx, "ks")
+	require.NoError(t, err)
+	assert.Equal(t, due.Unix(), schedule.LastScheduledTime.Seconds)
+
+	// The keyspaces without a schedule are never due.
+	_, claimed, err = s1.claim(ctx, "other", due)
+	require.NoError(t, err)
+	assert.False(t, claimed)
+}
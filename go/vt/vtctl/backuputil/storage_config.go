@@ -0,0 +1,58 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backuputil
+
+import (
+	"fmt"
+
+	"vitess.io/vitess/go/protoutil"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	"vitess.io/vitess/go/vt/proto/vttime"
+)
+
+// ValidateStorageConfig checks the sizes and the retry delays of the backup
+// storage config of a keyspace.
+func ValidateStorageConfig(config *topodatapb.BackupStorageConfig) error {
+	s3 := config.GetS3()
+	if s3 == nil {
+		return nil
+	}
+	if s3.UploadPartSize < 0 {
+		return fmt.Errorf("invalid upload_part_size %v: must be positive", s3.UploadPartSize)
+	}
+	if s3.UploadConcurrency < 0 {
+		return fmt.Errorf("invalid upload_concurrency %v: must be positive", s3.UploadConcurrency)
+	}
+	if err := validateRetryDelay("min_retry_delay", s3.MinRetryDelay); err != nil {
+		return err
+	}
+	return validateRetryDelay("max_retry_delay", s3.MaxRetryDelay)
+}
+
+// validateRetryDelay checks a retry delay of the config converts to a
+// positive duration.
+func validateRetryDelay(name string, delay *vttime.Duration) error {
+	d, _, err := protoutil.DurationFromProto(delay)
+	if err != nil {
+		return fmt.Errorf("invalid %v: %v", name, err)
+	}
+	if d < 0 {
+		return fmt.Errorf("invalid %v %v: must be positive", name, d)
+	}
+	return nil
+}
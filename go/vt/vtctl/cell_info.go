@@ -51,8 +51,8 @@ func init() {
 	addCommand(cellsGroupName, command{
 		"DeleteCellInfo",
 		commandDeleteCellInfo,
-		"[-force] <cell>",
-		"Deletes the CellInfo for the provided cell. The cell cannot be referenced by any Shard record."})
+		"[-force] [-drain] <cell>",
+		"Deletes the CellInfo for the provided cell. The cell cannot have SrvKeyspace or tablet records, unless -force is specified, and cannot be referenced by any CellsAlias. With -drain, the SrvKeyspace records of the cell are deleted first, so its vtgates stop serving the keyspaces."})
 
 	addCommand(cellsGroupName, command{
 		"GetCellInfoNames",
@@ -111,6 +111,7 @@ func commandUpdateCellInfo(ctx context.Context, wr *wrangler.Wrangler, subFlags
 
 func commandDeleteCellInfo(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
 	force := subFlags.Bool("force", false, "Proceeds even if the cell's topology server cannot be reached. The assumption is that you turned down the entire cell, and just need to update the global topo data.")
+	drain := subFlags.Bool("drain", false, "Removes the cell from the serving graph of all the keyspaces first, by deleting their SrvKeyspace in the cell.")
 	if err := subFlags.Parse(args); err != nil {
 		return err
 	}
@@ -119,10 +120,14 @@ func commandDeleteCellInfo(ctx context.Context, wr *wrangler.Wrangler, subFlags
 	}
 	cell := subFlags.Arg(0)
 
-	_, err := wr.VtctldServer().DeleteCellInfo(ctx, &vtctldatapb.DeleteCellInfoRequest{
+	resp, err := wr.VtctldServer().DeleteCellInfo(ctx, &vtctldatapb.DeleteCellInfoRequest{
 		Name:  cell,
 		Force: *force,
+		Drain: *drain,
 	})
+	for _, keyspace := range resp.GetDrainedKeyspaces() {
+		wr.Logger().Infof("Removed cell %v from the serving graph of keyspace %v", cell, keyspace)
+	}
 	return err
 }
 
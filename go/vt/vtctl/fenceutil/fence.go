@@ -0,0 +1,223 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fenceutil fences the shards, for vtctl and the VtctldServer RPCs.
+// A fenced shard doesn't accept writes: its master is read-only, and its
+// query service is disabled in the serving graph, so the vtgates stop
+// sending it queries. The position of the master when it was fenced is
+// recorded in the global topo, so an external tool can cut over to, or
+// export, a consistent state of the shard. UnfenceShard undoes it.
+package fenceutil
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"time"
+
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vttablet/tmclient"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// shardFencesPath is the path in the global topo of the fences of the
+// shards, recorded as <keyspace>/<shard> files.
+const shardFencesPath = "shard_fences"
+
+// ShardFence describes a fenced shard.
+type ShardFence struct {
+	Keyspace string `json:"keyspace"`
+	Shard    string `json:"shard"`
+	// Master is the alias of the master of the shard when it was fenced.
+	Master string `json:"master"`
+	// Position is the replication position of the master once it was
+	// read-only, i.e. its final GTID set.
+	Position string    `json:"position"`
+	Reason   string    `json:"reason,omitempty"`
+	Time     time.Time `json:"time"`
+}
+
+// FenceShard stops the writes of a shard, and returns the final position
+// of its master. It fails if the shard is already fenced.
+func FenceShard(ctx context.Context, ts *topo.Server, tmc tmclient.TabletManagerClient, logger logutil.Logger, keyspace, shard, reason string) (fence *ShardFence, err error) {
+	ctx, unlock, lockErr := ts.LockKeyspace(ctx, keyspace, fmt.Sprintf("FenceShard(%v/%v)", keyspace, shard))
+	if lockErr != nil {
+		return nil, lockErr
+	}
+	defer unlock(&err)
+
+	if existing, err := GetShardFence(ctx, ts, keyspace, shard); err == nil {
+		return nil, fmt.Errorf("shard %v/%v is already fenced since %v at position %v", keyspace, shard, existing.Time, existing.Position)
+	} else if !topo.IsErrType(err, topo.NoNode) {
+		return nil, err
+	}
+	si, err := ts.GetShard(ctx, keyspace, shard)
+	if err != nil {
+		return nil, err
+	}
+	if !si.HasMaster() {
+		return nil, fmt.Errorf("shard %v/%v has no master", keyspace, shard)
+	}
+	master, err := ts.GetTablet(ctx, si.MasterAlias)
+	if err != nil {
+		return nil, err
+	}
+
+	// The vtgates stop sending queries to the master first, then the
+	// master becomes read-only, for the writes which didn't go through
+	// the vtgates, and the ones in flight.
+	if err := setShardWritesDisabled(ctx, ts, tmc, si, master.Tablet, true); err != nil {
+		logger.Errorf("Cannot fence shard %v/%v, unfencing it: %v", keyspace, shard, err)
+		if rollbackErr := setShardWritesDisabled(ctx, ts, tmc, si, master.Tablet, false); rollbackErr != nil {
+			logger.Errorf("Cannot unfence shard %v/%v: %v", keyspace, shard, rollbackErr)
+		}
+		return nil, err
+	}
+	position, err := tmc.MasterPosition(ctx, master.Tablet)
+	if err == nil {
+		fence = &ShardFence{
+			Keyspace: keyspace,
+			Shard:    shard,
+			Master:   topoproto.TabletAliasString(si.MasterAlias),
+			Position: position,
+			Reason:   reason,
+			Time:     time.Now(),
+		}
+		err = saveShardFence(ctx, ts, fence)
+	}
+	if err != nil {
+		logger.Errorf("Cannot record the fence of shard %v/%v, unfencing it: %v", keyspace, shard, err)
+		if rollbackErr := setShardWritesDisabled(ctx, ts, tmc, si, master.Tablet, false); rollbackErr != nil {
+			logger.Errorf("Cannot unfence shard %v/%v: %v", keyspace, shard, rollbackErr)
+		}
+		return nil, err
+	}
+	logger.Infof("Fenced shard %v/%v at position %v", keyspace, shard, position)
+	return fence, nil
+}
+
+// UnfenceShard lets a fenced shard accept writes again, and returns its
+// fence.
+func UnfenceShard(ctx context.Context, ts *topo.Server, tmc tmclient.TabletManagerClient, logger logutil.Logger, keyspace, shard string) (fence *ShardFence, err error) {
+	ctx, unlock, lockErr := ts.LockKeyspace(ctx, keyspace, fmt.Sprintf("UnfenceShard(%v/%v)", keyspace, shard))
+	if lockErr != nil {
+		return nil, lockErr
+	}
+	defer unlock(&err)
+
+	fence, err = GetShardFence(ctx, ts, keyspace, shard)
+	if err != nil {
+		if topo.IsErrType(err, topo.NoNode) {
+			return nil, fmt.Errorf("shard %v/%v is not fenced", keyspace, shard)
+		}
+		return nil, err
+	}
+	si, err := ts.GetShard(ctx, keyspace, shard)
+	if err != nil {
+		return nil, err
+	}
+	if !si.HasMaster() {
+		return nil, fmt.Errorf("shard %v/%v has no master", keyspace, shard)
+	}
+	if alias := topoproto.TabletAliasString(si.MasterAlias); alias != fence.Master {
+		logger.Warningf("The master of shard %v/%v changed from %v to %v since it was fenced", keyspace, shard, fence.Master, alias)
+	}
+	master, err := ts.GetTablet(ctx, si.MasterAlias)
+	if err != nil {
+		return nil, err
+	}
+	if err := setShardWritesDisabled(ctx, ts, tmc, si, master.Tablet, false); err != nil {
+		return nil, err
+	}
+
+	conn, err := ts.ConnForCell(ctx, topo.GlobalCell)
+	if err != nil {
+		return nil, err
+	}
+	if err := conn.Delete(ctx, shardFencePath(keyspace, shard), nil); err != nil {
+		return nil, err
+	}
+	logger.Infof("Unfenced shard %v/%v", keyspace, shard)
+	return fence, nil
+}
+
+// GetShardFence returns the fence of a shard. It returns a NoNode error if
+// the shard is not fenced.
+func GetShardFence(ctx context.Context, ts *topo.Server, keyspace, shard string) (*ShardFence, error) {
+	conn, err := ts.ConnForCell(ctx, topo.GlobalCell)
+	if err != nil {
+		return nil, err
+	}
+	data, _, err := conn.Get(ctx, shardFencePath(keyspace, shard))
+	if err != nil {
+		return nil, err
+	}
+	fence := &ShardFence{}
+	if err := json.Unmarshal(data, fence); err != nil {
+		return nil, fmt.Errorf("invalid fence of shard %v/%v: %v", keyspace, shard, err)
+	}
+	return fence, nil
+}
+
+func saveShardFence(ctx context.Context, ts *topo.Server, fence *ShardFence) error {
+	data, err := json.Marshal(fence)
+	if err != nil {
+		return err
+	}
+	conn, err := ts.ConnForCell(ctx, topo.GlobalCell)
+	if err != nil {
+		return err
+	}
+	_, err = conn.Create(ctx, shardFencePath(fence.Keyspace, fence.Shard), data)
+	return err
+}
+
+// setShardWritesDisabled disables, or enables, the query service of the
+// master of the shard in the serving graph, and makes its MySQL read-only,
+// or read-write. The keyspace must be locked.
+func setShardWritesDisabled(ctx context.Context, ts *topo.Server, tmc tmclient.TabletManagerClient, si *topo.ShardInfo, master *topodatapb.Tablet, disabled bool) error {
+	if disabled {
+		if err := ts.UpdateDisableQueryService(ctx, si.Keyspace(), []*topo.ShardInfo{si}, topodatapb.TabletType_MASTER, nil, true); err != nil {
+			return err
+		}
+		if err := tmc.RefreshState(ctx, master); err != nil {
+			return err
+		}
+		return tmc.SetReadOnly(ctx, master)
+	}
+
+	if err := tmc.SetReadWrite(ctx, master); err != nil {
+		return err
+	}
+	if err := ts.UpdateDisableQueryService(ctx, si.Keyspace(), []*topo.ShardInfo{si}, topodatapb.TabletType_MASTER, nil, false); err != nil {
+		return err
+	}
+	if err := tmc.RefreshState(ctx, master); err != nil {
+		// The serving graph is updated, the master will pick it up
+		// eventually.
+		log.Warningf("RefreshState(%v) failed: %v", topoproto.TabletAliasString(master.Alias), err)
+	}
+	return nil
+}
+
+func shardFencePath(keyspace, shard string) string {
+	return path.Join(shardFencesPath, keyspace, shard)
+}
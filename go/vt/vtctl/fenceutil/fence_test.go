@@ -0,0 +1,123 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fenceutil
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+	"vitess.io/vitess/go/vt/vttablet/tmclient"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// fenceTMClient records the read-only state of the master.
+type fenceTMClient struct {
+	tmclient.TabletManagerClient
+	readOnly  bool
+	refreshes int
+}
+
+func (tmc *fenceTMClient) SetReadOnly(ctx context.Context, tablet *topodatapb.Tablet) error {
+	tmc.readOnly = true
+	return nil
+}
+
+func (tmc *fenceTMClient) SetReadWrite(ctx context.Context, tablet *topodatapb.Tablet) error {
+	tmc.readOnly = false
+	return nil
+}
+
+func (tmc *fenceTMClient) RefreshState(ctx context.Context, tablet *topodatapb.Tablet) error {
+	tmc.refreshes++
+	return nil
+}
+
+func (tmc *fenceTMClient) MasterPosition(ctx context.Context, tablet *topodatapb.Tablet) (string, error) {
+	return "MySQL56/00000000-0000-0000-0000-000000000001:1-100", nil
+}
+
+func TestFenceShard(t *testing.T) {
+	ctx := context.Background()
+	ts := memorytopo.NewServer("cell1")
+	tmc := &fenceTMClient{}
+	logger := logutil.NewMemoryLogger()
+
+	require.NoError(t, ts.CreateKeyspace(ctx, "ks", &topodatapb.Keyspace{}))
+	require.NoError(t, ts.CreateShard(ctx, "ks", "0"))
+	master := &topodatapb.Tablet{
+		Alias:    &topodatapb.TabletAlias{Cell: "cell1", Uid: 100},
+		Keyspace: "ks",
+		Shard:    "0",
+		Type:     topodatapb.TabletType_MASTER,
+	}
+	require.NoError(t, ts.CreateTablet(ctx, master))
+	_, err := ts.UpdateShardFields(ctx, "ks", "0", func(si *topo.ShardInfo) error {
+		si.MasterAlias = master.Alias
+		return nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, ts.UpdateSrvKeyspace(ctx, "cell1", "ks", &topodatapb.SrvKeyspace{
+		Partitions: []*topodatapb.SrvKeyspace_KeyspacePartition{{
+			ServedType:      topodatapb.TabletType_MASTER,
+			ShardReferences: []*topodatapb.ShardReference{{Name: "0"}},
+		}},
+	}))
+	queryServiceDisabled := func() bool {
+		t.Helper()
+		srvKeyspace, err := ts.GetSrvKeyspace(ctx, "cell1", "ks")
+		require.NoError(t, err)
+		controls := srvKeyspace.Partitions[0].ShardTabletControls
+		return len(controls) == 1 && controls[0].QueryServiceDisabled
+	}
+
+	_, err = GetShardFence(ctx, ts, "ks", "0")
+	assert.True(t, topo.IsErrType(err, topo.NoNode), "GetShardFence: %v", err)
+	_, err = UnfenceShard(ctx, ts, tmc, logger, "ks", "0")
+	assert.EqualError(t, err, "shard ks/0 is not fenced")
+
+	fence, err := FenceShard(ctx, ts, tmc, logger, "ks", "0", "export")
+	require.NoError(t, err)
+	assert.Equal(t, "cell1-0000000100", fence.Master)
+	assert.Equal(t, "MySQL56/00000000-0000-0000-0000-000000000001:1-100", fence.Position)
+	assert.Equal(t, "export", fence.Reason)
+	assert.True(t, tmc.readOnly)
+	assert.Equal(t, 1, tmc.refreshes)
+	assert.True(t, queryServiceDisabled())
+
+	got, err := GetShardFence(ctx, ts, "ks", "0")
+	require.NoError(t, err)
+	assert.Equal(t, fence.Position, got.Position)
+
+	_, err = FenceShard(ctx, ts, tmc, logger, "ks", "0", "again")
+	assert.Error(t, err)
+
+	unfenced, err := UnfenceShard(ctx, ts, tmc, logger, "ks", "0")
+	require.NoError(t, err)
+	assert.Equal(t, fence.Position, unfenced.Position)
+	assert.False(t, tmc.readOnly)
+	assert.Equal(t, 2, tmc.refreshes)
+	assert.False(t, queryServiceDisabled())
+	_, err = GetShardFence(ctx, ts, "ks", "0")
+	assert.True(t, topo.IsErrType(err, topo.NoNode), "GetShardFence: %v", err)
+}
@@ -0,0 +1,243 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grantutil
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vtctl/validateutil"
+	"vitess.io/vitess/go/vt/vttablet/tmclient"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// waitReplicasInterval is the interval at which the replicas are checked
+// while waiting for them to converge.
+var waitReplicasInterval = time.Second
+
+// TabletDrift is how the MySQL users of a tablet differ from the declared
+// ones.
+type TabletDrift struct {
+	Tablet     string       `json:"tablet"`
+	Shard      string       `json:"shard"`
+	TabletType string       `json:"tablet_type"`
+	Users      []*UserDrift `json:"users,omitempty"`
+	Error      string       `json:"error,omitempty"`
+}
+
+// DriftReport is the result of CheckDrift.
+type DriftReport struct {
+	Keyspace string         `json:"keyspace"`
+	Tablets  []*TabletDrift `json:"tablets"`
+	// Consistent is true if the users of all the tablets could be checked
+	// and match the declared ones.
+	Consistent bool `json:"consistent"`
+}
+
+// ShardApply is what Apply did on the master of a shard.
+type ShardApply struct {
+	Shard      string   `json:"shard"`
+	Master     string   `json:"master,omitempty"`
+	Statements []string `json:"statements,omitempty"`
+	Error      string   `json:"error,omitempty"`
+}
+
+// ApplyReport is the result of Apply.
+type ApplyReport struct {
+	Keyspace string        `json:"keyspace"`
+	DryRun   bool          `json:"dry_run,omitempty"`
+	Shards   []*ShardApply `json:"shards"`
+	// Verification is the drift of all the tablets of the keyspace once
+	// the replicas converged, or when the wait timed out. It is not set
+	// for a dry run.
+	Verification *DriftReport `json:"verification,omitempty"`
+}
+
+// ApplyOptions are the options of Apply.
+type ApplyOptions struct {
+	Keyspace string
+	// DryRun only reports the statements which would be executed.
+	DryRun bool
+	// WaitReplicasTimeout is how long to wait for the replicas to
+	// converge once the statements are executed on the masters.
+	WaitReplicasTimeout time.Duration
+	// Concurrency is the maximum number of tablets queried at the same
+	// time.
+	Concurrency int
+}
+
+// CheckDrift compares the MySQL users of the tablets in the scope of the
+// options with the ones declared for the keyspace.
+func CheckDrift(ctx context.Context, ts *topo.Server, tmc tmclient.TabletManagerClient, opts validateutil.Options) (*DriftReport, error) {
+	users, err := declaredUsers(ctx, ts, opts.Keyspace)
+	if err != nil {
+		return nil, err
+	}
+	return checkDrift(ctx, ts, tmc, users, opts)
+}
+
+func checkDrift(ctx context.Context, ts *topo.Server, tmc tmclient.TabletManagerClient, users []*topodatapb.MySQLUser, opts validateutil.Options) (*DriftReport, error) {
+	tablets, err := validateutil.ListTablets(ctx, ts, opts)
+	if err != nil {
+		return nil, err
+	}
+	report := &DriftReport{
+		Keyspace:   opts.Keyspace,
+		Tablets:    make([]*TabletDrift, len(tablets)),
+		Consistent: true,
+	}
+	validateutil.ForEachTablet(tablets, opts.Concurrency, func(i int, tablet *topodatapb.Tablet) {
+		result := &TabletDrift{
+			Tablet:     topoproto.TabletAliasString(tablet.Alias),
+			Shard:      tablet.Shard,
+			TabletType: topoproto.TabletTypeLString(tablet.Type),
+		}
+		report.Tablets[i] = result
+		permissions, err := tmc.GetPermissions(ctx, tablet)
+		if err != nil {
+			result.Error = err.Error()
+			return
+		}
+		for _, d := range diffUsers(users, permissions) {
+			result.Users = append(result.Users, d.drift())
+		}
+	})
+	for _, result := range report.Tablets {
+		if result.Error != "" || len(result.Users) > 0 {
+			report.Consistent = false
+		}
+	}
+	return report, nil
+}
+
+// Apply makes the MySQL users of the masters of the keyspace match the
+// declared ones, creating the missing users and granting and revoking
+// their privileges. The statements are written to the binary logs of the
+// masters, so the replicas converge through replication, which Apply
+// waits for.
+func Apply(ctx context.Context, ts *topo.Server, tmc tmclient.TabletManagerClient, opts ApplyOptions) (*ApplyReport, error) {
+	users, err := declaredUsers(ctx, ts, opts.Keyspace)
+	if err != nil {
+		return nil, err
+	}
+	shards, err := ts.GetShardNames(ctx, opts.Keyspace)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(shards)
+
+	report := &ApplyReport{
+		Keyspace: opts.Keyspace,
+		DryRun:   opts.DryRun,
+		Shards:   make([]*ShardApply, len(shards)),
+	}
+	var masters []*topodatapb.Tablet
+	var results []*ShardApply
+	for i, shard := range shards {
+		result := &ShardApply{Shard: shard}
+		report.Shards[i] = result
+		si, err := ts.GetShard(ctx, opts.Keyspace, shard)
+		if err != nil {
+			result.Error = err.Error()
+			continue
+		}
+		if !si.HasMaster() {
+			result.Error = "no master in shard"
+			continue
+		}
+		result.Master = topoproto.TabletAliasString(si.MasterAlias)
+		master, err := ts.GetTablet(ctx, si.MasterAlias)
+		if err != nil {
+			result.Error = err.Error()
+			continue
+		}
+		masters = append(masters, master.Tablet)
+		results = append(results, result)
+	}
+
+	validateutil.ForEachTablet(masters, opts.Concurrency, func(i int, master *topodatapb.Tablet) {
+		result := results[i]
+		permissions, err := tmc.GetPermissions(ctx, master)
+		if err != nil {
+			result.Error = err.Error()
+			return
+		}
+		for _, d := range diffUsers(users, permissions) {
+			result.Statements = append(result.Statements, d.statements()...)
+		}
+		if opts.DryRun || len(result.Statements) == 0 {
+			return
+		}
+		log.Infof("Applying the MySQL users of keyspace %v on %v: %v", opts.Keyspace, result.Master, result.Statements)
+		queries := make([][]byte, len(result.Statements))
+		for j, statement := range result.Statements {
+			queries[j] = []byte(statement)
+		}
+		if _, err := tmc.ExecuteFetchAsDbaMulti(ctx, master, false /* usePool */, queries, 0 /* maxRows */, false /* disableBinlogs */, false /* reloadSchema */); err != nil {
+			result.Error = err.Error()
+		}
+	})
+	for _, result := range report.Shards {
+		if result.Error != "" {
+			return report, fmt.Errorf("cannot apply the MySQL users of shard %v/%v: %v", opts.Keyspace, result.Shard, result.Error)
+		}
+	}
+	if opts.DryRun {
+		return report, nil
+	}
+
+	report.Verification, err = waitForConvergence(ctx, ts, tmc, users, opts)
+	return report, err
+}
+
+// waitForConvergence checks the drift of all the tablets of the keyspace
+// until there is none, or the timeout expires.
+func waitForConvergence(ctx context.Context, ts *topo.Server, tmc tmclient.TabletManagerClient, users []*topodatapb.MySQLUser, opts ApplyOptions) (*DriftReport, error) {
+	deadline := time.Now().Add(opts.WaitReplicasTimeout)
+	checkOpts := validateutil.Options{Keyspace: opts.Keyspace, Concurrency: opts.Concurrency}
+	for {
+		report, err := checkDrift(ctx, ts, tmc, users, checkOpts)
+		if err != nil || report.Consistent || time.Now().After(deadline) {
+			return report, err
+		}
+		select {
+		case <-ctx.Done():
+			return report, ctx.Err()
+		case <-time.After(waitReplicasInterval):
+		}
+	}
+}
+
+func declaredUsers(ctx context.Context, ts *topo.Server, keyspace string) ([]*topodatapb.MySQLUser, error) {
+	ki, err := ts.GetKeyspace(ctx, keyspace)
+	if err != nil {
+		return nil, err
+	}
+	if len(ki.MysqlUsers) == 0 {
+		return nil, fmt.Errorf("no MySQL users declared for keyspace %v", keyspace)
+	}
+	if err := Validate(ki.MysqlUsers); err != nil {
+		return nil, err
+	}
+	return ki.MysqlUsers, nil
+}
@@ -0,0 +1,354 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package grantutil manages the MySQL users declared in the keyspace
+// record: it computes how the users and grants of the MySQL
+// instances drift from the declared ones, and applies the declared ones
+// on the masters, from which they replicate to the replicas.
+package grantutil
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"vitess.io/vitess/go/sqlescape"
+	"vitess.io/vitess/go/sqltypes"
+
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+// globalTarget is the target of the global privileges.
+const globalTarget = "*.*"
+
+// grantOption is the privilege to grant privileges. It is granted with
+// the WITH GRANT OPTION clause rather than in the privilege list.
+const grantOption = "GRANT OPTION"
+
+// privilegeColumns maps the privilege columns of mysql.user and mysql.db
+// to the privileges of the GRANT statements.
+var privilegeColumns = map[string]string{
+	"Select_priv":            "SELECT",
+	"Insert_priv":            "INSERT",
+	"Update_priv":            "UPDATE",
+	"Delete_priv":            "DELETE",
+	"Create_priv":            "CREATE",
+	"Drop_priv":              "DROP",
+	"Reload_priv":            "RELOAD",
+	"Shutdown_priv":          "SHUTDOWN",
+	"Process_priv":           "PROCESS",
+	"File_priv":              "FILE",
+	"Grant_priv":             grantOption,
+	"References_priv":        "REFERENCES",
+	"Index_priv":             "INDEX",
+	"Alter_priv":             "ALTER",
+	"Show_db_priv":           "SHOW DATABASES",
+	"Super_priv":             "SUPER",
+	"Create_tmp_table_priv":  "CREATE TEMPORARY TABLES",
+	"Lock_tables_priv":       "LOCK TABLES",
+	"Execute_priv":           "EXECUTE",
+	"Repl_slave_priv":        "REPLICATION SLAVE",
+	"Repl_client_priv":       "REPLICATION CLIENT",
+	"Create_view_priv":       "CREATE VIEW",
+	"Show_view_priv":         "SHOW VIEW",
+	"Create_routine_priv":    "CREATE ROUTINE",
+	"Alter_routine_priv":     "ALTER ROUTINE",
+	"Create_user_priv":       "CREATE USER",
+	"Event_priv":             "EVENT",
+	"Trigger_priv":           "TRIGGER",
+	"Create_tablespace_priv": "CREATE TABLESPACE",
+	"Create_role_priv":       "CREATE ROLE",
+	"Drop_role_priv":         "DROP ROLE",
+}
+
+// databasePrivileges are the privileges which can be granted on a
+// database.
+var databasePrivileges = map[string]bool{
+	"SELECT":                  true,
+	"INSERT":                  true,
+	"UPDATE":                  true,
+	"DELETE":                  true,
+	"CREATE":                  true,
+	"DROP":                    true,
+	grantOption:               true,
+	"REFERENCES":              true,
+	"INDEX":                   true,
+	"ALTER":                   true,
+	"CREATE TEMPORARY TABLES": true,
+	"LOCK TABLES":             true,
+	"EXECUTE":                 true,
+	"CREATE VIEW":             true,
+	"SHOW VIEW":               true,
+	"CREATE ROUTINE":          true,
+	"ALTER ROUTINE":           true,
+	"EVENT":                   true,
+	"TRIGGER":                 true,
+}
+
+// UserDrift is how a MySQL user of a tablet differs from the declared
+// one. The privileges are formatted like "SELECT ON *.*".
+type UserDrift struct {
+	User                  string   `json:"user"`
+	Missing               bool     `json:"missing,omitempty"`
+	AuthenticationDiffers bool     `json:"authentication_differs,omitempty"`
+	MissingPrivileges     []string `json:"missing_privileges,omitempty"`
+	ExtraPrivileges       []string `json:"extra_privileges,omitempty"`
+}
+
+// ParseUsers parses users declared in the JSON format output by the
+// GetMySQLUsers command.
+func ParseUsers(data []byte) ([]*topodatapb.MySQLUser, error) {
+	resp := &vtctldatapb.GetMySQLUsersResponse{}
+	if err := protojson.Unmarshal(data, resp); err != nil {
+		return nil, err
+	}
+	return resp.Users, nil
+}
+
+// Validate checks that the declared users can be applied.
+func Validate(users []*topodatapb.MySQLUser) error {
+	if len(users) == 0 {
+		return fmt.Errorf("no MySQL users declared")
+	}
+	seen := make(map[string]bool)
+	for _, user := range users {
+		if user.User == "" || user.Host == "" {
+			return fmt.Errorf("the user and host of a MySQL user are required")
+		}
+		name := userName(user.User, user.Host)
+		if seen[name] {
+			return fmt.Errorf("MySQL user %v is declared twice", name)
+		}
+		seen[name] = true
+		if user.AuthenticationString != "" && user.AuthPlugin == "" {
+			return fmt.Errorf("MySQL user %v: the auth plugin of the authentication string is required", name)
+		}
+		for _, privilege := range user.GlobalPrivileges {
+			if !isKnownPrivilege(normalizePrivilege(privilege)) {
+				return fmt.Errorf("MySQL user %v: unsupported global privilege %q", name, privilege)
+			}
+		}
+		for db, privileges := range user.DatabasePrivileges {
+			if db == "" {
+				return fmt.Errorf("MySQL user %v: empty database name", name)
+			}
+			for _, privilege := range privileges.GetPrivileges() {
+				if !databasePrivileges[normalizePrivilege(privilege)] {
+					return fmt.Errorf("MySQL user %v: unsupported privilege %q on database %v", name, privilege, db)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// userDiff is how a MySQL user of a tablet differs from the declared
+// one, with the privileges to grant and revoke by target.
+type userDiff struct {
+	user        *topodatapb.MySQLUser
+	missing     bool
+	authDiffers bool
+	grant       map[string][]string
+	revoke      map[string][]string
+}
+
+func (d *userDiff) isEmpty() bool {
+	return !d.missing && !d.authDiffers && len(d.grant) == 0 && len(d.revoke) == 0
+}
+
+// diffUser compares a declared user with the permissions of a tablet.
+func diffUser(user *topodatapb.MySQLUser, permissions *tabletmanagerdatapb.Permissions) *userDiff {
+	d := &userDiff{
+		user:   user,
+		grant:  make(map[string][]string),
+		revoke: make(map[string][]string),
+	}
+
+	want := map[string]map[string]bool{globalTarget: privilegeSet(user.GlobalPrivileges)}
+	for db, privileges := range user.DatabasePrivileges {
+		want[databaseTarget(db)] = privilegeSet(privileges.GetPrivileges())
+	}
+
+	got := make(map[string]map[string]bool)
+	var up *tabletmanagerdatapb.UserPermission
+	for _, p := range permissions.GetUserPermissions() {
+		if p.User == user.User && p.Host == user.Host {
+			up = p
+			break
+		}
+	}
+	if up == nil {
+		d.missing = true
+	} else {
+		got[globalTarget] = grantedPrivileges(up.Privileges)
+		if user.AuthPlugin != "" && (up.Privileges["plugin"] != user.AuthPlugin || up.Privileges["authentication_string"] != user.AuthenticationString) {
+			d.authDiffers = true
+		}
+	}
+	for _, dp := range permissions.GetDbPermissions() {
+		if dp.User == user.User && dp.Host == user.Host {
+			got[databaseTarget(dp.Db)] = grantedPrivileges(dp.Privileges)
+		}
+	}
+
+	for target, privileges := range want {
+		for privilege := range privileges {
+			if !got[target][privilege] {
+				d.grant[target] = append(d.grant[target], privilege)
+			}
+		}
+	}
+	for target, privileges := range got {
+		for privilege := range privileges {
+			if !want[target][privilege] {
+				d.revoke[target] = append(d.revoke[target], privilege)
+			}
+		}
+	}
+	for _, privileges := range d.grant {
+		sort.Strings(privileges)
+	}
+	for _, privileges := range d.revoke {
+		sort.Strings(privileges)
+	}
+	return d
+}
+
+// drift returns the drift of the user, or nil if it doesn't drift.
+func (d *userDiff) drift() *UserDrift {
+	if d.isEmpty() {
+		return nil
+	}
+	return &UserDrift{
+		User:                  userName(d.user.User, d.user.Host),
+		Missing:               d.missing,
+		AuthenticationDiffers: d.authDiffers,
+		MissingPrivileges:     formatPrivileges(d.grant),
+		ExtraPrivileges:       formatPrivileges(d.revoke),
+	}
+}
+
+// statements returns the statements which make the user of the tablet
+// match the declared one.
+func (d *userDiff) statements() []string {
+	user := userName(d.user.User, d.user.Host)
+	var statements []string
+	identified := ""
+	if d.user.AuthPlugin != "" {
+		identified = fmt.Sprintf(" IDENTIFIED WITH %s AS %s", d.user.AuthPlugin, sqltypes.EncodeStringSQL(d.user.AuthenticationString))
+	}
+	switch {
+	case d.missing:
+		statements = append(statements, "CREATE USER "+user+identified)
+	case d.authDiffers:
+		statements = append(statements, "ALTER USER "+user+identified)
+	}
+	for _, target := range sortedTargets(d.grant) {
+		var privileges []string
+		withGrantOption := ""
+		for _, privilege := range d.grant[target] {
+			if privilege == grantOption {
+				withGrantOption = " WITH GRANT OPTION"
+				continue
+			}
+			privileges = append(privileges, privilege)
+		}
+		if len(privileges) == 0 {
+			privileges = []string{"USAGE"}
+		}
+		statements = append(statements, fmt.Sprintf("GRANT %s ON %s TO %s%s", strings.Join(privileges, ", "), target, user, withGrantOption))
+	}
+	for _, target := range sortedTargets(d.revoke) {
+		statements = append(statements, fmt.Sprintf("REVOKE %s ON %s FROM %s", strings.Join(d.revoke[target], ", "), target, user))
+	}
+	return statements
+}
+
+// diffUsers compares all the declared users with the permissions of a
+// tablet, and returns the ones which differ.
+func diffUsers(users []*topodatapb.MySQLUser, permissions *tabletmanagerdatapb.Permissions) []*userDiff {
+	var diffs []*userDiff
+	for _, user := range users {
+		if d := diffUser(user, permissions); !d.isEmpty() {
+			diffs = append(diffs, d)
+		}
+	}
+	return diffs
+}
+
+func userName(user, host string) string {
+	return sqltypes.EncodeStringSQL(user) + "@" + sqltypes.EncodeStringSQL(host)
+}
+
+func databaseTarget(db string) string {
+	return sqlescape.EscapeID(db) + ".*"
+}
+
+func normalizePrivilege(privilege string) string {
+	return strings.Join(strings.Fields(strings.ToUpper(privilege)), " ")
+}
+
+func isKnownPrivilege(privilege string) bool {
+	for _, p := range privilegeColumns {
+		if p == privilege {
+			return true
+		}
+	}
+	return false
+}
+
+func privilegeSet(privileges []string) map[string]bool {
+	set := make(map[string]bool)
+	for _, privilege := range privileges {
+		set[normalizePrivilege(privilege)] = true
+	}
+	return set
+}
+
+// grantedPrivileges returns the privileges granted by the privilege
+// columns of a row of mysql.user or mysql.db.
+func grantedPrivileges(columns map[string]string) map[string]bool {
+	set := make(map[string]bool)
+	for column, value := range columns {
+		if privilege, ok := privilegeColumns[column]; ok && value == "Y" {
+			set[privilege] = true
+		}
+	}
+	return set
+}
+
+func sortedTargets(privileges map[string][]string) []string {
+	targets := make([]string, 0, len(privileges))
+	for target := range privileges {
+		targets = append(targets, target)
+	}
+	sort.Strings(targets)
+	return targets
+}
+
+func formatPrivileges(privileges map[string][]string) []string {
+	var result []string
+	for _, target := range sortedTargets(privileges) {
+		for _, privilege := range privileges[target] {
+			result = append(result, privilege+" ON "+target)
+		}
+	}
+	return result
+}
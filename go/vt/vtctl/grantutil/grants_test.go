@@ -0,0 +1,238 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grantutil
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/test/utils"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vtctl/grpcvtctldserver/testutil"
+	"vitess.io/vitess/go/vt/vtctl/validateutil"
+	"vitess.io/vitess/go/vt/vttablet/tmclient"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+var appUser = &topodatapb.MySQLUser{
+	User:                 "app",
+	Host:                 "%",
+	AuthPlugin:           "mysql_native_password",
+	AuthenticationString: "*HASH",
+	GlobalPrivileges:     []string{"process"},
+	DatabasePrivileges: map[string]*topodatapb.MySQLUser_Privileges{
+		"vt_ks": {Privileges: []string{"SELECT", "insert", "GRANT OPTION"}},
+	},
+}
+
+// appPermissions are the permissions of a tablet with appUser.
+func appPermissions() *tabletmanagerdatapb.Permissions {
+	return &tabletmanagerdatapb.Permissions{
+		UserPermissions: []*tabletmanagerdatapb.UserPermission{{
+			Host: "%",
+			User: "app",
+			Privileges: map[string]string{
+				"Select_priv":           "N",
+				"Process_priv":          "Y",
+				"plugin":                "mysql_native_password",
+				"authentication_string": "*HASH",
+			},
+		}},
+		DbPermissions: []*tabletmanagerdatapb.DbPermission{{
+			Host:       "%",
+			Db:         "vt_ks",
+			User:       "app",
+			Privileges: map[string]string{"Select_priv": "Y", "Insert_priv": "Y", "Grant_priv": "Y", "Delete_priv": "N"},
+		}},
+	}
+}
+
+func TestParseUsers(t *testing.T) {
+	users, err := ParseUsers([]byte(`{"users": [{"user": "app", "host": "%", "auth_plugin": "mysql_native_password", "authentication_string": "*HASH", "global_privileges": ["process"], "database_privileges": {"vt_ks": {"privileges": ["SELECT", "insert", "GRANT OPTION"]}}}]}`))
+	require.NoError(t, err)
+	utils.MustMatch(t, []*topodatapb.MySQLUser{appUser}, users)
+
+	_, err = ParseUsers([]byte(`{"users": [{"name": "app"}]}`))
+	assert.Error(t, err)
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, Validate([]*topodatapb.MySQLUser{appUser}))
+	assert.Error(t, Validate(nil))
+	assert.Error(t, Validate([]*topodatapb.MySQLUser{appUser, appUser}))
+	assert.Error(t, Validate([]*topodatapb.MySQLUser{{User: "app", Host: "%", GlobalPrivileges: []string{"ALL"}}}))
+	assert.Error(t, Validate([]*topodatapb.MySQLUser{{User: "app", Host: "%", DatabasePrivileges: map[string]*topodatapb.MySQLUser_Privileges{"vt_ks": {Privileges: []string{"SUPER"}}}}}))
+	assert.Error(t, Validate([]*topodatapb.MySQLUser{{User: "app", Host: "%", AuthenticationString: "*HASH"}}))
+}
+
+func TestDiffUser(t *testing.T) {
+	d := diffUser(appUser, appPermissions())
+	assert.True(t, d.isEmpty())
+	assert.Nil(t, d.drift())
+	assert.Empty(t, d.statements())
+
+	d = diffUser(appUser, &tabletmanagerdatapb.Permissions{})
+	assert.Equal(t, &UserDrift{
+		User:              "'app'@'%'",
+		Missing:           true,
+		MissingPrivileges: []string{"PROCESS ON *.*", "GRANT OPTION ON `vt_ks`.*", "INSERT ON `vt_ks`.*", "SELECT ON `vt_ks`.*"},
+	}, d.drift())
+	assert.Equal(t, []string{
+		"CREATE USER 'app'@'%' IDENTIFIED WITH mysql_native_password AS '*HASH'",
+		"GRANT PROCESS ON *.* TO 'app'@'%'",
+		"GRANT INSERT, SELECT ON `vt_ks`.* TO 'app'@'%' WITH GRANT OPTION",
+	}, d.statements())
+
+	permissions := appPermissions()
+	permissions.UserPermissions[0].Privileges["authentication_string"] = "*OLD"
+	permissions.UserPermissions[0].Privileges["Super_priv"] = "Y"
+	permissions.DbPermissions[0].Privileges["Insert_priv"] = "N"
+	permissions.DbPermissions[0].Privileges["Grant_priv"] = "N"
+	permissions.DbPermissions = append(permissions.DbPermissions, &tabletmanagerdatapb.DbPermission{
+		Host:       "%",
+		Db:         "other",
+		User:       "app",
+		Privileges: map[string]string{"Select_priv": "Y"},
+	})
+	d = diffUser(appUser, permissions)
+	assert.Equal(t, &UserDrift{
+		User:                  "'app'@'%'",
+		AuthenticationDiffers: true,
+		MissingPrivileges:     []string{"GRANT OPTION ON `vt_ks`.*", "INSERT ON `vt_ks`.*"},
+		ExtraPrivileges:       []string{"SUPER ON *.*", "SELECT ON `other`.*"},
+	}, d.drift())
+	assert.Equal(t, []string{
+		"ALTER USER 'app'@'%' IDENTIFIED WITH mysql_native_password AS '*HASH'",
+		"GRANT INSERT ON `vt_ks`.* TO 'app'@'%' WITH GRANT OPTION",
+		"REVOKE SUPER ON *.* FROM 'app'@'%'",
+		"REVOKE SELECT ON `other`.* FROM 'app'@'%'",
+	}, d.statements())
+}
+
+// fakeTMC returns the permissions of the tablets, and gives the declared
+// users to all the tablets of a shard when statements are executed on
+// its master.
+type fakeTMC struct {
+	tmclient.TabletManagerClient
+
+	mu          sync.Mutex
+	permissions map[string]*tabletmanagerdatapb.Permissions
+	shards      map[string][]string
+	executed    []string
+}
+
+func (f *fakeTMC) GetPermissions(ctx context.Context, tablet *topodatapb.Tablet) (*tabletmanagerdatapb.Permissions, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p, ok := f.permissions[topoproto.TabletAliasString(tablet.Alias)]
+	if !ok {
+		return nil, fmt.Errorf("tablet unreachable")
+	}
+	return p, nil
+}
+
+func (f *fakeTMC) ExecuteFetchAsDbaMulti(ctx context.Context, tablet *topodatapb.Tablet, usePool bool, queries [][]byte, maxRows int, disableBinlogs, reloadSchema bool) ([]*querypb.QueryResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, query := range queries {
+		f.executed = append(f.executed, string(query))
+	}
+	for _, alias := range f.shards[tablet.Shard] {
+		f.permissions[alias] = appPermissions()
+	}
+	return nil, nil
+}
+
+func TestApply(t *testing.T) {
+	ctx := context.Background()
+	ts := memorytopo.NewServer("zone1")
+	tablet := func(uid uint32, shard string, tabletType topodatapb.TabletType) *topodatapb.Tablet {
+		return &topodatapb.Tablet{
+			Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: uid},
+			Keyspace: "ks",
+			Shard:    shard,
+			Type:     tabletType,
+		}
+	}
+	testutil.AddTablets(ctx, t, ts, &testutil.AddTabletOptions{AlsoSetShardMaster: true},
+		tablet(100, "-80", topodatapb.TabletType_MASTER),
+		tablet(101, "-80", topodatapb.TabletType_REPLICA),
+		tablet(200, "80-", topodatapb.TabletType_MASTER),
+		tablet(201, "80-", topodatapb.TabletType_REPLICA),
+	)
+	tmc := &fakeTMC{
+		permissions: map[string]*tabletmanagerdatapb.Permissions{
+			"zone1-0000000100": {},
+			"zone1-0000000101": {},
+			"zone1-0000000200": appPermissions(),
+			"zone1-0000000201": appPermissions(),
+		},
+		shards: map[string][]string{
+			"-80": {"zone1-0000000100", "zone1-0000000101"},
+			"80-": {"zone1-0000000200", "zone1-0000000201"},
+		},
+	}
+
+	_, err := CheckDrift(ctx, ts, tmc, validateutil.Options{Keyspace: "ks"})
+	assert.Error(t, err, "no declared users")
+	lockCtx, unlock, err := ts.LockKeyspace(ctx, "ks", "SetMySQLUsers")
+	require.NoError(t, err)
+	ki, err := ts.GetKeyspace(lockCtx, "ks")
+	require.NoError(t, err)
+	ki.MysqlUsers = []*topodatapb.MySQLUser{appUser}
+	require.NoError(t, ts.UpdateKeyspace(lockCtx, ki))
+	unlock(&err)
+
+	drift, err := CheckDrift(ctx, ts, tmc, validateutil.Options{Keyspace: "ks"})
+	require.NoError(t, err)
+	assert.False(t, drift.Consistent)
+	require.Len(t, drift.Tablets, 4)
+	assert.Len(t, drift.Tablets[0].Users, 1)
+	assert.Equal(t, "replica", drift.Tablets[1].TabletType)
+	assert.Len(t, drift.Tablets[1].Users, 1)
+	assert.Empty(t, drift.Tablets[2].Users)
+
+	drift, err = CheckDrift(ctx, ts, tmc, validateutil.Options{Keyspace: "ks", Shard: "80-"})
+	require.NoError(t, err)
+	assert.True(t, drift.Consistent)
+
+	report, err := Apply(ctx, ts, tmc, ApplyOptions{Keyspace: "ks", DryRun: true})
+	require.NoError(t, err)
+	require.Len(t, report.Shards, 2)
+	assert.Equal(t, "zone1-0000000100", report.Shards[0].Master)
+	assert.Len(t, report.Shards[0].Statements, 3)
+	assert.Empty(t, report.Shards[1].Statements)
+	assert.Nil(t, report.Verification)
+	assert.Empty(t, tmc.executed)
+
+	waitReplicasInterval = 10 * time.Millisecond
+	report, err = Apply(ctx, ts, tmc, ApplyOptions{Keyspace: "ks", WaitReplicasTimeout: 5 * time.Second})
+	require.NoError(t, err)
+	assert.Equal(t, report.Shards[0].Statements, tmc.executed)
+	require.NotNil(t, report.Verification)
+	assert.True(t, report.Verification.Consistent)
+}
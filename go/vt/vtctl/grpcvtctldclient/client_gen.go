@@ -28,6 +28,15 @@ import (
 	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
 )
 
+// AcquireKeyspaceLock is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) AcquireKeyspaceLock(ctx context.Context, in *vtctldatapb.AcquireKeyspaceLockRequest, opts ...grpc.CallOption) (*vtctldatapb.AcquireKeyspaceLockResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.AcquireKeyspaceLock(ctx, in, opts...)
+}
+
 // AddCellInfo is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) AddCellInfo(ctx context.Context, in *vtctldatapb.AddCellInfoRequest, opts ...grpc.CallOption) (*vtctldatapb.AddCellInfoResponse, error) {
 	if client.c == nil {
@@ -46,6 +55,24 @@ func (client *gRPCVtctldClient) AddCellsAlias(ctx context.Context, in *vtctldata
 	return client.c.AddCellsAlias(ctx, in, opts...)
 }
 
+// AdvanceGCTable is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) AdvanceGCTable(ctx context.Context, in *vtctldatapb.AdvanceGCTableRequest, opts ...grpc.CallOption) (*vtctldatapb.AdvanceGCTableResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.AdvanceGCTable(ctx, in, opts...)
+}
+
+// ApplyMySQLUsers is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) ApplyMySQLUsers(ctx context.Context, in *vtctldatapb.ApplyMySQLUsersRequest, opts ...grpc.CallOption) (*vtctldatapb.ApplyMySQLUsersResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.ApplyMySQLUsers(ctx, in, opts...)
+}
+
 // ApplyRoutingRules is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) ApplyRoutingRules(ctx context.Context, in *vtctldatapb.ApplyRoutingRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.ApplyRoutingRulesResponse, error) {
 	if client.c == nil {
@@ -55,6 +82,15 @@ func (client *gRPCVtctldClient) ApplyRoutingRules(ctx context.Context, in *vtctl
 	return client.c.ApplyRoutingRules(ctx, in, opts...)
 }
 
+// ApplyRoutingRulesBatch is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) ApplyRoutingRulesBatch(ctx context.Context, in *vtctldatapb.ApplyRoutingRulesBatchRequest, opts ...grpc.CallOption) (*vtctldatapb.ApplyRoutingRulesBatchResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.ApplyRoutingRulesBatch(ctx, in, opts...)
+}
+
 // ApplyVSchema is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) ApplyVSchema(ctx context.Context, in *vtctldatapb.ApplyVSchemaRequest, opts ...grpc.CallOption) (*vtctldatapb.ApplyVSchemaResponse, error) {
 	if client.c == nil {
@@ -64,6 +100,33 @@ func (client *gRPCVtctldClient) ApplyVSchema(ctx context.Context, in *vtctldatap
 	return client.c.ApplyVSchema(ctx, in, opts...)
 }
 
+// ApproveMigration is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) ApproveMigration(ctx context.Context, in *vtctldatapb.ApproveMigrationRequest, opts ...grpc.CallOption) (*vtctldatapb.ApproveMigrationResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.ApproveMigration(ctx, in, opts...)
+}
+
+// BackupKeyspace is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) BackupKeyspace(ctx context.Context, in *vtctldatapb.BackupKeyspaceRequest, opts ...grpc.CallOption) (*vtctldatapb.BackupKeyspaceResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.BackupKeyspace(ctx, in, opts...)
+}
+
+// BrowseTopology is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) BrowseTopology(ctx context.Context, in *vtctldatapb.BrowseTopologyRequest, opts ...grpc.CallOption) (*vtctldatapb.BrowseTopologyResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.BrowseTopology(ctx, in, opts...)
+}
+
 // ChangeTabletType is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) ChangeTabletType(ctx context.Context, in *vtctldatapb.ChangeTabletTypeRequest, opts ...grpc.CallOption) (*vtctldatapb.ChangeTabletTypeResponse, error) {
 	if client.c == nil {
@@ -73,6 +136,15 @@ func (client *gRPCVtctldClient) ChangeTabletType(ctx context.Context, in *vtctld
 	return client.c.ChangeTabletType(ctx, in, opts...)
 }
 
+// CheckMySQLUsers is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) CheckMySQLUsers(ctx context.Context, in *vtctldatapb.CheckMySQLUsersRequest, opts ...grpc.CallOption) (*vtctldatapb.CheckMySQLUsersResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.CheckMySQLUsers(ctx, in, opts...)
+}
+
 // CreateKeyspace is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) CreateKeyspace(ctx context.Context, in *vtctldatapb.CreateKeyspaceRequest, opts ...grpc.CallOption) (*vtctldatapb.CreateKeyspaceResponse, error) {
 	if client.c == nil {
@@ -91,6 +163,33 @@ func (client *gRPCVtctldClient) CreateShard(ctx context.Context, in *vtctldatapb
 	return client.c.CreateShard(ctx, in, opts...)
 }
 
+// DecommissionTablet is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) DecommissionTablet(ctx context.Context, in *vtctldatapb.DecommissionTabletRequest, opts ...grpc.CallOption) (*vtctldatapb.DecommissionTabletResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.DecommissionTablet(ctx, in, opts...)
+}
+
+// DeleteBackupSchedule is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) DeleteBackupSchedule(ctx context.Context, in *vtctldatapb.DeleteBackupScheduleRequest, opts ...grpc.CallOption) (*vtctldatapb.DeleteBackupScheduleResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.DeleteBackupSchedule(ctx, in, opts...)
+}
+
+// DeleteBackupStorageConfig is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) DeleteBackupStorageConfig(ctx context.Context, in *vtctldatapb.DeleteBackupStorageConfigRequest, opts ...grpc.CallOption) (*vtctldatapb.DeleteBackupStorageConfigResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.DeleteBackupStorageConfig(ctx, in, opts...)
+}
+
 // DeleteCellInfo is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) DeleteCellInfo(ctx context.Context, in *vtctldatapb.DeleteCellInfoRequest, opts ...grpc.CallOption) (*vtctldatapb.DeleteCellInfoResponse, error) {
 	if client.c == nil {
@@ -136,6 +235,15 @@ func (client *gRPCVtctldClient) DeleteTablets(ctx context.Context, in *vtctldata
 	return client.c.DeleteTablets(ctx, in, opts...)
 }
 
+// DrainTablet is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) DrainTablet(ctx context.Context, in *vtctldatapb.DrainTabletRequest, opts ...grpc.CallOption) (*vtctldatapb.DrainTabletResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.DrainTablet(ctx, in, opts...)
+}
+
 // EmergencyReparentShard is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) EmergencyReparentShard(ctx context.Context, in *vtctldatapb.EmergencyReparentShardRequest, opts ...grpc.CallOption) (*vtctldatapb.EmergencyReparentShardResponse, error) {
 	if client.c == nil {
@@ -145,6 +253,15 @@ func (client *gRPCVtctldClient) EmergencyReparentShard(ctx context.Context, in *
 	return client.c.EmergencyReparentShard(ctx, in, opts...)
 }
 
+// FenceShard is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) FenceShard(ctx context.Context, in *vtctldatapb.FenceShardRequest, opts ...grpc.CallOption) (*vtctldatapb.FenceShardResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.FenceShard(ctx, in, opts...)
+}
+
 // FindAllShardsInKeyspace is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) FindAllShardsInKeyspace(ctx context.Context, in *vtctldatapb.FindAllShardsInKeyspaceRequest, opts ...grpc.CallOption) (*vtctldatapb.FindAllShardsInKeyspaceResponse, error) {
 	if client.c == nil {
@@ -154,6 +271,24 @@ func (client *gRPCVtctldClient) FindAllShardsInKeyspace(ctx context.Context, in
 	return client.c.FindAllShardsInKeyspace(ctx, in, opts...)
 }
 
+// GetBackupSchedule is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) GetBackupSchedule(ctx context.Context, in *vtctldatapb.GetBackupScheduleRequest, opts ...grpc.CallOption) (*vtctldatapb.GetBackupScheduleResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.GetBackupSchedule(ctx, in, opts...)
+}
+
+// GetBackupStorageConfig is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) GetBackupStorageConfig(ctx context.Context, in *vtctldatapb.GetBackupStorageConfigRequest, opts ...grpc.CallOption) (*vtctldatapb.GetBackupStorageConfigResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.GetBackupStorageConfig(ctx, in, opts...)
+}
+
 // GetBackups is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) GetBackups(ctx context.Context, in *vtctldatapb.GetBackupsRequest, opts ...grpc.CallOption) (*vtctldatapb.GetBackupsResponse, error) {
 	if client.c == nil {
@@ -163,6 +298,15 @@ func (client *gRPCVtctldClient) GetBackups(ctx context.Context, in *vtctldatapb.
 	return client.c.GetBackups(ctx, in, opts...)
 }
 
+// GetCDCSinks is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) GetCDCSinks(ctx context.Context, in *vtctldatapb.GetCDCSinksRequest, opts ...grpc.CallOption) (*vtctldatapb.GetCDCSinksResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.GetCDCSinks(ctx, in, opts...)
+}
+
 // GetCellInfo is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) GetCellInfo(ctx context.Context, in *vtctldatapb.GetCellInfoRequest, opts ...grpc.CallOption) (*vtctldatapb.GetCellInfoResponse, error) {
 	if client.c == nil {
@@ -181,6 +325,15 @@ func (client *gRPCVtctldClient) GetCellInfoNames(ctx context.Context, in *vtctld
 	return client.c.GetCellInfoNames(ctx, in, opts...)
 }
 
+// GetCellServingOverride is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) GetCellServingOverride(ctx context.Context, in *vtctldatapb.GetCellServingOverrideRequest, opts ...grpc.CallOption) (*vtctldatapb.GetCellServingOverrideResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.GetCellServingOverride(ctx, in, opts...)
+}
+
 // GetCellsAliases is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) GetCellsAliases(ctx context.Context, in *vtctldatapb.GetCellsAliasesRequest, opts ...grpc.CallOption) (*vtctldatapb.GetCellsAliasesResponse, error) {
 	if client.c == nil {
@@ -190,6 +343,15 @@ func (client *gRPCVtctldClient) GetCellsAliases(ctx context.Context, in *vtctlda
 	return client.c.GetCellsAliases(ctx, in, opts...)
 }
 
+// GetGCTables is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) GetGCTables(ctx context.Context, in *vtctldatapb.GetGCTablesRequest, opts ...grpc.CallOption) (*vtctldatapb.GetGCTablesResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.GetGCTables(ctx, in, opts...)
+}
+
 // GetKeyspace is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) GetKeyspace(ctx context.Context, in *vtctldatapb.GetKeyspaceRequest, opts ...grpc.CallOption) (*vtctldatapb.GetKeyspaceResponse, error) {
 	if client.c == nil {
@@ -199,6 +361,15 @@ func (client *gRPCVtctldClient) GetKeyspace(ctx context.Context, in *vtctldatapb
 	return client.c.GetKeyspace(ctx, in, opts...)
 }
 
+// GetKeyspaceBackupRuns is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) GetKeyspaceBackupRuns(ctx context.Context, in *vtctldatapb.GetKeyspaceBackupRunsRequest, opts ...grpc.CallOption) (*vtctldatapb.GetKeyspaceBackupRunsResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.GetKeyspaceBackupRuns(ctx, in, opts...)
+}
+
 // GetKeyspaces is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) GetKeyspaces(ctx context.Context, in *vtctldatapb.GetKeyspacesRequest, opts ...grpc.CallOption) (*vtctldatapb.GetKeyspacesResponse, error) {
 	if client.c == nil {
@@ -208,6 +379,24 @@ func (client *gRPCVtctldClient) GetKeyspaces(ctx context.Context, in *vtctldatap
 	return client.c.GetKeyspaces(ctx, in, opts...)
 }
 
+// GetMySQLUsers is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) GetMySQLUsers(ctx context.Context, in *vtctldatapb.GetMySQLUsersRequest, opts ...grpc.CallOption) (*vtctldatapb.GetMySQLUsersResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.GetMySQLUsers(ctx, in, opts...)
+}
+
+// GetOnlineDDLProgress is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) GetOnlineDDLProgress(ctx context.Context, in *vtctldatapb.GetOnlineDDLProgressRequest, opts ...grpc.CallOption) (*vtctldatapb.GetOnlineDDLProgressResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.GetOnlineDDLProgress(ctx, in, opts...)
+}
+
 // GetRoutingRules is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) GetRoutingRules(ctx context.Context, in *vtctldatapb.GetRoutingRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.GetRoutingRulesResponse, error) {
 	if client.c == nil {
@@ -235,6 +424,42 @@ func (client *gRPCVtctldClient) GetShard(ctx context.Context, in *vtctldatapb.Ge
 	return client.c.GetShard(ctx, in, opts...)
 }
 
+// GetShardFence is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) GetShardFence(ctx context.Context, in *vtctldatapb.GetShardFenceRequest, opts ...grpc.CallOption) (*vtctldatapb.GetShardFenceResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.GetShardFence(ctx, in, opts...)
+}
+
+// GetShardReplicationGraph is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) GetShardReplicationGraph(ctx context.Context, in *vtctldatapb.GetShardReplicationGraphRequest, opts ...grpc.CallOption) (*vtctldatapb.GetShardReplicationGraphResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.GetShardReplicationGraph(ctx, in, opts...)
+}
+
+// GetShardServingTimeline is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) GetShardServingTimeline(ctx context.Context, in *vtctldatapb.GetShardServingTimelineRequest, opts ...grpc.CallOption) (*vtctldatapb.GetShardServingTimelineResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.GetShardServingTimeline(ctx, in, opts...)
+}
+
+// GetSrvKeyspaceWithVersion is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) GetSrvKeyspaceWithVersion(ctx context.Context, in *vtctldatapb.GetSrvKeyspaceWithVersionRequest, opts ...grpc.CallOption) (*vtctldatapb.GetSrvKeyspaceWithVersionResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.GetSrvKeyspaceWithVersion(ctx, in, opts...)
+}
+
 // GetSrvKeyspaces is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) GetSrvKeyspaces(ctx context.Context, in *vtctldatapb.GetSrvKeyspacesRequest, opts ...grpc.CallOption) (*vtctldatapb.GetSrvKeyspacesResponse, error) {
 	if client.c == nil {
@@ -271,6 +496,15 @@ func (client *gRPCVtctldClient) GetTablet(ctx context.Context, in *vtctldatapb.G
 	return client.c.GetTablet(ctx, in, opts...)
 }
 
+// GetTabletHealthHistory is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) GetTabletHealthHistory(ctx context.Context, in *vtctldatapb.GetTabletHealthHistoryRequest, opts ...grpc.CallOption) (*vtctldatapb.GetTabletHealthHistoryResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.GetTabletHealthHistory(ctx, in, opts...)
+}
+
 // GetTablets is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) GetTablets(ctx context.Context, in *vtctldatapb.GetTabletsRequest, opts ...grpc.CallOption) (*vtctldatapb.GetTabletsResponse, error) {
 	if client.c == nil {
@@ -289,6 +523,24 @@ func (client *gRPCVtctldClient) GetVSchema(ctx context.Context, in *vtctldatapb.
 	return client.c.GetVSchema(ctx, in, opts...)
 }
 
+// GetWorkflowAuditLog is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) GetWorkflowAuditLog(ctx context.Context, in *vtctldatapb.GetWorkflowAuditLogRequest, opts ...grpc.CallOption) (*vtctldatapb.GetWorkflowAuditLogResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.GetWorkflowAuditLog(ctx, in, opts...)
+}
+
+// GetWorkflowProgress is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) GetWorkflowProgress(ctx context.Context, in *vtctldatapb.GetWorkflowProgressRequest, opts ...grpc.CallOption) (*vtctldatapb.GetWorkflowProgressResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.GetWorkflowProgress(ctx, in, opts...)
+}
+
 // GetWorkflows is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) GetWorkflows(ctx context.Context, in *vtctldatapb.GetWorkflowsRequest, opts ...grpc.CallOption) (*vtctldatapb.GetWorkflowsResponse, error) {
 	if client.c == nil {
@@ -307,6 +559,42 @@ func (client *gRPCVtctldClient) InitShardPrimary(ctx context.Context, in *vtctld
 	return client.c.InitShardPrimary(ctx, in, opts...)
 }
 
+// ListKeyspaceBackups is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) ListKeyspaceBackups(ctx context.Context, in *vtctldatapb.ListKeyspaceBackupsRequest, opts ...grpc.CallOption) (*vtctldatapb.ListKeyspaceBackupsResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.ListKeyspaceBackups(ctx, in, opts...)
+}
+
+// ListLocks is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) ListLocks(ctx context.Context, in *vtctldatapb.ListLocksRequest, opts ...grpc.CallOption) (*vtctldatapb.ListLocksResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.ListLocks(ctx, in, opts...)
+}
+
+// ListVDiffResults is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) ListVDiffResults(ctx context.Context, in *vtctldatapb.ListVDiffResultsRequest, opts ...grpc.CallOption) (*vtctldatapb.ListVDiffResultsResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.ListVDiffResults(ctx, in, opts...)
+}
+
+// PlanReparentShard is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) PlanReparentShard(ctx context.Context, in *vtctldatapb.PlanReparentShardRequest, opts ...grpc.CallOption) (*vtctldatapb.PlanReparentShardResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.PlanReparentShard(ctx, in, opts...)
+}
+
 // PlannedReparentShard is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) PlannedReparentShard(ctx context.Context, in *vtctldatapb.PlannedReparentShardRequest, opts ...grpc.CallOption) (*vtctldatapb.PlannedReparentShardResponse, error) {
 	if client.c == nil {
@@ -316,6 +604,33 @@ func (client *gRPCVtctldClient) PlannedReparentShard(ctx context.Context, in *vt
 	return client.c.PlannedReparentShard(ctx, in, opts...)
 }
 
+// PreviewDeclarativeSchema is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) PreviewDeclarativeSchema(ctx context.Context, in *vtctldatapb.PreviewDeclarativeSchemaRequest, opts ...grpc.CallOption) (*vtctldatapb.PreviewDeclarativeSchemaResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.PreviewDeclarativeSchema(ctx, in, opts...)
+}
+
+// PruneBackups is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) PruneBackups(ctx context.Context, in *vtctldatapb.PruneBackupsRequest, opts ...grpc.CallOption) (*vtctldatapb.PruneBackupsResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.PruneBackups(ctx, in, opts...)
+}
+
+// RebuildKeyspaceGraph is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) RebuildKeyspaceGraph(ctx context.Context, in *vtctldatapb.RebuildKeyspaceGraphRequest, opts ...grpc.CallOption) (*vtctldatapb.RebuildKeyspaceGraphResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.RebuildKeyspaceGraph(ctx, in, opts...)
+}
+
 // RebuildVSchemaGraph is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) RebuildVSchemaGraph(ctx context.Context, in *vtctldatapb.RebuildVSchemaGraphRequest, opts ...grpc.CallOption) (*vtctldatapb.RebuildVSchemaGraphResponse, error) {
 	if client.c == nil {
@@ -325,6 +640,15 @@ func (client *gRPCVtctldClient) RebuildVSchemaGraph(ctx context.Context, in *vtc
 	return client.c.RebuildVSchemaGraph(ctx, in, opts...)
 }
 
+// RecommendTabletTypeChange is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) RecommendTabletTypeChange(ctx context.Context, in *vtctldatapb.RecommendTabletTypeChangeRequest, opts ...grpc.CallOption) (*vtctldatapb.RecommendTabletTypeChangeResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.RecommendTabletTypeChange(ctx, in, opts...)
+}
+
 // RefreshState is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) RefreshState(ctx context.Context, in *vtctldatapb.RefreshStateRequest, opts ...grpc.CallOption) (*vtctldatapb.RefreshStateResponse, error) {
 	if client.c == nil {
@@ -343,6 +667,24 @@ func (client *gRPCVtctldClient) RefreshStateByShard(ctx context.Context, in *vtc
 	return client.c.RefreshStateByShard(ctx, in, opts...)
 }
 
+// RejectMigration is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) RejectMigration(ctx context.Context, in *vtctldatapb.RejectMigrationRequest, opts ...grpc.CallOption) (*vtctldatapb.RejectMigrationResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.RejectMigration(ctx, in, opts...)
+}
+
+// ReleaseLock is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) ReleaseLock(ctx context.Context, in *vtctldatapb.ReleaseLockRequest, opts ...grpc.CallOption) (*vtctldatapb.ReleaseLockResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.ReleaseLock(ctx, in, opts...)
+}
+
 // RemoveKeyspaceCell is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) RemoveKeyspaceCell(ctx context.Context, in *vtctldatapb.RemoveKeyspaceCellRequest, opts ...grpc.CallOption) (*vtctldatapb.RemoveKeyspaceCellResponse, error) {
 	if client.c == nil {
@@ -370,6 +712,96 @@ func (client *gRPCVtctldClient) ReparentTablet(ctx context.Context, in *vtctldat
 	return client.c.ReparentTablet(ctx, in, opts...)
 }
 
+// RestoreGCTable is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) RestoreGCTable(ctx context.Context, in *vtctldatapb.RestoreGCTableRequest, opts ...grpc.CallOption) (*vtctldatapb.RestoreGCTableResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.RestoreGCTable(ctx, in, opts...)
+}
+
+// RestoreToPointInTime is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) RestoreToPointInTime(ctx context.Context, in *vtctldatapb.RestoreToPointInTimeRequest, opts ...grpc.CallOption) (*vtctldatapb.RestoreToPointInTimeResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.RestoreToPointInTime(ctx, in, opts...)
+}
+
+// RevertMigration is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) RevertMigration(ctx context.Context, in *vtctldatapb.RevertMigrationRequest, opts ...grpc.CallOption) (*vtctldatapb.RevertMigrationResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.RevertMigration(ctx, in, opts...)
+}
+
+// SeedTable is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) SeedTable(ctx context.Context, in *vtctldatapb.SeedTableRequest, opts ...grpc.CallOption) (*vtctldatapb.SeedTableResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.SeedTable(ctx, in, opts...)
+}
+
+// SetBackupSchedule is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) SetBackupSchedule(ctx context.Context, in *vtctldatapb.SetBackupScheduleRequest, opts ...grpc.CallOption) (*vtctldatapb.SetBackupScheduleResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.SetBackupSchedule(ctx, in, opts...)
+}
+
+// SetBackupStorageConfig is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) SetBackupStorageConfig(ctx context.Context, in *vtctldatapb.SetBackupStorageConfigRequest, opts ...grpc.CallOption) (*vtctldatapb.SetBackupStorageConfigResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.SetBackupStorageConfig(ctx, in, opts...)
+}
+
+// SetCDCSinks is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) SetCDCSinks(ctx context.Context, in *vtctldatapb.SetCDCSinksRequest, opts ...grpc.CallOption) (*vtctldatapb.SetCDCSinksResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.SetCDCSinks(ctx, in, opts...)
+}
+
+// SetCellServingOverride is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) SetCellServingOverride(ctx context.Context, in *vtctldatapb.SetCellServingOverrideRequest, opts ...grpc.CallOption) (*vtctldatapb.SetCellServingOverrideResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.SetCellServingOverride(ctx, in, opts...)
+}
+
+// SetGCTablesRetention is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) SetGCTablesRetention(ctx context.Context, in *vtctldatapb.SetGCTablesRetentionRequest, opts ...grpc.CallOption) (*vtctldatapb.SetGCTablesRetentionResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.SetGCTablesRetention(ctx, in, opts...)
+}
+
+// SetMySQLUsers is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) SetMySQLUsers(ctx context.Context, in *vtctldatapb.SetMySQLUsersRequest, opts ...grpc.CallOption) (*vtctldatapb.SetMySQLUsersResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.SetMySQLUsers(ctx, in, opts...)
+}
+
 // ShardReplicationPositions is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) ShardReplicationPositions(ctx context.Context, in *vtctldatapb.ShardReplicationPositionsRequest, opts ...grpc.CallOption) (*vtctldatapb.ShardReplicationPositionsResponse, error) {
 	if client.c == nil {
@@ -379,6 +811,24 @@ func (client *gRPCVtctldClient) ShardReplicationPositions(ctx context.Context, i
 	return client.c.ShardReplicationPositions(ctx, in, opts...)
 }
 
+// SplitReads is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) SplitReads(ctx context.Context, in *vtctldatapb.SplitReadsRequest, opts ...grpc.CallOption) (*vtctldatapb.SplitReadsResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.SplitReads(ctx, in, opts...)
+}
+
+// StartHostMaintenance is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) StartHostMaintenance(ctx context.Context, in *vtctldatapb.StartHostMaintenanceRequest, opts ...grpc.CallOption) (*vtctldatapb.StartHostMaintenanceResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.StartHostMaintenance(ctx, in, opts...)
+}
+
 // TabletExternallyReparented is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) TabletExternallyReparented(ctx context.Context, in *vtctldatapb.TabletExternallyReparentedRequest, opts ...grpc.CallOption) (*vtctldatapb.TabletExternallyReparentedResponse, error) {
 	if client.c == nil {
@@ -388,6 +838,24 @@ func (client *gRPCVtctldClient) TabletExternallyReparented(ctx context.Context,
 	return client.c.TabletExternallyReparented(ctx, in, opts...)
 }
 
+// UndrainTablet is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) UndrainTablet(ctx context.Context, in *vtctldatapb.UndrainTabletRequest, opts ...grpc.CallOption) (*vtctldatapb.UndrainTabletResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.UndrainTablet(ctx, in, opts...)
+}
+
+// UnfenceShard is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) UnfenceShard(ctx context.Context, in *vtctldatapb.UnfenceShardRequest, opts ...grpc.CallOption) (*vtctldatapb.UnfenceShardResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.UnfenceShard(ctx, in, opts...)
+}
+
 // UpdateCellInfo is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) UpdateCellInfo(ctx context.Context, in *vtctldatapb.UpdateCellInfoRequest, opts ...grpc.CallOption) (*vtctldatapb.UpdateCellInfoResponse, error) {
 	if client.c == nil {
@@ -405,3 +873,93 @@ func (client *gRPCVtctldClient) UpdateCellsAlias(ctx context.Context, in *vtctld
 
 	return client.c.UpdateCellsAlias(ctx, in, opts...)
 }
+
+// UpdateSrvKeyspace is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) UpdateSrvKeyspace(ctx context.Context, in *vtctldatapb.UpdateSrvKeyspaceRequest, opts ...grpc.CallOption) (*vtctldatapb.UpdateSrvKeyspaceResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.UpdateSrvKeyspace(ctx, in, opts...)
+}
+
+// UpdateWorkflowThrottling is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) UpdateWorkflowThrottling(ctx context.Context, in *vtctldatapb.UpdateWorkflowThrottlingRequest, opts ...grpc.CallOption) (*vtctldatapb.UpdateWorkflowThrottlingResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.UpdateWorkflowThrottling(ctx, in, opts...)
+}
+
+// ValidateConfig is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) ValidateConfig(ctx context.Context, in *vtctldatapb.ValidateConfigRequest, opts ...grpc.CallOption) (*vtctldatapb.ValidateConfigResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.ValidateConfig(ctx, in, opts...)
+}
+
+// ValidatePermissions is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) ValidatePermissions(ctx context.Context, in *vtctldatapb.ValidatePermissionsRequest, opts ...grpc.CallOption) (*vtctldatapb.ValidatePermissionsResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.ValidatePermissions(ctx, in, opts...)
+}
+
+// ValidateRoutingRules is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) ValidateRoutingRules(ctx context.Context, in *vtctldatapb.ValidateRoutingRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.ValidateRoutingRulesResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.ValidateRoutingRules(ctx, in, opts...)
+}
+
+// ValidateSrvKeyspace is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) ValidateSrvKeyspace(ctx context.Context, in *vtctldatapb.ValidateSrvKeyspaceRequest, opts ...grpc.CallOption) (*vtctldatapb.ValidateSrvKeyspaceResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.ValidateSrvKeyspace(ctx, in, opts...)
+}
+
+// ValidateVersion is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) ValidateVersion(ctx context.Context, in *vtctldatapb.ValidateVersionRequest, opts ...grpc.CallOption) (*vtctldatapb.ValidateVersionResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.ValidateVersion(ctx, in, opts...)
+}
+
+// VerifyReparentShard is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) VerifyReparentShard(ctx context.Context, in *vtctldatapb.VerifyReparentShardRequest, opts ...grpc.CallOption) (*vtctldatapb.VerifyReparentShardResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.VerifyReparentShard(ctx, in, opts...)
+}
+
+// VerifyReverseReplication is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) VerifyReverseReplication(ctx context.Context, in *vtctldatapb.VerifyReverseReplicationRequest, opts ...grpc.CallOption) (*vtctldatapb.VerifyReverseReplicationResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.VerifyReverseReplication(ctx, in, opts...)
+}
+
+// WatchTopology is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) WatchTopology(ctx context.Context, in *vtctldatapb.WatchTopologyRequest, opts ...grpc.CallOption) (*vtctldatapb.WatchTopologyResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.WatchTopology(ctx, in, opts...)
+}
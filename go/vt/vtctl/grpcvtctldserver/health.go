@@ -0,0 +1,67 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcvtctldserver
+
+import (
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/vt/vterrors"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+	"vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// TabletHealthSource provides the health of the tablets, as seen by the
+// healthcheck of vtctld. vtctld registers one when it runs with
+// -enable_realtime_stats.
+type TabletHealthSource interface {
+	// IsServing returns whether the tablet is up and serving. It returns
+	// false for the tablets unknown to the healthcheck.
+	IsServing(alias *topodatapb.TabletAlias) bool
+	// HealthHistory returns the health transitions of the tablet, or of
+	// all the tablets if alias is nil, which happened in [since, until),
+	// sorted by time. A zero since or until doesn't bound the range.
+	HealthHistory(alias *topodatapb.TabletAlias, since, until time.Time) []*vtctldatapb.TabletHealthEvent
+}
+
+var (
+	healthSourceMu sync.Mutex
+	healthSource   TabletHealthSource
+)
+
+// RegisterTabletHealthSource registers the source of the health of the
+// tablets for the RPCs which need it.
+func RegisterTabletHealthSource(source TabletHealthSource) {
+	healthSourceMu.Lock()
+	defer healthSourceMu.Unlock()
+
+	healthSource = source
+}
+
+// getTabletHealthSource returns the registered TabletHealthSource, or an
+// error if there is none.
+func getTabletHealthSource() (TabletHealthSource, error) {
+	healthSourceMu.Lock()
+	defer healthSourceMu.Unlock()
+
+	if healthSource == nil {
+		return nil, vterrors.Errorf(vtrpc.Code_FAILED_PRECONDITION, "the health of the tablets requires vtctld to run with -enable_realtime_stats")
+	}
+	return healthSource, nil
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcvtctldserver
+
+import (
+	"context"
+	"flag"
+
+	"google.golang.org/grpc"
+
+	"vitess.io/vitess/go/vt/vterrors"
+
+	"vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+var readOnly = flag.Bool("vtctld_read_only", false, "If true, vtctld only serves the requests which read the cluster, e.g. for inventory and status dashboards exposed to a broader audience: the other RPCs of the Vtctld and Vtctl gRPC APIs and the other requests of the /api HTTP API fail with PERMISSION_DENIED, and the workflow manager UI is disabled.")
+
+// IsReadOnly returns true if vtctld only serves the requests which read
+// the cluster, see -vtctld_read_only.
+func IsReadOnly() bool {
+	return *readOnly
+}
+
+// ErrReadOnly returns the error of a request denied by a read-only vtctld.
+func ErrReadOnly(request string) error {
+	return vterrors.Errorf(vtrpc.Code_PERMISSION_DENIED, "%v is not allowed: the vtctld is read-only", request)
+}
+
+// readOnlyRPCs are the RPCs of the Vtctld service which a read-only vtctld
+// serves, as they only read the cluster. All the other RPCs, including the
+// ones added later, are denied.
+var readOnlyRPCs = map[string]bool{
+	"BrowseTopology":            true,
+	"CheckMySQLUsers":           true,
+	"FindAllShardsInKeyspace":   true,
+	"GetBackupSchedule":         true,
+	"GetBackups":                true,
+	"GetBackupStorageConfig":    true,
+	"GetCDCSinks":               true,
+	"GetCellInfo":               true,
+	"GetCellInfoNames":          true,
+	"GetCellServingOverride":    true,
+	"GetCellsAliases":           true,
+	"GetGCTables":               true,
+	"GetKeyspace":               true,
+	"GetKeyspaceBackupRuns":     true,
+	"GetKeyspaces":              true,
+	"GetMySQLUsers":             true,
+	"GetOnlineDDLProgress":      true,
+	"GetRoutingRules":           true,
+	"GetSchema":                 true,
+	"GetShard":                  true,
+	"GetShardFence":             true,
+	"GetShardReplicationGraph":  true,
+	"GetShardServingTimeline":   true,
+	"GetSrvKeyspaceWithVersion": true,
+	"GetSrvKeyspaces":           true,
+	"GetSrvVSchema":             true,
+	"GetSrvVSchemas":            true,
+	"GetTablet":                 true,
+	"GetTabletHealthHistory":    true,
+	"GetTablets":                true,
+	"GetVSchema":                true,
+	"GetWorkflowAuditLog":       true,
+	"GetWorkflowProgress":       true,
+	"GetWorkflows":              true,
+	"ListKeyspaceBackups":       true,
+	"ListLocks":                 true,
+	"ListVDiffResults":          true,
+	"PlanReparentShard":         true,
+	"PreviewDeclarativeSchema":  true,
+	"RecommendTabletTypeChange": true,
+	"ShardReplicationPositions": true,
+	"ValidateConfig":            true,
+	"ValidatePermissions":       true,
+	"ValidateRoutingRules":      true,
+	"ValidateSrvKeyspace":       true,
+	"ValidateVersion":           true,
+	"VerifyReparentShard":       true,
+	"VerifyReverseReplication":  true,
+	"WatchTopology":             true,
+}
+
+// readOnlyServiceDesc returns a copy of the gRPC service desc in which the
+// RPCs which aren't in readOnlyRPCs fail with PERMISSION_DENIED.
+func readOnlyServiceDesc(desc *grpc.ServiceDesc) *grpc.ServiceDesc {
+	ro := *desc
+	ro.Methods = make([]grpc.MethodDesc, len(desc.Methods))
+	for i, method := range desc.Methods {
+		if !readOnlyRPCs[method.MethodName] {
+			name := method.MethodName
+			method.Handler = func(interface{}, context.Context, func(interface{}) error, grpc.UnaryServerInterceptor) (interface{}, error) {
+				return nil, ErrReadOnly(name)
+			}
+		}
+		ro.Methods[i] = method
+	}
+	ro.Streams = make([]grpc.StreamDesc, len(desc.Streams))
+	for i, stream := range desc.Streams {
+		if !readOnlyRPCs[stream.StreamName] {
+			name := stream.StreamName
+			stream.Handler = func(interface{}, grpc.ServerStream) error {
+				return ErrReadOnly(name)
+			}
+		}
+		ro.Streams[i] = stream
+	}
+	return &ro
+}
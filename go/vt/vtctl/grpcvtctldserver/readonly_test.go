@@ -0,0 +1,88 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcvtctldserver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+	vtctlservicepb "vitess.io/vitess/go/vt/proto/vtctlservice"
+	"vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+func TestReadOnlyServiceDesc(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ts := memorytopo.NewServer("zone1")
+	require.NoError(t, ts.CreateKeyspace(ctx, "testkeyspace", &topodatapb.Keyspace{}))
+	vtctld := NewVtctldServer(ts)
+	desc := readOnlyServiceDesc(&vtctlservicepb.Vtctld_ServiceDesc)
+
+	// Every RPC is denied, except the reads. The request isn't even
+	// decoded.
+	noDecode := func(interface{}) error {
+		return nil
+	}
+	methods := make(map[string]bool)
+	for _, method := range desc.Methods {
+		methods[method.MethodName] = true
+		if readOnlyRPCs[method.MethodName] {
+			continue
+		}
+		_, err := method.Handler(vtctld, ctx, noDecode, nil)
+		require.Error(t, err, "%v is not denied by the read-only vtctld", method.MethodName)
+		assert.Equal(t, vtrpc.Code_PERMISSION_DENIED, vterrors.Code(err), "%v", method.MethodName)
+	}
+	assert.Empty(t, desc.Streams)
+
+	// Reads are served.
+	for _, method := range desc.Methods {
+		if method.MethodName != "GetKeyspaces" {
+			continue
+		}
+		resp, err := method.Handler(vtctld, ctx, noDecode, nil)
+		require.NoError(t, err)
+		require.Len(t, resp.(*vtctldatapb.GetKeyspacesResponse).Keyspaces, 1)
+		assert.Equal(t, "testkeyspace", resp.(*vtctldatapb.GetKeyspacesResponse).Keyspaces[0].Name)
+	}
+
+	// Every read RPC exists.
+	for name := range readOnlyRPCs {
+		assert.True(t, methods[name], "%v is not a Vtctld RPC", name)
+	}
+
+	// The original service is left alone.
+	for _, method := range vtctlservicepb.Vtctld_ServiceDesc.Methods {
+		if method.MethodName != "CreateKeyspace" {
+			continue
+		}
+		_, err := method.Handler(vtctld, ctx, func(req interface{}) error {
+			req.(*vtctldatapb.CreateKeyspaceRequest).Name = "otherkeyspace"
+			return nil
+		}, nil)
+		require.NoError(t, err)
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path"
 	"path/filepath"
 	"strings"
 	"sync"
@@ -30,20 +31,36 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	"vitess.io/vitess/go/event"
+	"vitess.io/vitess/go/mysql"
 	"vitess.io/vitess/go/protoutil"
 	"vitess.io/vitess/go/sqlescape"
 	"vitess.io/vitess/go/trace"
+	"vitess.io/vitess/go/vt/cdcsink"
 	"vitess.io/vitess/go/vt/concurrency"
 	"vitess.io/vitess/go/vt/log"
 	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/mysqlctl"
 	"vitess.io/vitess/go/vt/mysqlctl/backupstorage"
 	"vitess.io/vitess/go/vt/mysqlctl/mysqlctlproto"
+	"vitess.io/vitess/go/vt/schema"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/topo/topoproto"
 	"vitess.io/vitess/go/vt/topotools"
 	"vitess.io/vitess/go/vt/topotools/events"
+	"vitess.io/vitess/go/vt/vtctl/backuputil"
+	"vitess.io/vitess/go/vt/vtctl/fenceutil"
+	"vitess.io/vitess/go/vt/vtctl/grantutil"
+	"vitess.io/vitess/go/vt/vtctl/loadutil"
+	"vitess.io/vitess/go/vt/vtctl/lockutil"
+	"vitess.io/vitess/go/vt/vtctl/onlineddlutil"
 	"vitess.io/vitess/go/vt/vtctl/reparentutil"
+	"vitess.io/vitess/go/vt/vtctl/replicationutil"
+	"vitess.io/vitess/go/vt/vtctl/servingutil"
+	"vitess.io/vitess/go/vt/vtctl/tablegcutil"
+	"vitess.io/vitess/go/vt/vtctl/tablettypeutil"
+	"vitess.io/vitess/go/vt/vtctl/topobrowser"
+	"vitess.io/vitess/go/vt/vtctl/validateutil"
 	"vitess.io/vitess/go/vt/vtctl/workflow"
 	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vttablet/tmclient"
@@ -57,6 +74,7 @@ import (
 	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
 	vtctlservicepb "vitess.io/vitess/go/vt/proto/vtctlservice"
 	"vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/proto/vttime"
 )
 
 const (
@@ -66,9 +84,11 @@ const (
 // VtctldServer implements the Vtctld RPC service protocol.
 type VtctldServer struct {
 	vtctlservicepb.UnimplementedVtctldServer
-	ts  *topo.Server
-	tmc tmclient.TabletManagerClient
-	ws  *workflow.Server
+	ts      *topo.Server
+	tmc     tmclient.TabletManagerClient
+	ws      *workflow.Server
+	locks   *lockutil.ExternalLocks
+	browser *topobrowser.Browser
 }
 
 // NewVtctldServer returns a new VtctldServer for the given topo server.
@@ -76,12 +96,40 @@ func NewVtctldServer(ts *topo.Server) *VtctldServer {
 	tmc := tmclient.NewTabletManagerClient()
 
 	return &VtctldServer{
-		ts:  ts,
-		tmc: tmc,
-		ws:  workflow.NewServer(ts, tmc),
+		ts:      ts,
+		tmc:     tmc,
+		ws:      workflow.NewServer(ts, tmc),
+		locks:   lockutil.ForServer(ts),
+		browser: topobrowser.New(ts),
 	}
 }
 
+// AcquireKeyspaceLock is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) AcquireKeyspaceLock(ctx context.Context, req *vtctldatapb.AcquireKeyspaceLockRequest) (*vtctldatapb.AcquireKeyspaceLockResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.AcquireKeyspaceLock")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("shard", req.Shard)
+	span.Annotate("purpose", req.Purpose)
+
+	ttl, _, err := protoutil.DurationFromProto(req.Ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	span.Annotate("ttl_sec", ttl.Seconds())
+
+	lock, err := s.locks.Acquire(ctx, req.Keyspace, req.Shard, req.Purpose, ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.AcquireKeyspaceLockResponse{
+		Lock: externalLockToProto(lock),
+	}, nil
+}
+
 // AddCellInfo is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) AddCellInfo(ctx context.Context, req *vtctldatapb.AddCellInfoRequest) (*vtctldatapb.AddCellInfoResponse, error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.AddCellInfo")
@@ -123,6 +171,65 @@ func (s *VtctldServer) AddCellsAlias(ctx context.Context, req *vtctldatapb.AddCe
 	return &vtctldatapb.AddCellsAliasResponse{}, nil
 }
 
+// AdvanceGCTable is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) AdvanceGCTable(ctx context.Context, req *vtctldatapb.AdvanceGCTableRequest) (*vtctldatapb.AdvanceGCTableResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.AdvanceGCTable")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("uuid", req.Uuid)
+
+	if req.Keyspace == "" || req.Uuid == "" {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "keyspace and uuid are required")
+	}
+
+	tables, err := tablegcutil.AdvanceGCTable(ctx, s.ts, s.tmc, req.Keyspace, req.Uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.AdvanceGCTableResponse{
+		Tables: gcTablesToProto(tables),
+	}, nil
+}
+
+// ApplyMySQLUsers is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) ApplyMySQLUsers(ctx context.Context, req *vtctldatapb.ApplyMySQLUsersRequest) (*vtctldatapb.ApplyMySQLUsersResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.ApplyMySQLUsers")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("dry_run", req.DryRun)
+	span.Annotate("concurrency", req.Concurrency)
+
+	if req.Keyspace == "" {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "keyspace is required")
+	}
+
+	waitReplicasTimeout, ok, err := protoutil.DurationFromProto(req.WaitReplicasTimeout)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		waitReplicasTimeout = 30 * time.Second
+	}
+
+	span.Annotate("wait_replicas_timeout", waitReplicasTimeout.String())
+
+	report, err := grantutil.Apply(ctx, s.ts, s.tmc, grantutil.ApplyOptions{
+		Keyspace:            req.Keyspace,
+		DryRun:              req.DryRun,
+		WaitReplicasTimeout: waitReplicasTimeout,
+		Concurrency:         int(req.Concurrency),
+	})
+	if report == nil {
+		return nil, err
+	}
+
+	// The errors of the shards are in the report, which is more useful to
+	// the caller than the error alone.
+	return applyReportToProto(report)
+}
+
 // ApplyRoutingRules is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) ApplyRoutingRules(ctx context.Context, req *vtctldatapb.ApplyRoutingRulesRequest) (*vtctldatapb.ApplyRoutingRulesResponse, error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.ApplyRoutingRules")
@@ -149,6 +256,67 @@ func (s *VtctldServer) ApplyRoutingRules(ctx context.Context, req *vtctldatapb.A
 	return resp, nil
 }
 
+// ApplyRoutingRulesBatch is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) ApplyRoutingRulesBatch(ctx context.Context, req *vtctldatapb.ApplyRoutingRulesBatchRequest) (*vtctldatapb.ApplyRoutingRulesBatchResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.ApplyRoutingRulesBatch")
+	defer span.Finish()
+
+	span.Annotate("delete_tables", strings.Join(req.DeleteTables, ","))
+	span.Annotate("replace", req.Replace)
+	span.Annotate("expected_version", req.ExpectedVersion)
+	span.Annotate("dry_run", req.DryRun)
+	span.Annotate("skip_rebuild", req.SkipRebuild)
+	span.Annotate("rebuild_cells", strings.Join(req.RebuildCells, ","))
+
+	update, err := topotools.ApplyRoutingRulesBatch(ctx, s.ts, req.Rules, req.DeleteTables, req.Replace, req.ExpectedVersion, req.DryRun)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &vtctldatapb.ApplyRoutingRulesBatchResponse{
+		Version:      update.Version,
+		RoutingRules: update.RoutingRules,
+		Changes:      make([]*vtctldatapb.ApplyRoutingRulesBatchResponse_Change, 0, len(update.Changes)),
+	}
+	for _, change := range update.Changes {
+		resp.Changes = append(resp.Changes, &vtctldatapb.ApplyRoutingRulesBatchResponse_Change{
+			FromTable:   change.FromTable,
+			OldToTables: change.OldToTables,
+			NewToTables: change.NewToTables,
+		})
+	}
+
+	if len(req.Vtgates) != 0 && len(update.Changes) != 0 {
+		resp.AffectedQueries = make(map[string]*vtctldatapb.ApplyRoutingRulesBatchResponse_AffectedQueries, len(req.Vtgates))
+		for _, vtgate := range req.Vtgates {
+			affected := &vtctldatapb.ApplyRoutingRulesBatchResponse_AffectedQueries{}
+			queries, err := topotools.GetVtgateCachedQueries(ctx, vtgate)
+			if err != nil {
+				log.Warningf("Cannot get the plan cache of vtgate %v: %v", vtgate, err)
+				affected.Error = err.Error()
+			} else {
+				affected.Queries = topotools.RoutingRulesAffectedQueries(queries, update.Changes)
+			}
+			resp.AffectedQueries[vtgate] = affected
+		}
+	}
+
+	if req.DryRun || len(update.Changes) == 0 {
+		return resp, nil
+	}
+
+	if req.SkipRebuild {
+		log.Warningf("Skipping rebuild of SrvVSchema, will need to run RebuildVSchemaGraph for changes to take effect")
+		return resp, nil
+	}
+
+	if err := s.ts.RebuildSrvVSchema(ctx, req.RebuildCells); err != nil {
+		return nil, vterrors.Wrapf(err, "RebuildSrvVSchema(%v) failed: %v", req.RebuildCells, err)
+	}
+
+	return resp, nil
+}
+
 // ApplyVSchema is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) ApplyVSchema(ctx context.Context, req *vtctldatapb.ApplyVSchemaRequest) (*vtctldatapb.ApplyVSchemaResponse, error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.ApplyVSchema")
@@ -221,6 +389,107 @@ func (s *VtctldServer) ApplyVSchema(ctx context.Context, req *vtctldatapb.ApplyV
 	return &vtctldatapb.ApplyVSchemaResponse{VSchema: updatedVS}, nil
 }
 
+// ApproveMigration is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) ApproveMigration(ctx context.Context, req *vtctldatapb.ApproveMigrationRequest) (*vtctldatapb.ApproveMigrationResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.ApproveMigration")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("uuid", req.Uuid)
+	span.Annotate("reviewer", req.Reviewer)
+
+	if req.Keyspace == "" {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "keyspace is required")
+	}
+
+	if !schema.IsOnlineDDLUUID(req.Uuid) {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "not an online DDL UUID: %s", req.Uuid)
+	}
+
+	if req.Reviewer == "" {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "reviewer is required")
+	}
+
+	review, err := onlineddlutil.ApproveMigration(ctx, s.ts, s.tmc, req.Keyspace, req.Uuid, req.Reviewer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.ApproveMigrationResponse{
+		Uuid:     review.UUID,
+		Reviewer: review.Reviewer,
+		Shards:   review.Shards,
+	}, nil
+}
+
+// BackupKeyspace is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) BackupKeyspace(ctx context.Context, req *vtctldatapb.BackupKeyspaceRequest) (*vtctldatapb.BackupKeyspaceResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.BackupKeyspace")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("shards", strings.Join(req.Shards, ","))
+	span.Annotate("concurrency", req.Concurrency)
+	span.Annotate("backup_concurrency", req.BackupConcurrency)
+	span.Annotate("allow_primary", req.AllowPrimary)
+
+	if req.Keyspace == "" {
+		return nil, vterrors.New(vtrpc.Code_INVALID_ARGUMENT, "keyspace must not be empty")
+	}
+
+	m := sync.RWMutex{}
+	logstream := []*logutilpb.Event{}
+	logger := logutil.NewCallbackLogger(func(e *logutilpb.Event) {
+		m.Lock()
+		defer m.Unlock()
+
+		logstream = append(logstream, e)
+	})
+
+	// The shards which failed to back up are reported in the run, so its
+	// error is only returned if the run could not start.
+	run, err := backuputil.BackupKeyspace(ctx, s.ts, s.tmc, logger, req.Keyspace, backuputil.KeyspaceBackupOptions{
+		Shards:            req.Shards,
+		Concurrency:       int(req.Concurrency),
+		BackupConcurrency: int(req.BackupConcurrency),
+		AllowMaster:       req.AllowPrimary,
+		Trigger:           "manual",
+	})
+	if run == nil {
+		return nil, err
+	}
+
+	m.RLock()
+	defer m.RUnlock()
+
+	resp := &vtctldatapb.BackupKeyspaceResponse{
+		Run:    keyspaceBackupRunToProto(run),
+		Events: make([]*logutilpb.Event, len(logstream)),
+	}
+	copy(resp.Events, logstream)
+
+	return resp, nil
+}
+
+// BrowseTopology is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) BrowseTopology(ctx context.Context, req *vtctldatapb.BrowseTopologyRequest) (*vtctldatapb.BrowseTopologyResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.BrowseTopology")
+	defer span.Finish()
+
+	span.Annotate("path", req.Path)
+
+	node, err := s.browser.Browse(ctx, path.Clean("/"+req.Path))
+	if err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.BrowseTopologyResponse{
+		Data:     node.Data,
+		Version:  node.Version,
+		Children: node.Children,
+	}, nil
+}
+
 // ChangeTabletType is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) ChangeTabletType(ctx context.Context, req *vtctldatapb.ChangeTabletTypeRequest) (*vtctldatapb.ChangeTabletTypeResponse, error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.ChangeTabletType")
@@ -228,6 +497,7 @@ func (s *VtctldServer) ChangeTabletType(ctx context.Context, req *vtctldatapb.Ch
 
 	span.Annotate("tablet_alias", topoproto.TabletAliasString(req.TabletAlias))
 	span.Annotate("dry_run", req.DryRun)
+	span.Annotate("force", req.Force)
 	span.Annotate("tablet_type", topoproto.TabletTypeLString(req.DbType))
 
 	ctx, cancel := context.WithTimeout(ctx, *topo.RemoteOperationTimeout)
@@ -244,6 +514,19 @@ func (s *VtctldServer) ChangeTabletType(ctx context.Context, req *vtctldatapb.Ch
 		return nil, fmt.Errorf("tablet %v type change %v -> %v is not an allowed transition for ChangeTabletType", req.TabletAlias, tablet.Type, req.DbType)
 	}
 
+	var impact *vtctldatapb.TabletTypeChangeImpact
+	switch {
+	case req.DryRun:
+		// A dry run reports the impact of the change, even if it is below
+		// the minimum.
+		impact, err = tablettypeutil.Impact(ctx, s.ts, tablet.Tablet, req.DbType)
+	case !req.Force:
+		impact, err = tablettypeutil.Check(ctx, s.ts, tablet.Tablet, req.DbType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
 	if req.DryRun {
 		afterTablet := proto.Clone(tablet.Tablet).(*topodatapb.Tablet)
 		afterTablet.Type = req.DbType
@@ -252,6 +535,7 @@ func (s *VtctldServer) ChangeTabletType(ctx context.Context, req *vtctldatapb.Ch
 			BeforeTablet: tablet.Tablet,
 			AfterTablet:  afterTablet,
 			WasDryRun:    true,
+			Impact:       impact,
 		}, nil
 	}
 
@@ -273,9 +557,42 @@ func (s *VtctldServer) ChangeTabletType(ctx context.Context, req *vtctldatapb.Ch
 		BeforeTablet: tablet.Tablet,
 		AfterTablet:  changedTablet,
 		WasDryRun:    false,
+		Impact:       impact,
 	}, nil
 }
 
+// CheckMySQLUsers is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) CheckMySQLUsers(ctx context.Context, req *vtctldatapb.CheckMySQLUsersRequest) (*vtctldatapb.CheckMySQLUsersResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.CheckMySQLUsers")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("shard", req.Shard)
+	span.Annotate("cells", strings.Join(req.Cells, ","))
+	span.Annotate("concurrency", req.Concurrency)
+
+	if req.Keyspace == "" {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "keyspace is required")
+	}
+
+	report, err := grantutil.CheckDrift(ctx, s.ts, s.tmc, validateutil.Options{
+		Keyspace:    req.Keyspace,
+		Shard:       req.Shard,
+		Cells:       req.Cells,
+		Concurrency: int(req.Concurrency),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	drift, err := mysqlUsersDriftToProto(report)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.CheckMySQLUsersResponse{Drift: drift}, nil
+}
+
 // CreateKeyspace is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) CreateKeyspace(ctx context.Context, req *vtctldatapb.CreateKeyspaceRequest) (*vtctldatapb.CreateKeyspaceResponse, error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.CreateKeyspace")
@@ -436,6 +753,93 @@ func (s *VtctldServer) CreateShard(ctx context.Context, req *vtctldatapb.CreateS
 	}, nil
 }
 
+// DecommissionTablet is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) DecommissionTablet(ctx context.Context, req *vtctldatapb.DecommissionTabletRequest) (*vtctldatapb.DecommissionTabletResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.DecommissionTablet")
+	defer span.Finish()
+
+	span.Annotate("tablet_alias", topoproto.TabletAliasString(req.TabletAlias))
+
+	drainGrace, ok, err := protoutil.DurationFromProto(req.DrainGrace)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		drainGrace = time.Second * 10
+	}
+
+	span.Annotate("drain_grace_sec", drainGrace.Seconds())
+
+	m := sync.RWMutex{}
+	logstream := []*logutilpb.Event{}
+	logger := logutil.NewCallbackLogger(func(e *logutilpb.Event) {
+		m.Lock()
+		defer m.Unlock()
+
+		logstream = append(logstream, e)
+	})
+
+	err = topotools.DecommissionTablet(ctx, s.ts, s.tmc, logger, req.TabletAlias, drainGrace, topotools.GetVReplicationSourcesFromDebugVars)
+
+	m.RLock()
+	defer m.RUnlock()
+
+	resp := &vtctldatapb.DecommissionTabletResponse{
+		Events: make([]*logutilpb.Event, len(logstream)),
+	}
+	copy(resp.Events, logstream)
+
+	return resp, err
+}
+
+// DeleteBackupSchedule is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) DeleteBackupSchedule(ctx context.Context, req *vtctldatapb.DeleteBackupScheduleRequest) (*vtctldatapb.DeleteBackupScheduleResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.DeleteBackupSchedule")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+
+	if err := s.ts.DeleteBackupSchedule(ctx, req.Keyspace); err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.DeleteBackupScheduleResponse{}, nil
+}
+
+// DeleteBackupStorageConfig is part of the vtctlservicepb.VtctldServer
+// interface.
+func (s *VtctldServer) DeleteBackupStorageConfig(ctx context.Context, req *vtctldatapb.DeleteBackupStorageConfigRequest) (resp *vtctldatapb.DeleteBackupStorageConfigResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.DeleteBackupStorageConfig")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+
+	if req.Keyspace == "" {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "keyspace is required")
+	}
+
+	ctx, unlock, lockErr := s.ts.LockKeyspace(ctx, req.Keyspace, "DeleteBackupStorageConfig")
+	if lockErr != nil {
+		return nil, lockErr
+	}
+	defer unlock(&err)
+
+	ki, err := s.ts.GetKeyspace(ctx, req.Keyspace)
+	if err != nil {
+		return nil, err
+	}
+
+	if ki.BackupStorageConfig == nil {
+		return &vtctldatapb.DeleteBackupStorageConfigResponse{}, nil
+	}
+
+	ki.BackupStorageConfig = nil
+	if err := s.ts.UpdateKeyspace(ctx, ki); err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.DeleteBackupStorageConfigResponse{}, nil
+}
+
 // DeleteCellInfo is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) DeleteCellInfo(ctx context.Context, req *vtctldatapb.DeleteCellInfoRequest) (*vtctldatapb.DeleteCellInfoResponse, error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.DeleteCellInfo")
@@ -443,15 +847,31 @@ func (s *VtctldServer) DeleteCellInfo(ctx context.Context, req *vtctldatapb.Dele
 
 	span.Annotate("cell", req.Name)
 	span.Annotate("force", req.Force)
+	span.Annotate("drain", req.Drain)
 
 	ctx, cancel := context.WithTimeout(ctx, *topo.RemoteOperationTimeout)
 	defer cancel()
 
+	resp := &vtctldatapb.DeleteCellInfoResponse{}
+
+	if req.Drain {
+		drained, err := s.ts.DrainCell(ctx, req.Name)
+		for _, keyspace := range drained {
+			log.Infof("Removed cell %v from the serving graph of keyspace %v", req.Name, keyspace)
+		}
+
+		resp.DrainedKeyspaces = drained
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if err := s.ts.DeleteCellInfo(ctx, req.Name, req.Force); err != nil {
 		return nil, err
 	}
 
-	return &vtctldatapb.DeleteCellInfoResponse{}, nil
+	return resp, nil
 }
 
 // DeleteCellsAlias is part of the vtctlservicepb.VtctldServer interface.
@@ -558,6 +978,24 @@ func (s *VtctldServer) DeleteTablets(ctx context.Context, req *vtctldatapb.Delet
 	return &vtctldatapb.DeleteTabletsResponse{}, nil
 }
 
+// DrainTablet is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) DrainTablet(ctx context.Context, req *vtctldatapb.DrainTabletRequest) (*vtctldatapb.DrainTabletResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.DrainTablet")
+	defer span.Finish()
+
+	if req.TabletAlias == nil {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "tablet alias is required")
+	}
+
+	span.Annotate("tablet_alias", topoproto.TabletAliasString(req.TabletAlias))
+
+	if err := topotools.SetTabletDrained(ctx, s.ts, req.TabletAlias, true); err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.DrainTabletResponse{}, nil
+}
+
 // EmergencyReparentShard is part of the vtctldservicepb.VtctldServer interface.
 func (s *VtctldServer) EmergencyReparentShard(ctx context.Context, req *vtctldatapb.EmergencyReparentShardRequest) (*vtctldatapb.EmergencyReparentShardResponse, error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.EmergencyReparentShard")
@@ -579,11 +1017,23 @@ func (s *VtctldServer) EmergencyReparentShard(ctx context.Context, req *vtctldat
 
 	span.Annotate("wait_replicas_timeout_sec", waitReplicasTimeout.Seconds())
 
-	m := sync.RWMutex{}
-	logstream := []*logutilpb.Event{}
-	logger := logutil.NewCallbackLogger(func(e *logutilpb.Event) {
-		m.Lock()
-		defer m.Unlock()
+	maxReplicationLag, _, err := protoutil.DurationFromProto(req.MaxReplicationLag)
+	if err != nil {
+		return nil, err
+	}
+
+	excludeTabletAliases := topoproto.TabletAliasList(req.ExcludeTablets).ToStringSlice()
+
+	span.Annotate("allowed_cells", strings.Join(req.AllowedCells, ","))
+	span.Annotate("exclude_tablets", strings.Join(excludeTabletAliases, ","))
+	span.Annotate("max_replication_lag_sec", maxReplicationLag.Seconds())
+	span.Annotate("dry_run", req.DryRun)
+
+	m := sync.RWMutex{}
+	logstream := []*logutilpb.Event{}
+	logger := logutil.NewCallbackLogger(func(e *logutilpb.Event) {
+		m.Lock()
+		defer m.Unlock()
 
 		logstream = append(logstream, e)
 	})
@@ -595,6 +1045,10 @@ func (s *VtctldServer) EmergencyReparentShard(ctx context.Context, req *vtctldat
 			NewPrimaryAlias:     req.NewPrimary,
 			IgnoreReplicas:      sets.NewString(ignoreReplicaAliases...),
 			WaitReplicasTimeout: waitReplicasTimeout,
+			AllowedCells:        sets.NewString(req.AllowedCells...),
+			ExcludeTablets:      sets.NewString(excludeTabletAliases...),
+			MaxReplicationLag:   maxReplicationLag,
+			DryRun:              req.DryRun,
 		},
 	)
 
@@ -621,6 +1075,51 @@ func (s *VtctldServer) EmergencyReparentShard(ctx context.Context, req *vtctldat
 	return resp, err
 }
 
+// FenceShard is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) FenceShard(ctx context.Context, req *vtctldatapb.FenceShardRequest) (*vtctldatapb.FenceShardResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.FenceShard")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("shard", req.Shard)
+	span.Annotate("reason", req.Reason)
+
+	if req.Keyspace == "" || req.Shard == "" {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "keyspace and shard are required")
+	}
+
+	m := sync.RWMutex{}
+	logstream := []*logutilpb.Event{}
+	logger := logutil.NewCallbackLogger(func(e *logutilpb.Event) {
+		m.Lock()
+		defer m.Unlock()
+
+		logstream = append(logstream, e)
+	})
+
+	fence, err := fenceutil.FenceShard(ctx, s.ts, s.tmc, logger, req.Keyspace, req.Shard, req.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	pbFence, err := shardFenceToProto(fence)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &vtctldatapb.FenceShardResponse{
+		Fence: pbFence,
+	}
+
+	m.RLock()
+	defer m.RUnlock()
+
+	resp.Events = make([]*logutilpb.Event, len(logstream))
+	copy(resp.Events, logstream)
+
+	return resp, nil
+}
+
 // FindAllShardsInKeyspace is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) FindAllShardsInKeyspace(ctx context.Context, req *vtctldatapb.FindAllShardsInKeyspaceRequest) (*vtctldatapb.FindAllShardsInKeyspaceResponse, error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.FindAllShardsInKeyspace")
@@ -647,6 +1146,48 @@ func (s *VtctldServer) FindAllShardsInKeyspace(ctx context.Context, req *vtctlda
 	}, nil
 }
 
+// GetBackupSchedule is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) GetBackupSchedule(ctx context.Context, req *vtctldatapb.GetBackupScheduleRequest) (*vtctldatapb.GetBackupScheduleResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetBackupSchedule")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+
+	status, err := backuputil.GetScheduleStatus(ctx, s.ts, req.Keyspace)
+	if err != nil {
+		return nil, err
+	}
+
+	if status == nil {
+		return nil, vterrors.Errorf(vtrpc.Code_NOT_FOUND, "keyspace %v has no backup schedule", req.Keyspace)
+	}
+
+	return &vtctldatapb.GetBackupScheduleResponse{
+		Schedule:    status.Schedule,
+		NextRunTime: protoutil.TimeToProto(status.NextRunTime),
+	}, nil
+}
+
+// GetBackupStorageConfig is part of the vtctlservicepb.VtctldServer
+// interface.
+func (s *VtctldServer) GetBackupStorageConfig(ctx context.Context, req *vtctldatapb.GetBackupStorageConfigRequest) (*vtctldatapb.GetBackupStorageConfigResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetBackupStorageConfig")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+
+	if req.Keyspace == "" {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "keyspace is required")
+	}
+
+	ki, err := s.ts.GetKeyspace(ctx, req.Keyspace)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.GetBackupStorageConfigResponse{Config: ki.BackupStorageConfig}, nil
+}
+
 // GetBackups is part of the vtctldservicepb.VtctldServer interface.
 func (s *VtctldServer) GetBackups(ctx context.Context, req *vtctldatapb.GetBackupsRequest) (*vtctldatapb.GetBackupsResponse, error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetBackups")
@@ -710,6 +1251,25 @@ func (s *VtctldServer) GetBackups(ctx context.Context, req *vtctldatapb.GetBacku
 	}, nil
 }
 
+// GetCDCSinks is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) GetCDCSinks(ctx context.Context, req *vtctldatapb.GetCDCSinksRequest) (*vtctldatapb.GetCDCSinksResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetCDCSinks")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+
+	if req.Keyspace == "" {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "keyspace is required")
+	}
+
+	ki, err := s.ts.GetKeyspace(ctx, req.Keyspace)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.GetCDCSinksResponse{Sinks: ki.CdcSinks}, nil
+}
+
 // GetCellInfoNames is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) GetCellInfoNames(ctx context.Context, req *vtctldatapb.GetCellInfoNamesRequest) (*vtctldatapb.GetCellInfoNamesResponse, error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetCellInfoNames")
@@ -745,6 +1305,34 @@ func (s *VtctldServer) GetCellInfo(ctx context.Context, req *vtctldatapb.GetCell
 	return &vtctldatapb.GetCellInfoResponse{CellInfo: ci}, nil
 }
 
+// GetCellServingOverride is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) GetCellServingOverride(ctx context.Context, req *vtctldatapb.GetCellServingOverrideRequest) (*vtctldatapb.GetCellServingOverrideResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetCellServingOverride")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("cell", req.Cell)
+
+	if req.Keyspace == "" {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "keyspace is required")
+	}
+
+	ki, err := s.ts.GetKeyspace(ctx, req.Keyspace)
+	if err != nil {
+		return nil, err
+	}
+
+	overrides := ki.CellServingOverrides
+	if req.Cell != "" {
+		overrides = nil
+		if override, ok := ki.CellServingOverrides[req.Cell]; ok {
+			overrides = map[string]*topodatapb.CellServingOverride{req.Cell: override}
+		}
+	}
+
+	return &vtctldatapb.GetCellServingOverrideResponse{Overrides: overrides}, nil
+}
+
 // GetCellsAliases is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) GetCellsAliases(ctx context.Context, req *vtctldatapb.GetCellsAliasesRequest) (*vtctldatapb.GetCellsAliasesResponse, error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetCellsAliases")
@@ -759,6 +1347,34 @@ func (s *VtctldServer) GetCellsAliases(ctx context.Context, req *vtctldatapb.Get
 	return &vtctldatapb.GetCellsAliasesResponse{Aliases: aliases}, nil
 }
 
+// GetGCTables is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) GetGCTables(ctx context.Context, req *vtctldatapb.GetGCTablesRequest) (*vtctldatapb.GetGCTablesResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetGCTables")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+
+	if req.Keyspace == "" {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "keyspace is required")
+	}
+
+	byState, err := tablegcutil.ListGCTables(ctx, s.ts, s.tmc, req.Keyspace)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &vtctldatapb.GetGCTablesResponse{
+		TablesByState: make(map[string]*vtctldatapb.GetGCTablesResponse_GCTables, len(byState)),
+	}
+	for state, tables := range byState {
+		resp.TablesByState[string(state)] = &vtctldatapb.GetGCTablesResponse_GCTables{
+			Tables: gcTablesToProto(tables),
+		}
+	}
+
+	return resp, nil
+}
+
 // GetKeyspace is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) GetKeyspace(ctx context.Context, req *vtctldatapb.GetKeyspaceRequest) (*vtctldatapb.GetKeyspaceResponse, error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetKeyspace")
@@ -779,6 +1395,24 @@ func (s *VtctldServer) GetKeyspace(ctx context.Context, req *vtctldatapb.GetKeys
 	}, nil
 }
 
+// GetKeyspaceBackupRuns is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) GetKeyspaceBackupRuns(ctx context.Context, req *vtctldatapb.GetKeyspaceBackupRunsRequest) (*vtctldatapb.GetKeyspaceBackupRunsResponse, error) {
+	span, _ := trace.NewSpan(ctx, "VtctldServer.GetKeyspaceBackupRuns")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+
+	runs := backuputil.KeyspaceBackupRuns(req.Keyspace)
+	resp := &vtctldatapb.GetKeyspaceBackupRunsResponse{
+		Runs: make([]*vtctldatapb.KeyspaceBackupRun, len(runs)),
+	}
+	for i, run := range runs {
+		resp.Runs[i] = keyspaceBackupRunToProto(run)
+	}
+
+	return resp, nil
+}
+
 // GetKeyspaces is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) GetKeyspaces(ctx context.Context, req *vtctldatapb.GetKeyspacesRequest) (*vtctldatapb.GetKeyspacesResponse, error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetKeyspaces")
@@ -803,19 +1437,67 @@ func (s *VtctldServer) GetKeyspaces(ctx context.Context, req *vtctldatapb.GetKey
 	return &vtctldatapb.GetKeyspacesResponse{Keyspaces: keyspaces}, nil
 }
 
+// GetMySQLUsers is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) GetMySQLUsers(ctx context.Context, req *vtctldatapb.GetMySQLUsersRequest) (*vtctldatapb.GetMySQLUsersResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetMySQLUsers")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+
+	if req.Keyspace == "" {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "keyspace is required")
+	}
+
+	ki, err := s.ts.GetKeyspace(ctx, req.Keyspace)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.GetMySQLUsersResponse{Users: ki.MysqlUsers}, nil
+}
+
+// GetOnlineDDLProgress is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) GetOnlineDDLProgress(ctx context.Context, req *vtctldatapb.GetOnlineDDLProgressRequest) (*vtctldatapb.GetOnlineDDLProgressResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetOnlineDDLProgress")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("uuid", req.Uuid)
+
+	if req.Keyspace == "" {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "keyspace is required")
+	}
+
+	if !schema.IsOnlineDDLUUID(req.Uuid) {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "not an online DDL UUID: %s", req.Uuid)
+	}
+
+	progress, err := onlineddlutil.GetProgress(ctx, s.ts, s.tmc, req.Keyspace, req.Uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	return onlineDDLProgressToProto(progress), nil
+}
+
 // GetRoutingRules is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) GetRoutingRules(ctx context.Context, req *vtctldatapb.GetRoutingRulesRequest) (*vtctldatapb.GetRoutingRulesResponse, error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetRoutingRules")
 	defer span.Finish()
 
-	rr, err := s.ts.GetRoutingRules(ctx)
+	rr, version, err := s.ts.GetRoutingRulesWithVersion(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	return &vtctldatapb.GetRoutingRulesResponse{
+	resp := &vtctldatapb.GetRoutingRulesResponse{
 		RoutingRules: rr,
-	}, nil
+	}
+	if version != nil {
+		resp.Version = version.String()
+	}
+
+	return resp, nil
 }
 
 // GetSchema is part of the vtctlservicepb.VtctldServer interface.
@@ -893,6 +1575,80 @@ func (s *VtctldServer) GetShard(ctx context.Context, req *vtctldatapb.GetShardRe
 	}, nil
 }
 
+// GetShardFence is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) GetShardFence(ctx context.Context, req *vtctldatapb.GetShardFenceRequest) (*vtctldatapb.GetShardFenceResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetShardFence")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("shard", req.Shard)
+
+	if req.Keyspace == "" || req.Shard == "" {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "keyspace and shard are required")
+	}
+
+	fence, err := fenceutil.GetShardFence(ctx, s.ts, req.Keyspace, req.Shard)
+	if err != nil {
+		if topo.IsErrType(err, topo.NoNode) {
+			return nil, vterrors.Errorf(vtrpc.Code_NOT_FOUND, "shard %v/%v is not fenced", req.Keyspace, req.Shard)
+		}
+
+		return nil, err
+	}
+
+	pbFence, err := shardFenceToProto(fence)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.GetShardFenceResponse{
+		Fence: pbFence,
+	}, nil
+}
+
+// GetShardReplicationGraph is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) GetShardReplicationGraph(ctx context.Context, req *vtctldatapb.GetShardReplicationGraphRequest) (*vtctldatapb.GetShardReplicationGraphResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetShardReplicationGraph")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("shard", req.Shard)
+
+	if req.Keyspace == "" || req.Shard == "" {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "keyspace and shard are required")
+	}
+
+	graph, err := replicationutil.GetShardReplicationGraph(ctx, s.ts, s.tmc, req.Keyspace, req.Shard)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.GetShardReplicationGraphResponse{Graph: graph}, nil
+}
+
+// GetShardServingTimeline is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) GetShardServingTimeline(ctx context.Context, req *vtctldatapb.GetShardServingTimelineRequest) (*vtctldatapb.GetShardServingTimelineResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetShardServingTimeline")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("shard", req.Shard)
+
+	if req.Keyspace == "" || req.Shard == "" {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "keyspace and shard are required")
+	}
+
+	events, tabletErrors, err := servingutil.ShardServingTimeline(ctx, s.ts, servingutil.GetServingEventsFromTablet, req.Keyspace, req.Shard)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.GetShardServingTimelineResponse{
+		Events:       events,
+		TabletErrors: tabletErrors,
+	}, nil
+}
+
 // GetSrvKeyspaces is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) GetSrvKeyspaces(ctx context.Context, req *vtctldatapb.GetSrvKeyspacesRequest) (*vtctldatapb.GetSrvKeyspacesResponse, error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetSrvKeyspaces")
@@ -934,6 +1690,25 @@ func (s *VtctldServer) GetSrvKeyspaces(ctx context.Context, req *vtctldatapb.Get
 	}, nil
 }
 
+// GetSrvKeyspaceWithVersion is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) GetSrvKeyspaceWithVersion(ctx context.Context, req *vtctldatapb.GetSrvKeyspaceWithVersionRequest) (*vtctldatapb.GetSrvKeyspaceWithVersionResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetSrvKeyspaceWithVersion")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("cell", req.Cell)
+
+	srvKeyspace, version, err := s.ts.GetSrvKeyspaceWithVersion(ctx, req.Cell, req.Keyspace)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.GetSrvKeyspaceWithVersionResponse{
+		SrvKeyspace: srvKeyspace,
+		Version:     version.String(),
+	}, nil
+}
+
 // GetSrvVSchema is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) GetSrvVSchema(ctx context.Context, req *vtctldatapb.GetSrvVSchemaRequest) (*vtctldatapb.GetSrvVSchemaResponse, error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetSrvVSchema")
@@ -1011,6 +1786,31 @@ func (s *VtctldServer) GetTablet(ctx context.Context, req *vtctldatapb.GetTablet
 	}, nil
 }
 
+// GetTabletHealthHistory is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) GetTabletHealthHistory(ctx context.Context, req *vtctldatapb.GetTabletHealthHistoryRequest) (*vtctldatapb.GetTabletHealthHistoryResponse, error) {
+	span, _ := trace.NewSpan(ctx, "VtctldServer.GetTabletHealthHistory")
+	defer span.Finish()
+
+	if req.TabletAlias != nil {
+		span.Annotate("tablet_alias", topoproto.TabletAliasString(req.TabletAlias))
+	}
+
+	health, err := getTabletHealthSource()
+	if err != nil {
+		return nil, err
+	}
+
+	since := protoutil.TimeFromProto(req.Since)
+	until := protoutil.TimeFromProto(req.Until)
+	if !since.IsZero() && !until.IsZero() && until.Before(since) {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "until (%v) is before since (%v)", until, since)
+	}
+
+	return &vtctldatapb.GetTabletHealthHistoryResponse{
+		Events: health.HealthHistory(req.TabletAlias, since, until),
+	}, nil
+}
+
 // GetTablets is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) GetTablets(ctx context.Context, req *vtctldatapb.GetTabletsRequest) (*vtctldatapb.GetTabletsResponse, error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetTablets")
@@ -1018,6 +1818,16 @@ func (s *VtctldServer) GetTablets(ctx context.Context, req *vtctldatapb.GetTable
 
 	span.Annotate("cells", strings.Join(req.Cells, ","))
 	span.Annotate("strict", req.Strict)
+	span.Annotate("page_size", req.PageSize)
+
+	if req.PageSize < 0 || req.PageSize > maxTabletPageSize {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "page_size must be between 0 and %d", maxTabletPageSize)
+	}
+
+	filter, err := newTabletFilter(req)
+	if err != nil {
+		return nil, err
+	}
 
 	// It is possible that an old primary has not yet updated its type in the
 	// topo. In that case, report its type as UNKNOWN. It used to be MASTER but
@@ -1036,10 +1846,11 @@ func (s *VtctldServer) GetTablets(ctx context.Context, req *vtctldatapb.GetTable
 	ctx, cancel := context.WithTimeout(ctx, *topo.RemoteOperationTimeout)
 	defer cancel()
 
-	var (
-		tabletMap map[string]*topo.TabletInfo
-		err       error
-	)
+	if req.PageSize > 0 {
+		return s.getTabletsPage(ctx, req, filter)
+	}
+
+	var tabletMap map[string]*topo.TabletInfo
 
 	switch {
 	case len(req.TabletAliases) > 0:
@@ -1092,7 +1903,7 @@ func (s *VtctldServer) GetTablets(ctx context.Context, req *vtctldatapb.GetTable
 			tablets = append(tablets, ti.Tablet)
 		}
 
-		return &vtctldatapb.GetTabletsResponse{Tablets: tablets}, nil
+		return &vtctldatapb.GetTabletsResponse{Tablets: filter.apply(tablets)}, nil
 	}
 
 	cells := req.Cells
@@ -1179,7 +1990,7 @@ func (s *VtctldServer) GetTablets(ctx context.Context, req *vtctldatapb.GetTable
 	}
 
 	return &vtctldatapb.GetTabletsResponse{
-		Tablets: adjustedTablets,
+		Tablets: filter.apply(adjustedTablets),
 	}, nil
 }
 
@@ -1200,18 +2011,57 @@ func (s *VtctldServer) GetVSchema(ctx context.Context, req *vtctldatapb.GetVSche
 	}, nil
 }
 
-// GetWorkflows is part of the vtctlservicepb.VtctldServer interface.
-func (s *VtctldServer) GetWorkflows(ctx context.Context, req *vtctldatapb.GetWorkflowsRequest) (*vtctldatapb.GetWorkflowsResponse, error) {
-	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetWorkflows")
+// GetWorkflowAuditLog is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) GetWorkflowAuditLog(ctx context.Context, req *vtctldatapb.GetWorkflowAuditLogRequest) (*vtctldatapb.GetWorkflowAuditLogResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetWorkflowAuditLog")
 	defer span.Finish()
 
-	span.Annotate("keyspace", req.Keyspace)
-	span.Annotate("active_only", req.ActiveOnly)
+	span.Annotate("uuid", req.Uuid)
 
-	return s.ws.GetWorkflows(ctx, req)
-}
+	if req.Uuid == "" {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "uuid is required")
+	}
 
-// InitShardPrimary is part of the vtctlservicepb.VtctldServer interface.
+	auditLog, err := s.ts.GetWorkflowAuditLog(ctx, req.Uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.GetWorkflowAuditLogResponse{Entries: auditLog.Entries}, nil
+}
+
+// GetWorkflowProgress is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) GetWorkflowProgress(ctx context.Context, req *vtctldatapb.GetWorkflowProgressRequest) (*vtctldatapb.GetWorkflowProgressResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetWorkflowProgress")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("workflow", req.Workflow)
+
+	if req.Keyspace == "" || req.Workflow == "" {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "keyspace and workflow are required")
+	}
+
+	progress, err := s.ws.GetWorkflowProgress(ctx, req.Keyspace, req.Workflow)
+	if err != nil {
+		return nil, err
+	}
+
+	return workflowProgressToProto(progress), nil
+}
+
+// GetWorkflows is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) GetWorkflows(ctx context.Context, req *vtctldatapb.GetWorkflowsRequest) (*vtctldatapb.GetWorkflowsResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetWorkflows")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("active_only", req.ActiveOnly)
+
+	return s.ws.GetWorkflows(ctx, req)
+}
+
+// InitShardPrimary is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) InitShardPrimary(ctx context.Context, req *vtctldatapb.InitShardPrimaryRequest) (*vtctldatapb.InitShardPrimaryResponse, error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.InitShardPrimary")
 	defer span.Finish()
@@ -1471,6 +2321,209 @@ func (s *VtctldServer) InitShardPrimaryLocked(
 	return nil
 }
 
+// ListKeyspaceBackups is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) ListKeyspaceBackups(ctx context.Context, req *vtctldatapb.ListKeyspaceBackupsRequest) (*vtctldatapb.ListKeyspaceBackupsResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.ListKeyspaceBackups")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+
+	shardBackups, err := backuputil.ListKeyspaceBackups(ctx, s.ts, req.Keyspace)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.ListKeyspaceBackupsResponse{
+		Backups: shardBackupsToProto(req.Keyspace, shardBackups),
+	}, nil
+}
+
+// ListLocks is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) ListLocks(ctx context.Context, req *vtctldatapb.ListLocksRequest) (*vtctldatapb.ListLocksResponse, error) {
+	span, _ := trace.NewSpan(ctx, "VtctldServer.ListLocks")
+	defer span.Finish()
+
+	locks := s.locks.List()
+	resp := &vtctldatapb.ListLocksResponse{
+		Locks: make([]*vtctldatapb.ExternalLock, len(locks)),
+	}
+	for i, lock := range locks {
+		resp.Locks[i] = externalLockToProto(lock)
+	}
+
+	return resp, nil
+}
+
+// ListVDiffResults is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) ListVDiffResults(ctx context.Context, req *vtctldatapb.ListVDiffResultsRequest) (*vtctldatapb.ListVDiffResultsResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.ListVDiffResults")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("workflow", req.Workflow)
+	span.Annotate("limit", req.Limit)
+
+	if req.Keyspace == "" || req.Workflow == "" {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "keyspace and workflow are required")
+	}
+
+	if req.Limit < 0 {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "limit must be non-negative, got %d", req.Limit)
+	}
+
+	results, err := s.ts.GetVDiffResults(ctx, req.Keyspace, req.Workflow)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &vtctldatapb.ListVDiffResultsResponse{}
+	for i := len(results) - 1; i >= 0; i-- {
+		if req.Limit > 0 && int64(len(resp.Results)) == req.Limit {
+			break
+		}
+
+		resp.Results = append(resp.Results, results[i])
+	}
+
+	return resp, nil
+}
+
+// PreviewDeclarativeSchema is part of the vtctlservicepb.VtctldServer
+// interface.
+func (s *VtctldServer) PreviewDeclarativeSchema(ctx context.Context, req *vtctldatapb.PreviewDeclarativeSchemaRequest) (*vtctldatapb.PreviewDeclarativeSchemaResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.PreviewDeclarativeSchema")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("allow_drops", req.AllowDrops)
+
+	if req.Keyspace == "" {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "keyspace is required")
+	}
+
+	plan, err := onlineddlutil.PlanDeclarativeSchema(ctx, s.ts, s.tmc, req.Keyspace, req.Sql, req.AllowDrops)
+	if err != nil {
+		return nil, err
+	}
+
+	return declarativeSchemaPlanToProto(plan), nil
+}
+
+// PruneBackups is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) PruneBackups(ctx context.Context, req *vtctldatapb.PruneBackupsRequest) (*vtctldatapb.PruneBackupsResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.PruneBackups")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("keep_last", req.KeepLast)
+	span.Annotate("dry_run", req.DryRun)
+
+	maxAge, _, err := protoutil.DurationFromProto(req.MaxAge)
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "invalid max_age")
+	}
+
+	span.Annotate("max_age", maxAge.String())
+
+	retention := backuputil.BackupRetention{
+		KeepLast: int(req.KeepLast),
+		MaxAge:   maxAge,
+	}
+	if retention.IsZero() {
+		return nil, vterrors.New(vtrpc.Code_INVALID_ARGUMENT, "keep_last or max_age must be set")
+	}
+
+	pruned, err := backuputil.PruneBackups(ctx, s.ts, logutil.NewConsoleLogger(), req.Keyspace, retention, req.DryRun)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.PruneBackupsResponse{
+		Pruned: shardBackupsToProto(req.Keyspace, pruned),
+	}, nil
+}
+
+// PlanReparentShard is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) PlanReparentShard(ctx context.Context, req *vtctldatapb.PlanReparentShardRequest) (*vtctldatapb.PlanReparentShardResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.PlanReparentShard")
+	defer span.Finish()
+
+	waitReplicasTimeout, ok, err := protoutil.DurationFromProto(req.WaitReplicasTimeout)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		waitReplicasTimeout = time.Second * 30
+	}
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("shard", req.Shard)
+	span.Annotate("emergency", req.Emergency)
+	span.Annotate("wait_replicas_timeout_sec", waitReplicasTimeout.Seconds())
+
+	if req.AvoidPrimary != nil {
+		span.Annotate("avoid_primary_alias", topoproto.TabletAliasString(req.AvoidPrimary))
+	}
+
+	if req.NewPrimary != nil {
+		span.Annotate("new_primary_alias", topoproto.TabletAliasString(req.NewPrimary))
+	}
+
+	plan, err := reparentutil.PlanReparent(ctx, s.ts, s.tmc, req.Keyspace, req.Shard, reparentutil.ReparentPlanOptions{
+		Emergency:           req.Emergency,
+		NewPrimaryAlias:     req.NewPrimary,
+		AvoidPrimaryAlias:   req.AvoidPrimary,
+		WaitReplicasTimeout: waitReplicasTimeout,
+	}, logutil.NewConsoleLogger())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &vtctldatapb.PlanReparentShardResponse{
+		Keyspace:         plan.Keyspace,
+		Shard:            plan.Shard,
+		Emergency:        plan.Emergency,
+		Candidates:       make([]*vtctldatapb.PlanReparentShardResponse_Candidate, len(plan.Candidates)),
+		Repointed:        make([]*topodatapb.TabletAlias, len(plan.Repointed)),
+		ExpectedDowntime: plan.ExpectedDowntime,
+		Warnings:         plan.Warnings,
+	}
+
+	if plan.CurrentPrimary != "" {
+		if resp.CurrentPrimary, err = topoproto.ParseTabletAlias(plan.CurrentPrimary); err != nil {
+			return nil, err
+		}
+	}
+
+	if plan.NewPrimary != "" {
+		if resp.NewPrimary, err = topoproto.ParseTabletAlias(plan.NewPrimary); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, candidate := range plan.Candidates {
+		alias, err := topoproto.ParseTabletAlias(candidate.Alias)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Candidates[i] = &vtctldatapb.PlanReparentShardResponse_Candidate{
+			Tablet:     alias,
+			Rank:       uint32(candidate.Rank),
+			Position:   candidate.Position,
+			LagSeconds: candidate.LagSeconds,
+			Ineligible: candidate.Ineligible,
+		}
+	}
+
+	for i, repointed := range plan.Repointed {
+		if resp.Repointed[i], err = topoproto.ParseTabletAlias(repointed); err != nil {
+			return nil, err
+		}
+	}
+
+	return resp, nil
+}
+
 // PlannedReparentShard is part of the vtctldservicepb.VtctldServer interface.
 func (s *VtctldServer) PlannedReparentShard(ctx context.Context, req *vtctldatapb.PlannedReparentShardRequest) (*vtctldatapb.PlannedReparentShardResponse, error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.PlannedReparentShard")
@@ -1537,6 +2590,25 @@ func (s *VtctldServer) PlannedReparentShard(ctx context.Context, req *vtctldatap
 	return resp, err
 }
 
+// RebuildKeyspaceGraph is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) RebuildKeyspaceGraph(ctx context.Context, req *vtctldatapb.RebuildKeyspaceGraphRequest) (*vtctldatapb.RebuildKeyspaceGraphResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.RebuildKeyspaceGraph")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("cells", strings.Join(req.Cells, ","))
+	span.Annotate("allow_partial", req.AllowPartial)
+
+	report, err := topotools.RebuildKeyspaceWithReport(ctx, logutil.NewConsoleLogger(), s.ts, req.Keyspace, req.Cells, req.AllowPartial)
+	if report == nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.RebuildKeyspaceGraphResponse{
+		Report: rebuildReportToProto(report),
+	}, err
+}
+
 // RebuildVSchemaGraph is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) RebuildVSchemaGraph(ctx context.Context, req *vtctldatapb.RebuildVSchemaGraphRequest) (*vtctldatapb.RebuildVSchemaGraphResponse, error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.RebuildVSchemaGraph")
@@ -1544,11 +2616,40 @@ func (s *VtctldServer) RebuildVSchemaGraph(ctx context.Context, req *vtctldatapb
 
 	span.Annotate("cells", strings.Join(req.Cells, ","))
 
-	if err := s.ts.RebuildSrvVSchema(ctx, req.Cells); err != nil {
+	report, err := s.ts.RebuildSrvVSchemaWithReport(ctx, req.Cells)
+	if report == nil {
 		return nil, err
 	}
 
-	return &vtctldatapb.RebuildVSchemaGraphResponse{}, nil
+	return &vtctldatapb.RebuildVSchemaGraphResponse{
+		Report: rebuildReportToProto(report),
+	}, err
+}
+
+// RecommendTabletTypeChange is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) RecommendTabletTypeChange(ctx context.Context, req *vtctldatapb.RecommendTabletTypeChangeRequest) (*vtctldatapb.RecommendTabletTypeChangeResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.RecommendTabletTypeChange")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("shard", req.Shard)
+	span.Annotate("cell", req.Cell)
+	span.Annotate("from_type", topoproto.TabletTypeLString(req.FromType))
+	span.Annotate("to_type", topoproto.TabletTypeLString(req.ToType))
+
+	if req.Keyspace == "" || req.Shard == "" || req.Cell == "" {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "keyspace, shard and cell are required")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, *topo.RemoteOperationTimeout)
+	defer cancel()
+
+	candidates, err := tablettypeutil.Recommend(ctx, s.ts, s.tmc, req.Keyspace, req.Shard, req.Cell, req.FromType, req.ToType)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.RecommendTabletTypeChangeResponse{Candidates: candidates}, nil
 }
 
 // RefreshState is part of the vtctldservicepb.VtctldServer interface.
@@ -1609,6 +2710,56 @@ func (s *VtctldServer) RefreshStateByShard(ctx context.Context, req *vtctldatapb
 	}, nil
 }
 
+// RejectMigration is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) RejectMigration(ctx context.Context, req *vtctldatapb.RejectMigrationRequest) (*vtctldatapb.RejectMigrationResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.RejectMigration")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("uuid", req.Uuid)
+	span.Annotate("reviewer", req.Reviewer)
+
+	if req.Keyspace == "" {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "keyspace is required")
+	}
+
+	if !schema.IsOnlineDDLUUID(req.Uuid) {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "not an online DDL UUID: %s", req.Uuid)
+	}
+
+	if req.Reviewer == "" {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "reviewer is required")
+	}
+
+	review, err := onlineddlutil.RejectMigration(ctx, s.ts, s.tmc, req.Keyspace, req.Uuid, req.Reviewer, req.Message)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.RejectMigrationResponse{
+		Uuid:     review.UUID,
+		Reviewer: review.Reviewer,
+		Shards:   review.Shards,
+	}, nil
+}
+
+// ReleaseLock is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) ReleaseLock(ctx context.Context, req *vtctldatapb.ReleaseLockRequest) (*vtctldatapb.ReleaseLockResponse, error) {
+	span, _ := trace.NewSpan(ctx, "VtctldServer.ReleaseLock")
+	defer span.Finish()
+
+	span.Annotate("id", req.Id)
+
+	lock, err := s.locks.Release(req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.ReleaseLockResponse{
+		Lock: externalLockToProto(lock),
+	}, nil
+}
+
 // RemoveKeyspaceCell is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) RemoveKeyspaceCell(ctx context.Context, req *vtctldatapb.RemoveKeyspaceCellRequest) (*vtctldatapb.RemoveKeyspaceCellResponse, error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.RemoveKeyspaceCell")
@@ -1712,52 +2863,466 @@ func (s *VtctldServer) ReparentTablet(ctx context.Context, req *vtctldatapb.Repa
 	}, nil
 }
 
-// ShardReplicationPositions is part of the vtctldservicepb.VtctldServer interface.
-func (s *VtctldServer) ShardReplicationPositions(ctx context.Context, req *vtctldatapb.ShardReplicationPositionsRequest) (*vtctldatapb.ShardReplicationPositionsResponse, error) {
-	span, ctx := trace.NewSpan(ctx, "VtctldServer.ShardReplicationPositions")
+// RestoreGCTable is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) RestoreGCTable(ctx context.Context, req *vtctldatapb.RestoreGCTableRequest) (*vtctldatapb.RestoreGCTableResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.RestoreGCTable")
 	defer span.Finish()
 
 	span.Annotate("keyspace", req.Keyspace)
-	span.Annotate("shard", req.Shard)
+	span.Annotate("uuid", req.Uuid)
+	span.Annotate("to_table", req.ToTable)
 
-	tabletInfoMap, err := s.ts.GetTabletMapForShard(ctx, req.Keyspace, req.Shard)
+	if req.Keyspace == "" || req.Uuid == "" {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "keyspace and uuid are required")
+	}
+
+	tables, err := tablegcutil.RestoreGCTable(ctx, s.ts, s.tmc, req.Keyspace, req.Uuid, req.ToTable)
 	if err != nil {
-		return nil, fmt.Errorf("GetTabletMapForShard(%s, %s) failed: %w", req.Keyspace, req.Shard, err)
+		return nil, err
 	}
 
-	log.Infof("Gathering tablet replication status for: %v", tabletInfoMap)
+	return &vtctldatapb.RestoreGCTableResponse{
+		Tables: gcTablesToProto(tables),
+	}, nil
+}
 
-	var (
-		m         sync.Mutex
-		wg        sync.WaitGroup
-		rec       concurrency.AllErrorRecorder
-		results   = make(map[string]*replicationdatapb.Status, len(tabletInfoMap))
-		tabletMap = make(map[string]*topodatapb.Tablet, len(tabletInfoMap))
-	)
+// RestoreToPointInTime is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) RestoreToPointInTime(ctx context.Context, req *vtctldatapb.RestoreToPointInTimeRequest) (*vtctldatapb.RestoreToPointInTimeResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.RestoreToPointInTime")
+	defer span.Finish()
 
-	// For each tablet, we're going to create an individual context, using
-	// *topo.RemoteOperationTimeout as the maximum timeout (but we'll respect
-	// any stricter timeout in the parent context). If an individual tablet
-	// times out fetching its replication position, we won't fail the overall
-	// request. Instead, we'll log a warning and record a nil entry in the
-	// result map; that way, the caller can tell the difference between a tablet
-	// that timed out vs a tablet that didn't get queried at all.
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("shard", req.Shard)
+	span.Annotate("restore_to_pos", req.RestoreToPos)
+	span.Annotate("dry_run", req.DryRun)
 
-	for alias, tabletInfo := range tabletInfoMap {
-		switch {
-		case tabletInfo.Type == topodatapb.TabletType_MASTER:
-			wg.Add(1)
+	if req.Keyspace == "" || req.Shard == "" {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "keyspace and shard are required")
+	}
 
-			go func(ctx context.Context, alias string, tablet *topodatapb.Tablet) {
-				defer wg.Done()
+	var (
+		toPos mysql.Position
+		err   error
+	)
+	if req.RestoreToPos != "" {
+		if toPos, err = mysql.DecodePosition(req.RestoreToPos); err != nil {
+			return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "invalid restore_to_pos %q: %v", req.RestoreToPos, err)
+		}
+	}
 
-				span, ctx := trace.NewSpan(ctx, "VtctldServer.getPrimaryPosition")
-				defer span.Finish()
+	toTime := protoutil.TimeFromProto(req.RestoreToTimestamp)
 
-				span.Annotate("tablet_alias", alias)
+	span.Annotate("restore_to_timestamp", toTime.String())
 
-				ctx, cancel := context.WithTimeout(ctx, *topo.RemoteOperationTimeout)
-				defer cancel()
+	m := sync.RWMutex{}
+	logstream := []*logutilpb.Event{}
+	logger := logutil.NewCallbackLogger(func(e *logutilpb.Event) {
+		m.Lock()
+		defer m.Unlock()
+
+		logstream = append(logstream, e)
+	})
+
+	plan, err := backuputil.RestoreToPointInTime(ctx, logger, req.Keyspace, req.Shard, toPos, toTime, req.DryRun)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &vtctldatapb.RestoreToPointInTimeResponse{
+		Keyspace:           plan.Keyspace,
+		Shard:              plan.Shard,
+		RestoreToPos:       plan.RestoreToPos,
+		Backup:             plan.Backup,
+		BackupTime:         plan.BackupTime,
+		BackupPosition:     plan.BackupPosition,
+		IncrementalBackups: plan.IncrementalBackups,
+	}
+	if !toTime.IsZero() {
+		resp.RestoreToTimestamp = protoutil.TimeToProto(toTime)
+	}
+
+	m.RLock()
+	defer m.RUnlock()
+
+	resp.Events = make([]*logutilpb.Event, len(logstream))
+	copy(resp.Events, logstream)
+
+	return resp, nil
+}
+
+// RevertMigration is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) RevertMigration(ctx context.Context, req *vtctldatapb.RevertMigrationRequest) (*vtctldatapb.RevertMigrationResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.RevertMigration")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("uuid", req.Uuid)
+	span.Annotate("dry_run", req.DryRun)
+
+	if req.Keyspace == "" {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "keyspace is required")
+	}
+
+	if !schema.IsOnlineDDLUUID(req.Uuid) {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "not an online DDL UUID: %s", req.Uuid)
+	}
+
+	if req.DryRun {
+		windows, err := onlineddlutil.RevertWindows(ctx, s.ts, s.tmc, req.Keyspace, req.Uuid)
+		if err != nil {
+			return nil, err
+		}
+
+		return &vtctldatapb.RevertMigrationResponse{
+			RevertedUuid: req.Uuid,
+			Windows:      revertWindowsToProto(windows),
+		}, nil
+	}
+
+	revert, err := onlineddlutil.RevertMigration(ctx, s.ts, s.tmc, req.Keyspace, req.Uuid, req.RequestContext)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.RevertMigrationResponse{
+		Uuid:         revert.UUID,
+		RevertedUuid: revert.RevertedUUID,
+		Windows:      revertWindowsToProto(revert.Windows),
+	}, nil
+}
+
+// SeedTable is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) SeedTable(ctx context.Context, req *vtctldatapb.SeedTableRequest) (*vtctldatapb.SeedTableResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.SeedTable")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("table", req.Table)
+
+	if req.Keyspace == "" || req.Table == "" {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "keyspace and table are required")
+	}
+
+	options := &loadutil.SeedOptions{
+		BatchSize:        int(req.BatchSize),
+		MaxRowsPerSecond: int(req.MaxRowsPerSecond),
+		MaxSourceRows:    int(req.MaxSourceRows),
+	}
+	if options.BatchSize == 0 {
+		options.BatchSize = 1000
+	}
+	if options.MaxSourceRows == 0 {
+		options.MaxSourceRows = 100000
+	}
+
+	span.Annotate("batch_size", options.BatchSize)
+	span.Annotate("max_rows_per_second", options.MaxRowsPerSecond)
+
+	progress, err := loadutil.NewLoader(s.ts, s.tmc, logutil.NewConsoleLogger()).SeedTable(ctx, req.Keyspace, req.Table, options)
+	if progress == nil {
+		return nil, err
+	}
+
+	resp := &vtctldatapb.SeedTableResponse{
+		Shards: make([]*vtctldatapb.SeedTableResponse_ShardProgress, len(progress)),
+	}
+	failed := false
+	for i, shardProgress := range progress {
+		primary, perr := topoproto.ParseTabletAlias(shardProgress.Master)
+		if perr != nil {
+			return nil, perr
+		}
+
+		resp.Shards[i] = &vtctldatapb.SeedTableResponse_ShardProgress{
+			Shard:      shardProgress.Shard,
+			Primary:    primary,
+			RowsLoaded: shardProgress.RowsLoaded,
+			Batches:    shardProgress.Batches,
+			Error:      shardProgress.Error,
+		}
+		failed = failed || shardProgress.Error != ""
+	}
+
+	// The errors of the shards are in the response, which is more useful to
+	// the caller than the error alone, but the other ones, e.g. a row which
+	// can't be routed, are not.
+	if err != nil && !failed {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// SetBackupSchedule is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) SetBackupSchedule(ctx context.Context, req *vtctldatapb.SetBackupScheduleRequest) (*vtctldatapb.SetBackupScheduleResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.SetBackupSchedule")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+
+	if req.Schedule == nil {
+		return nil, vterrors.New(vtrpc.Code_INVALID_ARGUMENT, "schedule must not be empty")
+	}
+
+	span.Annotate("cron", req.Schedule.Cron)
+
+	// The history of the schedule is kept by the topo.
+	schedule := proto.Clone(req.Schedule).(*topodatapb.BackupSchedule)
+	schedule.CreateTime = protoutil.TimeToProto(time.Now())
+	schedule.LastScheduledTime = nil
+	schedule.LastRunTime = nil
+	schedule.LastError = ""
+
+	if err := backuputil.SaveSchedule(ctx, s.ts, req.Keyspace, schedule); err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.SetBackupScheduleResponse{}, nil
+}
+
+// SetBackupStorageConfig is part of the vtctlservicepb.VtctldServer
+// interface.
+func (s *VtctldServer) SetBackupStorageConfig(ctx context.Context, req *vtctldatapb.SetBackupStorageConfigRequest) (resp *vtctldatapb.SetBackupStorageConfigResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.SetBackupStorageConfig")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+
+	if req.Keyspace == "" {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "keyspace is required")
+	}
+
+	if req.Config == nil {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "config is required")
+	}
+
+	if err := backuputil.ValidateStorageConfig(req.Config); err != nil {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "invalid backup storage config: %v", err)
+	}
+
+	ctx, unlock, lockErr := s.ts.LockKeyspace(ctx, req.Keyspace, "SetBackupStorageConfig")
+	if lockErr != nil {
+		return nil, lockErr
+	}
+	defer unlock(&err)
+
+	ki, err := s.ts.GetKeyspace(ctx, req.Keyspace)
+	if err != nil {
+		return nil, err
+	}
+
+	ki.BackupStorageConfig = req.Config
+	if err := s.ts.UpdateKeyspace(ctx, ki); err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.SetBackupStorageConfigResponse{}, nil
+}
+
+// SetCDCSinks is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) SetCDCSinks(ctx context.Context, req *vtctldatapb.SetCDCSinksRequest) (resp *vtctldatapb.SetCDCSinksResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.SetCDCSinks")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("sinks", len(req.Sinks))
+
+	if req.Keyspace == "" {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "keyspace is required")
+	}
+
+	if err := cdcsink.Validate(req.Sinks); err != nil {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "invalid CDC sinks: %v", err)
+	}
+
+	ctx, unlock, lockErr := s.ts.LockKeyspace(ctx, req.Keyspace, "SetCDCSinks")
+	if lockErr != nil {
+		return nil, lockErr
+	}
+	defer unlock(&err)
+
+	ki, err := s.ts.GetKeyspace(ctx, req.Keyspace)
+	if err != nil {
+		return nil, err
+	}
+
+	ki.CdcSinks = req.Sinks
+	if err := s.ts.UpdateKeyspace(ctx, ki); err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.SetCDCSinksResponse{}, nil
+}
+
+// SetCellServingOverride is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) SetCellServingOverride(ctx context.Context, req *vtctldatapb.SetCellServingOverrideRequest) (resp *vtctldatapb.SetCellServingOverrideResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.SetCellServingOverride")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("cell", req.Cell)
+	span.Annotate("do_not_serve", req.Override.GetDoNotServe())
+
+	if req.Keyspace == "" || req.Cell == "" {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "keyspace and cell are required")
+	}
+
+	if _, err := s.ts.GetCellInfo(ctx, req.Cell, false /* strongRead */); err != nil {
+		return nil, vterrors.Wrapf(err, "cannot read cell %v", req.Cell)
+	}
+
+	ctx, unlock, lockErr := s.ts.LockKeyspace(ctx, req.Keyspace, "SetCellServingOverride")
+	if lockErr != nil {
+		return nil, lockErr
+	}
+	defer unlock(&err)
+
+	ki, err := s.ts.GetKeyspace(ctx, req.Keyspace)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Override.GetDoNotServe() {
+		if ki.CellServingOverrides == nil {
+			ki.CellServingOverrides = make(map[string]*topodatapb.CellServingOverride)
+		}
+		ki.CellServingOverrides[req.Cell] = req.Override
+	} else {
+		if _, ok := ki.CellServingOverrides[req.Cell]; !ok {
+			return &vtctldatapb.SetCellServingOverrideResponse{}, nil
+		}
+		delete(ki.CellServingOverrides, req.Cell)
+	}
+
+	if err := s.ts.UpdateKeyspace(ctx, ki); err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.SetCellServingOverrideResponse{}, nil
+}
+
+// SetGCTablesRetention is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) SetGCTablesRetention(ctx context.Context, req *vtctldatapb.SetGCTablesRetentionRequest) (*vtctldatapb.SetGCTablesRetentionResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.SetGCTablesRetention")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("state", req.State)
+	span.Annotate("uuids", strings.Join(req.Uuids, ","))
+
+	if req.Keyspace == "" {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "keyspace is required")
+	}
+
+	state := schema.TableGCState(strings.ToUpper(req.State))
+	if state != schema.HoldTableGCState && state != schema.EvacTableGCState {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "invalid state %q: only the %v and %v states have a retention", req.State, schema.HoldTableGCState, schema.EvacTableGCState)
+	}
+
+	retention, ok, err := protoutil.DurationFromProto(req.Retention)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "retention is required")
+	} else if retention < 0 {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "invalid retention %v: it must be non-negative", retention)
+	}
+
+	span.Annotate("retention_sec", retention.Seconds())
+
+	tables, err := tablegcutil.SetGCTablesRetention(ctx, s.ts, s.tmc, req.Keyspace, state, req.Uuids, retention)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.SetGCTablesRetentionResponse{
+		Tables: gcTablesToProto(tables),
+	}, nil
+}
+
+// SetMySQLUsers is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) SetMySQLUsers(ctx context.Context, req *vtctldatapb.SetMySQLUsersRequest) (resp *vtctldatapb.SetMySQLUsersResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.SetMySQLUsers")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("users", len(req.Users))
+
+	if req.Keyspace == "" {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "keyspace is required")
+	}
+
+	if len(req.Users) > 0 {
+		if err := grantutil.Validate(req.Users); err != nil {
+			return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "invalid MySQL users: %v", err)
+		}
+	}
+
+	ctx, unlock, lockErr := s.ts.LockKeyspace(ctx, req.Keyspace, "SetMySQLUsers")
+	if lockErr != nil {
+		return nil, lockErr
+	}
+	defer unlock(&err)
+
+	ki, err := s.ts.GetKeyspace(ctx, req.Keyspace)
+	if err != nil {
+		return nil, err
+	}
+
+	ki.MysqlUsers = req.Users
+	if err := s.ts.UpdateKeyspace(ctx, ki); err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.SetMySQLUsersResponse{}, nil
+}
+
+// ShardReplicationPositions is part of the vtctldservicepb.VtctldServer interface.
+func (s *VtctldServer) ShardReplicationPositions(ctx context.Context, req *vtctldatapb.ShardReplicationPositionsRequest) (*vtctldatapb.ShardReplicationPositionsResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.ShardReplicationPositions")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("shard", req.Shard)
+
+	tabletInfoMap, err := s.ts.GetTabletMapForShard(ctx, req.Keyspace, req.Shard)
+	if err != nil {
+		return nil, fmt.Errorf("GetTabletMapForShard(%s, %s) failed: %w", req.Keyspace, req.Shard, err)
+	}
+
+	log.Infof("Gathering tablet replication status for: %v", tabletInfoMap)
+
+	var (
+		m         sync.Mutex
+		wg        sync.WaitGroup
+		rec       concurrency.AllErrorRecorder
+		results   = make(map[string]*replicationdatapb.Status, len(tabletInfoMap))
+		tabletMap = make(map[string]*topodatapb.Tablet, len(tabletInfoMap))
+	)
+
+	// For each tablet, we're going to create an individual context, using
+	// *topo.RemoteOperationTimeout as the maximum timeout (but we'll respect
+	// any stricter timeout in the parent context). If an individual tablet
+	// times out fetching its replication position, we won't fail the overall
+	// request. Instead, we'll log a warning and record a nil entry in the
+	// result map; that way, the caller can tell the difference between a tablet
+	// that timed out vs a tablet that didn't get queried at all.
+
+	for alias, tabletInfo := range tabletInfoMap {
+		switch {
+		case tabletInfo.Type == topodatapb.TabletType_MASTER:
+			wg.Add(1)
+
+			go func(ctx context.Context, alias string, tablet *topodatapb.Tablet) {
+				defer wg.Done()
+
+				span, ctx := trace.NewSpan(ctx, "VtctldServer.getPrimaryPosition")
+				defer span.Finish()
+
+				span.Annotate("tablet_alias", alias)
+
+				ctx, cancel := context.WithTimeout(ctx, *topo.RemoteOperationTimeout)
+				defer cancel()
 
 				var status *replicationdatapb.Status
 
@@ -1839,19 +3404,100 @@ func (s *VtctldServer) ShardReplicationPositions(ctx context.Context, req *vtctl
 	}, nil
 }
 
-// TabletExternallyReparented is part of the vtctldservicepb.VtctldServer interface.
-func (s *VtctldServer) TabletExternallyReparented(ctx context.Context, req *vtctldatapb.TabletExternallyReparentedRequest) (*vtctldatapb.TabletExternallyReparentedResponse, error) {
-	span, ctx := trace.NewSpan(ctx, "VtctldServer.TabletExternallyReparented")
+// SplitReads is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) SplitReads(ctx context.Context, req *vtctldatapb.SplitReadsRequest) (*vtctldatapb.SplitReadsResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.SplitReads")
 	defer span.Finish()
 
-	if req.Tablet == nil {
-		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "TabletExternallyReparentedRequest.Tablet must not be nil")
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("workflow", req.Workflow)
+	span.Annotate("percent", req.Percent)
+	span.Annotate("cells", strings.Join(req.Cells, ","))
+
+	if req.Keyspace == "" || req.Workflow == "" {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "keyspace and workflow are required")
 	}
 
-	span.Annotate("tablet_alias", topoproto.TabletAliasString(req.Tablet))
+	tabletTypes := req.TabletTypes
+	if len(tabletTypes) == 0 {
+		tabletTypes = []topodatapb.TabletType{topodatapb.TabletType_REPLICA, topodatapb.TabletType_RDONLY}
+	}
 
-	tablet, err := s.ts.GetTablet(ctx, req.Tablet)
-	if err != nil {
+	if err := workflow.ValidateSplitReads(tabletTypes, int(req.Percent)); err != nil {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "%v", err)
+	}
+
+	if err := s.ws.SplitReads(ctx, req.Keyspace, req.Workflow, tabletTypes, req.Cells, int(req.Percent)); err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.SplitReadsResponse{}, nil
+}
+
+// StartHostMaintenance is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) StartHostMaintenance(ctx context.Context, req *vtctldatapb.StartHostMaintenanceRequest) (*vtctldatapb.StartHostMaintenanceResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.StartHostMaintenance")
+	defer span.Finish()
+
+	span.Annotate("cell", req.Cell)
+	span.Annotate("hostname", req.Hostname)
+	span.Annotate("uncordon", req.Uncordon)
+
+	if req.Cell == "" || req.Hostname == "" {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "cell and hostname are required")
+	}
+
+	if workflowManager == nil {
+		return nil, vterrors.Errorf(vtrpc.Code_FAILED_PRECONDITION, "this vtctld runs no workflow manager")
+	}
+
+	drainGrace, ok, err := protoutil.DurationFromProto(req.DrainGrace)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		drainGrace = 30 * time.Second
+	}
+
+	waitReplicasTimeout, ok, err := protoutil.DurationFromProto(req.WaitReplicasTimeout)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		waitReplicasTimeout = 30 * time.Second
+	}
+
+	uuid, err := workflowManager.Create(ctx, hostMaintenanceFactoryName, []string{
+		"-cell=" + req.Cell,
+		"-hostname=" + req.Hostname,
+		fmt.Sprintf("-uncordon=%v", req.Uncordon),
+		"-drain_grace=" + drainGrace.String(),
+		"-wait_replicas_timeout=" + waitReplicasTimeout.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	span.Annotate("uuid", uuid)
+
+	if err := workflowManager.Start(ctx, uuid); err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.StartHostMaintenanceResponse{Uuid: uuid}, nil
+}
+
+// TabletExternallyReparented is part of the vtctldservicepb.VtctldServer interface.
+func (s *VtctldServer) TabletExternallyReparented(ctx context.Context, req *vtctldatapb.TabletExternallyReparentedRequest) (*vtctldatapb.TabletExternallyReparentedResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.TabletExternallyReparented")
+	defer span.Finish()
+
+	if req.Tablet == nil {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "TabletExternallyReparentedRequest.Tablet must not be nil")
+	}
+
+	span.Annotate("tablet_alias", topoproto.TabletAliasString(req.Tablet))
+
+	tablet, err := s.ts.GetTablet(ctx, req.Tablet)
+	if err != nil {
 		log.Warningf("TabletExternallyReparented: failed to read tablet record for %v: %v", topoproto.TabletAliasString(req.Tablet), err)
 		return nil, err
 	}
@@ -1904,6 +3550,68 @@ func (s *VtctldServer) TabletExternallyReparented(ctx context.Context, req *vtct
 	return resp, nil
 }
 
+// UndrainTablet is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) UndrainTablet(ctx context.Context, req *vtctldatapb.UndrainTabletRequest) (*vtctldatapb.UndrainTabletResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.UndrainTablet")
+	defer span.Finish()
+
+	if req.TabletAlias == nil {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "tablet alias is required")
+	}
+
+	span.Annotate("tablet_alias", topoproto.TabletAliasString(req.TabletAlias))
+
+	if err := topotools.SetTabletDrained(ctx, s.ts, req.TabletAlias, false); err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.UndrainTabletResponse{}, nil
+}
+
+// UnfenceShard is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) UnfenceShard(ctx context.Context, req *vtctldatapb.UnfenceShardRequest) (*vtctldatapb.UnfenceShardResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.UnfenceShard")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("shard", req.Shard)
+
+	if req.Keyspace == "" || req.Shard == "" {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "keyspace and shard are required")
+	}
+
+	m := sync.RWMutex{}
+	logstream := []*logutilpb.Event{}
+	logger := logutil.NewCallbackLogger(func(e *logutilpb.Event) {
+		m.Lock()
+		defer m.Unlock()
+
+		logstream = append(logstream, e)
+	})
+
+	fence, err := fenceutil.UnfenceShard(ctx, s.ts, s.tmc, logger, req.Keyspace, req.Shard)
+	if err != nil {
+		return nil, err
+	}
+
+	pbFence, err := shardFenceToProto(fence)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &vtctldatapb.UnfenceShardResponse{
+		Fence: pbFence,
+	}
+
+	m.RLock()
+	defer m.RUnlock()
+
+	resp.Events = make([]*logutilpb.Event, len(logstream))
+	copy(resp.Events, logstream)
+
+	return resp, nil
+}
+
 // UpdateCellInfo is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) UpdateCellInfo(ctx context.Context, req *vtctldatapb.UpdateCellInfoRequest) (*vtctldatapb.UpdateCellInfoResponse, error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.UpdateCellInfo")
@@ -1982,7 +3690,826 @@ func (s *VtctldServer) UpdateCellsAlias(ctx context.Context, req *vtctldatapb.Up
 	}, nil
 }
 
+// UpdateSrvKeyspace is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) UpdateSrvKeyspace(ctx context.Context, req *vtctldatapb.UpdateSrvKeyspaceRequest) (resp *vtctldatapb.UpdateSrvKeyspaceResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.UpdateSrvKeyspace")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("cell", req.Cell)
+	span.Annotate("expected_version", req.ExpectedVersion)
+
+	if req.SrvKeyspace == nil {
+		return nil, vterrors.New(vtrpc.Code_INVALID_ARGUMENT, "UpdateSrvKeyspace requires a SrvKeyspace")
+	}
+
+	// The keyspace is locked, so the update doesn't race with a rebuild of
+	// its serving graph.
+	ctx, unlock, lockErr := s.ts.LockKeyspace(ctx, req.Keyspace, fmt.Sprintf("UpdateSrvKeyspace(%v,%v)", req.Cell, req.Keyspace))
+	if lockErr != nil {
+		return nil, lockErr
+	}
+	defer unlock(&err)
+
+	version, err := s.ts.UpdateSrvKeyspaceIfVersion(ctx, req.Cell, req.Keyspace, req.SrvKeyspace, req.ExpectedVersion)
+	if err != nil {
+		if topo.IsErrType(err, topo.BadVersion) {
+			return nil, vterrors.Wrapf(err, "the SrvKeyspace of %v in cell %v changed since version %v, get it again", req.Keyspace, req.Cell, req.ExpectedVersion)
+		}
+		return nil, err
+	}
+
+	return &vtctldatapb.UpdateSrvKeyspaceResponse{
+		Version: version.String(),
+	}, nil
+}
+
+// UpdateWorkflowThrottling is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) UpdateWorkflowThrottling(ctx context.Context, req *vtctldatapb.UpdateWorkflowThrottlingRequest) (*vtctldatapb.UpdateWorkflowThrottlingResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.UpdateWorkflowThrottling")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("workflow", req.Workflow)
+	span.Annotate("fields", strings.Join(req.Fields, ","))
+
+	if req.Keyspace == "" || req.Workflow == "" {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "keyspace and workflow are required")
+	}
+
+	update := req.Throttling
+	if update == nil {
+		update = &topodatapb.WorkflowThrottling{}
+	}
+	if update.RowsPerSecond < 0 || update.BytesPerSecond < 0 || update.MaxConcurrentCopies < 0 {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "the rate limits of a workflow cannot be negative")
+	}
+
+	if _, err := s.ts.GetKeyspace(ctx, req.Keyspace); err != nil {
+		return nil, err
+	}
+
+	workflows, err := s.ts.GetWorkflowThrottling(ctx, req.Keyspace)
+	if err != nil {
+		return nil, err
+	}
+
+	throttling := &topodatapb.WorkflowThrottling{}
+	if current := workflows[req.Workflow]; current != nil {
+		throttling = proto.Clone(current).(*topodatapb.WorkflowThrottling)
+	}
+
+	fields := req.Fields
+	if len(fields) == 0 {
+		fields = []string{"rows_per_second", "bytes_per_second", "max_concurrent_copies"}
+	}
+
+	for _, field := range fields {
+		switch field {
+		case "rows_per_second":
+			throttling.RowsPerSecond = update.RowsPerSecond
+		case "bytes_per_second":
+			throttling.BytesPerSecond = update.BytesPerSecond
+		case "max_concurrent_copies":
+			throttling.MaxConcurrentCopies = update.MaxConcurrentCopies
+		default:
+			return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "unknown workflow throttling field %q", field)
+		}
+	}
+
+	if err := s.ts.UpdateWorkflowThrottling(ctx, req.Keyspace, req.Workflow, throttling); err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.UpdateWorkflowThrottlingResponse{
+		Throttling: throttling,
+	}, nil
+}
+
+// ValidateConfig is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) ValidateConfig(ctx context.Context, req *vtctldatapb.ValidateConfigRequest) (*vtctldatapb.ValidateConfigResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.ValidateConfig")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("shard", req.Shard)
+	span.Annotate("cells", strings.Join(req.Cells, ","))
+	span.Annotate("concurrency", req.Concurrency)
+	span.Annotate("mysql_variables", strings.Join(req.MysqlVariables, ","))
+	span.Annotate("tablet_flags", strings.Join(req.TabletFlags, ","))
+
+	configOpts := validateutil.ConfigOptions{
+		Flags: req.TabletFlags,
+	}
+	for _, variable := range req.MysqlVariables {
+		configOpts.MySQLVariables = append(configOpts.MySQLVariables, strings.ToLower(variable))
+	}
+	if req.Golden != nil {
+		configOpts.Golden = &validateutil.Config{
+			MySQLVariables: req.Golden.MysqlVariables,
+			Flags:          req.Golden.Flags,
+		}
+	}
+
+	report, err := validateutil.ValidateConfig(ctx, s.ts, s.tmc, func(ctx context.Context, tablet *topodatapb.Tablet, names []string) (map[string]string, error) {
+		return validateutil.GetFlagsFromTabletDebugFlags(topo.NewTabletInfo(tablet, nil).Addr(), names)
+	}, validateutil.Options{
+		Keyspace:    req.Keyspace,
+		Shard:       req.Shard,
+		Cells:       req.Cells,
+		Concurrency: int(req.Concurrency),
+	}, configOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	return configReportToProto(report)
+}
+
+// ValidatePermissions is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) ValidatePermissions(ctx context.Context, req *vtctldatapb.ValidatePermissionsRequest) (*vtctldatapb.ValidatePermissionsResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.ValidatePermissions")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("shard", req.Shard)
+	span.Annotate("cells", strings.Join(req.Cells, ","))
+	span.Annotate("concurrency", req.Concurrency)
+
+	report, err := validateutil.ValidatePermissions(ctx, s.ts, s.tmc, validateutil.Options{
+		Keyspace:    req.Keyspace,
+		Shard:       req.Shard,
+		Cells:       req.Cells,
+		Concurrency: int(req.Concurrency),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	reference, err := topoproto.ParseTabletAlias(report.Reference)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &vtctldatapb.ValidatePermissionsResponse{
+		Keyspace:   report.Keyspace,
+		Reference:  reference,
+		Tablets:    make([]*vtctldatapb.ValidatePermissionsResponse_TabletPermissions, len(report.Tablets)),
+		Consistent: report.Consistent,
+	}
+	for i, result := range report.Tablets {
+		alias, err := topoproto.ParseTabletAlias(result.Tablet)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Tablets[i] = &vtctldatapb.ValidatePermissionsResponse_TabletPermissions{
+			Tablet:      alias,
+			Shard:       result.Shard,
+			Differences: result.Differences,
+			Error:       result.Error,
+		}
+	}
+
+	return resp, nil
+}
+
+// ValidateRoutingRules is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) ValidateRoutingRules(ctx context.Context, req *vtctldatapb.ValidateRoutingRulesRequest) (*vtctldatapb.ValidateRoutingRulesResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.ValidateRoutingRules")
+	defer span.Finish()
+
+	resp := &vtctldatapb.ValidateRoutingRulesResponse{}
+	if err := topotools.ValidateRoutingRules(ctx, s.ts, req.RoutingRules); err != nil {
+		resp.Error = err.Error()
+	}
+
+	return resp, nil
+}
+
+// ValidateSrvKeyspace is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) ValidateSrvKeyspace(ctx context.Context, req *vtctldatapb.ValidateSrvKeyspaceRequest) (*vtctldatapb.ValidateSrvKeyspaceResponse, error) {
+	span, _ := trace.NewSpan(ctx, "VtctldServer.ValidateSrvKeyspace")
+	defer span.Finish()
+
+	if req.SrvKeyspace == nil {
+		return nil, vterrors.New(vtrpc.Code_INVALID_ARGUMENT, "ValidateSrvKeyspace requires a SrvKeyspace")
+	}
+
+	resp := &vtctldatapb.ValidateSrvKeyspaceResponse{}
+	if err := topo.ValidateSrvKeyspacePartitions(req.SrvKeyspace); err != nil {
+		resp.Error = err.Error()
+	}
+
+	return resp, nil
+}
+
+// ValidateVersion is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) ValidateVersion(ctx context.Context, req *vtctldatapb.ValidateVersionRequest) (*vtctldatapb.ValidateVersionResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.ValidateVersion")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("shard", req.Shard)
+	span.Annotate("cells", strings.Join(req.Cells, ","))
+	span.Annotate("concurrency", req.Concurrency)
+
+	report, err := validateutil.ValidateVersion(ctx, s.ts, func(ctx context.Context, tablet *topodatapb.Tablet) (string, error) {
+		return validateutil.GetVersionFromTabletDebugVars(topo.NewTabletInfo(tablet, nil).Addr())
+	}, validateutil.Options{
+		Keyspace:    req.Keyspace,
+		Shard:       req.Shard,
+		Cells:       req.Cells,
+		Concurrency: int(req.Concurrency),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &vtctldatapb.ValidateVersionResponse{
+		Keyspace:   report.Keyspace,
+		Tablets:    make([]*vtctldatapb.ValidateVersionResponse_TabletVersion, len(report.Tablets)),
+		Matrix:     make(map[string]*vtctldatapb.ValidateVersionResponse_ShardVersions, len(report.Matrix)),
+		Consistent: report.Consistent,
+	}
+	for i, result := range report.Tablets {
+		alias, err := topoproto.ParseTabletAlias(result.Tablet)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Tablets[i] = &vtctldatapb.ValidateVersionResponse_TabletVersion{
+			Tablet:  alias,
+			Shard:   result.Shard,
+			Version: result.Version,
+			Error:   result.Error,
+		}
+	}
+
+	for shard, versions := range report.Matrix {
+		shardVersions := &vtctldatapb.ValidateVersionResponse_ShardVersions{
+			Versions: make(map[string]*vtctldatapb.ValidateVersionResponse_TabletAliases, len(versions)),
+		}
+		for version, tablets := range versions {
+			aliases := &vtctldatapb.ValidateVersionResponse_TabletAliases{
+				Aliases: make([]*topodatapb.TabletAlias, len(tablets)),
+			}
+			for i, tablet := range tablets {
+				alias, err := topoproto.ParseTabletAlias(tablet)
+				if err != nil {
+					return nil, err
+				}
+
+				aliases.Aliases[i] = alias
+			}
+
+			shardVersions.Versions[version] = aliases
+		}
+
+		resp.Matrix[shard] = shardVersions
+	}
+
+	return resp, nil
+}
+
+// VerifyReparentShard is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) VerifyReparentShard(ctx context.Context, req *vtctldatapb.VerifyReparentShardRequest) (*vtctldatapb.VerifyReparentShardResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.VerifyReparentShard")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("shard", req.Shard)
+
+	report, err := reparentutil.VerifyReparent(ctx, s.ts, s.tmc, req.Keyspace, req.Shard)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &vtctldatapb.VerifyReparentShardResponse{
+		Keyspace: report.Keyspace,
+		Shard:    report.Shard,
+		Tablets:  make([]*vtctldatapb.VerifyReparentShardResponse_TabletCheck, len(report.Tablets)),
+		Healthy:  report.Healthy,
+	}
+
+	if report.Primary != "" {
+		if resp.Primary, err = topoproto.ParseTabletAlias(report.Primary); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, check := range report.Tablets {
+		alias, err := topoproto.ParseTabletAlias(check.Alias)
+		if err != nil {
+			return nil, err
+		}
+
+		tabletType, err := topoproto.ParseTabletType(check.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Tablets[i] = &vtctldatapb.VerifyReparentShardResponse_TabletCheck{
+			Tablet:     alias,
+			Type:       tabletType,
+			Source:     check.Source,
+			LagSeconds: check.LagSeconds,
+			Problem:    check.Problem,
+		}
+	}
+
+	return resp, nil
+}
+
+// VerifyReverseReplication is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) VerifyReverseReplication(ctx context.Context, req *vtctldatapb.VerifyReverseReplicationRequest) (*vtctldatapb.VerifyReverseReplicationResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.VerifyReverseReplication")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("workflow", req.Workflow)
+	span.Annotate("checksum", req.Checksum)
+
+	if req.Keyspace == "" || req.Workflow == "" {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "keyspace and workflow are required")
+	}
+
+	if req.Checksum && req.SampleRate < 1 {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "invalid sample_rate %d: it must be at least 1", req.SampleRate)
+	}
+
+	maxLag, ok, err := protoutil.DurationFromProto(req.MaxLag)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		maxLag = time.Second * 30
+	}
+
+	span.Annotate("max_lag_sec", maxLag.Seconds())
+
+	report, err := s.ws.VerifyReverseReplication(ctx, req.Keyspace, req.Workflow, workflow.ReverseVerificationOptions{
+		MaxLag:     maxLag,
+		Checksum:   req.Checksum,
+		SampleRate: int(req.SampleRate),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reverseReplicationReportToProto(report), nil
+}
+
+// WatchTopology is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) WatchTopology(ctx context.Context, req *vtctldatapb.WatchTopologyRequest) (*vtctldatapb.WatchTopologyResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.WatchTopology")
+	defer span.Finish()
+
+	span.Annotate("path", req.Path)
+	span.Annotate("version", req.Version)
+
+	timeout, ok, err := protoutil.DurationFromProto(req.Timeout)
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "invalid timeout")
+	}
+	if !ok || timeout > topobrowser.MaxWatchTimeout() {
+		timeout = topobrowser.MaxWatchTimeout()
+	}
+
+	version, changed, err := s.browser.Watch(ctx, path.Clean("/"+req.Path), req.Version, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.WatchTopologyResponse{
+		Version: version,
+		Changed: changed,
+	}, nil
+}
+
 // StartServer registers a VtctldServer for RPCs on the given gRPC server.
+// With -vtctld_read_only, the RPCs which change the cluster are denied.
 func StartServer(s *grpc.Server, ts *topo.Server) {
-	vtctlservicepb.RegisterVtctldServer(s, NewVtctldServer(ts))
+	desc := &vtctlservicepb.Vtctld_ServiceDesc
+	if *readOnly {
+		desc = readOnlyServiceDesc(desc)
+	}
+	s.RegisterService(desc, NewVtctldServer(ts))
+}
+
+func externalLockToProto(lock *lockutil.ExternalLock) *vtctldatapb.ExternalLock {
+	return &vtctldatapb.ExternalLock{
+		Id:       lock.ID,
+		Keyspace: lock.Keyspace,
+		Shard:    lock.Shard,
+		Purpose:  lock.Purpose,
+		Acquired: protoutil.TimeToProto(lock.Acquired),
+		Expires:  protoutil.TimeToProto(lock.Expires),
+	}
+}
+
+// optionalTimeToProto is like protoutil.TimeToProto, but leaves the zero time
+// unset.
+func optionalTimeToProto(t time.Time) *vttime.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	return protoutil.TimeToProto(t)
+}
+
+func keyspaceBackupRunToProto(run *backuputil.KeyspaceBackupRun) *vtctldatapb.KeyspaceBackupRun {
+	pb := &vtctldatapb.KeyspaceBackupRun{
+		Id:        run.ID,
+		Keyspace:  run.Keyspace,
+		Trigger:   run.Trigger,
+		State:     run.State,
+		StartTime: optionalTimeToProto(run.StartTime),
+		EndTime:   optionalTimeToProto(run.EndTime),
+		Error:     run.Error,
+		Shards:    make([]*vtctldatapb.KeyspaceBackupRun_ShardStatus, len(run.Shards)),
+	}
+
+	for i, shard := range run.Shards {
+		pb.Shards[i] = &vtctldatapb.KeyspaceBackupRun_ShardStatus{
+			Shard:                shard.Shard,
+			State:                shard.State,
+			SecondsBehindPrimary: shard.SecondsBehindMaster,
+			StartTime:            optionalTimeToProto(shard.StartTime),
+			EndTime:              optionalTimeToProto(shard.EndTime),
+			Error:                shard.Error,
+			Tablet:               shard.Tablet,
+		}
+	}
+
+	return pb
+}
+
+func shardBackupsToProto(keyspace string, shardBackups []*backuputil.ShardBackups) []*mysqlctlpb.BackupInfo {
+	var backups []*mysqlctlpb.BackupInfo
+	for _, sb := range shardBackups {
+		for _, backup := range sb.Backups {
+			bi := &mysqlctlpb.BackupInfo{
+				Name:        backup.Name,
+				Directory:   mysqlctl.GetBackupDir(keyspace, sb.Shard),
+				Keyspace:    keyspace,
+				Shard:       sb.Shard,
+				Time:        optionalTimeToProto(backup.Time),
+				TabletAlias: backup.Tablet,
+			}
+
+			backups = append(backups, bi)
+		}
+	}
+
+	return backups
+}
+
+func rebuildReportToProto(report *topo.RebuildReport) *vtctldatapb.RebuildReport {
+	pb := &vtctldatapb.RebuildReport{
+		Keyspace: report.Keyspace,
+		Cells:    make([]*vtctldatapb.RebuildReport_CellStatus, len(report.Cells)),
+	}
+
+	for i, status := range report.Cells {
+		cell := &vtctldatapb.RebuildReport_CellStatus{
+			Cell:            status.Cell,
+			Error:           status.Error,
+			PreviousVersion: status.PreviousVersion,
+			NewVersion:      status.NewVersion,
+			Changed:         status.Changed,
+			VschemaChanges:  status.VSchemaChanges,
+		}
+
+		for _, change := range status.PartitionChanges {
+			cell.PartitionChanges = append(cell.PartitionChanges, &vtctldatapb.RebuildReport_PartitionChange{
+				ServedType:            change.ServedType,
+				AddedShards:           change.AddedShards,
+				RemovedShards:         change.RemovedShards,
+				TabletControlsChanged: change.TabletControlsChanged,
+			})
+		}
+
+		pb.Cells[i] = cell
+	}
+
+	return pb
+}
+
+func workflowProgressToProto(progress *workflow.WorkflowProgress) *vtctldatapb.GetWorkflowProgressResponse {
+	pb := &vtctldatapb.GetWorkflowProgressResponse{
+		Workflow:                 progress.Workflow,
+		SourceKeyspace:           progress.SourceKeyspace,
+		TargetKeyspace:           progress.TargetKeyspace,
+		Phase:                    progress.Phase,
+		RowsCopied:               progress.RowsCopied,
+		RowsEstimated:            progress.RowsEstimated,
+		CopyPercent:              progress.CopyPercent,
+		CopyRowsPerSecond:        progress.CopyRowsPerSecond,
+		MaxReplicationLagSeconds: progress.MaxReplicationLagSeconds,
+		ErrorStreams:             int64(progress.ErrorStreams),
+		LastError:                progress.LastError,
+		Streams:                  make([]*vtctldatapb.GetWorkflowProgressResponse_StreamProgress, len(progress.Streams)),
+	}
+
+	if progress.LastErrorTime != nil {
+		pb.LastErrorTime = protoutil.TimeToProto(*progress.LastErrorTime)
+	}
+
+	for i, stream := range progress.Streams {
+		pb.Streams[i] = &vtctldatapb.GetWorkflowProgressResponse_StreamProgress{
+			Id:                    stream.ID,
+			Shard:                 stream.Shard,
+			Tablet:                stream.Tablet,
+			SourceShard:           stream.SourceShard,
+			State:                 stream.State,
+			Phase:                 stream.Phase,
+			TablesToCopy:          int64(stream.TablesToCopy),
+			RowsCopied:            stream.RowsCopied,
+			RowsEstimated:         stream.RowsEstimated,
+			CopyPercent:           stream.CopyPercent,
+			CopyRowsPerSecond:     stream.CopyRowsPerSecond,
+			ReplicationLagSeconds: stream.ReplicationLagSeconds,
+			LastError:             stream.LastError,
+		}
+
+		if stream.LastErrorTime != nil {
+			pb.Streams[i].LastErrorTime = protoutil.TimeToProto(*stream.LastErrorTime)
+		}
+	}
+
+	return pb
+}
+
+func reverseReplicationReportToProto(report *workflow.ReverseReplicationReport) *vtctldatapb.VerifyReverseReplicationResponse {
+	pb := &vtctldatapb.VerifyReverseReplicationResponse{
+		Keyspace:        report.Keyspace,
+		Workflow:        report.Workflow,
+		ReverseKeyspace: report.ReverseKeyspace,
+		ReverseWorkflow: report.ReverseWorkflow,
+		Streams:         make([]*vtctldatapb.VerifyReverseReplicationResponse_ReverseStream, len(report.Streams)),
+		StreamsHealthy:  report.StreamsHealthy,
+		StreamsReason:   report.StreamsReason,
+		MaxReverseLag:   report.MaxReverseLag,
+		Verified:        report.Verified,
+		Reason:          report.Reason,
+	}
+
+	for i, stream := range report.Streams {
+		pb.Streams[i] = &vtctldatapb.VerifyReverseReplicationResponse_ReverseStream{
+			Shard:   stream.Shard,
+			Tablet:  stream.Tablet,
+			Id:      stream.ID,
+			State:   stream.State,
+			Message: stream.Message,
+		}
+	}
+
+	for _, table := range report.Tables {
+		pb.Tables = append(pb.Tables, &vtctldatapb.VerifyReverseReplicationResponse_TableChecksum{
+			Table:          table.Table,
+			SampleRate:     int64(table.SampleRate),
+			SourceRows:     table.SourceRows,
+			TargetRows:     table.TargetRows,
+			SourceChecksum: table.SourceChecksum,
+			TargetChecksum: table.TargetChecksum,
+			Match:          table.Match,
+		})
+	}
+
+	return pb
+}
+
+func onlineDDLProgressToProto(progress *onlineddlutil.Progress) *vtctldatapb.GetOnlineDDLProgressResponse {
+	pb := &vtctldatapb.GetOnlineDDLProgressResponse{
+		Uuid:                      progress.UUID,
+		Keyspace:                  progress.Keyspace,
+		Table:                     progress.Table,
+		Strategy:                  progress.Strategy,
+		Status:                    progress.Status,
+		Progress:                  progress.Progress,
+		EtaSeconds:                progress.ETASeconds,
+		RowsCopied:                progress.RowsCopied,
+		TableRows:                 progress.TableRows,
+		ThrottledShards:           int64(progress.ThrottledShards),
+		MaxVreplicationLagSeconds: progress.MaxVReplicationLagSeconds,
+		Shards:                    make([]*vtctldatapb.GetOnlineDDLProgressResponse_ShardProgress, len(progress.Shards)),
+	}
+
+	for i, shard := range progress.Shards {
+		pb.Shards[i] = &vtctldatapb.GetOnlineDDLProgressResponse_ShardProgress{
+			Shard:                  shard.Shard,
+			Tablet:                 shard.Tablet,
+			Status:                 shard.Status,
+			Strategy:               shard.Strategy,
+			Table:                  shard.Table,
+			Progress:               shard.Progress,
+			EtaSeconds:             shard.ETASeconds,
+			RowsCopied:             shard.RowsCopied,
+			TableRows:              shard.TableRows,
+			Throttled:              shard.Throttled,
+			ThrottleMessage:        shard.ThrottleMessage,
+			VreplicationLagSeconds: shard.VReplicationLagSeconds,
+			Message:                shard.Message,
+		}
+
+		if shard.StartedAt != nil {
+			pb.Shards[i].StartedAt = protoutil.TimeToProto(*shard.StartedAt)
+		}
+	}
+
+	return pb
+}
+
+func revertWindowsToProto(windows []*onlineddlutil.RevertWindow) []*vtctldatapb.RevertMigrationResponse_RevertWindow {
+	pbs := make([]*vtctldatapb.RevertMigrationResponse_RevertWindow, len(windows))
+	for i, window := range windows {
+		pbs[i] = &vtctldatapb.RevertMigrationResponse_RevertWindow{
+			Shard:       window.Shard,
+			Tablet:      window.Tablet,
+			Table:       window.Table,
+			Action:      window.Action,
+			Strategy:    window.Strategy,
+			CompletedAt: protoutil.TimeToProto(window.CompletedAt),
+			ExpiresAt:   optionalTimeToProto(window.ExpiresAt),
+		}
+	}
+
+	return pbs
+}
+
+func declarativeSchemaPlanToProto(plan *onlineddlutil.DeclarativeSchemaPlan) *vtctldatapb.PreviewDeclarativeSchemaResponse {
+	pb := &vtctldatapb.PreviewDeclarativeSchemaResponse{
+		Keyspace:        plan.Keyspace,
+		Tablet:          plan.Tablet,
+		Changes:         make([]*vtctldatapb.PreviewDeclarativeSchemaResponse_Change, len(plan.Changes)),
+		UnchangedTables: plan.UnchangedTables,
+		SkippedDrops:    plan.SkippedDrops,
+	}
+
+	for i, change := range plan.Changes {
+		pb.Changes[i] = &vtctldatapb.PreviewDeclarativeSchemaResponse_Change{
+			Table:  change.Table,
+			Action: change.Action,
+			Sql:    change.SQL,
+		}
+	}
+
+	return pb
+}
+
+func gcTablesToProto(tables []*tablegcutil.GCTable) []*vtctldatapb.GCTable {
+	pbs := make([]*vtctldatapb.GCTable, len(tables))
+	for i, table := range tables {
+		pbs[i] = &vtctldatapb.GCTable{
+			Shard:   table.Shard,
+			Tablet:  table.Tablet,
+			Table:   table.Table,
+			State:   string(table.State),
+			Uuid:    table.UUID,
+			DueTime: protoutil.TimeToProto(table.DueTime),
+		}
+	}
+
+	return pbs
+}
+
+func configReportToProto(report *validateutil.ConfigReport) (*vtctldatapb.ValidateConfigResponse, error) {
+	resp := &vtctldatapb.ValidateConfigResponse{
+		Keyspace:   report.Keyspace,
+		Expected:   configToProto(report.Expected),
+		Tablets:    make([]*vtctldatapb.ValidateConfigResponse_TabletConfig, len(report.Tablets)),
+		Consistent: report.Consistent,
+	}
+
+	for i, result := range report.Tablets {
+		alias, err := topoproto.ParseTabletAlias(result.Tablet)
+		if err != nil {
+			return nil, err
+		}
+
+		tabletConfig := &vtctldatapb.ValidateConfigResponse_TabletConfig{
+			Tablet: alias,
+			Shard:  result.Shard,
+			Config: configToProto(result.Config),
+			Error:  result.Error,
+		}
+		for _, drift := range result.Drifts {
+			tabletConfig.Drifts = append(tabletConfig.Drifts, &vtctldatapb.ValidateConfigResponse_Drift{
+				Kind:     drift.Kind,
+				Name:     drift.Name,
+				Value:    drift.Value,
+				Expected: drift.Expected,
+			})
+		}
+
+		resp.Tablets[i] = tabletConfig
+	}
+
+	return resp, nil
+}
+
+func configToProto(config *validateutil.Config) *vtctldatapb.ValidateConfigResponse_Config {
+	if config == nil {
+		return nil
+	}
+
+	return &vtctldatapb.ValidateConfigResponse_Config{
+		MysqlVariables: config.MySQLVariables,
+		Flags:          config.Flags,
+	}
+}
+
+func mysqlUsersDriftToProto(report *grantutil.DriftReport) (*vtctldatapb.MySQLUsersDrift, error) {
+	if report == nil {
+		return nil, nil
+	}
+
+	drift := &vtctldatapb.MySQLUsersDrift{
+		Keyspace:   report.Keyspace,
+		Tablets:    make([]*vtctldatapb.MySQLUsersDrift_TabletDrift, len(report.Tablets)),
+		Consistent: report.Consistent,
+	}
+
+	for i, result := range report.Tablets {
+		alias, err := topoproto.ParseTabletAlias(result.Tablet)
+		if err != nil {
+			return nil, err
+		}
+
+		tabletType, err := topoproto.ParseTabletType(result.TabletType)
+		if err != nil {
+			return nil, err
+		}
+
+		tabletDrift := &vtctldatapb.MySQLUsersDrift_TabletDrift{
+			Tablet:     alias,
+			Shard:      result.Shard,
+			TabletType: tabletType,
+			Error:      result.Error,
+		}
+		for _, user := range result.Users {
+			tabletDrift.Users = append(tabletDrift.Users, &vtctldatapb.MySQLUsersDrift_UserDrift{
+				User:                  user.User,
+				Missing:               user.Missing,
+				AuthenticationDiffers: user.AuthenticationDiffers,
+				MissingPrivileges:     user.MissingPrivileges,
+				ExtraPrivileges:       user.ExtraPrivileges,
+			})
+		}
+
+		drift.Tablets[i] = tabletDrift
+	}
+
+	return drift, nil
+}
+
+func applyReportToProto(report *grantutil.ApplyReport) (*vtctldatapb.ApplyMySQLUsersResponse, error) {
+	resp := &vtctldatapb.ApplyMySQLUsersResponse{
+		Keyspace: report.Keyspace,
+		DryRun:   report.DryRun,
+		Shards:   make([]*vtctldatapb.ApplyMySQLUsersResponse_ShardApply, len(report.Shards)),
+	}
+
+	for i, result := range report.Shards {
+		shardApply := &vtctldatapb.ApplyMySQLUsersResponse_ShardApply{
+			Shard:      result.Shard,
+			Statements: result.Statements,
+			Error:      result.Error,
+		}
+		if result.Master != "" {
+			alias, err := topoproto.ParseTabletAlias(result.Master)
+			if err != nil {
+				return nil, err
+			}
+
+			shardApply.Primary = alias
+		}
+
+		resp.Shards[i] = shardApply
+	}
+
+	verification, err := mysqlUsersDriftToProto(report.Verification)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Verification = verification
+
+	return resp, nil
+}
+
+func shardFenceToProto(fence *fenceutil.ShardFence) (*vtctldatapb.ShardFence, error) {
+	primary, err := topoproto.ParseTabletAlias(fence.Master)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.ShardFence{
+		Keyspace: fence.Keyspace,
+		Shard:    fence.Shard,
+		Primary:  primary,
+		Position: fence.Position,
+		Reason:   fence.Reason,
+		Time:     protoutil.TimeToProto(fence.Time),
+	}, nil
 }
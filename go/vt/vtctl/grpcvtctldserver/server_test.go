@@ -20,6 +20,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -28,15 +31,25 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/netutil"
 	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/test/utils"
 	"vitess.io/vitess/go/vt/logutil"
 	"vitess.io/vitess/go/vt/mysqlctl/backupstorage"
+	"vitess.io/vitess/go/vt/schema"
 	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/topo/memorytopo"
 	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vtctl/fenceutil"
+	"vitess.io/vitess/go/vt/vtctl/grantutil"
 	"vitess.io/vitess/go/vt/vtctl/grpcvtctldserver/testutil"
+	"vitess.io/vitess/go/vt/vtctl/onlineddlutil"
+	"vitess.io/vitess/go/vt/vtctl/tablegcutil"
+	"vitess.io/vitess/go/vt/vtctl/validateutil"
+	"vitess.io/vitess/go/vt/vtctl/workflow"
 	"vitess.io/vitess/go/vt/vttablet/tmclient"
+	workflowmanager "vitess.io/vitess/go/vt/workflow"
 
 	mysqlctlpb "vitess.io/vitess/go/vt/proto/mysqlctl"
 	querypb "vitess.io/vitess/go/vt/proto/query"
@@ -47,6 +60,7 @@ import (
 	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
 	vtctlservicepb "vitess.io/vitess/go/vt/proto/vtctlservice"
 	"vitess.io/vitess/go/vt/proto/vttime"
+	workflowpb "vitess.io/vitess/go/vt/proto/workflow"
 )
 
 func init() {
@@ -65,6 +79,52 @@ func init() {
 	})
 }
 
+func TestAcquireKeyspaceLock(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ts := memorytopo.NewServer("zone1")
+	testutil.AddKeyspace(ctx, t, ts, &vtctldatapb.Keyspace{
+		Name:     "testkeyspace",
+		Keyspace: &topodatapb.Keyspace{},
+	})
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
+
+	resp, err := vtctld.AcquireKeyspaceLock(ctx, &vtctldatapb.AcquireKeyspaceLockRequest{
+		Keyspace: "testkeyspace",
+		Purpose:  "backup",
+		Ttl:      protoutil.DurationToProto(time.Hour),
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, resp.Lock.Id)
+	assert.Equal(t, "testkeyspace", resp.Lock.Keyspace)
+	assert.Equal(t, "backup", resp.Lock.Purpose)
+	assert.Equal(t, time.Hour, protoutil.TimeFromProto(resp.Lock.Expires).Sub(protoutil.TimeFromProto(resp.Lock.Acquired)))
+
+	// The keyspace is locked for the other operations.
+	lockCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	_, _, err = ts.LockKeyspace(lockCtx, "testkeyspace", "test")
+	assert.Error(t, err)
+
+	_, err = vtctld.ReleaseLock(ctx, &vtctldatapb.ReleaseLockRequest{Id: resp.Lock.Id})
+	require.NoError(t, err)
+
+	// A lock needs a purpose and a TTL.
+	_, err = vtctld.AcquireKeyspaceLock(ctx, &vtctldatapb.AcquireKeyspaceLockRequest{
+		Keyspace: "testkeyspace",
+		Ttl:      protoutil.DurationToProto(time.Hour),
+	})
+	assert.Error(t, err)
+	_, err = vtctld.AcquireKeyspaceLock(ctx, &vtctldatapb.AcquireKeyspaceLockRequest{
+		Keyspace: "testkeyspace",
+		Purpose:  "backup",
+	})
+	assert.Error(t, err)
+}
+
 func TestAddCellInfo(t *testing.T) {
 	t.Parallel()
 
@@ -322,6 +382,109 @@ func TestApplyRoutingRules(t *testing.T) {
 	}
 }
 
+func TestApplyRoutingRulesBatch(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ts := memorytopo.NewServer("zone1")
+	for _, ks := range []string{"ks1", "ks2"} {
+		testutil.AddKeyspace(ctx, t, ts, &vtctldatapb.Keyspace{
+			Name:     ks,
+			Keyspace: &topodatapb.Keyspace{},
+		})
+		require.NoError(t, ts.SaveVSchema(ctx, ks, &vschemapb.Keyspace{
+			Tables: map[string]*vschemapb.Table{"t1": {}, "t2": {}},
+		}))
+	}
+	require.NoError(t, ts.SaveRoutingRules(ctx, &vschemapb.RoutingRules{
+		Rules: []*vschemapb.RoutingRule{
+			{FromTable: "t1", ToTables: []string{"ks1.t1"}},
+			{FromTable: "t2", ToTables: []string{"ks1.t2"}},
+		},
+	}))
+	_, version, err := ts.GetRoutingRulesWithVersion(ctx)
+	require.NoError(t, err)
+
+	vtgate := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/debug/query_plans" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`[{"Key": "select * from t1"}, {"Key": "select * from t3"}]`))
+	}))
+	defer vtgate.Close()
+	vtgateAddr := strings.TrimPrefix(vtgate.URL, "http://")
+
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
+
+	// Invalid rules are refused.
+	_, err = vtctld.ApplyRoutingRulesBatch(ctx, &vtctldatapb.ApplyRoutingRulesBatchRequest{
+		Rules: []*vschemapb.RoutingRule{
+			{FromTable: "t1", ToTables: []string{"ks3.t1"}},
+		},
+	})
+	assert.Error(t, err)
+
+	// A dry run reports the changes, and the affected queries, without
+	// applying them.
+	resp, err := vtctld.ApplyRoutingRulesBatch(ctx, &vtctldatapb.ApplyRoutingRulesBatchRequest{
+		Rules: []*vschemapb.RoutingRule{
+			{FromTable: "t1", ToTables: []string{"ks2.t1"}},
+		},
+		DeleteTables:    []string{"t2"},
+		ExpectedVersion: version.String(),
+		DryRun:          true,
+		Vtgates:         []string{vtgateAddr},
+	})
+	require.NoError(t, err)
+	utils.MustMatch(t, &vtctldatapb.ApplyRoutingRulesBatchResponse{
+		Version: version.String(),
+		RoutingRules: &vschemapb.RoutingRules{
+			Rules: []*vschemapb.RoutingRule{
+				{FromTable: "t1", ToTables: []string{"ks2.t1"}},
+			},
+		},
+		Changes: []*vtctldatapb.ApplyRoutingRulesBatchResponse_Change{
+			{FromTable: "t1", OldToTables: []string{"ks1.t1"}, NewToTables: []string{"ks2.t1"}},
+			{FromTable: "t2", OldToTables: []string{"ks1.t2"}},
+		},
+		AffectedQueries: map[string]*vtctldatapb.ApplyRoutingRulesBatchResponse_AffectedQueries{
+			vtgateAddr: {Queries: []string{"select * from t1"}},
+		},
+	}, resp)
+	rr, err := ts.GetRoutingRules(ctx)
+	require.NoError(t, err)
+	assert.Len(t, rr.Rules, 2)
+
+	// The batch is applied atomically, at the expected version only.
+	_, err = vtctld.ApplyRoutingRulesBatch(ctx, &vtctldatapb.ApplyRoutingRulesBatchRequest{
+		Rules: []*vschemapb.RoutingRule{
+			{FromTable: "t1", ToTables: []string{"ks2.t1"}},
+		},
+		DeleteTables:    []string{"t2"},
+		ExpectedVersion: version.String(),
+	})
+	require.NoError(t, err)
+	rr, err = ts.GetRoutingRules(ctx)
+	require.NoError(t, err)
+	utils.MustMatch(t, &vschemapb.RoutingRules{
+		Rules: []*vschemapb.RoutingRule{
+			{FromTable: "t1", ToTables: []string{"ks2.t1"}},
+		},
+	}, rr)
+	srvVSchema, err := ts.GetSrvVSchema(ctx, "zone1")
+	require.NoError(t, err)
+	utils.MustMatch(t, rr, srvVSchema.RoutingRules)
+
+	_, err = vtctld.ApplyRoutingRulesBatch(ctx, &vtctldatapb.ApplyRoutingRulesBatchRequest{
+		DeleteTables:    []string{"t1"},
+		ExpectedVersion: version.String(),
+	})
+	assert.True(t, topo.IsErrType(err, topo.BadVersion), "got %v, want BadVersion", err)
+}
+
 func TestApplyVSchema(t *testing.T) {
 	t.Parallel()
 
@@ -478,6 +641,126 @@ func TestApplyVSchema(t *testing.T) {
 	}
 }
 
+func TestApproveMigration(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ts := memorytopo.NewServer("zone1")
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
+
+	tests := []struct {
+		name string
+		req  *vtctldatapb.ApproveMigrationRequest
+	}{
+		{
+			name: "no keyspace",
+			req: &vtctldatapb.ApproveMigrationRequest{
+				Uuid:     "bfb5a06b_6fa2_11eb_a1d2_0a43f95f28a3",
+				Reviewer: "alice",
+			},
+		},
+		{
+			name: "invalid uuid",
+			req: &vtctldatapb.ApproveMigrationRequest{
+				Keyspace: "testkeyspace",
+				Uuid:     "not-a-uuid",
+				Reviewer: "alice",
+			},
+		},
+		{
+			name: "no reviewer",
+			req: &vtctldatapb.ApproveMigrationRequest{
+				Keyspace: "testkeyspace",
+				Uuid:     "bfb5a06b_6fa2_11eb_a1d2_0a43f95f28a3",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := vtctld.ApproveMigration(ctx, tt.req)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestBackupKeyspace(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ts := memorytopo.NewServer("zone1")
+	testutil.AddShards(ctx, t, ts, &vtctldatapb.Shard{
+		Keyspace: "backupkeyspace",
+		Name:     "-",
+	})
+	testutil.AddKeyspace(ctx, t, ts, &vtctldatapb.Keyspace{
+		Name:     "emptykeyspace",
+		Keyspace: &topodatapb.Keyspace{},
+	})
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
+
+	// The shard has no tablet to back up: its failure is reported in the
+	// run, not as an error.
+	resp, err := vtctld.BackupKeyspace(ctx, &vtctldatapb.BackupKeyspaceRequest{
+		Keyspace: "backupkeyspace",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "backupkeyspace", resp.Run.Keyspace)
+	assert.Equal(t, "manual", resp.Run.Trigger)
+	assert.Equal(t, "failed", resp.Run.State)
+	assert.NotEmpty(t, resp.Run.Error)
+	require.Len(t, resp.Run.Shards, 1)
+	assert.Equal(t, "-", resp.Run.Shards[0].Shard)
+	assert.Equal(t, "failed", resp.Run.Shards[0].State)
+	assert.Nil(t, resp.Run.Shards[0].Tablet)
+	assert.NotEmpty(t, resp.Events)
+
+	_, err = vtctld.BackupKeyspace(ctx, &vtctldatapb.BackupKeyspaceRequest{
+		Keyspace: "emptykeyspace",
+	})
+	assert.Error(t, err)
+
+	_, err = vtctld.BackupKeyspace(ctx, &vtctldatapb.BackupKeyspaceRequest{})
+	assert.Error(t, err)
+}
+
+func TestBrowseTopology(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ts := memorytopo.NewServer("zone1")
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
+
+	err := ts.CreateKeyspace(ctx, "testkeyspace", &topodatapb.Keyspace{})
+	require.NoError(t, err)
+
+	resp, err := vtctld.BrowseTopology(ctx, &vtctldatapb.BrowseTopologyRequest{Path: "/"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{topo.GlobalCell, "zone1"}, resp.Children)
+
+	resp, err = vtctld.BrowseTopology(ctx, &vtctldatapb.BrowseTopologyRequest{Path: "/global/keyspaces"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"testkeyspace"}, resp.Children)
+
+	resp, err = vtctld.BrowseTopology(ctx, &vtctldatapb.BrowseTopologyRequest{Path: "/global/keyspaces/testkeyspace/Keyspace"})
+	require.NoError(t, err)
+	assert.NotEmpty(t, resp.Version)
+	assert.Empty(t, resp.Children)
+
+	_, err = vtctld.BrowseTopology(ctx, &vtctldatapb.BrowseTopologyRequest{Path: "/zone404"})
+	assert.Error(t, err)
+}
+
 func TestChangeTabletType(t *testing.T) {
 	t.Parallel()
 
@@ -1234,6 +1517,99 @@ func TestCreateShard(t *testing.T) {
 	}
 }
 
+func TestDecommissionTablet(t *testing.T) {
+	t.Parallel()
+
+	// The primary runs no vreplication stream.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"VReplicationSourceTablet": {}}`)
+	}))
+	defer server.Close()
+	host, port, err := netutil.SplitHostPort(strings.TrimPrefix(server.URL, "http://"))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	ts := memorytopo.NewServer("zone1")
+	testutil.AddTablets(ctx, t, ts, &testutil.AddTabletOptions{
+		AlsoSetShardMaster: true,
+	}, &topodatapb.Tablet{
+		Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+		Keyspace: "testkeyspace",
+		Shard:    "-",
+		Type:     topodatapb.TabletType_MASTER,
+		Hostname: host,
+		PortMap:  map[string]int32{"vt": int32(port)},
+	}, &topodatapb.Tablet{
+		Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 101},
+		Keyspace: "testkeyspace",
+		Shard:    "-",
+		Type:     topodatapb.TabletType_REPLICA,
+	}, &topodatapb.Tablet{
+		Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 102},
+		Keyspace: "testkeyspace",
+		Shard:    "-",
+		Type:     topodatapb.TabletType_REPLICA,
+	})
+
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, &testutil.TabletManagerClient{
+		StopReplicationResults: map[string]error{
+			"zone1-0000000102": nil,
+		},
+	}, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
+
+	// The primary cannot be decommissioned.
+	_, err = vtctld.DecommissionTablet(ctx, &vtctldatapb.DecommissionTabletRequest{
+		TabletAlias: &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+		DrainGrace:  protoutil.DurationToProto(time.Millisecond),
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "it is the master of testkeyspace/-")
+
+	// A replica is drained, stops replicating and is deleted.
+	resp, err := vtctld.DecommissionTablet(ctx, &vtctldatapb.DecommissionTabletRequest{
+		TabletAlias: &topodatapb.TabletAlias{Cell: "zone1", Uid: 102},
+		DrainGrace:  protoutil.DurationToProto(time.Millisecond),
+	})
+	require.NoError(t, err)
+	testutil.AssertLogutilEventsOccurred(t, resp, "expected events to occur during DecommissionTablet")
+	_, err = ts.GetTablet(ctx, &topodatapb.TabletAlias{Cell: "zone1", Uid: 102})
+	assert.True(t, topo.IsErrType(err, topo.NoNode), "expected the tablet to be deleted, got %v", err)
+
+	// The last replica cannot be decommissioned.
+	_, err = vtctld.DecommissionTablet(ctx, &vtctldatapb.DecommissionTabletRequest{
+		TabletAlias: &topodatapb.TabletAlias{Cell: "zone1", Uid: 101},
+		DrainGrace:  protoutil.DurationToProto(time.Millisecond),
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "it is the last replica of testkeyspace/-")
+}
+
+func TestDeleteBackupSchedule(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ts := memorytopo.NewServer("zone1")
+	testutil.AddKeyspace(ctx, t, ts, &vtctldatapb.Keyspace{
+		Name:     "testkeyspace",
+		Keyspace: &topodatapb.Keyspace{},
+	})
+	require.NoError(t, ts.SaveBackupSchedule(ctx, "testkeyspace", &topodatapb.BackupSchedule{Cron: "@daily"}))
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
+
+	_, err := vtctld.DeleteBackupSchedule(ctx, &vtctldatapb.DeleteBackupScheduleRequest{
+		Keyspace: "testkeyspace",
+	})
+	require.NoError(t, err)
+
+	schedule, err := ts.GetBackupSchedule(ctx, "testkeyspace")
+	require.NoError(t, err)
+	assert.Nil(t, schedule)
+}
+
 func TestDeleteCellInfo(t *testing.T) {
 	t.Parallel()
 
@@ -1241,7 +1617,9 @@ func TestDeleteCellInfo(t *testing.T) {
 	tests := []struct {
 		name      string
 		ts        *topo.Server
+		setup     func(t *testing.T, ts *topo.Server)
 		req       *vtctldatapb.DeleteCellInfoRequest
+		expected  *vtctldatapb.DeleteCellInfoResponse
 		shouldErr bool
 	}{
 		{
@@ -1249,6 +1627,7 @@ func TestDeleteCellInfo(t *testing.T) {
 			req: &vtctldatapb.DeleteCellInfoRequest{
 				Name: "zone2",
 			},
+			expected: &vtctldatapb.DeleteCellInfoResponse{},
 		},
 		{
 			name: "cell does not exist",
@@ -1258,6 +1637,68 @@ func TestDeleteCellInfo(t *testing.T) {
 			},
 			shouldErr: true,
 		},
+		{
+			name: "cell has tablets",
+			ts:   memorytopo.NewServer("zone1", "zone2"),
+			setup: func(t *testing.T, ts *topo.Server) {
+				testutil.AddTablet(ctx, t, ts, &topodatapb.Tablet{
+					Alias:    &topodatapb.TabletAlias{Cell: "zone2", Uid: 100},
+					Keyspace: "testkeyspace",
+					Shard:    "-",
+				}, nil)
+			},
+			req: &vtctldatapb.DeleteCellInfoRequest{
+				Name: "zone2",
+			},
+			shouldErr: true,
+		},
+		{
+			name: "cell is in a cells alias",
+			ts:   memorytopo.NewServer("zone1", "zone2"),
+			setup: func(t *testing.T, ts *topo.Server) {
+				require.NoError(t, ts.CreateCellsAlias(ctx, "zone", &topodatapb.CellsAlias{
+					Cells: []string{"zone1", "zone2"},
+				}))
+			},
+			req: &vtctldatapb.DeleteCellInfoRequest{
+				Name:  "zone2",
+				Force: true,
+			},
+			shouldErr: true,
+		},
+		{
+			name: "cell has serving keyspaces",
+			ts:   memorytopo.NewServer("zone1", "zone2"),
+			setup: func(t *testing.T, ts *topo.Server) {
+				testutil.AddSrvKeyspaces(t, ts, &testutil.SrvKeyspace{
+					Cell:        "zone2",
+					Keyspace:    "testkeyspace",
+					SrvKeyspace: &topodatapb.SrvKeyspace{},
+				})
+			},
+			req: &vtctldatapb.DeleteCellInfoRequest{
+				Name: "zone2",
+			},
+			shouldErr: true,
+		},
+		{
+			name: "drain",
+			ts:   memorytopo.NewServer("zone1", "zone2"),
+			setup: func(t *testing.T, ts *topo.Server) {
+				testutil.AddSrvKeyspaces(t, ts, &testutil.SrvKeyspace{
+					Cell:        "zone2",
+					Keyspace:    "testkeyspace",
+					SrvKeyspace: &topodatapb.SrvKeyspace{},
+				})
+			},
+			req: &vtctldatapb.DeleteCellInfoRequest{
+				Name:  "zone2",
+				Drain: true,
+			},
+			expected: &vtctldatapb.DeleteCellInfoResponse{
+				DrainedKeyspaces: []string{"testkeyspace"},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -1265,16 +1706,21 @@ func TestDeleteCellInfo(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
+			if tt.setup != nil {
+				tt.setup(t, tt.ts)
+			}
+
 			vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, tt.ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
 				return NewVtctldServer(ts)
 			})
-			_, err := vtctld.DeleteCellInfo(ctx, tt.req)
+			resp, err := vtctld.DeleteCellInfo(ctx, tt.req)
 			if tt.shouldErr {
 				assert.Error(t, err)
 				return
 			}
 
 			require.NoError(t, err)
+			utils.MustMatch(t, tt.expected, resp)
 			ci, err := tt.ts.GetCellInfo(ctx, tt.req.Name, true)
 			assert.True(t, topo.IsErrType(err, topo.NoNode), "expected cell %s to no longer exist; found %+v", tt.req.Name, ci)
 		})
@@ -2480,6 +2926,52 @@ func TestDeleteTablets(t *testing.T) {
 	}
 }
 
+func TestDrainTablet(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ts := memorytopo.NewServer("zone1")
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
+
+	replica := &topodatapb.Tablet{
+		Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+		Keyspace: "testkeyspace",
+		Shard:    "-",
+		Type:     topodatapb.TabletType_REPLICA,
+	}
+	primary := &topodatapb.Tablet{
+		Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 101},
+		Keyspace: "testkeyspace",
+		Shard:    "-",
+		Type:     topodatapb.TabletType_MASTER,
+	}
+	testutil.AddTablets(ctx, t, ts, nil, replica, primary)
+
+	_, err := vtctld.DrainTablet(ctx, &vtctldatapb.DrainTabletRequest{TabletAlias: replica.Alias})
+	require.NoError(t, err)
+	ti, err := ts.GetTablet(ctx, replica.Alias)
+	require.NoError(t, err)
+	assert.True(t, topoproto.IsTabletDrained(ti.Tablet))
+
+	// Draining it again is a no-op.
+	_, err = vtctld.DrainTablet(ctx, &vtctldatapb.DrainTabletRequest{TabletAlias: replica.Alias})
+	assert.NoError(t, err)
+
+	_, err = vtctld.UndrainTablet(ctx, &vtctldatapb.UndrainTabletRequest{TabletAlias: replica.Alias})
+	require.NoError(t, err)
+	ti, err = ts.GetTablet(ctx, replica.Alias)
+	require.NoError(t, err)
+	assert.False(t, topoproto.IsTabletDrained(ti.Tablet))
+
+	_, err = vtctld.DrainTablet(ctx, &vtctldatapb.DrainTabletRequest{TabletAlias: primary.Alias})
+	assert.Error(t, err)
+
+	_, err = vtctld.DrainTablet(ctx, &vtctldatapb.DrainTabletRequest{})
+	assert.Error(t, err)
+}
+
 func TestEmergencyReparentShard(t *testing.T) {
 	t.Parallel()
 
@@ -2605,12 +3097,94 @@ func TestEmergencyReparentShard(t *testing.T) {
 			shouldErr:           false,
 		},
 		{
-			// Note: this is testing the error-handling done in
-			// (*VtctldServer).EmergencyReparentShard, not the logic of an ERS.
-			// That logic is tested in reparentutil, and not here. Therefore,
-			// the simplest way to trigger a failure is to attempt an ERS on a
-			// shard that does not exist.
-			name:    "failed reparent",
+			name: "dry run with constraints",
+			ts:   memorytopo.NewServer("zone1"),
+			tablets: []*topodatapb.Tablet{
+				{
+					Alias: &topodatapb.TabletAlias{
+						Cell: "zone1",
+						Uid:  100,
+					},
+					Type: topodatapb.TabletType_MASTER,
+					MasterTermStartTime: &vttime.Time{
+						Seconds: 100,
+					},
+					Keyspace: "testkeyspace",
+					Shard:    "-",
+				},
+				{
+					Alias: &topodatapb.TabletAlias{
+						Cell: "zone1",
+						Uid:  200,
+					},
+					Type:     topodatapb.TabletType_REPLICA,
+					Keyspace: "testkeyspace",
+					Shard:    "-",
+				},
+				{
+					Alias: &topodatapb.TabletAlias{
+						Cell: "zone1",
+						Uid:  101,
+					},
+					Type:     topodatapb.TabletType_REPLICA,
+					Keyspace: "testkeyspace",
+					Shard:    "-",
+				},
+			},
+			tmc: &testutil.TabletManagerClient{
+				// Replication is only read. The old primary is unreachable,
+				// and zone1-0000000101, which is the most advanced, lags too
+				// much to be promoted.
+				ReplicationStatusResults: map[string]struct {
+					Position *replicationdatapb.Status
+					Error    error
+				}{
+					"zone1-0000000100": {
+						Error: assert.AnError,
+					},
+					"zone1-0000000101": {
+						Position: &replicationdatapb.Status{
+							MasterUuid:          "3E11FA47-71CA-11E1-9E33-C80AA9429562",
+							RelayLogPosition:    "MySQL56/3E11FA47-71CA-11E1-9E33-C80AA9429562:1-6",
+							Position:            "MySQL56/3E11FA47-71CA-11E1-9E33-C80AA9429562:1-6",
+							SecondsBehindMaster: 3600,
+						},
+					},
+					"zone1-0000000200": {
+						Position: &replicationdatapb.Status{
+							MasterUuid:       "3E11FA47-71CA-11E1-9E33-C80AA9429562",
+							RelayLogPosition: "MySQL56/3E11FA47-71CA-11E1-9E33-C80AA9429562:1-5",
+							Position:         "MySQL56/3E11FA47-71CA-11E1-9E33-C80AA9429562:1-5",
+						},
+					},
+				},
+			},
+			req: &vtctldatapb.EmergencyReparentShardRequest{
+				Keyspace:            "testkeyspace",
+				Shard:               "-",
+				WaitReplicasTimeout: protoutil.DurationToProto(time.Millisecond * 10),
+				AllowedCells:        []string{"zone1"},
+				MaxReplicationLag:   protoutil.DurationToProto(time.Minute),
+				DryRun:              true,
+			},
+			expected: &vtctldatapb.EmergencyReparentShardResponse{
+				Keyspace: "testkeyspace",
+				Shard:    "-",
+				PromotedPrimary: &topodatapb.TabletAlias{
+					Cell: "zone1",
+					Uid:  200,
+				},
+			},
+			expectEventsToOccur: true,
+			shouldErr:           false,
+		},
+		{
+			// Note: this is testing the error-handling done in
+			// (*VtctldServer).EmergencyReparentShard, not the logic of an ERS.
+			// That logic is tested in reparentutil, and not here. Therefore,
+			// the simplest way to trigger a failure is to attempt an ERS on a
+			// shard that does not exist.
+			name:    "failed reparent",
 			ts:      memorytopo.NewServer("zone1"),
 			tablets: nil,
 
@@ -2720,6 +3294,48 @@ func TestFindAllShardsInKeyspace(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestGetBackupSchedule(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ts := memorytopo.NewServer("zone1")
+	testutil.AddKeyspace(ctx, t, ts, &vtctldatapb.Keyspace{
+		Name:     "testkeyspace",
+		Keyspace: &topodatapb.Keyspace{},
+	})
+	createTime := time.Date(2021, time.June, 1, 12, 0, 0, 0, time.Local)
+	require.NoError(t, ts.SaveBackupSchedule(ctx, "testkeyspace", &topodatapb.BackupSchedule{
+		Cron:        "0 3 * * *",
+		Concurrency: 2,
+		KeepLast:    7,
+		MaxAge:      protoutil.DurationToProto(720 * time.Hour),
+		CreateTime:  protoutil.TimeToProto(createTime),
+	}))
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
+
+	resp, err := vtctld.GetBackupSchedule(ctx, &vtctldatapb.GetBackupScheduleRequest{
+		Keyspace: "testkeyspace",
+	})
+	require.NoError(t, err)
+	utils.MustMatch(t, &vtctldatapb.GetBackupScheduleResponse{
+		Schedule: &topodatapb.BackupSchedule{
+			Cron:        "0 3 * * *",
+			Concurrency: 2,
+			KeepLast:    7,
+			MaxAge:      protoutil.DurationToProto(720 * time.Hour),
+			CreateTime:  protoutil.TimeToProto(createTime),
+		},
+		NextRunTime: protoutil.TimeToProto(createTime.Add(15 * time.Hour)),
+	}, resp)
+
+	_, err = vtctld.GetBackupSchedule(ctx, &vtctldatapb.GetBackupScheduleRequest{
+		Keyspace: "otherkeyspace",
+	})
+	assert.Error(t, err)
+}
+
 func TestGetBackups(t *testing.T) {
 	t.Parallel()
 
@@ -2954,6 +3570,41 @@ func TestGetCellsAliases(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestGetKeyspaceBackupRuns(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ts := memorytopo.NewServer("zone1")
+	testutil.AddShards(ctx, t, ts, &vtctldatapb.Shard{
+		Keyspace: "runskeyspace",
+		Name:     "-80",
+	}, &vtctldatapb.Shard{
+		Keyspace: "runskeyspace",
+		Name:     "80-",
+	})
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
+
+	resp, err := vtctld.GetKeyspaceBackupRuns(ctx, &vtctldatapb.GetKeyspaceBackupRunsRequest{
+		Keyspace: "runskeyspace",
+	})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Runs)
+
+	backupResp, err := vtctld.BackupKeyspace(ctx, &vtctldatapb.BackupKeyspaceRequest{
+		Keyspace: "runskeyspace",
+		Shards:   []string{"80-"},
+	})
+	require.NoError(t, err)
+
+	resp, err = vtctld.GetKeyspaceBackupRuns(ctx, &vtctldatapb.GetKeyspaceBackupRunsRequest{
+		Keyspace: "runskeyspace",
+	})
+	require.NoError(t, err)
+	utils.MustMatch(t, []*vtctldatapb.KeyspaceBackupRun{backupResp.Run}, resp.Runs)
+}
+
 func TestGetKeyspaces(t *testing.T) {
 	t.Parallel()
 
@@ -3001,6 +3652,88 @@ func TestGetKeyspaces(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestGetOnlineDDLProgress(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ts := memorytopo.NewServer("zone1")
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
+
+	_, err := vtctld.GetOnlineDDLProgress(ctx, &vtctldatapb.GetOnlineDDLProgressRequest{Uuid: "bfb5a06b_6fa2_11eb_a1d2_0a43f95f28a3"})
+	assert.Error(t, err, "the keyspace is required")
+
+	_, err = vtctld.GetOnlineDDLProgress(ctx, &vtctldatapb.GetOnlineDDLProgressRequest{Keyspace: "testkeyspace", Uuid: "not-a-uuid"})
+	assert.Error(t, err, "the uuid must be an online DDL uuid")
+
+	startedAt := time.Unix(100, 0)
+	progress := &onlineddlutil.Progress{
+		UUID:                      "bfb5a06b_6fa2_11eb_a1d2_0a43f95f28a3",
+		Keyspace:                  "testkeyspace",
+		Table:                     "t1",
+		Strategy:                  "online",
+		Status:                    "running",
+		Progress:                  50,
+		ETASeconds:                60,
+		RowsCopied:                100,
+		TableRows:                 200,
+		ThrottledShards:           1,
+		MaxVReplicationLagSeconds: 3,
+		Shards: []*onlineddlutil.ShardProgress{
+			{
+				Shard:                  "-80",
+				Tablet:                 &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+				Status:                 "running",
+				Strategy:               "online",
+				Table:                  "t1",
+				Progress:               50,
+				ETASeconds:             60,
+				RowsCopied:             100,
+				TableRows:              200,
+				StartedAt:              &startedAt,
+				Throttled:              true,
+				ThrottleMessage:        "lag",
+				VReplicationLagSeconds: 3,
+			},
+		},
+	}
+	expected := &vtctldatapb.GetOnlineDDLProgressResponse{
+		Uuid:                      "bfb5a06b_6fa2_11eb_a1d2_0a43f95f28a3",
+		Keyspace:                  "testkeyspace",
+		Table:                     "t1",
+		Strategy:                  "online",
+		Status:                    "running",
+		Progress:                  50,
+		EtaSeconds:                60,
+		RowsCopied:                100,
+		TableRows:                 200,
+		ThrottledShards:           1,
+		MaxVreplicationLagSeconds: 3,
+		Shards: []*vtctldatapb.GetOnlineDDLProgressResponse_ShardProgress{
+			{
+				Shard: "-80",
+				Tablet: &topodatapb.TabletAlias{
+					Cell: "zone1",
+					Uid:  100,
+				},
+				Status:                 "running",
+				Strategy:               "online",
+				Table:                  "t1",
+				Progress:               50,
+				EtaSeconds:             60,
+				RowsCopied:             100,
+				TableRows:              200,
+				StartedAt:              &vttime.Time{Seconds: 100},
+				Throttled:              true,
+				ThrottleMessage:        "lag",
+				VreplicationLagSeconds: 3,
+			},
+		},
+	}
+	utils.MustMatch(t, expected, onlineDDLProgressToProto(progress))
+}
+
 func TestGetRoutingRules(t *testing.T) {
 	t.Parallel()
 
@@ -3069,6 +3802,15 @@ func TestGetRoutingRules(t *testing.T) {
 
 			require.NoError(t, err)
 			utils.MustMatch(t, resp.RoutingRules, tt.expected)
+
+			if tt.rrIn == nil {
+				assert.Empty(t, resp.Version)
+				return
+			}
+
+			_, version, err := ts.GetRoutingRulesWithVersion(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, version.String(), resp.Version)
 		})
 	}
 }
@@ -3357,6 +4099,183 @@ func TestGetShard(t *testing.T) {
 	}
 }
 
+func TestGetShardFence(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ts := memorytopo.NewServer("zone1")
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
+
+	testutil.AddShards(ctx, t, ts, &vtctldatapb.Shard{
+		Keyspace: "testkeyspace",
+		Name:     "-",
+	})
+
+	_, err := vtctld.GetShardFence(ctx, &vtctldatapb.GetShardFenceRequest{Keyspace: "testkeyspace"})
+	assert.Error(t, err)
+
+	// A shard which isn't fenced has no fence.
+	_, err = vtctld.GetShardFence(ctx, &vtctldatapb.GetShardFenceRequest{
+		Keyspace: "testkeyspace",
+		Shard:    "-",
+	})
+	assert.EqualError(t, err, "shard testkeyspace/- is not fenced")
+
+	_, err = vtctld.UnfenceShard(ctx, &vtctldatapb.UnfenceShardRequest{
+		Keyspace: "testkeyspace",
+		Shard:    "-",
+	})
+	assert.Error(t, err)
+
+	fenceTime := time.Date(2021, time.June, 1, 12, 0, 0, 0, time.UTC)
+	fence, err := shardFenceToProto(&fenceutil.ShardFence{
+		Keyspace: "testkeyspace",
+		Shard:    "-",
+		Master:   "zone1-0000000100",
+		Position: "MySQL56/16b1039f-22b6-11ed-b765-0a43f95f28a3:1-100",
+		Reason:   "export",
+		Time:     fenceTime,
+	})
+	require.NoError(t, err)
+	utils.MustMatch(t, &vtctldatapb.ShardFence{
+		Keyspace: "testkeyspace",
+		Shard:    "-",
+		Primary: &topodatapb.TabletAlias{
+			Cell: "zone1",
+			Uid:  100,
+		},
+		Position: "MySQL56/16b1039f-22b6-11ed-b765-0a43f95f28a3:1-100",
+		Reason:   "export",
+		Time:     protoutil.TimeToProto(fenceTime),
+	}, fence)
+}
+
+func TestGetShardReplicationGraph(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ts := memorytopo.NewServer("zone1")
+	testutil.AddTablets(ctx, t, ts, &testutil.AddTabletOptions{AlsoSetShardMaster: true},
+		&topodatapb.Tablet{
+			Alias:         &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+			Keyspace:      "testkeyspace",
+			Shard:         "-",
+			Type:          topodatapb.TabletType_MASTER,
+			MysqlHostname: "primary",
+			MysqlPort:     3306,
+		},
+		&topodatapb.Tablet{
+			Alias:         &topodatapb.TabletAlias{Cell: "zone1", Uid: 101},
+			Keyspace:      "testkeyspace",
+			Shard:         "-",
+			Type:          topodatapb.TabletType_REPLICA,
+			MysqlHostname: "replica",
+			MysqlPort:     3306,
+		},
+	)
+
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, &testutil.TabletManagerClient{
+		ReplicationStatusResults: map[string]struct {
+			Position *replicationdatapb.Status
+			Error    error
+		}{
+			"zone1-0000000100": {
+				Error: mysql.ErrNotReplica,
+			},
+			"zone1-0000000101": {
+				Position: &replicationdatapb.Status{
+					MasterHost:          "primary",
+					MasterPort:          3306,
+					IoThreadRunning:     true,
+					SqlThreadRunning:    false,
+					SecondsBehindMaster: 3,
+				},
+			},
+		},
+	}, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
+
+	resp, err := vtctld.GetShardReplicationGraph(ctx, &vtctldatapb.GetShardReplicationGraphRequest{
+		Keyspace: "testkeyspace",
+		Shard:    "-",
+	})
+	require.NoError(t, err)
+	utils.MustMatch(t, &vtctldatapb.ShardReplicationGraph{
+		Keyspace: "testkeyspace",
+		Shard:    "-",
+		Primary:  &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+		Tablets: []*vtctldatapb.ShardReplicationGraph_Tablet{
+			{
+				Alias:      &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+				TabletType: topodatapb.TabletType_MASTER,
+				MysqlAddr:  "primary:3306",
+			},
+			{
+				Alias:               &topodatapb.TabletAlias{Cell: "zone1", Uid: 101},
+				TabletType:          topodatapb.TabletType_REPLICA,
+				MysqlAddr:           "replica:3306",
+				ReplicatesFrom:      "zone1-0000000100",
+				IoThreadRunning:     true,
+				SecondsBehindMaster: 3,
+				Mismatches:          []string{"SQL thread is not running"},
+			},
+		},
+		Mismatches: []string{"zone1-0000000101: SQL thread is not running"},
+	}, resp.Graph)
+
+	_, err = vtctld.GetShardReplicationGraph(ctx, &vtctldatapb.GetShardReplicationGraphRequest{Keyspace: "testkeyspace"})
+	assert.Error(t, err)
+}
+
+func TestGetShardServingTimeline(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"time": "2021-06-01T10:00:00Z", "keyspace": "testkeyspace", "shard": "-", "tablet_type": "REPLICA", "serving": false, "reason": "lag", "details": "replication lag 60s"}]`)
+	}))
+	defer server.Close()
+	host, port, err := netutil.SplitHostPort(strings.TrimPrefix(server.URL, "http://"))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	ts := memorytopo.NewServer("zone1")
+	testutil.AddTablets(ctx, t, ts, nil, &topodatapb.Tablet{
+		Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+		Keyspace: "testkeyspace",
+		Shard:    "-",
+		Type:     topodatapb.TabletType_REPLICA,
+		Hostname: host,
+		PortMap:  map[string]int32{"vt": int32(port)},
+	})
+
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
+
+	resp, err := vtctld.GetShardServingTimeline(ctx, &vtctldatapb.GetShardServingTimelineRequest{
+		Keyspace: "testkeyspace",
+		Shard:    "-",
+	})
+	require.NoError(t, err)
+	utils.MustMatch(t, &vtctldatapb.GetShardServingTimelineResponse{
+		Events: []*vtctldatapb.ServingEvent{{
+			Tablet:     &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+			Time:       &vttime.Time{Seconds: 1622541600},
+			TabletType: topodatapb.TabletType_REPLICA,
+			Serving:    false,
+			Reason:     "lag",
+			Details:    "replication lag 60s",
+		}},
+		TabletErrors: map[string]string{},
+	}, resp)
+
+	_, err = vtctld.GetShardServingTimeline(ctx, &vtctldatapb.GetShardServingTimelineRequest{Keyspace: "testkeyspace"})
+	assert.Error(t, err)
+}
+
 func TestGetSrvKeyspaces(t *testing.T) {
 	t.Parallel()
 
@@ -3535,6 +4454,44 @@ func TestGetSrvKeyspaces(t *testing.T) {
 	}
 }
 
+func TestGetSrvKeyspaceWithVersion(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ts := memorytopo.NewServer("zone1")
+	testutil.AddSrvKeyspaces(t, ts, &testutil.SrvKeyspace{
+		Cell:     "zone1",
+		Keyspace: "testkeyspace",
+		SrvKeyspace: &topodatapb.SrvKeyspace{
+			ShardingColumnName: "sharding-col",
+		},
+	})
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
+
+	_, version, err := ts.GetSrvKeyspaceWithVersion(ctx, "zone1", "testkeyspace")
+	require.NoError(t, err)
+
+	resp, err := vtctld.GetSrvKeyspaceWithVersion(ctx, &vtctldatapb.GetSrvKeyspaceWithVersionRequest{
+		Keyspace: "testkeyspace",
+		Cell:     "zone1",
+	})
+	require.NoError(t, err)
+	utils.MustMatch(t, &vtctldatapb.GetSrvKeyspaceWithVersionResponse{
+		SrvKeyspace: &topodatapb.SrvKeyspace{
+			ShardingColumnName: "sharding-col",
+		},
+		Version: version.String(),
+	}, resp)
+
+	_, err = vtctld.GetSrvKeyspaceWithVersion(ctx, &vtctldatapb.GetSrvKeyspaceWithVersionRequest{
+		Keyspace: "otherkeyspace",
+		Cell:     "zone1",
+	})
+	assert.Error(t, err)
+}
+
 func TestGetSrvVSchema(t *testing.T) {
 	t.Parallel()
 
@@ -3845,19 +4802,85 @@ func TestGetTablet(t *testing.T) {
 	assert.Error(t, err)
 }
 
-func TestGetTablets(t *testing.T) {
-	t.Parallel()
+func TestGetTabletHealthHistory(t *testing.T) {
+	// This test doesn't run in parallel, as it registers a TabletHealthSource.
+	ctx := context.Background()
+	ts := memorytopo.NewServer("zone1")
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
 
-	tests := []struct {
-		name      string
-		cells     []string
-		tablets   []*topodatapb.Tablet
-		req       *vtctldatapb.GetTabletsRequest
-		expected  []*topodatapb.Tablet
-		shouldErr bool
-	}{
-		{
-			name:      "no tablets",
+	// Without the realtime stats, there is no history.
+	_, err := vtctld.GetTabletHealthHistory(ctx, &vtctldatapb.GetTabletHealthHistoryRequest{})
+	assert.Error(t, err)
+
+	now := time.Now()
+	added := &vtctldatapb.TabletHealthEvent{
+		Time: protoutil.TimeToProto(now.Add(-time.Hour)),
+		Tablet: &topodatapb.TabletAlias{
+			Cell: "zone1",
+			Uid:  100,
+		},
+		Type:    "added",
+		Serving: true,
+	}
+	lagBreach := &vtctldatapb.TabletHealthEvent{
+		Time: protoutil.TimeToProto(now.Add(-time.Minute)),
+		Tablet: &topodatapb.TabletAlias{
+			Cell: "zone1",
+			Uid:  100,
+		},
+		Type:                  "lag_breach",
+		Serving:               true,
+		ReplicationLagSeconds: 60,
+	}
+	removed := &vtctldatapb.TabletHealthEvent{
+		Time: protoutil.TimeToProto(now.Add(-time.Minute)),
+		Tablet: &topodatapb.TabletAlias{
+			Cell: "zone1",
+			Uid:  101,
+		},
+		Type: "removed",
+	}
+	RegisterTabletHealthSource(&fakeTabletHealthSource{
+		events: []*vtctldatapb.TabletHealthEvent{added, lagBreach, removed},
+	})
+	defer RegisterTabletHealthSource(nil)
+
+	resp, err := vtctld.GetTabletHealthHistory(ctx, &vtctldatapb.GetTabletHealthHistoryRequest{})
+	require.NoError(t, err)
+	utils.MustMatch(t, []*vtctldatapb.TabletHealthEvent{added, lagBreach, removed}, resp.Events)
+
+	resp, err = vtctld.GetTabletHealthHistory(ctx, &vtctldatapb.GetTabletHealthHistoryRequest{
+		TabletAlias: &topodatapb.TabletAlias{
+			Cell: "zone1",
+			Uid:  100,
+		},
+		Since: protoutil.TimeToProto(now.Add(-10 * time.Minute)),
+	})
+	require.NoError(t, err)
+	utils.MustMatch(t, []*vtctldatapb.TabletHealthEvent{lagBreach}, resp.Events)
+
+	_, err = vtctld.GetTabletHealthHistory(ctx, &vtctldatapb.GetTabletHealthHistoryRequest{
+		Since: protoutil.TimeToProto(now),
+		Until: protoutil.TimeToProto(now.Add(-time.Hour)),
+	})
+	assert.Error(t, err)
+}
+
+func TestGetTablets(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		cells     []string
+		tablets   []*topodatapb.Tablet
+		req       *vtctldatapb.GetTabletsRequest
+		expected  []*topodatapb.Tablet
+		shouldErr bool
+	}{
+		{
+			name:      "no tablets",
 			cells:     []string{"cell1"},
 			tablets:   []*topodatapb.Tablet{},
 			req:       &vtctldatapb.GetTabletsRequest{},
@@ -4278,6 +5301,112 @@ func TestGetTablets(t *testing.T) {
 			expected:  []*topodatapb.Tablet{},
 			shouldErr: false,
 		},
+		{
+			name:  "tablet type and hostname filters",
+			cells: []string{"zone1"},
+			tablets: []*topodatapb.Tablet{
+				{
+					Alias: &topodatapb.TabletAlias{
+						Cell: "zone1",
+						Uid:  100,
+					},
+					Hostname: "db-100.example.com",
+					Keyspace: "testkeyspace",
+					Shard:    "-",
+					Type:     topodatapb.TabletType_REPLICA,
+				},
+				{
+					Alias: &topodatapb.TabletAlias{
+						Cell: "zone1",
+						Uid:  101,
+					},
+					Hostname: "db-101.example.com",
+					Keyspace: "testkeyspace",
+					Shard:    "-",
+					Type:     topodatapb.TabletType_RDONLY,
+				},
+				{
+					Alias: &topodatapb.TabletAlias{
+						Cell: "zone1",
+						Uid:  102,
+					},
+					Hostname: "batch-102.example.com",
+					Keyspace: "testkeyspace",
+					Shard:    "-",
+					Type:     topodatapb.TabletType_REPLICA,
+				},
+			},
+			req: &vtctldatapb.GetTabletsRequest{
+				TabletTypes: []topodatapb.TabletType{topodatapb.TabletType_REPLICA},
+				Hostname:    "db-",
+			},
+			expected: []*topodatapb.Tablet{
+				{
+					Alias: &topodatapb.TabletAlias{
+						Cell: "zone1",
+						Uid:  100,
+					},
+					Hostname: "db-100.example.com",
+					Keyspace: "testkeyspace",
+					Shard:    "-",
+					Type:     topodatapb.TabletType_REPLICA,
+				},
+			},
+			shouldErr: false,
+		},
+		{
+			name:  "field mask",
+			cells: []string{"zone1"},
+			tablets: []*topodatapb.Tablet{
+				{
+					Alias: &topodatapb.TabletAlias{
+						Cell: "zone1",
+						Uid:  100,
+					},
+					Hostname: "db-100.example.com",
+					Keyspace: "testkeyspace",
+					Shard:    "-",
+					Type:     topodatapb.TabletType_REPLICA,
+				},
+			},
+			req: &vtctldatapb.GetTabletsRequest{
+				Fields: []string{"alias", "hostname"},
+			},
+			expected: []*topodatapb.Tablet{
+				{
+					Alias: &topodatapb.TabletAlias{
+						Cell: "zone1",
+						Uid:  100,
+					},
+					Hostname: "db-100.example.com",
+				},
+			},
+			shouldErr: false,
+		},
+		{
+			name:  "unknown field in the field mask",
+			cells: []string{"zone1"},
+			req: &vtctldatapb.GetTabletsRequest{
+				Fields: []string{"alias", "nickname"},
+			},
+			shouldErr: true,
+		},
+		{
+			name:  "serving filter without a health source",
+			cells: []string{"zone1"},
+			req: &vtctldatapb.GetTabletsRequest{
+				Serving: vtctldatapb.GetTabletsRequest_SERVING,
+			},
+			shouldErr: true,
+		},
+		{
+			name:  "page size too large",
+			cells: []string{"zone1"},
+			req: &vtctldatapb.GetTabletsRequest{
+				PageSize: maxTabletPageSize + 1,
+			},
+			shouldErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -4306,6 +5435,195 @@ func TestGetTablets(t *testing.T) {
 	}
 }
 
+func TestGetTabletsPagination(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ts := memorytopo.NewServer("zone1", "zone2")
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
+
+	testutil.AddTablets(ctx, t, ts, &testutil.AddTabletOptions{
+		AlsoSetShardMaster: true,
+	}, &topodatapb.Tablet{
+		Alias: &topodatapb.TabletAlias{
+			Cell: "zone1",
+			Uid:  100,
+		},
+		Keyspace: "testkeyspace",
+		Shard:    "-",
+		Type:     topodatapb.TabletType_MASTER,
+	})
+	testutil.AddTablets(ctx, t, ts, nil,
+		&topodatapb.Tablet{
+			Alias: &topodatapb.TabletAlias{
+				Cell: "zone2",
+				Uid:  200,
+			},
+			Keyspace: "testkeyspace",
+			Shard:    "-",
+			Type:     topodatapb.TabletType_REPLICA,
+		},
+		&topodatapb.Tablet{
+			Alias: &topodatapb.TabletAlias{
+				Cell: "zone1",
+				Uid:  101,
+			},
+			Keyspace: "testkeyspace",
+			Shard:    "-",
+			Type:     topodatapb.TabletType_RDONLY,
+		},
+		&topodatapb.Tablet{
+			Alias: &topodatapb.TabletAlias{
+				Cell: "zone1",
+				Uid:  102,
+			},
+			Keyspace: "otherkeyspace",
+			Shard:    "-",
+			Type:     topodatapb.TabletType_REPLICA,
+		},
+		&topodatapb.Tablet{
+			// A stale primary, which is reported as UNKNOWN.
+			Alias: &topodatapb.TabletAlias{
+				Cell: "zone2",
+				Uid:  201,
+			},
+			Keyspace: "testkeyspace",
+			Shard:    "-",
+			Type:     topodatapb.TabletType_MASTER,
+		},
+	)
+
+	// listAll pages through the tablets of the request, and returns the
+	// aliases of the tablets and their types.
+	listAll := func(t *testing.T, req *vtctldatapb.GetTabletsRequest) (pages [][]string) {
+		t.Helper()
+
+		for {
+			resp, err := vtctld.GetTablets(ctx, req)
+			require.NoError(t, err)
+
+			var page []string
+			for _, tablet := range resp.Tablets {
+				page = append(page, topoproto.TabletAliasString(tablet.Alias)+":"+tablet.Type.String())
+			}
+			pages = append(pages, page)
+			if resp.NextPageToken == "" {
+				return pages
+			}
+			req.PageToken = resp.NextPageToken
+		}
+	}
+
+	pages := listAll(t, &vtctldatapb.GetTabletsRequest{PageSize: 2})
+	assert.Equal(t, [][]string{
+		{"zone1-0000000100:MASTER", "zone1-0000000101:RDONLY"},
+		{"zone1-0000000102:REPLICA", "zone2-0000000200:REPLICA"},
+		{"zone2-0000000201:UNKNOWN"},
+	}, pages)
+
+	pages = listAll(t, &vtctldatapb.GetTabletsRequest{
+		PageSize:    2,
+		Keyspace:    "testkeyspace",
+		TabletTypes: []topodatapb.TabletType{topodatapb.TabletType_REPLICA, topodatapb.TabletType_RDONLY},
+	})
+	assert.Equal(t, [][]string{
+		{"zone1-0000000101:RDONLY", "zone2-0000000200:REPLICA"},
+		nil,
+	}, pages)
+
+	pages = listAll(t, &vtctldatapb.GetTabletsRequest{
+		PageSize: 1,
+		Keyspace: "testkeyspace",
+		Shard:    "-",
+		Cells:    []string{"zone2"},
+	})
+	assert.Equal(t, [][]string{
+		{"zone2-0000000200:REPLICA"},
+		{"zone2-0000000201:UNKNOWN"},
+	}, pages)
+
+	_, err := vtctld.GetTablets(ctx, &vtctldatapb.GetTabletsRequest{
+		PageSize:  2,
+		PageToken: "not-a-token",
+	})
+	assert.Error(t, err)
+}
+
+// fakeTabletHealthSource is a TabletHealthSource in which the tablets in
+// serving are serving, and which has the given history.
+type fakeTabletHealthSource struct {
+	serving map[string]bool
+	events  []*vtctldatapb.TabletHealthEvent
+}
+
+func (f *fakeTabletHealthSource) IsServing(alias *topodatapb.TabletAlias) bool {
+	return f.serving[topoproto.TabletAliasString(alias)]
+}
+
+func (f *fakeTabletHealthSource) HealthHistory(alias *topodatapb.TabletAlias, since, until time.Time) []*vtctldatapb.TabletHealthEvent {
+	var events []*vtctldatapb.TabletHealthEvent
+	for _, ev := range f.events {
+		t := protoutil.TimeFromProto(ev.Time)
+		if alias != nil && !topoproto.TabletAliasEqual(alias, ev.Tablet) {
+			continue
+		}
+		if t.Before(since) || (!until.IsZero() && !t.Before(until)) {
+			continue
+		}
+		events = append(events, ev)
+	}
+	return events
+}
+
+func TestGetTabletsServing(t *testing.T) {
+	// This test doesn't run in parallel, as it registers a TabletHealthSource.
+	RegisterTabletHealthSource(&fakeTabletHealthSource{
+		serving: map[string]bool{"zone1-0000000100": true},
+	})
+	defer RegisterTabletHealthSource(nil)
+
+	ctx := context.Background()
+	ts := memorytopo.NewServer("zone1")
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
+
+	serving := &topodatapb.Tablet{
+		Alias: &topodatapb.TabletAlias{
+			Cell: "zone1",
+			Uid:  100,
+		},
+		Keyspace: "testkeyspace",
+		Shard:    "-",
+		Type:     topodatapb.TabletType_REPLICA,
+	}
+	notServing := &topodatapb.Tablet{
+		Alias: &topodatapb.TabletAlias{
+			Cell: "zone1",
+			Uid:  101,
+		},
+		Keyspace: "testkeyspace",
+		Shard:    "-",
+		Type:     topodatapb.TabletType_REPLICA,
+	}
+	testutil.AddTablets(ctx, t, ts, nil, serving, notServing)
+
+	resp, err := vtctld.GetTablets(ctx, &vtctldatapb.GetTabletsRequest{
+		Serving: vtctldatapb.GetTabletsRequest_SERVING,
+	})
+	require.NoError(t, err)
+	testutil.AssertSameTablets(t, []*topodatapb.Tablet{serving}, resp.Tablets)
+
+	resp, err = vtctld.GetTablets(ctx, &vtctldatapb.GetTabletsRequest{
+		Serving:  vtctldatapb.GetTabletsRequest_NOT_SERVING,
+		PageSize: 10,
+	})
+	require.NoError(t, err)
+	testutil.AssertSameTablets(t, []*topodatapb.Tablet{notServing}, resp.Tablets)
+}
+
 func TestGetVSchema(t *testing.T) {
 	t.Parallel()
 
@@ -4356,43 +5674,456 @@ func TestGetVSchema(t *testing.T) {
 	})
 }
 
-func TestPlannedReparentShard(t *testing.T) {
+func TestGetWorkflowAuditLog(t *testing.T) {
 	t.Parallel()
 
-	tests := []struct {
-		name    string
-		ts      *topo.Server
-		tmc     tmclient.TabletManagerClient
-		tablets []*topodatapb.Tablet
+	ctx := context.Background()
+	ts := memorytopo.NewServer("zone1")
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
 
-		req                 *vtctldatapb.PlannedReparentShardRequest
-		expected            *vtctldatapb.PlannedReparentShardResponse
-		expectEventsToOccur bool
-		shouldErr           bool
-	}{
+	entries := []*workflowpb.WorkflowAuditEntry{
 		{
-			name: "successful reparent",
-			ts:   memorytopo.NewServer("zone1"),
-			tablets: []*topodatapb.Tablet{
-				{
-					Alias: &topodatapb.TabletAlias{
-						Cell: "zone1",
-						Uid:  100,
-					},
-					Type: topodatapb.TabletType_MASTER,
-					MasterTermStartTime: &vttime.Time{
-						Seconds: 100,
-					},
-					Keyspace: "testkeyspace",
-					Shard:    "-",
-				},
-				{
-					Alias: &topodatapb.TabletAlias{
-						Cell: "zone1",
-						Uid:  200,
-					},
-					Type:     topodatapb.TabletType_REPLICA,
-					Keyspace: "testkeyspace",
+			Time:    1,
+			Action:  "created",
+			Caller:  "alice",
+			Address: "10.0.0.1:1234",
+		},
+		{
+			Time:    2,
+			Action:  "started",
+			Caller:  "alice",
+			Address: "10.0.0.1:1234",
+		},
+	}
+	for _, entry := range entries {
+		require.NoError(t, ts.AppendWorkflowAuditEntry(ctx, "uuid1", entry))
+	}
+
+	resp, err := vtctld.GetWorkflowAuditLog(ctx, &vtctldatapb.GetWorkflowAuditLogRequest{Uuid: "uuid1"})
+	require.NoError(t, err)
+	utils.MustMatch(t, entries, resp.Entries)
+
+	// A workflow without audit log has no entries.
+	resp, err = vtctld.GetWorkflowAuditLog(ctx, &vtctldatapb.GetWorkflowAuditLogRequest{Uuid: "uuid2"})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Entries)
+
+	_, err = vtctld.GetWorkflowAuditLog(ctx, &vtctldatapb.GetWorkflowAuditLogRequest{})
+	assert.Error(t, err)
+}
+
+func TestGetWorkflowProgress(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ts := memorytopo.NewServer("zone1")
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
+
+	err := ts.CreateKeyspace(ctx, "testkeyspace", &topodatapb.Keyspace{})
+	require.NoError(t, err)
+
+	_, err = vtctld.GetWorkflowProgress(ctx, &vtctldatapb.GetWorkflowProgressRequest{Keyspace: "testkeyspace"})
+	assert.Error(t, err, "the workflow is required")
+
+	_, err = vtctld.GetWorkflowProgress(ctx, &vtctldatapb.GetWorkflowProgressRequest{
+		Keyspace: "testkeyspace",
+		Workflow: "noworkflow",
+	})
+	assert.Error(t, err, "the keyspace has no workflow")
+
+	errorTime := time.Unix(960, 0)
+	progress := &workflow.WorkflowProgress{
+		Workflow:                 "wf1",
+		SourceKeyspace:           "source",
+		TargetKeyspace:           "testkeyspace",
+		Phase:                    workflow.ProgressPhaseCopy,
+		RowsCopied:               500,
+		RowsEstimated:            2000,
+		CopyPercent:              25,
+		CopyRowsPerSecond:        5,
+		MaxReplicationLagSeconds: 10,
+		ErrorStreams:             1,
+		LastError:                "Error: table not found",
+		LastErrorTime:            &errorTime,
+		Streams: []*workflow.StreamProgress{
+			{
+				ID:                    1,
+				Shard:                 "-80",
+				Tablet:                &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+				SourceShard:           "0",
+				State:                 "Error",
+				Phase:                 workflow.ProgressPhaseCopy,
+				TablesToCopy:          1,
+				RowsCopied:            500,
+				RowsEstimated:         2000,
+				CopyPercent:           25,
+				CopyRowsPerSecond:     5,
+				ReplicationLagSeconds: 10,
+				LastError:             "Error: table not found",
+				LastErrorTime:         &errorTime,
+			},
+		},
+	}
+	expected := &vtctldatapb.GetWorkflowProgressResponse{
+		Workflow:                 "wf1",
+		SourceKeyspace:           "source",
+		TargetKeyspace:           "testkeyspace",
+		Phase:                    "copy",
+		RowsCopied:               500,
+		RowsEstimated:            2000,
+		CopyPercent:              25,
+		CopyRowsPerSecond:        5,
+		MaxReplicationLagSeconds: 10,
+		ErrorStreams:             1,
+		LastError:                "Error: table not found",
+		LastErrorTime:            &vttime.Time{Seconds: 960},
+		Streams: []*vtctldatapb.GetWorkflowProgressResponse_StreamProgress{
+			{
+				Id:    1,
+				Shard: "-80",
+				Tablet: &topodatapb.TabletAlias{
+					Cell: "zone1",
+					Uid:  100,
+				},
+				SourceShard:           "0",
+				State:                 "Error",
+				Phase:                 "copy",
+				TablesToCopy:          1,
+				RowsCopied:            500,
+				RowsEstimated:         2000,
+				CopyPercent:           25,
+				CopyRowsPerSecond:     5,
+				ReplicationLagSeconds: 10,
+				LastError:             "Error: table not found",
+				LastErrorTime:         &vttime.Time{Seconds: 960},
+			},
+		},
+	}
+	utils.MustMatch(t, expected, workflowProgressToProto(progress))
+}
+
+// TestListKeyspaceBackups is not parallel, as it sets the backups of the
+// test backup storage.
+func TestListKeyspaceBackups(t *testing.T) {
+	ctx := context.Background()
+	ts := memorytopo.NewServer("zone1")
+	testutil.AddShards(ctx, t, ts, &vtctldatapb.Shard{
+		Keyspace: "ks3",
+		Name:     "-80",
+	}, &vtctldatapb.Shard{
+		Keyspace: "ks3",
+		Name:     "80-",
+	})
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
+
+	testutil.BackupStorage.Backups["ks3/-80"] = []string{"2021-06-11.123456.zone1-101", "backup1"}
+	testutil.BackupStorage.Backups["ks3/80-"] = []string{"2021-06-12.123456.zone1-201"}
+	defer func() {
+		delete(testutil.BackupStorage.Backups, "ks3/-80")
+		delete(testutil.BackupStorage.Backups, "ks3/80-")
+	}()
+
+	resp, err := vtctld.ListKeyspaceBackups(ctx, &vtctldatapb.ListKeyspaceBackupsRequest{
+		Keyspace: "ks3",
+	})
+	require.NoError(t, err)
+	utils.MustMatch(t, []*mysqlctlpb.BackupInfo{
+		{
+			Name:      "2021-06-11.123456.zone1-101",
+			Directory: "ks3/-80",
+			Keyspace:  "ks3",
+			Shard:     "-80",
+			Time:      protoutil.TimeToProto(time.Date(2021, time.June, 11, 12, 34, 56, 0, time.UTC)),
+			TabletAlias: &topodatapb.TabletAlias{
+				Cell: "zone1",
+				Uid:  101,
+			},
+		},
+		{
+			Name:      "backup1",
+			Directory: "ks3/-80",
+			Keyspace:  "ks3",
+			Shard:     "-80",
+		},
+		{
+			Name:      "2021-06-12.123456.zone1-201",
+			Directory: "ks3/80-",
+			Keyspace:  "ks3",
+			Shard:     "80-",
+			Time:      protoutil.TimeToProto(time.Date(2021, time.June, 12, 12, 34, 56, 0, time.UTC)),
+			TabletAlias: &topodatapb.TabletAlias{
+				Cell: "zone1",
+				Uid:  201,
+			},
+		},
+	}, resp.Backups)
+
+	t.Run("listbackups error", func(t *testing.T) {
+		testutil.BackupStorage.ListBackupsError = assert.AnError
+		defer func() { testutil.BackupStorage.ListBackupsError = nil }()
+
+		_, err := vtctld.ListKeyspaceBackups(ctx, &vtctldatapb.ListKeyspaceBackupsRequest{
+			Keyspace: "ks3",
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestListLocks(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ts := memorytopo.NewServer("zone1")
+	testutil.AddShards(ctx, t, ts, &vtctldatapb.Shard{
+		Keyspace: "testkeyspace",
+		Name:     "-",
+	})
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
+
+	resp, err := vtctld.ListLocks(ctx, &vtctldatapb.ListLocksRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Locks)
+
+	keyspaceLock, err := vtctld.AcquireKeyspaceLock(ctx, &vtctldatapb.AcquireKeyspaceLockRequest{
+		Keyspace: "testkeyspace",
+		Purpose:  "backup",
+		Ttl:      protoutil.DurationToProto(time.Hour),
+	})
+	require.NoError(t, err)
+	shardLock, err := vtctld.AcquireKeyspaceLock(ctx, &vtctldatapb.AcquireKeyspaceLockRequest{
+		Keyspace: "testkeyspace",
+		Shard:    "-",
+		Purpose:  "failover drill",
+		Ttl:      protoutil.DurationToProto(time.Hour),
+	})
+	require.NoError(t, err)
+
+	resp, err = vtctld.ListLocks(ctx, &vtctldatapb.ListLocksRequest{})
+	require.NoError(t, err)
+	utils.MustMatch(t, []*vtctldatapb.ExternalLock{keyspaceLock.Lock, shardLock.Lock}, resp.Locks)
+
+	_, err = vtctld.ReleaseLock(ctx, &vtctldatapb.ReleaseLockRequest{Id: keyspaceLock.Lock.Id})
+	require.NoError(t, err)
+	_, err = vtctld.ReleaseLock(ctx, &vtctldatapb.ReleaseLockRequest{Id: shardLock.Lock.Id})
+	require.NoError(t, err)
+}
+
+func TestListVDiffResults(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ts := memorytopo.NewServer("zone1")
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
+
+	_, err := vtctld.ListVDiffResults(ctx, &vtctldatapb.ListVDiffResultsRequest{Keyspace: "testkeyspace"})
+	assert.Error(t, err, "the workflow is required")
+
+	_, err = vtctld.ListVDiffResults(ctx, &vtctldatapb.ListVDiffResultsRequest{
+		Keyspace: "testkeyspace",
+		Workflow: "wf1",
+		Limit:    -1,
+	})
+	assert.Error(t, err, "the limit must be non-negative")
+
+	resp, err := vtctld.ListVDiffResults(ctx, &vtctldatapb.ListVDiffResultsRequest{
+		Keyspace: "testkeyspace",
+		Workflow: "wf1",
+	})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Results, "the workflow has no results")
+
+	for i := 1; i <= 3; i++ {
+		err := ts.AppendVDiffResult(ctx, "testkeyspace", "wf1", &workflowpb.VDiffResult{
+			StartTime: &vttime.Time{Seconds: int64(i * 100)},
+			EndTime:   &vttime.Time{Seconds: int64(i*100 + 10)},
+			Tables: map[string]*workflowpb.VDiffTableResult{
+				"t1": {
+					ProcessedRows:  int64(i * 10),
+					MatchingRows:   int64(i*10 - 1),
+					MismatchedRows: 1,
+				},
+			},
+		}, 0)
+		require.NoError(t, err)
+	}
+
+	resp, err = vtctld.ListVDiffResults(ctx, &vtctldatapb.ListVDiffResultsRequest{
+		Keyspace: "testkeyspace",
+		Workflow: "wf1",
+		Limit:    2,
+	})
+	require.NoError(t, err)
+
+	expected := &vtctldatapb.ListVDiffResultsResponse{
+		Results: []*workflowpb.VDiffResult{
+			{
+				StartTime: &vttime.Time{Seconds: 300},
+				EndTime:   &vttime.Time{Seconds: 310},
+				Tables: map[string]*workflowpb.VDiffTableResult{
+					"t1": {
+						ProcessedRows:  30,
+						MatchingRows:   29,
+						MismatchedRows: 1,
+					},
+				},
+			},
+			{
+				StartTime: &vttime.Time{Seconds: 200},
+				EndTime:   &vttime.Time{Seconds: 210},
+				Tables: map[string]*workflowpb.VDiffTableResult{
+					"t1": {
+						ProcessedRows:  20,
+						MatchingRows:   19,
+						MismatchedRows: 1,
+					},
+				},
+			},
+		},
+	}
+	utils.MustMatch(t, expected, resp)
+}
+
+func TestPlanReparentShard(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ts := memorytopo.NewServer("zone1")
+	testutil.AddTablets(ctx, t, ts, &testutil.AddTabletOptions{
+		AlsoSetShardMaster: true,
+	}, &topodatapb.Tablet{
+		Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+		Keyspace: "testkeyspace",
+		Shard:    "-",
+		Type:     topodatapb.TabletType_MASTER,
+	}, &topodatapb.Tablet{
+		Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 101},
+		Keyspace: "testkeyspace",
+		Shard:    "-",
+		Type:     topodatapb.TabletType_REPLICA,
+	}, &topodatapb.Tablet{
+		Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 102},
+		Keyspace: "testkeyspace",
+		Shard:    "-",
+		Type:     topodatapb.TabletType_REPLICA,
+	})
+
+	tmc := &testutil.TabletManagerClient{
+		ReplicationStatusResults: map[string]struct {
+			Position *replicationdatapb.Status
+			Error    error
+		}{
+			// The status of the primary can't be read, which a plan
+			// tolerates for one tablet.
+			"zone1-0000000100": {Error: assert.AnError},
+			"zone1-0000000101": {Position: &replicationdatapb.Status{
+				Position:            "MySQL56/3e11fa47-71ca-11e1-9e33-c80aa9429562:1-10",
+				SecondsBehindMaster: 1,
+			}},
+			"zone1-0000000102": {Position: &replicationdatapb.Status{
+				Position:            "MySQL56/3e11fa47-71ca-11e1-9e33-c80aa9429562:1-5",
+				SecondsBehindMaster: 5,
+			}},
+		},
+	}
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, tmc, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
+
+	resp, err := vtctld.PlanReparentShard(ctx, &vtctldatapb.PlanReparentShardRequest{
+		Keyspace: "testkeyspace",
+		Shard:    "-",
+	})
+	require.NoError(t, err)
+	utils.MustMatch(t, &vtctldatapb.PlanReparentShardResponse{
+		Keyspace:       "testkeyspace",
+		Shard:          "-",
+		CurrentPrimary: &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+		NewPrimary:     &topodatapb.TabletAlias{Cell: "zone1", Uid: 101},
+		Candidates: []*vtctldatapb.PlanReparentShardResponse_Candidate{{
+			Tablet:     &topodatapb.TabletAlias{Cell: "zone1", Uid: 101},
+			Rank:       1,
+			Position:   "MySQL56/3e11fa47-71ca-11e1-9e33-c80aa9429562:1-10",
+			LagSeconds: 1,
+		}, {
+			Tablet:     &topodatapb.TabletAlias{Cell: "zone1", Uid: 102},
+			Rank:       2,
+			Position:   "MySQL56/3e11fa47-71ca-11e1-9e33-c80aa9429562:1-5",
+			LagSeconds: 5,
+		}, {
+			Tablet:     &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+			Ineligible: "its replication status cannot be read",
+		}},
+		Repointed: []*topodatapb.TabletAlias{
+			{Cell: "zone1", Uid: 100},
+			{Cell: "zone1", Uid: 102},
+		},
+		ExpectedDowntime: "about 1s, while the new primary catches up with the demoted primary",
+	}, resp)
+
+	// Planning a given new primary warns when it is not the best one.
+	resp, err = vtctld.PlanReparentShard(ctx, &vtctldatapb.PlanReparentShardRequest{
+		Keyspace:   "testkeyspace",
+		Shard:      "-",
+		NewPrimary: &topodatapb.TabletAlias{Cell: "zone1", Uid: 102},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "zone1-0000000102", topoproto.TabletAliasString(resp.NewPrimary))
+	assert.Equal(t, []string{"the requested new primary zone1-0000000102 is not the best candidate zone1-0000000101"}, resp.Warnings)
+
+	_, err = vtctld.PlanReparentShard(ctx, &vtctldatapb.PlanReparentShardRequest{
+		Keyspace: "otherkeyspace",
+		Shard:    "-",
+	})
+	assert.Error(t, err)
+}
+
+func TestPlannedReparentShard(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		ts      *topo.Server
+		tmc     tmclient.TabletManagerClient
+		tablets []*topodatapb.Tablet
+
+		req                 *vtctldatapb.PlannedReparentShardRequest
+		expected            *vtctldatapb.PlannedReparentShardResponse
+		expectEventsToOccur bool
+		shouldErr           bool
+	}{
+		{
+			name: "successful reparent",
+			ts:   memorytopo.NewServer("zone1"),
+			tablets: []*topodatapb.Tablet{
+				{
+					Alias: &topodatapb.TabletAlias{
+						Cell: "zone1",
+						Uid:  100,
+					},
+					Type: topodatapb.TabletType_MASTER,
+					MasterTermStartTime: &vttime.Time{
+						Seconds: 100,
+					},
+					Keyspace: "testkeyspace",
+					Shard:    "-",
+				},
+				{
+					Alias: &topodatapb.TabletAlias{
+						Cell: "zone1",
+						Uid:  200,
+					},
+					Type:     topodatapb.TabletType_REPLICA,
+					Keyspace: "testkeyspace",
 					Shard:    "-",
 				},
 				{
@@ -4542,62 +6273,280 @@ func TestPlannedReparentShard(t *testing.T) {
 	}
 }
 
-func TestRebuildVSchemaGraph(t *testing.T) {
+// TestPruneBackups is not parallel, as it sets the backups of the test backup
+// storage.
+func TestPreviewDeclarativeSchema(t *testing.T) {
 	t.Parallel()
 
 	ctx := context.Background()
-	req := &vtctldatapb.RebuildVSchemaGraphRequest{}
+	ts := memorytopo.NewServer("zone1")
+	testutil.AddShards(ctx, t, ts, &vtctldatapb.Shard{
+		Keyspace: "testkeyspace",
+		Name:     "-",
+	})
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
+
 	tests := []struct {
-		name      string
-		topoDown  bool
-		shouldErr bool
+		name string
+		req  *vtctldatapb.PreviewDeclarativeSchemaRequest
 	}{
 		{
-			name: "success",
+			name: "no keyspace",
+			req: &vtctldatapb.PreviewDeclarativeSchemaRequest{
+				Sql: "create table t1 (id int, primary key (id))",
+			},
 		},
 		{
-			name:      "topo down",
-			topoDown:  true,
-			shouldErr: true,
+			name: "not a create table",
+			req: &vtctldatapb.PreviewDeclarativeSchemaRequest{
+				Keyspace: "testkeyspace",
+				Sql:      "alter table t1 add column c int",
+			},
+		},
+		{
+			name: "no primary",
+			req: &vtctldatapb.PreviewDeclarativeSchemaRequest{
+				Keyspace: "testkeyspace",
+				Sql:      "create table t1 (id int, primary key (id))",
+			},
 		},
 	}
 
 	for _, tt := range tests {
 		tt := tt
+
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			ts, factory := memorytopo.NewServerAndFactory("zone1")
-			if tt.topoDown {
-				factory.SetError(errors.New("topo down for testing"))
-			}
-
-			vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
-				return NewVtctldServer(ts)
-			})
-			_, err := vtctld.RebuildVSchemaGraph(ctx, req)
-			if tt.shouldErr {
-				assert.Error(t, err)
-				return
-			}
-
-			assert.NoError(t, err)
+			_, err := vtctld.PreviewDeclarativeSchema(ctx, tt.req)
+			assert.Error(t, err)
 		})
 	}
 }
 
-func TestRefreshState(t *testing.T) {
-	t.Parallel()
-
+func TestPruneBackups(t *testing.T) {
 	ctx := context.Background()
-	tests := []struct {
-		name              string
-		ts                *topo.Server
-		tablet            *topodatapb.Tablet
-		refreshStateError error
-		req               *vtctldatapb.RefreshStateRequest
-		shouldErr         bool
-	}{
+	ts := memorytopo.NewServer("zone1")
+	testutil.AddShards(ctx, t, ts, &vtctldatapb.Shard{
+		Keyspace: "ks4",
+		Name:     "-",
+	})
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
+
+	testutil.BackupStorage.Backups["ks4/-"] = []string{
+		"2021-06-10.123456.zone1-101",
+		"2021-06-11.123456.zone1-101",
+		"2021-06-12.123456.zone1-101",
+	}
+	defer delete(testutil.BackupStorage.Backups, "ks4/-")
+
+	resp, err := vtctld.PruneBackups(ctx, &vtctldatapb.PruneBackupsRequest{
+		Keyspace: "ks4",
+		KeepLast: 2,
+		DryRun:   true,
+	})
+	require.NoError(t, err)
+	utils.MustMatch(t, []*mysqlctlpb.BackupInfo{
+		{
+			Name:      "2021-06-10.123456.zone1-101",
+			Directory: "ks4/-",
+			Keyspace:  "ks4",
+			Shard:     "-",
+			Time:      protoutil.TimeToProto(time.Date(2021, time.June, 10, 12, 34, 56, 0, time.UTC)),
+			TabletAlias: &topodatapb.TabletAlias{
+				Cell: "zone1",
+				Uid:  101,
+			},
+		},
+	}, resp.Pruned)
+
+	_, err = vtctld.PruneBackups(ctx, &vtctldatapb.PruneBackupsRequest{
+		Keyspace: "ks4",
+		DryRun:   true,
+	})
+	assert.Error(t, err, "a retention must be set")
+}
+
+func TestRebuildKeyspaceGraph(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ts := memorytopo.NewServer("zone1")
+	require.NoError(t, ts.CreateKeyspace(ctx, "testkeyspace", &topodatapb.Keyspace{}))
+	require.NoError(t, ts.CreateShard(ctx, "testkeyspace", "0"))
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
+
+	resp, err := vtctld.RebuildKeyspaceGraph(ctx, &vtctldatapb.RebuildKeyspaceGraphRequest{
+		Keyspace: "testkeyspace",
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Report.Cells, 1)
+	assert.Equal(t, "testkeyspace", resp.Report.Keyspace)
+	assert.Equal(t, "zone1", resp.Report.Cells[0].Cell)
+	assert.True(t, resp.Report.Cells[0].Changed)
+	utils.MustMatch(t, []*vtctldatapb.RebuildReport_PartitionChange{
+		{ServedType: topodatapb.TabletType_MASTER, AddedShards: []string{"0"}},
+		{ServedType: topodatapb.TabletType_REPLICA, AddedShards: []string{"0"}},
+		{ServedType: topodatapb.TabletType_RDONLY, AddedShards: []string{"0"}},
+	}, resp.Report.Cells[0].PartitionChanges)
+
+	// Nothing changes.
+	resp, err = vtctld.RebuildKeyspaceGraph(ctx, &vtctldatapb.RebuildKeyspaceGraphRequest{
+		Keyspace: "testkeyspace",
+	})
+	require.NoError(t, err)
+	assert.False(t, resp.Report.Cells[0].Changed)
+	assert.Empty(t, resp.Report.Cells[0].PartitionChanges)
+
+	_, err = vtctld.RebuildKeyspaceGraph(ctx, &vtctldatapb.RebuildKeyspaceGraphRequest{
+		Keyspace: "otherkeyspace",
+	})
+	assert.Error(t, err)
+}
+
+func TestRebuildVSchemaGraph(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	req := &vtctldatapb.RebuildVSchemaGraphRequest{}
+	tests := []struct {
+		name      string
+		topoDown  bool
+		shouldErr bool
+	}{
+		{
+			name: "success",
+		},
+		{
+			name:      "topo down",
+			topoDown:  true,
+			shouldErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ts, factory := memorytopo.NewServerAndFactory("zone1")
+			if tt.topoDown {
+				factory.SetError(errors.New("topo down for testing"))
+			}
+
+			vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+				return NewVtctldServer(ts)
+			})
+			resp, err := vtctld.RebuildVSchemaGraph(ctx, req)
+			if tt.shouldErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, resp.Report.Cells, 1)
+			assert.Equal(t, "zone1", resp.Report.Cells[0].Cell)
+			assert.Empty(t, resp.Report.Cells[0].Error)
+			assert.NotEmpty(t, resp.Report.Cells[0].NewVersion)
+			assert.True(t, resp.Report.Cells[0].Changed)
+		})
+	}
+}
+
+func TestRecommendTabletTypeChange(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ts := memorytopo.NewServer("zone1")
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, &testutil.TabletManagerClient{
+		ReplicationStatusResults: map[string]struct {
+			Position *replicationdatapb.Status
+			Error    error
+		}{
+			"zone1-0000000100": {
+				Position: &replicationdatapb.Status{SecondsBehindMaster: 1},
+			},
+			"zone1-0000000101": {
+				Position: &replicationdatapb.Status{SecondsBehindMaster: 10},
+			},
+		},
+	}, func(ts *topo.Server) vtctlservicepb.VtctldServer { return NewVtctldServer(ts) })
+
+	testutil.AddTablets(ctx, t, ts, nil,
+		&topodatapb.Tablet{
+			Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+			Keyspace: "testkeyspace",
+			Shard:    "-",
+			Type:     topodatapb.TabletType_RDONLY,
+		},
+		&topodatapb.Tablet{
+			Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 101},
+			Keyspace: "testkeyspace",
+			Shard:    "-",
+			Type:     topodatapb.TabletType_RDONLY,
+		},
+		&topodatapb.Tablet{
+			Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 102},
+			Keyspace: "testkeyspace",
+			Shard:    "-",
+			Type:     topodatapb.TabletType_RDONLY,
+		},
+		&topodatapb.Tablet{
+			Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 103},
+			Keyspace: "testkeyspace",
+			Shard:    "-",
+			Type:     topodatapb.TabletType_REPLICA,
+		},
+	)
+
+	resp, err := vtctld.RecommendTabletTypeChange(ctx, &vtctldatapb.RecommendTabletTypeChangeRequest{
+		Keyspace: "testkeyspace",
+		Shard:    "-",
+		Cell:     "zone1",
+		FromType: topodatapb.TabletType_RDONLY,
+		ToType:   topodatapb.TabletType_REPLICA,
+	})
+	require.NoError(t, err)
+
+	// The unreachable tablet first, then the most lagging one.
+	var got []uint32
+	for _, candidate := range resp.Candidates {
+		got = append(got, candidate.Impact.TabletAlias.Uid)
+		assert.Equal(t, uint32(2), candidate.Impact.Remaining)
+		assert.Empty(t, candidate.Impact.Violation)
+	}
+	assert.Equal(t, []uint32{102, 101, 100}, got)
+	assert.False(t, resp.Candidates[0].Reachable)
+	assert.Equal(t, uint32(10), resp.Candidates[1].ReplicationLagSeconds)
+
+	_, err = vtctld.RecommendTabletTypeChange(ctx, &vtctldatapb.RecommendTabletTypeChangeRequest{
+		Keyspace: "testkeyspace",
+		Shard:    "-",
+		Cell:     "zone1",
+		FromType: topodatapb.TabletType_REPLICA,
+		ToType:   topodatapb.TabletType_MASTER,
+	})
+	assert.Error(t, err, "promotions are not allowed transitions")
+}
+
+func TestRefreshState(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	tests := []struct {
+		name              string
+		ts                *topo.Server
+		tablet            *topodatapb.Tablet
+		refreshStateError error
+		req               *vtctldatapb.RefreshStateRequest
+		shouldErr         bool
+	}{
 		{
 			name: "success",
 			ts:   memorytopo.NewServer("zone1"),
@@ -4874,6 +6823,90 @@ func TestRefreshStateByShard(t *testing.T) {
 	}
 }
 
+func TestRejectMigration(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ts := memorytopo.NewServer("zone1")
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
+
+	tests := []struct {
+		name string
+		req  *vtctldatapb.RejectMigrationRequest
+	}{
+		{
+			name: "no keyspace",
+			req: &vtctldatapb.RejectMigrationRequest{
+				Uuid:     "bfb5a06b_6fa2_11eb_a1d2_0a43f95f28a3",
+				Reviewer: "alice",
+			},
+		},
+		{
+			name: "invalid uuid",
+			req: &vtctldatapb.RejectMigrationRequest{
+				Keyspace: "testkeyspace",
+				Uuid:     "not-a-uuid",
+				Reviewer: "alice",
+			},
+		},
+		{
+			name: "no reviewer",
+			req: &vtctldatapb.RejectMigrationRequest{
+				Keyspace: "testkeyspace",
+				Uuid:     "bfb5a06b_6fa2_11eb_a1d2_0a43f95f28a3",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := vtctld.RejectMigration(ctx, tt.req)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestReleaseLock(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ts := memorytopo.NewServer("zone1")
+	testutil.AddKeyspace(ctx, t, ts, &vtctldatapb.Keyspace{
+		Name:     "testkeyspace",
+		Keyspace: &topodatapb.Keyspace{},
+	})
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
+
+	lock, err := vtctld.AcquireKeyspaceLock(ctx, &vtctldatapb.AcquireKeyspaceLockRequest{
+		Keyspace: "testkeyspace",
+		Purpose:  "backup",
+		Ttl:      protoutil.DurationToProto(time.Hour),
+	})
+	require.NoError(t, err)
+
+	resp, err := vtctld.ReleaseLock(ctx, &vtctldatapb.ReleaseLockRequest{Id: lock.Lock.Id})
+	require.NoError(t, err)
+	utils.MustMatch(t, lock.Lock, resp.Lock)
+
+	// The keyspace can be locked again.
+	_, unlock, err := ts.LockKeyspace(ctx, "testkeyspace", "test")
+	require.NoError(t, err)
+	unlock(&err)
+	require.NoError(t, err)
+
+	// A lock can only be released once.
+	_, err = vtctld.ReleaseLock(ctx, &vtctldatapb.ReleaseLockRequest{Id: lock.Lock.Id})
+	assert.True(t, topo.IsErrType(err, topo.NoNode), "%v", err)
+}
+
 func TestRemoveKeyspaceCell(t *testing.T) {
 	t.Parallel()
 
@@ -5932,239 +7965,105 @@ func TestReparentTablet(t *testing.T) {
 	}
 }
 
-func TestShardReplicationPositions(t *testing.T) {
+func TestRestoreToPointInTime(t *testing.T) {
 	t.Parallel()
 
+	ctx := context.Background()
+	ts := memorytopo.NewServer("zone1")
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
+
 	tests := []struct {
-		name       string
-		ts         *topo.Server
-		tablets    []*topodatapb.Tablet
-		tmc        tmclient.TabletManagerClient
-		ctxTimeout time.Duration
-		req        *vtctldatapb.ShardReplicationPositionsRequest
-		expected   *vtctldatapb.ShardReplicationPositionsResponse
-		shouldErr  bool
+		name string
+		req  *vtctldatapb.RestoreToPointInTimeRequest
 	}{
 		{
-			name: "success",
-			ts:   memorytopo.NewServer("zone1"),
-			tablets: []*topodatapb.Tablet{
-				{
-					Alias: &topodatapb.TabletAlias{
-						Cell: "zone1",
-						Uid:  100,
-					},
-					Keyspace: "testkeyspace",
-					Shard:    "-",
-					Type:     topodatapb.TabletType_MASTER,
-				},
-				{
-					Alias: &topodatapb.TabletAlias{
-						Cell: "zone1",
-						Uid:  101,
-					},
-					Keyspace: "testkeyspace",
-					Shard:    "-",
-					Type:     topodatapb.TabletType_REPLICA,
-				},
+			name: "no shard",
+			req: &vtctldatapb.RestoreToPointInTimeRequest{
+				Keyspace:           "testkeyspace",
+				RestoreToTimestamp: protoutil.TimeToProto(time.Now().Add(-time.Hour)),
 			},
-			tmc: &testutil.TabletManagerClient{
-				MasterPositionResults: map[string]struct {
-					Position string
-					Error    error
-				}{
-					"zone1-0000000100": {
-						Position: "primary_tablet_position",
-					},
-				},
-				ReplicationStatusResults: map[string]struct {
-					Position *replicationdatapb.Status
-					Error    error
-				}{
-					"zone1-0000000101": {
-						Position: &replicationdatapb.Status{
-							Position: "replica_tablet_position",
-						},
-					},
-				},
+		},
+		{
+			name: "invalid position",
+			req: &vtctldatapb.RestoreToPointInTimeRequest{
+				Keyspace:     "testkeyspace",
+				Shard:        "-",
+				RestoreToPos: "not-a-position",
 			},
-			req: &vtctldatapb.ShardReplicationPositionsRequest{
+		},
+		{
+			name: "no point in time",
+			req: &vtctldatapb.RestoreToPointInTimeRequest{
 				Keyspace: "testkeyspace",
 				Shard:    "-",
 			},
-			expected: &vtctldatapb.ShardReplicationPositionsResponse{
-				ReplicationStatuses: map[string]*replicationdatapb.Status{
-					"zone1-0000000100": {
-						Position: "primary_tablet_position",
-					},
-					"zone1-0000000101": {
-						Position: "replica_tablet_position",
-					},
-				},
-				TabletMap: map[string]*topodatapb.Tablet{
-					"zone1-0000000100": {
-						Alias: &topodatapb.TabletAlias{
-							Cell: "zone1",
-							Uid:  100,
-						},
-						Keyspace: "testkeyspace",
-						Shard:    "-",
-						Type:     topodatapb.TabletType_MASTER,
-					},
-					"zone1-0000000101": {
-						Alias: &topodatapb.TabletAlias{
-							Cell: "zone1",
-							Uid:  101,
-						},
-						Keyspace: "testkeyspace",
-						Shard:    "-",
-						Type:     topodatapb.TabletType_REPLICA,
-					},
-				},
-			},
-			shouldErr: false,
 		},
 		{
-			name: "timeouts are nonfatal",
-			ts:   memorytopo.NewServer("zone1"),
-			tablets: []*topodatapb.Tablet{
-				{
-					Alias: &topodatapb.TabletAlias{
-						Cell: "zone1",
-						Uid:  100,
-					},
-					Keyspace: "testkeyspace",
-					Shard:    "-",
-					Type:     topodatapb.TabletType_MASTER,
-				},
-				{
-					Alias: &topodatapb.TabletAlias{
-						Cell: "zone1",
-						Uid:  101,
-					},
-					Keyspace: "testkeyspace",
-					Shard:    "-",
-					Type:     topodatapb.TabletType_REPLICA,
-				},
-			},
-			tmc: &testutil.TabletManagerClient{
-				MasterPositionDelays: map[string]time.Duration{
-					"zone1-0000000100": time.Millisecond * 100,
-				},
-				MasterPositionResults: map[string]struct {
-					Position string
-					Error    error
-				}{
-					"zone1-0000000100": {
-						Position: "primary_tablet_position",
-					},
-				},
-				ReplicationStatusDelays: map[string]time.Duration{
-					"zone1-0000000101": time.Millisecond * 100,
-				},
-				ReplicationStatusResults: map[string]struct {
-					Position *replicationdatapb.Status
-					Error    error
-				}{
-					"zone1-0000000101": {
-						Position: &replicationdatapb.Status{
-							Position: "replica_tablet_position",
-						},
-					},
-				},
+			name: "position and time",
+			req: &vtctldatapb.RestoreToPointInTimeRequest{
+				Keyspace:           "testkeyspace",
+				Shard:              "-",
+				RestoreToPos:       "MySQL56/16b1039f-22b6-11ed-b765-0a43f95f28a3:1-100",
+				RestoreToTimestamp: protoutil.TimeToProto(time.Now().Add(-time.Hour)),
 			},
-			ctxTimeout: time.Millisecond * 10,
-			req: &vtctldatapb.ShardReplicationPositionsRequest{
-				Keyspace: "testkeyspace",
-				Shard:    "-",
-			},
-			expected: &vtctldatapb.ShardReplicationPositionsResponse{
-				ReplicationStatuses: map[string]*replicationdatapb.Status{
-					"zone1-0000000100": nil,
-					"zone1-0000000101": nil,
-				},
-				TabletMap: map[string]*topodatapb.Tablet{
-					"zone1-0000000100": {
-						Alias: &topodatapb.TabletAlias{
-							Cell: "zone1",
-							Uid:  100,
-						},
-						Keyspace: "testkeyspace",
-						Shard:    "-",
-						Type:     topodatapb.TabletType_MASTER,
-					},
-					"zone1-0000000101": {
-						Alias: &topodatapb.TabletAlias{
-							Cell: "zone1",
-							Uid:  101,
-						},
-						Keyspace: "testkeyspace",
-						Shard:    "-",
-						Type:     topodatapb.TabletType_REPLICA,
-					},
-				},
-			},
-			shouldErr: false,
 		},
 		{
-			name: "other rpc errors are fatal",
-			ts:   memorytopo.NewServer("zone1"),
-			tablets: []*topodatapb.Tablet{
-				{
-					Alias: &topodatapb.TabletAlias{
-						Cell: "zone1",
-						Uid:  100,
-					},
-					Keyspace: "testkeyspace",
-					Shard:    "-",
-					Type:     topodatapb.TabletType_MASTER,
-				},
-				{
-					Alias: &topodatapb.TabletAlias{
-						Cell: "zone1",
-						Uid:  101,
-					},
-					Keyspace: "testkeyspace",
-					Shard:    "-",
-					Type:     topodatapb.TabletType_REPLICA,
-				},
+			name: "time in the future",
+			req: &vtctldatapb.RestoreToPointInTimeRequest{
+				Keyspace:           "testkeyspace",
+				Shard:              "-",
+				RestoreToTimestamp: protoutil.TimeToProto(time.Now().Add(time.Hour)),
 			},
-			tmc: &testutil.TabletManagerClient{
-				MasterPositionResults: map[string]struct {
-					Position string
-					Error    error
-				}{
-					"zone1-0000000100": {
-						Error: assert.AnError,
-					},
-				},
-				ReplicationStatusResults: map[string]struct {
-					Position *replicationdatapb.Status
-					Error    error
-				}{
-					"zone1-0000000101": {
-						Position: &replicationdatapb.Status{
-							Position: "replica_tablet_position",
-						},
-					},
-				},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := vtctld.RestoreToPointInTime(ctx, tt.req)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestRevertMigration(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ts := memorytopo.NewServer("zone1")
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
+
+	tests := []struct {
+		name string
+		req  *vtctldatapb.RevertMigrationRequest
+	}{
+		{
+			name: "no keyspace",
+			req: &vtctldatapb.RevertMigrationRequest{
+				Uuid: "bfb5a06b_6fa2_11eb_a1d2_0a43f95f28a3",
 			},
-			req: &vtctldatapb.ShardReplicationPositionsRequest{
+		},
+		{
+			name: "invalid uuid",
+			req: &vtctldatapb.RevertMigrationRequest{
 				Keyspace: "testkeyspace",
-				Shard:    "-",
+				Uuid:     "not-a-uuid",
 			},
-			expected:  nil,
-			shouldErr: true,
 		},
 		{
-			name: "nonexistent shard",
-			ts:   memorytopo.NewServer("zone1"),
-			req: &vtctldatapb.ShardReplicationPositionsRequest{
+			name: "invalid uuid dry run",
+			req: &vtctldatapb.RevertMigrationRequest{
 				Keyspace: "testkeyspace",
-				Shard:    "-",
+				Uuid:     "not-a-uuid",
+				DryRun:   true,
 			},
-			expected:  nil,
-			shouldErr: true,
 		},
 	}
 
@@ -6174,57 +8073,1025 @@ func TestShardReplicationPositions(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			ctx := context.Background()
-
-			testutil.AddTablets(ctx, t, tt.ts, &testutil.AddTabletOptions{
-				AlsoSetShardMaster: true,
-				SkipShardCreation:  false,
-			}, tt.tablets...)
-
-			vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, tt.ts, tt.tmc, func(ts *topo.Server) vtctlservicepb.VtctldServer {
-				return NewVtctldServer(ts)
-			})
+			_, err := vtctld.RevertMigration(ctx, tt.req)
+			assert.Error(t, err)
+		})
+	}
+}
 
-			if tt.ctxTimeout > 0 {
-				_ctx, cancel := context.WithTimeout(ctx, tt.ctxTimeout)
-				defer cancel()
+func TestSeedTable(t *testing.T) {
+	t.Parallel()
 
-				ctx = _ctx
-			}
+	ctx := context.Background()
+	ts := memorytopo.NewServer("zone1")
+	tablet := func(uid uint32, keyspace, shard string) *topodatapb.Tablet {
+		return &topodatapb.Tablet{
+			Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: uid},
+			Keyspace: keyspace,
+			Shard:    shard,
+			Type:     topodatapb.TabletType_MASTER,
+		}
+	}
+	testutil.AddTablets(ctx, t, ts, &testutil.AddTabletOptions{AlsoSetShardMaster: true},
+		tablet(100, "source", "0"),
+		tablet(200, "testkeyspace", "-80"),
+		tablet(300, "testkeyspace", "80-"),
+	)
+	require.NoError(t, ts.SaveVSchema(ctx, "source", &vschemapb.Keyspace{}))
+	require.NoError(t, ts.SaveVSchema(ctx, "testkeyspace", &vschemapb.Keyspace{
+		Sharded: true,
+		Vindexes: map[string]*vschemapb.Vindex{
+			"hash": {Type: "hash"},
+		},
+		Tables: map[string]*vschemapb.Table{
+			"countries": {
+				ColumnVindexes: []*vschemapb.ColumnVindex{{Column: "id", Name: "hash"}},
+				Seed: &vschemapb.TableSeed{
+					SourceTable: &vschemapb.TableSeed_SourceTable{Keyspace: "source", Table: "countries"},
+				},
+			},
+			"cities": {
+				ColumnVindexes: []*vschemapb.ColumnVindex{{Column: "id", Name: "hash"}},
+			},
+		},
+	}))
 
-			resp, err := vtctld.ShardReplicationPositions(ctx, tt.req)
-			if tt.shouldErr {
-				assert.Error(t, err)
+	type result = struct {
+		Result *querypb.QueryResult
+		Error  error
+	}
+	tmc := &testutil.TabletManagerClient{
+		ExecuteFetchAsAppResults: map[string]map[string]result{
+			"zone1-0000000100": {
+				"select * from `countries`": {
+					Result: sqltypes.ResultToProto3(sqltypes.MakeTestResult(
+						sqltypes.MakeTestFields("id|name", "int64|varchar"),
+						"1|a",
+						"4|d",
+					)),
+				},
+			},
+			// The hash of 1 is in -80, the one of 4 in 80-.
+			"zone1-0000000200": {
+				"insert ignore into `countries` (`id`, `name`) values (1, 'a')": {Result: &querypb.QueryResult{}},
+			},
+			"zone1-0000000300": {
+				"insert ignore into `countries` (`id`, `name`) values (4, 'd')": {Error: assert.AnError},
+			},
+		},
+	}
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, tmc, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
 
-				return
-			}
+	// The error of a shard is in the response.
+	resp, err := vtctld.SeedTable(ctx, &vtctldatapb.SeedTableRequest{
+		Keyspace: "testkeyspace",
+		Table:    "countries",
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Shards, 2)
+	utils.MustMatch(t, &vtctldatapb.SeedTableResponse_ShardProgress{
+		Shard:      "-80",
+		Primary:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 200},
+		RowsLoaded: 1,
+		Batches:    1,
+	}, resp.Shards[0])
+	assert.Equal(t, "80-", resp.Shards[1].Shard)
+	assert.Zero(t, resp.Shards[1].RowsLoaded)
+	assert.NotEmpty(t, resp.Shards[1].Error)
+
+	// Tables without a seed source can't be seeded.
+	_, err = vtctld.SeedTable(ctx, &vtctldatapb.SeedTableRequest{
+		Keyspace: "testkeyspace",
+		Table:    "cities",
+	})
+	assert.Error(t, err)
 
-			assert.NoError(t, err)
-			utils.MustMatch(t, tt.expected, resp)
-		})
-	}
+	_, err = vtctld.SeedTable(ctx, &vtctldatapb.SeedTableRequest{Keyspace: "testkeyspace"})
+	assert.Error(t, err)
 }
 
-func TestTabletExternallyReparented(t *testing.T) {
+func TestSetBackupSchedule(t *testing.T) {
 	t.Parallel()
 
+	ctx := context.Background()
+	ts := memorytopo.NewServer("zone1")
+	testutil.AddKeyspace(ctx, t, ts, &vtctldatapb.Keyspace{
+		Name:     "testkeyspace",
+		Keyspace: &topodatapb.Keyspace{},
+	})
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
+
+	_, err := vtctld.SetBackupSchedule(ctx, &vtctldatapb.SetBackupScheduleRequest{
+		Keyspace: "testkeyspace",
+		Schedule: &topodatapb.BackupSchedule{
+			Cron:         "@daily",
+			AllowPrimary: true,
+			MaxAge:       protoutil.DurationToProto(48 * time.Hour),
+			LastError:    "ignored",
+		},
+	})
+	require.NoError(t, err)
+
+	schedule, err := ts.GetBackupSchedule(ctx, "testkeyspace")
+	require.NoError(t, err)
+	require.NotNil(t, schedule)
+	assert.Equal(t, "@daily", schedule.Cron)
+	assert.True(t, schedule.AllowPrimary)
+	utils.MustMatch(t, protoutil.DurationToProto(48*time.Hour), schedule.MaxAge)
+	assert.NotNil(t, schedule.CreateTime)
+	assert.Empty(t, schedule.LastError)
+
 	tests := []struct {
-		name         string
-		topo         []*topodatapb.Tablet
-		topoErr      error
-		tmcHasNoTopo bool
-		req          *vtctldatapb.TabletExternallyReparentedRequest
-		expected     *vtctldatapb.TabletExternallyReparentedResponse
-		shouldErr    bool
-		expectedTopo []*topodatapb.Tablet
+		name string
+		req  *vtctldatapb.SetBackupScheduleRequest
 	}{
 		{
-			name: "success",
-			topo: []*topodatapb.Tablet{
-				{
-					Alias: &topodatapb.TabletAlias{
-						Cell: "zone1",
-						Uid:  100,
+			name: "no schedule",
+			req: &vtctldatapb.SetBackupScheduleRequest{
+				Keyspace: "testkeyspace",
+			},
+		},
+		{
+			name: "invalid cron",
+			req: &vtctldatapb.SetBackupScheduleRequest{
+				Keyspace: "testkeyspace",
+				Schedule: &topodatapb.BackupSchedule{
+					Cron: "not a cron",
+				},
+			},
+		},
+		{
+			name: "keyspace not found",
+			req: &vtctldatapb.SetBackupScheduleRequest{
+				Keyspace: "otherkeyspace",
+				Schedule: &topodatapb.BackupSchedule{
+					Cron: "@daily",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := vtctld.SetBackupSchedule(ctx, tt.req)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestSetBackupStorageConfig(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ts := memorytopo.NewServer("zone1")
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
+
+	testutil.AddKeyspace(ctx, t, ts, &vtctldatapb.Keyspace{
+		Name:     "testkeyspace",
+		Keyspace: &topodatapb.Keyspace{},
+	})
+
+	config := &topodatapb.BackupStorageConfig{
+		S3: &topodatapb.BackupStorageConfig_S3{
+			Bucket:        "backups",
+			SseKmsKeyId:   "key",
+			Retries:       3,
+			MaxRetryDelay: protoutil.DurationToProto(10 * time.Second),
+		},
+	}
+
+	_, err := vtctld.SetBackupStorageConfig(ctx, &vtctldatapb.SetBackupStorageConfigRequest{
+		Keyspace: "testkeyspace",
+		Config:   config,
+	})
+	require.NoError(t, err)
+
+	resp, err := vtctld.GetBackupStorageConfig(ctx, &vtctldatapb.GetBackupStorageConfigRequest{Keyspace: "testkeyspace"})
+	require.NoError(t, err)
+	utils.MustMatch(t, config, resp.Config)
+
+	_, err = vtctld.DeleteBackupStorageConfig(ctx, &vtctldatapb.DeleteBackupStorageConfigRequest{Keyspace: "testkeyspace"})
+	require.NoError(t, err)
+
+	resp, err = vtctld.GetBackupStorageConfig(ctx, &vtctldatapb.GetBackupStorageConfigRequest{Keyspace: "testkeyspace"})
+	require.NoError(t, err)
+	assert.Nil(t, resp.Config)
+
+	// Deleting a config which doesn't exist is a no-op.
+	_, err = vtctld.DeleteBackupStorageConfig(ctx, &vtctldatapb.DeleteBackupStorageConfigRequest{Keyspace: "testkeyspace"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		req  *vtctldatapb.SetBackupStorageConfigRequest
+	}{
+		{
+			name: "no keyspace",
+			req:  &vtctldatapb.SetBackupStorageConfigRequest{Config: config},
+		},
+		{
+			name: "no config",
+			req:  &vtctldatapb.SetBackupStorageConfigRequest{Keyspace: "testkeyspace"},
+		},
+		{
+			name: "nonexistent keyspace",
+			req: &vtctldatapb.SetBackupStorageConfigRequest{
+				Keyspace: "notfound",
+				Config:   config,
+			},
+		},
+		{
+			name: "negative retry delay",
+			req: &vtctldatapb.SetBackupStorageConfigRequest{
+				Keyspace: "testkeyspace",
+				Config: &topodatapb.BackupStorageConfig{
+					S3: &topodatapb.BackupStorageConfig_S3{
+						MinRetryDelay: protoutil.DurationToProto(-time.Second),
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := vtctld.SetBackupStorageConfig(ctx, tt.req)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestSetCDCSinks(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ts := memorytopo.NewServer("zone1")
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
+
+	testutil.AddKeyspace(ctx, t, ts, &vtctldatapb.Keyspace{
+		Name:     "testkeyspace",
+		Keyspace: &topodatapb.Keyspace{},
+	})
+
+	sinks := []*topodatapb.CDCSink{
+		{
+			Name:       "audit",
+			Topic:      "testkeyspace.audit",
+			Tables:     []string{"t1", "t2"},
+			TabletType: "replica",
+		},
+	}
+
+	_, err := vtctld.SetCDCSinks(ctx, &vtctldatapb.SetCDCSinksRequest{
+		Keyspace: "testkeyspace",
+		Sinks:    sinks,
+	})
+	require.NoError(t, err)
+
+	resp, err := vtctld.GetCDCSinks(ctx, &vtctldatapb.GetCDCSinksRequest{Keyspace: "testkeyspace"})
+	require.NoError(t, err)
+	utils.MustMatch(t, sinks, resp.Sinks)
+
+	// No sinks remove them.
+	_, err = vtctld.SetCDCSinks(ctx, &vtctldatapb.SetCDCSinksRequest{Keyspace: "testkeyspace"})
+	require.NoError(t, err)
+
+	resp, err = vtctld.GetCDCSinks(ctx, &vtctldatapb.GetCDCSinksRequest{Keyspace: "testkeyspace"})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Sinks)
+
+	tests := []struct {
+		name string
+		req  *vtctldatapb.SetCDCSinksRequest
+	}{
+		{
+			name: "no keyspace",
+			req:  &vtctldatapb.SetCDCSinksRequest{Sinks: sinks},
+		},
+		{
+			name: "nonexistent keyspace",
+			req: &vtctldatapb.SetCDCSinksRequest{
+				Keyspace: "notfound",
+				Sinks:    sinks,
+			},
+		},
+		{
+			name: "duplicate sink",
+			req: &vtctldatapb.SetCDCSinksRequest{
+				Keyspace: "testkeyspace",
+				Sinks: []*topodatapb.CDCSink{
+					{Name: "audit", Topic: "a"},
+					{Name: "audit", Topic: "b"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := vtctld.SetCDCSinks(ctx, tt.req)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestSetCellServingOverride(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ts := memorytopo.NewServer("zone1", "zone2")
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
+
+	testutil.AddKeyspace(ctx, t, ts, &vtctldatapb.Keyspace{
+		Name:     "testkeyspace",
+		Keyspace: &topodatapb.Keyspace{},
+	})
+
+	override := &topodatapb.CellServingOverride{
+		DoNotServe: true,
+		Reason:     "maintenance",
+	}
+	_, err := vtctld.SetCellServingOverride(ctx, &vtctldatapb.SetCellServingOverrideRequest{
+		Keyspace: "testkeyspace",
+		Cell:     "zone1",
+		Override: override,
+	})
+	require.NoError(t, err)
+
+	resp, err := vtctld.GetCellServingOverride(ctx, &vtctldatapb.GetCellServingOverrideRequest{Keyspace: "testkeyspace"})
+	require.NoError(t, err)
+	utils.MustMatch(t, map[string]*topodatapb.CellServingOverride{"zone1": override}, resp.Overrides)
+
+	// The other cells are not marked.
+	resp, err = vtctld.GetCellServingOverride(ctx, &vtctldatapb.GetCellServingOverrideRequest{
+		Keyspace: "testkeyspace",
+		Cell:     "zone2",
+	})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Overrides)
+
+	// An override which serves removes the mark.
+	_, err = vtctld.SetCellServingOverride(ctx, &vtctldatapb.SetCellServingOverrideRequest{
+		Keyspace: "testkeyspace",
+		Cell:     "zone1",
+	})
+	require.NoError(t, err)
+
+	resp, err = vtctld.GetCellServingOverride(ctx, &vtctldatapb.GetCellServingOverrideRequest{Keyspace: "testkeyspace"})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Overrides)
+
+	tests := []struct {
+		name string
+		req  *vtctldatapb.SetCellServingOverrideRequest
+	}{
+		{
+			name: "no keyspace",
+			req: &vtctldatapb.SetCellServingOverrideRequest{
+				Cell:     "zone1",
+				Override: override,
+			},
+		},
+		{
+			name: "no cell",
+			req: &vtctldatapb.SetCellServingOverrideRequest{
+				Keyspace: "testkeyspace",
+				Override: override,
+			},
+		},
+		{
+			name: "nonexistent keyspace",
+			req: &vtctldatapb.SetCellServingOverrideRequest{
+				Keyspace: "notfound",
+				Cell:     "zone1",
+				Override: override,
+			},
+		},
+		{
+			name: "nonexistent cell",
+			req: &vtctldatapb.SetCellServingOverrideRequest{
+				Keyspace: "testkeyspace",
+				Cell:     "zone3",
+				Override: override,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := vtctld.SetCellServingOverride(ctx, tt.req)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestSetGCTablesRetention(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ts := memorytopo.NewServer("zone1")
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
+
+	_, err := vtctld.SetGCTablesRetention(ctx, &vtctldatapb.SetGCTablesRetentionRequest{
+		State:     "HOLD",
+		Retention: &vttime.Duration{Seconds: 60},
+	})
+	assert.Error(t, err, "the keyspace is required")
+
+	_, err = vtctld.SetGCTablesRetention(ctx, &vtctldatapb.SetGCTablesRetentionRequest{
+		Keyspace:  "testkeyspace",
+		State:     "PURGE",
+		Retention: &vttime.Duration{Seconds: 60},
+	})
+	assert.Error(t, err, "the PURGE state has no retention")
+
+	_, err = vtctld.SetGCTablesRetention(ctx, &vtctldatapb.SetGCTablesRetentionRequest{
+		Keyspace: "testkeyspace",
+		State:    "hold",
+	})
+	assert.Error(t, err, "the retention is required")
+
+	_, err = vtctld.SetGCTablesRetention(ctx, &vtctldatapb.SetGCTablesRetentionRequest{
+		Keyspace:  "testkeyspace",
+		State:     "EVAC",
+		Retention: &vttime.Duration{Seconds: -60},
+	})
+	assert.Error(t, err, "the retention must be non-negative")
+
+	tables := []*tablegcutil.GCTable{
+		{
+			Shard:   "-80",
+			Tablet:  &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+			Table:   "_vt_HOLD_6ace8bcef73211ea87e9f875a4d24e90_20200915120410",
+			State:   schema.HoldTableGCState,
+			UUID:    "6ace8bcef73211ea87e9f875a4d24e90",
+			DueTime: time.Date(2020, 9, 15, 12, 4, 10, 0, time.UTC),
+		},
+	}
+	expected := []*vtctldatapb.GCTable{
+		{
+			Shard: "-80",
+			Tablet: &topodatapb.TabletAlias{
+				Cell: "zone1",
+				Uid:  100,
+			},
+			Table:   "_vt_HOLD_6ace8bcef73211ea87e9f875a4d24e90_20200915120410",
+			State:   "HOLD",
+			Uuid:    "6ace8bcef73211ea87e9f875a4d24e90",
+			DueTime: &vttime.Time{Seconds: time.Date(2020, 9, 15, 12, 4, 10, 0, time.UTC).Unix()},
+		},
+	}
+	utils.MustMatch(t, expected, gcTablesToProto(tables))
+}
+
+func TestSetMySQLUsers(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ts := memorytopo.NewServer("zone1")
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
+
+	testutil.AddKeyspace(ctx, t, ts, &vtctldatapb.Keyspace{
+		Name:     "testkeyspace",
+		Keyspace: &topodatapb.Keyspace{},
+	})
+
+	users := []*topodatapb.MySQLUser{
+		{
+			User:                 "app",
+			Host:                 "%",
+			AuthPlugin:           "mysql_native_password",
+			AuthenticationString: "*6BB4837EB74329105EE4568DDA7DC67ED2CA2AD9",
+			GlobalPrivileges:     []string{"PROCESS"},
+			DatabasePrivileges: map[string]*topodatapb.MySQLUser_Privileges{
+				"vt_testkeyspace": {Privileges: []string{"SELECT", "INSERT"}},
+			},
+		},
+	}
+
+	_, err := vtctld.SetMySQLUsers(ctx, &vtctldatapb.SetMySQLUsersRequest{
+		Keyspace: "testkeyspace",
+		Users:    users,
+	})
+	require.NoError(t, err)
+
+	resp, err := vtctld.GetMySQLUsers(ctx, &vtctldatapb.GetMySQLUsersRequest{Keyspace: "testkeyspace"})
+	require.NoError(t, err)
+	utils.MustMatch(t, users, resp.Users)
+
+	// No users remove the declaration.
+	_, err = vtctld.SetMySQLUsers(ctx, &vtctldatapb.SetMySQLUsersRequest{Keyspace: "testkeyspace"})
+	require.NoError(t, err)
+
+	resp, err = vtctld.GetMySQLUsers(ctx, &vtctldatapb.GetMySQLUsersRequest{Keyspace: "testkeyspace"})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Users)
+
+	tests := []struct {
+		name string
+		req  *vtctldatapb.SetMySQLUsersRequest
+	}{
+		{
+			name: "no keyspace",
+			req:  &vtctldatapb.SetMySQLUsersRequest{Users: users},
+		},
+		{
+			name: "nonexistent keyspace",
+			req: &vtctldatapb.SetMySQLUsersRequest{
+				Keyspace: "notfound",
+				Users:    users,
+			},
+		},
+		{
+			name: "invalid privilege",
+			req: &vtctldatapb.SetMySQLUsersRequest{
+				Keyspace: "testkeyspace",
+				Users: []*topodatapb.MySQLUser{
+					{
+						User:             "app",
+						Host:             "%",
+						GlobalPrivileges: []string{"NOT A PRIVILEGE"},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := vtctld.SetMySQLUsers(ctx, tt.req)
+			assert.Error(t, err)
+		})
+	}
+
+	drift, err := mysqlUsersDriftToProto(&grantutil.DriftReport{
+		Keyspace: "testkeyspace",
+		Tablets: []*grantutil.TabletDrift{
+			{
+				Tablet:     "zone1-0000000100",
+				Shard:      "-",
+				TabletType: "replica",
+				Users: []*grantutil.UserDrift{
+					{
+						User:              "'app'@'%'",
+						MissingPrivileges: []string{"INSERT ON `vt_testkeyspace`.*"},
+					},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	utils.MustMatch(t, &vtctldatapb.MySQLUsersDrift{
+		Keyspace: "testkeyspace",
+		Tablets: []*vtctldatapb.MySQLUsersDrift_TabletDrift{
+			{
+				Tablet: &topodatapb.TabletAlias{
+					Cell: "zone1",
+					Uid:  100,
+				},
+				Shard:      "-",
+				TabletType: topodatapb.TabletType_REPLICA,
+				Users: []*vtctldatapb.MySQLUsersDrift_UserDrift{
+					{
+						User:              "'app'@'%'",
+						MissingPrivileges: []string{"INSERT ON `vt_testkeyspace`.*"},
+					},
+				},
+			},
+		},
+	}, drift)
+}
+
+func TestShardReplicationPositions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		ts         *topo.Server
+		tablets    []*topodatapb.Tablet
+		tmc        tmclient.TabletManagerClient
+		ctxTimeout time.Duration
+		req        *vtctldatapb.ShardReplicationPositionsRequest
+		expected   *vtctldatapb.ShardReplicationPositionsResponse
+		shouldErr  bool
+	}{
+		{
+			name: "success",
+			ts:   memorytopo.NewServer("zone1"),
+			tablets: []*topodatapb.Tablet{
+				{
+					Alias: &topodatapb.TabletAlias{
+						Cell: "zone1",
+						Uid:  100,
+					},
+					Keyspace: "testkeyspace",
+					Shard:    "-",
+					Type:     topodatapb.TabletType_MASTER,
+				},
+				{
+					Alias: &topodatapb.TabletAlias{
+						Cell: "zone1",
+						Uid:  101,
+					},
+					Keyspace: "testkeyspace",
+					Shard:    "-",
+					Type:     topodatapb.TabletType_REPLICA,
+				},
+			},
+			tmc: &testutil.TabletManagerClient{
+				MasterPositionResults: map[string]struct {
+					Position string
+					Error    error
+				}{
+					"zone1-0000000100": {
+						Position: "primary_tablet_position",
+					},
+				},
+				ReplicationStatusResults: map[string]struct {
+					Position *replicationdatapb.Status
+					Error    error
+				}{
+					"zone1-0000000101": {
+						Position: &replicationdatapb.Status{
+							Position: "replica_tablet_position",
+						},
+					},
+				},
+			},
+			req: &vtctldatapb.ShardReplicationPositionsRequest{
+				Keyspace: "testkeyspace",
+				Shard:    "-",
+			},
+			expected: &vtctldatapb.ShardReplicationPositionsResponse{
+				ReplicationStatuses: map[string]*replicationdatapb.Status{
+					"zone1-0000000100": {
+						Position: "primary_tablet_position",
+					},
+					"zone1-0000000101": {
+						Position: "replica_tablet_position",
+					},
+				},
+				TabletMap: map[string]*topodatapb.Tablet{
+					"zone1-0000000100": {
+						Alias: &topodatapb.TabletAlias{
+							Cell: "zone1",
+							Uid:  100,
+						},
+						Keyspace: "testkeyspace",
+						Shard:    "-",
+						Type:     topodatapb.TabletType_MASTER,
+					},
+					"zone1-0000000101": {
+						Alias: &topodatapb.TabletAlias{
+							Cell: "zone1",
+							Uid:  101,
+						},
+						Keyspace: "testkeyspace",
+						Shard:    "-",
+						Type:     topodatapb.TabletType_REPLICA,
+					},
+				},
+			},
+			shouldErr: false,
+		},
+		{
+			name: "timeouts are nonfatal",
+			ts:   memorytopo.NewServer("zone1"),
+			tablets: []*topodatapb.Tablet{
+				{
+					Alias: &topodatapb.TabletAlias{
+						Cell: "zone1",
+						Uid:  100,
+					},
+					Keyspace: "testkeyspace",
+					Shard:    "-",
+					Type:     topodatapb.TabletType_MASTER,
+				},
+				{
+					Alias: &topodatapb.TabletAlias{
+						Cell: "zone1",
+						Uid:  101,
+					},
+					Keyspace: "testkeyspace",
+					Shard:    "-",
+					Type:     topodatapb.TabletType_REPLICA,
+				},
+			},
+			tmc: &testutil.TabletManagerClient{
+				MasterPositionDelays: map[string]time.Duration{
+					"zone1-0000000100": time.Millisecond * 100,
+				},
+				MasterPositionResults: map[string]struct {
+					Position string
+					Error    error
+				}{
+					"zone1-0000000100": {
+						Position: "primary_tablet_position",
+					},
+				},
+				ReplicationStatusDelays: map[string]time.Duration{
+					"zone1-0000000101": time.Millisecond * 100,
+				},
+				ReplicationStatusResults: map[string]struct {
+					Position *replicationdatapb.Status
+					Error    error
+				}{
+					"zone1-0000000101": {
+						Position: &replicationdatapb.Status{
+							Position: "replica_tablet_position",
+						},
+					},
+				},
+			},
+			ctxTimeout: time.Millisecond * 10,
+			req: &vtctldatapb.ShardReplicationPositionsRequest{
+				Keyspace: "testkeyspace",
+				Shard:    "-",
+			},
+			expected: &vtctldatapb.ShardReplicationPositionsResponse{
+				ReplicationStatuses: map[string]*replicationdatapb.Status{
+					"zone1-0000000100": nil,
+					"zone1-0000000101": nil,
+				},
+				TabletMap: map[string]*topodatapb.Tablet{
+					"zone1-0000000100": {
+						Alias: &topodatapb.TabletAlias{
+							Cell: "zone1",
+							Uid:  100,
+						},
+						Keyspace: "testkeyspace",
+						Shard:    "-",
+						Type:     topodatapb.TabletType_MASTER,
+					},
+					"zone1-0000000101": {
+						Alias: &topodatapb.TabletAlias{
+							Cell: "zone1",
+							Uid:  101,
+						},
+						Keyspace: "testkeyspace",
+						Shard:    "-",
+						Type:     topodatapb.TabletType_REPLICA,
+					},
+				},
+			},
+			shouldErr: false,
+		},
+		{
+			name: "other rpc errors are fatal",
+			ts:   memorytopo.NewServer("zone1"),
+			tablets: []*topodatapb.Tablet{
+				{
+					Alias: &topodatapb.TabletAlias{
+						Cell: "zone1",
+						Uid:  100,
+					},
+					Keyspace: "testkeyspace",
+					Shard:    "-",
+					Type:     topodatapb.TabletType_MASTER,
+				},
+				{
+					Alias: &topodatapb.TabletAlias{
+						Cell: "zone1",
+						Uid:  101,
+					},
+					Keyspace: "testkeyspace",
+					Shard:    "-",
+					Type:     topodatapb.TabletType_REPLICA,
+				},
+			},
+			tmc: &testutil.TabletManagerClient{
+				MasterPositionResults: map[string]struct {
+					Position string
+					Error    error
+				}{
+					"zone1-0000000100": {
+						Error: assert.AnError,
+					},
+				},
+				ReplicationStatusResults: map[string]struct {
+					Position *replicationdatapb.Status
+					Error    error
+				}{
+					"zone1-0000000101": {
+						Position: &replicationdatapb.Status{
+							Position: "replica_tablet_position",
+						},
+					},
+				},
+			},
+			req: &vtctldatapb.ShardReplicationPositionsRequest{
+				Keyspace: "testkeyspace",
+				Shard:    "-",
+			},
+			expected:  nil,
+			shouldErr: true,
+		},
+		{
+			name: "nonexistent shard",
+			ts:   memorytopo.NewServer("zone1"),
+			req: &vtctldatapb.ShardReplicationPositionsRequest{
+				Keyspace: "testkeyspace",
+				Shard:    "-",
+			},
+			expected:  nil,
+			shouldErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+
+			testutil.AddTablets(ctx, t, tt.ts, &testutil.AddTabletOptions{
+				AlsoSetShardMaster: true,
+				SkipShardCreation:  false,
+			}, tt.tablets...)
+
+			vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, tt.ts, tt.tmc, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+				return NewVtctldServer(ts)
+			})
+
+			if tt.ctxTimeout > 0 {
+				_ctx, cancel := context.WithTimeout(ctx, tt.ctxTimeout)
+				defer cancel()
+
+				ctx = _ctx
+			}
+
+			resp, err := vtctld.ShardReplicationPositions(ctx, tt.req)
+			if tt.shouldErr {
+				assert.Error(t, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			utils.MustMatch(t, tt.expected, resp)
+		})
+	}
+}
+
+// fakeHostMaintenanceFactory records the args of the workflows it creates
+// in their data.
+type fakeHostMaintenanceFactory struct{}
+
+func (*fakeHostMaintenanceFactory) Init(m *workflowmanager.Manager, w *workflowpb.Workflow, args []string) error {
+	w.Data = []byte(strings.Join(args, " "))
+	return nil
+}
+
+func (*fakeHostMaintenanceFactory) Instantiate(m *workflowmanager.Manager, w *workflowpb.Workflow, rootNode *workflowmanager.Node) (workflowmanager.Workflow, error) {
+	return &fakeHostMaintenanceWorkflow{}, nil
+}
+
+type fakeHostMaintenanceWorkflow struct{}
+
+func (*fakeHostMaintenanceWorkflow) Run(ctx context.Context, manager *workflowmanager.Manager, wi *topo.WorkflowInfo) error {
+	return nil
+}
+
+func TestSplitReads(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ts := memorytopo.NewServer("zone1")
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
+
+	err := ts.CreateKeyspace(ctx, "testkeyspace", &topodatapb.Keyspace{})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		req  *vtctldatapb.SplitReadsRequest
+	}{
+		{
+			name: "no workflow",
+			req:  &vtctldatapb.SplitReadsRequest{Keyspace: "testkeyspace", Percent: 10},
+		},
+		{
+			name: "invalid percent",
+			req:  &vtctldatapb.SplitReadsRequest{Keyspace: "testkeyspace", Workflow: "wf1", Percent: 101},
+		},
+		{
+			name: "invalid tablet type",
+			req: &vtctldatapb.SplitReadsRequest{
+				Keyspace:    "testkeyspace",
+				Workflow:    "wf1",
+				Percent:     10,
+				TabletTypes: []topodatapb.TabletType{topodatapb.TabletType_MASTER},
+			},
+		},
+		{
+			name: "nonexistent workflow",
+			req:  &vtctldatapb.SplitReadsRequest{Keyspace: "testkeyspace", Workflow: "noworkflow", Percent: 10},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := vtctld.SplitReads(ctx, tt.req)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestStartHostMaintenance(t *testing.T) {
+	ctx := context.Background()
+	ts := memorytopo.NewServer("zone1")
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
+
+	req := &vtctldatapb.StartHostMaintenanceRequest{
+		Cell:       "zone1",
+		Hostname:   "host1",
+		Uncordon:   true,
+		DrainGrace: &vttime.Duration{Seconds: 5},
+	}
+
+	// The vtctld runs no workflow manager.
+	_, err := vtctld.StartHostMaintenance(ctx, req)
+	assert.Error(t, err)
+
+	workflowmanager.Register(hostMaintenanceFactoryName, &fakeHostMaintenanceFactory{})
+	defer workflowmanager.Unregister(hostMaintenanceFactoryName)
+	m := workflowmanager.NewManager(ts)
+	wg, _, cancel := workflowmanager.StartManager(m)
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+	SetWorkflowManager(m)
+	defer SetWorkflowManager(nil)
+
+	_, err = vtctld.StartHostMaintenance(ctx, &vtctldatapb.StartHostMaintenanceRequest{Cell: "zone1"})
+	assert.Error(t, err)
+
+	resp, err := vtctld.StartHostMaintenance(ctx, req)
+	require.NoError(t, err)
+	require.NoError(t, m.Wait(ctx, resp.Uuid))
+
+	wi, err := ts.GetWorkflow(ctx, resp.Uuid)
+	require.NoError(t, err)
+	assert.Equal(t, hostMaintenanceFactoryName, wi.FactoryName)
+	assert.Equal(t, workflowpb.WorkflowState_Done, wi.State)
+	assert.Equal(t, "-cell=zone1 -hostname=host1 -uncordon=true -drain_grace=5s -wait_replicas_timeout=30s", string(wi.Data))
+}
+
+func TestTabletExternallyReparented(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		topo         []*topodatapb.Tablet
+		topoErr      error
+		tmcHasNoTopo bool
+		req          *vtctldatapb.TabletExternallyReparentedRequest
+		expected     *vtctldatapb.TabletExternallyReparentedResponse
+		shouldErr    bool
+		expectedTopo []*topodatapb.Tablet
+	}{
+		{
+			name: "success",
+			topo: []*topodatapb.Tablet{
+				{
+					Alias: &topodatapb.TabletAlias{
+						Cell: "zone1",
+						Uid:  100,
 					},
 					Type:     topodatapb.TabletType_MASTER,
 					Keyspace: "testkeyspace",
@@ -6263,8 +9130,187 @@ func TestTabletExternallyReparented(t *testing.T) {
 				Keyspace: "testkeyspace",
 				Shard:    "-",
 				NewPrimary: &topodatapb.TabletAlias{
-					Cell: "zone2",
-					Uid:  200,
+					Cell: "zone2",
+					Uid:  200,
+				},
+				OldPrimary: &topodatapb.TabletAlias{
+					Cell: "zone1",
+					Uid:  100,
+				},
+			},
+			shouldErr: false,
+			// NOTE: this seems weird, right? Why is the old primary still a
+			// MASTER, and why is the new primary's term start 0,0? Well, our
+			// test client implementation is a little incomplete. See
+			// ./testutil/test_tmclient.go for reference.
+			expectedTopo: []*topodatapb.Tablet{
+				{
+					Alias: &topodatapb.TabletAlias{
+						Cell: "zone1",
+						Uid:  100,
+					},
+					Type:     topodatapb.TabletType_MASTER,
+					Keyspace: "testkeyspace",
+					Shard:    "-",
+					MasterTermStartTime: &vttime.Time{
+						Seconds: 1000,
+					},
+				},
+				{
+					Alias: &topodatapb.TabletAlias{
+						Cell: "zone2",
+						Uid:  200,
+					},
+					Type:                topodatapb.TabletType_UNKNOWN,
+					Keyspace:            "testkeyspace",
+					Shard:               "-",
+					MasterTermStartTime: &vttime.Time{},
+				},
+				{
+					Alias: &topodatapb.TabletAlias{
+						Cell: "zone3",
+						Uid:  300,
+					},
+					Type:     topodatapb.TabletType_REPLICA,
+					Keyspace: "testkeyspace",
+					Shard:    "-",
+				},
+			},
+		},
+		{
+			name:    "tablet is nil",
+			topo:    nil,
+			topoErr: nil,
+			req: &vtctldatapb.TabletExternallyReparentedRequest{
+				Tablet: nil,
+			},
+			expected:     nil,
+			shouldErr:    true,
+			expectedTopo: nil,
+		},
+		{
+			name: "topo is down",
+			topo: []*topodatapb.Tablet{
+				{
+					Alias: &topodatapb.TabletAlias{
+						Cell: "zone1",
+						Uid:  100,
+					},
+					Type:     topodatapb.TabletType_MASTER,
+					Keyspace: "testkeyspace",
+					Shard:    "-",
+					MasterTermStartTime: &vttime.Time{
+						Seconds: 1000,
+					},
+				},
+				{
+					Alias: &topodatapb.TabletAlias{
+						Cell: "zone2",
+						Uid:  200,
+					},
+					Type:     topodatapb.TabletType_REPLICA,
+					Keyspace: "testkeyspace",
+					Shard:    "-",
+				},
+				{
+					Alias: &topodatapb.TabletAlias{
+						Cell: "zone3",
+						Uid:  300,
+					},
+					Type:     topodatapb.TabletType_REPLICA,
+					Keyspace: "testkeyspace",
+					Shard:    "-",
+				},
+			},
+			topoErr: assert.AnError,
+			req: &vtctldatapb.TabletExternallyReparentedRequest{
+				Tablet: &topodatapb.TabletAlias{
+					Cell: "zone2",
+					Uid:  200,
+				},
+			},
+			expected:  nil,
+			shouldErr: true,
+			expectedTopo: []*topodatapb.Tablet{
+				{
+					Alias: &topodatapb.TabletAlias{
+						Cell: "zone1",
+						Uid:  100,
+					},
+					Type:     topodatapb.TabletType_MASTER,
+					Keyspace: "testkeyspace",
+					Shard:    "-",
+					MasterTermStartTime: &vttime.Time{
+						Seconds: 1000,
+					},
+				},
+				{
+					Alias: &topodatapb.TabletAlias{
+						Cell: "zone2",
+						Uid:  200,
+					},
+					Type:     topodatapb.TabletType_REPLICA,
+					Keyspace: "testkeyspace",
+					Shard:    "-",
+				},
+				{
+					Alias: &topodatapb.TabletAlias{
+						Cell: "zone3",
+						Uid:  300,
+					},
+					Type:     topodatapb.TabletType_REPLICA,
+					Keyspace: "testkeyspace",
+					Shard:    "-",
+				},
+			},
+		},
+		{
+			name: "tablet is already primary",
+			topo: []*topodatapb.Tablet{
+				{
+					Alias: &topodatapb.TabletAlias{
+						Cell: "zone1",
+						Uid:  100,
+					},
+					Type:     topodatapb.TabletType_MASTER,
+					Keyspace: "testkeyspace",
+					Shard:    "-",
+					MasterTermStartTime: &vttime.Time{
+						Seconds: 1000,
+					},
+				},
+				{
+					Alias: &topodatapb.TabletAlias{
+						Cell: "zone2",
+						Uid:  200,
+					},
+					Type:     topodatapb.TabletType_REPLICA,
+					Keyspace: "testkeyspace",
+					Shard:    "-",
+				},
+				{
+					Alias: &topodatapb.TabletAlias{
+						Cell: "zone3",
+						Uid:  300,
+					},
+					Type:     topodatapb.TabletType_REPLICA,
+					Keyspace: "testkeyspace",
+					Shard:    "-",
+				},
+			},
+			topoErr: nil,
+			req: &vtctldatapb.TabletExternallyReparentedRequest{
+				Tablet: &topodatapb.TabletAlias{
+					Cell: "zone1",
+					Uid:  100,
+				},
+			},
+			expected: &vtctldatapb.TabletExternallyReparentedResponse{
+				Keyspace: "testkeyspace",
+				Shard:    "-",
+				NewPrimary: &topodatapb.TabletAlias{
+					Cell: "zone1",
+					Uid:  100,
 				},
 				OldPrimary: &topodatapb.TabletAlias{
 					Cell: "zone1",
@@ -6272,10 +9318,6 @@ func TestTabletExternallyReparented(t *testing.T) {
 				},
 			},
 			shouldErr: false,
-			// NOTE: this seems weird, right? Why is the old primary still a
-			// MASTER, and why is the new primary's term start 0,0? Well, our
-			// test client implementation is a little incomplete. See
-			// ./testutil/test_tmclient.go for reference.
 			expectedTopo: []*topodatapb.Tablet{
 				{
 					Alias: &topodatapb.TabletAlias{
@@ -6294,10 +9336,9 @@ func TestTabletExternallyReparented(t *testing.T) {
 						Cell: "zone2",
 						Uid:  200,
 					},
-					Type:                topodatapb.TabletType_UNKNOWN,
-					Keyspace:            "testkeyspace",
-					Shard:               "-",
-					MasterTermStartTime: &vttime.Time{},
+					Type:     topodatapb.TabletType_REPLICA,
+					Keyspace: "testkeyspace",
+					Shard:    "-",
 				},
 				{
 					Alias: &topodatapb.TabletAlias{
@@ -6311,18 +9352,7 @@ func TestTabletExternallyReparented(t *testing.T) {
 			},
 		},
 		{
-			name:    "tablet is nil",
-			topo:    nil,
-			topoErr: nil,
-			req: &vtctldatapb.TabletExternallyReparentedRequest{
-				Tablet: nil,
-			},
-			expected:     nil,
-			shouldErr:    true,
-			expectedTopo: nil,
-		},
-		{
-			name: "topo is down",
+			name: "cannot change tablet type",
 			topo: []*topodatapb.Tablet{
 				{
 					Alias: &topodatapb.TabletAlias{
@@ -6355,7 +9385,8 @@ func TestTabletExternallyReparented(t *testing.T) {
 					Shard:    "-",
 				},
 			},
-			topoErr: assert.AnError,
+			topoErr:      nil,
+			tmcHasNoTopo: true,
 			req: &vtctldatapb.TabletExternallyReparentedRequest{
 				Tablet: &topodatapb.TabletAlias{
 					Cell: "zone2",
@@ -6397,527 +9428,1074 @@ func TestTabletExternallyReparented(t *testing.T) {
 				},
 			},
 		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cells := []string{"zone1", "zone2", "zone3"}
+
+			ctx := context.Background()
+			ts, topofactory := memorytopo.NewServerAndFactory(cells...)
+			tmc := testutil.TabletManagerClient{
+				TopoServer: ts,
+			}
+			vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, &tmc, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+				return NewVtctldServer(ts)
+			})
+
+			if tt.tmcHasNoTopo {
+				// For certain test cases, we want specifically just the
+				// ChangeType call to fail, which is why we rely on a separate
+				// bool rather than using tt.topoErr.
+				tmc.TopoServer = nil
+			}
+
+			testutil.AddTablets(ctx, t, ts, &testutil.AddTabletOptions{
+				AlsoSetShardMaster: true,
+			}, tt.topo...)
+
+			if tt.topoErr != nil {
+				topofactory.SetError(tt.topoErr)
+			}
+
+			if tt.expectedTopo != nil {
+				// assert on expectedTopo state when we've fininished the rest
+				// of the test.
+				defer func() {
+					topofactory.SetError(nil)
+
+					ctx, cancel := context.WithTimeout(ctx, time.Millisecond*10)
+					defer cancel()
+
+					resp, err := vtctld.GetTablets(ctx, &vtctldatapb.GetTabletsRequest{})
+					require.NoError(t, err, "cannot get all tablets in the topo")
+					testutil.AssertSameTablets(t, tt.expectedTopo, resp.Tablets)
+				}()
+			}
+
+			resp, err := vtctld.TabletExternallyReparented(ctx, tt.req)
+			if tt.shouldErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			utils.MustMatch(t, tt.expected, resp)
+		})
+	}
+}
+
+func TestUpdateCellInfo(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	tests := []struct {
+		name           string
+		cells          map[string]*topodatapb.CellInfo
+		forceTopoError bool
+		req            *vtctldatapb.UpdateCellInfoRequest
+		expected       *vtctldatapb.UpdateCellInfoResponse
+		shouldErr      bool
+	}{
+		{
+			name: "update",
+			cells: map[string]*topodatapb.CellInfo{
+				"zone1": {
+					ServerAddress: ":1111",
+					Root:          "/zone1",
+				},
+			},
+			req: &vtctldatapb.UpdateCellInfoRequest{
+				Name: "zone1",
+				CellInfo: &topodatapb.CellInfo{
+					ServerAddress: ":0101",
+					Root:          "/zones/zone1",
+				},
+			},
+			expected: &vtctldatapb.UpdateCellInfoResponse{
+				Name: "zone1",
+				CellInfo: &topodatapb.CellInfo{
+					ServerAddress: ":0101",
+					Root:          "/zones/zone1",
+				},
+			},
+		},
+		{
+			name: "partial update",
+			cells: map[string]*topodatapb.CellInfo{
+				"zone1": {
+					ServerAddress: ":1111",
+					Root:          "/zone1",
+				},
+			},
+			req: &vtctldatapb.UpdateCellInfoRequest{
+				Name: "zone1",
+				CellInfo: &topodatapb.CellInfo{
+					Root: "/zones/zone1",
+				},
+			},
+			expected: &vtctldatapb.UpdateCellInfoResponse{
+				Name: "zone1",
+				CellInfo: &topodatapb.CellInfo{
+					ServerAddress: ":1111",
+					Root:          "/zones/zone1",
+				},
+			},
+		},
+		{
+			name: "no update",
+			cells: map[string]*topodatapb.CellInfo{
+				"zone1": {
+					ServerAddress: ":1111",
+					Root:          "/zone1",
+				},
+			},
+			req: &vtctldatapb.UpdateCellInfoRequest{
+				Name: "zone1",
+				CellInfo: &topodatapb.CellInfo{
+					Root: "/zone1",
+				},
+			},
+			expected: &vtctldatapb.UpdateCellInfoResponse{
+				Name: "zone1",
+				CellInfo: &topodatapb.CellInfo{
+					ServerAddress: ":1111",
+					Root:          "/zone1",
+				},
+			},
+		},
+		{
+			name: "cell not found",
+			cells: map[string]*topodatapb.CellInfo{
+				"zone1": {
+					ServerAddress: ":1111",
+					Root:          "/zone1",
+				},
+			},
+			req: &vtctldatapb.UpdateCellInfoRequest{
+				Name: "zone404",
+				CellInfo: &topodatapb.CellInfo{
+					ServerAddress: ":4040",
+					Root:          "/zone404",
+				},
+			},
+			expected: &vtctldatapb.UpdateCellInfoResponse{
+				Name: "zone404",
+				CellInfo: &topodatapb.CellInfo{
+					ServerAddress: ":4040",
+					Root:          "/zone404",
+				},
+			},
+		},
+		{
+			name: "cannot update",
+			cells: map[string]*topodatapb.CellInfo{
+				"zone1": {
+					ServerAddress: ":1111",
+					Root:          "/zone1",
+				},
+			},
+			forceTopoError: true,
+			req: &vtctldatapb.UpdateCellInfoRequest{
+				Name: "zone1",
+				CellInfo: &topodatapb.CellInfo{
+					Root: "/zone1",
+				},
+			},
+			expected:  nil,
+			shouldErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ts, factory := memorytopo.NewServerAndFactory()
+			for name, cell := range tt.cells {
+				err := ts.CreateCellInfo(ctx, name, cell)
+				require.NoError(t, err, "failed to create cell %s: %+v for test", name, cell)
+			}
+
+			if tt.forceTopoError {
+				factory.SetError(fmt.Errorf("%w: topo down for testing", assert.AnError))
+			}
+
+			vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+				return NewVtctldServer(ts)
+			})
+			resp, err := vtctld.UpdateCellInfo(ctx, tt.req)
+			if tt.shouldErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			utils.MustMatch(t, tt.expected, resp)
+		})
+	}
+}
+
+func TestUpdateCellsAlias(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	tests := []struct {
+		name      string
+		cells     []string
+		aliases   map[string][]string
+		req       *vtctldatapb.UpdateCellsAliasRequest
+		expected  *vtctldatapb.UpdateCellsAliasResponse
+		shouldErr bool
+	}{
 		{
-			name: "tablet is already primary",
-			topo: []*topodatapb.Tablet{
-				{
-					Alias: &topodatapb.TabletAlias{
-						Cell: "zone1",
-						Uid:  100,
-					},
-					Type:     topodatapb.TabletType_MASTER,
-					Keyspace: "testkeyspace",
-					Shard:    "-",
-					MasterTermStartTime: &vttime.Time{
-						Seconds: 1000,
-					},
-				},
-				{
-					Alias: &topodatapb.TabletAlias{
-						Cell: "zone2",
-						Uid:  200,
-					},
-					Type:     topodatapb.TabletType_REPLICA,
-					Keyspace: "testkeyspace",
-					Shard:    "-",
-				},
-				{
-					Alias: &topodatapb.TabletAlias{
-						Cell: "zone3",
-						Uid:  300,
-					},
-					Type:     topodatapb.TabletType_REPLICA,
-					Keyspace: "testkeyspace",
-					Shard:    "-",
+			name: "remove one cell",
+			aliases: map[string][]string{
+				"zone": {
+					"zone1",
+					"zone2",
+					"zone3",
 				},
 			},
-			topoErr: nil,
-			req: &vtctldatapb.TabletExternallyReparentedRequest{
-				Tablet: &topodatapb.TabletAlias{
-					Cell: "zone1",
-					Uid:  100,
+			req: &vtctldatapb.UpdateCellsAliasRequest{
+				Name: "zone",
+				CellsAlias: &topodatapb.CellsAlias{
+					Cells: []string{"zone1", "zone2"},
 				},
 			},
-			expected: &vtctldatapb.TabletExternallyReparentedResponse{
-				Keyspace: "testkeyspace",
-				Shard:    "-",
-				NewPrimary: &topodatapb.TabletAlias{
-					Cell: "zone1",
-					Uid:  100,
-				},
-				OldPrimary: &topodatapb.TabletAlias{
-					Cell: "zone1",
-					Uid:  100,
+			expected: &vtctldatapb.UpdateCellsAliasResponse{
+				Name: "zone",
+				CellsAlias: &topodatapb.CellsAlias{
+					Cells: []string{"zone1", "zone2"},
 				},
 			},
-			shouldErr: false,
-			expectedTopo: []*topodatapb.Tablet{
-				{
-					Alias: &topodatapb.TabletAlias{
-						Cell: "zone1",
-						Uid:  100,
-					},
-					Type:     topodatapb.TabletType_MASTER,
-					Keyspace: "testkeyspace",
-					Shard:    "-",
-					MasterTermStartTime: &vttime.Time{
-						Seconds: 1000,
-					},
+		},
+		{
+			name:  "add one cell",
+			cells: []string{"zone4"}, // all other cells get created via the aliases map
+			aliases: map[string][]string{
+				"zone": {
+					"zone1",
+					"zone2",
+					"zone3",
 				},
-				{
-					Alias: &topodatapb.TabletAlias{
-						Cell: "zone2",
-						Uid:  200,
+			},
+			req: &vtctldatapb.UpdateCellsAliasRequest{
+				Name: "zone",
+				CellsAlias: &topodatapb.CellsAlias{
+					Cells: []string{
+						"zone1",
+						"zone2",
+						"zone3",
+						"zone4",
 					},
-					Type:     topodatapb.TabletType_REPLICA,
-					Keyspace: "testkeyspace",
-					Shard:    "-",
 				},
-				{
-					Alias: &topodatapb.TabletAlias{
-						Cell: "zone3",
-						Uid:  300,
+			},
+			expected: &vtctldatapb.UpdateCellsAliasResponse{
+				Name: "zone",
+				CellsAlias: &topodatapb.CellsAlias{
+					Cells: []string{
+						"zone1",
+						"zone2",
+						"zone3",
+						"zone4",
 					},
-					Type:     topodatapb.TabletType_REPLICA,
-					Keyspace: "testkeyspace",
-					Shard:    "-",
 				},
 			},
 		},
 		{
-			name: "cannot change tablet type",
-			topo: []*topodatapb.Tablet{
-				{
-					Alias: &topodatapb.TabletAlias{
-						Cell: "zone1",
-						Uid:  100,
-					},
-					Type:     topodatapb.TabletType_MASTER,
-					Keyspace: "testkeyspace",
-					Shard:    "-",
-					MasterTermStartTime: &vttime.Time{
-						Seconds: 1000,
-					},
-				},
-				{
-					Alias: &topodatapb.TabletAlias{
-						Cell: "zone2",
-						Uid:  200,
-					},
-					Type:     topodatapb.TabletType_REPLICA,
-					Keyspace: "testkeyspace",
-					Shard:    "-",
-				},
-				{
-					Alias: &topodatapb.TabletAlias{
-						Cell: "zone3",
-						Uid:  300,
-					},
-					Type:     topodatapb.TabletType_REPLICA,
-					Keyspace: "testkeyspace",
-					Shard:    "-",
+			name:  "alias does not exist",
+			cells: []string{"zone1", "zone2"},
+			req: &vtctldatapb.UpdateCellsAliasRequest{
+				Name: "zone",
+				CellsAlias: &topodatapb.CellsAlias{
+					Cells: []string{"zone1", "zone2"},
 				},
 			},
-			topoErr:      nil,
-			tmcHasNoTopo: true,
-			req: &vtctldatapb.TabletExternallyReparentedRequest{
-				Tablet: &topodatapb.TabletAlias{
-					Cell: "zone2",
-					Uid:  200,
+			expected: &vtctldatapb.UpdateCellsAliasResponse{
+				Name: "zone",
+				CellsAlias: &topodatapb.CellsAlias{
+					Cells: []string{"zone1", "zone2"},
 				},
 			},
-			expected:  nil,
-			shouldErr: true,
-			expectedTopo: []*topodatapb.Tablet{
-				{
-					Alias: &topodatapb.TabletAlias{
-						Cell: "zone1",
-						Uid:  100,
-					},
-					Type:     topodatapb.TabletType_MASTER,
-					Keyspace: "testkeyspace",
-					Shard:    "-",
-					MasterTermStartTime: &vttime.Time{
-						Seconds: 1000,
-					},
+		},
+		{
+			name: "invalid alias list",
+			aliases: map[string][]string{
+				"zone_a": {
+					"zone1",
+					"zone2",
 				},
-				{
-					Alias: &topodatapb.TabletAlias{
-						Cell: "zone2",
-						Uid:  200,
-					},
-					Type:     topodatapb.TabletType_REPLICA,
-					Keyspace: "testkeyspace",
-					Shard:    "-",
+				"zone_b": {
+					"zone3",
+					"zone4",
 				},
-				{
-					Alias: &topodatapb.TabletAlias{
-						Cell: "zone3",
-						Uid:  300,
+			},
+			req: &vtctldatapb.UpdateCellsAliasRequest{
+				Name: "zone_a",
+				CellsAlias: &topodatapb.CellsAlias{
+					Cells: []string{
+						"zone1",
+						"zone2",
+						"zone3", // this is invalid because it belongs to alias zone_b
 					},
-					Type:     topodatapb.TabletType_REPLICA,
-					Keyspace: "testkeyspace",
-					Shard:    "-",
 				},
 			},
+			shouldErr: true,
 		},
 	}
 
-	for _, tt := range tests {
-		tt := tt
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ts := memorytopo.NewServer(tt.cells...)
+			for name, cells := range tt.aliases {
+				for _, cell := range cells {
+					// We use UpdateCellInfoFields rather than CreateCellInfo
+					// for the update-or-create behavior.
+					err := ts.UpdateCellInfoFields(ctx, cell, func(ci *topodatapb.CellInfo) error {
+						ci.Root = "/" + cell
+						ci.ServerAddress = cell + ":8080"
+						return nil
+					})
+					require.NoError(t, err, "failed to create cell %v", cell)
+				}
+
+				err := ts.CreateCellsAlias(ctx, name, &topodatapb.CellsAlias{
+					Cells: cells,
+				})
+				require.NoError(t, err, "failed to create cell alias %v (cells = %v)", name, cells)
+			}
+
+			vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+				return NewVtctldServer(ts)
+			})
+			resp, err := vtctld.UpdateCellsAlias(ctx, tt.req)
+			if tt.shouldErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			utils.MustMatch(t, tt.expected, resp)
+		})
+	}
+}
+
+func TestUpdateSrvKeyspace(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ts := memorytopo.NewServer("zone1")
+	testutil.AddKeyspace(ctx, t, ts, &vtctldatapb.Keyspace{
+		Name:     "testkeyspace",
+		Keyspace: &topodatapb.Keyspace{},
+	})
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
+
+	unsharded := &topodatapb.SrvKeyspace{
+		Partitions: []*topodatapb.SrvKeyspace_KeyspacePartition{{
+			ServedType:      topodatapb.TabletType_MASTER,
+			ShardReferences: []*topodatapb.ShardReference{{Name: "0"}},
+		}},
+	}
+	sharded := &topodatapb.SrvKeyspace{
+		Partitions: []*topodatapb.SrvKeyspace_KeyspacePartition{{
+			ServedType: topodatapb.TabletType_MASTER,
+			ShardReferences: []*topodatapb.ShardReference{
+				{Name: "-80", KeyRange: &topodatapb.KeyRange{End: []byte{0x80}}},
+				{Name: "80-", KeyRange: &topodatapb.KeyRange{Start: []byte{0x80}}},
+			},
+		}},
+	}
+
+	// Without an expected version, the SrvKeyspace is created.
+	resp, err := vtctld.UpdateSrvKeyspace(ctx, &vtctldatapb.UpdateSrvKeyspaceRequest{
+		Keyspace:    "testkeyspace",
+		Cell:        "zone1",
+		SrvKeyspace: unsharded,
+	})
+	require.NoError(t, err)
+	created := resp.Version
+	_, err = vtctld.UpdateSrvKeyspace(ctx, &vtctldatapb.UpdateSrvKeyspaceRequest{
+		Keyspace:    "testkeyspace",
+		Cell:        "zone1",
+		SrvKeyspace: unsharded,
+	})
+	assert.Error(t, err, "creating an existing SrvKeyspace should fail")
 
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
+	// An invalid SrvKeyspace is not saved.
+	_, err = vtctld.UpdateSrvKeyspace(ctx, &vtctldatapb.UpdateSrvKeyspaceRequest{
+		Keyspace: "testkeyspace",
+		Cell:     "zone1",
+		SrvKeyspace: &topodatapb.SrvKeyspace{
+			Partitions: []*topodatapb.SrvKeyspace_KeyspacePartition{{
+				ServedType: topodatapb.TabletType_MASTER,
+				ShardReferences: []*topodatapb.ShardReference{
+					{Name: "-80", KeyRange: &topodatapb.KeyRange{End: []byte{0x80}}},
+				},
+			}},
+		},
+		ExpectedVersion: created,
+	})
+	assert.Error(t, err, "a SrvKeyspace with a gap should not be saved")
 
-			cells := []string{"zone1", "zone2", "zone3"}
+	resp, err = vtctld.UpdateSrvKeyspace(ctx, &vtctldatapb.UpdateSrvKeyspaceRequest{
+		Keyspace:        "testkeyspace",
+		Cell:            "zone1",
+		SrvKeyspace:     sharded,
+		ExpectedVersion: created,
+	})
+	require.NoError(t, err)
+	assert.NotEqual(t, created, resp.Version)
 
-			ctx := context.Background()
-			ts, topofactory := memorytopo.NewServerAndFactory(cells...)
-			tmc := testutil.TabletManagerClient{
-				TopoServer: ts,
-			}
-			vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, &tmc, func(ts *topo.Server) vtctlservicepb.VtctldServer {
-				return NewVtctldServer(ts)
-			})
+	srvKeyspace, version, err := ts.GetSrvKeyspaceWithVersion(ctx, "zone1", "testkeyspace")
+	require.NoError(t, err)
+	utils.MustMatch(t, sharded, srvKeyspace)
+	assert.Equal(t, resp.Version, version.String())
+
+	// The SrvKeyspace changed since the created version.
+	_, err = vtctld.UpdateSrvKeyspace(ctx, &vtctldatapb.UpdateSrvKeyspaceRequest{
+		Keyspace:        "testkeyspace",
+		Cell:            "zone1",
+		SrvKeyspace:     unsharded,
+		ExpectedVersion: created,
+	})
+	assert.Error(t, err, "updating from a stale version should fail")
 
-			if tt.tmcHasNoTopo {
-				// For certain test cases, we want specifically just the
-				// ChangeType call to fail, which is why we rely on a separate
-				// bool rather than using tt.topoErr.
-				tmc.TopoServer = nil
-			}
+	srvKeyspace, err = ts.GetSrvKeyspace(ctx, "zone1", "testkeyspace")
+	require.NoError(t, err)
+	utils.MustMatch(t, sharded, srvKeyspace)
+}
 
-			testutil.AddTablets(ctx, t, ts, &testutil.AddTabletOptions{
-				AlsoSetShardMaster: true,
-			}, tt.topo...)
+func TestUpdateWorkflowThrottling(t *testing.T) {
+	t.Parallel()
 
-			if tt.topoErr != nil {
-				topofactory.SetError(tt.topoErr)
-			}
+	ctx := context.Background()
+	ts := memorytopo.NewServer("zone1")
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
 
-			if tt.expectedTopo != nil {
-				// assert on expectedTopo state when we've fininished the rest
-				// of the test.
-				defer func() {
-					topofactory.SetError(nil)
+	err := ts.CreateKeyspace(ctx, "testkeyspace", &topodatapb.Keyspace{})
+	require.NoError(t, err)
 
-					ctx, cancel := context.WithTimeout(ctx, time.Millisecond*10)
-					defer cancel()
+	resp, err := vtctld.UpdateWorkflowThrottling(ctx, &vtctldatapb.UpdateWorkflowThrottlingRequest{
+		Keyspace: "testkeyspace",
+		Workflow: "wf1",
+		Throttling: &topodatapb.WorkflowThrottling{
+			RowsPerSecond:  1000,
+			BytesPerSecond: 1 << 20,
+		},
+	})
+	require.NoError(t, err)
+	utils.MustMatch(t, &topodatapb.WorkflowThrottling{
+		RowsPerSecond:  1000,
+		BytesPerSecond: 1 << 20,
+	}, resp.Throttling)
 
-					resp, err := vtctld.GetTablets(ctx, &vtctldatapb.GetTabletsRequest{})
-					require.NoError(t, err, "cannot get all tablets in the topo")
-					testutil.AssertSameTablets(t, tt.expectedTopo, resp.Tablets)
-				}()
-			}
+	// Only the limits of the fields are changed.
+	resp, err = vtctld.UpdateWorkflowThrottling(ctx, &vtctldatapb.UpdateWorkflowThrottlingRequest{
+		Keyspace: "testkeyspace",
+		Workflow: "wf1",
+		Throttling: &topodatapb.WorkflowThrottling{
+			RowsPerSecond:       500,
+			MaxConcurrentCopies: 2,
+		},
+		Fields: []string{"max_concurrent_copies"},
+	})
+	require.NoError(t, err)
+	utils.MustMatch(t, &topodatapb.WorkflowThrottling{
+		RowsPerSecond:       1000,
+		BytesPerSecond:      1 << 20,
+		MaxConcurrentCopies: 2,
+	}, resp.Throttling)
 
-			resp, err := vtctld.TabletExternallyReparented(ctx, tt.req)
-			if tt.shouldErr {
-				assert.Error(t, err)
-				return
-			}
+	workflows, err := ts.GetWorkflowThrottling(ctx, "testkeyspace")
+	require.NoError(t, err)
+	utils.MustMatch(t, map[string]*topodatapb.WorkflowThrottling{
+		"wf1": {
+			RowsPerSecond:       1000,
+			BytesPerSecond:      1 << 20,
+			MaxConcurrentCopies: 2,
+		},
+	}, workflows)
 
-			assert.NoError(t, err)
-			utils.MustMatch(t, tt.expected, resp)
-		})
+	// Removing all the limits removes the throttling of the workflow.
+	_, err = vtctld.UpdateWorkflowThrottling(ctx, &vtctldatapb.UpdateWorkflowThrottlingRequest{
+		Keyspace: "testkeyspace",
+		Workflow: "wf1",
+	})
+	require.NoError(t, err)
+	workflows, err = ts.GetWorkflowThrottling(ctx, "testkeyspace")
+	require.NoError(t, err)
+	assert.Empty(t, workflows)
+
+	for _, req := range []*vtctldatapb.UpdateWorkflowThrottlingRequest{
+		{Keyspace: "testkeyspace"},
+		{Keyspace: "nokeyspace", Workflow: "wf1"},
+		{Keyspace: "testkeyspace", Workflow: "wf1", Throttling: &topodatapb.WorkflowThrottling{RowsPerSecond: -1}},
+		{Keyspace: "testkeyspace", Workflow: "wf1", Fields: []string{"rows"}},
+	} {
+		_, err := vtctld.UpdateWorkflowThrottling(ctx, req)
+		assert.Error(t, err, "UpdateWorkflowThrottling(%v)", req)
 	}
 }
 
-func TestUpdateCellInfo(t *testing.T) {
+func TestValidateConfig(t *testing.T) {
 	t.Parallel()
 
 	ctx := context.Background()
-	tests := []struct {
-		name           string
-		cells          map[string]*topodatapb.CellInfo
-		forceTopoError bool
-		req            *vtctldatapb.UpdateCellInfoRequest
-		expected       *vtctldatapb.UpdateCellInfoResponse
-		shouldErr      bool
-	}{
-		{
-			name: "update",
-			cells: map[string]*topodatapb.CellInfo{
-				"zone1": {
-					ServerAddress: ":1111",
-					Root:          "/zone1",
-				},
-			},
-			req: &vtctldatapb.UpdateCellInfoRequest{
-				Name: "zone1",
-				CellInfo: &topodatapb.CellInfo{
-					ServerAddress: ":0101",
-					Root:          "/zones/zone1",
-				},
-			},
-			expected: &vtctldatapb.UpdateCellInfoResponse{
-				Name: "zone1",
-				CellInfo: &topodatapb.CellInfo{
-					ServerAddress: ":0101",
-					Root:          "/zones/zone1",
-				},
-			},
+	ts := memorytopo.NewServer("zone1")
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, &testutil.TabletManagerClient{}, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
+
+	_, err := vtctld.ValidateConfig(ctx, &vtctldatapb.ValidateConfigRequest{
+		Keyspace: "testkeyspace",
+	})
+	assert.Error(t, err, "the keyspace has no tablets")
+
+	report := &validateutil.ConfigReport{
+		Keyspace: "testkeyspace",
+		Expected: &validateutil.Config{
+			MySQLVariables: map[string]string{"sql_mode": "STRICT_TRANS_TABLES"},
+			Flags:          map[string]string{"queryserver-config-pool-size": "16"},
 		},
-		{
-			name: "partial update",
-			cells: map[string]*topodatapb.CellInfo{
-				"zone1": {
-					ServerAddress: ":1111",
-					Root:          "/zone1",
-				},
-			},
-			req: &vtctldatapb.UpdateCellInfoRequest{
-				Name: "zone1",
-				CellInfo: &topodatapb.CellInfo{
-					Root: "/zones/zone1",
-				},
-			},
-			expected: &vtctldatapb.UpdateCellInfoResponse{
-				Name: "zone1",
-				CellInfo: &topodatapb.CellInfo{
-					ServerAddress: ":1111",
-					Root:          "/zones/zone1",
-				},
+		Tablets: []*validateutil.TabletConfig{{
+			Tablet: "zone1-0000000100",
+			Shard:  "-",
+			Config: &validateutil.Config{
+				MySQLVariables: map[string]string{"sql_mode": "STRICT_TRANS_TABLES"},
+				Flags:          map[string]string{"queryserver-config-pool-size": "16"},
 			},
+		}, {
+			Tablet: "zone1-0000000101",
+			Shard:  "-",
+			Config: &validateutil.Config{
+				MySQLVariables: map[string]string{"sql_mode": ""},
+				Flags:          map[string]string{"queryserver-config-pool-size": "16"},
+			},
+			Drifts: []*validateutil.ConfigDrift{{
+				Kind:     validateutil.MySQLVariableSetting,
+				Name:     "sql_mode",
+				Value:    "",
+				Expected: "STRICT_TRANS_TABLES",
+			}},
+		}, {
+			Tablet: "zone1-0000000102",
+			Shard:  "-",
+			Error:  "cannot get the MySQL variables: unreachable",
+		}},
+	}
+	resp, err := configReportToProto(report)
+	require.NoError(t, err)
+	utils.MustMatch(t, &vtctldatapb.ValidateConfigResponse{
+		Keyspace: "testkeyspace",
+		Expected: &vtctldatapb.ValidateConfigResponse_Config{
+			MysqlVariables: map[string]string{"sql_mode": "STRICT_TRANS_TABLES"},
+			Flags:          map[string]string{"queryserver-config-pool-size": "16"},
 		},
-		{
-			name: "no update",
-			cells: map[string]*topodatapb.CellInfo{
-				"zone1": {
-					ServerAddress: ":1111",
-					Root:          "/zone1",
-				},
+		Tablets: []*vtctldatapb.ValidateConfigResponse_TabletConfig{{
+			Tablet: &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+			Shard:  "-",
+			Config: &vtctldatapb.ValidateConfigResponse_Config{
+				MysqlVariables: map[string]string{"sql_mode": "STRICT_TRANS_TABLES"},
+				Flags:          map[string]string{"queryserver-config-pool-size": "16"},
 			},
-			req: &vtctldatapb.UpdateCellInfoRequest{
-				Name: "zone1",
-				CellInfo: &topodatapb.CellInfo{
-					Root: "/zone1",
-				},
+		}, {
+			Tablet: &topodatapb.TabletAlias{Cell: "zone1", Uid: 101},
+			Shard:  "-",
+			Config: &vtctldatapb.ValidateConfigResponse_Config{
+				MysqlVariables: map[string]string{"sql_mode": ""},
+				Flags:          map[string]string{"queryserver-config-pool-size": "16"},
+			},
+			Drifts: []*vtctldatapb.ValidateConfigResponse_Drift{{
+				Kind:     "mysql_variable",
+				Name:     "sql_mode",
+				Expected: "STRICT_TRANS_TABLES",
+			}},
+		}, {
+			Tablet: &topodatapb.TabletAlias{Cell: "zone1", Uid: 102},
+			Shard:  "-",
+			Error:  "cannot get the MySQL variables: unreachable",
+		}},
+	}, resp)
+}
+
+func TestValidatePermissions(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ts := memorytopo.NewServer("zone1", "zone2")
+	testutil.AddTablets(ctx, t, ts, &testutil.AddTabletOptions{
+		AlsoSetShardMaster: true,
+	}, &topodatapb.Tablet{
+		Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+		Keyspace: "testkeyspace",
+		Shard:    "-",
+		Type:     topodatapb.TabletType_MASTER,
+	}, &topodatapb.Tablet{
+		Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 101},
+		Keyspace: "testkeyspace",
+		Shard:    "-",
+		Type:     topodatapb.TabletType_REPLICA,
+	}, &topodatapb.Tablet{
+		Alias:    &topodatapb.TabletAlias{Cell: "zone2", Uid: 200},
+		Keyspace: "testkeyspace",
+		Shard:    "-",
+		Type:     topodatapb.TabletType_REPLICA,
+	})
+
+	permissions := func(host string) *tabletmanagerdatapb.Permissions {
+		return &tabletmanagerdatapb.Permissions{
+			UserPermissions: []*tabletmanagerdatapb.UserPermission{{Host: host, User: "vt_app"}},
+		}
+	}
+	tmc := &testutil.TabletManagerClient{
+		GetPermissionsResults: map[string]struct {
+			Permissions *tabletmanagerdatapb.Permissions
+			Error       error
+		}{
+			"zone1-0000000100": {Permissions: permissions("%")},
+			"zone1-0000000101": {Permissions: permissions("%")},
+			"zone2-0000000200": {Permissions: permissions("localhost")},
+		},
+	}
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, tmc, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
+
+	resp, err := vtctld.ValidatePermissions(ctx, &vtctldatapb.ValidatePermissionsRequest{
+		Keyspace: "testkeyspace",
+		Cells:    []string{"zone1"},
+	})
+	require.NoError(t, err)
+	utils.MustMatch(t, &vtctldatapb.ValidatePermissionsResponse{
+		Keyspace:  "testkeyspace",
+		Reference: &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+		Tablets: []*vtctldatapb.ValidatePermissionsResponse_TabletPermissions{{
+			Tablet: &topodatapb.TabletAlias{Cell: "zone1", Uid: 101},
+			Shard:  "-",
+		}},
+		Consistent: true,
+	}, resp)
+
+	resp, err = vtctld.ValidatePermissions(ctx, &vtctldatapb.ValidatePermissionsRequest{
+		Keyspace: "testkeyspace",
+	})
+	require.NoError(t, err)
+	assert.False(t, resp.Consistent)
+	require.Len(t, resp.Tablets, 2)
+	assert.Empty(t, resp.Tablets[0].Differences)
+	assert.Equal(t, "zone2-0000000200", topoproto.TabletAliasString(resp.Tablets[1].Tablet))
+	assert.NotEmpty(t, resp.Tablets[1].Differences)
+
+	_, err = vtctld.ValidatePermissions(ctx, &vtctldatapb.ValidatePermissionsRequest{
+		Keyspace: "otherkeyspace",
+	})
+	assert.Error(t, err)
+}
+
+func TestValidateRoutingRules(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ts := memorytopo.NewServer("zone1")
+	testutil.AddKeyspace(ctx, t, ts, &vtctldatapb.Keyspace{
+		Name:     "ks1",
+		Keyspace: &topodatapb.Keyspace{},
+	})
+	require.NoError(t, ts.SaveVSchema(ctx, "ks1", &vschemapb.Keyspace{
+		Tables: map[string]*vschemapb.Table{"t1": {}},
+	}))
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
+
+	resp, err := vtctld.ValidateRoutingRules(ctx, &vtctldatapb.ValidateRoutingRulesRequest{
+		RoutingRules: &vschemapb.RoutingRules{
+			Rules: []*vschemapb.RoutingRule{
+				{FromTable: "t1", ToTables: []string{"ks1.t1"}},
 			},
-			expected: &vtctldatapb.UpdateCellInfoResponse{
-				Name: "zone1",
-				CellInfo: &topodatapb.CellInfo{
-					ServerAddress: ":1111",
-					Root:          "/zone1",
-				},
+		},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Error)
+
+	resp, err = vtctld.ValidateRoutingRules(ctx, &vtctldatapb.ValidateRoutingRulesRequest{
+		RoutingRules: &vschemapb.RoutingRules{
+			Rules: []*vschemapb.RoutingRule{
+				{FromTable: "t1", ToTables: []string{"t1"}},
 			},
 		},
+	})
+	require.NoError(t, err)
+	assert.Contains(t, resp.Error, "rule for table t1: table t1 must be qualified")
+}
+
+func TestValidateSrvKeyspace(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		srvKeyspace *topodatapb.SrvKeyspace
+		expectedErr string
+	}{
 		{
-			name: "cell not found",
-			cells: map[string]*topodatapb.CellInfo{
-				"zone1": {
-					ServerAddress: ":1111",
-					Root:          "/zone1",
-				},
-			},
-			req: &vtctldatapb.UpdateCellInfoRequest{
-				Name: "zone404",
-				CellInfo: &topodatapb.CellInfo{
-					ServerAddress: ":4040",
-					Root:          "/zone404",
-				},
-			},
-			expected: &vtctldatapb.UpdateCellInfoResponse{
-				Name: "zone404",
-				CellInfo: &topodatapb.CellInfo{
-					ServerAddress: ":4040",
-					Root:          "/zone404",
-				},
+			name: "valid",
+			srvKeyspace: &topodatapb.SrvKeyspace{
+				Partitions: []*topodatapb.SrvKeyspace_KeyspacePartition{{
+					ServedType: topodatapb.TabletType_MASTER,
+					ShardReferences: []*topodatapb.ShardReference{
+						{Name: "-80", KeyRange: &topodatapb.KeyRange{End: []byte{0x80}}},
+						{Name: "80-", KeyRange: &topodatapb.KeyRange{Start: []byte{0x80}}},
+					},
+				}},
 			},
 		},
-		{
-			name: "cannot update",
-			cells: map[string]*topodatapb.CellInfo{
-				"zone1": {
-					ServerAddress: ":1111",
-					Root:          "/zone1",
-				},
+		{
+			name: "overlap",
+			srvKeyspace: &topodatapb.SrvKeyspace{
+				Partitions: []*topodatapb.SrvKeyspace_KeyspacePartition{{
+					ServedType: topodatapb.TabletType_MASTER,
+					ShardReferences: []*topodatapb.ShardReference{
+						{Name: "-80", KeyRange: &topodatapb.KeyRange{End: []byte{0x80}}},
+						{Name: "40-", KeyRange: &topodatapb.KeyRange{Start: []byte{0x40}}},
+					},
+				}},
 			},
-			forceTopoError: true,
-			req: &vtctldatapb.UpdateCellInfoRequest{
-				Name: "zone1",
-				CellInfo: &topodatapb.CellInfo{
-					Root: "/zone1",
-				},
+			expectedErr: "overlapping shards -80 and 40-",
+		},
+		{
+			name: "gap",
+			srvKeyspace: &topodatapb.SrvKeyspace{
+				Partitions: []*topodatapb.SrvKeyspace_KeyspacePartition{{
+					ServedType: topodatapb.TabletType_MASTER,
+					ShardReferences: []*topodatapb.ShardReference{
+						{Name: "-40", KeyRange: &topodatapb.KeyRange{End: []byte{0x40}}},
+						{Name: "80-", KeyRange: &topodatapb.KeyRange{Start: []byte{0x80}}},
+					},
+				}},
 			},
-			expected:  nil,
-			shouldErr: true,
+			expectedErr: "gap between shards -40 and 80-",
 		},
 	}
 
+	ctx := context.Background()
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, memorytopo.NewServer("zone1"), nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
+
 	for _, tt := range tests {
 		tt := tt
+
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			ts, factory := memorytopo.NewServerAndFactory()
-			for name, cell := range tt.cells {
-				err := ts.CreateCellInfo(ctx, name, cell)
-				require.NoError(t, err, "failed to create cell %s: %+v for test", name, cell)
-			}
-
-			if tt.forceTopoError {
-				factory.SetError(fmt.Errorf("%w: topo down for testing", assert.AnError))
-			}
-
-			vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
-				return NewVtctldServer(ts)
+			resp, err := vtctld.ValidateSrvKeyspace(ctx, &vtctldatapb.ValidateSrvKeyspaceRequest{
+				SrvKeyspace: tt.srvKeyspace,
 			})
-			resp, err := vtctld.UpdateCellInfo(ctx, tt.req)
-			if tt.shouldErr {
-				assert.Error(t, err)
+			require.NoError(t, err)
+
+			if tt.expectedErr == "" {
+				assert.Empty(t, resp.Error)
 				return
 			}
 
-			require.NoError(t, err)
-			utils.MustMatch(t, tt.expected, resp)
+			assert.Contains(t, resp.Error, tt.expectedErr)
 		})
 	}
 }
 
-func TestUpdateCellsAlias(t *testing.T) {
+func TestValidateVersion(t *testing.T) {
 	t.Parallel()
 
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"BuildHost": "host", "BuildUser": "user", "BuildTimestamp": 1, "BuildGitRev": "rev"}`)
+	}))
+	defer server.Close()
+	host, port, err := netutil.SplitHostPort(strings.TrimPrefix(server.URL, "http://"))
+	require.NoError(t, err)
+
 	ctx := context.Background()
-	tests := []struct {
-		name      string
-		cells     []string
-		aliases   map[string][]string
-		req       *vtctldatapb.UpdateCellsAliasRequest
-		expected  *vtctldatapb.UpdateCellsAliasResponse
-		shouldErr bool
-	}{
-		{
-			name: "remove one cell",
-			aliases: map[string][]string{
-				"zone": {
-					"zone1",
-					"zone2",
-					"zone3",
-				},
-			},
-			req: &vtctldatapb.UpdateCellsAliasRequest{
-				Name: "zone",
-				CellsAlias: &topodatapb.CellsAlias{
-					Cells: []string{"zone1", "zone2"},
-				},
-			},
-			expected: &vtctldatapb.UpdateCellsAliasResponse{
-				Name: "zone",
-				CellsAlias: &topodatapb.CellsAlias{
-					Cells: []string{"zone1", "zone2"},
-				},
-			},
-		},
-		{
-			name:  "add one cell",
-			cells: []string{"zone4"}, // all other cells get created via the aliases map
-			aliases: map[string][]string{
-				"zone": {
-					"zone1",
-					"zone2",
-					"zone3",
-				},
-			},
-			req: &vtctldatapb.UpdateCellsAliasRequest{
-				Name: "zone",
-				CellsAlias: &topodatapb.CellsAlias{
-					Cells: []string{
-						"zone1",
-						"zone2",
-						"zone3",
-						"zone4",
-					},
-				},
-			},
-			expected: &vtctldatapb.UpdateCellsAliasResponse{
-				Name: "zone",
-				CellsAlias: &topodatapb.CellsAlias{
-					Cells: []string{
-						"zone1",
-						"zone2",
-						"zone3",
-						"zone4",
+	ts := memorytopo.NewServer("zone1")
+	testutil.AddTablets(ctx, t, ts, &testutil.AddTabletOptions{
+		AlsoSetShardMaster: true,
+	}, &topodatapb.Tablet{
+		Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+		Keyspace: "testkeyspace",
+		Shard:    "-",
+		Type:     topodatapb.TabletType_MASTER,
+		Hostname: host,
+		PortMap:  map[string]int32{"vt": int32(port)},
+	}, &topodatapb.Tablet{
+		Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 101},
+		Keyspace: "testkeyspace",
+		Shard:    "-",
+		Type:     topodatapb.TabletType_REPLICA,
+		Hostname: host,
+		PortMap:  map[string]int32{"vt": int32(port)},
+	})
+
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, &testutil.TabletManagerClient{}, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
+
+	resp, err := vtctld.ValidateVersion(ctx, &vtctldatapb.ValidateVersionRequest{
+		Keyspace: "testkeyspace",
+	})
+	require.NoError(t, err)
+
+	version := "{host user 1 rev}"
+	utils.MustMatch(t, &vtctldatapb.ValidateVersionResponse{
+		Keyspace: "testkeyspace",
+		Tablets: []*vtctldatapb.ValidateVersionResponse_TabletVersion{{
+			Tablet:  &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+			Shard:   "-",
+			Version: version,
+		}, {
+			Tablet:  &topodatapb.TabletAlias{Cell: "zone1", Uid: 101},
+			Shard:   "-",
+			Version: version,
+		}},
+		Matrix: map[string]*vtctldatapb.ValidateVersionResponse_ShardVersions{
+			"-": {
+				Versions: map[string]*vtctldatapb.ValidateVersionResponse_TabletAliases{
+					version: {
+						Aliases: []*topodatapb.TabletAlias{
+							{Cell: "zone1", Uid: 100},
+							{Cell: "zone1", Uid: 101},
+						},
 					},
 				},
 			},
 		},
-		{
-			name:  "alias does not exist",
-			cells: []string{"zone1", "zone2"},
-			req: &vtctldatapb.UpdateCellsAliasRequest{
-				Name: "zone",
-				CellsAlias: &topodatapb.CellsAlias{
-					Cells: []string{"zone1", "zone2"},
-				},
+		Consistent: true,
+	}, resp)
+}
+
+func TestVerifyReparentShard(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ts := memorytopo.NewServer("zone1")
+	testutil.AddTablets(ctx, t, ts, &testutil.AddTabletOptions{
+		AlsoSetShardMaster: true,
+	}, &topodatapb.Tablet{
+		Alias:         &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+		Keyspace:      "testkeyspace",
+		Shard:         "-",
+		Type:          topodatapb.TabletType_MASTER,
+		MysqlHostname: "primary",
+		MysqlPort:     3306,
+	}, &topodatapb.Tablet{
+		Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 101},
+		Keyspace: "testkeyspace",
+		Shard:    "-",
+		Type:     topodatapb.TabletType_REPLICA,
+	}, &topodatapb.Tablet{
+		Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 102},
+		Keyspace: "testkeyspace",
+		Shard:    "-",
+		Type:     topodatapb.TabletType_RDONLY,
+	})
+
+	tmc := &testutil.TabletManagerClient{
+		ReplicationStatusResults: map[string]struct {
+			Position *replicationdatapb.Status
+			Error    error
+		}{
+			"zone1-0000000101": {Position: &replicationdatapb.Status{
+				MasterHost:          "primary",
+				MasterPort:          3306,
+				IoThreadRunning:     true,
+				SqlThreadRunning:    true,
+				SecondsBehindMaster: 2,
+			}},
+			"zone1-0000000102": {Position: &replicationdatapb.Status{
+				MasterHost:       "oldprimary",
+				MasterPort:       3306,
+				IoThreadRunning:  true,
+				SqlThreadRunning: true,
+			}},
+		},
+	}
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, tmc, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
+
+	resp, err := vtctld.VerifyReparentShard(ctx, &vtctldatapb.VerifyReparentShardRequest{
+		Keyspace: "testkeyspace",
+		Shard:    "-",
+	})
+	require.NoError(t, err)
+	utils.MustMatch(t, &vtctldatapb.VerifyReparentShardResponse{
+		Keyspace: "testkeyspace",
+		Shard:    "-",
+		Primary:  &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+		Tablets: []*vtctldatapb.VerifyReparentShardResponse_TabletCheck{{
+			Tablet: &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+			Type:   topodatapb.TabletType_MASTER,
+		}, {
+			Tablet:     &topodatapb.TabletAlias{Cell: "zone1", Uid: 101},
+			Type:       topodatapb.TabletType_REPLICA,
+			Source:     "primary:3306",
+			LagSeconds: 2,
+		}, {
+			Tablet:  &topodatapb.TabletAlias{Cell: "zone1", Uid: 102},
+			Type:    topodatapb.TabletType_RDONLY,
+			Source:  "oldprimary:3306",
+			Problem: "it replicates from oldprimary:3306 instead of the primary primary:3306",
+		}},
+	}, resp)
+
+	_, err = vtctld.VerifyReparentShard(ctx, &vtctldatapb.VerifyReparentShardRequest{
+		Keyspace: "otherkeyspace",
+		Shard:    "-",
+	})
+	assert.Error(t, err)
+}
+
+func TestVerifyReverseReplication(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ts := memorytopo.NewServer("zone1")
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
+
+	err := ts.CreateKeyspace(ctx, "testkeyspace", &topodatapb.Keyspace{})
+	require.NoError(t, err)
+
+	_, err = vtctld.VerifyReverseReplication(ctx, &vtctldatapb.VerifyReverseReplicationRequest{Keyspace: "testkeyspace"})
+	assert.Error(t, err, "the workflow is required")
+
+	_, err = vtctld.VerifyReverseReplication(ctx, &vtctldatapb.VerifyReverseReplicationRequest{
+		Keyspace: "testkeyspace",
+		Workflow: "wf1",
+		Checksum: true,
+	})
+	assert.Error(t, err, "the sample rate is required with checksum")
+
+	_, err = vtctld.VerifyReverseReplication(ctx, &vtctldatapb.VerifyReverseReplicationRequest{
+		Keyspace: "testkeyspace",
+		Workflow: "noworkflow",
+	})
+	assert.Error(t, err, "the keyspace has no workflow")
+
+	report := &workflow.ReverseReplicationReport{
+		Keyspace:        "testkeyspace",
+		Workflow:        "wf1",
+		ReverseKeyspace: "source",
+		ReverseWorkflow: "wf1_reverse",
+		Streams: []*workflow.ReverseStreamReport{
+			{
+				Shard:  "0",
+				Tablet: &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+				ID:     1,
+				State:  "Running",
 			},
-			expected: &vtctldatapb.UpdateCellsAliasResponse{
-				Name: "zone",
-				CellsAlias: &topodatapb.CellsAlias{
-					Cells: []string{"zone1", "zone2"},
-				},
+		},
+		StreamsHealthy: true,
+		StreamsReason:  "reverse replication is running, lag 2s",
+		MaxReverseLag:  2,
+		Tables: []*workflow.TableChecksumReport{
+			{
+				Table:          "customer",
+				SampleRate:     100,
+				SourceRows:     10,
+				TargetRows:     9,
+				SourceChecksum: 42,
+				TargetChecksum: 7,
 			},
 		},
-		{
-			name: "invalid alias list",
-			aliases: map[string][]string{
-				"zone_a": {
-					"zone1",
-					"zone2",
-				},
-				"zone_b": {
-					"zone3",
-					"zone4",
+		Reason: "the sampled checksums of tables customer differ between the source and the target",
+	}
+	expected := &vtctldatapb.VerifyReverseReplicationResponse{
+		Keyspace:        "testkeyspace",
+		Workflow:        "wf1",
+		ReverseKeyspace: "source",
+		ReverseWorkflow: "wf1_reverse",
+		Streams: []*vtctldatapb.VerifyReverseReplicationResponse_ReverseStream{
+			{
+				Shard: "0",
+				Tablet: &topodatapb.TabletAlias{
+					Cell: "zone1",
+					Uid:  100,
 				},
+				Id:    1,
+				State: "Running",
 			},
-			req: &vtctldatapb.UpdateCellsAliasRequest{
-				Name: "zone_a",
-				CellsAlias: &topodatapb.CellsAlias{
-					Cells: []string{
-						"zone1",
-						"zone2",
-						"zone3", // this is invalid because it belongs to alias zone_b
-					},
-				},
+		},
+		StreamsHealthy: true,
+		StreamsReason:  "reverse replication is running, lag 2s",
+		MaxReverseLag:  2,
+		Tables: []*vtctldatapb.VerifyReverseReplicationResponse_TableChecksum{
+			{
+				Table:          "customer",
+				SampleRate:     100,
+				SourceRows:     10,
+				TargetRows:     9,
+				SourceChecksum: 42,
+				TargetChecksum: 7,
 			},
-			shouldErr: true,
 		},
+		Reason: "the sampled checksums of tables customer differ between the source and the target",
 	}
+	utils.MustMatch(t, expected, reverseReplicationReportToProto(report))
+}
 
-	for _, tt := range tests {
-		tt := tt
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
+func TestWatchTopology(t *testing.T) {
+	t.Parallel()
 
-			ts := memorytopo.NewServer(tt.cells...)
-			for name, cells := range tt.aliases {
-				for _, cell := range cells {
-					// We use UpdateCellInfoFields rather than CreateCellInfo
-					// for the update-or-create behavior.
-					err := ts.UpdateCellInfoFields(ctx, cell, func(ci *topodatapb.CellInfo) error {
-						ci.Root = "/" + cell
-						ci.ServerAddress = cell + ":8080"
-						return nil
-					})
-					require.NoError(t, err, "failed to create cell %v", cell)
-				}
+	ctx := context.Background()
+	ts := memorytopo.NewServer("zone1")
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(ts)
+	})
 
-				err := ts.CreateCellsAlias(ctx, name, &topodatapb.CellsAlias{
-					Cells: cells,
-				})
-				require.NoError(t, err, "failed to create cell alias %v (cells = %v)", name, cells)
-			}
+	err := ts.CreateKeyspace(ctx, "testkeyspace", &topodatapb.Keyspace{})
+	require.NoError(t, err)
 
-			vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
-				return NewVtctldServer(ts)
-			})
-			resp, err := vtctld.UpdateCellsAlias(ctx, tt.req)
-			if tt.shouldErr {
-				assert.Error(t, err)
-				return
-			}
+	// An empty version returns the current version.
+	resp, err := vtctld.WatchTopology(ctx, &vtctldatapb.WatchTopologyRequest{Path: "/global/keyspaces"})
+	require.NoError(t, err)
+	require.NotEmpty(t, resp.Version)
+	assert.False(t, resp.Changed)
+	version := resp.Version
 
-			require.NoError(t, err)
-			utils.MustMatch(t, tt.expected, resp)
-		})
-	}
+	// A change made before the watch is seen right away.
+	err = ts.CreateShard(ctx, "testkeyspace", "-")
+	require.NoError(t, err)
+
+	resp, err = vtctld.WatchTopology(ctx, &vtctldatapb.WatchTopologyRequest{
+		Path:    "/global/keyspaces",
+		Version: version,
+		Timeout: protoutil.DurationToProto(time.Second),
+	})
+	require.NoError(t, err)
+	assert.True(t, resp.Changed)
+	assert.NotEqual(t, version, resp.Version)
+	version = resp.Version
+
+	// Without a change, the watch times out.
+	resp, err = vtctld.WatchTopology(ctx, &vtctldatapb.WatchTopologyRequest{
+		Path:    "/global/keyspaces",
+		Version: version,
+		Timeout: protoutil.DurationToProto(10 * time.Millisecond),
+	})
+	require.NoError(t, err)
+	assert.False(t, resp.Changed)
+	assert.Equal(t, version, resp.Version)
 }
@@ -0,0 +1,266 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcvtctldserver
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+	"vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// maxTabletPageSize is the maximum PageSize of a GetTablets request.
+const maxTabletPageSize = 1000
+
+// tabletFilter holds the filters and the field mask of a GetTablets
+// request.
+type tabletFilter struct {
+	tabletTypes []topodatapb.TabletType
+	hostname    string
+	serving     vtctldatapb.GetTabletsRequest_ServingFilter
+	health      TabletHealthSource
+	fields      map[string]bool
+}
+
+func newTabletFilter(req *vtctldatapb.GetTabletsRequest) (*tabletFilter, error) {
+	f := &tabletFilter{
+		tabletTypes: req.TabletTypes,
+		hostname:    req.Hostname,
+		serving:     req.Serving,
+	}
+	if f.serving != vtctldatapb.GetTabletsRequest_ANY {
+		var err error
+		if f.health, err = getTabletHealthSource(); err != nil {
+			return nil, err
+		}
+	}
+	if len(req.Fields) > 0 {
+		descs := (&topodatapb.Tablet{}).ProtoReflect().Descriptor().Fields()
+		f.fields = make(map[string]bool, len(req.Fields))
+		for _, field := range req.Fields {
+			if descs.ByName(protoreflect.Name(field)) == nil {
+				return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "unknown tablet field %q", field)
+			}
+			f.fields[field] = true
+		}
+	}
+	return f, nil
+}
+
+// matches returns whether the tablet passes the filters.
+func (f *tabletFilter) matches(tablet *topodatapb.Tablet) bool {
+	if len(f.tabletTypes) > 0 && !topoproto.IsTypeInList(tablet.Type, f.tabletTypes) {
+		return false
+	}
+	if f.hostname != "" && !strings.Contains(tablet.Hostname, f.hostname) {
+		return false
+	}
+	if f.serving != vtctldatapb.GetTabletsRequest_ANY {
+		serving := f.health.IsServing(tablet.Alias)
+		if serving != (f.serving == vtctldatapb.GetTabletsRequest_SERVING) {
+			return false
+		}
+	}
+	return true
+}
+
+// mask clears the fields of the tablet which aren't in the field mask.
+func (f *tabletFilter) mask(tablet *topodatapb.Tablet) {
+	if f.fields == nil {
+		return
+	}
+	m := tablet.ProtoReflect()
+	descs := m.Descriptor().Fields()
+	for i := 0; i < descs.Len(); i++ {
+		if desc := descs.Get(i); !f.fields[string(desc.Name())] {
+			m.Clear(desc)
+		}
+	}
+}
+
+// apply returns the tablets which pass the filters, masked.
+func (f *tabletFilter) apply(tablets []*topodatapb.Tablet) []*topodatapb.Tablet {
+	filtered := make([]*topodatapb.Tablet, 0, len(tablets))
+	for _, tablet := range tablets {
+		if !f.matches(tablet) {
+			continue
+		}
+		f.mask(tablet)
+		filtered = append(filtered, tablet)
+	}
+	return filtered
+}
+
+// getTabletsPage returns a page of the tablets of a GetTablets request
+// with a PageSize, in the order of their aliases. The tablet records are
+// read one page at a time, and the filters are applied before the
+// pagination.
+func (s *VtctldServer) getTabletsPage(ctx context.Context, req *vtctldatapb.GetTabletsRequest, filter *tabletFilter) (*vtctldatapb.GetTabletsResponse, error) {
+	var after string
+	if req.PageToken != "" {
+		data, err := base64.RawURLEncoding.DecodeString(req.PageToken)
+		if err == nil {
+			_, err = topoproto.ParseTabletAlias(string(data))
+		}
+		if err != nil {
+			return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "invalid page_token %q", req.PageToken)
+		}
+		after = string(data)
+	}
+
+	aliases, err := s.listTabletAliases(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(aliases)
+	start := sort.SearchStrings(aliases, after)
+	if start < len(aliases) && aliases[start] == after {
+		start++
+	}
+	aliases = aliases[start:]
+
+	pageSize := int(req.PageSize)
+	// primaries are the primary aliases of the shards of the MASTER
+	// tablets, to report the stale primaries as UNKNOWN.
+	primaries := map[string]*topodatapb.TabletAlias{}
+	resp := &vtctldatapb.GetTabletsResponse{}
+	for len(aliases) > 0 && len(resp.Tablets) < pageSize {
+		batch := aliases
+		if len(batch) > pageSize {
+			batch = batch[:pageSize]
+		}
+		tabletAliases := make([]*topodatapb.TabletAlias, 0, len(batch))
+		for _, alias := range batch {
+			tabletAlias, err := topoproto.ParseTabletAlias(alias)
+			if err != nil {
+				return nil, err
+			}
+			tabletAliases = append(tabletAliases, tabletAlias)
+		}
+		// A tablet which can't be read would be skipped for good by the
+		// next page, so the page fails instead.
+		tabletMap, err := s.ts.GetTabletMap(ctx, tabletAliases)
+		if err != nil {
+			return nil, fmt.Errorf("GetTabletMap(%v) failed: %w", batch, err)
+		}
+
+		examined := 0
+		for _, alias := range batch {
+			if len(resp.Tablets) == pageSize {
+				break
+			}
+			examined++
+			ti, ok := tabletMap[alias]
+			if !ok {
+				// The tablet was deleted since it was listed.
+				continue
+			}
+			if len(req.TabletAliases) == 0 && req.Keyspace != "" {
+				if ti.Keyspace != req.Keyspace || (req.Shard != "" && ti.Shard != req.Shard) {
+					continue
+				}
+			}
+			if ti.Type == topodatapb.TabletType_MASTER {
+				key := topoproto.KeyspaceShardString(ti.Keyspace, ti.Shard)
+				primary, ok := primaries[key]
+				if !ok {
+					si, err := s.ts.GetShard(ctx, ti.Keyspace, ti.Shard)
+					if err != nil {
+						return nil, fmt.Errorf("GetShard(%v) failed: %w", key, err)
+					}
+					primary = si.MasterAlias
+					primaries[key] = primary
+				}
+				if !topoproto.TabletAliasEqual(primary, ti.Alias) {
+					ti.Tablet.Type = topodatapb.TabletType_UNKNOWN
+				}
+			}
+			if !filter.matches(ti.Tablet) {
+				continue
+			}
+			filter.mask(ti.Tablet)
+			resp.Tablets = append(resp.Tablets, ti.Tablet)
+		}
+		if examined < len(aliases) {
+			resp.NextPageToken = base64.RawURLEncoding.EncodeToString([]byte(batch[examined-1]))
+		} else {
+			resp.NextPageToken = ""
+		}
+		aliases = aliases[examined:]
+	}
+	return resp, nil
+}
+
+// listTabletAliases returns the aliases of the tablets of a GetTablets
+// request: its TabletAliases, or the aliases of the tablets of its shard,
+// or of its cells.
+func (s *VtctldServer) listTabletAliases(ctx context.Context, req *vtctldatapb.GetTabletsRequest) ([]string, error) {
+	var tabletAliases []*topodatapb.TabletAlias
+	switch {
+	case len(req.TabletAliases) > 0:
+		tabletAliases = req.TabletAliases
+	case req.Keyspace != "" && req.Shard != "":
+		var err error
+		tabletAliases, err = s.ts.FindAllTabletAliasesInShardByCell(ctx, req.Keyspace, req.Shard, req.Cells)
+		if err != nil {
+			if !topo.IsErrType(err, topo.PartialResult) || req.Strict {
+				return nil, fmt.Errorf("FindAllTabletAliasesInShardByCell(%s, %s) failed: %w", req.Keyspace, req.Shard, err)
+			}
+			log.Warningf("GetTablets encountered non-fatal error %s; continuing because Strict=false", err)
+		}
+	default:
+		cells := req.Cells
+		if len(cells) == 0 {
+			var err error
+			if cells, err = s.ts.GetKnownCells(ctx); err != nil {
+				return nil, err
+			}
+		}
+		failed := 0
+		for _, cell := range cells {
+			cellAliases, err := s.ts.GetTabletsByCell(ctx, cell)
+			if err != nil {
+				err = fmt.Errorf("GetTabletsByCell(%s) failed: %w", cell, err)
+				failed++
+				if req.Strict || failed == len(cells) {
+					return nil, err
+				}
+				log.Warningf("GetTablets encountered non-fatal error %s; continuing because Strict=false", err)
+				continue
+			}
+			tabletAliases = append(tabletAliases, cellAliases...)
+		}
+	}
+
+	aliases := make([]string, 0, len(tabletAliases))
+	for _, tabletAlias := range tabletAliases {
+		aliases = append(aliases, topoproto.TabletAliasString(tabletAlias))
+	}
+	return aliases, nil
+}
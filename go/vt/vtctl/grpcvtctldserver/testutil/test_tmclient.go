@@ -134,6 +134,16 @@ type TabletManagerClient struct {
 		Status *replicationdatapb.MasterStatus
 		Error  error
 	}
+	// tablet alias => query string => result
+	ExecuteFetchAsAppResults map[string]map[string]struct {
+		Result *querypb.QueryResult
+		Error  error
+	}
+	// keyed by tablet alias.
+	GetPermissionsResults map[string]struct {
+		Permissions *tabletmanagerdatapb.Permissions
+		Error       error
+	}
 	// keyed by tablet alias.
 	GetSchemaDelays map[string]time.Duration
 	// keyed by tablet alias.
@@ -178,6 +188,8 @@ type TabletManagerClient struct {
 	// keyed by tablet alias.
 	SetReadWriteResults map[string]error
 	// keyed by tablet alias.
+	StopReplicationResults map[string]error
+	// keyed by tablet alias.
 	StopReplicationAndGetStatusDelays map[string]time.Duration
 	// keyed by tablet alias.
 	StopReplicationAndGetStatusResults map[string]struct {
@@ -246,6 +258,48 @@ func (fake *TabletManagerClient) DemoteMaster(ctx context.Context, tablet *topod
 	return nil, assert.AnError
 }
 
+// ExecuteFetchAsApp is part of the tmclient.TabletManagerClient interface.
+func (fake *TabletManagerClient) ExecuteFetchAsApp(ctx context.Context, tablet *topodatapb.Tablet, usePool bool, query []byte, maxRows int) (*querypb.QueryResult, error) {
+	if fake.ExecuteFetchAsAppResults == nil {
+		return nil, assert.AnError
+	}
+
+	if tablet.Alias == nil {
+		return nil, assert.AnError
+	}
+
+	key := topoproto.TabletAliasString(tablet.Alias)
+
+	if resultsForTablet, ok := fake.ExecuteFetchAsAppResults[key]; ok {
+		if result, ok := resultsForTablet[string(query)]; ok {
+			return result.Result, result.Error
+		}
+
+		return nil, fmt.Errorf("%w: no ExecuteFetchAsApp result set for query %s", assert.AnError, query)
+	}
+
+	return nil, fmt.Errorf("%w: no ExecuteFetchAsApp results on fake TabletManagerClient", assert.AnError)
+}
+
+// GetPermissions is part of the tmclient.TabletManagerClient interface.
+func (fake *TabletManagerClient) GetPermissions(ctx context.Context, tablet *topodatapb.Tablet) (*tabletmanagerdatapb.Permissions, error) {
+	if fake.GetPermissionsResults == nil {
+		return nil, assert.AnError
+	}
+
+	if tablet.Alias == nil {
+		return nil, assert.AnError
+	}
+
+	key := topoproto.TabletAliasString(tablet.Alias)
+
+	if result, ok := fake.GetPermissionsResults[key]; ok {
+		return result.Permissions, result.Error
+	}
+
+	return nil, fmt.Errorf("%w: no permissions for %s", assert.AnError, key)
+}
+
 // GetSchema is part of the tmclient.TabletManagerClient interface.
 func (fake *TabletManagerClient) GetSchema(ctx context.Context, tablet *topodatapb.Tablet, tablets []string, excludeTables []string, includeViews bool) (*tabletmanagerdatapb.SchemaDefinition, error) {
 	if fake.GetSchemaResults == nil {
@@ -464,6 +518,21 @@ func (fake *TabletManagerClient) SetReadWrite(ctx context.Context, tablet *topod
 	return assert.AnError
 }
 
+// StopReplication is part of the tmclient.TabletManagerClient interface.
+func (fake *TabletManagerClient) StopReplication(ctx context.Context, tablet *topodatapb.Tablet) error {
+	if fake.StopReplicationResults == nil {
+		return assert.AnError
+	}
+
+	key := topoproto.TabletAliasString(tablet.Alias)
+
+	if err, ok := fake.StopReplicationResults[key]; ok {
+		return err
+	}
+
+	return assert.AnError
+}
+
 // StopReplicationAndGetStatus is part of the tmclient.TabletManagerClient
 // interface.
 func (fake *TabletManagerClient) StopReplicationAndGetStatus(ctx context.Context, tablet *topodatapb.Tablet, mode replicationdatapb.StopReplicationMode) (*replicationdatapb.Status, *replicationdatapb.StopReplicationStatus, error) {
@@ -0,0 +1,36 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcvtctldserver
+
+import (
+	workflowmanager "vitess.io/vitess/go/vt/workflow"
+)
+
+// hostMaintenanceFactoryName is the name of the factory of the workflows
+// created by StartHostMaintenance, registered by the hostmaintenance
+// package.
+const hostMaintenanceFactoryName = "host_maintenance"
+
+// workflowManager runs the workflows created by the RPCs, e.g.
+// StartHostMaintenance. It is nil if the vtctld runs no workflow manager.
+var workflowManager *workflowmanager.Manager
+
+// SetWorkflowManager sets the workflow manager of the vtctld, see
+// -workflow_manager_init.
+func SetWorkflowManager(m *workflowmanager.Manager) {
+	workflowManager = m
+}
@@ -52,6 +52,10 @@ func NewVtctlServer(ts *topo.Server) *VtctlServer {
 func (s *VtctlServer) ExecuteVtctlCommand(args *vtctldatapb.ExecuteVtctlCommandRequest, stream vtctlservicepb.Vtctl_ExecuteVtctlCommandServer) (err error) {
 	defer servenv.HandlePanic("vtctl", &err)
 
+	if err := vtctl.CheckReadOnlyCommand(args.Args); err != nil {
+		return err
+	}
+
 	// Create a logger, send the result back to the caller.
 	// We may execute this in parallel (inside multiple go routines),
 	// but the stream.Send() method is not thread safe in gRPC.
@@ -0,0 +1,551 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package loadutil inserts rows into the tables of a keyspace on the
+// masters of its shards, routing each row with the primary vindex of its
+// table: LoadData imports CSV files from the backup storage, and SeedTable
+// copies the rows of the seed source declared in the VSchema of a table.
+package loadutil
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"vitess.io/vitess/go/sqlescape"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/key"
+	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/mysqlctl/backupstorage"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+	"vitess.io/vitess/go/vt/vttablet/tmclient"
+
+	logutilpb "vitess.io/vitess/go/vt/proto/logutil"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// This file implements LoadData, which imports CSV files from the backup
+// storage into a table. Each row is routed to its shard with the primary
+// vindex of the table, and inserted in batches on the master of the shard.
+// The rows of reference tables are inserted on all the shards. Tables with
+// owned vindexes can't be loaded this way, as their lookup tables wouldn't
+// be populated.
+
+// loadDataNull is the representation of NULL in the files, as with the
+// LOAD DATA statement of MySQL.
+const loadDataNull = `\N`
+
+// Loader performs LoadData and SeedTable operations.
+type Loader struct {
+	ts     *topo.Server
+	tmc    tmclient.TabletManagerClient
+	logger logutil.Logger
+}
+
+// NewLoader returns a new Loader, which inserts the rows using the given
+// topo.Server and TabletManagerClient, and logs its progress to the given
+// logger.
+//
+// Providing a nil logger instance is allowed.
+func NewLoader(ts *topo.Server, tmc tmclient.TabletManagerClient, logger logutil.Logger) *Loader {
+	l := &Loader{
+		ts:     ts,
+		tmc:    tmc,
+		logger: logger,
+	}
+
+	if l.logger == nil {
+		l.logger = logutil.NewCallbackLogger(func(*logutilpb.Event) {})
+	}
+
+	return l
+}
+
+// LoadDataOptions are the options of LoadData.
+type LoadDataOptions struct {
+	// Columns are the columns of the rows in the files. If empty, the
+	// first line of each file lists them.
+	Columns []string
+	// BatchSize is the number of rows inserted per statement.
+	BatchSize int
+	// MaxRowsPerSecond throttles the inserts on each shard. 0 means
+	// unlimited.
+	MaxRowsPerSecond int
+	// IgnoreDuplicates skips the rows whose keys already exist, instead
+	// of failing.
+	IgnoreDuplicates bool
+}
+
+// LoadDataShardProgress is the progress of LoadData on a shard.
+type LoadDataShardProgress struct {
+	Shard      string
+	Master     string
+	RowsLoaded int64
+	Batches    int64
+	// Error is the error which stopped the load of the shard, if any.
+	Error string `json:",omitempty"`
+}
+
+// LoadData loads the CSV files of the backup storage directory dir and
+// backup name into the table of the keyspace. It returns the progress of
+// each shard, and an error if any of them failed. The rows inserted before
+// a failure are not rolled back.
+func (l *Loader) LoadData(ctx context.Context, keyspace, table, dir, name string, files []string, options *LoadDataOptions) ([]*LoadDataShardProgress, error) {
+	if options.BatchSize <= 0 {
+		return nil, fmt.Errorf("invalid batch size: %v", options.BatchSize)
+	}
+	shards, err := l.ts.GetServingShards(ctx, keyspace)
+	if err != nil {
+		return nil, err
+	}
+	router, err := l.newLoadDataRouter(ctx, keyspace, table, shards)
+	if err != nil {
+		return nil, err
+	}
+
+	bs, err := backupstorage.GetBackupStorage()
+	if err != nil {
+		return nil, err
+	}
+	defer bs.Close()
+	handle, err := findBackupHandle(ctx, bs, dir, name)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	loaders, err := l.newShardLoaders(ctx, keyspace, table, shards, options)
+	if err != nil {
+		return nil, err
+	}
+	var wg sync.WaitGroup
+	readErr := l.readLoadData(ctx, handle, files, options, router, loaders, &wg)
+	return finishShardLoaders(loaders, &wg, readErr)
+}
+
+// findBackupHandle returns the handle of the backup storage directory.
+func findBackupHandle(ctx context.Context, bs backupstorage.BackupStorage, dir, name string) (backupstorage.BackupHandle, error) {
+	handles, err := bs.ListBackups(ctx, dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, h := range handles {
+		if h.Name() == name {
+			return h, nil
+		}
+	}
+	return nil, fmt.Errorf("no %v in backup storage directory %v", name, dir)
+}
+
+// newShardLoaders returns the loaders of the masters of the shards.
+func (l *Loader) newShardLoaders(ctx context.Context, keyspace, table string, shards []*topo.ShardInfo, options *LoadDataOptions) ([]*shardLoader, error) {
+	loaders := make([]*shardLoader, len(shards))
+	for i, si := range shards {
+		if si.MasterAlias == nil {
+			return nil, fmt.Errorf("shard %v/%v has no master", keyspace, si.ShardName())
+		}
+		ti, err := l.ts.GetTablet(ctx, si.MasterAlias)
+		if err != nil {
+			return nil, err
+		}
+		loaders[i] = &shardLoader{
+			loader:           l,
+			tablet:           ti.Tablet,
+			table:            table,
+			ignoreDuplicates: options.IgnoreDuplicates,
+			rows:             make(chan []sqltypes.Value, options.BatchSize),
+			progress: &LoadDataShardProgress{
+				Shard:  si.ShardName(),
+				Master: topoproto.TabletAliasString(si.MasterAlias),
+			},
+		}
+		if options.MaxRowsPerSecond > 0 {
+			loaders[i].limiter = rate.NewLimiter(rate.Limit(options.MaxRowsPerSecond), options.BatchSize)
+		}
+	}
+	return loaders, nil
+}
+
+// startShardLoaders starts the loaders once the columns of the rows are
+// known.
+func startShardLoaders(ctx context.Context, loaders []*shardLoader, columns []string, batchSize int, wg *sync.WaitGroup) {
+	for _, loader := range loaders {
+		loader.columns = columns
+		wg.Add(1)
+		go loader.run(ctx, batchSize, wg)
+	}
+}
+
+// finishShardLoaders waits for the loaders to insert the rows sent to
+// them, and returns their progress.
+func finishShardLoaders(loaders []*shardLoader, wg *sync.WaitGroup, readErr error) ([]*LoadDataShardProgress, error) {
+	for _, loader := range loaders {
+		close(loader.rows)
+	}
+	wg.Wait()
+
+	var progress []*LoadDataShardProgress
+	var failed []string
+	for _, loader := range loaders {
+		progress = append(progress, loader.progress)
+		if loader.progress.Error != "" {
+			failed = append(failed, loader.progress.Shard)
+		}
+	}
+	if readErr != nil {
+		return progress, readErr
+	}
+	if len(failed) > 0 {
+		return progress, fmt.Errorf("LoadData failed on shards: %v", strings.Join(failed, ", "))
+	}
+	return progress, nil
+}
+
+// readLoadData reads the files and sends their rows to the loader of their
+// shard, which it starts once the columns are known.
+func (l *Loader) readLoadData(ctx context.Context, handle backupstorage.BackupHandle, files []string, options *LoadDataOptions, router *loadDataRouter, loaders []*shardLoader, wg *sync.WaitGroup) error {
+	started := false
+	var columns []string
+	for _, file := range files {
+		rc, err := handle.ReadFile(ctx, file)
+		if err != nil {
+			return err
+		}
+		reader := csv.NewReader(rc)
+		fileColumns := options.Columns
+		if len(fileColumns) > 0 {
+			reader.FieldsPerRecord = len(fileColumns)
+		} else {
+			if fileColumns, err = reader.Read(); err != nil {
+				rc.Close()
+				return fmt.Errorf("cannot read the header of %v: %v", file, err)
+			}
+		}
+		if !started {
+			columns = fileColumns
+			if err := router.setColumns(columns); err != nil {
+				rc.Close()
+				return err
+			}
+			startShardLoaders(ctx, loaders, columns, options.BatchSize, wg)
+			started = true
+		} else if strings.Join(columns, ",") != strings.Join(fileColumns, ",") {
+			rc.Close()
+			return fmt.Errorf("the columns of %v differ from the ones of %v", file, files[0])
+		}
+
+		l.logger.Infof("LoadData: reading %v", file)
+		err = l.routeLoadData(ctx, reader, router, loaders, options.BatchSize)
+		rc.Close()
+		if err != nil {
+			return fmt.Errorf("cannot load %v: %v", file, err)
+		}
+	}
+	return nil
+}
+
+// routeLoadData routes the rows of the reader in chunks of batchSize.
+func (l *Loader) routeLoadData(ctx context.Context, reader *csv.Reader, router *loadDataRouter, loaders []*shardLoader, batchSize int) error {
+	line := 0
+	for {
+		var chunk [][]sqltypes.Value
+		for len(chunk) < batchSize {
+			record, err := reader.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return err
+			}
+			line++
+			row := make([]sqltypes.Value, len(record))
+			for i, field := range record {
+				row[i] = parseLoadDataValue(field)
+			}
+			chunk = append(chunk, row)
+		}
+		if len(chunk) == 0 {
+			return nil
+		}
+		if err := sendLoadDataRows(ctx, chunk, router, loaders); err != nil {
+			return fmt.Errorf("around row %v: %v", line, err)
+		}
+	}
+}
+
+// sendLoadDataRows sends the rows to the loaders of their shards.
+func sendLoadDataRows(ctx context.Context, rows [][]sqltypes.Value, router *loadDataRouter, loaders []*shardLoader) error {
+	if router.reference {
+		for _, row := range rows {
+			for _, loader := range loaders {
+				select {
+				case loader.rows <- row:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+		}
+		return nil
+	}
+	shards, err := router.route(rows)
+	if err != nil {
+		return err
+	}
+	for i, row := range rows {
+		select {
+		case loaders[shards[i]].rows <- row:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// parseLoadDataValue returns the value of a field. Integers are typed as
+// such, so that numeric vindexes can map them.
+func parseLoadDataValue(field string) sqltypes.Value {
+	if field == loadDataNull {
+		return sqltypes.NULL
+	}
+	if i, err := strconv.ParseInt(field, 10, 64); err == nil {
+		return sqltypes.NewInt64(i)
+	}
+	if u, err := strconv.ParseUint(field, 10, 64); err == nil {
+		return sqltypes.NewUint64(u)
+	}
+	return sqltypes.NewVarChar(field)
+}
+
+// loadDataRouter routes rows to shards.
+type loadDataRouter struct {
+	table  string
+	shards []*topo.ShardInfo
+	// reference is true for the reference tables of sharded keyspaces,
+	// whose rows go to all the shards.
+	reference bool
+	// vindex is nil if the keyspace is unsharded or the table is a
+	// reference table.
+	vindex *vindexes.ColumnVindex
+	// vindexColumns are the indexes of the columns of the vindex in the
+	// rows.
+	vindexColumns []int
+}
+
+func (l *Loader) newLoadDataRouter(ctx context.Context, keyspace, table string, shards []*topo.ShardInfo) (*loadDataRouter, error) {
+	vschema, err := l.ts.GetVSchema(ctx, keyspace)
+	if err != nil {
+		return nil, err
+	}
+	kschema, err := vindexes.BuildKeyspaceSchema(vschema, keyspace)
+	if err != nil {
+		return nil, err
+	}
+	return newLoadDataRouter(kschema, table, shards)
+}
+
+func newLoadDataRouter(kschema *vindexes.KeyspaceSchema, table string, shards []*topo.ShardInfo) (*loadDataRouter, error) {
+	if len(shards) == 0 {
+		return nil, fmt.Errorf("keyspace %v has no serving shards", kschema.Keyspace.Name)
+	}
+	router := &loadDataRouter{table: table, shards: shards}
+	if !kschema.Keyspace.Sharded {
+		if len(shards) != 1 {
+			return nil, fmt.Errorf("unsharded keyspace %v has %v serving shards", kschema.Keyspace.Name, len(shards))
+		}
+		return router, nil
+	}
+	t := kschema.Tables[table]
+	if t == nil {
+		return nil, fmt.Errorf("table %v not found in the vschema of keyspace %v", table, kschema.Keyspace.Name)
+	}
+	if t.Type == vindexes.TypeReference {
+		router.reference = true
+		return router, nil
+	}
+	if t.Pinned != nil || len(t.ColumnVindexes) == 0 {
+		return nil, fmt.Errorf("table %v has no primary vindex", table)
+	}
+	if len(t.Owned) > 0 {
+		return nil, fmt.Errorf("table %v owns vindex %v, whose lookup table would not be populated", table, t.Owned[0].Name)
+	}
+	router.vindex = t.ColumnVindexes[0]
+	if router.vindex.Vindex.NeedsVCursor() {
+		return nil, fmt.Errorf("primary vindex %v of table %v needs to look up values, which is not supported", router.vindex.Name, table)
+	}
+	return router, nil
+}
+
+// setColumns sets the columns of the rows.
+func (r *loadDataRouter) setColumns(columns []string) error {
+	if r.vindex == nil {
+		return nil
+	}
+	r.vindexColumns = nil
+	for _, vcol := range r.vindex.Columns {
+		found := false
+		for i, col := range columns {
+			if vcol.EqualString(col) {
+				r.vindexColumns = append(r.vindexColumns, i)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("column %v of primary vindex %v is missing from the columns of table %v", vcol, r.vindex.Name, r.table)
+		}
+	}
+	return nil
+}
+
+// route returns the index of the shard of each row.
+func (r *loadDataRouter) route(rows [][]sqltypes.Value) ([]int, error) {
+	shards := make([]int, len(rows))
+	if r.vindex == nil {
+		return shards, nil
+	}
+	values := make([][]sqltypes.Value, len(rows))
+	for i, row := range rows {
+		for _, col := range r.vindexColumns {
+			if col >= len(row) {
+				return nil, fmt.Errorf("row %v has %v columns", row, len(row))
+			}
+			values[i] = append(values[i], row[col])
+		}
+	}
+	destinations, err := vindexes.Map(r.vindex.Vindex, nil, values)
+	if err != nil {
+		return nil, err
+	}
+	for i, dest := range destinations {
+		ksid, ok := dest.(key.DestinationKeyspaceID)
+		if !ok {
+			return nil, fmt.Errorf("vindex %v doesn't map %v to a keyspace id", r.vindex.Name, values[i])
+		}
+		shards[i] = -1
+		for j, si := range r.shards {
+			if key.KeyRangeContains(si.KeyRange, ksid) {
+				shards[i] = j
+				break
+			}
+		}
+		if shards[i] < 0 {
+			return nil, fmt.Errorf("no serving shard for keyspace id %v of %v", ksid, values[i])
+		}
+	}
+	return shards, nil
+}
+
+// shardLoader inserts the rows of a shard in batches.
+type shardLoader struct {
+	loader           *Loader
+	tablet           *topodatapb.Tablet
+	table            string
+	ignoreDuplicates bool
+	columns          []string
+	rows             chan []sqltypes.Value
+	limiter          *rate.Limiter
+	progress         *LoadDataShardProgress
+}
+
+// run inserts the rows until the channel is closed. After a failure, it
+// keeps draining the channel without inserting.
+func (sl *shardLoader) run(ctx context.Context, batchSize int, wg *sync.WaitGroup) {
+	defer wg.Done()
+	var batch [][]sqltypes.Value
+	lastLog := time.Now()
+	flush := func() {
+		if len(batch) == 0 || sl.progress.Error != "" {
+			batch = batch[:0]
+			return
+		}
+		if err := sl.insert(ctx, batch); err != nil {
+			sl.progress.Error = err.Error()
+			sl.loader.logger.Errorf("LoadData: shard %v failed after %v rows: %v", sl.progress.Shard, sl.progress.RowsLoaded, err)
+		} else {
+			sl.progress.RowsLoaded += int64(len(batch))
+			sl.progress.Batches++
+		}
+		batch = batch[:0]
+		if time.Since(lastLog) > 10*time.Second {
+			sl.loader.logger.Infof("LoadData: shard %v: %v rows loaded", sl.progress.Shard, sl.progress.RowsLoaded)
+			lastLog = time.Now()
+		}
+	}
+	for row := range sl.rows {
+		batch = append(batch, row)
+		if len(batch) >= batchSize {
+			flush()
+		}
+	}
+	flush()
+	if sl.progress.Error == "" {
+		sl.loader.logger.Infof("LoadData: shard %v done: %v rows loaded", sl.progress.Shard, sl.progress.RowsLoaded)
+	}
+}
+
+func (sl *shardLoader) insert(ctx context.Context, rows [][]sqltypes.Value) error {
+	if sl.limiter != nil {
+		if err := sl.limiter.WaitN(ctx, len(rows)); err != nil {
+			return err
+		}
+	}
+	query := buildLoadDataInsert(sl.table, sl.columns, rows, sl.ignoreDuplicates)
+	_, err := sl.loader.tmc.ExecuteFetchAsApp(ctx, sl.tablet, true, []byte(query), 0)
+	return err
+}
+
+// buildLoadDataInsert returns the statement which inserts the rows.
+func buildLoadDataInsert(table string, columns []string, rows [][]sqltypes.Value, ignoreDuplicates bool) string {
+	var b strings.Builder
+	b.WriteString("insert ")
+	if ignoreDuplicates {
+		b.WriteString("ignore ")
+	}
+	b.WriteString("into ")
+	b.WriteString(sqlescape.EscapeID(table))
+	b.WriteString(" (")
+	for i, col := range columns {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(sqlescape.EscapeID(col))
+	}
+	b.WriteString(") values ")
+	for i, row := range rows {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteByte('(')
+		for j, value := range row {
+			if j > 0 {
+				b.WriteString(", ")
+			}
+			value.EncodeSQLStringBuilder(&b)
+		}
+		b.WriteByte(')')
+	}
+	return b.String()
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+)
+
+func TestParseLoadDataValue(t *testing.T) {
+	assert.Equal(t, sqltypes.NULL, parseLoadDataValue(`\N`))
+	assert.Equal(t, sqltypes.NewInt64(-12), parseLoadDataValue("-12"))
+	assert.Equal(t, sqltypes.NewUint64(18446744073709551615), parseLoadDataValue("18446744073709551615"))
+	assert.Equal(t, sqltypes.NewVarChar("a'b"), parseLoadDataValue("a'b"))
+	assert.Equal(t, sqltypes.NewVarChar(""), parseLoadDataValue(""))
+}
+
+func TestBuildLoadDataInsert(t *testing.T) {
+	rows := [][]sqltypes.Value{
+		{sqltypes.NewInt64(1), sqltypes.NewVarChar("a'b")},
+		{sqltypes.NewInt64(2), sqltypes.NULL},
+	}
+	assert.Equal(t, "insert into `t1` (`id`, `name`) values (1, 'a\\'b'), (2, null)", buildLoadDataInsert("t1", []string{"id", "name"}, rows, false))
+	assert.Equal(t, "insert ignore into `t1` (`id`, `name`) values (1, 'a\\'b'), (2, null)", buildLoadDataInsert("t1", []string{"id", "name"}, rows, true))
+}
+
+func TestLoadDataRouter(t *testing.T) {
+	kschema, err := vindexes.BuildKeyspaceSchema(&vschemapb.Keyspace{
+		Sharded: true,
+		Vindexes: map[string]*vschemapb.Vindex{
+			"hash":   {Type: "hash"},
+			"lookup": {Type: "lookup_unique", Params: map[string]string{"table": "lkp", "from": "c", "to": "keyspace_id"}, Owner: "t2"},
+		},
+		Tables: map[string]*vschemapb.Table{
+			"t1": {ColumnVindexes: []*vschemapb.ColumnVindex{{Column: "id", Name: "hash"}}},
+			"t2": {ColumnVindexes: []*vschemapb.ColumnVindex{{Column: "id", Name: "hash"}, {Column: "c", Name: "lookup"}}},
+			"r1": {Type: "reference"},
+		},
+	}, "ks")
+	require.NoError(t, err)
+
+	var shards []*topo.ShardInfo
+	for _, name := range []string{"-80", "80-"} {
+		_, kr, err := topo.ValidateShardName(name)
+		require.NoError(t, err)
+		shards = append(shards, topo.NewShardInfo("ks", name, &topodatapb.Shard{KeyRange: kr}, nil))
+	}
+
+	_, err = newLoadDataRouter(kschema, "t2", shards)
+	assert.EqualError(t, err, "table t2 owns vindex lookup, whose lookup table would not be populated")
+	_, err = newLoadDataRouter(kschema, "t3", shards)
+	assert.Error(t, err)
+
+	// The rows of reference tables go to all the shards.
+	router, err := newLoadDataRouter(kschema, "r1", shards)
+	require.NoError(t, err)
+	assert.True(t, router.reference)
+
+	router, err = newLoadDataRouter(kschema, "t1", shards)
+	require.NoError(t, err)
+	assert.Error(t, router.setColumns([]string{"name"}))
+	require.NoError(t, router.setColumns([]string{"name", "id"}))
+
+	// The hash of 1 is in -80, the one of 4 in 80-.
+	got, err := router.route([][]sqltypes.Value{
+		{sqltypes.NewVarChar("a"), sqltypes.NewInt64(1)},
+		{sqltypes.NewVarChar("b"), sqltypes.NewInt64(4)},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 1}, got)
+
+	// Unsharded keyspaces route everything to their only shard.
+	unsharded, err := vindexes.BuildKeyspaceSchema(&vschemapb.Keyspace{}, "uks")
+	require.NoError(t, err)
+	router, err = newLoadDataRouter(unsharded, "t1", shards[:1])
+	require.NoError(t, err)
+	require.NoError(t, router.setColumns([]string{"id"}))
+	got, err = router.route([][]sqltypes.Value{{sqltypes.NewInt64(1)}, {sqltypes.NewInt64(4)}})
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 0}, got)
+}
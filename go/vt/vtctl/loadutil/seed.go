@@ -0,0 +1,292 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadutil
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"sync"
+
+	"vitess.io/vitess/go/sqlescape"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/mysqlctl/backupstorage"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+)
+
+// This file implements SeedTable, which copies the initial rows of a
+// table from the seed source declared for it in the VSchema. The rows are
+// routed and inserted like the ones of LoadData.
+
+// SeedOptions are the options of SeedTable.
+type SeedOptions struct {
+	// BatchSize is the number of rows inserted per statement.
+	BatchSize int
+	// MaxRowsPerSecond throttles the inserts on each shard. 0 means
+	// unlimited.
+	MaxRowsPerSecond int
+	// MaxSourceRows is the maximum number of rows read from each shard of
+	// a source table.
+	MaxSourceRows int
+}
+
+// SeedTable inserts the rows of the seed source of the table of the
+// keyspace on the masters of its shards. The rows whose keys already
+// exist are skipped, so a table can be seeded again. It returns the
+// progress of each shard, and an error if any of them failed.
+func (l *Loader) SeedTable(ctx context.Context, keyspace, table string, options *SeedOptions) ([]*LoadDataShardProgress, error) {
+	if options.BatchSize <= 0 {
+		return nil, fmt.Errorf("invalid batch size: %v", options.BatchSize)
+	}
+	vschema, err := l.ts.GetVSchema(ctx, keyspace)
+	if err != nil {
+		return nil, err
+	}
+	seed := vschema.Tables[table].GetSeed()
+	if seed == nil {
+		return nil, fmt.Errorf("no seed source for table %v of keyspace %v", table, keyspace)
+	}
+	columns, rows, err := l.readSeed(ctx, table, seed, options)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the seed of table %v: %v", table, err)
+	}
+
+	shards, err := l.ts.GetServingShards(ctx, keyspace)
+	if err != nil {
+		return nil, err
+	}
+	router, err := l.newLoadDataRouter(ctx, keyspace, table, shards)
+	if err != nil {
+		return nil, err
+	}
+	if err := router.setColumns(columns); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	loaders, err := l.newShardLoaders(ctx, keyspace, table, shards, &LoadDataOptions{
+		BatchSize:        options.BatchSize,
+		MaxRowsPerSecond: options.MaxRowsPerSecond,
+		IgnoreDuplicates: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var wg sync.WaitGroup
+	startShardLoaders(ctx, loaders, columns, options.BatchSize, &wg)
+	l.logger.Infof("SeedTable: inserting %v rows into %v.%v", len(rows), keyspace, table)
+	var sendErr error
+	for start := 0; start < len(rows) && sendErr == nil; start += options.BatchSize {
+		end := start + options.BatchSize
+		if end > len(rows) {
+			end = len(rows)
+		}
+		sendErr = sendLoadDataRows(ctx, rows[start:end], router, loaders)
+	}
+	return finishShardLoaders(loaders, &wg, sendErr)
+}
+
+// SeedCreatedTables seeds the tables created by the statements of sql
+// which have a seed source. It is meant to run after the statements were
+// applied, e.g. by ApplySchema. It returns the progress of each seeded
+// table, by table name.
+func (l *Loader) SeedCreatedTables(ctx context.Context, keyspace, sql string, options *SeedOptions) (map[string][]*LoadDataShardProgress, error) {
+	vschema, err := l.ts.GetVSchema(ctx, keyspace)
+	if err != nil {
+		return nil, err
+	}
+	pieces, err := sqlparser.SplitStatementToPieces(sql)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string][]*LoadDataShardProgress)
+	for _, piece := range pieces {
+		stmt, err := sqlparser.Parse(piece)
+		if err != nil {
+			// The statements were applied already, so they only fail to
+			// parse if they aren't supported by the parser.
+			continue
+		}
+		create, ok := stmt.(*sqlparser.CreateTable)
+		if !ok {
+			continue
+		}
+		table := create.Table.Name.String()
+		if vschema.Tables[table].GetSeed() == nil {
+			continue
+		}
+		progress, err := l.SeedTable(ctx, keyspace, table, options)
+		if progress != nil {
+			result[table] = progress
+		}
+		if err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// readSeed returns the columns and the rows of the seed source.
+func (l *Loader) readSeed(ctx context.Context, table string, seed *vschemapb.TableSeed, options *SeedOptions) ([]string, [][]sqltypes.Value, error) {
+	switch {
+	case seed.SqlFile != nil && seed.SourceTable != nil:
+		return nil, nil, fmt.Errorf("the seed source has both a SQL file and a source table")
+	case seed.SqlFile != nil:
+		return l.readSeedSQLFile(ctx, table, seed.SqlFile)
+	case seed.SourceTable != nil:
+		return l.readSeedSourceTable(ctx, seed.SourceTable, options.MaxSourceRows)
+	}
+	return nil, nil, fmt.Errorf("empty seed source")
+}
+
+func (l *Loader) readSeedSQLFile(ctx context.Context, table string, file *vschemapb.TableSeed_SQLFile) ([]string, [][]sqltypes.Value, error) {
+	bs, err := backupstorage.GetBackupStorage()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer bs.Close()
+	handle, err := findBackupHandle(ctx, bs, file.Dir, file.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	rc, err := handle.ReadFile(ctx, file.File)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rc.Close()
+	data, err := ioutil.ReadAll(rc)
+	if err != nil {
+		return nil, nil, err
+	}
+	return parseSeedSQL(string(data), table)
+}
+
+// parseSeedSQL returns the columns and the rows of the INSERT statements
+// of a seed file, which must all list the same columns of the table.
+func parseSeedSQL(sql, table string) ([]string, [][]sqltypes.Value, error) {
+	pieces, err := sqlparser.SplitStatementToPieces(sql)
+	if err != nil {
+		return nil, nil, err
+	}
+	var columns []string
+	var rows [][]sqltypes.Value
+	for _, piece := range pieces {
+		stmt, err := sqlparser.Parse(piece)
+		if err == sqlparser.ErrEmpty {
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		insert, ok := stmt.(*sqlparser.Insert)
+		if !ok || insert.Action != sqlparser.InsertAct {
+			return nil, nil, fmt.Errorf("only INSERT statements are supported: %v", sqlparser.String(stmt))
+		}
+		if name := insert.Table.Name.String(); name != table {
+			return nil, nil, fmt.Errorf("INSERT into table %v instead of %v", name, table)
+		}
+		values, ok := insert.Rows.(sqlparser.Values)
+		if !ok || len(insert.Columns) == 0 {
+			return nil, nil, fmt.Errorf("only INSERT statements listing their columns and values are supported: %v", sqlparser.String(stmt))
+		}
+		var insertColumns []string
+		for _, col := range insert.Columns {
+			insertColumns = append(insertColumns, col.String())
+		}
+		if columns == nil {
+			columns = insertColumns
+		} else if strings.Join(columns, ",") != strings.Join(insertColumns, ",") {
+			return nil, nil, fmt.Errorf("all the INSERT statements must list the same columns: %v", sqlparser.String(stmt))
+		}
+		for _, tuple := range values {
+			if len(tuple) != len(columns) {
+				return nil, nil, fmt.Errorf("row %v doesn't have %v values", sqlparser.String(tuple), len(columns))
+			}
+			row := make([]sqltypes.Value, len(tuple))
+			for i, expr := range tuple {
+				pv, err := sqlparser.NewPlanValue(expr)
+				if err != nil {
+					return nil, nil, err
+				}
+				if row[i], err = pv.ResolveValue(nil); err != nil {
+					return nil, nil, err
+				}
+			}
+			rows = append(rows, row)
+		}
+	}
+	if columns == nil {
+		return nil, nil, fmt.Errorf("no INSERT statements")
+	}
+	return columns, rows, nil
+}
+
+// readSeedSourceTable reads the rows of the source table from the masters
+// of the serving shards of its keyspace. A reference table is only read
+// from the first one.
+func (l *Loader) readSeedSourceTable(ctx context.Context, source *vschemapb.TableSeed_SourceTable, maxRows int) ([]string, [][]sqltypes.Value, error) {
+	shards, err := l.ts.GetServingShards(ctx, source.Keyspace)
+	if err != nil {
+		return nil, nil, err
+	}
+	vschema, err := l.ts.GetVSchema(ctx, source.Keyspace)
+	if err != nil {
+		return nil, nil, err
+	}
+	kschema, err := vindexes.BuildKeyspaceSchema(vschema, source.Keyspace)
+	if err != nil {
+		return nil, nil, err
+	}
+	if t := kschema.Tables[source.Table]; t != nil && t.Type == vindexes.TypeReference && len(shards) > 1 {
+		shards = shards[:1]
+	}
+
+	query := "select * from " + sqlescape.EscapeID(source.Table)
+	var columns []string
+	var rows [][]sqltypes.Value
+	for _, si := range shards {
+		if si.MasterAlias == nil {
+			return nil, nil, fmt.Errorf("shard %v/%v has no master", source.Keyspace, si.ShardName())
+		}
+		ti, err := l.ts.GetTablet(ctx, si.MasterAlias)
+		if err != nil {
+			return nil, nil, err
+		}
+		p3qr, err := l.tmc.ExecuteFetchAsApp(ctx, ti.Tablet, true, []byte(query), maxRows)
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot read table %v on %v: %v", source.Table, topoproto.TabletAliasString(si.MasterAlias), err)
+		}
+		qr := sqltypes.Proto3ToResult(p3qr)
+		var shardColumns []string
+		for _, field := range qr.Fields {
+			shardColumns = append(shardColumns, field.Name)
+		}
+		if columns == nil {
+			columns = shardColumns
+		} else if strings.Join(columns, ",") != strings.Join(shardColumns, ",") {
+			return nil, nil, fmt.Errorf("the columns of table %v differ between the shards of keyspace %v", source.Table, source.Keyspace)
+		}
+		rows = append(rows, qr.Rows...)
+	}
+	return columns, rows, nil
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+)
+
+func TestParseSeedSQL(t *testing.T) {
+	columns, rows, err := parseSeedSQL(`
+-- initial countries
+insert into t1 (id, name) values (1, 'a'), (2, null);
+insert into t1 (id, name) values (3, 'c');
+`, "t1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"id", "name"}, columns)
+	assert.Equal(t, [][]sqltypes.Value{
+		{sqltypes.NewInt64(1), sqltypes.NewVarBinary("a")},
+		{sqltypes.NewInt64(2), sqltypes.NULL},
+		{sqltypes.NewInt64(3), sqltypes.NewVarBinary("c")},
+	}, rows)
+
+	for _, sql := range []string{
+		"",
+		"delete from t1",
+		"replace into t1 (id) values (1)",
+		"insert into t2 (id) values (1)",
+		"insert into t1 values (1)",
+		"insert into t1 (id) select id from t2",
+		"insert into t1 (id) values (1); insert into t1 (name) values ('a')",
+		"insert into t1 (id, name) values (1)",
+	} {
+		_, _, err := parseSeedSQL(sql, "t1")
+		assert.Error(t, err, sql)
+	}
+}
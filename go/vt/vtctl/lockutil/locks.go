@@ -0,0 +1,204 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package lockutil holds keyspace and shard locks on behalf of external
+// tools, so an orchestration tool can coordinate with the operations of
+// Vitess which take the same topo locks (resharding, reparents, schema
+// changes...) instead of racing with them. The locks are held by this
+// process until they are released or their TTL expires, and they are lost
+// if it restarts.
+package lockutil
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/topo"
+)
+
+var externalLockMaxTTL = flag.Duration("external_lock_max_ttl", time.Hour, "Maximum TTL of the keyspace and shard locks acquired through the locks API of vtctld.")
+
+// ExternalLock is a keyspace or shard lock held for an external tool.
+type ExternalLock struct {
+	// ID identifies the lock to release it.
+	ID       string `json:"id"`
+	Keyspace string `json:"keyspace"`
+	// Shard is empty for a keyspace lock.
+	Shard    string    `json:"shard,omitempty"`
+	Purpose  string    `json:"purpose"`
+	Acquired time.Time `json:"acquired"`
+	// Expires is when the lock is released if it wasn't before.
+	Expires time.Time `json:"expires"`
+
+	unlock func(*error)
+	timer  *time.Timer
+}
+
+// ExternalLocks are the locks held by this process for external tools.
+type ExternalLocks struct {
+	ts *topo.Server
+
+	// mu protects locks.
+	mu    sync.Mutex
+	locks map[string]*ExternalLock
+}
+
+// NewExternalLocks returns an empty set of locks of the topo server.
+func NewExternalLocks(ts *topo.Server) *ExternalLocks {
+	return &ExternalLocks{
+		ts:    ts,
+		locks: make(map[string]*ExternalLock),
+	}
+}
+
+var (
+	// sharedMu protects shared.
+	sharedMu sync.Mutex
+	shared   = make(map[*topo.Server]*ExternalLocks)
+)
+
+// ForServer returns the locks this process holds in the topo server, so
+// the locks acquired through the HTTP API of vtctld and through its Vtctld
+// RPCs are the same.
+func ForServer(ts *topo.Server) *ExternalLocks {
+	sharedMu.Lock()
+	defer sharedMu.Unlock()
+	locks, ok := shared[ts]
+	if !ok {
+		locks = NewExternalLocks(ts)
+		shared[ts] = locks
+	}
+	return locks
+}
+
+// Acquire locks the keyspace, or the shard if it isn't empty, for ttl. It
+// waits for the lock until the context is done.
+func (m *ExternalLocks) Acquire(ctx context.Context, keyspace, shard, purpose string, ttl time.Duration) (*ExternalLock, error) {
+	if keyspace == "" {
+		return nil, errors.New("a lock needs a keyspace")
+	}
+	if purpose == "" {
+		return nil, errors.New("a lock needs a purpose")
+	}
+	if ttl <= 0 || ttl > *externalLockMaxTTL {
+		return nil, fmt.Errorf("invalid ttl %v: it must be positive and at most %v", ttl, *externalLockMaxTTL)
+	}
+	id, err := newExternalLockID()
+	if err != nil {
+		return nil, err
+	}
+
+	// The action is recorded in the lock, for the operations which wait
+	// for it.
+	action := fmt.Sprintf("external lock %v: %v", id, purpose)
+	var unlock func(*error)
+	if shard != "" {
+		_, unlock, err = m.ts.LockShard(ctx, keyspace, shard, action)
+	} else {
+		_, unlock, err = m.ts.LockKeyspace(ctx, keyspace, action)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	lock := &ExternalLock{
+		ID:       id,
+		Keyspace: keyspace,
+		Shard:    shard,
+		Purpose:  purpose,
+		Acquired: now,
+		Expires:  now.Add(ttl),
+		unlock:   unlock,
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.locks[id] = lock
+	lock.timer = time.AfterFunc(ttl, func() {
+		if _, err := m.Release(id); err != nil {
+			log.Errorf("Cannot release expired lock %v of %v: %v", id, lock.resource(), err)
+			return
+		}
+		log.Warningf("Released lock %v of %v for %q, its TTL of %v expired", id, lock.resource(), lock.Purpose, ttl)
+	})
+	log.Infof("Acquired lock %v of %v for %q", id, lock.resource(), lock.Purpose)
+	return lock, nil
+}
+
+// Release releases a lock, and returns it.
+func (m *ExternalLocks) Release(id string) (*ExternalLock, error) {
+	m.mu.Lock()
+	lock, ok := m.locks[id]
+	delete(m.locks, id)
+	m.mu.Unlock()
+	if !ok {
+		return nil, topo.NewError(topo.NoNode, "lock "+id)
+	}
+
+	lock.timer.Stop()
+	var err error
+	lock.unlock(&err)
+	return lock, err
+}
+
+// Get returns a lock.
+func (m *ExternalLocks) Get(id string) (*ExternalLock, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	lock, ok := m.locks[id]
+	if !ok {
+		return nil, topo.NewError(topo.NoNode, "lock "+id)
+	}
+	return lock, nil
+}
+
+// List returns the locks, in the order they were acquired.
+func (m *ExternalLocks) List() []*ExternalLock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	locks := make([]*ExternalLock, 0, len(m.locks))
+	for _, lock := range m.locks {
+		locks = append(locks, lock)
+	}
+	sort.Slice(locks, func(i, j int) bool {
+		return locks[i].Acquired.Before(locks[j].Acquired)
+	})
+	return locks
+}
+
+// resource returns the keyspace, or keyspace/shard, of the lock.
+func (lock *ExternalLock) resource() string {
+	if lock.Shard == "" {
+		return lock.Keyspace
+	}
+	return lock.Keyspace + "/" + lock.Shard
+}
+
+func newExternalLockID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
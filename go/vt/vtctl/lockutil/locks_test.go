@@ -0,0 +1,117 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lockutil
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// checkLocked returns whether the keyspace is locked in the topo.
+func checkLocked(t *testing.T, ts *topo.Server, keyspace string) bool {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, unlock, err := ts.LockKeyspace(ctx, keyspace, "test")
+	if err != nil {
+		return true
+	}
+	unlock(&err)
+	require.NoError(t, err)
+	return false
+}
+
+func TestExternalLocks(t *testing.T) {
+	ctx := context.Background()
+	ts := memorytopo.NewServer("cell1")
+	require.NoError(t, ts.CreateKeyspace(ctx, "ks", &topodatapb.Keyspace{}))
+	require.NoError(t, ts.CreateShard(ctx, "ks", "0"))
+	locks := NewExternalLocks(ts)
+
+	lock, err := locks.Acquire(ctx, "ks", "", "backup", time.Hour)
+	require.NoError(t, err)
+	assert.True(t, checkLocked(t, ts, "ks"))
+	shardLock, err := locks.Acquire(ctx, "ks", "0", "failover drill", time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, []*ExternalLock{lock, shardLock}, locks.List())
+
+	got, err := locks.Get(lock.ID)
+	require.NoError(t, err)
+	assert.Equal(t, lock, got)
+
+	_, err = locks.Release(lock.ID)
+	require.NoError(t, err)
+	assert.False(t, checkLocked(t, ts, "ks"))
+	assert.Equal(t, []*ExternalLock{shardLock}, locks.List())
+
+	// A lock can only be released once.
+	_, err = locks.Release(lock.ID)
+	assert.True(t, topo.IsErrType(err, topo.NoNode), "%v", err)
+	_, err = locks.Get(lock.ID)
+	assert.True(t, topo.IsErrType(err, topo.NoNode), "%v", err)
+
+	_, err = locks.Release(shardLock.ID)
+	require.NoError(t, err)
+	assert.Empty(t, locks.List())
+}
+
+func TestExternalLockTTL(t *testing.T) {
+	ctx := context.Background()
+	ts := memorytopo.NewServer("cell1")
+	require.NoError(t, ts.CreateKeyspace(ctx, "ks", &topodatapb.Keyspace{}))
+	locks := NewExternalLocks(ts)
+
+	_, err := locks.Acquire(ctx, "ks", "", "backup", 50*time.Millisecond)
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return len(locks.List()) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.False(t, checkLocked(t, ts, "ks"))
+}
+
+func TestExternalLockErrors(t *testing.T) {
+	ts := memorytopo.NewServer("cell1")
+	locks := NewExternalLocks(ts)
+	for _, req := range []struct {
+		keyspace, purpose string
+		ttl               time.Duration
+	}{
+		{purpose: "backup", ttl: time.Hour},
+		{keyspace: "ks", ttl: time.Hour},
+		{keyspace: "ks", purpose: "backup"},
+		{keyspace: "ks", purpose: "backup", ttl: -time.Second},
+		{keyspace: "ks", purpose: "backup", ttl: 1000 * time.Hour},
+	} {
+		_, err := locks.Acquire(context.Background(), req.keyspace, "", req.purpose, req.ttl)
+		assert.Error(t, err, "%+v", req)
+	}
+}
+
+func TestForServer(t *testing.T) {
+	ts := memorytopo.NewServer("cell1")
+	assert.Same(t, ForServer(ts), ForServer(ts))
+	assert.NotSame(t, ForServer(ts), ForServer(memorytopo.NewServer("cell1")))
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package onlineddlutil
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/schema"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/vtctl/workflow/vexec"
+	"vitess.io/vitess/go/vt/vttablet/tmclient"
+)
+
+// Review is the approval or the rejection of an online DDL migration pending
+// approval.
+type Review struct {
+	UUID     string
+	Decision string
+	Reviewer string
+	// Shards are the shards where the migration was pending approval, and
+	// was queued or cancelled by the review.
+	Shards []string
+}
+
+// ApproveMigration releases a migration pending approval on all the shards,
+// recording the reviewer as the identity which approved it.
+func ApproveMigration(ctx context.Context, ts *topo.Server, tmc tmclient.TabletManagerClient, keyspace, uuid, reviewer string) (*Review, error) {
+	query, err := sqlparser.ParseAndBind(`update _vt.schema_migrations set migration_status='approve', reviewed_by=%a where migration_uuid=%a`,
+		sqltypes.StringBindVariable(reviewer),
+		sqltypes.StringBindVariable(uuid))
+	if err != nil {
+		return nil, err
+	}
+	return reviewMigration(ctx, ts, tmc, keyspace, uuid, query, "approved", reviewer)
+}
+
+// RejectMigration cancels a migration pending approval on all the shards,
+// recording the reviewer as the identity which rejected it, and the message
+// as the reason.
+func RejectMigration(ctx context.Context, ts *topo.Server, tmc tmclient.TabletManagerClient, keyspace, uuid, reviewer, message string) (*Review, error) {
+	query, err := sqlparser.ParseAndBind(`update _vt.schema_migrations set migration_status='reject', reviewed_by=%a, message=%a where migration_uuid=%a`,
+		sqltypes.StringBindVariable(reviewer),
+		sqltypes.StringBindVariable(message),
+		sqltypes.StringBindVariable(uuid))
+	if err != nil {
+		return nil, err
+	}
+	return reviewMigration(ctx, ts, tmc, keyspace, uuid, query, "rejected", reviewer)
+}
+
+// reviewMigration runs the query which approves or rejects the migration on
+// the primaries of the keyspace, and returns the shards where it was pending
+// approval.
+func reviewMigration(ctx context.Context, ts *topo.Server, tmc tmclient.TabletManagerClient, keyspace, uuid, query, decision, reviewer string) (*Review, error) {
+	if !schema.IsOnlineDDLUUID(uuid) {
+		return nil, fmt.Errorf("not an online DDL UUID: %s", uuid)
+	}
+	if reviewer == "" {
+		return nil, fmt.Errorf("the reviewer is required")
+	}
+	results, err := vexec.NewVExec(keyspace, uuid, ts, tmc).QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	review := &Review{
+		UUID:     uuid,
+		Decision: decision,
+		Reviewer: reviewer,
+	}
+	for tablet, result := range results {
+		if result.RowsAffected > 0 {
+			review.Shards = append(review.Shards, tablet.Shard)
+		}
+	}
+	if len(review.Shards) == 0 {
+		return nil, fmt.Errorf("migration %s is not pending approval on any shard of keyspace %s", uuid, keyspace)
+	}
+	sort.Strings(review.Shards)
+	log.Infof("Migration %s %s by %s on shards %v", uuid, decision, reviewer, review.Shards)
+	return review, nil
+}
@@ -0,0 +1,242 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package onlineddlutil
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"vitess.io/vitess/go/vt/schema"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vttablet/tmclient"
+
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// This file plans the declarative schema changes of a keyspace: the
+// operator submits the CREATE TABLE statements of all the tables the
+// keyspace should have, and the plan lists the tables to create, alter and
+// drop to converge to them. The plan is applied as declarative online DDL
+// migrations, in which each tablet computes the ALTER of a table against
+// its own schema, so the ALTERs of the plan are a preview.
+
+// The actions of the changes of a declarative schema plan.
+const (
+	DeclarativeActionCreate = "create"
+	DeclarativeActionAlter  = "alter"
+	DeclarativeActionDrop   = "drop"
+)
+
+// DeclarativeSchemaChange is a change of a table in a declarative schema
+// plan.
+type DeclarativeSchemaChange struct {
+	Table  string
+	Action string
+	// SQL is the CREATE TABLE, ALTER TABLE or DROP TABLE statement of the
+	// change.
+	SQL string
+
+	// migration is the statement of the declarative migration which
+	// applies the change.
+	migration string
+}
+
+// DeclarativeSchemaPlan is the plan converging the schema of a keyspace to
+// a desired schema.
+type DeclarativeSchemaPlan struct {
+	Keyspace string
+	// Tablet is the master whose schema is compared to the desired one.
+	Tablet *topodatapb.TabletAlias
+	// Changes are the creations first, then the alterations and the drops.
+	Changes []*DeclarativeSchemaChange
+	// UnchangedTables are the desired tables which already exist as
+	// desired.
+	UnchangedTables []string
+	// SkippedDrops are the tables which aren't in the desired schema but
+	// aren't dropped, because the drops weren't allowed.
+	SkippedDrops []string
+}
+
+// Statements returns the statements of the declarative online DDL
+// migrations applying the plan.
+func (plan *DeclarativeSchemaPlan) Statements() []string {
+	var statements []string
+	for _, change := range plan.Changes {
+		statements = append(statements, change.migration)
+	}
+	return statements
+}
+
+// PlanDeclarativeSchema computes the changes converging the schema of the
+// keyspace to the desired schema, which is made of the CREATE TABLE
+// statements of all its tables. The tables which aren't desired are only
+// dropped if allowDrops is set. The schema of the keyspace is read from
+// the master of its first shard.
+func PlanDeclarativeSchema(ctx context.Context, ts *topo.Server, tmc tmclient.TabletManagerClient, keyspace, desiredSchema string, allowDrops bool) (*DeclarativeSchemaPlan, error) {
+	desired, err := parseDesiredSchema(desiredSchema)
+	if err != nil {
+		return nil, err
+	}
+	shards, err := ts.GetServingShards(ctx, keyspace)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(shards, func(i, j int) bool {
+		return shards[i].ShardName() < shards[j].ShardName()
+	})
+	if len(shards) == 0 || shards[0].MasterAlias == nil {
+		return nil, fmt.Errorf("keyspace %v has no master to read its schema from", keyspace)
+	}
+	masterAlias := shards[0].MasterAlias
+	master, err := ts.GetTablet(ctx, masterAlias)
+	if err != nil {
+		return nil, fmt.Errorf("GetTablet(%v) failed: %v", topoproto.TabletAliasString(masterAlias), err)
+	}
+	current, err := tmc.GetSchema(ctx, master.Tablet, nil /* tables */, nil /* excludeTables */, false /* includeViews */)
+	if err != nil {
+		return nil, fmt.Errorf("GetSchema(%v) failed: %v", topoproto.TabletAliasString(masterAlias), err)
+	}
+	plan, err := planDeclarativeSchema(current, desired, allowDrops)
+	if err != nil {
+		return nil, err
+	}
+	plan.Keyspace = keyspace
+	plan.Tablet = masterAlias
+	return plan, nil
+}
+
+// parseDesiredSchema parses the CREATE TABLE statements of a desired
+// schema.
+func parseDesiredSchema(desiredSchema string) ([]*sqlparser.CreateTable, error) {
+	pieces, err := sqlparser.SplitStatementToPieces(desiredSchema)
+	if err != nil {
+		return nil, err
+	}
+	var desired []*sqlparser.CreateTable
+	tables := make(map[string]bool)
+	for _, piece := range pieces {
+		if strings.TrimSpace(piece) == "" {
+			continue
+		}
+		stmt, err := sqlparser.Parse(piece)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse %v: %v", piece, err)
+		}
+		create, ok := stmt.(*sqlparser.CreateTable)
+		if !ok {
+			return nil, fmt.Errorf("a desired schema can only contain CREATE TABLE statements: %v", piece)
+		}
+		switch {
+		case create.TableSpec == nil:
+			return nil, fmt.Errorf("CREATE TABLE ... LIKE is not supported in a desired schema: %v", piece)
+		case create.Temp || create.IfNotExists:
+			return nil, fmt.Errorf("CREATE TEMPORARY TABLE and IF NOT EXISTS are not supported in a desired schema: %v", piece)
+		case !create.Table.Qualifier.IsEmpty():
+			return nil, fmt.Errorf("the tables of a desired schema cannot be qualified with a keyspace: %v", piece)
+		}
+		name := create.Table.Name.String()
+		if tables[name] {
+			return nil, fmt.Errorf("table %v is declared more than once in the desired schema", name)
+		}
+		tables[name] = true
+		desired = append(desired, create)
+	}
+	if len(desired) == 0 {
+		return nil, fmt.Errorf("the desired schema has no CREATE TABLE statement")
+	}
+	return desired, nil
+}
+
+// planDeclarativeSchema compares the current schema with the desired
+// tables. The internal tables of online DDL and table GC are ignored.
+func planDeclarativeSchema(current *tabletmanagerdatapb.SchemaDefinition, desired []*sqlparser.CreateTable, allowDrops bool) (*DeclarativeSchemaPlan, error) {
+	currentTables := make(map[string]*sqlparser.CreateTable)
+	var currentNames []string
+	for _, td := range current.TableDefinitions {
+		if schema.IsInternalOperationTableName(td.Name) {
+			continue
+		}
+		stmt, err := sqlparser.Parse(td.Schema)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse the schema of table %v: %v", td.Name, err)
+		}
+		create, ok := stmt.(*sqlparser.CreateTable)
+		if !ok {
+			return nil, fmt.Errorf("unexpected schema of table %v: %v", td.Name, td.Schema)
+		}
+		currentTables[td.Name] = create
+		currentNames = append(currentNames, td.Name)
+	}
+	sort.Strings(currentNames)
+
+	plan := &DeclarativeSchemaPlan{}
+	var alters []*DeclarativeSchemaChange
+	desiredTables := make(map[string]bool)
+	for _, create := range desired {
+		name := create.Table.Name.String()
+		desiredTables[name] = true
+		createSQL := sqlparser.String(create)
+		currentCreate, ok := currentTables[name]
+		if !ok {
+			plan.Changes = append(plan.Changes, &DeclarativeSchemaChange{
+				Table:     name,
+				Action:    DeclarativeActionCreate,
+				SQL:       createSQL,
+				migration: createSQL,
+			})
+			continue
+		}
+		alter, err := schema.DiffCreateTable(currentCreate, create)
+		if err != nil {
+			return nil, err
+		}
+		if alter == nil {
+			plan.UnchangedTables = append(plan.UnchangedTables, name)
+			continue
+		}
+		alters = append(alters, &DeclarativeSchemaChange{
+			Table:     name,
+			Action:    DeclarativeActionAlter,
+			SQL:       sqlparser.String(alter),
+			migration: createSQL,
+		})
+	}
+	plan.Changes = append(plan.Changes, alters...)
+
+	for _, name := range currentNames {
+		if desiredTables[name] {
+			continue
+		}
+		if !allowDrops {
+			plan.SkippedDrops = append(plan.SkippedDrops, name)
+			continue
+		}
+		dropSQL := sqlparser.String(&sqlparser.DropTable{FromTables: sqlparser.TableNames{currentTables[name].Table}})
+		plan.Changes = append(plan.Changes, &DeclarativeSchemaChange{
+			Table:     name,
+			Action:    DeclarativeActionDrop,
+			SQL:       dropSQL,
+			migration: dropSQL,
+		})
+	}
+	return plan, nil
+}
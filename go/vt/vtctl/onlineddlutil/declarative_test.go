@@ -0,0 +1,93 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package onlineddlutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+)
+
+func TestParseDesiredSchema(t *testing.T) {
+	desired, err := parseDesiredSchema("create table t1 (id int, primary key (id)); create table t2 (id int, primary key (id));")
+	require.NoError(t, err)
+	require.Len(t, desired, 2)
+	assert.Equal(t, "t2", desired[1].Table.Name.String())
+
+	for sql, wantErr := range map[string]string{
+		"":                                       "the desired schema has no CREATE TABLE statement",
+		"alter table t1 add column c int":        "a desired schema can only contain CREATE TABLE statements: alter table t1 add column c int",
+		"create table t1 like t2":                "CREATE TABLE ... LIKE is not supported in a desired schema: create table t1 like t2",
+		"create table if not exists t1 (id int)": "CREATE TEMPORARY TABLE and IF NOT EXISTS are not supported in a desired schema: create table if not exists t1 (id int)",
+		"create table ks.t1 (id int)":            "the tables of a desired schema cannot be qualified with a keyspace: create table ks.t1 (id int)",
+		"create table t1 (id int); create table t1 (id bigint)": "table t1 is declared more than once in the desired schema",
+	} {
+		_, err := parseDesiredSchema(sql)
+		assert.EqualError(t, err, wantErr, sql)
+	}
+}
+
+func TestPlanDeclarativeSchema(t *testing.T) {
+	current := &tabletmanagerdatapb.SchemaDefinition{
+		TableDefinitions: []*tabletmanagerdatapb.TableDefinition{{
+			Name:   "t1",
+			Schema: "CREATE TABLE `t1` (`id` int(11) NOT NULL, PRIMARY KEY (`id`)) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4",
+		}, {
+			Name:   "t2",
+			Schema: "CREATE TABLE `t2` (`id` int(11) NOT NULL, PRIMARY KEY (`id`)) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4",
+		}, {
+			Name:   "t3",
+			Schema: "CREATE TABLE `t3` (`id` int(11) NOT NULL, PRIMARY KEY (`id`)) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4",
+		}, {
+			Name:   "_vt_HOLD_6ace8bcef73211ea87e9f875a4d24e90_20200915120410",
+			Schema: "CREATE TABLE `_vt_HOLD_6ace8bcef73211ea87e9f875a4d24e90_20200915120410` (`id` int(11) NOT NULL, PRIMARY KEY (`id`)) ENGINE=InnoDB",
+		}},
+	}
+	desired, err := parseDesiredSchema(`
+		create table t1 (id int, primary key (id));
+		create table t2 (id int, val varchar(10), primary key (id));
+		create table t4 (id int, primary key (id));
+	`)
+	require.NoError(t, err)
+
+	plan, err := planDeclarativeSchema(current, desired, false)
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 2)
+	assert.Equal(t, &DeclarativeSchemaChange{
+		Table:     "t4",
+		Action:    DeclarativeActionCreate,
+		SQL:       "create table t4 (\n\tid int,\n\tprimary key (id)\n)",
+		migration: "create table t4 (\n\tid int,\n\tprimary key (id)\n)",
+	}, plan.Changes[0])
+	assert.Equal(t, DeclarativeActionAlter, plan.Changes[1].Action)
+	assert.Equal(t, "alter table t2 add column val varchar(10) after id", plan.Changes[1].SQL)
+	// The whole desired table is migrated declaratively.
+	assert.Contains(t, plan.Statements()[1], "create table t2")
+	assert.Equal(t, []string{"t1"}, plan.UnchangedTables)
+	// The internal tables aren't dropped.
+	assert.Equal(t, []string{"t3"}, plan.SkippedDrops)
+
+	plan, err = planDeclarativeSchema(current, desired, true)
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 3)
+	assert.Equal(t, DeclarativeActionDrop, plan.Changes[2].Action)
+	assert.Equal(t, "drop table t3", plan.Changes[2].SQL)
+	assert.Empty(t, plan.SkippedDrops)
+}
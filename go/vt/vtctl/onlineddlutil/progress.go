@@ -0,0 +1,321 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package onlineddlutil reports on the online DDL migrations of a keyspace,
+// by querying _vt.schema_migrations on the primaries of its shards through
+// vexec, for vtctl and the VtctldServer RPCs.
+package onlineddlutil
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"time"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/schema"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vtctl/workflow/vexec"
+	"vitess.io/vitess/go/vt/vttablet/tmclient"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// ShardProgress is the progress of a migration on a shard.
+type ShardProgress struct {
+	Shard    string                  `json:"shard"`
+	Tablet   *topodatapb.TabletAlias `json:"tablet"`
+	Status   string                  `json:"status"`
+	Strategy string                  `json:"strategy"`
+	Table    string                  `json:"table"`
+	// Progress is the percentage of the rows of the table copied.
+	Progress float64 `json:"progress"`
+	// ETASeconds is the estimated time until the copy completes, -1 if it
+	// is unknown.
+	ETASeconds int64      `json:"eta_seconds"`
+	RowsCopied int64      `json:"rows_copied"`
+	TableRows  int64      `json:"table_rows"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	// Throttled is set if the lag throttler of the shard pushes back on the
+	// migration. ThrottleMessage is its reason.
+	Throttled       bool   `json:"throttled"`
+	ThrottleMessage string `json:"throttle_message,omitempty"`
+	// VReplicationLagSeconds is the lag of the vreplication stream of a
+	// running migration of the online strategy.
+	VReplicationLagSeconds int64  `json:"vreplication_lag_seconds"`
+	Message                string `json:"message,omitempty"`
+}
+
+// Progress is the progress of a migration, aggregated over the shards of its
+// keyspace.
+type Progress struct {
+	UUID     string `json:"uuid"`
+	Keyspace string `json:"keyspace"`
+	Table    string `json:"table"`
+	Strategy string `json:"strategy"`
+	// Status is failed or cancelled if the migration is on any shard,
+	// complete if it is on all of them, running if it runs on any, and
+	// else the status of the least advanced shard.
+	Status string `json:"status"`
+	// Progress is the percentage of the rows copied over all the shards.
+	Progress float64 `json:"progress"`
+	// ETASeconds is the ETA of the slowest shard, -1 if the ETA of any
+	// shard which isn't complete is unknown.
+	ETASeconds                int64            `json:"eta_seconds"`
+	RowsCopied                int64            `json:"rows_copied"`
+	TableRows                 int64            `json:"table_rows"`
+	ThrottledShards           int              `json:"throttled_shards"`
+	MaxVReplicationLagSeconds int64            `json:"max_vreplication_lag_seconds"`
+	Shards                    []*ShardProgress `json:"shards"`
+}
+
+// statusOrder orders the statuses from the least advanced, for
+// the status of a migration which isn't running on any shard.
+var statusOrder = []schema.OnlineDDLStatus{
+	schema.OnlineDDLStatusRequested,
+	schema.OnlineDDLStatusQueued,
+	schema.OnlineDDLStatusReady,
+	schema.OnlineDDLStatusComplete,
+}
+
+// throttlerCheckResult is the response of /throttler/check.
+type throttlerCheckResult struct {
+	StatusCode int     `json:"StatusCode"`
+	Value      float64 `json:"Value"`
+	Threshold  float64 `json:"Threshold"`
+	Message    string  `json:"Message"`
+}
+
+// checkThrottler checks the lag throttler of the tablet as the
+// app of the migration. It returns whether the migration is throttled, and
+// why.
+var checkThrottler = func(tabletAddr, appName string) (bool, string, error) {
+	resp, err := http.Get(fmt.Sprintf("http://%s/throttler/check?app=%s&p=low", tabletAddr, appName))
+	if err != nil {
+		return false, "", err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return false, "", err
+	}
+
+	var result throttlerCheckResult
+	if err := json.Unmarshal(body, &result); err != nil {
+		return false, "", fmt.Errorf("unexpected throttler check response %v: %s", resp.Status, body)
+	}
+	switch result.StatusCode {
+	case http.StatusTooManyRequests:
+		return true, fmt.Sprintf("lag %.2f above threshold %.2f", result.Value, result.Threshold), nil
+	case http.StatusExpectationFailed:
+		return true, result.Message, nil
+	}
+	return false, "", nil
+}
+
+// throttlerApp returns the app name the migration checks the
+// throttler with.
+func throttlerApp(strategy, uuid string) string {
+	switch schema.DDLStrategy(strategy) {
+	case schema.DDLStrategyGhost, schema.DDLStrategyPTOSC:
+		return fmt.Sprintf("online-ddl:%s:%s", strategy, uuid)
+	}
+	// The migrations of the online strategy run on vreplication.
+	return "vreplication"
+}
+
+// GetProgress returns the progress of a migration on each shard of the
+// keyspace, and aggregated over them: the rows copied, the ETA, whether the
+// throttler pushes back on it, and for the online strategy the lag of its
+// vreplication streams.
+func GetProgress(ctx context.Context, ts *topo.Server, tmc tmclient.TabletManagerClient, keyspace, uuid string) (*Progress, error) {
+	if !schema.IsOnlineDDLUUID(uuid) {
+		return nil, fmt.Errorf("not an online DDL UUID: %s", uuid)
+	}
+	query, err := sqlparser.ParseAndBind(`select
+			shard, mysql_table, strategy, migration_status, progress, eta_seconds, rows_copied, table_rows,
+			unix_timestamp(started_timestamp) as started_timestamp, message
+		from _vt.schema_migrations where migration_uuid=%a`,
+		sqltypes.StringBindVariable(uuid))
+	if err != nil {
+		return nil, err
+	}
+	vx := vexec.NewVExec(keyspace, uuid, ts, tmc)
+	results, err := vx.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	progress := &Progress{
+		UUID:     uuid,
+		Keyspace: keyspace,
+	}
+	now := time.Now()
+	var vreplLags map[string]int64
+	for tablet, result := range results {
+		for _, row := range sqltypes.Proto3ToResult(result).Named().Rows {
+			sp := &ShardProgress{
+				Shard:      row.AsString("shard", ""),
+				Tablet:     tablet.Alias,
+				Status:     row.AsString("migration_status", ""),
+				Strategy:   row.AsString("strategy", ""),
+				Table:      row.AsString("mysql_table", ""),
+				Progress:   row.AsFloat64("progress", 0),
+				ETASeconds: row.AsInt64("eta_seconds", -1),
+				RowsCopied: row.AsInt64("rows_copied", 0),
+				TableRows:  row.AsInt64("table_rows", 0),
+				Message:    row.AsString("message", ""),
+			}
+			if started := row.AsInt64("started_timestamp", 0); started > 0 {
+				startedAt := time.Unix(started, 0)
+				sp.StartedAt = &startedAt
+			}
+
+			if sp.Status == string(schema.OnlineDDLStatusRunning) {
+				throttled, message, err := checkThrottler(tablet.Addr(), throttlerApp(sp.Strategy, uuid))
+				if err != nil {
+					log.Warningf("cannot check the throttler of tablet %v: %v", topoproto.TabletAliasString(sp.Tablet), err)
+				}
+				sp.Throttled = throttled
+				sp.ThrottleMessage = message
+
+				if sp.Strategy == string(schema.DDLStrategyOnline) {
+					if vreplLags == nil {
+						if vreplLags, err = vreplicationLags(ctx, vx, now); err != nil {
+							log.Warningf("cannot read the vreplication lag of migration %v: %v", uuid, err)
+							vreplLags = map[string]int64{}
+						}
+					}
+					sp.VReplicationLagSeconds = vreplLags[sp.Shard]
+				}
+			}
+			progress.Shards = append(progress.Shards, sp)
+		}
+	}
+	if len(progress.Shards) == 0 {
+		return nil, fmt.Errorf("migration %s not found in keyspace %s", uuid, keyspace)
+	}
+	aggregateProgress(progress)
+	return progress, nil
+}
+
+// vreplicationLags returns the lag of the vreplication streams of a
+// migration of the online strategy on each shard: the time since they last
+// applied an event or heartbeat.
+func vreplicationLags(ctx context.Context, vx *vexec.VExec, now time.Time) (map[string]int64, error) {
+	results, err := vx.QueryContext(ctx, "select time_updated, transaction_timestamp from _vt.vreplication")
+	if err != nil {
+		return nil, err
+	}
+	lags := make(map[string]int64, len(results))
+	for tablet, result := range results {
+		for _, row := range sqltypes.Proto3ToResult(result).Named().Rows {
+			updated := row.AsInt64("time_updated", 0)
+			if transactionTimestamp := row.AsInt64("transaction_timestamp", 0); transactionTimestamp > updated {
+				updated = transactionTimestamp
+			}
+			if updated == 0 {
+				continue
+			}
+			if lag := int64(now.Sub(time.Unix(updated, 0)).Seconds()); lag > lags[tablet.Shard] {
+				lags[tablet.Shard] = lag
+			}
+		}
+	}
+	return lags, nil
+}
+
+// aggregateProgress sets the progress of the migration from its
+// progress on each shard, and sorts the shards.
+func aggregateProgress(progress *Progress) {
+	sort.Slice(progress.Shards, func(i, j int) bool { return progress.Shards[i].Shard < progress.Shards[j].Shard })
+
+	progress.RowsCopied = 0
+	progress.TableRows = 0
+	progress.ThrottledShards = 0
+	progress.MaxVReplicationLagSeconds = 0
+	statuses := make(map[schema.OnlineDDLStatus]int)
+	allRowsKnown := true
+	var copiedEstimate, progressSum float64
+	for _, sp := range progress.Shards {
+		progress.Table = sp.Table
+		progress.Strategy = sp.Strategy
+		statuses[schema.OnlineDDLStatus(sp.Status)]++
+
+		shardProgress := sp.Progress
+		if sp.Status == string(schema.OnlineDDLStatusComplete) {
+			shardProgress = 100
+		}
+		progressSum += shardProgress
+		if sp.TableRows > 0 {
+			copiedEstimate += shardProgress / 100 * float64(sp.TableRows)
+		} else {
+			allRowsKnown = false
+		}
+		progress.RowsCopied += sp.RowsCopied
+		progress.TableRows += sp.TableRows
+
+		if sp.Throttled {
+			progress.ThrottledShards++
+		}
+		if sp.VReplicationLagSeconds > progress.MaxVReplicationLagSeconds {
+			progress.MaxVReplicationLagSeconds = sp.VReplicationLagSeconds
+		}
+	}
+
+	// The shards are weighed by their rows, unless some are unknown.
+	if allRowsKnown && progress.TableRows > 0 {
+		progress.Progress = 100 * copiedEstimate / float64(progress.TableRows)
+	} else {
+		progress.Progress = progressSum / float64(len(progress.Shards))
+	}
+
+	progress.ETASeconds = 0
+	for _, sp := range progress.Shards {
+		if sp.Status == string(schema.OnlineDDLStatusComplete) {
+			continue
+		}
+		if sp.ETASeconds < 0 {
+			progress.ETASeconds = -1
+			break
+		}
+		if sp.ETASeconds > progress.ETASeconds {
+			progress.ETASeconds = sp.ETASeconds
+		}
+	}
+
+	switch {
+	case statuses[schema.OnlineDDLStatusFailed] > 0:
+		progress.Status = string(schema.OnlineDDLStatusFailed)
+	case statuses[schema.OnlineDDLStatusCancelled] > 0:
+		progress.Status = string(schema.OnlineDDLStatusCancelled)
+	case statuses[schema.OnlineDDLStatusRunning] > 0:
+		progress.Status = string(schema.OnlineDDLStatusRunning)
+	default:
+		for _, status := range statusOrder {
+			if statuses[status] > 0 {
+				progress.Status = string(status)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package onlineddlutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAggregateProgress(t *testing.T) {
+	progress := &Progress{Shards: []*ShardProgress{{
+		Shard:                  "80-",
+		Status:                 "running",
+		Strategy:               "online",
+		Table:                  "t1",
+		Progress:               50,
+		ETASeconds:             120,
+		RowsCopied:             150,
+		TableRows:              300,
+		Throttled:              true,
+		VReplicationLagSeconds: 4,
+	}, {
+		Shard:      "-80",
+		Status:     "complete",
+		Strategy:   "online",
+		Table:      "t1",
+		Progress:   99,
+		ETASeconds: 0,
+		RowsCopied: 100,
+		TableRows:  100,
+	}}}
+	aggregateProgress(progress)
+
+	assert.Equal(t, "-80", progress.Shards[0].Shard)
+	assert.Equal(t, "running", progress.Status)
+	assert.Equal(t, "online", progress.Strategy)
+	assert.Equal(t, "t1", progress.Table)
+	// 100 rows of -80 and half of the 300 rows of 80- are copied.
+	assert.InDelta(t, 62.5, progress.Progress, 0.001)
+	assert.EqualValues(t, 120, progress.ETASeconds)
+	assert.EqualValues(t, 250, progress.RowsCopied)
+	assert.EqualValues(t, 400, progress.TableRows)
+	assert.Equal(t, 1, progress.ThrottledShards)
+	assert.EqualValues(t, 4, progress.MaxVReplicationLagSeconds)
+
+	// Without the rows of a shard, the shards weigh the same.
+	progress.Shards[0].Status = "running"
+	progress.Shards[0].TableRows = 0
+	progress.Shards[0].ETASeconds = -1
+	aggregateProgress(progress)
+	assert.InDelta(t, 74.5, progress.Progress, 0.001)
+	assert.EqualValues(t, 250, progress.RowsCopied)
+	assert.EqualValues(t, -1, progress.ETASeconds)
+
+	progress.Shards[0].Status = "failed"
+	aggregateProgress(progress)
+	assert.Equal(t, "failed", progress.Status)
+
+	progress.Shards[0].Status = "queued"
+	progress.Shards[1].Status = "ready"
+	aggregateProgress(progress)
+	assert.Equal(t, "queued", progress.Status)
+}
+
+func TestThrottlerApp(t *testing.T) {
+	assert.Equal(t, "online-ddl:gh-ost:uuid", throttlerApp("gh-ost", "uuid"))
+	assert.Equal(t, "online-ddl:pt-osc:uuid", throttlerApp("pt-osc", "uuid"))
+	assert.Equal(t, "vreplication", throttlerApp("online", "uuid"))
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package onlineddlutil
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/schema"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/vtctl/workflow/vexec"
+	"vitess.io/vitess/go/vt/vttablet/tmclient"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// RevertWindow is the window during which a complete online DDL
+// migration can be reverted on a shard. The tablet keeps the artifacts of
+// the migration, such as the table before an ALTER, until its end. The
+// revert of an ALTER of the online strategy replays the writes made to the
+// table since the cut-over into the former table, so it's lossless.
+type RevertWindow struct {
+	Shard       string
+	Tablet      *topodatapb.TabletAlias
+	Table       string
+	Action      string
+	Strategy    string
+	CompletedAt time.Time
+	// ExpiresAt is when the artifacts are garbage collected, for the
+	// migrations submitted with -retain-artifacts. It's zero for the other
+	// ones, whose artifacts are kept for the -retain_online_ddl_tables of
+	// the tablet.
+	ExpiresAt time.Time
+}
+
+// Revert is a submitted revert of an online DDL migration.
+type Revert struct {
+	// UUID is the UUID of the revert migration.
+	UUID         string
+	RevertedUUID string
+	Windows      []*RevertWindow
+}
+
+// RevertWindows returns the revert windows of the migration on each shard.
+// It returns an error if the migration can't be reverted on a shard: it
+// isn't complete, its strategy doesn't keep the former table, or its
+// artifacts were already garbage collected.
+func RevertWindows(ctx context.Context, ts *topo.Server, tmc tmclient.TabletManagerClient, keyspace, uuid string) ([]*RevertWindow, error) {
+	if !schema.IsOnlineDDLUUID(uuid) {
+		return nil, fmt.Errorf("not an online DDL UUID: %s", uuid)
+	}
+	query, err := sqlparser.ParseAndBind(`select
+			shard, mysql_table, ddl_action, strategy, migration_status, retain_artifacts_seconds,
+			unix_timestamp(completed_timestamp) as completed_timestamp, unix_timestamp(cleanup_timestamp) as cleanup_timestamp
+		from _vt.schema_migrations where migration_uuid=%a`,
+		sqltypes.StringBindVariable(uuid))
+	if err != nil {
+		return nil, err
+	}
+	results, err := vexec.NewVExec(keyspace, uuid, ts, tmc).QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var windows []*RevertWindow
+	for tablet, result := range results {
+		for _, row := range sqltypes.Proto3ToResult(result).Named().Rows {
+			window, err := revertWindow(uuid, row)
+			if err != nil {
+				return nil, err
+			}
+			window.Tablet = tablet.Alias
+			windows = append(windows, window)
+		}
+	}
+	if len(windows) == 0 {
+		return nil, fmt.Errorf("migration %s not found in keyspace %s", uuid, keyspace)
+	}
+	sort.Slice(windows, func(i, j int) bool { return windows[i].Shard < windows[j].Shard })
+	return windows, nil
+}
+
+// RevertMigration submits a migration reverting the given one, once it
+// checked that the migration is still within its revert window on all the
+// shards. The request context defaults to vtctl:<uuid>.
+func RevertMigration(ctx context.Context, ts *topo.Server, tmc tmclient.TabletManagerClient, keyspace, uuid, requestContext string) (*Revert, error) {
+	windows, err := RevertWindows(ctx, ts, tmc, keyspace, uuid)
+	if err != nil {
+		return nil, err
+	}
+	if requestContext == "" {
+		contextUUID, err := schema.CreateUUID()
+		if err != nil {
+			return nil, err
+		}
+		requestContext = fmt.Sprintf("vtctl:%s", contextUUID)
+	}
+	sql := sqlparser.String(&sqlparser.RevertMigration{UUID: uuid})
+	onlineDDL, err := schema.NewOnlineDDL(keyspace, "", sql, schema.NewDDLStrategySetting(schema.DDLStrategyOnline, ""), requestContext)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := ts.ConnForCell(ctx, topo.GlobalCell)
+	if err != nil {
+		return nil, err
+	}
+	if err := onlineDDL.WriteTopo(ctx, conn, schema.MigrationRequestsPath()); err != nil {
+		return nil, err
+	}
+	log.Infof("Submitted migration %s reverting migration %s", onlineDDL.UUID, uuid)
+	return &Revert{
+		UUID:         onlineDDL.UUID,
+		RevertedUUID: uuid,
+		Windows:      windows,
+	}, nil
+}
+
+// revertWindow returns the revert window of the migration row of a shard,
+// or an error if it can't be reverted.
+func revertWindow(uuid string, row sqltypes.RowNamedValues) (*RevertWindow, error) {
+	window := &RevertWindow{
+		Shard:    row.AsString("shard", ""),
+		Table:    row.AsString("mysql_table", ""),
+		Action:   row.AsString("ddl_action", ""),
+		Strategy: row.AsString("strategy", ""),
+	}
+	if status := row.AsString("migration_status", ""); status != string(schema.OnlineDDLStatusComplete) {
+		return nil, fmt.Errorf("migration %s is %s on shard %s: only complete migrations can be reverted", uuid, status, window.Shard)
+	}
+	if window.Action == sqlparser.AlterStr && window.Strategy != string(schema.DDLStrategyOnline) {
+		return nil, fmt.Errorf("migration %s has strategy %s: only the ALTERs of the %s strategy keep the former table and can be reverted", uuid, window.Strategy, schema.DDLStrategyOnline)
+	}
+	if cleanup := row.AsInt64("cleanup_timestamp", 0); cleanup > 0 {
+		return nil, fmt.Errorf("the revert window of migration %s has passed on shard %s: its artifacts were garbage collected at %v", uuid, window.Shard, time.Unix(cleanup, 0).UTC())
+	}
+	window.CompletedAt = time.Unix(row.AsInt64("completed_timestamp", 0), 0).UTC()
+	if retention := row.AsInt64("retain_artifacts_seconds", 0); retention > 0 {
+		window.ExpiresAt = window.CompletedAt.Add(time.Duration(retention) * time.Second)
+	}
+	return window, nil
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package onlineddlutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+)
+
+func TestRevertWindow(t *testing.T) {
+	const uuid = "6ace8bce_f732_11ea_87e9_f875a4d24e90"
+	result := sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields(
+			"shard|mysql_table|ddl_action|strategy|migration_status|retain_artifacts_seconds|completed_timestamp|cleanup_timestamp",
+			"varchar|varchar|varchar|varchar|varchar|int64|int64|int64"),
+		"-80|t1|alter|online|complete|259200|1600171450|null",
+		"80-|t1|alter|online|complete|0|1600171450|null",
+		"-80|t1|alter|online|running|0|null|null",
+		"-80|t1|alter|gh-ost|complete|0|1600171450|null",
+		"-80|t1|drop|gh-ost|complete|0|1600171450|null",
+		"-80|t1|alter|online|complete|0|1600171450|1600257850",
+	)
+	rows := result.Named().Rows
+
+	completedAt := time.Date(2020, 9, 15, 12, 4, 10, 0, time.UTC)
+	window, err := revertWindow(uuid, rows[0])
+	require.NoError(t, err)
+	assert.Equal(t, &RevertWindow{
+		Shard:       "-80",
+		Table:       "t1",
+		Action:      "alter",
+		Strategy:    "online",
+		CompletedAt: completedAt,
+		ExpiresAt:   completedAt.Add(72 * time.Hour),
+	}, window)
+
+	// The artifacts are kept for the -retain_online_ddl_tables of the tablet.
+	window, err = revertWindow(uuid, rows[1])
+	require.NoError(t, err)
+	assert.True(t, window.ExpiresAt.IsZero())
+
+	_, err = revertWindow(uuid, rows[2])
+	assert.EqualError(t, err, "migration 6ace8bce_f732_11ea_87e9_f875a4d24e90 is running on shard -80: only complete migrations can be reverted")
+	_, err = revertWindow(uuid, rows[3])
+	assert.EqualError(t, err, "migration 6ace8bce_f732_11ea_87e9_f875a4d24e90 has strategy gh-ost: only the ALTERs of the online strategy keep the former table and can be reverted")
+	// The DROPs of all the strategies rename the table away.
+	_, err = revertWindow(uuid, rows[4])
+	assert.NoError(t, err)
+	_, err = revertWindow(uuid, rows[5])
+	assert.EqualError(t, err, "the revert window of migration 6ace8bce_f732_11ea_87e9_f875a4d24e90 has passed on shard -80: its artifacts were garbage collected at 2020-09-16 12:04:10 +0000 UTC")
+}
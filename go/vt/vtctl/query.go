@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"io"
 	"strconv"
+	"strings"
 
 	"google.golang.org/protobuf/encoding/prototext"
 
@@ -34,6 +35,7 @@ import (
 	"vitess.io/vitess/go/vt/callerid"
 	"vitess.io/vitess/go/vt/grpcclient"
 	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/topo/topoproto"
 	"vitess.io/vitess/go/vt/vtgate/vtgateconn"
 	"vitess.io/vitess/go/vt/vttablet/tabletconn"
@@ -184,6 +186,34 @@ func commandVtGateExecute(ctx context.Context, wr *wrangler.Wrangler, subFlags *
 	return nil
 }
 
+// vtgateCanaryExecutor runs SwitchTraffic canary queries through vtgate.
+type vtgateCanaryExecutor struct {
+	conn *vtgateconn.VTGateConn
+}
+
+// ExecuteCanaryQuery is part of the wrangler.CanaryQueryExecutor interface.
+func (e *vtgateCanaryExecutor) ExecuteCanaryQuery(ctx context.Context, keyspace, query string) error {
+	_, err := e.conn.Session(keyspace+"@master", nil).Execute(ctx, query, nil)
+	return err
+}
+
+// splitCanaryQueries splits the -canary_queries of SwitchTraffic into
+// single queries. Semicolons inside string literals and comments do not end
+// a query.
+func splitCanaryQueries(sql string) ([]string, error) {
+	pieces, err := sqlparser.SplitStatementToPieces(sql)
+	if err != nil {
+		return nil, err
+	}
+	var queries []string
+	for _, piece := range pieces {
+		if query := strings.TrimSpace(piece); query != "" {
+			queries = append(queries, query)
+		}
+	}
+	return queries, nil
+}
+
 func commandVtTabletExecute(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
 	if !*enableQueries {
 		return fmt.Errorf("query commands are disabled (set the -enable_queries flag to enable)")
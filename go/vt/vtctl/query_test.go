@@ -18,6 +18,7 @@ package vtctl
 
 import (
 	"bytes"
+	"reflect"
 	"testing"
 
 	"vitess.io/vitess/go/sqltypes"
@@ -49,3 +50,32 @@ func TestPrintQueryResult(t *testing.T) {
 		t.Errorf("printQueryResult() = %q, want %q", got, want)
 	}
 }
+
+func TestSplitCanaryQueries(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{{
+		in:   "select 1 from t",
+		want: []string{"select 1 from t"},
+	}, {
+		in:   "select 1 from t; select 2 from u;",
+		want: []string{"select 1 from t", "select 2 from u"},
+	}, {
+		in:   "select * from t where name = 'a;b' ;; select 2 from u",
+		want: []string{"select * from t where name = 'a;b'", "select 2 from u"},
+	}, {
+		in:   " ; ",
+		want: nil,
+	}}
+	for _, tt := range tests {
+		got, err := splitCanaryQueries(tt.in)
+		if err != nil {
+			t.Errorf("splitCanaryQueries(%q) failed: %v", tt.in, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("splitCanaryQueries(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
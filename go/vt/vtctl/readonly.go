@@ -0,0 +1,116 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtctl
+
+import (
+	"strings"
+
+	"vitess.io/vitess/go/vt/vtctl/grpcvtctldserver"
+)
+
+// readOnlyCommands are the commands which a read-only vtctld runs, as they
+// only read the cluster, see -vtctld_read_only. All the other commands,
+// including the ones added later, are denied.
+var readOnlyCommands = []string{
+	"CheckMySQLUsers",
+	"FindAllShardsInKeyspace",
+	"GenerateShardRanges",
+	"GetBackupSchedule",
+	"GetBackupStorageConfig",
+	"GetCDCSinks",
+	"GetCellInfo",
+	"GetCellInfoNames",
+	"GetCellServingOverride",
+	"GetCellsAliases",
+	"GetKeyspace",
+	"GetKeyspaceBackupRuns",
+	"GetKeyspaceServingFlags",
+	"GetKeyspaces",
+	"GetMySQLUsers",
+	"GetPermissions",
+	"GetQueryQuotas",
+	"GetRateWindows",
+	"GetReplicationLagThresholds",
+	"GetRoutingRules",
+	"GetRoutingRulesWithVersion",
+	"GetSchema",
+	"GetShard",
+	"GetShardFence",
+	"GetShardReplication",
+	"GetShardReplicationGraph",
+	"GetShardServingTimeline",
+	"GetSrvKeyspace",
+	"GetSrvKeyspaceNames",
+	"GetSrvKeyspaceWithVersion",
+	"GetSrvVSchema",
+	"GetTablet",
+	"GetThrottlerConfiguration",
+	"GetVSchema",
+	"GetWorkflowAuditLog",
+	"GetWorkflowProgress",
+	"GetWorkflowStates",
+	"GetWorkflowThrottling",
+	"Help",
+	"ListAllTablets",
+	"ListBackups",
+	"ListKeyspaceBackups",
+	"ListQueryPins",
+	"ListShardTablets",
+	"ListTablets",
+	"ListVDiffResults",
+	"PlanReparentShard",
+	"PreviewDeclarativeSchema",
+	"RecommendTabletTypeChange",
+	"ShardReplicationPositions",
+	"ShowResharding",
+	"ThrottlerMaxRates",
+	"TopoCat",
+	"Validate",
+	"ValidateBlacklistedTables",
+	"ValidateConfig",
+	"ValidateKeyspace",
+	"ValidatePermissions",
+	"ValidatePermissionsKeyspace",
+	"ValidatePermissionsShard",
+	"ValidateRoutingRules",
+	"ValidateSchemaKeyspace",
+	"ValidateSchemaShard",
+	"ValidateShard",
+	"ValidateVersion",
+	"ValidateVersionKeyspace",
+	"ValidateVersionShard",
+	"VerifyReparentShard",
+	"VerifyReverseReplication",
+	"VtTabletStreamHealth",
+	"WorkflowSchedules",
+	"WorkflowTree",
+}
+
+// CheckReadOnlyCommand returns a PERMISSION_DENIED error if vtctld is
+// read-only and the command of args isn't one which only reads the
+// cluster. Commands are matched regardless of case, like in RunCommand.
+func CheckReadOnlyCommand(args []string) error {
+	if !grpcvtctldserver.IsReadOnly() || len(args) == 0 {
+		return nil
+	}
+	for _, name := range readOnlyCommands {
+		if strings.EqualFold(name, args[0]) {
+			return nil
+		}
+	}
+	return grpcvtctldserver.ErrReadOnly(args[0])
+}
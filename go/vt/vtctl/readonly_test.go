@@ -0,0 +1,53 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtctl
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/vterrors"
+
+	"vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+func TestCheckReadOnlyCommand(t *testing.T) {
+	assert.NoError(t, CheckReadOnlyCommand([]string{"FenceShard", "ks/0"}))
+
+	require.NoError(t, flag.Set("vtctld_read_only", "true"))
+	defer flag.Set("vtctld_read_only", "false")
+
+	assert.NoError(t, CheckReadOnlyCommand([]string{"GetKeyspace", "ks"}))
+	assert.NoError(t, CheckReadOnlyCommand([]string{"getkeyspace", "ks"}))
+	err := CheckReadOnlyCommand([]string{"FenceShard", "ks/0"})
+	require.Error(t, err)
+	assert.Equal(t, vtrpc.Code_PERMISSION_DENIED, vterrors.Code(err))
+
+	// Every read command exists.
+	names := make(map[string]bool)
+	for _, group := range commands {
+		for _, cmd := range group.commands {
+			names[cmd.name] = true
+		}
+	}
+	for _, name := range readOnlyCommands {
+		assert.True(t, names[name], "%v is not a vtctl command", name)
+	}
+}
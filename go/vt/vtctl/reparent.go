@@ -19,11 +19,15 @@ package vtctl
 import (
 	"flag"
 	"fmt"
+	"strings"
 
 	"context"
 
+	"k8s.io/apimachinery/pkg/util/sets"
+
 	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vtctl/reparentutil"
 	"vitess.io/vitess/go/vt/wrangler"
 
 	"vitess.io/vitess/go/vt/mysqlctl"
@@ -50,8 +54,18 @@ func init() {
 	addCommand("Shards", command{
 		"EmergencyReparentShard",
 		commandEmergencyReparentShard,
-		"-keyspace_shard=<keyspace/shard> [-new_master=<tablet alias>] [-wait_replicas_timeout=<duration>] [-ignore_replicas=<tablet alias list>]",
-		"Reparents the shard to the new master. Assumes the old master is dead and not responding."})
+		"-keyspace_shard=<keyspace/shard> [-new_master=<tablet alias>] [-wait_replicas_timeout=<duration>] [-ignore_replicas=<tablet alias list>] [-allowed_cells=<cell list>] [-exclude_tablets=<tablet alias list>] [-max_replication_lag=<duration>] [-dry_run]",
+		"Reparents the shard to the new master. Assumes the old master is dead and not responding. The candidates for promotion can be constrained to cells, exclude tablets, or exclude the tablets lagging more than a duration. With -dry_run, only logs which tablet would be promoted and why, without stopping replication."})
+	addCommand("Shards", command{
+		"PlanReparentShard",
+		commandPlanReparentShard,
+		"[-emergency] [-new_master=<tablet alias>] [-avoid_master=<tablet alias>] [-wait_replicas_timeout=<duration>] <keyspace/shard>",
+		"Outputs a JSON plan of a PlannedReparentShard, or of an EmergencyReparentShard with -emergency, without changing the shard: the candidates for promotion ranked by replication position and lag, the tablet which would be promoted, the tablets which would be repointed to it, and the expected write downtime."})
+	addCommand("Shards", command{
+		"VerifyReparentShard",
+		commandVerifyReparentShard,
+		"<keyspace/shard>",
+		"Outputs a JSON report of the replication of the tablets of the shard, typically after a reparent: whether the shard record points to a master tablet, and whether its replicas replicate from it. Fails if the shard is not healthy."})
 	addCommand("Shards", command{
 		"TabletExternallyReparented",
 		commandTabletExternallyReparented,
@@ -154,6 +168,10 @@ func commandEmergencyReparentShard(ctx context.Context, wr *wrangler.Wrangler, s
 	keyspaceShard := subFlags.String("keyspace_shard", "", "keyspace/shard of the shard that needs to be reparented")
 	newMaster := subFlags.String("new_master", "", "optional alias of a tablet that should be the new master. If not specified, Vitess will select the best candidate")
 	ignoreReplicasList := subFlags.String("ignore_replicas", "", "comma-separated list of replica tablet aliases to ignore during emergency reparent")
+	allowedCells := subFlags.String("allowed_cells", "", "comma-separated list of cells the new master must be in")
+	excludeTabletsList := subFlags.String("exclude_tablets", "", "comma-separated list of tablet aliases which must not be promoted")
+	maxReplicationLag := subFlags.Duration("max_replication_lag", 0, "if not zero, the tablets which lagged more before replication was stopped are not promoted")
+	dryRun := subFlags.Bool("dry_run", false, "only logs which tablet would be promoted and why, without stopping replication or promoting it")
 	if err := subFlags.Parse(args); err != nil {
 		return err
 	}
@@ -179,8 +197,78 @@ func commandEmergencyReparentShard(ctx context.Context, wr *wrangler.Wrangler, s
 			return err
 		}
 	}
-	unreachableReplicas := topoproto.ParseTabletSet(*ignoreReplicasList)
-	return wr.EmergencyReparentShard(ctx, keyspace, shard, tabletAlias, *waitReplicasTimeout, unreachableReplicas)
+	opts := reparentutil.EmergencyReparentOptions{
+		NewPrimaryAlias:     tabletAlias,
+		WaitReplicasTimeout: *waitReplicasTimeout,
+		IgnoreReplicas:      topoproto.ParseTabletSet(*ignoreReplicasList),
+		ExcludeTablets:      topoproto.ParseTabletSet(*excludeTabletsList),
+		MaxReplicationLag:   *maxReplicationLag,
+		DryRun:              *dryRun,
+	}
+	if *allowedCells != "" {
+		opts.AllowedCells = sets.NewString(strings.Split(*allowedCells, ",")...)
+	}
+	return wr.EmergencyReparentShardWithOptions(ctx, keyspace, shard, opts)
+}
+
+func commandPlanReparentShard(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	emergency := subFlags.Bool("emergency", false, "plans an EmergencyReparentShard instead of a PlannedReparentShard")
+	newMaster := subFlags.String("new_master", "", "optional alias of a tablet that should be the new master")
+	avoidMaster := subFlags.String("avoid_master", "", "optional alias of a tablet that should not be the master")
+	waitReplicasTimeout := subFlags.Duration("wait_replicas_timeout", *topo.RemoteOperationTimeout, "time to wait for the replication statuses of the tablets, and for replicas to catch up in reparenting")
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("action PlanReparentShard requires <keyspace/shard>")
+	}
+	keyspace, shard, err := topoproto.ParseKeyspaceShard(subFlags.Arg(0))
+	if err != nil {
+		return err
+	}
+	opts := reparentutil.ReparentPlanOptions{
+		Emergency:           *emergency,
+		WaitReplicasTimeout: *waitReplicasTimeout,
+	}
+	if *newMaster != "" {
+		if opts.NewPrimaryAlias, err = topoproto.ParseTabletAlias(*newMaster); err != nil {
+			return err
+		}
+	}
+	if *avoidMaster != "" {
+		if opts.AvoidPrimaryAlias, err = topoproto.ParseTabletAlias(*avoidMaster); err != nil {
+			return err
+		}
+	}
+	plan, err := wr.PlanReparentShard(ctx, keyspace, shard, opts)
+	if err != nil {
+		return err
+	}
+	return printJSON(wr.Logger(), plan)
+}
+
+func commandVerifyReparentShard(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("action VerifyReparentShard requires <keyspace/shard>")
+	}
+	keyspace, shard, err := topoproto.ParseKeyspaceShard(subFlags.Arg(0))
+	if err != nil {
+		return err
+	}
+	report, err := wr.VerifyReparentShard(ctx, keyspace, shard)
+	if err != nil {
+		return err
+	}
+	if err := printJSON(wr.Logger(), report); err != nil {
+		return err
+	}
+	if !report.Healthy {
+		return fmt.Errorf("shard %v/%v has replication problems", keyspace, shard)
+	}
+	return nil
 }
 
 func commandTabletExternallyReparented(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
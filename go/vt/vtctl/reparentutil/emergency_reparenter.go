@@ -57,6 +57,21 @@ type EmergencyReparentOptions struct {
 	IgnoreReplicas      sets.String
 	WaitReplicasTimeout time.Duration
 
+	// Constraints on the candidates for promotion. AllowedCells, if not
+	// empty, are the cells the new primary must be in. ExcludeTablets are
+	// the aliases of the tablets which must not be promoted.
+	// MaxReplicationLag, if not zero, excludes the tablets which lagged
+	// more before replication was stopped.
+	AllowedCells      sets.String
+	ExcludeTablets    sets.String
+	MaxReplicationLag time.Duration
+
+	// DryRun elects the new primary without stopping replication, waiting
+	// for the relay logs to apply or promoting it. The election and its
+	// rationale are logged, and the elected tablet is returned as the
+	// NewMaster of the event.
+	DryRun bool
+
 	// Private options managed internally. We use value passing to avoid leaking
 	// these details back out.
 
@@ -97,12 +112,16 @@ func (erp *EmergencyReparenter) ReparentShard(ctx context.Context, keyspace stri
 	defer unlock(&err)
 
 	ev := &events.Reparent{}
+	action := "EmergencyReparentShard"
+	if opts.DryRun {
+		action += " dry run"
+	}
 	defer func() {
 		switch err {
 		case nil:
-			event.DispatchUpdate(ev, "finished EmergencyReparentShard")
+			event.DispatchUpdate(ev, "finished "+action)
 		default:
-			event.DispatchUpdate(ev, "failed EmergencyReparentShard: "+err.Error())
+			event.DispatchUpdate(ev, "failed "+action+": "+err.Error())
 		}
 	}()
 
@@ -279,9 +298,20 @@ func (erp *EmergencyReparenter) reparentShardLocked(ctx context.Context, ev *eve
 		return vterrors.Wrapf(err, "failed to get tablet map for %v/%v: %v", keyspace, shard, err)
 	}
 
-	statusMap, primaryStatusMap, err := StopReplicationAndBuildStatusMaps(ctx, erp.tmc, ev, tabletMap, opts.WaitReplicasTimeout, opts.IgnoreReplicas, erp.logger)
-	if err != nil {
-		return vterrors.Wrapf(err, "failed to stop replication and build status maps: %v", err)
+	var (
+		statusMap        map[string]*replicationdatapb.StopReplicationStatus
+		primaryStatusMap map[string]*replicationdatapb.MasterStatus
+	)
+	if opts.DryRun {
+		statusMap, primaryStatusMap, err = ReadReplicationStatusMaps(ctx, erp.tmc, tabletMap, opts.WaitReplicasTimeout, opts.IgnoreReplicas, erp.logger)
+		if err != nil {
+			return vterrors.Wrapf(err, "failed to build status maps: %v", err)
+		}
+	} else {
+		statusMap, primaryStatusMap, err = StopReplicationAndBuildStatusMaps(ctx, erp.tmc, ev, tabletMap, opts.WaitReplicasTimeout, opts.IgnoreReplicas, erp.logger)
+		if err != nil {
+			return vterrors.Wrapf(err, "failed to stop replication and build status maps: %v", err)
+		}
 	}
 
 	if err := topo.CheckShardLocked(ctx, keyspace, shard); err != nil {
@@ -291,13 +321,17 @@ func (erp *EmergencyReparenter) reparentShardLocked(ctx context.Context, ev *eve
 	validCandidates, err := FindValidEmergencyReparentCandidates(statusMap, primaryStatusMap)
 	if err != nil {
 		return err
-	} else if len(validCandidates) == 0 {
+	}
+	excluded := erp.constrainCandidates(validCandidates, tabletMap, statusMap, opts)
+	if len(validCandidates) == 0 {
 		return vterrors.Errorf(vtrpc.Code_FAILED_PRECONDITION, "no valid candidates for emergency reparent")
 	}
 
 	// Wait for all candidates to apply relay logs
-	if err := erp.waitForAllRelayLogsToApply(ctx, validCandidates, tabletMap, statusMap, opts); err != nil {
-		return err
+	if !opts.DryRun {
+		if err := erp.waitForAllRelayLogsToApply(ctx, validCandidates, tabletMap, statusMap, opts); err != nil {
+			return err
+		}
 	}
 
 	// Elect the candidate with the most up-to-date position.
@@ -312,6 +346,7 @@ func (erp *EmergencyReparenter) reparentShardLocked(ctx context.Context, ev *eve
 			winningPrimaryTabletAliasStr = alias
 		}
 	}
+	erp.logger.Infof("tablet %v has the most advanced position %v of the %v candidate(s)", winningPrimaryTabletAliasStr, winningPosition, len(validCandidates))
 
 	// If we were requested to elect a particular primary, verify it's a valid
 	// candidate (non-zero position, no errant GTIDs) and is at least as
@@ -320,6 +355,8 @@ func (erp *EmergencyReparenter) reparentShardLocked(ctx context.Context, ev *eve
 		winningPrimaryTabletAliasStr = topoproto.TabletAliasString(opts.NewPrimaryAlias)
 		pos, ok := validCandidates[winningPrimaryTabletAliasStr]
 		switch {
+		case excluded[winningPrimaryTabletAliasStr] != "":
+			return vterrors.Errorf(vtrpc.Code_FAILED_PRECONDITION, "master elect %v %v", winningPrimaryTabletAliasStr, excluded[winningPrimaryTabletAliasStr])
 		case !ok:
 			return vterrors.Errorf(vtrpc.Code_FAILED_PRECONDITION, "master elect %v has errant GTIDs", winningPrimaryTabletAliasStr)
 		case !pos.AtLeast(winningPosition):
@@ -327,6 +364,12 @@ func (erp *EmergencyReparenter) reparentShardLocked(ctx context.Context, ev *eve
 		}
 	}
 
+	if opts.DryRun {
+		erp.logger.Infof("dry run: tablet %v would be promoted to master", winningPrimaryTabletAliasStr)
+		ev.NewMaster = proto.Clone(tabletMap[winningPrimaryTabletAliasStr].Tablet).(*topodatapb.Tablet)
+		return nil
+	}
+
 	// Check (again) we still have the topology lock.
 	if err := topo.CheckShardLocked(ctx, keyspace, shard); err != nil {
 		return vterrors.Wrapf(err, "lost topology lock, aborting: %v", err)
@@ -341,6 +384,40 @@ func (erp *EmergencyReparenter) reparentShardLocked(ctx context.Context, ev *eve
 	return nil
 }
 
+// constrainCandidates removes the candidates which don't satisfy the
+// constraints of the options, and logs why. It returns the reason each
+// removed candidate was excluded, by alias.
+func (erp *EmergencyReparenter) constrainCandidates(
+	validCandidates map[string]mysql.Position,
+	tabletMap map[string]*topo.TabletInfo,
+	statusMap map[string]*replicationdatapb.StopReplicationStatus,
+	opts EmergencyReparentOptions,
+) map[string]string {
+	excluded := make(map[string]string)
+	for alias, position := range validCandidates {
+		var reason string
+		tabletInfo, ok := tabletMap[alias]
+		switch {
+		case !ok:
+			reason = "is not in the tablet map"
+		case opts.ExcludeTablets.Has(alias):
+			reason = "is excluded"
+		case opts.AllowedCells.Len() > 0 && !opts.AllowedCells.Has(tabletInfo.Alias.Cell):
+			reason = fmt.Sprintf("is in cell %v, which is not allowed", tabletInfo.Alias.Cell)
+		case opts.MaxReplicationLag > 0 && statusMap[alias] != nil && time.Duration(statusMap[alias].Before.GetSecondsBehindMaster())*time.Second > opts.MaxReplicationLag:
+			reason = fmt.Sprintf("lagged %vs, more than the maximum replication lag of %v", statusMap[alias].Before.GetSecondsBehindMaster(), opts.MaxReplicationLag)
+		}
+		if reason == "" {
+			erp.logger.Infof("candidate %v is at position %v", alias, position)
+			continue
+		}
+		erp.logger.Infof("candidate %v at position %v %v", alias, position, reason)
+		excluded[alias] = reason
+		delete(validCandidates, alias)
+	}
+	return excluded
+}
+
 func (erp *EmergencyReparenter) waitForAllRelayLogsToApply(
 	ctx context.Context,
 	validCandidates map[string]mysql.Position,
@@ -29,6 +29,7 @@ import (
 	"vitess.io/vitess/go/vt/logutil"
 	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/topo/memorytopo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
 	"vitess.io/vitess/go/vt/topotools/events"
 	"vitess.io/vitess/go/vt/vtctl/grpcvtctldserver/testutil"
 
@@ -1061,6 +1062,135 @@ func TestEmergencyReparenter_reparentShardLocked(t *testing.T) {
 	}
 }
 
+func TestEmergencyReparenter_dryRunConstraints(t *testing.T) {
+	t.Parallel()
+
+	status := func(gtids string, lag uint32) struct {
+		Position *replicationdatapb.Status
+		Error    error
+	} {
+		return struct {
+			Position *replicationdatapb.Status
+			Error    error
+		}{
+			Position: &replicationdatapb.Status{
+				MasterUuid:          "3E11FA47-71CA-11E1-9E33-C80AA9429562",
+				RelayLogPosition:    "MySQL56/3E11FA47-71CA-11E1-9E33-C80AA9429562:" + gtids,
+				SecondsBehindMaster: lag,
+			},
+		}
+	}
+	tablets := []*topodatapb.Tablet{
+		{
+			Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+			Keyspace: "testkeyspace",
+			Shard:    "-",
+		},
+		{
+			Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 101},
+			Keyspace: "testkeyspace",
+			Shard:    "-",
+		},
+		{
+			Alias:    &topodatapb.TabletAlias{Cell: "zone2", Uid: 102},
+			Keyspace: "testkeyspace",
+			Shard:    "-",
+		},
+	}
+
+	tests := []struct {
+		name      string
+		opts      EmergencyReparentOptions
+		expected  string
+		shouldErr bool
+	}{
+		{
+			name:     "most advanced position",
+			opts:     EmergencyReparentOptions{},
+			expected: "zone2-0000000102",
+		},
+		{
+			name: "allowed cells",
+			opts: EmergencyReparentOptions{
+				AllowedCells: sets.NewString("zone1"),
+			},
+			expected: "zone1-0000000101",
+		},
+		{
+			name: "max replication lag",
+			opts: EmergencyReparentOptions{
+				AllowedCells:      sets.NewString("zone1"),
+				MaxReplicationLag: 10 * time.Second,
+			},
+			expected: "zone1-0000000100",
+		},
+		{
+			name: "excluded tablets",
+			opts: EmergencyReparentOptions{
+				ExcludeTablets: sets.NewString("zone1-0000000101", "zone2-0000000102"),
+			},
+			expected: "zone1-0000000100",
+		},
+		{
+			name: "requested primary-elect is excluded",
+			opts: EmergencyReparentOptions{
+				NewPrimaryAlias: &topodatapb.TabletAlias{Cell: "zone2", Uid: 102},
+				AllowedCells:    sets.NewString("zone1"),
+			},
+			shouldErr: true,
+		},
+		{
+			name: "no candidate satisfies the constraints",
+			opts: EmergencyReparentOptions{
+				AllowedCells: sets.NewString("zone3"),
+			},
+			shouldErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			ts := memorytopo.NewServer("zone1", "zone2")
+			// The dry run only reads the replication statuses, any other
+			// call to the tablets fails.
+			tmc := &testutil.TabletManagerClient{
+				ReplicationStatusResults: map[string]struct {
+					Position *replicationdatapb.Status
+					Error    error
+				}{
+					"zone1-0000000100": status("1-21", 0),
+					"zone1-0000000101": status("1-23", 100),
+					"zone2-0000000102": status("1-26", 0),
+				},
+			}
+			testutil.AddShards(ctx, t, ts, &vtctldatapb.Shard{Keyspace: "testkeyspace", Name: "-"})
+			testutil.AddTablets(ctx, t, ts, nil, tablets...)
+
+			lctx, unlock, lerr := ts.LockShard(ctx, "testkeyspace", "-", "test lock")
+			require.NoError(t, lerr, "could not lock testkeyspace/- for testing")
+			defer unlock(&lerr)
+
+			ev := &events.Reparent{}
+			opts := tt.opts
+			opts.DryRun = true
+			opts.WaitReplicasTimeout = time.Minute
+			err := NewEmergencyReparenter(ts, tmc, logutil.NewMemoryLogger()).reparentShardLocked(lctx, ev, "testkeyspace", "-", opts)
+			if tt.shouldErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, topoproto.TabletAliasString(ev.NewMaster.Alias))
+		})
+	}
+}
+
 func TestEmergencyReparenter_promoteNewPrimary(t *testing.T) {
 	t.Parallel()
 
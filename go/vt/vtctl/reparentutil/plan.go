@@ -0,0 +1,369 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reparentutil
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/netutil"
+	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vttablet/tmclient"
+
+	replicationdatapb "vitess.io/vitess/go/vt/proto/replicationdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// ReparentPlanOptions provides the parameters of the reparent planned by
+// PlanReparent.
+type ReparentPlanOptions struct {
+	// Emergency plans an EmergencyReparentShard instead of a
+	// PlannedReparentShard.
+	Emergency           bool
+	NewPrimaryAlias     *topodatapb.TabletAlias
+	AvoidPrimaryAlias   *topodatapb.TabletAlias
+	WaitReplicasTimeout time.Duration
+}
+
+// ReparentCandidate is a tablet of a shard, ranked by how fit it is to be
+// promoted by a reparent.
+type ReparentCandidate struct {
+	Alias string `json:"alias"`
+	// Rank is 1 for the best candidate, and 0 for the ineligible ones.
+	Rank       int    `json:"rank"`
+	Position   string `json:"position,omitempty"`
+	LagSeconds uint32 `json:"lag_seconds"`
+	// Ineligible is why the tablet can't be promoted, if it can't.
+	Ineligible string `json:"ineligible,omitempty"`
+}
+
+// ReparentPlan describes what a reparent of a shard would do, for the
+// operator to review it before running it.
+type ReparentPlan struct {
+	Keyspace       string `json:"keyspace"`
+	Shard          string `json:"shard"`
+	Emergency      bool   `json:"emergency"`
+	CurrentPrimary string `json:"current_primary,omitempty"`
+	NewPrimary     string `json:"new_primary,omitempty"`
+	// Candidates are all the tablets of the shard, the eligible ones first
+	// by rank.
+	Candidates []*ReparentCandidate `json:"candidates"`
+	// Repointed are the tablets which would replicate from the new primary.
+	Repointed []string `json:"repointed"`
+	// ExpectedDowntime describes how long the shard wouldn't accept
+	// writes.
+	ExpectedDowntime string   `json:"expected_downtime,omitempty"`
+	Warnings         []string `json:"warnings,omitempty"`
+}
+
+// PlanReparent plans a reparent of the shard without changing anything: it
+// ranks the candidates for promotion, and describes which tablet would be
+// promoted, which tablets would be repointed to it and how long writes
+// would be unavailable. The shard is not locked, so the plan can be stale
+// by the time the reparent runs.
+func PlanReparent(ctx context.Context, ts *topo.Server, tmc tmclient.TabletManagerClient, keyspace, shard string, opts ReparentPlanOptions, logger logutil.Logger) (*ReparentPlan, error) {
+	shardInfo, err := ts.GetShard(ctx, keyspace, shard)
+	if err != nil {
+		return nil, err
+	}
+	tabletMap, err := ts.GetTabletMapForShard(ctx, keyspace, shard)
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "failed to get tablet map for %v/%v: %v", keyspace, shard, err)
+	}
+	statusMap, primaryStatusMap, err := ReadReplicationStatusMaps(ctx, tmc, tabletMap, opts.WaitReplicasTimeout, sets.NewString(), logger)
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "failed to read the replication statuses: %v", err)
+	}
+	return buildReparentPlan(shardInfo, tabletMap, statusMap, primaryStatusMap, opts), nil
+}
+
+// buildReparentPlan builds the plan of PlanReparent from the replication
+// statuses of the tablets.
+func buildReparentPlan(
+	shardInfo *topo.ShardInfo,
+	tabletMap map[string]*topo.TabletInfo,
+	statusMap map[string]*replicationdatapb.StopReplicationStatus,
+	primaryStatusMap map[string]*replicationdatapb.MasterStatus,
+	opts ReparentPlanOptions,
+) *ReparentPlan {
+	plan := &ReparentPlan{
+		Keyspace:  shardInfo.Keyspace(),
+		Shard:     shardInfo.ShardName(),
+		Emergency: opts.Emergency,
+		Repointed: []string{},
+	}
+	var primaryCell string
+	if shardInfo.MasterAlias != nil {
+		plan.CurrentPrimary = topoproto.TabletAliasString(shardInfo.MasterAlias)
+		primaryCell = shardInfo.MasterAlias.Cell
+	}
+
+	var emergencyCandidates map[string]mysql.Position
+	if opts.Emergency {
+		var err error
+		if emergencyCandidates, err = FindValidEmergencyReparentCandidates(statusMap, primaryStatusMap); err != nil {
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("cannot compare the positions of the tablets: %v", err))
+		}
+	}
+
+	aliases := make([]string, 0, len(tabletMap))
+	for alias := range tabletMap {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+
+	positions := make(map[string]mysql.Position)
+	for _, alias := range aliases {
+		tablet := tabletMap[alias]
+		candidate := &ReparentCandidate{Alias: alias}
+		plan.Candidates = append(plan.Candidates, candidate)
+
+		status, isReplica := statusMap[alias]
+		_, isPrimary := primaryStatusMap[alias]
+		if isReplica {
+			candidate.Position = status.After.Position
+			candidate.LagSeconds = status.After.SecondsBehindMaster
+		}
+		switch {
+		case !isReplica && !isPrimary:
+			candidate.Ineligible = "its replication status cannot be read"
+		case opts.AvoidPrimaryAlias != nil && topoproto.TabletAliasEqual(tablet.Alias, opts.AvoidPrimaryAlias):
+			candidate.Ineligible = "it is the tablet to avoid"
+		case opts.Emergency:
+			pos, ok := emergencyCandidates[alias]
+			if !ok {
+				candidate.Ineligible = "it has errant GTIDs or no relay log position"
+				continue
+			}
+			positions[alias] = pos
+			candidate.Position = mysql.EncodePosition(pos)
+		case isPrimary:
+			candidate.Ineligible = "it is the current primary"
+		case tablet.Type != topodatapb.TabletType_REPLICA:
+			candidate.Ineligible = fmt.Sprintf("it is a %v tablet", topoproto.TabletTypeLString(tablet.Type))
+		case primaryCell != "" && tablet.Alias.Cell != primaryCell:
+			candidate.Ineligible = fmt.Sprintf("it is not in the cell %v of the current primary", primaryCell)
+		default:
+			pos, err := mysql.DecodePosition(status.After.Position)
+			if err != nil {
+				candidate.Ineligible = fmt.Sprintf("its position cannot be decoded: %v", err)
+				continue
+			}
+			positions[alias] = pos
+		}
+	}
+
+	// The most advanced candidates are the best ones, then the least
+	// lagging ones.
+	sort.SliceStable(plan.Candidates, func(i, j int) bool {
+		ci, cj := plan.Candidates[i], plan.Candidates[j]
+		if (ci.Ineligible == "") != (cj.Ineligible == "") {
+			return ci.Ineligible == ""
+		}
+		if ci.Ineligible != "" {
+			return false
+		}
+		pi, pj := positions[ci.Alias], positions[cj.Alias]
+		if pi.AtLeast(pj) != pj.AtLeast(pi) {
+			return pi.AtLeast(pj)
+		}
+		return ci.LagSeconds < cj.LagSeconds
+	})
+	byAlias := make(map[string]*ReparentCandidate, len(plan.Candidates))
+	for i, candidate := range plan.Candidates {
+		if candidate.Ineligible == "" {
+			candidate.Rank = i + 1
+		}
+		byAlias[candidate.Alias] = candidate
+	}
+
+	switch {
+	case opts.NewPrimaryAlias != nil:
+		plan.NewPrimary = topoproto.TabletAliasString(opts.NewPrimaryAlias)
+		switch candidate := byAlias[plan.NewPrimary]; {
+		case candidate == nil:
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("the requested new primary %v is not in the shard", plan.NewPrimary))
+		case candidate.Ineligible != "":
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("the requested new primary %v cannot be promoted: %v", plan.NewPrimary, candidate.Ineligible))
+		case candidate.Rank > 1:
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("the requested new primary %v is not the best candidate %v", plan.NewPrimary, plan.Candidates[0].Alias))
+		}
+	case len(plan.Candidates) > 0 && plan.Candidates[0].Rank == 1:
+		plan.NewPrimary = plan.Candidates[0].Alias
+	default:
+		plan.Warnings = append(plan.Warnings, "no tablet can be promoted")
+	}
+
+	for _, alias := range aliases {
+		if alias == plan.NewPrimary {
+			continue
+		}
+		switch {
+		case alias == plan.CurrentPrimary && opts.Emergency:
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("the current primary %v is not repointed: it must be repaired and reparented with ReparentTablet", alias))
+		case alias == plan.CurrentPrimary, topo.IsReplicaType(tabletMap[alias].Type):
+			plan.Repointed = append(plan.Repointed, alias)
+		}
+	}
+
+	if candidate := byAlias[plan.NewPrimary]; candidate != nil && candidate.Ineligible == "" {
+		lag := time.Duration(candidate.LagSeconds) * time.Second
+		if opts.WaitReplicasTimeout > 0 && lag > opts.WaitReplicasTimeout {
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("the new primary lags %v, more than the wait replicas timeout %v: the reparent would likely time out", lag, opts.WaitReplicasTimeout))
+			lag = opts.WaitReplicasTimeout
+		}
+		if opts.Emergency {
+			plan.ExpectedDowntime = fmt.Sprintf("writes are unavailable since the primary failed, and for about %v more while the candidates apply their relay logs", lag)
+		} else {
+			plan.ExpectedDowntime = fmt.Sprintf("about %v, while the new primary catches up with the demoted primary", lag)
+		}
+	}
+	return plan
+}
+
+// TabletReplicationCheck is the replication state of a tablet after a
+// reparent.
+type TabletReplicationCheck struct {
+	Alias      string `json:"alias"`
+	Type       string `json:"type"`
+	Source     string `json:"source,omitempty"`
+	LagSeconds uint32 `json:"lag_seconds"`
+	Problem    string `json:"problem,omitempty"`
+}
+
+// ReparentVerification is the report of VerifyReparent.
+type ReparentVerification struct {
+	Keyspace string                    `json:"keyspace"`
+	Shard    string                    `json:"shard"`
+	Primary  string                    `json:"primary,omitempty"`
+	Tablets  []*TabletReplicationCheck `json:"tablets"`
+	// Healthy is true if no tablet has a problem.
+	Healthy bool `json:"healthy"`
+}
+
+// VerifyReparent checks that a shard is sound after a reparent: its shard
+// record points to a MASTER tablet, and its replicas replicate from it with
+// both replication threads running.
+func VerifyReparent(ctx context.Context, ts *topo.Server, tmc tmclient.TabletManagerClient, keyspace, shard string) (*ReparentVerification, error) {
+	shardInfo, err := ts.GetShard(ctx, keyspace, shard)
+	if err != nil {
+		return nil, err
+	}
+	tabletMap, err := ts.GetTabletMapForShard(ctx, keyspace, shard)
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "failed to get tablet map for %v/%v: %v", keyspace, shard, err)
+	}
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		statuses = make(map[string]*replicationdatapb.Status)
+		errs     = make(map[string]error)
+	)
+	for alias, tabletInfo := range tabletMap {
+		if !topo.IsReplicaType(tabletInfo.Type) {
+			continue
+		}
+		wg.Add(1)
+		go func(alias string, tablet *topodatapb.Tablet) {
+			defer wg.Done()
+			status, err := tmc.ReplicationStatus(ctx, tablet)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs[alias] = err
+				return
+			}
+			statuses[alias] = status
+		}(alias, tabletInfo.Tablet)
+	}
+	wg.Wait()
+	return verifyReparentedShard(shardInfo, tabletMap, statuses, errs), nil
+}
+
+// verifyReparentedShard builds the report of VerifyReparent from the
+// replication statuses of the replicas.
+func verifyReparentedShard(
+	shardInfo *topo.ShardInfo,
+	tabletMap map[string]*topo.TabletInfo,
+	statuses map[string]*replicationdatapb.Status,
+	errs map[string]error,
+) *ReparentVerification {
+	report := &ReparentVerification{
+		Keyspace: shardInfo.Keyspace(),
+		Shard:    shardInfo.ShardName(),
+		Tablets:  []*TabletReplicationCheck{},
+		Healthy:  true,
+	}
+	var primary *topo.TabletInfo
+	if shardInfo.MasterAlias != nil {
+		report.Primary = topoproto.TabletAliasString(shardInfo.MasterAlias)
+		primary = tabletMap[report.Primary]
+	}
+	if primary == nil {
+		report.Healthy = false
+	}
+
+	aliases := make([]string, 0, len(tabletMap))
+	for alias := range tabletMap {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+	for _, alias := range aliases {
+		tablet := tabletMap[alias]
+		check := &TabletReplicationCheck{
+			Alias: alias,
+			Type:  topoproto.TabletTypeLString(tablet.Type),
+		}
+		report.Tablets = append(report.Tablets, check)
+
+		status := statuses[alias]
+		if status != nil {
+			check.Source = netutil.JoinHostPort(status.MasterHost, status.MasterPort)
+			check.LagSeconds = status.SecondsBehindMaster
+		}
+		switch {
+		case alias == report.Primary && tablet.Type != topodatapb.TabletType_MASTER:
+			check.Problem = "the shard record points to it, but it is not a master tablet"
+		case alias == report.Primary:
+		case tablet.Type == topodatapb.TabletType_MASTER:
+			check.Problem = fmt.Sprintf("it is a master tablet, but the shard record points to %v", report.Primary)
+		case !topo.IsReplicaType(tablet.Type):
+		case errs[alias] != nil:
+			check.Problem = fmt.Sprintf("its replication status cannot be read: %v", errs[alias])
+		case status == nil:
+			check.Problem = "its replication status cannot be read"
+		case primary != nil && check.Source != topoproto.MysqlAddr(primary.Tablet):
+			check.Problem = fmt.Sprintf("it replicates from %v instead of the primary %v", check.Source, topoproto.MysqlAddr(primary.Tablet))
+		case !status.IoThreadRunning || !status.SqlThreadRunning:
+			check.Problem = "its replication is not running"
+		}
+		if check.Problem != "" {
+			report.Healthy = false
+		}
+	}
+	return report
+}
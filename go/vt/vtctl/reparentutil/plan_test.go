@@ -0,0 +1,131 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reparentutil
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+
+	replicationdatapb "vitess.io/vitess/go/vt/proto/replicationdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func planTestShard(t *testing.T) (*topo.ShardInfo, map[string]*topo.TabletInfo) {
+	t.Helper()
+
+	tabletMap := make(map[string]*topo.TabletInfo)
+	for _, tablet := range []*topodatapb.Tablet{
+		{Alias: &topodatapb.TabletAlias{Cell: "zone1", Uid: 100}, Type: topodatapb.TabletType_MASTER, MysqlHostname: "h100", MysqlPort: 3306},
+		{Alias: &topodatapb.TabletAlias{Cell: "zone1", Uid: 101}, Type: topodatapb.TabletType_REPLICA},
+		{Alias: &topodatapb.TabletAlias{Cell: "zone1", Uid: 102}, Type: topodatapb.TabletType_REPLICA},
+		{Alias: &topodatapb.TabletAlias{Cell: "zone1", Uid: 103}, Type: topodatapb.TabletType_RDONLY},
+		{Alias: &topodatapb.TabletAlias{Cell: "zone2", Uid: 200}, Type: topodatapb.TabletType_REPLICA},
+	} {
+		tabletMap[topoproto.TabletAliasString(tablet.Alias)] = &topo.TabletInfo{Tablet: tablet}
+	}
+	shardInfo := topo.NewShardInfo("ks", "-", &topodatapb.Shard{
+		MasterAlias: &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+	}, nil)
+	return shardInfo, tabletMap
+}
+
+func TestBuildReparentPlan(t *testing.T) {
+	t.Parallel()
+
+	shardInfo, tabletMap := planTestShard(t)
+	replicaStatus := func(position string, lag uint32) *replicationdatapb.StopReplicationStatus {
+		status := &replicationdatapb.Status{Position: position, SecondsBehindMaster: lag}
+		return &replicationdatapb.StopReplicationStatus{Before: status, After: status}
+	}
+	statusMap := map[string]*replicationdatapb.StopReplicationStatus{
+		"zone1-0000000101": replicaStatus("MySQL56/3E11FA47-71CA-11E1-9E33-C80AA9429562:1-5", 2),
+		"zone1-0000000102": replicaStatus("MySQL56/3E11FA47-71CA-11E1-9E33-C80AA9429562:1-7", 0),
+		"zone1-0000000103": replicaStatus("MySQL56/3E11FA47-71CA-11E1-9E33-C80AA9429562:1-7", 0),
+		"zone2-0000000200": replicaStatus("MySQL56/3E11FA47-71CA-11E1-9E33-C80AA9429562:1-7", 0),
+	}
+	primaryStatusMap := map[string]*replicationdatapb.MasterStatus{
+		"zone1-0000000100": {Position: "MySQL56/3E11FA47-71CA-11E1-9E33-C80AA9429562:1-7"},
+	}
+
+	plan := buildReparentPlan(shardInfo, tabletMap, statusMap, primaryStatusMap, ReparentPlanOptions{WaitReplicasTimeout: time.Second})
+	assert.Equal(t, "zone1-0000000100", plan.CurrentPrimary)
+	assert.Equal(t, "zone1-0000000102", plan.NewPrimary)
+	require.Len(t, plan.Candidates, 5)
+	assert.Equal(t, "zone1-0000000102", plan.Candidates[0].Alias)
+	assert.Equal(t, 1, plan.Candidates[0].Rank)
+	assert.Equal(t, "zone1-0000000101", plan.Candidates[1].Alias)
+	assert.Equal(t, 2, plan.Candidates[1].Rank)
+	for _, candidate := range plan.Candidates[2:] {
+		assert.Zero(t, candidate.Rank)
+		assert.NotEmpty(t, candidate.Ineligible, candidate.Alias)
+	}
+	assert.Equal(t, []string{"zone1-0000000100", "zone1-0000000101", "zone1-0000000103", "zone2-0000000200"}, plan.Repointed)
+	assert.Equal(t, "about 0s, while the new primary catches up with the demoted primary", plan.ExpectedDowntime)
+	assert.Empty(t, plan.Warnings)
+
+	// A requested new primary which lags is used, with warnings.
+	plan = buildReparentPlan(shardInfo, tabletMap, statusMap, primaryStatusMap, ReparentPlanOptions{
+		NewPrimaryAlias:     &topodatapb.TabletAlias{Cell: "zone1", Uid: 101},
+		WaitReplicasTimeout: time.Second,
+	})
+	assert.Equal(t, "zone1-0000000101", plan.NewPrimary)
+	assert.Equal(t, "about 1s, while the new primary catches up with the demoted primary", plan.ExpectedDowntime)
+	assert.Len(t, plan.Warnings, 2)
+
+	// Without a reachable replica, no tablet can be promoted.
+	plan = buildReparentPlan(shardInfo, tabletMap, nil, primaryStatusMap, ReparentPlanOptions{})
+	assert.Empty(t, plan.NewPrimary)
+	assert.Equal(t, []string{"no tablet can be promoted"}, plan.Warnings)
+}
+
+func TestVerifyReparentedShard(t *testing.T) {
+	t.Parallel()
+
+	shardInfo, tabletMap := planTestShard(t)
+	running := func(host string) *replicationdatapb.Status {
+		return &replicationdatapb.Status{MasterHost: host, MasterPort: 3306, IoThreadRunning: true, SqlThreadRunning: true}
+	}
+	statuses := map[string]*replicationdatapb.Status{
+		"zone1-0000000101": running("h100"),
+		"zone1-0000000102": running("h100"),
+		"zone1-0000000103": running("h100"),
+		"zone2-0000000200": running("h100"),
+	}
+
+	report := verifyReparentedShard(shardInfo, tabletMap, statuses, nil)
+	assert.True(t, report.Healthy)
+	assert.Equal(t, "zone1-0000000100", report.Primary)
+	require.Len(t, report.Tablets, 5)
+	assert.Equal(t, "h100:3306", report.Tablets[1].Source)
+
+	statuses["zone1-0000000102"] = running("h999")
+	statuses["zone1-0000000103"].SqlThreadRunning = false
+	delete(statuses, "zone2-0000000200")
+	report = verifyReparentedShard(shardInfo, tabletMap, statuses, map[string]error{"zone2-0000000200": errors.New("unreachable")})
+	assert.False(t, report.Healthy)
+	assert.Empty(t, report.Tablets[1].Problem)
+	assert.Equal(t, "it replicates from h999:3306 instead of the primary h100:3306", report.Tablets[2].Problem)
+	assert.Equal(t, "its replication is not running", report.Tablets[3].Problem)
+	assert.Equal(t, "its replication status cannot be read: unreachable", report.Tablets[4].Problem)
+}
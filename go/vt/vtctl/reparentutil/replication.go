@@ -228,6 +228,80 @@ func StopReplicationAndBuildStatusMaps(
 	return statusMap, masterStatusMap, nil
 }
 
+// ReadReplicationStatusMaps is the read-only counterpart of
+// StopReplicationAndBuildStatusMaps, used by the dry runs: it reads the
+// replication status of the replicas without stopping replication, and the
+// status of the tablets which think they are the primary without demoting
+// them. The statuses of the replicas have the same Before and After
+// status.
+func ReadReplicationStatusMaps(
+	ctx context.Context,
+	tmc tmclient.TabletManagerClient,
+	tabletMap map[string]*topo.TabletInfo,
+	waitReplicasTimeout time.Duration,
+	ignoredTablets sets.String,
+	logger logutil.Logger,
+) (map[string]*replicationdatapb.StopReplicationStatus, map[string]*replicationdatapb.MasterStatus, error) {
+	var (
+		statusMap       = map[string]*replicationdatapb.StopReplicationStatus{}
+		masterStatusMap = map[string]*replicationdatapb.MasterStatus{}
+		m               sync.Mutex
+		errChan         = make(chan error)
+	)
+
+	groupCtx, groupCancel := context.WithTimeout(ctx, waitReplicasTimeout)
+	defer groupCancel()
+
+	readStatus := func(alias string, tabletInfo *topo.TabletInfo) {
+		err := vterrors.Errorf(vtrpc.Code_UNAVAILABLE, "readStatus did not successfully complete")
+		defer func() { errChan <- err }()
+
+		status, err := tmc.ReplicationStatus(groupCtx, tabletInfo.Tablet)
+		switch err {
+		case mysql.ErrNotReplica:
+			var masterStatus *replicationdatapb.MasterStatus
+
+			masterStatus, err = tmc.MasterStatus(groupCtx, tabletInfo.Tablet)
+			if err != nil {
+				logger.Warningf("failed to get master status from %v: %v", alias, err)
+				err = vterrors.Wrapf(err, "error when getting master status for alias %v: %v", alias, err)
+				return
+			}
+
+			m.Lock()
+			masterStatusMap[alias] = masterStatus
+			m.Unlock()
+		case nil:
+			m.Lock()
+			statusMap[alias] = &replicationdatapb.StopReplicationStatus{Before: status, After: status}
+			m.Unlock()
+		default:
+			logger.Warningf("failed to get replication status from %v: %v", alias, err)
+
+			err = vterrors.Wrapf(err, "error when getting replication status for alias %v: %v", alias, err)
+		}
+	}
+
+	for alias, tabletInfo := range tabletMap {
+		if !ignoredTablets.Has(alias) {
+			go readStatus(alias, tabletInfo)
+		}
+	}
+
+	errgroup := concurrency.ErrorGroup{
+		NumGoroutines:        len(tabletMap) - ignoredTablets.Len(),
+		NumRequiredSuccesses: len(tabletMap) - ignoredTablets.Len() - 1,
+		NumAllowedErrors:     1,
+	}
+
+	errRecorder := errgroup.Wait(groupCancel, errChan)
+	if len(errRecorder.Errors) > 1 {
+		return nil, nil, vterrors.Wrapf(errRecorder.Error(), "encountered more than one error when trying to get positions: %v", errRecorder.Error())
+	}
+
+	return statusMap, masterStatusMap, nil
+}
+
 // WaitForRelayLogsToApply blocks execution waiting for the given tablet's relay
 // logs to apply, unless the specified context is canceled or exceeded.
 // Typically a caller will set a timeout of WaitReplicasTimeout on a context and
@@ -0,0 +1,162 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package replicationutil reads the live MySQL replication topology of the
+// shards, for vtctl and the VtctldServer RPCs.
+package replicationutil
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/netutil"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vttablet/tmclient"
+
+	replicationdatapb "vitess.io/vitess/go/vt/proto/replicationdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+// GetShardReplicationGraph asks each tablet of the shard for its
+// replication status, and returns who replicates from whom. The tablets
+// which don't replicate from the master of the shard in the topo, or whose
+// replication is stopped, are reported as mismatches.
+func GetShardReplicationGraph(ctx context.Context, ts *topo.Server, tmc tmclient.TabletManagerClient, keyspace, shard string) (*vtctldatapb.ShardReplicationGraph, error) {
+	si, err := ts.GetShard(ctx, keyspace, shard)
+	if err != nil {
+		return nil, err
+	}
+	tabletMap, err := ts.GetTabletMapForShard(ctx, keyspace, shard)
+	if err != nil {
+		return nil, fmt.Errorf("GetTabletMapForShard(%v, %v) failed: %v", keyspace, shard, err)
+	}
+
+	var tablets []*topodatapb.Tablet
+	for _, ti := range tabletMap {
+		tablets = append(tablets, ti.Tablet)
+	}
+	statuses := make([]*replicationdatapb.Status, len(tablets))
+	errs := make([]error, len(tablets))
+	var wg sync.WaitGroup
+	for i, tablet := range tablets {
+		wg.Add(1)
+		go func(i int, tablet *topodatapb.Tablet) {
+			defer wg.Done()
+			status, err := tmc.ReplicationStatus(ctx, tablet)
+			if err != nil && strings.Contains(err.Error(), mysql.ErrNotReplica.Error()) {
+				// Replication isn't configured, e.g. on a master.
+				status, err = &replicationdatapb.Status{}, nil
+			}
+			statuses[i], errs[i] = status, err
+		}(i, tablet)
+	}
+	wg.Wait()
+	return buildShardReplicationGraph(si, tablets, statuses, errs), nil
+}
+
+// buildShardReplicationGraph builds the replication graph of the shard
+// from the replication status of each tablet, or the error returned
+// instead.
+func buildShardReplicationGraph(si *topo.ShardInfo, tablets []*topodatapb.Tablet, statuses []*replicationdatapb.Status, errs []error) *vtctldatapb.ShardReplicationGraph {
+	graph := &vtctldatapb.ShardReplicationGraph{
+		Keyspace: si.Keyspace(),
+		Shard:    si.ShardName(),
+		Primary:  si.MasterAlias,
+	}
+	masterAlias := ""
+	if si.MasterAlias != nil {
+		masterAlias = topoproto.TabletAliasString(si.MasterAlias)
+	}
+	aliasByMysqlAddr := make(map[string]string)
+	masterMysqlAddr := ""
+	for _, tablet := range tablets {
+		alias := topoproto.TabletAliasString(tablet.Alias)
+		aliasByMysqlAddr[topoproto.MysqlAddr(tablet)] = alias
+		if alias == masterAlias {
+			masterMysqlAddr = topoproto.MysqlAddr(tablet)
+		}
+	}
+
+	for i, tablet := range tablets {
+		node := &vtctldatapb.ShardReplicationGraph_Tablet{
+			Alias:      tablet.Alias,
+			TabletType: tablet.Type,
+			MysqlAddr:  topoproto.MysqlAddr(tablet),
+		}
+		graph.Tablets = append(graph.Tablets, node)
+		isMaster := topoproto.TabletAliasEqual(tablet.Alias, si.MasterAlias)
+		if tablet.Type == topodatapb.TabletType_MASTER && !isMaster {
+			node.Mismatches = append(node.Mismatches, fmt.Sprintf("tablet type is master, but the master of the shard is %v", masterAlias))
+		}
+		if errs[i] != nil {
+			node.ReplicationStatusError = errs[i].Error()
+			node.Mismatches = append(node.Mismatches, "cannot get the replication status")
+			continue
+		}
+
+		status := statuses[i]
+		node.IoThreadRunning = status.IoThreadRunning
+		node.SqlThreadRunning = status.SqlThreadRunning
+		node.SecondsBehindMaster = status.SecondsBehindMaster
+		sourceAddr := ""
+		if status.MasterHost != "" {
+			sourceAddr = netutil.JoinHostPort(status.MasterHost, status.MasterPort)
+			node.ReplicatesFrom = sourceAddr
+			if alias, ok := aliasByMysqlAddr[sourceAddr]; ok {
+				node.ReplicatesFrom = alias
+			}
+		}
+
+		switch {
+		case isMaster:
+			if node.IoThreadRunning || node.SqlThreadRunning {
+				node.Mismatches = append(node.Mismatches, fmt.Sprintf("master replicates from %v", node.ReplicatesFrom))
+			}
+		case !topo.IsReplicaType(tablet.Type):
+			// e.g. a tablet being restored, or not initialized yet.
+		case sourceAddr == "":
+			node.Mismatches = append(node.Mismatches, "replication is not configured")
+		default:
+			if masterMysqlAddr != "" && sourceAddr != masterMysqlAddr {
+				node.Mismatches = append(node.Mismatches, fmt.Sprintf("replicates from %v instead of the master %v", node.ReplicatesFrom, masterAlias))
+			}
+			if tablet.Type == topodatapb.TabletType_REPLICA || tablet.Type == topodatapb.TabletType_RDONLY {
+				if !node.IoThreadRunning {
+					node.Mismatches = append(node.Mismatches, "IO thread is not running")
+				}
+				if !node.SqlThreadRunning {
+					node.Mismatches = append(node.Mismatches, "SQL thread is not running")
+				}
+			}
+		}
+	}
+
+	sort.Slice(graph.Tablets, func(i, j int) bool {
+		return topoproto.TabletAliasString(graph.Tablets[i].Alias) < topoproto.TabletAliasString(graph.Tablets[j].Alias)
+	})
+	for _, node := range graph.Tablets {
+		for _, mismatch := range node.Mismatches {
+			graph.Mismatches = append(graph.Mismatches, fmt.Sprintf("%v: %v", topoproto.TabletAliasString(node.Alias), mismatch))
+		}
+	}
+	return graph
+}
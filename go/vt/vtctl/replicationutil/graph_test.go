@@ -0,0 +1,93 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replicationutil
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+
+	replicationdatapb "vitess.io/vitess/go/vt/proto/replicationdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func TestBuildShardReplicationGraph(t *testing.T) {
+	newTablet := func(uid uint32, tabletType topodatapb.TabletType) *topodatapb.Tablet {
+		return &topodatapb.Tablet{
+			Alias:         &topodatapb.TabletAlias{Cell: "cell1", Uid: uid},
+			Type:          tabletType,
+			MysqlHostname: fmt.Sprintf("host%d", uid),
+			MysqlPort:     3306,
+		}
+	}
+	replicating := func(host string) *replicationdatapb.Status {
+		return &replicationdatapb.Status{
+			MasterHost:          host,
+			MasterPort:          3306,
+			IoThreadRunning:     true,
+			SqlThreadRunning:    true,
+			SecondsBehindMaster: 1,
+		}
+	}
+	si := topo.NewShardInfo("ks", "0", &topodatapb.Shard{
+		MasterAlias: &topodatapb.TabletAlias{Cell: "cell1", Uid: 100},
+	}, nil)
+	tablets := []*topodatapb.Tablet{
+		newTablet(104, topodatapb.TabletType_RDONLY),
+		newTablet(100, topodatapb.TabletType_MASTER),
+		newTablet(101, topodatapb.TabletType_REPLICA),
+		newTablet(102, topodatapb.TabletType_REPLICA),
+		newTablet(103, topodatapb.TabletType_RDONLY),
+		newTablet(105, topodatapb.TabletType_BACKUP),
+	}
+	stopped := replicating("host100")
+	stopped.SqlThreadRunning = false
+	statuses := []*replicationdatapb.Status{
+		nil,
+		{},
+		replicating("host100"),
+		replicating("host101"),
+		stopped,
+		{},
+	}
+	errs := []error{fmt.Errorf("tablet unreachable"), nil, nil, nil, nil, nil}
+
+	graph := buildShardReplicationGraph(si, tablets, statuses, errs)
+	assert.Equal(t, "cell1-0000000100", topoproto.TabletAliasString(graph.Primary))
+	var replicatesFrom []string
+	for _, node := range graph.Tablets {
+		replicatesFrom = append(replicatesFrom, topoproto.TabletAliasString(node.Alias)+"<"+node.ReplicatesFrom)
+	}
+	assert.Equal(t, []string{
+		"cell1-0000000100<",
+		"cell1-0000000101<cell1-0000000100",
+		"cell1-0000000102<cell1-0000000101",
+		"cell1-0000000103<cell1-0000000100",
+		"cell1-0000000104<",
+		"cell1-0000000105<",
+	}, replicatesFrom)
+	assert.Equal(t, "tablet unreachable", graph.Tablets[4].ReplicationStatusError)
+	assert.Equal(t, []string{
+		"cell1-0000000102: replicates from cell1-0000000101 instead of the master cell1-0000000100",
+		"cell1-0000000103: SQL thread is not running",
+		"cell1-0000000104: cannot get the replication status",
+	}, graph.Mismatches)
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtctl
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+
+	"vitess.io/vitess/go/flagutil"
+	"vitess.io/vitess/go/json2"
+	"vitess.io/vitess/go/vt/wrangler"
+
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+// This file contains the commands to manage the routing rules safely:
+// they are validated, updated with compare-and-swap semantics, and the
+// update of several tables is atomic.
+
+func init() {
+	addCommand("Schema, Version, Permissions", command{
+		"GetRoutingRulesWithVersion",
+		commandGetRoutingRulesWithVersion,
+		"",
+		"Displays the VSchema routing rules, with their version to use with ApplyRoutingRulesBatch -expected_version."})
+	addCommand("Schema, Version, Permissions", command{
+		"ValidateRoutingRules",
+		commandValidateRoutingRules,
+		"{-rules=<rules> || -rules_file=<rules_file>}",
+		"Validates the VSchema routing rules against the current VSchemas, without applying them."})
+	addCommand("Schema, Version, Permissions", command{
+		"ApplyRoutingRulesBatch",
+		commandApplyRoutingRulesBatch,
+		"{-rules=<rules> || -rules_file=<rules_file>} [-delete=<table1>,<table2>,...] [-replace] [-expected_version=<version>] [-dry_run] [-vtgates=<host:port>,...] [-cells=c1,c2,...] [-skip_rebuild]",
+		"Atomically sets the routing rules of the tables in the given rules, and deletes the ones of the -delete tables, or replaces all the rules with -replace. The rules are validated, and only saved if they are at -expected_version, or didn't change since they were read. Displays the changes of the rules, and with -vtgates, the queries in the plan cache of the vtgates whose routing changes."})
+}
+
+func commandGetRoutingRulesWithVersion(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 0 {
+		return fmt.Errorf("GetRoutingRulesWithVersion doesn't take any arguments")
+	}
+
+	rr, version, err := wr.TopoServer().GetRoutingRulesWithVersion(ctx)
+	if err != nil {
+		return err
+	}
+	data, err := json2.MarshalPB(rr)
+	if err != nil {
+		return err
+	}
+	result := struct {
+		Version      string
+		RoutingRules json.RawMessage
+	}{
+		RoutingRules: data,
+	}
+	if version != nil {
+		result.Version = version.String()
+	}
+	return printJSON(wr.Logger(), result)
+}
+
+func readRoutingRules(rules, rulesFile string) (*vschemapb.RoutingRules, error) {
+	var rulesBytes []byte
+	switch {
+	case rules != "" && rulesFile != "":
+		return nil, fmt.Errorf("only one of -rules and -rules_file can be specified")
+	case rulesFile != "":
+		var err error
+		rulesBytes, err = ioutil.ReadFile(rulesFile)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		rulesBytes = []byte(rules)
+	}
+	rr := &vschemapb.RoutingRules{}
+	if len(rulesBytes) == 0 {
+		return rr, nil
+	}
+	if err := json2.Unmarshal(rulesBytes, rr); err != nil {
+		return nil, err
+	}
+	return rr, nil
+}
+
+func commandValidateRoutingRules(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	routingRules := subFlags.String("rules", "", "Specify rules as a string")
+	routingRulesFile := subFlags.String("rules_file", "", "Specify rules in a file")
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 0 {
+		return fmt.Errorf("ValidateRoutingRules doesn't take any arguments")
+	}
+
+	rr, err := readRoutingRules(*routingRules, *routingRulesFile)
+	if err != nil {
+		return err
+	}
+	resp, err := wr.VtctldServer().ValidateRoutingRules(ctx, &vtctldatapb.ValidateRoutingRulesRequest{
+		RoutingRules: rr,
+	})
+	if err != nil {
+		return err
+	}
+	if resp.Error != "" {
+		return fmt.Errorf("invalid routing rules: %v", resp.Error)
+	}
+	wr.Logger().Printf("The routing rules are valid.\n")
+	return nil
+}
+
+func commandApplyRoutingRulesBatch(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	routingRules := subFlags.String("rules", "", "Specify the rules to set as a string")
+	routingRulesFile := subFlags.String("rules_file", "", "Specify the rules to set in a file")
+	replace := subFlags.Bool("replace", false, "Replaces all the routing rules with the given ones")
+	expectedVersion := subFlags.String("expected_version", "", "Only applies the rules if the current ones are at this version, as displayed by GetRoutingRulesWithVersion")
+	dryRun := subFlags.Bool("dry_run", false, "Validates the rules and displays the changes, without applying them")
+	skipRebuild := subFlags.Bool("skip_rebuild", false, "If set, do no rebuild the SrvSchema objects.")
+	var deletes, vtgates, cells flagutil.StringListValue
+	subFlags.Var(&deletes, "delete", "Tables whose routing rules are deleted")
+	subFlags.Var(&vtgates, "vtgates", "Host:port of the vtgates whose plan cache is checked for the queries whose routing changes")
+	subFlags.Var(&cells, "cells", "If specified, limits the rebuild to the cells, after upload. Ignored if skipRebuild is set.")
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 0 {
+		return fmt.Errorf("ApplyRoutingRulesBatch doesn't take any arguments")
+	}
+
+	rr, err := readRoutingRules(*routingRules, *routingRulesFile)
+	if err != nil {
+		return err
+	}
+	resp, err := wr.VtctldServer().ApplyRoutingRulesBatch(ctx, &vtctldatapb.ApplyRoutingRulesBatchRequest{
+		Rules:           rr.Rules,
+		DeleteTables:    deletes,
+		Replace:         *replace,
+		ExpectedVersion: *expectedVersion,
+		DryRun:          *dryRun,
+		Vtgates:         vtgates,
+		SkipRebuild:     *skipRebuild,
+		RebuildCells:    cells,
+	})
+	if err != nil {
+		return err
+	}
+	return printJSON(wr.Logger(), resp)
+}
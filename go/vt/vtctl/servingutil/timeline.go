@@ -0,0 +1,133 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package servingutil reads the transitions of the tablets between serving
+// and not serving, for vtctl and the VtctldServer RPCs.
+package servingutil
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+// EventsGetter returns the serving state transitions recorded by a tablet.
+type EventsGetter func(ctx context.Context, tablet *topodatapb.Tablet) ([]*vtctldatapb.ServingEvent, error)
+
+// GetServingEventsFromTablet returns the serving state transitions of the
+// tablet, read from its /debug/serving_events page.
+func GetServingEventsFromTablet(ctx context.Context, tablet *topodatapb.Tablet) ([]*vtctldatapb.ServingEvent, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+topo.NewTabletInfo(tablet, nil).Addr()+"/debug/serving_events", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %v: %s", resp.Status, body)
+	}
+
+	var events []struct {
+		Time       time.Time `json:"time"`
+		TabletType string    `json:"tablet_type"`
+		Serving    bool      `json:"serving"`
+		Reason     string    `json:"reason"`
+		Details    string    `json:"details"`
+	}
+	if err := json.Unmarshal(body, &events); err != nil {
+		return nil, err
+	}
+	result := make([]*vtctldatapb.ServingEvent, len(events))
+	for i, event := range events {
+		tabletType, err := topoproto.ParseTabletType(event.TabletType)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = &vtctldatapb.ServingEvent{
+			Tablet:     tablet.Alias,
+			Time:       logutil.TimeToProto(event.Time),
+			TabletType: tabletType,
+			Serving:    event.Serving,
+			Reason:     event.Reason,
+			Details:    event.Details,
+		}
+	}
+	return result, nil
+}
+
+// ShardServingTimeline returns the serving state transitions of all the
+// tablets of a shard, oldest first, and the errors of the tablets whose
+// transitions can't be read, by tablet alias. These tablets are skipped,
+// since they may be the ones the timeline is looked at for.
+func ShardServingTimeline(ctx context.Context, ts *topo.Server, getEvents EventsGetter, keyspace, shard string) ([]*vtctldatapb.ServingEvent, map[string]string, error) {
+	tabletMap, err := ts.GetTabletMapForShard(ctx, keyspace, shard)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		mu           sync.Mutex
+		wg           sync.WaitGroup
+		events       []*vtctldatapb.ServingEvent
+		tabletErrors = make(map[string]string)
+	)
+	for alias, ti := range tabletMap {
+		wg.Add(1)
+		go func(alias string, tablet *topodatapb.Tablet) {
+			defer wg.Done()
+			tabletEvents, err := getEvents(ctx, tablet)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				tabletErrors[alias] = err.Error()
+				return
+			}
+			for _, event := range tabletEvents {
+				event.Tablet = tablet.Alias
+			}
+			events = append(events, tabletEvents...)
+		}(alias, ti.Tablet)
+	}
+	wg.Wait()
+
+	sort.SliceStable(events, func(i, j int) bool {
+		ti, tj := logutil.ProtoToTime(events[i].Time), logutil.ProtoToTime(events[j].Time)
+		if !ti.Equal(tj) {
+			return ti.Before(tj)
+		}
+		return topoproto.TabletAliasString(events[i].Tablet) < topoproto.TabletAliasString(events[j].Tablet)
+	})
+	return events, tabletErrors, nil
+}
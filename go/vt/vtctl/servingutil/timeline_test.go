@@ -0,0 +1,76 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servingutil
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+func TestShardServingTimeline(t *testing.T) {
+	ctx := context.Background()
+	ts := memorytopo.NewServer("cell1")
+	require.NoError(t, ts.CreateKeyspace(ctx, "ks", &topodatapb.Keyspace{}))
+	require.NoError(t, ts.CreateShard(ctx, "ks", "0"))
+	for uid := uint32(1); uid <= 3; uid++ {
+		require.NoError(t, ts.CreateTablet(ctx, &topodatapb.Tablet{
+			Alias:    &topodatapb.TabletAlias{Cell: "cell1", Uid: uid},
+			Keyspace: "ks",
+			Shard:    "0",
+			Type:     topodatapb.TabletType_REPLICA,
+		}))
+	}
+
+	start := time.Now()
+	getEvents := func(ctx context.Context, tablet *topodatapb.Tablet) ([]*vtctldatapb.ServingEvent, error) {
+		switch tablet.Alias.Uid {
+		case 1:
+			return []*vtctldatapb.ServingEvent{
+				{Time: logutil.TimeToProto(start), Serving: true, Reason: "operator"},
+				{Time: logutil.TimeToProto(start.Add(2 * time.Second)), Serving: false, Reason: "lag"},
+			}, nil
+		case 2:
+			return []*vtctldatapb.ServingEvent{
+				{Time: logutil.TimeToProto(start.Add(time.Second)), Serving: false, Reason: "replication_stopped"},
+			}, nil
+		}
+		return nil, fmt.Errorf("connection refused")
+	}
+
+	events, tabletErrors, err := ShardServingTimeline(ctx, ts, getEvents, "ks", "0")
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	assert.Equal(t, "cell1-0000000001", topoproto.TabletAliasString(events[0].Tablet))
+	assert.Equal(t, "operator", events[0].Reason)
+	assert.Equal(t, "cell1-0000000002", topoproto.TabletAliasString(events[1].Tablet))
+	assert.Equal(t, "replication_stopped", events[1].Reason)
+	assert.Equal(t, "cell1-0000000001", topoproto.TabletAliasString(events[2].Tablet))
+	assert.Equal(t, "lag", events[2].Reason)
+	assert.Equal(t, map[string]string{"cell1-0000000003": "connection refused"}, tabletErrors)
+}
@@ -0,0 +1,307 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tablegcutil controls the lifecycle of the tables garbage collected
+// by the table GC of the masters, for vtctl and the VtctldServer RPCs: a
+// dropped table is renamed to a HOLD table, which can be renamed back, then
+// to a PURGE table whose rows are deleted, an EVAC table, and a DROP table
+// which is finally dropped. The name of a GC table holds the time at which
+// it moves to its next state, so its retention is changed by renaming it.
+package tablegcutil
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"vitess.io/vitess/go/sqlescape"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/schema"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/vttablet/tmclient"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+const sqlShowGCTables = `show tables like '\_vt\_%'`
+
+// GCTable is a table garbage collected by the table GC of a master.
+type GCTable struct {
+	Shard  string
+	Tablet *topodatapb.TabletAlias
+	Table  string
+	State  schema.TableGCState
+	UUID   string
+	// DueTime is the time at which the table GC moves the table to its next
+	// state.
+	DueTime time.Time
+}
+
+// ListGCTables returns the GC tables of the masters of the keyspace, by
+// state.
+func ListGCTables(ctx context.Context, ts *topo.Server, tmc tmclient.TabletManagerClient, keyspace string) (map[schema.TableGCState][]*GCTable, error) {
+	tables, err := listGCTables(ctx, ts, tmc, keyspace)
+	if err != nil {
+		return nil, err
+	}
+	return groupGCTablesByState(tables), nil
+}
+
+// SetGCTablesRetention sets the time at which the GC tables of the keyspace
+// in the given state move to their next state to now plus the retention. If
+// uuids are given, only the tables with these UUIDs are changed. Only the
+// HOLD and EVAC states have a retention. The retention of the tables which
+// are dropped later is set by the flags of the tablets.
+func SetGCTablesRetention(ctx context.Context, ts *topo.Server, tmc tmclient.TabletManagerClient, keyspace string, state schema.TableGCState, uuids []string, retention time.Duration) ([]*GCTable, error) {
+	if state != schema.HoldTableGCState && state != schema.EvacTableGCState {
+		return nil, fmt.Errorf("only the %v and %v states have a retention, not %v", schema.HoldTableGCState, schema.EvacTableGCState, state)
+	}
+	if retention < 0 {
+		return nil, fmt.Errorf("negative retention: %v", retention)
+	}
+	selected := make(map[string]bool)
+	for _, uuid := range uuids {
+		gcUUID, err := parseGCUUID(uuid)
+		if err != nil {
+			return nil, err
+		}
+		selected[gcUUID] = true
+	}
+	tables, err := listGCTables(ctx, ts, tmc, keyspace)
+	if err != nil {
+		return nil, err
+	}
+	var retimed []*GCTable
+	for _, table := range tables {
+		if table.State == state && (len(selected) == 0 || selected[table.UUID]) {
+			retimed = append(retimed, table)
+		}
+	}
+	return retimeGCTables(ctx, ts, tmc, retimed, time.Now().UTC().Add(retention))
+}
+
+// AdvanceGCTable moves the GC tables of the keyspace with the given UUID to
+// their next state, the next time the table GC checks its tables. The tables
+// must be in the HOLD or EVAC state, as the PURGE tables move once their rows
+// are deleted and the DROP tables are already dropped as soon as possible.
+func AdvanceGCTable(ctx context.Context, ts *topo.Server, tmc tmclient.TabletManagerClient, keyspace, uuid string) ([]*GCTable, error) {
+	tables, err := gcTablesOfUUID(ctx, ts, tmc, keyspace, uuid)
+	if err != nil {
+		return nil, err
+	}
+	for _, table := range tables {
+		if table.State != schema.HoldTableGCState && table.State != schema.EvacTableGCState {
+			return nil, fmt.Errorf("table %v on shard %v is in the %v state, only the %v and %v tables can be advanced", table.Table, table.Shard, table.State, schema.HoldTableGCState, schema.EvacTableGCState)
+		}
+	}
+	return retimeGCTables(ctx, ts, tmc, tables, time.Now().UTC())
+}
+
+// RestoreGCTable renames the HOLD tables of the keyspace with the given UUID
+// back to toTable. If toTable is empty, the tables are renamed back to the
+// table of the DROP migration with this UUID.
+func RestoreGCTable(ctx context.Context, ts *topo.Server, tmc tmclient.TabletManagerClient, keyspace, uuid, toTable string) ([]*GCTable, error) {
+	tables, err := gcTablesOfUUID(ctx, ts, tmc, keyspace, uuid)
+	if err != nil {
+		return nil, err
+	}
+	shards := make(map[string]bool)
+	for _, table := range tables {
+		if table.State != schema.HoldTableGCState {
+			return nil, fmt.Errorf("table %v on shard %v is in the %v state, only the %v tables can be restored", table.Table, table.Shard, table.State, schema.HoldTableGCState)
+		}
+		if shards[table.Shard] {
+			return nil, fmt.Errorf("shard %v has more than one table with UUID %v", table.Shard, table.UUID)
+		}
+		shards[table.Shard] = true
+	}
+	if toTable == "" {
+		if toTable, err = droppedTableOfMigration(ctx, ts, tmc, tables[0]); err != nil {
+			return nil, err
+		}
+	}
+	for _, table := range tables {
+		query := fmt.Sprintf("RENAME TABLE %s TO %s", sqlescape.EscapeID(table.Table), sqlescape.EscapeID(toTable))
+		if _, err := executeFetchAsDba(ctx, ts, tmc, table.Tablet, query, 1, false /* disableBinlogs */, true /* reloadSchema */); err != nil {
+			return nil, fmt.Errorf("cannot restore table %v to %v on shard %v: %v", table.Table, toTable, table.Shard, err)
+		}
+		log.Infof("Restored table %v to %v on shard %v", table.Table, toTable, table.Shard)
+		table.Table = toTable
+	}
+	return tables, nil
+}
+
+// listGCTables returns the GC tables of the masters of the keyspace, sorted
+// by shard and table.
+func listGCTables(ctx context.Context, ts *topo.Server, tmc tmclient.TabletManagerClient, keyspace string) ([]*GCTable, error) {
+	shards, err := ts.GetServingShards(ctx, keyspace)
+	if err != nil {
+		return nil, err
+	}
+	var tables []*GCTable
+	for _, si := range shards {
+		if si.MasterAlias == nil {
+			return nil, fmt.Errorf("shard %v/%v has no master", keyspace, si.ShardName())
+		}
+		p3qr, err := executeFetchAsDba(ctx, ts, tmc, si.MasterAlias, sqlShowGCTables, math.MaxInt32, false /* disableBinlogs */, false /* reloadSchema */)
+		if err != nil {
+			return nil, fmt.Errorf("cannot list the GC tables of shard %v/%v: %v", keyspace, si.ShardName(), err)
+		}
+		for _, row := range sqltypes.Proto3ToResult(p3qr).Rows {
+			table, err := analyzeGCTable(row[0].ToString())
+			if err != nil {
+				return nil, err
+			}
+			if table == nil {
+				continue
+			}
+			table.Shard = si.ShardName()
+			table.Tablet = si.MasterAlias
+			tables = append(tables, table)
+		}
+	}
+	sort.Slice(tables, func(i, j int) bool {
+		if tables[i].Shard != tables[j].Shard {
+			return tables[i].Shard < tables[j].Shard
+		}
+		return tables[i].Table < tables[j].Table
+	})
+	return tables, nil
+}
+
+// gcTablesOfUUID returns the GC tables of the keyspace with the given UUID.
+func gcTablesOfUUID(ctx context.Context, ts *topo.Server, tmc tmclient.TabletManagerClient, keyspace, uuid string) ([]*GCTable, error) {
+	gcUUID, err := parseGCUUID(uuid)
+	if err != nil {
+		return nil, err
+	}
+	tables, err := listGCTables(ctx, ts, tmc, keyspace)
+	if err != nil {
+		return nil, err
+	}
+	var found []*GCTable
+	for _, table := range tables {
+		if table.UUID == gcUUID {
+			found = append(found, table)
+		}
+	}
+	if len(found) == 0 {
+		return nil, fmt.Errorf("no GC table with UUID %v in keyspace %v", gcUUID, keyspace)
+	}
+	return found, nil
+}
+
+// retimeGCTables renames the tables to the same state and UUID, with the
+// given due time.
+func retimeGCTables(ctx context.Context, ts *topo.Server, tmc tmclient.TabletManagerClient, tables []*GCTable, dueTime time.Time) ([]*GCTable, error) {
+	for _, table := range tables {
+		query, toTableName, err := schema.GenerateRenameStatementWithUUID(table.Table, table.State, table.UUID, dueTime)
+		if err != nil {
+			return nil, err
+		}
+		if toTableName == table.Table {
+			continue
+		}
+		if _, err := executeFetchAsDba(ctx, ts, tmc, table.Tablet, query, 1, false /* disableBinlogs */, true /* reloadSchema */); err != nil {
+			return nil, fmt.Errorf("cannot rename table %v on shard %v: %v", table.Table, table.Shard, err)
+		}
+		log.Infof("Renamed table %v to %v on shard %v", table.Table, toTableName, table.Shard)
+		table.Table = toTableName
+		table.DueTime = dueTime.Truncate(time.Second)
+	}
+	return tables, nil
+}
+
+// droppedTableOfMigration returns the table dropped by the online DDL
+// migration whose UUID is the UUID of the GC table.
+func droppedTableOfMigration(ctx context.Context, ts *topo.Server, tmc tmclient.TabletManagerClient, table *GCTable) (string, error) {
+	query, err := sqlparser.ParseAndBind(
+		"select mysql_table from _vt.schema_migrations where migration_uuid=%a and ddl_action='drop'",
+		sqltypes.StringBindVariable(gcUUIDToOnlineDDLUUID(table.UUID)))
+	if err != nil {
+		return "", err
+	}
+	p3qr, err := executeFetchAsDba(ctx, ts, tmc, table.Tablet, query, 1, false /* disableBinlogs */, false /* reloadSchema */)
+	if err != nil {
+		return "", err
+	}
+	qr := sqltypes.Proto3ToResult(p3qr)
+	if len(qr.Rows) == 0 {
+		return "", fmt.Errorf("table %v wasn't dropped by an online DDL migration, the table to restore it to must be given", table.Table)
+	}
+	return qr.Rows[0][0].ToString(), nil
+}
+
+// executeFetchAsDba executes the query on the tablet as the dba user.
+func executeFetchAsDba(ctx context.Context, ts *topo.Server, tmc tmclient.TabletManagerClient, tabletAlias *topodatapb.TabletAlias, query string, maxRows int, disableBinlogs bool, reloadSchema bool) (*querypb.QueryResult, error) {
+	ti, err := ts.GetTablet(ctx, tabletAlias)
+	if err != nil {
+		return nil, err
+	}
+	return tmc.ExecuteFetchAsDba(ctx, ti.Tablet, false, []byte(query), maxRows, disableBinlogs, reloadSchema)
+}
+
+// analyzeGCTable returns the GC table of the given name, or nil if it isn't
+// a GC table.
+func analyzeGCTable(tableName string) (*GCTable, error) {
+	isGCTable, state, uuid, t, err := schema.AnalyzeGCTableName(tableName)
+	if err != nil {
+		return nil, fmt.Errorf("cannot analyze GC table %v: %v", tableName, err)
+	}
+	if !isGCTable {
+		return nil, nil
+	}
+	return &GCTable{Table: tableName, State: state, UUID: uuid, DueTime: t}, nil
+}
+
+// groupGCTablesByState groups the tables by state, keeping their order.
+func groupGCTablesByState(tables []*GCTable) map[schema.TableGCState][]*GCTable {
+	byState := make(map[schema.TableGCState][]*GCTable)
+	for _, table := range tables {
+		byState[table.State] = append(byState[table.State], table)
+	}
+	return byState
+}
+
+// parseGCUUID returns the GC UUID of a GC table name, of the UUID of an
+// online DDL migration or of a GC UUID.
+func parseGCUUID(arg string) (string, error) {
+	switch {
+	case schema.IsGCUUID(arg):
+		return arg, nil
+	case schema.IsOnlineDDLUUID(arg):
+		return schema.OnlineDDLToGCUUID(arg), nil
+	}
+	table, err := analyzeGCTable(arg)
+	if err != nil {
+		return "", err
+	}
+	if table == nil {
+		return "", fmt.Errorf("neither a GC table, a GC UUID nor an online DDL UUID: %v", arg)
+	}
+	return table.UUID, nil
+}
+
+// gcUUIDToOnlineDDLUUID returns the online DDL format of a GC UUID.
+func gcUUIDToOnlineDDLUUID(uuid string) string {
+	return strings.Join([]string{uuid[0:8], uuid[8:12], uuid[12:16], uuid[16:20], uuid[20:]}, "_")
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tablegcutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/schema"
+)
+
+func TestParseGCUUID(t *testing.T) {
+	for _, arg := range []string{
+		"6ace8bcef73211ea87e9f875a4d24e90",
+		"6ace8bce_f732_11ea_87e9_f875a4d24e90",
+		"_vt_HOLD_6ace8bcef73211ea87e9f875a4d24e90_20200915120410",
+	} {
+		uuid, err := parseGCUUID(arg)
+		require.NoError(t, err, arg)
+		assert.Equal(t, "6ace8bcef73211ea87e9f875a4d24e90", uuid, arg)
+	}
+	for _, arg := range []string{"", "t1", "_vt_HOLD_6ace8bcef73211ea87e9f875a4d24e90"} {
+		_, err := parseGCUUID(arg)
+		assert.Error(t, err, arg)
+	}
+
+	assert.Equal(t, "6ace8bce_f732_11ea_87e9_f875a4d24e90", gcUUIDToOnlineDDLUUID("6ace8bcef73211ea87e9f875a4d24e90"))
+}
+
+func TestGroupGCTablesByState(t *testing.T) {
+	var tables []*GCTable
+	for _, name := range []string{
+		"_vt_HOLD_6ace8bcef73211ea87e9f875a4d24e90_20200915120410",
+		"t1",
+		"_vt_EVAC_6ace8bcef73211ea87e9f875a4d24e91_20200915120411",
+		"_vt_HOLD_6ace8bcef73211ea87e9f875a4d24e92_20200915120412",
+	} {
+		table, err := analyzeGCTable(name)
+		require.NoError(t, err)
+		if table != nil {
+			tables = append(tables, table)
+		}
+	}
+	require.Len(t, tables, 3)
+	assert.Equal(t, time.Date(2020, 9, 15, 12, 4, 10, 0, time.UTC), tables[0].DueTime)
+
+	byState := groupGCTablesByState(tables)
+	require.Len(t, byState, 2)
+	assert.Equal(t, []*GCTable{tables[0], tables[2]}, byState[schema.HoldTableGCState])
+	assert.Equal(t, []*GCTable{tables[1]}, byState[schema.EvacTableGCState])
+}
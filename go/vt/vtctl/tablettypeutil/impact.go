@@ -0,0 +1,191 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tablettypeutil checks the impact of ChangeTabletType on the
+// serving tablets, for the wrangler and the VtctldServer RPCs. Changing the
+// type of a tablet, e.g. from RDONLY to REPLICA, removes it from the
+// tablets serving its former type, so a minimum of serving tablets of each
+// type can be kept in each shard and cell with -min_tablets_per_cell.
+package tablettypeutil
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+
+	"vitess.io/vitess/go/flagutil"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vttablet/tmclient"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+var minTabletsPerCell flagutil.StringMapValue
+
+func init() {
+	flag.Var(&minTabletsPerCell, "min_tablets_per_cell", "Minimum number of serving tablets of each type which must remain in a shard and cell when ChangeTabletType changes the type of one of them, e.g. replica:2,rdonly:1. A forced ChangeTabletType ignores it.")
+}
+
+// minimums parses -min_tablets_per_cell.
+func minimums() (map[topodatapb.TabletType]uint32, error) {
+	minimums := make(map[topodatapb.TabletType]uint32, len(minTabletsPerCell))
+	for typ, minimum := range minTabletsPerCell {
+		tabletType, err := topoproto.ParseTabletType(typ)
+		if err != nil {
+			return nil, fmt.Errorf("invalid -min_tablets_per_cell: %v", err)
+		}
+		n, err := strconv.ParseUint(minimum, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid -min_tablets_per_cell minimum %q for %v", minimum, typ)
+		}
+		minimums[tabletType] = uint32(n)
+	}
+	return minimums, nil
+}
+
+// Impact returns the impact of changing the type of a tablet on the
+// serving tablets of its shard and cell. It returns nil if
+// -min_tablets_per_cell has no minimum for the type of the tablet, as
+// there is nothing to check.
+func Impact(ctx context.Context, ts *topo.Server, tablet *topodatapb.Tablet, tabletType topodatapb.TabletType) (*vtctldatapb.TabletTypeChangeImpact, error) {
+	minimums, err := minimums()
+	if err != nil {
+		return nil, err
+	}
+	if minimums[tablet.Type] == 0 {
+		return nil, nil
+	}
+	tablets, err := ts.GetTabletMapForShardByCell(ctx, tablet.Keyspace, tablet.Shard, []string{tablet.Alias.Cell})
+	if err != nil {
+		return nil, err
+	}
+	return impact(tablet, tabletType, tablets, minimums), nil
+}
+
+// Check returns the impact of changing the type of a tablet, like Impact,
+// and a FAILED_PRECONDITION error if the change leaves fewer serving
+// tablets of its former type than the minimum.
+func Check(ctx context.Context, ts *topo.Server, tablet *topodatapb.Tablet, tabletType topodatapb.TabletType) (*vtctldatapb.TabletTypeChangeImpact, error) {
+	impact, err := Impact(ctx, ts, tablet, tabletType)
+	if err != nil {
+		return nil, vterrors.Wrap(err, "cannot check the impact of the type change, force the change to skip the check")
+	}
+	if impact != nil && impact.Violation != "" {
+		return impact, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "%v, force the change to ignore the minimum", impact.Violation)
+	}
+	return impact, nil
+}
+
+// Recommend returns the tablets of a shard and cell which can change from
+// one type to another, the ones with the least impact first: the changes
+// which keep the minimum of -min_tablets_per_cell first, then the drained
+// tablets, the unreachable tablets, and the tablets with the most
+// replication lag.
+func Recommend(ctx context.Context, ts *topo.Server, tmc tmclient.TabletManagerClient, keyspace, shard, cell string, from, to topodatapb.TabletType) ([]*vtctldatapb.TabletTypeChangeCandidate, error) {
+	if !topo.IsTrivialTypeChange(from, to) {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "type change %v -> %v is not an allowed transition for ChangeTabletType", from, to)
+	}
+	minimums, err := minimums()
+	if err != nil {
+		return nil, err
+	}
+	tablets, err := ts.GetTabletMapForShardByCell(ctx, keyspace, shard, []string{cell})
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		mu         sync.Mutex
+		wg         sync.WaitGroup
+		candidates []*vtctldatapb.TabletTypeChangeCandidate
+	)
+	for _, ti := range tablets {
+		if ti.Type != from {
+			continue
+		}
+		wg.Add(1)
+		go func(tablet *topodatapb.Tablet) {
+			defer wg.Done()
+			candidate := &vtctldatapb.TabletTypeChangeCandidate{
+				Impact: impact(tablet, to, tablets, minimums),
+			}
+			if status, err := tmc.ReplicationStatus(ctx, tablet); err == nil {
+				candidate.Reachable = true
+				candidate.ReplicationLagSeconds = status.SecondsBehindMaster
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			candidates = append(candidates, candidate)
+		}(ti.Tablet)
+	}
+	wg.Wait()
+
+	sortCandidates(candidates)
+	return candidates, nil
+}
+
+// impact returns the impact of changing the type of the tablet, given the
+// tablets of its shard and cell.
+func impact(tablet *topodatapb.Tablet, tabletType topodatapb.TabletType, tablets map[string]*topo.TabletInfo, minimums map[topodatapb.TabletType]uint32) *vtctldatapb.TabletTypeChangeImpact {
+	alias := topoproto.TabletAliasString(tablet.Alias)
+	impact := &vtctldatapb.TabletTypeChangeImpact{
+		TabletAlias: tablet.Alias,
+		FromType:    tablet.Type,
+		ToType:      tabletType,
+		Drained:     topoproto.IsTabletDrained(tablet),
+		Minimum:     minimums[tablet.Type],
+	}
+	for other, ti := range tablets {
+		if other == alias || ti.Type != tablet.Type || topoproto.IsTabletDrained(ti.Tablet) {
+			continue
+		}
+		impact.Remaining++
+	}
+	// A drained tablet is not serving, so changing its type doesn't lower
+	// the number of serving tablets.
+	if !impact.Drained && impact.Remaining < impact.Minimum {
+		impact.Violation = fmt.Sprintf("changing %v from %v to %v leaves %v serving %v tablets in shard %v/%v of cell %v, below the minimum of %v", alias, tablet.Type, tabletType, impact.Remaining, tablet.Type, tablet.Keyspace, tablet.Shard, tablet.Alias.Cell, impact.Minimum)
+	}
+	return impact
+}
+
+// sortCandidates sorts the candidates, the ones with the least impact
+// first.
+func sortCandidates(candidates []*vtctldatapb.TabletTypeChangeCandidate) {
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if (a.Impact.Violation == "") != (b.Impact.Violation == "") {
+			return a.Impact.Violation == ""
+		}
+		if a.Impact.Drained != b.Impact.Drained {
+			return a.Impact.Drained
+		}
+		if a.Reachable != b.Reachable {
+			return !a.Reachable
+		}
+		if a.ReplicationLagSeconds != b.ReplicationLagSeconds {
+			return a.ReplicationLagSeconds > b.ReplicationLagSeconds
+		}
+		return topoproto.TabletAliasString(a.Impact.TabletAlias) < topoproto.TabletAliasString(b.Impact.TabletAlias)
+	})
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tablettypeutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/flagutil"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+func TestMinimums(t *testing.T) {
+	defer func(saved flagutil.StringMapValue) { minTabletsPerCell = saved }(minTabletsPerCell)
+
+	require.NoError(t, minTabletsPerCell.Set("replica:2,rdonly:1"))
+	got, err := minimums()
+	require.NoError(t, err)
+	assert.Equal(t, map[topodatapb.TabletType]uint32{topodatapb.TabletType_REPLICA: 2, topodatapb.TabletType_RDONLY: 1}, got)
+
+	for _, value := range []string{"bogus:1", "rdonly:x", "rdonly:-1"} {
+		require.NoError(t, minTabletsPerCell.Set(value))
+		_, err := minimums()
+		assert.Error(t, err, value)
+	}
+}
+
+func TestImpact(t *testing.T) {
+	newTablet := func(uid uint32, tabletType topodatapb.TabletType, drained bool) *topodatapb.Tablet {
+		tablet := &topodatapb.Tablet{
+			Alias:    &topodatapb.TabletAlias{Cell: "cell1", Uid: uid},
+			Keyspace: "ks",
+			Shard:    "0",
+			Type:     tabletType,
+		}
+		topoproto.SetTabletDrained(tablet, drained)
+		return tablet
+	}
+	tablets := make(map[string]*topo.TabletInfo)
+	for _, tablet := range []*topodatapb.Tablet{
+		newTablet(1, topodatapb.TabletType_REPLICA, false),
+		newTablet(2, topodatapb.TabletType_RDONLY, false),
+		newTablet(3, topodatapb.TabletType_RDONLY, true),
+		newTablet(4, topodatapb.TabletType_REPLICA, false),
+	} {
+		tablets[topoproto.TabletAliasString(tablet.Alias)] = &topo.TabletInfo{Tablet: tablet}
+	}
+	minimums := map[topodatapb.TabletType]uint32{topodatapb.TabletType_RDONLY: 1, topodatapb.TabletType_REPLICA: 1}
+
+	// The drained rdonly doesn't count, so the last serving rdonly can't
+	// be converted.
+	got := impact(tablets["cell1-0000000002"].Tablet, topodatapb.TabletType_REPLICA, tablets, minimums)
+	assert.Equal(t, uint32(0), got.Remaining)
+	assert.Equal(t, uint32(1), got.Minimum)
+	assert.NotEmpty(t, got.Violation)
+
+	// The drained rdonly isn't serving, it can be converted.
+	got = impact(tablets["cell1-0000000003"].Tablet, topodatapb.TabletType_REPLICA, tablets, minimums)
+	assert.Equal(t, uint32(1), got.Remaining)
+	assert.Empty(t, got.Violation)
+
+	// A replica is left.
+	got = impact(tablets["cell1-0000000001"].Tablet, topodatapb.TabletType_RDONLY, tablets, minimums)
+	assert.Equal(t, uint32(1), got.Remaining)
+	assert.Empty(t, got.Violation)
+
+	// Without minimums, nothing is a violation.
+	got = impact(tablets["cell1-0000000002"].Tablet, topodatapb.TabletType_REPLICA, tablets, nil)
+	assert.Empty(t, got.Violation)
+}
+
+func TestSortCandidates(t *testing.T) {
+	candidate := func(uid uint32, violation string, drained, reachable bool, lag uint32) *vtctldatapb.TabletTypeChangeCandidate {
+		return &vtctldatapb.TabletTypeChangeCandidate{
+			Impact: &vtctldatapb.TabletTypeChangeImpact{
+				TabletAlias: &topodatapb.TabletAlias{Cell: "cell1", Uid: uid},
+				Drained:     drained,
+				Violation:   violation,
+			},
+			Reachable:             reachable,
+			ReplicationLagSeconds: lag,
+		}
+	}
+	candidates := []*vtctldatapb.TabletTypeChangeCandidate{
+		candidate(1, "below the minimum", false, false, 0),
+		candidate(2, "", false, true, 1),
+		candidate(3, "", false, true, 10),
+		candidate(4, "", false, false, 0),
+		candidate(5, "", true, true, 0),
+		candidate(6, "", false, true, 1),
+	}
+	sortCandidates(candidates)
+	var got []uint32
+	for _, candidate := range candidates {
+		got = append(got, candidate.Impact.TabletAlias.Uid)
+	}
+	assert.Equal(t, []uint32{5, 4, 3, 2, 6, 1}, got)
+}
@@ -0,0 +1,290 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package topobrowser reads the nodes of the topo on behalf of the UIs, for
+// the topodata API of vtctld and the BrowseTopology and WatchTopology RPCs.
+// The paths it can read can be restricted with -topo_browse_allowed_paths,
+// so UIs can browse the topo through vtctld without having credentials for
+// it.
+package topobrowser
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"hash/fnv"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"vitess.io/vitess/go/flagutil"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	"vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+var (
+	allowedPaths      flagutil.StringListValue
+	watchMaxTimeout   = flag.Duration("topo_browse_watch_max_timeout", time.Minute, "Maximum time a watch of the topodata API waits for a change.")
+	watchPollInterval = flag.Duration("topo_browse_watch_poll_interval", time.Second, "Interval at which a watch of the topodata API reads the watched subtree.")
+	watchMaxNodes     = flag.Int("topo_browse_watch_max_nodes", 1000, "Maximum number of nodes of a subtree watched through the topodata API.")
+)
+
+func init() {
+	flag.Var(&allowedPaths, "topo_browse_allowed_paths", "comma separated list of the topo paths which can be read through the topodata API, e.g. /global/keyspaces,/zone1/tablets. All paths by default. The ancestors of the allowed paths only list the children leading to them.")
+}
+
+// MaxWatchTimeout returns the maximum time a watch waits for a change,
+// see -topo_browse_watch_max_timeout.
+func MaxWatchTimeout() time.Duration {
+	return *watchMaxTimeout
+}
+
+// Browser reads the nodes of the topo. Paths are of the form
+// /<cell>/<path in the cell>, where the cell is topo.GlobalCell for the
+// global topo, and / lists the cells.
+type Browser struct {
+	ts *topo.Server
+	// allowedPaths are the paths which can be read, with their
+	// subtrees. Empty means all paths.
+	allowedPaths []string
+	pollInterval time.Duration
+	maxNodes     int
+}
+
+// Node is a node of the topo.
+type Node struct {
+	// Data is the decoded contents of the node, when it is a file.
+	Data string
+	// Version is the version of the node, when it is a file.
+	Version  string
+	Children []string
+}
+
+// New returns a Browser of the paths allowed by -topo_browse_allowed_paths.
+func New(ts *topo.Server) *Browser {
+	return NewWithAllowedPaths(ts, allowedPaths)
+}
+
+// NewWithAllowedPaths returns a Browser of the given paths and their
+// subtrees. No paths means all paths.
+func NewWithAllowedPaths(ts *topo.Server, paths []string) *Browser {
+	b := &Browser{
+		ts:           ts,
+		pollInterval: *watchPollInterval,
+		maxNodes:     *watchMaxNodes,
+	}
+	for _, p := range paths {
+		b.allowedPaths = append(b.allowedPaths, path.Clean("/"+p))
+	}
+	return b
+}
+
+// access returns whether the node can be read, and whether it is an
+// ancestor of the allowed paths, which only lists the children leading
+// to them.
+func (b *Browser) access(nodePath string) (allowed, ancestor bool) {
+	if len(b.allowedPaths) == 0 {
+		return true, false
+	}
+	for _, p := range b.allowedPaths {
+		if p == "/" || nodePath == p || strings.HasPrefix(nodePath, p+"/") {
+			return true, false
+		}
+		if nodePath == "/" || strings.HasPrefix(p, nodePath+"/") {
+			ancestor = true
+		}
+	}
+	return false, ancestor
+}
+
+// filterChildren returns the children of an ancestor of the allowed
+// paths which lead to them.
+func (b *Browser) filterChildren(nodePath string, children []string) []string {
+	var filtered []string
+	for _, child := range children {
+		allowed, ancestor := b.access(path.Join(nodePath, child))
+		if allowed || ancestor {
+			filtered = append(filtered, child)
+		}
+	}
+	return filtered
+}
+
+// conn returns the connection to the cell of the node, and the path of
+// the node in the cell.
+func (b *Browser) conn(ctx context.Context, nodePath string) (topo.Conn, string, error) {
+	parts := strings.Split(nodePath, "/")
+	if parts[0] != "" || len(parts) < 2 {
+		return nil, "", vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "Invalid path: %v", nodePath)
+	}
+	cell := parts[1]
+	conn, err := b.ts.ConnForCell(ctx, cell)
+	if err != nil {
+		return nil, "", vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "Invalid cell: %v", err)
+	}
+	return conn, nodePath[len(cell)+1:], nil
+}
+
+// Browse returns the node at the path. The ancestors of the allowed paths
+// only list their children. On error, the node holds what could be read
+// of it, if anything.
+func (b *Browser) Browse(ctx context.Context, nodePath string) (*Node, error) {
+	node := &Node{}
+
+	allowed, ancestor := b.access(nodePath)
+	if !allowed && !ancestor {
+		return node, vterrors.Errorf(vtrpc.Code_PERMISSION_DENIED, "Access denied: %v", nodePath)
+	}
+
+	// Handle toplevel display: global, then one line per cell.
+	if nodePath == "/" {
+		cells, err := b.ts.GetKnownCells(ctx)
+		if err != nil {
+			return node, err
+		}
+		sort.Strings(cells)
+		node.Children = append([]string{topo.GlobalCell}, cells...)
+		if !allowed {
+			node.Children = b.filterChildren(nodePath, node.Children)
+		}
+		return node, nil
+	}
+
+	// Now find the cell.
+	conn, relativePath, err := b.conn(ctx, nodePath)
+	if err != nil {
+		return node, err
+	}
+
+	// The ancestors of the allowed paths only list their children.
+	if !allowed {
+		children, err := conn.ListDir(ctx, relativePath, false /*full*/)
+		if err != nil {
+			return node, err
+		}
+		node.Children = b.filterChildren(nodePath, topo.DirEntriesToStringArray(children))
+		return node, nil
+	}
+
+	// Get the file contents, if any.
+	data, version, getErr := conn.Get(ctx, relativePath)
+	if getErr == nil {
+		node.Version = version.String()
+		if len(data) > 0 {
+			// It has contents, we just use it if possible. With contents,
+			// it can't have children, so we're done.
+			decoded, err := topo.DecodeContent(relativePath, data, false)
+			if err != nil {
+				return node, err
+			}
+			node.Data = decoded
+			return node, nil
+		}
+	}
+
+	// Get the children, if any.
+	children, err := conn.ListDir(ctx, relativePath, false /*full*/)
+	if err != nil {
+		// It failed as a directory, let's just return what it did
+		// as a file.
+		return node, getErr
+	}
+
+	// It worked as a directory, ignore any file error.
+	node.Children = topo.DirEntriesToStringArray(children)
+	return node, nil
+}
+
+// Watch waits for a change of the subtree of the node, i.e. the node and
+// all its descendants, from the provided version, and returns its new
+// version, and whether it changed. The subtree is read periodically, so
+// it also sees the nodes which are created or deleted. An empty version
+// returns the current version right away. When the timeout expires
+// without a change, it returns the provided version.
+func (b *Browser) Watch(ctx context.Context, nodePath, version string, timeout time.Duration) (string, bool, error) {
+	if allowed, _ := b.access(nodePath); !allowed {
+		return "", false, vterrors.Errorf(vtrpc.Code_PERMISSION_DENIED, "Access denied: %v", nodePath)
+	}
+	conn, relativePath, err := b.conn(ctx, nodePath)
+	if err != nil {
+		return "", false, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	ticker := time.NewTicker(b.pollInterval)
+	defer ticker.Stop()
+	for {
+		current, err := b.subtreeVersion(ctx, conn, relativePath)
+		if err != nil {
+			if ctx.Err() != nil {
+				// Timed out while reading the subtree.
+				return version, false, nil
+			}
+			return "", false, err
+		}
+		if version == "" || current != version {
+			return current, version != "", nil
+		}
+		select {
+		case <-ctx.Done():
+			return current, false, nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// subtreeVersion returns a hash of the paths and versions of the files of
+// the subtree of the node.
+func (b *Browser) subtreeVersion(ctx context.Context, conn topo.Conn, nodePath string) (string, error) {
+	var files []string
+	toVisit := []string{nodePath}
+	for visited := 1; len(toVisit) > 0; visited++ {
+		p := toVisit[0]
+		toVisit = toVisit[1:]
+		if visited > b.maxNodes {
+			return "", fmt.Errorf("the subtree of %v has more than %v nodes", nodePath, b.maxNodes)
+		}
+
+		// Get fails on the directories, and ListDir on the files, with
+		// errors which depend on the implementation. The subtree can't be
+		// read when both fail, unless the node doesn't exist.
+		_, version, getErr := conn.Get(ctx, p)
+		if getErr == nil {
+			files = append(files, p+"@"+version.String())
+		}
+		children, listErr := conn.ListDir(ctx, p, false /*full*/)
+		if listErr == nil {
+			for _, child := range children {
+				toVisit = append(toVisit, path.Join(p, child.Name))
+			}
+		}
+		if getErr != nil && listErr != nil && !topo.IsErrType(getErr, topo.NoNode) {
+			return "", getErr
+		}
+	}
+
+	sort.Strings(files)
+	h := fnv.New64a()
+	for _, file := range files {
+		h.Write([]byte(file))
+		h.Write([]byte{0})
+	}
+	return fmt.Sprintf("%016x", h.Sum64()), nil
+}
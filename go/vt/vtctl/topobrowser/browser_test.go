@@ -0,0 +1,95 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topobrowser
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	"vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+func TestBrowseErrors(t *testing.T) {
+	ctx := context.Background()
+	ts := memorytopo.NewServer("cell1")
+	b := NewWithAllowedPaths(ts, []string{"/global/keyspaces"})
+
+	table := []struct {
+		input string
+		code  vtrpc.Code
+	}{
+		{input: "/cell1", code: vtrpc.Code_PERMISSION_DENIED},
+		{input: "/global/keyspaces/ks1/Keyspace", code: vtrpc.Code_UNKNOWN},
+	}
+	for _, tc := range table {
+		if _, err := b.Browse(ctx, tc.input); vterrors.Code(err) != tc.code {
+			t.Errorf("Browse(%q) = %v, want code %v", tc.input, err, tc.code)
+		}
+	}
+
+	b = NewWithAllowedPaths(ts, nil)
+	if _, err := b.Browse(ctx, "/foo"); vterrors.Code(err) != vtrpc.Code_INVALID_ARGUMENT {
+		t.Errorf("Browse(/foo) = %v, want code %v", err, vtrpc.Code_INVALID_ARGUMENT)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	ctx := context.Background()
+	ts := memorytopo.NewServer("cell1")
+	if err := ts.CreateKeyspace(ctx, "ks1", &topodatapb.Keyspace{}); err != nil {
+		t.Fatalf("CreateKeyspace error: %v", err)
+	}
+
+	b := NewWithAllowedPaths(ts, nil)
+	b.pollInterval = 10 * time.Millisecond
+	input := "/global/keyspaces"
+
+	// An empty version returns the current version.
+	version, changed, err := b.Watch(ctx, input, "", time.Second)
+	if err != nil || version == "" || changed {
+		t.Fatalf("Watch(%q) = %v, %v, %v", input, version, changed, err)
+	}
+
+	// Without a change, the watch times out.
+	current, changed, err := b.Watch(ctx, input, version, 50*time.Millisecond)
+	if err != nil || current != version || changed {
+		t.Errorf("Watch(%q) = %v, %v, %v, want version %v unchanged", input, current, changed, err, version)
+	}
+
+	// A node created in the subtree is a change.
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		if err := ts.CreateShard(ctx, "ks1", "0"); err != nil {
+			t.Errorf("CreateShard error: %v", err)
+		}
+	}()
+	current, changed, err = b.Watch(ctx, input, version, 5*time.Second)
+	if err != nil || current == version || !changed {
+		t.Errorf("Watch(%q) = %v, %v, %v, want a change from version %v", input, current, changed, err, version)
+	}
+
+	// The watched paths are restricted like the browsed paths.
+	b = NewWithAllowedPaths(ts, []string{"/global/keyspaces/ks2"})
+	if _, _, err := b.Watch(ctx, input, "", time.Second); vterrors.Code(err) != vtrpc.Code_PERMISSION_DENIED {
+		t.Errorf("Watch(%q) = %v, want access denied", input, err)
+	}
+}
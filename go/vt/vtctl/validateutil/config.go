@@ -0,0 +1,302 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validateutil
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vttablet/tmclient"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// DefaultMySQLVariables are the MySQL global variables compared by
+// ValidateConfig if ConfigOptions.MySQLVariables isn't set. A mismatch of
+// any of them between the tablets of a shard causes subtle replication or
+// validation failures.
+var DefaultMySQLVariables = []string{
+	"binlog_format",
+	"binlog_row_image",
+	"character_set_server",
+	"collation_server",
+	"enforce_gtid_consistency",
+	"explicit_defaults_for_timestamp",
+	"gtid_mode",
+	"lower_case_table_names",
+	"sql_mode",
+	"time_zone",
+}
+
+// The kinds of settings compared by ValidateConfig.
+const (
+	MySQLVariableSetting = "mysql_variable"
+	FlagSetting          = "flag"
+)
+
+// Config is the configuration of a tablet: the values of some of its MySQL
+// global variables and vttablet flags.
+type Config struct {
+	MySQLVariables map[string]string `json:"mysql_variables,omitempty"`
+	Flags          map[string]string `json:"flags,omitempty"`
+}
+
+// ConfigOptions are the settings compared by ValidateConfig.
+type ConfigOptions struct {
+	// MySQLVariables are the MySQL global variables to compare, in lower
+	// case. They default to DefaultMySQLVariables.
+	MySQLVariables []string
+	// Flags are the vttablet flags to compare.
+	Flags []string
+	// Golden is the expected configuration. The settings it has are
+	// compared with it, and the other ones with the value of the majority
+	// of the tablets. Its settings are compared even if they aren't in
+	// MySQLVariables or Flags.
+	Golden *Config
+}
+
+// ConfigDrift is a setting of a tablet which differs from the expected
+// value.
+type ConfigDrift struct {
+	Kind     string `json:"kind"`
+	Name     string `json:"name"`
+	Value    string `json:"value"`
+	Expected string `json:"expected"`
+}
+
+// TabletConfig is the result of the validation of the configuration of a
+// tablet.
+type TabletConfig struct {
+	Tablet string         `json:"tablet"`
+	Shard  string         `json:"shard"`
+	Config *Config        `json:"config,omitempty"`
+	Drifts []*ConfigDrift `json:"drifts,omitempty"`
+	Error  string         `json:"error,omitempty"`
+}
+
+// ConfigReport is the result of ValidateConfig.
+type ConfigReport struct {
+	Keyspace string `json:"keyspace"`
+	// Expected is the configuration the tablets are compared with: the
+	// golden one, completed with the values of the majority.
+	Expected *Config         `json:"expected"`
+	Tablets  []*TabletConfig `json:"tablets"`
+	// Consistent is true if the configuration of all the tablets is known
+	// and the expected one.
+	Consistent bool `json:"consistent"`
+}
+
+// FlagsGetter returns the values of the given flags of a tablet. The
+// unknown flags are omitted.
+type FlagsGetter func(ctx context.Context, tablet *topodatapb.Tablet, names []string) (map[string]string, error)
+
+// GetFlagsFromTabletDebugFlags returns the values of the given flags of the
+// tablet serving on tabletAddr, as exported on its /debug/flags page.
+func GetFlagsFromTabletDebugFlags(tabletAddr string, names []string) (map[string]string, error) {
+	resp, err := http.Get("http://" + tabletAddr + "/debug/flags?" + url.Values{"name": names}.Encode())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%v: %s", resp.Status, body)
+	}
+
+	var flags map[string]string
+	if err := json.Unmarshal(body, &flags); err != nil {
+		return nil, err
+	}
+	return flags, nil
+}
+
+// ValidateConfig gathers the given MySQL global variables and vttablet
+// flags of the tablets, and reports the tablets which drift from the
+// golden configuration or, for the settings it doesn't have, from the
+// majority. A setting a tablet doesn't have is reported with an empty
+// value.
+func ValidateConfig(ctx context.Context, ts *topo.Server, tmc tmclient.TabletManagerClient, getFlags FlagsGetter, opts Options, configOpts ConfigOptions) (*ConfigReport, error) {
+	tablets, err := ListTablets(ctx, ts, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(tablets) == 0 {
+		return nil, fmt.Errorf("no tablets in %v", scopeString(opts))
+	}
+	variables, flags := configSettings(configOpts)
+
+	report := &ConfigReport{
+		Keyspace: opts.Keyspace,
+		Tablets:  make([]*TabletConfig, len(tablets)),
+	}
+	ForEachTablet(tablets, opts.Concurrency, func(i int, tablet *topodatapb.Tablet) {
+		result := &TabletConfig{Tablet: topoproto.TabletAliasString(tablet.Alias), Shard: tablet.Shard}
+		report.Tablets[i] = result
+		config := &Config{}
+		var err error
+		if len(variables) > 0 {
+			if config.MySQLVariables, err = getMySQLVariables(ctx, tmc, tablet, variables); err != nil {
+				result.Error = err.Error()
+				return
+			}
+		}
+		if len(flags) > 0 {
+			if config.Flags, err = getFlags(ctx, tablet, flags); err != nil {
+				result.Error = fmt.Sprintf("cannot get the flags: %v", err)
+				return
+			}
+		}
+		result.Config = config
+	})
+
+	var configs []*Config
+	for _, result := range report.Tablets {
+		if result.Config != nil {
+			configs = append(configs, result.Config)
+		}
+	}
+	report.Expected = &Config{
+		MySQLVariables: expectedValues(variables, golden(configOpts).MySQLVariables, configs, func(c *Config) map[string]string { return c.MySQLVariables }),
+		Flags:          expectedValues(flags, golden(configOpts).Flags, configs, func(c *Config) map[string]string { return c.Flags }),
+	}
+
+	report.Consistent = true
+	for _, result := range report.Tablets {
+		if result.Config != nil {
+			result.Drifts = append(diffValues(MySQLVariableSetting, variables, report.Expected.MySQLVariables, result.Config.MySQLVariables),
+				diffValues(FlagSetting, flags, report.Expected.Flags, result.Config.Flags)...)
+		}
+		if result.Error != "" || len(result.Drifts) > 0 {
+			report.Consistent = false
+		}
+	}
+	return report, nil
+}
+
+// getMySQLVariables returns the values of the given global variables of
+// the mysqld of the tablet. The unknown variables are omitted.
+func getMySQLVariables(ctx context.Context, tmc tmclient.TabletManagerClient, tablet *topodatapb.Tablet, names []string) (map[string]string, error) {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = sqltypes.EncodeStringSQL(name)
+	}
+	query := fmt.Sprintf("SHOW GLOBAL VARIABLES WHERE Variable_name IN (%s)", strings.Join(quoted, ", "))
+	p3qr, err := tmc.ExecuteFetchAsDba(ctx, tablet, false, []byte(query), len(names), false, false)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get the MySQL variables: %v", err)
+	}
+	values := make(map[string]string)
+	for _, row := range sqltypes.Proto3ToResult(p3qr).Rows {
+		if len(row) != 2 {
+			return nil, fmt.Errorf("unexpected row in the MySQL variables: %v", row)
+		}
+		values[strings.ToLower(row[0].ToString())] = row[1].ToString()
+	}
+	return values, nil
+}
+
+// configSettings returns the sorted MySQL variables and flags to compare.
+func configSettings(configOpts ConfigOptions) ([]string, []string) {
+	variables := configOpts.MySQLVariables
+	if len(variables) == 0 {
+		variables = DefaultMySQLVariables
+	}
+	return mergeNames(variables, golden(configOpts).MySQLVariables), mergeNames(configOpts.Flags, golden(configOpts).Flags)
+}
+
+func golden(configOpts ConfigOptions) *Config {
+	if configOpts.Golden == nil {
+		return &Config{}
+	}
+	return configOpts.Golden
+}
+
+// mergeNames returns the sorted and deduplicated names of the list and of
+// the keys of the map.
+func mergeNames(names []string, values map[string]string) []string {
+	set := make(map[string]bool)
+	for _, name := range names {
+		set[name] = true
+	}
+	for name := range values {
+		set[name] = true
+	}
+	merged := make([]string, 0, len(set))
+	for name := range set {
+		merged = append(merged, name)
+	}
+	sort.Strings(merged)
+	return merged
+}
+
+// expectedValues returns the expected value of each setting: the golden
+// one, or the most common one among the configurations. A tie is broken
+// in favor of the smallest value, so that the result is deterministic.
+func expectedValues(names []string, golden map[string]string, configs []*Config, values func(*Config) map[string]string) map[string]string {
+	if len(names) == 0 {
+		return nil
+	}
+	expected := make(map[string]string, len(names))
+	for _, name := range names {
+		if value, ok := golden[name]; ok {
+			expected[name] = value
+			continue
+		}
+		counts := make(map[string]int)
+		for _, config := range configs {
+			counts[values(config)[name]]++
+		}
+		best, bestCount := "", 0
+		for value, count := range counts {
+			if count > bestCount || (count == bestCount && value < best) {
+				best, bestCount = value, count
+			}
+		}
+		expected[name] = best
+	}
+	return expected
+}
+
+// diffValues returns the drifts of the values from the expected ones.
+func diffValues(kind string, names []string, expected, values map[string]string) []*ConfigDrift {
+	var drifts []*ConfigDrift
+	for _, name := range names {
+		if values[name] != expected[name] {
+			drifts = append(drifts, &ConfigDrift{Kind: kind, Name: name, Value: values[name], Expected: expected[name]})
+		}
+	}
+	return drifts
+}
+
+func scopeString(opts Options) string {
+	if opts.Shard != "" {
+		return topoproto.KeyspaceShardString(opts.Keyspace, opts.Shard)
+	}
+	return opts.Keyspace
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validateutil
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vttablet/tmclient"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+type fakeVariablesTMC struct {
+	tmclient.TabletManagerClient
+	sqlModes map[string]string
+
+	mu      sync.Mutex
+	queries []string
+}
+
+func (f *fakeVariablesTMC) ExecuteFetchAsDba(ctx context.Context, tablet *topodatapb.Tablet, usePool bool, query []byte, maxRows int, disableBinlogs, reloadSchema bool) (*querypb.QueryResult, error) {
+	sqlMode, ok := f.sqlModes[topoproto.TabletAliasString(tablet.Alias)]
+	if !ok {
+		return nil, fmt.Errorf("tablet unreachable")
+	}
+	f.mu.Lock()
+	f.queries = append(f.queries, string(query))
+	f.mu.Unlock()
+	return sqltypes.ResultToProto3(sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields("Variable_name|Value", "varchar|varchar"),
+		"SQL_MODE|"+sqlMode,
+	)), nil
+}
+
+func TestValidateConfig(t *testing.T) {
+	ctx := context.Background()
+	ts := setupTopo(ctx, t)
+	tmc := &fakeVariablesTMC{sqlModes: map[string]string{
+		"zone1-0000000100": "STRICT_TRANS_TABLES",
+		"zone1-0000000101": "STRICT_TRANS_TABLES",
+		"zone1-0000000200": "",
+		"zone2-0000000201": "STRICT_TRANS_TABLES",
+	}}
+	getFlags := func(ctx context.Context, tablet *topodatapb.Tablet, names []string) (map[string]string, error) {
+		assert.Equal(t, []string{"queryserver-config-pool-size"}, names)
+		if tablet.Alias.Uid == 201 {
+			return map[string]string{"queryserver-config-pool-size": "8"}, nil
+		}
+		return map[string]string{"queryserver-config-pool-size": "16"}, nil
+	}
+
+	report, err := ValidateConfig(ctx, ts, tmc, getFlags, Options{Keyspace: "ks", Concurrency: 1}, ConfigOptions{
+		MySQLVariables: []string{"sql_mode"},
+		Flags:          []string{"queryserver-config-pool-size"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "SHOW GLOBAL VARIABLES WHERE Variable_name IN ('sql_mode')", tmc.queries[0])
+	assert.False(t, report.Consistent)
+	assert.Equal(t, &Config{
+		MySQLVariables: map[string]string{"sql_mode": "STRICT_TRANS_TABLES"},
+		Flags:          map[string]string{"queryserver-config-pool-size": "16"},
+	}, report.Expected)
+	require.Len(t, report.Tablets, 4)
+	assert.Empty(t, report.Tablets[0].Drifts)
+	assert.Empty(t, report.Tablets[1].Drifts)
+	assert.Equal(t, []*ConfigDrift{{Kind: MySQLVariableSetting, Name: "sql_mode", Value: "", Expected: "STRICT_TRANS_TABLES"}}, report.Tablets[2].Drifts)
+	assert.Equal(t, []*ConfigDrift{{Kind: FlagSetting, Name: "queryserver-config-pool-size", Value: "8", Expected: "16"}}, report.Tablets[3].Drifts)
+
+	// The golden configuration wins over the majority.
+	report, err = ValidateConfig(ctx, ts, tmc, getFlags, Options{Keyspace: "ks", Shard: "80-"}, ConfigOptions{
+		Golden: &Config{MySQLVariables: map[string]string{"sql_mode": ""}},
+	})
+	require.NoError(t, err)
+	require.Len(t, report.Tablets, 2)
+	assert.Empty(t, report.Tablets[0].Drifts)
+	assert.Equal(t, []*ConfigDrift{{Kind: MySQLVariableSetting, Name: "sql_mode", Value: "STRICT_TRANS_TABLES", Expected: ""}}, report.Tablets[1].Drifts)
+
+	delete(tmc.sqlModes, "zone1-0000000101")
+	report, err = ValidateConfig(ctx, ts, tmc, getFlags, Options{Keyspace: "ks", Shard: "-80"}, ConfigOptions{MySQLVariables: []string{"sql_mode"}})
+	require.NoError(t, err)
+	assert.False(t, report.Consistent)
+	assert.Equal(t, "cannot get the MySQL variables: tablet unreachable", report.Tablets[1].Error)
+}
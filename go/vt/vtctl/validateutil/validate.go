@@ -0,0 +1,283 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package validateutil validates that the tablets of a keyspace are
+// consistent, e.g. have the same permissions or run the same version, and
+// reports the result of each tablet.
+package validateutil
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/mysqlctl/tmutils"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vttablet/tmclient"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// DefaultConcurrency is the number of tablets queried at the same time if
+// Options.Concurrency isn't set.
+const DefaultConcurrency = 10
+
+// Options scopes a validation.
+type Options struct {
+	// Keyspace is the keyspace to validate. It is required.
+	Keyspace string
+	// Shard restricts the validation to one shard of the keyspace.
+	Shard string
+	// Cells restricts the validation to the tablets of these cells. The
+	// reference tablet is used even if it's in another cell.
+	Cells []string
+	// Concurrency is the maximum number of tablets queried at the same
+	// time.
+	Concurrency int
+}
+
+// TabletPermissions is the result of the validation of the permissions
+// of a tablet.
+type TabletPermissions struct {
+	Tablet string `json:"tablet"`
+	Shard  string `json:"shard"`
+	// Differences are the grants which differ from the reference tablet.
+	Differences []string `json:"differences,omitempty"`
+	Error       string   `json:"error,omitempty"`
+}
+
+// PermissionsReport is the result of ValidatePermissions.
+type PermissionsReport struct {
+	Keyspace string `json:"keyspace"`
+	// Reference is the tablet all the others are compared with: the
+	// master of the first shard.
+	Reference string               `json:"reference"`
+	Tablets   []*TabletPermissions `json:"tablets"`
+	// Consistent is true if all the tablets could be compared and have
+	// the same permissions.
+	Consistent bool `json:"consistent"`
+}
+
+// TabletVersion is the version of a tablet.
+type TabletVersion struct {
+	Tablet  string `json:"tablet"`
+	Shard   string `json:"shard"`
+	Version string `json:"version,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// VersionReport is the result of ValidateVersion.
+type VersionReport struct {
+	Keyspace string           `json:"keyspace"`
+	Tablets  []*TabletVersion `json:"tablets"`
+	// Matrix has the tablets running each version, by shard and version.
+	Matrix map[string]map[string][]string `json:"matrix"`
+	// Consistent is true if the versions of all the tablets are known
+	// and the same.
+	Consistent bool `json:"consistent"`
+}
+
+// VersionGetter returns the version a tablet runs.
+type VersionGetter func(ctx context.Context, tablet *topodatapb.Tablet) (string, error)
+
+// ValidatePermissions compares the permissions of the tablets with the
+// ones of the master of the first shard of the keyspace.
+func ValidatePermissions(ctx context.Context, ts *topo.Server, tmc tmclient.TabletManagerClient, opts Options) (*PermissionsReport, error) {
+	reference, tablets, err := findTablets(ctx, ts, opts)
+	if err != nil {
+		return nil, err
+	}
+	referenceAlias := topoproto.TabletAliasString(reference.Alias)
+	log.Infof("Gathering permissions for reference master %v", referenceAlias)
+	referencePermissions, err := tmc.GetPermissions(ctx, reference)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get the permissions of reference tablet %v: %v", referenceAlias, err)
+	}
+
+	report := &PermissionsReport{
+		Keyspace:   opts.Keyspace,
+		Reference:  referenceAlias,
+		Tablets:    make([]*TabletPermissions, len(tablets)),
+		Consistent: true,
+	}
+	ForEachTablet(tablets, opts.Concurrency, func(i int, tablet *topodatapb.Tablet) {
+		result := &TabletPermissions{Tablet: topoproto.TabletAliasString(tablet.Alias), Shard: tablet.Shard}
+		report.Tablets[i] = result
+		permissions, err := tmc.GetPermissions(ctx, tablet)
+		if err != nil {
+			result.Error = err.Error()
+			return
+		}
+		result.Differences = tmutils.DiffPermissionsToArray(referenceAlias, referencePermissions, result.Tablet, permissions)
+	})
+	for _, result := range report.Tablets {
+		if result.Error != "" || len(result.Differences) > 0 {
+			report.Consistent = false
+		}
+	}
+	return report, nil
+}
+
+// ValidateVersion gathers the versions of the tablets.
+func ValidateVersion(ctx context.Context, ts *topo.Server, getVersion VersionGetter, opts Options) (*VersionReport, error) {
+	reference, tablets, err := findTablets(ctx, ts, opts)
+	if err != nil {
+		return nil, err
+	}
+	// The reference tablet is reported like the others.
+	tablets = append([]*topodatapb.Tablet{reference}, tablets...)
+
+	report := &VersionReport{
+		Keyspace: opts.Keyspace,
+		Tablets:  make([]*TabletVersion, len(tablets)),
+		Matrix:   make(map[string]map[string][]string),
+	}
+	ForEachTablet(tablets, opts.Concurrency, func(i int, tablet *topodatapb.Tablet) {
+		result := &TabletVersion{Tablet: topoproto.TabletAliasString(tablet.Alias), Shard: tablet.Shard}
+		report.Tablets[i] = result
+		version, err := getVersion(ctx, tablet)
+		if err != nil {
+			result.Error = err.Error()
+			return
+		}
+		result.Version = version
+	})
+
+	versions := make(map[string]bool)
+	report.Consistent = true
+	for _, result := range report.Tablets {
+		if result.Error != "" {
+			report.Consistent = false
+			continue
+		}
+		versions[result.Version] = true
+		if report.Matrix[result.Shard] == nil {
+			report.Matrix[result.Shard] = make(map[string][]string)
+		}
+		report.Matrix[result.Shard][result.Version] = append(report.Matrix[result.Shard][result.Version], result.Tablet)
+	}
+	if len(versions) > 1 {
+		report.Consistent = false
+	}
+	return report, nil
+}
+
+// findTablets returns the reference tablet, i.e. the master of the first
+// shard, and the other tablets in scope, sorted by shard and alias.
+func findTablets(ctx context.Context, ts *topo.Server, opts Options) (*topodatapb.Tablet, []*topodatapb.Tablet, error) {
+	shards, err := shardsInScope(ctx, ts, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(shards) == 0 {
+		return nil, nil, fmt.Errorf("no shards in keyspace %v", opts.Keyspace)
+	}
+
+	si, err := ts.GetShard(ctx, opts.Keyspace, shards[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	if !si.HasMaster() {
+		return nil, nil, fmt.Errorf("no master in shard %v/%v", opts.Keyspace, shards[0])
+	}
+	reference, err := ts.GetTablet(ctx, si.MasterAlias)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	all, err := listShardsTablets(ctx, ts, opts.Keyspace, shards, opts.Cells)
+	if err != nil {
+		return nil, nil, err
+	}
+	var tablets []*topodatapb.Tablet
+	for _, tablet := range all {
+		if topoproto.TabletAliasEqual(tablet.Alias, reference.Alias) {
+			continue
+		}
+		tablets = append(tablets, tablet)
+	}
+	return reference.Tablet, tablets, nil
+}
+
+// ListTablets returns the tablets in the scope of the options, sorted by
+// shard and alias.
+func ListTablets(ctx context.Context, ts *topo.Server, opts Options) ([]*topodatapb.Tablet, error) {
+	shards, err := shardsInScope(ctx, ts, opts)
+	if err != nil {
+		return nil, err
+	}
+	return listShardsTablets(ctx, ts, opts.Keyspace, shards, opts.Cells)
+}
+
+// shardsInScope returns the shards in the scope of the options, sorted.
+func shardsInScope(ctx context.Context, ts *topo.Server, opts Options) ([]string, error) {
+	if opts.Keyspace == "" {
+		return nil, fmt.Errorf("a keyspace is required")
+	}
+	if opts.Shard != "" {
+		return []string{opts.Shard}, nil
+	}
+	shards, err := ts.GetShardNames(ctx, opts.Keyspace)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(shards)
+	return shards, nil
+}
+
+func listShardsTablets(ctx context.Context, ts *topo.Server, keyspace string, shards, cells []string) ([]*topodatapb.Tablet, error) {
+	var tablets []*topodatapb.Tablet
+	for _, shard := range shards {
+		tabletMap, err := ts.GetTabletMapForShardByCell(ctx, keyspace, shard, cells)
+		if err != nil && !topo.IsErrType(err, topo.PartialResult) {
+			return nil, err
+		}
+		aliases := make([]string, 0, len(tabletMap))
+		for alias := range tabletMap {
+			aliases = append(aliases, alias)
+		}
+		sort.Strings(aliases)
+		for _, alias := range aliases {
+			tablets = append(tablets, tabletMap[alias].Tablet)
+		}
+	}
+	return tablets, nil
+}
+
+// ForEachTablet runs f for each tablet, with at most concurrency running
+// at the same time, and waits for them to return.
+func ForEachTablet(tablets []*topodatapb.Tablet, concurrency int, f func(i int, tablet *topodatapb.Tablet)) {
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, tablet := range tablets {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, tablet *topodatapb.Tablet) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			f(i, tablet)
+		}(i, tablet)
+	}
+	wg.Wait()
+}
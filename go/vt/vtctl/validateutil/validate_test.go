@@ -0,0 +1,138 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validateutil
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vtctl/grpcvtctldserver/testutil"
+	"vitess.io/vitess/go/vt/vttablet/tmclient"
+
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+type fakePermissionsTMC struct {
+	tmclient.TabletManagerClient
+	permissions map[string]*tabletmanagerdatapb.Permissions
+}
+
+func (f *fakePermissionsTMC) GetPermissions(ctx context.Context, tablet *topodatapb.Tablet) (*tabletmanagerdatapb.Permissions, error) {
+	p, ok := f.permissions[topoproto.TabletAliasString(tablet.Alias)]
+	if !ok {
+		return nil, fmt.Errorf("tablet unreachable")
+	}
+	return p, nil
+}
+
+func setupTopo(ctx context.Context, t *testing.T) *topo.Server {
+	ts := memorytopo.NewServer("zone1", "zone2")
+	tablet := func(cell string, uid uint32, shard string, tabletType topodatapb.TabletType) *topodatapb.Tablet {
+		return &topodatapb.Tablet{
+			Alias:    &topodatapb.TabletAlias{Cell: cell, Uid: uid},
+			Keyspace: "ks",
+			Shard:    shard,
+			Type:     tabletType,
+		}
+	}
+	testutil.AddTablets(ctx, t, ts, &testutil.AddTabletOptions{AlsoSetShardMaster: true},
+		tablet("zone1", 100, "-80", topodatapb.TabletType_MASTER),
+		tablet("zone1", 101, "-80", topodatapb.TabletType_REPLICA),
+		tablet("zone1", 200, "80-", topodatapb.TabletType_MASTER),
+		tablet("zone2", 201, "80-", topodatapb.TabletType_REPLICA),
+	)
+	return ts
+}
+
+func userPermissions(privilege string) *tabletmanagerdatapb.Permissions {
+	return &tabletmanagerdatapb.Permissions{
+		UserPermissions: []*tabletmanagerdatapb.UserPermission{{
+			Host:       "%",
+			User:       "vt_app",
+			Privileges: map[string]string{"Select_priv": privilege},
+		}},
+	}
+}
+
+func TestValidatePermissions(t *testing.T) {
+	ctx := context.Background()
+	ts := setupTopo(ctx, t)
+	tmc := &fakePermissionsTMC{permissions: map[string]*tabletmanagerdatapb.Permissions{
+		"zone1-0000000100": userPermissions("Y"),
+		"zone1-0000000101": userPermissions("Y"),
+		"zone1-0000000200": userPermissions("N"),
+	}}
+
+	report, err := ValidatePermissions(ctx, ts, tmc, Options{Keyspace: "ks", Concurrency: 1})
+	require.NoError(t, err)
+	assert.Equal(t, "zone1-0000000100", report.Reference)
+	assert.False(t, report.Consistent)
+	require.Len(t, report.Tablets, 3)
+	assert.Equal(t, "zone1-0000000101", report.Tablets[0].Tablet)
+	assert.Empty(t, report.Tablets[0].Differences)
+	assert.Equal(t, "zone1-0000000200", report.Tablets[1].Tablet)
+	assert.NotEmpty(t, report.Tablets[1].Differences)
+	assert.Equal(t, "zone2-0000000201", report.Tablets[2].Tablet)
+	assert.Equal(t, "tablet unreachable", report.Tablets[2].Error)
+
+	report, err = ValidatePermissions(ctx, ts, tmc, Options{Keyspace: "ks", Shard: "-80"})
+	require.NoError(t, err)
+	assert.True(t, report.Consistent)
+	assert.Len(t, report.Tablets, 1)
+
+	_, err = ValidatePermissions(ctx, ts, tmc, Options{Keyspace: "ks", Shard: "80-", Cells: []string{"zone2"}})
+	require.NoError(t, err)
+}
+
+func TestValidateVersion(t *testing.T) {
+	ctx := context.Background()
+	ts := setupTopo(ctx, t)
+	versions := map[string]string{
+		"zone1-0000000100": "v11",
+		"zone1-0000000101": "v11",
+		"zone1-0000000200": "v11",
+		"zone2-0000000201": "v10",
+	}
+	getVersion := func(ctx context.Context, tablet *topodatapb.Tablet) (string, error) {
+		return versions[topoproto.TabletAliasString(tablet.Alias)], nil
+	}
+
+	report, err := ValidateVersion(ctx, ts, getVersion, Options{Keyspace: "ks"})
+	require.NoError(t, err)
+	assert.False(t, report.Consistent)
+	assert.Len(t, report.Tablets, 4)
+	assert.Equal(t, map[string]map[string][]string{
+		"-80": {"v11": {"zone1-0000000100", "zone1-0000000101"}},
+		"80-": {"v11": {"zone1-0000000200"}, "v10": {"zone2-0000000201"}},
+	}, report.Matrix)
+
+	report, err = ValidateVersion(ctx, ts, getVersion, Options{Keyspace: "ks", Cells: []string{"zone1"}})
+	require.NoError(t, err)
+	assert.True(t, report.Consistent)
+	assert.Len(t, report.Tablets, 3)
+
+	_, err = ValidateVersion(ctx, ts, getVersion, Options{Keyspace: "ks", Shard: "unknown"})
+	assert.Error(t, err)
+}
@@ -114,8 +114,16 @@ import (
 	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/topo/topoproto"
 	"vitess.io/vitess/go/vt/topotools"
+	"vitess.io/vitess/go/vt/vtctl/fenceutil"
+	"vitess.io/vitess/go/vt/vtctl/grantutil"
+	"vitess.io/vitess/go/vt/vtctl/loadutil"
+	"vitess.io/vitess/go/vt/vtctl/onlineddlutil"
+	"vitess.io/vitess/go/vt/vtctl/tablegcutil"
+	"vitess.io/vitess/go/vt/vtctl/tablettypeutil"
+	"vitess.io/vitess/go/vt/vtctl/validateutil"
 	"vitess.io/vitess/go/vt/vtctl/workflow"
 	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/vtgateconn"
 	"vitess.io/vitess/go/vt/wrangler"
 
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
@@ -169,6 +177,9 @@ var commands = []commandGroup{
 			{"DeleteTablet", commandDeleteTablet,
 				"[-allow_master] <tablet alias> ...",
 				"Deletes tablet(s) from the topology."},
+			{"DecommissionTablet", commandDecommissionTablet,
+				"[-drain_grace=<duration>] <tablet alias>",
+				"Checks the tablet isn't the master, the last replica of its shard, or the source of vreplication streams, then drains it, stops its replication and deletes it from the topology. Refuses with the reasons otherwise."},
 			{"SetReadOnly", commandSetReadOnly,
 				"<tablet alias>",
 				"Sets the tablet as read-only."},
@@ -182,12 +193,25 @@ var commands = []commandGroup{
 				"<tablet alias>",
 				"Stops replication on the specified tablet."},
 			{"ChangeTabletType", commandChangeTabletType,
-				"[-dry-run] <tablet alias> <tablet type>",
+				"[-dry-run] [-force] <tablet alias> <tablet type>",
 				"Changes the db type for the specified tablet, if possible. This command is used primarily to arrange replicas, and it will not convert a master.\n" +
+					"It fails if fewer serving tablets of the former type than the -min_tablets_per_cell minimum would be left in the shard and cell of the tablet, unless -force is set.\n" +
 					"NOTE: This command automatically updates the serving graph.\n"},
+			{"RecommendTabletTypeChange", commandRecommendTabletTypeChange,
+				"<keyspace/shard> <cell> <from tablet type> <to tablet type>",
+				"Lists the tablets of the shard and cell which can be changed from one type to another with ChangeTabletType, as JSON, the ones with the least impact on the serving tablets first."},
 			{"Ping", commandPing,
 				"<tablet alias>",
 				"Checks that the specified tablet is awake and responding to RPCs. This command can be blocked by other in-flight operations."},
+			{"DrainTablet", commandDrainTablet,
+				"<tablet alias>",
+				"Drains the specified non-master tablet: vtgates stop sending new queries to it, and let the in-flight ones finish. The tablet keeps serving and replicating."},
+			{"UndrainTablet", commandUndrainTablet,
+				"<tablet alias>",
+				"Undrains the specified tablet, so that vtgates send queries to it again."},
+			{"SetTabletTrafficWeight", commandSetTabletTrafficWeight,
+				"<tablet alias> <weight>",
+				"Sets the traffic weight of the specified tablet, from 0 to 100 (the default). vtgates send it a share of the queries of its target proportional to its weight, e.g. to ramp up the traffic of a canary tablet. A tablet with a weight of 0 only gets queries when the other tablets fail."},
 			{"RefreshState", commandRefreshState,
 				"<tablet alias>",
 				"Reloads the tablet record on the specified tablet."},
@@ -226,12 +250,18 @@ var commands = []commandGroup{
 			{"GetShard", commandGetShard,
 				"<keyspace/shard>",
 				"Outputs a JSON structure that contains information about the Shard."},
+			{"GetShardServingTimeline", commandGetShardServingTimeline,
+				"<keyspace/shard>",
+				"Outputs a JSON list of the transitions between serving and not serving of the tablets of the shard, with their reason, oldest first."},
 			{"ValidateShard", commandValidateShard,
 				"[-ping-tablets] <keyspace/shard>",
 				"Validates that all nodes that are reachable from this shard are consistent."},
 			{"ShardReplicationPositions", commandShardReplicationPositions,
 				"<keyspace/shard>",
 				"Shows the replication status of each replica machine in the shard graph. In this case, the status refers to the replication lag between the master vttablet and the replica vttablet. In Vitess, data is always written to the master vttablet first and then replicated to all replica vttablets. Output is sorted by tablet type, then replication position. Use ctrl-C to interrupt command and see partial result if needed."},
+			{"GetShardReplicationGraph", commandGetShardReplicationGraph,
+				"<keyspace/shard>",
+				"Outputs a JSON structure with the live MySQL replication topology of the shard: which tablet each tablet replicates from, the state of its replication threads and its lag. The tablets which don't replicate from the master of the shard in the topo, or whose replication is stopped, are listed as mismatches."},
 			{"ListShardTablets", commandListShardTablets,
 				"<keyspace/shard>",
 				"Lists all tablets in the specified shard."},
@@ -247,6 +277,9 @@ var commands = []commandGroup{
 			{"UpdateSrvKeyspacePartition", commandUpdateSrvKeyspacePartition,
 				"[--cells=c1,c2,...] [--remove] <keyspace/shard> <tablet type>",
 				"Updates KeyspaceGraph partition for a shard and type. Only use this for an emergency fix during an horizontal shard split. The *MigrateServedType* commands set this field appropriately already. Specify the remove flag, if you want the shard to be removed from the desired partition."},
+			{"UpdateSrvKeyspace", commandUpdateSrvKeyspace,
+				"[-expected_version=<version>] [-dry_run] {-srv_keyspace=<JSON> || -srv_keyspace_file=<JSON file>} <cell> <keyspace>",
+				"Replaces the SrvKeyspace of a keyspace in a cell, e.g. during a manual recovery. Its partitions are validated first: each served type must have a single partition, whose shards cover the whole key space without gaps or overlaps. The update fails if the SrvKeyspace is not at the expected version anymore, as output by GetSrvKeyspaceWithVersion. Without an expected version, the SrvKeyspace is created. With -dry_run, it is only validated."},
 			{"SourceShardDelete", commandSourceShardDelete,
 				"<keyspace/shard> <uid>",
 				"Deletes the SourceShard record with the provided index. This is meant as an emergency cleanup function. It does not call RefreshState for the shard master."},
@@ -271,6 +304,15 @@ var commands = []commandGroup{
 			{"DeleteShard", commandDeleteShard,
 				"[-recursive] [-even_if_serving] <keyspace/shard> ...",
 				"Deletes the specified shard(s). In recursive mode, it also deletes all tablets belonging to the shard. Otherwise, there must be no tablets left in the shard."},
+			{"FenceShard", commandFenceShard,
+				"[-reason <reason>] <keyspace/shard>",
+				"Stops the writes of the specified shard: the vtgates stop sending queries to its master, and the master becomes read-only. Prints the fence, with the final replication position of the master."},
+			{"UnfenceShard", commandUnfenceShard,
+				"<keyspace/shard>",
+				"Lets the specified fenced shard accept writes again."},
+			{"GetShardFence", commandGetShardFence,
+				"<keyspace/shard>",
+				"Outputs a JSON structure that contains the fence of the specified shard."},
 		},
 	},
 	{
@@ -281,6 +323,12 @@ var commands = []commandGroup{
 			{"DeleteKeyspace", commandDeleteKeyspace,
 				"[-recursive] <keyspace>",
 				"Deletes the specified keyspace. In recursive mode, it also recursively deletes all shards in the keyspace. Otherwise, there must be no shards left in the keyspace."},
+			{"TimeTravel", commandTimeTravel,
+				"[-dry_run] <keyspace> <time>",
+				"Creates the time-travel keyspace <keyspace>_asof_<time>, a SNAPSHOT keyspace of the keyspace as of the time in UTC, in RFC3339 time format, and provisions its tablets with the provision_time_travel_tablet hook. Outputs the backup each shard restores and the binlogs it replays. Its tables are only reachable by qualifying them with the time-travel keyspace. With -dry_run, only outputs the backups and binlogs."},
+			{"DeleteTimeTravel", commandDeleteTimeTravel,
+				"<time-travel keyspace>",
+				"Deprovisions the tablets of the time-travel keyspace with the deprovision_time_travel_tablet hook, and deletes it."},
 			{"RemoveKeyspaceCell", commandRemoveKeyspaceCell,
 				"[-force] [-recursive] <keyspace> <cell>",
 				"Removes the cell from the Cells list for all shards in the keyspace, and the SrvKeyspace for that keyspace in that cell."},
@@ -296,12 +344,54 @@ var commands = []commandGroup{
 			{"SetKeyspaceServedFrom", commandSetKeyspaceServedFrom,
 				"[-source=<source keyspace name>] [-remove] [-cells=c1,c2,...] <keyspace name> <tablet type>",
 				"Changes the ServedFromMap manually. This command is intended for emergency fixes. This field is automatically set when you call the *MigrateServedFrom* command. This command does not rebuild the serving graph."},
+			{"SetKeyspaceServingFlags", commandSetKeyspaceServingFlags,
+				"<keyspace name> <flag1,flag2,...>",
+				"Sets the serving flags of the keyspace, which describe the operations in progress on it, e.g. resharding_in_progress or read_only_maintenance. The vtgates started with -enable_keyspace_serving_flags show them to their clients with SHOW VITESS_KEYSPACE_FLAGS and @@vitess_keyspace_flags, so applications can adapt, e.g. defer their writes. An empty list removes them."},
+			{"GetKeyspaceServingFlags", commandGetKeyspaceServingFlags,
+				"<keyspace name>",
+				"Outputs the serving flags of the keyspace."},
+			{"SetReplicationLagThresholds", commandSetReplicationLagThresholds,
+				"[-tablet_type=<tablet type>] <keyspace name> <soft threshold> <hard threshold>",
+				"Sets the replication lag thresholds vtgate applies to the tablets of the keyspace, or of one of its tablet types with -tablet_type. Tablets lagging more than the soft threshold are only used to keep -min_number_serving_vttablets tablets serving, and tablets lagging more than the hard threshold are never used. They override -discovery_low_replication_lag and -discovery_high_replication_lag_minimum_serving, which apply to a threshold of 0. Thresholds of 0 0 remove them. Only the vtgates started with -enable_keyspace_replication_lag_thresholds apply them."},
+			{"GetReplicationLagThresholds", commandGetReplicationLagThresholds,
+				"<keyspace name>",
+				"Outputs a JSON structure that contains the replication lag thresholds of the keyspace and of its tablet types."},
+			{"SetCellServingOverride", commandSetCellServingOverride,
+				"[-do_not_serve] [-reason=<reason>] <keyspace name> <cell>",
+				"Marks the cell as \"do not serve\" for the keyspace with -do_not_serve, or removes the mark without it. The vtgates of the other cells don't route the queries of the keyspace to the tablets of a marked cell, whatever their health, which isolates a misbehaving region without changing its tablets. The queries to the primary are still routed to it. Only the vtgates started with -enable_keyspace_cell_serving_overrides apply the marks."},
+			{"GetCellServingOverride", commandGetCellServingOverride,
+				"[-cell=<cell>] <keyspace name>",
+				"Outputs a JSON structure that contains the cells marked as \"do not serve\" for the keyspace, and why, or only the mark of one cell with -cell."},
+			{"ApplyQueryQuotas", commandApplyQueryQuotas,
+				"{-quotas=<quotas> || -quotas_file=<quotas_file>}",
+				"Replaces the query quotas enforced by vtgate on the queries of the users, e.g. {\"default\": {\"qps\": 100}, \"users\": {\"batch\": {\"concurrent_queries\": 4, \"rows_per_minute\": 1000000, \"scatter_queries_per_minute\": 60}}}. The quotas of a user apply on each vtgate, which reloads them every -query_quotas_refresh_interval. Empty quotas remove them."},
+			{"GetQueryQuotas", commandGetQueryQuotas,
+				"",
+				"Outputs a JSON structure that contains the query quotas of the users."},
+			{"PinQuery", commandPinQuery,
+				"[-expire_after=<duration>] [-comment=<comment>] <keyspace name> <query> <replacement>",
+				"Makes the vttablets of the keyspace execute the replacement query instead of the query, e.g. to force an index when the MySQL optimizer picks a bad plan. The query is the normalized query received by vttablet, and the replacement must be the same kind of statement, taking the same bind variables. The vttablets reload the pins every -query_pins_refresh_interval."},
+			{"UnpinQuery", commandUnpinQuery,
+				"[-expired] <keyspace name> [<query>]",
+				"Removes the pin of the query, or all the expired pins of the keyspace with -expired."},
+			{"ListQueryPins", commandListQueryPins,
+				"<keyspace name>",
+				"Outputs a JSON structure that contains the query pins of the keyspace."},
 			{"RebuildKeyspaceGraph", commandRebuildKeyspaceGraph,
-				"[-cells=c1,c2,...] [-allow_partial] <keyspace> ...",
-				"Rebuilds the serving data for the keyspace. This command may trigger an update to all connected clients."},
+				"[-cells=c1,c2,...] [-allow_partial] [-report] <keyspace> ...",
+				"Rebuilds the serving data for the keyspace. This command may trigger an update to all connected clients. With -report, outputs a JSON structure that describes the rebuild in each cell: its error, the previous and new versions of the SrvKeyspace, and the changes of its partitions."},
 			{"ValidateKeyspace", commandValidateKeyspace,
 				"[-ping-tablets] <keyspace name>",
 				"Validates that all nodes reachable from the specified keyspace are consistent."},
+			{"ValidateBlacklistedTables", commandValidateBlacklistedTables,
+				"[-tablet_type=master] [-tables=<table1,table2,...>] [-probe=false] [-probe_timeout=<duration>] <keyspace name>",
+				"Validates that the blacklisted tables are set consistently in all the shard records of the keyspace. Unless -probe=false, also sends a query for every blacklisted table to every tablet of the given type, and reports the tablets still serving a blacklisted table. Blacklisted regular expressions are expanded to the matching tables of the tablet schema."},
+			{"LoadData", commandLoadData,
+				"[-columns=<column1,column2,...>] [-batch_size=1000] [-max_rows_per_second=0] <keyspace name> <table> <storage dir> <storage name> <file> [<file> ...]",
+				"Loads CSV files into a table. The files are read from the backup storage of -backup_storage_implementation, in the backup of the given directory and name. Each row is routed with the primary vindex of the table, and inserted in batches on the master of its shard. \\N is NULL. The first line of each file lists the columns, unless -columns is set. The rows of reference tables are inserted on all the shards. Tables which own a vindex or whose primary vindex needs lookups are not supported. Outputs the progress of each shard."},
+			{"SeedTable", commandSeedTable,
+				"[-batch_size=1000] [-max_rows_per_second=0] [-max_source_rows=100000] <keyspace name> <table>",
+				"Inserts the rows of the seed source of a table on the masters of its shards, routed like the ones of LoadData. The seed source is declared in the seed of the table in the VSchema: either a file of INSERT statements in the backup storage of -backup_storage_implementation, in the backup of the given directory and name, or a table of another keyspace. The rows whose keys already exist are skipped, so seeding again is safe. Outputs the progress of each shard."},
 			{"Reshard", commandReshard,
 				"[-cells=<cells>] [-tablet_types=<source_tablet_types>] [-skip_schema_copy] <keyspace.workflow> <source_shards> <target_shards>",
 				"Start a Resharding process. Example: Reshard -cells='zone1,alias1' -tablet_types='master,replica,rdonly'  ks.workflow001 '0' '-80,80-'"},
@@ -330,8 +420,11 @@ var commands = []commandGroup{
 				"<from_keyspace> <to_keyspace> <tables>",
 				"Start the VerticalSplitClone process to perform vertical resharding. Example: SplitClone from_ks to_ks 'a,/b.*/'"},
 			{"VDiff", commandVDiff,
-				"[-source_cell=<cell>] [-target_cell=<cell>] [-tablet_types=replica] [-filtered_replication_wait_time=30s] <keyspace.workflow>",
-				"Perform a diff of all tables in the workflow"},
+				"[-source_cell=<cell>] [-target_cell=<cell>] [-tablet_types=replica] [-filtered_replication_wait_time=30s] [-max_source_lag=<duration> [-wait_for_source_catchup]] [-save_results] [-incremental_column=<column>] <keyspace.workflow>",
+				"Perform a diff of all tables in the workflow. With -save_results, the result is stored and can be read with ListVDiffResults. With -incremental_column, only the rows whose value of this update timestamp column changed since the previous stored VDiff are compared, and the result is stored. To run a VDiff periodically, create a workflow schedule of the vdiff workflow, e.g. WorkflowScheduleCreate hourly_vdiff '0 * * * *' vdiff -incremental_column=updated_at <keyspace.workflow>"},
+			{"ListVDiffResults", commandListVDiffResults,
+				"[-limit=<count>] <keyspace.workflow>",
+				"Outputs a JSON structure with the stored results of the VDiffs of the workflow, from the newest."},
 			{"MigrateServedTypes", commandMigrateServedTypes,
 				"[-cells=c1,c2,...] [-reverse] [-skip-refresh-state] [-filtered_replication_wait_time=30s] [-reverse_replication=false] <keyspace/shard> <served tablet type>",
 				"Migrates a serving type from the source shard to the shards that it replicates to. This command also rebuilds the serving graph. The <keyspace/shard> argument can specify any of the shards involved in the migration."},
@@ -341,6 +434,9 @@ var commands = []commandGroup{
 			{"SwitchReads", commandSwitchReads,
 				"[-cells=c1,c2,...] [-reverse] -tablet_type={replica|rdonly} [-dry-run] <keyspace.workflow>",
 				"Switch read traffic for the specified workflow."},
+			{"SplitReads", commandSplitReads,
+				"[-cells=c1,c2,...] [-tablet_types=replica,rdonly] <percent> <keyspace.workflow>",
+				"Switches a percentage of the read traffic of the specified MoveTables workflow to its target keyspace, e.g. 1, 10, 50, then 100. 0 switches all the reads back to the source keyspace, and 100 is the same as SwitchReads."},
 			{"SwitchWrites", commandSwitchWrites,
 				"[-timeout=30s] [-reverse] [-reverse_replication=true] [-dry-run] <keyspace.workflow>",
 				"Switch write traffic for the specified workflow."},
@@ -403,21 +499,43 @@ var commands = []commandGroup{
 				"[-exclude_tables=''] [-include-views] [-skip-no-master] [-include-vschema] <keyspace name>",
 				"Validates that the master schema from shard 0 matches the schema on all of the other tablets in the keyspace."},
 			{"ApplySchema", commandApplySchema,
-				"[-allow_long_unavailability] [-wait_replicas_timeout=10s] [-ddl_strategy=<ddl_strategy>] [-request_context=<unique-request-context>] [-skip_preflight] {-sql=<sql> || -sql-file=<filename>} <keyspace>",
-				"Applies the schema change to the specified keyspace on every master, running in parallel on all shards. The changes are then propagated to replicas via replication. If -allow_long_unavailability is set, schema changes affecting a large number of rows (and possibly incurring a longer period of unavailability) will not be rejected. -ddl_strategy is used to intruct migrations via vreplication, gh-ost or pt-osc with optional parameters. -request_context allows the user to specify a custom request context for online DDL migrations. If -skip_preflight, SQL goes directly to shards without going through sanity checks"},
+				"[-allow_long_unavailability] [-wait_replicas_timeout=10s] [-ddl_strategy=<ddl_strategy>] [-request_context=<unique-request-context>] [-skip_preflight] [-seed_created_tables] [-desired_state] [-allow_drops] {-sql=<sql> || -sql-file=<filename>} <keyspace>",
+				"Applies the schema change to the specified keyspace on every master, running in parallel on all shards. The changes are then propagated to replicas via replication. If -allow_long_unavailability is set, schema changes affecting a large number of rows (and possibly incurring a longer period of unavailability) will not be rejected. -ddl_strategy is used to intruct migrations via vreplication, gh-ost or pt-osc with optional parameters. -request_context allows the user to specify a custom request context for online DDL migrations. If -skip_preflight, SQL goes directly to shards without going through sanity checks. If -seed_created_tables, the tables created by the change which have a seed source are then seeded, see SeedTable. If -desired_state, the SQL is the CREATE TABLE statements of all the tables the keyspace should have, and the tables to create, alter and drop to converge to them are applied as declarative online DDL migrations of the -ddl_strategy, see PreviewDeclarativeSchema. The tables which aren't desired are only dropped with -allow_drops"},
+			{"PreviewDeclarativeSchema", commandPreviewDeclarativeSchema,
+				"[-allow_drops] {-sql=<sql> || -sql-file=<filename>} <keyspace>",
+				"Outputs as JSON the plan of ApplySchema -desired_state: the tables to create, alter and drop to converge the schema of the keyspace to the CREATE TABLE statements of the SQL, compared to the schema of the master of its first shard. The ALTER TABLE statements are a preview, as each tablet computes the ALTER of a table against its own schema when the plan is applied."},
 			{"CopySchemaShard", commandCopySchemaShard,
 				"[-tables=<table1>,<table2>,...] [-exclude_tables=<table1>,<table2>,...] [-include-views] [-skip-verify] [-wait_replicas_timeout=10s] {<source keyspace/shard> || <source tablet alias>} <destination keyspace/shard>",
 				"Copies the schema from a source shard's master (or a specific tablet) to a destination shard. The schema is applied directly on the master of the destination shard, and it is propagated to the replicas through binlogs."},
 			{"OnlineDDL", commandOnlineDDL,
-				"<keyspace> <command> [<migration_uuid>]",
-				"Operates on online DDL (migrations). Examples:" +
+				"[-preview_max_rows=<rows>] [-preview_timeout=<timeout>] [-preview_max_examined_rows=<rows>] <keyspace> <command> [<migration_uuid>] [<query>]",
+				"Operates on online DDL (migrations). progress outputs as JSON the progress of a migration on each shard and aggregated over them: the rows copied, the ETA, whether the throttler pushes back on it, and the lag of its vreplication streams for the online strategy. preview runs a read-only SELECT of the migrated table against the shadow table of a running migration of the online strategy, on a replica of each shard, to verify the transformed data before the cut-over. Examples:" +
 					" \nvtctl OnlineDDL test_keyspace show 82fa54ac_e83e_11ea_96b7_f875a4d24e90" +
 					" \nvtctl OnlineDDL test_keyspace show all" +
 					" \nvtctl OnlineDDL test_keyspace show running" +
 					" \nvtctl OnlineDDL test_keyspace show complete" +
 					" \nvtctl OnlineDDL test_keyspace show failed" +
+					" \nvtctl OnlineDDL test_keyspace progress 82fa54ac_e83e_11ea_96b7_f875a4d24e90" +
 					" \nvtctl OnlineDDL test_keyspace retry 82fa54ac_e83e_11ea_96b7_f875a4d24e90" +
-					" \nvtctl OnlineDDL test_keyspace cancel 82fa54ac_e83e_11ea_96b7_f875a4d24e90",
+					" \nvtctl OnlineDDL test_keyspace cancel 82fa54ac_e83e_11ea_96b7_f875a4d24e90" +
+					" \nvtctl OnlineDDL test_keyspace preview 82fa54ac_e83e_11ea_96b7_f875a4d24e90 \"select count(*) from customer where email is null\"",
+			},
+			{"RevertMigration", commandRevertMigration,
+				"[-dry_run] [-request_context=<context>] <keyspace> <migration_uuid>",
+				"Reverts a complete online DDL migration, if it's still within its revert window on all the shards: the window during which its artifacts, such as the table before an ALTER, are kept. The artifacts are kept for -retain_online_ddl_tables of the tablets, or for the -retain-artifacts=<duration> option of the ddl_strategy of the migration. The revert of an ALTER of the online strategy replays into the former table the writes made since the cut-over, so no write is lost. Outputs as JSON the UUID of the revert migration and the revert windows of the migration."},
+			{"ApproveMigration", commandApproveMigration,
+				"-reviewer=<identity> <keyspace> <migration_uuid>",
+				"Approves an online DDL migration pending approval, i.e. submitted with the -require-approval option of the ddl_strategy or to tablets started with -online_ddl_require_approval. The migration is then queued on all the shards. The reviewer is recorded as the identity which approved it."},
+			{"RejectMigration", commandRejectMigration,
+				"-reviewer=<identity> [-message=<reason>] <keyspace> <migration_uuid>",
+				"Rejects an online DDL migration pending approval. The migration is then cancelled on all the shards. The reviewer and the reason are recorded. A retry of the migration puts it back pending approval."},
+			{"TableGC", commandTableGC,
+				"[-retention=<duration>] [-to_table=<table>] <keyspace> <command> [<state>] [<uuid>...]",
+				"Operates on the tables garbage collected by the masters of the keyspace, which go through the HOLD, PURGE, EVAC and DROP states after a drop. list outputs as JSON the GC tables by state. retain sets the time the HOLD or EVAC tables, or only those of the given UUIDs, move to their next state to now plus -retention. advance moves the HOLD or EVAC tables of a UUID to their next state at the next check of the table GC. restore renames the HOLD tables of a UUID back to -to_table, or to the table dropped by the online DDL migration of the UUID. A UUID is a GC UUID, an online DDL UUID or a GC table name. Examples:" +
+					" \nvtctl TableGC test_keyspace list" +
+					" \nvtctl TableGC -retention=72h test_keyspace retain HOLD" +
+					" \nvtctl TableGC test_keyspace advance 82fa54ac_e83e_11ea_96b7_f875a4d24e90" +
+					" \nvtctl TableGC test_keyspace restore 82fa54ac_e83e_11ea_96b7_f875a4d24e90",
 			},
 
 			{"ValidateVersionShard", commandValidateVersionShard,
@@ -426,6 +544,9 @@ var commands = []commandGroup{
 			{"ValidateVersionKeyspace", commandValidateVersionKeyspace,
 				"<keyspace name>",
 				"Validates that the master version from shard 0 matches all of the other tablets in the keyspace."},
+			{"ValidateVersion", commandValidateVersion,
+				"[-cells=c1,c2,...] [-concurrency=10] <keyspace|keyspace/shard>",
+				"Outputs a JSON report of the versions of the tablets of the keyspace or shard, with the tablets running each version by shard. Fails if they don't all run the same version."},
 
 			{"GetPermissions", commandGetPermissions,
 				"<tablet alias>",
@@ -436,6 +557,30 @@ var commands = []commandGroup{
 			{"ValidatePermissionsKeyspace", commandValidatePermissionsKeyspace,
 				"<keyspace name>",
 				"Validates that the master permissions from shard 0 match those of all of the other tablets in the keyspace."},
+			{"ValidatePermissions", commandValidatePermissions,
+				"[-cells=c1,c2,...] [-concurrency=10] <keyspace|keyspace/shard>",
+				"Outputs a JSON report of the grants of each tablet of the keyspace or shard which differ from the ones of the master of the first shard. Fails if the permissions differ."},
+			{"ValidateConfig", commandValidateConfig,
+				"[-cells=c1,c2,...] [-concurrency=10] [-mysql_variables=v1,v2,...] [-tablet_flags=f1,f2,...] [-golden_config=<json>|-golden_config_file=<file>] <keyspace|keyspace/shard>",
+				"Outputs a JSON report of the MySQL global variables and vttablet flags of each tablet of the keyspace or shard which drift from the golden config, or from the value of the majority of the tablets for the settings the golden config doesn't have. The golden config is a JSON object with mysql_variables and flags objects mapping the names of the settings to their expected values. Fails if a tablet drifts."},
+			{"SetMySQLUsers", commandSetMySQLUsers,
+				"{-users=<json> || -users_file=<file>} <keyspace>",
+				"Declares the MySQL users of the tablets of the keyspace, with their authentication and their privileges on *.* and on databases, in the JSON format output by GetMySQLUsers. The authentication string is the hash of the password, never the password itself. No users remove the declaration. The MySQL instances are only changed by ApplyMySQLUsers."},
+			{"GetMySQLUsers", commandGetMySQLUsers,
+				"<keyspace>",
+				"Outputs a JSON structure that contains the MySQL users declared for the tablets of the keyspace."},
+			{"CheckMySQLUsers", commandCheckMySQLUsers,
+				"[-cells=c1,c2,...] [-concurrency=10] <keyspace|keyspace/shard>",
+				"Outputs a JSON report of how the MySQL users of each tablet of the keyspace or shard drift from the declared ones. The users which aren't declared are ignored. Fails if there is any drift."},
+			{"ApplyMySQLUsers", commandApplyMySQLUsers,
+				"[-dry_run] [-wait_replicas_timeout=30s] [-concurrency=10] <keyspace>",
+				"Creates the declared MySQL users which are missing on the masters of the keyspace, and grants and revokes their privileges to match the declared ones, then waits for the replicas to converge through replication. Outputs a JSON report of the statements executed on each master and of the remaining drift of the tablets. With -dry_run, only outputs the statements which would be executed. Fails if the replicas don't converge."},
+			{"SetCDCSinks", commandSetCDCSinks,
+				"{-sinks=<json> || -sinks_file=<file>} <keyspace>",
+				"Declares the CDC sinks of the keyspace, in the JSON format output by GetCDCSinks. Each sink publishes the row changes of its tables, or of all the tables, to a topic. The CDC sink service restarts the sinks which changed. No sinks remove the declaration."},
+			{"GetCDCSinks", commandGetCDCSinks,
+				"<keyspace>",
+				"Outputs a JSON structure that contains the CDC sinks declared for the keyspace."},
 
 			{"GetVSchema", commandGetVSchema,
 				"<keyspace>",
@@ -450,8 +595,8 @@ var commands = []commandGroup{
 				"{-rules=<rules> || -rules_file=<rules_file>} [-cells=c1,c2,...] [-skip_rebuild] [-dry-run]",
 				"Applies the VSchema routing rules."},
 			{"RebuildVSchemaGraph", commandRebuildVSchemaGraph,
-				"[-cells=c1,c2,...]",
-				"Rebuilds the cell-specific SrvVSchema from the global VSchema objects in the provided cells (or all cells if none provided)."},
+				"[-cells=c1,c2,...] [-report]",
+				"Rebuilds the cell-specific SrvVSchema from the global VSchema objects in the provided cells (or all cells if none provided). With -report, outputs a JSON structure that describes the rebuild in each cell: its error, the previous and new versions of the SrvVSchema, and its changes."},
 		},
 	},
 	{
@@ -462,6 +607,9 @@ var commands = []commandGroup{
 			{"GetSrvKeyspace", commandGetSrvKeyspace,
 				"<cell> <keyspace>",
 				"Outputs a JSON structure that contains information about the SrvKeyspace."},
+			{"GetSrvKeyspaceWithVersion", commandGetSrvKeyspaceWithVersion,
+				"<cell> <keyspace>",
+				"Outputs a JSON structure that contains the SrvKeyspace and its version, to edit it with UpdateSrvKeyspace."},
 			{"GetSrvVSchema", commandGetSrvVSchema,
 				"<cell>",
 				"Outputs a JSON structure that contains information about the SrvVSchema."},
@@ -491,6 +639,22 @@ var commands = []commandGroup{
 				"<ks.workflow> <action> --dry-run",
 				"Start/Stop/Delete/Show/ListAll Workflow on all target tablets in workflow. Example: Workflow merchant.morders Start",
 			},
+			{"GetWorkflowProgress", commandGetWorkflowProgress,
+				"<ks.workflow>",
+				"Outputs a JSON structure with the progress of a vreplication workflow, e.g. MoveTables or Reshard: for each stream, the rows copied against the rows estimated from the source tables, the copy throughput, the replication lag and the last error, and their aggregates over the workflow.",
+			},
+			{"VerifyReverseReplication", commandVerifyReverseReplication,
+				"[-max_lag=30s] [-checksum] [-sample_rate=100] <ks.workflow>",
+				"After SwitchTraffic switched the writes of a workflow, verifies that its reverse streams exist on all the source shards, are running and have caught up, and with -checksum compares the checksums of a sample of the rows of the moved tables between the source and the target. Outputs the report as JSON, and fails if the verification fails, so it can be run before Complete.",
+			},
+			{"UpdateWorkflowThrottling", commandUpdateWorkflowThrottling,
+				"[-rows_per_sec=<rows>] [-bytes_per_sec=<bytes>] [-max_concurrent_copies=<streams>] <ks.workflow>",
+				"Changes the rate limits of the copy phase of a vreplication workflow on each of its target primary tablets, without restarting its streams. Only the limits passed are changed, and 0 removes a limit. The tablets apply them within -vreplication_workflow_throttling_refresh_interval. Outputs the resulting limits as JSON.",
+			},
+			{"GetWorkflowThrottling", commandGetWorkflowThrottling,
+				"<keyspace name>",
+				"Outputs a JSON structure with the rate limits of the vreplication workflows of the keyspace, by workflow name.",
+			},
 		},
 	},
 }
@@ -777,6 +941,21 @@ func commandUpdateTabletAddrs(ctx context.Context, wr *wrangler.Wrangler, subFla
 	return err
 }
 
+func commandDecommissionTablet(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	drainGrace := subFlags.Duration("drain_grace", 10*time.Second, "time to wait after draining the tablet for its in-flight queries to finish")
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("the <tablet alias> argument is required for the DecommissionTablet command")
+	}
+	tabletAlias, err := topoproto.ParseTabletAlias(subFlags.Arg(0))
+	if err != nil {
+		return err
+	}
+	return wr.DecommissionTablet(ctx, tabletAlias, *drainGrace)
+}
+
 func commandDeleteTablet(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
 	allowMaster := subFlags.Bool("allow_master", false, "Allows for the master tablet of a shard to be deleted. Use with caution.")
 	if err := subFlags.Parse(args); err != nil {
@@ -876,6 +1055,7 @@ func commandStopReplication(ctx context.Context, wr *wrangler.Wrangler, subFlags
 
 func commandChangeTabletType(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
 	dryRun := subFlags.Bool("dry-run", false, "Lists the proposed change without actually executing it")
+	force := subFlags.Bool("force", false, "Changes the type even if it leaves fewer serving tablets of the former type than the -min_tablets_per_cell minimum")
 
 	if err := subFlags.Parse(args); err != nil {
 		return err
@@ -907,9 +1087,50 @@ func commandChangeTabletType(ctx context.Context, wr *wrangler.Wrangler, subFlag
 		wr.Logger().Printf("- %v\n", fmtTabletAwkable(ti))
 		ti.Type = newType
 		wr.Logger().Printf("+ %v\n", fmtTabletAwkable(ti))
+		impact, err := tablettypeutil.Impact(ctx, wr.TopoServer(), ti.Tablet, newType)
+		if err != nil {
+			return err
+		}
+		if impact != nil && impact.Violation != "" {
+			wr.Logger().Warningf("%v\n", impact.Violation)
+		}
 		return nil
 	}
-	return wr.ChangeTabletType(ctx, tabletAlias, newType)
+	return wr.ChangeTabletType(ctx, tabletAlias, newType, *force)
+}
+
+func commandRecommendTabletTypeChange(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 4 {
+		return fmt.Errorf("the <keyspace/shard>, <cell>, <from tablet type> and <to tablet type> arguments are required for the RecommendTabletTypeChange command")
+	}
+
+	keyspace, shard, err := topoproto.ParseKeyspaceShard(subFlags.Arg(0))
+	if err != nil {
+		return err
+	}
+	from, err := parseTabletType(subFlags.Arg(2), topoproto.AllTabletTypes)
+	if err != nil {
+		return err
+	}
+	to, err := parseTabletType(subFlags.Arg(3), topoproto.AllTabletTypes)
+	if err != nil {
+		return err
+	}
+
+	resp, err := wr.VtctldServer().RecommendTabletTypeChange(ctx, &vtctldatapb.RecommendTabletTypeChangeRequest{
+		Keyspace: keyspace,
+		Shard:    shard,
+		Cell:     subFlags.Arg(1),
+		FromType: from,
+		ToType:   to,
+	})
+	if err != nil {
+		return err
+	}
+	return printJSON(wr.Logger(), resp)
 }
 
 func commandPing(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
@@ -930,6 +1151,60 @@ func commandPing(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.Flag
 	return wr.TabletManagerClient().Ping(ctx, tabletInfo.Tablet)
 }
 
+func commandDrainTablet(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("the <tablet alias> argument is required for the DrainTablet command")
+	}
+	tabletAlias, err := topoproto.ParseTabletAlias(subFlags.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	_, err = wr.VtctldServer().DrainTablet(ctx, &vtctldatapb.DrainTabletRequest{
+		TabletAlias: tabletAlias,
+	})
+	return err
+}
+
+func commandUndrainTablet(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("the <tablet alias> argument is required for the UndrainTablet command")
+	}
+	tabletAlias, err := topoproto.ParseTabletAlias(subFlags.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	_, err = wr.VtctldServer().UndrainTablet(ctx, &vtctldatapb.UndrainTabletRequest{
+		TabletAlias: tabletAlias,
+	})
+	return err
+}
+
+func commandSetTabletTrafficWeight(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 2 {
+		return fmt.Errorf("the <tablet alias> and <weight> arguments are required for the SetTabletTrafficWeight command")
+	}
+	tabletAlias, err := topoproto.ParseTabletAlias(subFlags.Arg(0))
+	if err != nil {
+		return err
+	}
+	weight, err := strconv.Atoi(subFlags.Arg(1))
+	if err != nil {
+		return fmt.Errorf("invalid weight %v: %v", subFlags.Arg(1), err)
+	}
+	return wr.SetTabletTrafficWeight(ctx, tabletAlias, weight)
+}
+
 func commandRefreshState(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
 	if err := subFlags.Parse(args); err != nil {
 		return err
@@ -1220,6 +1495,28 @@ func commandGetShard(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.
 	return printJSON(wr.Logger(), shardInfo.Shard)
 }
 
+func commandGetShardServingTimeline(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("the <keyspace/shard> argument is required for the GetShardServingTimeline command")
+	}
+
+	keyspace, shard, err := topoproto.ParseKeyspaceShard(subFlags.Arg(0))
+	if err != nil {
+		return err
+	}
+	resp, err := wr.VtctldServer().GetShardServingTimeline(ctx, &vtctldatapb.GetShardServingTimelineRequest{
+		Keyspace: keyspace,
+		Shard:    shard,
+	})
+	if err != nil {
+		return err
+	}
+	return printJSON(wr.Logger(), resp)
+}
+
 func commandValidateShard(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
 	pingTablets := subFlags.Bool("ping-tablets", true, "Indicates whether all tablets should be pinged during the validation process")
 	if err := subFlags.Parse(args); err != nil {
@@ -1272,6 +1569,28 @@ func commandShardReplicationPositions(ctx context.Context, wr *wrangler.Wrangler
 	return nil
 }
 
+func commandGetShardReplicationGraph(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("the <keyspace/shard> argument is required for the GetShardReplicationGraph command")
+	}
+	keyspace, shard, err := topoproto.ParseKeyspaceShard(subFlags.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	resp, err := wr.VtctldServer().GetShardReplicationGraph(ctx, &vtctldatapb.GetShardReplicationGraphRequest{
+		Keyspace: keyspace,
+		Shard:    shard,
+	})
+	if err != nil {
+		return err
+	}
+	return printJSON(wr.Logger(), resp.Graph)
+}
+
 func commandListShardTablets(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
 	if err := subFlags.Parse(args); err != nil {
 		return err
@@ -1351,6 +1670,51 @@ func commandUpdateSrvKeyspacePartition(ctx context.Context, wr *wrangler.Wrangle
 	return nil
 }
 
+func commandUpdateSrvKeyspace(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	expectedVersion := subFlags.String("expected_version", "", "The version of the SrvKeyspace being replaced, as output by GetSrvKeyspaceWithVersion. Empty creates the SrvKeyspace.")
+	dryRun := subFlags.Bool("dry_run", false, "Only validates the SrvKeyspace")
+	srvKeyspaceJSON := subFlags.String("srv_keyspace", "", "The SrvKeyspace, as JSON")
+	srvKeyspaceFile := subFlags.String("srv_keyspace_file", "", "A file containing the SrvKeyspace, as JSON")
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 2 {
+		return fmt.Errorf("the <cell> and <keyspace> arguments are required for the UpdateSrvKeyspace command")
+	}
+	if (*srvKeyspaceJSON == "") == (*srvKeyspaceFile == "") {
+		return fmt.Errorf("exactly one of the -srv_keyspace and -srv_keyspace_file flags is required for the UpdateSrvKeyspace command")
+	}
+	cell := subFlags.Arg(0)
+	keyspace := subFlags.Arg(1)
+
+	data := []byte(*srvKeyspaceJSON)
+	if *srvKeyspaceFile != "" {
+		var err error
+		data, err = ioutil.ReadFile(*srvKeyspaceFile)
+		if err != nil {
+			return err
+		}
+	}
+	srvKeyspace := &topodatapb.SrvKeyspace{}
+	if err := json2.Unmarshal(data, srvKeyspace); err != nil {
+		return fmt.Errorf("cannot parse SrvKeyspace: %v", err)
+	}
+
+	if *dryRun {
+		if err := topo.ValidateSrvKeyspacePartitions(srvKeyspace); err != nil {
+			return fmt.Errorf("invalid SrvKeyspace: %v", err)
+		}
+		wr.Logger().Printf("Dry run: the SrvKeyspace is valid, skipping its update\n")
+		return nil
+	}
+	version, err := wr.UpdateSrvKeyspace(ctx, cell, keyspace, srvKeyspace, *expectedVersion)
+	if err != nil {
+		return err
+	}
+	wr.Logger().Printf("New version of the SrvKeyspace: %v\n", version)
+	return nil
+}
+
 func commandSetShardTabletControl(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
 	cellsStr := subFlags.String("cells", "", "Specifies a comma-separated list of cells to update")
 	blacklistedTablesStr := subFlags.String("blacklisted_tables", "", "Specifies a comma-separated list of tables to blacklist (used for vertical split). Each is either an exact match, or a regular expression of the form '/regexp/'.")
@@ -1560,6 +1924,64 @@ func commandDeleteShard(ctx context.Context, wr *wrangler.Wrangler, subFlags *fl
 	return nil
 }
 
+func commandFenceShard(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	reason := subFlags.String("reason", "", "Records why the shard is fenced")
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("the <keyspace/shard> argument is required for the FenceShard command")
+	}
+
+	keyspace, shard, err := topoproto.ParseKeyspaceShard(subFlags.Arg(0))
+	if err != nil {
+		return err
+	}
+	fence, err := fenceutil.FenceShard(ctx, wr.TopoServer(), wr.TabletManagerClient(), wr.Logger(), keyspace, shard, *reason)
+	if err != nil {
+		return err
+	}
+	return printJSON(wr.Logger(), fence)
+}
+
+func commandUnfenceShard(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("the <keyspace/shard> argument is required for the UnfenceShard command")
+	}
+
+	keyspace, shard, err := topoproto.ParseKeyspaceShard(subFlags.Arg(0))
+	if err != nil {
+		return err
+	}
+	_, err = fenceutil.UnfenceShard(ctx, wr.TopoServer(), wr.TabletManagerClient(), wr.Logger(), keyspace, shard)
+	return err
+}
+
+func commandGetShardFence(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("the <keyspace/shard> argument is required for the GetShardFence command")
+	}
+
+	keyspace, shard, err := topoproto.ParseKeyspaceShard(subFlags.Arg(0))
+	if err != nil {
+		return err
+	}
+	fence, err := fenceutil.GetShardFence(ctx, wr.TopoServer(), keyspace, shard)
+	if err != nil {
+		if topo.IsErrType(err, topo.NoNode) {
+			return fmt.Errorf("shard %v/%v is not fenced", keyspace, shard)
+		}
+		return err
+	}
+	return printJSON(wr.Logger(), fence)
+}
+
 func commandCreateKeyspace(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
 	shardingColumnName := subFlags.String("sharding_column_name", "", "Specifies the column to use for sharding operations")
 	shardingColumnType := subFlags.String("sharding_column_type", "", "Specifies the type of the column to use for sharding operations")
@@ -1688,6 +2110,40 @@ func commandDeleteKeyspace(ctx context.Context, wr *wrangler.Wrangler, subFlags
 	return wr.DeleteKeyspace(ctx, subFlags.Arg(0), *recursive)
 }
 
+func commandTimeTravel(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	dryRun := subFlags.Bool("dry_run", false, "Only outputs the backups and binlogs of the time-travel keyspace, without creating it")
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 2 {
+		return fmt.Errorf("the <keyspace> and <time> arguments are required for the TimeTravel command")
+	}
+	asOf, err := time.Parse(time.RFC3339, subFlags.Arg(1))
+	if err != nil {
+		return err
+	}
+	plan, err := wr.PlanTimeTravel(ctx, subFlags.Arg(0), asOf)
+	if err != nil {
+		return err
+	}
+	if !*dryRun {
+		if err := wr.CreateTimeTravelKeyspace(ctx, plan); err != nil {
+			return err
+		}
+	}
+	return printJSON(wr.Logger(), plan)
+}
+
+func commandDeleteTimeTravel(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("the <time-travel keyspace> argument is required for the DeleteTimeTravel command")
+	}
+	return wr.DeleteTimeTravelKeyspace(ctx, subFlags.Arg(0))
+}
+
 func commandRemoveKeyspaceCell(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
 	force := subFlags.Bool("force", false, "Proceeds even if the cell's topology server cannot be reached. The assumption is that you turned down the entire cell, and just need to update the global topo data.")
 	recursive := subFlags.Bool("recursive", false, "Also delete all tablets in that cell belonging to the specified keyspace.")
@@ -1768,32 +2224,291 @@ func commandSetKeyspaceShardingInfo(ctx context.Context, wr *wrangler.Wrangler,
 	return wr.SetKeyspaceShardingInfo(ctx, keyspace, columnName, kit, *force)
 }
 
-func commandSetKeyspaceServedFrom(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
-	source := subFlags.String("source", "", "Specifies the source keyspace name")
-	remove := subFlags.Bool("remove", false, "Indicates whether to add (default) or remove the served from record")
-	cellsStr := subFlags.String("cells", "", "Specifies a comma-separated list of cells to affect")
+func commandSetReplicationLagThresholds(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	tabletTypeStr := subFlags.String("tablet_type", "", "Sets the thresholds of this tablet type instead of the keyspace")
 	if err := subFlags.Parse(args); err != nil {
 		return err
 	}
-	if subFlags.NArg() != 2 {
-		return fmt.Errorf("the <keyspace name> and <tablet type> arguments are required for the SetKeyspaceServedFrom command")
+	if subFlags.NArg() != 3 {
+		return fmt.Errorf("the <keyspace name>, <soft threshold> and <hard threshold> arguments are required for the SetReplicationLagThresholds command")
+	}
+	tabletType := ""
+	if *tabletTypeStr != "" {
+		tt, err := topoproto.ParseTabletType(*tabletTypeStr)
+		if err != nil {
+			return err
+		}
+		tabletType = topoproto.TabletTypeLString(tt)
 	}
+
 	keyspace := subFlags.Arg(0)
-	servedType, err := parseTabletType(subFlags.Arg(1), []topodatapb.TabletType{topodatapb.TabletType_MASTER, topodatapb.TabletType_REPLICA, topodatapb.TabletType_RDONLY})
+	soft, err := time.ParseDuration(subFlags.Arg(1))
 	if err != nil {
 		return err
 	}
-	var cells []string
-	if *cellsStr != "" {
-		cells = strings.Split(*cellsStr, ",")
+	hard, err := time.ParseDuration(subFlags.Arg(2))
+	if err != nil {
+		return err
 	}
-
-	return wr.SetKeyspaceServedFrom(ctx, keyspace, servedType, cells, *source, *remove)
+	if soft < 0 || hard < 0 {
+		return fmt.Errorf("invalid thresholds: %v %v", soft, hard)
+	}
+	if soft > 0 && hard > 0 && soft > hard {
+		return fmt.Errorf("the soft threshold %v cannot be higher than the hard threshold %v", soft, hard)
+	}
+	var threshold *topodatapb.ReplicationLagThreshold
+	if soft > 0 || hard > 0 {
+		threshold = &topodatapb.ReplicationLagThreshold{
+			SoftSeconds: int64(soft.Seconds()),
+			HardSeconds: int64(hard.Seconds()),
+		}
+	}
+	return wr.SetReplicationLagThreshold(ctx, keyspace, tabletType, threshold)
 }
 
-func commandRebuildKeyspaceGraph(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+func commandGetReplicationLagThresholds(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("the <keyspace name> argument is required for the GetReplicationLagThresholds command")
+	}
+
+	ki, err := wr.TopoServer().GetKeyspace(ctx, subFlags.Arg(0))
+	if err != nil {
+		return err
+	}
+	rt := ki.ReplicationLagThresholds
+	if rt == nil {
+		rt = &topodatapb.ReplicationLagThresholds{}
+	}
+	return printJSON(wr.Logger(), rt)
+}
+
+func commandSetCellServingOverride(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	doNotServe := subFlags.Bool("do_not_serve", false, "Marks the cell as \"do not serve\" for the keyspace, instead of removing the mark")
+	reason := subFlags.String("reason", "", "Why the cell must not serve the keyspace, logged by the vtgates")
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 2 {
+		return fmt.Errorf("the <keyspace name> and <cell> arguments are required for the SetCellServingOverride command")
+	}
+
+	_, err := wr.VtctldServer().SetCellServingOverride(ctx, &vtctldatapb.SetCellServingOverrideRequest{
+		Keyspace: subFlags.Arg(0),
+		Cell:     subFlags.Arg(1),
+		Override: &topodatapb.CellServingOverride{
+			DoNotServe: *doNotServe,
+			Reason:     *reason,
+		},
+	})
+	return err
+}
+
+func commandGetCellServingOverride(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	cell := subFlags.String("cell", "", "Only outputs the mark of this cell")
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("the <keyspace name> argument is required for the GetCellServingOverride command")
+	}
+
+	resp, err := wr.VtctldServer().GetCellServingOverride(ctx, &vtctldatapb.GetCellServingOverrideRequest{
+		Keyspace: subFlags.Arg(0),
+		Cell:     *cell,
+	})
+	if err != nil {
+		return err
+	}
+	return printJSON(wr.Logger(), resp)
+}
+
+func commandApplyQueryQuotas(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	quotas := subFlags.String("quotas", "", "Specify the quotas as a string")
+	quotasFile := subFlags.String("quotas_file", "", "Specify the quotas in a file")
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 0 {
+		return fmt.Errorf("ApplyQueryQuotas doesn't take any arguments")
+	}
+
+	var data []byte
+	if *quotasFile != "" {
+		var err error
+		data, err = ioutil.ReadFile(*quotasFile)
+		if err != nil {
+			return err
+		}
+	} else {
+		data = []byte(*quotas)
+	}
+	qq := &topo.QueryQuotas{}
+	if len(data) != 0 {
+		if err := json.Unmarshal(data, qq); err != nil {
+			return fmt.Errorf("invalid query quotas: %v", err)
+		}
+	}
+	return wr.TopoServer().SaveQueryQuotas(ctx, qq)
+}
+
+func commandGetQueryQuotas(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 0 {
+		return fmt.Errorf("GetQueryQuotas doesn't take any arguments")
+	}
+
+	qq, err := wr.TopoServer().GetQueryQuotas(ctx)
+	if err != nil {
+		return err
+	}
+	if qq == nil {
+		qq = &topo.QueryQuotas{}
+	}
+	return printJSON(wr.Logger(), qq)
+}
+
+// normalizeQuery returns the query the way vttablet receives it from
+// vtgate, and its statement type.
+func normalizeQuery(sql string) (string, sqlparser.StatementType, error) {
+	stmt, err := sqlparser.Parse(sql)
+	if err != nil {
+		return "", sqlparser.StmtUnknown, err
+	}
+	return sqlparser.String(stmt), sqlparser.ASTToStatementType(stmt), nil
+}
+
+func commandPinQuery(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	expireAfter := subFlags.Duration("expire_after", 0, "If set, the pin expires after this duration")
+	comment := subFlags.String("comment", "", "Explains why the query is pinned")
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 3 {
+		return fmt.Errorf("the <keyspace name>, <query> and <replacement> arguments are required for the PinQuery command")
+	}
+
+	keyspace := subFlags.Arg(0)
+	query, queryType, err := normalizeQuery(subFlags.Arg(1))
+	if err != nil {
+		return fmt.Errorf("invalid query: %v", err)
+	}
+	replacement, replacementType, err := normalizeQuery(subFlags.Arg(2))
+	if err != nil {
+		return fmt.Errorf("invalid replacement: %v", err)
+	}
+	if queryType != replacementType {
+		return fmt.Errorf("the replacement is a %v statement, the query is a %v statement", replacementType, queryType)
+	}
+	pin := &topodatapb.QueryPin{Query: query, Replacement: replacement, Comment: *comment}
+	if *expireAfter > 0 {
+		pin.Expires = logutil.TimeToProto(time.Now().Add(*expireAfter))
+	}
+	return wr.PinQuery(ctx, keyspace, pin)
+}
+
+func commandUnpinQuery(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	expired := subFlags.Bool("expired", false, "Removes all the expired pins instead of the pin of a query")
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if (*expired && subFlags.NArg() != 1) || (!*expired && subFlags.NArg() != 2) {
+		return fmt.Errorf("the <keyspace name> argument, and either -expired or the <query> argument are required for the UnpinQuery command")
+	}
+
+	keyspace := subFlags.Arg(0)
+	var query string
+	if !*expired {
+		var err error
+		if query, _, err = normalizeQuery(subFlags.Arg(1)); err != nil {
+			return fmt.Errorf("invalid query: %v", err)
+		}
+	}
+	return wr.UnpinQuery(ctx, keyspace, query, *expired)
+}
+
+func commandListQueryPins(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("the <keyspace name> argument is required for the ListQueryPins command")
+	}
+
+	ki, err := wr.TopoServer().GetKeyspace(ctx, subFlags.Arg(0))
+	if err != nil {
+		return err
+	}
+	pins := ki.QueryPins
+	if pins == nil {
+		pins = []*topodatapb.QueryPin{}
+	}
+	return printJSON(wr.Logger(), pins)
+}
+
+func commandSetKeyspaceServingFlags(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 2 {
+		return fmt.Errorf("the <keyspace name> and <flags> arguments are required for the SetKeyspaceServingFlags command")
+	}
+
+	keyspace := subFlags.Arg(0)
+	flags, err := topo.ParseServingFlags(subFlags.Arg(1))
+	if err != nil {
+		return err
+	}
+	return wr.SetKeyspaceServingFlags(ctx, keyspace, flags)
+}
+
+func commandGetKeyspaceServingFlags(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("the <keyspace name> argument is required for the GetKeyspaceServingFlags command")
+	}
+
+	ki, err := wr.TopoServer().GetKeyspace(ctx, subFlags.Arg(0))
+	if err != nil {
+		return err
+	}
+	wr.Logger().Printf("%v\n", strings.Join(ki.ServingFlags, ","))
+	return nil
+}
+
+func commandSetKeyspaceServedFrom(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	source := subFlags.String("source", "", "Specifies the source keyspace name")
+	remove := subFlags.Bool("remove", false, "Indicates whether to add (default) or remove the served from record")
+	cellsStr := subFlags.String("cells", "", "Specifies a comma-separated list of cells to affect")
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 2 {
+		return fmt.Errorf("the <keyspace name> and <tablet type> arguments are required for the SetKeyspaceServedFrom command")
+	}
+	keyspace := subFlags.Arg(0)
+	servedType, err := parseTabletType(subFlags.Arg(1), []topodatapb.TabletType{topodatapb.TabletType_MASTER, topodatapb.TabletType_REPLICA, topodatapb.TabletType_RDONLY})
+	if err != nil {
+		return err
+	}
+	var cells []string
+	if *cellsStr != "" {
+		cells = strings.Split(*cellsStr, ",")
+	}
+
+	return wr.SetKeyspaceServedFrom(ctx, keyspace, servedType, cells, *source, *remove)
+}
+
+func commandRebuildKeyspaceGraph(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
 	cells := subFlags.String("cells", "", "Specifies a comma-separated list of cells to update")
 	allowPartial := subFlags.Bool("allow_partial", false, "Specifies whether a SNAPSHOT keyspace is allowed to serve with an incomplete set of shards. Ignored for all other types of keyspaces")
+	report := subFlags.Bool("report", false, "Outputs the rebuild report of each keyspace, even if the rebuild failed in some cells")
 	if err := subFlags.Parse(args); err != nil {
 		return err
 	}
@@ -1810,12 +2525,32 @@ func commandRebuildKeyspaceGraph(ctx context.Context, wr *wrangler.Wrangler, sub
 	if err != nil {
 		return err
 	}
+
+	// The reports of the keyspaces rebuilt so far are output, even if
+	// one failed.
+	var reports []*vtctldatapb.RebuildReport
+	var rebuildErr error
 	for _, keyspace := range keyspaces {
-		if err := wr.RebuildKeyspaceGraph(ctx, keyspace, cellArray, *allowPartial); err != nil {
-			return err
+		resp, err := wr.VtctldServer().RebuildKeyspaceGraph(ctx, &vtctldatapb.RebuildKeyspaceGraphRequest{
+			Keyspace:     keyspace,
+			Cells:        cellArray,
+			AllowPartial: *allowPartial,
+		})
+		if resp != nil {
+			reports = append(reports, resp.Report)
+		}
+		if err != nil {
+			rebuildErr = fmt.Errorf("rebuilding keyspace %v failed: %v", keyspace, err)
+			break
 		}
 	}
-	return nil
+	if !*report {
+		return rebuildErr
+	}
+	if err := printJSON(wr.Logger(), reports); err != nil {
+		return err
+	}
+	return rebuildErr
 }
 
 func commandValidateKeyspace(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
@@ -1831,6 +2566,91 @@ func commandValidateKeyspace(ctx context.Context, wr *wrangler.Wrangler, subFlag
 	return wr.ValidateKeyspace(ctx, keyspace, *pingTablets)
 }
 
+func commandValidateBlacklistedTables(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	tabletTypeStr := subFlags.String("tablet_type", "master", "The tablet type whose blacklisted tables are validated")
+	tablesStr := subFlags.String("tables", "", "Specifies a comma-separated list of tables which must be blacklisted in every shard. By default, the union of the blacklisted tables of all shards is used")
+	probe := subFlags.Bool("probe", true, "Specifies whether all tablets of the given type are probed to verify that they enforce the blacklisted tables")
+	probeTimeout := subFlags.Duration("probe_timeout", 5*time.Second, "Timeout of every probe query")
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("the <keyspace name> argument is required for the ValidateBlacklistedTables command")
+	}
+	tabletType, err := topo.ParseServingTabletType(*tabletTypeStr)
+	if err != nil {
+		return err
+	}
+	opts := &wrangler.ValidateBlacklistedTablesOptions{
+		TabletType:   tabletType,
+		Probe:        *probe,
+		ProbeTimeout: *probeTimeout,
+	}
+	if *tablesStr != "" {
+		opts.Tables = strings.Split(*tablesStr, ",")
+	}
+	return wr.ValidateBlacklistedTables(ctx, subFlags.Arg(0), opts)
+}
+
+func commandLoadData(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	columns := subFlags.String("columns", "", "Specifies a comma-separated list of the columns of the rows. By default, the first line of each file lists them")
+	batchSize := subFlags.Int("batch_size", 1000, "Number of rows inserted per statement")
+	maxRowsPerSecond := subFlags.Int("max_rows_per_second", 0, "Maximum number of rows inserted per second on each shard. 0 means unlimited")
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() < 5 {
+		return fmt.Errorf("the <keyspace name>, <table>, <storage dir>, <storage name> and <file> arguments are required for the LoadData command")
+	}
+	opts := &loadutil.LoadDataOptions{
+		BatchSize:        *batchSize,
+		MaxRowsPerSecond: *maxRowsPerSecond,
+	}
+	if *columns != "" {
+		opts.Columns = strings.Split(*columns, ",")
+	}
+	loader := loadutil.NewLoader(wr.TopoServer(), wr.TabletManagerClient(), wr.Logger())
+	progress, err := loader.LoadData(ctx, subFlags.Arg(0), subFlags.Arg(1), subFlags.Arg(2), subFlags.Arg(3), subFlags.Args()[4:], opts)
+	if progress != nil {
+		if perr := printJSON(wr.Logger(), progress); perr != nil {
+			return perr
+		}
+	}
+	return err
+}
+
+func commandSeedTable(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	batchSize := subFlags.Int("batch_size", 1000, "Number of rows inserted per statement")
+	maxRowsPerSecond := subFlags.Int("max_rows_per_second", 0, "Maximum number of rows inserted per second on each shard. 0 means unlimited")
+	maxSourceRows := subFlags.Int("max_source_rows", 100000, "Maximum number of rows read from each shard of a source table")
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 2 {
+		return fmt.Errorf("the <keyspace name> and <table> arguments are required for the SeedTable command")
+	}
+
+	resp, err := wr.VtctldServer().SeedTable(ctx, &vtctldatapb.SeedTableRequest{
+		Keyspace:         subFlags.Arg(0),
+		Table:            subFlags.Arg(1),
+		BatchSize:        int64(*batchSize),
+		MaxRowsPerSecond: int64(*maxRowsPerSecond),
+		MaxSourceRows:    int64(*maxSourceRows),
+	})
+	if err != nil {
+		return err
+	}
+	if err := printJSON(wr.Logger(), resp); err != nil {
+		return err
+	}
+	for _, shard := range resp.Shards {
+		if shard.Error != "" {
+			return fmt.Errorf("SeedTable failed on shard %v: %v", shard.Shard, shard.Error)
+		}
+	}
+	return nil
+}
+
 func useV1(args []string) bool {
 	for _, arg := range args {
 		if arg == "-v1" {
@@ -1953,6 +2773,18 @@ func commandVRWorkflow(ctx context.Context, wr *wrangler.Wrangler, subFlags *fla
 	reverseReplication := subFlags.Bool("reverse_replication", true, "Also reverse the replication")
 	keepData := subFlags.Bool("keep_data", false, "Do not drop tables or shards (if true, only vreplication artifacts are cleaned up)")
 
+	// SwitchTraffic canary verification params
+	canaryQueries := subFlags.String("canary_queries", "", "Semicolon-separated canary queries run through vtgate against the target keyspace after writes are switched. If any of them fails, writes are switched back. Requires -enable_queries and -reverse_replication.")
+	canaryVtgate := subFlags.String("canary_vtgate", "", "VtGate server used to run the canary queries")
+	canaryWindow := subFlags.Duration("canary_window", 0, "How long canary queries keep being run after writes are switched. 0 runs them once.")
+	canaryInterval := subFlags.Duration("canary_interval", time.Second, "Pause between two runs of the canary queries")
+
+	// SwitchTraffic cutover gate params
+	cutoverMaxLag := subFlags.Duration("cutover_max_lag", 0, "If set, SwitchTraffic waits until all streams have replicated below this lag, without errors, for -cutover_stable_for before it switches traffic")
+	cutoverStableFor := subFlags.Duration("cutover_stable_for", 30*time.Second, "How long all streams must replicate below -cutover_max_lag, without errors, for the cutover gate to open")
+	cutoverAuto := subFlags.Bool("cutover_auto", false, "Keep waiting for the cutover gate to open, and then switch traffic, instead of failing as soon as a stream lags or errs")
+	cutoverDeadline := subFlags.Duration("cutover_deadline", 30*time.Minute, "How long to wait for the cutover gate to open before SwitchTraffic is aborted")
+
 	autoStart := subFlags.Bool("auto_start", true, "If false, streams will start in the Stopped state and will need to be explicitly started")
 	stopAfterCopy := subFlags.Bool("stop_after_copy", false, "Streams will be stopped once the copy phase is completed")
 
@@ -2023,6 +2855,13 @@ func commandVRWorkflow(ctx context.Context, wr *wrangler.Wrangler, subFlags *fla
 				s += fmt.Sprintf("id=%d on %s: Status: %s.%s\n", st.ID, ksShard, st.State, msg)
 			}
 		}
+		if rs := res.RollbackSafety; rs != nil {
+			safe := "no"
+			if rs.Safe {
+				safe = "yes"
+			}
+			s += fmt.Sprintf("\nRollback safe: %s (%s)\n", safe, rs.Reason)
+		}
 		wr.Logger().Printf("\n%s\n", s)
 		return nil
 	}
@@ -2105,6 +2944,40 @@ func commandVRWorkflow(ctx context.Context, wr *wrangler.Wrangler, subFlags *fla
 		}
 		vrwp.Timeout = *timeout
 		vrwp.EnableReverseReplication = *reverseReplication
+		if action == vReplicationWorkflowActionSwitchTraffic && *canaryQueries != "" {
+			if !*enableQueries {
+				return fmt.Errorf("canary queries are disabled (set the -enable_queries flag to enable)")
+			}
+			if *canaryVtgate == "" {
+				return fmt.Errorf("-canary_vtgate is required with -canary_queries")
+			}
+			if !*reverseReplication {
+				return fmt.Errorf("-canary_queries requires -reverse_replication")
+			}
+			queries, err := splitCanaryQueries(*canaryQueries)
+			if err != nil {
+				return fmt.Errorf("cannot split -canary_queries: %v", err)
+			}
+			vtgateConn, err := vtgateconn.Dial(ctx, *canaryVtgate)
+			if err != nil {
+				return fmt.Errorf("error connecting to vtgate '%v': %v", *canaryVtgate, err)
+			}
+			defer vtgateConn.Close()
+			vrwp.Canary = &wrangler.CanaryVerification{
+				Queries:  queries,
+				Window:   *canaryWindow,
+				Interval: *canaryInterval,
+				Executor: &vtgateCanaryExecutor{conn: vtgateConn},
+			}
+		}
+		if action == vReplicationWorkflowActionSwitchTraffic && *cutoverMaxLag > 0 {
+			vrwp.CutoverGate = &wrangler.CutoverGate{
+				MaxLag:    *cutoverMaxLag,
+				StableFor: *cutoverStableFor,
+				Auto:      *cutoverAuto,
+				Deadline:  *cutoverDeadline,
+			}
+		}
 	case vReplicationWorkflowActionCancel:
 		vrwp.KeepData = *keepData
 	case vReplicationWorkflowActionComplete:
@@ -2361,6 +3234,10 @@ func commandVDiff(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.Fla
 	onlyPks := subFlags.Bool("only_pks", false, "When reporting missing rows, only show primary keys in the report.")
 	format := subFlags.String("format", "", "Format of report") //"json" or ""
 	tables := subFlags.String("tables", "", "Only run vdiff for these tables in the workflow")
+	saveResults := subFlags.Bool("save_results", false, "Store the result of the vdiff, all the rows are then compared and -limit, -debug_query, -only_pks and -format are ignored")
+	maxSourceLag := subFlags.Duration("max_source_lag", 0, "Only read from tablets whose replication lag is at most this, and restart the vdiff with other tablets if it failed while one of its tablets was lagging. 0 disables it")
+	waitForSourceCatchup := subFlags.Bool("wait_for_source_catchup", false, "If all the tablets of a shard are above -max_source_lag, wait for one of them to catch up instead of failing")
+	incrementalColumn := subFlags.String("incremental_column", "", "Only compare the rows whose value of this update timestamp column changed since the previous stored vdiff of the workflow, and store the result. The tables without this column are fully compared")
 	if err := subFlags.Parse(args); err != nil {
 		return err
 	}
@@ -2375,8 +3252,21 @@ func commandVDiff(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.Fla
 	if *maxRows <= 0 {
 		return fmt.Errorf("maximum number of rows to compare needs to be greater than 0")
 	}
-	_, err = wr.
-		VDiff(ctx, keyspace, workflow, *sourceCell, *targetCell, *tabletTypes, *filteredReplicationWaitTime, *format, *maxRows, *tables, *debugQuery, *onlyPks)
+	params := &wrangler.VDiffParams{
+		SourceCell:                  *sourceCell,
+		TargetCell:                  *targetCell,
+		TabletTypes:                 *tabletTypes,
+		FilteredReplicationWaitTime: *filteredReplicationWaitTime,
+		Tables:                      *tables,
+		IncrementalColumn:           *incrementalColumn,
+		MaxSourceLag:                *maxSourceLag,
+		WaitForSourceCatchup:        *waitForSourceCatchup,
+	}
+	if *saveResults || *incrementalColumn != "" {
+		_, err = wr.RunVDiff(ctx, keyspace, workflow, params)
+	} else {
+		_, err = wr.VDiffWithParams(ctx, keyspace, workflow, params, *format, *maxRows, *debugQuery, *onlyPks)
+	}
 	if err != nil {
 		log.Errorf("vdiff returning with error: %v", err)
 		if strings.Contains(err.Error(), "context deadline exceeded") {
@@ -2386,6 +3276,25 @@ func commandVDiff(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.Fla
 	return err
 }
 
+func commandListVDiffResults(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	limit := subFlags.Int("limit", 0, "Maximum number of results to output, all of them if 0")
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("<keyspace.workflow> is required")
+	}
+	keyspace, workflow, err := splitKeyspaceWorkflow(subFlags.Arg(0))
+	if err != nil {
+		return err
+	}
+	results, err := wr.ListVDiffResults(ctx, keyspace, workflow, *limit)
+	if err != nil {
+		return err
+	}
+	return printJSON(wr.Logger(), results)
+}
+
 func splitKeyspaceWorkflow(in string) (keyspace, workflow string, err error) {
 	splits := strings.Split(in, ".")
 	if len(splits) != 2 {
@@ -2539,28 +3448,61 @@ func commandSwitchReads(ctx context.Context, wr *wrangler.Wrangler, subFlags *fl
 	return nil
 }
 
-func commandSwitchWrites(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
-	wr.Logger().Printf("*** SwitchWrites is deprecated. Consider using v2 commands instead, see https://vitess.io/docs/reference/vreplication/v2/ ***\n")
-
-	timeout := subFlags.Duration("timeout", 30*time.Second, "Specifies the maximum time to wait, in seconds, for vreplication to catch up on master migrations. The migration will be cancelled on a timeout.")
-	filteredReplicationWaitTime := subFlags.Duration("filtered_replication_wait_time", 30*time.Second, "DEPRECATED Specifies the maximum time to wait, in seconds, for vreplication to catch up on master migrations. The migration will be cancelled on a timeout.")
-	reverseReplication := subFlags.Bool("reverse_replication", true, "Also reverse the replication")
-	cancel := subFlags.Bool("cancel", false, "Cancel the failed migration and serve from source")
-	reverse := subFlags.Bool("reverse", false, "Reverse a previous SwitchWrites serve from source")
-	dryRun := subFlags.Bool("dry_run", false, "Does a dry run of SwitchWrites and only reports the actions to be taken")
+func commandSplitReads(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	cellsStr := subFlags.String("cells", "", "Specifies a comma-separated list of cells to update")
+	tabletTypes := subFlags.String("tablet_types", "rdonly,replica", "Tablet types to split the reads of, one or both of rdonly/replica")
 	if err := subFlags.Parse(args); err != nil {
 		return err
 	}
-
-	if subFlags.NArg() != 1 {
-		return fmt.Errorf("<keyspace.workflow> is required")
+	if subFlags.NArg() != 2 {
+		return fmt.Errorf("<percent> and <keyspace.workflow> are required")
 	}
-	keyspace, workflow, err := splitKeyspaceWorkflow(subFlags.Arg(0))
+	percent, err := strconv.Atoi(subFlags.Arg(0))
 	if err != nil {
-		return err
+		return fmt.Errorf("invalid percent %q: %v", subFlags.Arg(0), err)
 	}
-	if filteredReplicationWaitTime != timeout {
-		timeout = filteredReplicationWaitTime
+	keyspace, workflow, err := splitKeyspaceWorkflow(subFlags.Arg(1))
+	if err != nil {
+		return err
+	}
+
+	var servedTypes []topodatapb.TabletType
+	for _, tabletType := range strings.Split(*tabletTypes, ",") {
+		servedType, err := parseTabletType(tabletType, []topodatapb.TabletType{topodatapb.TabletType_REPLICA, topodatapb.TabletType_RDONLY})
+		if err != nil {
+			return err
+		}
+		servedTypes = append(servedTypes, servedType)
+	}
+	var cells []string
+	if *cellsStr != "" {
+		cells = strings.Split(*cellsStr, ",")
+	}
+	return wr.SplitReads(ctx, keyspace, workflow, servedTypes, cells, percent)
+}
+
+func commandSwitchWrites(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	wr.Logger().Printf("*** SwitchWrites is deprecated. Consider using v2 commands instead, see https://vitess.io/docs/reference/vreplication/v2/ ***\n")
+
+	timeout := subFlags.Duration("timeout", 30*time.Second, "Specifies the maximum time to wait, in seconds, for vreplication to catch up on master migrations. The migration will be cancelled on a timeout.")
+	filteredReplicationWaitTime := subFlags.Duration("filtered_replication_wait_time", 30*time.Second, "DEPRECATED Specifies the maximum time to wait, in seconds, for vreplication to catch up on master migrations. The migration will be cancelled on a timeout.")
+	reverseReplication := subFlags.Bool("reverse_replication", true, "Also reverse the replication")
+	cancel := subFlags.Bool("cancel", false, "Cancel the failed migration and serve from source")
+	reverse := subFlags.Bool("reverse", false, "Reverse a previous SwitchWrites serve from source")
+	dryRun := subFlags.Bool("dry_run", false, "Does a dry run of SwitchWrites and only reports the actions to be taken")
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("<keyspace.workflow> is required")
+	}
+	keyspace, workflow, err := splitKeyspaceWorkflow(subFlags.Arg(0))
+	if err != nil {
+		return err
+	}
+	if filteredReplicationWaitTime != timeout {
+		timeout = filteredReplicationWaitTime
 	}
 
 	journalID, dryRunResults, err := wr.SwitchWrites(ctx, keyspace, workflow, *timeout, *cancel, *reverse, *reverseReplication, *dryRun)
@@ -2848,6 +3790,9 @@ func commandApplySchema(ctx context.Context, wr *wrangler.Wrangler, subFlags *fl
 	requestContext := subFlags.String("request_context", "", "For Only DDL, optionally supply a custom unique string used as context for the migration(s) in this command. By default a unique context is auto-generated by Vitess")
 	waitReplicasTimeout := subFlags.Duration("wait_replicas_timeout", wrangler.DefaultWaitReplicasTimeout, "The amount of time to wait for replicas to receive the schema change via replication.")
 	skipPreflight := subFlags.Bool("skip_preflight", false, "Skip pre-apply schema checks, and dircetly forward schema change query to shards")
+	seedCreatedTables := subFlags.Bool("seed_created_tables", false, "Seed the created tables which have a seed source once the change is applied")
+	desiredState := subFlags.Bool("desired_state", false, "The SQL is the CREATE TABLE statements of all the tables of the keyspace, which is converged to them with declarative online DDL migrations")
+	allowDrops := subFlags.Bool("allow_drops", false, "With -desired_state, drop the tables of the keyspace which aren't in the SQL")
 	if err := subFlags.Parse(args); err != nil {
 		return err
 	}
@@ -2860,6 +3805,36 @@ func commandApplySchema(ctx context.Context, wr *wrangler.Wrangler, subFlags *fl
 	if err != nil {
 		return err
 	}
+	if *desiredState {
+		if *seedCreatedTables {
+			return fmt.Errorf("-seed_created_tables cannot be used with -desired_state")
+		}
+		setting, err := schema.ParseDDLStrategy(*ddlStrategy)
+		if err != nil {
+			return err
+		}
+		if setting.Strategy.IsDirect() {
+			return fmt.Errorf("-desired_state requires an online ddl_strategy")
+		}
+		plan, err := onlineddlutil.PlanDeclarativeSchema(ctx, wr.TopoServer(), wr.TabletManagerClient(), keyspace, change, *allowDrops)
+		if err != nil {
+			return err
+		}
+		if len(plan.SkippedDrops) > 0 {
+			wr.Logger().Warningf("Tables %v of keyspace %v aren't in the desired schema but aren't dropped without -allow_drops", strings.Join(plan.SkippedDrops, ", "), keyspace)
+		}
+		if len(plan.Changes) == 0 {
+			wr.Logger().Printf("The schema of keyspace %v is already in the desired state\n", keyspace)
+			return nil
+		}
+		for _, change := range plan.Changes {
+			wr.Logger().Printf("%v table %v: %v\n", change.Action, change.Table, change.SQL)
+		}
+		change = strings.Join(plan.Statements(), ";\n")
+		if !setting.IsDeclarative() {
+			*ddlStrategy = strings.TrimSpace(*ddlStrategy + " -declarative")
+		}
+	}
 	executionUUID, err := schema.CreateUUID()
 	if err != nil {
 		return err
@@ -2877,15 +3852,61 @@ func commandApplySchema(ctx context.Context, wr *wrangler.Wrangler, subFlags *fl
 	if err := executor.SetDDLStrategy(*ddlStrategy); err != nil {
 		return err
 	}
+	if *seedCreatedTables {
+		setting, err := schema.ParseDDLStrategy(*ddlStrategy)
+		if err != nil {
+			return err
+		}
+		if setting.Strategy != schema.DDLStrategyDirect {
+			return fmt.Errorf("-seed_created_tables requires the %v ddl_strategy", schema.DDLStrategyDirect)
+		}
+	}
 
-	return schemamanager.Run(
+	if err := schemamanager.Run(
 		ctx,
 		schemamanager.NewPlainController(change, keyspace),
 		executor,
-	)
+	); err != nil || !*seedCreatedTables {
+		return err
+	}
+	loader := loadutil.NewLoader(wr.TopoServer(), wr.TabletManagerClient(), wr.Logger())
+	progress, err := loader.SeedCreatedTables(ctx, keyspace, change, &loadutil.SeedOptions{
+		BatchSize:     1000,
+		MaxSourceRows: 100000,
+	})
+	if len(progress) > 0 {
+		if perr := printJSON(wr.Logger(), progress); perr != nil {
+			return perr
+		}
+	}
+	return err
+}
+
+func commandPreviewDeclarativeSchema(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	sql := subFlags.String("sql", "", "The CREATE TABLE statements of all the tables of the keyspace, semicolon-delimited")
+	sqlFile := subFlags.String("sql-file", "", "Identifies the file that contains the CREATE TABLE statements")
+	allowDrops := subFlags.Bool("allow_drops", false, "Drop the tables of the keyspace which aren't in the SQL")
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("the <keyspace> argument is required for the PreviewDeclarativeSchema command")
+	}
+	desiredSchema, err := getFileParam(*sql, *sqlFile, "sql")
+	if err != nil {
+		return err
+	}
+	plan, err := onlineddlutil.PlanDeclarativeSchema(ctx, wr.TopoServer(), wr.TabletManagerClient(), subFlags.Arg(0), desiredSchema, *allowDrops)
+	if err != nil {
+		return err
+	}
+	return printJSON(wr.Logger(), plan)
 }
 
 func commandOnlineDDL(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	previewMaxRows := subFlags.Int("preview_max_rows", 1000, "Maximum number of rows a preview returns from each shard")
+	previewTimeout := subFlags.Duration("preview_timeout", 10*time.Second, "Maximum execution time of a preview on each shard")
+	previewMaxExaminedRows := subFlags.Int64("preview_max_examined_rows", 1000000, "Maximum number of rows the plan of a preview may examine on each shard. 0 disables the check")
 	if err := subFlags.Parse(args); err != nil {
 		return err
 	}
@@ -2915,6 +3936,7 @@ func commandOnlineDDL(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag
 				condition = "requested_timestamp > now() - interval 1 week"
 			case
 				string(schema.OnlineDDLStatusCancelled),
+				string(schema.OnlineDDLStatusPendingApproval),
 				string(schema.OnlineDDLStatusQueued),
 				string(schema.OnlineDDLStatusReady),
 				string(schema.OnlineDDLStatusRunning),
@@ -2949,6 +3971,37 @@ func commandOnlineDDL(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag
 			uuid = arg
 			query, bindErr = sqlparser.ParseAndBind(`update _vt.schema_migrations set migration_status='cancel' where migration_uuid=%a`, sqltypes.StringBindVariable(arg))
 		}
+	case "preview":
+		{
+			if arg == "" || subFlags.NArg() != 4 {
+				return fmt.Errorf("UUID and query required")
+			}
+			previews, err := wr.PreviewOnlineDDLShadowTable(ctx, keyspace, arg, subFlags.Arg(3), wrangler.ShadowTablePreviewOptions{
+				MaxRows:         *previewMaxRows,
+				Timeout:         *previewTimeout,
+				MaxExaminedRows: *previewMaxExaminedRows,
+			})
+			if err != nil {
+				return err
+			}
+			for _, preview := range previews {
+				wr.Logger().Printf("PREVIEW of the shadow table %s of running migration %s on shard %s, read on %s. It is not cut over yet: its rows may be incomplete or lag.\n%s\n",
+					preview.ShadowTable, arg, preview.Shard, preview.Tablet, preview.Query)
+				printQueryResult(loggerWriter{wr.Logger()}, preview.Result)
+			}
+			return nil
+		}
+	case "progress":
+		{
+			if arg == "" {
+				return fmt.Errorf("UUID required")
+			}
+			progress, err := onlineddlutil.GetProgress(ctx, wr.TopoServer(), wr.TabletManagerClient(), keyspace, arg)
+			if err != nil {
+				return err
+			}
+			return printJSON(wr.Logger(), progress)
+		}
 	case "cancel-all":
 		{
 			if arg != "" {
@@ -3003,6 +4056,122 @@ func commandOnlineDDL(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag
 	return nil
 }
 
+func commandRevertMigration(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	requestContext := subFlags.String("request_context", "", "The request context of the revert migration. Defaults to vtctl:<uuid>")
+	dryRun := subFlags.Bool("dry_run", false, "Only outputs the revert windows of the migration on each shard, without reverting it")
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 2 {
+		return fmt.Errorf("the <keyspace> and <migration_uuid> arguments are required for the RevertMigration command")
+	}
+	keyspace := subFlags.Arg(0)
+	uuid := subFlags.Arg(1)
+	if *dryRun {
+		windows, err := onlineddlutil.RevertWindows(ctx, wr.TopoServer(), wr.TabletManagerClient(), keyspace, uuid)
+		if err != nil {
+			return err
+		}
+		return printJSON(wr.Logger(), windows)
+	}
+	revert, err := onlineddlutil.RevertMigration(ctx, wr.TopoServer(), wr.TabletManagerClient(), keyspace, uuid, *requestContext)
+	if err != nil {
+		return err
+	}
+	return printJSON(wr.Logger(), revert)
+}
+
+func commandApproveMigration(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	reviewer := subFlags.String("reviewer", "", "The identity which approves the migration")
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 2 {
+		return fmt.Errorf("the <keyspace> and <migration_uuid> arguments are required for the ApproveMigration command")
+	}
+	if *reviewer == "" {
+		return fmt.Errorf("the reviewer flag is required for the ApproveMigration command")
+	}
+	review, err := onlineddlutil.ApproveMigration(ctx, wr.TopoServer(), wr.TabletManagerClient(), subFlags.Arg(0), subFlags.Arg(1), *reviewer)
+	if err != nil {
+		return err
+	}
+	return printJSON(wr.Logger(), review)
+}
+
+func commandRejectMigration(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	reviewer := subFlags.String("reviewer", "", "The identity which rejects the migration")
+	message := subFlags.String("message", "", "The reason of the rejection")
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 2 {
+		return fmt.Errorf("the <keyspace> and <migration_uuid> arguments are required for the RejectMigration command")
+	}
+	if *reviewer == "" {
+		return fmt.Errorf("the reviewer flag is required for the RejectMigration command")
+	}
+	review, err := onlineddlutil.RejectMigration(ctx, wr.TopoServer(), wr.TabletManagerClient(), subFlags.Arg(0), subFlags.Arg(1), *reviewer, *message)
+	if err != nil {
+		return err
+	}
+	return printJSON(wr.Logger(), review)
+}
+
+func commandTableGC(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	retention := subFlags.Duration("retention", 24*time.Hour, "For retain, the time the tables stay in their state from now on")
+	toTable := subFlags.String("to_table", "", "For restore, the table to rename the HOLD tables to. Defaults to the table dropped by the online DDL migration of the UUID")
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() < 2 {
+		return fmt.Errorf("the <keyspace> and <command> arguments are required for the TableGC command")
+	}
+	keyspace := subFlags.Arg(0)
+	command := subFlags.Arg(1)
+	switch command {
+	case "list":
+		if subFlags.NArg() != 2 {
+			return fmt.Errorf("no argument allowed in %s", command)
+		}
+		tables, err := tablegcutil.ListGCTables(ctx, wr.TopoServer(), wr.TabletManagerClient(), keyspace)
+		if err != nil {
+			return err
+		}
+		return printJSON(wr.Logger(), tables)
+	case "retain":
+		if subFlags.NArg() < 3 {
+			return fmt.Errorf("the <state> argument is required in %s", command)
+		}
+		state := schema.TableGCState(strings.ToUpper(subFlags.Arg(2)))
+		tables, err := tablegcutil.SetGCTablesRetention(ctx, wr.TopoServer(), wr.TabletManagerClient(), keyspace, state, subFlags.Args()[3:], *retention)
+		if err != nil {
+			return err
+		}
+		return printJSON(wr.Logger(), tables)
+	case "advance":
+		if subFlags.NArg() != 3 {
+			return fmt.Errorf("UUID required")
+		}
+		tables, err := tablegcutil.AdvanceGCTable(ctx, wr.TopoServer(), wr.TabletManagerClient(), keyspace, subFlags.Arg(2))
+		if err != nil {
+			return err
+		}
+		return printJSON(wr.Logger(), tables)
+	case "restore":
+		if subFlags.NArg() != 3 {
+			return fmt.Errorf("UUID required")
+		}
+		tables, err := tablegcutil.RestoreGCTable(ctx, wr.TopoServer(), wr.TabletManagerClient(), keyspace, subFlags.Arg(2), *toTable)
+		if err != nil {
+			return err
+		}
+		return printJSON(wr.Logger(), tables)
+	default:
+		return fmt.Errorf("Unknown TableGC command: %s", command)
+	}
+}
+
 func commandCopySchemaShard(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
 	tables := subFlags.String("tables", "", "Specifies a comma-separated list of tables to copy. Each is either an exact match, or a regular expression of the form /regexp/")
 	excludeTables := subFlags.String("exclude_tables", "", "Specifies a comma-separated list of tables to exclude. Each is either an exact match, or a regular expression of the form /regexp/")
@@ -3068,6 +4237,260 @@ func commandValidateVersionKeyspace(ctx context.Context, wr *wrangler.Wrangler,
 	return wr.ValidateVersionKeyspace(ctx, keyspace)
 }
 
+func commandValidateVersion(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	opts, err := parseValidateOptions(subFlags, args, "ValidateVersion")
+	if err != nil {
+		return err
+	}
+	report, err := wr.ValidateVersionReport(ctx, opts)
+	if err != nil {
+		return err
+	}
+	if err := printJSON(wr.Logger(), report); err != nil {
+		return err
+	}
+	if !report.Consistent {
+		return fmt.Errorf("the tablets of %v don't all run the same version", subFlags.Arg(0))
+	}
+	return nil
+}
+
+func commandValidatePermissions(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	opts, err := parseValidateOptions(subFlags, args, "ValidatePermissions")
+	if err != nil {
+		return err
+	}
+	report, err := validateutil.ValidatePermissions(ctx, wr.TopoServer(), wr.TabletManagerClient(), opts)
+	if err != nil {
+		return err
+	}
+	if err := printJSON(wr.Logger(), report); err != nil {
+		return err
+	}
+	if !report.Consistent {
+		return fmt.Errorf("the permissions of the tablets of %v differ", subFlags.Arg(0))
+	}
+	return nil
+}
+
+func commandValidateConfig(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	mysqlVariables := subFlags.String("mysql_variables", strings.Join(validateutil.DefaultMySQLVariables, ","), "Specifies a comma-separated list of the MySQL global variables to compare")
+	tabletFlags := subFlags.String("tablet_flags", "", "Specifies a comma-separated list of the vttablet flags to compare")
+	goldenJSON := subFlags.String("golden_config", "", "Specifies the golden config as a JSON string")
+	goldenFile := subFlags.String("golden_config_file", "", "Specifies a file containing the golden config as JSON")
+	opts, err := parseValidateOptions(subFlags, args, "ValidateConfig")
+	if err != nil {
+		return err
+	}
+	if *goldenJSON != "" && *goldenFile != "" {
+		return fmt.Errorf("only one of the golden_config and golden_config_file flags can be specified when calling the ValidateConfig command")
+	}
+
+	var configOpts validateutil.ConfigOptions
+	if *mysqlVariables != "" {
+		configOpts.MySQLVariables = strings.Split(strings.ToLower(*mysqlVariables), ",")
+	}
+	if *tabletFlags != "" {
+		configOpts.Flags = strings.Split(*tabletFlags, ",")
+	}
+	data := []byte(*goldenJSON)
+	if *goldenFile != "" {
+		if data, err = ioutil.ReadFile(*goldenFile); err != nil {
+			return err
+		}
+	}
+	if len(data) > 0 {
+		configOpts.Golden = &validateutil.Config{}
+		if err := json.Unmarshal(data, configOpts.Golden); err != nil {
+			return fmt.Errorf("cannot parse the golden config: %v", err)
+		}
+	}
+
+	report, err := wr.ValidateConfig(ctx, opts, configOpts)
+	if err != nil {
+		return err
+	}
+	if err := printJSON(wr.Logger(), report); err != nil {
+		return err
+	}
+	if !report.Consistent {
+		return fmt.Errorf("the config of the tablets of %v drifts", subFlags.Arg(0))
+	}
+	return nil
+}
+
+// parseValidateOptions parses the flags and arguments shared by the
+// ValidateVersion and ValidatePermissions commands.
+func parseValidateOptions(subFlags *flag.FlagSet, args []string, command string) (validateutil.Options, error) {
+	cells := subFlags.String("cells", "", "Specifies a comma-separated list of cells whose tablets are validated. If empty, all cells are considered.")
+	concurrency := subFlags.Int("concurrency", validateutil.DefaultConcurrency, "Specifies the maximum number of tablets queried at the same time.")
+	if err := subFlags.Parse(args); err != nil {
+		return validateutil.Options{}, err
+	}
+	if subFlags.NArg() != 1 {
+		return validateutil.Options{}, fmt.Errorf("the <keyspace|keyspace/shard> argument is required for the %v command", command)
+	}
+
+	opts := validateutil.Options{Keyspace: subFlags.Arg(0), Concurrency: *concurrency}
+	if strings.Contains(opts.Keyspace, "/") {
+		var err error
+		if opts.Keyspace, opts.Shard, err = topoproto.ParseKeyspaceShard(subFlags.Arg(0)); err != nil {
+			return validateutil.Options{}, err
+		}
+	}
+	if *cells != "" {
+		opts.Cells = strings.Split(*cells, ",")
+	}
+	return opts, nil
+}
+
+func commandSetMySQLUsers(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	usersJSON := subFlags.String("users", "", "Specifies the users as a JSON string")
+	usersFile := subFlags.String("users_file", "", "Specifies a file containing the users as JSON")
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("the <keyspace> argument is required for the SetMySQLUsers command")
+	}
+	if (*usersJSON == "") == (*usersFile == "") {
+		return fmt.Errorf("either the users or users_file flag must be specified when calling the SetMySQLUsers command")
+	}
+
+	data := []byte(*usersJSON)
+	if *usersFile != "" {
+		var err error
+		if data, err = ioutil.ReadFile(*usersFile); err != nil {
+			return err
+		}
+	}
+	users, err := grantutil.ParseUsers(data)
+	if err != nil {
+		return fmt.Errorf("cannot parse the users: %v", err)
+	}
+
+	_, err = wr.VtctldServer().SetMySQLUsers(ctx, &vtctldatapb.SetMySQLUsersRequest{
+		Keyspace: subFlags.Arg(0),
+		Users:    users,
+	})
+	return err
+}
+
+func commandGetMySQLUsers(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("the <keyspace> argument is required for the GetMySQLUsers command")
+	}
+
+	resp, err := wr.VtctldServer().GetMySQLUsers(ctx, &vtctldatapb.GetMySQLUsersRequest{
+		Keyspace: subFlags.Arg(0),
+	})
+	if err != nil {
+		return err
+	}
+	return printJSON(wr.Logger(), resp)
+}
+
+func commandSetCDCSinks(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	sinksJSON := subFlags.String("sinks", "", "Specifies the sinks as a JSON string")
+	sinksFile := subFlags.String("sinks_file", "", "Specifies a file containing the sinks as JSON")
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("the <keyspace> argument is required for the SetCDCSinks command")
+	}
+	if (*sinksJSON == "") == (*sinksFile == "") {
+		return fmt.Errorf("either the sinks or sinks_file flag must be specified when calling the SetCDCSinks command")
+	}
+
+	data := []byte(*sinksJSON)
+	if *sinksFile != "" {
+		var err error
+		if data, err = ioutil.ReadFile(*sinksFile); err != nil {
+			return err
+		}
+	}
+	sinks := &vtctldatapb.GetCDCSinksResponse{}
+	if err := json2.Unmarshal(data, sinks); err != nil {
+		return fmt.Errorf("cannot parse the sinks: %v", err)
+	}
+
+	_, err := wr.VtctldServer().SetCDCSinks(ctx, &vtctldatapb.SetCDCSinksRequest{
+		Keyspace: subFlags.Arg(0),
+		Sinks:    sinks.Sinks,
+	})
+	return err
+}
+
+func commandGetCDCSinks(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("the <keyspace> argument is required for the GetCDCSinks command")
+	}
+
+	resp, err := wr.VtctldServer().GetCDCSinks(ctx, &vtctldatapb.GetCDCSinksRequest{
+		Keyspace: subFlags.Arg(0),
+	})
+	if err != nil {
+		return err
+	}
+	return printJSON(wr.Logger(), resp)
+}
+
+func commandCheckMySQLUsers(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	opts, err := parseValidateOptions(subFlags, args, "CheckMySQLUsers")
+	if err != nil {
+		return err
+	}
+	report, err := grantutil.CheckDrift(ctx, wr.TopoServer(), wr.TabletManagerClient(), opts)
+	if err != nil {
+		return err
+	}
+	if err := printJSON(wr.Logger(), report); err != nil {
+		return err
+	}
+	if !report.Consistent {
+		return fmt.Errorf("the MySQL users of the tablets of %v drift from the declared ones", subFlags.Arg(0))
+	}
+	return nil
+}
+
+func commandApplyMySQLUsers(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	dryRun := subFlags.Bool("dry_run", false, "Only outputs the statements which would be executed on the masters")
+	waitReplicasTimeout := subFlags.Duration("wait_replicas_timeout", 30*time.Second, "Specifies how long to wait for the replicas to converge")
+	concurrency := subFlags.Int("concurrency", validateutil.DefaultConcurrency, "Specifies the maximum number of tablets queried at the same time.")
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("the <keyspace> argument is required for the ApplyMySQLUsers command")
+	}
+
+	report, err := grantutil.Apply(ctx, wr.TopoServer(), wr.TabletManagerClient(), grantutil.ApplyOptions{
+		Keyspace:            subFlags.Arg(0),
+		DryRun:              *dryRun,
+		WaitReplicasTimeout: *waitReplicasTimeout,
+		Concurrency:         *concurrency,
+	})
+	if report != nil {
+		if err := printJSON(wr.Logger(), report); err != nil {
+			return err
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if report.Verification != nil && !report.Verification.Consistent {
+		return fmt.Errorf("the MySQL users of the tablets of %v didn't converge within %v", subFlags.Arg(0), *waitReplicasTimeout)
+	}
+	return nil
+}
+
 func commandGetPermissions(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
 	if err := subFlags.Parse(args); err != nil {
 		return err
@@ -3152,6 +4575,7 @@ func commandGetRoutingRules(ctx context.Context, wr *wrangler.Wrangler, subFlags
 func commandRebuildVSchemaGraph(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
 	var cells flagutil.StringListValue
 	subFlags.Var(&cells, "cells", "Specifies a comma-separated list of cells to look for tablets")
+	report := subFlags.Bool("report", false, "Outputs the rebuild report, even if the rebuild failed in some cells")
 
 	if err := subFlags.Parse(args); err != nil {
 		return err
@@ -3160,10 +4584,15 @@ func commandRebuildVSchemaGraph(ctx context.Context, wr *wrangler.Wrangler, subF
 		return fmt.Errorf("RebuildVSchemaGraph doesn't take any arguments")
 	}
 
-	_, err := wr.VtctldServer().RebuildVSchemaGraph(ctx, &vtctldatapb.RebuildVSchemaGraphRequest{
+	resp, rebuildErr := wr.VtctldServer().RebuildVSchemaGraph(ctx, &vtctldatapb.RebuildVSchemaGraphRequest{
 		Cells: cells,
 	})
-	return err
+	if *report && resp != nil {
+		if err := printJSON(wr.Logger(), resp.Report); err != nil {
+			return err
+		}
+	}
+	return rebuildErr
 }
 
 func commandApplyVSchema(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
@@ -3400,6 +4829,31 @@ func commandGetSrvKeyspace(ctx context.Context, wr *wrangler.Wrangler, subFlags
 	return printJSON(wr.Logger(), cellKs)
 }
 
+func commandGetSrvKeyspaceWithVersion(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 2 {
+		return fmt.Errorf("the <cell> and <keyspace> arguments are required for the GetSrvKeyspaceWithVersion command")
+	}
+
+	srvKeyspace, version, err := wr.TopoServer().GetSrvKeyspaceWithVersion(ctx, subFlags.Arg(0), subFlags.Arg(1))
+	if err != nil {
+		return err
+	}
+	data, err := json2.MarshalPB(srvKeyspace)
+	if err != nil {
+		return err
+	}
+	return printJSON(wr.Logger(), struct {
+		Version     string
+		SrvKeyspace json.RawMessage
+	}{
+		Version:     version.String(),
+		SrvKeyspace: data,
+	})
+}
+
 func commandGetSrvVSchema(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
 	if err := subFlags.Parse(args); err != nil {
 		return err
@@ -3546,6 +5000,120 @@ func commandWorkflow(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.
 	return nil
 }
 
+func commandGetWorkflowProgress(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("the <ks.workflow> argument is required for the GetWorkflowProgress command")
+	}
+	keyspace, workflowName, err := splitKeyspaceWorkflow(subFlags.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	progress, err := workflow.NewServer(wr.TopoServer(), wr.TabletManagerClient()).GetWorkflowProgress(ctx, keyspace, workflowName)
+	if err != nil {
+		return err
+	}
+	return printJSON(wr.Logger(), progress)
+}
+
+func commandVerifyReverseReplication(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	maxLag := subFlags.Duration("max_lag", 30*time.Second, "Maximum lag of the reverse streams for them to be caught up")
+	checksum := subFlags.Bool("checksum", false, "Compares the checksums of a sample of the rows of the moved tables between the source and the target. It scans the tables on the primaries")
+	sampleRate := subFlags.Int("sample_rate", 100, "With -checksum, compares one row in sample_rate, sampled by a hash of their primary key. 1 compares all the rows")
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("the <ks.workflow> argument is required for the VerifyReverseReplication command")
+	}
+	keyspace, workflowName, err := splitKeyspaceWorkflow(subFlags.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	report, err := workflow.NewServer(wr.TopoServer(), wr.TabletManagerClient()).VerifyReverseReplication(ctx, keyspace, workflowName, workflow.ReverseVerificationOptions{
+		MaxLag:     *maxLag,
+		Checksum:   *checksum,
+		SampleRate: *sampleRate,
+	})
+	if err != nil {
+		return err
+	}
+	if err := printJSON(wr.Logger(), report); err != nil {
+		return err
+	}
+	if !report.Verified {
+		return fmt.Errorf("the reverse replication of workflow %s is not verified: %s", subFlags.Arg(0), report.Reason)
+	}
+	return nil
+}
+
+func commandUpdateWorkflowThrottling(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	rowsPerSec := subFlags.Int64("rows_per_sec", 0, "Maximum number of rows copied per second on each target primary. 0 removes the limit")
+	bytesPerSec := subFlags.Int64("bytes_per_sec", 0, "Maximum number of bytes copied per second on each target primary. 0 removes the limit")
+	maxConcurrentCopies := subFlags.Int64("max_concurrent_copies", 0, "Maximum number of streams copying rows at the same time on each target primary. 0 removes the limit")
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("the <ks.workflow> argument is required for the UpdateWorkflowThrottling command")
+	}
+	keyspace, workflowName, err := splitKeyspaceWorkflow(subFlags.Arg(0))
+	if err != nil {
+		return err
+	}
+	if *rowsPerSec < 0 || *bytesPerSec < 0 || *maxConcurrentCopies < 0 {
+		return fmt.Errorf("the rate limits of the UpdateWorkflowThrottling command cannot be negative")
+	}
+	if _, err := wr.TopoServer().GetKeyspace(ctx, keyspace); err != nil {
+		return err
+	}
+
+	workflows, err := wr.TopoServer().GetWorkflowThrottling(ctx, keyspace)
+	if err != nil {
+		return err
+	}
+	throttling := &topodatapb.WorkflowThrottling{}
+	if current := workflows[workflowName]; current != nil {
+		throttling = proto.Clone(current).(*topodatapb.WorkflowThrottling)
+	}
+	subFlags.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "rows_per_sec":
+			throttling.RowsPerSecond = *rowsPerSec
+		case "bytes_per_sec":
+			throttling.BytesPerSecond = *bytesPerSec
+		case "max_concurrent_copies":
+			throttling.MaxConcurrentCopies = *maxConcurrentCopies
+		}
+	})
+	if err := wr.TopoServer().UpdateWorkflowThrottling(ctx, keyspace, workflowName, throttling); err != nil {
+		return err
+	}
+	return printJSON(wr.Logger(), throttling)
+}
+
+func commandGetWorkflowThrottling(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("the <keyspace name> argument is required for the GetWorkflowThrottling command")
+	}
+
+	workflows, err := wr.TopoServer().GetWorkflowThrottling(ctx, subFlags.Arg(0))
+	if err != nil {
+		return err
+	}
+	if workflows == nil {
+		workflows = map[string]*topodatapb.WorkflowThrottling{}
+	}
+	return printJSON(wr.Logger(), workflows)
+}
+
 func commandMount(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
 	clusterType := subFlags.String("type", "vitess", "Specify cluster type: mysql or vitess, only vitess clustered right now")
 	unmount := subFlags.Bool("unmount", false, "Unmount cluster")
@@ -0,0 +1,227 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtctl
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/wrangler"
+)
+
+// This file contains the commands to list the MySQL protocol connections
+// of the vtgates and kill them, like SHOW PROCESSLIST and KILL, through
+// the /debug/processlist and /debug/kill pages of the vtgates. The
+// vtgates are the ones registered in the topology of their cell, and no
+// other address is ever queried.
+
+func init() {
+	addCommand(queriesGroupName, command{
+		"VtGateProcessList",
+		commandVtGateProcessList,
+		"[-cells=<cell>,...] [-user=<user>] [-running]",
+		"Lists the MySQL protocol connections of the vtgates registered in the cells, and the queries they run, as SHOW PROCESSLIST would."})
+	addCommand(queriesGroupName, command{
+		"VtGateKill",
+		commandVtGateKill,
+		"[-query] <vtgate host:port> <connection id>",
+		"Closes a MySQL protocol connection of a registered vtgate, which rolls back its transaction, as KILL would. With -query, only kills the query it runs, as KILL QUERY would."})
+}
+
+// vtgateProcess is a MySQL protocol connection of a vtgate, as
+// vtgate.ProcessInfo. The vtgate package isn't imported for it, as it
+// would register all the vtgate flags.
+type vtgateProcess struct {
+	ID            uint32 `json:"id"`
+	User          string `json:"user"`
+	Host          string `json:"host"`
+	Target        string `json:"target"`
+	Command       string `json:"command"`
+	Time          int64  `json:"time"`
+	Query         string `json:"query,omitempty"`
+	RowsStreamed  int    `json:"rows_streamed,omitempty"`
+	InTransaction bool   `json:"in_transaction"`
+}
+
+// vtgateProcessList is the process list of a vtgate.
+type vtgateProcessList struct {
+	Vtgate    string           `json:"vtgate"`
+	Processes []*vtgateProcess `json:"processes"`
+	Error     string           `json:"error,omitempty"`
+}
+
+func commandVtGateProcessList(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	user := subFlags.String("user", "", "Only lists the connections of this user")
+	running := subFlags.Bool("running", false, "Only lists the connections running a query")
+	cellsStr := subFlags.String("cells", "", "Specifies a comma-separated list of cells whose vtgates are included. If empty, all cells are considered.")
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 0 {
+		return fmt.Errorf("VtGateProcessList doesn't take any arguments")
+	}
+
+	var cells []string
+	if *cellsStr != "" {
+		cells = strings.Split(*cellsStr, ",")
+	}
+	vtgates, err := getRegisteredVtGates(ctx, wr.TopoServer(), cells)
+	if err != nil {
+		return err
+	}
+
+	// The vtgates are queried in parallel, and the ones which fail are
+	// reported with their error.
+	lists := make([]*vtgateProcessList, len(vtgates))
+	wg := sync.WaitGroup{}
+	for i, addr := range vtgates {
+		wg.Add(1)
+		go func(i int, addr string) {
+			defer wg.Done()
+			list := &vtgateProcessList{Vtgate: addr}
+			lists[i] = list
+			processes, err := getVtgateProcessList(ctx, addr)
+			if err != nil {
+				list.Error = err.Error()
+				return
+			}
+			for _, process := range processes {
+				if *user != "" && process.User != *user {
+					continue
+				}
+				if *running && process.Command != "Query" {
+					continue
+				}
+				list.Processes = append(list.Processes, process)
+			}
+		}(i, addr)
+	}
+	wg.Wait()
+	return printJSON(wr.Logger(), lists)
+}
+
+func commandVtGateKill(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	queryOnly := subFlags.Bool("query", false, "Only kills the query running on the connection, and keeps the connection")
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 2 {
+		return fmt.Errorf("the <vtgate host:port> and <connection id> arguments are required for the VtGateKill command")
+	}
+	addr := subFlags.Arg(0)
+	id, err := strconv.ParseUint(subFlags.Arg(1), 10, 32)
+	if err != nil {
+		return fmt.Errorf("invalid connection id %v: %v", subFlags.Arg(1), err)
+	}
+	if err := checkRegisteredVtGate(ctx, wr.TopoServer(), addr); err != nil {
+		return err
+	}
+
+	form := url.Values{}
+	form.Set("id", strconv.FormatUint(id, 10))
+	form.Set("query", strconv.FormatBool(*queryOnly))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("http://%v/debug/kill", addr), strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("cannot kill connection %v of vtgate %v: %v: %s", id, addr, resp.Status, strings.TrimSpace(string(body)))
+	}
+	if *queryOnly {
+		wr.Logger().Printf("Killed the query of connection %v of vtgate %v\n", id, addr)
+	} else {
+		wr.Logger().Printf("Killed connection %v of vtgate %v\n", id, addr)
+	}
+	return nil
+}
+
+// getRegisteredVtGates returns the host:port of the vtgates registered in
+// the cells, or in all the cells if none is given.
+func getRegisteredVtGates(ctx context.Context, ts *topo.Server, cells []string) ([]string, error) {
+	if len(cells) == 0 {
+		var err error
+		cells, err = ts.GetCellInfoNames(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var addrs []string
+	for _, cell := range cells {
+		vtgates, err := ts.GetVtGates(ctx, cell)
+		if err != nil {
+			return nil, fmt.Errorf("cannot list the vtgates of cell %v: %v", cell, err)
+		}
+		for _, vtgate := range vtgates {
+			addrs = append(addrs, topo.VtGateAddr(vtgate))
+		}
+	}
+	return addrs, nil
+}
+
+// checkRegisteredVtGate returns an error if no vtgate is registered at
+// addr in any cell, so that the commands never send requests to an
+// arbitrary address.
+func checkRegisteredVtGate(ctx context.Context, ts *topo.Server, addr string) error {
+	addrs, err := getRegisteredVtGates(ctx, ts, nil)
+	if err != nil {
+		return err
+	}
+	for _, a := range addrs {
+		if a == addr {
+			return nil
+		}
+	}
+	return fmt.Errorf("%v is not a vtgate registered in any cell", addr)
+}
+
+// getVtgateProcessList returns the MySQL protocol connections of a vtgate,
+// from its /debug/processlist page.
+func getVtgateProcessList(ctx context.Context, addr string) ([]*vtgateProcess, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("http://%v/debug/processlist", addr), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %v", resp.Status)
+	}
+	var processes []*vtgateProcess
+	if err := json.NewDecoder(resp.Body).Decode(&processes); err != nil {
+		return nil, err
+	}
+	return processes, nil
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtctl
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/wrangler"
+)
+
+// This file contains the command to read the rates of the key metrics of a
+// vttablet or a vtgate over the last minute, 5 minutes and hour, through
+// its /debug/rate_windows page. Only the tablets and the vtgates registered
+// in the topology are ever queried.
+
+func init() {
+	addCommand("Generic", command{
+		"GetRateWindows",
+		commandGetRateWindows,
+		"[-name=<metric>] <tablet alias|vtgate host:port>",
+		"Outputs a JSON structure with the rates per second of the key metrics of a vttablet, or of a vtgate registered in the topology, over the last minute, 5 minutes and hour, by metric and category, e.g. QPSByShardWindows for the queries of a vtgate per keyspace and shard."})
+}
+
+func commandGetRateWindows(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	name := subFlags.String("name", "", "Only outputs the rates of this metric")
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("the <tablet alias|vtgate host:port> argument is required for the GetRateWindows command")
+	}
+
+	addr := subFlags.Arg(0)
+	if alias, err := topoproto.ParseTabletAlias(addr); err == nil {
+		ti, err := wr.TopoServer().GetTablet(ctx, alias)
+		if err != nil {
+			return err
+		}
+		addr = ti.Addr()
+	} else if err := checkRegisteredVtGate(ctx, wr.TopoServer(), addr); err != nil {
+		return err
+	}
+	rates, err := getRateWindows(ctx, addr, *name)
+	if err != nil {
+		return err
+	}
+	return printJSON(wr.Logger(), rates)
+}
+
+// getRateWindows returns the rates of the metrics of a process by metric,
+// category and window, from its /debug/rate_windows page.
+func getRateWindows(ctx context.Context, addr, name string) (map[string]map[string]map[string]float64, error) {
+	u := url.URL{Scheme: "http", Host: addr, Path: "/debug/rate_windows"}
+	if name != "" {
+		u.RawQuery = url.Values{"name": []string{name}}.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return nil, fmt.Errorf("cannot read the rate windows of %v: %v: %s", addr, resp.Status, strings.TrimSpace(string(body)))
+	}
+	var rates map[string]map[string]map[string]float64
+	if err := json.NewDecoder(resp.Body).Decode(&rates); err != nil {
+		return nil, err
+	}
+	return rates, nil
+}
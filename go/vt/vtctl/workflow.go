@@ -19,11 +19,16 @@ package vtctl
 import (
 	"flag"
 	"fmt"
+	"io/ioutil"
+	"time"
 
 	"context"
 
+	"vitess.io/vitess/go/protoutil"
 	"vitess.io/vitess/go/vt/workflow"
 	"vitess.io/vitess/go/vt/wrangler"
+
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
 )
 
 // This file contains the workflows command group for vtctl.
@@ -64,6 +69,16 @@ func init() {
 		commandWorkflowWait,
 		"<uuid>",
 		"Waits for the workflow to finish."})
+	addCommand(workflowsGroupName, command{
+		"WorkflowExport",
+		commandWorkflowExport,
+		"<uuid>",
+		"Outputs the last checkpoint of the workflow as a JSON blob, which can be imported into another topology with WorkflowImport."})
+	addCommand(workflowsGroupName, command{
+		"WorkflowImport",
+		commandWorkflowImport,
+		"{-checkpoint=<blob> || -checkpoint_file=<file>}",
+		"Creates a workflow from a blob output by WorkflowExport. A running workflow is resumed from its checkpoint, so it must not run anymore where it was exported from."})
 
 	addCommand(workflowsGroupName, command{
 		"WorkflowTree",
@@ -75,6 +90,47 @@ func init() {
 		commandWorkflowAction,
 		"<path> <name>",
 		"Sends the provided action name on the specified path."})
+	addCommand(workflowsGroupName, command{
+		"GetWorkflowAuditLog",
+		commandGetWorkflowAuditLog,
+		"<uuid>",
+		"Outputs a JSON list of the actions on the workflow, with who requested them and from which address. The log is kept after the workflow is deleted."})
+	addCommand(workflowsGroupName, command{
+		"GetWorkflowStates",
+		commandGetWorkflowStates,
+		"[<uuid>]",
+		"Outputs a JSON list of the states of the workflows, or of one workflow, as last saved by the workflow manager running them: their owner vtctld, state and progress. It works on any vtctld, not only on the one running the workflow manager. A running workflow whose state is stale is waiting for a standby vtctld to adopt it."})
+	addCommand(workflowsGroupName, command{
+		"HostMaintenance",
+		commandHostMaintenance,
+		"[-uncordon] [-drain_grace=<duration>] [-wait_replicas_timeout=<duration>] <cell> <hostname>",
+		"Creates and starts a host_maintenance workflow which cordons the tablets of the host: the shards mastered on the host are reparented to a replica on another host, then each tablet is drained and its replication stopped. With -uncordon, the replication of the tablets which was running before they were cordoned is restarted, and they are undrained. A failed tablet can be retried from the workflow."})
+
+	addCommand(workflowsGroupName, command{
+		"WorkflowScheduleCreate",
+		commandWorkflowScheduleCreate,
+		"[-disabled] <name> <cron expression> <factoryName> [parameters...]",
+		"Creates a schedule which creates and starts a workflow with the provided parameters whenever the cron expression matches, e.g. '0 2 * * *' or @nightly, in the local time of the workflow manager. A run is skipped if the previous run of the schedule is still running."})
+	addCommand(workflowsGroupName, command{
+		"WorkflowScheduleEnable",
+		commandWorkflowScheduleEnable,
+		"<name>",
+		"Enables the schedule. The runs missed while it was disabled are not started."})
+	addCommand(workflowsGroupName, command{
+		"WorkflowScheduleDisable",
+		commandWorkflowScheduleDisable,
+		"<name>",
+		"Disables the schedule. Its running workflow, if any, keeps running."})
+	addCommand(workflowsGroupName, command{
+		"WorkflowScheduleDelete",
+		commandWorkflowScheduleDelete,
+		"<name>",
+		"Deletes the schedule. The workflows it created are kept."})
+	addCommand(workflowsGroupName, command{
+		"WorkflowSchedules",
+		commandWorkflowSchedules,
+		"",
+		"Outputs a JSON list of the schedules, with their last and next runs."})
 }
 
 func commandWorkflowCreate(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
@@ -103,6 +159,31 @@ func commandWorkflowCreate(ctx context.Context, wr *wrangler.Wrangler, subFlags
 	return nil
 }
 
+func commandHostMaintenance(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	uncordon := subFlags.Bool("uncordon", false, "Uncordon the tablets of the host after the maintenance")
+	drainGrace := subFlags.Duration("drain_grace", 30*time.Second, "How long to wait after draining a tablet for its in-flight queries to complete")
+	waitReplicasTimeout := subFlags.Duration("wait_replicas_timeout", 30*time.Second, "The time to wait for the replicas to catch up during the planned reparent of a master")
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 2 {
+		return fmt.Errorf("the <cell> and <hostname> arguments are required for the HostMaintenance command")
+	}
+
+	resp, err := wr.VtctldServer().StartHostMaintenance(ctx, &vtctldatapb.StartHostMaintenanceRequest{
+		Cell:                subFlags.Arg(0),
+		Hostname:            subFlags.Arg(1),
+		Uncordon:            *uncordon,
+		DrainGrace:          protoutil.DurationToProto(*drainGrace),
+		WaitReplicasTimeout: protoutil.DurationToProto(*waitReplicasTimeout),
+	})
+	if err != nil {
+		return err
+	}
+	wr.Logger().Printf("uuid: %v\n", resp.Uuid)
+	return nil
+}
+
 func commandWorkflowStart(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
 	if WorkflowManager == nil {
 		return fmt.Errorf("no workflow.Manager registered")
@@ -163,6 +244,57 @@ func commandWorkflowWait(ctx context.Context, wr *wrangler.Wrangler, subFlags *f
 	return WorkflowManager.Wait(ctx, uuid)
 }
 
+func commandWorkflowExport(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	if WorkflowManager == nil {
+		return fmt.Errorf("no workflow.Manager registered")
+	}
+
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("the <uuid> argument is required for the WorkflowExport command")
+	}
+	blob, err := WorkflowManager.Export(ctx, subFlags.Arg(0))
+	if err != nil {
+		return err
+	}
+	wr.Logger().Printf("%s\n", blob)
+	return nil
+}
+
+func commandWorkflowImport(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	if WorkflowManager == nil {
+		return fmt.Errorf("no workflow.Manager registered")
+	}
+
+	checkpoint := subFlags.String("checkpoint", "", "The workflow blob output by WorkflowExport")
+	checkpointFile := subFlags.String("checkpoint_file", "", "The file containing the workflow blob output by WorkflowExport")
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 0 {
+		return fmt.Errorf("the WorkflowImport command takes no parameter")
+	}
+	if (*checkpoint == "") == (*checkpointFile == "") {
+		return fmt.Errorf("exactly one of the checkpoint or checkpoint_file flags must be specified when calling the WorkflowImport command")
+	}
+	blob := []byte(*checkpoint)
+	if *checkpointFile != "" {
+		var err error
+		if blob, err = ioutil.ReadFile(*checkpointFile); err != nil {
+			return err
+		}
+	}
+
+	uuid, err := WorkflowManager.Import(ctx, blob)
+	if err != nil {
+		return err
+	}
+	wr.Logger().Printf("uuid: %v\n", uuid)
+	return nil
+}
+
 func commandWorkflowTree(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
 	if WorkflowManager == nil {
 		return fmt.Errorf("no workflow.Manager registered")
@@ -199,5 +331,112 @@ func commandWorkflowAction(ctx context.Context, wr *wrangler.Wrangler, subFlags
 		Name: subFlags.Arg(1),
 	}
 
-	return WorkflowManager.NodeManager().Action(ctx, ap)
+	return WorkflowManager.Action(ctx, ap)
+}
+
+func commandGetWorkflowAuditLog(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("the <uuid> argument is required for the GetWorkflowAuditLog command")
+	}
+	resp, err := wr.VtctldServer().GetWorkflowAuditLog(ctx, &vtctldatapb.GetWorkflowAuditLogRequest{
+		Uuid: subFlags.Arg(0),
+	})
+	if err != nil {
+		return err
+	}
+	return printJSON(wr.Logger(), resp.Entries)
+}
+
+func commandGetWorkflowStates(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() > 1 {
+		return fmt.Errorf("the GetWorkflowStates command takes at most one <uuid> argument")
+	}
+	states, err := workflow.GetRunStates(ctx, wr.TopoServer())
+	if err != nil {
+		return err
+	}
+	if subFlags.NArg() == 0 {
+		return printJSON(wr.Logger(), states)
+	}
+	for _, state := range states {
+		if state.UUID == subFlags.Arg(0) {
+			return printJSON(wr.Logger(), state)
+		}
+	}
+	return fmt.Errorf("no state for workflow %v", subFlags.Arg(0))
+}
+
+func commandWorkflowScheduleCreate(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	if WorkflowManager == nil {
+		return fmt.Errorf("no workflow.Manager registered")
+	}
+
+	disabled := subFlags.Bool("disabled", false, "If set, the schedule is created disabled.")
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() < 3 {
+		return fmt.Errorf("the <name>, <cron expression> and <factoryName> arguments are required for the WorkflowScheduleCreate command")
+	}
+	return WorkflowManager.CreateSchedule(ctx, subFlags.Arg(0), subFlags.Arg(1), subFlags.Arg(2), subFlags.Args()[3:], !*disabled)
+}
+
+func commandWorkflowScheduleEnable(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	return setWorkflowScheduleEnabled(ctx, subFlags, args, "WorkflowScheduleEnable", true)
+}
+
+func commandWorkflowScheduleDisable(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	return setWorkflowScheduleEnabled(ctx, subFlags, args, "WorkflowScheduleDisable", false)
+}
+
+func setWorkflowScheduleEnabled(ctx context.Context, subFlags *flag.FlagSet, args []string, commandName string, enabled bool) error {
+	if WorkflowManager == nil {
+		return fmt.Errorf("no workflow.Manager registered")
+	}
+
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("the <name> argument is required for the %v command", commandName)
+	}
+	return WorkflowManager.SetScheduleEnabled(ctx, subFlags.Arg(0), enabled)
+}
+
+func commandWorkflowScheduleDelete(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	if WorkflowManager == nil {
+		return fmt.Errorf("no workflow.Manager registered")
+	}
+
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("the <name> argument is required for the WorkflowScheduleDelete command")
+	}
+	return WorkflowManager.DeleteSchedule(ctx, subFlags.Arg(0))
+}
+
+func commandWorkflowSchedules(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	if WorkflowManager == nil {
+		return fmt.Errorf("no workflow.Manager registered")
+	}
+
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 0 {
+		return fmt.Errorf("the WorkflowSchedules command takes no parameter")
+	}
+	schedules, err := WorkflowManager.Schedules(ctx)
+	if err != nil {
+		return err
+	}
+	return printJSON(wr.Logger(), schedules)
 }
@@ -0,0 +1,363 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/trace"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vtctl/workflow/vexec"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+	"vitess.io/vitess/go/vt/proto/vttime"
+)
+
+const (
+	// ProgressPhaseCopy is the phase of a stream which still copies tables.
+	ProgressPhaseCopy = "copy"
+	// ProgressPhaseReplicate is the phase of a stream which has copied all
+	// its tables, and replicates the changes of the source.
+	ProgressPhaseReplicate = "replicate"
+
+	// logTypeCopyStart is the _vt.vreplication_log type recorded when the
+	// copy phase of a stream starts.
+	logTypeCopyStart = "Started Copy Phase"
+)
+
+// StreamProgress is the progress of a vreplication stream of a workflow.
+type StreamProgress struct {
+	ID          int64                   `json:"id"`
+	Shard       string                  `json:"shard"`
+	Tablet      *topodatapb.TabletAlias `json:"tablet"`
+	SourceShard string                  `json:"source_shard"`
+	State       string                  `json:"state"`
+	Phase       string                  `json:"phase"`
+	// TablesToCopy is the number of tables which remain to be copied.
+	TablesToCopy int `json:"tables_to_copy"`
+	// RowsCopied is the number of rows copied by the stream.
+	RowsCopied int64 `json:"rows_copied"`
+	// RowsEstimated is the approximate number of rows the stream copies,
+	// from the table statistics of its source shard. It is 0 if they can't
+	// be read.
+	RowsEstimated int64 `json:"rows_estimated"`
+	// CopyPercent is 100 once the stream replicates.
+	CopyPercent float64 `json:"copy_percent"`
+	// CopyRowsPerSecond is the average copy throughput since the copy
+	// phase started. It is 0 once the stream replicates.
+	CopyRowsPerSecond     float64    `json:"copy_rows_per_second"`
+	ReplicationLagSeconds int64      `json:"replication_lag_seconds"`
+	LastError             string     `json:"last_error,omitempty"`
+	LastErrorTime         *time.Time `json:"last_error_time,omitempty"`
+}
+
+// WorkflowProgress is the progress of a workflow, aggregated over the
+// streams of all its target shards.
+type WorkflowProgress struct {
+	Workflow       string `json:"workflow"`
+	SourceKeyspace string `json:"source_keyspace"`
+	TargetKeyspace string `json:"target_keyspace"`
+	// Phase is ProgressPhaseCopy while any stream copies.
+	Phase                    string  `json:"phase"`
+	RowsCopied               int64   `json:"rows_copied"`
+	RowsEstimated            int64   `json:"rows_estimated"`
+	CopyPercent              float64 `json:"copy_percent"`
+	CopyRowsPerSecond        float64 `json:"copy_rows_per_second"`
+	MaxReplicationLagSeconds int64   `json:"max_replication_lag_seconds"`
+	// ErrorStreams is the number of streams in the Error state.
+	ErrorStreams int `json:"error_streams"`
+	// LastError is the most recent error of any stream.
+	LastError     string            `json:"last_error,omitempty"`
+	LastErrorTime *time.Time        `json:"last_error_time,omitempty"`
+	Streams       []*StreamProgress `json:"streams"`
+}
+
+// GetWorkflowProgress returns the progress of a vreplication workflow, e.g.
+// MoveTables or Reshard, in the target keyspace: for each stream, the rows
+// copied against the rows estimated from the source tables, the copy
+// throughput, the replication lag and the last error, and their aggregates
+// over the workflow.
+func (s *Server) GetWorkflowProgress(ctx context.Context, keyspace string, workflow string) (*WorkflowProgress, error) {
+	span, ctx := trace.NewSpan(ctx, "workflow.Server.GetWorkflowProgress")
+	defer span.Finish()
+
+	span.Annotate("keyspace", keyspace)
+	span.Annotate("workflow", workflow)
+
+	resp, err := s.GetWorkflows(ctx, &vtctldatapb.GetWorkflowsRequest{Keyspace: keyspace})
+	if err != nil {
+		return nil, err
+	}
+
+	var wf *vtctldatapb.Workflow
+	for _, w := range resp.Workflows {
+		if w.Name == workflow {
+			wf = w
+			break
+		}
+	}
+
+	if wf == nil {
+		return nil, fmt.Errorf("workflow %s not found in keyspace %s", workflow, keyspace)
+	}
+
+	rowsCopied, err := s.getWorkflowRowsCopied(ctx, keyspace, workflow)
+	if err != nil {
+		return nil, err
+	}
+
+	// The target shards of a source shard split its rows, so each stream is
+	// estimated to copy its share of the source tables.
+	streamsBySource := make(map[string]int)
+	for _, shardStream := range wf.ShardStreams {
+		for _, stream := range shardStream.Streams {
+			streamsBySource[stream.BinlogSource.Shard]++
+		}
+	}
+
+	sourceRows := make(map[string]int64)
+	now := time.Now()
+	progress := &WorkflowProgress{
+		Workflow:       wf.Name,
+		SourceKeyspace: wf.Source.Keyspace,
+		TargetKeyspace: wf.Target.Keyspace,
+	}
+
+	for _, shardStream := range wf.ShardStreams {
+		for _, stream := range shardStream.Streams {
+			sourceShard := stream.BinlogSource.Shard
+			rows, ok := sourceRows[sourceShard]
+			if !ok {
+				rows, err = s.getSourceRowCount(ctx, stream)
+				if err != nil {
+					log.Warningf("Cannot estimate the rows of workflow %s.%s in source shard %s/%s: %v", keyspace, workflow, stream.BinlogSource.Keyspace, sourceShard, err)
+				}
+
+				sourceRows[sourceShard] = rows
+			}
+
+			copied := rowsCopied[fmt.Sprintf("%s/%d", topoproto.TabletAliasString(stream.Tablet), stream.Id)]
+			progress.Streams = append(progress.Streams, newStreamProgress(stream, copied, rows/int64(streamsBySource[sourceShard]), now))
+		}
+	}
+
+	aggregateWorkflowProgress(progress)
+
+	return progress, nil
+}
+
+// getWorkflowRowsCopied returns the rows copied by the streams of the
+// workflow, keyed by "<tablet alias>/<stream id>".
+func (s *Server) getWorkflowRowsCopied(ctx context.Context, keyspace string, workflow string) (map[string]int64, error) {
+	vx := vexec.NewVExec(keyspace, workflow, s.ts, s.tmc)
+	results, err := vx.QueryContext(ctx, "select id, rows_copied from _vt.vreplication")
+	if err != nil {
+		return nil, err
+	}
+
+	rowsCopied := make(map[string]int64)
+	for tablet, p3qr := range results {
+		qr := sqltypes.Proto3ToResult(p3qr)
+		for _, row := range qr.Rows {
+			id, err := evalengine.ToInt64(row[0])
+			if err != nil {
+				return nil, err
+			}
+
+			copied, err := evalengine.ToInt64(row[1])
+			if err != nil {
+				return nil, err
+			}
+
+			rowsCopied[fmt.Sprintf("%s/%d", tablet.AliasString(), id)] = copied
+		}
+	}
+
+	return rowsCopied, nil
+}
+
+// getSourceRowCount returns the approximate number of rows of the tables
+// the stream copies, from the table statistics of the primary of its source
+// shard.
+func (s *Server) getSourceRowCount(ctx context.Context, stream *vtctldatapb.Workflow_Stream) (int64, error) {
+	bls := stream.BinlogSource
+	if bls.Filter == nil || len(bls.Filter.Rules) == 0 {
+		return 0, nil
+	}
+
+	si, err := s.ts.GetShard(ctx, bls.Keyspace, bls.Shard)
+	if err != nil {
+		return 0, err
+	}
+
+	if si.MasterAlias == nil {
+		return 0, fmt.Errorf("shard %s/%s has no primary", bls.Keyspace, bls.Shard)
+	}
+
+	primary, err := s.ts.GetTablet(ctx, si.MasterAlias)
+	if err != nil {
+		return 0, err
+	}
+
+	tables := make([]string, 0, len(bls.Filter.Rules))
+	for _, rule := range bls.Filter.Rules {
+		tables = append(tables, rule.Match)
+	}
+
+	schema, err := s.tmc.GetSchema(ctx, primary.Tablet, tables, nil, false)
+	if err != nil {
+		return 0, err
+	}
+
+	var rows int64
+	for _, td := range schema.TableDefinitions {
+		rows += int64(td.RowCount)
+	}
+
+	return rows, nil
+}
+
+// newStreamProgress returns the progress of a stream, given the rows it
+// copied and its share of the rows of the source tables.
+func newStreamProgress(stream *vtctldatapb.Workflow_Stream, rowsCopied int64, rowsEstimated int64, now time.Time) *StreamProgress {
+	sp := &StreamProgress{
+		ID:            stream.Id,
+		Shard:         stream.Shard,
+		Tablet:        stream.Tablet,
+		SourceShard:   stream.BinlogSource.Shard,
+		State:         stream.State,
+		Phase:         ProgressPhaseReplicate,
+		TablesToCopy:  len(stream.CopyStates),
+		RowsCopied:    rowsCopied,
+		RowsEstimated: rowsEstimated,
+		CopyPercent:   100,
+	}
+
+	timeUpdated := protoTime(stream.TimeUpdated)
+	if !timeUpdated.IsZero() {
+		sp.ReplicationLagSeconds = int64(now.Sub(timeUpdated).Seconds())
+	}
+
+	if len(stream.CopyStates) > 0 {
+		sp.Phase = ProgressPhaseCopy
+		sp.CopyPercent = 0
+		if rowsEstimated > 0 {
+			// The estimate comes from table statistics, so the rows copied
+			// may exceed it.
+			sp.CopyPercent = 100 * float64(rowsCopied) / float64(rowsEstimated)
+			if sp.CopyPercent > 100 {
+				sp.CopyPercent = 100
+			}
+		}
+
+		var copyStart time.Time
+		for _, l := range stream.Logs {
+			if l.Type == logTypeCopyStart {
+				copyStart = protoTime(l.CreatedAt)
+				break
+			}
+		}
+
+		if !copyStart.IsZero() && timeUpdated.After(copyStart) {
+			sp.CopyRowsPerSecond = float64(rowsCopied) / timeUpdated.Sub(copyStart).Seconds()
+		}
+	}
+
+	setLastError := func(message string, at time.Time) {
+		if sp.LastErrorTime != nil && !at.After(*sp.LastErrorTime) {
+			return
+		}
+
+		sp.LastError = message
+		sp.LastErrorTime = &at
+	}
+
+	for _, l := range stream.Logs {
+		if l.Type == "Error" || strings.Contains(strings.ToLower(l.Message), "error") {
+			setLastError(l.Message, protoTime(l.UpdatedAt))
+		}
+	}
+
+	if stream.State == "Error" {
+		setLastError(stream.Message, timeUpdated)
+	}
+
+	return sp
+}
+
+// aggregateWorkflowProgress sets the progress of the workflow from the
+// progress of its streams, and sorts them by shard and id.
+func aggregateWorkflowProgress(progress *WorkflowProgress) {
+	sort.Slice(progress.Streams, func(i, j int) bool {
+		if progress.Streams[i].Shard != progress.Streams[j].Shard {
+			return progress.Streams[i].Shard < progress.Streams[j].Shard
+		}
+
+		return progress.Streams[i].ID < progress.Streams[j].ID
+	})
+
+	progress.Phase = ProgressPhaseReplicate
+	progress.CopyPercent = 100
+
+	var copiedEstimate float64
+	for _, sp := range progress.Streams {
+		progress.RowsCopied += sp.RowsCopied
+		progress.RowsEstimated += sp.RowsEstimated
+		progress.CopyRowsPerSecond += sp.CopyRowsPerSecond
+		copiedEstimate += sp.CopyPercent / 100 * float64(sp.RowsEstimated)
+
+		if sp.Phase == ProgressPhaseCopy {
+			progress.Phase = ProgressPhaseCopy
+		}
+
+		if sp.ReplicationLagSeconds > progress.MaxReplicationLagSeconds {
+			progress.MaxReplicationLagSeconds = sp.ReplicationLagSeconds
+		}
+
+		if sp.State == "Error" {
+			progress.ErrorStreams++
+		}
+
+		if sp.LastErrorTime != nil && (progress.LastErrorTime == nil || sp.LastErrorTime.After(*progress.LastErrorTime)) {
+			progress.LastError = sp.LastError
+			progress.LastErrorTime = sp.LastErrorTime
+		}
+	}
+
+	if progress.Phase == ProgressPhaseCopy {
+		progress.CopyPercent = 0
+		if progress.RowsEstimated > 0 {
+			progress.CopyPercent = 100 * copiedEstimate / float64(progress.RowsEstimated)
+		}
+	}
+}
+
+func protoTime(t *vttime.Time) time.Time {
+	if t == nil || t.Seconds == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(t.Seconds, int64(t.Nanoseconds))
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+	"vitess.io/vitess/go/vt/proto/vttime"
+)
+
+func TestWorkflowProgress(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1000, 0)
+	copying := &vtctldatapb.Workflow_Stream{
+		Id:           1,
+		Shard:        "-80",
+		Tablet:       &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+		BinlogSource: &binlogdatapb.BinlogSource{Keyspace: "source", Shard: "0"},
+		State:        "Copying",
+		TimeUpdated:  &vttime.Time{Seconds: 990},
+		CopyStates:   []*vtctldatapb.Workflow_Stream_CopyState{{Table: "t1"}},
+		Logs: []*vtctldatapb.Workflow_Stream_Log{
+			{Type: "Stream Created", CreatedAt: &vttime.Time{Seconds: 800}, UpdatedAt: &vttime.Time{Seconds: 800}},
+			{Type: logTypeCopyStart, CreatedAt: &vttime.Time{Seconds: 890}, UpdatedAt: &vttime.Time{Seconds: 890}},
+			{Type: "Message", Message: "vttablet: rpc error: code = Unavailable", CreatedAt: &vttime.Time{Seconds: 900}, UpdatedAt: &vttime.Time{Seconds: 900}},
+			{Type: "Message", Message: "Error: table not found", CreatedAt: &vttime.Time{Seconds: 950}, UpdatedAt: &vttime.Time{Seconds: 960}},
+		},
+	}
+	replicating := &vtctldatapb.Workflow_Stream{
+		Id:           1,
+		Shard:        "80-",
+		Tablet:       &topodatapb.TabletAlias{Cell: "zone1", Uid: 200},
+		BinlogSource: &binlogdatapb.BinlogSource{Keyspace: "source", Shard: "0"},
+		State:        "Error",
+		Message:      "error applying event: duplicate key",
+		TimeUpdated:  &vttime.Time{Seconds: 970},
+	}
+
+	sp := newStreamProgress(copying, 500, 2000, now)
+	assert.Equal(t, ProgressPhaseCopy, sp.Phase)
+	assert.Equal(t, &topodatapb.TabletAlias{Cell: "zone1", Uid: 100}, sp.Tablet)
+	assert.Equal(t, 1, sp.TablesToCopy)
+	assert.Equal(t, 25.0, sp.CopyPercent)
+	assert.Equal(t, 5.0, sp.CopyRowsPerSecond)
+	assert.Equal(t, int64(10), sp.ReplicationLagSeconds)
+	assert.Equal(t, "Error: table not found", sp.LastError)
+	require.NotNil(t, sp.LastErrorTime)
+	assert.Equal(t, time.Unix(960, 0), *sp.LastErrorTime)
+
+	// The table statistics may underestimate the rows.
+	assert.Equal(t, 100.0, newStreamProgress(copying, 3000, 2000, now).CopyPercent)
+
+	sp2 := newStreamProgress(replicating, 2000, 2000, now)
+	assert.Equal(t, ProgressPhaseReplicate, sp2.Phase)
+	assert.Equal(t, 100.0, sp2.CopyPercent)
+	assert.Zero(t, sp2.CopyRowsPerSecond)
+	assert.Equal(t, int64(30), sp2.ReplicationLagSeconds)
+	assert.Equal(t, "error applying event: duplicate key", sp2.LastError)
+
+	progress := &WorkflowProgress{Streams: []*StreamProgress{sp2, sp}}
+	aggregateWorkflowProgress(progress)
+	assert.Equal(t, []*StreamProgress{sp, sp2}, progress.Streams)
+	assert.Equal(t, ProgressPhaseCopy, progress.Phase)
+	assert.Equal(t, int64(2500), progress.RowsCopied)
+	assert.Equal(t, int64(4000), progress.RowsEstimated)
+	assert.Equal(t, 62.5, progress.CopyPercent)
+	assert.Equal(t, 5.0, progress.CopyRowsPerSecond)
+	assert.Equal(t, int64(30), progress.MaxReplicationLagSeconds)
+	assert.Equal(t, 1, progress.ErrorStreams)
+	assert.Equal(t, "error applying event: duplicate key", progress.LastError)
+}
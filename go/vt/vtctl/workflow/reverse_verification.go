@@ -0,0 +1,446 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"vitess.io/vitess/go/sqlescape"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/trace"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+// Once writes are switched, the workflow must not be completed before its
+// reverse workflow is known to keep the original source up to date, or the
+// traffic could not be switched back without losing writes. The functions
+// below verify the reverse streams, and optionally compare a sample of the
+// rows of the moved tables between the original source and the target.
+
+// ReverseVerificationOptions are the options of VerifyReverseReplication.
+type ReverseVerificationOptions struct {
+	// MaxLag is the maximum lag of the reverse streams for them to be
+	// caught up.
+	MaxLag time.Duration
+	// Checksum compares the checksums of a sample of the rows of the moved
+	// tables between the source and the target.
+	Checksum bool
+	// SampleRate is the inverse of the fraction of the rows, by hash of
+	// their primary key, whose checksums are compared: 1 compares all the
+	// rows.
+	SampleRate int
+}
+
+// ReverseReplicationReport is the result of VerifyReverseReplication.
+type ReverseReplicationReport struct {
+	Keyspace        string
+	Workflow        string
+	ReverseKeyspace string
+	ReverseWorkflow string
+
+	// Streams are the reverse streams, on the primaries of the original
+	// source shards.
+	Streams []*ReverseStreamReport
+	// StreamsHealthy is true if there is a reverse stream for each source
+	// shard, running without errors, with a lag below the maximum.
+	StreamsHealthy bool
+	// StreamsReason explains the value of StreamsHealthy.
+	StreamsReason string
+	// MaxReverseLag is the maximum lag of the reverse streams, in seconds.
+	MaxReverseLag int64
+
+	// Tables are the checksum comparisons of the moved tables, if
+	// requested.
+	Tables []*TableChecksumReport `json:",omitempty"`
+
+	// Verified is true if the reverse streams are healthy, and the
+	// checksums of the tables match if they were compared.
+	Verified bool
+	// Reason explains the value of Verified.
+	Reason string
+}
+
+// ReverseStreamReport is the state of a reverse stream.
+type ReverseStreamReport struct {
+	Shard   string
+	Tablet  *topodatapb.TabletAlias
+	ID      int64
+	State   string
+	Message string `json:",omitempty"`
+}
+
+// TableChecksumReport compares the checksum of a sample of the rows of a
+// table on the source and on the target. The rows are sampled by a hash of
+// their primary key, so the same rows are sampled whatever the sharding.
+type TableChecksumReport struct {
+	Table          string
+	SampleRate     int
+	SourceRows     int64
+	TargetRows     int64
+	SourceChecksum uint64
+	TargetChecksum uint64
+	Match          bool
+}
+
+// VerifyReverseReplication verifies that the reverse workflow of a workflow
+// whose writes were switched exists, is running and has caught up, and
+// optionally compares the checksums of a sample of the moved rows between
+// the source and the target. Rows written while the comparison runs may
+// make it report a transient mismatch.
+func (s *Server) VerifyReverseReplication(ctx context.Context, keyspace string, workflow string, opts ReverseVerificationOptions) (*ReverseReplicationReport, error) {
+	span, ctx := trace.NewSpan(ctx, "workflow.Server.VerifyReverseReplication")
+	defer span.Finish()
+
+	span.Annotate("keyspace", keyspace)
+	span.Annotate("workflow", workflow)
+	span.Annotate("checksum", opts.Checksum)
+
+	if opts.Checksum && opts.SampleRate < 1 {
+		return nil, fmt.Errorf("invalid sample rate %d: it must be at least 1", opts.SampleRate)
+	}
+
+	wf, err := s.getWorkflow(ctx, keyspace, workflow)
+	if err != nil {
+		return nil, err
+	}
+
+	if wf == nil {
+		return nil, fmt.Errorf("no streams found for workflow %s in keyspace %s", workflow, keyspace)
+	}
+
+	if !workflowWritesSwitched(wf) {
+		return nil, fmt.Errorf("writes of workflow %s.%s are not switched: there is no reverse workflow to verify", keyspace, workflow)
+	}
+
+	report := &ReverseReplicationReport{
+		Keyspace:        keyspace,
+		Workflow:        workflow,
+		ReverseKeyspace: wf.Source.Keyspace,
+		ReverseWorkflow: ReverseWorkflowName(workflow),
+	}
+
+	reverse, err := s.getWorkflow(ctx, report.ReverseKeyspace, report.ReverseWorkflow)
+	switch {
+	case err != nil:
+		report.StreamsReason = fmt.Sprintf("cannot read the reverse workflow %s.%s: %v", report.ReverseKeyspace, report.ReverseWorkflow, err)
+	case reverse == nil:
+		report.StreamsReason = "no reverse streams found"
+	default:
+		report.Streams = reverseStreamReports(reverse)
+		report.MaxReverseLag = reverse.MaxVReplicationLag
+		report.StreamsHealthy, report.StreamsReason = evaluateReverseStreams(report.Streams, reverse.MaxVReplicationLag, opts.MaxLag)
+		if missing := missingReverseShards(wf.Source.Shards, report.Streams); len(missing) > 0 {
+			report.StreamsHealthy = false
+			report.StreamsReason = fmt.Sprintf("no reverse stream on source shards %s", strings.Join(missing, ","))
+		}
+	}
+
+	report.Verified, report.Reason = report.StreamsHealthy, report.StreamsReason
+
+	if opts.Checksum {
+		tables, err := s.checksumMovedTables(ctx, wf, opts.SampleRate)
+		if err != nil {
+			return nil, err
+		}
+
+		report.Tables = tables
+
+		var mismatches []string
+		for _, table := range tables {
+			if !table.Match {
+				mismatches = append(mismatches, table.Table)
+			}
+		}
+
+		if len(mismatches) > 0 && report.Verified {
+			report.Verified = false
+			report.Reason = fmt.Sprintf("the sampled checksums of tables %s differ between the source and the target", strings.Join(mismatches, ","))
+		}
+	}
+
+	return report, nil
+}
+
+// getWorkflow returns the workflow of the keyspace, or nil if it has no
+// streams.
+func (s *Server) getWorkflow(ctx context.Context, keyspace string, workflow string) (*vtctldatapb.Workflow, error) {
+	resp, err := s.GetWorkflows(ctx, &vtctldatapb.GetWorkflowsRequest{Keyspace: keyspace})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, wf := range resp.Workflows {
+		if wf.Name == workflow {
+			return wf, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// workflowWritesSwitched returns true if the streams of the workflow were
+// frozen by SwitchWrites.
+func workflowWritesSwitched(wf *vtctldatapb.Workflow) bool {
+	for _, shardStream := range wf.ShardStreams {
+		for _, stream := range shardStream.Streams {
+			if stream.Message == Frozen {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// reverseStreamReports returns the reverse streams, sorted by shard.
+func reverseStreamReports(reverse *vtctldatapb.Workflow) []*ReverseStreamReport {
+	var streams []*ReverseStreamReport
+	for _, shardStream := range reverse.ShardStreams {
+		for _, stream := range shardStream.Streams {
+			streams = append(streams, &ReverseStreamReport{
+				Shard:   stream.Shard,
+				Tablet:  stream.Tablet,
+				ID:      stream.Id,
+				State:   stream.State,
+				Message: stream.Message,
+			})
+		}
+	}
+
+	sort.Slice(streams, func(i, j int) bool {
+		if streams[i].Shard != streams[j].Shard {
+			return streams[i].Shard < streams[j].Shard
+		}
+
+		return streams[i].ID < streams[j].ID
+	})
+
+	return streams
+}
+
+// evaluateReverseStreams returns whether the reverse streams, sorted by
+// shard, run without errors with a lag below the maximum, and why.
+func evaluateReverseStreams(streams []*ReverseStreamReport, lagSeconds int64, maxLag time.Duration) (bool, string) {
+	if len(streams) == 0 {
+		return false, "no reverse streams found"
+	}
+
+	for _, stream := range streams {
+		switch {
+		case stream.State == "Error":
+			return false, fmt.Sprintf("reverse stream %d on %s/%s is in error: %s", stream.ID, stream.Shard, topoproto.TabletAliasString(stream.Tablet), stream.Message)
+		case stream.State == "Stopped" && stream.Message != "":
+			return false, fmt.Sprintf("reverse stream %d on %s/%s is stopped: %s", stream.ID, stream.Shard, topoproto.TabletAliasString(stream.Tablet), stream.Message)
+		case stream.State == "Stopped":
+			return false, fmt.Sprintf("reverse stream %d on %s/%s is stopped", stream.ID, stream.Shard, topoproto.TabletAliasString(stream.Tablet))
+		}
+	}
+
+	if lag := time.Duration(lagSeconds) * time.Second; lag > maxLag {
+		return false, fmt.Sprintf("reverse replication lag %v exceeds %v", lag, maxLag)
+	}
+
+	return true, fmt.Sprintf("reverse replication is running, lag %ds", lagSeconds)
+}
+
+// missingReverseShards returns the source shards without a reverse stream.
+func missingReverseShards(sourceShards []string, streams []*ReverseStreamReport) []string {
+	covered := make(map[string]bool)
+	for _, stream := range streams {
+		covered[stream.Shard] = true
+	}
+
+	var missing []string
+	for _, shard := range sourceShards {
+		if !covered[shard] {
+			missing = append(missing, shard)
+		}
+	}
+
+	return missing
+}
+
+// checksumMovedTables compares the checksums of a sample of the rows of the
+// tables moved by the workflow, between its source and target shards.
+func (s *Server) checksumMovedTables(ctx context.Context, wf *vtctldatapb.Workflow, sampleRate int) ([]*TableChecksumReport, error) {
+	targetPrimary, err := s.shardPrimary(ctx, wf.Target.Keyspace, wf.Target.Shards[0])
+	if err != nil {
+		return nil, err
+	}
+
+	tables, allTables := movedTables(wf)
+	if allTables {
+		tables = nil
+	}
+
+	schema, err := s.tmc.GetSchema(ctx, targetPrimary, tables, nil, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var reports []*TableChecksumReport
+	for _, td := range schema.TableDefinitions {
+		query, err := sampledChecksumQuery(td, sampleRate)
+		if err != nil {
+			return nil, err
+		}
+
+		report := &TableChecksumReport{Table: td.Name, SampleRate: sampleRate}
+		report.SourceRows, report.SourceChecksum, err = s.keyspaceChecksum(ctx, wf.Source, query)
+		if err != nil {
+			return nil, fmt.Errorf("table %s on the source: %v", td.Name, err)
+		}
+
+		report.TargetRows, report.TargetChecksum, err = s.keyspaceChecksum(ctx, wf.Target, query)
+		if err != nil {
+			return nil, fmt.Errorf("table %s on the target: %v", td.Name, err)
+		}
+
+		report.Match = report.SourceRows == report.TargetRows && report.SourceChecksum == report.TargetChecksum
+		reports = append(reports, report)
+	}
+
+	return reports, nil
+}
+
+// movedTables returns the tables moved by the streams of the workflow, or
+// true if they move all the tables, like Reshard does.
+func movedTables(wf *vtctldatapb.Workflow) ([]string, bool) {
+	tables := make(map[string]bool)
+	for _, shardStream := range wf.ShardStreams {
+		for _, stream := range shardStream.Streams {
+			for _, rule := range stream.BinlogSource.GetFilter().GetRules() {
+				if strings.HasPrefix(rule.Match, "/") {
+					return nil, true
+				}
+
+				tables[rule.Match] = true
+			}
+		}
+	}
+
+	names := make([]string, 0, len(tables))
+	for table := range tables {
+		names = append(names, table)
+	}
+
+	sort.Strings(names)
+
+	return names, false
+}
+
+// sampledChecksumQuery returns the query which counts and checksums the
+// sampled rows of the table on a shard. The checksums of the rows are
+// XORed, so the checksums of the shards can be combined.
+func sampledChecksumQuery(td *tabletmanagerdatapb.TableDefinition, sampleRate int) (string, error) {
+	if len(td.PrimaryKeyColumns) == 0 {
+		return "", fmt.Errorf("table %s has no primary key to sample its rows", td.Name)
+	}
+
+	escape := func(columns []string) string {
+		escaped := make([]string, len(columns))
+		for i, column := range columns {
+			escaped[i] = sqlescape.EscapeID(column)
+		}
+
+		return strings.Join(escaped, ", ")
+	}
+
+	query := fmt.Sprintf("select count(*) as row_count, ifnull(bit_xor(crc32(concat_ws('#', %s))), 0) as checksum from %s",
+		escape(td.Columns), sqlescape.EscapeID(td.Name))
+	if sampleRate > 1 {
+		query += fmt.Sprintf(" where crc32(concat_ws('#', %s)) %% %d = 0", escape(td.PrimaryKeyColumns), sampleRate)
+	}
+
+	return query, nil
+}
+
+// keyspaceChecksum runs the checksum query on the primaries of the shards,
+// and combines their results.
+func (s *Server) keyspaceChecksum(ctx context.Context, location *vtctldatapb.Workflow_ReplicationLocation, query string) (int64, uint64, error) {
+	var (
+		rows     int64
+		checksum uint64
+	)
+
+	for _, shard := range location.Shards {
+		primary, err := s.shardPrimary(ctx, location.Keyspace, shard)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		p3qr, err := s.tmc.ExecuteFetchAsApp(ctx, primary, true, []byte(query), 1)
+		if err != nil {
+			return 0, 0, fmt.Errorf("shard %s: %v", shard, err)
+		}
+
+		shardRows, shardChecksum, err := parseChecksumResult(sqltypes.Proto3ToResult(p3qr))
+		if err != nil {
+			return 0, 0, fmt.Errorf("shard %s: %v", shard, err)
+		}
+
+		rows += shardRows
+		checksum ^= shardChecksum
+	}
+
+	return rows, checksum, nil
+}
+
+func parseChecksumResult(qr *sqltypes.Result) (int64, uint64, error) {
+	if len(qr.Rows) != 1 || len(qr.Rows[0]) != 2 {
+		return 0, 0, fmt.Errorf("unexpected checksum result: %v", qr.Rows)
+	}
+
+	rows, err := qr.Rows[0][0].ToInt64()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	checksum, err := qr.Rows[0][1].ToUint64()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return rows, checksum, nil
+}
+
+// shardPrimary returns the primary tablet of the shard.
+func (s *Server) shardPrimary(ctx context.Context, keyspace string, shard string) (*topodatapb.Tablet, error) {
+	si, err := s.ts.GetShard(ctx, keyspace, shard)
+	if err != nil {
+		return nil, err
+	}
+
+	if si.MasterAlias == nil {
+		return nil, fmt.Errorf("shard %s/%s has no primary", keyspace, shard)
+	}
+
+	ti, err := s.ts.GetTablet(ctx, si.MasterAlias)
+	if err != nil {
+		return nil, err
+	}
+
+	return ti.Tablet, nil
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+func TestSampledChecksumQuery(t *testing.T) {
+	td := &tabletmanagerdatapb.TableDefinition{
+		Name:              "customer",
+		Columns:           []string{"customer_id", "email"},
+		PrimaryKeyColumns: []string{"customer_id"},
+	}
+	query, err := sampledChecksumQuery(td, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "select count(*) as row_count, ifnull(bit_xor(crc32(concat_ws('#', `customer_id`, `email`))), 0) as checksum from `customer`", query)
+
+	query, err = sampledChecksumQuery(td, 100)
+	require.NoError(t, err)
+	assert.Equal(t, "select count(*) as row_count, ifnull(bit_xor(crc32(concat_ws('#', `customer_id`, `email`))), 0) as checksum from `customer` where crc32(concat_ws('#', `customer_id`)) % 100 = 0", query)
+
+	_, err = sampledChecksumQuery(&tabletmanagerdatapb.TableDefinition{Name: "nopk", Columns: []string{"c"}}, 1)
+	assert.EqualError(t, err, "table nopk has no primary key to sample its rows")
+}
+
+func TestMovedTables(t *testing.T) {
+	streams := func(matches ...string) *vtctldatapb.Workflow {
+		var rules []*binlogdatapb.Rule
+		for _, match := range matches {
+			rules = append(rules, &binlogdatapb.Rule{Match: match})
+		}
+
+		return &vtctldatapb.Workflow{ShardStreams: map[string]*vtctldatapb.Workflow_ShardStream{
+			"-80/cell1-0000000100": {Streams: []*vtctldatapb.Workflow_Stream{{
+				BinlogSource: &binlogdatapb.BinlogSource{Filter: &binlogdatapb.Filter{Rules: rules}},
+			}}},
+		}}
+	}
+
+	tables, all := movedTables(streams("customer", "corder"))
+	assert.False(t, all)
+	assert.Equal(t, []string{"corder", "customer"}, tables)
+
+	_, all = movedTables(streams("/.*"))
+	assert.True(t, all)
+}
+
+func TestMissingReverseShards(t *testing.T) {
+	streams := []*ReverseStreamReport{{Shard: "-80", ID: 1}, {Shard: "-80", ID: 2}, {Shard: "c0-", ID: 1}}
+	assert.Equal(t, []string{"80-c0"}, missingReverseShards([]string{"-80", "80-c0", "c0-"}, streams))
+	assert.Empty(t, missingReverseShards([]string{"-80", "c0-"}, streams))
+}
+
+func TestParseChecksumResult(t *testing.T) {
+	rows, checksum, err := parseChecksumResult(sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields("row_count|checksum", "int64|uint64"),
+		"42|3735928559",
+	))
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), rows)
+	assert.Equal(t, uint64(3735928559), checksum)
+
+	_, _, err = parseChecksumResult(&sqltypes.Result{})
+	assert.Error(t, err)
+}
@@ -229,6 +229,12 @@ func (s *Server) GetCellsWithTableReadsSwitched(
 			if rule.FromTable == ruleName {
 				found = true
 
+				// The reads are not switched while they are split between
+				// the keyspaces by SplitReads, with weights.
+				if len(rule.Weights) > 0 {
+					break
+				}
+
 				for _, to := range rule.ToTables {
 					ks, err := getKeyspace(to)
 					if err != nil {
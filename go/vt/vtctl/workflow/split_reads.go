@@ -0,0 +1,153 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"vitess.io/vitess/go/trace"
+	"vitess.io/vitess/go/vt/topo"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+)
+
+// The reads of the tables of a MoveTables workflow can be switched to its
+// target keyspace in steps, e.g. 1%, 10%, 50%, then 100%, instead of all at
+// once like SwitchReads does. The routing rules of the tables get weights,
+// which the vtgates use to pick the keyspace of each query.
+
+// ValidateSplitReads checks the percentage of the reads and the tablet types
+// of a read split.
+func ValidateSplitReads(servedTypes []topodatapb.TabletType, percent int) error {
+	if percent < 0 || percent > 100 {
+		return fmt.Errorf("invalid percentage of reads %d: it must be between 0 and 100", percent)
+	}
+	for _, servedType := range servedTypes {
+		if servedType != topodatapb.TabletType_REPLICA && servedType != topodatapb.TabletType_RDONLY {
+			return fmt.Errorf("tablet type must be REPLICA or RDONLY: %v", servedType)
+		}
+	}
+	return nil
+}
+
+// SplitReads routes a percentage of the reads of the tables of a MoveTables
+// workflow to its target keyspace. 0% routes all the reads to the source
+// keyspace again, and 100% is the same as SwitchReads.
+func (s *Server) SplitReads(ctx context.Context, targetKeyspace string, workflow string, servedTypes []topodatapb.TabletType, cells []string, percent int) (err error) {
+	span, ctx := trace.NewSpan(ctx, "workflow.Server.SplitReads")
+	defer span.Finish()
+
+	span.Annotate("keyspace", targetKeyspace)
+	span.Annotate("workflow", workflow)
+	span.Annotate("percent", percent)
+
+	if err := ValidateSplitReads(servedTypes, percent); err != nil {
+		return err
+	}
+
+	wf, err := s.getWorkflow(ctx, targetKeyspace, workflow)
+	if err != nil {
+		return err
+	}
+
+	if wf == nil {
+		return fmt.Errorf("no streams found for workflow %s in keyspace %s", workflow, targetKeyspace)
+	}
+
+	tables, all := movedTables(wf)
+	if all || wf.Source.Keyspace == targetKeyspace {
+		return fmt.Errorf("the reads of workflow %s.%s can't be split: only the reads of MoveTables workflows can", targetKeyspace, workflow)
+	}
+
+	if workflowWritesSwitched(wf) {
+		return fmt.Errorf("the writes of workflow %s.%s are switched: its reads can't be split anymore", targetKeyspace, workflow)
+	}
+
+	// For reads, locking the source keyspace is sufficient.
+	ctx, unlock, lockErr := s.ts.LockKeyspace(ctx, wf.Source.Keyspace, "SplitReads")
+	if lockErr != nil {
+		return lockErr
+	}
+	defer unlock(&err)
+
+	return SplitTableReads(ctx, s.ts, wf.Source.Keyspace, targetKeyspace, tables, servedTypes, cells, percent)
+}
+
+// SplitTableReads routes a percentage of the reads of the tables to the
+// target keyspace, by setting the weights of their routing rules, and
+// rebuilds the SrvVSchema of the cells. The source keyspace must be locked.
+func SplitTableReads(ctx context.Context, ts *topo.Server, sourceKeyspace, targetKeyspace string, tables []string, servedTypes []topodatapb.TabletType, cells []string, percent int) error {
+	rr, err := ts.GetRoutingRules(ctx)
+	if err != nil {
+		return err
+	}
+
+	rules := make(map[string]*vschemapb.RoutingRule, len(rr.Rules))
+	for _, rule := range rr.Rules {
+		rules[rule.FromTable] = rule
+	}
+
+	for _, servedType := range servedTypes {
+		tt := strings.ToLower(servedType.String())
+		for _, table := range tables {
+			toSource := sourceKeyspace + "." + table
+			toTarget := targetKeyspace + "." + table
+
+			var (
+				toTables []string
+				weights  []int32
+			)
+
+			switch percent {
+			case 0:
+				toTables = []string{toSource}
+			case 100:
+				toTables = []string{toTarget}
+			default:
+				toTables = []string{toSource, toTarget}
+				weights = []int32{int32(100 - percent), int32(percent)}
+			}
+
+			for _, from := range []string{table + "@" + tt, targetKeyspace + "." + table + "@" + tt, sourceKeyspace + "." + table + "@" + tt} {
+				rules[from] = &vschemapb.RoutingRule{
+					FromTable: from,
+					ToTables:  toTables,
+					Weights:   weights,
+				}
+			}
+		}
+	}
+
+	rr = &vschemapb.RoutingRules{Rules: make([]*vschemapb.RoutingRule, 0, len(rules))}
+	for _, rule := range rules {
+		rr.Rules = append(rr.Rules, rule)
+	}
+
+	sort.Slice(rr.Rules, func(i, j int) bool {
+		return rr.Rules[i].FromTable < rr.Rules[j].FromTable
+	})
+
+	if err := ts.SaveRoutingRules(ctx, rr); err != nil {
+		return err
+	}
+
+	return ts.RebuildSrvVSchema(ctx, cells)
+}
@@ -201,3 +201,77 @@ func (qp *PerTargetQueryPlan) ExecuteScatter(ctx context.Context, targets ...*to
 
 	return results, rec.AggrError(vterrors.Aggregate)
 }
+
+// SchemaMigrationsQueryPlan wraps a planned query produced by a
+// SchemaMigrationsQueryPlanner. It executes the same query on each target,
+// through their VExec RPC.
+type SchemaMigrationsQueryPlan struct {
+	ParsedQuery *sqlparser.ParsedQuery
+
+	keyspace string
+	uuid     string
+	tmc      tmclient.TabletManagerClient
+}
+
+// Execute is part of the QueryPlan interface.
+func (qp *SchemaMigrationsQueryPlan) Execute(ctx context.Context, target *topo.TabletInfo) (qr *querypb.QueryResult, err error) {
+	if qp.ParsedQuery == nil {
+		return nil, fmt.Errorf("%w: call PlanQuery on a query planner first", ErrUnpreparedQuery)
+	}
+
+	targetAliasStr := target.AliasString()
+
+	defer func() {
+		if err != nil {
+			log.Warningf("Result on %v: %v", targetAliasStr, err)
+			return
+		}
+	}()
+
+	qr, err = qp.tmc.VExec(ctx, target.Tablet, qp.ParsedQuery.Query, qp.uuid, qp.keyspace)
+	if err != nil {
+		return nil, err
+	}
+
+	return qr, nil
+}
+
+// ExecuteScatter is part of the QueryPlan interface. For a
+// SchemaMigrationsQueryPlan, the exact same query is executed on each target,
+// and errors from individual targets are aggregated into a singular error.
+func (qp *SchemaMigrationsQueryPlan) ExecuteScatter(ctx context.Context, targets ...*topo.TabletInfo) (map[*topo.TabletInfo]*querypb.QueryResult, error) {
+	if qp.ParsedQuery == nil {
+		return nil, fmt.Errorf("%w: call PlanQuery on a query planner first", ErrUnpreparedQuery)
+	}
+
+	var (
+		m       sync.Mutex
+		wg      sync.WaitGroup
+		rec     concurrency.AllErrorRecorder
+		results = make(map[*topo.TabletInfo]*querypb.QueryResult, len(targets))
+	)
+
+	for _, target := range targets {
+		wg.Add(1)
+
+		go func(ctx context.Context, target *topo.TabletInfo) {
+			defer wg.Done()
+
+			qr, err := qp.Execute(ctx, target)
+			if err != nil {
+				rec.RecordError(err)
+
+				return
+			}
+
+			m.Lock()
+			defer m.Unlock()
+
+			results[target] = qr
+		}(ctx, target)
+	}
+
+	wg.Wait()
+
+	return results, rec.AggrError(vterrors.Aggregate)
+}
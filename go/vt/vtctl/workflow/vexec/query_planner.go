@@ -374,6 +374,121 @@ func (planner *VReplicationLogQueryPlanner) planSelect(sel *sqlparser.Select) (Q
 	}, nil
 }
 
+// SchemaMigrationsQueryPlanner implements the QueryPlanner interface for
+// queries on the _vt.schema_migrations table. Its queries run through the
+// VExec RPC of the tablets, whose online DDL executor validates them.
+type SchemaMigrationsQueryPlanner struct {
+	tmc tmclient.TabletManagerClient
+
+	keyspace string
+	dbname   string
+	uuid     string
+}
+
+// NewSchemaMigrationsQueryPlanner returns a new SchemaMigrationsQueryPlanner.
+// It is valid to pass an empty string for the uuid parameter, to query all the
+// migrations of the keyspace.
+func NewSchemaMigrationsQueryPlanner(tmc tmclient.TabletManagerClient, keyspace string, uuid string, dbname string) *SchemaMigrationsQueryPlanner {
+	return &SchemaMigrationsQueryPlanner{
+		tmc:      tmc,
+		keyspace: keyspace,
+		dbname:   dbname,
+		uuid:     uuid,
+	}
+}
+
+// PlanQuery is part of the QueryPlanner interface.
+//
+// For schema_migrations query planners, only SELECT and UPDATE queries are
+// supported.
+//
+// For UPDATE queries, ORDER BY and LIMIT clauses are not supported. Attempting
+// to update schema_migrations.migration_uuid is an error.
+func (planner *SchemaMigrationsQueryPlanner) PlanQuery(stmt sqlparser.Statement) (plan QueryPlan, err error) {
+	switch stmt := stmt.(type) {
+	case *sqlparser.Select:
+		plan, err = planner.planSelect(stmt)
+	case *sqlparser.Insert:
+		err = ErrUnsupportedQuery
+	case *sqlparser.Update:
+		plan, err = planner.planUpdate(stmt)
+	case *sqlparser.Delete:
+		err = ErrUnsupportedQuery
+	default:
+		err = ErrUnsupportedQuery
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, sqlparser.String(stmt))
+	}
+
+	return plan, nil
+}
+
+// QueryParams is part of the QueryPlanner interface. A
+// SchemaMigrationsQueryPlanner will attach the following WHERE clauses iff (a)
+// DBName, Workflow are set, respectively, and (b) mysql_schema and
+// migration_uuid do not appear in the original query's WHERE clause:
+//
+// 		WHERE (mysql_schema = {{ .DBName }} AND)? (migration_uuid = {{ .Workflow }} AND)? {{ .OriginalWhere }}
+func (planner *SchemaMigrationsQueryPlanner) QueryParams() QueryParams {
+	return QueryParams{
+		DBName:         planner.dbname,
+		DBNameColumn:   "mysql_schema",
+		Workflow:       planner.uuid,
+		WorkflowColumn: "migration_uuid",
+	}
+}
+
+func (planner *SchemaMigrationsQueryPlanner) planSelect(sel *sqlparser.Select) (*SchemaMigrationsQueryPlan, error) {
+	sel.Where = addDefaultWheres(planner, sel.Where)
+
+	buf := sqlparser.NewTrackedBuffer(nil)
+	buf.Myprintf("%v", sel)
+
+	return &SchemaMigrationsQueryPlan{
+		ParsedQuery: buf.ParsedQuery(),
+		keyspace:    planner.keyspace,
+		uuid:        planner.uuid,
+		tmc:         planner.tmc,
+	}, nil
+}
+
+func (planner *SchemaMigrationsQueryPlanner) planUpdate(upd *sqlparser.Update) (*SchemaMigrationsQueryPlan, error) {
+	if upd.OrderBy != nil || upd.Limit != nil {
+		return nil, fmt.Errorf(
+			"%w: UPDATE must not have explicit ordering (have: %v) or limit clauses (have: %v): %v",
+			ErrUnsupportedQueryConstruct,
+			upd.OrderBy,
+			upd.Limit,
+			sqlparser.String(upd),
+		)
+	}
+
+	for _, expr := range upd.Exprs {
+		if expr.Name.Name.EqualString("migration_uuid") {
+			return nil, fmt.Errorf(
+				"%w %+v: %v",
+				ErrCannotUpdateImmutableColumn,
+				expr.Name.Name,
+				sqlparser.String(expr),
+			)
+		}
+	}
+
+	upd.Where = addDefaultWheres(planner, upd.Where)
+
+	buf := sqlparser.NewTrackedBuffer(nil)
+	buf.Myprintf("%v", upd)
+
+	return &SchemaMigrationsQueryPlan{
+		ParsedQuery: buf.ParsedQuery(),
+		keyspace:    planner.keyspace,
+		uuid:        planner.uuid,
+		tmc:         planner.tmc,
+	}, nil
+}
+
 func addDefaultWheres(planner QueryPlanner, where *sqlparser.Where) *sqlparser.Where {
 	cols := extractWhereComparisonColumns(where)
 
@@ -381,3 +381,63 @@ func assertQueryMapsMatch(t *testing.T, expected map[string]string, actual map[s
 
 	assert.Equal(t, expected, actualQueryMap, msgAndArgs...)
 }
+
+func TestSchemaMigrationsQueryPlanner(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name                 string
+		query                string
+		expectedPlannedQuery string
+		err                  error
+	}{
+		{
+			name:                 "simple select",
+			query:                "SELECT shard, migration_status FROM _vt.schema_migrations",
+			expectedPlannedQuery: "SELECT shard, migration_status FROM _vt.schema_migrations WHERE mysql_schema = 'vt_testkeyspace' AND migration_uuid = 'a1b2c3d4_e5f6_11eb_8a51_0242ac120002'",
+		},
+		{
+			name:                 "update with uuid in WHERE",
+			query:                "UPDATE _vt.schema_migrations SET migration_status = 'approve', reviewed_by = 'alice' WHERE migration_uuid = 'a1b2c3d4_e5f6_11eb_8a51_0242ac120002'",
+			expectedPlannedQuery: "UPDATE _vt.schema_migrations SET migration_status = 'approve', reviewed_by = 'alice' WHERE migration_uuid = 'a1b2c3d4_e5f6_11eb_8a51_0242ac120002' AND mysql_schema = 'vt_testkeyspace'",
+		},
+		{
+			name:  "update of the uuid",
+			query: "UPDATE _vt.schema_migrations SET migration_uuid = 'other'",
+			err:   ErrCannotUpdateImmutableColumn,
+		},
+		{
+			name:  "update with limit",
+			query: "UPDATE _vt.schema_migrations SET migration_status = 'cancel' LIMIT 1",
+			err:   ErrUnsupportedQueryConstruct,
+		},
+		{
+			name:  "delete not supported",
+			query: "DELETE FROM _vt.schema_migrations",
+			err:   ErrUnsupportedQuery,
+		},
+	}
+
+	planner := NewSchemaMigrationsQueryPlanner(nil, "testkeyspace", "a1b2c3d4_e5f6_11eb_8a51_0242ac120002", "vt_testkeyspace")
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			stmt := testutil.StatementFromString(t, tt.query)
+			qp, err := planner.PlanQuery(stmt)
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err), "expected err of type %v, got %v", tt.err, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			smqp, ok := qp.(*SchemaMigrationsQueryPlan)
+			require.True(t, ok, "SchemaMigrationsQueryPlanner should always return a SchemaMigrationsQueryPlan from PlanQuery, got %T", qp)
+			assert.Equal(t, testutil.ParsedQueryFromString(t, tt.expectedPlannedQuery), smqp.ParsedQuery)
+		})
+	}
+}
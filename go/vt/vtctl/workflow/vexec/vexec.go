@@ -62,14 +62,16 @@ var ( // Query parsing and planning errors.
 	ErrUnsupportedQuery = errors.New("query not supported by vexec")
 	// ErrUnsupportedTable occurs when attempting to run vexec on an unsupported
 	// table. At the time of writing, this occurs when attempting to query any
-	// table other than _vt.vreplication.
+	// table other than _vt.vreplication, _vt.vreplication_log and
+	// _vt.schema_migrations.
 	ErrUnsupportedTable = errors.New("table not supported by vexec")
 )
 
 // VExec provides the main interface to planning and executing vexec queries
 // (normally, queries on tables in the `_vt` database). It currently supports
 // some limited vreplication queries; this set of supported behavior will expand
-// over time. It may be extended to support schema_migrations queries as well.
+// over time. Queries on _vt.schema_migrations use the workflow as the UUID of
+// the migration.
 type VExec struct {
 	ts  *topo.Server
 	tmc tmclient.TabletManagerClient
@@ -238,7 +240,7 @@ func (vx *VExec) GetPlanner(ctx context.Context, table string) (QueryPlanner, er
 
 		return NewVReplicationLogQueryPlanner(vx.tmc, tabletStreamIDMap), nil
 	case qualifiedTableName(SchemaMigrationsTableName):
-		return nil, errors.New("Schema Migrations not yet supported in new workflow package")
+		return NewSchemaMigrationsQueryPlanner(vx.tmc, vx.keyspace, vx.workflow, vx.primaries[0].DbName()), nil
 	default:
 		return nil, fmt.Errorf("%w: %v", ErrUnsupportedTable, table)
 	}
@@ -37,6 +37,8 @@ import (
 	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/topo/topoproto"
 	"vitess.io/vitess/go/vt/vtctl"
+	"vitess.io/vitess/go/vt/vtctl/grpcvtctldserver"
+	"vitess.io/vitess/go/vt/vtctl/lockutil"
 	"vitess.io/vitess/go/vt/vttablet/tmclient"
 	"vitess.io/vitess/go/vt/workflow"
 	"vitess.io/vitess/go/vt/wrangler"
@@ -88,6 +90,16 @@ type TabletWithStatsAndURL struct {
 	URL                 string                  `json:"url,omitempty"`
 }
 
+// externalLockRequest is the request to acquire a lock for an external
+// tool.
+type externalLockRequest struct {
+	Keyspace string `json:"keyspace"`
+	Shard    string `json:"shard,omitempty"`
+	Purpose  string `json:"purpose"`
+	// TTL is a duration, e.g. "10m".
+	TTL string `json:"ttl"`
+}
+
 func newTabletWithStatsAndURL(t *topodatapb.Tablet, realtimeStats *realtimeStats) *TabletWithStatsAndURL {
 	tablet := &TabletWithStatsAndURL{
 		Alias:               t.Alias,
@@ -139,6 +151,12 @@ func handleAPI(apiPath string, handlerFunc func(w http.ResponseWriter, r *http.R
 				httpErrorf(w, r, "uncaught panic: %v", x)
 			}
 		}()
+		// A read-only vtctld only serves the GETs, and the commands of the
+		// vtctl API which only read the cluster.
+		if grpcvtctldserver.IsReadOnly() && r.Method != http.MethodGet && r.Method != http.MethodHead && apiPath != "vtctl/" {
+			http.Error(w, grpcvtctldserver.ErrReadOnly(r.Method+" "+r.URL.Path).Error(), http.StatusForbidden)
+			return
+		}
 		if err := handlerFunc(w, r); err != nil {
 			httpErrorf(w, r, "%v", err)
 		}
@@ -403,6 +421,15 @@ func initAPI(ctx context.Context, ts *topo.Server, actions *ActionRepository, re
 			if err := r.ParseForm(); err != nil {
 				return nil, err
 			}
+			// List a page of the tablet records, with filters.
+			if r.FormValue("page_size") != "" {
+				req, err := parseTabletListRequest(r)
+				if err != nil {
+					return nil, err
+				}
+				return listTablets(ctx, ts, realtimeStats, req)
+			}
+
 			shardRef := r.FormValue("shard")
 			cell := r.FormValue("cell")
 
@@ -546,6 +573,47 @@ func initAPI(ctx context.Context, ts *topo.Server, actions *ActionRepository, re
 		return tabletStat, nil
 	})
 
+	// Health transitions of the tablets: /tablet_health_history/ for all
+	// the tablets, or /tablet_health_history/<cell>/<uid> for one, with the
+	// optional ?since=<RFC3339 time>&until=<RFC3339 time> range.
+	handleCollection("tablet_health_history", func(r *http.Request) (interface{}, error) {
+		if realtimeStats == nil {
+			return nil, fmt.Errorf("realtimeStats not initialized")
+		}
+		if err := r.ParseForm(); err != nil {
+			return nil, err
+		}
+		var since, until time.Time
+		if value := r.FormValue("since"); value != "" {
+			t, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid since: %v", err)
+			}
+			since = t
+		}
+		if value := r.FormValue("until"); value != "" {
+			t, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid until: %v", err)
+			}
+			until = t
+		}
+
+		alias := ""
+		if tabletPath := getItemPath(r.URL.Path); tabletPath != "" {
+			parts := strings.SplitN(tabletPath, "/", 2)
+			if len(parts) != 2 {
+				return nil, fmt.Errorf("invalid tablet_health_history path: %q  expected path: /tablet_health_history/<cell>/<uid>", tabletPath)
+			}
+			uid, err := topoproto.ParseUID(parts[1])
+			if err != nil {
+				return nil, fmt.Errorf("incorrect uid: %v", err)
+			}
+			alias = topoproto.TabletAliasString(&topodatapb.TabletAlias{Cell: parts[0], Uid: uid})
+		}
+		return realtimeStats.healthHistory.history(alias, since, until), nil
+	})
+
 	handleCollection("topology_info", func(r *http.Request) (interface{}, error) {
 		targetPath := getItemPath(r.URL.Path)
 
@@ -588,6 +656,10 @@ func initAPI(ctx context.Context, ts *topo.Server, actions *ActionRepository, re
 		if err := unmarshalRequest(r, &args); err != nil {
 			return fmt.Errorf("can't unmarshal request: %v", err)
 		}
+		if err := vtctl.CheckReadOnlyCommand(args); err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return nil
+		}
 
 		logstream := logutil.NewMemoryLogger()
 
@@ -644,6 +716,96 @@ func initAPI(ctx context.Context, ts *topo.Server, actions *ActionRepository, re
 			schemamanager.NewUIController(req.SQL, req.Keyspace, w), executor)
 	})
 
+	// Workflow schedules
+	handleCollection("workflow_schedules", func(r *http.Request) (interface{}, error) {
+		if getItemPath(r.URL.Path) != "" {
+			return nil, errors.New("workflow schedules can only be listed, not retrieved")
+		}
+		if vtctl.WorkflowManager == nil {
+			return nil, errors.New("the workflow manager is not initialized, see -workflow_manager_init")
+		}
+		return vtctl.WorkflowManager.Schedules(ctx)
+	})
+
+	// Workflow states, as saved by the running workflow manager. They
+	// can be read from any vtctld, e.g. from a standby.
+	handleCollection("workflow_states", func(r *http.Request) (interface{}, error) {
+		states, err := workflow.GetRunStates(ctx, ts)
+		if err != nil {
+			return nil, err
+		}
+		uuid := getItemPath(r.URL.Path)
+		if uuid == "" {
+			return states, nil
+		}
+		for _, state := range states {
+			if state.UUID == uuid {
+				return state, nil
+			}
+		}
+		return nil, topo.NewError(topo.NoNode, uuid)
+	})
+
+	// Workflow manager election
+	handleCollection("workflow_manager", func(r *http.Request) (interface{}, error) {
+		election, err := getWorkflowElection()
+		if err != nil {
+			return nil, err
+		}
+		switch item := getItemPath(r.URL.Path); {
+		case item == "leader" && r.Method == "GET":
+			return election.leader(ctx)
+		case item == "transfer" && r.Method == "POST":
+			if err := acl.CheckAccessHTTP(r, acl.ADMIN); err != nil {
+				return nil, err
+			}
+			return election.transfer(ctx)
+		default:
+			return nil, fmt.Errorf("unsupported workflow_manager request: %v %v", r.Method, item)
+		}
+	})
+
+	// Keyspace and shard locks held for external tools, shared with the
+	// Vtctld RPCs.
+	locks := lockutil.ForServer(ts)
+	handleCollection("locks", func(r *http.Request) (interface{}, error) {
+		id := getItemPath(r.URL.Path)
+		switch r.Method {
+		case "GET":
+			// List the locks.
+			if id == "" {
+				return locks.List(), nil
+			}
+			return locks.Get(id)
+		case "POST":
+			if err := acl.CheckAccessHTTP(r, acl.ADMIN); err != nil {
+				return nil, err
+			}
+			// Acquire a lock.
+			if id == "" {
+				req := &externalLockRequest{}
+				if err := unmarshalRequest(r, req); err != nil {
+					return nil, fmt.Errorf("can't unmarshal request: %v", err)
+				}
+				ttl, err := time.ParseDuration(req.TTL)
+				if err != nil {
+					return nil, fmt.Errorf("invalid ttl %q: %v", req.TTL, err)
+				}
+				return locks.Acquire(r.Context(), req.Keyspace, req.Shard, req.Purpose, ttl)
+			}
+			// Release a lock.
+			if err := r.ParseForm(); err != nil {
+				return nil, err
+			}
+			if action := r.FormValue("action"); action != "release" {
+				return nil, fmt.Errorf("unsupported lock action %q", action)
+			}
+			return locks.Release(id)
+		default:
+			return nil, fmt.Errorf("unsupported HTTP method: %v", r.Method)
+		}
+	})
+
 	// Features
 	handleAPI("features", func(w http.ResponseWriter, r *http.Request) error {
 		if err := acl.CheckAccessHTTP(r, acl.ADMIN); err != nil {
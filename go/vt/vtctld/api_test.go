@@ -19,6 +19,7 @@ package vtctld
 import (
 	"bytes"
 	"encoding/json"
+	"flag"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -27,6 +28,9 @@ import (
 
 	"context"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
 	"vitess.io/vitess/go/vt/topo/memorytopo"
 	"vitess.io/vitess/go/vt/wrangler"
 
@@ -261,7 +265,14 @@ func TestAPI(t *testing.T) {
 				"served_froms": [],
                                 "keyspace_type":0,
                                 "base_keyspace":"",
-                                "snapshot_time":null
+                                "snapshot_time":null,
+                                "query_pins":[],
+                                "mysql_users":[],
+                                "cdc_sinks":[],
+                                "cell_serving_overrides":{},
+                                "backup_storage_config":null,
+                                "replication_lag_thresholds":null,
+                                "serving_flags":[]
 			}`, http.StatusOK},
 		{"GET", "keyspaces/nonexistent", "", "404 page not found", http.StatusNotFound},
 		{"POST", "keyspaces/ks1?action=TestKeyspaceAction", "", `{
@@ -378,9 +389,11 @@ func TestAPI(t *testing.T) {
 		// Tablet Health
 		{"GET", "tablet_health/cell1/100", "", `{ "Key": "", "Tablet": { "alias": { "cell": "cell1", "uid": 100 },"port_map": { "vt": 100 }, "keyspace": "ks1", "shard": "-80", "type": 2},
 		  "Name": "", "Target": { "keyspace": "ks1", "shard": "-80", "tablet_type": 2 }, "Up": true, "Serving": true, "TabletExternallyReparentedTimestamp": 0,
-		  "Stats": { "seconds_behind_master": 100 }, "LastError": null }`, http.StatusOK},
+		  "Stats": { "seconds_behind_master": 100 }, "LastError": null, "Drained": false }`, http.StatusOK},
 		{"GET", "tablet_health/cell1", "", "can't get tablet_health: invalid tablet_health path: \"cell1\"  expected path: /tablet_health/<cell>/<uid>", http.StatusInternalServerError},
 		{"GET", "tablet_health/cell1/gh", "", "can't get tablet_health: incorrect uid", http.StatusInternalServerError},
+		{"GET", "tablet_health_history/cell1", "", "can't get tablet_health_history: invalid tablet_health_history path: \"cell1\"  expected path: /tablet_health_history/<cell>/<uid>", http.StatusInternalServerError},
+		{"GET", "tablet_health_history/?since=yesterday", "", "can't get tablet_health_history: invalid since", http.StatusInternalServerError},
 
 		// Topology Info
 		{"GET", "topology_info/?keyspace=all&cell=all", "", `{
@@ -397,11 +410,11 @@ func TestAPI(t *testing.T) {
 		// vtctl RunCommand
 		{"POST", "vtctl/", `["GetKeyspace","ks1"]`, `{
 		   "Error": "",
-		   "Output": "{\n  \"sharding_column_name\": \"shardcol\",\n  \"sharding_column_type\": 0,\n  \"served_froms\": [],\n  \"keyspace_type\": 0,\n  \"base_keyspace\": \"\",\n  \"snapshot_time\": null\n}\n\n"
+		   "Output": "{\n  \"sharding_column_name\": \"shardcol\",\n  \"sharding_column_type\": 0,\n  \"served_froms\": [],\n  \"keyspace_type\": 0,\n  \"base_keyspace\": \"\",\n  \"snapshot_time\": null,\n  \"query_pins\": [],\n  \"mysql_users\": [],\n  \"cdc_sinks\": [],\n  \"cell_serving_overrides\": {},\n  \"backup_storage_config\": null,\n  \"replication_lag_thresholds\": null,\n  \"serving_flags\": []\n}\n\n"
 		}`, http.StatusOK},
 		{"POST", "vtctl/", `["GetKeyspace","ks3"]`, `{
 		   "Error": "",
-		   "Output": "{\n  \"sharding_column_name\": \"\",\n  \"sharding_column_type\": 0,\n  \"served_froms\": [],\n  \"keyspace_type\": 1,\n  \"base_keyspace\": \"ks1\",\n  \"snapshot_time\": {\n    \"seconds\": \"1136214245\",\n    \"nanoseconds\": 0\n  }\n}\n\n"
+		   "Output": "{\n  \"sharding_column_name\": \"\",\n  \"sharding_column_type\": 0,\n  \"served_froms\": [],\n  \"keyspace_type\": 1,\n  \"base_keyspace\": \"ks1\",\n  \"snapshot_time\": {\n    \"seconds\": \"1136214245\",\n    \"nanoseconds\": 0\n  },\n  \"query_pins\": [],\n  \"mysql_users\": [],\n  \"cdc_sinks\": [],\n  \"cell_serving_overrides\": {},\n  \"backup_storage_config\": null,\n  \"replication_lag_thresholds\": null,\n  \"serving_flags\": []\n}\n\n"
 		}`, http.StatusOK},
 		{"POST", "vtctl/", `["GetVSchema","ks3"]`, `{
 		   "Error": "",
@@ -460,4 +473,30 @@ func TestAPI(t *testing.T) {
 		})
 
 	}
+
+	// A read-only vtctld only serves the requests which read the cluster.
+	require.NoError(t, flag.Set("vtctld_read_only", "true"))
+	defer flag.Set("vtctld_read_only", "false")
+	readOnlyTable := []struct {
+		method, path, body string
+		statusCode         int
+	}{
+		{"GET", "keyspaces/", "", http.StatusOK},
+		{"POST", "vtctl/", `["GetKeyspace","ks1"]`, http.StatusOK},
+		{"POST", "vtctl/", `["getkeyspace","ks1"]`, http.StatusOK},
+		{"POST", "vtctl/", `["DeleteKeyspace","ks3"]`, http.StatusForbidden},
+		{"POST", "vtctl/", `["FenceShard","ks1/-80"]`, http.StatusForbidden},
+		{"POST", "keyspaces/ks1?action=TestKeyspaceAction", "", http.StatusForbidden},
+		{"POST", "tablets/cell1-100?action=TestTabletAction", "", http.StatusForbidden},
+		{"POST", "locks/", `{"keyspace":"ks1","purpose":"test","ttl":"1m"}`, http.StatusForbidden},
+		{"POST", "schema/apply", `{"Keyspace":"ks1","SQL":"drop table t"}`, http.StatusForbidden},
+	}
+	for _, in := range readOnlyTable {
+		req, err := http.NewRequest(in.method, server.URL+apiPrefix+in.path, strings.NewReader(in.body))
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, in.statusCode, resp.StatusCode, "%v %v %v", in.method, in.path, in.body)
+	}
 }
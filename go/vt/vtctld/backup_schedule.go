@@ -0,0 +1,42 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtctld
+
+import (
+	"context"
+	"flag"
+	"time"
+
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/vtctl/backuputil"
+	"vitess.io/vitess/go/vt/vttablet/tmclient"
+)
+
+var backupScheduleCheckInterval = flag.Duration("backup_schedule_check_interval", time.Minute, "how often vtctld checks whether the backup of a keyspace with a backup schedule is due. 0 disables the backup schedules in this vtctld")
+
+// initBackupScheduler runs the backup schedules of the keyspaces until
+// vtctld terminates.
+func initBackupScheduler(ts *topo.Server) {
+	if *backupScheduleCheckInterval <= 0 {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	scheduler := backuputil.NewScheduler(ts, tmclient.NewTabletManagerClient())
+	go scheduler.Run(ctx, *backupScheduleCheckInterval)
+	servenv.OnTermSync(cancel)
+}
@@ -21,100 +21,75 @@ import (
 	"fmt"
 	"net/http"
 	"path"
-	"sort"
-	"strings"
+	"time"
 
 	"context"
 
 	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/topo/topoproto"
-	"vitess.io/vitess/go/vt/vtctl"
+	"vitess.io/vitess/go/vt/vtctl/topobrowser"
 )
 
 // backendExplorer is a class that uses the Backend interface of a
 // topo.Server to display content. It is separated in its own class
 // now for easier testing.
+//
+// The explorer only reads the topo, through a topobrowser.Browser.
 type backendExplorer struct {
-	ts *topo.Server
+	browser *topobrowser.Browser
 }
 
 // Result is what the backendExplorer returns. It represents one directory node.
 // It is exported so the JSON encoder can print it.
 type Result struct {
-	Data     string
+	Data string
+	// Version is the version of the node, when it is a file.
+	Version  string
 	Children []string
 	Error    string
 }
 
+// WatchResult is what the backendExplorer returns for a watch. It is
+// exported so the JSON encoder can print it.
+type WatchResult struct {
+	// Version identifies the contents of the watched subtree. It is
+	// passed back to the next watch to wait for a change.
+	Version string
+	// Changed is true if the subtree changed since the version of the
+	// request, and false if the watch timed out.
+	Changed bool
+	Error   string
+}
+
 func newBackendExplorer(ts *topo.Server) *backendExplorer {
 	return &backendExplorer{
-		ts: ts,
+		browser: topobrowser.New(ts),
 	}
 }
 
 // HandlePath is the main function for this class.
 func (ex *backendExplorer) HandlePath(nodePath string, r *http.Request) *Result {
-	ctx := context.Background()
-	result := &Result{}
-
-	// Handle toplevel display: global, then one line per cell.
-	if nodePath == "/" {
-		cells, err := ex.ts.GetKnownCells(ctx)
-		if err != nil {
-			result.Error = err.Error()
-			return result
-		}
-		sort.Strings(cells)
-		result.Children = append([]string{topo.GlobalCell}, cells...)
-		return result
-	}
-
-	// Now find the cell.
-	parts := strings.Split(nodePath, "/")
-	if parts[0] != "" || len(parts) < 2 {
-		result.Error = "Invalid path: " + nodePath
-		return result
+	node, err := ex.browser.Browse(context.Background(), nodePath)
+	result := &Result{
+		Data:     node.Data,
+		Version:  node.Version,
+		Children: node.Children,
 	}
-	cell := parts[1]
-	relativePath := nodePath[len(cell)+1:]
-	conn, err := ex.ts.ConnForCell(ctx, cell)
 	if err != nil {
-		result.Error = fmt.Sprintf("Invalid cell: %v", err)
-		return result
-	}
-
-	// Get the file contents, if any.
-	data, _, err := conn.Get(ctx, relativePath)
-	switch err {
-	case nil:
-		if len(data) > 0 {
-			// It has contents, we just use it if possible.
-			decoded, err := vtctl.DecodeContent(relativePath, data, false)
-			if err != nil {
-				result.Error = err.Error()
-			} else {
-				result.Data = decoded
-			}
-
-			// With contents, it can't have children, so we're done.
-			return result
-		}
-	default:
-		// Something is wrong. Might not be a file.
 		result.Error = err.Error()
 	}
+	return result
+}
 
-	// Get the children, if any.
-	children, err := conn.ListDir(ctx, relativePath, false /*full*/)
+// HandleWatch waits for a change of the subtree of the node from the
+// provided version, see topobrowser.Browser.Watch.
+func (ex *backendExplorer) HandleWatch(ctx context.Context, nodePath, version string, timeout time.Duration) *WatchResult {
+	result := &WatchResult{}
+	var err error
+	result.Version, result.Changed, err = ex.browser.Watch(ctx, nodePath, version, timeout)
 	if err != nil {
-		// It failed as a directory, let's just return what it did
-		// as a file.
-		return result
+		result.Error = err.Error()
 	}
-
-	// It worked as a directory, clear any file error.
-	result.Error = ""
-	result.Children = topo.DirEntriesToStringArray(children)
 	return result
 }
 
@@ -172,6 +147,22 @@ func initExplorer(ts *topo.Server) {
 		return be.HandlePath(path.Clean("/"+getItemPath(r.URL.Path)), r), nil
 	})
 
+	// Watch of a subtree, e.g.
+	// /api/topodata_watch/global/keyspaces?version=<version>&timeout=30s
+	handleCollection("topodata_watch", func(r *http.Request) (interface{}, error) {
+		timeout := topobrowser.MaxWatchTimeout()
+		if t := r.FormValue("timeout"); t != "" {
+			d, err := time.ParseDuration(t)
+			if err != nil {
+				return nil, fmt.Errorf("invalid timeout %q: %v", t, err)
+			}
+			if d < timeout {
+				timeout = d
+			}
+		}
+		return be.HandleWatch(r.Context(), path.Clean("/"+getItemPath(r.URL.Path)), r.FormValue("version"), timeout), nil
+	})
+
 	// Redirects for explorers.
 	http.HandleFunc("/explorers/redirect", func(w http.ResponseWriter, r *http.Request) {
 		if err := r.ParseForm(); err != nil {
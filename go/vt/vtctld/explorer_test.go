@@ -26,6 +26,7 @@ import (
 
 	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/topo/memorytopo"
+	"vitess.io/vitess/go/vt/vtctl/topobrowser"
 
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 )
@@ -213,3 +214,63 @@ func TestHandleBadPath(t *testing.T) {
 		t.Errorf("HandlePath(%q) = %v, want %v", input, got, want)
 	}
 }
+
+func TestHandlePathVersion(t *testing.T) {
+	ctx := context.Background()
+	ts := memorytopo.NewServer("cell1")
+	if err := ts.CreateKeyspace(ctx, "test_keyspace", &topodatapb.Keyspace{}); err != nil {
+		t.Fatalf("CreateKeyspace error: %v", err)
+	}
+
+	ex := newBackendExplorer(ts)
+	input := path.Join("/global", "keyspaces", "test_keyspace", "Keyspace")
+	result := ex.HandlePath(input, nil)
+	if result.Version == "" {
+		t.Errorf("HandlePath(%q).Version is empty", input)
+	}
+
+	// Directories don't have a version.
+	input = path.Join("/global", "keyspaces")
+	if got := ex.HandlePath(input, nil).Version; got != "" {
+		t.Errorf("HandlePath(%q).Version = %q, want empty", input, got)
+	}
+}
+
+func TestHandlePathAllowedPaths(t *testing.T) {
+	ctx := context.Background()
+	ts := memorytopo.NewServer("cell1", "cell2")
+	for _, keyspace := range []string{"ks1", "ks2"} {
+		if err := ts.CreateKeyspace(ctx, keyspace, &topodatapb.Keyspace{}); err != nil {
+			t.Fatalf("CreateKeyspace error: %v", err)
+		}
+	}
+
+	ex := &backendExplorer{
+		browser: topobrowser.NewWithAllowedPaths(ts, []string{"/global/keyspaces/ks1", "/cell2"}),
+	}
+
+	table := []struct {
+		input    string
+		children []string
+		denied   bool
+	}{
+		// The ancestors only list the children leading to the allowed paths.
+		{input: "/", children: []string{topo.GlobalCell, "cell2"}},
+		{input: "/global", children: []string{"keyspaces"}},
+		{input: "/global/keyspaces", children: []string{"ks1"}},
+		{input: "/global/keyspaces/ks1", children: []string{"Keyspace"}},
+		{input: "/global/keyspaces/ks2", denied: true},
+		{input: "/global/keyspaces/ks2/Keyspace", denied: true},
+		{input: "/global/cells", denied: true},
+		{input: "/cell1", denied: true},
+	}
+	for _, tc := range table {
+		result := ex.HandlePath(tc.input, nil)
+		if got := result.Error != ""; got != tc.denied {
+			t.Errorf("HandlePath(%q).Error = %q, want denied = %v", tc.input, result.Error, tc.denied)
+		}
+		if !reflect.DeepEqual(result.Children, tc.children) {
+			t.Errorf("HandlePath(%q).Children = %v, want %v", tc.input, result.Children, tc.children)
+		}
+	}
+}
@@ -18,31 +18,37 @@ package vtctld
 
 import (
 	"fmt"
+	"time"
 
 	"context"
 
 	"vitess.io/vitess/go/vt/discovery"
 	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
 	"vitess.io/vitess/go/vt/vtctl"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
 )
 
 // realtimeStats holds the objects needed to obtain realtime health stats of tablets.
 type realtimeStats struct {
 	healthCheck discovery.LegacyHealthCheck
 	*tabletStatsCache
-	cellWatchers []*discovery.LegacyTopologyWatcher
+	healthHistory *tabletHealthHistory
+	cellWatchers  []*discovery.LegacyTopologyWatcher
 }
 
 func newRealtimeStats(ts *topo.Server) (*realtimeStats, error) {
 	hc := discovery.NewLegacyHealthCheck(*vtctl.HealthcheckRetryDelay, *vtctl.HealthCheckTimeout)
-	tabletStatsCache := newTabletStatsCache()
-	// sendDownEvents is set to true here, as we want to receive
-	// Up=False events for a tablet.
-	hc.SetListener(tabletStatsCache, true)
 	r := &realtimeStats{
 		healthCheck:      hc,
-		tabletStatsCache: tabletStatsCache,
+		tabletStatsCache: newTabletStatsCache(),
+		healthHistory:    newTabletHealthHistory(*tabletHealthHistorySize, *tabletHealthHistoryRetention, *tabletHealthLagThreshold),
 	}
+	// sendDownEvents is set to true here, as we want to receive
+	// Up=False events for a tablet.
+	hc.SetListener(r, true)
 
 	// Get the list of all tablets from all cells and monitor the topology for added or removed tablets with a CellTabletsWatcher.
 	cells, err := ts.GetKnownCells(context.Background())
@@ -59,6 +65,33 @@ func newRealtimeStats(ts *topo.Server) (*realtimeStats, error) {
 	return r, nil
 }
 
+// StatsUpdate is part of the discovery.LegacyHealthCheckStatsListener interface.
+// It updates the tabletStatsCache and the health history of the tablet.
+func (r *realtimeStats) StatsUpdate(stats *discovery.LegacyTabletStats) {
+	r.tabletStatsCache.StatsUpdate(stats)
+	r.healthHistory.StatsUpdate(stats)
+}
+
+// IsServing is part of the grpcvtctldserver.TabletHealthSource interface.
+func (r *realtimeStats) IsServing(alias *topodatapb.TabletAlias) bool {
+	stats, err := r.tabletStats(alias)
+	return err == nil && stats.Up && stats.Serving
+}
+
+// HealthHistory is part of the grpcvtctldserver.TabletHealthSource interface.
+func (r *realtimeStats) HealthHistory(alias *topodatapb.TabletAlias, since, until time.Time) []*vtctldatapb.TabletHealthEvent {
+	aliasString := ""
+	if alias != nil {
+		aliasString = topoproto.TabletAliasString(alias)
+	}
+	history := r.healthHistory.history(aliasString, since, until)
+	events := make([]*vtctldatapb.TabletHealthEvent, 0, len(history))
+	for _, ev := range history {
+		events = append(events, ev.proto())
+	}
+	return events
+}
+
 func (r *realtimeStats) Stop() error {
 	for _, w := range r.cellWatchers {
 		w.Stop()
@@ -140,5 +140,6 @@ func newRealtimeStatsForTesting() *realtimeStats {
 	tabletStatsCache := newTabletStatsCache()
 	return &realtimeStats{
 		tabletStatsCache: tabletStatsCache,
+		healthHistory:    newTabletHealthHistory(*tabletHealthHistorySize, *tabletHealthHistoryRetention, *tabletHealthLagThreshold),
 	}
 }
@@ -29,10 +29,16 @@ import (
 	"vitess.io/vitess/go/vt/log"
 	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vtctl/grpcvtctldserver"
 )
 
 func initVTTabletRedirection(ts *topo.Server) {
 	http.HandleFunc("/vttablet/", func(w http.ResponseWriter, r *http.Request) {
+		// The pages of the tablets can only be browsed in a read-only vtctld.
+		if grpcvtctldserver.IsReadOnly() && r.Method != http.MethodGet && r.Method != http.MethodHead {
+			http.Error(w, grpcvtctldserver.ErrReadOnly(r.Method+" "+r.URL.Path).Error(), http.StatusForbidden)
+			return
+		}
 		splits := strings.SplitN(r.URL.Path, "/", 4)
 		if len(splits) < 4 {
 			log.Errorf("Invalid URL: %v", r.URL)
@@ -0,0 +1,262 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtctld
+
+import (
+	"flag"
+	"sort"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/discovery"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+var (
+	tabletHealthHistorySize      = flag.Int("tablet_health_history_size", 100, "Maximum number of health transitions kept per tablet by the realtime stats of vtctld.")
+	tabletHealthHistoryRetention = flag.Duration("tablet_health_history_retention", 24*time.Hour, "How long the health transitions of the tablets are kept by the realtime stats of vtctld.")
+	tabletHealthLagThreshold     = flag.Duration("tablet_health_history_lag_threshold", 30*time.Second, "Replication lag above which a tablet is recorded as lagging in its health history.")
+)
+
+// The types of the health transitions of a tablet.
+const (
+	TabletHealthAdded        = "added"
+	TabletHealthRemoved      = "removed"
+	TabletHealthServing      = "serving"
+	TabletHealthNotServing   = "not_serving"
+	TabletHealthLagBreach    = "lag_breach"
+	TabletHealthLagRecovered = "lag_recovered"
+	TabletHealthError        = "error"
+	TabletHealthErrorCleared = "error_cleared"
+)
+
+// TabletHealthEvent is a health transition of a tablet, as seen by the
+// healthcheck of vtctld.
+type TabletHealthEvent struct {
+	Time       time.Time
+	Alias      string
+	Keyspace   string
+	Shard      string
+	TabletType string
+	Type       string
+	// Serving, ReplicationLagSeconds and Error are the health of the
+	// tablet after the transition.
+	Serving               bool
+	ReplicationLagSeconds uint32
+	Error                 string `json:",omitempty"`
+}
+
+// proto returns the event as a vtctldatapb.TabletHealthEvent.
+func (ev *TabletHealthEvent) proto() *vtctldatapb.TabletHealthEvent {
+	// The alias and the type were formatted by StatsUpdate, so they parse.
+	alias, _ := topoproto.ParseTabletAlias(ev.Alias)
+	tabletType, _ := topoproto.ParseTabletType(ev.TabletType)
+	return &vtctldatapb.TabletHealthEvent{
+		Time:                  protoutil.TimeToProto(ev.Time),
+		Tablet:                alias,
+		Keyspace:              ev.Keyspace,
+		Shard:                 ev.Shard,
+		TabletType:            tabletType,
+		Type:                  ev.Type,
+		Serving:               ev.Serving,
+		ReplicationLagSeconds: ev.ReplicationLagSeconds,
+		Error:                 ev.Error,
+	}
+}
+
+// tabletHealthState is the last health of a tablet, to detect the
+// transitions.
+type tabletHealthState struct {
+	up      bool
+	serving bool
+	lagging bool
+	err     string
+}
+
+// pruneInterval is how often the histories of all the tablets are pruned
+// of the events older than the retention.
+const pruneInterval = time.Minute
+
+// tabletHealthHistory records the health transitions of the tablets, in
+// a ring buffer per tablet. It is a discovery.LegacyHealthCheckStatsListener.
+type tabletHealthHistory struct {
+	size         int
+	retention    time.Duration
+	lagThreshold time.Duration
+	now          func() time.Time
+
+	// mu guards the fields below.
+	mu sync.Mutex
+	// states and events are indexed by tablet alias. The events of the
+	// removed tablets are kept until they expire.
+	states    map[string]*tabletHealthState
+	events    map[string][]*TabletHealthEvent
+	lastPrune time.Time
+}
+
+func newTabletHealthHistory(size int, retention, lagThreshold time.Duration) *tabletHealthHistory {
+	return &tabletHealthHistory{
+		size:         size,
+		retention:    retention,
+		lagThreshold: lagThreshold,
+		now:          time.Now,
+		states:       make(map[string]*tabletHealthState),
+		events:       make(map[string][]*TabletHealthEvent),
+	}
+}
+
+// StatsUpdate is part of the discovery.LegacyHealthCheckStatsListener interface.
+func (h *tabletHealthHistory) StatsUpdate(stats *discovery.LegacyTabletStats) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := h.now()
+	alias := topoproto.TabletAliasString(stats.Tablet.Alias)
+	state := &tabletHealthState{
+		up:      stats.Up,
+		serving: stats.Up && stats.Serving,
+	}
+	var lag uint32
+	if stats.Stats != nil {
+		lag = stats.Stats.SecondsBehindMaster
+		state.lagging = time.Duration(lag)*time.Second > h.lagThreshold
+		state.err = stats.Stats.HealthError
+	}
+	if stats.LastError != nil {
+		state.err = stats.LastError.Error()
+	}
+
+	newEvent := func(eventType string) *TabletHealthEvent {
+		return &TabletHealthEvent{
+			Time:                  now,
+			Alias:                 alias,
+			Keyspace:              stats.Tablet.Keyspace,
+			Shard:                 stats.Tablet.Shard,
+			TabletType:            stats.Tablet.Type.String(),
+			Type:                  eventType,
+			Serving:               state.serving,
+			ReplicationLagSeconds: lag,
+			Error:                 state.err,
+		}
+	}
+	var events []*TabletHealthEvent
+	prev, ok := h.states[alias]
+	switch {
+	case !state.up:
+		if !ok {
+			return
+		}
+		events = append(events, newEvent(TabletHealthRemoved))
+		delete(h.states, alias)
+	case !ok:
+		events = append(events, newEvent(TabletHealthAdded))
+		if state.lagging {
+			events = append(events, newEvent(TabletHealthLagBreach))
+		}
+		if state.err != "" {
+			events = append(events, newEvent(TabletHealthError))
+		}
+	default:
+		if state.serving != prev.serving {
+			if state.serving {
+				events = append(events, newEvent(TabletHealthServing))
+			} else {
+				events = append(events, newEvent(TabletHealthNotServing))
+			}
+		}
+		if state.lagging != prev.lagging {
+			if state.lagging {
+				events = append(events, newEvent(TabletHealthLagBreach))
+			} else {
+				events = append(events, newEvent(TabletHealthLagRecovered))
+			}
+		}
+		// A tablet which keeps failing with different errors is recorded
+		// once per error.
+		if state.err != prev.err {
+			if state.err != "" {
+				events = append(events, newEvent(TabletHealthError))
+			} else {
+				events = append(events, newEvent(TabletHealthErrorCleared))
+			}
+		}
+	}
+	if state.up {
+		h.states[alias] = state
+	}
+
+	if len(events) > 0 {
+		history := append(h.events[alias], events...)
+		if len(history) > h.size {
+			history = history[len(history)-h.size:]
+		}
+		h.events[alias] = history
+	}
+	if now.Sub(h.lastPrune) >= pruneInterval {
+		h.pruneLocked(now)
+		h.lastPrune = now
+	}
+}
+
+// pruneLocked drops the events older than the retention. h.mu must be
+// held.
+func (h *tabletHealthHistory) pruneLocked(now time.Time) {
+	cutoff := now.Add(-h.retention)
+	for alias, history := range h.events {
+		i := sort.Search(len(history), func(i int) bool { return !history[i].Time.Before(cutoff) })
+		if i == len(history) {
+			delete(h.events, alias)
+			continue
+		}
+		h.events[alias] = history[i:]
+	}
+}
+
+// history returns the health transitions of a tablet, or of all the
+// tablets if alias is empty, which happened in [since, until), sorted by
+// time. A zero since or until doesn't bound the range.
+func (h *tabletHealthHistory) history(alias string, since, until time.Time) []*TabletHealthEvent {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	cutoff := h.now().Add(-h.retention)
+	if since.Before(cutoff) {
+		since = cutoff
+	}
+	result := []*TabletHealthEvent{}
+	for tablet, history := range h.events {
+		if alias != "" && tablet != alias {
+			continue
+		}
+		for _, ev := range history {
+			if ev.Time.Before(since) || (!until.IsZero() && !ev.Time.Before(until)) {
+				continue
+			}
+			result = append(result, ev)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if !result[i].Time.Equal(result[j].Time) {
+			return result[i].Time.Before(result[j].Time)
+		}
+		return result[i].Alias < result[j].Alias
+	})
+	return result
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtctld
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func eventTypes(events []*TabletHealthEvent) []string {
+	var types []string
+	for _, ev := range events {
+		types = append(types, ev.Type)
+	}
+	return types
+}
+
+func TestTabletHealthHistory(t *testing.T) {
+	h := newTabletHealthHistory(5, time.Hour, 30*time.Second)
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	h.now = func() time.Time { return now }
+	tick := func() { now = now.Add(time.Second) }
+
+	ts1 := tabletStats("ks1", "cell1", "-80", topodatapb.TabletType_REPLICA, 10)
+	h.StatsUpdate(ts1)
+	tick()
+	// An update without a transition isn't recorded.
+	h.StatsUpdate(ts1)
+	tick()
+	ts1.Serving = false
+	h.StatsUpdate(ts1)
+	tick()
+	ts1.Serving = true
+	ts1.Stats.SecondsBehindMaster = 60
+	h.StatsUpdate(ts1)
+	tick()
+	ts1.LastError = errors.New("stream error")
+	h.StatsUpdate(ts1)
+	tick()
+	ts2 := tabletStats("ks1", "cell1", "80-", topodatapb.TabletType_REPLICA, 20)
+	h.StatsUpdate(ts2)
+	tick()
+	ts2.Up = false
+	h.StatsUpdate(ts2)
+
+	got := h.history("", time.Time{}, time.Time{})
+	assert.Equal(t, []string{"added", "not_serving", "serving", "lag_breach", "error", "added", "removed"}, eventTypes(got))
+	assert.Equal(t, "cell1-0000000010", got[0].Alias)
+	assert.Equal(t, "REPLICA", got[0].TabletType)
+	assert.EqualValues(t, 60, got[3].ReplicationLagSeconds)
+	assert.Equal(t, "stream error", got[4].Error)
+
+	ev := got[3].proto()
+	assert.Equal(t, "cell1-0000000010", topoproto.TabletAliasString(ev.Tablet))
+	assert.Equal(t, topodatapb.TabletType_REPLICA, ev.TabletType)
+	assert.Equal(t, "lag_breach", ev.Type)
+	assert.EqualValues(t, 60, ev.ReplicationLagSeconds)
+	assert.True(t, protoutil.TimeFromProto(ev.Time).Equal(got[3].Time))
+
+	got = h.history("cell1-0000000010", time.Time{}, time.Time{})
+	assert.Equal(t, []string{"added", "not_serving", "serving", "lag_breach", "error"}, eventTypes(got))
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	got = h.history("cell1-0000000010", start.Add(2*time.Second), start.Add(4*time.Second))
+	assert.Equal(t, []string{"not_serving", "serving", "lag_breach"}, eventTypes(got))
+
+	// The oldest events are dropped once the history of a tablet is full.
+	ts1.LastError = nil
+	ts1.Stats.SecondsBehindMaster = 0
+	tick()
+	h.StatsUpdate(ts1)
+	got = h.history("cell1-0000000010", time.Time{}, time.Time{})
+	assert.Equal(t, []string{"serving", "lag_breach", "error", "lag_recovered", "error_cleared"}, eventTypes(got))
+
+	// The events expire after the retention.
+	now = now.Add(time.Hour + time.Second)
+	assert.Empty(t, h.history("", time.Time{}, time.Time{}))
+	h.StatsUpdate(ts1)
+	assert.Empty(t, h.events)
+}
@@ -0,0 +1,305 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtctld
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// This file implements the paginated listing of the tablets of the API,
+// for the clusters where listing all the tablets of a cell at once is too
+// big. It is used when the page_size parameter is set, e.g.
+// /api/tablets/?page_size=100&cell=zone1&tablet_type=replica,rdonly&fields=alias,hostname,type
+
+// maxTabletPageSize is the maximum number of tablets of a page.
+const maxTabletPageSize = 1000
+
+// tabletListRequest is a request for a page of the tablets.
+type tabletListRequest struct {
+	// cells are the cells to list the tablets of. Empty means all cells.
+	cells    []string
+	keyspace string
+	shard    string
+	// tabletTypes are the types of the listed tablets. Empty means all
+	// types.
+	tabletTypes []topodatapb.TabletType
+	// hostname is a substring of the hostname of the listed tablets.
+	hostname string
+	// serving, if set, only lists the tablets which are serving, or not,
+	// according to their health stream.
+	serving *bool
+	// fields are the JSON fields of the tablets to return. Empty means
+	// all fields.
+	fields []string
+
+	pageSize int
+	// after is the alias of the last tablet of the previous page.
+	after string
+}
+
+// TabletPage is a page of the tablets listed by the API.
+type TabletPage struct {
+	// Tablets are *TabletWithStatsAndURL, or maps of the requested
+	// fields when there is a field mask.
+	Tablets []interface{} `json:"tablets"`
+	// NextPageToken is the page_token of the next page. It is empty on
+	// the last page.
+	NextPageToken string `json:"next_page_token,omitempty"`
+}
+
+// tabletFields are the JSON fields of TabletWithStatsAndURL which can be
+// requested in a field mask.
+var tabletFields = func() map[string]bool {
+	fields := make(map[string]bool)
+	typ := reflect.TypeOf(TabletWithStatsAndURL{})
+	for i := 0; i < typ.NumField(); i++ {
+		name := strings.Split(typ.Field(i).Tag.Get("json"), ",")[0]
+		if name != "" && name != "-" {
+			fields[name] = true
+		}
+	}
+	return fields
+}()
+
+// parseTabletListRequest parses the query parameters of a paginated
+// listing of the tablets:
+// - page_size: the maximum number of tablets to return, up to 1000.
+// - page_token: the next_page_token of the previous page.
+// - cell: a comma-separated list of cells. All cells by default.
+// - keyspace, and shard as <keyspace>/<shard>.
+// - tablet_type: a comma-separated list of tablet types.
+// - hostname: a substring of the hostnames.
+// - serving: true or false.
+// - fields: a comma-separated list of the fields to return.
+func parseTabletListRequest(r *http.Request) (*tabletListRequest, error) {
+	req := &tabletListRequest{
+		keyspace: r.FormValue("keyspace"),
+		hostname: r.FormValue("hostname"),
+	}
+
+	var err error
+	if req.pageSize, err = strconv.Atoi(r.FormValue("page_size")); err != nil || req.pageSize <= 0 || req.pageSize > maxTabletPageSize {
+		return nil, fmt.Errorf("invalid page_size %q: it must be between 1 and %v", r.FormValue("page_size"), maxTabletPageSize)
+	}
+	if token := r.FormValue("page_token"); token != "" {
+		after, err := base64.RawURLEncoding.DecodeString(token)
+		if err != nil {
+			return nil, fmt.Errorf("invalid page_token %q", token)
+		}
+		if _, err := topoproto.ParseTabletAlias(string(after)); err != nil {
+			return nil, fmt.Errorf("invalid page_token %q", token)
+		}
+		req.after = string(after)
+	}
+	if cells := r.FormValue("cell"); cells != "" {
+		req.cells = strings.Split(cells, ",")
+	}
+	if shardRef := r.FormValue("shard"); shardRef != "" {
+		keyspace, shard, err := topoproto.ParseKeyspaceShard(shardRef)
+		if err != nil {
+			return nil, err
+		}
+		if req.keyspace != "" && req.keyspace != keyspace {
+			return nil, fmt.Errorf("shard %v is not in keyspace %v", shardRef, req.keyspace)
+		}
+		req.keyspace, req.shard = keyspace, shard
+	}
+	if tabletTypes := r.FormValue("tablet_type"); tabletTypes != "" {
+		if req.tabletTypes, err = topoproto.ParseTabletTypes(tabletTypes); err != nil {
+			return nil, err
+		}
+	}
+	if serving := r.FormValue("serving"); serving != "" {
+		b, err := strconv.ParseBool(serving)
+		if err != nil {
+			return nil, fmt.Errorf("invalid serving %q", serving)
+		}
+		req.serving = &b
+	}
+	if fields := r.FormValue("fields"); fields != "" {
+		for _, field := range strings.Split(fields, ",") {
+			if !tabletFields[field] {
+				return nil, fmt.Errorf("unknown tablet field %q", field)
+			}
+			req.fields = append(req.fields, field)
+		}
+	}
+	return req, nil
+}
+
+// listTablets returns a page of the tablets matching the request, in the
+// order of their aliases. The tablet records are read one page at a time,
+// and the filters are applied before the pagination.
+func listTablets(ctx context.Context, ts *topo.Server, realtimeStats *realtimeStats, req *tabletListRequest) (*TabletPage, error) {
+	if req.serving != nil && realtimeStats == nil {
+		return nil, fmt.Errorf("the serving filter requires the realtime stats of vtctld")
+	}
+
+	aliases, err := tabletListAliases(ctx, ts, req)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(aliases)
+	start := sort.SearchStrings(aliases, req.after)
+	if start < len(aliases) && aliases[start] == req.after {
+		start++
+	}
+	aliases = aliases[start:]
+
+	page := &TabletPage{Tablets: []interface{}{}}
+	for len(aliases) > 0 && len(page.Tablets) < req.pageSize {
+		batch := aliases
+		if len(batch) > req.pageSize {
+			batch = batch[:req.pageSize]
+		}
+		tabletAliases := make([]*topodatapb.TabletAlias, 0, len(batch))
+		for _, alias := range batch {
+			tabletAlias, err := topoproto.ParseTabletAlias(alias)
+			if err != nil {
+				return nil, err
+			}
+			tabletAliases = append(tabletAliases, tabletAlias)
+		}
+		// A tablet which can't be read would be skipped for good by the
+		// next page, so the page fails instead.
+		tabletMap, err := ts.GetTabletMap(ctx, tabletAliases)
+		if err != nil {
+			return nil, err
+		}
+
+		examined := 0
+		for _, alias := range batch {
+			if len(page.Tablets) == req.pageSize {
+				break
+			}
+			examined++
+			ti, ok := tabletMap[alias]
+			if !ok {
+				// The tablet was deleted since it was listed.
+				continue
+			}
+			tablet := newTabletWithStatsAndURL(ti.Tablet, realtimeStats)
+			if !req.matches(tablet) {
+				continue
+			}
+			t, err := req.mask(tablet)
+			if err != nil {
+				return nil, err
+			}
+			page.Tablets = append(page.Tablets, t)
+		}
+		if examined < len(aliases) {
+			page.NextPageToken = base64.RawURLEncoding.EncodeToString([]byte(batch[examined-1]))
+		} else {
+			page.NextPageToken = ""
+		}
+		aliases = aliases[examined:]
+	}
+	return page, nil
+}
+
+// tabletListAliases returns the aliases of the tablets of the cells, or of
+// the shard of the request.
+func tabletListAliases(ctx context.Context, ts *topo.Server, req *tabletListRequest) ([]string, error) {
+	var tabletAliases []*topodatapb.TabletAlias
+	if req.shard != "" {
+		var err error
+		tabletAliases, err = ts.FindAllTabletAliasesInShardByCell(ctx, req.keyspace, req.shard, req.cells)
+		if err != nil && !topo.IsErrType(err, topo.PartialResult) {
+			return nil, err
+		}
+	} else {
+		cells := req.cells
+		if len(cells) == 0 {
+			var err error
+			if cells, err = ts.GetKnownCells(ctx); err != nil {
+				return nil, err
+			}
+		}
+		for _, cell := range cells {
+			cellAliases, err := ts.GetTabletsByCell(ctx, cell)
+			if err != nil {
+				return nil, err
+			}
+			tabletAliases = append(tabletAliases, cellAliases...)
+		}
+	}
+
+	aliases := make([]string, 0, len(tabletAliases))
+	for _, tabletAlias := range tabletAliases {
+		aliases = append(aliases, topoproto.TabletAliasString(tabletAlias))
+	}
+	return aliases, nil
+}
+
+// matches returns whether the tablet passes the filters of the request.
+func (req *tabletListRequest) matches(tablet *TabletWithStatsAndURL) bool {
+	if req.keyspace != "" && tablet.Keyspace != req.keyspace {
+		return false
+	}
+	if req.shard != "" && tablet.Shard != req.shard {
+		return false
+	}
+	if len(req.tabletTypes) > 0 && !topoproto.IsTypeInList(tablet.Type, req.tabletTypes) {
+		return false
+	}
+	if req.hostname != "" && !strings.Contains(tablet.Hostname, req.hostname) {
+		return false
+	}
+	if req.serving != nil {
+		serving := tablet.Stats != nil && tablet.Stats.Serving
+		if serving != *req.serving {
+			return false
+		}
+	}
+	return true
+}
+
+// mask returns the tablet with only the fields of the request.
+func (req *tabletListRequest) mask(tablet *TabletWithStatsAndURL) (interface{}, error) {
+	if len(req.fields) == 0 {
+		return tablet, nil
+	}
+	data, err := json.Marshal(tablet)
+	if err != nil {
+		return nil, err
+	}
+	all := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+	masked := make(map[string]json.RawMessage, len(req.fields))
+	for _, field := range req.fields {
+		if value, ok := all[field]; ok {
+			masked[field] = value
+		}
+	}
+	return masked, nil
+}
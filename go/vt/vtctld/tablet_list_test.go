@@ -0,0 +1,114 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtctld
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func TestListTablets(t *testing.T) {
+	ctx := context.Background()
+	ts := memorytopo.NewServer("cell1", "cell2")
+	require.NoError(t, ts.CreateKeyspace(ctx, "ks", &topodatapb.Keyspace{}))
+	require.NoError(t, ts.CreateShard(ctx, "ks", "-80"))
+	require.NoError(t, ts.CreateShard(ctx, "ks", "80-"))
+	require.NoError(t, ts.CreateKeyspace(ctx, "ks2", &topodatapb.Keyspace{}))
+	require.NoError(t, ts.CreateShard(ctx, "ks2", "0"))
+	for _, tablet := range []*topodatapb.Tablet{
+		{Alias: &topodatapb.TabletAlias{Cell: "cell1", Uid: 100}, Hostname: "db-a1", Keyspace: "ks", Shard: "-80", Type: topodatapb.TabletType_MASTER},
+		{Alias: &topodatapb.TabletAlias{Cell: "cell1", Uid: 101}, Hostname: "db-a2", Keyspace: "ks", Shard: "-80", Type: topodatapb.TabletType_REPLICA},
+		{Alias: &topodatapb.TabletAlias{Cell: "cell1", Uid: 102}, Hostname: "db-b1", Keyspace: "ks", Shard: "80-", Type: topodatapb.TabletType_REPLICA},
+		{Alias: &topodatapb.TabletAlias{Cell: "cell1", Uid: 103}, Hostname: "db-b2", Keyspace: "ks2", Shard: "0", Type: topodatapb.TabletType_RDONLY},
+		{Alias: &topodatapb.TabletAlias{Cell: "cell2", Uid: 200}, Hostname: "db-a3", Keyspace: "ks", Shard: "-80", Type: topodatapb.TabletType_REPLICA},
+	} {
+		require.NoError(t, ts.CreateTablet(ctx, tablet))
+	}
+
+	list := func(query string) *TabletPage {
+		t.Helper()
+		r := httptest.NewRequest("GET", "/api/tablets/?"+query, nil)
+		require.NoError(t, r.ParseForm())
+		req, err := parseTabletListRequest(r)
+		require.NoError(t, err)
+		page, err := listTablets(ctx, ts, nil, req)
+		require.NoError(t, err)
+		return page
+	}
+	aliases := func(page *TabletPage) []string {
+		var aliases []string
+		for _, tablet := range page.Tablets {
+			aliases = append(aliases, topoproto.TabletAliasString(tablet.(*TabletWithStatsAndURL).Alias))
+		}
+		return aliases
+	}
+
+	// The pages cover all the cells, in the order of the aliases.
+	page := list("page_size=2")
+	assert.Equal(t, []string{"cell1-0000000100", "cell1-0000000101"}, aliases(page))
+	require.NotEmpty(t, page.NextPageToken)
+	page = list("page_size=2&page_token=" + page.NextPageToken)
+	assert.Equal(t, []string{"cell1-0000000102", "cell1-0000000103"}, aliases(page))
+	page = list("page_size=2&page_token=" + page.NextPageToken)
+	assert.Equal(t, []string{"cell2-0000000200"}, aliases(page))
+	assert.Empty(t, page.NextPageToken)
+
+	// The filters are applied before the pagination.
+	page = list("page_size=1&keyspace=ks&tablet_type=replica")
+	assert.Equal(t, []string{"cell1-0000000101"}, aliases(page))
+	page = list("page_size=1&keyspace=ks&tablet_type=replica&page_token=" + page.NextPageToken)
+	assert.Equal(t, []string{"cell1-0000000102"}, aliases(page))
+	page = list("page_size=1&keyspace=ks&tablet_type=replica&page_token=" + page.NextPageToken)
+	assert.Equal(t, []string{"cell2-0000000200"}, aliases(page))
+	assert.Empty(t, page.NextPageToken)
+
+	assert.Equal(t, []string{"cell1-0000000101"}, aliases(list("page_size=10&cell=cell1&shard=ks/-80&tablet_type=replica")))
+	assert.Equal(t, []string{"cell1-0000000101", "cell2-0000000200"}, aliases(list("page_size=10&hostname=db-a&tablet_type=replica,rdonly")))
+
+	// The field mask only returns the requested fields.
+	page = list("page_size=1&fields=hostname,type")
+	data, err := json.Marshal(page.Tablets[0])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"hostname":"db-a1","type":1}`, string(data))
+}
+
+func TestParseTabletListRequestErrors(t *testing.T) {
+	for _, query := range []string{
+		"page_size=0",
+		"page_size=1001",
+		"page_size=10&page_token=xyz",
+		"page_size=10&tablet_type=bogus",
+		"page_size=10&fields=alias,password",
+		"page_size=10&serving=maybe",
+		"page_size=10&keyspace=ks&shard=ks2/0",
+	} {
+		r := httptest.NewRequest("GET", "/api/tablets/?"+query, nil)
+		require.NoError(t, r.ParseForm())
+		_, err := parseTabletListRequest(r)
+		assert.Error(t, err, query)
+	}
+}
@@ -27,18 +27,24 @@ import (
 
 	rice "github.com/GeertJohan/go.rice"
 
+	"vitess.io/vitess/go/vt/cdcsink"
 	"vitess.io/vitess/go/vt/log"
 
 	"vitess.io/vitess/go/acl"
+	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/mysqlctl/backupstorage"
 	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/vtctl/grpcvtctldserver"
+	"vitess.io/vitess/go/vt/vttablet/tmclient"
 	"vitess.io/vitess/go/vt/wrangler"
 
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 )
 
 var (
-	enableRealtimeStats = flag.Bool("enable_realtime_stats", false, "Required for the Realtime Stats view. If set, vtctld will maintain a streaming RPC to each tablet (in all cells) to gather the realtime health stats.")
-	sanitizeLogMessages = flag.Bool("vtctld_sanitize_log_messages", false, "When true, vtctld sanitizes logging.")
+	enableRealtimeStats         = flag.Bool("enable_realtime_stats", false, "Required for the Realtime Stats view. If set, vtctld will maintain a streaming RPC to each tablet (in all cells) to gather the realtime health stats.")
+	sanitizeLogMessages         = flag.Bool("vtctld_sanitize_log_messages", false, "When true, vtctld sanitizes logging.")
+	rollbackSafetyCheckInterval = flag.Duration("workflow_rollback_safety_check_interval", 0, "If set, vtctld periodically checks the reverse workflows of workflows for which writes were switched, and exports whether a rollback is safe in the WorkflowRollbackSafe stat.")
 
 	_ = flag.String("web_dir", "", "NOT USED, here for backward compatibility")
 	_ = flag.String("web_dir2", "", "NOT USED, here for backward compatibility")
@@ -50,6 +56,7 @@ const (
 
 // InitVtctld initializes all the vtctld functionality.
 func InitVtctld(ts *topo.Server) {
+	backupstorage.SetTopoServer(ts)
 	actionRepo := NewActionRepository(ts)
 
 	// keyspace actions
@@ -169,6 +176,7 @@ func InitVtctld(ts *topo.Server) {
 		if err != nil {
 			log.Errorf("Failed to instantiate RealtimeStats at startup: %v", err)
 		}
+		grpcvtctldserver.RegisterTabletHealthSource(realtimeStats)
 	}
 
 	// Serve the REST API for the vtctld web app.
@@ -183,6 +191,17 @@ func InitVtctld(ts *topo.Server) {
 	// Init online DDL schema manager
 	initSchemaManager(ts)
 
+	// Run the backup schedules of the keyspaces.
+	initBackupScheduler(ts)
+
 	// Setup reverse proxy for all vttablets through /vttablet/.
 	initVTTabletRedirection(ts)
+
+	if *rollbackSafetyCheckInterval > 0 {
+		wr := wrangler.New(logutil.NewConsoleLogger(), ts, tmclient.NewTabletManagerClient())
+		go wr.MonitorRollbackSafety(context.Background(), *rollbackSafetyCheckInterval)
+	}
+
+	// Run the CDC sinks, if configured.
+	cdcsink.Init(ts)
 }
@@ -19,7 +19,6 @@ package vtctld
 import (
 	"context"
 	"flag"
-	"time"
 
 	"vitess.io/vitess/go/trace"
 
@@ -28,10 +27,16 @@ import (
 	"vitess.io/vitess/go/vt/servenv"
 	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/vtctl"
+	"vitess.io/vitess/go/vt/vtctl/grpcvtctldserver"
 	"vitess.io/vitess/go/vt/workflow"
+	"vitess.io/vitess/go/vt/workflow/cellmigration"
+	"vitess.io/vitess/go/vt/workflow/hostmaintenance"
 	"vitess.io/vitess/go/vt/workflow/resharding"
 	"vitess.io/vitess/go/vt/workflow/reshardingworkflowgen"
+	"vitess.io/vitess/go/vt/workflow/rollingrestart"
+	"vitess.io/vitess/go/vt/workflow/schemarollout"
 	"vitess.io/vitess/go/vt/workflow/topovalidator"
+	"vitess.io/vitess/go/vt/workflow/vdiff"
 )
 
 var (
@@ -52,6 +57,8 @@ func initWorkflowManager(ts *topo.Server) {
 		// Register the Topo Validators, and the workflow.
 		topovalidator.RegisterKeyspaceValidator()
 		topovalidator.RegisterShardValidator()
+		topovalidator.RegisterTabletValidator()
+		topovalidator.RegisterVSchemaValidator()
 		topovalidator.Register()
 
 		// Register the Horizontal Resharding workflow.
@@ -60,6 +67,21 @@ func initWorkflowManager(ts *topo.Server) {
 		// Register workflow that generates Horizontal Resharding workflows.
 		reshardingworkflowgen.Register()
 
+		// Register the schema rollout workflow.
+		schemarollout.Register()
+
+		// Register the rolling restart workflow.
+		rollingrestart.Register()
+
+		// Register the host maintenance workflow.
+		hostmaintenance.Register()
+
+		// Register the VDiff workflow, which can be run on a schedule.
+		vdiff.Register()
+
+		// Register the cell migration workflow.
+		cellmigration.Register()
+
 		// Unregister the blacklisted workflows.
 		for _, name := range workflowManagerDisable {
 			workflow.Unregister(name)
@@ -68,10 +90,14 @@ func initWorkflowManager(ts *topo.Server) {
 		// Create the WorkflowManager.
 		vtctl.WorkflowManager = workflow.NewManager(ts)
 		vtctl.WorkflowManager.SetSanitizeHTTPHeaders(*sanitizeLogMessages)
+		grpcvtctldserver.SetWorkflowManager(vtctl.WorkflowManager)
 
-		// Register the long polling and websocket handlers.
-		vtctl.WorkflowManager.HandleHTTPLongPolling(apiPrefix + "workflow")
-		vtctl.WorkflowManager.HandleHTTPWebSocket(apiPrefix + "workflow")
+		// Register the long polling and websocket handlers, unless the
+		// vtctld is read-only: they also run the actions of the workflows.
+		if !grpcvtctldserver.IsReadOnly() {
+			vtctl.WorkflowManager.HandleHTTPLongPolling(apiPrefix + "workflow")
+			vtctl.WorkflowManager.HandleHTTPWebSocket(apiPrefix + "workflow")
+		}
 
 		if *workflowManagerUseElection {
 			runWorkflowManagerElection(ts)
@@ -92,8 +118,6 @@ func runWorkflowManagerAlone() {
 }
 
 func runWorkflowManagerElection(ts *topo.Server) {
-	var mp topo.MasterParticipation
-
 	// We use servenv.ListeningURL which is only populated during Run,
 	// so we have to start this with OnRun.
 	servenv.OnRun(func() {
@@ -106,37 +130,27 @@ func runWorkflowManagerElection(ts *topo.Server) {
 			return
 		}
 
-		mp, err = conn.NewMasterParticipation("vtctld", servenv.ListeningURL.Host)
-		if err != nil {
+		// The workflows run by this vtctld are recorded with the same
+		// ID as in the election.
+		vtctl.WorkflowManager.SetID(servenv.ListeningURL.Host)
+		election := newWorkflowManagerElection(conn, servenv.ListeningURL.Host, vtctl.WorkflowManager.Run)
+		if err := election.start(); err != nil {
 			log.Errorf("Cannot start MasterParticipation, disabling workflow manager: %v", err)
 			return
 		}
+		setWorkflowElection(election)
 
 		// Set up a redirect host so when we are not the
 		// master, we can redirect traffic properly.
 		vtctl.WorkflowManager.SetRedirectFunc(func() (string, error) {
-			ctx := context.Background()
-			return mp.GetCurrentMasterID(ctx)
+			return election.leaderID(context.Background())
 		})
-
-		go func() {
-			for {
-				ctx, err := mp.WaitForMastership()
-				switch {
-				case err == nil:
-					vtctl.WorkflowManager.Run(ctx)
-				case topo.IsErrType(err, topo.Interrupted):
-					return
-				default:
-					log.Errorf("Got error while waiting for master, will retry in 5s: %v", err)
-					time.Sleep(5 * time.Second)
-				}
-			}
-		}()
 	})
 
 	// When we get killed, clean up.
 	servenv.OnTermSync(func() {
-		mp.Stop()
+		if election, err := getWorkflowElection(); err == nil {
+			election.stop()
+		}
 	})
 }
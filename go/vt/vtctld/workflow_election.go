@@ -0,0 +1,337 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtctld
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/topo"
+)
+
+// This file implements the election of the workflow manager between the
+// vtctlds started with -workflow_manager_use_election. On top of the
+// election of the topo server, the leader records when it was elected,
+// and steps down when a transfer of its leadership is requested.
+
+const (
+	workflowManagerElectionName = "vtctld"
+
+	// workflowManagerLeaderPath is the path in the global topo of the
+	// record of the current leader, with the start of its lease.
+	workflowManagerLeaderPath = "workflow_manager/leader"
+	// workflowManagerTransferPath is the path in the global topo of the
+	// last request to transfer the leadership.
+	workflowManagerTransferPath = "workflow_manager/transfer"
+)
+
+// workflowManagerTransferPollInterval is how often the leader checks if a
+// transfer of its leadership was requested.
+var workflowManagerTransferPollInterval = 5 * time.Second
+
+// WorkflowManagerLeader describes the vtctld whose workflow manager is
+// running.
+type WorkflowManagerLeader struct {
+	// ID is the host:port of the leader.
+	ID string `json:"id"`
+	// Self is true if the vtctld reporting the leader is the leader.
+	Self bool `json:"self"`
+	// LeaseStart is when the leader was elected. It's not set if the
+	// leader didn't record it yet.
+	LeaseStart *time.Time `json:"lease_start,omitempty"`
+	// LeaseAge is how long the leader has been elected for.
+	LeaseAge string `json:"lease_age,omitempty"`
+}
+
+// workflowManagerRecord is stored as JSON in the global topo. For the
+// leader record, Time is the start of the lease of ID. For the transfer
+// record, it's when the leader ID was asked to step down.
+type workflowManagerRecord struct {
+	ID   string    `json:"id"`
+	Time time.Time `json:"time"`
+}
+
+// workflowManagerElection runs the workflow manager of this vtctld while
+// it is the leader.
+type workflowManagerElection struct {
+	conn topo.Conn
+	id   string
+	// runManager runs the workflow manager until ctx is canceled.
+	runManager func(ctx context.Context)
+	// done is closed when the election stopped.
+	done chan struct{}
+
+	// mu protects the fields below.
+	mu sync.Mutex
+	mp topo.MasterParticipation
+	// steppedDown is true while this vtctld left the election after a
+	// transfer, before it rejoins it. mp is stopped then.
+	steppedDown bool
+	stopped     bool
+}
+
+func newWorkflowManagerElection(conn topo.Conn, id string, runManager func(ctx context.Context)) *workflowManagerElection {
+	return &workflowManagerElection{
+		conn:       conn,
+		id:         id,
+		runManager: runManager,
+		done:       make(chan struct{}),
+	}
+}
+
+// start joins the election.
+func (e *workflowManagerElection) start() error {
+	mp, err := e.conn.NewMasterParticipation(workflowManagerElectionName, e.id)
+	if err != nil {
+		return err
+	}
+	e.mu.Lock()
+	e.mp = mp
+	e.mu.Unlock()
+	go e.run(mp)
+	return nil
+}
+
+// stop leaves the election, and stops the workflow manager if this vtctld
+// is the leader.
+func (e *workflowManagerElection) stop() {
+	e.mu.Lock()
+	e.stopped = true
+	mp, steppedDown := e.mp, e.steppedDown
+	e.mu.Unlock()
+	if !steppedDown {
+		mp.Stop()
+	}
+}
+
+func (e *workflowManagerElection) run(mp topo.MasterParticipation) {
+	defer close(e.done)
+	for {
+		ctx, err := mp.WaitForMastership()
+		switch {
+		case err == nil:
+			if !e.lead(ctx) {
+				continue
+			}
+			// Rejoin the election after the other candidates, so one of
+			// them takes over.
+			if mp, err = e.rejoin(); err != nil {
+				log.Errorf("Cannot rejoin the workflow manager election, disabling workflow manager: %v", err)
+				return
+			}
+			if mp == nil {
+				return
+			}
+		case topo.IsErrType(err, topo.Interrupted):
+			return
+		default:
+			log.Errorf("Got error while waiting for master, will retry in 5s: %v", err)
+			time.Sleep(5 * time.Second)
+		}
+	}
+}
+
+// lead runs the workflow manager until the leadership is lost or
+// transferred. It returns true if it was transferred.
+func (e *workflowManagerElection) lead(ctx context.Context) bool {
+	leaseStart := time.Now()
+	log.Infof("Elected as the workflow manager leader")
+	if err := e.writeRecord(ctx, workflowManagerLeaderPath, &workflowManagerRecord{ID: e.id, Time: leaseStart}); err != nil {
+		log.Warningf("Cannot record the workflow manager leader: %v", err)
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	transferred := make(chan bool, 1)
+	go func() {
+		transferred <- e.waitForTransfer(runCtx, leaseStart)
+		cancel()
+	}()
+	e.runManager(runCtx)
+	cancel()
+	if <-transferred {
+		log.Infof("Stepped down as the workflow manager leader")
+		return true
+	}
+	log.Infof("Lost the workflow manager leadership")
+	return false
+}
+
+// waitForTransfer returns true once the leadership of this vtctld is
+// requested to be transferred, or false when ctx is done.
+func (e *workflowManagerElection) waitForTransfer(ctx context.Context, leaseStart time.Time) bool {
+	ticker := time.NewTicker(workflowManagerTransferPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-ticker.C:
+		}
+		record, err := e.readRecord(ctx, workflowManagerTransferPath)
+		if err != nil {
+			log.Warningf("Cannot read the workflow manager transfer request: %v", err)
+			continue
+		}
+		if record != nil && record.ID == e.id && record.Time.After(leaseStart) {
+			return true
+		}
+	}
+}
+
+// rejoin leaves the election after a transfer, and joins it again once
+// another vtctld took over, or after workflowManagerTransferPollInterval
+// if there is none. It returns nil if the election was stopped meanwhile.
+func (e *workflowManagerElection) rejoin() (topo.MasterParticipation, error) {
+	e.mu.Lock()
+	if e.stopped {
+		e.mu.Unlock()
+		return nil, nil
+	}
+	mp := e.mp
+	mp.Stop()
+	e.steppedDown = true
+	e.mu.Unlock()
+
+	// Not all the topo servers elect the candidates in the order they
+	// joined, so we don't rejoin right away.
+	ctx, cancel := context.WithTimeout(context.Background(), workflowManagerTransferPollInterval)
+	defer cancel()
+	ticker := time.NewTicker(workflowManagerTransferPollInterval / 10)
+	defer ticker.Stop()
+	for ctx.Err() == nil {
+		if id, err := mp.GetCurrentMasterID(ctx); err == nil && id != "" && id != e.id {
+			break
+		}
+		select {
+		case <-ctx.Done():
+		case <-ticker.C:
+		}
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.stopped {
+		return nil, nil
+	}
+	mp, err := e.conn.NewMasterParticipation(workflowManagerElectionName, e.id)
+	if err != nil {
+		return nil, err
+	}
+	e.mp = mp
+	e.steppedDown = false
+	return mp, nil
+}
+
+// leaderID returns the ID of the current leader, or "" if there is none.
+func (e *workflowManagerElection) leaderID(ctx context.Context) (string, error) {
+	e.mu.Lock()
+	mp := e.mp
+	e.mu.Unlock()
+	return mp.GetCurrentMasterID(ctx)
+}
+
+// leader returns the current leader, with the start of its lease.
+func (e *workflowManagerElection) leader(ctx context.Context) (*WorkflowManagerLeader, error) {
+	id, err := e.leaderID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if id == "" {
+		return nil, fmt.Errorf("no workflow manager leader is elected")
+	}
+	leader := &WorkflowManagerLeader{
+		ID:   id,
+		Self: id == e.id,
+	}
+	record, err := e.readRecord(ctx, workflowManagerLeaderPath)
+	if err != nil {
+		return nil, err
+	}
+	if record != nil && record.ID == id {
+		leader.LeaseStart = &record.Time
+		leader.LeaseAge = time.Since(record.Time).Truncate(time.Second).String()
+	}
+	return leader, nil
+}
+
+// transfer asks the current leader to step down, and returns it. The
+// leader stops its workflow manager within
+// workflowManagerTransferPollInterval, and rejoins the election after the
+// other vtctlds, so one of them takes over. The leader stays the leader if
+// it is the only candidate.
+func (e *workflowManagerElection) transfer(ctx context.Context) (*WorkflowManagerLeader, error) {
+	leader, err := e.leader(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := e.writeRecord(ctx, workflowManagerTransferPath, &workflowManagerRecord{ID: leader.ID, Time: time.Now()}); err != nil {
+		return nil, fmt.Errorf("cannot request the transfer of the workflow manager leadership: %v", err)
+	}
+	return leader, nil
+}
+
+func (e *workflowManagerElection) readRecord(ctx context.Context, filePath string) (*workflowManagerRecord, error) {
+	data, _, err := e.conn.Get(ctx, filePath)
+	switch {
+	case topo.IsErrType(err, topo.NoNode):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+	record := &workflowManagerRecord{}
+	if err := json.Unmarshal(data, record); err != nil {
+		return nil, fmt.Errorf("bad workflow manager record in %v: %v", filePath, err)
+	}
+	return record, nil
+}
+
+func (e *workflowManagerElection) writeRecord(ctx context.Context, filePath string, record *workflowManagerRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	_, err = e.conn.Update(ctx, filePath, data, nil)
+	return err
+}
+
+var (
+	// workflowElectionMu protects workflowElection.
+	workflowElectionMu sync.Mutex
+	// workflowElection is the workflow manager election of this vtctld. It
+	// is nil if -workflow_manager_use_election isn't set, or until the
+	// election started.
+	workflowElection *workflowManagerElection
+)
+
+func setWorkflowElection(e *workflowManagerElection) {
+	workflowElectionMu.Lock()
+	defer workflowElectionMu.Unlock()
+	workflowElection = e
+}
+
+func getWorkflowElection() (*workflowManagerElection, error) {
+	workflowElectionMu.Lock()
+	defer workflowElectionMu.Unlock()
+	if workflowElection == nil {
+		return nil, fmt.Errorf("the workflow manager election is not running, see -workflow_manager_use_election")
+	}
+	return workflowElection, nil
+}
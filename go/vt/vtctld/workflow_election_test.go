@@ -0,0 +1,100 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtctld
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+)
+
+// fakeWorkflowManagers records which vtctlds run their workflow manager.
+type fakeWorkflowManagers struct {
+	mu      sync.Mutex
+	running map[string]bool
+}
+
+func (f *fakeWorkflowManagers) run(id string) func(ctx context.Context) {
+	return func(ctx context.Context) {
+		f.mu.Lock()
+		f.running[id] = true
+		f.mu.Unlock()
+		<-ctx.Done()
+		f.mu.Lock()
+		f.running[id] = false
+		f.mu.Unlock()
+	}
+}
+
+func (f *fakeWorkflowManagers) isRunning(id string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.running[id]
+}
+
+func TestWorkflowManagerElection(t *testing.T) {
+	ctx := context.Background()
+	defer func(interval time.Duration) {
+		workflowManagerTransferPollInterval = interval
+	}(workflowManagerTransferPollInterval)
+	workflowManagerTransferPollInterval = 10 * time.Millisecond
+
+	ts := memorytopo.NewServer("cell1")
+	conn, err := ts.ConnForCell(ctx, topo.GlobalCell)
+	require.NoError(t, err)
+	managers := &fakeWorkflowManagers{running: make(map[string]bool)}
+
+	e1 := newWorkflowManagerElection(conn, "vtctld1:15000", managers.run("vtctld1:15000"))
+	require.NoError(t, e1.start())
+	require.Eventually(t, func() bool { return managers.isRunning("vtctld1:15000") }, 5*time.Second, 10*time.Millisecond)
+	e2 := newWorkflowManagerElection(conn, "vtctld2:15000", managers.run("vtctld2:15000"))
+	require.NoError(t, e2.start())
+
+	leader, err := e2.leader(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "vtctld1:15000", leader.ID)
+	assert.False(t, leader.Self)
+	assert.NotNil(t, leader.LeaseStart)
+	assert.NotEmpty(t, leader.LeaseAge)
+
+	// The leader steps down, and the other vtctld takes over.
+	leader, err = e2.transfer(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "vtctld1:15000", leader.ID)
+	require.Eventually(t, func() bool { return managers.isRunning("vtctld2:15000") }, 5*time.Second, 10*time.Millisecond)
+	assert.False(t, managers.isRunning("vtctld1:15000"))
+
+	leader, err = e2.leader(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "vtctld2:15000", leader.ID)
+	assert.True(t, leader.Self)
+
+	// The old leader is still a candidate.
+	e2.stop()
+	<-e2.done
+	require.Eventually(t, func() bool { return managers.isRunning("vtctld1:15000") }, 5*time.Second, 10*time.Millisecond)
+	e1.stop()
+	<-e1.done
+	assert.False(t, managers.isRunning("vtctld1:15000"))
+}
@@ -118,3 +118,8 @@ func (et *ExplainTopo) GetSrvKeyspace(ctx context.Context, cell, keyspace string
 func (et *ExplainTopo) WatchSrvVSchema(ctx context.Context, cell string, callback func(*vschemapb.SrvVSchema, error)) {
 	callback(et.getSrvVSchema(), nil)
 }
+
+// WatchSrvKeyspace is part of the srvtopo.Server interface.
+func (et *ExplainTopo) WatchSrvKeyspace(ctx context.Context, cell, keyspace string, callback func(*topodatapb.SrvKeyspace, error) bool) {
+	callback(et.GetSrvKeyspace(ctx, cell, keyspace))
+}
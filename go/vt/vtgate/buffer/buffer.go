@@ -48,6 +48,7 @@ var (
 	bufferFullError      = vterrors.New(vtrpcpb.Code_UNAVAILABLE, "master buffer is full")
 	entryEvictedError    = vterrors.New(vtrpcpb.Code_UNAVAILABLE, "buffer full: request evicted for newer request")
 	contextCanceledError = vterrors.New(vtrpcpb.Code_UNAVAILABLE, "context was canceled before failover finished")
+	partitionSwitchError = vterrors.New(vtrpcpb.Code_FAILED_PRECONDITION, "keyspace partition switch in progress")
 )
 
 // bufferMode specifies how the buffer is configured for a given shard.
@@ -257,6 +258,32 @@ func (b *Buffer) StatsUpdate(ts *discovery.LegacyTabletStats) {
 	sb.recordExternallyReparentedTimestamp(timestamp, ts.Tablet.Alias)
 }
 
+// StartPartitionSwitch starts buffering the MASTER requests of
+// keyspace/shard because the shard is about to stop serving them, e.g. when
+// its query service was disabled by a resharding.
+// The requests are buffered without waiting for them to fail, until
+// EndPartitionSwitch is called or -buffer_max_failover_duration is reached.
+func (b *Buffer) StartPartitionSwitch(keyspace, shard string) {
+	sb := b.getOrCreateBuffer(keyspace, shard)
+	if sb == nil || sb.disabled() {
+		return
+	}
+	sb.startBufferingForPartitionSwitch()
+}
+
+// EndPartitionSwitch stops any buffering in progress for keyspace/shard
+// because the keyspace partition no longer serves MASTER requests from the
+// shard. The buffered requests are retried right away and get resolved to
+// the new shards after failing on the old one.
+func (b *Buffer) EndPartitionSwitch(keyspace, shard string) {
+	sb := b.getOrCreateBuffer(keyspace, shard)
+	if sb == nil {
+		// Buffer is shut down. Ignore all calls.
+		return
+	}
+	sb.stopBufferingForPartitionSwitch()
+}
+
 // CausedByFailover returns true if "err" was supposedly caused by a failover.
 // To simplify things, we've merged the detection for different MySQL flavors
 // in one function. Supported flavors: MariaDB, MySQL, Google internal.
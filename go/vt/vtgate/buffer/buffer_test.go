@@ -51,6 +51,7 @@ var (
 	statsKeyJoined = fmt.Sprintf("%s.%s", keyspace, shard)
 
 	statsKeyJoinedFailoverEndDetected = statsKeyJoined + "." + string(stopFailoverEndDetected)
+	statsKeyJoinedPartitionSwitched   = statsKeyJoined + "." + string(stopPartitionSwitched)
 
 	statsKeyJoinedWindowExceeded = statsKeyJoined + "." + string(evictedWindowExceeded)
 
@@ -902,6 +903,57 @@ func TestShutdown(t *testing.T) {
 	}
 }
 
+// TestPartitionSwitch tests that the buffering started ahead of a keyspace
+// partition switch buffers requests without errors and stops at the switch.
+func TestPartitionSwitch(t *testing.T) {
+	resetVariables()
+	defer checkVariables(t)
+
+	flag.Set("enable_buffer", "true")
+	defer resetFlagsForTesting()
+	b := New()
+
+	// The shard is about to stop serving: buffering starts right away.
+	b.StartPartitionSwitch(keyspace, shard)
+	if err := waitForState(b, stateBuffering); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := starts.Counts()[statsKeyJoined], int64(1); got != want {
+		t.Fatalf("buffering start was not tracked: got = %v, want = %v", got, want)
+	}
+
+	// Requests are buffered even though they did not fail yet.
+	stopped1 := issueRequest(context.Background(), t, b, nil)
+	stopped2 := issueRequest(context.Background(), t, b, failoverErr)
+	if err := waitForRequestsInFlight(b, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	// Starting again is a no-op.
+	b.StartPartitionSwitch(keyspace, shard)
+	if got, want := starts.Counts()[statsKeyJoined], int64(1); got != want {
+		t.Fatalf("buffering must not be started twice: got = %v, want = %v", got, want)
+	}
+
+	// The partition switched: the requests are drained.
+	b.EndPartitionSwitch(keyspace, shard)
+	if err := <-stopped1; err != nil {
+		t.Fatalf("request should have been buffered and not returned an error: %v", err)
+	}
+	if err := <-stopped2; err != nil {
+		t.Fatalf("request should have been buffered and not returned an error: %v", err)
+	}
+	if err := waitForState(b, stateIdle); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := stops.Counts()[statsKeyJoinedPartitionSwitched], int64(1); got != want {
+		t.Fatalf("buffering stop was not tracked: got = %v, want = %v", got, want)
+	}
+	if err := waitForPoolSlots(b, *size); err != nil {
+		t.Fatal(err)
+	}
+}
+
 // resetVariables resets the task level variables. The code does not reset these
 // with very failover.
 func resetVariables() {
@@ -85,6 +85,16 @@ type shardBuffer struct {
 	lastStart time.Time
 	// lastEnd is the last time we saw the end of a failover.
 	lastEnd time.Time
+	// failoverReason is the error which triggered the current or last
+	// buffering.
+	failoverReason string
+	// lastDrainDuration is how long the last drain took.
+	lastDrainDuration time.Duration
+	// lastDrainedRequests is the number of requests retried by the last drain.
+	lastDrainedRequests int
+	// draining is the number of requests of the ongoing drain which have not
+	// been retried yet.
+	draining int
 	// lastReparent is the last time we saw that the tablet alias of the MASTER
 	// changed i.e. we definitely reparented to a different tablet.
 	lastReparent time.Time
@@ -266,6 +276,10 @@ func (sb *shardBuffer) startBufferingLocked(err error) {
 	failoverDurationSumMs.Reset(sb.statsKey)
 
 	sb.lastStart = sb.now()
+	sb.failoverReason = ""
+	if err != nil {
+		sb.failoverReason = err.Error()
+	}
 	sb.logErrorIfStateNotLocked(stateIdle)
 	sb.state = stateBuffering
 	sb.queue = make([]*entry, 0)
@@ -499,6 +513,24 @@ func (sb *shardBuffer) recordExternallyReparentedTimestamp(timestamp int64, alia
 	sb.stopBufferingLocked(stopFailoverEndDetected, "failover end detected")
 }
 
+func (sb *shardBuffer) startBufferingForPartitionSwitch() {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+
+	if sb.state != stateIdle {
+		// Already buffering, or draining the requests of the last failover.
+		return
+	}
+	sb.startBufferingLocked(partitionSwitchError)
+}
+
+func (sb *shardBuffer) stopBufferingForPartitionSwitch() {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+
+	sb.stopBufferingLocked(stopPartitionSwitched, "keyspace partition switched")
+}
+
 func (sb *shardBuffer) stopBufferingDueToMaxDuration() {
 	sb.mu.Lock()
 	defer sb.mu.Unlock()
@@ -534,6 +566,7 @@ func (sb *shardBuffer) stopBufferingLocked(reason stopReason, details string) {
 	sb.logErrorIfStateNotLocked(stateBuffering)
 	sb.state = stateDraining
 	q := sb.queue
+	sb.draining = len(q)
 	// Clear the queue such that remove(), oldestEntry() and evictOldestEntry()
 	// will not work on obsolete data.
 	sb.queue = nil
@@ -560,6 +593,9 @@ func (sb *shardBuffer) drain(q []*entry) {
 	// TODO(mberlin): Parallelize the drain by pumping the data through a channel.
 	for _, e := range q {
 		sb.unblockAndWait(e, nil /* err */, true /* releaseSlot */, true /* blockingWait */)
+		sb.mu.Lock()
+		sb.draining--
+		sb.mu.Unlock()
 	}
 	d := sb.now().Sub(start)
 	log.Infof("Draining finished for shard: %s Took: %v for: %d requests.", topoproto.KeyspaceShardString(sb.keyspace, sb.shard), d, len(q))
@@ -571,6 +607,30 @@ func (sb *shardBuffer) drain(q []*entry) {
 	sb.logErrorIfStateNotLocked(stateDraining)
 	sb.state = stateIdle
 	sb.timeoutThread = nil
+	sb.lastDrainDuration = d
+	sb.lastDrainedRequests = len(q)
+}
+
+// status returns a snapshot of the state of the buffer for this shard.
+func (sb *shardBuffer) status() *ShardStatus {
+	sb.mu.RLock()
+	defer sb.mu.RUnlock()
+
+	status := &ShardStatus{
+		Keyspace:            sb.keyspace,
+		Shard:               sb.shard,
+		Mode:                sb.mode.String(),
+		State:               string(sb.state),
+		Active:              sb.state != stateIdle,
+		QueuedRequests:      len(sb.queue) + sb.draining,
+		FailoverReason:      sb.failoverReason,
+		LastDrainDuration:   sb.lastDrainDuration,
+		LastDrainedRequests: sb.lastDrainedRequests,
+		WindowStart:         sb.lastStart,
+		LastFailoverEnd:     sb.lastEnd,
+		LastReparent:        sb.lastReparent,
+	}
+	return status
 }
 
 func (sb *shardBuffer) shutdown() {
@@ -0,0 +1,153 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buffer
+
+import (
+	"sort"
+	"time"
+
+	"vitess.io/vitess/go/protoutil"
+
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+	"vitess.io/vitess/go/vt/proto/vttime"
+)
+
+// This file exposes the state of the buffer to status pages and external
+// failover automation (see /debug/buffer and the GetBufferStatus RPC in
+// vtgate).
+
+// ShardStatus is a snapshot of the buffer state of a single shard.
+type ShardStatus struct {
+	Keyspace string
+	Shard    string
+	// Mode is one of "disabled", "enabled" or "dry-run".
+	Mode string
+	// State is one of "IDLE", "BUFFERING" or "DRAINING".
+	State string
+	// Active is true while a failover is buffered or drained.
+	Active bool
+	// WindowStart is the time the current or last buffering started.
+	WindowStart time.Time
+	// LastFailoverEnd is the time the last buffering stopped.
+	LastFailoverEnd time.Time
+	// LastReparent is the last time a change of the MASTER was seen.
+	LastReparent time.Time
+	// QueuedRequests is the number of buffered requests which were not
+	// retried yet, including the ones waiting to be drained.
+	QueuedRequests int
+	// FailoverReason is the error which triggered the current or last
+	// buffering.
+	FailoverReason string `json:",omitempty"`
+	// LastDrainDuration is how long the last drain took.
+	LastDrainDuration time.Duration
+	// LastDrainedRequests is the number of requests retried by the last drain.
+	LastDrainedRequests int
+}
+
+// Status is a snapshot of the state of the whole buffer.
+type Status struct {
+	// Capacity is the maximum number of requests which can be buffered
+	// across all shards ("-buffer_size").
+	Capacity int
+	// Available is the number of free slots in the buffer.
+	Available int
+	Shards    []*ShardStatus
+}
+
+// Drained returns true if no shard is currently buffering or draining.
+func (s *Status) Drained() bool {
+	for _, ss := range s.Shards {
+		if ss.Active {
+			return false
+		}
+	}
+	return true
+}
+
+// Proto returns the status as the response of the GetBufferStatus RPC.
+func (s *Status) Proto() *vtgatepb.GetBufferStatusResponse {
+	resp := &vtgatepb.GetBufferStatusResponse{
+		Capacity:  int64(s.Capacity),
+		Available: int64(s.Available),
+		Shards:    make([]*vtgatepb.BufferShardStatus, 0, len(s.Shards)),
+		Drained:   s.Drained(),
+	}
+	for _, ss := range s.Shards {
+		resp.Shards = append(resp.Shards, &vtgatepb.BufferShardStatus{
+			Keyspace:            ss.Keyspace,
+			Shard:               ss.Shard,
+			Mode:                ss.Mode,
+			State:               ss.State,
+			Active:              ss.Active,
+			WindowStart:         timeToProto(ss.WindowStart),
+			LastFailoverEnd:     timeToProto(ss.LastFailoverEnd),
+			LastReparent:        timeToProto(ss.LastReparent),
+			QueuedRequests:      int64(ss.QueuedRequests),
+			FailoverReason:      ss.FailoverReason,
+			LastDrainDuration:   protoutil.DurationToProto(ss.LastDrainDuration),
+			LastDrainedRequests: int64(ss.LastDrainedRequests),
+		})
+	}
+	return resp
+}
+
+// timeToProto leaves the times which never happened unset.
+func timeToProto(t time.Time) *vttime.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return protoutil.TimeToProto(t)
+}
+
+// Status returns a snapshot of the state of the buffer. Shards are sorted
+// by keyspace and shard name.
+func (b *Buffer) Status() *Status {
+	b.mu.RLock()
+	buffers := make([]*shardBuffer, 0, len(b.buffers))
+	for _, sb := range b.buffers {
+		buffers = append(buffers, sb)
+	}
+	b.mu.RUnlock()
+
+	status := &Status{
+		Capacity:  *size,
+		Available: b.bufferSizeSema.Size(),
+		Shards:    make([]*ShardStatus, 0, len(buffers)),
+	}
+	for _, sb := range buffers {
+		status.Shards = append(status.Shards, sb.status())
+	}
+	sort.Slice(status.Shards, func(i, j int) bool {
+		if status.Shards[i].Keyspace != status.Shards[j].Keyspace {
+			return status.Shards[i].Keyspace < status.Shards[j].Keyspace
+		}
+		return status.Shards[i].Shard < status.Shards[j].Shard
+	})
+	return status
+}
+
+// String returns the name of the mode as shown in the status.
+func (m bufferMode) String() string {
+	switch m {
+	case bufferEnabled:
+		return "enabled"
+	case bufferDryRun:
+		return "dry-run"
+	default:
+		return "disabled"
+	}
+}
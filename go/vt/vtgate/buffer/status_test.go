@@ -0,0 +1,123 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buffer
+
+import (
+	"context"
+	"flag"
+	"testing"
+	"time"
+
+	"vitess.io/vitess/go/vt/discovery"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func TestStatus(t *testing.T) {
+	resetVariables()
+	defer checkVariables(t)
+
+	flag.Set("enable_buffer", "true")
+	defer resetFlagsForTesting()
+
+	now := time.Now()
+	b := newWithNow(func() time.Time { return now })
+	defer b.Shutdown()
+
+	status := b.Status()
+	if got, want := status.Capacity, *size; got != want {
+		t.Fatalf("wrong capacity: got = %v, want = %v", got, want)
+	}
+	if len(status.Shards) != 0 || !status.Drained() {
+		t.Fatalf("no shard should be tracked yet: %+v", status)
+	}
+
+	b.StatsUpdate(&discovery.LegacyTabletStats{
+		Tablet:                              oldMaster,
+		Target:                              &querypb.Target{Keyspace: keyspace, Shard: shard, TabletType: topodatapb.TabletType_MASTER},
+		TabletExternallyReparentedTimestamp: now.Unix(),
+	})
+
+	// Buffer two requests.
+	stopped1 := issueRequest(context.Background(), t, b, failoverErr)
+	stopped2 := issueRequest(context.Background(), t, b, failoverErr)
+	if err := waitForRequestsInFlight(b, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	status = b.Status()
+	if status.Drained() {
+		t.Fatalf("buffer must not be drained during a failover: %+v", status)
+	}
+	if got, want := status.Available, *size-2; got != want {
+		t.Fatalf("wrong number of available slots: got = %v, want = %v", got, want)
+	}
+	ss := status.Shards[0]
+	if ss.Keyspace != keyspace || ss.Shard != shard || ss.Mode != "enabled" || ss.State != string(stateBuffering) || !ss.Active {
+		t.Fatalf("wrong shard status: %+v", ss)
+	}
+	if got, want := ss.QueuedRequests, 2; got != want {
+		t.Fatalf("wrong number of queued requests: got = %v, want = %v", got, want)
+	}
+	if !ss.WindowStart.Equal(now) {
+		t.Fatalf("wrong window start: got = %v, want = %v", ss.WindowStart, now)
+	}
+	if got, want := ss.FailoverReason, failoverErr.Error(); got != want {
+		t.Fatalf("wrong failover reason: got = %v, want = %v", got, want)
+	}
+	resp := status.Proto()
+	if resp.Drained || resp.Capacity != int64(*size) || len(resp.Shards) != 1 {
+		t.Fatalf("wrong GetBufferStatus response: %v", resp)
+	}
+	if sp := resp.Shards[0]; sp.QueuedRequests != 2 || sp.State != string(stateBuffering) || sp.LastFailoverEnd != nil {
+		t.Fatalf("wrong GetBufferStatus shard status: %v", sp)
+	}
+
+	// End the failover.
+	now = now.Add(1 * time.Second)
+	b.StatsUpdate(&discovery.LegacyTabletStats{
+		Tablet:                              newMaster,
+		Target:                              &querypb.Target{Keyspace: keyspace, Shard: shard, TabletType: topodatapb.TabletType_MASTER},
+		TabletExternallyReparentedTimestamp: now.Unix(),
+	})
+	if err := <-stopped1; err != nil {
+		t.Fatalf("request should have been buffered and not returned an error: %v", err)
+	}
+	if err := <-stopped2; err != nil {
+		t.Fatalf("request should have been buffered and not returned an error: %v", err)
+	}
+	if err := waitForState(b, stateIdle); err != nil {
+		t.Fatal(err)
+	}
+
+	status = b.Status()
+	if !status.Drained() {
+		t.Fatalf("buffer must be drained after the failover: %+v", status)
+	}
+	ss = status.Shards[0]
+	if ss.QueuedRequests != 0 || ss.LastDrainedRequests != 2 {
+		t.Fatalf("wrong drain status: %+v", ss)
+	}
+	if !ss.LastFailoverEnd.Equal(now) {
+		t.Fatalf("wrong failover end: got = %v, want = %v", ss.LastFailoverEnd, now)
+	}
+	resp = status.Proto()
+	if !resp.Drained || resp.Shards[0].LastDrainedRequests != 2 || resp.Shards[0].LastFailoverEnd.GetSeconds() != now.Unix() {
+		t.Fatalf("wrong GetBufferStatus response after the failover: %v", resp)
+	}
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package buffer
 
 import (
+	"time"
+
 	"vitess.io/vitess/go/stats"
 )
 
@@ -102,13 +104,24 @@ var (
 		[]string{"Keyspace", "ShardName", "Reason"})
 )
 
+// PublishRateWindows publishes the rates of the buffering events per
+// keyspace and shard over the last minute, 5 minutes and hour, sampled
+// every interval.
+func PublishRateWindows(interval time.Duration) {
+	stats.NewWindowRates("BufferStartsWindows", stats.CounterForDimensions(starts, "Keyspace", "ShardName"), stats.DefaultRateWindows, interval)
+	stats.NewWindowRates("BufferRequestsBufferedWindows", stats.CounterForDimensions(requestsBuffered, "Keyspace", "ShardName"), stats.DefaultRateWindows, interval)
+	stats.NewWindowRates("BufferRequestsEvictedWindows", stats.CounterForDimensions(requestsEvicted, "Keyspace", "ShardName"), stats.DefaultRateWindows, interval)
+	stats.NewWindowRates("BufferRequestsSkippedWindows", stats.CounterForDimensions(requestsSkipped, "Keyspace", "ShardName"), stats.DefaultRateWindows, interval)
+}
+
 // stopReason is used in "stopsByReason" as "Reason" label.
 type stopReason string
 
-var stopReasons = []stopReason{stopFailoverEndDetected, stopMaxFailoverDurationExceeded, stopShutdown}
+var stopReasons = []stopReason{stopFailoverEndDetected, stopPartitionSwitched, stopMaxFailoverDurationExceeded, stopShutdown}
 
 const (
 	stopFailoverEndDetected         stopReason = "NewMasterSeen"
+	stopPartitionSwitched           stopReason = "PartitionSwitched"
 	stopMaxFailoverDurationExceeded stopReason = "MaxDurationExceeded"
 	stopShutdown                    stopReason = "Shutdown"
 )
@@ -0,0 +1,152 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"flag"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+
+	"vitess.io/vitess/go/vt/discovery"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/topo"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// This file keeps the serving overrides of the cells of the keyspaces, set
+// in their keyspace records by the SetCellServingOverride RPC, up to date in
+// the gateway. The
+// gateway doesn't route the queries of a keyspace to the tablets of the
+// other cells marked as "do not serve" for it, whatever their health.
+
+var enableCellServingOverrides = flag.Bool("enable_keyspace_cell_serving_overrides", false, "Apply the serving overrides of the cells of the keyspaces, set in their keyspace records by the SetCellServingOverride vtctld RPC, reloaded from the topo every -keyspace_settings_refresh_interval. If disabled, the tablets of all the watched cells serve.")
+
+// cellServingOverridesSetter is the part of the gateway that applies the
+// serving overrides of the cells.
+type cellServingOverridesSetter interface {
+	SetCellServingOverrides(keyspaces map[string]map[string]*topodatapb.CellServingOverride)
+}
+
+// cellServingOverrides watches the serving overrides of the cells of the
+// keyspaces, and sets them in the gateway when they change.
+type cellServingOverrides struct {
+	setter cellServingOverridesSetter
+
+	mu        sync.Mutex
+	keyspaces map[string]map[string]*topodatapb.CellServingOverride
+}
+
+// refresh is part of the keyspaceSetting interface.
+func (co *cellServingOverrides) refresh(ctx context.Context, ts *topo.Server, keyspaces []string) {
+	co.mu.Lock()
+	defer co.mu.Unlock()
+	overrides := make(map[string]map[string]*topodatapb.CellServingOverride)
+	for _, keyspace := range keyspaces {
+		var ko map[string]*topodatapb.CellServingOverride
+		ki, err := ts.GetKeyspace(ctx, keyspace)
+		if err != nil {
+			log.Warningf("Cannot read the cell serving overrides of keyspace %v: %v", keyspace, err)
+			ko = co.keyspaces[keyspace]
+		} else {
+			ko = ki.CellServingOverrides
+		}
+		if len(ko) > 0 {
+			overrides[keyspace] = ko
+		}
+	}
+	if co.keyspaces != nil && equalCellServingOverrides(overrides, co.keyspaces) {
+		return
+	}
+	co.keyspaces = overrides
+	co.setter.SetCellServingOverrides(overrides)
+}
+
+func equalCellServingOverrides(a, b map[string]map[string]*topodatapb.CellServingOverride) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for keyspace, ka := range a {
+		kb, ok := b[keyspace]
+		if !ok || len(ka) != len(kb) {
+			return false
+		}
+		for cell, oa := range ka {
+			if ob, ok := kb[cell]; !ok || !proto.Equal(oa, ob) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// startCellServingOverrides adds the serving overrides of the cells of the
+// keyspaces to the loader, which keeps them up to date in the gateway.
+func startCellServingOverrides(loader *keyspaceSettingsLoader, setter cellServingOverridesSetter) {
+	if !*enableCellServingOverrides {
+		return
+	}
+	loader.add(&cellServingOverrides{setter: setter})
+}
+
+// SetCellServingOverrides sets the cells whose tablets must not serve each
+// keyspace. The tablets of the local cell keep serving.
+func (gw *TabletGateway) SetCellServingOverrides(keyspaces map[string]map[string]*topodatapb.CellServingOverride) {
+	doNotServeCells := make(map[string]map[string]bool, len(keyspaces))
+	for keyspace, ko := range keyspaces {
+		cells := make(map[string]bool, len(ko))
+		for cell, override := range ko {
+			if !override.DoNotServe || cell == gw.localCell {
+				continue
+			}
+			log.Infof("Not routing the queries of keyspace %v to cell %v: %v", keyspace, cell, override.Reason)
+			cells[cell] = true
+		}
+		if len(cells) > 0 {
+			doNotServeCells[keyspace] = cells
+		}
+	}
+	gw.doNotServeMu.Lock()
+	defer gw.doNotServeMu.Unlock()
+	gw.doNotServeCells = doNotServeCells
+}
+
+// servingTablets returns the healthy tablets of the target, without the
+// ones in the cells which must not serve its keyspace. The primary is
+// always kept, since there is no other tablet to send its queries to.
+func (gw *TabletGateway) servingTablets(target *querypb.Target) []*discovery.TabletHealth {
+	tablets := gw.hc.GetHealthyTabletStats(target)
+	if target.TabletType == topodatapb.TabletType_MASTER {
+		return tablets
+	}
+	gw.doNotServeMu.RLock()
+	cells := gw.doNotServeCells[target.Keyspace]
+	gw.doNotServeMu.RUnlock()
+	if len(cells) == 0 {
+		return tablets
+	}
+	serving := make([]*discovery.TabletHealth, 0, len(tablets))
+	for _, th := range tablets {
+		if !cells[th.Tablet.Alias.Cell] {
+			serving = append(serving, th)
+		}
+	}
+	return serving
+}
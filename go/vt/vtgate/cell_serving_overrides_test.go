@@ -0,0 +1,114 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/discovery"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+type fakeCellServingOverridesSetter struct {
+	calls     int
+	keyspaces map[string]map[string]*topodatapb.CellServingOverride
+}
+
+func (f *fakeCellServingOverridesSetter) SetCellServingOverrides(keyspaces map[string]map[string]*topodatapb.CellServingOverride) {
+	f.calls++
+	f.keyspaces = keyspaces
+}
+
+func TestCellServingOverridesRefresh(t *testing.T) {
+	ctx := context.Background()
+	ts := memorytopo.NewServer("cell1", "cell2")
+	require.NoError(t, ts.CreateKeyspace(ctx, "ks1", &topodatapb.Keyspace{
+		CellServingOverrides: map[string]*topodatapb.CellServingOverride{
+			"cell2": {DoNotServe: true, Reason: "network partition"},
+		},
+	}))
+	require.NoError(t, ts.CreateKeyspace(ctx, "ks2", &topodatapb.Keyspace{}))
+
+	setter := &fakeCellServingOverridesSetter{}
+	co := &cellServingOverrides{setter: setter}
+	refreshKeyspaceSetting(ctx, t, ts, co)
+	assert.Equal(t, 1, setter.calls)
+	require.Len(t, setter.keyspaces, 1)
+	assert.Equal(t, "network partition", setter.keyspaces["ks1"]["cell2"].GetReason())
+
+	// Unchanged overrides are not set again.
+	refreshKeyspaceSetting(ctx, t, ts, co)
+	assert.Equal(t, 1, setter.calls)
+
+	// Removed overrides are dropped on refresh.
+	lockCtx, unlock, err := ts.LockKeyspace(ctx, "ks1", "test")
+	require.NoError(t, err)
+	ki, err := ts.GetKeyspace(lockCtx, "ks1")
+	require.NoError(t, err)
+	ki.CellServingOverrides = nil
+	require.NoError(t, ts.UpdateKeyspace(lockCtx, ki))
+	unlock(&err)
+	require.NoError(t, err)
+	refreshKeyspaceSetting(ctx, t, ts, co)
+	assert.Equal(t, 2, setter.calls)
+	assert.Empty(t, setter.keyspaces)
+}
+
+func TestTabletGatewayCellServingOverrides(t *testing.T) {
+	hc := discovery.NewFakeHealthCheck()
+	tg := NewTabletGateway(context.Background(), hc, nil, "cell1")
+	for i, cell := range []string{"cell1", "cell2", "cell3"} {
+		hc.AddTestTablet(cell, cell, int32(1000+i), "ks", "0", topodatapb.TabletType_REPLICA, true, 10, nil)
+	}
+	hc.AddTestTablet("cell2", "primary", 2000, "ks", "0", topodatapb.TabletType_MASTER, true, 10, nil)
+	replicaCells := func() []string {
+		var cells []string
+		for _, th := range tg.servingTablets(&querypb.Target{Keyspace: "ks", Shard: "0", TabletType: topodatapb.TabletType_REPLICA}) {
+			cells = append(cells, th.Tablet.Alias.Cell)
+		}
+		sort.Strings(cells)
+		return cells
+	}
+	assert.Equal(t, []string{"cell1", "cell2", "cell3"}, replicaCells())
+
+	// The overridden cells don't serve, except the local cell and the
+	// primary.
+	tg.SetCellServingOverrides(map[string]map[string]*topodatapb.CellServingOverride{
+		"ks": {
+			"cell1": {DoNotServe: true},
+			"cell2": {DoNotServe: true, Reason: "network partition"},
+		},
+		"other": {
+			"cell3": {DoNotServe: true},
+		},
+	})
+	assert.Equal(t, []string{"cell1", "cell3"}, replicaCells())
+	primaries := tg.servingTablets(&querypb.Target{Keyspace: "ks", Shard: "0", TabletType: topodatapb.TabletType_MASTER})
+	require.Len(t, primaries, 1)
+	assert.Equal(t, "cell2", primaries[0].Tablet.Alias.Cell)
+
+	tg.SetCellServingOverrides(nil)
+	assert.Equal(t, []string{"cell1", "cell2", "cell3"}, replicaCells())
+}
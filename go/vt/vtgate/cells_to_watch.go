@@ -0,0 +1,143 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"vitess.io/vitess/go/acl"
+	"vitess.io/vitess/go/vt/log"
+)
+
+// The cells whose tablets are watched can be changed without a restart, e.g.
+// to turn up a new cell, with a POST to /debug/cells_to_watch, or by
+// changing -cells_to_watch_file and sending SIGHUP to vtgate. The watchers
+// of the removed cells are stopped and their tablets removed, and watchers
+// are started for the new cells.
+
+var cellsToWatchFile = flag.String("cells_to_watch_file", "", "file containing the comma-separated list of cells for watching tablets, which overrides -cells_to_watch; send SIGHUP to reload this file")
+
+// cellsToWatchSetter is implemented by the gateways which can change the
+// cells they watch at runtime.
+type cellsToWatchSetter interface {
+	CellsToWatch() ([]string, error)
+	SetCellsToWatch(cellsToWatch string) error
+}
+
+var (
+	_ cellsToWatchSetter = (*TabletGateway)(nil)
+	_ cellsToWatchSetter = (*DiscoveryGateway)(nil)
+)
+
+// LoadCellsToWatchFile sets -cells_to_watch from -cells_to_watch_file, if
+// it is set. It must be called before the gateway is created.
+func LoadCellsToWatchFile() error {
+	if *cellsToWatchFile == "" {
+		return nil
+	}
+	cells, err := readCellsToWatchFile()
+	if err != nil {
+		return err
+	}
+	*CellsToWatch = cells
+	return nil
+}
+
+func readCellsToWatchFile() (string, error) {
+	data, err := ioutil.ReadFile(*cellsToWatchFile)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// reloadCellsToWatchOnSIGHUP changes the cells to watch to the ones of
+// -cells_to_watch_file when vtgate receives SIGHUP.
+func (vtg *VTGate) reloadCellsToWatchOnSIGHUP() {
+	if *cellsToWatchFile == "" {
+		return
+	}
+	gw, ok := vtg.gw.(cellsToWatchSetter)
+	if !ok {
+		log.Warningf("-cells_to_watch_file is not reloaded: the gateway doesn't support changing the cells to watch")
+		return
+	}
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGHUP)
+	go func() {
+		for range sigChan {
+			cells, err := readCellsToWatchFile()
+			if err != nil {
+				log.Errorf("Cannot reload -cells_to_watch_file: %v", err)
+				continue
+			}
+			if err := gw.SetCellsToWatch(cells); err != nil {
+				log.Errorf("Cannot change the cells to watch to %v: %v", cells, err)
+				continue
+			}
+			log.Infof("Cells to watch changed to %v", cells)
+		}
+	}()
+}
+
+// registerDebugCellsToWatchHandler exposes the cells whose tablets are
+// watched. A POST with a cells parameter, in the format of -cells_to_watch,
+// changes them without a restart, e.g. to turn up a new cell.
+func (vtg *VTGate) registerDebugCellsToWatchHandler() {
+	http.HandleFunc("/debug/cells_to_watch", func(w http.ResponseWriter, r *http.Request) {
+		gw, ok := vtg.gw.(cellsToWatchSetter)
+		if !ok {
+			http.Error(w, "the gateway doesn't support changing the cells to watch", http.StatusNotImplemented)
+			return
+		}
+		if r.Method == http.MethodPost {
+			if err := acl.CheckAccessHTTP(r, acl.ADMIN); err != nil {
+				acl.SendError(w, err)
+				return
+			}
+			cells := r.FormValue("cells")
+			if err := gw.SetCellsToWatch(cells); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			log.Infof("Cells to watch changed to %v", cells)
+		} else if err := acl.CheckAccessHTTP(r, acl.MONITORING); err != nil {
+			acl.SendError(w, err)
+			return
+		}
+		cells, err := gw.CellsToWatch()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		data, err := json.MarshalIndent(cells, "", "  ")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Write(data)
+	})
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/sqlparser"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+// This file implements the checksum mode of the sessions, to validate a
+// migration such as MoveTables while its reads are switched. A session
+// which sets @@vitess_checksum_keyspaces = 'old_ks:new_ks' runs each of
+// its SELECTs outside of a transaction on the keyspace it is routed to
+// and, asynchronously, on the other keyspace of the pair, ignoring the
+// routing rules. The checksums of the results are compared, and the
+// mismatches are logged with the fingerprint of the query. The response
+// to the client doesn't wait for the checksum query.
+
+var (
+	checksumMaxConcurrency = flag.Int("checksum_max_concurrency", 10, "Maximum number of checksum queries of the sessions with @@vitess_checksum_keyspaces in flight. Queries are not checksummed when it is reached.")
+	checksumTimeout        = flag.Duration("checksum_timeout", 5*time.Second, "Timeout of a checksum query.")
+
+	checksumQueries = stats.NewCountersWithMultiLabels(
+		"ChecksumQueries",
+		"Number of read queries checksummed against another keyspace, by outcome of the comparison",
+		[]string{"Keyspace", "Result"})
+)
+
+// checksumKeyspacesVariable is the user defined variable which holds the
+// @@vitess_checksum_keyspaces setting of the session.
+const checksumKeyspacesVariable = "__vt_checksum_keyspaces"
+
+// parseChecksumKeyspaces parses a comma separated list of
+// keyspace:keyspace pairs, and returns the other keyspace of each
+// keyspace.
+func parseChecksumKeyspaces(value string) (map[string]string, error) {
+	pairs := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		parts := strings.Split(pair, ":")
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" || parts[0] == parts[1] {
+			return nil, fmt.Errorf("invalid keyspace pair %q, expected keyspace:keyspace", pair)
+		}
+		for i, keyspace := range parts {
+			other := parts[1-i]
+			if existing, ok := pairs[keyspace]; ok && existing != other {
+				return nil, fmt.Errorf("keyspace %v is in several pairs", keyspace)
+			}
+			pairs[keyspace] = other
+		}
+	}
+	return pairs, nil
+}
+
+// queryChecksummer checksums the read queries of the sessions in
+// checksum mode.
+type queryChecksummer struct {
+	timeout time.Duration
+	exec    mirrorExecFunc
+	// slots bounds the number of checksum queries in flight.
+	slots  chan struct{}
+	logger *logutil.ThrottledLogger
+}
+
+func newQueryChecksummer(exec mirrorExecFunc) *queryChecksummer {
+	return &queryChecksummer{
+		timeout: *checksumTimeout,
+		exec:    exec,
+		slots:   make(chan struct{}, *checksumMaxConcurrency),
+		logger:  logutil.NewThrottledLogger("QueryChecksum", 5*time.Second),
+	}
+}
+
+// maybeChecksum runs the query on the other keyspace of the keyspace it
+// ran on, if the session is in checksum mode for it, and compares the
+// results. It returns immediately.
+func (qc *queryChecksummer) maybeChecksum(session *SafeSession, stmtType sqlparser.StatementType, keyspace, sql string, bindVars map[string]*querypb.BindVariable, result *sqltypes.Result, err error) {
+	if stmtType != sqlparser.StmtSelect || session.InTransaction() {
+		return
+	}
+	setting := session.ChecksumKeyspaces()
+	if setting == "" {
+		return
+	}
+	pairs, parseErr := parseChecksumKeyspaces(setting)
+	if parseErr != nil {
+		return
+	}
+	other, ok := pairs[keyspace]
+	if !ok {
+		return
+	}
+	select {
+	case qc.slots <- struct{}{}:
+	default:
+		checksumQueries.Add([]string{keyspace, mirrorSkippedBusy}, 1)
+		return
+	}
+
+	otherSQL, _, rewriteErr := rewriteForShadow(sql, keyspace, other)
+	if rewriteErr != nil {
+		<-qc.slots
+		checksumQueries.Add([]string{keyspace, mirrorRewriteFailed}, 1)
+		return
+	}
+	target, ok := shadowTarget(session.TargetString, other)
+	if !ok {
+		<-qc.slots
+		return
+	}
+	otherSession := NewSafeSession(&vtgatepb.Session{
+		TargetString: target,
+		Autocommit:   true,
+		Options:      proto.Clone(session.GetOptions()).(*querypb.ExecuteOptions),
+	})
+	otherSession.ignoreRoutingRules = true
+	otherBindVars := make(map[string]*querypb.BindVariable, len(bindVars))
+	for k, v := range bindVars {
+		otherBindVars[k] = v
+	}
+	// The caller owns the result once this returns.
+	primary := summarizeResult(result, err)
+
+	go func() {
+		defer func() { <-qc.slots }()
+		ctx, cancel := context.WithTimeout(context.Background(), qc.timeout)
+		defer cancel()
+		otherResult, otherErr := qc.exec(ctx, otherSession, otherSQL, otherBindVars)
+		checked := summarizeResult(otherResult, otherErr)
+		outcome := compareMirrorResults(primary, checked)
+		checksumQueries.Add([]string{keyspace, outcome}, 1)
+		if outcome == mirrorMatch {
+			return
+		}
+		fingerprint, normalized := queryFingerprint(sql)
+		switch outcome {
+		case mirrorRowsMismatch:
+			qc.logger.Warningf("Checksum mismatch of query %016x on %v and %v: %v rows with checksum %016x vs %v rows with checksum %016x: %v", fingerprint, keyspace, other, primary.rows, primary.fingerprint, checked.rows, checked.fingerprint, normalized)
+		default:
+			qc.logger.Warningf("Checksum of query %016x on %v and %v failed: %v, error on %v: %v, error on %v: %v: %v", fingerprint, keyspace, other, outcome, keyspace, err, other, otherErr, normalized)
+		}
+	}()
+}
+
+// queryFingerprint returns a hash of the query with its literals redacted,
+// which identifies the queries which only differ by their values, and the
+// redacted query.
+func queryFingerprint(sql string) (uint64, string) {
+	redacted, err := sqlparser.RedactSQLQuery(sql)
+	if err != nil {
+		redacted = sql
+	}
+	h := fnv.New64a()
+	h.Write([]byte(redacted))
+	return h.Sum64(), redacted
+}
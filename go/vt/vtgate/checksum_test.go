@@ -0,0 +1,128 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/sqlparser"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+func TestParseChecksumKeyspaces(t *testing.T) {
+	pairs, err := parseChecksumKeyspaces("ks_old:ks_new, a:b")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"ks_old": "ks_new", "ks_new": "ks_old", "a": "b", "b": "a"}, pairs)
+
+	pairs, err = parseChecksumKeyspaces("")
+	require.NoError(t, err)
+	assert.Empty(t, pairs)
+
+	for _, value := range []string{"ks", "ks:", "ks:ks", "a:b:c", "a:b,a:c"} {
+		_, err := parseChecksumKeyspaces(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestQueryFingerprint(t *testing.T) {
+	f1, redacted := queryFingerprint("select id from t where a = 1")
+	f2, _ := queryFingerprint("select id from t where a = 2")
+	f3, _ := queryFingerprint("select id from t where b = 1")
+	assert.Equal(t, f1, f2)
+	assert.NotEqual(t, f1, f3)
+	assert.Equal(t, "select id from t where a = :redacted1", redacted)
+}
+
+func TestSessionChecksumKeyspaces(t *testing.T) {
+	session := NewSafeSession(&vtgatepb.Session{})
+	assert.Equal(t, "", session.ChecksumKeyspaces())
+	session.SetChecksumKeyspaces("ks_old:ks_new")
+	assert.Equal(t, "ks_old:ks_new", session.ChecksumKeyspaces())
+	session.SetChecksumKeyspaces("")
+	assert.Equal(t, "", session.ChecksumKeyspaces())
+	assert.Empty(t, session.UserDefinedVariables)
+}
+
+func TestQueryChecksummer(t *testing.T) {
+	type checksummed struct {
+		target             string
+		sql                string
+		ignoreRoutingRules bool
+	}
+	done := make(chan checksummed, 10)
+	result := sqltypes.MakeTestResult(sqltypes.MakeTestFields("id", "int64"), "1")
+	other := sqltypes.MakeTestResult(sqltypes.MakeTestFields("id", "int64"), "2")
+	qc := &queryChecksummer{
+		timeout: time.Second,
+		slots:   make(chan struct{}, 1),
+		logger:  logutil.NewThrottledLogger("TestQueryChecksummer", time.Second),
+		exec: func(ctx context.Context, session *SafeSession, sql string, bindVars map[string]*querypb.BindVariable) (*sqltypes.Result, error) {
+			done <- checksummed{target: session.TargetString, sql: sql, ignoreRoutingRules: session.ignoreRoutingRules}
+			return other, nil
+		},
+	}
+	session := NewSafeSession(&vtgatepb.Session{TargetString: "@replica", Autocommit: true})
+	session.SetChecksumKeyspaces("ks_old:ks_new")
+	before := checksumQueries.Counts()["ks_new.RowsMismatch"]
+
+	// The query routed to ks_new is checksummed on ks_old.
+	qc.maybeChecksum(session, sqlparser.StmtSelect, "ks_new", "select id from ks_new.t", nil, result, nil)
+	select {
+	case got := <-done:
+		assert.Equal(t, checksummed{target: "ks_old@replica", sql: "select id from ks_old.t", ignoreRoutingRules: true}, got)
+	case <-time.After(5 * time.Second):
+		t.Fatal("query not checksummed")
+	}
+	assert.Eventually(t, func() bool {
+		return checksumQueries.Counts()["ks_new.RowsMismatch"] == before+1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Not eligible: other keyspace, not a select, or not in checksum mode.
+	qc.maybeChecksum(session, sqlparser.StmtSelect, "ks2", "select id from t", nil, result, nil)
+	qc.maybeChecksum(session, sqlparser.StmtUpdate, "ks_new", "update t set id = 1", nil, result, nil)
+	qc.maybeChecksum(NewSafeSession(&vtgatepb.Session{TargetString: "@replica"}), sqlparser.StmtSelect, "ks_new", "select id from t", nil, result, nil)
+	select {
+	case got := <-done:
+		t.Errorf("unexpected checksummed query: %v", got)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// The result is compared as it was returned, even if the caller modifies
+	// it while the query is checksummed.
+	release := make(chan struct{})
+	qc.exec = func(ctx context.Context, session *SafeSession, sql string, bindVars map[string]*querypb.BindVariable) (*sqltypes.Result, error) {
+		<-release
+		return sqltypes.MakeTestResult(sqltypes.MakeTestFields("id", "int64"), "1"), nil
+	}
+	beforeMatch := checksumQueries.Counts()["ks_new.Match"]
+	returned := sqltypes.MakeTestResult(sqltypes.MakeTestFields("id", "int64"), "1")
+	qc.maybeChecksum(session, sqlparser.StmtSelect, "ks_new", "select id from ks_new.t", nil, returned, nil)
+	returned.Rows = nil
+	close(release)
+	assert.Eventually(t, func() bool {
+		return checksumQueries.Counts()["ks_new.Match"] == beforeMatch+1
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"flag"
+	"math/rand"
+	"time"
+
+	"vitess.io/vitess/go/stats"
+
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+// This file implements the recycling of the long-lived MySQL protocol
+// connections. Clients with a connection pool keep their connections to
+// the same vtgates forever, so the load isn't rebalanced when vtgates are
+// added. Closing the connections after some age, or number of queries,
+// once their current query completed, makes the clients reconnect through
+// the load balancer.
+
+var (
+	mysqlMaxConnectionAge       = flag.Duration("mysql_server_max_connection_age", 0, "If set, the MySQL protocol connections older than this are closed once their current query completes, so the clients reconnect and get rebalanced across the vtgates. Connections in a transaction, or with a reserved connection or a lock, are closed once they are released.")
+	mysqlMaxConnectionAgeJitter = flag.Duration("mysql_server_max_connection_age_jitter", 0, "If set, a random duration up to this is added to -mysql_server_max_connection_age for each connection, so the connections opened at the same time don't all reconnect at once.")
+	mysqlMaxQueriesPerConn      = flag.Int("mysql_server_max_queries_per_connection", 0, "If set, the MySQL protocol connections are closed once they executed this many queries, with the same rules as -mysql_server_max_connection_age.")
+
+	connectionsRecycled = stats.NewCountersWithSingleLabel(
+		"MysqlServerConnectionsRecycled",
+		"Number of MySQL protocol connections closed after their query to rebalance them, by reason",
+		"Reason")
+)
+
+const (
+	recycleMaxAge     = "MaxAge"
+	recycleMaxQueries = "MaxQueries"
+)
+
+// connectionMaxAge returns the age after which a new connection is
+// recycled, or 0 if it isn't.
+func connectionMaxAge() time.Duration {
+	if *mysqlMaxConnectionAge <= 0 {
+		return 0
+	}
+	maxAge := *mysqlMaxConnectionAge
+	if *mysqlMaxConnectionAgeJitter > 0 {
+		maxAge += time.Duration(rand.Int63n(int64(*mysqlMaxConnectionAgeJitter)))
+	}
+	return maxAge
+}
+
+// recycleReason returns why the connection must be closed after its last
+// query, or "" if it mustn't. A connection whose session state lives on
+// the tablets is never recycled, as the client would lose it.
+func (activity *connActivity) recycleReason(now time.Time, session *vtgatepb.Session, maxQueries int) string {
+	if session.InTransaction || session.InReservedConn || session.LockSession != nil {
+		return ""
+	}
+	if activity.maxAge > 0 && now.Sub(activity.connected) >= activity.maxAge {
+		return recycleMaxAge
+	}
+	if maxQueries > 0 && activity.queries >= maxQueries {
+		return recycleMaxQueries
+	}
+	return ""
+}
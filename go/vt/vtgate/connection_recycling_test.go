@@ -0,0 +1,65 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+func TestConnectionRecycleReason(t *testing.T) {
+	now := time.Now()
+	activity := &connActivity{connected: now.Add(-time.Hour), maxAge: 30 * time.Minute, queries: 10}
+	idle := &vtgatepb.Session{}
+
+	assert.Equal(t, recycleMaxAge, activity.recycleReason(now, idle, 0))
+	// The connections with session state on the tablets are kept.
+	assert.Empty(t, activity.recycleReason(now, &vtgatepb.Session{InTransaction: true}, 0))
+	assert.Empty(t, activity.recycleReason(now, &vtgatepb.Session{InReservedConn: true}, 0))
+	assert.Empty(t, activity.recycleReason(now, &vtgatepb.Session{LockSession: &vtgatepb.Session_ShardSession{}}, 0))
+
+	activity.maxAge = 2 * time.Hour
+	assert.Empty(t, activity.recycleReason(now, idle, 0))
+	assert.Empty(t, activity.recycleReason(now, idle, 11))
+	assert.Equal(t, recycleMaxQueries, activity.recycleReason(now, idle, 10))
+
+	// Without a max age, the connections are kept forever.
+	activity.maxAge = 0
+	assert.Empty(t, activity.recycleReason(now.Add(24*time.Hour), idle, 0))
+}
+
+func TestConnectionMaxAge(t *testing.T) {
+	defer func(maxAge, jitter time.Duration) {
+		*mysqlMaxConnectionAge = maxAge
+		*mysqlMaxConnectionAgeJitter = jitter
+	}(*mysqlMaxConnectionAge, *mysqlMaxConnectionAgeJitter)
+
+	*mysqlMaxConnectionAge = 0
+	*mysqlMaxConnectionAgeJitter = time.Minute
+	assert.Zero(t, connectionMaxAge())
+
+	*mysqlMaxConnectionAge = time.Hour
+	for i := 0; i < 10; i++ {
+		maxAge := connectionMaxAge()
+		assert.GreaterOrEqual(t, int64(maxAge), int64(time.Hour))
+		assert.Less(t, int64(maxAge), int64(time.Hour+time.Minute))
+	}
+}
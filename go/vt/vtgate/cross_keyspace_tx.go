@@ -0,0 +1,220 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"vitess.io/vitess/go/flagutil"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/engine"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+var (
+	// noCrossKeyspaceWrites lists the keyspaces which can't be written
+	// in a transaction which spans other keyspaces.
+	noCrossKeyspaceWrites flagutil.StringListValue
+
+	partialCommits = stats.NewCountersWithSingleLabel(
+		"PartialCommits",
+		"Number of MULTI transactions which were committed on some shards only, by keyspace of the failed shard",
+		"Keyspace")
+)
+
+func init() {
+	flag.Var(&noCrossKeyspaceWrites, "no_cross_keyspace_writes", "Comma separated list of keyspaces which can't be written in a transaction which spans other keyspaces, and conversely. Such writes are rejected before they are executed, instead of risking a partial commit in MULTI transaction mode. The keyspaces of the lookup vindexes owned by the written tables count, and reading other keyspaces before or after writing in the same transaction is rejected too.")
+}
+
+// checkCrossKeyspaceWrite returns an error if the plan reads or writes a
+// keyspace while its transaction spans another keyspace, and one of them
+// disallows it. The keyspaces of a plan include the ones of the lookup
+// tables of the vindexes owned by its DMLs, so a write which needs a
+// transaction of its own is checked even out of a transaction.
+func checkCrossKeyspaceWrite(plan *engine.Plan, session *SafeSession, vschema *vindexes.VSchema) error {
+	if len(noCrossKeyspaceWrites) == 0 || plan.Instructions == nil {
+		return nil
+	}
+	write := false
+	switch plan.Type {
+	case sqlparser.StmtInsert, sqlparser.StmtReplace, sqlparser.StmtUpdate, sqlparser.StmtDelete:
+		write = true
+	case sqlparser.StmtSelect:
+	default:
+		return nil
+	}
+	if !session.InTransaction() && !(write && plan.Instructions.NeedsTransaction()) {
+		return nil
+	}
+	keyspaces := planKeyspaces(plan.Instructions, vschema)
+	others := append(session.ShardSessionKeyspaces(), keyspaces...)
+	for _, keyspace := range keyspaces {
+		for _, other := range others {
+			if other == keyspace {
+				continue
+			}
+			if !isNoCrossKeyspaceWrites(keyspace) && !isNoCrossKeyspaceWrites(other) {
+				continue
+			}
+			if write {
+				return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "cross-keyspace write not allowed in a transaction: cannot write to keyspace %s in a transaction on keyspace %s (see -no_cross_keyspace_writes)", keyspace, other)
+			}
+			return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "cross-keyspace write not allowed in a transaction: cannot read keyspace %s in a transaction on keyspace %s (see -no_cross_keyspace_writes)", keyspace, other)
+		}
+	}
+	return nil
+}
+
+// planKeyspaces returns the keyspaces the primitive and its inputs send
+// queries to, including the ones of the lookup tables of the vindexes
+// owned by its DMLs, except the autocommit ones.
+func planKeyspaces(primitive engine.Primitive, vschema *vindexes.VSchema) []string {
+	var keyspaces []string
+	add := func(keyspace string) {
+		if keyspace == "" {
+			return
+		}
+		for _, ks := range keyspaces {
+			if ks == keyspace {
+				return
+			}
+		}
+		keyspaces = append(keyspaces, keyspace)
+	}
+	var visit func(p engine.Primitive)
+	visit = func(p engine.Primitive) {
+		inputs := p.Inputs()
+		if len(inputs) == 0 {
+			// The primitives with inputs return a combination of the
+			// keyspaces of their inputs.
+			add(p.GetKeyspaceName())
+		}
+		var table *vindexes.Table
+		switch p := p.(type) {
+		case *engine.Insert:
+			table = p.Table
+		case *engine.Update:
+			table = p.Table
+		case *engine.Delete:
+			table = p.Table
+		}
+		if table != nil {
+			for _, owned := range table.Owned {
+				add(lookupTableKeyspace(owned.Vindex, table.Keyspace, vschema))
+			}
+		}
+		for _, input := range inputs {
+			visit(input)
+		}
+	}
+	visit(primitive)
+	return keyspaces
+}
+
+// lookupTableKeyspace returns the keyspace of the lookup table of the
+// vindex, or "" if it has none, is written in autocommit, or is unknown.
+// An unqualified lookup table is resolved like the queries of the vindex,
+// or is in the keyspace of its owner if the vschema doesn't know it.
+func lookupTableKeyspace(vindex vindexes.Vindex, owner *vindexes.Keyspace, vschema *vindexes.VSchema) string {
+	lookup, ok := vindex.(vindexes.LookupTableVindex)
+	if !ok {
+		return ""
+	}
+	name, autocommit := lookup.LookupTable()
+	if autocommit {
+		return ""
+	}
+	keyspace, table, err := sqlparser.ParseTable(name)
+	if err != nil {
+		return ""
+	}
+	if keyspace != "" {
+		return keyspace
+	}
+	if vschema != nil {
+		if t, err := vschema.FindTable("", table); err == nil && t.Keyspace != nil {
+			return t.Keyspace.Name
+		}
+	}
+	if owner == nil {
+		return ""
+	}
+	return owner.Name
+}
+
+func isNoCrossKeyspaceWrites(keyspace string) bool {
+	for _, ks := range noCrossKeyspaceWrites {
+		if ks == keyspace {
+			return true
+		}
+	}
+	return false
+}
+
+// PartialCommitError is returned when a MULTI transaction failed to commit
+// after some of its shards were already committed. The shards which were
+// not committed yet are rolled back.
+type PartialCommitError struct {
+	// Committed lists the keyspace/shard of the committed shards.
+	Committed []string
+	// RolledBack lists the keyspace/shard of the rolled back shards,
+	// including Failed.
+	RolledBack []string
+	// Failed is the keyspace/shard whose commit failed.
+	Failed string
+	// Err is the commit error of Failed.
+	Err error
+}
+
+// Error is part of the error interface.
+func (e *PartialCommitError) Error() string {
+	return fmt.Sprintf("transaction partially committed: commit failed on %s: %v; committed shards: [%s]; rolled back shards: [%s]",
+		e.Failed, e.Err, strings.Join(e.Committed, ", "), strings.Join(e.RolledBack, ", "))
+}
+
+// Cause returns the commit error, so that vterrors.Code returns its code.
+func (e *PartialCommitError) Cause() error {
+	return e.Err
+}
+
+func shardSessionName(s *vtgatepb.Session_ShardSession) string {
+	return s.Target.Keyspace + "/" + s.Target.Shard
+}
+
+// newPartialCommitError builds the error for a commit which failed on the
+// shard session failed, after the committed ones. pending are the shard
+// sessions which are still open, and will be rolled back.
+func newPartialCommitError(err error, failed *vtgatepb.Session_ShardSession, committed, pending []*vtgatepb.Session_ShardSession) *PartialCommitError {
+	pce := &PartialCommitError{Failed: shardSessionName(failed), Err: err}
+	for _, s := range committed {
+		pce.Committed = append(pce.Committed, shardSessionName(s))
+	}
+	for _, s := range pending {
+		if s.TransactionId != 0 {
+			pce.RolledBack = append(pce.RolledBack, shardSessionName(s))
+		}
+	}
+	partialCommits.Add(failed.Target.Keyspace, 1)
+	return pce
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtgate/engine"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+func TestCheckCrossKeyspaceWrite(t *testing.T) {
+	defer func(saved []string) { noCrossKeyspaceWrites = saved }(noCrossKeyspaceWrites)
+
+	update := func(keyspace string) *engine.Plan {
+		return &engine.Plan{
+			Type: sqlparser.StmtUpdate,
+			Instructions: &engine.Update{DML: engine.DML{
+				Keyspace: &vindexes.Keyspace{Name: keyspace},
+			}},
+		}
+	}
+	session := NewSafeSession(&vtgatepb.Session{
+		InTransaction: true,
+		ShardSessions: []*vtgatepb.Session_ShardSession{{
+			Target:        &querypb.Target{Keyspace: "ks1", Shard: "0", TabletType: topodatapb.TabletType_MASTER},
+			TransactionId: 1,
+		}},
+	})
+
+	// Disabled.
+	noCrossKeyspaceWrites = nil
+	require.NoError(t, checkCrossKeyspaceWrite(update("ks2"), session, nil))
+
+	noCrossKeyspaceWrites = []string{"ks1"}
+	require.NoError(t, checkCrossKeyspaceWrite(update("ks1"), session, nil))
+	require.EqualError(t, checkCrossKeyspaceWrite(update("ks2"), session, nil),
+		"cross-keyspace write not allowed in a transaction: cannot write to keyspace ks2 in a transaction on keyspace ks1 (see -no_cross_keyspace_writes)")
+
+	// The restriction applies both ways.
+	noCrossKeyspaceWrites = []string{"ks2"}
+	require.Error(t, checkCrossKeyspaceWrite(update("ks2"), session, nil))
+
+	// Unrestricted keyspaces.
+	noCrossKeyspaceWrites = []string{"ks3"}
+	require.NoError(t, checkCrossKeyspaceWrite(update("ks2"), session, nil))
+
+	// Reads after a write are checked too.
+	noCrossKeyspaceWrites = []string{"ks1"}
+	read := &engine.Plan{
+		Type: sqlparser.StmtSelect,
+		Instructions: &engine.Route{
+			Keyspace: &vindexes.Keyspace{Name: "ks2"},
+		},
+	}
+	require.EqualError(t, checkCrossKeyspaceWrite(read, session, nil),
+		"cross-keyspace write not allowed in a transaction: cannot read keyspace ks2 in a transaction on keyspace ks1 (see -no_cross_keyspace_writes)")
+
+	// Not in a transaction.
+	noTx := NewSafeSession(&vtgatepb.Session{})
+	assert.NoError(t, checkCrossKeyspaceWrite(update("ks2"), noTx, nil))
+	assert.NoError(t, checkCrossKeyspaceWrite(read, noTx, nil))
+}
+
+func TestCheckCrossKeyspaceWriteOwnedVindexes(t *testing.T) {
+	defer func(saved []string) { noCrossKeyspaceWrites = saved }(noCrossKeyspaceWrites)
+
+	vschema := vindexes.BuildVSchema(&vschemapb.SrvVSchema{
+		Keyspaces: map[string]*vschemapb.Keyspace{
+			"ks2": {
+				Tables: map[string]*vschemapb.Table{
+					"unqualified_lkp": {},
+				},
+			},
+		},
+	})
+	insert := func(lookupTable string, autocommit bool) *engine.Plan {
+		params := map[string]string{
+			"table": lookupTable,
+			"from":  "id",
+			"to":    "keyspace_id",
+		}
+		if autocommit {
+			params["autocommit"] = "true"
+		}
+		lookup, err := vindexes.CreateVindex("lookup_hash", "lkp", params)
+		require.NoError(t, err)
+		return &engine.Plan{
+			Type: sqlparser.StmtInsert,
+			Instructions: &engine.Insert{
+				Keyspace: &vindexes.Keyspace{Name: "ks1"},
+				Table: &vindexes.Table{
+					Keyspace: &vindexes.Keyspace{Name: "ks1"},
+					Owned:    []*vindexes.ColumnVindex{{Vindex: lookup}},
+				},
+			},
+		}
+	}
+	noTx := NewSafeSession(&vtgatepb.Session{})
+
+	noCrossKeyspaceWrites = []string{"ks1"}
+	// The write of the lookup table makes its transaction span its keyspace,
+	// even if it isn't in a transaction.
+	require.EqualError(t, checkCrossKeyspaceWrite(insert("ks2.lkp", false), noTx, vschema),
+		"cross-keyspace write not allowed in a transaction: cannot write to keyspace ks1 in a transaction on keyspace ks2 (see -no_cross_keyspace_writes)")
+	require.Error(t, checkCrossKeyspaceWrite(insert("unqualified_lkp", false), noTx, vschema))
+	// An autocommit lookup vindex is written in its own transaction.
+	require.NoError(t, checkCrossKeyspaceWrite(insert("ks2.lkp", true), noTx, vschema))
+	// A lookup table in the same keyspace.
+	require.NoError(t, checkCrossKeyspaceWrite(insert("ks1.lkp", false), noTx, vschema))
+	// Without a vschema, an unqualified lookup table is in the keyspace of
+	// its owner.
+	require.NoError(t, checkCrossKeyspaceWrite(insert("unqualified_lkp", false), noTx, nil))
+}
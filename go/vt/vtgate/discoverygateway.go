@@ -69,6 +69,14 @@ type DiscoveryGateway struct {
 	localCell     string
 	retryCount    int
 
+	// ctx, topoServer and recorder are used to create the watchers of the
+	// cells to watch.
+	ctx        context.Context
+	topoServer *topo.Server
+	recorder   discovery.LegacyTabletRecorder
+
+	// twMu protects tabletsWatchers.
+	twMu sync.Mutex
 	// tabletsWatchers contains a list of all the watchers we use.
 	// We create one per cell.
 	tabletsWatchers []*discovery.LegacyTopologyWatcher
@@ -83,9 +91,9 @@ type DiscoveryGateway struct {
 	buffer *buffer.Buffer
 }
 
-//TabletsCacheStatus is not implemented for this struct
+// TabletsCacheStatus returns a displayable version of the tablets cache.
 func (dg *DiscoveryGateway) TabletsCacheStatus() discovery.TabletsCacheStatusList {
-	return nil
+	return dg.tsc.CacheStatus()
 }
 
 var _ Gateway = (*DiscoveryGateway)(nil)
@@ -114,6 +122,9 @@ func NewDiscoveryGateway(ctx context.Context, hc discovery.LegacyHealthCheck, se
 		srvTopoServer:     serv,
 		localCell:         cell,
 		retryCount:        retryCount,
+		ctx:               ctx,
+		topoServer:        topoServer,
+		recorder:          hc,
 		tabletsWatchers:   make([]*discovery.LegacyTopologyWatcher, 0, 1),
 		statusAggregators: make(map[string]*TabletStatusAggregator),
 		buffer:            buffer.New(),
@@ -123,32 +134,87 @@ func NewDiscoveryGateway(ctx context.Context, hc discovery.LegacyHealthCheck, se
 	// We set sendDownEvents=true because it's required by LegacyTabletStatsCache.
 	hc.SetListener(dg, true /* sendDownEvents */)
 
+	if len(discovery.TabletFilters) > 0 {
+		if discovery.FilteringKeyspaces() {
+			log.Exitf("Only one of -keyspaces_to_watch and -tablet_filters may be specified at a time")
+		}
+
+		fbs, err := discovery.NewLegacyFilterByShard(dg.recorder, discovery.TabletFilters)
+		if err != nil {
+			log.Exitf("Cannot parse tablet_filters parameter: %v", err)
+		}
+		dg.recorder = fbs
+	} else if discovery.FilteringKeyspaces() {
+		dg.recorder = discovery.NewLegacyFilterByKeyspace(dg.recorder, discovery.KeyspacesToWatch)
+	}
+
 	cells := *CellsToWatch
 	log.Infof("loading tablets for cells: %v", cells)
 	for _, c := range strings.Split(cells, ",") {
 		if c == "" {
 			continue
 		}
-		var recorder discovery.LegacyTabletRecorder = dg.hc
-		if len(discovery.TabletFilters) > 0 {
-			if discovery.FilteringKeyspaces() {
-				log.Exitf("Only one of -keyspaces_to_watch and -tablet_filters may be specified at a time")
-			}
+		dg.tabletsWatchers = append(dg.tabletsWatchers, dg.newCellTabletsWatcher(c))
+	}
+	dg.QueryService = queryservice.Wrap(nil, dg.withRetry)
+	return dg
+}
 
-			fbs, err := discovery.NewLegacyFilterByShard(recorder, discovery.TabletFilters)
-			if err != nil {
-				log.Exitf("Cannot parse tablet_filters parameter: %v", err)
-			}
-			recorder = fbs
-		} else if discovery.FilteringKeyspaces() {
-			recorder = discovery.NewLegacyFilterByKeyspace(recorder, discovery.KeyspacesToWatch)
+// newCellTabletsWatcher returns a watcher of the tablets of the cell.
+func (dg *DiscoveryGateway) newCellTabletsWatcher(cell string) *discovery.LegacyTopologyWatcher {
+	return discovery.NewLegacyCellTabletsWatcher(dg.ctx, dg.topoServer, dg.recorder, cell, *discovery.RefreshInterval, *discovery.RefreshKnownTablets, *discovery.TopoReadConcurrency)
+}
+
+// CellsToWatch returns the cells whose tablets are watched.
+func (dg *DiscoveryGateway) CellsToWatch() ([]string, error) {
+	dg.twMu.Lock()
+	defer dg.twMu.Unlock()
+	cells := make([]string, 0, len(dg.tabletsWatchers))
+	for _, tw := range dg.tabletsWatchers {
+		cells = append(cells, tw.Cell())
+	}
+	sort.Strings(cells)
+	return cells, nil
+}
+
+// SetCellsToWatch changes the cells whose tablets are watched, without a
+// restart. cellsToWatch has the format of -cells_to_watch. The watchers of
+// the removed cells are stopped and their tablets removed from the
+// healthcheck, and watchers are started for the new cells.
+func (dg *DiscoveryGateway) SetCellsToWatch(cellsToWatch string) error {
+	wanted := make(map[string]bool)
+	for _, c := range strings.Split(cellsToWatch, ",") {
+		if c = strings.TrimSpace(c); c != "" {
+			wanted[c] = true
+		}
+	}
+	if len(wanted) == 0 {
+		return fmt.Errorf("at least one cell must be watched")
+	}
+
+	dg.twMu.Lock()
+	var kept, removed []*discovery.LegacyTopologyWatcher
+	for _, tw := range dg.tabletsWatchers {
+		if wanted[tw.Cell()] {
+			kept = append(kept, tw)
+			delete(wanted, tw.Cell())
+		} else {
+			removed = append(removed, tw)
 		}
+	}
+	for c := range wanted {
+		log.Infof("loading tablets for cell: %v", c)
+		kept = append(kept, dg.newCellTabletsWatcher(c))
+	}
+	dg.tabletsWatchers = kept
+	dg.twMu.Unlock()
 
-		ctw := discovery.NewLegacyCellTabletsWatcher(ctx, topoServer, recorder, c, *discovery.RefreshInterval, *discovery.RefreshKnownTablets, *discovery.TopoReadConcurrency)
-		dg.tabletsWatchers = append(dg.tabletsWatchers, ctw)
+	for _, tw := range removed {
+		log.Infof("removing the tablets of cell: %v", tw.Cell())
+		tw.Stop()
+		tw.RemoveAllTablets()
 	}
-	dg.QueryService = queryservice.Wrap(nil, dg.withRetry)
-	return dg
+	return nil
 }
 
 // RegisterStats registers the stats to export the lag since the last refresh
@@ -170,6 +236,8 @@ func (dg *DiscoveryGateway) RegisterStats() {
 // topologyWatcherMaxRefreshLag returns the maximum lag since the watched
 // cells were refreshed from the topo server
 func (dg *DiscoveryGateway) topologyWatcherMaxRefreshLag() time.Duration {
+	dg.twMu.Lock()
+	defer dg.twMu.Unlock()
 	var lag time.Duration
 	for _, tw := range dg.tabletsWatchers {
 		cellLag := tw.RefreshLag()
@@ -182,6 +250,8 @@ func (dg *DiscoveryGateway) topologyWatcherMaxRefreshLag() time.Duration {
 
 // topologyWatcherChecksum returns a checksum of the topology watcher state
 func (dg *DiscoveryGateway) topologyWatcherChecksum() int64 {
+	dg.twMu.Lock()
+	defer dg.twMu.Unlock()
 	var checksum int64
 	for _, tw := range dg.tabletsWatchers {
 		checksum = checksum ^ int64(tw.TopoChecksum())
@@ -220,12 +290,19 @@ func (dg *DiscoveryGateway) WaitForTablets(ctx context.Context, tabletTypesToWai
 // This function hides the inner implementation.
 func (dg *DiscoveryGateway) Close(ctx context.Context) error {
 	dg.buffer.Shutdown()
+	dg.twMu.Lock()
+	defer dg.twMu.Unlock()
 	for _, ctw := range dg.tabletsWatchers {
 		ctw.Stop()
 	}
 	return nil
 }
 
+// BufferStatus returns a snapshot of the state of the MASTER failover buffer.
+func (dg *DiscoveryGateway) BufferStatus() *buffer.Status {
+	return dg.buffer.Status()
+}
+
 // CacheStatus returns a list of TabletCacheStatus per
 // keyspace/shard/tablet_type.
 func (dg *DiscoveryGateway) CacheStatus() TabletCacheStatusList {
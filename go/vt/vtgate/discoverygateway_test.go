@@ -21,6 +21,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
 	"vitess.io/vitess/go/vt/log"
 
 	"context"
@@ -214,6 +217,44 @@ func TestDiscoveryGatewayWaitForTablets(t *testing.T) {
 	discovery.KeyspacesToWatch = []string{}
 }
 
+func TestDiscoveryGatewaySetCellsToWatch(t *testing.T) {
+	ctx := context.Background()
+	hc := discovery.NewFakeLegacyHealthCheck()
+	ts := memorytopo.NewServer("cell1", "cell2")
+	srvTopo := srvtopotest.NewPassthroughSrvTopoServer()
+	srvTopo.TopoServer = ts
+	dg := NewDiscoveryGateway(ctx, hc, srvTopo, "cell1", 2)
+	defer dg.Close(ctx)
+
+	tablet := &topodatapb.Tablet{
+		Alias:    &topodatapb.TabletAlias{Cell: "cell2", Uid: 1},
+		Hostname: "host1",
+		PortMap:  map[string]int32{"vt": 1, "grpc": 2},
+		Keyspace: "ks",
+		Shard:    "0",
+		Type:     topodatapb.TabletType_REPLICA,
+	}
+	require.NoError(t, ts.CreateTablet(ctx, tablet))
+
+	// Turn up cell2.
+	require.NoError(t, dg.SetCellsToWatch("cell1,cell2"))
+	cells, err := dg.CellsToWatch()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cell1", "cell2"}, cells)
+	assert.Eventually(t, func() bool {
+		return len(hc.GetAllTablets()) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Turn it down: its tablets are removed.
+	require.NoError(t, dg.SetCellsToWatch("cell1"))
+	cells, err = dg.CellsToWatch()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cell1"}, cells)
+	assert.Empty(t, hc.GetAllTablets())
+
+	assert.Error(t, dg.SetCellsToWatch(" , "))
+}
+
 func TestShuffleTablets(t *testing.T) {
 	ts1 := discovery.LegacyTabletStats{
 		Key:     "t1",
@@ -56,6 +56,10 @@ func TestDistinct(t *testing.T) {
 		testName:       "float64 columns designed to produce the same hashcode but not be equal",
 		inputs:         r("a|b", "float64|float64", "0.1|0.2", "0.1|0.3", "0.1|0.4", "0.1|0.5"),
 		expectedResult: r("a|b", "float64|float64", "0.1|0.2", "0.1|0.3", "0.1|0.4", "0.1|0.5"),
+	}, {
+		testName:       "geometry columns",
+		inputs:         r("a|g", "int64|geometry", "1|point1", "1|point2", "1|point1", "2|point1", "null|null", "null|null"),
+		expectedResult: r("a|g", "int64|geometry", "1|point1", "1|point2", "2|point1", "null|null"),
 	}, {
 		testName:      "varchar columns",
 		inputs:        r("myid", "varchar", "monkey", "horse"),
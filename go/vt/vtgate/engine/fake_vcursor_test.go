@@ -102,6 +102,18 @@ func (t *noopVCursor) SetSessionTrackGTIDs(b bool) {
 	panic("implement me")
 }
 
+func (t *noopVCursor) GetGroupConcatMaxLen() uint64 {
+	return 1024
+}
+
+func (t *noopVCursor) SetReadYourWrites(b bool) {
+	panic("implement me")
+}
+
+func (t *noopVCursor) SetChecksumKeyspaces(s string) error {
+	panic("implement me")
+}
+
 func (t *noopVCursor) HasCreatedTempTable() {
 	panic("implement me")
 }
@@ -204,9 +216,17 @@ func (t *noopVCursor) GetKeyspace() string {
 }
 
 func (t *noopVCursor) SetContextTimeout(timeout time.Duration) context.CancelFunc {
+	origCtx := t.ctx
 	ctx, cancel := context.WithTimeout(t.Context(), timeout)
 	t.ctx = ctx
-	return cancel
+	return func() {
+		cancel()
+		t.ctx = origCtx
+	}
+}
+
+func (t *noopVCursor) DefaultQueryTimeout(keyspace, table string) time.Duration {
+	return 0
 }
 
 func (t *noopVCursor) ErrorGroupCancellableContext() (*errgroup.Group, func()) {
@@ -234,7 +254,7 @@ func (t *noopVCursor) ExecuteStandalone(query string, bindvars map[string]*query
 	panic("unimplemented")
 }
 
-func (t *noopVCursor) StreamExecuteMulti(query string, rss []*srvtopo.ResolvedShard, bindVars []map[string]*querypb.BindVariable, callback func(reply *sqltypes.Result) error) []error {
+func (t *noopVCursor) StreamExecuteMulti(ctx context.Context, query string, rss []*srvtopo.ResolvedShard, bindVars []map[string]*querypb.BindVariable, callback func(reply *sqltypes.Result) error) []error {
 	panic("unimplemented")
 }
 
@@ -285,6 +305,10 @@ type loggingVCursor struct {
 	tableRoutes tableRoutes
 	dbDDLPlugin string
 	ksAvailable bool
+
+	// defaultQueryTimeouts are the timeouts of the query timeout policy,
+	// by keyspace.table.
+	defaultQueryTimeouts map[string]time.Duration
 }
 
 type tableRoutes struct {
@@ -348,9 +372,17 @@ func (f *loggingVCursor) Context() context.Context {
 }
 
 func (f *loggingVCursor) SetContextTimeout(timeout time.Duration) context.CancelFunc {
+	origCtx := f.ctx
 	ctx, cancel := context.WithTimeout(f.Context(), timeout)
 	f.ctx = ctx
-	return cancel
+	return func() {
+		cancel()
+		f.ctx = origCtx
+	}
+}
+
+func (f *loggingVCursor) DefaultQueryTimeout(keyspace, table string) time.Duration {
+	return f.defaultQueryTimeouts[keyspace+"."+table]
 }
 
 func (f *loggingVCursor) ErrorGroupCancellableContext() (*errgroup.Group, func()) {
@@ -405,7 +437,7 @@ func (f *loggingVCursor) ExecuteStandalone(query string, bindvars map[string]*qu
 	return f.nextResult()
 }
 
-func (f *loggingVCursor) StreamExecuteMulti(query string, rss []*srvtopo.ResolvedShard, bindVars []map[string]*querypb.BindVariable, callback func(reply *sqltypes.Result) error) []error {
+func (f *loggingVCursor) StreamExecuteMulti(ctx context.Context, query string, rss []*srvtopo.ResolvedShard, bindVars []map[string]*querypb.BindVariable, callback func(reply *sqltypes.Result) error) []error {
 	f.mu.Lock()
 	f.log = append(f.log, fmt.Sprintf("StreamExecuteMulti %s %s", query, printResolvedShardsBindVars(rss, bindVars)))
 	r, err := f.nextResult()
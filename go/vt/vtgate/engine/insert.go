@@ -397,18 +397,25 @@ func (ins *Insert) getInsertShardedRoute(vcursor VCursor, bindVars map[string]*q
 	if len(vindexRowsValues) == 0 || len(ins.Table.ColumnVindexes) == 0 {
 		return nil, nil, vterrors.NewErrorf(vtrpcpb.Code_FAILED_PRECONDITION, vterrors.RequiresPrimaryKey, vterrors.PrimaryVindexNotSet, ins.Table.Name)
 	}
-	keyspaceIDs, err := ins.processPrimary(vcursor, vindexRowsValues[0], ins.Table.ColumnVindexes[0])
+	primaryKeys, err := jsonPathKeys(ins.Table.ColumnVindexes[0], vindexRowsValues[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	keyspaceIDs, err := ins.processPrimary(vcursor, primaryKeys, ins.Table.ColumnVindexes[0])
 	if err != nil {
 		return nil, nil, err
 	}
 
 	for vIdx := 1; vIdx < len(ins.Table.ColumnVindexes); vIdx++ {
 		colVindex := ins.Table.ColumnVindexes[vIdx]
-		var err error
+		keys, err := jsonPathKeys(colVindex, vindexRowsValues[vIdx])
+		if err != nil {
+			return nil, nil, err
+		}
 		if colVindex.Owned {
-			err = ins.processOwned(vcursor, vindexRowsValues[vIdx], colVindex, keyspaceIDs)
+			err = ins.processOwned(vcursor, keys, colVindex, keyspaceIDs)
 		} else {
-			err = ins.processUnowned(vcursor, vindexRowsValues[vIdx], colVindex, keyspaceIDs)
+			err = ins.processUnowned(vcursor, keys, colVindex, keyspaceIDs)
 		}
 		if err != nil {
 			return nil, nil, err
@@ -474,6 +481,25 @@ func (ins *Insert) getInsertShardedRoute(vcursor VCursor, bindVars map[string]*q
 	return rss, queries, nil
 }
 
+// jsonPathKeys returns the ids of a vindex over a JSON path, which are
+// the values at the path of the documents of its column. The documents
+// themselves stay in vindexColumnsKeys, as they are bound to the query.
+func jsonPathKeys(colVindex *vindexes.ColumnVindex, vindexColumnsKeys [][]sqltypes.Value) ([][]sqltypes.Value, error) {
+	jp, ok := colVindex.Vindex.(vindexes.JSONPathVindex)
+	if !ok {
+		return vindexColumnsKeys, nil
+	}
+	keys := make([][]sqltypes.Value, len(vindexColumnsKeys))
+	for rowNum, rowColumnKeys := range vindexColumnsKeys {
+		id, err := jp.Extract(rowColumnKeys[0])
+		if err != nil {
+			return nil, fmt.Errorf("cannot extract %v from column %v: %v", jp.JSONPath(), colVindex.Columns[0], err)
+		}
+		keys[rowNum] = []sqltypes.Value{id}
+	}
+	return keys, nil
+}
+
 // processPrimary maps the primary vindex values to the keyspace ids.
 func (ins *Insert) processPrimary(vcursor VCursor, vindexColumnsKeys [][]sqltypes.Value, colVindex *vindexes.ColumnVindex) ([][]byte, error) {
 	destinations, err := vindexes.Map(colVindex.Vindex, vcursor, vindexColumnsKeys)
@@ -313,6 +313,67 @@ func TestInsertShardedSimple(t *testing.T) {
 	})
 }
 
+func TestInsertShardedJSONPath(t *testing.T) {
+	invschema := &vschemapb.SrvVSchema{
+		Keyspaces: map[string]*vschemapb.Keyspace{
+			"sharded": {
+				Sharded: true,
+				Vindexes: map[string]*vschemapb.Vindex{
+					"user_id": {
+						Type:   "json_path",
+						Params: map[string]string{"json_path": "$.user_id", "vindex": "hash"},
+					},
+				},
+				Tables: map[string]*vschemapb.Table{
+					"t1": {
+						ColumnVindexes: []*vschemapb.ColumnVindex{{
+							Name:    "user_id",
+							Columns: []string{"doc"},
+						}},
+					},
+				},
+			},
+		},
+	}
+	vs := vindexes.BuildVSchema(invschema)
+	ks := vs.Keyspaces["sharded"]
+
+	// The rows are routed by the value at the path of their document,
+	// as if it was the value of the column.
+	ins := NewInsert(
+		InsertSharded,
+		ks.Keyspace,
+		[]sqltypes.PlanValue{{
+			// colVindex columns: doc
+			Values: []sqltypes.PlanValue{{
+				// 2 rows.
+				Values: []sqltypes.PlanValue{{
+					Value: sqltypes.NewVarChar(`{"user_id": 1}`),
+				}, {
+					Value: sqltypes.NewVarChar(`{"user_id": 2, "name": "b"}`),
+				}},
+			}},
+		}},
+		ks.Tables["t1"],
+		"prefix",
+		[]string{" mid1", " mid2"},
+		" suffix",
+	)
+	vc := newDMLTestVCursor("-20", "20-")
+	vc.shardForKsid = []string{"20-", "-20"}
+
+	_, err := ins.Execute(vc, map[string]*querypb.BindVariable{}, false)
+	require.NoError(t, err)
+	require.NotEmpty(t, vc.log)
+	require.Equal(t, `ResolveDestinations sharded [value:"0" value:"1"] Destinations:DestinationKeyspaceID(166b40b44aba4bd6),DestinationKeyspaceID(06e7ea22ce92708f)`, vc.log[0])
+
+	// A document without the value at the path can't be routed.
+	ins.VindexValues[0].Values[0].Values[1].Value = sqltypes.NewVarChar(`{"name": "b"}`)
+	vc = newDMLTestVCursor("-20", "20-")
+	_, err = ins.Execute(vc, map[string]*querypb.BindVariable{}, false)
+	require.EqualError(t, err, "could not map [NULL] to a keyspace id")
+}
+
 func TestInsertShardedFail(t *testing.T) {
 	invschema := &vschemapb.SrvVSchema{
 		Keyspaces: map[string]*vschemapb.Keyspace{
@@ -77,12 +77,18 @@ func (ms *MergeSort) GetFields(vcursor VCursor, bindVars map[string]*querypb.Bin
 
 // StreamExecute performs a streaming exec.
 func (ms *MergeSort) StreamExecute(vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool, callback func(*sqltypes.Result) error) error {
+	// The streams run on a cancelable context, so the shards stop
+	// scanning as soon as the merge ends, e.g. because the LIMIT of the
+	// query was reached, and not only when they send their next row. The
+	// context of the vcursor itself isn't changed, it may be used by
+	// other primitives meanwhile.
 	ctx, cancel := context.WithCancel(vcursor.Context())
 	defer cancel()
+	streamVCursor := &contextVCursor{VCursor: vcursor, ctx: ctx}
 	gotFields := wantfields
 	handles := make([]*streamHandle, len(ms.Primitives))
 	for i, input := range ms.Primitives {
-		handles[i] = runOneStream(ctx, vcursor, input, bindVars, gotFields)
+		handles[i] = runOneStream(ctx, streamVCursor, input, bindVars, gotFields)
 		if !ms.ScatterErrorsAsWarnings {
 			// we only need the fields from the first input, unless we allow ScatterErrorsAsWarnings.
 			// in that case, we need to ask all the inputs for fields - we don't know which will return anything
@@ -272,6 +278,17 @@ func runOneStream(ctx context.Context, vcursor VCursor, input StreamExecutor, bi
 	return handle
 }
 
+// contextVCursor is a VCursor running on its own context.
+type contextVCursor struct {
+	VCursor
+	ctx context.Context
+}
+
+// Context is part of the VCursor interface.
+func (vc *contextVCursor) Context() context.Context {
+	return vc.ctx
+}
+
 // A streamRow represents a row identified by the stream
 // it came from. It is used as an element in scatterHeap.
 type streamRow struct {
@@ -17,6 +17,7 @@ limitations under the License.
 package engine
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -325,6 +326,34 @@ func TestMergeSortDataFailures(t *testing.T) {
 	require.EqualError(t, err, want)
 }
 
+func TestMergeSortCancelsStreams(t *testing.T) {
+	idFields := sqltypes.MakeTestFields("id", "int32")
+	shardResults := []*shardResult{{
+		results: sqltypes.MakeTestStreamingResults(idFields, "1"),
+	}, {
+		results: sqltypes.MakeTestStreamingResults(idFields, "2"),
+	}}
+	prims := []StreamExecutor{shardResults[0], shardResults[1]}
+	ms := MergeSort{
+		Primitives: prims,
+		OrderBy: []OrderbyParams{{
+			WeightStringCol: -1,
+			Col:             0,
+		}},
+	}
+	vc := &noopVCursor{ctx: context.Background()}
+	err := ms.StreamExecute(vc, nil, true, func(qr *sqltypes.Result) error { return nil })
+	require.NoError(t, err)
+
+	// The streams ran on a context canceled at the end of the merge,
+	// while the context of the vcursor was left alone.
+	for _, sr := range shardResults {
+		require.NotNil(t, sr.ctx)
+		require.Error(t, sr.ctx.Err())
+	}
+	require.Equal(t, context.Background(), vc.Context())
+}
+
 func testMergeSort(shardResults []*shardResult, orderBy []OrderbyParams, callback func(qr *sqltypes.Result) error) error {
 	prims := make([]StreamExecutor, 0, len(shardResults))
 	for _, sr := range shardResults {
@@ -343,9 +372,13 @@ type shardResult struct {
 
 	results []*sqltypes.Result
 	sendErr error
+
+	// ctx is the context the results were streamed on.
+	ctx context.Context
 }
 
 func (sr *shardResult) StreamExecute(vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool, callback func(*sqltypes.Result) error) error {
+	sr.ctx = vcursor.Context()
 	for _, r := range sr.results {
 		if err := callback(r); err != nil {
 			return err
@@ -19,6 +19,7 @@ package engine
 import (
 	"fmt"
 	"strconv"
+	"unicode/utf8"
 
 	"google.golang.org/protobuf/proto"
 
@@ -68,14 +69,20 @@ type AggregateParams struct {
 	Col    int
 	// Alias is set only for distinct opcodes.
 	Alias string `json:",omitempty"`
+	// Separator is the separator of the values of group_concat.
+	Separator string `json:",omitempty"`
 }
 
 func (ap AggregateParams) isDistinct() bool {
-	return ap.Opcode == AggregateCountDistinct || ap.Opcode == AggregateSumDistinct
+	return ap.Opcode == AggregateCountDistinct || ap.Opcode == AggregateSumDistinct || ap.Opcode == AggregateGroupConcatDistinct
 }
 
 func (ap AggregateParams) preProcess() bool {
-	return ap.Opcode == AggregateCountDistinct || ap.Opcode == AggregateSumDistinct || ap.Opcode == AggregateGtid
+	return ap.isDistinct() || ap.Opcode == AggregateGtid
+}
+
+func (ap AggregateParams) isGroupConcat() bool {
+	return ap.Opcode == AggregateGroupConcat || ap.Opcode == AggregateGroupConcatDistinct
 }
 
 func (ap AggregateParams) String() string {
@@ -98,6 +105,8 @@ const (
 	AggregateCountDistinct
 	AggregateSumDistinct
 	AggregateGtid
+	AggregateGroupConcat
+	AggregateGroupConcatDistinct
 )
 
 var (
@@ -105,6 +114,8 @@ var (
 		AggregateCountDistinct: sqltypes.Int64,
 		AggregateSumDistinct:   sqltypes.Decimal,
 		AggregateGtid:          sqltypes.VarChar,
+		// The distinct values are concatenated by vtgate.
+		AggregateGroupConcatDistinct: sqltypes.VarChar,
 	}
 	// Some predefined values
 	countZero = sqltypes.MakeTrusted(sqltypes.Int64, []byte("0"))
@@ -119,11 +130,15 @@ var SupportedAggregates = map[string]AggregateOpcode{
 	"sum":   AggregateSum,
 	"min":   AggregateMin,
 	"max":   AggregateMax,
+	// group_concat is not a FuncExpr, but its results are merged like
+	// the ones of the other aggregates.
+	"group_concat": AggregateGroupConcat,
 	// These functions don't exist in mysql, but are used
 	// to display the plan.
-	"count_distinct": AggregateCountDistinct,
-	"sum_distinct":   AggregateSumDistinct,
-	"vgtid":          AggregateGtid,
+	"count_distinct":        AggregateCountDistinct,
+	"sum_distinct":          AggregateSumDistinct,
+	"group_concat_distinct": AggregateGroupConcatDistinct,
+	"vgtid":                 AggregateGtid,
 }
 
 func (code AggregateOpcode) String() string {
@@ -182,9 +197,10 @@ func (oa *OrderedAggregate) execute(vcursor VCursor, bindVars map[string]*queryp
 	// This code is similar to the one in StreamExecute.
 	var current []sqltypes.Value
 	var curDistinct sqltypes.Value
+	maxLen := oa.groupConcatMaxLen(vcursor)
 	for _, row := range result.Rows {
 		if current == nil {
-			current, curDistinct = oa.convertRow(row)
+			current, curDistinct = oa.convertRow(row, maxLen)
 			continue
 		}
 
@@ -194,14 +210,14 @@ func (oa *OrderedAggregate) execute(vcursor VCursor, bindVars map[string]*queryp
 		}
 
 		if equal {
-			current, curDistinct, err = oa.merge(result.Fields, current, row, curDistinct)
+			current, curDistinct, err = oa.merge(result.Fields, current, row, curDistinct, maxLen)
 			if err != nil {
 				return nil, err
 			}
 			continue
 		}
 		out.Rows = append(out.Rows, current)
-		current, curDistinct = oa.convertRow(row)
+		current, curDistinct = oa.convertRow(row, maxLen)
 	}
 
 	if len(result.Rows) == 0 && len(oa.Keys) == 0 {
@@ -229,6 +245,7 @@ func (oa *OrderedAggregate) StreamExecute(vcursor VCursor, bindVars map[string]*
 	var current []sqltypes.Value
 	var curDistinct sqltypes.Value
 	var fields []*querypb.Field
+	maxLen := oa.groupConcatMaxLen(vcursor)
 
 	cb := func(qr *sqltypes.Result) error {
 		return callback(qr.Truncate(oa.TruncateColumnCount))
@@ -244,7 +261,7 @@ func (oa *OrderedAggregate) StreamExecute(vcursor VCursor, bindVars map[string]*
 		// This code is similar to the one in Execute.
 		for _, row := range qr.Rows {
 			if current == nil {
-				current, curDistinct = oa.convertRow(row)
+				current, curDistinct = oa.convertRow(row, maxLen)
 				continue
 			}
 
@@ -254,7 +271,7 @@ func (oa *OrderedAggregate) StreamExecute(vcursor VCursor, bindVars map[string]*
 			}
 
 			if equal {
-				current, curDistinct, err = oa.merge(fields, current, row, curDistinct)
+				current, curDistinct, err = oa.merge(fields, current, row, curDistinct, maxLen)
 				if err != nil {
 					return err
 				}
@@ -263,7 +280,7 @@ func (oa *OrderedAggregate) StreamExecute(vcursor VCursor, bindVars map[string]*
 			if err := cb(&sqltypes.Result{Rows: [][]sqltypes.Value{current}}); err != nil {
 				return err
 			}
-			current, curDistinct = oa.convertRow(row)
+			current, curDistinct = oa.convertRow(row, maxLen)
 		}
 		return nil
 	})
@@ -295,7 +312,18 @@ func (oa *OrderedAggregate) convertFields(fields []*querypb.Field) []*querypb.Fi
 	return fields
 }
 
-func (oa *OrderedAggregate) convertRow(row []sqltypes.Value) (newRow []sqltypes.Value, curDistinct sqltypes.Value) {
+// groupConcatMaxLen returns the maximum length of the values of
+// group_concat for the session, or 0 if oa doesn't merge any.
+func (oa *OrderedAggregate) groupConcatMaxLen(vcursor VCursor) uint64 {
+	for _, aggr := range oa.Aggregates {
+		if aggr.isGroupConcat() {
+			return vcursor.Session().GetGroupConcatMaxLen()
+		}
+	}
+	return 0
+}
+
+func (oa *OrderedAggregate) convertRow(row []sqltypes.Value, maxLen uint64) (newRow []sqltypes.Value, curDistinct sqltypes.Value) {
 	if !oa.PreProcess {
 		return row, sqltypes.NULL
 	}
@@ -327,6 +355,11 @@ func (oa *OrderedAggregate) convertRow(row []sqltypes.Value) (newRow []sqltypes.
 			data, _ := proto.Marshal(vgtid)
 			val, _ := sqltypes.NewValue(sqltypes.VarBinary, data)
 			newRow[aggr.Col] = val
+		case AggregateGroupConcatDistinct:
+			curDistinct = row[aggr.Col]
+			if !row[aggr.Col].IsNull() {
+				newRow[aggr.Col] = truncateGroupConcat(row[aggr.Col].ToBytes(), opcodeType[aggr.Opcode], maxLen)
+			}
 		}
 	}
 	return newRow, curDistinct
@@ -365,7 +398,7 @@ func (oa *OrderedAggregate) keysEqual(row1, row2 []sqltypes.Value) (bool, error)
 	return true, nil
 }
 
-func (oa *OrderedAggregate) merge(fields []*querypb.Field, row1, row2 []sqltypes.Value, curDistinct sqltypes.Value) ([]sqltypes.Value, sqltypes.Value, error) {
+func (oa *OrderedAggregate) merge(fields []*querypb.Field, row1, row2 []sqltypes.Value, curDistinct sqltypes.Value, maxLen uint64) ([]sqltypes.Value, sqltypes.Value, error) {
 	result := sqltypes.CopyRow(row1)
 	for _, aggr := range oa.Aggregates {
 		if aggr.isDistinct() {
@@ -409,6 +442,10 @@ func (oa *OrderedAggregate) merge(fields []*querypb.Field, row1, row2 []sqltypes
 			data, _ := proto.Marshal(vgtid)
 			val, _ := sqltypes.NewValue(sqltypes.VarBinary, data)
 			result[aggr.Col] = val
+		case AggregateGroupConcat:
+			result[aggr.Col] = mergeGroupConcat(row1[aggr.Col], row2[aggr.Col], aggr.Separator, fields[aggr.Col].Type, maxLen)
+		case AggregateGroupConcatDistinct:
+			result[aggr.Col] = mergeGroupConcat(row1[aggr.Col], row2[aggr.Col], aggr.Separator, opcodeType[aggr.Opcode], maxLen)
 		default:
 			return nil, sqltypes.NULL, fmt.Errorf("BUG: Unexpected opcode: %v", aggr.Opcode)
 		}
@@ -419,6 +456,39 @@ func (oa *OrderedAggregate) merge(fields []*querypb.Field, row1, row2 []sqltypes
 	return result, curDistinct, nil
 }
 
+// mergeGroupConcat concatenates the results of group_concat for the
+// same group from two shards, or a new distinct value. NULL means the
+// group had no values. Like MySQL, the result is truncated to maxLen
+// bytes, which also bounds the memory used for each group.
+func mergeGroupConcat(v1, v2 sqltypes.Value, separator string, typ querypb.Type, maxLen uint64) sqltypes.Value {
+	switch {
+	case v2.IsNull(), uint64(v1.Len()) >= maxLen:
+		return v1
+	case v1.IsNull():
+		return truncateGroupConcat(v2.Raw(), typ, maxLen)
+	}
+	merged := make([]byte, 0, v1.Len()+len(separator)+v2.Len())
+	merged = append(merged, v1.Raw()...)
+	merged = append(merged, separator...)
+	merged = append(merged, v2.Raw()...)
+	return truncateGroupConcat(merged, typ, maxLen)
+}
+
+// truncateGroupConcat returns the value of group_concat truncated to
+// maxLen bytes, without cutting a character of a text value.
+func truncateGroupConcat(value []byte, typ querypb.Type, maxLen uint64) sqltypes.Value {
+	if uint64(len(value)) > maxLen {
+		end := int(maxLen)
+		if sqltypes.IsText(typ) {
+			for end > 0 && !utf8.RuneStart(value[end]) {
+				end--
+			}
+		}
+		value = value[:end]
+	}
+	return sqltypes.MakeTrusted(typ, value)
+}
+
 // creates the empty row for the case when we are missing grouping keys and have empty input table
 func (oa *OrderedAggregate) createEmptyRow() ([]sqltypes.Value, error) {
 	out := make([]sqltypes.Value, len(oa.Aggregates))
@@ -442,7 +512,9 @@ func createEmptyValueFor(opcode AggregateOpcode) (sqltypes.Value, error) {
 		AggregateSumDistinct,
 		AggregateSum,
 		AggregateMin,
-		AggregateMax:
+		AggregateMax,
+		AggregateGroupConcat,
+		AggregateGroupConcatDistinct:
 		return sqltypes.NULL, nil
 
 	}
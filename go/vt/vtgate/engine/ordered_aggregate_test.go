@@ -652,13 +652,13 @@ func TestMerge(t *testing.T) {
 		"1|3|2.8|2|bc",
 	)
 
-	merged, _, err := oa.merge(fields, r.Rows[0], r.Rows[1], sqltypes.NULL)
+	merged, _, err := oa.merge(fields, r.Rows[0], r.Rows[1], sqltypes.NULL, 0)
 	assert.NoError(err)
 	want := sqltypes.MakeTestResult(fields, "1|5|6|2|bc").Rows[0]
 	assert.Equal(want, merged)
 
 	// swap and retry
-	merged, _, err = oa.merge(fields, r.Rows[1], r.Rows[0], sqltypes.NULL)
+	merged, _, err = oa.merge(fields, r.Rows[1], r.Rows[0], sqltypes.NULL, 0)
 	assert.NoError(err)
 	assert.Equal(want, merged)
 }
@@ -790,3 +790,101 @@ func TestOrderedAggregateExecuteGtid(t *testing.T) {
 	)
 	assert.Equal(t, wantResult, result)
 }
+
+func TestOrderedAggregateGroupConcat(t *testing.T) {
+	fields := sqltypes.MakeTestFields(
+		"col|group_concat(name)",
+		"varbinary|text",
+	)
+	fp := &fakePrimitive{
+		results: []*sqltypes.Result{sqltypes.MakeTestResult(
+			fields,
+			"a|x,y",
+			"a|null",
+			"a|z",
+			"b|null",
+			"c|w",
+		)},
+	}
+
+	oa := &OrderedAggregate{
+		Aggregates: []AggregateParams{{
+			Opcode:    AggregateGroupConcat,
+			Col:       1,
+			Separator: ",",
+		}},
+		Keys:  []int{0},
+		Input: fp,
+	}
+
+	result, err := oa.Execute(&noopVCursor{}, nil, false)
+	require.NoError(t, err)
+
+	wantResult := sqltypes.MakeTestResult(
+		fields,
+		"a|x,y,z",
+		"b|null",
+		"c|w",
+	)
+	assert.Equal(t, wantResult, result)
+}
+
+func TestOrderedAggregateGroupConcatDistinct(t *testing.T) {
+	fp := &fakePrimitive{
+		results: []*sqltypes.Result{sqltypes.MakeTestResult(
+			sqltypes.MakeTestFields(
+				"col|name",
+				"varbinary|int64",
+			),
+			"a|1",
+			"a|1",
+			"a|2",
+			"b|null",
+			"c|null",
+			"c|3",
+			"c|3",
+			"c|4",
+		)},
+	}
+
+	oa := &OrderedAggregate{
+		PreProcess: true,
+		Aggregates: []AggregateParams{{
+			Opcode:    AggregateGroupConcatDistinct,
+			Col:       1,
+			Alias:     "group_concat(distinct name)",
+			Separator: ";",
+		}},
+		Keys:  []int{0},
+		Input: fp,
+	}
+
+	result, err := oa.Execute(&noopVCursor{}, nil, false)
+	require.NoError(t, err)
+
+	wantResult := sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields(
+			"col|group_concat(distinct name)",
+			"varbinary|varchar",
+		),
+		"a|1;2",
+		"b|null",
+		"c|3;4",
+	)
+	assert.Equal(t, wantResult, result)
+}
+
+func TestMergeGroupConcatMaxLen(t *testing.T) {
+	merged := mergeGroupConcat(sqltypes.NewVarChar("abc"), sqltypes.NewVarChar("def"), ",", sqltypes.VarChar, 5)
+	assert.Equal(t, sqltypes.NewVarChar("abc,d"), merged)
+
+	// Once the maximum length is reached, the new values are dropped.
+	merged = mergeGroupConcat(merged, sqltypes.NewVarChar("ghi"), ",", sqltypes.VarChar, 5)
+	assert.Equal(t, sqltypes.NewVarChar("abc,d"), merged)
+
+	// A character isn't cut in the middle.
+	merged = mergeGroupConcat(sqltypes.NewVarChar("ab"), sqltypes.NewVarChar("éé"), ",", sqltypes.VarChar, 5)
+	assert.Equal(t, sqltypes.NewVarChar("ab,é"), merged)
+	merged = mergeGroupConcat(sqltypes.NULL, sqltypes.NewVarChar("éé"), ",", sqltypes.VarChar, 3)
+	assert.Equal(t, sqltypes.NewVarChar("é"), merged)
+}
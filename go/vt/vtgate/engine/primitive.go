@@ -61,9 +61,15 @@ type (
 		// if the max memory rows override directive is set to true
 		ExceedsMaxMemoryRows(numRows int) bool
 
-		// SetContextTimeout updates the context and sets a timeout.
+		// SetContextTimeout updates the context and sets a timeout. The
+		// returned function cancels the context and restores the previous one.
 		SetContextTimeout(timeout time.Duration) context.CancelFunc
 
+		// DefaultQueryTimeout returns the timeout of the query timeout policy
+		// for the queries routed to the table of the keyspace, or 0 if none
+		// applies, e.g. because the query sets its own timeout.
+		DefaultQueryTimeout(keyspace, table string) time.Duration
+
 		// ErrorGroupCancellableContext updates context that can be cancelled.
 		ErrorGroupCancellableContext() (*errgroup.Group, func())
 
@@ -74,7 +80,7 @@ type (
 		// Shard-level functions.
 		ExecuteMultiShard(rss []*srvtopo.ResolvedShard, queries []*querypb.BoundQuery, rollbackOnError, canAutocommit bool) (*sqltypes.Result, []error)
 		ExecuteStandalone(query string, bindvars map[string]*querypb.BindVariable, rs *srvtopo.ResolvedShard) (*sqltypes.Result, error)
-		StreamExecuteMulti(query string, rss []*srvtopo.ResolvedShard, bindVars []map[string]*querypb.BindVariable, callback func(reply *sqltypes.Result) error) []error
+		StreamExecuteMulti(ctx context.Context, query string, rss []*srvtopo.ResolvedShard, bindVars []map[string]*querypb.BindVariable, callback func(reply *sqltypes.Result) error) []error
 
 		// Keyspace ID level functions.
 		ExecuteKeyspaceID(keyspace string, ksid []byte, query string, bindVars map[string]*querypb.BindVariable, rollbackOnError, autocommit bool) (*sqltypes.Result, error)
@@ -145,10 +151,18 @@ type (
 		SetSessionEnableSystemSettings(bool) error
 		GetSessionEnableSystemSettings() bool
 
+		// GetGroupConcatMaxLen returns the group_concat_max_len of the session
+		GetGroupConcatMaxLen() uint64
+
 		// SetReadAfterWriteGTID sets the GTID that the user expects a replica to have caught up with before answering a query
 		SetReadAfterWriteGTID(string)
 		SetReadAfterWriteTimeout(float64)
 		SetSessionTrackGTIDs(bool)
+		SetReadYourWrites(bool)
+
+		// SetChecksumKeyspaces sets the pairs of keyspaces whose read queries
+		// are checksummed against each other
+		SetChecksumKeyspaces(string) error
 
 		// HasCreatedTempTable will mark the session as having created temp tables
 		HasCreatedTempTable()
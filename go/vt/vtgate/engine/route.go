@@ -235,8 +235,8 @@ func (route *Route) SetTruncateColumnCount(count int) {
 
 // Execute performs a non-streaming exec.
 func (route *Route) Execute(vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool) (*sqltypes.Result, error) {
-	if route.QueryTimeout != 0 {
-		cancel := vcursor.SetContextTimeout(time.Duration(route.QueryTimeout) * time.Millisecond)
+	if timeout := route.queryTimeout(vcursor); timeout != 0 {
+		cancel := vcursor.SetContextTimeout(timeout)
 		defer cancel()
 	}
 	qr, err := route.execute(vcursor, bindVars, wantfields)
@@ -246,6 +246,19 @@ func (route *Route) Execute(vcursor VCursor, bindVars map[string]*querypb.BindVa
 	return qr.Truncate(route.TruncateColumnCount), nil
 }
 
+// queryTimeout returns the timeout of the route: the one of the
+// QUERY_TIMEOUT_MS directive of the query if set, else the one of the query
+// timeout policy for the keyspace, table and tablet type of the route.
+func (route *Route) queryTimeout(vcursor VCursor) time.Duration {
+	if route.QueryTimeout != 0 {
+		return time.Duration(route.QueryTimeout) * time.Millisecond
+	}
+	if route.Keyspace == nil {
+		return 0
+	}
+	return vcursor.DefaultQueryTimeout(route.Keyspace.Name, route.TableName)
+}
+
 func (route *Route) execute(vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool) (*sqltypes.Result, error) {
 	var rss []*srvtopo.ResolvedShard
 	var bvs []map[string]*querypb.BindVariable
@@ -320,8 +333,8 @@ func (route *Route) StreamExecute(vcursor VCursor, bindVars map[string]*querypb.
 	var rss []*srvtopo.ResolvedShard
 	var bvs []map[string]*querypb.BindVariable
 	var err error
-	if route.QueryTimeout != 0 {
-		cancel := vcursor.SetContextTimeout(time.Duration(route.QueryTimeout) * time.Millisecond)
+	if timeout := route.queryTimeout(vcursor); timeout != 0 {
+		cancel := vcursor.SetContextTimeout(timeout)
 		defer cancel()
 	}
 	switch route.Opcode {
@@ -359,7 +372,7 @@ func (route *Route) StreamExecute(vcursor VCursor, bindVars map[string]*querypb.
 	}
 
 	if len(route.OrderBy) == 0 {
-		errs := vcursor.StreamExecuteMulti(route.Query, rss, bvs, func(qr *sqltypes.Result) error {
+		errs := vcursor.StreamExecuteMulti(vcursor.Context(), route.Query, rss, bvs, func(qr *sqltypes.Result) error {
 			return callback(qr.Truncate(route.TruncateColumnCount))
 		})
 		if len(errs) > 0 {
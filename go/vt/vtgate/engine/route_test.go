@@ -19,6 +19,7 @@ package engine
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -34,6 +35,7 @@ import (
 	"vitess.io/vitess/go/mysql"
 	"vitess.io/vitess/go/sqltypes"
 	querypb "vitess.io/vitess/go/vt/proto/query"
+	"vitess.io/vitess/go/vt/srvtopo"
 	"vitess.io/vitess/go/vt/vtgate/vindexes"
 )
 
@@ -1232,3 +1234,53 @@ func TestExecFail(t *testing.T) {
 		vc.ExpectWarnings(t, []*querypb.QueryWarning{{Code: mysql.ERQueryInterrupted, Message: "query timeout -20 (errno 1317) (sqlstate HY000)"}})
 	})
 }
+
+// deadlineVCursor records the time left before the deadline of the context
+// of each multi-shard query.
+type deadlineVCursor struct {
+	*loggingVCursor
+	timeLeft []time.Duration
+}
+
+func (f *deadlineVCursor) ExecuteMultiShard(rss []*srvtopo.ResolvedShard, queries []*querypb.BoundQuery, rollbackOnError, canAutocommit bool) (*sqltypes.Result, []error) {
+	var timeLeft time.Duration
+	if deadline, ok := f.Context().Deadline(); ok {
+		timeLeft = time.Until(deadline)
+	}
+	f.timeLeft = append(f.timeLeft, timeLeft)
+	return f.loggingVCursor.ExecuteMultiShard(rss, queries, rollbackOnError, canAutocommit)
+}
+
+func TestRouteQueryTimeout(t *testing.T) {
+	sel := NewRoute(
+		SelectUnsharded,
+		&vindexes.Keyspace{
+			Name:    "ks",
+			Sharded: false,
+		},
+		"dummy_select",
+		"dummy_select_field",
+	)
+	sel.TableName = "t1"
+
+	vc := &deadlineVCursor{loggingVCursor: &loggingVCursor{
+		shards:               []string{"0"},
+		results:              []*sqltypes.Result{defaultSelectResult, defaultSelectResult},
+		defaultQueryTimeouts: map[string]time.Duration{"ks.t1": time.Minute},
+	}}
+	_, err := sel.Execute(vc, map[string]*querypb.BindVariable{}, false)
+	require.NoError(t, err)
+	require.Len(t, vc.timeLeft, 1)
+	assert.Greater(t, int64(vc.timeLeft[0]), int64(50*time.Second))
+	assert.LessOrEqual(t, int64(vc.timeLeft[0]), int64(time.Minute))
+	// The context of the route is restored once it is done.
+	_, ok := vc.Context().Deadline()
+	assert.False(t, ok)
+
+	// The QUERY_TIMEOUT_MS directive overrides the policy.
+	sel.QueryTimeout = 100
+	_, err = sel.Execute(vc, map[string]*querypb.BindVariable{}, false)
+	require.NoError(t, err)
+	require.Len(t, vc.timeLeft, 2)
+	assert.LessOrEqual(t, int64(vc.timeLeft[1]), int64(100*time.Millisecond))
+}
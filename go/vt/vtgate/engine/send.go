@@ -155,7 +155,7 @@ func (s *Send) StreamExecute(vcursor VCursor, bindVars map[string]*querypb.BindV
 		}
 		multiBindVars[i] = bv
 	}
-	errors := vcursor.StreamExecuteMulti(s.Query, rss, multiBindVars, callback)
+	errors := vcursor.StreamExecuteMulti(vcursor.Context(), s.Query, rss, multiBindVars, callback)
 	return vterrors.Aggregate(errors)
 }
 
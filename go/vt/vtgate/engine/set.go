@@ -451,6 +451,25 @@ func (svss *SysVarSetAware) Execute(vcursor VCursor, env evalengine.ExpressionEn
 		default:
 			return vterrors.NewErrorf(vtrpcpb.Code_INVALID_ARGUMENT, vterrors.WrongValueForVar, "variable 'session_track_gtids' can't be set to the value of '%s'", str)
 		}
+	case sysvars.Consistency.Name:
+		str, err := svss.evalAsString(env)
+		if err != nil {
+			return err
+		}
+		switch strings.ToLower(str) {
+		case "eventual":
+			vcursor.Session().SetReadYourWrites(false)
+		case "read_your_writes":
+			vcursor.Session().SetReadYourWrites(true)
+		default:
+			return vterrors.NewErrorf(vtrpcpb.Code_INVALID_ARGUMENT, vterrors.WrongValueForVar, "variable 'vitess_consistency' can't be set to the value of '%s'", str)
+		}
+	case sysvars.ChecksumKeyspaces.Name:
+		str, err := svss.evalAsString(env)
+		if err != nil {
+			return err
+		}
+		return vcursor.Session().SetChecksumKeyspaces(str)
 	default:
 		return vterrors.NewErrorf(vtrpcpb.Code_NOT_FOUND, vterrors.UnknownSystemVariable, "unknown system variable '%s'", svss.Name)
 	}
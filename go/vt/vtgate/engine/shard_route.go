@@ -35,6 +35,6 @@ type shardRoute struct {
 
 // StreamExecute performs a streaming exec.
 func (sr *shardRoute) StreamExecute(vcursor VCursor, _ map[string]*querypb.BindVariable, _ bool, callback func(*sqltypes.Result) error) error {
-	errors := vcursor.StreamExecuteMulti(sr.query, []*srvtopo.ResolvedShard{sr.rs}, []map[string]*querypb.BindVariable{sr.bv}, callback)
+	errors := vcursor.StreamExecuteMulti(vcursor.Context(), sr.query, []*srvtopo.ResolvedShard{sr.rs}, []map[string]*querypb.BindVariable{sr.bv}, callback)
 	return vterrors.Aggregate(errors)
 }
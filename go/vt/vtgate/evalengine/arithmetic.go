@@ -19,6 +19,7 @@ package evalengine
 import (
 	"bytes"
 	"fmt"
+	"hash/fnv"
 	"math"
 
 	"vitess.io/vitess/go/sqltypes"
@@ -198,6 +199,13 @@ func NullsafeCompare(v1, v2 sqltypes.Value) (int, error) {
 	if v2.IsNull() {
 		return 1, nil
 	}
+	if (v1.IsGeometry() || v2.IsGeometry()) && (sqltypes.IsNumber(v1.Type()) || sqltypes.IsNumber(v2.Type())) {
+		// A spatial value has no numeric value to compare.
+		return 0, UnsupportedComparisonError{
+			Type1: v1.Type(),
+			Type2: v2.Type(),
+		}
+	}
 	if sqltypes.IsNumber(v1.Type()) || sqltypes.IsNumber(v2.Type()) {
 		lv1, err := newEvalResult(v1)
 		if err != nil {
@@ -234,16 +242,24 @@ func NullsafeHashcode(v sqltypes.Value) (int64, error) {
 		return hashCode(result), nil
 	}
 
+	if v.IsGeometry() {
+		// The spatial values are compared by their internal format.
+		hash := fnv.New64a()
+		hash.Write(v.Raw())
+		return int64(hash.Sum64()), nil
+	}
+
 	return 0, vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "types does not support hashcode yet: %v", v.Type())
 }
 
-// isByteComparable returns true if the type is binary or date/time.
+// isByteComparable returns true if the type is binary, date/time or
+// spatial. MySQL sorts the spatial values by their internal format.
 func isByteComparable(v sqltypes.Value) bool {
 	if v.IsBinary() {
 		return true
 	}
 	switch v.Type() {
-	case sqltypes.Timestamp, sqltypes.Date, sqltypes.Time, sqltypes.Datetime, sqltypes.Enum, sqltypes.Set, sqltypes.TypeJSON, sqltypes.Bit:
+	case sqltypes.Timestamp, sqltypes.Date, sqltypes.Time, sqltypes.Datetime, sqltypes.Enum, sqltypes.Set, sqltypes.TypeJSON, sqltypes.Bit, sqltypes.Geometry:
 		return true
 	}
 	return false
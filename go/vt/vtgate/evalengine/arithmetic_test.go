@@ -582,6 +582,21 @@ func TestNullsafeCompare(t *testing.T) {
 		v1:  TestValue(querypb.Type_BIT, "0"),
 		v2:  TestValue(querypb.Type_BIT, "1"),
 		out: -1,
+	}, {
+		// Spatial types
+		v1:  TestValue(querypb.Type_GEOMETRY, "\x00\x00\x00\x00\x01\x01"),
+		v2:  TestValue(querypb.Type_GEOMETRY, "\x00\x00\x00\x00\x01\x02"),
+		out: -1,
+	}, {
+		// Spatial types
+		v1:  TestValue(querypb.Type_GEOMETRY, "\x00\x00\x00\x00\x01\x01"),
+		v2:  TestValue(querypb.Type_VARBINARY, "\x00\x00\x00\x00\x01\x01"),
+		out: 0,
+	}, {
+		// Spatial types have no numeric value.
+		v1:  TestValue(querypb.Type_GEOMETRY, "1"),
+		v2:  NewInt64(1),
+		err: vterrors.New(vtrpcpb.Code_UNKNOWN, "types are not comparable: GEOMETRY vs INT64"),
 	}}
 	for _, tcase := range tcases {
 		got, err := NullsafeCompare(tcase.v1, tcase.v2)
@@ -1348,6 +1363,15 @@ func TestHashCodes(t *testing.T) {
 	num := TestValue(querypb.Type_INT64, "123")
 	_, err = NullsafeHashcode(num)
 	require.NoError(t, err)
+
+	g1, err := NullsafeHashcode(TestValue(querypb.Type_GEOMETRY, "\x00\x00\x00\x00\x01\x01"))
+	require.NoError(t, err)
+	g2, err := NullsafeHashcode(TestValue(querypb.Type_GEOMETRY, "\x00\x00\x00\x00\x01\x01"))
+	require.NoError(t, err)
+	g3, err := NullsafeHashcode(TestValue(querypb.Type_GEOMETRY, "\x00\x00\x00\x00\x01\x02"))
+	require.NoError(t, err)
+	assert.Equal(t, g1, g2)
+	assert.NotEqual(t, g1, g3)
 }
 
 func printValue(v sqltypes.Value) string {
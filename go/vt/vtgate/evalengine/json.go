@@ -0,0 +1,253 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evalengine
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"vitess.io/vitess/go/sqltypes"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+type (
+	// JSONPath is a parsed JSON path without wildcards, such as
+	// $.user.id or $.tags[0].
+	JSONPath []jsonPathLeg
+
+	jsonPathLeg struct {
+		key   string
+		index int
+		// isIndex is set for the [n] legs, and unset for the .key legs.
+		isIndex bool
+	}
+
+	// JSONExtract is the -> operator, or JSON_EXTRACT with a single path.
+	JSONExtract struct{}
+	// JSONUnquoteExtract is the ->> operator.
+	JSONUnquoteExtract struct{}
+)
+
+var _ BinaryExpr = (*JSONExtract)(nil)
+var _ BinaryExpr = (*JSONUnquoteExtract)(nil)
+
+var jsonPathIdentifierRegexp = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// ParseJSONPath parses a JSON path such as $.user.id, $."first name"
+// or $.tags[0]. Wildcards and ranges are not supported, as the path must
+// select a single value.
+func ParseJSONPath(path string) (JSONPath, error) {
+	s := strings.TrimSpace(path)
+	if !strings.HasPrefix(s, "$") {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid JSON path %q: it must start with $", path)
+	}
+	s = strings.TrimSpace(s[1:])
+	var legs JSONPath
+	for s != "" {
+		switch s[0] {
+		case '.':
+			s = strings.TrimSpace(s[1:])
+			if strings.HasPrefix(s, `"`) {
+				end := strings.IndexByte(s[1:], '"')
+				if end == -1 {
+					return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid JSON path %q: unterminated quoted key", path)
+				}
+				legs = append(legs, jsonPathLeg{key: s[1 : end+1]})
+				s = s[end+2:]
+				break
+			}
+			end := strings.IndexAny(s, ".[ ")
+			if end == -1 {
+				end = len(s)
+			}
+			key := s[:end]
+			if !jsonPathIdentifierRegexp.MatchString(key) {
+				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid JSON path %q: unsupported key %q", path, key)
+			}
+			legs = append(legs, jsonPathLeg{key: key})
+			s = s[end:]
+		case '[':
+			end := strings.IndexByte(s, ']')
+			if end == -1 {
+				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid JSON path %q: unterminated array index", path)
+			}
+			index, err := strconv.Atoi(strings.TrimSpace(s[1:end]))
+			if err != nil || index < 0 {
+				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid JSON path %q: unsupported array index %q", path, s[1:end])
+			}
+			legs = append(legs, jsonPathLeg{index: index, isIndex: true})
+			s = s[end+1:]
+		default:
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid JSON path %q", path)
+		}
+		s = strings.TrimSpace(s)
+	}
+	return legs, nil
+}
+
+// String returns the canonical form of the path, in which the keys are
+// only quoted when they need to be.
+func (p JSONPath) String() string {
+	var buf strings.Builder
+	buf.WriteString("$")
+	for _, leg := range p {
+		switch {
+		case leg.isIndex:
+			buf.WriteString("[" + strconv.Itoa(leg.index) + "]")
+		case jsonPathIdentifierRegexp.MatchString(leg.key):
+			buf.WriteString("." + leg.key)
+		default:
+			buf.WriteString(`."` + leg.key + `"`)
+		}
+	}
+	return buf.String()
+}
+
+// Extract returns the value at the path of a JSON document, decoded
+// with json.Number for the numbers. found is false if the document
+// doesn't have a value at the path.
+func (p JSONPath) Extract(doc []byte) (value interface{}, found bool, err error) {
+	decoder := json.NewDecoder(bytes.NewReader(doc))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return nil, false, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid JSON document: %v", err)
+	}
+	for _, leg := range p {
+		if leg.isIndex {
+			array, ok := value.([]interface{})
+			if !ok {
+				// As in MySQL, a value which isn't an array is
+				// treated as an array of a single value.
+				if leg.index == 0 {
+					continue
+				}
+				return nil, false, nil
+			}
+			if leg.index >= len(array) {
+				return nil, false, nil
+			}
+			value = array[leg.index]
+			continue
+		}
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false, nil
+		}
+		if value, ok = object[leg.key]; !ok {
+			return nil, false, nil
+		}
+	}
+	return value, true, nil
+}
+
+// ExtractValue returns the value at the path of a JSON document, as the
+// ->> operator does: strings are unquoted, numbers are returned with a
+// numeric type, and null or a missing value is NULL. It is used to
+// compute the routing value of the vindexes over a JSON path.
+func (p JSONPath) ExtractValue(doc sqltypes.Value) (sqltypes.Value, error) {
+	if doc.IsNull() {
+		return sqltypes.NULL, nil
+	}
+	value, found, err := p.Extract(doc.Raw())
+	if err != nil || !found {
+		return sqltypes.NULL, err
+	}
+	switch value := value.(type) {
+	case nil:
+		return sqltypes.NULL, nil
+	case string:
+		return sqltypes.NewVarChar(value), nil
+	case json.Number:
+		if _, err := strconv.ParseInt(value.String(), 10, 64); err == nil {
+			return sqltypes.MakeTrusted(sqltypes.Int64, []byte(value.String())), nil
+		}
+		if _, err := strconv.ParseUint(value.String(), 10, 64); err == nil {
+			return sqltypes.MakeTrusted(sqltypes.Uint64, []byte(value.String())), nil
+		}
+		return sqltypes.MakeTrusted(sqltypes.Float64, []byte(value.String())), nil
+	}
+	text, err := json.Marshal(value)
+	if err != nil {
+		return sqltypes.NULL, err
+	}
+	return sqltypes.MakeTrusted(sqltypes.VarChar, text), nil
+}
+
+// extractJSON evaluates the -> and ->> operators.
+func extractJSON(left, right EvalResult, unquote bool) (EvalResult, error) {
+	if left.typ == sqltypes.Null || right.typ == sqltypes.Null {
+		return EvalResult{typ: sqltypes.Null}, nil
+	}
+	path, err := ParseJSONPath(string(right.bytes))
+	if err != nil {
+		return EvalResult{}, err
+	}
+	value, found, err := path.Extract(left.bytes)
+	if err != nil || !found {
+		return EvalResult{typ: sqltypes.Null}, err
+	}
+	if str, ok := value.(string); ok && unquote {
+		return EvalResult{typ: sqltypes.VarChar, bytes: []byte(str)}, nil
+	}
+	if value == nil && unquote {
+		return EvalResult{typ: sqltypes.VarChar, bytes: []byte("null")}, nil
+	}
+	text, err := json.Marshal(value)
+	if err != nil {
+		return EvalResult{}, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "cannot encode JSON value: %v", err)
+	}
+	if unquote {
+		return EvalResult{typ: sqltypes.VarChar, bytes: text}, nil
+	}
+	return EvalResult{typ: sqltypes.TypeJSON, bytes: text}, nil
+}
+
+// Evaluate implements the BinaryOp interface
+func (j *JSONExtract) Evaluate(left, right EvalResult) (EvalResult, error) {
+	return extractJSON(left, right, false)
+}
+
+// Evaluate implements the BinaryOp interface
+func (j *JSONUnquoteExtract) Evaluate(left, right EvalResult) (EvalResult, error) {
+	return extractJSON(left, right, true)
+}
+
+// Type implements the BinaryExpr interface
+func (j *JSONExtract) Type(querypb.Type) querypb.Type {
+	return sqltypes.TypeJSON
+}
+
+// Type implements the BinaryExpr interface
+func (j *JSONUnquoteExtract) Type(querypb.Type) querypb.Type {
+	return sqltypes.VarChar
+}
+
+// String implements the BinaryExpr interface
+func (j *JSONExtract) String() string {
+	return "->"
+}
+
+// String implements the BinaryExpr interface
+func (j *JSONUnquoteExtract) String() string {
+	return "->>"
+}
@@ -100,6 +100,25 @@ type Executor struct {
 
 	vm            *VSchemaManager
 	schemaTracker SchemaInfo
+
+	// mirror is nil if query mirroring is disabled.
+	mirror *queryMirror
+	// checksummer checksums the read queries of the sessions which set
+	// @@vitess_checksum_keyspaces.
+	checksummer *queryChecksummer
+	// timeouts is nil if -query_timeout_by_tablet_type is not set, only
+	// the timeouts of the VSchema apply then.
+	timeouts *queryTimeoutPolicy
+	// quotas is nil if the query quotas are disabled.
+	quotas *queryQuotas
+	// resultCache is nil if the result cache is disabled.
+	resultCache *resultCache
+	// partitions is nil if the partition watch is disabled.
+	partitions *partitionWatcher
+	// servingFlags is nil if the keyspace serving flags are disabled.
+	servingFlags *keyspaceServingFlags
+	// anomalies is nil if the query anomaly detection is disabled.
+	anomalies *queryAnomalyDetector
 }
 
 var executorOnce sync.Once
@@ -131,6 +150,8 @@ func NewExecutor(
 		streamSize:      streamSize,
 		schemaTracker:   schemaTracker,
 	}
+	e.mirror = newQueryMirror(e.executeMirrored)
+	e.checksummer = newQueryChecksummer(e.executeMirrored)
 
 	vschemaacl.Init()
 	// we subscribe to update from the VSchemaManager
@@ -163,8 +184,17 @@ func (e *Executor) Execute(ctx context.Context, method string, safeSession *Safe
 	trace.AnnotateSQL(span, sql)
 	defer span.Finish()
 
+	done, err := e.quotas.start(ctx)
+	if err != nil {
+		return nil, err
+	}
 	logStats := NewLogStats(ctx, method, sql, bindVars)
 	stmtType, result, err := e.execute(ctx, safeSession, sql, bindVars, logStats)
+	if result != nil {
+		done(int64(len(result.Rows)))
+	} else {
+		done(0)
+	}
 	logStats.Error = err
 	saveSessionStats(safeSession, stmtType, result, err)
 	if result != nil && len(result.Rows) > *warnMemoryRows {
@@ -177,6 +207,16 @@ func (e *Executor) Execute(ctx context.Context, method string, safeSession *Safe
 	}
 
 	logStats.Send()
+	e.anomalies.observe(logStats)
+	e.mirror.maybeMirror(safeSession, stmtType, logStats.Keyspace, sql, bindVars, result, err, logStats.TotalTime())
+	e.checksummer.maybeChecksum(safeSession, stmtType, logStats.Keyspace, sql, bindVars, result, err)
+	return result, err
+}
+
+// executeMirrored executes a query mirrored to a shadow keyspace, or
+// checksummed against another keyspace. It is not logged.
+func (e *Executor) executeMirrored(ctx context.Context, safeSession *SafeSession, sql string, bindVars map[string]*querypb.BindVariable) (*sqltypes.Result, error) {
+	_, result, err := e.execute(ctx, safeSession, sql, bindVars, NewLogStats(ctx, "Mirror", sql, bindVars))
 	return result, err
 }
 
@@ -334,6 +374,18 @@ func (e *Executor) addNeededBindVars(bindVarNeeds *sqlparser.BindVarNeeds, bindV
 				}
 			})
 			bindVars[key] = sqltypes.StringBindVariable(v)
+		case sysvars.Consistency.Name:
+			v := "eventual"
+			if session.ReadYourWrites() {
+				v = "read_your_writes"
+			}
+			bindVars[key] = sqltypes.StringBindVariable(v)
+		case sysvars.ChecksumKeyspaces.Name:
+			bindVars[key] = sqltypes.StringBindVariable(session.ChecksumKeyspaces())
+		case sysvars.KeyspaceFlags.Name:
+			// The flags of the keyspace of the session, if any.
+			keyspace, _, _, _ := e.ParseDestinationTarget(session.TargetString)
+			bindVars[key] = sqltypes.StringBindVariable(strings.Join(e.servingFlags.get(keyspace), ","))
 		case sysvars.Version.Name:
 			bindVars[key] = sqltypes.StringBindVariable(servenv.AppVersion.MySQLVersion())
 		case sysvars.VersionComment.Name:
@@ -739,6 +791,8 @@ func (e *Executor) handleShow(ctx context.Context, safeSession *SafeSession, sql
 		}, nil
 	case sqlparser.KeywordString(sqlparser.VITESS_TABLETS):
 		return e.showTablets(show)
+	case "vitess_keyspace_flags":
+		return e.servingFlags.result(), nil
 	case "vitess_target":
 		var rows [][]sqltypes.Value
 		rows = append(rows, buildVarCharRow(safeSession.TargetString))
@@ -1020,8 +1074,25 @@ func (e *Executor) handleOther(ctx context.Context, safeSession *SafeSession, sq
 
 // StreamExecute executes a streaming query.
 func (e *Executor) StreamExecute(ctx context.Context, method string, safeSession *SafeSession, sql string, bindVars map[string]*querypb.BindVariable, target *querypb.Target, callback func(*sqltypes.Result) error) (err error) {
+	done, err := e.quotas.start(ctx)
+	if err != nil {
+		return err
+	}
+	var streamedRows int64
+	defer func() { done(streamedRows) }()
+	if e.quotas != nil {
+		send := callback
+		callback = func(qr *sqltypes.Result) error {
+			streamedRows += int64(len(qr.Rows))
+			return send(qr)
+		}
+	}
+
 	logStats := NewLogStats(ctx, method, sql, bindVars)
-	defer logStats.Send()
+	defer func() {
+		logStats.Send()
+		e.anomalies.observe(logStats)
+	}()
 
 	if bindVars == nil {
 		bindVars = make(map[string]*querypb.BindVariable)
@@ -1043,6 +1114,12 @@ func (e *Executor) StreamExecute(ctx context.Context, method string, safeSession
 		return err
 	}
 	logStats.StmtType = plan.Type.String()
+	if e.quotas != nil && engine.Find(findScatter, plan.Instructions) != nil {
+		if err := e.quotas.checkScatter(ctx); err != nil {
+			logStats.Error = err
+			return err
+		}
+	}
 	switch plan.Type {
 	case sqlparser.StmtBegin, sqlparser.StmtCommit, sqlparser.StmtRollback:
 		return vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "OLAP does not supported statement type: %s", plan.Type)
@@ -1162,6 +1239,10 @@ func (e *Executor) SaveVSchema(vschema *vindexes.VSchema, stats *VSchemaStats) {
 	}
 	e.vschemaStats = stats
 	e.plans.Clear()
+	if e.partitions != nil {
+		e.partitions.watchKeyspaces(e.vschemaKeyspacesLocked())
+	}
+	e.resultCache.watchVSchema(e.vschema)
 
 	if vschemaCounters != nil {
 		vschemaCounters.Add("Reload", 1)
@@ -1169,6 +1250,44 @@ func (e *Executor) SaveVSchema(vschema *vindexes.VSchema, stats *VSchemaStats) {
 
 }
 
+// setPartitionWatcher makes the partition watcher follow the keyspaces of
+// the VSchema.
+func (e *Executor) setPartitionWatcher(pw *partitionWatcher) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.partitions = pw
+	pw.watchKeyspaces(e.vschemaKeyspacesLocked())
+}
+
+// setResultCache makes the result cache follow the cacheable tables of the
+// VSchema.
+func (e *Executor) setResultCache(rc *resultCache) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.resultCache = rc
+	rc.watchVSchema(e.vschema)
+}
+
+func (e *Executor) vschemaKeyspacesLocked() []string {
+	if e.vschema == nil {
+		return nil
+	}
+	keyspaces := make([]string, 0, len(e.vschema.Keyspaces))
+	for keyspace := range e.vschema.Keyspaces {
+		keyspaces = append(keyspaces, keyspace)
+	}
+	return keyspaces
+}
+
+// onPartitionChange clears the plans when the shards serving a keyspace
+// change, so none of them outlives a resharding.
+func (e *Executor) onPartitionChange(change *partitionChange) {
+	if len(change.Added) == 0 && len(change.Removed) == 0 {
+		return
+	}
+	e.plans.Clear()
+}
+
 // ParseDestinationTarget parses destination target string and sets default keyspace if possible.
 func (e *Executor) ParseDestinationTarget(targetString string) (string, topodatapb.TabletType, key.Destination, error) {
 	destKeyspace, destTabletType, dest, err := topoproto.ParseDestination(targetString, defaultTabletType)
@@ -1206,6 +1325,7 @@ func (e *Executor) getPlan(vcursor *vcursorImpl, sql string, comments sqlparser.
 	}
 	ignoreMaxMemoryRows := sqlparser.IgnoreMaxMaxMemoryRowsDirective(stmt)
 	vcursor.SetIgnoreMaxMemoryRows(ignoreMaxMemoryRows)
+	vcursor.hasQueryTimeoutDirective = sqlparser.QueryTimeoutDirective(stmt) > 0
 
 	// Normalize if possible and retry.
 	if (e.normalize && sqlparser.CanNormalize(stmt)) || sqlparser.MustRewriteAST(stmt) {
@@ -346,6 +346,11 @@ func TestSelectSystemVariables(t *testing.T) {
 		ReadAfterWriteTimeout: 13,
 		SessionTrackGtids:     true,
 	}
+	masterSession.ReadYourWrites = &vtgatepb.ReadYourWrites{Enabled: true}
+	defer func() {
+		masterSession.ReadAfterWrite = nil
+		masterSession.ReadYourWrites = nil
+	}()
 	executor, _, _, _ := createLegacyExecutorEnv()
 	executor.normalize = true
 	logChan := QueryLogger.Subscribe("Test")
@@ -353,7 +358,7 @@ func TestSelectSystemVariables(t *testing.T) {
 
 	sql := "select @@autocommit, @@client_found_rows, @@skip_query_plan_cache, @@enable_system_settings, " +
 		"@@sql_select_limit, @@transaction_mode, @@workload, @@read_after_write_gtid, " +
-		"@@read_after_write_timeout, @@session_track_gtids, @@ddl_strategy, @@socket"
+		"@@read_after_write_timeout, @@session_track_gtids, @@vitess_consistency, @@ddl_strategy, @@socket"
 
 	result, err := executorExec(executor, sql, map[string]*querypb.BindVariable{})
 	wantResult := &sqltypes.Result{
@@ -368,6 +373,7 @@ func TestSelectSystemVariables(t *testing.T) {
 			{Name: "@@read_after_write_gtid", Type: sqltypes.VarBinary},
 			{Name: "@@read_after_write_timeout", Type: sqltypes.Float64},
 			{Name: "@@session_track_gtids", Type: sqltypes.VarBinary},
+			{Name: "@@vitess_consistency", Type: sqltypes.VarBinary},
 			{Name: "@@ddl_strategy", Type: sqltypes.VarBinary},
 			{Name: "@@socket", Type: sqltypes.VarBinary},
 		},
@@ -384,6 +390,7 @@ func TestSelectSystemVariables(t *testing.T) {
 			sqltypes.NewVarBinary("a fine gtid"),
 			sqltypes.NewFloat64(13),
 			sqltypes.NewVarBinary("own_gtid"),
+			sqltypes.NewVarBinary("read_your_writes"),
 			sqltypes.NewVarBinary(""),
 			sqltypes.NewVarBinary(""),
 		}},
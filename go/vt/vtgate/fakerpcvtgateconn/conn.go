@@ -187,6 +187,11 @@ func (conn *FakeVTGateConn) ResolveTransaction(ctx context.Context, dtid string)
 	return nil
 }
 
+// GetBufferStatus please see vtgateconn.Impl.GetBufferStatus
+func (conn *FakeVTGateConn) GetBufferStatus(ctx context.Context) (*vtgatepb.GetBufferStatusResponse, error) {
+	return &vtgatepb.GetBufferStatusResponse{Drained: true}, nil
+}
+
 // VStream streams binlog events.
 func (conn *FakeVTGateConn) VStream(ctx context.Context, tabletType topodatapb.TabletType, vgtid *binlogdatapb.VGtid,
 	filter *binlogdatapb.Filter, flags *vtgatepb.VStreamFlags) (vtgateconn.VStreamReader, error) {
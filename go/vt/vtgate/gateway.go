@@ -25,6 +25,7 @@ import (
 
 	"vitess.io/vitess/go/vt/discovery"
 	"vitess.io/vitess/go/vt/srvtopo"
+	"vitess.io/vitess/go/vt/vtgate/buffer"
 	"vitess.io/vitess/go/vt/vttablet/queryservice"
 
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
@@ -69,6 +70,9 @@ type Gateway interface {
 	// TabletsCacheStatus returns a displayable version of the health check cache.
 	TabletsCacheStatus() discovery.TabletsCacheStatusList
 
+	// BufferStatus returns a snapshot of the state of the MASTER failover buffer.
+	BufferStatus() *buffer.Status
+
 	// TabletByAlias returns a QueryService
 	QueryServiceByAlias(alias *topodatapb.TabletAlias, target *querypb.Target) (queryservice.QueryService, error)
 }
@@ -206,6 +206,17 @@ func (conn *vtgateConn) ResolveTransaction(ctx context.Context, dtid string) err
 	return vterrors.FromGRPC(err)
 }
 
+func (conn *vtgateConn) GetBufferStatus(ctx context.Context) (*vtgatepb.GetBufferStatusResponse, error) {
+	request := &vtgatepb.GetBufferStatusRequest{
+		CallerId: callerid.EffectiveCallerIDFromContext(ctx),
+	}
+	response, err := conn.c.GetBufferStatus(ctx, request)
+	if err != nil {
+		return nil, vterrors.FromGRPC(err)
+	}
+	return response, nil
+}
+
 type vstreamAdapter struct {
 	stream vtgateservicepb.Vitess_VStreamClient
 }
@@ -249,6 +249,18 @@ func (f *fakeVTGateService) ResolveTransaction(ctx context.Context, dtid string)
 	return nil
 }
 
+// GetBufferStatus is part of the VTGateService interface
+func (f *fakeVTGateService) GetBufferStatus(ctx context.Context) (*vtgatepb.GetBufferStatusResponse, error) {
+	if f.hasError {
+		return nil, errTestVtGateError
+	}
+	if f.panics {
+		panic(fmt.Errorf("test forced panic"))
+	}
+	f.checkCallerID(ctx, "GetBufferStatus")
+	return bufferStatusResult, nil
+}
+
 func (f *fakeVTGateService) VStream(ctx context.Context, tabletType topodatapb.TabletType, vgtid *binlogdatapb.VGtid, filter *binlogdatapb.Filter, flags *vtgatepb.VStreamFlags, send func([]*binlogdatapb.VEvent) error) error {
 	panic("unimplemented")
 }
@@ -295,6 +307,7 @@ func RunTests(t *testing.T, impl vtgateconn.Impl, fakeServer vtgateservice.VTGat
 	testStreamExecute(t, session)
 	testExecuteBatch(t, session)
 	testPrepare(t, session)
+	testGetBufferStatus(t, conn)
 
 	// force a panic at every call, then test that works
 	fs.panics = true
@@ -302,6 +315,7 @@ func RunTests(t *testing.T, impl vtgateconn.Impl, fakeServer vtgateservice.VTGat
 	testExecuteBatchPanic(t, session)
 	testStreamExecutePanic(t, session)
 	testPreparePanic(t, session)
+	testGetBufferStatusPanic(t, conn)
 	fs.panics = false
 }
 
@@ -321,6 +335,7 @@ func RunErrorTests(t *testing.T, fakeServer vtgateservice.VTGateService) {
 	testExecuteBatchError(t, session, fs)
 	testStreamExecuteError(t, session, fs)
 	testPrepareError(t, session, fs)
+	testGetBufferStatusError(t, conn)
 	fs.hasError = false
 }
 
@@ -527,6 +542,27 @@ func testPreparePanic(t *testing.T, session *vtgateconn.VTGateSession) {
 	expectPanic(t, err)
 }
 
+func testGetBufferStatus(t *testing.T, conn *vtgateconn.VTGateConn) {
+	ctx := newContext()
+	status, err := conn.GetBufferStatus(ctx)
+	require.NoError(t, err)
+	if !proto.Equal(status, bufferStatusResult) {
+		t.Errorf("Unexpected result from GetBufferStatus: got %v want %v", status, bufferStatusResult)
+	}
+}
+
+func testGetBufferStatusError(t *testing.T, conn *vtgateconn.VTGateConn) {
+	ctx := newContext()
+	_, err := conn.GetBufferStatus(ctx)
+	verifyError(t, err, "GetBufferStatus")
+}
+
+func testGetBufferStatusPanic(t *testing.T, conn *vtgateconn.VTGateConn) {
+	ctx := newContext()
+	_, err := conn.GetBufferStatus(ctx)
+	expectPanic(t, err)
+}
+
 var testCallerID = &vtrpcpb.CallerID{
 	Principal:    "test_principal",
 	Component:    "test_component",
@@ -603,3 +639,17 @@ var streamResultFields = sqltypes.Result{
 }
 
 var dtid2 = "aa"
+
+var bufferStatusResult = &vtgatepb.GetBufferStatusResponse{
+	Capacity:  10,
+	Available: 8,
+	Shards: []*vtgatepb.BufferShardStatus{{
+		Keyspace:       "ks",
+		Shard:          "-80",
+		Mode:           "enabled",
+		State:          "BUFFERING",
+		Active:         true,
+		QueuedRequests: 2,
+		FailoverReason: "reparent",
+	}},
+}
@@ -19,9 +19,11 @@ package grpcvtgateservice
 
 import (
 	"flag"
+	"strconv"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
 
 	"context"
@@ -45,6 +47,11 @@ import (
 
 const (
 	unsecureClient = "unsecure_grpc_client"
+
+	// retryAfterTrailer is the trailer which tells the clients of a query
+	// rejected by a query quota how many milliseconds to wait before
+	// retrying it.
+	retryAfterTrailer = "retry-after-ms"
 )
 
 var (
@@ -117,6 +124,9 @@ func (vtg *VTGate) Execute(ctx context.Context, request *vtgatepb.ExecuteRequest
 		session.Options = request.Options
 	}
 	session, result, err := vtg.server.Execute(ctx, session, request.Query.Sql, request.Query.BindVariables)
+	if md, ok := retryAfterMetadata(err); ok {
+		grpc.SetTrailer(ctx, md)
+	}
 	return &vtgatepb.ExecuteResponse{
 		Result:  sqltypes.ResultToProto3(result),
 		Session: session,
@@ -176,9 +186,22 @@ func (vtg *VTGate) StreamExecute(request *vtgatepb.StreamExecuteRequest, stream
 			Result: sqltypes.ResultToProto3(value),
 		})
 	})
+	if md, ok := retryAfterMetadata(vtgErr); ok {
+		stream.SetTrailer(md)
+	}
 	return vterrors.ToGRPC(vtgErr)
 }
 
+// retryAfterMetadata returns the retry-after trailer of an error, if it
+// is the rejection of a query quota.
+func retryAfterMetadata(err error) (metadata.MD, bool) {
+	retryAfter, ok := vtgate.RetryAfter(err)
+	if !ok {
+		return nil, false
+	}
+	return metadata.Pairs(retryAfterTrailer, strconv.FormatInt(retryAfter.Milliseconds(), 10)), true
+}
+
 // Prepare is the RPC version of vtgateservice.VTGateService method
 func (vtg *VTGate) Prepare(ctx context.Context, request *vtgatepb.PrepareRequest) (response *vtgatepb.PrepareResponse, err error) {
 	defer vtg.server.HandlePanic(&err)
@@ -212,6 +235,14 @@ func (vtg *VTGate) CloseSession(ctx context.Context, request *vtgatepb.CloseSess
 	}, nil
 }
 
+// GetBufferStatus is the RPC version of vtgateservice.VTGateService method
+func (vtg *VTGate) GetBufferStatus(ctx context.Context, request *vtgatepb.GetBufferStatusRequest) (response *vtgatepb.GetBufferStatusResponse, err error) {
+	defer vtg.server.HandlePanic(&err)
+	ctx = withCallerIDContext(ctx, request.CallerId)
+	response, err = vtg.server.GetBufferStatus(ctx)
+	return response, vterrors.ToGRPC(err)
+}
+
 // ResolveTransaction is the RPC version of vtgateservice.VTGateService method
 func (vtg *VTGate) ResolveTransaction(ctx context.Context, request *vtgatepb.ResolveTransactionRequest) (response *vtgatepb.ResolveTransactionResponse, err error) {
 	defer vtg.server.HandlePanic(&err)
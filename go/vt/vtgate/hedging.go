@@ -0,0 +1,264 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"flag"
+	"sort"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/discovery"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vttablet/queryservice"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// This file contains the request hedging logic of the TabletGateway.
+// Non-transactional Execute calls against REPLICA and RDONLY tablets are
+// read-only and can safely be sent to more than one tablet. If the first
+// tablet hasn't answered after a delay derived from the recent latencies of
+// the target, the query is also sent to a second healthy tablet and the
+// first answer wins.
+
+var (
+	hedgingEnabled      = flag.Bool("gateway_hedging_enabled", false, "If set, non-transactional reads against replica and rdonly tablets are also sent to a second tablet if the first one is slower than -gateway_hedging_percentile.")
+	hedgingPercentile   = flag.Float64("gateway_hedging_percentile", 95, "Latency percentile of a keyspace/shard/tablet_type after which a hedged request is sent.")
+	hedgingMinDelay     = flag.Duration("gateway_hedging_min_delay", 5*time.Millisecond, "Minimum delay before a hedged request is sent.")
+	hedgingDefaultDelay = flag.Duration("gateway_hedging_default_delay", 100*time.Millisecond, "Delay before a hedged request is sent while not enough latency samples are available.")
+
+	hedgedRequests = stats.NewCountersWithMultiLabels(
+		"GatewayHedgedRequests",
+		"Number of hedged requests sent to a second tablet",
+		[]string{"Keyspace", "ShardName", "DbType"})
+	hedgedRequestWins = stats.NewCountersWithMultiLabels(
+		"GatewayHedgedRequestWins",
+		"Number of hedged requests which answered before the original request",
+		[]string{"Keyspace", "ShardName", "DbType"})
+)
+
+const (
+	// hedgingWindowSize is the number of latency samples kept per target.
+	hedgingWindowSize = 1000
+	// hedgingMinSamples is the number of samples required before the
+	// percentile is used instead of -gateway_hedging_default_delay.
+	hedgingMinSamples = 100
+	// hedgingRecomputeEvery is how often, in samples, the delay is recomputed.
+	hedgingRecomputeEvery = 50
+)
+
+// latencyWindow keeps the most recent latencies of a target in a ring buffer.
+type latencyWindow struct {
+	samples []time.Duration
+	next    int
+	added   int
+	delay   time.Duration
+}
+
+func (lw *latencyWindow) add(d time.Duration) {
+	if len(lw.samples) < hedgingWindowSize {
+		lw.samples = append(lw.samples, d)
+	} else {
+		lw.samples[lw.next] = d
+	}
+	lw.next = (lw.next + 1) % hedgingWindowSize
+	lw.added++
+	if lw.added%hedgingRecomputeEvery == 0 {
+		lw.delay = percentile(lw.samples, *hedgingPercentile)
+	}
+}
+
+// percentile returns the p-th percentile of samples.
+func percentile(samples []time.Duration, p float64) time.Duration {
+	if len(samples) == 0 {
+		return 0
+	}
+	sorted := make([]time.Duration, len(samples))
+	copy(sorted, samples)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	idx := int(float64(len(sorted)-1) * p / 100)
+	if idx < 0 {
+		idx = 0
+	}
+	if idx >= len(sorted) {
+		idx = len(sorted) - 1
+	}
+	return sorted[idx]
+}
+
+// hedger tracks per target latencies to compute the hedging delays.
+type hedger struct {
+	mu      sync.Mutex
+	windows map[string]*latencyWindow
+}
+
+func newHedger() *hedger {
+	return &hedger{windows: make(map[string]*latencyWindow)}
+}
+
+// targetKey returns the keyspace/shard/tablet_type key of a target.
+func targetKey(target *querypb.Target) string {
+	return target.Keyspace + "/" + target.Shard + "/" + topoproto.TabletTypeLString(target.TabletType)
+}
+
+func (h *hedger) record(target *querypb.Target, d time.Duration) {
+	key := targetKey(target)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	lw, ok := h.windows[key]
+	if !ok {
+		lw = &latencyWindow{}
+		h.windows[key] = lw
+	}
+	lw.add(d)
+}
+
+// delay returns how long to wait for the first tablet before hedging.
+func (h *hedger) delay(target *querypb.Target) time.Duration {
+	h.mu.Lock()
+	lw, ok := h.windows[targetKey(target)]
+	var d time.Duration
+	if ok && len(lw.samples) >= hedgingMinSamples && lw.delay > 0 {
+		d = lw.delay
+	} else {
+		d = *hedgingDefaultDelay
+	}
+	h.mu.Unlock()
+	if d < *hedgingMinDelay {
+		d = *hedgingMinDelay
+	}
+	return d
+}
+
+// canHedge returns true if the request is a read which can be sent to
+// more than one tablet.
+func canHedge(target *querypb.Target, transactionID, reservedID int64) bool {
+	if !*hedgingEnabled || target == nil || transactionID != 0 || reservedID != 0 {
+		return false
+	}
+	if target.TabletType != topodatapb.TabletType_REPLICA && target.TabletType != topodatapb.TabletType_RDONLY {
+		return false
+	}
+	if len(discovery.AllowedTabletTypes) > 0 {
+		// Let the regular code path reject disallowed tablet types.
+		for _, allowed := range discovery.AllowedTabletTypes {
+			if allowed == target.TabletType {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+type hedgeResult struct {
+	qr     *sqltypes.Result
+	err    error
+	hedged bool
+}
+
+// Execute is part of the queryservice.QueryService interface. It hedges
+// eligible reads and delegates everything else to the retrying wrapper.
+func (gw *TabletGateway) Execute(ctx context.Context, target *querypb.Target, query string, bindVars map[string]*querypb.BindVariable, transactionID, reservedID int64, options *querypb.ExecuteOptions) (*sqltypes.Result, error) {
+	if !canHedge(target, transactionID, reservedID) {
+		return gw.QueryService.Execute(ctx, target, query, bindVars, transactionID, reservedID, options)
+	}
+	return gw.hedgedService.Execute(ctx, target, query, bindVars, transactionID, reservedID, options)
+}
+
+// withHedging is withRetry for the hedged reads: the retry policy, the
+// buffer and the stats apply to each attempt, which is sent to the tablet
+// picked by withRetry and, if it is slow, to a second tablet.
+func (gw *TabletGateway) withHedging(ctx context.Context, target *querypb.Target, conn queryservice.QueryService,
+	name string, inTransaction bool, inner func(ctx context.Context, target *querypb.Target, conn queryservice.QueryService) (bool, error)) error {
+	return gw.withRetry(ctx, target, conn, name, inTransaction, func(ctx context.Context, target *querypb.Target, conn queryservice.QueryService) (bool, error) {
+		return inner(ctx, target, &hedgedConn{QueryService: conn, gw: gw})
+	})
+}
+
+// hedgedConn is the connection to the tablet of an attempt of withHedging.
+// Its Execute also sends the query to a second tablet if the first one
+// hasn't answered after the hedging delay of the target.
+type hedgedConn struct {
+	queryservice.QueryService
+	gw *TabletGateway
+}
+
+// hedgeTablet returns the connection to a serving tablet other than the one
+// of the attempt, or nil if there is none.
+func (hc *hedgedConn) hedgeTablet(target *querypb.Target) queryservice.QueryService {
+	tablets := hc.gw.servingTablets(target)
+	hc.gw.shuffleTablets(hc.gw.localCell, tablets)
+	for _, th := range tablets {
+		if th.Conn != nil && th.Conn != hc.QueryService {
+			return th.Conn
+		}
+	}
+	return nil
+}
+
+// Execute is part of the queryservice.QueryService interface.
+func (hc *hedgedConn) Execute(ctx context.Context, target *querypb.Target, query string, bindVars map[string]*querypb.BindVariable, transactionID, reservedID int64, options *querypb.ExecuteOptions) (*sqltypes.Result, error) {
+	gw := hc.gw
+	hedgeCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	results := make(chan hedgeResult, 2)
+	run := func(conn queryservice.QueryService, hedged bool) {
+		startTime := time.Now()
+		qr, err := conn.Execute(hedgeCtx, target, query, bindVars, transactionID, reservedID, options)
+		if err == nil {
+			gw.hedger.record(target, time.Since(startTime))
+		}
+		results <- hedgeResult{qr: qr, err: err, hedged: hedged}
+	}
+
+	go run(hc.QueryService, false)
+	timer := time.NewTimer(gw.hedger.delay(target))
+	defer timer.Stop()
+
+	var res hedgeResult
+	select {
+	case res = <-results:
+		// The first tablet answered before we hedged. If it failed,
+		// withRetry retries on another tablet.
+	case <-timer.C:
+		conn := hc.hedgeTablet(target)
+		if conn == nil {
+			res = <-results
+			break
+		}
+		statsKey := []string{target.Keyspace, target.Shard, topoproto.TabletTypeLString(target.TabletType)}
+		hedgedRequests.Add(statsKey, 1)
+		go run(conn, true)
+		res = <-results
+		if res.err != nil {
+			// Give the other request a chance to succeed.
+			if other := <-results; other.err == nil {
+				res = other
+			}
+		}
+		if res.err == nil && res.hedged {
+			hedgedRequestWins.Add(statsKey, 1)
+		}
+	}
+	return res.qr, res.err
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/discovery"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+func TestHedgingPercentile(t *testing.T) {
+	assert.Equal(t, time.Duration(0), percentile(nil, 95))
+
+	var samples []time.Duration
+	for i := 100; i >= 1; i-- {
+		samples = append(samples, time.Duration(i)*time.Millisecond)
+	}
+	assert.Equal(t, 95*time.Millisecond, percentile(samples, 95))
+	assert.Equal(t, 100*time.Millisecond, percentile(samples, 100))
+	assert.Equal(t, 1*time.Millisecond, percentile(samples, 0))
+	// The input is not modified.
+	assert.Equal(t, 100*time.Millisecond, samples[0])
+}
+
+func TestHedgerDelay(t *testing.T) {
+	defer func(d, m time.Duration) {
+		*hedgingDefaultDelay = d
+		*hedgingMinDelay = m
+	}(*hedgingDefaultDelay, *hedgingMinDelay)
+	*hedgingDefaultDelay = 100 * time.Millisecond
+	*hedgingMinDelay = 5 * time.Millisecond
+
+	target := &querypb.Target{Keyspace: "ks", Shard: "0", TabletType: topodatapb.TabletType_REPLICA}
+	h := newHedger()
+
+	// Not enough samples: use the default delay.
+	assert.Equal(t, 100*time.Millisecond, h.delay(target))
+	for i := 1; i <= hedgingMinSamples; i++ {
+		h.record(target, time.Duration(i)*time.Millisecond)
+	}
+	assert.Equal(t, 95*time.Millisecond, h.delay(target))
+
+	// Other targets are tracked separately.
+	other := &querypb.Target{Keyspace: "ks", Shard: "0", TabletType: topodatapb.TabletType_RDONLY}
+	assert.Equal(t, 100*time.Millisecond, h.delay(other))
+
+	// The delay is never below the minimum.
+	h = newHedger()
+	for i := 0; i < hedgingMinSamples; i++ {
+		h.record(target, time.Millisecond)
+	}
+	assert.Equal(t, 5*time.Millisecond, h.delay(target))
+}
+
+func TestCanHedge(t *testing.T) {
+	defer func(enabled bool) { *hedgingEnabled = enabled }(*hedgingEnabled)
+
+	replica := &querypb.Target{Keyspace: "ks", Shard: "0", TabletType: topodatapb.TabletType_REPLICA}
+	rdonly := &querypb.Target{Keyspace: "ks", Shard: "0", TabletType: topodatapb.TabletType_RDONLY}
+	master := &querypb.Target{Keyspace: "ks", Shard: "0", TabletType: topodatapb.TabletType_MASTER}
+
+	*hedgingEnabled = false
+	assert.False(t, canHedge(replica, 0, 0))
+
+	*hedgingEnabled = true
+	assert.True(t, canHedge(replica, 0, 0))
+	assert.True(t, canHedge(rdonly, 0, 0))
+	assert.False(t, canHedge(master, 0, 0))
+	assert.False(t, canHedge(replica, 1, 0))
+	assert.False(t, canHedge(replica, 0, 1))
+	assert.False(t, canHedge(nil, 0, 0))
+}
+
+func TestTabletGatewayHedgedExecute(t *testing.T) {
+	defer func(enabled bool) { *hedgingEnabled = enabled }(*hedgingEnabled)
+	*hedgingEnabled = true
+
+	target := &querypb.Target{Keyspace: "ks", Shard: "0", TabletType: topodatapb.TabletType_REPLICA}
+	hc := discovery.NewFakeHealthCheck()
+	tg := NewTabletGateway(context.Background(), hc, nil, "cell")
+
+	// A single tablet: no hedging possible, the regular path is used.
+	sc1 := hc.AddTestTablet("cell", "1.1.1.1", 1001, "ks", "0", topodatapb.TabletType_REPLICA, true, 10, nil)
+	_, err := tg.Execute(context.Background(), target, "select 1", nil, 0, 0, nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, sc1.ExecCount.Get())
+
+	// Two tablets: the query succeeds and at least one tablet served it.
+	sc2 := hc.AddTestTablet("cell", "1.1.1.1", 1002, "ks", "0", topodatapb.TabletType_REPLICA, true, 10, nil)
+	_, err = tg.Execute(context.Background(), target, "select 1", nil, 0, 0, nil)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, sc1.ExecCount.Get()+sc2.ExecCount.Get(), int64(2))
+}
+
+func TestTabletGatewayHedgedExecuteRetryBudget(t *testing.T) {
+	defer func(enabled bool, ratio, maxTokens float64) {
+		*hedgingEnabled = enabled
+		*retryBudgetRatio = ratio
+		*retryBudgetMaxTokens = maxTokens
+	}(*hedgingEnabled, *retryBudgetRatio, *retryBudgetMaxTokens)
+	*hedgingEnabled = true
+
+	// The budget never allows a retry.
+	*retryBudgetRatio = 0.1
+	*retryBudgetMaxTokens = 0
+
+	target := &querypb.Target{Keyspace: "ks", Shard: "0", TabletType: topodatapb.TabletType_REPLICA}
+	hc := discovery.NewFakeHealthCheck()
+	tg := NewTabletGateway(context.Background(), hc, nil, "cell")
+	sc1 := hc.AddTestTablet("cell", "1.1.1.1", 1001, "ks", "0", topodatapb.TabletType_REPLICA, true, 10, nil)
+	sc2 := hc.AddTestTablet("cell", "1.1.1.1", 1002, "ks", "0", topodatapb.TabletType_REPLICA, true, 10, nil)
+	sc1.MustFailCodes[vtrpcpb.Code_UNAVAILABLE] = 1
+	sc2.MustFailCodes[vtrpcpb.Code_UNAVAILABLE] = 1
+
+	// The first tablet fails before the read is hedged, and the retry is
+	// denied by the budget like for the other requests.
+	statsKey := "ks.0.replica"
+	denied := gatewayRetriesDenied.Counts()[statsKey]
+	_, err := tg.Execute(context.Background(), target, "select 1", nil, 0, 0, nil)
+	require.Error(t, err)
+	assert.Equal(t, denied+1, gatewayRetriesDenied.Counts()[statsKey])
+	assert.EqualValues(t, 1, sc1.ExecCount.Get()+sc2.ExecCount.Get())
+}
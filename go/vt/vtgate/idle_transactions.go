@@ -0,0 +1,156 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"flag"
+	"time"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/log"
+
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+// This file implements the idle transaction timeout of the MySQL protocol
+// server. Unlike the transaction timeout of the tablets, it applies to the
+// whole transaction of a client, across all its shards, and attributes it
+// to the client which abandoned it.
+
+var (
+	mysqlIdleTransactionTimeout = flag.Duration("mysql_server_idle_transaction_timeout", 0, "If set, the MySQL protocol connections which stay idle for longer than this in a transaction are closed, which rolls back their transaction. The killed transactions are logged with the details of their client and counted by user in IdleTransactionsKilled.")
+
+	idleTransactionsKilled = stats.NewCountersWithSingleLabel(
+		"IdleTransactionsKilled",
+		"Number of MySQL protocol connections closed because they were idle in a transaction for longer than -mysql_server_idle_transaction_timeout, by user",
+		"User")
+)
+
+// connActivity tracks the activity of a MySQL protocol connection.
+type connActivity struct {
+	// running is true while a query of the connection is executing.
+	running bool
+	// lastActive is the end time of the last query.
+	lastActive time.Time
+	// connected is when the connection was opened, and maxAge the age
+	// after which it is recycled, if not 0.
+	connected time.Time
+	maxAge    time.Duration
+	// queries is the number of queries executed by the connection.
+	queries int
+	// recycled is true once the connection is closed after its command.
+	recycled bool
+	// The following fields describe the running query, for the process
+	// list. cancel kills it.
+	query      string
+	queryStart time.Time
+	rows       int
+	target     string
+	host       string
+	cancel     context.CancelFunc
+	// The following fields describe the session as of the end of the
+	// last query.
+	inTransaction bool
+	sessionUUID   string
+	shardSessions int
+}
+
+// queryStarted must be called before a query of the connection executes.
+func (vh *vtgateHandler) queryStarted(c *mysql.Conn) {
+	vh.mu.Lock()
+	defer vh.mu.Unlock()
+	if activity, ok := vh.connections[c]; ok {
+		activity.running = true
+	}
+}
+
+// queryFinished must be called after a query of the connection executed.
+func (vh *vtgateHandler) queryFinished(c *mysql.Conn, session *vtgatepb.Session) {
+	vh.mu.Lock()
+	defer vh.mu.Unlock()
+	activity, ok := vh.connections[c]
+	if !ok {
+		return
+	}
+	activity.running = false
+	activity.lastActive = time.Now()
+	if activity.cancel != nil {
+		activity.cancel()
+		activity.cancel = nil
+	}
+	activity.target = session.TargetString
+	activity.inTransaction = session.InTransaction
+	activity.sessionUUID = session.SessionUUID
+	activity.shardSessions = len(session.ShardSessions)
+	activity.queries++
+	if activity.recycled {
+		return
+	}
+	if reason := activity.recycleReason(activity.lastActive, session, *mysqlMaxQueriesPerConn); reason != "" {
+		activity.recycled = true
+		connectionsRecycled.Add(reason, 1)
+		c.CloseAfterCommand()
+	}
+}
+
+// idleTransactions returns the connections which have been idle in a
+// transaction for longer than timeout. vh.mu must be held.
+func (vh *vtgateHandler) idleTransactions(now time.Time, timeout time.Duration) []*mysql.Conn {
+	var idle []*mysql.Conn
+	for c, activity := range vh.connections {
+		if activity.running || !activity.inTransaction {
+			continue
+		}
+		if now.Sub(activity.lastActive) > timeout {
+			idle = append(idle, c)
+		}
+	}
+	return idle
+}
+
+// killIdleTransactions closes the connections which have been idle in a
+// transaction for longer than timeout. Their transaction is rolled back by
+// ConnectionClosed.
+func (vh *vtgateHandler) killIdleTransactions(timeout time.Duration) {
+	vh.mu.Lock()
+	defer vh.mu.Unlock()
+	now := time.Now()
+	for _, c := range vh.idleTransactions(now, timeout) {
+		activity := vh.connections[c]
+		log.Warningf("Closing connection %v of user %v from %v: idle in transaction for %v (session %v, %v shards)",
+			c.ConnectionID, c.User, c.RemoteAddr(), now.Sub(activity.lastActive), activity.sessionUUID, activity.shardSessions)
+		idleTransactionsKilled.Add(c.User, 1)
+		// Don't kill it again before it is removed by ConnectionClosed.
+		activity.inTransaction = false
+		c.Close()
+	}
+}
+
+// reapIdleTransactions runs killIdleTransactions periodically, forever.
+func (vh *vtgateHandler) reapIdleTransactions(timeout time.Duration) {
+	interval := timeout / 10
+	if interval < time.Second {
+		interval = time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		vh.killIdleTransactions(timeout)
+	}
+}
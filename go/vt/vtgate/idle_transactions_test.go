@@ -0,0 +1,62 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"vitess.io/vitess/go/mysql"
+
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+func TestIdleTransactions(t *testing.T) {
+	vh := newVtgateHandler(nil)
+	idleInTx := &mysql.Conn{ConnectionID: 1}
+	idle := &mysql.Conn{ConnectionID: 2}
+	running := &mysql.Conn{ConnectionID: 3}
+	recent := &mysql.Conn{ConnectionID: 4}
+	for _, c := range []*mysql.Conn{idleInTx, idle, running, recent} {
+		vh.NewConnection(c)
+	}
+
+	inTx := &vtgatepb.Session{InTransaction: true, ShardSessions: []*vtgatepb.Session_ShardSession{{}}}
+	vh.queryStarted(idleInTx)
+	vh.queryFinished(idleInTx, inTx)
+	vh.queryStarted(idle)
+	vh.queryFinished(idle, &vtgatepb.Session{})
+	vh.queryStarted(running)
+	vh.queryFinished(running, inTx)
+	vh.queryStarted(running)
+
+	now := time.Now().Add(time.Minute)
+	vh.queryStarted(recent)
+	vh.queryFinished(recent, inTx)
+	vh.connections[recent].lastActive = now
+
+	assert.Equal(t, []*mysql.Conn{idleInTx}, vh.idleTransactions(now, 30*time.Second))
+	assert.Empty(t, vh.idleTransactions(now, 2*time.Minute))
+	assert.Equal(t, 1, vh.connections[idleInTx].shardSessions)
+
+	// Once the transaction is over, it's not idle in a transaction anymore.
+	vh.queryStarted(idleInTx)
+	vh.queryFinished(idleInTx, &vtgatepb.Session{})
+	assert.Empty(t, vh.idleTransactions(time.Now().Add(time.Minute), 30*time.Second))
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"flag"
+	"sort"
+	"strings"
+	"sync"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/topo"
+)
+
+var enableKeyspaceServingFlags = flag.Bool("enable_keyspace_serving_flags", false, "Show the serving flags of the keyspaces, set in their keyspace records by the SetKeyspaceServingFlags vtctl command, with SHOW VITESS_KEYSPACE_FLAGS and @@vitess_keyspace_flags. They are reloaded from the topo every -keyspace_settings_refresh_interval.")
+
+// keyspaceServingFlags caches the serving flags of the keyspaces, set in
+// their keyspace records by the SetKeyspaceServingFlags vtctl command, so
+// the clients can see the operations in progress on the keyspaces.
+type keyspaceServingFlags struct {
+	mu    sync.RWMutex
+	flags map[string][]string
+}
+
+func newKeyspaceServingFlags() *keyspaceServingFlags {
+	return &keyspaceServingFlags{flags: make(map[string][]string)}
+}
+
+// get returns the serving flags of the keyspace. It is safe to call on a
+// nil receiver.
+func (kf *keyspaceServingFlags) get(keyspace string) []string {
+	if kf == nil {
+		return nil
+	}
+	kf.mu.RLock()
+	defer kf.mu.RUnlock()
+	return kf.flags[keyspace]
+}
+
+// getAll returns the serving flags of all the keyspaces which have some.
+// It is safe to call on a nil receiver.
+func (kf *keyspaceServingFlags) getAll() map[string][]string {
+	if kf == nil {
+		return nil
+	}
+	kf.mu.RLock()
+	defer kf.mu.RUnlock()
+	return kf.flags
+}
+
+func (kf *keyspaceServingFlags) set(flags map[string][]string) {
+	kf.mu.Lock()
+	defer kf.mu.Unlock()
+	kf.flags = flags
+}
+
+// refresh is part of the keyspaceSetting interface.
+func (kf *keyspaceServingFlags) refresh(ctx context.Context, ts *topo.Server, keyspaces []string) {
+	flags := make(map[string][]string)
+	for _, keyspace := range keyspaces {
+		var keyspaceFlags []string
+		ki, err := ts.GetKeyspace(ctx, keyspace)
+		if err != nil {
+			log.Warningf("Cannot read the serving flags of keyspace %v: %v", keyspace, err)
+			keyspaceFlags = kf.get(keyspace)
+		} else {
+			keyspaceFlags = ki.ServingFlags
+		}
+		if len(keyspaceFlags) > 0 {
+			flags[keyspace] = keyspaceFlags
+		}
+	}
+	kf.set(flags)
+}
+
+// result returns the serving flags of the keyspaces as the result of
+// SHOW VITESS_KEYSPACE_FLAGS: one row per keyspace which has some, with
+// the flags comma separated.
+func (kf *keyspaceServingFlags) result() *sqltypes.Result {
+	all := kf.getAll()
+	keyspaces := make([]string, 0, len(all))
+	for keyspace := range all {
+		keyspaces = append(keyspaces, keyspace)
+	}
+	sort.Strings(keyspaces)
+	rows := make([][]sqltypes.Value, 0, len(keyspaces))
+	for _, keyspace := range keyspaces {
+		rows = append(rows, buildVarCharRow(keyspace, strings.Join(all[keyspace], ",")))
+	}
+	return &sqltypes.Result{
+		Fields: buildVarCharFields("Keyspace", "Flags"),
+		Rows:   rows,
+	}
+}
+
+// startKeyspaceServingFlags adds the serving flags of the keyspaces to the
+// loader. It returns nil if they are disabled or can't be loaded.
+func startKeyspaceServingFlags(loader *keyspaceSettingsLoader) *keyspaceServingFlags {
+	if !*enableKeyspaceServingFlags {
+		return nil
+	}
+	kf := newKeyspaceServingFlags()
+	if !loader.add(kf) {
+		return nil
+	}
+	return kf
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+func TestKeyspaceServingFlags(t *testing.T) {
+	ctx := context.Background()
+	ts := memorytopo.NewServer("cell1")
+	require.NoError(t, ts.CreateKeyspace(ctx, "ks1", &topodatapb.Keyspace{ServingFlags: []string{topo.ServingFlagReshardingInProgress}}))
+	require.NoError(t, ts.CreateKeyspace(ctx, "ks2", &topodatapb.Keyspace{ServingFlags: []string{topo.ServingFlagReadOnlyMaintenance, topo.ServingFlagReshardingInProgress}}))
+	require.NoError(t, ts.CreateKeyspace(ctx, "ks3", &topodatapb.Keyspace{}))
+
+	kf := newKeyspaceServingFlags()
+	refreshKeyspaceSetting(ctx, t, ts, kf)
+	assert.Equal(t, []string{topo.ServingFlagReshardingInProgress}, kf.get("ks1"))
+	assert.Equal(t, []string{topo.ServingFlagReadOnlyMaintenance, topo.ServingFlagReshardingInProgress}, kf.get("ks2"))
+	assert.Empty(t, kf.get("ks3"))
+
+	result := kf.result()
+	assert.Equal(t, [][]sqltypes.Value{
+		buildVarCharRow("ks1", "resharding_in_progress"),
+		buildVarCharRow("ks2", "read_only_maintenance,resharding_in_progress"),
+	}, result.Rows)
+
+	// Removed flags are dropped on refresh.
+	lockCtx, unlock, err := ts.LockKeyspace(ctx, "ks1", "test")
+	require.NoError(t, err)
+	ki, err := ts.GetKeyspace(lockCtx, "ks1")
+	require.NoError(t, err)
+	ki.ServingFlags = nil
+	require.NoError(t, ts.UpdateKeyspace(lockCtx, ki))
+	unlock(&err)
+	require.NoError(t, err)
+	refreshKeyspaceSetting(ctx, t, ts, kf)
+	assert.Empty(t, kf.get("ks1"))
+
+	// Disabled.
+	kf = nil
+	assert.Empty(t, kf.get("ks2"))
+	assert.Empty(t, kf.result().Rows)
+}
+
+func TestExecutorKeyspaceServingFlags(t *testing.T) {
+	executor, _, _, _ := createLegacyExecutorEnv()
+	// The system variables are only rewritten to bind variables when the
+	// queries are normalized.
+	executor.normalize = true
+	executor.servingFlags = newKeyspaceServingFlags()
+	executor.servingFlags.set(map[string][]string{
+		KsTestSharded: {topo.ServingFlagReadOnlyMaintenance, topo.ServingFlagReshardingInProgress},
+	})
+
+	result, err := executor.Execute(context.Background(), "TestExecute", NewSafeSession(masterSession), "show vitess_keyspace_flags", nil)
+	require.NoError(t, err)
+	assert.Equal(t, [][]sqltypes.Value{
+		buildVarCharRow(KsTestSharded, "read_only_maintenance,resharding_in_progress"),
+	}, result.Rows)
+
+	session := NewSafeSession(&vtgatepb.Session{TargetString: KsTestSharded})
+	result, err = executor.Execute(context.Background(), "TestExecute", session, "select @@vitess_keyspace_flags", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "read_only_maintenance,resharding_in_progress", result.Rows[0][0].ToString())
+
+	session = NewSafeSession(&vtgatepb.Session{TargetString: KsTestUnsharded})
+	result, err = executor.Execute(context.Background(), "TestExecute", session, "select @@vitess_keyspace_flags", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "", result.Rows[0][0].ToString())
+
+	_, err = executor.Execute(context.Background(), "TestExecute", session, "set @@vitess_keyspace_flags = 'x'", nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"flag"
+	"time"
+
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/srvtopo"
+	"vitess.io/vitess/go/vt/topo"
+)
+
+// This file implements the loader of the keyspace settings that vtgate
+// reads from the global topo, e.g. the serving flags of the keyspaces.
+// All the settings are opt-in, and the enabled ones are reloaded together
+// by a single poller, which lists the keyspaces once per refresh for all
+// of them.
+
+var keyspaceSettingsRefreshInterval = flag.Duration("keyspace_settings_refresh_interval", time.Minute, "How often the keyspace settings enabled by the -enable_keyspace_* flags are reloaded from the topo")
+
+// keyspaceSetting is a per keyspace setting reloaded by the
+// keyspaceSettingsLoader.
+type keyspaceSetting interface {
+	// refresh reloads the setting of the keyspaces. If a keyspace can't
+	// be read, its previous setting is kept.
+	refresh(ctx context.Context, ts *topo.Server, keyspaces []string)
+}
+
+// keyspaceSettingsLoader reloads the enabled keyspace settings.
+type keyspaceSettingsLoader struct {
+	ts       *topo.Server
+	settings []keyspaceSetting
+}
+
+// newKeyspaceSettingsLoader returns a loader reading the settings from the
+// topo server of serv. If it is not available, no setting can be added.
+func newKeyspaceSettingsLoader(serv srvtopo.Server) *keyspaceSettingsLoader {
+	ts, err := serv.GetTopoServer()
+	if err != nil || ts == nil {
+		log.Warningf("Keyspace settings disabled, topo server not available: %v", err)
+		return &keyspaceSettingsLoader{}
+	}
+	return &keyspaceSettingsLoader{ts: ts}
+}
+
+// add adds a setting to reload. It returns false if the topo server is not
+// available, and the setting can't be loaded.
+func (l *keyspaceSettingsLoader) add(setting keyspaceSetting) bool {
+	if l.ts == nil {
+		return false
+	}
+	l.settings = append(l.settings, setting)
+	return true
+}
+
+// refresh reloads all the settings.
+func (l *keyspaceSettingsLoader) refresh(ctx context.Context) error {
+	keyspaces, err := l.ts.GetKeyspaces(ctx)
+	if err != nil {
+		return err
+	}
+	for _, setting := range l.settings {
+		setting.refresh(ctx, l.ts, keyspaces)
+	}
+	return nil
+}
+
+// start refreshes the settings every -keyspace_settings_refresh_interval
+// until ctx is done. It doesn't start the poller if no setting was added.
+func (l *keyspaceSettingsLoader) start(ctx context.Context) {
+	if len(l.settings) == 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(*keyspaceSettingsRefreshInterval)
+		defer ticker.Stop()
+		for {
+			if err := l.refresh(ctx); err != nil {
+				log.Warningf("Cannot refresh the keyspace settings: %v", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/srvtopo"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// countingSetting records the keyspaces of its refreshes.
+type countingSetting struct {
+	refreshes [][]string
+}
+
+func (cs *countingSetting) refresh(ctx context.Context, ts *topo.Server, keyspaces []string) {
+	cs.refreshes = append(cs.refreshes, keyspaces)
+}
+
+// refreshKeyspaceSetting reloads a single setting from ts.
+func refreshKeyspaceSetting(ctx context.Context, t *testing.T, ts *topo.Server, setting keyspaceSetting) {
+	t.Helper()
+	l := &keyspaceSettingsLoader{ts: ts}
+	require.True(t, l.add(setting))
+	require.NoError(t, l.refresh(ctx))
+}
+
+func TestKeyspaceSettingsLoader(t *testing.T) {
+	ctx := context.Background()
+	ts := memorytopo.NewServer("cell1")
+	for _, keyspace := range []string{"ks1", "ks2"} {
+		require.NoError(t, ts.CreateKeyspace(ctx, keyspace, &topodatapb.Keyspace{}))
+	}
+
+	l := newKeyspaceSettingsLoader(srvtopo.NewResilientServer(ts, "TestKeyspaceSettingsLoader"))
+	cs1, cs2 := &countingSetting{}, &countingSetting{}
+	require.True(t, l.add(cs1))
+	require.True(t, l.add(cs2))
+	require.NoError(t, l.refresh(ctx))
+	assert.Equal(t, [][]string{{"ks1", "ks2"}}, cs1.refreshes)
+	assert.Equal(t, [][]string{{"ks1", "ks2"}}, cs2.refreshes)
+
+	// Without a topo server, no setting is loaded.
+	l = &keyspaceSettingsLoader{}
+	assert.False(t, l.add(cs1))
+	l.start(ctx)
+}
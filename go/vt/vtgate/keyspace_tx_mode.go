@@ -0,0 +1,44 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+// mergeTxModes returns the transaction mode of a transaction which spans
+// keyspaces with the given default modes: the most restrictive one wins,
+// and def applies if none of them is set.
+func mergeTxModes(def vtgatepb.TransactionMode, modes ...vtgatepb.TransactionMode) vtgatepb.TransactionMode {
+	merged := vtgatepb.TransactionMode_UNSPECIFIED
+	for _, mode := range modes {
+		switch mode {
+		case vtgatepb.TransactionMode_SINGLE:
+			return vtgatepb.TransactionMode_SINGLE
+		case vtgatepb.TransactionMode_TWOPC:
+			merged = vtgatepb.TransactionMode_TWOPC
+		case vtgatepb.TransactionMode_MULTI:
+			if merged == vtgatepb.TransactionMode_UNSPECIFIED {
+				merged = vtgatepb.TransactionMode_MULTI
+			}
+		}
+	}
+	if merged == vtgatepb.TransactionMode_UNSPECIFIED {
+		return def
+	}
+	return merged
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+func TestMergeTxModes(t *testing.T) {
+	multi := vtgatepb.TransactionMode_MULTI
+	single := vtgatepb.TransactionMode_SINGLE
+	twopc := vtgatepb.TransactionMode_TWOPC
+	unspecified := vtgatepb.TransactionMode_UNSPECIFIED
+
+	assert.Equal(t, multi, mergeTxModes(multi))
+	assert.Equal(t, twopc, mergeTxModes(twopc, unspecified, unspecified))
+	assert.Equal(t, multi, mergeTxModes(twopc, multi, unspecified))
+	assert.Equal(t, twopc, mergeTxModes(single, multi, twopc))
+	assert.Equal(t, single, mergeTxModes(multi, twopc, single, multi))
+}
+
+func TestKeyspaceTxModes(t *testing.T) {
+	vschema := vindexes.BuildVSchema(&vschemapb.SrvVSchema{
+		Keyspaces: map[string]*vschemapb.Keyspace{
+			"ks1": {TransactionMode: vtgatepb.TransactionMode_SINGLE},
+			"ks2": {TransactionMode: vtgatepb.TransactionMode_TWOPC},
+			"ks3": {},
+		},
+	})
+	txc := &TxConn{mode: vtgatepb.TransactionMode_MULTI, vschema: func() *vindexes.VSchema { return vschema }}
+	session := NewSafeSession(&vtgatepb.Session{InTransaction: true})
+	assert.Equal(t, vtgatepb.TransactionMode_MULTI, txc.txMode(session, "ks3"))
+	assert.Equal(t, vtgatepb.TransactionMode_TWOPC, txc.txMode(session, "ks2"))
+	assert.Equal(t, vtgatepb.TransactionMode_MULTI, txc.txMode(session, "nosuchks"))
+
+	// A transaction already in a SINGLE keyspace can't span another one.
+	require.NoError(t, session.AppendOrUpdate(&vtgatepb.Session_ShardSession{
+		Target:        &querypb.Target{Keyspace: "ks1", Shard: "0", TabletType: topodatapb.TabletType_MASTER},
+		TransactionId: 1,
+	}, txc.txMode(session, "ks1")))
+	err := session.AppendOrUpdate(&vtgatepb.Session_ShardSession{
+		Target:        &querypb.Target{Keyspace: "ks3", Shard: "0", TabletType: topodatapb.TabletType_MASTER},
+		TransactionId: 2,
+	}, txc.txMode(session, "ks3"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "multi-db transaction attempted")
+
+	// The mode of the session wins.
+	session = NewSafeSession(&vtgatepb.Session{InTransaction: true, TransactionMode: vtgatepb.TransactionMode_MULTI})
+	require.NoError(t, session.AppendOrUpdate(&vtgatepb.Session_ShardSession{
+		Target:        &querypb.Target{Keyspace: "ks1", Shard: "0", TabletType: topodatapb.TabletType_MASTER},
+		TransactionId: 1,
+	}, txc.txMode(session, "ks1")))
+	require.NoError(t, session.AppendOrUpdate(&vtgatepb.Session_ShardSession{
+		Target:        &querypb.Target{Keyspace: "ks3", Shard: "0", TabletType: topodatapb.TabletType_MASTER},
+		TransactionId: 2,
+	}, txc.txMode(session, "ks3")))
+
+	// No VSchema.
+	txc.vschema = nil
+	assert.Equal(t, vtgatepb.TransactionMode_MULTI, txc.txMode(session, "ks2"))
+}
@@ -0,0 +1,277 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"vitess.io/vitess/go/flagutil"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+// This file implements the mirroring of read queries to a shadow keyspace,
+// e.g. a copy of a keyspace with a new sharding scheme under test. A sample
+// of the SELECTs which run outside of a transaction on a mirrored keyspace
+// are run again, asynchronously, on its shadow keyspace, and the latency,
+// errors and results of both are compared. The response to the client
+// doesn't wait for the shadow query.
+
+var (
+	mirrorKeyspaces      flagutil.StringMapValue
+	mirrorTables         flagutil.StringListValue
+	mirrorPercent        = flag.Float64("mirror_percent", 0, "Percentage of the eligible read queries which are mirrored to the shadow keyspace of -mirror_keyspaces, between 0 and 100.")
+	mirrorMaxConcurrency = flag.Int("mirror_max_concurrency", 10, "Maximum number of mirrored queries in flight. Queries are not mirrored when it is reached.")
+	mirrorTimeout        = flag.Duration("mirror_timeout", 5*time.Second, "Timeout of a mirrored query.")
+
+	mirrorQueries = stats.NewCountersWithMultiLabels(
+		"MirrorQueries",
+		"Number of queries mirrored to a shadow keyspace, by outcome of the comparison with the original query",
+		[]string{"Keyspace", "Result"})
+	mirrorLatencies = stats.NewMultiTimings(
+		"MirrorLatencies",
+		"Latency of the original and mirrored queries",
+		[]string{"Keyspace", "Side"})
+)
+
+func init() {
+	flag.Var(&mirrorKeyspaces, "mirror_keyspaces", "Comma separated list of keyspace:shadow_keyspace. A sample of the read queries on keyspace are mirrored to shadow_keyspace, see -mirror_percent.")
+	flag.Var(&mirrorTables, "mirror_tables", "Comma separated list of tables. If set, only the queries which read one of them are mirrored.")
+}
+
+// Results of a mirrored query.
+const (
+	mirrorMatch         = "Match"
+	mirrorRowsMismatch  = "RowsMismatch"
+	mirrorShadowError   = "ShadowError"
+	mirrorPrimaryError  = "PrimaryError"
+	mirrorBothError     = "BothError"
+	mirrorSkippedBusy   = "SkippedBusy"
+	mirrorRewriteFailed = "RewriteFailed"
+)
+
+// mirrorExecFunc executes a query for the mirror.
+type mirrorExecFunc func(ctx context.Context, session *SafeSession, sql string, bindVars map[string]*querypb.BindVariable) (*sqltypes.Result, error)
+
+// queryMirror mirrors queries to shadow keyspaces.
+type queryMirror struct {
+	shadows map[string]string
+	tables  map[string]bool
+	percent float64
+	timeout time.Duration
+	exec    mirrorExecFunc
+	// slots bounds the number of mirrored queries in flight.
+	slots  chan struct{}
+	logger *logutil.ThrottledLogger
+}
+
+// newQueryMirror returns the queryMirror configured by the flags, or nil if
+// mirroring is disabled.
+func newQueryMirror(exec mirrorExecFunc) *queryMirror {
+	if len(mirrorKeyspaces) == 0 || *mirrorPercent <= 0 {
+		return nil
+	}
+	qm := &queryMirror{
+		shadows: make(map[string]string),
+		percent: *mirrorPercent,
+		timeout: *mirrorTimeout,
+		exec:    exec,
+		slots:   make(chan struct{}, *mirrorMaxConcurrency),
+		logger:  logutil.NewThrottledLogger("QueryMirror", 5*time.Second),
+	}
+	for keyspace, shadow := range mirrorKeyspaces {
+		qm.shadows[keyspace] = shadow
+	}
+	if len(mirrorTables) > 0 {
+		qm.tables = make(map[string]bool)
+		for _, table := range mirrorTables {
+			qm.tables[table] = true
+		}
+	}
+	return qm
+}
+
+// maybeMirror mirrors the query, if it is eligible and sampled. It returns
+// immediately. It is safe to call on a nil receiver.
+func (qm *queryMirror) maybeMirror(session *SafeSession, stmtType sqlparser.StatementType, keyspace, sql string, bindVars map[string]*querypb.BindVariable, result *sqltypes.Result, err error, latency time.Duration) {
+	if qm == nil || stmtType != sqlparser.StmtSelect || session.InTransaction() {
+		return
+	}
+	shadow, ok := qm.shadows[keyspace]
+	if !ok || rand.Float64()*100 >= qm.percent {
+		return
+	}
+	select {
+	case qm.slots <- struct{}{}:
+	default:
+		mirrorQueries.Add([]string{keyspace, mirrorSkippedBusy}, 1)
+		return
+	}
+
+	shadowSQL, tables, rewriteErr := rewriteForShadow(sql, keyspace, shadow)
+	if rewriteErr != nil {
+		<-qm.slots
+		mirrorQueries.Add([]string{keyspace, mirrorRewriteFailed}, 1)
+		return
+	}
+	if !qm.matchesTables(tables) {
+		<-qm.slots
+		return
+	}
+	target, ok := shadowTarget(session.TargetString, shadow)
+	if !ok {
+		<-qm.slots
+		return
+	}
+	shadowSession := NewSafeSession(&vtgatepb.Session{
+		TargetString: target,
+		Autocommit:   true,
+		Options:      proto.Clone(session.GetOptions()).(*querypb.ExecuteOptions),
+	})
+	shadowBindVars := make(map[string]*querypb.BindVariable, len(bindVars))
+	for k, v := range bindVars {
+		shadowBindVars[k] = v
+	}
+	// The caller owns the result once this returns.
+	primary := summarizeResult(result, err)
+
+	go func() {
+		defer func() { <-qm.slots }()
+		ctx, cancel := context.WithTimeout(context.Background(), qm.timeout)
+		defer cancel()
+		start := time.Now()
+		shadowResult, shadowErr := qm.exec(ctx, shadowSession, shadowSQL, shadowBindVars)
+		mirrorLatencies.Add([]string{keyspace, "Primary"}, latency)
+		mirrorLatencies.Record([]string{keyspace, "Shadow"}, start)
+		outcome := compareMirrorResults(primary, summarizeResult(shadowResult, shadowErr))
+		mirrorQueries.Add([]string{keyspace, outcome}, 1)
+		if outcome != mirrorMatch {
+			qm.logger.Warningf("Mirrored query on %v differs from %v: %v, primary error: %v, shadow error: %v", shadow, keyspace, outcome, err, shadowErr)
+		}
+	}()
+}
+
+func (qm *queryMirror) matchesTables(tables []string) bool {
+	if qm.tables == nil {
+		return true
+	}
+	for _, table := range tables {
+		if qm.tables[table] {
+			return true
+		}
+	}
+	return false
+}
+
+// rewriteForShadow replaces the keyspace qualifiers of the tables of the
+// query with the shadow keyspace, and returns the names of the tables.
+func rewriteForShadow(sql, keyspace, shadow string) (string, []string, error) {
+	stmt, err := sqlparser.Parse(sql)
+	if err != nil {
+		return "", nil, err
+	}
+	var tables []string
+	stmt = sqlparser.Rewrite(stmt, func(cursor *sqlparser.Cursor) bool {
+		tableName, ok := cursor.Node().(sqlparser.TableName)
+		if !ok || tableName.Name.IsEmpty() {
+			return true
+		}
+		if !tableName.Qualifier.IsEmpty() && tableName.Qualifier.String() != keyspace {
+			return true
+		}
+		tables = append(tables, tableName.Name.String())
+		if tableName.Qualifier.String() == keyspace {
+			tableName.Qualifier = sqlparser.NewTableIdent(shadow)
+			cursor.Replace(tableName)
+		}
+		return true
+	}, nil).(sqlparser.Statement)
+	return sqlparser.String(stmt), tables, nil
+}
+
+// shadowTarget returns the target string of the shadow query, with the
+// tablet type of the original one. Queries which target a shard or a
+// keyrange are not mirrored.
+func shadowTarget(targetString, shadow string) (string, bool) {
+	_, tabletType, dest, err := topoproto.ParseDestination(targetString, defaultTabletType)
+	if err != nil || dest != nil {
+		return "", false
+	}
+	return fmt.Sprintf("%s@%s", shadow, topoproto.TabletTypeLString(tabletType)), true
+}
+
+// resultSummary is what is compared of the result of a mirrored query.
+type resultSummary struct {
+	rows        int
+	fingerprint uint64
+	err         error
+}
+
+// summarizeResult returns the summary of the result of a query, or of its
+// error.
+func summarizeResult(result *sqltypes.Result, err error) resultSummary {
+	if err != nil {
+		return resultSummary{err: err}
+	}
+	return resultSummary{rows: len(result.Rows), fingerprint: rowsFingerprint(result)}
+}
+
+// compareMirrorResults returns the outcome of a mirrored query. The rows
+// are compared regardless of their order, as scatter queries don't
+// guarantee one.
+func compareMirrorResults(primary, shadow resultSummary) string {
+	switch {
+	case primary.err != nil && shadow.err != nil:
+		return mirrorBothError
+	case primary.err != nil:
+		return mirrorPrimaryError
+	case shadow.err != nil:
+		return mirrorShadowError
+	}
+	if primary.rows != shadow.rows || primary.fingerprint != shadow.fingerprint {
+		return mirrorRowsMismatch
+	}
+	return mirrorMatch
+}
+
+// rowsFingerprint returns a hash of the rows which doesn't depend on their
+// order.
+func rowsFingerprint(result *sqltypes.Result) uint64 {
+	var sum uint64
+	for _, row := range result.Rows {
+		h := fnv.New64a()
+		for _, value := range row {
+			h.Write([]byte(value.String()))
+			h.Write([]byte{0})
+		}
+		sum += h.Sum64()
+	}
+	return sum
+}
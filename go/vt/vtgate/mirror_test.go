@@ -0,0 +1,138 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/sqlparser"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+func TestRewriteForShadow(t *testing.T) {
+	sql, tables, err := rewriteForShadow("select a.id, ks.b.x from ks.a join b on a.id = b.id where other.c.y = 1", "ks", "shadow")
+	require.NoError(t, err)
+	assert.Equal(t, "select a.id, shadow.b.x from shadow.a join b on a.id = b.id where other.c.y = 1", sql)
+	assert.Contains(t, tables, "a")
+	assert.Contains(t, tables, "b")
+	assert.NotContains(t, tables, "c")
+
+	_, _, err = rewriteForShadow("select from", "ks", "shadow")
+	assert.Error(t, err)
+}
+
+func TestShadowTarget(t *testing.T) {
+	target, ok := shadowTarget("ks@replica", "shadow")
+	assert.True(t, ok)
+	assert.Equal(t, "shadow@replica", target)
+
+	_, ok = shadowTarget("ks:-80@replica", "shadow")
+	assert.False(t, ok)
+}
+
+func TestCompareMirrorResults(t *testing.T) {
+	r1 := sqltypes.MakeTestResult(sqltypes.MakeTestFields("id|v", "int64|varchar"), "1|a", "2|b")
+	r2 := sqltypes.MakeTestResult(sqltypes.MakeTestFields("id|v", "int64|varchar"), "2|b", "1|a")
+	r3 := sqltypes.MakeTestResult(sqltypes.MakeTestFields("id|v", "int64|varchar"), "1|a", "2|c")
+	err := fmt.Errorf("err")
+
+	compare := func(primary *sqltypes.Result, primaryErr error, shadow *sqltypes.Result, shadowErr error) string {
+		return compareMirrorResults(summarizeResult(primary, primaryErr), summarizeResult(shadow, shadowErr))
+	}
+	assert.Equal(t, mirrorMatch, compare(r1, nil, r2, nil))
+	assert.Equal(t, mirrorRowsMismatch, compare(r1, nil, r3, nil))
+	assert.Equal(t, mirrorRowsMismatch, compare(r1, nil, &sqltypes.Result{}, nil))
+	assert.Equal(t, mirrorShadowError, compare(r1, nil, nil, err))
+	assert.Equal(t, mirrorPrimaryError, compare(nil, err, r1, nil))
+	assert.Equal(t, mirrorBothError, compare(nil, err, nil, err))
+}
+
+func TestQueryMirror(t *testing.T) {
+	type mirrored struct {
+		target string
+		sql    string
+	}
+	done := make(chan mirrored, 10)
+	result := sqltypes.MakeTestResult(sqltypes.MakeTestFields("id", "int64"), "1")
+	qm := &queryMirror{
+		shadows: map[string]string{"ks": "shadow"},
+		tables:  map[string]bool{"t1": true},
+		percent: 100,
+		timeout: time.Second,
+		slots:   make(chan struct{}, 1),
+		logger:  logutil.NewThrottledLogger("TestQueryMirror", time.Second),
+		exec: func(ctx context.Context, session *SafeSession, sql string, bindVars map[string]*querypb.BindVariable) (*sqltypes.Result, error) {
+			done <- mirrored{target: session.TargetString, sql: sql}
+			return result, nil
+		},
+	}
+	session := NewSafeSession(&vtgatepb.Session{TargetString: "ks@replica", Autocommit: true})
+	before := mirrorQueries.Counts()["ks.Match"]
+
+	qm.maybeMirror(session, sqlparser.StmtSelect, "ks", "select id from t1", nil, result, nil, time.Millisecond)
+	select {
+	case got := <-done:
+		assert.Equal(t, mirrored{target: "shadow@replica", sql: "select id from t1"}, got)
+	case <-time.After(5 * time.Second):
+		t.Fatal("query not mirrored")
+	}
+	assert.Eventually(t, func() bool {
+		return mirrorQueries.Counts()["ks.Match"] == before+1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Not eligible: other table, other keyspace, not a select, or in a
+	// transaction.
+	qm.maybeMirror(session, sqlparser.StmtSelect, "ks", "select id from t2", nil, result, nil, time.Millisecond)
+	qm.maybeMirror(session, sqlparser.StmtSelect, "ks2", "select id from t1", nil, result, nil, time.Millisecond)
+	qm.maybeMirror(session, sqlparser.StmtUpdate, "ks", "update t1 set id = 1", nil, result, nil, time.Millisecond)
+	txSession := NewSafeSession(&vtgatepb.Session{TargetString: "ks@replica", InTransaction: true})
+	qm.maybeMirror(txSession, sqlparser.StmtSelect, "ks", "select id from t1", nil, result, nil, time.Millisecond)
+	select {
+	case got := <-done:
+		t.Errorf("unexpected mirrored query: %v", got)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// The result is compared as it was returned, even if the caller modifies
+	// it while the query is mirrored.
+	release := make(chan struct{})
+	qm.exec = func(ctx context.Context, session *SafeSession, sql string, bindVars map[string]*querypb.BindVariable) (*sqltypes.Result, error) {
+		<-release
+		return sqltypes.MakeTestResult(sqltypes.MakeTestFields("id", "int64"), "1"), nil
+	}
+	returned := sqltypes.MakeTestResult(sqltypes.MakeTestFields("id", "int64"), "1")
+	qm.maybeMirror(session, sqlparser.StmtSelect, "ks", "select id from t1", nil, returned, nil, time.Millisecond)
+	returned.Rows = nil
+	close(release)
+	assert.Eventually(t, func() bool {
+		return mirrorQueries.Counts()["ks.Match"] == before+2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// A nil mirror is a no-op.
+	var nilMirror *queryMirror
+	nilMirror.maybeMirror(session, sqlparser.StmtSelect, "ks", "select id from t1", nil, result, nil, time.Millisecond)
+}
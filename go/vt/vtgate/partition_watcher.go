@@ -0,0 +1,229 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"flag"
+	"sort"
+	"sync"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/srvtopo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+var (
+	enablePartitionWatch = flag.Bool("enable_partition_watch", true, "Watch the SrvKeyspace partitions of the keyspaces of the VSchema, to clear the query plans and buffer the MASTER requests of the shards which stop serving during a resharding, instead of waiting for the queries to fail on them.")
+
+	partitionChanges = stats.NewCountersWithMultiLabels(
+		"VtgatePartitionChanges",
+		"Changes of the keyspace partitions seen by the partition watcher",
+		[]string{"Keyspace", "TabletType"})
+)
+
+// partitionChange describes how the partition of a keyspace for a tablet
+// type changed, e.g. during the SwitchReads or SwitchWrites of a resharding.
+type partitionChange struct {
+	Keyspace   string
+	TabletType topodatapb.TabletType
+	// Added are the shards which started serving the tablet type.
+	Added []string
+	// Removed are the shards which stopped serving the tablet type.
+	Removed []string
+	// Disabled are the shards which still serve the tablet type, but whose
+	// query service was disabled, e.g. right before the partition switches.
+	Disabled []string
+	// Enabled are the shards whose query service was enabled again.
+	Enabled []string
+}
+
+func (pc *partitionChange) empty() bool {
+	return len(pc.Added) == 0 && len(pc.Removed) == 0 && len(pc.Disabled) == 0 && len(pc.Enabled) == 0
+}
+
+// servedShards maps the shards serving a tablet type to whether their
+// query service is disabled.
+type servedShards map[string]bool
+
+// partitionWatcher watches the SrvKeyspace of the keyspaces in the local
+// cell and calls its listeners when their partitions change, so vtgate
+// doesn't learn about a resharding only from the queries which fail on the
+// old shards.
+type partitionWatcher struct {
+	ctx  context.Context
+	serv srvtopo.Server
+	cell string
+
+	// mu protects the fields below.
+	mu        sync.Mutex
+	listeners []func(*partitionChange)
+	// cancels has the cancel function of the watch of each keyspace.
+	cancels map[string]context.CancelFunc
+	// partitions has the last partitions seen for each keyspace.
+	partitions map[string]map[topodatapb.TabletType]servedShards
+}
+
+func newPartitionWatcher(ctx context.Context, serv srvtopo.Server, cell string) *partitionWatcher {
+	return &partitionWatcher{
+		ctx:        ctx,
+		serv:       serv,
+		cell:       cell,
+		cancels:    make(map[string]context.CancelFunc),
+		partitions: make(map[string]map[topodatapb.TabletType]servedShards),
+	}
+}
+
+// subscribe adds a listener for the partition changes. It must not block.
+func (pw *partitionWatcher) subscribe(listener func(*partitionChange)) {
+	pw.mu.Lock()
+	defer pw.mu.Unlock()
+	pw.listeners = append(pw.listeners, listener)
+}
+
+// watchKeyspaces starts watching the keyspaces which are not watched yet,
+// and stops watching the ones which are not in the list anymore.
+func (pw *partitionWatcher) watchKeyspaces(keyspaces []string) {
+	pw.mu.Lock()
+	defer pw.mu.Unlock()
+
+	wanted := make(map[string]bool)
+	for _, keyspace := range keyspaces {
+		wanted[keyspace] = true
+		if _, ok := pw.cancels[keyspace]; ok {
+			continue
+		}
+		ctx, cancel := context.WithCancel(pw.ctx)
+		pw.cancels[keyspace] = cancel
+		go pw.watch(ctx, keyspace)
+	}
+	for keyspace, cancel := range pw.cancels {
+		if !wanted[keyspace] {
+			cancel()
+			delete(pw.cancels, keyspace)
+			delete(pw.partitions, keyspace)
+		}
+	}
+}
+
+func (pw *partitionWatcher) watch(ctx context.Context, keyspace string) {
+	pw.serv.WatchSrvKeyspace(ctx, pw.cell, keyspace, func(srvKeyspace *topodatapb.SrvKeyspace, err error) bool {
+		if ctx.Err() != nil {
+			return false
+		}
+		if err != nil {
+			log.Warningf("Cannot watch the partitions of keyspace %v: %v", keyspace, err)
+			return true
+		}
+		pw.update(keyspace, srvKeyspace)
+		return true
+	})
+}
+
+// update records the partitions of a new SrvKeyspace value, and calls the
+// listeners with their changes. The first value of a keyspace is only
+// recorded.
+func (pw *partitionWatcher) update(keyspace string, srvKeyspace *topodatapb.SrvKeyspace) {
+	partitions := make(map[topodatapb.TabletType]servedShards)
+	for _, partition := range srvKeyspace.GetPartitions() {
+		shards := make(servedShards)
+		for _, ref := range partition.ShardReferences {
+			shards[ref.Name] = false
+		}
+		for _, control := range partition.ShardTabletControls {
+			if _, ok := shards[control.Name]; ok && control.QueryServiceDisabled {
+				shards[control.Name] = true
+			}
+		}
+		partitions[partition.ServedType] = shards
+	}
+
+	pw.mu.Lock()
+	if _, ok := pw.cancels[keyspace]; !ok {
+		// The keyspace isn't watched anymore.
+		pw.mu.Unlock()
+		return
+	}
+	old, ok := pw.partitions[keyspace]
+	pw.partitions[keyspace] = partitions
+	listeners := pw.listeners
+	pw.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	for _, change := range diffPartitions(keyspace, old, partitions) {
+		log.Infof("Partition of keyspace %v for %v changed: added %v, removed %v, disabled %v, enabled %v",
+			keyspace, topoproto.TabletTypeLString(change.TabletType), change.Added, change.Removed, change.Disabled, change.Enabled)
+		partitionChanges.Add([]string{keyspace, topoproto.TabletTypeLString(change.TabletType)}, 1)
+		for _, listener := range listeners {
+			listener(change)
+		}
+	}
+}
+
+// diffPartitions returns the changes between the old and the new
+// partitions of a keyspace, sorted by tablet type.
+func diffPartitions(keyspace string, old, updated map[topodatapb.TabletType]servedShards) []*partitionChange {
+	tabletTypes := make(map[topodatapb.TabletType]bool)
+	for tabletType := range old {
+		tabletTypes[tabletType] = true
+	}
+	for tabletType := range updated {
+		tabletTypes[tabletType] = true
+	}
+
+	var changes []*partitionChange
+	for tabletType := range tabletTypes {
+		change := &partitionChange{
+			Keyspace:   keyspace,
+			TabletType: tabletType,
+		}
+		oldShards, newShards := old[tabletType], updated[tabletType]
+		for shard, disabled := range newShards {
+			wasDisabled, ok := oldShards[shard]
+			switch {
+			case !ok:
+				change.Added = append(change.Added, shard)
+			case disabled && !wasDisabled:
+				change.Disabled = append(change.Disabled, shard)
+			case !disabled && wasDisabled:
+				change.Enabled = append(change.Enabled, shard)
+			}
+		}
+		for shard := range oldShards {
+			if _, ok := newShards[shard]; !ok {
+				change.Removed = append(change.Removed, shard)
+			}
+		}
+		if change.empty() {
+			continue
+		}
+		sort.Strings(change.Added)
+		sort.Strings(change.Removed)
+		sort.Strings(change.Disabled)
+		sort.Strings(change.Enabled)
+		changes = append(changes, change)
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].TabletType < changes[j].TabletType
+	})
+	return changes
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/srvtopo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func masterPartition(shards []string, disabled ...string) *topodatapb.SrvKeyspace {
+	partition := &topodatapb.SrvKeyspace_KeyspacePartition{ServedType: topodatapb.TabletType_MASTER}
+	for _, shard := range shards {
+		partition.ShardReferences = append(partition.ShardReferences, &topodatapb.ShardReference{Name: shard})
+	}
+	for _, shard := range disabled {
+		partition.ShardTabletControls = append(partition.ShardTabletControls, &topodatapb.ShardTabletControl{
+			Name:                 shard,
+			QueryServiceDisabled: true,
+		})
+	}
+	return &topodatapb.SrvKeyspace{Partitions: []*topodatapb.SrvKeyspace_KeyspacePartition{partition}}
+}
+
+func TestDiffPartitions(t *testing.T) {
+	old := map[topodatapb.TabletType]servedShards{
+		topodatapb.TabletType_MASTER:  {"0": false},
+		topodatapb.TabletType_REPLICA: {"0": false},
+		topodatapb.TabletType_RDONLY:  {"0": true},
+	}
+	updated := map[topodatapb.TabletType]servedShards{
+		topodatapb.TabletType_MASTER:  {"0": true},
+		topodatapb.TabletType_REPLICA: {"-80": false, "80-": false},
+		topodatapb.TabletType_RDONLY:  {"0": false},
+	}
+	assert.Equal(t, []*partitionChange{{
+		Keyspace:   "ks",
+		TabletType: topodatapb.TabletType_MASTER,
+		Disabled:   []string{"0"},
+	}, {
+		Keyspace:   "ks",
+		TabletType: topodatapb.TabletType_REPLICA,
+		Added:      []string{"-80", "80-"},
+		Removed:    []string{"0"},
+	}, {
+		Keyspace:   "ks",
+		TabletType: topodatapb.TabletType_RDONLY,
+		Enabled:    []string{"0"},
+	}}, diffPartitions("ks", old, updated))
+	assert.Empty(t, diffPartitions("ks", updated, updated))
+}
+
+func TestPartitionWatcher(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ts := memorytopo.NewServer("cell1")
+	require.NoError(t, ts.UpdateSrvKeyspace(ctx, "cell1", "ks", masterPartition([]string{"0"})))
+
+	changes := make(chan *partitionChange, 10)
+	pw := newPartitionWatcher(ctx, srvtopo.NewResilientServer(ts, "TestPartitionWatcher"), "cell1")
+	pw.subscribe(func(change *partitionChange) {
+		changes <- change
+	})
+	pw.watchKeyspaces([]string{"ks"})
+	next := func() *partitionChange {
+		t.Helper()
+		select {
+		case change := <-changes:
+			return change
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for a partition change")
+			return nil
+		}
+	}
+
+	// Wait for the first value to be recorded. It isn't a change.
+	require.Eventually(t, func() bool {
+		pw.mu.Lock()
+		defer pw.mu.Unlock()
+		return pw.partitions["ks"] != nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// SwitchWrites disables the query service of the source shard first,
+	// then switches the partition.
+	require.NoError(t, ts.UpdateSrvKeyspace(ctx, "cell1", "ks", masterPartition([]string{"0"}, "0")))
+	assert.Equal(t, &partitionChange{
+		Keyspace:   "ks",
+		TabletType: topodatapb.TabletType_MASTER,
+		Disabled:   []string{"0"},
+	}, next())
+	require.NoError(t, ts.UpdateSrvKeyspace(ctx, "cell1", "ks", masterPartition([]string{"-80", "80-"})))
+	assert.Equal(t, &partitionChange{
+		Keyspace:   "ks",
+		TabletType: topodatapb.TabletType_MASTER,
+		Added:      []string{"-80", "80-"},
+		Removed:    []string{"0"},
+	}, next())
+
+	// Once the keyspace isn't watched anymore, its changes are ignored.
+	pw.watchKeyspaces(nil)
+	require.NoError(t, ts.UpdateSrvKeyspace(ctx, "cell1", "ks", masterPartition([]string{"0"})))
+	select {
+	case change := <-changes:
+		t.Errorf("unexpected change after the watch stopped: %v", change)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
@@ -109,6 +109,11 @@ func (e *Executor) newExecute(ctx context.Context, safeSession *SafeSession, sql
 	}
 
 	// 3: Prepare for execution
+	if err := checkCrossKeyspaceWrite(plan, safeSession, e.VSchema()); err != nil {
+		logStats.Error = err
+		return 0, nil, err
+	}
+
 	err = e.addNeededBindVars(plan.BindVarNeeds, bindVars, safeSession)
 	if err != nil {
 		logStats.Error = err
@@ -175,13 +180,38 @@ type currFunc func(*LogStats, *SafeSession) (sqlparser.StatementType, *sqltypes.
 func (e *Executor) executePlan(ctx context.Context, plan *engine.Plan, vcursor *vcursorImpl, bindVars map[string]*querypb.BindVariable, execStart time.Time) currFunc {
 	return func(logStats *LogStats, safeSession *SafeSession) (sqlparser.StatementType, *sqltypes.Result, error) {
 		// 4: Execute!
-		qr, err := plan.Instructions.Execute(vcursor, bindVars, true)
+		if e.quotas != nil && logStats.Method != "Mirror" && engine.Find(findScatter, plan.Instructions) != nil {
+			if err := e.quotas.checkScatter(ctx); err != nil {
+				return 0, nil, err
+			}
+		}
+		var timeout time.Duration
+		if !vcursor.hasQueryTimeoutDirective {
+			timeout = e.timeouts.timeout(vcursor.vschema, plan.Instructions.GetKeyspaceName(), plan.Instructions.GetTableName(), vcursor.TabletType())
+		}
+		if timeout > 0 {
+			cancel := vcursor.SetContextTimeout(timeout)
+			defer cancel()
+		}
+		cacheKey := e.resultCache.lookup(plan, vcursor, bindVars)
+		qr := e.resultCache.get(cacheKey)
+		var err error
+		if qr == nil {
+			qr, err = plan.Instructions.Execute(vcursor, bindVars, true)
+			if err != nil && timeout > 0 && vcursor.ctx.Err() == context.DeadlineExceeded && ctx.Err() == nil {
+				queryTimeoutErrors.Add([]string{plan.Instructions.GetKeyspaceName(), plan.Instructions.GetTableName()}, 1)
+			}
+			if err == nil {
+				e.resultCache.set(cacheKey, qr)
+			}
+		}
 
 		// 5: Log and add statistics
 		logStats.Keyspace = plan.Instructions.GetKeyspaceName()
 		logStats.Table = plan.Instructions.GetTableName()
 		logStats.TabletType = vcursor.TabletType().String()
 		errCount := e.logExecutionEnd(logStats, execStart, plan, err, qr)
+		vcursor.recordTrafficSplit(logStats.Keyspace, logStats.Table, time.Since(execStart), err)
 		plan.AddStats(1, time.Since(logStats.StartTime), uint64(logStats.ShardQueries), logStats.RowsAffected, logStats.RowsReturned, errCount)
 
 		// Check if there was partial DML execution. If so, rollback the transaction.
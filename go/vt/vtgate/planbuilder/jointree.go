@@ -348,10 +348,10 @@ func equalOrEqualUnique(vindex *vindexes.ColumnVindex) engine.RouteOpcode {
 }
 
 func (rp *routePlan) planEqualOp(node *sqlparser.ComparisonExpr) (bool, error) {
-	column, ok := node.Left.(*sqlparser.ColName)
+	column, path, ok := vindexColumn(node.Left)
 	other := node.Right
 	if !ok {
-		column, ok = node.Right.(*sqlparser.ColName)
+		column, path, ok = vindexColumn(node.Right)
 		if !ok {
 			// either the LHS or RHS have to be a column to be useful for the vindex
 			return false, nil
@@ -363,10 +363,10 @@ func (rp *routePlan) planEqualOp(node *sqlparser.ComparisonExpr) (bool, error) {
 		return false, err
 	}
 
-	return rp.haveMatchingVindex(node, column, *val, equalOrEqualUnique, justTheVindex), err
+	return rp.haveMatchingVindex(node, column, path, *val, equalOrEqualUnique, justTheVindex), err
 }
 
-func (rp *routePlan) planSimpleInOp(node *sqlparser.ComparisonExpr, left *sqlparser.ColName) (bool, error) {
+func (rp *routePlan) planSimpleInOp(node *sqlparser.ComparisonExpr, left *sqlparser.ColName, path string) (bool, error) {
 	value, err := sqlparser.NewPlanValue(node.Right)
 	if err != nil {
 		// if we are unable to create a PlanValue, we can't use a vindex, but we don't have to fail
@@ -384,7 +384,7 @@ func (rp *routePlan) planSimpleInOp(node *sqlparser.ComparisonExpr, left *sqlpar
 		}
 	}
 	opcode := func(*vindexes.ColumnVindex) engine.RouteOpcode { return engine.SelectIN }
-	return rp.haveMatchingVindex(node, left, value, opcode, justTheVindex), err
+	return rp.haveMatchingVindex(node, left, path, value, opcode, justTheVindex), err
 }
 
 func (rp *routePlan) planCompositeInOp(node *sqlparser.ComparisonExpr, left sqlparser.ValTuple) (bool, error) {
@@ -433,7 +433,7 @@ func (rp *routePlan) planCompositeInOpRecursive(node *sqlparser.ComparisonExpr,
 			}
 
 			opcode := func(*vindexes.ColumnVindex) engine.RouteOpcode { return engine.SelectMultiEqual }
-			newVindex := rp.haveMatchingVindex(node, expr, *newPlanValues, opcode, justTheVindex)
+			newVindex := rp.haveMatchingVindex(node, expr, "", *newPlanValues, opcode, justTheVindex)
 			foundVindex = newVindex || foundVindex
 		}
 	}
@@ -442,8 +442,12 @@ func (rp *routePlan) planCompositeInOpRecursive(node *sqlparser.ComparisonExpr,
 
 func (rp *routePlan) planInOp(node *sqlparser.ComparisonExpr) (bool, error) {
 	switch left := node.Left.(type) {
-	case *sqlparser.ColName:
-		return rp.planSimpleInOp(node, left)
+	case *sqlparser.ColName, *sqlparser.BinaryExpr:
+		column, path, ok := vindexColumn(left)
+		if !ok {
+			return false, nil
+		}
+		return rp.planSimpleInOp(node, column, path)
 	case sqlparser.ValTuple:
 		return rp.planCompositeInOp(node, left)
 	}
@@ -471,7 +475,7 @@ func (rp *routePlan) planLikeOp(node *sqlparser.ComparisonExpr) (bool, error) {
 		return nil
 	}
 
-	return rp.haveMatchingVindex(node, column, *val, selectEqual, vdx), err
+	return rp.haveMatchingVindex(node, column, "", *val, selectEqual, vdx), err
 }
 
 func (rp *routePlan) planIsExpr(node *sqlparser.IsExpr) (bool, error) {
@@ -488,7 +492,7 @@ func (rp *routePlan) planIsExpr(node *sqlparser.IsExpr) (bool, error) {
 		return false, err
 	}
 
-	return rp.haveMatchingVindex(node, column, *val, equalOrEqualUnique, justTheVindex), err
+	return rp.haveMatchingVindex(node, column, "", *val, equalOrEqualUnique, justTheVindex), err
 }
 
 func makePlanValue(n sqlparser.Expr) (*sqltypes.PlanValue, error) {
@@ -506,6 +510,9 @@ func makePlanValue(n sqlparser.Expr) (*sqltypes.PlanValue, error) {
 
 func (rp routePlan) hasVindex(column *sqlparser.ColName) bool {
 	for _, v := range rp.vindexPreds {
+		if vindexJSONPath(v.colVindex) != "" {
+			continue
+		}
 		for _, col := range v.colVindex.Columns {
 			if column.Name.Equal(col) {
 				return true
@@ -518,6 +525,7 @@ func (rp routePlan) hasVindex(column *sqlparser.ColName) bool {
 func (rp *routePlan) haveMatchingVindex(
 	node sqlparser.Expr,
 	column *sqlparser.ColName,
+	path string,
 	value sqltypes.PlanValue,
 	opcode func(*vindexes.ColumnVindex) engine.RouteOpcode,
 	vfunc func(*vindexes.ColumnVindex) vindexes.Vindex,
@@ -527,6 +535,12 @@ func (rp *routePlan) haveMatchingVindex(
 		if v.foundVindex != nil {
 			continue
 		}
+		// A vindex over a JSON path of a column is only used for the
+		// predicates on that path, and the other vindexes only for the
+		// predicates on the column itself.
+		if vindexJSONPath(v.colVindex) != path {
+			continue
+		}
 		for _, col := range v.colVindex.Columns {
 			// If the column for the predicate matches any column in the vindex add it to the list
 			if column.Name.Equal(col) {
@@ -545,6 +559,15 @@ func (rp *routePlan) haveMatchingVindex(
 	return newVindexFound
 }
 
+// vindexJSONPath returns the JSON path of a vindex over a JSON path, or
+// an empty string for the other vindexes.
+func vindexJSONPath(colVindex *vindexes.ColumnVindex) string {
+	if jp, ok := colVindex.Vindex.(vindexes.JSONPathVindex); ok {
+		return jp.JSONPath()
+	}
+	return ""
+}
+
 // pickBestAvailableVindex goes over the available vindexes for this route and picks the best one available.
 func (rp *routePlan) pickBestAvailableVindex() {
 	for _, v := range rp.vindexPreds {
@@ -84,7 +84,7 @@ func transformRoutePlan(n *routePlan) (*route, error) {
 		case *sqlparser.ComparisonExpr:
 			if predicate.Operator == sqlparser.InOp {
 				switch predicate.Left.(type) {
-				case *sqlparser.ColName:
+				case *sqlparser.ColName, *sqlparser.BinaryExpr:
 					predicate.Right = sqlparser.ListArg(engine.ListVarName)
 				}
 			}
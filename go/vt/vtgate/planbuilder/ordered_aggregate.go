@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"vitess.io/vitess/go/vt/vtgate/semantics"
 
@@ -247,28 +248,10 @@ func (oa *orderedAggregate) pushAggr(pb *primitiveBuilder, expr *sqlparser.Alias
 		return nil, 0, err
 	}
 	if handleDistinct {
-		if oa.extraDistinct != nil {
-			return nil, 0, fmt.Errorf("unsupported: only one distinct aggregation allowed in a select: %s", sqlparser.String(funcExpr))
-		}
-		// Push the expression that's inside the aggregate.
-		// The column will eventually get added to the group by and order by clauses.
-		newBuilder, _, innerCol, err := planProjection(pb, oa.input, innerAliased, origin)
-		if err != nil {
-			return nil, 0, err
-		}
-		pb.plan = newBuilder
-		col, err := BuildColName(oa.input.ResultColumns(), innerCol)
+		innerCol, err := oa.pushDistinctExpr(pb, funcExpr, innerAliased, origin)
 		if err != nil {
 			return nil, 0, err
 		}
-		oa.extraDistinct = col
-		oa.eaggr.PreProcess = true
-		var alias string
-		if expr.As.IsEmpty() {
-			alias = sqlparser.String(expr.Expr)
-		} else {
-			alias = expr.As.String()
-		}
 		switch opcode {
 		case engine.AggregateCount:
 			opcode = engine.AggregateCountDistinct
@@ -278,7 +261,7 @@ func (oa *orderedAggregate) pushAggr(pb *primitiveBuilder, expr *sqlparser.Alias
 		oa.eaggr.Aggregates = append(oa.eaggr.Aggregates, engine.AggregateParams{
 			Opcode: opcode,
 			Col:    innerCol,
-			Alias:  alias,
+			Alias:  distinctAlias(expr),
 		})
 	} else {
 		newBuilder, _, innerCol, err := planProjection(pb, oa.input, expr, origin)
@@ -299,6 +282,123 @@ func (oa *orderedAggregate) pushAggr(pb *primitiveBuilder, expr *sqlparser.Alias
 	return rc, len(oa.resultColumns) - 1, nil
 }
 
+// pushDistinctExpr pushes the expression inside a distinct aggregate down
+// to the route. The column will eventually get added to the group by and
+// order by clauses, so that the distinct values of each group come in
+// order.
+func (oa *orderedAggregate) pushDistinctExpr(pb *primitiveBuilder, aggr sqlparser.Expr, innerAliased *sqlparser.AliasedExpr, origin logicalPlan) (int, error) {
+	if oa.extraDistinct != nil {
+		return 0, fmt.Errorf("unsupported: only one distinct aggregation allowed in a select: %s", sqlparser.String(aggr))
+	}
+	newBuilder, _, innerCol, err := planProjection(pb, oa.input, innerAliased, origin)
+	if err != nil {
+		return 0, err
+	}
+	pb.plan = newBuilder
+	col, err := BuildColName(oa.input.ResultColumns(), innerCol)
+	if err != nil {
+		return 0, err
+	}
+	oa.extraDistinct = col
+	oa.eaggr.PreProcess = true
+	return innerCol, nil
+}
+
+// distinctAlias returns the name of the column of a distinct aggregate.
+func distinctAlias(expr *sqlparser.AliasedExpr) string {
+	if expr.As.IsEmpty() {
+		return sqlparser.String(expr.Expr)
+	}
+	return expr.As.String()
+}
+
+// pushGroupConcat pushes group_concat down to the route, and concatenates
+// the results of the shards for each group. For group_concat(distinct),
+// the values are pushed down instead, and vtgate concatenates the distinct
+// ones. The order of the values isn't defined anyway, but an order by or
+// a limit can't be enforced across shards.
+func (oa *orderedAggregate) pushGroupConcat(pb *primitiveBuilder, expr *sqlparser.AliasedExpr, origin logicalPlan) (rc *resultColumn, colNumber int, err error) {
+	groupConcat := expr.Expr.(*sqlparser.GroupConcatExpr)
+	if len(groupConcat.OrderBy) != 0 || groupConcat.Limit != nil {
+		return nil, 0, fmt.Errorf("unsupported: in scatter query: group_concat with order by or limit: %s", sqlparser.String(groupConcat))
+	}
+	separator, err := groupConcatSeparator(groupConcat)
+	if err != nil {
+		return nil, 0, err
+	}
+	handleDistinct, innerAliased, err := oa.needGroupConcatDistinctHandling(pb, groupConcat)
+	if err != nil {
+		return nil, 0, err
+	}
+	if handleDistinct {
+		innerCol, err := oa.pushDistinctExpr(pb, groupConcat, innerAliased, origin)
+		if err != nil {
+			return nil, 0, err
+		}
+		oa.eaggr.Aggregates = append(oa.eaggr.Aggregates, engine.AggregateParams{
+			Opcode:    engine.AggregateGroupConcatDistinct,
+			Col:       innerCol,
+			Alias:     distinctAlias(expr),
+			Separator: separator,
+		})
+	} else {
+		newBuilder, _, innerCol, err := planProjection(pb, oa.input, expr, origin)
+		if err != nil {
+			return nil, 0, err
+		}
+		pb.plan = newBuilder
+		oa.eaggr.Aggregates = append(oa.eaggr.Aggregates, engine.AggregateParams{
+			Opcode:    engine.AggregateGroupConcat,
+			Col:       innerCol,
+			Separator: separator,
+		})
+	}
+
+	rc = newResultColumn(expr, oa)
+	oa.resultColumns = append(oa.resultColumns, rc)
+	return rc, len(oa.resultColumns) - 1, nil
+}
+
+// needGroupConcatDistinctHandling is like needDistinctHandling for
+// group_concat: the distinct values of a unique vindex are already
+// distinct across shards.
+func (oa *orderedAggregate) needGroupConcatDistinctHandling(pb *primitiveBuilder, groupConcat *sqlparser.GroupConcatExpr) (bool, *sqlparser.AliasedExpr, error) {
+	if !groupConcat.Distinct {
+		return false, nil, nil
+	}
+	if len(groupConcat.Exprs) != 1 {
+		return false, nil, fmt.Errorf("unsupported: in scatter query: group_concat(distinct) with more than one expression: %s", sqlparser.String(groupConcat))
+	}
+	innerAliased, ok := groupConcat.Exprs[0].(*sqlparser.AliasedExpr)
+	if !ok {
+		return false, nil, fmt.Errorf("syntax error: %s", sqlparser.String(groupConcat))
+	}
+	rb, ok := oa.input.(*route)
+	if !ok {
+		// Unreachable
+		return true, innerAliased, nil
+	}
+	vindex := pb.st.Vindex(innerAliased.Expr, rb)
+	if vindex != nil && vindex.IsUnique() {
+		return false, nil, nil
+	}
+	return true, innerAliased, nil
+}
+
+// groupConcatSeparator returns the separator of the values of
+// group_concat, which the parser keeps as a SQL string.
+func groupConcatSeparator(groupConcat *sqlparser.GroupConcatExpr) (string, error) {
+	if groupConcat.Separator == "" {
+		return ",", nil
+	}
+	tokenizer := sqlparser.NewStringTokenizer(strings.TrimPrefix(groupConcat.Separator, " separator "))
+	typ, separator := tokenizer.Scan()
+	if typ != sqlparser.STRING {
+		return "", fmt.Errorf("syntax error: %s", sqlparser.String(groupConcat))
+	}
+	return separator, nil
+}
+
 // needDistinctHandling returns true if oa needs to handle the distinct clause.
 // If true, it will also return the aliased expression that needs to be pushed
 // down into the underlying route.
@@ -698,7 +698,7 @@ func (rb *route) computeISPlan(pb *primitiveBuilder, comparison *sqlparser.IsExp
 // computeINPlan computes the plan for an IN constraint.
 func (rb *route) computeINPlan(pb *primitiveBuilder, comparison *sqlparser.ComparisonExpr) (opcode engine.RouteOpcode, vindex vindexes.SingleColumn, expr sqlparser.Expr) {
 	switch comparison.Left.(type) {
-	case *sqlparser.ColName:
+	case *sqlparser.ColName, *sqlparser.BinaryExpr:
 		return rb.computeSimpleINPlan(pb, comparison)
 	case sqlparser.ValTuple:
 		return rb.computeCompositeINPlan(pb, comparison)
@@ -26,6 +26,7 @@ import (
 	"vitess.io/vitess/go/vt/vterrors"
 
 	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
 	"vitess.io/vitess/go/vt/vtgate/vindexes"
 
 	querypb "vitess.io/vitess/go/vt/proto/query"
@@ -122,10 +123,22 @@ func (st *symtab) AddVSchemaTable(alias sqlparser.TableName, vschemaTable *vinde
 			if err != nil {
 				return err
 			}
-			if i == 0 {
-				if col.vindex == nil || col.vindex.Cost() > single.Cost() {
-					col.vindex = single
+			if i != 0 {
+				continue
+			}
+			// A vindex over a JSON path of the column is only used for
+			// the predicates on that path.
+			if jp, ok := single.(vindexes.JSONPathVindex); ok {
+				if col.jsonPathVindexes == nil {
+					col.jsonPathVindexes = make(map[string]vindexes.SingleColumn)
+				}
+				if other := col.jsonPathVindexes[jp.JSONPath()]; other == nil || other.Cost() > jp.Cost() {
+					col.jsonPathVindexes[jp.JSONPath()] = jp
 				}
+				continue
+			}
+			if col.vindex == nil || col.vindex.Cost() > single.Cost() {
+				col.vindex = single
 			}
 		}
 	}
@@ -394,9 +407,11 @@ func ResultFromNumber(rcs []*resultColumn, val *sqlparser.Literal) (int, error)
 }
 
 // Vindex returns the vindex if the expression is a plain column reference
-// that is part of the specified route, and has an associated vindex.
+// that is part of the specified route, and has an associated vindex. The
+// expression can also be a JSON path of a column, e.g. doc->>'$.id', if
+// the column has a vindex over that path.
 func (st *symtab) Vindex(expr sqlparser.Expr, scope *route) vindexes.SingleColumn {
-	col, ok := expr.(*sqlparser.ColName)
+	col, path, ok := vindexColumn(expr)
 	if !ok {
 		return nil
 	}
@@ -410,9 +425,40 @@ func (st *symtab) Vindex(expr sqlparser.Expr, scope *route) vindexes.SingleColum
 	if c.Origin() != scope {
 		return nil
 	}
+	if path != "" {
+		return c.jsonPathVindexes[path]
+	}
 	return c.vindex
 }
 
+// vindexColumn returns the column of an expression which can be routed
+// with a vindex: a plain column reference, or a JSON path of a column
+// with the -> or ->> operators, for which the canonical path is returned.
+func vindexColumn(expr sqlparser.Expr) (col *sqlparser.ColName, path string, ok bool) {
+	switch expr := expr.(type) {
+	case *sqlparser.ColName:
+		return expr, "", true
+	case *sqlparser.BinaryExpr:
+		if expr.Operator != sqlparser.JSONExtractOp && expr.Operator != sqlparser.JSONUnquoteExtractOp {
+			return nil, "", false
+		}
+		col, ok := expr.Left.(*sqlparser.ColName)
+		if !ok {
+			return nil, "", false
+		}
+		lit, ok := expr.Right.(*sqlparser.Literal)
+		if !ok || lit.Type != sqlparser.StrVal {
+			return nil, "", false
+		}
+		jsonPath, err := evalengine.ParseJSONPath(lit.Val)
+		if err != nil {
+			return nil, "", false
+		}
+		return col, jsonPath.String(), true
+	}
+	return nil, "", false
+}
+
 // BuildColName builds a *sqlparser.ColName for the resultColumn specified
 // by the index. The built ColName will correctly reference the resultColumn
 // it was built from.
@@ -519,11 +565,14 @@ func (t *table) Origin() logicalPlan {
 // For subquery and vindexFunc, the colNumber is also set because
 // the column order is known and unchangeable.
 type column struct {
-	origin    logicalPlan
-	st        *symtab
-	vindex    vindexes.SingleColumn
-	typ       querypb.Type
-	colNumber int
+	origin logicalPlan
+	st     *symtab
+	vindex vindexes.SingleColumn
+	// jsonPathVindexes are the vindexes over the JSON paths of the
+	// column, by canonical path.
+	jsonPathVindexes map[string]vindexes.SingleColumn
+	typ              querypb.Type
+	colNumber        int
 }
 
 // Origin returns the route that originates the column.
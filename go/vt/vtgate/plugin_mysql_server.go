@@ -72,6 +72,8 @@ var (
 	mysqlConnWriteTimeout = flag.Duration("mysql_server_write_timeout", 0, "connection write timeout")
 	mysqlQueryTimeout     = flag.Duration("mysql_server_query_timeout", 0, "mysql query timeout")
 
+	mysqlMaxPreparedStatements = flag.Int("mysql_server_max_prepared_statements", 16382, "Maximum number of prepared statements a connection can have open at once, as max_prepared_stmt_count in MySQL. 0 means unlimited.")
+
 	mysqlDefaultWorkloadName = flag.String("mysql_default_workload", "OLTP", "Default session workload (OLTP, OLAP, DBA)")
 	mysqlDefaultWorkload     int32
 
@@ -84,20 +86,25 @@ type vtgateHandler struct {
 	mu sync.Mutex
 
 	vtg         *VTGate
-	connections map[*mysql.Conn]bool
+	connections map[*mysql.Conn]*connActivity
 }
 
 func newVtgateHandler(vtg *VTGate) *vtgateHandler {
 	return &vtgateHandler{
 		vtg:         vtg,
-		connections: make(map[*mysql.Conn]bool),
+		connections: make(map[*mysql.Conn]*connActivity),
 	}
 }
 
 func (vh *vtgateHandler) NewConnection(c *mysql.Conn) {
 	vh.mu.Lock()
 	defer vh.mu.Unlock()
-	vh.connections[c] = true
+	now := time.Now()
+	vh.connections[c] = &connActivity{
+		lastActive: now,
+		connected:  now,
+		maxAge:     connectionMaxAge(),
+	}
 }
 
 func (vh *vtgateHandler) numConnections() int {
@@ -207,11 +214,15 @@ func (vh *vtgateHandler) ComQuery(c *mysql.Conn, query string, callback func(*sq
 	if !session.InTransaction {
 		atomic.AddInt32(&busyConnections, 1)
 	}
+	vh.queryStarted(c)
 	defer func() {
+		vh.queryFinished(c, session)
 		if !session.InTransaction {
 			atomic.AddInt32(&busyConnections, -1)
 		}
 	}()
+	ctx = vh.trackQuery(ctx, c, session, query)
+	callback = vh.countRows(c, callback)
 
 	if session.Options.Workload == querypb.ExecuteOptions_OLAP {
 		err := vh.vtg.StreamExecute(ctx, session, query, make(map[string]*querypb.BindVariable), callback)
@@ -268,11 +279,14 @@ func (vh *vtgateHandler) ComPrepare(c *mysql.Conn, query string, bindVars map[st
 	if !session.InTransaction {
 		atomic.AddInt32(&busyConnections, 1)
 	}
+	vh.queryStarted(c)
 	defer func() {
+		vh.queryFinished(c, session)
 		if !session.InTransaction {
 			atomic.AddInt32(&busyConnections, -1)
 		}
 	}()
+	ctx = vh.trackQuery(ctx, c, session, query)
 
 	session, fld, err := vh.vtg.Prepare(ctx, session, query, bindVars)
 	err = mysql.NewSQLErrorFromError(err)
@@ -310,11 +324,15 @@ func (vh *vtgateHandler) ComStmtExecute(c *mysql.Conn, prepare *mysql.PrepareDat
 	if !session.InTransaction {
 		atomic.AddInt32(&busyConnections, 1)
 	}
+	vh.queryStarted(c)
 	defer func() {
+		vh.queryFinished(c, session)
 		if !session.InTransaction {
 			atomic.AddInt32(&busyConnections, -1)
 		}
 	}()
+	ctx = vh.trackQuery(ctx, c, session, prepare.PrepareStmt)
+	callback = vh.countRows(c, callback)
 
 	if session.Options.Workload == querypb.ExecuteOptions_OLAP {
 		err := vh.vtg.StreamExecute(ctx, session, prepare.PrepareStmt, prepare.BindVars, callback)
@@ -421,6 +439,9 @@ func initMySQLProtocol() {
 	// Create a Listener.
 	var err error
 	vtgateHandle = newVtgateHandler(rpcVTGate)
+	if *mysqlIdleTransactionTimeout > 0 {
+		go vtgateHandle.reapIdleTransactions(*mysqlIdleTransactionTimeout)
+	}
 	if *mysqlServerPort >= 0 {
 		mysqlListener, err = mysql.NewListener(*mysqlTCPVersion, net.JoinHostPort(*mysqlServerBindAddress, fmt.Sprintf("%v", *mysqlServerPort)), authServer, vtgateHandle, *mysqlConnReadTimeout, *mysqlConnWriteTimeout, *mysqlProxyProtocol)
 		if err != nil {
@@ -438,6 +459,7 @@ func initMySQLProtocol() {
 			log.Infof("setting mysql slow connection threshold to %v", mysqlSlowConnectWarnThreshold)
 			mysqlListener.SlowConnectWarnThreshold.Set(*mysqlSlowConnectWarnThreshold)
 		}
+		mysqlListener.MaxPreparedStatements = *mysqlMaxPreparedStatements
 		// Start listening for tcp
 		go mysqlListener.Accept()
 	}
@@ -452,6 +474,7 @@ func initMySQLProtocol() {
 			log.Exitf("mysql.NewListener failed: %v", err)
 			return
 		}
+		mysqlUnixListener.MaxPreparedStatements = *mysqlMaxPreparedStatements
 		// Listen for unix socket
 		go mysqlUnixListener.Accept()
 	}
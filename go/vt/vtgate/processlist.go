@@ -0,0 +1,201 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"vitess.io/vitess/go/acl"
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/sqlparser"
+
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+// This file implements the equivalent of SHOW PROCESSLIST and KILL for
+// the MySQL protocol connections of the vtgate, on its /debug/processlist
+// and /debug/kill pages. vtctld fans them out to all the vtgates.
+
+// ProcessInfo describes a MySQL protocol connection, and the query it is
+// running, as a row of SHOW PROCESSLIST.
+type ProcessInfo struct {
+	ID     uint32 `json:"id"`
+	User   string `json:"user"`
+	Host   string `json:"host"`
+	Target string `json:"target"`
+	// Command is Query while the connection runs a query, Sleep otherwise.
+	Command string `json:"command"`
+	// Time is for how many seconds the connection is in its current
+	// command.
+	Time int64 `json:"time"`
+	// Query is the running query, with its literals redacted.
+	Query         string `json:"query,omitempty"`
+	RowsStreamed  int    `json:"rows_streamed,omitempty"`
+	InTransaction bool   `json:"in_transaction"`
+}
+
+func init() {
+	http.HandleFunc("/debug/processlist", func(w http.ResponseWriter, r *http.Request) {
+		if err := acl.CheckAccessHTTP(r, acl.DEBUGGING); err != nil {
+			acl.SendError(w, err)
+			return
+		}
+		if vtgateHandle == nil {
+			http.Error(w, "the MySQL protocol server is not running", http.StatusServiceUnavailable)
+			return
+		}
+		data, err := json.MarshalIndent(vtgateHandle.processList(time.Now()), "", "  ")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Write(data)
+	})
+	http.HandleFunc("/debug/kill", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+			return
+		}
+		if err := acl.CheckAccessHTTP(r, acl.ADMIN); err != nil {
+			acl.SendError(w, err)
+			return
+		}
+		if vtgateHandle == nil {
+			http.Error(w, "the MySQL protocol server is not running", http.StatusServiceUnavailable)
+			return
+		}
+		id, err := strconv.ParseUint(r.FormValue("id"), 10, 32)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid connection id %q", r.FormValue("id")), http.StatusBadRequest)
+			return
+		}
+		queryOnly := r.FormValue("query") == "true"
+		if err := vtgateHandle.kill(uint32(id), queryOnly); err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte("ok"))
+	})
+}
+
+// trackQuery records the query a connection starts to run, after
+// queryStarted. It returns the context to run the query with, which is
+// canceled if the query is killed.
+func (vh *vtgateHandler) trackQuery(ctx context.Context, c *mysql.Conn, session *vtgatepb.Session, query string) context.Context {
+	vh.mu.Lock()
+	defer vh.mu.Unlock()
+	activity, ok := vh.connections[c]
+	if !ok {
+		return ctx
+	}
+	ctx, activity.cancel = context.WithCancel(ctx)
+	activity.query = query
+	activity.queryStart = time.Now()
+	activity.rows = 0
+	activity.target = session.TargetString
+	// The component of the caller is the address of the client.
+	activity.host = callerid.GetComponent(callerid.EffectiveCallerIDFromContext(ctx))
+	return ctx
+}
+
+// countRows returns a callback which counts the rows sent to the client
+// by callback.
+func (vh *vtgateHandler) countRows(c *mysql.Conn, callback func(*sqltypes.Result) error) func(*sqltypes.Result) error {
+	return func(qr *sqltypes.Result) error {
+		vh.mu.Lock()
+		if activity, ok := vh.connections[c]; ok {
+			activity.rows += len(qr.Rows)
+		}
+		vh.mu.Unlock()
+		return callback(qr)
+	}
+}
+
+// processList returns the MySQL protocol connections, sorted by id.
+func (vh *vtgateHandler) processList(now time.Time) []*ProcessInfo {
+	vh.mu.Lock()
+	defer vh.mu.Unlock()
+	processes := make([]*ProcessInfo, 0, len(vh.connections))
+	for c, activity := range vh.connections {
+		process := &ProcessInfo{
+			ID:            c.ConnectionID,
+			User:          c.User,
+			Host:          activity.host,
+			Target:        activity.target,
+			Command:       "Sleep",
+			Time:          int64(now.Sub(activity.lastActive).Seconds()),
+			InTransaction: activity.inTransaction,
+		}
+		if activity.running {
+			process.Command = "Query"
+			process.Time = int64(now.Sub(activity.queryStart).Seconds())
+			process.Query = redactQuery(activity.query)
+			process.RowsStreamed = activity.rows
+		}
+		processes = append(processes, process)
+	}
+	sort.Slice(processes, func(i, j int) bool {
+		return processes[i].ID < processes[j].ID
+	})
+	return processes
+}
+
+// redactQuery returns the query without its literals, or only its type if
+// it can't be parsed.
+func redactQuery(query string) string {
+	redacted, err := sqlparser.RedactSQLQuery(query)
+	if err != nil {
+		return sqlparser.Preview(query).String()
+	}
+	return redacted
+}
+
+// kill kills the query running on a connection if queryOnly is set, like
+// KILL QUERY. Otherwise it closes the connection, like KILL, which rolls
+// back its transaction.
+func (vh *vtgateHandler) kill(id uint32, queryOnly bool) error {
+	vh.mu.Lock()
+	defer vh.mu.Unlock()
+	for c, activity := range vh.connections {
+		if c.ConnectionID != id {
+			continue
+		}
+		if queryOnly {
+			if !activity.running || activity.cancel == nil {
+				return fmt.Errorf("connection %v is not running a query", id)
+			}
+			log.Infof("Killing query of connection %v of user %v: %v", id, c.User, redactQuery(activity.query))
+			activity.cancel()
+			return nil
+		}
+		log.Infof("Killing connection %v of user %v from %v", id, c.User, activity.host)
+		c.Close()
+		return nil
+	}
+	return fmt.Errorf("unknown connection id: %v", id)
+}
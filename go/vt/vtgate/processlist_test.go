@@ -0,0 +1,71 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/callerid"
+
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+func TestProcessListAndKillQuery(t *testing.T) {
+	vh := newVtgateHandler(nil)
+	running := &mysql.Conn{ConnectionID: 1, User: "user1"}
+	idle := &mysql.Conn{ConnectionID: 2, User: "user2"}
+	vh.NewConnection(running)
+	vh.NewConnection(idle)
+
+	ctx := callerid.NewContext(context.Background(), callerid.NewEffectiveCallerID("user1", "10.0.0.1:40000", "VTGate MySQL Connector"), nil)
+	vh.queryStarted(running)
+	ctx = vh.trackQuery(ctx, running, &vtgatepb.Session{TargetString: "ks1"}, "select * from t where id = 42")
+	callback := vh.countRows(running, func(*sqltypes.Result) error { return nil })
+	require.NoError(t, callback(&sqltypes.Result{Rows: make([][]sqltypes.Value, 3)}))
+	require.NoError(t, callback(&sqltypes.Result{Rows: make([][]sqltypes.Value, 2)}))
+
+	processes := vh.processList(time.Now().Add(10 * time.Second))
+	require.Len(t, processes, 2)
+	assert.Equal(t, &ProcessInfo{
+		ID:           1,
+		User:         "user1",
+		Host:         "10.0.0.1:40000",
+		Target:       "ks1",
+		Command:      "Query",
+		Time:         10,
+		Query:        "select * from t where id = :redacted1",
+		RowsStreamed: 5,
+	}, processes[0])
+	assert.Equal(t, "Sleep", processes[1].Command)
+	assert.Empty(t, processes[1].Query)
+
+	// KILL QUERY cancels the context of the query, and keeps the connection.
+	assert.EqualError(t, vh.kill(2, true), "connection 2 is not running a query")
+	assert.EqualError(t, vh.kill(3, true), "unknown connection id: 3")
+	require.NoError(t, vh.kill(1, true))
+	assert.Error(t, ctx.Err())
+	vh.queryFinished(running, &vtgatepb.Session{TargetString: "ks1"})
+	assert.Len(t, vh.processList(time.Now()), 2)
+	assert.Equal(t, "Sleep", vh.processList(time.Now())[0].Command)
+}
@@ -0,0 +1,475 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/acl"
+	"vitess.io/vitess/go/flagutil"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/eventlog"
+	"vitess.io/vitess/go/vt/log"
+)
+
+// This file implements the detection of the anomalies of the queries of
+// vtgate. The queries are grouped by fingerprint, i.e. with their literals
+// redacted, and each fingerprint has a baseline of its QPS, latency and
+// error rate, averaged over the past windows. At the end of each window,
+// the fingerprints which deviate from their baseline, or which are new and
+// expensive, are reported to the -query_anomaly_sinks, e.g. a webhook which
+// opens a ticket. Only the fingerprints and their redacted queries are
+// reported, never the queries themselves.
+
+var (
+	queryAnomalyWindow           = flag.Duration("query_anomaly_window", 0, "The window over which the QPS, latency and error rate of each query fingerprint are measured and compared to their baseline. 0 disables the query anomaly detection.")
+	queryAnomalyFactor           = flag.Float64("query_anomaly_factor", 10, "How many times its baseline the QPS or the latency of a query fingerprint must be over a window to be reported as an anomaly.")
+	queryAnomalyErrorRate        = flag.Float64("query_anomaly_error_rate", 0.1, "The error rate over a window above which a query fingerprint whose baseline error rate is below half of it is reported as an anomaly.")
+	queryAnomalyExpensiveLatency = flag.Duration("query_anomaly_expensive_latency", time.Second, "The average latency above which a new query fingerprint is reported as an anomaly.")
+	queryAnomalyMinQueries       = flag.Int("query_anomaly_min_queries", 10, "The number of queries of a fingerprint over a window below which its latency and error rate aren't compared to its baseline.")
+	queryAnomalyBaselineWindows  = flag.Int("query_anomaly_baseline_windows", 5, "The number of windows over which the baseline of a query fingerprint is averaged, and which are needed before it is compared to it.")
+	queryAnomalyCooldown         = flag.Duration("query_anomaly_cooldown", time.Hour, "How long after an anomaly of a query fingerprint the anomalies of the same type of that fingerprint aren't reported again.")
+	queryAnomalyMaxFingerprints  = flag.Int("query_anomaly_max_fingerprints", 10000, "The maximum number of query fingerprints whose baseline is tracked. The new fingerprints above it are ignored until the idle ones are forgotten.")
+	queryAnomalySinks            flagutil.StringListValue
+	queryAnomalySinkTimeout      = flag.Duration("query_anomaly_sink_timeout", 10*time.Second, "The timeout of the reports of the query anomalies to a sink.")
+
+	queryAnomalies             = stats.NewCountersWithSingleLabel("QueryAnomalies", "Number of query anomalies detected, by type", "Type")
+	queryAnomalySinkErrors     = stats.NewCountersWithSingleLabel("QueryAnomalySinkErrors", "Number of query anomalies which couldn't be reported to a sink, by sink", "Sink")
+	queryAnomalyQueriesDropped = stats.NewCounter("QueryAnomalyQueriesDropped", "Number of queries not accounted for by the query anomaly detection because it lagged behind")
+)
+
+// The types of the query anomalies.
+const (
+	// NewExpensiveFingerprint is a fingerprint which wasn't seen before,
+	// and whose latency is above -query_anomaly_expensive_latency.
+	NewExpensiveFingerprint = "new_expensive_fingerprint"
+	// LatencyRegression is a fingerprint whose latency is
+	// -query_anomaly_factor times its baseline.
+	LatencyRegression = "latency_regression"
+	// QPSSpike is a fingerprint whose QPS is -query_anomaly_factor times
+	// its baseline.
+	QPSSpike = "qps_spike"
+	// ErrorRateSpike is a fingerprint whose error rate is above
+	// -query_anomaly_error_rate.
+	ErrorRateSpike = "error_rate_spike"
+)
+
+const (
+	// queryAnomalyQueueSize is the number of queries buffered for the
+	// detection, which is asynchronous so that the queries don't pay for
+	// their fingerprinting.
+	queryAnomalyQueueSize = 10000
+	// queryAnomalyHistorySize is the number of the last anomalies served on
+	// /debug/query_anomalies.
+	queryAnomalyHistorySize = 100
+	// queryAnomalyMaxQueryLength is the length the redacted queries of the
+	// anomalies are truncated to.
+	queryAnomalyMaxQueryLength = 1024
+)
+
+// QueryAnomaly is an anomaly of a query fingerprint over a window.
+type QueryAnomaly struct {
+	Time        time.Time `json:"time"`
+	Type        string    `json:"type"`
+	Fingerprint string    `json:"fingerprint"`
+	// Query is a query of the fingerprint, with its literals redacted.
+	Query    string `json:"query"`
+	Keyspace string `json:"keyspace,omitempty"`
+
+	Queries        int64   `json:"queries"`
+	QPS            float64 `json:"qps"`
+	LatencySeconds float64 `json:"latency_seconds"`
+	ErrorRate      float64 `json:"error_rate"`
+	// The baselines are zero for a new fingerprint.
+	BaselineQPS            float64 `json:"baseline_qps"`
+	BaselineLatencySeconds float64 `json:"baseline_latency_seconds"`
+	BaselineErrorRate      float64 `json:"baseline_error_rate"`
+}
+
+// QueryAnomalySink receives the query anomalies.
+type QueryAnomalySink interface {
+	// Report reports an anomaly. It is called from the goroutine of the
+	// detection, one anomaly at a time.
+	Report(ctx context.Context, anomaly *QueryAnomaly) error
+}
+
+// QueryAnomalySinkFactory creates a sink from the argument of its
+// -query_anomaly_sinks entry, i.e. what follows <name>:.
+type QueryAnomalySinkFactory func(arg string) (QueryAnomalySink, error)
+
+var queryAnomalySinkFactories = make(map[string]QueryAnomalySinkFactory)
+
+// RegisterQueryAnomalySink registers a sink of the query anomalies, which
+// -query_anomaly_sinks refers to by its name, e.g. to report them over
+// gRPC to a ticketing service.
+func RegisterQueryAnomalySink(name string, factory QueryAnomalySinkFactory) {
+	if _, ok := queryAnomalySinkFactories[name]; ok {
+		log.Fatalf("query anomaly sink %v already registered", name)
+	}
+	queryAnomalySinkFactories[name] = factory
+}
+
+func init() {
+	flag.Var(&queryAnomalySinks, "query_anomaly_sinks", "Comma separated list of the sinks the query anomalies are reported to: eventlog to log them as structured events, webhook:<url> to POST them as JSON, or a sink registered with RegisterQueryAnomalySink.")
+
+	RegisterQueryAnomalySink("eventlog", func(arg string) (QueryAnomalySink, error) {
+		return eventlogAnomalySink{}, nil
+	})
+	RegisterQueryAnomalySink("webhook", func(arg string) (QueryAnomalySink, error) {
+		if arg == "" {
+			return nil, fmt.Errorf("the url of the webhook query anomaly sink is missing")
+		}
+		return &webhookAnomalySink{url: arg, client: &http.Client{}}, nil
+	})
+}
+
+// eventlogAnomalySink logs the anomalies as structured events.
+type eventlogAnomalySink struct{}
+
+// Report is part of the QueryAnomalySink interface.
+func (eventlogAnomalySink) Report(ctx context.Context, anomaly *QueryAnomaly) error {
+	eventlog.Warningf("query_anomaly", anomaly.Fingerprint, eventlog.Fields{
+		"anomaly":                  anomaly.Type,
+		"query":                    anomaly.Query,
+		"keyspace":                 anomaly.Keyspace,
+		"qps":                      anomaly.QPS,
+		"latency_seconds":          anomaly.LatencySeconds,
+		"error_rate":               anomaly.ErrorRate,
+		"baseline_qps":             anomaly.BaselineQPS,
+		"baseline_latency_seconds": anomaly.BaselineLatencySeconds,
+		"baseline_error_rate":      anomaly.BaselineErrorRate,
+	}, "Query anomaly %v of fingerprint %v", anomaly.Type, anomaly.Fingerprint)
+	return nil
+}
+
+// webhookAnomalySink POSTs the anomalies as JSON to a URL.
+type webhookAnomalySink struct {
+	url    string
+	client *http.Client
+}
+
+// Report is part of the QueryAnomalySink interface.
+func (s *webhookAnomalySink) Report(ctx context.Context, anomaly *QueryAnomaly) error {
+	data, err := json.Marshal(anomaly)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", s.url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("webhook %v returned %v", s.url, resp.Status)
+	}
+	return nil
+}
+
+// newQueryAnomalySinks creates the sinks of -query_anomaly_sinks.
+func newQueryAnomalySinks(names []string) (map[string]QueryAnomalySink, error) {
+	sinks := make(map[string]QueryAnomalySink)
+	for _, entry := range names {
+		parts := strings.SplitN(entry, ":", 2)
+		factory, ok := queryAnomalySinkFactories[parts[0]]
+		if !ok {
+			return nil, fmt.Errorf("unknown query anomaly sink %q", entry)
+		}
+		arg := ""
+		if len(parts) == 2 {
+			arg = parts[1]
+		}
+		sink, err := factory(arg)
+		if err != nil {
+			return nil, err
+		}
+		sinks[entry] = sink
+	}
+	return sinks, nil
+}
+
+// observedQuery is a query accounted for by the detection.
+type observedQuery struct {
+	sql      string
+	keyspace string
+	latency  time.Duration
+	failed   bool
+}
+
+// fingerprintStats are the stats of a fingerprint over the current window,
+// and its baseline.
+type fingerprintStats struct {
+	query    string
+	keyspace string
+
+	queries      int64
+	errors       int64
+	totalLatency time.Duration
+
+	// windows is the number of windows averaged in the baseline.
+	windows           int
+	baselineQPS       float64
+	baselineLatency   float64
+	baselineErrorRate float64
+	// idleWindows is the number of windows since the last query.
+	idleWindows  int
+	lastReported map[string]time.Time
+}
+
+// queryAnomalyDetector detects the anomalies of the query fingerprints.
+// Its methods are safe to call on a nil receiver, which detects nothing.
+type queryAnomalyDetector struct {
+	window time.Duration
+	sinks  map[string]QueryAnomalySink
+	queue  chan observedQuery
+
+	// fingerprints is only accessed by the goroutine of the detection.
+	fingerprints map[uint64]*fingerprintStats
+	// windows is the number of windows since the detection started. The
+	// fingerprints of the first window aren't new.
+	windows int
+
+	mu      sync.Mutex
+	history []*QueryAnomaly
+}
+
+func newQueryAnomalyDetector(window time.Duration, sinks map[string]QueryAnomalySink) *queryAnomalyDetector {
+	return &queryAnomalyDetector{
+		window:       window,
+		sinks:        sinks,
+		queue:        make(chan observedQuery, queryAnomalyQueueSize),
+		fingerprints: make(map[uint64]*fingerprintStats),
+	}
+}
+
+// startQueryAnomalyDetector starts the query anomaly detection, or
+// returns nil if it is disabled.
+func startQueryAnomalyDetector(ctx context.Context) *queryAnomalyDetector {
+	if *queryAnomalyWindow <= 0 {
+		return nil
+	}
+	sinks, err := newQueryAnomalySinks(queryAnomalySinks)
+	if err != nil {
+		log.Fatalf("Invalid -query_anomaly_sinks: %v", err)
+	}
+	d := newQueryAnomalyDetector(*queryAnomalyWindow, sinks)
+	http.HandleFunc("/debug/query_anomalies", d.serveHTTP)
+	go d.run(ctx)
+	return d
+}
+
+// observe accounts for a query. It doesn't block: the queries are dropped
+// if the detection lags behind.
+func (d *queryAnomalyDetector) observe(logStats *LogStats) {
+	if d == nil {
+		return
+	}
+	select {
+	case d.queue <- observedQuery{
+		sql:      logStats.SQL,
+		keyspace: logStats.Keyspace,
+		latency:  logStats.TotalTime(),
+		failed:   logStats.Error != nil,
+	}:
+	default:
+		queryAnomalyQueriesDropped.Add(1)
+	}
+}
+
+func (d *queryAnomalyDetector) run(ctx context.Context) {
+	ticker := time.NewTicker(d.window)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case q := <-d.queue:
+			d.account(q)
+		case now := <-ticker.C:
+			for _, anomaly := range d.endWindow(now) {
+				d.report(ctx, anomaly)
+			}
+		}
+	}
+}
+
+// account adds a query to the current window of its fingerprint.
+func (d *queryAnomalyDetector) account(q observedQuery) {
+	fingerprint, redacted := queryFingerprint(q.sql)
+	fs, ok := d.fingerprints[fingerprint]
+	if !ok {
+		if len(d.fingerprints) >= *queryAnomalyMaxFingerprints {
+			return
+		}
+		if len(redacted) > queryAnomalyMaxQueryLength {
+			redacted = redacted[:queryAnomalyMaxQueryLength]
+		}
+		fs = &fingerprintStats{query: redacted, lastReported: make(map[string]time.Time)}
+		d.fingerprints[fingerprint] = fs
+	}
+	fs.keyspace = q.keyspace
+	fs.queries++
+	fs.totalLatency += q.latency
+	if q.failed {
+		fs.errors++
+	}
+}
+
+// endWindow compares the window of each fingerprint to its baseline, then
+// adds it to the baseline. It returns the anomalies.
+func (d *queryAnomalyDetector) endWindow(now time.Time) []*QueryAnomaly {
+	var anomalies []*QueryAnomaly
+	seconds := d.window.Seconds()
+	for fingerprint, fs := range d.fingerprints {
+		if fs.queries == 0 {
+			// The idle fingerprints are forgotten once their baseline
+			// is out of date.
+			fs.idleWindows++
+			if fs.idleWindows > *queryAnomalyBaselineWindows {
+				delete(d.fingerprints, fingerprint)
+			}
+			continue
+		}
+		fs.idleWindows = 0
+
+		anomaly := &QueryAnomaly{
+			Time:                   now,
+			Fingerprint:            fmt.Sprintf("%016x", fingerprint),
+			Query:                  fs.query,
+			Keyspace:               fs.keyspace,
+			Queries:                fs.queries,
+			QPS:                    float64(fs.queries) / seconds,
+			LatencySeconds:         fs.totalLatency.Seconds() / float64(fs.queries),
+			ErrorRate:              float64(fs.errors) / float64(fs.queries),
+			BaselineQPS:            fs.baselineQPS,
+			BaselineLatencySeconds: fs.baselineLatency,
+			BaselineErrorRate:      fs.baselineErrorRate,
+		}
+		for _, anomalyType := range fs.anomalyTypes(anomaly, d.windows) {
+			if last, ok := fs.lastReported[anomalyType]; ok && now.Sub(last) < *queryAnomalyCooldown {
+				continue
+			}
+			fs.lastReported[anomalyType] = now
+			a := *anomaly
+			a.Type = anomalyType
+			anomalies = append(anomalies, &a)
+		}
+		fs.addToBaseline(anomaly)
+	}
+	d.windows++
+	return anomalies
+}
+
+// anomalyTypes returns the types of the anomalies of the window of the
+// fingerprint.
+func (fs *fingerprintStats) anomalyTypes(window *QueryAnomaly, detectorWindows int) []string {
+	var types []string
+	if fs.windows == 0 {
+		if detectorWindows > 0 && window.LatencySeconds >= queryAnomalyExpensiveLatency.Seconds() {
+			types = append(types, NewExpensiveFingerprint)
+		}
+		return types
+	}
+	if fs.windows < *queryAnomalyBaselineWindows {
+		return types
+	}
+	if fs.baselineQPS > 0 && window.QPS >= *queryAnomalyFactor*fs.baselineQPS {
+		types = append(types, QPSSpike)
+	}
+	if window.Queries < int64(*queryAnomalyMinQueries) {
+		return types
+	}
+	if fs.baselineLatency > 0 && window.LatencySeconds >= *queryAnomalyFactor*fs.baselineLatency {
+		types = append(types, LatencyRegression)
+	}
+	if window.ErrorRate >= *queryAnomalyErrorRate && fs.baselineErrorRate < *queryAnomalyErrorRate/2 {
+		types = append(types, ErrorRateSpike)
+	}
+	return types
+}
+
+// addToBaseline averages the window in the baseline of the fingerprint,
+// with the weight of one of the -query_anomaly_baseline_windows windows.
+func (fs *fingerprintStats) addToBaseline(window *QueryAnomaly) {
+	n := fs.windows + 1
+	if n > *queryAnomalyBaselineWindows {
+		n = *queryAnomalyBaselineWindows
+	}
+	weight := 1 / float64(n)
+	fs.baselineQPS += (window.QPS - fs.baselineQPS) * weight
+	fs.baselineLatency += (window.LatencySeconds - fs.baselineLatency) * weight
+	fs.baselineErrorRate += (window.ErrorRate - fs.baselineErrorRate) * weight
+	fs.windows++
+
+	fs.queries = 0
+	fs.errors = 0
+	fs.totalLatency = 0
+}
+
+// report records the anomaly and reports it to the sinks.
+func (d *queryAnomalyDetector) report(ctx context.Context, anomaly *QueryAnomaly) {
+	queryAnomalies.Add(anomaly.Type, 1)
+	d.mu.Lock()
+	d.history = append(d.history, anomaly)
+	if len(d.history) > queryAnomalyHistorySize {
+		d.history = d.history[len(d.history)-queryAnomalyHistorySize:]
+	}
+	d.mu.Unlock()
+
+	for name, sink := range d.sinks {
+		sinkCtx, cancel := context.WithTimeout(ctx, *queryAnomalySinkTimeout)
+		err := sink.Report(sinkCtx, anomaly)
+		cancel()
+		if err != nil {
+			queryAnomalySinkErrors.Add(name, 1)
+			log.Warningf("Cannot report the query anomaly %v of fingerprint %v to %v: %v", anomaly.Type, anomaly.Fingerprint, name, err)
+		}
+	}
+}
+
+// serveHTTP serves the last anomalies as JSON, the most recent first.
+func (d *queryAnomalyDetector) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := acl.CheckAccessHTTP(r, acl.MONITORING); err != nil {
+		acl.SendError(w, err)
+		return
+	}
+	d.mu.Lock()
+	anomalies := make([]*QueryAnomaly, 0, len(d.history))
+	for i := len(d.history) - 1; i >= 0; i-- {
+		anomalies = append(anomalies, d.history[i])
+	}
+	d.mu.Unlock()
+	data, err := json.MarshalIndent(anomalies, "", "  ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Write(data)
+}
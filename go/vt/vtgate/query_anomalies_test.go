@@ -0,0 +1,150 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// accountQueries accounts for n queries of the given latency, the first
+// failed of which fail.
+func accountQueries(d *queryAnomalyDetector, sql string, n, failed int, latency time.Duration) {
+	for i := 0; i < n; i++ {
+		d.account(observedQuery{sql: sql, keyspace: "ks", latency: latency, failed: i < failed})
+	}
+}
+
+func anomalyTypesOf(anomalies []*QueryAnomaly) []string {
+	var types []string
+	for _, anomaly := range anomalies {
+		types = append(types, anomaly.Type)
+	}
+	return types
+}
+
+func TestQueryAnomalyDetector(t *testing.T) {
+	d := newQueryAnomalyDetector(time.Second, nil)
+	now := time.Now()
+	tick := func() []*QueryAnomaly {
+		now = now.Add(time.Second)
+		return d.endWindow(now)
+	}
+
+	// The fingerprints of the first window aren't new, even if they are
+	// expensive.
+	accountQueries(d, "select * from t where id = 1", 20, 0, 10*time.Millisecond)
+	accountQueries(d, "select * from v", 1, 0, 2*time.Second)
+	assert.Empty(t, tick())
+
+	// Later, a new expensive fingerprint is an anomaly. The fingerprint
+	// doesn't depend on the literals.
+	accountQueries(d, "select * from t where id = 2", 20, 0, 10*time.Millisecond)
+	accountQueries(d, "select * from u where a = 'x'", 1, 0, 2*time.Second)
+	anomalies := tick()
+	require.Len(t, anomalies, 1)
+	assert.Equal(t, NewExpensiveFingerprint, anomalies[0].Type)
+	assert.Equal(t, "select * from u where a = :redacted1", anomalies[0].Query)
+	assert.Equal(t, "ks", anomalies[0].Keyspace)
+
+	// Build the baseline of the first fingerprint.
+	for i := 0; i < *queryAnomalyBaselineWindows; i++ {
+		accountQueries(d, "select * from t where id = 3", 20, 0, 10*time.Millisecond)
+		assert.Empty(t, tick())
+	}
+
+	// A latency regression, a QPS spike and an error rate spike.
+	accountQueries(d, "select * from t where id = 4", 20, 0, 2*time.Second)
+	assert.Equal(t, []string{LatencyRegression}, anomalyTypesOf(tick()))
+	accountQueries(d, "select * from t where id = 5", 500, 0, 10*time.Millisecond)
+	assert.Equal(t, []string{QPSSpike}, anomalyTypesOf(tick()))
+	accountQueries(d, "select * from t where id = 6", 20, 10, 10*time.Millisecond)
+	assert.Equal(t, []string{ErrorRateSpike}, anomalyTypesOf(tick()))
+
+	// The same anomaly isn't reported again until the cooldown is over.
+	accountQueries(d, "select * from t where id = 7", 20, 0, 200*time.Second)
+	assert.Empty(t, tick())
+}
+
+func TestQueryAnomalyDetectorForgetsIdleFingerprints(t *testing.T) {
+	d := newQueryAnomalyDetector(time.Second, nil)
+	accountQueries(d, "select 1 from dual", 1, 0, time.Millisecond)
+	for i := 0; i <= *queryAnomalyBaselineWindows+1; i++ {
+		d.endWindow(time.Now())
+	}
+	assert.Empty(t, d.fingerprints)
+}
+
+type fakeAnomalySink struct {
+	anomalies []*QueryAnomaly
+	err       error
+}
+
+func (s *fakeAnomalySink) Report(ctx context.Context, anomaly *QueryAnomaly) error {
+	s.anomalies = append(s.anomalies, anomaly)
+	return s.err
+}
+
+func TestQueryAnomalyDetectorReport(t *testing.T) {
+	sink := &fakeAnomalySink{}
+	failing := &fakeAnomalySink{err: errors.New("unavailable")}
+	d := newQueryAnomalyDetector(time.Second, map[string]QueryAnomalySink{"fake": sink, "failing": failing})
+	d.report(context.Background(), &QueryAnomaly{Type: QPSSpike, Fingerprint: "1"})
+	d.report(context.Background(), &QueryAnomaly{Type: LatencyRegression, Fingerprint: "2"})
+	assert.Len(t, sink.anomalies, 2)
+	assert.Len(t, failing.anomalies, 2)
+
+	rw := httptest.NewRecorder()
+	d.serveHTTP(rw, httptest.NewRequest("GET", "/debug/query_anomalies", nil))
+	var anomalies []*QueryAnomaly
+	require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &anomalies))
+	require.Len(t, anomalies, 2)
+	assert.Equal(t, "2", anomalies[0].Fingerprint)
+}
+
+func TestWebhookAnomalySink(t *testing.T) {
+	var received QueryAnomaly
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+	}))
+	defer server.Close()
+
+	sinks, err := newQueryAnomalySinks([]string{"eventlog", "webhook:" + server.URL})
+	require.NoError(t, err)
+	require.Len(t, sinks, 2)
+	err = sinks["webhook:"+server.URL].Report(context.Background(), &QueryAnomaly{Type: QPSSpike, Fingerprint: "1"})
+	require.NoError(t, err)
+	assert.Equal(t, QPSSpike, received.Type)
+
+	_, err = newQueryAnomalySinks([]string{"pager"})
+	assert.EqualError(t, err, `unknown query anomaly sink "pager"`)
+	_, err = newQueryAnomalySinks([]string{"webhook"})
+	assert.Error(t, err)
+}
+
+func TestQueryAnomalyDetectorNil(t *testing.T) {
+	var d *queryAnomalyDetector
+	d.observe(&LogStats{})
+}
@@ -0,0 +1,277 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"flag"
+	"math"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/srvtopo"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// This file implements the per user query quotas of vtgate, set by the
+// ApplyQueryQuotas vtctl command. The user of a query is the user of its
+// immediate caller ID, e.g. the MySQL user, or else the principal of its
+// effective caller ID. A query which exceeds a quota of its user fails
+// with RESOURCE_EXHAUSTED, and its error tells when to retry. The quotas
+// are enforced by each vtgate: the limits of a user apply to each of the
+// vtgates it connects to.
+
+var (
+	queryQuotasRefreshInterval = flag.Duration("query_quotas_refresh_interval", 10*time.Second, "How often the query quotas of the users are reloaded from the topo. 0 disables them.")
+
+	queryQuotaRejections = stats.NewCountersWithMultiLabels(
+		"QueryQuotaRejections",
+		"Number of queries rejected because they exceeded a query quota, by user and quota",
+		[]string{"User", "Quota"})
+
+	retryAfterRegexp = regexp.MustCompile(`retry after (\d+)ms`)
+)
+
+const (
+	// quotaWindow is the window of the per minute quotas.
+	quotaWindow = time.Minute
+	// concurrencyRetryAfter is the delay after which a query rejected by
+	// the concurrent queries quota should be retried.
+	concurrencyRetryAfter = 100 * time.Millisecond
+)
+
+// queryQuotas enforces the query quotas. All its methods are safe to call
+// on a nil receiver, which doesn't enforce any quota.
+type queryQuotas struct {
+	now func() time.Time
+
+	mu     sync.Mutex
+	quotas *topo.QueryQuotas
+	usages map[string]*quotaUsage
+}
+
+// quotaUsage is the usage of the quotas of a user.
+type quotaUsage struct {
+	// tokens is the token bucket of the QPS quota, refilled at QPS tokens
+	// per second up to one second of queries.
+	tokens     float64
+	lastRefill time.Time
+	concurrent int
+	// rows and scatters are counted in fixed windows of a minute.
+	windowStart time.Time
+	rows        int64
+	scatters    int64
+}
+
+func newQueryQuotas() *queryQuotas {
+	return &queryQuotas{
+		now:    time.Now,
+		usages: make(map[string]*quotaUsage),
+	}
+}
+
+// quotaUser returns the user whose quotas apply to the queries of ctx.
+func quotaUser(ctx context.Context) string {
+	if user := callerid.ImmediateCallerIDFromContext(ctx).GetUsername(); user != "" {
+		return user
+	}
+	return callerid.EffectiveCallerIDFromContext(ctx).GetPrincipal()
+}
+
+// quotaExceeded returns the error of a query which exceeded a quota.
+func quotaExceeded(user, quota string, retryAfter time.Duration) error {
+	queryQuotaRejections.Add([]string{user, quota}, 1)
+	ms := int64(math.Ceil(float64(retryAfter) / float64(time.Millisecond)))
+	return vterrors.Errorf(vtrpcpb.Code_RESOURCE_EXHAUSTED, "query quota %v exceeded for user %v, retry after %dms", quota, user, ms)
+}
+
+// RetryAfter returns how long to wait before retrying a query which was
+// rejected by a query quota. ok is false if err isn't such a rejection.
+func RetryAfter(err error) (retryAfter time.Duration, ok bool) {
+	if err == nil || vterrors.Code(err) != vtrpcpb.Code_RESOURCE_EXHAUSTED {
+		return 0, false
+	}
+	match := retryAfterRegexp.FindStringSubmatch(err.Error())
+	if match == nil {
+		return 0, false
+	}
+	ms, err := strconv.ParseInt(match[1], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return time.Duration(ms) * time.Millisecond, true
+}
+
+// quotaLocked returns the quota of the user, or nil if none is set.
+func (q *queryQuotas) quotaLocked(user string) *topo.QueryQuota {
+	if q.quotas == nil {
+		return nil
+	}
+	if quota, ok := q.quotas.Users[user]; ok {
+		return quota
+	}
+	return q.quotas.Default
+}
+
+// usageLocked returns the usage of the user, with its token bucket
+// refilled and its window rolled over at now.
+func (q *queryQuotas) usageLocked(user string, quota *topo.QueryQuota, now time.Time) *quotaUsage {
+	burst := math.Max(quota.QPS, 1)
+	u := q.usages[user]
+	if u == nil {
+		u = &quotaUsage{tokens: burst, lastRefill: now, windowStart: now}
+		q.usages[user] = u
+	}
+	if elapsed := now.Sub(u.lastRefill); elapsed > 0 {
+		u.tokens = math.Min(burst, u.tokens+elapsed.Seconds()*quota.QPS)
+		u.lastRefill = now
+	}
+	if now.Sub(u.windowStart) >= quotaWindow {
+		u.windowStart = now
+		u.rows = 0
+		u.scatters = 0
+	}
+	return u
+}
+
+// start admits a query of the user of ctx, or returns the error of the
+// quota it exceeds. done must be called with the number of rows the query
+// returned once it finished.
+func (q *queryQuotas) start(ctx context.Context) (done func(rows int64), err error) {
+	if q == nil {
+		return func(int64) {}, nil
+	}
+	user := quotaUser(ctx)
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	quota := q.quotaLocked(user)
+	if quota.IsEmpty() {
+		return func(int64) {}, nil
+	}
+	now := q.now()
+	u := q.usageLocked(user, quota, now)
+	if quota.RowsPerMinute > 0 && u.rows >= quota.RowsPerMinute {
+		return nil, quotaExceeded(user, "rows_per_minute", u.windowStart.Add(quotaWindow).Sub(now))
+	}
+	if quota.ConcurrentQueries > 0 && u.concurrent >= quota.ConcurrentQueries {
+		return nil, quotaExceeded(user, "concurrent_queries", concurrencyRetryAfter)
+	}
+	if quota.QPS > 0 {
+		if u.tokens < 1 {
+			return nil, quotaExceeded(user, "qps", time.Duration((1-u.tokens)/quota.QPS*float64(time.Second)))
+		}
+		u.tokens--
+	}
+	u.concurrent++
+	return func(rows int64) {
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		u.concurrent--
+		if now := q.now(); now.Sub(u.windowStart) >= quotaWindow {
+			u.windowStart = now
+			u.rows = 0
+			u.scatters = 0
+		}
+		u.rows += rows
+	}, nil
+}
+
+// checkScatter admits a scatter query of the user of ctx, or returns the
+// error of the scatter queries quota if it exceeds it.
+func (q *queryQuotas) checkScatter(ctx context.Context) error {
+	if q == nil {
+		return nil
+	}
+	user := quotaUser(ctx)
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	quota := q.quotaLocked(user)
+	if quota.IsEmpty() || quota.ScatterQueriesPerMinute == 0 {
+		return nil
+	}
+	now := q.now()
+	u := q.usageLocked(user, quota, now)
+	if u.scatters >= quota.ScatterQueriesPerMinute {
+		return quotaExceeded(user, "scatter_queries_per_minute", u.windowStart.Add(quotaWindow).Sub(now))
+	}
+	u.scatters++
+	return nil
+}
+
+// set replaces the quotas, and forgets the usage of the idle users.
+func (q *queryQuotas) set(quotas *topo.QueryQuotas) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.quotas = quotas
+	now := q.now()
+	for user, u := range q.usages {
+		if u.concurrent == 0 && now.Sub(u.lastRefill) >= quotaWindow && now.Sub(u.windowStart) >= quotaWindow {
+			delete(q.usages, user)
+		}
+	}
+}
+
+// refresh reloads the query quotas. If they can't be read, the previous
+// quotas are kept.
+func (q *queryQuotas) refresh(ctx context.Context, ts *topo.Server) error {
+	quotas, err := ts.GetQueryQuotas(ctx)
+	if err != nil {
+		return err
+	}
+	q.set(quotas)
+	return nil
+}
+
+// run refreshes the query quotas every interval until ctx is done.
+func (q *queryQuotas) run(ctx context.Context, ts *topo.Server, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := q.refresh(ctx, ts); err != nil {
+			log.Warningf("Cannot refresh the query quotas: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// startQueryQuotas returns the query quotas, and keeps them up to date. It
+// returns nil if they are disabled.
+func startQueryQuotas(ctx context.Context, serv srvtopo.Server) *queryQuotas {
+	if *queryQuotasRefreshInterval <= 0 {
+		return nil
+	}
+	ts, err := serv.GetTopoServer()
+	if err != nil || ts == nil {
+		log.Warningf("Query quotas disabled, topo server not available: %v", err)
+		return nil
+	}
+	q := newQueryQuotas()
+	go q.run(ctx, ts, *queryQuotasRefreshInterval)
+	return q
+}
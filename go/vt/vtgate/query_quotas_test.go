@@ -0,0 +1,125 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+func TestQueryQuotas(t *testing.T) {
+	ctx := context.Background()
+	ts := memorytopo.NewServer("cell1")
+	require.NoError(t, ts.SaveQueryQuotas(ctx, &topo.QueryQuotas{
+		Default: &topo.QueryQuota{QPS: 2},
+		Users: map[string]*topo.QueryQuota{
+			"batch": {ConcurrentQueries: 1, RowsPerMinute: 10, ScatterQueriesPerMinute: 1},
+			"admin": {},
+		},
+	}))
+
+	now := time.Unix(1000, 0)
+	q := newQueryQuotas()
+	q.now = func() time.Time { return now }
+	require.NoError(t, q.refresh(ctx, ts))
+
+	userCtx := func(user string) context.Context {
+		return callerid.NewContext(ctx, nil, callerid.NewImmediateCallerID(user))
+	}
+
+	// The default quota allows bursts of a second of queries.
+	app := userCtx("app")
+	for i := 0; i < 2; i++ {
+		done, err := q.start(app)
+		require.NoError(t, err)
+		done(1)
+	}
+	_, err := q.start(app)
+	require.Error(t, err)
+	assert.Equal(t, vtrpcpb.Code_RESOURCE_EXHAUSTED, vterrors.Code(err))
+	assert.Contains(t, err.Error(), "query quota qps exceeded for user app")
+	retryAfter, ok := RetryAfter(err)
+	require.True(t, ok)
+	assert.Equal(t, 500*time.Millisecond, retryAfter)
+	now = now.Add(500 * time.Millisecond)
+	done, err := q.start(app)
+	require.NoError(t, err)
+	done(0)
+
+	// The users with their own quota don't use the default one.
+	admin := userCtx("admin")
+	for i := 0; i < 10; i++ {
+		done, err := q.start(admin)
+		require.NoError(t, err)
+		done(1000)
+	}
+
+	batch := userCtx("batch")
+	done, err = q.start(batch)
+	require.NoError(t, err)
+	_, err = q.start(batch)
+	assert.Contains(t, err.Error(), "query quota concurrent_queries exceeded for user batch")
+	done(10)
+	_, err = q.start(batch)
+	assert.Contains(t, err.Error(), "query quota rows_per_minute exceeded for user batch")
+	retryAfter, _ = RetryAfter(err)
+	assert.Equal(t, time.Minute, retryAfter)
+
+	require.NoError(t, q.checkScatter(batch))
+	err = q.checkScatter(batch)
+	assert.Contains(t, err.Error(), "query quota scatter_queries_per_minute exceeded for user batch")
+	require.NoError(t, q.checkScatter(app))
+
+	// The per minute quotas are reset every minute.
+	now = now.Add(time.Minute)
+	done, err = q.start(batch)
+	require.NoError(t, err)
+	done(0)
+	require.NoError(t, q.checkScatter(batch))
+
+	// The quotas are reloaded, and the idle users are forgotten.
+	require.NoError(t, ts.SaveQueryQuotas(ctx, nil))
+	now = now.Add(time.Minute)
+	require.NoError(t, q.refresh(ctx, ts))
+	assert.Empty(t, q.usages)
+	for i := 0; i < 10; i++ {
+		done, err := q.start(app)
+		require.NoError(t, err)
+		done(0)
+	}
+
+	var nilQuotas *queryQuotas
+	done, err = nilQuotas.start(app)
+	require.NoError(t, err)
+	done(0)
+	require.NoError(t, nilQuotas.checkScatter(app))
+
+	_, ok = RetryAfter(vterrors.Errorf(vtrpcpb.Code_INTERNAL, "retry after 10ms"))
+	assert.False(t, ok)
+	assert.Error(t, ts.SaveQueryQuotas(ctx, &topo.QueryQuotas{Default: &topo.QueryQuota{QPS: -1}}))
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"vitess.io/vitess/go/flagutil"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// This file implements the query timeout policy of vtgate. The timeout of a
+// query is, by order of precedence: the query_timeout_ms of its table, the
+// tablet_types_ms of its tablet type in the query_timeouts of its keyspace,
+// the default_ms of its keyspace, all set in the VSchema, and the timeout
+// of its tablet type, set by -query_timeout_by_tablet_type. The policy
+// applies to the whole plan, and to each of its routes with the keyspace
+// and table of the route, s.t. e.g. a join can't run a query longer than
+// the timeout of its table. The QUERY_TIMEOUT_MS comment directive of a
+// query overrides the policy, and can raise its timeout. None of them can
+// exceed the timeout of the client request, e.g.
+// -mysql_server_query_timeout.
+
+var (
+	queryTimeoutByTabletType flagutil.StringMapValue
+
+	queryTimeoutErrors = stats.NewCountersWithMultiLabels(
+		"QueryTimeoutErrors",
+		"Number of queries which failed because they exceeded the timeout of the query timeout policy, by keyspace and table",
+		[]string{"Keyspace", "Table"})
+)
+
+func init() {
+	flag.Var(&queryTimeoutByTabletType, "query_timeout_by_tablet_type", "Comma separated list of tablet_type:timeout, e.g. master:10s,rdonly:5m. The default timeout of the queries on the tablets of the given type, when the VSchema of their keyspace and table don't set one.")
+}
+
+// queryTimeoutPolicy returns the timeout of the queries. Its methods are
+// safe to call on a nil receiver, which only applies the VSchema timeouts.
+type queryTimeoutPolicy struct {
+	byTabletType map[topodatapb.TabletType]time.Duration
+}
+
+// newQueryTimeoutPolicy returns a policy with the given timeouts by tablet
+// type, in the format of -query_timeout_by_tablet_type.
+func newQueryTimeoutPolicy(byTabletType map[string]string) (*queryTimeoutPolicy, error) {
+	p := &queryTimeoutPolicy{
+		byTabletType: make(map[topodatapb.TabletType]time.Duration),
+	}
+	for name, value := range byTabletType {
+		tabletType, err := topoproto.ParseTabletType(name)
+		if err != nil {
+			return nil, err
+		}
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout for tablet type %v: %v", name, err)
+		}
+		p.byTabletType[tabletType] = timeout
+	}
+	return p, nil
+}
+
+// timeout returns the timeout of a query on the table of the keyspace, or
+// 0 if none applies. table is the table name of the plan, which is a comma
+// separated list if the query was merged across tables: the largest
+// timeout of the listed tables applies then.
+func (p *queryTimeoutPolicy) timeout(vschema *vindexes.VSchema, keyspace, table string, tabletType topodatapb.TabletType) time.Duration {
+	var ks *vindexes.KeyspaceSchema
+	if vschema != nil {
+		ks = vschema.Keyspaces[keyspace]
+	}
+	if ks != nil {
+		var tableMs int64
+		for _, name := range strings.Split(table, ",") {
+			if t := ks.Tables[strings.TrimSpace(name)]; t != nil && t.QueryTimeoutMs > tableMs {
+				tableMs = t.QueryTimeoutMs
+			}
+		}
+		if tableMs > 0 {
+			return time.Duration(tableMs) * time.Millisecond
+		}
+		if qt := ks.QueryTimeouts; qt != nil {
+			if ms := qt.TabletTypesMs[topoproto.TabletTypeLString(tabletType)]; ms > 0 {
+				return time.Duration(ms) * time.Millisecond
+			}
+			if qt.DefaultMs > 0 {
+				return time.Duration(qt.DefaultMs) * time.Millisecond
+			}
+		}
+	}
+	if p == nil {
+		return 0
+	}
+	return p.byTabletType[tabletType]
+}
+
+// initQueryTimeoutPolicy returns the query timeout policy configured by
+// -query_timeout_by_tablet_type, or nil if it is not set.
+func initQueryTimeoutPolicy() *queryTimeoutPolicy {
+	if len(queryTimeoutByTabletType) == 0 {
+		return nil
+	}
+	p, err := newQueryTimeoutPolicy(queryTimeoutByTabletType)
+	if err != nil {
+		log.Exitf("Invalid -query_timeout_by_tablet_type: %v", err)
+	}
+	return p
+}
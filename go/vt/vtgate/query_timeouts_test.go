@@ -0,0 +1,80 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+)
+
+func TestQueryTimeoutPolicy(t *testing.T) {
+	vschema := vindexes.BuildVSchema(&vschemapb.SrvVSchema{
+		Keyspaces: map[string]*vschemapb.Keyspace{
+			"ks1": {
+				QueryTimeouts: &vschemapb.QueryTimeouts{
+					DefaultMs:     1000,
+					TabletTypesMs: map[string]int64{"rdonly": 600000},
+				},
+				Tables: map[string]*vschemapb.Table{
+					"t1": {QueryTimeoutMs: 30000},
+					"t2": {QueryTimeoutMs: 5000},
+					"t3": {},
+				},
+			},
+			"ks2": {
+				Tables: map[string]*vschemapb.Table{
+					"t1": {QueryTimeoutMs: 2000},
+					"t2": {},
+				},
+			},
+			"ks3": {},
+		},
+	})
+
+	p, err := newQueryTimeoutPolicy(map[string]string{"master": "10s", "rdonly": "5m"})
+	require.NoError(t, err)
+
+	master := topodatapb.TabletType_MASTER
+	assert.Equal(t, 30*time.Second, p.timeout(vschema, "ks1", "t1", master))
+	assert.Equal(t, 30*time.Second, p.timeout(vschema, "ks1", "t2, t1", master))
+	assert.Equal(t, time.Second, p.timeout(vschema, "ks1", "t3", master))
+	assert.Equal(t, 30*time.Second, p.timeout(vschema, "ks1", "t1", topodatapb.TabletType_RDONLY))
+	assert.Equal(t, 10*time.Minute, p.timeout(vschema, "ks1", "t3", topodatapb.TabletType_RDONLY))
+	assert.Equal(t, 2*time.Second, p.timeout(vschema, "ks2", "t1", master))
+	assert.Equal(t, 10*time.Second, p.timeout(vschema, "ks2", "t2", master))
+	assert.Equal(t, 5*time.Minute, p.timeout(vschema, "ks3", "t1", topodatapb.TabletType_RDONLY))
+	assert.Equal(t, time.Duration(0), p.timeout(vschema, "ks3", "t1", topodatapb.TabletType_REPLICA))
+	assert.Equal(t, 10*time.Second, p.timeout(nil, "ks1", "t1", master))
+
+	// Without -query_timeout_by_tablet_type, only the VSchema applies.
+	var nilPolicy *queryTimeoutPolicy
+	assert.Equal(t, 30*time.Second, nilPolicy.timeout(vschema, "ks1", "t1", master))
+	assert.Equal(t, time.Duration(0), nilPolicy.timeout(vschema, "ks3", "t1", master))
+
+	_, err = newQueryTimeoutPolicy(map[string]string{"master": "abc"})
+	assert.Error(t, err)
+	_, err = newQueryTimeoutPolicy(map[string]string{"bogus": "1s"})
+	assert.Error(t, err)
+}
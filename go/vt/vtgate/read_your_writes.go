@@ -0,0 +1,173 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/srvtopo"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
+	"vitess.io/vitess/go/vt/vttablet/queryservice"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// A session with read-your-writes consistency, set with
+// @@vitess_consistency = 'read_your_writes', records the GTID set executed
+// by the master of each shard it writes to. Its reads outside of a
+// transaction, streamed or not, are then sent to a replica of the shard
+// if the replica has executed this GTID set, and to the master otherwise.
+// The GTID sets are kept in their own field of the session: the
+// @@read_after_write_gtid and @@session_track_gtids settings are left to
+// the application.
+
+var (
+	readYourWritesTimeout = flag.Duration("read_your_writes_timeout", 0, "how long a replica is waited for to catch up with the writes of a session with read-your-writes consistency before reading from the master instead, if the session doesn't set @@read_after_write_timeout. With 0, reads only go to replicas which have already caught up.")
+
+	readYourWritesReads = stats.NewCountersWithMultiLabels(
+		"ReadYourWritesReads",
+		"Reads of sessions with read-your-writes consistency by keyspace and tablet type they were sent to",
+		[]string{"Keyspace", "TabletType"})
+)
+
+// recordWriteGTIDs records the GTID sets executed by the masters of the
+// targets after a write of the session. If one can't be read, the
+// reads of the session on this shard will go to the master.
+func recordWriteGTIDs(ctx context.Context, qs queryservice.QueryService, session *SafeSession, targets []*querypb.Target) {
+	for _, target := range targets {
+		if target.TabletType != topodatapb.TabletType_MASTER {
+			continue
+		}
+		var gtid string
+		qr, err := qs.Execute(ctx, target, "select @@global.gtid_executed", nil, 0, 0, nil)
+		switch {
+		case err != nil:
+			log.Warningf("Cannot read the executed GTID set of %s/%s, the reads of the session will go to the master: %v", target.Keyspace, target.Shard, err)
+		case len(qr.Rows) != 1 || len(qr.Rows[0]) != 1:
+			log.Warningf("Unexpected result reading the executed GTID set of %s/%s, the reads of the session will go to the master: %v", target.Keyspace, target.Shard, qr.Rows)
+		default:
+			// The GTID set of MySQL contains new lines between the
+			// server UUIDs.
+			gtid = strings.Replace(qr.Rows[0][0].ToString(), "\n", "", -1)
+		}
+		session.RecordReadYourWritesGTID(target.Keyspace, target.Shard, gtid)
+	}
+}
+
+// readYourWritesShards returns the shards to send a read of the session
+// to: for each shard, a replica which has executed the GTID set of the
+// writes of the session, or the master.
+func readYourWritesShards(ctx context.Context, session *SafeSession, rss []*srvtopo.ResolvedShard) []*srvtopo.ResolvedShard {
+	timeout := *readYourWritesTimeout
+	if t := session.ReadAfterWriteTimeout(); t > 0 {
+		timeout = time.Duration(t * float64(time.Second))
+	}
+
+	routed := make([]*srvtopo.ResolvedShard, len(rss))
+	var wg sync.WaitGroup
+	for i, rs := range rss {
+		wg.Add(1)
+		go func(i int, rs *srvtopo.ResolvedShard) {
+			defer wg.Done()
+			routed[i] = readYourWritesShard(ctx, session, rs, timeout)
+			readYourWritesReads.Add([]string{routed[i].Target.Keyspace, routed[i].Target.TabletType.String()}, 1)
+		}(i, rs)
+	}
+	wg.Wait()
+	return routed
+}
+
+func readYourWritesShard(ctx context.Context, session *SafeSession, rs *srvtopo.ResolvedShard, timeout time.Duration) *srvtopo.ResolvedShard {
+	master := &srvtopo.ResolvedShard{
+		Target: &querypb.Target{
+			Keyspace:   rs.Target.Keyspace,
+			Shard:      rs.Target.Shard,
+			TabletType: topodatapb.TabletType_MASTER,
+			Cell:       rs.Target.Cell,
+		},
+		Gateway: rs.Gateway,
+	}
+	gw, ok := rs.Gateway.(*TabletGateway)
+	if !ok {
+		return master
+	}
+	gtid, ok := session.ReadYourWritesGTIDFor(rs.Target.Keyspace, rs.Target.Shard)
+	if ok && gtid == "" {
+		// The position of the master after the last write is unknown.
+		return master
+	}
+	target := &querypb.Target{
+		Keyspace:   rs.Target.Keyspace,
+		Shard:      rs.Target.Shard,
+		TabletType: topodatapb.TabletType_REPLICA,
+		Cell:       rs.Target.Cell,
+	}
+	conn := gw.PickTablet(target)
+	if conn == nil {
+		return master
+	}
+	if ok {
+		caughtUp, err := replicaCaughtUp(ctx, conn, target, gtid, timeout)
+		if err != nil {
+			log.Warningf("Cannot check the executed GTID set of a replica of %s/%s, reading from the master: %v", target.Keyspace, target.Shard, err)
+		}
+		if !caughtUp {
+			return master
+		}
+	}
+	// The read must go to the replica which was checked.
+	return &srvtopo.ResolvedShard{Target: target, Gateway: tabletConn{conn}}
+}
+
+// replicaCaughtUp returns true if the replica has executed the GTID set,
+// waiting for it up to timeout.
+func replicaCaughtUp(ctx context.Context, conn queryservice.QueryService, target *querypb.Target, gtid string, timeout time.Duration) (bool, error) {
+	query := fmt.Sprintf("select gtid_subset(%s, @@global.gtid_executed)", sqltypes.EncodeStringSQL(gtid))
+	if timeout > 0 {
+		query = fmt.Sprintf("select wait_for_executed_gtid_set(%s, %s) = 0", sqltypes.EncodeStringSQL(gtid), strconv.FormatFloat(timeout.Seconds(), 'f', -1, 64))
+	}
+	qr, err := conn.Execute(ctx, target, query, nil, 0, 0, nil)
+	if err != nil {
+		return false, err
+	}
+	if len(qr.Rows) != 1 || len(qr.Rows[0]) != 1 {
+		return false, fmt.Errorf("unexpected result: %v", qr.Rows)
+	}
+	v, err := evalengine.ToInt64(qr.Rows[0][0])
+	return v == 1, err
+}
+
+// tabletConn is a srvtopo.Gateway which sends all the queries to the same
+// tablet.
+type tabletConn struct {
+	queryservice.QueryService
+}
+
+// QueryServiceByAlias is part of the srvtopo.Gateway interface.
+func (tc tabletConn) QueryServiceByAlias(_ *topodatapb.TabletAlias, _ *querypb.Target) (queryservice.QueryService, error) {
+	return tc.QueryService, nil
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/discovery"
+	"vitess.io/vitess/go/vt/srvtopo"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+func TestReadYourWritesSession(t *testing.T) {
+	session := NewSafeSession(&vtgatepb.Session{})
+	session.SetSessionTrackGtids(true)
+	session.SetReadAfterWriteGTID("uuid3:1-2")
+	assert.False(t, session.ReadYourWrites())
+
+	session.SetReadYourWrites(true)
+	assert.True(t, session.ReadYourWrites())
+	_, ok := session.ReadYourWritesGTIDFor("ks", "-80")
+	assert.False(t, ok)
+	session.RecordReadYourWritesGTID("ks", "-80", "uuid1:1-5")
+	gtid, ok := session.ReadYourWritesGTIDFor("ks", "-80")
+	assert.True(t, ok)
+	assert.Equal(t, "uuid1:1-5", gtid)
+	_, ok = session.ReadYourWritesGTIDFor("ks", "80-")
+	assert.False(t, ok)
+
+	// The settings of the application are left alone.
+	assert.Equal(t, "uuid3:1-2", session.ReadAfterWrite.ReadAfterWriteGtid)
+	assert.True(t, session.ReadAfterWrite.SessionTrackGtids)
+
+	session.SetReadYourWrites(false)
+	assert.False(t, session.ReadYourWrites())
+	assert.Nil(t, session.Session.ReadYourWrites)
+}
+
+func TestReadYourWritesShards(t *testing.T) {
+	ctx := context.Background()
+	hc := discovery.NewFakeHealthCheck()
+	gw := NewTabletGateway(ctx, hc, nil, "cell")
+	master := hc.AddTestTablet("cell", "1", 1, "ks", "0", topodatapb.TabletType_MASTER, true, 10, nil)
+	replica := hc.AddTestTablet("cell", "2", 1, "ks", "0", topodatapb.TabletType_REPLICA, true, 10, nil)
+	masterTarget := &querypb.Target{Keyspace: "ks", Shard: "0", TabletType: topodatapb.TabletType_MASTER}
+	rss := []*srvtopo.ResolvedShard{{Target: masterTarget, Gateway: gw}}
+
+	session := NewSafeSession(&vtgatepb.Session{})
+	session.SetReadYourWrites(true)
+
+	// Without writes, the reads go to a replica.
+	routed := readYourWritesShards(ctx, session, rss)
+	assert.Equal(t, topodatapb.TabletType_REPLICA, routed[0].Target.TabletType)
+	assert.Empty(t, replica.Queries)
+
+	master.SetResults([]*sqltypes.Result{sqltypes.MakeTestResult(sqltypes.MakeTestFields("gtid", "varchar"), "uuid1:1-5,\nuuid2:1-3")})
+	recordWriteGTIDs(ctx, gw, session, []*querypb.Target{masterTarget})
+	assert.Equal(t, map[string]string{"ks/0": "uuid1:1-5,uuid2:1-3"}, session.Session.ReadYourWrites.Gtids)
+
+	caughtUp := func(v string) []*sqltypes.Result {
+		return []*sqltypes.Result{sqltypes.MakeTestResult(sqltypes.MakeTestFields("caught_up", "int64"), v)}
+	}
+	replica.SetResults(caughtUp("1"))
+	routed = readYourWritesShards(ctx, session, rss)
+	assert.Equal(t, topodatapb.TabletType_REPLICA, routed[0].Target.TabletType)
+	require.Len(t, replica.Queries, 1)
+	assert.Equal(t, "select gtid_subset('uuid1:1-5,uuid2:1-3', @@global.gtid_executed)", replica.Queries[0].Sql)
+
+	// The read goes to the replica which was checked.
+	_, err := routed[0].Gateway.Execute(ctx, routed[0].Target, "select 1", nil, 0, 0, nil)
+	require.NoError(t, err)
+	assert.Len(t, replica.Queries, 2)
+
+	session.SetReadAfterWriteTimeout(0.5)
+	replica.SetResults(caughtUp("0"))
+	routed = readYourWritesShards(ctx, session, rss)
+	assert.Equal(t, topodatapb.TabletType_MASTER, routed[0].Target.TabletType)
+	assert.Equal(t, "select wait_for_executed_gtid_set('uuid1:1-5,uuid2:1-3', 0.5) = 0", replica.Queries[2].Sql)
+
+	// If the position of the master is unknown, the reads go to the master.
+	master.MustFailCodes[vtrpcpb.Code_INVALID_ARGUMENT] = 1
+	recordWriteGTIDs(ctx, gw, session, []*querypb.Target{masterTarget})
+	assert.Equal(t, map[string]string{"ks/0": ""}, session.Session.ReadYourWrites.Gtids)
+	routed = readYourWritesShards(ctx, session, rss)
+	assert.Equal(t, topodatapb.TabletType_MASTER, routed[0].Target.TabletType)
+	assert.Len(t, replica.Queries, 3)
+}
+
+// streamExecutor records the shards the queries are streamed to.
+type streamExecutor struct {
+	iExecute
+	rss []*srvtopo.ResolvedShard
+}
+
+func (se *streamExecutor) StreamExecuteMulti(_ context.Context, _ string, rss []*srvtopo.ResolvedShard, _ []map[string]*querypb.BindVariable, _ *querypb.ExecuteOptions, _ func(reply *sqltypes.Result) error) []error {
+	se.rss = rss
+	return nil
+}
+
+func TestReadYourWritesStreamExecute(t *testing.T) {
+	ctx := context.Background()
+	hc := discovery.NewFakeHealthCheck()
+	gw := NewTabletGateway(ctx, hc, nil, "cell")
+	hc.AddTestTablet("cell", "1", 1, "ks", "0", topodatapb.TabletType_MASTER, true, 10, nil)
+	hc.AddTestTablet("cell", "2", 1, "ks", "0", topodatapb.TabletType_REPLICA, true, 10, nil)
+	rss := []*srvtopo.ResolvedShard{{Target: &querypb.Target{Keyspace: "ks", Shard: "0", TabletType: topodatapb.TabletType_REPLICA}, Gateway: gw}}
+
+	session := NewSafeSession(&vtgatepb.Session{})
+	session.SetReadYourWrites(true)
+	session.RecordReadYourWritesGTID("ks", "0", "")
+	executor := &streamExecutor{}
+	vc := &vcursorImpl{
+		ctx:         ctx,
+		safeSession: session,
+		tabletType:  topodatapb.TabletType_REPLICA,
+		logStats:    &LogStats{StmtType: "SELECT"},
+		executor:    executor,
+	}
+
+	// The position of the master after the last write is unknown, so the
+	// streamed read goes to the master.
+	assert.Empty(t, vc.StreamExecuteMulti(ctx, "select 1", rss, nil, nil))
+	require.Len(t, executor.rss, 1)
+	assert.Equal(t, topodatapb.TabletType_MASTER, executor.rss[0].Target.TabletType)
+
+	session.SetReadYourWrites(false)
+	assert.Empty(t, vc.StreamExecuteMulti(ctx, "select 1", rss, nil, nil))
+	assert.Equal(t, topodatapb.TabletType_REPLICA, executor.rss[0].Target.TabletType)
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"flag"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// This file keeps the replication lag thresholds of the keyspaces, set in
+// their keyspace records by the SetReplicationLagThresholds vtctl command,
+// up to date in the health check of the gateway. The health check applies
+// them instead of -discovery_low_replication_lag and
+// -discovery_high_replication_lag_minimum_serving when it selects the
+// replicas queries are routed to.
+
+var enableReplicationLagThresholds = flag.Bool("enable_keyspace_replication_lag_thresholds", false, "Apply the replication lag thresholds of the keyspaces, set in their keyspace records by the SetReplicationLagThresholds vtctl command, reloaded from the topo every -keyspace_settings_refresh_interval. If disabled, only the -discovery_*_replication_lag* flags apply.")
+
+// replicationLagThresholdsSetter is the part of the health check that
+// applies the replication lag thresholds.
+type replicationLagThresholdsSetter interface {
+	SetReplicationLagThresholds(keyspaces map[string]*topodatapb.ReplicationLagThresholds)
+}
+
+// replicationLagThresholds watches the replication lag thresholds of the
+// keyspaces, and sets them in the health check when they change.
+type replicationLagThresholds struct {
+	setter replicationLagThresholdsSetter
+
+	mu        sync.Mutex
+	keyspaces map[string]*topodatapb.ReplicationLagThresholds
+}
+
+// refresh is part of the keyspaceSetting interface.
+func (rt *replicationLagThresholds) refresh(ctx context.Context, ts *topo.Server, keyspaces []string) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	thresholds := make(map[string]*topodatapb.ReplicationLagThresholds)
+	for _, keyspace := range keyspaces {
+		var kt *topodatapb.ReplicationLagThresholds
+		ki, err := ts.GetKeyspace(ctx, keyspace)
+		if err != nil {
+			log.Warningf("Cannot read the replication lag thresholds of keyspace %v: %v", keyspace, err)
+			kt = rt.keyspaces[keyspace]
+		} else {
+			kt = ki.ReplicationLagThresholds
+		}
+		if !topoproto.ReplicationLagThresholdsEmpty(kt) {
+			thresholds[keyspace] = kt
+		}
+	}
+	if rt.keyspaces != nil && equalReplicationLagThresholds(thresholds, rt.keyspaces) {
+		return
+	}
+	rt.keyspaces = thresholds
+	rt.setter.SetReplicationLagThresholds(thresholds)
+}
+
+func equalReplicationLagThresholds(a, b map[string]*topodatapb.ReplicationLagThresholds) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for keyspace, ta := range a {
+		if tb, ok := b[keyspace]; !ok || !proto.Equal(ta, tb) {
+			return false
+		}
+	}
+	return true
+}
+
+// startReplicationLagThresholds adds the replication lag thresholds of the
+// keyspaces to the loader, which keeps them up to date in the health check
+// if it supports them.
+func startReplicationLagThresholds(loader *keyspaceSettingsLoader, hc interface{}) {
+	if !*enableReplicationLagThresholds {
+		return
+	}
+	setter, ok := hc.(replicationLagThresholdsSetter)
+	if !ok {
+		return
+	}
+	loader.add(&replicationLagThresholds{setter: setter})
+}
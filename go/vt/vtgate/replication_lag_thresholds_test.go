@@ -0,0 +1,77 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/test/utils"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+type fakeLagThresholdsSetter struct {
+	calls     int
+	keyspaces map[string]*topodatapb.ReplicationLagThresholds
+}
+
+func (f *fakeLagThresholdsSetter) SetReplicationLagThresholds(keyspaces map[string]*topodatapb.ReplicationLagThresholds) {
+	f.calls++
+	f.keyspaces = keyspaces
+}
+
+func TestReplicationLagThresholds(t *testing.T) {
+	ctx := context.Background()
+	ts := memorytopo.NewServer("cell1")
+	ks1 := &topodatapb.ReplicationLagThresholds{
+		Default:     &topodatapb.ReplicationLagThreshold{SoftSeconds: 10},
+		TabletTypes: map[string]*topodatapb.ReplicationLagThreshold{"rdonly": {SoftSeconds: 60, HardSeconds: 600}},
+	}
+	require.NoError(t, ts.CreateKeyspace(ctx, "ks1", &topodatapb.Keyspace{ReplicationLagThresholds: ks1}))
+	require.NoError(t, ts.CreateKeyspace(ctx, "ks2", &topodatapb.Keyspace{}))
+
+	setter := &fakeLagThresholdsSetter{}
+	rt := &replicationLagThresholds{setter: setter}
+	refreshKeyspaceSetting(ctx, t, ts, rt)
+	assert.Equal(t, 1, setter.calls)
+	utils.MustMatch(t, map[string]*topodatapb.ReplicationLagThresholds{"ks1": ks1}, setter.keyspaces)
+	assert.Equal(t, int64(600), topoproto.ReplicationLagThresholdForTabletType(setter.keyspaces["ks1"], "rdonly").HardSeconds)
+	assert.Equal(t, int64(10), topoproto.ReplicationLagThresholdForTabletType(setter.keyspaces["ks1"], "replica").SoftSeconds)
+
+	// Unchanged thresholds are not set again.
+	refreshKeyspaceSetting(ctx, t, ts, rt)
+	assert.Equal(t, 1, setter.calls)
+
+	// Removed thresholds are dropped on refresh.
+	lockCtx, unlock, err := ts.LockKeyspace(ctx, "ks1", "test")
+	require.NoError(t, err)
+	ki, err := ts.GetKeyspace(lockCtx, "ks1")
+	require.NoError(t, err)
+	ki.ReplicationLagThresholds = nil
+	require.NoError(t, ts.UpdateKeyspace(lockCtx, ki))
+	unlock(&err)
+	require.NoError(t, err)
+	refreshKeyspaceSetting(ctx, t, ts, rt)
+	assert.Equal(t, 2, setter.calls)
+	assert.Empty(t, setter.keyspaces)
+}
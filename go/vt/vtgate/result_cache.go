@@ -0,0 +1,498 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"encoding/hex"
+	"flag"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/cache"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtgate/engine"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+// This file implements the result cache of vtgate. The results of the
+// select queries which only read cacheable tables, i.e. the tables with a
+// cache_ttl_ms in the VSchema, are cached outside of transactions,
+// keyed by their normalized query, bind variables, target and callers. A
+// cached result expires after the smallest TTL of its tables, and is
+// invalidated as soon as a vstream of the keyspace observes a change to one
+// of its tables. The results of the streaming queries, and of the queries
+// calling non-deterministic functions like now() or rand(), are not cached.
+
+var (
+	enableResultCache  = flag.Bool("enable_result_cache", false, "Cache the results of the queries which only read cacheable tables, i.e. the tables with a cache_ttl_ms in the VSchema, for up to their TTL")
+	resultCacheSize    = flag.Int("result_cache_size", 10000, "Maximum number of query results in the result cache")
+	resultCacheMaxRows = flag.Int("result_cache_max_rows", 1000, "Maximum number of rows of a query result in the result cache. Larger results are not cached.")
+
+	resultCacheHits          = stats.NewCountersWithSingleLabel("ResultCacheHits", "Number of queries answered from the result cache, by keyspace.table", "Table")
+	resultCacheMisses        = stats.NewCountersWithSingleLabel("ResultCacheMisses", "Number of cacheable queries not answered from the result cache, by keyspace.table", "Table")
+	resultCacheInvalidations = stats.NewCountersWithSingleLabel("ResultCacheInvalidations", "Number of times the cached results of a table were invalidated by a change, by keyspace.table", "Table")
+)
+
+// resultCacheRetryDelay is how long a watcher waits before restarting its
+// vstream when it failed.
+var resultCacheRetryDelay = 5 * time.Second
+
+// resultCacheStream streams the changes of the tables of a keyspace from
+// its current position, until ctx is done or the stream fails.
+type resultCacheStream func(ctx context.Context, keyspace string, tables []string, send func([]*binlogdatapb.VEvent) error) error
+
+// resultCache is the result cache. All its methods are safe to call on a
+// nil receiver, which doesn't cache anything.
+type resultCache struct {
+	// ctx is the context of the watchers of the keyspaces.
+	ctx     context.Context
+	maxRows int
+	now     func() time.Time
+	stream  resultCacheStream
+	results *cache.LRUCache
+	// deterministic tells whether the queries of the plans, by their
+	// original query, only call deterministic functions.
+	deterministic *cache.LRUCache
+
+	mu sync.Mutex
+	// lastGeneration is the last generation given to a table. The
+	// generations are unique, s.t. a table which stops being cacheable
+	// and becomes cacheable again can't match its previous results.
+	lastGeneration uint64
+	// tables are the cacheable tables, by keyspace.table.
+	tables map[string]*cacheableTable
+	// watchers are the watchers of the keyspaces with cacheable tables.
+	watchers map[string]*cacheWatcher
+}
+
+// cacheableTable is a cacheable table. Its generation changes when the
+// table changes, which invalidates the results cached at the previous one.
+type cacheableTable struct {
+	ttl        time.Duration
+	generation uint64
+}
+
+// cacheWatcher watches the changes of the cacheable tables of a keyspace.
+type cacheWatcher struct {
+	tables []string
+	cancel context.CancelFunc
+	// live is set once the vstream of the watcher sent its first events.
+	// The results of the tables of the keyspace aren't cached until then,
+	// as their changes could be missed.
+	live bool
+}
+
+// resultCacheKey is the key of a cacheable query, with the generations of
+// its tables when it started.
+type resultCacheKey struct {
+	key         string
+	tables      []string
+	generations []uint64
+	ttl         time.Duration
+}
+
+type cachedResult struct {
+	result      *sqltypes.Result
+	expire      time.Time
+	generations []uint64
+}
+
+func newResultCache(ctx context.Context, size, maxRows int, stream resultCacheStream) *resultCache {
+	return &resultCache{
+		ctx:           ctx,
+		maxRows:       maxRows,
+		now:           time.Now,
+		stream:        stream,
+		results:       cache.NewLRUCache(int64(size), func(interface{}) int64 { return 1 }),
+		deterministic: cache.NewLRUCache(int64(size), func(interface{}) int64 { return 1 }),
+		tables:        make(map[string]*cacheableTable),
+		watchers:      make(map[string]*cacheWatcher),
+	}
+}
+
+// lookup returns the key of the query of the plan, or nil if its result
+// can't be cached.
+func (rc *resultCache) lookup(plan *engine.Plan, vcursor *vcursorImpl, bindVars map[string]*querypb.BindVariable) *resultCacheKey {
+	if rc == nil || plan.Type != sqlparser.StmtSelect {
+		return nil
+	}
+	if session := vcursor.safeSession; session.InTransaction() || session.InReservedConn() {
+		return nil
+	}
+	tables := make(map[string]bool)
+	if !planTables(plan.Instructions, tables) {
+		return nil
+	}
+
+	k := &resultCacheKey{}
+	rc.mu.Lock()
+	for name := range tables {
+		table := rc.tables[name]
+		if table == nil {
+			rc.mu.Unlock()
+			return nil
+		}
+		if watcher := rc.watchers[name[:strings.IndexByte(name, '.')]]; watcher == nil || !watcher.live {
+			rc.mu.Unlock()
+			return nil
+		}
+		if k.ttl == 0 || table.ttl < k.ttl {
+			k.ttl = table.ttl
+		}
+		k.tables = append(k.tables, name)
+	}
+	sort.Strings(k.tables)
+	for _, name := range k.tables {
+		k.generations = append(k.generations, rc.tables[name].generation)
+	}
+	rc.mu.Unlock()
+
+	if !rc.isDeterministic(plan) {
+		return nil
+	}
+
+	// The results are only shared by the same callers, as the table ACLs
+	// of the tablets are checked for them.
+	var buf strings.Builder
+	buf.WriteString(callerid.EffectiveCallerIDFromContext(vcursor.ctx).GetPrincipal())
+	buf.WriteByte(0)
+	buf.WriteString(callerid.ImmediateCallerIDFromContext(vcursor.ctx).GetUsername())
+	buf.WriteByte(0)
+	buf.WriteString(vcursor.safeSession.TargetString)
+	buf.WriteByte(0)
+	buf.WriteString(strconv.FormatInt(vcursor.safeSession.GetOptions().GetSqlSelectLimit(), 10))
+	buf.WriteByte(0)
+	buf.WriteString(plan.Original)
+	names := make([]string, 0, len(bindVars))
+	for name := range bindVars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		bv := bindVars[name]
+		buf.WriteByte(0)
+		buf.WriteString(name)
+		buf.WriteByte('=')
+		buf.WriteString(bv.Type.String())
+		buf.WriteByte(':')
+		buf.WriteString(hex.EncodeToString(bv.Value))
+		for _, value := range bv.Values {
+			buf.WriteByte(',')
+			buf.WriteString(value.Type.String())
+			buf.WriteByte(':')
+			buf.WriteString(hex.EncodeToString(value.Value))
+		}
+	}
+	k.key = buf.String()
+	return k
+}
+
+// nonDeterministicFunctions are the functions whose result can change
+// between two runs of the same query on the same data.
+var nonDeterministicFunctions = map[string]bool{
+	"benchmark":         true,
+	"connection_id":     true,
+	"curdate":           true,
+	"current_date":      true,
+	"current_time":      true,
+	"current_timestamp": true,
+	"current_user":      true,
+	"curtime":           true,
+	"found_rows":        true,
+	"get_lock":          true,
+	"is_free_lock":      true,
+	"is_used_lock":      true,
+	"last_insert_id":    true,
+	"localtime":         true,
+	"localtimestamp":    true,
+	"now":               true,
+	"rand":              true,
+	"random_bytes":      true,
+	"release_lock":      true,
+	"row_count":         true,
+	"session_user":      true,
+	"sleep":             true,
+	"sysdate":           true,
+	"system_user":       true,
+	"unix_timestamp":    true,
+	"user":              true,
+	"utc_date":          true,
+	"utc_time":          true,
+	"utc_timestamp":     true,
+	"uuid":              true,
+	"uuid_short":        true,
+}
+
+// isDeterministic returns true if the query of the plan only calls
+// deterministic functions. It is parsed once per query.
+func (rc *resultCache) isDeterministic(plan *engine.Plan) bool {
+	if value, ok := rc.deterministic.Get(plan.Original); ok {
+		return value.(bool)
+	}
+	deterministic := false
+	if stmt, err := sqlparser.Parse(plan.Original); err == nil {
+		deterministic = true
+		_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+			switch node := node.(type) {
+			case *sqlparser.CurTimeFuncExpr:
+				deterministic = false
+			case *sqlparser.FuncExpr:
+				if nonDeterministicFunctions[node.Name.Lowered()] {
+					deterministic = false
+				}
+			}
+			return deterministic, nil
+		}, stmt)
+	}
+	rc.deterministic.Set(plan.Original, deterministic)
+	return deterministic
+}
+
+// planTables adds the tables read by the primitive to tables, by
+// keyspace.table. It returns false if the primitive reads anything else
+// than tables, e.g. a sequence or the information schema.
+func planTables(primitive engine.Primitive, tables map[string]bool) bool {
+	if route, ok := primitive.(*engine.Route); ok {
+		switch {
+		case route.Opcode == engine.SelectNext || route.Opcode == engine.SelectDBA:
+			return false
+		case route.Keyspace == nil || route.TableName == "":
+			return false
+		}
+		for _, name := range strings.Split(route.TableName, ",") {
+			tables[route.Keyspace.Name+"."+strings.TrimSpace(name)] = true
+		}
+		return true
+	}
+	inputs := primitive.Inputs()
+	if len(inputs) == 0 {
+		return false
+	}
+	for _, input := range inputs {
+		if !planTables(input, tables) {
+			return false
+		}
+	}
+	return true
+}
+
+// get returns the cached result of the query, or nil if it isn't cached.
+func (rc *resultCache) get(k *resultCacheKey) *sqltypes.Result {
+	if rc == nil || k == nil {
+		return nil
+	}
+	value, ok := rc.results.Get(k.key)
+	if ok {
+		cached := value.(*cachedResult)
+		if rc.now().Before(cached.expire) && equalGenerations(cached.generations, k.generations) {
+			for _, name := range k.tables {
+				resultCacheHits.Add(name, 1)
+			}
+			return cached.result.Copy()
+		}
+		rc.results.Delete(k.key)
+	}
+	for _, name := range k.tables {
+		resultCacheMisses.Add(name, 1)
+	}
+	return nil
+}
+
+// set caches the result of the query, unless it is too large.
+func (rc *resultCache) set(k *resultCacheKey, result *sqltypes.Result) {
+	if rc == nil || k == nil || len(result.Rows) > rc.maxRows {
+		return
+	}
+	rc.results.Set(k.key, &cachedResult{
+		result:      result.Copy(),
+		expire:      rc.now().Add(k.ttl),
+		generations: k.generations,
+	})
+}
+
+func equalGenerations(a, b []uint64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// invalidateLocked invalidates the cached results of a table.
+func (rc *resultCache) invalidateLocked(name string) {
+	if table := rc.tables[name]; table != nil {
+		rc.lastGeneration++
+		table.generation = rc.lastGeneration
+		resultCacheInvalidations.Add(name, 1)
+	}
+}
+
+// invalidateKeyspaceLocked invalidates the cached results of all the
+// tables of a keyspace.
+func (rc *resultCache) invalidateKeyspaceLocked(keyspace string) {
+	if watcher := rc.watchers[keyspace]; watcher != nil {
+		for _, table := range watcher.tables {
+			rc.invalidateLocked(keyspace + "." + table)
+		}
+	}
+}
+
+// onEvents invalidates the tables changed by the events of the vstream of
+// a watcher.
+func (rc *resultCache) onEvents(keyspace string, watcher *cacheWatcher, events []*binlogdatapb.VEvent) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if rc.watchers[keyspace] != watcher {
+		return
+	}
+	watcher.live = true
+	for _, event := range events {
+		switch event.Type {
+		case binlogdatapb.VEventType_ROW:
+			rc.invalidateLocked(keyspace + "." + event.RowEvent.GetTableName())
+		case binlogdatapb.VEventType_DDL, binlogdatapb.VEventType_JOURNAL:
+			rc.invalidateKeyspaceLocked(keyspace)
+		}
+	}
+}
+
+// watch streams the changes of the tables of a keyspace until ctx is
+// done, and restarts the stream when it fails.
+func (rc *resultCache) watch(ctx context.Context, keyspace string, watcher *cacheWatcher) {
+	for {
+		err := rc.stream(ctx, keyspace, watcher.tables, func(events []*binlogdatapb.VEvent) error {
+			rc.onEvents(keyspace, watcher, events)
+			return nil
+		})
+		// The changes are missed until the stream restarts.
+		rc.mu.Lock()
+		if rc.watchers[keyspace] == watcher {
+			watcher.live = false
+			rc.invalidateKeyspaceLocked(keyspace)
+		}
+		rc.mu.Unlock()
+		if ctx.Err() != nil {
+			return
+		}
+		log.Warningf("Result cache vstream of keyspace %v failed, restarting it in %v: %v", keyspace, resultCacheRetryDelay, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(resultCacheRetryDelay):
+		}
+	}
+}
+
+// update replaces the cacheable tables, given with their TTL by keyspace
+// and table, and starts or restarts the watchers of the keyspaces whose
+// cacheable tables changed.
+func (rc *resultCache) update(keyspaces map[string]map[string]time.Duration) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	tables := make(map[string]*cacheableTable)
+	for keyspace, ttls := range keyspaces {
+		var names []string
+		for table, ttl := range ttls {
+			names = append(names, table)
+			name := keyspace + "." + table
+			cached := rc.tables[name]
+			if cached == nil {
+				rc.lastGeneration++
+				cached = &cacheableTable{generation: rc.lastGeneration}
+			}
+			cached.ttl = ttl
+			tables[name] = cached
+		}
+		sort.Strings(names)
+		if watcher := rc.watchers[keyspace]; watcher != nil {
+			if strings.Join(watcher.tables, ",") == strings.Join(names, ",") {
+				continue
+			}
+			watcher.cancel()
+			delete(rc.watchers, keyspace)
+		}
+		watchCtx, cancel := context.WithCancel(rc.ctx)
+		watcher := &cacheWatcher{tables: names, cancel: cancel}
+		rc.watchers[keyspace] = watcher
+		go rc.watch(watchCtx, keyspace, watcher)
+	}
+	for keyspace, watcher := range rc.watchers {
+		if _, ok := keyspaces[keyspace]; !ok {
+			watcher.cancel()
+			delete(rc.watchers, keyspace)
+		}
+	}
+	rc.tables = tables
+}
+
+// watchVSchema makes the tables with a cache_ttl_ms in the VSchema the
+// cacheable tables. It is called with each new VSchema.
+func (rc *resultCache) watchVSchema(vschema *vindexes.VSchema) {
+	if rc == nil || vschema == nil {
+		return
+	}
+	keyspaces := make(map[string]map[string]time.Duration)
+	for keyspace, ks := range vschema.Keyspaces {
+		for name, table := range ks.Tables {
+			if table.CacheTTLMs <= 0 {
+				continue
+			}
+			if keyspaces[keyspace] == nil {
+				keyspaces[keyspace] = make(map[string]time.Duration)
+			}
+			keyspaces[keyspace][name] = time.Duration(table.CacheTTLMs) * time.Millisecond
+		}
+	}
+	rc.update(keyspaces)
+}
+
+// startResultCache returns the result cache, whose watchers run until ctx
+// is done. It returns nil if the result cache is disabled.
+func startResultCache(ctx context.Context, vsm *vstreamManager) *resultCache {
+	if !*enableResultCache {
+		return nil
+	}
+	stream := func(ctx context.Context, keyspace string, tables []string, send func([]*binlogdatapb.VEvent) error) error {
+		filter := &binlogdatapb.Filter{}
+		for _, table := range tables {
+			filter.Rules = append(filter.Rules, &binlogdatapb.Rule{Match: table})
+		}
+		vgtid := &binlogdatapb.VGtid{ShardGtids: []*binlogdatapb.ShardGtid{{Keyspace: keyspace, Gtid: "current"}}}
+		// The heartbeats make the watcher live even if the tables don't
+		// change.
+		flags := &vtgatepb.VStreamFlags{HeartbeatInterval: 1}
+		return vsm.VStream(ctx, topodatapb.TabletType_MASTER, vgtid, filter, flags, send)
+	}
+	return newResultCache(ctx, *resultCacheSize, *resultCacheMaxRows, stream)
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtgate/engine"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+func TestResultCache(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	vschema := func(ttls map[string]int64) *vindexes.VSchema {
+		ks := &vindexes.KeyspaceSchema{Tables: map[string]*vindexes.Table{"t3": {}}}
+		for name, ttl := range ttls {
+			ks.Tables[name] = &vindexes.Table{CacheTTLMs: ttl}
+		}
+		return &vindexes.VSchema{Keyspaces: map[string]*vindexes.KeyspaceSchema{"ks": ks}}
+	}
+
+	type stream struct {
+		ctx    context.Context
+		tables []string
+		send   func([]*binlogdatapb.VEvent) error
+	}
+	streams := make(chan *stream, 10)
+	rc := newResultCache(ctx, 10, 2, func(ctx context.Context, keyspace string, tables []string, send func([]*binlogdatapb.VEvent) error) error {
+		streams <- &stream{ctx: ctx, tables: tables, send: send}
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	now := time.Unix(1000, 0)
+	rc.now = func() time.Time { return now }
+	rc.watchVSchema(vschema(map[string]int64{"t1": 60000, "t2": 1000}))
+	s := <-streams
+	assert.Equal(t, []string{"t1", "t2"}, s.tables)
+
+	route := func(opcode engine.RouteOpcode, table string) *engine.Route {
+		return &engine.Route{Opcode: opcode, Keyspace: &vindexes.Keyspace{Name: "ks"}, TableName: table}
+	}
+	plan := &engine.Plan{Type: sqlparser.StmtSelect, Original: "select * from t1 where id = :id", Instructions: route(engine.SelectEqualUnique, "t1")}
+	vcursor := &vcursorImpl{ctx: ctx, safeSession: NewSafeSession(&vtgatepb.Session{})}
+	bindVars := map[string]*querypb.BindVariable{"id": sqltypes.Int64BindVariable(1)}
+	result := sqltypes.MakeTestResult(sqltypes.MakeTestFields("id", "int64"), "1")
+
+	// Nothing is cached until the vstream of the keyspace runs.
+	assert.Nil(t, rc.lookup(plan, vcursor, bindVars))
+	require.NoError(t, s.send([]*binlogdatapb.VEvent{{Type: binlogdatapb.VEventType_HEARTBEAT}}))
+
+	k := rc.lookup(plan, vcursor, bindVars)
+	require.NotNil(t, k)
+	assert.Nil(t, rc.get(k))
+	rc.set(k, result)
+	assert.True(t, result.Equal(rc.get(rc.lookup(plan, vcursor, bindVars))))
+	assert.Nil(t, rc.get(rc.lookup(plan, vcursor, map[string]*querypb.BindVariable{"id": sqltypes.Int64BindVariable(2)})))
+
+	// A change to the table invalidates its results.
+	require.NoError(t, s.send([]*binlogdatapb.VEvent{{Type: binlogdatapb.VEventType_ROW, RowEvent: &binlogdatapb.RowEvent{TableName: "t1"}}}))
+	assert.Nil(t, rc.get(rc.lookup(plan, vcursor, bindVars)))
+
+	// A result started before a change isn't cached.
+	k = rc.lookup(plan, vcursor, bindVars)
+	require.NoError(t, s.send([]*binlogdatapb.VEvent{{Type: binlogdatapb.VEventType_ROW, RowEvent: &binlogdatapb.RowEvent{TableName: "t1"}}}))
+	rc.set(k, result)
+	assert.Nil(t, rc.get(rc.lookup(plan, vcursor, bindVars)))
+
+	// The results of a join expire after the smallest ttl of its tables.
+	join := &engine.Plan{Type: sqlparser.StmtSelect, Original: "select * from t1 join t2", Instructions: &engine.Join{
+		Left:  route(engine.SelectScatter, "t1"),
+		Right: route(engine.SelectScatter, "t2"),
+	}}
+	k = rc.lookup(join, vcursor, nil)
+	require.NotNil(t, k)
+	rc.set(k, result)
+	assert.True(t, result.Equal(rc.get(rc.lookup(join, vcursor, nil))))
+	now = now.Add(time.Second)
+	assert.Nil(t, rc.get(rc.lookup(join, vcursor, nil)))
+
+	// Large results aren't cached.
+	k = rc.lookup(plan, vcursor, bindVars)
+	rc.set(k, sqltypes.MakeTestResult(sqltypes.MakeTestFields("id", "int64"), "1", "2", "3"))
+	assert.Nil(t, rc.get(k))
+
+	// The queries of the other tables, of transactions, and of sequences
+	// aren't cacheable.
+	other := &engine.Plan{Type: sqlparser.StmtSelect, Instructions: &engine.Join{Left: route(engine.SelectScatter, "t1"), Right: route(engine.SelectScatter, "t3")}}
+	assert.Nil(t, rc.lookup(other, vcursor, nil))
+	assert.Nil(t, rc.lookup(plan, &vcursorImpl{ctx: ctx, safeSession: NewSafeSession(&vtgatepb.Session{InTransaction: true})}, bindVars))
+	assert.Nil(t, rc.lookup(&engine.Plan{Type: sqlparser.StmtSelect, Instructions: route(engine.SelectNext, "t1")}, vcursor, nil))
+	assert.Nil(t, rc.lookup(&engine.Plan{Type: sqlparser.StmtUpdate, Instructions: route(engine.SelectEqualUnique, "t1")}, vcursor, nil))
+
+	// The results are only shared by the same callers.
+	k = rc.lookup(plan, vcursor, bindVars)
+	rc.set(k, result)
+	assert.True(t, result.Equal(rc.get(rc.lookup(plan, vcursor, bindVars))))
+	other = &engine.Plan{Type: sqlparser.StmtSelect, Original: plan.Original, Instructions: plan.Instructions}
+	for _, callerCtx := range []context.Context{
+		callerid.NewContext(ctx, callerid.NewEffectiveCallerID("other", "", ""), nil),
+		callerid.NewContext(ctx, nil, callerid.NewImmediateCallerID("other")),
+	} {
+		callerVCursor := &vcursorImpl{ctx: callerCtx, safeSession: NewSafeSession(&vtgatepb.Session{})}
+		k = rc.lookup(other, callerVCursor, bindVars)
+		require.NotNil(t, k)
+		assert.Nil(t, rc.get(k))
+	}
+
+	// The queries calling non-deterministic functions aren't cacheable.
+	for _, query := range []string{
+		"select now() from t1",
+		"select id from t1 where created < current_timestamp(3)",
+		"select rand() from t1 join t2",
+		"select uuid() from t1",
+	} {
+		nonDeterministic := &engine.Plan{Type: sqlparser.StmtSelect, Original: query, Instructions: plan.Instructions}
+		assert.Nil(t, rc.lookup(nonDeterministic, vcursor, nil), query)
+	}
+
+	// A change to the cacheable tables restarts the vstream, and the
+	// tables which aren't cacheable anymore aren't cached.
+	rc.watchVSchema(vschema(map[string]int64{"t2": 1000}))
+	<-s.ctx.Done()
+	s = <-streams
+	assert.Equal(t, []string{"t2"}, s.tables)
+	require.NoError(t, s.send([]*binlogdatapb.VEvent{{Type: binlogdatapb.VEventType_HEARTBEAT}}))
+	assert.Nil(t, rc.lookup(plan, vcursor, bindVars))
+
+	rc.watchVSchema(vschema(nil))
+	<-s.ctx.Done()
+
+	var nilCache *resultCache
+	assert.Nil(t, nilCache.lookup(plan, vcursor, bindVars))
+	assert.Nil(t, nilCache.get(nil))
+	nilCache.set(nil, result)
+	nilCache.watchVSchema(vschema(nil))
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"flag"
+	"math/rand"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+// This file contains the retry policy of TabletGateway.withRetry. Retries
+// are delayed by an exponential backoff with jitter, are not attempted if
+// the backoff would not fit in the deadline of the request, and are limited
+// by a per target token bucket: every request adds
+// -gateway_retry_budget_ratio tokens and every retry takes one, so that
+// retries can't exceed that fraction of the traffic once the bucket is
+// empty.
+
+var (
+	retryInitialBackoff  = flag.Duration("gateway_retry_initial_backoff", 0, "Delay before the first retry of a failed request by the tablet gateway. It doubles on every retry, up to -gateway_retry_max_backoff, and a random jitter of up to half of it is subtracted. 0 retries immediately.")
+	retryMaxBackoff      = flag.Duration("gateway_retry_max_backoff", time.Second, "Maximum delay between two retries of a request by the tablet gateway.")
+	retryBudgetRatio     = flag.Float64("gateway_retry_budget_ratio", 0, "If set, the retries of the tablet gateway are limited to this fraction of the requests of each keyspace/shard/tablet_type, e.g. 0.1 for 10%. 0 disables the retry budget.")
+	retryBudgetMaxTokens = flag.Float64("gateway_retry_budget_max_tokens", 100, "Maximum number of retries which can be accumulated by the retry budget of a keyspace/shard/tablet_type. It is also the initial number of retries available.")
+
+	gatewayRetries = stats.NewCountersWithMultiLabels(
+		"GatewayRetries",
+		"Number of requests retried by the tablet gateway",
+		[]string{"Keyspace", "ShardName", "DbType"})
+	gatewayRetriesDenied = stats.NewCountersWithMultiLabels(
+		"GatewayRetriesDenied",
+		"Number of retries not attempted by the tablet gateway because of the retry budget or the request deadline",
+		[]string{"Keyspace", "ShardName", "DbType"})
+)
+
+// retryBackoff returns the delay before the given retry, starting at 1.
+func retryBackoff(retry int) time.Duration {
+	d := *retryInitialBackoff
+	if d <= 0 {
+		return 0
+	}
+	for i := 1; i < retry && d < *retryMaxBackoff; i++ {
+		d *= 2
+	}
+	if d > *retryMaxBackoff {
+		d = *retryMaxBackoff
+	}
+	// Subtract a random jitter of up to half of the delay.
+	return d - time.Duration(rand.Int63n(int64(d)/2+1))
+}
+
+// retryBudget is a token bucket shared by all the requests of a target.
+type retryBudget struct {
+	tokens float64
+}
+
+// retryPolicy decides whether and when a failed request is retried.
+type retryPolicy struct {
+	mu      sync.Mutex
+	budgets map[string]*retryBudget
+}
+
+func newRetryPolicy() *retryPolicy {
+	return &retryPolicy{budgets: make(map[string]*retryBudget)}
+}
+
+func (rp *retryPolicy) budget(target *querypb.Target) *retryBudget {
+	key := targetKey(target)
+	b, ok := rp.budgets[key]
+	if !ok {
+		b = &retryBudget{tokens: *retryBudgetMaxTokens}
+		rp.budgets[key] = b
+	}
+	return b
+}
+
+// onRequest adds the tokens of a new request to the budget of the target.
+func (rp *retryPolicy) onRequest(target *querypb.Target) {
+	if *retryBudgetRatio <= 0 {
+		return
+	}
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+	b := rp.budget(target)
+	b.tokens += *retryBudgetRatio
+	if b.tokens > *retryBudgetMaxTokens {
+		b.tokens = *retryBudgetMaxTokens
+	}
+}
+
+// takeRetry takes a token from the budget of the target. It returns false
+// if the budget is exhausted.
+func (rp *retryPolicy) takeRetry(target *querypb.Target) bool {
+	if *retryBudgetRatio <= 0 {
+		return true
+	}
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+	b := rp.budget(target)
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// waitForRetry returns true once the given retry, starting at 1, can be
+// attempted. It returns false if the retry must not be attempted: the
+// budget is exhausted, the backoff would exceed the deadline of the
+// context, or the context is done.
+func (rp *retryPolicy) waitForRetry(ctx context.Context, target *querypb.Target, retry int) bool {
+	statsKey := []string{target.Keyspace, target.Shard, topoproto.TabletTypeLString(target.TabletType)}
+	d := retryBackoff(retry)
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= d {
+		gatewayRetriesDenied.Add(statsKey, 1)
+		return false
+	}
+	if !rp.takeRetry(target) {
+		gatewayRetriesDenied.Add(statsKey, 1)
+		return false
+	}
+	gatewayRetries.Add(statsKey, 1)
+	if d <= 0 {
+		return true
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/discovery"
+	"vitess.io/vitess/go/vt/vttablet/queryservice"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func TestRetryBackoff(t *testing.T) {
+	defer func(initial, max time.Duration) {
+		*retryInitialBackoff = initial
+		*retryMaxBackoff = max
+	}(*retryInitialBackoff, *retryMaxBackoff)
+
+	*retryInitialBackoff = 0
+	assert.Equal(t, time.Duration(0), retryBackoff(1))
+
+	*retryInitialBackoff = 10 * time.Millisecond
+	*retryMaxBackoff = 50 * time.Millisecond
+	for retry, want := range map[int]time.Duration{
+		1: 10 * time.Millisecond,
+		2: 20 * time.Millisecond,
+		3: 40 * time.Millisecond,
+		4: 50 * time.Millisecond,
+		9: 50 * time.Millisecond,
+	} {
+		for i := 0; i < 20; i++ {
+			got := retryBackoff(retry)
+			assert.LessOrEqual(t, got, want, "retry %d", retry)
+			assert.GreaterOrEqual(t, got, want/2, "retry %d", retry)
+		}
+	}
+}
+
+func TestRetryBudget(t *testing.T) {
+	defer func(ratio, max float64) {
+		*retryBudgetRatio = ratio
+		*retryBudgetMaxTokens = max
+	}(*retryBudgetRatio, *retryBudgetMaxTokens)
+
+	target := &querypb.Target{Keyspace: "ks", Shard: "0", TabletType: topodatapb.TabletType_REPLICA}
+	other := &querypb.Target{Keyspace: "ks", Shard: "0", TabletType: topodatapb.TabletType_RDONLY}
+
+	// No budget.
+	*retryBudgetRatio = 0
+	rp := newRetryPolicy()
+	for i := 0; i < 10; i++ {
+		assert.True(t, rp.takeRetry(target))
+	}
+
+	*retryBudgetRatio = 0.5
+	*retryBudgetMaxTokens = 2
+	rp = newRetryPolicy()
+	assert.True(t, rp.takeRetry(target))
+	assert.True(t, rp.takeRetry(target))
+	assert.False(t, rp.takeRetry(target))
+	// Budgets are per target.
+	assert.True(t, rp.takeRetry(other))
+
+	// Two requests give back one retry.
+	rp.onRequest(target)
+	assert.False(t, rp.takeRetry(target))
+	rp.onRequest(target)
+	assert.True(t, rp.takeRetry(target))
+
+	// The bucket is capped.
+	for i := 0; i < 10; i++ {
+		rp.onRequest(target)
+	}
+	assert.True(t, rp.takeRetry(target))
+	assert.True(t, rp.takeRetry(target))
+	assert.False(t, rp.takeRetry(target))
+}
+
+func TestWaitForRetry(t *testing.T) {
+	defer func(initial time.Duration) { *retryInitialBackoff = initial }(*retryInitialBackoff)
+
+	target := &querypb.Target{Keyspace: "ks", Shard: "0", TabletType: topodatapb.TabletType_REPLICA}
+	rp := newRetryPolicy()
+
+	*retryInitialBackoff = 0
+	assert.True(t, rp.waitForRetry(context.Background(), target, 1))
+
+	// The backoff is applied.
+	*retryInitialBackoff = 20 * time.Millisecond
+	start := time.Now()
+	assert.True(t, rp.waitForRetry(context.Background(), target, 1))
+	assert.GreaterOrEqual(t, int64(time.Since(start)), int64(10*time.Millisecond))
+
+	// The backoff doesn't fit in the deadline of the request.
+	*retryInitialBackoff = time.Second
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start = time.Now()
+	assert.False(t, rp.waitForRetry(ctx, target, 1))
+	assert.Less(t, int64(time.Since(start)), int64(100*time.Millisecond))
+}
+
+func TestRetryBudgetSkipsTabletsWithoutConnection(t *testing.T) {
+	defer func(ratio, maxTokens float64) {
+		*retryBudgetRatio = ratio
+		*retryBudgetMaxTokens = maxTokens
+	}(*retryBudgetRatio, *retryBudgetMaxTokens)
+
+	// The budget never allows a retry.
+	*retryBudgetRatio = 0.1
+	*retryBudgetMaxTokens = 0
+
+	target := &querypb.Target{Keyspace: "ks", Shard: "0", TabletType: topodatapb.TabletType_REPLICA}
+	hc := discovery.NewFakeHealthCheck()
+	tg := NewTabletGateway(context.Background(), hc, nil, "cell")
+	hc.AddFakeTablet("cell", "1.1.1.1", 1001, "ks", "0", topodatapb.TabletType_REPLICA, true, 10, nil, func(*topodatapb.Tablet) queryservice.QueryService {
+		return nil
+	})
+	hc.AddTestTablet("cell", "1.1.1.1", 1002, "ks", "0", topodatapb.TabletType_REPLICA, true, 10, nil)
+
+	// Whichever tablet is picked first, the request gets to the one with a
+	// connection: skipping the other one is not a retry.
+	statsKey := "ks.0.replica"
+	denied := gatewayRetriesDenied.Counts()[statsKey]
+	for i := 0; i < 10; i++ {
+		_, err := tg.Execute(context.Background(), target, "query", nil, 0, 0, nil)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, denied, gatewayRetriesDenied.Counts()[statsKey])
+}
@@ -23,6 +23,7 @@ import (
 
 	"google.golang.org/protobuf/proto"
 
+	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/vt/vterrors"
 
 	querypb "vitess.io/vitess/go/vt/proto/query"
@@ -44,6 +45,10 @@ type SafeSession struct {
 	// this is a signal that found_rows has already been handles by the primitives,
 	// and doesn't have to be updated by the executor
 	foundRowsHandled bool
+
+	// ignoreRoutingRules is set for the checksum queries, which run on
+	// the keyspace the routing rules don't route to.
+	ignoreRoutingRules bool
 	*vtgatepb.Session
 }
 
@@ -173,6 +178,17 @@ func (session *SafeSession) InTransaction() bool {
 	return session.Session.InTransaction
 }
 
+// ShardSessionKeyspaces returns the keyspaces of the shard sessions.
+func (session *SafeSession) ShardSessionKeyspaces() []string {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	keyspaces := make([]string, 0, len(session.ShardSessions))
+	for _, shardSession := range session.ShardSessions {
+		keyspaces = append(keyspaces, shardSession.Target.Keyspace)
+	}
+	return keyspaces
+}
+
 // Find returns the transactionId and tabletAlias, if any, for a session
 func (session *SafeSession) Find(keyspace, shard string, tabletType topodatapb.TabletType) (transactionID int64, reservedID int64, alias *topodatapb.TabletAlias) {
 	session.mu.Lock()
@@ -332,6 +348,14 @@ func (session *SafeSession) SetSystemVariable(name string, expr string) {
 	session.SystemVariables[name] = expr
 }
 
+// GetSystemVariable returns the value of the system variable in the session.
+func (session *SafeSession) GetSystemVariable(name string) (string, bool) {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	expr, ok := session.SystemVariables[name]
+	return expr, ok
+}
+
 // SetOptions sets the options
 func (session *SafeSession) SetOptions(options *querypb.ExecuteOptions) {
 	session.mu.Lock()
@@ -522,6 +546,86 @@ func (session *SafeSession) SetSessionTrackGtids(enable bool) {
 	session.ReadAfterWrite.SessionTrackGtids = enable
 }
 
+// SetReadYourWrites sets the read-your-writes consistency of the session.
+// The GTID sets of the previous writes are forgotten when it is disabled.
+func (session *SafeSession) SetReadYourWrites(enable bool) {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	if !enable {
+		session.Session.ReadYourWrites = nil
+		return
+	}
+	if session.Session.ReadYourWrites == nil {
+		session.Session.ReadYourWrites = &vtgatepb.ReadYourWrites{}
+	}
+	session.Session.ReadYourWrites.Enabled = true
+}
+
+// ReadYourWrites returns true if the session tracks the GTIDs of its
+// writes to read them back from the replicas.
+func (session *SafeSession) ReadYourWrites() bool {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	return session.Session.ReadYourWrites.GetEnabled()
+}
+
+// ReadAfterWriteTimeout returns the ReadAfterWriteTimeout setting.
+func (session *SafeSession) ReadAfterWriteTimeout() float64 {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	return session.ReadAfterWrite.GetReadAfterWriteTimeout()
+}
+
+// ReadYourWritesGTIDFor returns the GTID set a replica of the shard must
+// have executed to serve the reads of the session, and whether there is
+// one. An empty GTID set means that the reads must go to the master.
+func (session *SafeSession) ReadYourWritesGTIDFor(keyspace, shard string) (string, bool) {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	gtid, ok := session.Session.ReadYourWrites.GetGtids()[keyspace+"/"+shard]
+	return gtid, ok
+}
+
+// RecordReadYourWritesGTID records the GTID set executed by the master of
+// the shard after a write of the session.
+func (session *SafeSession) RecordReadYourWritesGTID(keyspace, shard, gtid string) {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	if session.Session.ReadYourWrites == nil {
+		session.Session.ReadYourWrites = &vtgatepb.ReadYourWrites{}
+	}
+	if session.Session.ReadYourWrites.Gtids == nil {
+		session.Session.ReadYourWrites.Gtids = make(map[string]string)
+	}
+	session.Session.ReadYourWrites.Gtids[keyspace+"/"+shard] = gtid
+}
+
+// ChecksumKeyspaces returns the @@vitess_checksum_keyspaces setting. It is
+// kept with the user defined variables of the session.
+func (session *SafeSession) ChecksumKeyspaces() string {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	bv, ok := session.UserDefinedVariables[checksumKeyspacesVariable]
+	if !ok {
+		return ""
+	}
+	return string(bv.Value)
+}
+
+// SetChecksumKeyspaces sets the @@vitess_checksum_keyspaces setting.
+func (session *SafeSession) SetChecksumKeyspaces(value string) {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	if value == "" {
+		delete(session.UserDefinedVariables, checksumKeyspacesVariable)
+		return
+	}
+	if session.UserDefinedVariables == nil {
+		session.UserDefinedVariables = make(map[string]*querypb.BindVariable)
+	}
+	session.UserDefinedVariables[checksumKeyspacesVariable] = sqltypes.StringBindVariable(value)
+}
+
 func removeShard(tabletAlias *topodatapb.TabletAlias, sessions []*vtgatepb.Session_ShardSession) ([]*vtgatepb.Session_ShardSession, error) {
 	idx := -1
 	for i, session := range sessions {
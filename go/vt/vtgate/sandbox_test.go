@@ -308,6 +308,14 @@ func (sct *sandboxTopo) WatchSrvVSchema(ctx context.Context, cell string, callba
 	}()
 }
 
+// WatchSrvKeyspace is part of the srvtopo.Server interface.
+//
+// The sandbox keyspaces never change, so the callback is only called
+// with their current value.
+func (sct *sandboxTopo) WatchSrvKeyspace(ctx context.Context, cell, keyspace string, callback func(*topodatapb.SrvKeyspace, error) bool) {
+	callback(sct.GetSrvKeyspace(ctx, cell, keyspace))
+}
+
 func sandboxDialer(tablet *topodatapb.Tablet, failFast grpcclient.FailFast) (queryservice.QueryService, error) {
 	sand := getSandbox(tablet.Keyspace)
 	sand.sandmu.Lock()
@@ -368,15 +368,44 @@ func (stc *ScatterConn) StreamExecuteMulti(
 	options *querypb.ExecuteOptions,
 	callback func(reply *sqltypes.Result) error,
 ) []error {
-	// mu protects fieldSent, callback and replyErr
+	// The cancelable context is used to stop all the streams as soon as
+	// the callback fails, e.g. because the LIMIT of the query was reached,
+	// so the other shards stop scanning.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// mu protects fieldSent, callback and callbackErr
 	var mu sync.Mutex
 	fieldSent := false
+	var callbackErr error
+	sendReply := func(qr *sqltypes.Result) error {
+		if callbackErr != nil {
+			return callbackErr
+		}
+		if err := callback(qr); err != nil {
+			callbackErr = err
+			cancel()
+			return err
+		}
+		return nil
+	}
 
 	allErrors := stc.multiGo("StreamExecute", rss, func(rs *srvtopo.ResolvedShard, i int) error {
-		return rs.Gateway.StreamExecute(ctx, rs.Target, query, bindVars[i], 0, options, func(qr *sqltypes.Result) error {
-			return stc.processOneStreamingResult(&mu, &fieldSent, qr, callback)
+		err := rs.Gateway.StreamExecute(ctx, rs.Target, query, bindVars[i], 0, options, func(qr *sqltypes.Result) error {
+			return stc.processOneStreamingResult(&mu, &fieldSent, qr, sendReply)
 		})
+		mu.Lock()
+		defer mu.Unlock()
+		if callbackErr != nil {
+			// The stream was stopped because of the callback: its own
+			// error, e.g. context canceled, doesn't matter.
+			return nil
+		}
+		return err
 	})
+	if callbackErr != nil {
+		return []error{callbackErr}
+	}
 	return allErrors.GetErrors()
 }
 
@@ -576,7 +605,7 @@ func (stc *ScatterConn) multiGoTransaction(
 				TransactionId: updated.transactionID,
 				ReservedId:    updated.reservedID,
 				TabletAlias:   updated.alias,
-			}, stc.txConn.mode)
+			}, stc.txConn.txMode(session, rs.Target.Keyspace))
 			if appendErr != nil {
 				err = appendErr
 			}
@@ -17,6 +17,7 @@ limitations under the License.
 package vtgate
 
 import (
+	"io"
 	"testing"
 
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
@@ -428,3 +429,30 @@ func TestIsConnClosed(t *testing.T) {
 		})
 	}
 }
+
+func TestStreamExecuteMultiStopsOnCallbackError(t *testing.T) {
+	keyspace := "TestStreamExecuteMultiStopsOnCallbackError"
+	createSandbox(keyspace)
+	hc := discovery.NewFakeHealthCheck()
+	sc := newTestScatterConn(hc, new(sandboxTopo), "aa")
+	var rss []*srvtopo.ResolvedShard
+	for _, shard := range []string{"0", "1", "2"} {
+		sbc := hc.AddTestTablet("aa", shard, 1, keyspace, shard, topodatapb.TabletType_REPLICA, true, 1, nil)
+		rss = append(rss, &srvtopo.ResolvedShard{
+			Target:  &querypb.Target{Keyspace: keyspace, Shard: shard, TabletType: topodatapb.TabletType_REPLICA},
+			Gateway: sbc,
+		})
+	}
+	bvs := make([]map[string]*querypb.BindVariable, len(rss))
+
+	// The first reply is enough, e.g. because it has the rows of the
+	// LIMIT: the callback isn't called anymore, and only its error is
+	// returned.
+	calls := 0
+	errs := sc.StreamExecuteMulti(ctx, "query", rss, bvs, nil, func(*sqltypes.Result) error {
+		calls++
+		return io.EOF
+	})
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, []error{io.EOF}, errs)
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"math"
 	"math/rand"
 	"sort"
 	"sync"
@@ -28,6 +29,7 @@ import (
 	"vitess.io/vitess/go/vt/topo/topoproto"
 
 	"vitess.io/vitess/go/vt/discovery"
+	"vitess.io/vitess/go/vt/eventlog"
 	"vitess.io/vitess/go/vt/log"
 	"vitess.io/vitess/go/vt/srvtopo"
 	"vitess.io/vitess/go/vt/topo"
@@ -71,6 +73,21 @@ type TabletGateway struct {
 
 	// buffer, if enabled, buffers requests during a detected MASTER failover.
 	buffer *buffer.Buffer
+
+	// hedger tracks latencies to decide when to hedge reads.
+	hedger *hedger
+	// hedgedService sends the hedged reads, through withRetry like the
+	// other requests.
+	hedgedService queryservice.QueryService
+
+	// retryPolicy applies the backoff and the budget of the retries.
+	retryPolicy *retryPolicy
+
+	// doNotServeMu protects doNotServeCells.
+	doNotServeMu sync.RWMutex
+	// doNotServeCells are the cells excluded from the cross-cell routing
+	// of each keyspace.
+	doNotServeCells map[string]map[string]bool
 }
 
 func createTabletGateway(ctx context.Context, _ discovery.LegacyHealthCheck, serv srvtopo.Server, cell string, _ int) Gateway {
@@ -106,6 +123,8 @@ func NewTabletGateway(ctx context.Context, hc discovery.HealthCheck, serv srvtop
 		retryCount:        *RetryCount,
 		statusAggregators: make(map[string]*TabletStatusAggregator),
 		buffer:            buffer.New(),
+		hedger:            newHedger(),
+		retryPolicy:       newRetryPolicy(),
 	}
 	// subscribe to healthcheck updates so that buffer can be notified if needed
 	// we run this in a separate goroutine so that normal processing doesn't need to block
@@ -129,6 +148,7 @@ func NewTabletGateway(ctx context.Context, hc discovery.HealthCheck, serv srvtop
 		}
 	}(bufferCtx, hcChan, gw.buffer)
 	gw.QueryService = queryservice.Wrap(nil, gw.withRetry)
+	gw.hedgedService = queryservice.Wrap(nil, gw.withHedging)
 	return gw
 }
 
@@ -137,6 +157,17 @@ func (gw *TabletGateway) QueryServiceByAlias(alias *topodatapb.TabletAlias, targ
 	return gw.hc.TabletConnection(alias, target)
 }
 
+// PickTablet returns the connection to one of the healthy tablets of the
+// target, preferably in the local cell, or nil if there is none.
+func (gw *TabletGateway) PickTablet(target *querypb.Target) queryservice.QueryService {
+	tablets := gw.servingTablets(target)
+	if len(tablets) == 0 {
+		return nil
+	}
+	gw.shuffleTablets(gw.localCell, tablets)
+	return tablets[0].Conn
+}
+
 // RegisterStats registers the stats to export the lag since the last refresh
 // and the checksum of the topology
 func (gw *TabletGateway) RegisterStats() {
@@ -165,6 +196,55 @@ func (gw *TabletGateway) Close(_ context.Context) error {
 	return gw.hc.Close()
 }
 
+// BufferStatus returns a snapshot of the state of the MASTER failover buffer.
+func (gw *TabletGateway) BufferStatus() *buffer.Status {
+	return gw.buffer.Status()
+}
+
+// onPartitionChange buffers the MASTER requests of the shards whose query
+// service is disabled ahead of a partition switch, and retries them once
+// the shards stopped serving, so they get resolved to the new shards.
+func (gw *TabletGateway) onPartitionChange(change *partitionChange) {
+	if change.TabletType != topodatapb.TabletType_MASTER {
+		return
+	}
+	for _, shard := range change.Disabled {
+		gw.buffer.StartPartitionSwitch(change.Keyspace, shard)
+	}
+	for _, shard := range change.Removed {
+		gw.buffer.EndPartitionSwitch(change.Keyspace, shard)
+	}
+	for _, shard := range change.Enabled {
+		gw.buffer.EndPartitionSwitch(change.Keyspace, shard)
+	}
+}
+
+// cellsWatcher is implemented by the healthchecks which can change the
+// cells they watch at runtime.
+type cellsWatcher interface {
+	CellsToWatch() []string
+	SetCellsToWatch(cellsToWatch string) error
+}
+
+// CellsToWatch returns the cells whose tablets are watched.
+func (gw *TabletGateway) CellsToWatch() ([]string, error) {
+	cw, ok := gw.hc.(cellsWatcher)
+	if !ok {
+		return nil, vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "the healthcheck doesn't support changing the cells to watch")
+	}
+	return cw.CellsToWatch(), nil
+}
+
+// SetCellsToWatch changes the cells whose tablets are watched, without a
+// restart. cellsToWatch has the format of -cells_to_watch.
+func (gw *TabletGateway) SetCellsToWatch(cellsToWatch string) error {
+	cw, ok := gw.hc.(cellsWatcher)
+	if !ok {
+		return vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "the healthcheck doesn't support changing the cells to watch")
+	}
+	return cw.SetCellsToWatch(cellsToWatch)
+}
+
 // CacheStatus returns a list of TabletCacheStatus per
 // keyspace/shard/tablet_type.
 func (gw *TabletGateway) CacheStatus() TabletCacheStatusList {
@@ -179,7 +259,8 @@ func (gw *TabletGateway) CacheStatus() TabletCacheStatusList {
 }
 
 // withRetry gets available connections and executes the action. If there are retryable errors,
-// it retries retryCount times before failing. It does not retry if the connection is in
+// it retries retryCount times before failing, as allowed by the retry policy (see retry.go).
+// It does not retry if the connection is in
 // the middle of a transaction. While returning the error check if it maybe a result of
 // a resharding event, and set the re-resolve bit and let the upper layers
 // re-resolve and retry.
@@ -206,8 +287,12 @@ func (gw *TabletGateway) withRetry(ctx context.Context, target *querypb.Target,
 		}
 	}
 
+	gw.retryPolicy.onRequest(target)
 	bufferedOnce := false
+	// retrying is true if the previous attempt failed and must be retried.
+	retrying := false
 	for i := 0; i < gw.retryCount+1; i++ {
+		buffered := false
 		// Check if we should buffer MASTER queries which failed due to an ongoing
 		// failover.
 		// Note: We only buffer once and only "!inTransaction" queries i.e.
@@ -230,10 +315,19 @@ func (gw *TabletGateway) withRetry(ctx context.Context, target *querypb.Target,
 				// Notify the buffer after we retried.
 				defer retryDone()
 				bufferedOnce = true
+				buffered = true
 			}
 		}
 
-		tablets := gw.hc.GetHealthyTabletStats(target)
+		// A request which was buffered is retried as soon as the failover
+		// ended, without going through the backoff and the retry budget.
+		if retrying && !buffered && !gw.retryPolicy.waitForRetry(ctx, target, i) {
+			// Keep the error of the last attempt.
+			break
+		}
+		retrying = false
+
+		tablets := gw.servingTablets(target)
 		if len(tablets) == 0 {
 			// fail fast if there is no tablet
 			err = vterrors.Errorf(vtrpcpb.Code_UNAVAILABLE, "no healthy tablet available for '%s'", target.String())
@@ -262,6 +356,9 @@ func (gw *TabletGateway) withRetry(ctx context.Context, target *querypb.Target,
 		if th.Conn == nil {
 			err = vterrors.Errorf(vtrpcpb.Code_UNAVAILABLE, "no connection for tablet %v", tabletLastUsed)
 			invalidTablets[topoproto.TabletAliasString(tabletLastUsed.Alias)] = true
+			// No request was sent, so this isn't an attempt: the next tablet
+			// is tried right away. This ends since the tablet is skipped now.
+			i--
 			continue
 		}
 
@@ -270,7 +367,16 @@ func (gw *TabletGateway) withRetry(ctx context.Context, target *querypb.Target,
 		canRetry, err = inner(ctx, target, th.Conn)
 		gw.updateStats(target, startTime, err)
 		if canRetry {
-			invalidTablets[topoproto.TabletAliasString(tabletLastUsed.Alias)] = true
+			alias := topoproto.TabletAliasString(tabletLastUsed.Alias)
+			invalidTablets[alias] = true
+			eventlog.Infof("gateway_retry", alias, eventlog.Fields{
+				"keyspace":    target.Keyspace,
+				"shard":       target.Shard,
+				"tablet_type": topoproto.TabletTypeLString(target.TabletType),
+				"attempt":     i + 1,
+				"error":       fmt.Sprint(err),
+			}, "request to tablet %v failed, retrying on another tablet: %v", alias, err)
+			retrying = true
 			continue
 		}
 		break
@@ -336,6 +442,43 @@ func (gw *TabletGateway) shuffleTablets(cell string, tablets []*discovery.Tablet
 		swap := rand.Intn(i-sameCellMax) + diffCellMin
 		tablets[i], tablets[swap] = tablets[swap], tablets[i]
 	}
+
+	// order the same cell and the diff cell tablets by traffic weight
+	sameCellCount := 0
+	for sameCellCount < length && tablets[sameCellCount].Tablet.Alias.Cell == cell {
+		sameCellCount++
+	}
+	weightTablets(tablets[:sameCellCount])
+	weightTablets(tablets[sameCellCount:])
+}
+
+// weightTablets reorders the tablets if some of them have a traffic weight,
+// so that each tablet comes first with a probability proportional to its
+// weight. The tablets with a zero weight come last: they only get queries
+// when the other tablets fail.
+func weightTablets(tablets []*discovery.TabletHealth) {
+	weighted := false
+	for _, th := range tablets {
+		if topoproto.TabletTrafficWeight(th.Tablet) != topoproto.MaxTabletTrafficWeight {
+			weighted = true
+			break
+		}
+	}
+	if !weighted {
+		return
+	}
+	// This is the weighted random sampling of Efraimidis and Spirakis:
+	// the tablets are ordered by decreasing rand^(1/weight).
+	keys := make(map[*discovery.TabletHealth]float64, len(tablets))
+	for _, th := range tablets {
+		keys[th] = -1
+		if weight := topoproto.TabletTrafficWeight(th.Tablet); weight > 0 {
+			keys[th] = math.Pow(rand.Float64(), 1/float64(weight))
+		}
+	}
+	sort.SliceStable(tablets, func(i, j int) bool {
+		return keys[tablets[i]] > keys[tablets[j]]
+	})
 }
 
 func (gw *TabletGateway) nextTablet(cell string, tablets []*discovery.TabletHealth, offset, length int, sameCell bool) int {
@@ -35,6 +35,7 @@ import (
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
 )
 
 func TestTabletGatewayExecute(t *testing.T) {
@@ -163,6 +164,42 @@ func TestTabletGatewayShuffleTablets(t *testing.T) {
 	}
 }
 
+func TestTabletGatewayShuffleWeightedTablets(t *testing.T) {
+	tg := NewTabletGateway(context.Background(), nil, nil, "local")
+
+	newTablet := func(uid uint32, cell string, weight int) *discovery.TabletHealth {
+		tablet := topo.NewTablet(uid, cell, fmt.Sprintf("host%d", uid))
+		require.NoError(t, topoproto.SetTabletTrafficWeight(tablet, weight))
+		return &discovery.TabletHealth{
+			Tablet:  tablet,
+			Target:  &querypb.Target{Keyspace: "k", Shard: "s", TabletType: topodatapb.TabletType_REPLICA},
+			Serving: true,
+			Stats:   &querypb.RealtimeStats{SecondsBehindMaster: 1, CpuUsage: 0.2},
+		}
+	}
+	ts1 := newTablet(1, "cell1", 100)
+	ts2 := newTablet(2, "cell1", 100)
+	canary := newTablet(3, "cell1", 1)
+	disabled := newTablet(4, "cell1", 0)
+	remote := newTablet(5, "cell2", 100)
+
+	const iterations = 10000
+	first := make(map[*discovery.TabletHealth]int)
+	for i := 0; i < iterations; i++ {
+		tablets := []*discovery.TabletHealth{remote, disabled, canary, ts2, ts1}
+		tg.shuffleTablets("cell1", tablets)
+		first[tablets[0]]++
+		// The tablets with a zero weight come after the other tablets of
+		// their cell, and the weights don't change the cell preference.
+		assert.Equal(t, disabled, tablets[3])
+		assert.Equal(t, remote, tablets[4])
+	}
+	// The canary gets 1/201 of the traffic.
+	assert.Greater(t, first[canary], 0)
+	assert.Less(t, first[canary], iterations/50)
+	assert.InDelta(t, first[ts1], first[ts2], iterations/10)
+}
+
 func TestTabletGatewayReplicaTransactionError(t *testing.T) {
 	keyspace := "ks"
 	shard := "0"
@@ -0,0 +1,58 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"time"
+
+	"vitess.io/vitess/go/stats"
+)
+
+// The routing rules with weights split the reads of a table between
+// several keyspaces, e.g. while the reads of a MoveTables workflow are
+// progressively switched with SplitReads. The queries of the tables they
+// target are counted and timed per keyspace, so that the errors and
+// latencies of both sides can be compared before switching more traffic.
+
+var (
+	trafficSplitQueries = stats.NewCountersWithMultiLabels(
+		"TrafficSplitQueries",
+		"Number of queries of the tables targeted by weighted routing rules, by keyspace",
+		[]string{"Keyspace", "Table"})
+	trafficSplitErrors = stats.NewCountersWithMultiLabels(
+		"TrafficSplitErrors",
+		"Number of failed queries of the tables targeted by weighted routing rules, by keyspace",
+		[]string{"Keyspace", "Table"})
+	trafficSplitLatency = stats.NewMultiTimings(
+		"TrafficSplitLatency",
+		"Latency of the queries of the tables targeted by weighted routing rules, by keyspace",
+		[]string{"Keyspace", "Table"})
+)
+
+// recordTrafficSplit records the outcome of a query of the table, if it
+// is targeted by a weighted routing rule.
+func (vc *vcursorImpl) recordTrafficSplit(keyspace, table string, latency time.Duration, err error) {
+	if !vc.vschema.IsTrafficSplitTable(keyspace, table) {
+		return
+	}
+	labels := []string{keyspace, table}
+	trafficSplitQueries.Add(labels, 1)
+	if err != nil {
+		trafficSplitErrors.Add(labels, 1)
+	}
+	trafficSplitLatency.Add(labels, latency)
+}
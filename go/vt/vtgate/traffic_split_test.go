@@ -0,0 +1,68 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/srvtopo"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+func TestTrafficSplit(t *testing.T) {
+	vschema := vindexes.BuildVSchema(&vschemapb.SrvVSchema{
+		RoutingRules: &vschemapb.RoutingRules{
+			Rules: []*vschemapb.RoutingRule{{
+				FromTable: "t1@replica",
+				ToTables:  []string{"source.t1", "target.t1"},
+				Weights:   []int32{90, 10},
+			}},
+		},
+		Keyspaces: map[string]*vschemapb.Keyspace{
+			"source": {Tables: map[string]*vschemapb.Table{"t1": {}}},
+			"target": {Tables: map[string]*vschemapb.Table{"t1": {}}},
+		},
+	})
+
+	ss := NewSafeSession(&vtgatepb.Session{TargetString: "source@replica"})
+	vc, err := newVCursorImpl(context.Background(), ss, sqlparser.MarginComments{}, nil, nil, &fakeVSchemaOperator{vschema: vschema}, vschema, srvtopo.NewResolver(&fakeTopoServer{}, nil, ""), nil, false)
+	require.NoError(t, err)
+
+	// The plans of each split point are cached separately.
+	vc.trafficSplitPoint = 0
+	assert.Equal(t, "source@replica+split0", vc.planPrefixKey())
+	vc.trafficSplitPoint = 90
+	assert.Equal(t, "source@replica+split90", vc.planPrefixKey())
+
+	before := trafficSplitQueries.Counts()["target.t1"]
+	beforeErrors := trafficSplitErrors.Counts()["target.t1"]
+	vc.recordTrafficSplit("target", "t1", time.Millisecond, errors.New("failed"))
+	vc.recordTrafficSplit("other", "t1", time.Millisecond, nil)
+	assert.Equal(t, before+1, trafficSplitQueries.Counts()["target.t1"])
+	assert.Equal(t, beforeErrors+1, trafficSplitErrors.Counts()["target.t1"])
+	assert.Zero(t, trafficSplitQueries.Counts()["other.t1"])
+}
@@ -33,12 +33,16 @@ import (
 	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
 )
 
 // TxConn is used for executing transactional requests.
 type TxConn struct {
 	gateway Gateway
 	mode    vtgatepb.TransactionMode
+	// vschema returns the VSchema which sets the default transaction
+	// modes of the keyspaces. It can be nil.
+	vschema func() *vindexes.VSchema
 }
 
 // NewTxConn builds a new TxConn.
@@ -74,12 +78,44 @@ func (txc *TxConn) Commit(ctx context.Context, session *SafeSession) error {
 	case vtgatepb.TransactionMode_TWOPC:
 		twopc = true
 	case vtgatepb.TransactionMode_UNSPECIFIED:
-		twopc = txc.mode == vtgatepb.TransactionMode_TWOPC
+		twopc = txc.txMode(session) == vtgatepb.TransactionMode_TWOPC
 	}
+	var targets []*querypb.Target
+	if session.ReadYourWrites() {
+		for _, shardSession := range session.ShardSessions {
+			targets = append(targets, shardSession.Target)
+		}
+	}
+	var err error
 	if twopc {
-		return txc.commit2PC(ctx, session)
+		err = txc.commit2PC(ctx, session)
+	} else {
+		err = txc.commitNormal(ctx, session)
+	}
+	if err == nil && len(targets) > 0 {
+		recordWriteGTIDs(ctx, txc.gateway, session, targets)
+	}
+	return err
+}
+
+// txMode returns the transaction mode which applies to the session if it
+// didn't set its own: the most restrictive default of the keyspaces it
+// has a transaction in, plus the given keyspaces, or -transaction_mode.
+func (txc *TxConn) txMode(session *SafeSession, keyspaces ...string) vtgatepb.TransactionMode {
+	if txc.vschema == nil {
+		return txc.mode
+	}
+	vschema := txc.vschema()
+	if vschema == nil {
+		return txc.mode
+	}
+	var modes []vtgatepb.TransactionMode
+	for _, keyspace := range append(session.ShardSessionKeyspaces(), keyspaces...) {
+		if ks := vschema.Keyspaces[keyspace]; ks != nil {
+			modes = append(modes, ks.TransactionMode)
+		}
 	}
-	return txc.commitNormal(ctx, session)
+	return mergeTxModes(txc.mode, modes...)
 }
 
 func (txc *TxConn) queryService(alias *topodatapb.TabletAlias) (queryservice.QueryService, error) {
@@ -116,8 +152,15 @@ func (txc *TxConn) commitNormal(ctx context.Context, session *SafeSession) error
 	}
 
 	// Retain backward compatibility on commit order for the normal session.
-	for _, shardSession := range session.ShardSessions {
+	for i, shardSession := range session.ShardSessions {
 		if err := txc.commitShard(ctx, shardSession); err != nil {
+			// The pre sessions and the previous shard sessions are
+			// committed, and the others are rolled back by Release.
+			committed := append(append([]*vtgatepb.Session_ShardSession(nil), session.PreSessions...), session.ShardSessions[:i]...)
+			if len(committed) > 0 {
+				pending := append(append([]*vtgatepb.Session_ShardSession(nil), session.ShardSessions[i:]...), session.PostSessions...)
+				err = newPartialCommitError(err, shardSession, committed, pending)
+			}
 			_ = txc.Release(ctx, session)
 			return err
 		}
@@ -479,6 +479,41 @@ func TestTxConnCommitOrderFailure3(t *testing.T) {
 	assert.EqualValues(t, 0, sbc1.RollbackCount.Get(), "sbc1.RollbackCount")
 }
 
+func TestTxConnCommitPartialFailure(t *testing.T) {
+	sc, sbc0, sbc1, rss0, rss1, _ := newLegacyTestTxConnEnv(t, "TestTxConn")
+	sc.txConn.mode = vtgatepb.TransactionMode_MULTI
+
+	session := NewSafeSession(&vtgatepb.Session{InTransaction: true})
+	sc.ExecuteMultiShard(ctx, rss0, queries, session, false, false)
+	sc.ExecuteMultiShard(ctx, rss1, queries, session, false, false)
+
+	sbc1.MustFailCodes[vtrpcpb.Code_INVALID_ARGUMENT] = 1
+	err := sc.txConn.Commit(ctx, session)
+	require.Error(t, err)
+	pce, ok := err.(*PartialCommitError)
+	require.True(t, ok, "want a PartialCommitError, got %T: %v", err, err)
+	assert.Equal(t, []string{"TestTxConn/0"}, pce.Committed)
+	assert.Equal(t, []string{"TestTxConn/1"}, pce.RolledBack)
+	assert.Equal(t, "TestTxConn/1", pce.Failed)
+	assert.Equal(t, vtrpcpb.Code_INVALID_ARGUMENT, vterrors.Code(err))
+	assert.Contains(t, err.Error(), "transaction partially committed: commit failed on TestTxConn/1")
+
+	utils.MustMatch(t, &vtgatepb.Session{}, session.Session, "Session")
+	assert.EqualValues(t, 1, sbc0.CommitCount.Get(), "sbc0.CommitCount")
+	assert.EqualValues(t, 1, sbc1.CommitCount.Get(), "sbc1.CommitCount")
+	assert.EqualValues(t, 1, sbc1.ReleaseCount.Get(), "sbc1.ReleaseCount")
+
+	// Nothing was committed: the original error is returned.
+	session = NewSafeSession(&vtgatepb.Session{InTransaction: true})
+	sc.ExecuteMultiShard(ctx, rss0, queries, session, false, false)
+	sc.ExecuteMultiShard(ctx, rss1, queries, session, false, false)
+	sbc0.MustFailCodes[vtrpcpb.Code_INVALID_ARGUMENT] = 1
+	err = sc.txConn.Commit(ctx, session)
+	require.Error(t, err)
+	_, ok = err.(*PartialCommitError)
+	assert.False(t, ok)
+}
+
 func TestTxConnCommitOrderSuccess(t *testing.T) {
 	sc, sbc0, sbc1, rss0, rss1, _ := newLegacyTestTxConnEnv(t, "TestTxConn")
 	sc.txConn.mode = vtgatepb.TransactionMode_MULTI
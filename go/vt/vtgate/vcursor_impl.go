@@ -19,7 +19,9 @@ package vtgate
 import (
 	"context"
 	"fmt"
+	"math/rand"
 	"sort"
+	"strconv"
 	"strings"
 	"sync/atomic"
 	"time"
@@ -101,6 +103,18 @@ type vcursorImpl struct {
 	semTable              *semantics.SemTable
 	warnShardedOnly       bool // when using sharded only features, a warning will be warnings field
 
+	// hasQueryTimeoutDirective is true if the query sets its own timeout,
+	// which overrides the query timeout policy.
+	hasQueryTimeoutDirective bool
+	// timeouts is the query timeout policy of the executor.
+	timeouts *queryTimeoutPolicy
+	// inParallelContext is true while the primitives of the plan run in
+	// parallel on the context of ErrorGroupCancellableContext.
+	inParallelContext bool
+	// trafficSplitPoint picks the tables of the routing rules which split
+	// the traffic between several tables, see vindexes.TrafficSplitPoint.
+	trafficSplitPoint int
+
 	warnings []*querypb.QueryWarning // any warnings that are accumulated during the planning phase are stored here
 }
 
@@ -138,21 +152,31 @@ func newVCursorImpl(
 			return nil, err
 		}
 	}
+	var timeouts *queryTimeoutPolicy
+	if executor != nil {
+		timeouts = executor.timeouts
+	}
+	var trafficSplitPoint int
+	if vschema.HasTrafficSplits() {
+		trafficSplitPoint = vschema.TrafficSplitPoint(rand.Intn(100))
+	}
 
 	return &vcursorImpl{
-		ctx:             ctx,
-		safeSession:     safeSession,
-		keyspace:        keyspace,
-		tabletType:      tabletType,
-		destination:     destination,
-		marginComments:  marginComments,
-		executor:        executor,
-		logStats:        logStats,
-		resolver:        resolver,
-		vschema:         vschema,
-		vm:              vm,
-		topoServer:      ts,
-		warnShardedOnly: warnShardedOnly,
+		ctx:               ctx,
+		safeSession:       safeSession,
+		keyspace:          keyspace,
+		tabletType:        tabletType,
+		destination:       destination,
+		marginComments:    marginComments,
+		executor:          executor,
+		logStats:          logStats,
+		resolver:          resolver,
+		vschema:           vschema,
+		vm:                vm,
+		topoServer:        ts,
+		warnShardedOnly:   warnShardedOnly,
+		timeouts:          timeouts,
+		trafficSplitPoint: trafficSplitPoint,
 	}, nil
 }
 
@@ -177,20 +201,39 @@ func (vc *vcursorImpl) SetIgnoreMaxMemoryRows(ignoreMaxMemoryRows bool) {
 	vc.ignoreMaxMemoryRows = ignoreMaxMemoryRows
 }
 
-// SetContextTimeout updates context and sets a timeout.
+// SetContextTimeout updates context and sets a timeout. The returned
+// function cancels the context and restores the previous one, s.t. the
+// timeout of a primitive doesn't apply to the ones executed after it.
 func (vc *vcursorImpl) SetContextTimeout(timeout time.Duration) context.CancelFunc {
+	origCtx := vc.ctx
 	ctx, cancel := context.WithTimeout(vc.ctx, timeout)
 	vc.ctx = ctx
-	return cancel
+	return func() {
+		cancel()
+		vc.ctx = origCtx
+	}
+}
+
+// DefaultQueryTimeout is part of the engine.VCursor interface.
+// The routes executed in parallel don't set their own timeout, as they
+// share the context of the vcursor: only the timeout of the plan applies.
+func (vc *vcursorImpl) DefaultQueryTimeout(keyspace, table string) time.Duration {
+	if vc.hasQueryTimeoutDirective || vc.inParallelContext {
+		return 0
+	}
+	return vc.timeouts.timeout(vc.vschema, keyspace, table, vc.tabletType)
 }
 
 // ErrorGroupCancellableContext updates context that can be cancelled.
 func (vc *vcursorImpl) ErrorGroupCancellableContext() (*errgroup.Group, func()) {
 	origCtx := vc.ctx
+	origInParallelContext := vc.inParallelContext
 	g, ctx := errgroup.WithContext(vc.ctx)
 	vc.ctx = ctx
+	vc.inParallelContext = true
 	return g, func() {
 		vc.ctx = origCtx
+		vc.inParallelContext = origInParallelContext
 	}
 }
 
@@ -225,7 +268,10 @@ func (vc *vcursorImpl) FindRoutedTable(name sqlparser.TableName) (*vindexes.Tabl
 		destKeyspace = vc.keyspace
 	}
 
-	table, err := vc.vschema.FindRoutedTable(destKeyspace, name.Name.String(), destTabletType)
+	if vc.safeSession.ignoreRoutingRules {
+		return vc.vschema.FindTable(destKeyspace, name.Name.String())
+	}
+	table, err := vc.vschema.FindRoutedTableAtSplit(destKeyspace, name.Name.String(), destTabletType, vc.trafficSplitPoint)
 	if err != nil {
 		return nil, err
 	}
@@ -242,7 +288,15 @@ func (vc *vcursorImpl) FindTableOrVindex(name sqlparser.TableName) (*vindexes.Ta
 	if destKeyspace == "" {
 		destKeyspace = vc.getActualKeyspace()
 	}
-	table, vindex, err := vc.vschema.FindTableOrVindex(destKeyspace, name.Name.String(), vc.tabletType)
+	var (
+		table  *vindexes.Table
+		vindex vindexes.Vindex
+	)
+	if vc.safeSession.ignoreRoutingRules {
+		table, vindex, err = vc.vschema.FindUnroutedTableOrVindex(destKeyspace, name.Name.String(), vc.tabletType)
+	} else {
+		table, vindex, err = vc.vschema.FindTableOrVindexAtSplit(destKeyspace, name.Name.String(), vc.tabletType, vc.trafficSplitPoint)
+	}
 	if err != nil {
 		return nil, nil, "", destTabletType, nil, err
 	}
@@ -389,14 +443,39 @@ func (vc *vcursorImpl) Execute(method string, query string, bindVars map[string]
 // ExecuteMultiShard is part of the engine.VCursor interface.
 func (vc *vcursorImpl) ExecuteMultiShard(rss []*srvtopo.ResolvedShard, queries []*querypb.BoundQuery, rollbackOnError, autocommit bool) (*sqltypes.Result, []error) {
 	atomic.AddUint64(&vc.logStats.ShardQueries, uint64(len(queries)))
+	readYourWrites := vc.readYourWrites()
+	if readYourWrites && vc.logStats.StmtType == "SELECT" {
+		rss = readYourWritesShards(vc.ctx, vc.safeSession, rss)
+	}
 	qr, errs := vc.executor.ExecuteMultiShard(vc.ctx, rss, commentedShardQueries(queries, vc.marginComments), vc.safeSession, autocommit, vc.ignoreMaxMemoryRows)
 
 	if errs == nil && rollbackOnError {
 		vc.rollbackOnPartialExec = true
 	}
+	if readYourWrites && autocommit && len(rss) > 0 {
+		// The writes in a transaction are recorded on commit.
+		targets := make([]*querypb.Target, 0, len(rss))
+		for _, rs := range rss {
+			targets = append(targets, rs.Target)
+		}
+		recordWriteGTIDs(vc.ctx, rss[0].Gateway, vc.safeSession, targets)
+	}
 	return qr, errs
 }
 
+// readYourWrites returns true if the queries outside of a transaction
+// must follow the read-your-writes consistency of the session.
+func (vc *vcursorImpl) readYourWrites() bool {
+	if !vc.safeSession.ReadYourWrites() || vc.safeSession.InTransaction() || vc.safeSession.InReservedConn() {
+		return false
+	}
+	switch vc.tabletType {
+	case topodatapb.TabletType_MASTER, topodatapb.TabletType_REPLICA:
+		return true
+	}
+	return false
+}
+
 func (vc *vcursorImpl) InTransactionAndIsDML() bool {
 	if !vc.safeSession.InTransaction() {
 		return false
@@ -442,9 +521,12 @@ func (vc *vcursorImpl) ExecuteStandalone(query string, bindVars map[string]*quer
 }
 
 // StreamExeculteMulti is the streaming version of ExecuteMultiShard.
-func (vc *vcursorImpl) StreamExecuteMulti(query string, rss []*srvtopo.ResolvedShard, bindVars []map[string]*querypb.BindVariable, callback func(reply *sqltypes.Result) error) []error {
+func (vc *vcursorImpl) StreamExecuteMulti(ctx context.Context, query string, rss []*srvtopo.ResolvedShard, bindVars []map[string]*querypb.BindVariable, callback func(reply *sqltypes.Result) error) []error {
 	atomic.AddUint64(&vc.logStats.ShardQueries, uint64(len(rss)))
-	return vc.executor.StreamExecuteMulti(vc.ctx, vc.marginComments.Leading+query+vc.marginComments.Trailing, rss, bindVars, vc.safeSession.Options, callback)
+	if vc.readYourWrites() && vc.logStats.StmtType == "SELECT" {
+		rss = readYourWritesShards(ctx, vc.safeSession, rss)
+	}
+	return vc.executor.StreamExecuteMulti(ctx, vc.marginComments.Leading+query+vc.marginComments.Trailing, rss, bindVars, vc.safeSession.Options, callback)
 }
 
 // ExecuteKeyspaceID is part of the engine.VCursor interface.
@@ -678,6 +760,30 @@ func (vc *vcursorImpl) SetSessionTrackGTIDs(enable bool) {
 	vc.safeSession.SetSessionTrackGtids(enable)
 }
 
+// GetGroupConcatMaxLen implements the SessionActions interface
+func (vc *vcursorImpl) GetGroupConcatMaxLen() uint64 {
+	if expr, ok := vc.safeSession.GetSystemVariable("group_concat_max_len"); ok {
+		if maxLen, err := strconv.ParseUint(strings.Trim(expr, "'"), 10, 64); err == nil {
+			return maxLen
+		}
+	}
+	return *groupConcatMaxLen
+}
+
+// SetReadYourWrites implements the SessionActions interface
+func (vc *vcursorImpl) SetReadYourWrites(enable bool) {
+	vc.safeSession.SetReadYourWrites(enable)
+}
+
+// SetChecksumKeyspaces implements the SessionActions interface
+func (vc *vcursorImpl) SetChecksumKeyspaces(value string) error {
+	if _, err := parseChecksumKeyspaces(value); err != nil {
+		return vterrors.NewErrorf(vtrpcpb.Code_INVALID_ARGUMENT, vterrors.WrongValueForVar, "variable 'vitess_checksum_keyspaces' can't be set to the value of '%s': %v", value, err)
+	}
+	vc.safeSession.SetChecksumKeyspaces(value)
+	return nil
+}
+
 // HasCreatedTempTable implements the SessionActions interface
 func (vc *vcursorImpl) HasCreatedTempTable() {
 	vc.safeSession.GetOrCreateOptions().HasCreatedTempTables = true
@@ -740,6 +846,18 @@ func parseDestinationTarget(targetString string, vschema *vindexes.VSchema) (str
 }
 
 func (vc *vcursorImpl) planPrefixKey() string {
+	if vc.safeSession.ignoreRoutingRules {
+		return vc.planPrefixKeyWithDestination() + "+unrouted"
+	}
+	// The plans of the queries routed by the weighted routing rules vary
+	// with their traffic split point.
+	if vc.vschema.HasTrafficSplits() {
+		return fmt.Sprintf("%s+split%d", vc.planPrefixKeyWithDestination(), vc.trafficSplitPoint)
+	}
+	return vc.planPrefixKeyWithDestination()
+}
+
+func (vc *vcursorImpl) planPrefixKeyWithDestination() string {
 	if vc.destination != nil {
 		switch vc.destination.(type) {
 		case key.DestinationKeyspaceID, key.DestinationKeyspaceIDs:
@@ -75,6 +75,12 @@ func (f *fakeTopoServer) WatchSrvVSchema(ctx context.Context, cell string, callb
 
 }
 
+// WatchSrvKeyspace starts watching the SrvKeyspace object for
+// the provided cell and keyspace.
+func (f *fakeTopoServer) WatchSrvKeyspace(ctx context.Context, cell, keyspace string, callback func(*topodatapb.SrvKeyspace, error) bool) {
+
+}
+
 func TestDestinationKeyspace(t *testing.T) {
 	ks1 := &vindexes.Keyspace{
 		Name:    "ks1",
@@ -306,3 +312,14 @@ func TestFirstSortedKeyspace(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, ks3Schema.Keyspace, ks)
 }
+
+func TestGetGroupConcatMaxLen(t *testing.T) {
+	session := NewSafeSession(&vtgatepb.Session{})
+	vc := &vcursorImpl{safeSession: session}
+	require.EqualValues(t, *groupConcatMaxLen, vc.GetGroupConcatMaxLen())
+
+	session.SetSystemVariable("group_concat_max_len", "2048")
+	require.EqualValues(t, 2048, vc.GetGroupConcatMaxLen())
+	session.SetSystemVariable("group_concat_max_len", "'4096'")
+	require.EqualValues(t, 4096, vc.GetGroupConcatMaxLen())
+}
@@ -158,6 +158,29 @@ func (cached *Hash) CachedSize(alloc bool) int64 {
 	size += int64(len(cached.name))
 	return size
 }
+func (cached *JSONPath) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(64)
+	}
+	// field name string
+	size += int64(len(cached.name))
+	// field path vitess.io/vitess/go/vt/vtgate/evalengine.JSONPath
+	{
+		size += int64(cap(cached.path)) * int64(32)
+		for _, elem := range cached.path {
+			size += elem.CachedSize(false)
+		}
+	}
+	// field vindex vitess.io/vitess/go/vt/vtgate/vindexes.SingleColumn
+	if cc, ok := cached.vindex.(cachedObject); ok {
+		size += cc.CachedSize(true)
+	}
+	return size
+}
 func (cached *Keyspace) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -34,12 +34,14 @@ import (
 )
 
 var (
-	_ SingleColumn  = (*ConsistentLookupUnique)(nil)
-	_ Lookup        = (*ConsistentLookupUnique)(nil)
-	_ WantOwnerInfo = (*ConsistentLookupUnique)(nil)
-	_ SingleColumn  = (*ConsistentLookup)(nil)
-	_ Lookup        = (*ConsistentLookup)(nil)
-	_ WantOwnerInfo = (*ConsistentLookup)(nil)
+	_ SingleColumn      = (*ConsistentLookupUnique)(nil)
+	_ Lookup            = (*ConsistentLookupUnique)(nil)
+	_ LookupTableVindex = (*ConsistentLookupUnique)(nil)
+	_ WantOwnerInfo     = (*ConsistentLookupUnique)(nil)
+	_ SingleColumn      = (*ConsistentLookup)(nil)
+	_ Lookup            = (*ConsistentLookup)(nil)
+	_ LookupTableVindex = (*ConsistentLookup)(nil)
+	_ WantOwnerInfo     = (*ConsistentLookup)(nil)
 )
 
 func init() {
@@ -237,6 +239,12 @@ func (lu *clCommon) String() string {
 	return lu.name
 }
 
+// LookupTable returns the lookup table and whether it is written in a
+// separate autocommit transaction.
+func (lu *clCommon) LookupTable() (string, bool) {
+	return lu.lkp.Table, lu.lkp.Autocommit
+}
+
 // Verify returns true if ids maps to ksids.
 func (lu *clCommon) Verify(vcursor VCursor, ids []sqltypes.Value, ksids [][]byte) ([]bool, error) {
 	if lu.writeOnly {
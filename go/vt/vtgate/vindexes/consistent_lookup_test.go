@@ -451,7 +451,6 @@ func TestConsistentLookupUpdateBecauseUncomparableTypes(t *testing.T) {
 		{querypb.Type_TEXT, "some string"},
 		{querypb.Type_VARCHAR, "some string"},
 		{querypb.Type_CHAR, "some string"},
-		{querypb.Type_GEOMETRY, "some string"},
 	}
 
 	for _, val := range tests {
@@ -469,6 +468,19 @@ func TestConsistentLookupUpdateBecauseUncomparableTypes(t *testing.T) {
 	}
 }
 
+func TestConsistentLookupNoUpdateOfEqualSpatialValues(t *testing.T) {
+	lookup := createConsistentLookup(t, "consistent_lookup", false)
+	vc := &loggingVCursor{}
+
+	// The spatial values are compared by their bytes, so an unchanged one
+	// doesn't update the lookup.
+	literal, err := sqltypes.NewValue(querypb.Type_GEOMETRY, []byte("some string"))
+	require.NoError(t, err)
+	err = lookup.(Lookup).Update(vc, []sqltypes.Value{literal, literal}, []byte("test"), []sqltypes.Value{literal, literal})
+	require.NoError(t, err)
+	require.Empty(t, vc.log)
+}
+
 func createConsistentLookup(t *testing.T, name string, writeOnly bool) SingleColumn {
 	t.Helper()
 	write := "false"
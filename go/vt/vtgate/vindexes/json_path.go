@@ -0,0 +1,128 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vindexes
+
+import (
+	"fmt"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/key"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
+)
+
+var (
+	_ SingleColumn   = (*JSONPath)(nil)
+	_ JSONPathVindex = (*JSONPath)(nil)
+)
+
+// A JSONPathVindex is a vindex over the value at a JSON path of its
+// column, rather than over the value of the column. The planner only uses
+// it for the predicates on that path, e.g. doc->>'$.user_id' = 5, and the
+// value at the path is extracted from the documents which are inserted.
+type JSONPathVindex interface {
+	SingleColumn
+	// JSONPath returns the canonical form of the path.
+	JSONPath() string
+	// Extract returns the value at the path of a document, which is
+	// the id the vindex maps.
+	Extract(doc sqltypes.Value) (sqltypes.Value, error)
+}
+
+// JSONPath is a functional vindex which maps the value at a JSON path of
+// its column with another functional vindex. It is declared with the
+// json_path and vindex params, e.g. {"json_path": "$.user_id",
+// "vindex": "hash"}. The other params are passed to the underlying vindex.
+type JSONPath struct {
+	name   string
+	path   evalengine.JSONPath
+	vindex SingleColumn
+}
+
+// NewJSONPath creates a new JSONPath.
+func NewJSONPath(name string, m map[string]string) (Vindex, error) {
+	path, err := evalengine.ParseJSONPath(m["json_path"])
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "json_path vindex %v", name)
+	}
+	vindexType := m["vindex"]
+	if vindexType == "" {
+		return nil, fmt.Errorf("json_path vindex %v: the vindex param is required", name)
+	}
+	params := make(map[string]string, len(m))
+	for k, v := range m {
+		if k != "json_path" && k != "vindex" {
+			params[k] = v
+		}
+	}
+	vindex, err := CreateVindex(vindexType, name, params)
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "json_path vindex %v", name)
+	}
+	single, ok := vindex.(SingleColumn)
+	if !ok || vindex.NeedsVCursor() {
+		return nil, fmt.Errorf("json_path vindex %v: %v is not a functional single column vindex", name, vindexType)
+	}
+	if _, ok := vindex.(JSONPathVindex); ok {
+		return nil, fmt.Errorf("json_path vindex %v: json_path vindexes can't be nested", name)
+	}
+	return &JSONPath{name: name, path: path, vindex: single}, nil
+}
+
+// String returns the name of the vindex.
+func (vind *JSONPath) String() string {
+	return vind.name
+}
+
+// Cost returns the cost of the underlying vindex.
+func (vind *JSONPath) Cost() int {
+	return vind.vindex.Cost()
+}
+
+// IsUnique returns true if the underlying vindex is unique.
+func (vind *JSONPath) IsUnique() bool {
+	return vind.vindex.IsUnique()
+}
+
+// NeedsVCursor satisfies the Vindex interface.
+func (vind *JSONPath) NeedsVCursor() bool {
+	return false
+}
+
+// Map can map the values at the path to key.Destination objects.
+func (vind *JSONPath) Map(vcursor VCursor, ids []sqltypes.Value) ([]key.Destination, error) {
+	return vind.vindex.Map(vcursor, ids)
+}
+
+// Verify returns true if the values at the path map to ksids.
+func (vind *JSONPath) Verify(vcursor VCursor, ids []sqltypes.Value, ksids [][]byte) ([]bool, error) {
+	return vind.vindex.Verify(vcursor, ids, ksids)
+}
+
+// JSONPath satisfies the JSONPathVindex interface.
+func (vind *JSONPath) JSONPath() string {
+	return vind.path.String()
+}
+
+// Extract satisfies the JSONPathVindex interface.
+func (vind *JSONPath) Extract(doc sqltypes.Value) (sqltypes.Value, error) {
+	return vind.path.ExtractValue(doc)
+}
+
+func init() {
+	Register("json_path", NewJSONPath)
+}
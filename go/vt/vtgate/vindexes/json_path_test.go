@@ -0,0 +1,88 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vindexes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+)
+
+func TestJSONPathInfo(t *testing.T) {
+	vindex, err := CreateVindex("json_path", "user_id", map[string]string{"json_path": `$ . "user_id"`, "vindex": "hash"})
+	require.NoError(t, err)
+	jp := vindex.(JSONPathVindex)
+	assert.Equal(t, "user_id", jp.String())
+	assert.Equal(t, "$.user_id", jp.JSONPath())
+	assert.Equal(t, 1, jp.Cost())
+	assert.True(t, jp.IsUnique())
+	assert.False(t, jp.NeedsVCursor())
+
+	_, err = CreateVindex("json_path", "bad", map[string]string{"json_path": "$.a[*]", "vindex": "hash"})
+	assert.EqualError(t, err, `json_path vindex bad: invalid JSON path "$.a[*]": unsupported array index "*"`)
+	_, err = CreateVindex("json_path", "bad", map[string]string{"json_path": "$.a"})
+	assert.EqualError(t, err, "json_path vindex bad: the vindex param is required")
+	_, err = CreateVindex("json_path", "bad", map[string]string{"json_path": "$.a", "vindex": "lookup_hash", "table": "t", "from": "a", "to": "b"})
+	assert.EqualError(t, err, "json_path vindex bad: lookup_hash is not a functional single column vindex")
+}
+
+func TestJSONPathMapAndExtract(t *testing.T) {
+	vindex, err := CreateVindex("json_path", "user_id", map[string]string{"json_path": "$.user.id", "vindex": "hash"})
+	require.NoError(t, err)
+	jp := vindex.(JSONPathVindex)
+
+	// The vindex maps the values at the path as the underlying vindex.
+	got, err := jp.Map(nil, []sqltypes.Value{sqltypes.NewInt64(1)})
+	require.NoError(t, err)
+	want, err := hash.Map(nil, []sqltypes.Value{sqltypes.NewInt64(1)})
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	tcases := []struct {
+		doc  string
+		want sqltypes.Value
+	}{{
+		doc:  `{"user": {"id": 1}}`,
+		want: sqltypes.NewInt64(1),
+	}, {
+		doc:  `{"user": {"id": 18446744073709551615}}`,
+		want: sqltypes.NewUint64(18446744073709551615),
+	}, {
+		doc:  `{"user": {"id": "abc"}}`,
+		want: sqltypes.NewVarChar("abc"),
+	}, {
+		doc:  `{"user": {"id": null}}`,
+		want: sqltypes.NULL,
+	}, {
+		doc:  `{"user": {}}`,
+		want: sqltypes.NULL,
+	}, {
+		doc:  `{"user": {"id": [1, 2]}}`,
+		want: sqltypes.NewVarChar("[1,2]"),
+	}}
+	for _, tcase := range tcases {
+		got, err := jp.Extract(sqltypes.MakeTrusted(sqltypes.TypeJSON, []byte(tcase.doc)))
+		require.NoError(t, err)
+		assert.Equal(t, tcase.want, got, tcase.doc)
+	}
+
+	_, err = jp.Extract(sqltypes.NewVarChar("not json"))
+	assert.Error(t, err)
+}
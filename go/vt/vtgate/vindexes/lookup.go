@@ -27,10 +27,12 @@ import (
 )
 
 var (
-	_ SingleColumn = (*LookupUnique)(nil)
-	_ Lookup       = (*LookupUnique)(nil)
-	_ SingleColumn = (*LookupNonUnique)(nil)
-	_ Lookup       = (*LookupNonUnique)(nil)
+	_ SingleColumn      = (*LookupUnique)(nil)
+	_ Lookup            = (*LookupUnique)(nil)
+	_ LookupTableVindex = (*LookupUnique)(nil)
+	_ SingleColumn      = (*LookupNonUnique)(nil)
+	_ Lookup            = (*LookupNonUnique)(nil)
+	_ LookupTableVindex = (*LookupNonUnique)(nil)
 )
 
 func init() {
@@ -51,6 +53,12 @@ func (ln *LookupNonUnique) String() string {
 	return ln.name
 }
 
+// LookupTable returns the lookup table and whether it is written in a
+// separate autocommit transaction.
+func (ln *LookupNonUnique) LookupTable() (string, bool) {
+	return ln.lkp.Table, ln.lkp.Autocommit
+}
+
 // Cost returns the cost of this vindex as 20.
 func (ln *LookupNonUnique) Cost() int {
 	return 20
@@ -214,6 +222,12 @@ func (lu *LookupUnique) String() string {
 	return lu.name
 }
 
+// LookupTable returns the lookup table and whether it is written in a
+// separate autocommit transaction.
+func (lu *LookupUnique) LookupTable() (string, bool) {
+	return lu.lkp.Table, lu.lkp.Autocommit
+}
+
 // Cost returns the cost of this vindex as 10.
 func (lu *LookupUnique) Cost() int {
 	return 10
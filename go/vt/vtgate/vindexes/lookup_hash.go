@@ -29,10 +29,12 @@ import (
 )
 
 var (
-	_ SingleColumn = (*LookupHash)(nil)
-	_ Lookup       = (*LookupHash)(nil)
-	_ SingleColumn = (*LookupHashUnique)(nil)
-	_ Lookup       = (*LookupHashUnique)(nil)
+	_ SingleColumn      = (*LookupHash)(nil)
+	_ Lookup            = (*LookupHash)(nil)
+	_ LookupTableVindex = (*LookupHash)(nil)
+	_ SingleColumn      = (*LookupHashUnique)(nil)
+	_ Lookup            = (*LookupHashUnique)(nil)
+	_ LookupTableVindex = (*LookupHashUnique)(nil)
 )
 
 func init() {
@@ -85,6 +87,12 @@ func (lh *LookupHash) String() string {
 	return lh.name
 }
 
+// LookupTable returns the lookup table and whether it is written in a
+// separate autocommit transaction.
+func (lh *LookupHash) LookupTable() (string, bool) {
+	return lh.lkp.Table, lh.lkp.Autocommit
+}
+
 // Cost returns the cost of this vindex as 20.
 func (lh *LookupHash) Cost() int {
 	return 20
@@ -249,6 +257,12 @@ func (lhu *LookupHashUnique) String() string {
 	return lhu.name
 }
 
+// LookupTable returns the lookup table and whether it is written in a
+// separate autocommit transaction.
+func (lhu *LookupHashUnique) LookupTable() (string, bool) {
+	return lhu.lkp.Table, lhu.lkp.Autocommit
+}
+
 // Cost returns the cost of this vindex as 10.
 func (lhu *LookupHashUnique) Cost() int {
 	return 10
@@ -30,10 +30,12 @@ import (
 )
 
 var (
-	_ SingleColumn = (*LookupUnicodeLooseMD5Hash)(nil)
-	_ Lookup       = (*LookupUnicodeLooseMD5Hash)(nil)
-	_ SingleColumn = (*LookupUnicodeLooseMD5HashUnique)(nil)
-	_ Lookup       = (*LookupUnicodeLooseMD5HashUnique)(nil)
+	_ SingleColumn      = (*LookupUnicodeLooseMD5Hash)(nil)
+	_ Lookup            = (*LookupUnicodeLooseMD5Hash)(nil)
+	_ LookupTableVindex = (*LookupUnicodeLooseMD5Hash)(nil)
+	_ SingleColumn      = (*LookupUnicodeLooseMD5HashUnique)(nil)
+	_ Lookup            = (*LookupUnicodeLooseMD5HashUnique)(nil)
+	_ LookupTableVindex = (*LookupUnicodeLooseMD5HashUnique)(nil)
 )
 
 func init() {
@@ -86,6 +88,12 @@ func (lh *LookupUnicodeLooseMD5Hash) String() string {
 	return lh.name
 }
 
+// LookupTable returns the lookup table and whether it is written in a
+// separate autocommit transaction.
+func (lh *LookupUnicodeLooseMD5Hash) LookupTable() (string, bool) {
+	return lh.lkp.Table, lh.lkp.Autocommit
+}
+
 // Cost returns the cost of this vindex as 20.
 func (lh *LookupUnicodeLooseMD5Hash) Cost() int {
 	return 20
@@ -261,6 +269,12 @@ func (lhu *LookupUnicodeLooseMD5HashUnique) String() string {
 	return lhu.name
 }
 
+// LookupTable returns the lookup table and whether it is written in a
+// separate autocommit transaction.
+func (lhu *LookupUnicodeLooseMD5HashUnique) LookupTable() (string, bool) {
+	return lhu.lkp.Table, lhu.lkp.Autocommit
+}
+
 // Cost returns the cost of this vindex as 10.
 func (lhu *LookupUnicodeLooseMD5HashUnique) Cost() int {
 	return 10
@@ -0,0 +1,125 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vindexes
+
+import (
+	"fmt"
+	"sort"
+
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+)
+
+// A routing rule can split the traffic of a table between several tables,
+// e.g. to progressively switch the reads of a MoveTables workflow, when
+// its targets have weights:
+//   "from_table": "t@replica", "to_tables": ["source.t", "target.t"], "weights": [90, 10]
+// The weights are percentages adding up to 100. Each query picks a random
+// point in [0, 100), and all the weighted rules route it to the table
+// whose weight range contains that point. The points are grouped by the
+// boundaries of the weights, so a query only has as many plans as there
+// are ranges between the boundaries.
+
+// routingRuleWeights validates the weights of a weighted routing rule.
+func routingRuleWeights(rule *vschemapb.RoutingRule) ([]int, error) {
+	if len(rule.Weights) != len(rule.ToTables) {
+		return nil, fmt.Errorf("table %v has %d weights for %d targets: %v", rule.FromTable, len(rule.Weights), len(rule.ToTables), rule.ToTables)
+	}
+	weights := make([]int, 0, len(rule.Weights))
+	total := 0
+	for i, weight := range rule.Weights {
+		if weight < 0 || weight > 100 {
+			return nil, fmt.Errorf("invalid weight %d of routing target %s: it must be between 0 and 100", weight, rule.ToTables[i])
+		}
+		weights = append(weights, int(weight))
+		total += int(weight)
+	}
+	if total != 100 {
+		return nil, fmt.Errorf("the weights of routing targets %v add up to %d, not 100", rule.ToTables, total)
+	}
+	return weights, nil
+}
+
+// buildTrafficSplits collects the boundaries and the targets of the
+// weighted routing rules.
+func buildTrafficSplits(vschema *VSchema) {
+	bounds := make(map[int]bool)
+	for _, rr := range vschema.RoutingRules {
+		if rr.Error != nil || rr.Weights == nil {
+			continue
+		}
+		cumulative := 0
+		for i, weight := range rr.Weights {
+			cumulative += weight
+			if cumulative > 0 && cumulative < 100 {
+				bounds[cumulative] = true
+			}
+			if weight > 0 {
+				if vschema.trafficSplitTables == nil {
+					vschema.trafficSplitTables = make(map[string]bool)
+				}
+				t := rr.Tables[i]
+				vschema.trafficSplitTables[t.Keyspace.Name+"."+t.Name.String()] = true
+			}
+		}
+	}
+	for bound := range bounds {
+		vschema.trafficSplitBounds = append(vschema.trafficSplitBounds, bound)
+	}
+	sort.Ints(vschema.trafficSplitBounds)
+}
+
+// HasTrafficSplits returns true if some routing rules split the traffic
+// between several tables.
+func (vschema *VSchema) HasTrafficSplits() bool {
+	return vschema != nil && len(vschema.trafficSplitBounds) > 0
+}
+
+// TrafficSplitPoint returns the traffic split point of a random number n
+// in [0, 100): the lower boundary of the range of n between the boundaries
+// of the weights of the routing rules. All the numbers of a range pick the
+// same tables.
+func (vschema *VSchema) TrafficSplitPoint(n int) int {
+	point := 0
+	for _, bound := range vschema.trafficSplitBounds {
+		if n < bound {
+			break
+		}
+		point = bound
+	}
+	return point
+}
+
+// IsTrafficSplitTable returns true if the table is a target of a routing
+// rule splitting the traffic.
+func (vschema *VSchema) IsTrafficSplitTable(keyspace, tablename string) bool {
+	return vschema != nil && vschema.trafficSplitTables[keyspace+"."+tablename]
+}
+
+// tableAtSplit returns the table of the rule at the traffic split point.
+func (rr *RoutingRule) tableAtSplit(point int) *Table {
+	if rr.Weights == nil {
+		return rr.Tables[0]
+	}
+	cumulative := 0
+	for i, weight := range rr.Weights {
+		cumulative += weight
+		if point < cumulative {
+			return rr.Tables[i]
+		}
+	}
+	return rr.Tables[len(rr.Tables)-1]
+}
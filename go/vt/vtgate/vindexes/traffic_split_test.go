@@ -0,0 +1,107 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vindexes
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+)
+
+func TestWeightedRoutingRules(t *testing.T) {
+	input := &vschemapb.SrvVSchema{
+		RoutingRules: &vschemapb.RoutingRules{
+			Rules: []*vschemapb.RoutingRule{{
+				FromTable: "t1@replica",
+				ToTables:  []string{"source.t1", "target.t1"},
+				Weights:   []int32{90, 10},
+			}, {
+				FromTable: "t2@replica",
+				ToTables:  []string{"source.t2", "target.t2"},
+				Weights:   []int32{50, 50},
+			}, {
+				FromTable: "t1@rdonly",
+				ToTables:  []string{"source.t1", "target.t1"},
+				Weights:   []int32{100, 0},
+			}, {
+				FromTable: "badtotal",
+				ToTables:  []string{"source.t1", "target.t1"},
+				Weights:   []int32{90, 20},
+			}, {
+				FromTable: "badweight",
+				ToTables:  []string{"source.t1", "target.t1"},
+				Weights:   []int32{-10, 110},
+			}, {
+				FromTable: "someweights",
+				ToTables:  []string{"source.t1", "target.t1"},
+				Weights:   []int32{100},
+			}, {
+				FromTable: "noweights",
+				ToTables:  []string{"source.t1", "target.t1"},
+			}},
+		},
+		Keyspaces: map[string]*vschemapb.Keyspace{
+			"source": {
+				Tables: map[string]*vschemapb.Table{"t1": {}, "t2": {}},
+			},
+			"target": {
+				Tables: map[string]*vschemapb.Table{"t1": {}, "t2": {}},
+			},
+		},
+	}
+	vschema := BuildVSchema(input)
+
+	for _, name := range []string{"badtotal", "badweight", "someweights", "noweights"} {
+		assert.Error(t, vschema.RoutingRules[name].Error, name)
+	}
+	assert.True(t, vschema.HasTrafficSplits())
+	assert.True(t, vschema.IsTrafficSplitTable("target", "t1"))
+	assert.True(t, vschema.IsTrafficSplitTable("source", "t2"))
+	assert.False(t, vschema.IsTrafficSplitTable("target", "t3"))
+
+	// The boundaries are 50 and 90.
+	for n, want := range map[int]int{0: 0, 49: 0, 50: 50, 89: 50, 90: 90, 99: 90} {
+		assert.Equal(t, want, vschema.TrafficSplitPoint(n), "point of %d", n)
+	}
+
+	find := func(table string, tabletType topodatapb.TabletType, point int) string {
+		t.Helper()
+		got, err := vschema.FindRoutedTableAtSplit("", table, tabletType, point)
+		require.NoError(t, err)
+		return got.Keyspace.Name
+	}
+	assert.Equal(t, "source", find("t1", topodatapb.TabletType_REPLICA, 0))
+	assert.Equal(t, "source", find("t1", topodatapb.TabletType_REPLICA, 50))
+	assert.Equal(t, "target", find("t1", topodatapb.TabletType_REPLICA, 90))
+	assert.Equal(t, "source", find("t2", topodatapb.TabletType_REPLICA, 0))
+	assert.Equal(t, "target", find("t2", topodatapb.TabletType_REPLICA, 50))
+	assert.Equal(t, "source", find("t1", topodatapb.TabletType_RDONLY, 90))
+
+	// Without a split point, the first table is used.
+	got, err := vschema.FindRoutedTable("", "t1", topodatapb.TabletType_REPLICA)
+	require.NoError(t, err)
+	assert.Equal(t, "source", got.Keyspace.Name)
+
+	data, err := json.Marshal(vschema.RoutingRules["t1@replica"])
+	require.NoError(t, err)
+	assert.JSONEq(t, `["source.t1:90","target.t1:10"]`, string(data))
+}
@@ -128,6 +128,16 @@ type Lookup interface {
 	Update(vc VCursor, oldValues []sqltypes.Value, ksid []byte, newValues []sqltypes.Value) error
 }
 
+// LookupTableVindex defines the interface that a vindex which stores its
+// map in a lookup table must satisfy. It lets vtgate know which keyspaces
+// the owner's DMLs touch.
+type LookupTableVindex interface {
+	// LookupTable returns the lookup table, qualified with its keyspace if
+	// it was in the vindex params, and whether it is written in a separate
+	// autocommit transaction.
+	LookupTable() (table string, autocommit bool)
+}
+
 // WantOwnerInfo defines the interface that a vindex must
 // satisfy to request info about the owner table. This information can
 // be used to query the owner's table for the owning row's presence.
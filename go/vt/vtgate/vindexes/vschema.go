@@ -29,10 +29,12 @@ import (
 	"vitess.io/vitess/go/json2"
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/topo/topoproto"
 
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
 )
 
 // TabletTypeSuffix maps the tablet type to its suffix string.
@@ -61,12 +63,23 @@ type VSchema struct {
 	uniqueTables   map[string]*Table
 	uniqueVindexes map[string]Vindex
 	Keyspaces      map[string]*KeyspaceSchema `json:"keyspaces"`
+
+	// trafficSplitBounds are the sorted boundaries between the weights
+	// of the weighted routing rules, see TrafficSplitPoint.
+	trafficSplitBounds []int
+	// trafficSplitTables are the keyspace.table targets of the weighted
+	// routing rules.
+	trafficSplitTables map[string]bool
 }
 
 // RoutingRule represents one routing rule.
 type RoutingRule struct {
 	Tables []*Table
-	Error  error
+	// Weights are the percentages of the queries routed to each of
+	// Tables, when the rule splits the traffic between several tables.
+	// They add up to 100.
+	Weights []int
+	Error   error
 }
 
 // MarshalJSON returns a JSON representation of Column.
@@ -75,8 +88,12 @@ func (rr *RoutingRule) MarshalJSON() ([]byte, error) {
 		return json.Marshal(rr.Error.Error())
 	}
 	tables := make([]string, 0, len(rr.Tables))
-	for _, t := range rr.Tables {
-		tables = append(tables, t.Keyspace.Name+"."+t.Name.String())
+	for i, t := range rr.Tables {
+		table := t.Keyspace.Name + "." + t.Name.String()
+		if rr.Weights != nil {
+			table = fmt.Sprintf("%s:%d", table, rr.Weights[i])
+		}
+		tables = append(tables, table)
 	}
 	return json.Marshal(tables)
 }
@@ -93,6 +110,8 @@ type Table struct {
 	Columns                 []Column             `json:"columns,omitempty"`
 	Pinned                  []byte               `json:"pinned,omitempty"`
 	ColumnListAuthoritative bool                 `json:"column_list_authoritative,omitempty"`
+	QueryTimeoutMs          int64                `json:"query_timeout_ms,omitempty"`
+	CacheTTLMs              int64                `json:"cache_ttl_ms,omitempty"`
 }
 
 // Keyspace contains the keyspcae info for each Table.
@@ -129,23 +148,35 @@ func (col *Column) MarshalJSON() ([]byte, error) {
 
 // KeyspaceSchema contains the schema(table) for a keyspace.
 type KeyspaceSchema struct {
-	Keyspace *Keyspace
-	Tables   map[string]*Table
-	Vindexes map[string]Vindex
-	Error    error
+	Keyspace      *Keyspace
+	Tables        map[string]*Table
+	Vindexes      map[string]Vindex
+	QueryTimeouts *vschemapb.QueryTimeouts
+	// TransactionMode is the default transaction mode of the keyspace.
+	TransactionMode vtgatepb.TransactionMode
+	Error           error
 }
 
 // MarshalJSON returns a JSON representation of KeyspaceSchema.
 func (ks *KeyspaceSchema) MarshalJSON() ([]byte, error) {
 	return json.Marshal(struct {
-		Sharded  bool              `json:"sharded,omitempty"`
-		Tables   map[string]*Table `json:"tables,omitempty"`
-		Vindexes map[string]Vindex `json:"vindexes,omitempty"`
-		Error    string            `json:"error,omitempty"`
+		Sharded         bool                     `json:"sharded,omitempty"`
+		Tables          map[string]*Table        `json:"tables,omitempty"`
+		Vindexes        map[string]Vindex        `json:"vindexes,omitempty"`
+		QueryTimeouts   *vschemapb.QueryTimeouts `json:"query_timeouts,omitempty"`
+		TransactionMode string                   `json:"transaction_mode,omitempty"`
+		Error           string                   `json:"error,omitempty"`
 	}{
-		Sharded:  ks.Keyspace.Sharded,
-		Tables:   ks.Tables,
-		Vindexes: ks.Vindexes,
+		Sharded:       ks.Keyspace.Sharded,
+		Tables:        ks.Tables,
+		Vindexes:      ks.Vindexes,
+		QueryTimeouts: ks.QueryTimeouts,
+		TransactionMode: func(ks *KeyspaceSchema) string {
+			if ks.TransactionMode == vtgatepb.TransactionMode_UNSPECIFIED {
+				return ""
+			}
+			return ks.TransactionMode.String()
+		}(ks),
 		Error: func(ks *KeyspaceSchema) string {
 			if ks.Error == nil {
 				return ""
@@ -173,6 +204,7 @@ func BuildVSchema(source *vschemapb.SrvVSchema) (vschema *VSchema) {
 	resolveAutoIncrement(source, vschema)
 	addDual(vschema)
 	buildRoutingRule(source, vschema)
+	buildTrafficSplits(vschema)
 	return vschema
 }
 
@@ -212,8 +244,10 @@ func buildKeyspaces(source *vschemapb.SrvVSchema, vschema *VSchema) {
 				Name:    ksname,
 				Sharded: ks.Sharded,
 			},
-			Tables:   make(map[string]*Table),
-			Vindexes: make(map[string]Vindex),
+			Tables:          make(map[string]*Table),
+			Vindexes:        make(map[string]Vindex),
+			QueryTimeouts:   ks.QueryTimeouts,
+			TransactionMode: ks.TransactionMode,
 		}
 		vschema.Keyspaces[ksname] = ksvschema
 		ksvschema.Error = buildTables(ks, vschema, ksvschema)
@@ -222,6 +256,19 @@ func buildKeyspaces(source *vschemapb.SrvVSchema, vschema *VSchema) {
 
 func buildTables(ks *vschemapb.Keyspace, vschema *VSchema, ksvschema *KeyspaceSchema) error {
 	keyspace := ksvschema.Keyspace
+	if qt := ks.QueryTimeouts; qt != nil {
+		if qt.DefaultMs < 0 {
+			return fmt.Errorf("negative query timeout for keyspace %s: %d", keyspace.Name, qt.DefaultMs)
+		}
+		for name, ms := range qt.TabletTypesMs {
+			if _, err := topoproto.ParseTabletType(name); err != nil {
+				return fmt.Errorf("invalid tablet type in the query timeouts of keyspace %s: %v", keyspace.Name, err)
+			}
+			if ms < 0 {
+				return fmt.Errorf("negative query timeout for tablet type %s of keyspace %s: %d", name, keyspace.Name, ms)
+			}
+		}
+	}
 	for vname, vindexInfo := range ks.Vindexes {
 		vindex, err := CreateVindex(vindexInfo.Type, vname, vindexInfo.Params)
 		if err != nil {
@@ -243,6 +290,14 @@ func buildTables(ks *vschemapb.Keyspace, vschema *VSchema, ksvschema *KeyspaceSc
 			Name:                    sqlparser.NewTableIdent(tname),
 			Keyspace:                keyspace,
 			ColumnListAuthoritative: table.ColumnListAuthoritative,
+			QueryTimeoutMs:          table.QueryTimeoutMs,
+			CacheTTLMs:              table.CacheTtlMs,
+		}
+		if t.QueryTimeoutMs < 0 {
+			return fmt.Errorf("negative query timeout for table %s: %d", tname, t.QueryTimeoutMs)
+		}
+		if t.CacheTTLMs < 0 {
+			return fmt.Errorf("negative cache ttl for table %s: %d", tname, t.CacheTTLMs)
 		}
 		switch table.Type {
 		case "", TypeReference:
@@ -403,7 +458,18 @@ func buildRoutingRule(source *vschemapb.SrvVSchema, vschema *VSchema) {
 outer:
 	for _, rule := range source.RoutingRules.Rules {
 		rr := &RoutingRule{}
-		if len(rule.ToTables) > 1 {
+		switch {
+		case len(rule.Weights) > 0:
+			weights, err := routingRuleWeights(rule)
+			if err != nil {
+				vschema.RoutingRules[rule.FromTable] = &RoutingRule{
+					Error: err,
+				}
+				continue
+			}
+			rr.Weights = weights
+		case len(rule.ToTables) > 1:
+			// Several targets must have weights.
 			vschema.RoutingRules[rule.FromTable] = &RoutingRule{
 				Error: fmt.Errorf("table %v has more than one target: %v", rule.FromTable, rule.ToTables),
 			}
@@ -498,6 +564,13 @@ func (vschema *VSchema) findTable(keyspace, tablename string) (*Table, error) {
 
 // FindRoutedTable finds a table checking the routing rules.
 func (vschema *VSchema) FindRoutedTable(keyspace, tablename string, tabletType topodatapb.TabletType) (*Table, error) {
+	return vschema.FindRoutedTableAtSplit(keyspace, tablename, tabletType, 0)
+}
+
+// FindRoutedTableAtSplit is like FindRoutedTable, but picks the table of
+// the weighted routing rules at the traffic split point, see
+// TrafficSplitPoint.
+func (vschema *VSchema) FindRoutedTableAtSplit(keyspace, tablename string, tabletType topodatapb.TabletType, point int) (*Table, error) {
 	qualified := tablename
 	if keyspace != "" {
 		qualified = keyspace + "." + tablename
@@ -514,7 +587,7 @@ func (vschema *VSchema) FindRoutedTable(keyspace, tablename string, tabletType t
 			if len(rr.Tables) == 0 {
 				return nil, fmt.Errorf("table %s has been disabled", tablename)
 			}
-			return rr.Tables[0], nil
+			return rr.tableAtSplit(point), nil
 		}
 	}
 	return vschema.findTable(keyspace, tablename)
@@ -522,10 +595,32 @@ func (vschema *VSchema) FindRoutedTable(keyspace, tablename string, tabletType t
 
 // FindTableOrVindex finds a table or a Vindex by name using Find and FindVindex.
 func (vschema *VSchema) FindTableOrVindex(keyspace, name string, tabletType topodatapb.TabletType) (*Table, Vindex, error) {
-	tables, err := vschema.FindRoutedTable(keyspace, name, tabletType)
+	return vschema.FindTableOrVindexAtSplit(keyspace, name, tabletType, 0)
+}
+
+// FindTableOrVindexAtSplit is like FindTableOrVindex, but picks the table
+// of the weighted routing rules at the traffic split point.
+func (vschema *VSchema) FindTableOrVindexAtSplit(keyspace, name string, tabletType topodatapb.TabletType, point int) (*Table, Vindex, error) {
+	tables, err := vschema.FindRoutedTableAtSplit(keyspace, name, tabletType, point)
 	if err != nil {
 		return nil, nil, err
 	}
+	return vschema.tableOrVindex(tables, keyspace, name)
+}
+
+// FindUnroutedTableOrVindex is like FindTableOrVindex, but bypasses the
+// routing rules.
+func (vschema *VSchema) FindUnroutedTableOrVindex(keyspace, name string, tabletType topodatapb.TabletType) (*Table, Vindex, error) {
+	tables, err := vschema.findTable(keyspace, name)
+	if err != nil {
+		return nil, nil, err
+	}
+	return vschema.tableOrVindex(tables, keyspace, name)
+}
+
+// tableOrVindex returns the table if there is one, or the vindex of the
+// name.
+func (vschema *VSchema) tableOrVindex(tables *Table, keyspace, name string) (*Table, Vindex, error) {
 	if tables != nil {
 		return tables, nil, nil
 	}
@@ -2340,6 +2340,21 @@ func TestFindTableOrVindex(t *testing.T) {
 	if err == nil || err.Error() != wantErr {
 		t.Errorf("FindTableOrVindex(\"\"): %v, want %s", err, wantErr)
 	}
+
+	// The routing rules are bypassed.
+	_, _, err = vschema.FindUnroutedTableOrVindex("", "unqualified", topodatapb.TabletType_MASTER)
+	wantErr = "table unqualified not found"
+	if err == nil || err.Error() != wantErr {
+		t.Errorf("FindUnroutedTableOrVindex(unqualified): %v, want %s", err, wantErr)
+	}
+
+	got, _, err = vschema.FindUnroutedTableOrVindex("ksb", "t1", topodatapb.TabletType_REPLICA)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := t1; !reflect.DeepEqual(got, want) {
+		t.Errorf("FindUnroutedTableOrVindex(ksb.t1): %+v, want %+v", got, want)
+	}
 }
 
 func TestBuildKeyspaceSchema(t *testing.T) {
@@ -2415,6 +2430,58 @@ func TestValidate(t *testing.T) {
 	}
 }
 
+func TestValidateQueryTimeouts(t *testing.T) {
+	good := &vschemapb.Keyspace{
+		QueryTimeouts: &vschemapb.QueryTimeouts{
+			DefaultMs:     1000,
+			TabletTypesMs: map[string]int64{"rdonly": 60000},
+		},
+		Tables: map[string]*vschemapb.Table{
+			"t1": {QueryTimeoutMs: 30000},
+		},
+	}
+	ks, err := BuildKeyspaceSchema(good, "ks")
+	require.NoError(t, err)
+	assert.Equal(t, good.QueryTimeouts, ks.QueryTimeouts)
+	assert.EqualValues(t, 30000, ks.Tables["t1"].QueryTimeoutMs)
+
+	testcases := []struct {
+		in   *vschemapb.Keyspace
+		want string
+	}{{
+		in:   &vschemapb.Keyspace{QueryTimeouts: &vschemapb.QueryTimeouts{DefaultMs: -1}},
+		want: "negative query timeout for keyspace ks: -1",
+	}, {
+		in:   &vschemapb.Keyspace{QueryTimeouts: &vschemapb.QueryTimeouts{TabletTypesMs: map[string]int64{"nosuchtype": 1000}}},
+		want: "invalid tablet type in the query timeouts of keyspace ks",
+	}, {
+		in:   &vschemapb.Keyspace{QueryTimeouts: &vschemapb.QueryTimeouts{TabletTypesMs: map[string]int64{"replica": -1}}},
+		want: "negative query timeout for tablet type replica of keyspace ks: -1",
+	}, {
+		in:   &vschemapb.Keyspace{Tables: map[string]*vschemapb.Table{"t1": {QueryTimeoutMs: -1}}},
+		want: "negative query timeout for table t1: -1",
+	}}
+	for _, tcase := range testcases {
+		_, err := BuildKeyspaceSchema(tcase.in, "ks")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), tcase.want)
+	}
+}
+
+func TestValidateCacheTTL(t *testing.T) {
+	ks, err := BuildKeyspaceSchema(&vschemapb.Keyspace{
+		Tables: map[string]*vschemapb.Table{
+			"t1": {CacheTtlMs: 60000},
+		},
+	}, "ks")
+	require.NoError(t, err)
+	assert.EqualValues(t, 60000, ks.Tables["t1"].CacheTTLMs)
+
+	_, err = BuildKeyspaceSchema(&vschemapb.Keyspace{Tables: map[string]*vschemapb.Table{"t1": {CacheTtlMs: -1}}}, "ks")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "negative cache ttl for table t1: -1")
+}
+
 func TestVSchemaPBJSON(t *testing.T) {
 	in := `
 	{
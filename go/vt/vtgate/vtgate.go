@@ -20,6 +20,7 @@ package vtgate
 
 import (
 	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"net/http"
@@ -65,6 +66,7 @@ var (
 	_                    = flag.Bool("disable_local_gateway", false, "deprecated: if specified, this process will not route any queries to local tablets in the local cell")
 	maxMemoryRows        = flag.Int("max_memory_rows", 300000, "Maximum number of rows that will be held in memory for intermediate results as well as the final result.")
 	warnMemoryRows       = flag.Int("warn_memory_rows", 30000, "Warning threshold for in-memory results. A row count higher than this amount will cause the VtGateWarnings.ResultsExceeded counter to be incremented.")
+	groupConcatMaxLen    = flag.Uint64("group_concat_max_len", 1024, "Maximum length in bytes of the group_concat values merged across shards, for the sessions which don't set @@group_concat_max_len. It should match the group_concat_max_len of the MySQL servers.")
 	defaultDDLStrategy   = flag.String("ddl_strategy", string(schema.DDLStrategyDirect), "Set default strategy for DDL statements. Override with @@ddl_strategy session variable")
 	dbDDLPlugin          = flag.String("dbddl_plugin", "fail", "controls how to handle CREATE/DROP DATABASE. use it if you are using your own database provisioning service")
 
@@ -147,6 +149,9 @@ type VTGate struct {
 	// the throttled loggers for all errors, one per API entry
 	logExecute       *logutil.ThrottledLogger
 	logStreamExecute *logutil.ThrottledLogger
+
+	// warmer warms up the -warmup_keyspaces. It is nil if there are none.
+	warmer *keyspaceWarmer
 }
 
 // RegisterVTGate defines the type of registration mechanism.
@@ -178,6 +183,16 @@ func Init(ctx context.Context, serv srvtopo.Server, cell string, tabletTypesToWa
 		log.Fatalf("gateway.WaitForTablets failed: %v", err)
 	}
 
+	// The query quotas, serving flags, replication lag thresholds and cell
+	// serving overrides are read from the topo server, which is not
+	// available through the filtering server below.
+	keyspaceSettings := newKeyspaceSettingsLoader(serv)
+	queryQuotas := startQueryQuotas(ctx, serv)
+	servingFlags := startKeyspaceServingFlags(keyspaceSettings)
+	startReplicationLagThresholds(keyspaceSettings, gw.hc)
+	startCellServingOverrides(keyspaceSettings, gw)
+	keyspaceSettings.start(ctx)
+
 	// If we want to filter keyspaces replace the srvtopo.Server with a
 	// filtering server
 	if discovery.FilteringKeyspaces() {
@@ -198,6 +213,7 @@ func Init(ctx context.Context, serv srvtopo.Server, cell string, tabletTypesToWa
 	srvResolver := srvtopo.NewResolver(serv, gw, cell)
 	resolver := NewResolver(srvResolver, serv, cell, sc)
 	vsm := newVStreamManager(srvResolver, serv, cell)
+	resultCache := startResultCache(ctx, vsm)
 
 	var si SchemaInfo = nil
 	var st *vtschema.Tracker
@@ -214,6 +230,21 @@ func Init(ctx context.Context, serv srvtopo.Server, cell string, tabletTypesToWa
 	}
 
 	executor := NewExecutor(ctx, serv, cell, resolver, *normalizeQueries, *warnShardedOnly, *streamBufferSize, cacheCfg, si)
+	tc.vschema = executor.VSchema
+	executor.timeouts = initQueryTimeoutPolicy()
+	executor.quotas = queryQuotas
+	executor.servingFlags = servingFlags
+	executor.anomalies = startQueryAnomalyDetector(ctx)
+	if resultCache != nil {
+		executor.setResultCache(resultCache)
+	}
+
+	if *enablePartitionWatch {
+		pw := newPartitionWatcher(ctx, serv, cell)
+		pw.subscribe(executor.onPartitionChange)
+		pw.subscribe(gw.onPartitionChange)
+		executor.setPartitionWatcher(pw)
+	}
 
 	// connect the schema tracker with the vschema manager
 	if *enableSchemaChangeSignal {
@@ -244,6 +275,10 @@ func Init(ctx context.Context, serv srvtopo.Server, cell string, tabletTypesToWa
 		logExecute:       logutil.NewThrottledLogger("Execute", 5*time.Second),
 		logStreamExecute: logutil.NewThrottledLogger("StreamExecute", 5*time.Second),
 	}
+	if *warmupKeyspaces != "" {
+		rpcVTGate.warmer = newKeyspaceWarmer(serv, cell, executor, gw.hc, tabletTypesToWait)
+		go rpcVTGate.warmer.run(ctx)
+	}
 
 	errorCounts = stats.NewCountersWithMultiLabels("VtgateApiErrorCounts", "Vtgate API error counts per error type", []string{"Operation", "Keyspace", "DbType", "Code"})
 
@@ -255,6 +290,7 @@ func Init(ctx context.Context, serv srvtopo.Server, cell string, tabletTypesToWa
 	_ = stats.NewRates("ErrorsByKeyspace", stats.CounterForDimension(errorCounts, "Keyspace"), 15, 1*time.Minute)
 	_ = stats.NewRates("ErrorsByDbType", stats.CounterForDimension(errorCounts, "DbType"), 15, 1*time.Minute)
 	_ = stats.NewRates("ErrorsByCode", stats.CounterForDimension(errorCounts, "Code"), 15, 1*time.Minute)
+	publishRateWindows(sc)
 
 	warnings = stats.NewCountersWithSingleLabel("VtGateWarnings", "Vtgate warnings", "type", "IgnoredSet", "ResultsExceeded", "WarnPayloadSizeExceeded")
 
@@ -273,6 +309,10 @@ func Init(ctx context.Context, serv srvtopo.Server, cell string, tabletTypesToWa
 	})
 	rpcVTGate.registerDebugHealthHandler()
 	rpcVTGate.registerDebugEnvHandler()
+	rpcVTGate.registerDebugBufferHandler()
+	rpcVTGate.registerDebugCellsToWatchHandler()
+	rpcVTGate.reloadCellsToWatchOnSIGHUP()
+	rpcVTGate.registerDebugWarmupHandler()
 	err := initQueryLogger(rpcVTGate)
 	if err != nil {
 		log.Fatalf("error initializing query logger: %v", err)
@@ -342,10 +382,29 @@ func (vtg *VTGate) registerDebugHealthHandler() {
 	})
 }
 
+// registerDebugBufferHandler exposes the state of the MASTER failover buffer
+// as JSON, so that failover automation can wait for the buffer to drain
+// before declaring a reparent complete.
+func (vtg *VTGate) registerDebugBufferHandler() {
+	http.HandleFunc("/debug/buffer", func(w http.ResponseWriter, r *http.Request) {
+		if err := acl.CheckAccessHTTP(r, acl.MONITORING); err != nil {
+			acl.SendError(w, err)
+			return
+		}
+		data, err := json.MarshalIndent(vtg.gw.BufferStatus(), "", "  ")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Write(data)
+	})
+}
+
 // IsHealthy returns nil if server is healthy.
 // Otherwise, it returns an error indicating the reason.
 func (vtg *VTGate) IsHealthy() error {
-	return nil
+	return vtg.warmer.ready()
 }
 
 // Gateway returns the current gateway implementation. Mostly used for tests.
@@ -458,6 +517,12 @@ func (vtg *VTGate) CloseSession(ctx context.Context, session *vtgatepb.Session)
 	return vtg.executor.CloseSession(ctx, NewSafeSession(session))
 }
 
+// GetBufferStatus returns the state of the MASTER failover buffer, as shown
+// on /debug/buffer.
+func (vtg *VTGate) GetBufferStatus(ctx context.Context) (*vtgatepb.GetBufferStatusResponse, error) {
+	return vtg.gw.BufferStatus().Proto(), nil
+}
+
 // ResolveTransaction resolves the specified 2PC transaction.
 func (vtg *VTGate) ResolveTransaction(ctx context.Context, dtid string) error {
 	return formatError(vtg.txConn.Resolve(ctx, dtid))
@@ -649,6 +714,7 @@ func LegacyInit(ctx context.Context, hc discovery.LegacyHealthCheck, serv srvtop
 	_ = stats.NewRates("ErrorsByKeyspace", stats.CounterForDimension(errorCounts, "Keyspace"), 15, 1*time.Minute)
 	_ = stats.NewRates("ErrorsByDbType", stats.CounterForDimension(errorCounts, "DbType"), 15, 1*time.Minute)
 	_ = stats.NewRates("ErrorsByCode", stats.CounterForDimension(errorCounts, "Code"), 15, 1*time.Minute)
+	publishRateWindows(sc)
 
 	warnings = stats.NewCountersWithSingleLabel("VtGateWarnings", "Vtgate warnings", "type", "IgnoredSet", "ResultsExceeded")
 
@@ -658,6 +724,8 @@ func LegacyInit(ctx context.Context, hc discovery.LegacyHealthCheck, serv srvtop
 		}
 	})
 	rpcVTGate.registerDebugHealthHandler()
+	rpcVTGate.registerDebugCellsToWatchHandler()
+	rpcVTGate.reloadCellsToWatchOnSIGHUP()
 	err := initQueryLogger(rpcVTGate)
 	if err != nil {
 		log.Fatalf("error initializing query logger: %v", err)
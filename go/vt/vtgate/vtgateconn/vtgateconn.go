@@ -60,6 +60,11 @@ func (conn *VTGateConn) ResolveTransaction(ctx context.Context, dtid string) err
 	return conn.impl.ResolveTransaction(ctx, dtid)
 }
 
+// GetBufferStatus returns the state of the MASTER failover buffer of vtgate.
+func (conn *VTGateConn) GetBufferStatus(ctx context.Context) (*vtgatepb.GetBufferStatusResponse, error) {
+	return conn.impl.GetBufferStatus(ctx)
+}
+
 // Close must be called for releasing resources.
 func (conn *VTGateConn) Close() {
 	conn.impl.Close()
@@ -147,6 +152,9 @@ type Impl interface {
 	// ResolveTransaction resolves the specified 2pc transaction.
 	ResolveTransaction(ctx context.Context, dtid string) error
 
+	// GetBufferStatus returns the state of the MASTER failover buffer.
+	GetBufferStatus(ctx context.Context) (*vtgatepb.GetBufferStatusResponse, error)
+
 	// VStream streams binlogevents
 	VStream(ctx context.Context, tabletType topodatapb.TabletType, vgtid *binlogdatapb.VGtid, filter *binlogdatapb.Filter, flags *vtgatepb.VStreamFlags) (VStreamReader, error)
 
@@ -44,6 +44,9 @@ type VTGateService interface {
 	// but does not affect the query statistics.
 	CloseSession(ctx context.Context, session *vtgatepb.Session) error
 
+	// GetBufferStatus returns the state of the MASTER failover buffer.
+	GetBufferStatus(ctx context.Context) (*vtgatepb.GetBufferStatusResponse, error)
+
 	// 2PC support
 	ResolveTransaction(ctx context.Context, dtid string) error
 
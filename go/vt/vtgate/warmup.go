@@ -0,0 +1,304 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/acl"
+	"vitess.io/vitess/go/vt/discovery"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/srvtopo"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+var (
+	warmupKeyspaces = flag.String("warmup_keyspaces", "", "Comma separated list of the keyspaces warmed up when vtgate starts, or 'all': their SrvKeyspace and vschema are loaded, and their serving tablets of the -tablet_types_to_wait types are waited for. /debug/health reports vtgate as not ready until the warm-up is over, and /debug/warmup reports the readiness of each keyspace.")
+	warmupTimeout   = flag.Duration("warmup_timeout", 30*time.Second, "How long the warm-up of the -warmup_keyspaces lasts at most. The keyspaces which aren't warmed up by then are loaded on their first query.")
+)
+
+// warmupRetryInterval is the interval at which the warm-up of a keyspace
+// is retried until it succeeds.
+const warmupRetryInterval = 500 * time.Millisecond
+
+// KeyspaceWarmup is the warm-up of a keyspace.
+type KeyspaceWarmup struct {
+	Keyspace string `json:"keyspace"`
+	Ready    bool   `json:"ready"`
+	// Error is why the keyspace isn't ready yet.
+	Error string `json:"error,omitempty"`
+	// Seconds is how long the warm-up took, once ready.
+	Seconds float64 `json:"seconds,omitempty"`
+}
+
+// keyspaceWarmer warms up the keyspaces when vtgate starts, so that their
+// first queries don't pay for the loading of their metadata and the
+// discovery of their tablets.
+type keyspaceWarmer struct {
+	serv        srvtopo.Server
+	cell        string
+	executor    *Executor
+	hc          discovery.HealthCheck
+	tabletTypes []topodatapb.TabletType
+
+	mu        sync.Mutex
+	done      bool
+	keyspaces map[string]*KeyspaceWarmup
+}
+
+func newKeyspaceWarmer(serv srvtopo.Server, cell string, executor *Executor, hc discovery.HealthCheck, tabletTypes []topodatapb.TabletType) *keyspaceWarmer {
+	return &keyspaceWarmer{
+		serv:        serv,
+		cell:        cell,
+		executor:    executor,
+		hc:          hc,
+		tabletTypes: tabletTypes,
+		keyspaces:   make(map[string]*KeyspaceWarmup),
+	}
+}
+
+// run warms up the -warmup_keyspaces, concurrently, until they are all
+// ready or -warmup_timeout expires.
+func (w *keyspaceWarmer) run(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, *warmupTimeout)
+	defer cancel()
+	defer w.finish()
+
+	start := time.Now()
+	keyspaces, err := w.keyspacesToWarmUp(ctx)
+	if err != nil {
+		log.Warningf("Cannot warm up the keyspaces: %v", err)
+		return
+	}
+	w.mu.Lock()
+	for _, keyspace := range keyspaces {
+		w.keyspaces[keyspace] = &KeyspaceWarmup{Keyspace: keyspace, Error: "pending"}
+	}
+	w.mu.Unlock()
+
+	log.Infof("Warming up keyspaces %v", keyspaces)
+	var wg sync.WaitGroup
+	for _, keyspace := range keyspaces {
+		wg.Add(1)
+		go func(keyspace string) {
+			defer wg.Done()
+			w.warmUp(ctx, keyspace)
+		}(keyspace)
+	}
+	wg.Wait()
+	log.Infof("Keyspace warm-up over after %v", time.Since(start))
+}
+
+func (w *keyspaceWarmer) keyspacesToWarmUp(ctx context.Context) ([]string, error) {
+	if strings.TrimSpace(*warmupKeyspaces) != "all" {
+		var keyspaces []string
+		for _, keyspace := range strings.Split(*warmupKeyspaces, ",") {
+			if keyspace = strings.TrimSpace(keyspace); keyspace != "" {
+				keyspaces = append(keyspaces, keyspace)
+			}
+		}
+		return keyspaces, nil
+	}
+	keyspaces, err := w.serv.GetSrvKeyspaceNames(ctx, w.cell, false)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(keyspaces)
+	return keyspaces, nil
+}
+
+// warmUp retries the warm-up of the keyspace until it succeeds or the
+// context expires.
+func (w *keyspaceWarmer) warmUp(ctx context.Context, keyspace string) {
+	start := time.Now()
+	for {
+		err := w.warmUpOnce(ctx, keyspace)
+		w.mu.Lock()
+		kw := w.keyspaces[keyspace]
+		if err == nil {
+			kw.Ready = true
+			kw.Error = ""
+			kw.Seconds = time.Since(start).Seconds()
+		} else {
+			kw.Error = err.Error()
+		}
+		w.mu.Unlock()
+		if err == nil {
+			log.Infof("Keyspace %v warmed up in %v", keyspace, time.Since(start))
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Warningf("Keyspace %v not warmed up: %v", keyspace, err)
+			return
+		case <-time.After(warmupRetryInterval):
+		}
+	}
+}
+
+// warmUpOnce loads the SrvKeyspace of the keyspace, checks it is in the
+// vschema, and waits for its serving tablets.
+func (w *keyspaceWarmer) warmUpOnce(ctx context.Context, keyspace string) error {
+	srvKeyspace, err := w.serv.GetSrvKeyspace(ctx, w.cell, keyspace)
+	if err != nil {
+		return fmt.Errorf("cannot get the SrvKeyspace: %v", err)
+	}
+	vschema := w.executor.VSchema()
+	if vschema == nil {
+		return errors.New("the vschema isn't loaded yet")
+	}
+	if _, ok := vschema.Keyspaces[keyspace]; !ok {
+		return errors.New("the keyspace isn't in the vschema")
+	}
+
+	var targets []*querypb.Target
+	for _, partition := range srvKeyspace.Partitions {
+		if !warmupTabletType(w.tabletTypes, partition.ServedType) {
+			continue
+		}
+		for _, shard := range partition.ShardReferences {
+			targets = append(targets, &querypb.Target{
+				Cell:       w.cell,
+				Keyspace:   keyspace,
+				Shard:      shard.Name,
+				TabletType: partition.ServedType,
+			})
+		}
+	}
+	if err := w.hc.WaitForAllServingTablets(ctx, targets); err != nil {
+		return fmt.Errorf("waiting for the serving tablets: %v", err)
+	}
+	return nil
+}
+
+func warmupTabletType(tabletTypes []topodatapb.TabletType, tabletType topodatapb.TabletType) bool {
+	for _, tt := range tabletTypes {
+		if tt == tabletType {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *keyspaceWarmer) finish() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.done = true
+}
+
+// ready returns an error until the warm-up is over. It is safe to call on
+// a nil receiver.
+func (w *keyspaceWarmer) ready() error {
+	if w == nil {
+		return nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.done {
+		return nil
+	}
+	var pending []string
+	for keyspace, kw := range w.keyspaces {
+		if !kw.Ready {
+			pending = append(pending, keyspace)
+		}
+	}
+	sort.Strings(pending)
+	return fmt.Errorf("warming up keyspaces %v", strings.Join(pending, ","))
+}
+
+// status returns the warm-up of the keyspaces, sorted by name.
+func (w *keyspaceWarmer) status() []*KeyspaceWarmup {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	result := make([]*KeyspaceWarmup, 0, len(w.keyspaces))
+	for _, kw := range w.keyspaces {
+		c := *kw
+		result = append(result, &c)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Keyspace < result[j].Keyspace })
+	return result
+}
+
+// keyspaceStatus returns the warm-up of the keyspace, or nil if it isn't
+// warmed up.
+func (w *keyspaceWarmer) keyspaceStatus(keyspace string) *KeyspaceWarmup {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	kw, ok := w.keyspaces[keyspace]
+	if !ok {
+		return nil
+	}
+	c := *kw
+	return &c
+}
+
+// registerDebugWarmupHandler exposes the warm-up of the keyspaces as JSON.
+// With a keyspace parameter, only the warm-up of that keyspace is returned,
+// with a 503 status until it is ready, so that it can serve as a
+// per-keyspace readiness probe.
+func (vtg *VTGate) registerDebugWarmupHandler() {
+	http.HandleFunc("/debug/warmup", func(w http.ResponseWriter, r *http.Request) {
+		if err := acl.CheckAccessHTTP(r, acl.MONITORING); err != nil {
+			acl.SendError(w, err)
+			return
+		}
+		vtg.warmer.serveHTTP(w, r)
+	})
+}
+
+func (w *keyspaceWarmer) serveHTTP(rw http.ResponseWriter, r *http.Request) {
+	if w == nil {
+		http.Error(rw, "keyspace warm-up is disabled, see -warmup_keyspaces", http.StatusNotFound)
+		return
+	}
+	var result interface{}
+	status := http.StatusOK
+	if keyspace := r.FormValue("keyspace"); keyspace != "" {
+		kw := w.keyspaceStatus(keyspace)
+		if kw == nil {
+			http.Error(rw, fmt.Sprintf("keyspace %v isn't warmed up", keyspace), http.StatusNotFound)
+			return
+		}
+		if !kw.Ready {
+			status = http.StatusServiceUnavailable
+		}
+		result = kw
+	} else {
+		result = w.status()
+	}
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rw.Header().Set("Content-Type", "application/json; charset=utf-8")
+	rw.WriteHeader(status)
+	rw.Write(data)
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyspaceWarmerReady(t *testing.T) {
+	var nilWarmer *keyspaceWarmer
+	assert.NoError(t, nilWarmer.ready())
+
+	defer func(keyspaces string) { *warmupKeyspaces = keyspaces }(*warmupKeyspaces)
+	*warmupKeyspaces = "ks1, ks2,"
+	w := newKeyspaceWarmer(nil, "cell", nil, nil, nil)
+	keyspaces, err := w.keyspacesToWarmUp(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ks1", "ks2"}, keyspaces)
+
+	w.keyspaces["ks1"] = &KeyspaceWarmup{Keyspace: "ks1", Ready: true}
+	w.keyspaces["ks2"] = &KeyspaceWarmup{Keyspace: "ks2", Error: "the vschema isn't loaded yet"}
+	assert.EqualError(t, w.ready(), "warming up keyspaces ks2")
+
+	// Once the warm-up is over, vtgate is ready even if some keyspaces
+	// weren't warmed up.
+	w.finish()
+	assert.NoError(t, w.ready())
+}
+
+func TestKeyspaceWarmerHTTP(t *testing.T) {
+	w := newKeyspaceWarmer(nil, "cell", nil, nil, nil)
+	w.keyspaces["ks1"] = &KeyspaceWarmup{Keyspace: "ks1", Ready: true, Seconds: 1.5}
+	w.keyspaces["ks2"] = &KeyspaceWarmup{Keyspace: "ks2", Error: "the vschema isn't loaded yet"}
+
+	get := func(url string) *httptest.ResponseRecorder {
+		rw := httptest.NewRecorder()
+		w.serveHTTP(rw, httptest.NewRequest("GET", url, nil))
+		return rw
+	}
+	rw := get("/debug/warmup")
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.JSONEq(t, `[{"keyspace": "ks1", "ready": true, "seconds": 1.5}, {"keyspace": "ks2", "ready": false, "error": "the vschema isn't loaded yet"}]`, rw.Body.String())
+
+	rw = get("/debug/warmup?keyspace=ks1")
+	assert.Equal(t, http.StatusOK, rw.Code)
+	rw = get("/debug/warmup?keyspace=ks2")
+	assert.Equal(t, http.StatusServiceUnavailable, rw.Code)
+	rw = get("/debug/warmup?keyspace=ks3")
+	assert.Equal(t, http.StatusNotFound, rw.Code)
+}
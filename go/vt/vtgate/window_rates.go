@@ -0,0 +1,46 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"flag"
+	"time"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/vtgate/buffer"
+)
+
+var rateWindowsInterval = flag.Duration("rate_windows_interval", 10*time.Second, "Sampling interval of the rates of the key metrics over the last minute, 5 minutes and hour, shown on /debug/rate_windows. 0 disables them. The minimum is 1s.")
+
+// publishRateWindows publishes the rates over the last minute, 5 minutes
+// and hour of the queries and errors per keyspace and shard, and of the
+// buffering events, so recent behavior can be seen without a metrics
+// pipeline.
+func publishRateWindows(sc *ScatterConn) {
+	interval := *rateWindowsInterval
+	if interval <= 0 {
+		return
+	}
+	if interval < time.Second {
+		interval = time.Second
+	}
+	stats.NewWindowRates("QPSByKeyspaceWindows", stats.CounterForDimension(rpcVTGate.timings, "Keyspace"), stats.DefaultRateWindows, interval)
+	stats.NewWindowRates("ErrorsByKeyspaceWindows", stats.CounterForDimension(errorCounts, "Keyspace"), stats.DefaultRateWindows, interval)
+	stats.NewWindowRates("QPSByShardWindows", stats.CounterForDimensions(sc.timings, "Keyspace", "ShardName"), stats.DefaultRateWindows, interval)
+	stats.NewWindowRates("ErrorsByShardWindows", stats.CounterForDimensions(sc.tabletCallErrorCount, "Keyspace", "ShardName"), stats.DefaultRateWindows, interval)
+	buffer.PublishRateWindows(interval)
+}
@@ -162,6 +162,15 @@ func (client *FakeTabletManagerClient) ExecuteFetchAsDba(ctx context.Context, ta
 	return &querypb.QueryResult{}, nil
 }
 
+// ExecuteFetchAsDbaMulti is part of the tmclient.TabletManagerClient interface.
+func (client *FakeTabletManagerClient) ExecuteFetchAsDbaMulti(ctx context.Context, tablet *topodatapb.Tablet, usePool bool, queries [][]byte, maxRows int, disableBinlogs, reloadSchema bool) ([]*querypb.QueryResult, error) {
+	results := make([]*querypb.QueryResult, len(queries))
+	for i := range queries {
+		results[i] = &querypb.QueryResult{}
+	}
+	return results, nil
+}
+
 // ExecuteFetchAsAllPrivs is part of the tmclient.TabletManagerClient interface.
 func (client *FakeTabletManagerClient) ExecuteFetchAsAllPrivs(ctx context.Context, tablet *topodatapb.Tablet, query []byte, maxRows int, reloadSchema bool) (*querypb.QueryResult, error) {
 	return &querypb.QueryResult{}, nil
@@ -25,7 +25,6 @@ import (
 
 	"google.golang.org/grpc"
 
-	"vitess.io/vitess/go/netutil"
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/vt/callerid"
 	"vitess.io/vitess/go/vt/grpcclient"
@@ -69,7 +68,7 @@ func DialTablet(tablet *topodatapb.Tablet, failFast grpcclient.FailFast) (querys
 	// create the RPC client
 	addr := ""
 	if grpcPort, ok := tablet.PortMap["grpc"]; ok {
-		addr = netutil.JoinHostPort(tablet.Hostname, grpcPort)
+		addr = grpcclient.TabletTarget(tablet.Hostname, grpcPort)
 	} else {
 		addr = tablet.Hostname
 	}
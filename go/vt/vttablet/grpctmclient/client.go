@@ -26,7 +26,6 @@ import (
 
 	"google.golang.org/grpc"
 
-	"vitess.io/vitess/go/netutil"
 	"vitess.io/vitess/go/vt/grpcclient"
 	"vitess.io/vitess/go/vt/hook"
 	"vitess.io/vitess/go/vt/logutil"
@@ -79,7 +78,7 @@ func NewClient() *Client {
 
 // dial returns a client to use
 func (client *Client) dial(tablet *topodatapb.Tablet) (*grpc.ClientConn, tabletmanagerservicepb.TabletManagerClient, error) {
-	addr := netutil.JoinHostPort(tablet.Hostname, int32(tablet.PortMap["grpc"]))
+	addr := grpcclient.TabletTarget(tablet.Hostname, int32(tablet.PortMap["grpc"]))
 	opt, err := grpcclient.SecureDialOption(*cert, *key, *ca, *name)
 	if err != nil {
 		return nil, nil, err
@@ -92,7 +91,7 @@ func (client *Client) dial(tablet *topodatapb.Tablet) (*grpc.ClientConn, tabletm
 }
 
 func (client *Client) dialPool(tablet *topodatapb.Tablet) (tabletmanagerservicepb.TabletManagerClient, error) {
-	addr := netutil.JoinHostPort(tablet.Hostname, int32(tablet.PortMap["grpc"]))
+	addr := grpcclient.TabletTarget(tablet.Hostname, int32(tablet.PortMap["grpc"]))
 	opt, err := grpcclient.SecureDialOption(*cert, *key, *ca, *name)
 	if err != nil {
 		return nil, err
@@ -419,6 +418,37 @@ func (client *Client) ExecuteFetchAsDba(ctx context.Context, tablet *topodatapb.
 	return response.Result, nil
 }
 
+// ExecuteFetchAsDbaMulti is part of the tmclient.TabletManagerClient interface.
+func (client *Client) ExecuteFetchAsDbaMulti(ctx context.Context, tablet *topodatapb.Tablet, usePool bool, queries [][]byte, maxRows int, disableBinlogs, reloadSchema bool) ([]*querypb.QueryResult, error) {
+	var c tabletmanagerservicepb.TabletManagerClient
+	var err error
+	if usePool {
+		c, err = client.dialPool(tablet)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		var cc *grpc.ClientConn
+		cc, c, err = client.dial(tablet)
+		if err != nil {
+			return nil, err
+		}
+		defer cc.Close()
+	}
+
+	response, err := c.ExecuteFetchAsDbaMulti(ctx, &tabletmanagerdatapb.ExecuteFetchAsDbaMultiRequest{
+		Queries:        queries,
+		DbName:         topoproto.TabletDbName(tablet),
+		MaxRows:        uint64(maxRows),
+		DisableBinlogs: disableBinlogs,
+		ReloadSchema:   reloadSchema,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response.Results, nil
+}
+
 // ExecuteFetchAsAllPrivs is part of the tmclient.TabletManagerClient interface.
 func (client *Client) ExecuteFetchAsAllPrivs(ctx context.Context, tablet *topodatapb.Tablet, query []byte, maxRows int, reloadSchema bool) (*querypb.QueryResult, error) {
 	var c tabletmanagerservicepb.TabletManagerClient
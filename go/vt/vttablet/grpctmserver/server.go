@@ -220,6 +220,18 @@ func (s *server) ExecuteFetchAsDba(ctx context.Context, request *tabletmanagerda
 	return response, nil
 }
 
+func (s *server) ExecuteFetchAsDbaMulti(ctx context.Context, request *tabletmanagerdatapb.ExecuteFetchAsDbaMultiRequest) (response *tabletmanagerdatapb.ExecuteFetchAsDbaMultiResponse, err error) {
+	defer s.tm.HandleRPCPanic(ctx, "ExecuteFetchAsDbaMulti", request, response, false /*verbose*/, &err)
+	ctx = callinfo.GRPCCallInfo(ctx)
+	response = &tabletmanagerdatapb.ExecuteFetchAsDbaMultiResponse{}
+	qrs, err := s.tm.ExecuteFetchAsDbaMulti(ctx, request.Queries, request.DbName, int(request.MaxRows), request.DisableBinlogs, request.ReloadSchema)
+	if err != nil {
+		return nil, vterrors.ToGRPC(err)
+	}
+	response.Results = qrs
+	return response, nil
+}
+
 func (s *server) ExecuteFetchAsAllPrivs(ctx context.Context, request *tabletmanagerdatapb.ExecuteFetchAsAllPrivsRequest) (response *tabletmanagerdatapb.ExecuteFetchAsAllPrivsResponse, err error) {
 	defer s.tm.HandleRPCPanic(ctx, "ExecuteFetchAsAllPrivs", request, response, false /*verbose*/, &err)
 	ctx = callinfo.GRPCCallInfo(ctx)
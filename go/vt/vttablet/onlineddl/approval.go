@@ -0,0 +1,173 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package onlineddl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/vt/schema"
+)
+
+// The migrations which require approval wait in pending-approval status,
+// and are neither scheduled nor run, until they're approved or rejected:
+// by a user, with ApproveMigration or RejectMigration, or by the approval
+// gate of the tablet, e.g. an external change management system called
+// through a webhook.
+
+var (
+	requireApproval        = flag.Bool("online_ddl_require_approval", false, "if set, all the online DDL migrations wait for an approval before they run, as if their ddl_strategy had -require-approval")
+	approvalGateName       = flag.String("online_ddl_approval_gate", "", "the approval gate which reviews the online DDL migrations pending approval, e.g. 'webhook'. If empty, they are only approved or rejected by the users")
+	approvalWebhookURL     = flag.String("online_ddl_approval_webhook_url", "", "with -online_ddl_approval_gate=webhook, the URL the migrations pending approval are POSTed to as JSON")
+	approvalWebhookTimeout = flag.Duration("online_ddl_approval_webhook_timeout", 10*time.Second, "with -online_ddl_approval_gate=webhook, the timeout of a call to the webhook")
+)
+
+// ApprovalDecision is the decision of a review of a migration pending
+// approval.
+type ApprovalDecision string
+
+const (
+	// ApprovalPending keeps the migration pending approval.
+	ApprovalPending ApprovalDecision = "pending"
+	// ApprovalApproved queues the migration.
+	ApprovalApproved ApprovalDecision = "approved"
+	// ApprovalRejected cancels the migration.
+	ApprovalRejected ApprovalDecision = "rejected"
+)
+
+// ApprovalRequest is a migration pending approval, as submitted to an
+// approval gate.
+type ApprovalRequest struct {
+	UUID     string `json:"uuid"`
+	Keyspace string `json:"keyspace"`
+	Shard    string `json:"shard"`
+	Tablet   string `json:"tablet"`
+	Table    string `json:"table"`
+	SQL      string `json:"sql"`
+	Strategy string `json:"strategy"`
+	Options  string `json:"options"`
+	Context  string `json:"context"`
+}
+
+// ApprovalResponse is the review of a migration by an approval gate.
+type ApprovalResponse struct {
+	// Decision is empty or ApprovalPending while the review isn't done.
+	Decision ApprovalDecision `json:"decision"`
+	// Reviewer is recorded as the identity which approved or rejected the
+	// migration. It defaults to the name of the gate.
+	Reviewer string `json:"reviewer,omitempty"`
+	// Message is recorded as the message of a rejected migration.
+	Message string `json:"message,omitempty"`
+}
+
+// ApprovalGate reviews the migrations pending approval. It's called for
+// each of them at every migration check, until it decides.
+type ApprovalGate interface {
+	Review(ctx context.Context, request *ApprovalRequest) (*ApprovalResponse, error)
+}
+
+var (
+	approvalGatesMu sync.Mutex
+	approvalGates   = make(map[string]ApprovalGate)
+)
+
+// RegisterApprovalGate registers an approval gate, which is used by the
+// tablets started with -online_ddl_approval_gate=<name>.
+func RegisterApprovalGate(name string, gate ApprovalGate) {
+	approvalGatesMu.Lock()
+	defer approvalGatesMu.Unlock()
+	if _, ok := approvalGates[name]; ok {
+		panic(fmt.Sprintf("approval gate %s is already registered", name))
+	}
+	approvalGates[name] = gate
+}
+
+// approvalGate returns the approval gate of the tablet, or nil if there's
+// none.
+func approvalGate() (ApprovalGate, error) {
+	if *approvalGateName == "" {
+		return nil, nil
+	}
+	approvalGatesMu.Lock()
+	defer approvalGatesMu.Unlock()
+	gate, ok := approvalGates[*approvalGateName]
+	if !ok {
+		return nil, fmt.Errorf("unknown approval gate: %s", *approvalGateName)
+	}
+	return gate, nil
+}
+
+// requiresApproval returns true if the migration must be approved before
+// it runs.
+func requiresApproval(onlineDDL *schema.OnlineDDL) bool {
+	return *requireApproval || onlineDDL.StrategySetting().IsRequireApproval()
+}
+
+// webhookApprovalGate POSTs the migrations pending approval to
+// -online_ddl_approval_webhook_url, and expects an ApprovalResponse as
+// JSON.
+type webhookApprovalGate struct{}
+
+func (webhookApprovalGate) Review(ctx context.Context, request *ApprovalRequest) (*ApprovalResponse, error) {
+	if *approvalWebhookURL == "" {
+		return nil, fmt.Errorf("-online_ddl_approval_webhook_url is required by the webhook approval gate")
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, *approvalWebhookTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, *approvalWebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("approval webhook returned %v: %s", resp.Status, data)
+	}
+	response := &ApprovalResponse{}
+	if err := json.Unmarshal(data, response); err != nil {
+		return nil, fmt.Errorf("cannot parse the response of the approval webhook: %v", err)
+	}
+	switch response.Decision {
+	case "", ApprovalPending, ApprovalApproved, ApprovalRejected:
+	default:
+		return nil, fmt.Errorf("unknown decision from the approval webhook: %s", response.Decision)
+	}
+	return response, nil
+}
+
+func init() {
+	RegisterApprovalGate("webhook", webhookApprovalGate{})
+}
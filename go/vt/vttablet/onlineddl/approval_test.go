@@ -0,0 +1,72 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package onlineddl
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/schema"
+)
+
+func TestRequiresApproval(t *testing.T) {
+	assert.False(t, requiresApproval(&schema.OnlineDDL{Strategy: schema.DDLStrategyOnline}))
+	assert.True(t, requiresApproval(&schema.OnlineDDL{Strategy: schema.DDLStrategyOnline, Options: "-require-approval"}))
+
+	*requireApproval = true
+	defer func() { *requireApproval = false }()
+	assert.True(t, requiresApproval(&schema.OnlineDDL{Strategy: schema.DDLStrategyGhost}))
+}
+
+func TestWebhookApprovalGate(t *testing.T) {
+	response := `{"decision": "approved", "reviewer": "change-board"}`
+	var request ApprovalRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		w.Write([]byte(response))
+	}))
+	defer server.Close()
+
+	*approvalGateName = "webhook"
+	*approvalWebhookURL = server.URL
+	defer func() {
+		*approvalGateName = ""
+		*approvalWebhookURL = ""
+	}()
+	gate, err := approvalGate()
+	require.NoError(t, err)
+
+	review, err := gate.Review(context.Background(), &ApprovalRequest{UUID: "6ace8bce_f732_11ea_87e9_f875a4d24e90", Keyspace: "ks", Table: "t1"})
+	require.NoError(t, err)
+	assert.Equal(t, &ApprovalResponse{Decision: ApprovalApproved, Reviewer: "change-board"}, review)
+	assert.Equal(t, "6ace8bce_f732_11ea_87e9_f875a4d24e90", request.UUID)
+	assert.Equal(t, "t1", request.Table)
+
+	response = `{"decision": "maybe"}`
+	_, err = gate.Review(context.Background(), &ApprovalRequest{})
+	assert.EqualError(t, err, "unknown decision from the approval webhook: maybe")
+
+	*approvalGateName = "unknown"
+	_, err = approvalGate()
+	assert.EqualError(t, err, "unknown approval gate: unknown")
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package onlineddl
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"vitess.io/vitess/go/vt/dbconnpool"
+	"vitess.io/vitess/go/vt/mysqlctl/tmutils"
+	"vitess.io/vitess/go/vt/schema"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// The names of CHECK constraints are unique in a schema, so the shadow
+// table of a migration can't have the constraints of the original table
+// under the same names. vreplication migrations either rename them, in
+// which case the migrated table keeps the new names, or strip them.
+
+const (
+	checkConstraintsRename = "rename"
+	checkConstraintsStrip  = "strip"
+
+	// maxConstraintNameLength is the maximum length of a constraint name
+	// in MySQL.
+	maxConstraintNameLength = 64
+)
+
+var (
+	checkConstraintsHandling = flag.String("online_ddl_check_constraints", checkConstraintsRename, "How online DDL migrations of the online strategy handle the CHECK constraints of the migrated table: 'rename' gives them new unique names, 'strip' removes them from the migrated table")
+
+	alterCheckConstraintRegexp = regexp.MustCompile("(?i)\\b(DROP|ALTER)\\s+(CHECK|CONSTRAINT)\\s+(`[^`]+`|\\w+)")
+)
+
+// shadowCheckConstraintName returns the name of a CHECK constraint of the
+// original table of a migration in its shadow table.
+func shadowCheckConstraintName(uuid, name string) string {
+	sum := sha256.Sum256([]byte(uuid + ":" + name))
+	suffix := hex.EncodeToString(sum[:4])
+	if maxLength := maxConstraintNameLength - len(suffix) - 1; len(name) > maxLength {
+		name = name[:maxLength]
+	}
+	return name + "_" + suffix
+}
+
+// shadowTableStatement returns the CREATE TABLE statement of the shadow
+// table of a migration from the one of its original table, with the CHECK
+// constraints renamed or stripped according to handling. It also returns
+// the names of the constraints in the shadow table, by their lower case
+// names in the original table, with an empty name for the stripped ones.
+func shadowTableStatement(createTable, table, shadowTable, uuid, handling string) (string, map[string]string, error) {
+	if handling != checkConstraintsRename && handling != checkConstraintsStrip {
+		return "", nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid -online_ddl_check_constraints value %q", handling)
+	}
+	prefix := fmt.Sprintf("CREATE TABLE `%s`", table)
+	if !strings.HasPrefix(createTable, prefix) {
+		return "", nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "unexpected CREATE TABLE statement for table %s: %s", table, createTable)
+	}
+	names := make(map[string]string)
+	createTable, err := tmutils.RewriteCheckConstraints(createTable, func(name string) string {
+		newName := ""
+		if handling == checkConstraintsRename {
+			newName = shadowCheckConstraintName(uuid, name)
+		}
+		names[strings.ToLower(name)] = newName
+		return newName
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	return fmt.Sprintf("CREATE TABLE `%s`", shadowTable) + createTable[len(prefix):], names, nil
+}
+
+// rewriteAlterCheckConstraints replaces the names of the CHECK constraints
+// of the original table in the ALTER options of a migration with their
+// names in the shadow table. It fails if the options refer to a stripped
+// constraint.
+func rewriteAlterCheckConstraints(alterOptions string, names map[string]string) (string, error) {
+	var err error
+	rewritten := alterCheckConstraintRegexp.ReplaceAllStringFunc(alterOptions, func(clause string) string {
+		match := alterCheckConstraintRegexp.FindStringSubmatch(clause)
+		name := strings.Trim(match[3], "`")
+		newName, ok := names[strings.ToLower(name)]
+		switch {
+		case !ok:
+			return clause
+		case newName == "":
+			err = vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "%s refers to check constraint %s, which -online_ddl_check_constraints=%s removes from the migrated table", clause, name, checkConstraintsStrip)
+			return clause
+		}
+		return fmt.Sprintf("%s %s `%s`", match[1], match[2], newName)
+	})
+	return rewritten, err
+}
+
+// validateAlterCheckConstraints fails if the ALTER options of a gh-ost or
+// pt-online-schema-change migration refer to a CHECK constraint by name:
+// these tools copy the table to a new one, whose constraints don't have
+// the names of the original ones.
+func validateAlterCheckConstraints(alterOptions string) error {
+	if match := alterCheckConstraintRegexp.FindString(alterOptions); match != "" {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "%s refers to a check constraint by name, which is only supported by the online strategy", match)
+	}
+	return nil
+}
+
+// createShadowTable creates the shadow table of a vreplication migration,
+// as a copy of its original table. It returns the names of the CHECK
+// constraints of the original table in the shadow table, as returned by
+// shadowTableStatement.
+func (e *Executor) createShadowTable(ctx context.Context, conn *dbconnpool.DBConnection, onlineDDL *schema.OnlineDDL, shadowTable string) (map[string]string, error) {
+	showCreateTable := sqlparser.BuildParsedQuery(sqlShowCreateTable, onlineDDL.Table).Query
+	rs, err := conn.ExecuteFetch(showCreateTable, 1, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(rs.Rows) != 1 || len(rs.Rows[0]) < 2 {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "unexpected result of %s", showCreateTable)
+	}
+	createShadowTable, names, err := shadowTableStatement(rs.Rows[0][1].ToString(), onlineDDL.Table, shadowTable, onlineDDL.UUID, *checkConstraintsHandling)
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		// Without CHECK constraints, the table is copied as is.
+		createShadowTable = sqlparser.BuildParsedQuery(sqlCreateTableLike, shadowTable, onlineDDL.Table).Query
+	}
+	if _, err := conn.ExecuteFetch(createShadowTable, 0, false); err != nil {
+		return nil, err
+	}
+	return names, nil
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package onlineddl
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const checkConstraintsTable = "CREATE TABLE `t` (\n" +
+	"  `id` bigint NOT NULL,\n" +
+	"  `price` int DEFAULT NULL,\n" +
+	"  PRIMARY KEY (`id`),\n" +
+	"  CONSTRAINT `price_positive` CHECK ((`price` > 0))\n" +
+	") ENGINE=InnoDB"
+
+func TestShadowCheckConstraintName(t *testing.T) {
+	name := shadowCheckConstraintName("uuid", "price_positive")
+	assert.True(t, strings.HasPrefix(name, "price_positive_"))
+	assert.Equal(t, len(name), len("price_positive_")+8)
+	assert.NotEqual(t, name, shadowCheckConstraintName("other_uuid", "price_positive"))
+
+	long := strings.Repeat("c", 64)
+	assert.Equal(t, len(shadowCheckConstraintName("uuid", long)), 64)
+}
+
+func TestShadowTableStatement(t *testing.T) {
+	newName := shadowCheckConstraintName("uuid", "price_positive")
+
+	createTable, names, err := shadowTableStatement(checkConstraintsTable, "t", "_t_vrepl", "uuid", checkConstraintsRename)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"price_positive": newName}, names)
+	assert.True(t, strings.HasPrefix(createTable, "CREATE TABLE `_t_vrepl` ("))
+	assert.Contains(t, createTable, "CONSTRAINT `"+newName+"` CHECK ((`price` > 0))")
+
+	createTable, names, err = shadowTableStatement(checkConstraintsTable, "t", "_t_vrepl", "uuid", checkConstraintsStrip)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"price_positive": ""}, names)
+	assert.NotContains(t, createTable, "CHECK")
+
+	_, _, err = shadowTableStatement(checkConstraintsTable, "t", "_t_vrepl", "uuid", "keep")
+	assert.Error(t, err)
+	_, _, err = shadowTableStatement(checkConstraintsTable, "u", "_u_vrepl", "uuid", checkConstraintsRename)
+	assert.Error(t, err)
+}
+
+func TestRewriteAlterCheckConstraints(t *testing.T) {
+	names := map[string]string{"price_positive": "price_positive_1234abcd", "price_max": ""}
+
+	alterOptions, err := rewriteAlterCheckConstraints("drop check `Price_Positive`, add column c int", names)
+	assert.NoError(t, err)
+	assert.Equal(t, "drop check `price_positive_1234abcd`, add column c int", alterOptions)
+
+	alterOptions, err = rewriteAlterCheckConstraints("ALTER CONSTRAINT other NOT ENFORCED", names)
+	assert.NoError(t, err)
+	assert.Equal(t, "ALTER CONSTRAINT other NOT ENFORCED", alterOptions)
+
+	_, err = rewriteAlterCheckConstraints("DROP CHECK price_max", names)
+	assert.Error(t, err)
+}
+
+func TestValidateAlterCheckConstraints(t *testing.T) {
+	assert.NoError(t, validateAlterCheckConstraints("ADD CONSTRAINT price_positive CHECK (price > 0)"))
+	assert.Error(t, validateAlterCheckConstraints("DROP CHECK price_positive"))
+	assert.Error(t, validateAlterCheckConstraints("alter constraint `price_positive` not enforced"))
+}
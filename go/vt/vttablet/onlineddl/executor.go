@@ -80,6 +80,10 @@ var vexecUpdateTemplates = []string{
 	`update _vt.schema_migrations set migration_status='val' where mysql_schema='val'`,
 	`update _vt.schema_migrations set migration_status='val' where migration_uuid='val' and mysql_schema='val'`,
 	`update _vt.schema_migrations set migration_status='val' where migration_uuid='val' and mysql_schema='val' and shard='val'`,
+	`update _vt.schema_migrations set migration_status='val', reviewed_by='val' where migration_uuid='val' and mysql_schema='val'`,
+	`update _vt.schema_migrations set migration_status='val', reviewed_by='val' where migration_uuid='val' and mysql_schema='val' and shard='val'`,
+	`update _vt.schema_migrations set migration_status='val', reviewed_by='val', message='val' where migration_uuid='val' and mysql_schema='val'`,
+	`update _vt.schema_migrations set migration_status='val', reviewed_by='val', message='val' where migration_uuid='val' and mysql_schema='val' and shard='val'`,
 }
 
 var vexecInsertTemplates = []string{
@@ -185,6 +189,15 @@ func newGCTableRetainTime() time.Time {
 	return time.Now().UTC().Add(*retainOnlineDDLTables)
 }
 
+// artifactsRetention returns how long the artifacts of a migration are kept after it completes, during
+// which it can be reverted: its -retain-artifacts duration if set, or -retain_online_ddl_tables.
+func artifactsRetention(onlineDDL *schema.OnlineDDL) time.Duration {
+	if retention, err := onlineDDL.StrategySetting().RetainArtifactsDuration(); err == nil && retention > 0 {
+		return retention
+	}
+	return *retainOnlineDDLTables
+}
+
 // NewExecutor creates a new gh-ost executor.
 func NewExecutor(env tabletenv.Env, tabletAlias *topodatapb.TabletAlias, ts *topo.Server, tabletTypeFunc func() topodatapb.TabletType) *Executor {
 	return &Executor{
@@ -696,14 +709,15 @@ func (e *Executor) cutOverVReplMigration(ctx context.Context, s *VReplStream) er
 
 func (e *Executor) initVreplicationOriginalMigration(ctx context.Context, onlineDDL *schema.OnlineDDL, conn *dbconnpool.DBConnection) (v *VRepl, err error) {
 	vreplTableName := fmt.Sprintf("_%s_%s_vrepl", onlineDDL.UUID, ReadableTimestamp())
-	{
-		// Apply CREATE TABLE for materialized table
-		parsed := sqlparser.BuildParsedQuery(sqlCreateTableLike, vreplTableName, onlineDDL.Table)
-		if _, err := conn.ExecuteFetch(parsed.Query, 0, false); err != nil {
-			return v, err
-		}
+	// Apply CREATE TABLE for materialized table
+	checkConstraintNames, err := e.createShadowTable(ctx, conn, onlineDDL, vreplTableName)
+	if err != nil {
+		return v, err
+	}
+	alterOptions, err := rewriteAlterCheckConstraints(e.parseAlterOptions(ctx, onlineDDL), checkConstraintNames)
+	if err != nil {
+		return v, err
 	}
-	alterOptions := e.parseAlterOptions(ctx, onlineDDL)
 	{
 		// Apply ALTER TABLE to materialized table
 		parsed := sqlparser.BuildParsedQuery(sqlAlterTableOptions, vreplTableName, alterOptions)
@@ -870,6 +884,10 @@ func (e *Executor) ExecuteWithGhost(ctx context.Context, onlineDDL *schema.Onlin
 		log.Errorf(err.Error())
 		return err
 	}
+	if err := validateAlterCheckConstraints(e.parseAlterOptions(ctx, onlineDDL)); err != nil {
+		log.Errorf("Error before running gh-ost: %+v", err)
+		return err
+	}
 	onlineDDLPassword, err := e.createOnlineDDLUser(ctx)
 	if err != nil {
 		err := fmt.Errorf("Error creating gh-ost user: %+v", err)
@@ -1074,6 +1092,10 @@ func (e *Executor) ExecuteWithPTOSC(ctx context.Context, onlineDDL *schema.Onlin
 		log.Errorf(err.Error())
 		return err
 	}
+	if err := validateAlterCheckConstraints(e.parseAlterOptions(ctx, onlineDDL)); err != nil {
+		log.Errorf("Error before running pt-online-schema-change: %+v", err)
+		return err
+	}
 	onlineDDLPassword, err := e.createOnlineDDLUser(ctx)
 	if err != nil {
 		err := fmt.Errorf("Error creating pt-online-schema-change user: %+v", err)
@@ -1385,7 +1407,7 @@ func (e *Executor) CancelMigration(ctx context.Context, uuid string, terminateRu
 	switch onlineDDL.Status {
 	case schema.OnlineDDLStatusComplete, schema.OnlineDDLStatusFailed:
 		return emptyResult, nil
-	case schema.OnlineDDLStatusQueued, schema.OnlineDDLStatusReady:
+	case schema.OnlineDDLStatusPendingApproval, schema.OnlineDDLStatusQueued, schema.OnlineDDLStatusReady:
 		if err := e.updateMigrationStatus(ctx, onlineDDL.UUID, schema.OnlineDDLStatusCancelled); err != nil {
 			return nil, err
 		}
@@ -1874,7 +1896,7 @@ func (e *Executor) executeMigration(ctx context.Context, onlineDDL *schema.Onlin
 			}
 
 			var toTableName string
-			onlineDDL.SQL, toTableName, err = schema.GenerateRenameStatementWithUUID(onlineDDL.Table, schema.HoldTableGCState, onlineDDL.GetGCUUID(), newGCTableRetainTime())
+			onlineDDL.SQL, toTableName, err = schema.GenerateRenameStatementWithUUID(onlineDDL.Table, schema.HoldTableGCState, onlineDDL.GetGCUUID(), time.Now().UTC().Add(artifactsRetention(onlineDDL)))
 			if err != nil {
 				return failMigration(err)
 			}
@@ -1904,7 +1926,7 @@ func (e *Executor) executeMigration(ctx context.Context, onlineDDL *schema.Onlin
 			e.migrationMutex.Lock()
 			defer e.migrationMutex.Unlock()
 
-			sentryArtifactTableName, err := schema.GenerateGCTableName(schema.HoldTableGCState, newGCTableRetainTime())
+			sentryArtifactTableName, err := schema.GenerateGCTableName(schema.HoldTableGCState, time.Now().UTC().Add(artifactsRetention(onlineDDL)))
 			if err != nil {
 				return failMigration(err)
 			}
@@ -1939,6 +1961,19 @@ func (e *Executor) executeMigration(ctx context.Context, onlineDDL *schema.Onlin
 			return nil
 		}()
 	case sqlparser.AlterDDLAction:
+		if onlineDDL.IsPartitionMaintenance() {
+			// Adding or dropping partitions doesn't rebuild the table, there is
+			// nothing to gain from running it through a migration tool.
+			go func() {
+				e.migrationMutex.Lock()
+				defer e.migrationMutex.Unlock()
+
+				if _, err := e.executeDirectly(ctx, onlineDDL); err != nil {
+					failMigration(err)
+				}
+			}()
+			return nil
+		}
 		switch onlineDDL.Strategy {
 		case schema.DDLStrategyOnline:
 			go func() {
@@ -2468,6 +2503,9 @@ func (e *Executor) onMigrationCheckTick() {
 	if err := e.retryTabletFailureMigrations(ctx); err != nil {
 		log.Error(err)
 	}
+	if err := e.reviewPendingApprovalMigrations(ctx); err != nil {
+		log.Error(err)
+	}
 	if err := e.scheduleNextMigration(ctx); err != nil {
 		log.Error(err)
 	}
@@ -2736,6 +2774,111 @@ func (e *Executor) RetryMigration(ctx context.Context, uuid string) (result *sql
 	return e.execQuery(ctx, query)
 }
 
+// ApproveMigration releases a migration pending approval, recording the
+// identity which approved it. The migration is then queued.
+func (e *Executor) ApproveMigration(ctx context.Context, uuid string, reviewer string) (result *sqltypes.Result, err error) {
+	result, err = e.reviewMigration(ctx, uuid, ApprovalApproved, reviewer, "")
+	if err == nil && result.RowsAffected > 0 {
+		log.Infof("ApproveMigration: migration %s approved by %s", uuid, reviewer)
+		e.triggerNextCheckInterval()
+	}
+	return result, err
+}
+
+// RejectMigration cancels a migration pending approval, recording the
+// identity which rejected it and why.
+func (e *Executor) RejectMigration(ctx context.Context, uuid string, reviewer string, message string) (result *sqltypes.Result, err error) {
+	if message == "" {
+		message = "rejected"
+	}
+	result, err = e.reviewMigration(ctx, uuid, ApprovalRejected, reviewer, fmt.Sprintf("rejected by %s: %s", reviewer, message))
+	if err == nil && result.RowsAffected > 0 {
+		log.Infof("RejectMigration: migration %s rejected by %s", uuid, reviewer)
+	}
+	return result, err
+}
+
+// reviewMigration records the decision on a migration pending approval:
+// an approved migration is queued, and a rejected one is cancelled. It
+// doesn't affect the migrations which aren't pending approval, so that a
+// review is idempotent. A retry of a rejected migration puts it back in
+// pending-approval status.
+func (e *Executor) reviewMigration(ctx context.Context, uuid string, decision ApprovalDecision, reviewer string, message string) (result *sqltypes.Result, err error) {
+	if !schema.IsOnlineDDLUUID(uuid) {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Not a valid migration ID: %s", uuid)
+	}
+	if reviewer == "" {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "the reviewer of migration %s is required", uuid)
+	}
+	e.migrationMutex.Lock()
+	defer e.migrationMutex.Unlock()
+
+	status := schema.OnlineDDLStatusQueued
+	if decision == ApprovalRejected {
+		status = schema.OnlineDDLStatusCancelled
+	}
+	query, err := sqlparser.ParseAndBind(sqlReviewMigration,
+		sqltypes.StringBindVariable(string(status)),
+		sqltypes.StringBindVariable(string(decision)),
+		sqltypes.StringBindVariable(reviewer),
+		sqltypes.StringBindVariable(message),
+		sqltypes.StringBindVariable(uuid),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return e.execQuery(ctx, query)
+}
+
+// reviewPendingApprovalMigrations submits the migrations pending approval
+// to the approval gate of the tablet, if any, and applies its decisions.
+func (e *Executor) reviewPendingApprovalMigrations(ctx context.Context) error {
+	gate, err := approvalGate()
+	if gate == nil || err != nil {
+		return err
+	}
+	r, err := e.execQuery(ctx, sqlSelectPendingApprovalMigrations)
+	if err != nil {
+		return err
+	}
+	for _, row := range r.Named().Rows {
+		onlineDDL, _, err := e.readMigration(ctx, row["migration_uuid"].ToString())
+		if err != nil {
+			return err
+		}
+		response, err := gate.Review(ctx, &ApprovalRequest{
+			UUID:     onlineDDL.UUID,
+			Keyspace: e.keyspace,
+			Shard:    e.shard,
+			Tablet:   e.TabletAliasString(),
+			Table:    onlineDDL.Table,
+			SQL:      onlineDDL.SQL,
+			Strategy: string(onlineDDL.Strategy),
+			Options:  onlineDDL.Options,
+			Context:  onlineDDL.RequestContext,
+		})
+		if err != nil {
+			// The other migrations may still be reviewed
+			log.Errorf("approval gate %s cannot review migration %s: %v", *approvalGateName, onlineDDL.UUID, err)
+			continue
+		}
+		reviewer := response.Reviewer
+		if reviewer == "" {
+			reviewer = *approvalGateName
+		}
+		switch response.Decision {
+		case ApprovalApproved:
+			_, err = e.ApproveMigration(ctx, onlineDDL.UUID, reviewer)
+		case ApprovalRejected:
+			_, err = e.RejectMigration(ctx, onlineDDL.UUID, reviewer, response.Message)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // SubmitMigration inserts a new migration request
 func (e *Executor) SubmitMigration(
 	ctx context.Context,
@@ -2750,6 +2893,15 @@ func (e *Executor) SubmitMigration(
 	if err != nil {
 		return nil, err
 	}
+	// The artifacts of the migrations without -retain-artifacts are kept for -retain_online_ddl_tables
+	retainArtifacts, err := onlineDDL.StrategySetting().RetainArtifactsDuration()
+	if err != nil {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Error submitting migration %s: %v", sqlparser.String(stmt), err)
+	}
+	status, reviewDecision := schema.OnlineDDLStatusQueued, ""
+	if requiresApproval(onlineDDL) {
+		status, reviewDecision = schema.OnlineDDLStatusPendingApproval, string(ApprovalPending)
+	}
 
 	query, err := sqlparser.ParseAndBind(sqlInsertMigration,
 		sqltypes.StringBindVariable(onlineDDL.UUID),
@@ -2762,8 +2914,10 @@ func (e *Executor) SubmitMigration(
 		sqltypes.StringBindVariable(onlineDDL.Options),
 		sqltypes.StringBindVariable(actionStr),
 		sqltypes.StringBindVariable(onlineDDL.RequestContext),
-		sqltypes.StringBindVariable(string(schema.OnlineDDLStatusQueued)),
+		sqltypes.StringBindVariable(string(status)),
 		sqltypes.StringBindVariable(e.TabletAliasString()),
+		sqltypes.Int64BindVariable(int64(retainArtifacts.Seconds())),
+		sqltypes.StringBindVariable(reviewDecision),
 	)
 	if err != nil {
 		return nil, err
@@ -2948,6 +3102,14 @@ func (e *Executor) VExec(ctx context.Context, vx *vexec.TabletVExec) (qr *queryp
 		vx.ReplaceInsertColumnVal("shard", vx.ToStringVal(e.shard))
 		vx.ReplaceInsertColumnVal("mysql_schema", vx.ToStringVal(e.dbName))
 		vx.AddOrReplaceInsertColumnVal("tablet", vx.ToStringVal(e.TabletAliasString()))
+		if status, _ := vx.ColumnStringVal(vx.InsertCols, "migration_status"); status == string(schema.OnlineDDLStatusQueued) {
+			strategy, _ := vx.ColumnStringVal(vx.InsertCols, "strategy")
+			options, _ := vx.ColumnStringVal(vx.InsertCols, "options")
+			if requiresApproval(&schema.OnlineDDL{Strategy: schema.DDLStrategy(strategy), Options: options}) {
+				vx.ReplaceInsertColumnVal("migration_status", vx.ToStringVal(string(schema.OnlineDDLStatusPendingApproval)))
+				vx.AddOrReplaceInsertColumnVal("review_decision", vx.ToStringVal(string(ApprovalPending)))
+			}
+		}
 		e.triggerNextCheckInterval()
 		return response(e.execQuery(ctx, vx.Query))
 	case *sqlparser.Update:
@@ -2987,9 +3149,23 @@ func (e *Executor) VExec(ctx context.Context, vx *vexec.TabletVExec) (qr *queryp
 				return nil, fmt.Errorf("Unexpetced UUID: %s", uuid)
 			}
 			return response(e.CancelPendingMigrations(ctx, "cancel-all by user"))
+		case approveMigrationHint, rejectMigrationHint:
+			uuid, err := vx.ColumnStringVal(vx.WhereCols, "migration_uuid")
+			if err != nil {
+				return nil, err
+			}
+			reviewer, err := vx.ColumnStringVal(vx.UpdateCols, "reviewed_by")
+			if err != nil {
+				return nil, err
+			}
+			if statusVal == approveMigrationHint {
+				return response(e.ApproveMigration(ctx, uuid, reviewer))
+			}
+			message, _ := vx.ColumnStringVal(vx.UpdateCols, "message")
+			return response(e.RejectMigration(ctx, uuid, reviewer, message))
 		default:
-			return nil, fmt.Errorf("Unexpected value for migration_status: %v. Supported values are: %s, %s",
-				statusVal, retryMigrationHint, cancelMigrationHint)
+			return nil, fmt.Errorf("Unexpected value for migration_status: %v. Supported values are: %s, %s, %s, %s",
+				statusVal, retryMigrationHint, cancelMigrationHint, approveMigrationHint, rejectMigrationHint)
 		}
 	default:
 		return nil, fmt.Errorf("No handler for this query: %s", vx.Query)
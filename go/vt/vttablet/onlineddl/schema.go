@@ -59,6 +59,10 @@ const (
 	alterSchemaMigrationsTableRowsCopied         = "ALTER TABLE _vt.schema_migrations add column rows_copied bigint unsigned NOT NULL DEFAULT 0"
 	alterSchemaMigrationsTableTableRows          = "ALTER TABLE _vt.schema_migrations add column table_rows bigint NOT NULL DEFAULT 0"
 	alterSchemaMigrationsTableLogFile            = "ALTER TABLE _vt.schema_migrations add column log_file varchar(1024) NOT NULL DEFAULT ''"
+	alterSchemaMigrationsTableRetainArtifacts    = "ALTER TABLE _vt.schema_migrations add column retain_artifacts_seconds bigint NOT NULL DEFAULT 0"
+	alterSchemaMigrationsTableReviewDecision     = "ALTER TABLE _vt.schema_migrations add column review_decision varchar(16) NOT NULL DEFAULT ''"
+	alterSchemaMigrationsTableReviewedBy         = "ALTER TABLE _vt.schema_migrations add column reviewed_by varchar(128) NOT NULL DEFAULT ''"
+	alterSchemaMigrationsTableReviewedTimestamp  = "ALTER TABLE _vt.schema_migrations add column reviewed_timestamp timestamp NULL DEFAULT NULL"
 
 	sqlInsertMigration = `INSERT IGNORE INTO _vt.schema_migrations (
 		migration_uuid,
@@ -73,9 +77,11 @@ const (
 		requested_timestamp,
 		migration_context,
 		migration_status,
-		tablet
+		tablet,
+		retain_artifacts_seconds,
+		review_decision
 	) VALUES (
-		%a, %a, %a, %a, %a, %a, %a, %a, %a, FROM_UNIXTIME(NOW()), %a, %a, %a
+		%a, %a, %a, %a, %a, %a, %a, %a, %a, FROM_UNIXTIME(NOW()), %a, %a, %a, %a, %a
 	)`
 
 	sqlScheduleSingleMigration = `UPDATE _vt.schema_migrations
@@ -98,6 +104,12 @@ const (
 		WHERE
 			migration_uuid=%a
 	`
+	sqlReviewMigration = `UPDATE _vt.schema_migrations
+			SET migration_status=%a, review_decision=%a, reviewed_by=%a, reviewed_timestamp=NOW(), message=%a
+		WHERE
+			migration_uuid=%a
+			AND migration_status='pending-approval'
+	`
 	sqlUpdateMigrationProgress = `UPDATE _vt.schema_migrations
 			SET progress=%a
 		WHERE
@@ -182,7 +194,8 @@ const (
 	`
 	sqlRetryMigrationWhere = `UPDATE _vt.schema_migrations
 		SET
-			migration_status='queued',
+			migration_status=IF(review_decision IN ('pending', 'rejected'), 'pending-approval', 'queued'),
+			review_decision=IF(review_decision='rejected', 'pending', review_decision),
 			tablet=%a,
 			retries=retries + 1,
 			tablet_failure=0,
@@ -198,7 +211,8 @@ const (
 	`
 	sqlRetryMigration = `UPDATE _vt.schema_migrations
 		SET
-			migration_status='queued',
+			migration_status=IF(review_decision IN ('pending', 'rejected'), 'pending-approval', 'queued'),
+			review_decision=IF(review_decision='rejected', 'pending', review_decision),
 			tablet=%a,
 			retries=retries + 1,
 			tablet_failure=0,
@@ -254,7 +268,15 @@ const (
 			migration_uuid
 		FROM _vt.schema_migrations
 		WHERE
-			migration_status IN ('queued', 'ready', 'running')
+			migration_status IN ('pending-approval', 'queued', 'ready', 'running')
+	`
+	sqlSelectPendingApprovalMigrations = `SELECT
+			migration_uuid
+		FROM _vt.schema_migrations
+		WHERE
+			migration_status='pending-approval'
+		ORDER BY
+			requested_timestamp ASC
 	`
 	sqlSelectUncollectedArtifacts = `SELECT
 			migration_uuid,
@@ -264,7 +286,7 @@ const (
 		WHERE
 			migration_status IN ('complete', 'failed')
 			AND cleanup_timestamp IS NULL
-			AND completed_timestamp <= NOW() - INTERVAL %a SECOND
+			AND completed_timestamp <= NOW() - INTERVAL IF(retain_artifacts_seconds > 0, retain_artifacts_seconds, %a) SECOND
 	`
 	sqlFixCompletedTimestamp = `UPDATE _vt.schema_migrations
 		SET
@@ -367,6 +389,7 @@ const (
 	sqlDropTrigger       = "DROP TRIGGER IF EXISTS `%a`.`%a`"
 	sqlShowTablesLike    = "SHOW TABLES LIKE '%a'"
 	sqlCreateTableLike   = "CREATE TABLE `%a` LIKE `%a`"
+	sqlShowCreateTable   = "SHOW CREATE TABLE `%a`"
 	sqlDropTable         = "DROP TABLE `%a`"
 	sqlAlterTableOptions = "ALTER TABLE `%a` %s"
 	sqlShowColumnsFrom   = "SHOW COLUMNS FROM `%a`"
@@ -412,6 +435,8 @@ const (
 	retryMigrationHint     = "retry"
 	cancelMigrationHint    = "cancel"
 	cancelAllMigrationHint = "cancel-all"
+	approveMigrationHint   = "approve"
+	rejectMigrationHint    = "reject"
 )
 
 var (
@@ -447,4 +472,8 @@ var ApplyDDL = []string{
 	alterSchemaMigrationsTableRowsCopied,
 	alterSchemaMigrationsTableTableRows,
 	alterSchemaMigrationsTableLogFile,
+	alterSchemaMigrationsTableRetainArtifacts,
+	alterSchemaMigrationsTableReviewDecision,
+	alterSchemaMigrationsTableReviewedBy,
+	alterSchemaMigrationsTableReviewedTimestamp,
 }
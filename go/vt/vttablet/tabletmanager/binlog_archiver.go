@@ -0,0 +1,200 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletmanager
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/binlog"
+	"vitess.io/vitess/go/vt/binlog/binlogarchive"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/mysqlctl/backupstorage"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+var (
+	binlogArchive                = flag.Bool("binlog_archive", false, "if set, the master streams its binary logs to the backup storage, so the vstreams can catch up from there after the binary logs are purged")
+	binlogArchiveSegmentDuration = flag.Duration("binlog_archive_segment_duration", 10*time.Minute, "duration of the segments of the binlog archive. The archived events are only readable once their segment ends")
+
+	binlogArchiveErrors = stats.NewCounter("BinlogArchiveErrors", "Number of errors while archiving the binary logs")
+)
+
+// binlogArchiveRetryDelay is the delay before restarting the archiver
+// after an error.
+const binlogArchiveRetryDelay = 10 * time.Second
+
+// errBinlogArchiverStopped is the error of the archive consumer of the
+// binary logs while the archiver isn't running.
+var errBinlogArchiverStopped = errors.New("the binlog archiver is not running")
+
+// binlogArchiver streams the binary logs of the master to the binlog
+// archive of the shard, while the tablet is the master.
+type binlogArchiver struct {
+	ctx context.Context
+	tm  *TabletManager
+
+	// mu protects the fields below.
+	mu sync.Mutex
+	// cancel and done are set while the archiver is running.
+	cancel context.CancelFunc
+	done   chan struct{}
+	// writer is set while the archiver is streaming.
+	writer *binlogarchive.Writer
+}
+
+func newBinlogArchiver(ctx context.Context, tm *TabletManager) *binlogArchiver {
+	return &binlogArchiver{
+		ctx: ctx,
+		tm:  tm,
+	}
+}
+
+// SetTabletType starts the archiver if the tablet is the master, and
+// stops it otherwise.
+func (ba *binlogArchiver) SetTabletType(tabletType topodatapb.TabletType) {
+	if !*binlogArchive {
+		return
+	}
+	ba.mu.Lock()
+	defer ba.mu.Unlock()
+
+	if tabletType != topodatapb.TabletType_MASTER {
+		if ba.cancel == nil {
+			return
+		}
+		log.Info("Binlog archiver: stopping")
+		ba.cancel()
+		// Wait for the last segment to be written, so the next master
+		// starts from its end.
+		done := ba.done
+		ba.cancel, ba.done = nil, nil
+		ba.mu.Unlock()
+		<-done
+		ba.mu.Lock()
+		return
+	}
+	if ba.cancel != nil {
+		return
+	}
+	log.Info("Binlog archiver: starting")
+	ctx, cancel := context.WithCancel(ba.ctx)
+	ba.cancel = cancel
+	ba.done = make(chan struct{})
+	go ba.run(ctx, ba.done)
+}
+
+// Position returns the position up to which the binary logs are archived.
+func (ba *binlogArchiver) Position() (mysql.Position, error) {
+	ba.mu.Lock()
+	defer ba.mu.Unlock()
+	if ba.writer == nil {
+		return mysql.Position{}, errBinlogArchiverStopped
+	}
+	return ba.writer.Position(), nil
+}
+
+func (ba *binlogArchiver) run(ctx context.Context, done chan struct{}) {
+	defer close(done)
+	for {
+		if err := ba.archive(ctx); err != nil {
+			binlogArchiveErrors.Add(1)
+			log.Warningf("Cannot archive the binary logs: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(binlogArchiveRetryDelay):
+		}
+	}
+}
+
+// archive streams the binary logs from the end of the archive, or from the
+// current position if it is empty, until an error or ctx is done.
+func (ba *binlogArchiver) archive(ctx context.Context) error {
+	bs, err := backupstorage.GetBackupStorage()
+	if err != nil {
+		return err
+	}
+	defer bs.Close()
+
+	tablet := ba.tm.Tablet()
+	startPos, err := binlogarchive.EndPosition(ctx, bs, tablet.Keyspace, tablet.Shard)
+	if err != nil {
+		return err
+	}
+	if startPos.IsZero() {
+		if startPos, err = ba.tm.MysqlDaemon.PrimaryPosition(); err != nil {
+			return err
+		}
+	}
+
+	bc, err := binlog.NewBinlogConnection(ba.tm.DBConfigs.DbaWithDB())
+	if err != nil {
+		return err
+	}
+	defer bc.Close()
+	events, err := bc.StartBinlogDumpFromPosition(ctx, startPos)
+	if err != nil {
+		return err
+	}
+
+	writer := binlogarchive.NewWriter(bs, tablet.Keyspace, tablet.Shard, startPos, *binlogArchiveSegmentDuration)
+	ba.mu.Lock()
+	ba.writer = writer
+	ba.mu.Unlock()
+	defer func() {
+		ba.mu.Lock()
+		ba.writer = nil
+		ba.mu.Unlock()
+	}()
+	// The last segment is written even if ctx is done.
+	defer func() {
+		if err := writer.Close(ba.ctx); err != nil {
+			log.Warningf("Cannot end the binlog archive segment: %v", err)
+		}
+	}()
+
+	rotate := time.NewTicker(*binlogArchiveSegmentDuration / 10)
+	defer rotate.Stop()
+	for {
+		select {
+		case ev, ok := <-events:
+			if !ok {
+				if ctx.Err() != nil {
+					return nil
+				}
+				return errors.New("binlog stream ended")
+			}
+			if err := writer.Write(ctx, ev); err != nil {
+				return err
+			}
+		case <-rotate.C:
+			if err := writer.Rotate(ctx); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
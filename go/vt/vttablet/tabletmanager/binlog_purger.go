@@ -0,0 +1,370 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletmanager
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/encoding/prototext"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/timer"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/mysqlctl"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vttablet/tmclient"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+var (
+	binlogPurgeInterval         = flag.Duration("binlog_purge_interval", 0, "if set, the master purges its binary logs at this interval, except the last -binlog_purge_retain_count ones and the ones still needed by a replica, a vstream or a vreplication stream")
+	binlogPurgeRetainCount      = flag.Int("binlog_purge_retain_count", 10, "number of the most recent binary logs which are never purged by -binlog_purge_interval")
+	binlogPurgeSkipSafetyChecks = flag.Bool("binlog_purge_skip_safety_checks", false, "if set, -binlog_purge_interval purges the binary logs without checking if the replicas, the vstreams and the vreplication streams still need them")
+
+	binlogPurges         = stats.NewCounter("BinlogPurges", "Number of binary log purges")
+	binlogPurgeDeferrals = stats.NewCountersWithSingleLabel("BinlogPurgeDeferrals", "Number of binary log purges deferred because a consumer still needs the binary logs, by consumer type", "consumer")
+)
+
+// binlogPurgeTimeout bounds a run of the binlogPurger.
+const binlogPurgeTimeout = 1 * time.Minute
+
+// The types of the consumers of the binary logs.
+const (
+	binlogConsumerReplica      = "replica"
+	binlogConsumerVStream      = "vstream"
+	binlogConsumerVReplication = "vreplication"
+	binlogConsumerArchive      = "archive"
+	binlogConsumerIncremental  = "incremental_backup"
+)
+
+// binlogPurger runs a poller which purges the old binary logs while the
+// tablet is the master. Before purging, it checks that the replicas of the
+// shard, the vstreams of the tablet, the vreplication streams whose source
+// is the shard and the binlog archiver have all read past the purged GTIDs. Otherwise, the
+// purge is deferred to the next run.
+type binlogPurger struct {
+	ctx   context.Context
+	tm    *TabletManager
+	ticks *timer.Timer
+
+	// tmc is created on the first run. It's only used by the ticks.
+	tmc tmclient.TabletManagerClient
+}
+
+// binlogConsumer is a consumer of the binary logs of the master, with the
+// position up to which it has read them.
+type binlogConsumer struct {
+	kind string
+	name string
+	pos  mysql.Position
+	// err is set if the position of the consumer is unknown.
+	err error
+}
+
+func newBinlogPurger(ctx context.Context, tm *TabletManager, interval time.Duration) *binlogPurger {
+	return &binlogPurger{
+		ctx:   ctx,
+		tm:    tm,
+		ticks: timer.NewTimer(interval),
+	}
+}
+
+// SetTabletType starts the purger ticks if the tablet is the master, and
+// stops them otherwise.
+func (bp *binlogPurger) SetTabletType(tabletType topodatapb.TabletType) {
+	if bp.ticks.Interval() == 0 {
+		return
+	}
+	if tabletType != topodatapb.TabletType_MASTER {
+		bp.ticks.Stop()
+		return
+	}
+	if bp.ticks.Running() {
+		return
+	}
+	log.Info("Binlog purger: starting")
+	bp.ticks.Start(bp.check)
+}
+
+func (bp *binlogPurger) check() {
+	ctx, cancel := context.WithTimeout(bp.ctx, binlogPurgeTimeout)
+	defer cancel()
+	if err := bp.purge(ctx); err != nil {
+		log.Warningf("Cannot purge the binary logs: %v", err)
+	}
+}
+
+// purge purges the binary logs before the last -binlog_purge_retain_count
+// ones, unless a consumer still needs them.
+func (bp *binlogPurger) purge(ctx context.Context) error {
+	qr, err := bp.tm.MysqlDaemon.FetchSuperQuery(ctx, "SHOW BINARY LOGS")
+	if err != nil {
+		return err
+	}
+	var files []string
+	for _, row := range qr.Rows {
+		files = append(files, row[0].ToString())
+	}
+	target := binlogPurgeTarget(files, *binlogPurgeRetainCount)
+	if target == "" {
+		return nil
+	}
+
+	if !*binlogPurgeSkipSafetyChecks {
+		purged, err := bp.purgedPosition(ctx, target)
+		if err != nil {
+			return err
+		}
+		if consumer, reason := firstBlockingConsumer(bp.consumers(ctx), purged); consumer != nil {
+			binlogPurgeDeferrals.Add(consumer.kind, 1)
+			log.Infof("Deferring the purge of the binary logs before %v: %v", target, reason)
+			return nil
+		}
+	}
+
+	if err := bp.tm.MysqlDaemon.ExecuteSuperQueryList(ctx, []string{
+		fmt.Sprintf("PURGE BINARY LOGS TO %s", sqltypes.EncodeStringSQL(target)),
+	}); err != nil {
+		return err
+	}
+	binlogPurges.Add(1)
+	log.Infof("Purged the binary logs before %v", target)
+	return nil
+}
+
+// binlogPurgeTarget returns the first binary log to keep, or "" if there
+// is nothing to purge. files are the binary logs, from the oldest.
+func binlogPurgeTarget(files []string, retainCount int) string {
+	if retainCount < 1 {
+		// The current binary log can't be purged anyway.
+		retainCount = 1
+	}
+	if len(files) <= retainCount {
+		return ""
+	}
+	return files[len(files)-retainCount]
+}
+
+// purgedPosition returns the position covering all the GTIDs of the binary
+// logs before target, i.e. the Previous_gtids event at the start of target.
+func (bp *binlogPurger) purgedPosition(ctx context.Context, target string) (mysql.Position, error) {
+	qr, err := bp.tm.MysqlDaemon.FetchSuperQuery(ctx, fmt.Sprintf("SHOW BINLOG EVENTS IN %s LIMIT 2", sqltypes.EncodeStringSQL(target)))
+	if err != nil {
+		return mysql.Position{}, err
+	}
+	// The columns are Log_name, Pos, Event_type, Server_id, End_log_pos
+	// and Info.
+	for _, row := range qr.Rows {
+		if len(row) < 6 || row[2].ToString() != "Previous_gtids" {
+			continue
+		}
+		gtids := strings.Join(strings.Fields(row[5].ToString()), "")
+		return mysql.ParsePosition(mysql.Mysql56FlavorID, gtids)
+	}
+	return mysql.Position{}, fmt.Errorf("no Previous_gtids event at the start of binary log %v, the safety checks need MySQL GTIDs", target)
+}
+
+// firstBlockingConsumer returns the first consumer which didn't read all
+// the purged GTIDs yet, or whose position is unknown, with the reason.
+func firstBlockingConsumer(consumers []*binlogConsumer, purged mysql.Position) (*binlogConsumer, string) {
+	for _, c := range consumers {
+		if c.err != nil {
+			return c, fmt.Sprintf("cannot get the position of %v: %v", c.name, c.err)
+		}
+		if !c.pos.AtLeast(purged) {
+			return c, fmt.Sprintf("%v is at %v, which doesn't include the purged GTIDs %v", c.name, c.pos, purged)
+		}
+	}
+	return nil, ""
+}
+
+// consumers returns the consumers of the binary logs of the master.
+func (bp *binlogPurger) consumers(ctx context.Context) []*binlogConsumer {
+	if bp.tmc == nil {
+		bp.tmc = tmclient.NewTabletManagerClient()
+	}
+	consumers := bp.vstreamConsumers()
+	consumers = append(consumers, bp.replicaConsumers(ctx)...)
+	consumers = append(consumers, bp.vreplicationConsumers(ctx)...)
+	if *binlogArchive {
+		consumers = append(consumers, bp.archiveConsumer())
+	}
+	if *incrementalBackupInterval != 0 {
+		consumers = append(consumers, bp.incrementalBackupConsumer(ctx))
+	}
+	return consumers
+}
+
+// incrementalBackupConsumer returns the incremental backups of the
+// tablet, which need the binary logs after the most recent backup of the
+// shard.
+func (bp *binlogPurger) incrementalBackupConsumer(ctx context.Context) *binlogConsumer {
+	tablet := bp.tm.Tablet()
+	consumer := &binlogConsumer{
+		kind: binlogConsumerIncremental,
+		name: "the incremental backups",
+	}
+	consumer.pos, consumer.err = mysqlctl.FindLatestBackupPosition(ctx, tablet.Keyspace, tablet.Shard)
+	return consumer
+}
+
+// archiveConsumer returns the binlog archiver, whose position is unknown
+// while it isn't running.
+func (bp *binlogPurger) archiveConsumer() *binlogConsumer {
+	consumer := &binlogConsumer{
+		kind: binlogConsumerArchive,
+		name: "the binlog archiver",
+	}
+	consumer.pos, consumer.err = bp.tm.binlogArchiver.Position()
+	return consumer
+}
+
+// vstreamConsumers returns the vstreams running on this tablet.
+func (bp *binlogPurger) vstreamConsumers() []*binlogConsumer {
+	var consumers []*binlogConsumer
+	for i, pos := range bp.tm.QueryServiceControl.VStreamerPositions() {
+		consumers = append(consumers, &binlogConsumer{
+			kind: binlogConsumerVStream,
+			name: fmt.Sprintf("vstream %v", i),
+			pos:  pos,
+		})
+	}
+	return consumers
+}
+
+// replicaConsumers returns the other tablets of the shard, with the
+// position up to which they received the binary logs.
+func (bp *binlogPurger) replicaConsumers(ctx context.Context) []*binlogConsumer {
+	tablet := bp.tm.Tablet()
+	tabletMap, err := bp.tm.TopoServer.GetTabletMapForShard(ctx, tablet.Keyspace, tablet.Shard)
+	if err != nil {
+		return []*binlogConsumer{{
+			kind: binlogConsumerReplica,
+			name: fmt.Sprintf("the tablets of %v/%v", tablet.Keyspace, tablet.Shard),
+			err:  err,
+		}}
+	}
+
+	var consumers []*binlogConsumer
+	var wg sync.WaitGroup
+	for _, ti := range tabletMap {
+		if topoproto.TabletAliasEqual(ti.Alias, tablet.Alias) || ti.Type == topodatapb.TabletType_MASTER {
+			continue
+		}
+		consumer := &binlogConsumer{
+			kind: binlogConsumerReplica,
+			name: fmt.Sprintf("replica %v", ti.AliasString()),
+		}
+		consumers = append(consumers, consumer)
+		wg.Add(1)
+		go func(replica *topodatapb.Tablet) {
+			defer wg.Done()
+			status, err := bp.tmc.ReplicationStatus(ctx, replica)
+			if err != nil {
+				consumer.err = err
+				return
+			}
+			// The replica needs the binary logs after what its IO thread
+			// received, which may be ahead of what it applied.
+			pos := status.RelayLogPosition
+			if pos == "" {
+				pos = status.Position
+			}
+			consumer.pos, consumer.err = mysql.DecodePosition(pos)
+		}(ti.Tablet)
+	}
+	wg.Wait()
+	return consumers
+}
+
+// vreplicationConsumers returns the vreplication streams, on the masters
+// of all the keyspaces, whose source is the shard of this tablet. The
+// frozen streams are skipped.
+func (bp *binlogPurger) vreplicationConsumers(ctx context.Context) []*binlogConsumer {
+	tablet := bp.tm.Tablet()
+	keyspaces, err := bp.tm.TopoServer.GetKeyspaces(ctx)
+	if err != nil {
+		return []*binlogConsumer{{
+			kind: binlogConsumerVReplication,
+			name: "the keyspaces",
+			err:  err,
+		}}
+	}
+
+	var consumers []*binlogConsumer
+	for _, keyspace := range keyspaces {
+		shards, err := bp.tm.TopoServer.FindAllShardsInKeyspace(ctx, keyspace)
+		if err != nil {
+			consumers = append(consumers, &binlogConsumer{
+				kind: binlogConsumerVReplication,
+				name: fmt.Sprintf("the shards of %v", keyspace),
+				err:  err,
+			})
+			continue
+		}
+		for _, si := range shards {
+			if si.MasterAlias == nil {
+				continue
+			}
+			consumers = append(consumers, bp.shardVReplicationConsumers(ctx, si.MasterAlias, tablet.Keyspace, tablet.Shard)...)
+		}
+	}
+	return consumers
+}
+
+// shardVReplicationConsumers returns the vreplication streams of the
+// master whose source is keyspace/shard.
+func (bp *binlogPurger) shardVReplicationConsumers(ctx context.Context, masterAlias *topodatapb.TabletAlias, keyspace, shard string) []*binlogConsumer {
+	name := fmt.Sprintf("the vreplication streams of %v", topoproto.TabletAliasString(masterAlias))
+	master, err := bp.tm.TopoServer.GetTablet(ctx, masterAlias)
+	if err != nil {
+		return []*binlogConsumer{{kind: binlogConsumerVReplication, name: name, err: err}}
+	}
+	p3qr, err := bp.tmc.VReplicationExec(ctx, master.Tablet, "select id, workflow, source, pos from _vt.vreplication where message != 'FROZEN'")
+	if err != nil {
+		return []*binlogConsumer{{kind: binlogConsumerVReplication, name: name, err: err}}
+	}
+	qr := sqltypes.Proto3ToResult(p3qr)
+
+	var consumers []*binlogConsumer
+	for _, row := range qr.Rows {
+		var bls binlogdatapb.BinlogSource
+		if err := prototext.Unmarshal(row[2].ToBytes(), &bls); err != nil {
+			consumers = append(consumers, &binlogConsumer{kind: binlogConsumerVReplication, name: name, err: err})
+			continue
+		}
+		if bls.Keyspace != keyspace || bls.Shard != shard {
+			continue
+		}
+		consumer := &binlogConsumer{
+			kind: binlogConsumerVReplication,
+			name: fmt.Sprintf("vreplication stream %v of workflow %v on %v", row[0].ToString(), row[1].ToString(), master.AliasString()),
+		}
+		consumer.pos, consumer.err = mysql.DecodePosition(row[3].ToString())
+		consumers = append(consumers, consumer)
+	}
+	return consumers
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletmanager
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/mysqlctl/fakemysqldaemon"
+	"vitess.io/vitess/go/vt/vttablet/tabletservermock"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func TestBinlogPurgerSetTabletType(t *testing.T) {
+	tm := &TabletManager{}
+
+	// A zero interval disables the purger.
+	bp := newBinlogPurger(context.Background(), tm, 0)
+	bp.SetTabletType(topodatapb.TabletType_MASTER)
+	assert.False(t, bp.ticks.Running())
+
+	bp = newBinlogPurger(context.Background(), tm, time.Hour)
+	bp.SetTabletType(topodatapb.TabletType_MASTER)
+	assert.True(t, bp.ticks.Running())
+	bp.SetTabletType(topodatapb.TabletType_REPLICA)
+	assert.False(t, bp.ticks.Running())
+}
+
+func TestBinlogPurgeTarget(t *testing.T) {
+	files := []string{"bin.000001", "bin.000002", "bin.000003"}
+	assert.Equal(t, "bin.000002", binlogPurgeTarget(files, 2))
+	assert.Equal(t, "bin.000003", binlogPurgeTarget(files, 1))
+	assert.Equal(t, "bin.000003", binlogPurgeTarget(files, 0))
+	assert.Equal(t, "", binlogPurgeTarget(files, 3))
+	assert.Equal(t, "", binlogPurgeTarget(nil, 1))
+}
+
+func TestBinlogPurgerPurgedPosition(t *testing.T) {
+	fmd := fakemysqldaemon.NewFakeMysqlDaemon(nil)
+	fmd.FetchSuperQueryMap = map[string]*sqltypes.Result{
+		"SHOW BINLOG EVENTS IN 'bin.000002' LIMIT 2": sqltypes.MakeTestResult(
+			sqltypes.MakeTestFields("Log_name|Pos|Event_type|Server_id|End_log_pos|Info", "varchar|int64|varchar|int64|int64|varchar"),
+			"bin.000002|4|Format_desc|1|125|Server ver: 8.0.23, Binlog ver: 4",
+			"bin.000002|125|Previous_gtids|1|196|00010203-0405-0607-0809-0a0b0c0d0e0f:1-10,\n00010203-0405-0607-0809-0a0b0c0d0e10:1-5",
+		),
+		"SHOW BINLOG EVENTS IN 'bin.000003' LIMIT 2": sqltypes.MakeTestResult(
+			sqltypes.MakeTestFields("Log_name|Pos|Event_type|Server_id|End_log_pos|Info", "varchar|int64|varchar|int64|int64|varchar"),
+			"bin.000003|4|Format_desc|1|125|Server ver: 8.0.23, Binlog ver: 4",
+		),
+	}
+	bp := newBinlogPurger(context.Background(), &TabletManager{MysqlDaemon: fmd}, 0)
+
+	pos, err := bp.purgedPosition(context.Background(), "bin.000002")
+	require.NoError(t, err)
+	want, err := mysql.ParsePosition(mysql.Mysql56FlavorID, "00010203-0405-0607-0809-0a0b0c0d0e0f:1-10,00010203-0405-0607-0809-0a0b0c0d0e10:1-5")
+	require.NoError(t, err)
+	assert.True(t, pos.Equal(want), "got %v, want %v", pos, want)
+
+	_, err = bp.purgedPosition(context.Background(), "bin.000003")
+	assert.Error(t, err)
+}
+
+func TestFirstBlockingConsumer(t *testing.T) {
+	purged := mysql.MustParsePosition(mysql.Mysql56FlavorID, "00010203-0405-0607-0809-0a0b0c0d0e0f:1-10")
+	ahead := &binlogConsumer{kind: binlogConsumerReplica, name: "ahead", pos: mysql.MustParsePosition(mysql.Mysql56FlavorID, "00010203-0405-0607-0809-0a0b0c0d0e0f:1-20")}
+	behind := &binlogConsumer{kind: binlogConsumerVStream, name: "behind", pos: mysql.MustParsePosition(mysql.Mysql56FlavorID, "00010203-0405-0607-0809-0a0b0c0d0e0f:1-5")}
+	unknown := &binlogConsumer{kind: binlogConsumerVReplication, name: "unknown", err: errors.New("unreachable")}
+
+	consumer, _ := firstBlockingConsumer([]*binlogConsumer{ahead}, purged)
+	assert.Nil(t, consumer)
+	consumer, reason := firstBlockingConsumer([]*binlogConsumer{ahead, behind, unknown}, purged)
+	assert.Equal(t, behind, consumer)
+	assert.Contains(t, reason, "behind")
+	consumer, reason = firstBlockingConsumer([]*binlogConsumer{ahead, unknown}, purged)
+	assert.Equal(t, unknown, consumer)
+	assert.Contains(t, reason, "unreachable")
+}
+
+func TestBinlogPurgerVStreamConsumers(t *testing.T) {
+	pos := mysql.MustParsePosition(mysql.Mysql56FlavorID, "00010203-0405-0607-0809-0a0b0c0d0e0f:1-10")
+	qsc := tabletservermock.NewController()
+	qsc.VStreamerPos = []mysql.Position{pos}
+	bp := newBinlogPurger(context.Background(), &TabletManager{QueryServiceControl: qsc}, 0)
+
+	consumers := bp.vstreamConsumers()
+	require.Len(t, consumers, 1)
+	assert.Equal(t, binlogConsumerVStream, consumers[0].kind)
+	assert.True(t, consumers[0].pos.Equal(pos))
+}
+
+func TestBinlogPurgerSkipSafetyChecks(t *testing.T) {
+	defer func(saved bool) { *binlogPurgeSkipSafetyChecks = saved }(*binlogPurgeSkipSafetyChecks)
+	*binlogPurgeSkipSafetyChecks = true
+	defer func(saved int) { *binlogPurgeRetainCount = saved }(*binlogPurgeRetainCount)
+	*binlogPurgeRetainCount = 1
+
+	fmd := fakemysqldaemon.NewFakeMysqlDaemon(nil)
+	fmd.FetchSuperQueryMap = map[string]*sqltypes.Result{
+		"SHOW BINARY LOGS": sqltypes.MakeTestResult(
+			sqltypes.MakeTestFields("Log_name|File_size", "varchar|int64"),
+			"bin.000001|1000",
+			"bin.000002|2000",
+		),
+	}
+	fmd.ExpectedExecuteSuperQueryList = []string{"PURGE BINARY LOGS TO 'bin.000002'"}
+	bp := newBinlogPurger(context.Background(), &TabletManager{MysqlDaemon: fmd}, 0)
+
+	purges := binlogPurges.Get()
+	require.NoError(t, bp.purge(context.Background()))
+	assert.NoError(t, fmd.CheckSuperQueryList())
+	assert.Equal(t, purges+1, binlogPurges.Get())
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletmanager
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/timer"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/mysqlctl"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+var (
+	incrementalBackupInterval = flag.Duration("incremental_backup_interval", 0, "if set, the tablet backs up its binary logs at this interval, in incremental backups layered on the most recent backup of its shard, so the shard can be restored to any point in time since with -restore_to_pos or -restore_to_timestamp. It should be set on a single tablet of each shard")
+
+	incrementalBackups      = stats.NewCounter("IncrementalBackups", "Number of incremental backups")
+	incrementalBackupErrors = stats.NewCounter("IncrementalBackupErrors", "Number of failed incremental backups")
+)
+
+const (
+	// incrementalBackupTimeout bounds an incremental backup.
+	incrementalBackupTimeout = 1 * time.Hour
+	// incrementalBackupConcurrency is the number of binary logs backed up
+	// at the same time.
+	incrementalBackupConcurrency = 4
+)
+
+// incrementalBackupper runs a poller which takes the incremental backups
+// of the tablet while it is serving. Each backup holds the binary logs
+// since the position of the most recent backup of the shard.
+type incrementalBackupper struct {
+	ctx   context.Context
+	tm    *TabletManager
+	ticks *timer.Timer
+}
+
+func newIncrementalBackupper(ctx context.Context, tm *TabletManager, interval time.Duration) *incrementalBackupper {
+	return &incrementalBackupper{
+		ctx:   ctx,
+		tm:    tm,
+		ticks: timer.NewTimer(interval),
+	}
+}
+
+// SetTabletType starts the backupper ticks if the tablet is serving, and
+// stops them otherwise.
+func (ib *incrementalBackupper) SetTabletType(tabletType topodatapb.TabletType) {
+	if ib.ticks.Interval() == 0 {
+		return
+	}
+	switch tabletType {
+	case topodatapb.TabletType_MASTER, topodatapb.TabletType_REPLICA, topodatapb.TabletType_RDONLY, topodatapb.TabletType_SPARE:
+	default:
+		ib.ticks.Stop()
+		return
+	}
+	if ib.ticks.Running() {
+		return
+	}
+	log.Info("Incremental backupper: starting")
+	ib.ticks.Start(ib.check)
+}
+
+func (ib *incrementalBackupper) check() {
+	ctx, cancel := context.WithTimeout(ib.ctx, incrementalBackupTimeout)
+	defer cancel()
+	if err := ib.backup(ctx); err != nil {
+		incrementalBackupErrors.Add(1)
+		log.Warningf("Cannot take an incremental backup: %v", err)
+	}
+}
+
+// backup takes an incremental backup, unless the shard has no backup to
+// layer it on, or there is no transaction since its most recent backup.
+func (ib *incrementalBackupper) backup(ctx context.Context) error {
+	tm := ib.tm
+	if tm.Cnf == nil {
+		return fmt.Errorf("cannot perform backup without my.cnf, please restart vttablet with a my.cnf file specified")
+	}
+	if err := tm.checkNotSharedMysqld("IncrementalBackup"); err != nil {
+		return err
+	}
+	tablet := tm.Tablet()
+	fromPos, err := mysqlctl.FindLatestBackupPosition(ctx, tablet.Keyspace, tablet.Shard)
+	switch {
+	case err == mysqlctl.ErrNoBackup:
+		log.Infof("Skipping the incremental backup: shard %v/%v has no backup to layer it on", tablet.Keyspace, tablet.Shard)
+		return nil
+	case err != nil:
+		return err
+	}
+	pos, err := tm.MysqlDaemon.PrimaryPosition()
+	if err != nil {
+		return err
+	}
+	if fromPos.AtLeast(pos) {
+		return nil
+	}
+
+	if err := tm.beginBackup(backupModeOnline); err != nil {
+		return err
+	}
+	defer tm.endBackup(backupModeOnline)
+	err = mysqlctl.Backup(ctx, mysqlctl.BackupParams{
+		Cnf:                tm.Cnf,
+		Mysqld:             tm.MysqlDaemon,
+		Logger:             logutil.NewConsoleLogger(),
+		Concurrency:        incrementalBackupConcurrency,
+		HookExtraEnv:       tm.hookExtraEnv(),
+		TopoServer:         tm.TopoServer,
+		Keyspace:           tablet.Keyspace,
+		Shard:              tablet.Shard,
+		TabletAlias:        topoproto.TabletAliasString(tablet.Alias),
+		BackupTime:         time.Now(),
+		IncrementalFromPos: fromPos,
+	})
+	if err != nil {
+		return err
+	}
+	incrementalBackups.Add(1)
+	return nil
+}
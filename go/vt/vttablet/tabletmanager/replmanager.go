@@ -68,7 +68,7 @@ func newReplManager(ctx context.Context, tm *TabletManager, interval time.Durati
 // SetTabletType starts/stops the replication manager ticks based on the tablet type provided.
 // It stops the ticks if the tablet type is not a replica type, starts the ticks otherwise.
 func (rm *replManager) SetTabletType(tabletType topodatapb.TabletType) {
-	if *mysqlctl.DisableActiveReparents {
+	if activeReparentsDisabled() {
 		return
 	}
 	if !topo.IsReplicaType(tabletType) {
@@ -135,7 +135,7 @@ func (rm *replManager) checkActionLocked() {
 // setReplicationStopped performs a best effort attempt of
 // remembering a decision to stop replication.
 func (rm *replManager) setReplicationStopped(stopped bool) {
-	if *mysqlctl.DisableActiveReparents {
+	if activeReparentsDisabled() {
 		return
 	}
 
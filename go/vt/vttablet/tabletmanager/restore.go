@@ -48,6 +48,8 @@ var (
 	restoreFromBackup     = flag.Bool("restore_from_backup", false, "(init restore parameter) will check BackupStorage for a recent backup at startup and start there")
 	restoreConcurrency    = flag.Int("restore_concurrency", 4, "(init restore parameter) how many concurrent files to restore at once")
 	waitForBackupInterval = flag.Duration("wait_for_backup_interval", 0, "(init restore parameter) if this is greater than 0, instead of starting up empty when no backups are found, keep checking at this interval for a backup to appear")
+	restoreToPos          = flag.String("restore_to_pos", "", "(init restore parameter) if set, restore to this GTID position: the most recent full backup before it is restored, then the binary logs of the incremental backups are replayed up to it, and replication isn't started")
+	restoreToTimestamp    = flag.String("restore_to_timestamp", "", "(init restore parameter) if set, restore to this time, in RFC 3339 format: the most recent full backup before it is restored, then the binary logs of the incremental backups are replayed up to it, and replication isn't started")
 
 	// Flags for PITR
 	binlogHost           = flag.String("binlog_host", "", "PITR restore parameter: hostname/IP of binlog server.")
@@ -73,6 +75,9 @@ func (tm *TabletManager) RestoreData(ctx context.Context, logger logutil.Logger,
 	if tm.Cnf == nil {
 		return fmt.Errorf("cannot perform restore without my.cnf, please restart vttablet with a my.cnf file specified")
 	}
+	if err := tm.checkNotSharedMysqld("Restore"); err != nil {
+		return err
+	}
 	// Tell Orchestrator we're stopped on purpose for some Vitess task.
 	// Do this in the background, as it's best-effort.
 	go func() {
@@ -175,6 +180,9 @@ func (tm *TabletManager) restoreDataLocked(ctx context.Context, logger logutil.L
 		Shard:               tablet.Shard,
 		StartTime:           logutil.ProtoToTime(keyspaceInfo.SnapshotTime),
 	}
+	if err := setRestorePointInTime(&params); err != nil {
+		return err
+	}
 
 	// Check whether we're going to restore before changing to RESTORE type,
 	// so we keep our MasterTermStartTime (if any) if we aren't actually restoring.
@@ -234,7 +242,12 @@ func (tm *TabletManager) restoreDataLocked(ctx context.Context, logger logutil.L
 	case nil:
 		// Starting from here we won't be able to recover if we get stopped by a cancelled
 		// context. Thus we use the background context to get through to the finish.
-		if keyspaceInfo.KeyspaceType == topodatapb.KeyspaceType_NORMAL {
+		switch {
+		case params.IsPointInTime():
+			// The tablet stays at the point in time: keep the replication
+			// manager from reconnecting it to the master.
+			tm.replManager.setReplicationStopped(true)
+		case keyspaceInfo.KeyspaceType == topodatapb.KeyspaceType_NORMAL:
 			// Reconnect to master only for "NORMAL" keyspaces
 			if err := tm.startReplication(context.Background(), pos, originalType); err != nil {
 				return err
@@ -263,6 +276,29 @@ func (tm *TabletManager) restoreDataLocked(ctx context.Context, logger logutil.L
 	return tm.tmState.ChangeTabletType(ctx, originalType, DBActionNone)
 }
 
+// setRestorePointInTime sets the point in time of the restore from the
+// -restore_to_pos and -restore_to_timestamp flags.
+func setRestorePointInTime(params *mysqlctl.RestoreParams) error {
+	if *restoreToPos != "" && *restoreToTimestamp != "" {
+		return fmt.Errorf("only one of -restore_to_pos and -restore_to_timestamp can be set")
+	}
+	if *restoreToPos != "" {
+		pos, err := mysql.DecodePosition(*restoreToPos)
+		if err != nil {
+			return vterrors.Wrapf(err, "invalid -restore_to_pos %q", *restoreToPos)
+		}
+		params.RestoreToPos = pos
+	}
+	if *restoreToTimestamp != "" {
+		t, err := time.Parse(time.RFC3339, *restoreToTimestamp)
+		if err != nil {
+			return vterrors.Wrapf(err, "invalid -restore_to_timestamp %q", *restoreToTimestamp)
+		}
+		params.RestoreToTimestamp = t
+	}
+	return nil
+}
+
 // restoreToTimeFromBinlog restores to the snapshot time of the keyspace
 // currently this works with mysql based database only (as it uses mysql specific queries for restoring)
 func (tm *TabletManager) restoreToTimeFromBinlog(ctx context.Context, pos mysql.Position, restoreTime *vttime.Time) error {
@@ -58,6 +58,9 @@ func (tm *TabletManager) GetPermissions(ctx context.Context) (*tabletmanagerdata
 
 // SetReadOnly makes the mysql instance read-only or read-write.
 func (tm *TabletManager) SetReadOnly(ctx context.Context, rdonly bool) error {
+	if err := tm.checkNotSharedMysqld("SetReadOnly"); err != nil {
+		return err
+	}
 	if err := tm.lock(ctx); err != nil {
 		return err
 	}
@@ -74,6 +74,8 @@ type RPCTM interface {
 
 	ExecuteFetchAsDba(ctx context.Context, query []byte, dbName string, maxrows int, disableBinlogs bool, reloadSchema bool) (*querypb.QueryResult, error)
 
+	ExecuteFetchAsDbaMulti(ctx context.Context, queries [][]byte, dbName string, maxrows int, disableBinlogs bool, reloadSchema bool) ([]*querypb.QueryResult, error)
+
 	ExecuteFetchAsAllPrivs(ctx context.Context, query []byte, dbName string, maxrows int, reloadSchema bool) (*querypb.QueryResult, error)
 
 	ExecuteFetchAsApp(ctx context.Context, query []byte, maxrows int) (*querypb.QueryResult, error)
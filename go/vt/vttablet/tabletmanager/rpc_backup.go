@@ -40,6 +40,9 @@ func (tm *TabletManager) Backup(ctx context.Context, concurrency int, logger log
 	if tm.Cnf == nil {
 		return fmt.Errorf("cannot perform backup without my.cnf, please restart vttablet with a my.cnf file specified")
 	}
+	if err := tm.checkNotSharedMysqld("Backup"); err != nil {
+		return err
+	}
 
 	// Check tablet type current process has.
 	// During a network partition it is possible that from the topology perspective this is no longer the master,
@@ -152,6 +155,9 @@ func (tm *TabletManager) Backup(ctx context.Context, concurrency int, logger log
 
 // RestoreFromBackup deletes all local data and restores anew from the latest backup.
 func (tm *TabletManager) RestoreFromBackup(ctx context.Context, logger logutil.Logger) error {
+	if err := tm.checkNotSharedMysqld("RestoreFromBackup"); err != nil {
+		return err
+	}
 	if err := tm.lock(ctx); err != nil {
 		return err
 	}
@@ -58,16 +58,23 @@ func (tm *TabletManager) LockTables(ctx context.Context) error {
 		}
 	}()
 
-	// FTWRL is preferable, so we'll try that first
-	_, err = conn.ExecuteFetch("FLUSH TABLES WITH READ LOCK", 0, false)
-	if err != nil {
-		// as fall back, we can lock each individual table as well.
-		// this requires slightly less privileges but achieves the same effect
+	if *mysqlSharedInstance {
+		// FTWRL would also lock the databases of the other tablets of the
+		// shared mysqld, so only the tables of this tablet are locked.
 		err = tm.lockTablesUsingLockTables(conn)
+	} else {
+		// FTWRL is preferable, so we'll try that first
+		_, err = conn.ExecuteFetch("FLUSH TABLES WITH READ LOCK", 0, false)
 		if err != nil {
-			return err
+			// as fall back, we can lock each individual table as well.
+			// this requires slightly less privileges but achieves the same effect
+			log.Warningf("failed to lock tables with FTWRL - falling back to LOCK TABLES")
+			err = tm.lockTablesUsingLockTables(conn)
 		}
 	}
+	if err != nil {
+		return err
+	}
 	log.Infof("[%v] Tables locked", conn.ConnectionID)
 
 	tm._lockTablesConnection = conn
@@ -91,8 +98,6 @@ func (tm *TabletManager) LockTables(ctx context.Context) error {
 }
 
 func (tm *TabletManager) lockTablesUsingLockTables(conn *dbconnpool.DBConnection) error {
-	log.Warningf("failed to lock tables with FTWRL - falling back to LOCK TABLES")
-
 	// Ensure schema engine is Open. If vttablet came up in a non_serving role,
 	// the schema engine may not have been initialized. Open() is idempotent, so this
 	// is always safe
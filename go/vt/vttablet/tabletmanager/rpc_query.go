@@ -22,12 +22,18 @@ import (
 	"vitess.io/vitess/go/sqlescape"
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/vterrors"
 
 	querypb "vitess.io/vitess/go/vt/proto/query"
 )
 
 // ExecuteFetchAsDba will execute the given query, possibly disabling binlogs and reload schema.
 func (tm *TabletManager) ExecuteFetchAsDba(ctx context.Context, query []byte, dbName string, maxrows int, disableBinlogs bool, reloadSchema bool) (*querypb.QueryResult, error) {
+	dbName, err := tm.scopedDBName(dbName)
+	if err != nil {
+		return nil, err
+	}
+
 	// get a connection
 	conn, err := tm.MysqlDaemon.GetDbaConnection(ctx)
 	if err != nil {
@@ -71,8 +77,74 @@ func (tm *TabletManager) ExecuteFetchAsDba(ctx context.Context, query []byte, db
 	return sqltypes.ResultToProto3(result), err
 }
 
+// ExecuteFetchAsDbaMulti will execute the given queries in order on the same
+// connection, possibly disabling binlogs, and reloading the schema once after
+// the last one. It stops at the first query which fails.
+func (tm *TabletManager) ExecuteFetchAsDbaMulti(ctx context.Context, queries [][]byte, dbName string, maxrows int, disableBinlogs bool, reloadSchema bool) ([]*querypb.QueryResult, error) {
+	dbName, err := tm.scopedDBName(dbName)
+	if err != nil {
+		return nil, err
+	}
+
+	// get a connection
+	conn, err := tm.MysqlDaemon.GetDbaConnection(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	// disable binlogs if necessary
+	if disableBinlogs {
+		_, err := conn.ExecuteFetch("SET sql_log_bin = OFF", 0, false)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if dbName != "" {
+		// This execute might fail if db does not exist.
+		// Error is ignored because given queries might create this database.
+		conn.ExecuteFetch("USE "+sqlescape.EscapeID(dbName), 1, false)
+	}
+
+	// run the queries
+	results := make([]*querypb.QueryResult, 0, len(queries))
+	for i, query := range queries {
+		var result *sqltypes.Result
+		result, err = conn.ExecuteFetch(string(query), maxrows, true /*wantFields*/)
+		if err != nil {
+			err = vterrors.Wrapf(err, "query %d of %d failed: %s", i+1, len(queries), query)
+			break
+		}
+		results = append(results, sqltypes.ResultToProto3(result))
+	}
+
+	// re-enable binlogs if necessary
+	if disableBinlogs && !conn.IsClosed() {
+		_, err := conn.ExecuteFetch("SET sql_log_bin = ON", 0, false)
+		if err != nil {
+			// if we can't reset the sql_log_bin flag,
+			// let's just close the connection.
+			conn.Close()
+		}
+	}
+
+	if err == nil && reloadSchema {
+		reloadErr := tm.QueryServiceControl.ReloadSchema(ctx)
+		if reloadErr != nil {
+			log.Errorf("failed to reload the schema %v", reloadErr)
+		}
+	}
+	return results, err
+}
+
 // ExecuteFetchAsAllPrivs will execute the given query, possibly reloading schema.
 func (tm *TabletManager) ExecuteFetchAsAllPrivs(ctx context.Context, query []byte, dbName string, maxrows int, reloadSchema bool) (*querypb.QueryResult, error) {
+	dbName, err := tm.scopedDBName(dbName)
+	if err != nil {
+		return nil, err
+	}
+
 	// get a connection
 	conn, err := tm.MysqlDaemon.GetAllPrivsConnection(ctx)
 	if err != nil {
@@ -18,6 +18,7 @@ package tabletmanager
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"vitess.io/vitess/go/sqltypes"
@@ -50,3 +51,36 @@ func TestTabletManager_ExecuteFetchAsDba(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, "use ` escap``e me `;select 42", db.QueryLog())
 }
+
+func TestTabletManager_ExecuteFetchAsDbaMulti(t *testing.T) {
+	ctx := context.Background()
+	cp := mysql.ConnParams{}
+	db := fakesqldb.New(t)
+	db.AddQuery("select 1", sqltypes.MakeTestResult(sqltypes.MakeTestFields("a", "int64"), "1"))
+	db.AddQuery("select 2", sqltypes.MakeTestResult(sqltypes.MakeTestFields("a", "int64"), "2"))
+	db.AddRejectedQuery("select 3", errors.New("rejected"))
+	db.AddQueryPattern("use .*", &sqltypes.Result{})
+	daemon := fakemysqldaemon.NewFakeMysqlDaemon(db)
+
+	dbName := "db"
+	tm := &TabletManager{
+		MysqlDaemon:         daemon,
+		DBConfigs:           dbconfigs.NewTestDBConfigs(cp, cp, dbName),
+		QueryServiceControl: tabletservermock.NewController(),
+	}
+
+	// The queries run in order on the same connection.
+	qrs, err := tm.ExecuteFetchAsDbaMulti(ctx, [][]byte{[]byte("select 1"), []byte("select 2")}, dbName, 10, false, false)
+	require.NoError(t, err)
+	require.Len(t, qrs, 2)
+	require.Equal(t, "1", string(qrs[0].Rows[0].Values))
+	require.Equal(t, "2", string(qrs[1].Rows[0].Values))
+	require.Equal(t, "use `db`;select 1;select 2", db.QueryLog())
+
+	// The first query which fails stops the request.
+	db.ResetQueryLog()
+	_, err = tm.ExecuteFetchAsDbaMulti(ctx, [][]byte{[]byte("select 1"), []byte("select 3"), []byte("select 2")}, dbName, 10, false, false)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "query 2 of 3 failed: select 3")
+	require.Equal(t, "use `db`;select 1;select 3", db.QueryLog())
+}
@@ -93,6 +93,9 @@ func (tm *TabletManager) WaitForPosition(ctx context.Context, pos string) error
 // replication or not (using hook if not).
 func (tm *TabletManager) StopReplication(ctx context.Context) error {
 	log.Infof("StopReplication")
+	if err := tm.checkNotSharedMysqld("StopReplication"); err != nil {
+		return err
+	}
 	if err := tm.lock(ctx); err != nil {
 		return err
 	}
@@ -146,6 +149,9 @@ func (tm *TabletManager) stopIOThreadLocked(ctx context.Context) error {
 // replication or not (using hook if not).
 func (tm *TabletManager) StopReplicationMinimum(ctx context.Context, position string, waitTime time.Duration) (string, error) {
 	log.Infof("StopReplicationMinimum: position: %v waitTime: %v", position, waitTime)
+	if err := tm.checkNotSharedMysqld("StopReplicationMinimum"); err != nil {
+		return "", err
+	}
 	if err := tm.lock(ctx); err != nil {
 		return "", err
 	}
@@ -174,6 +180,9 @@ func (tm *TabletManager) StopReplicationMinimum(ctx context.Context, position st
 // replication or not (using hook if not).
 func (tm *TabletManager) StartReplication(ctx context.Context) error {
 	log.Infof("StartReplication")
+	if err := tm.checkNotSharedMysqld("StartReplication"); err != nil {
+		return err
+	}
 	if err := tm.lock(ctx); err != nil {
 		return err
 	}
@@ -202,6 +211,9 @@ func (tm *TabletManager) StartReplication(ctx context.Context) error {
 // until and including the transactions in `position`
 func (tm *TabletManager) StartReplicationUntilAfter(ctx context.Context, position string, waitTime time.Duration) error {
 	log.Infof("StartReplicationUntilAfter: position: %v waitTime: %v", position, waitTime)
+	if err := tm.checkNotSharedMysqld("StartReplicationUntilAfter"); err != nil {
+		return err
+	}
 	if err := tm.lock(ctx); err != nil {
 		return err
 	}
@@ -227,6 +239,9 @@ func (tm *TabletManager) GetReplicas(ctx context.Context) ([]string, error) {
 // All binary and relay logs are flushed. All replication positions are reset.
 func (tm *TabletManager) ResetReplication(ctx context.Context) error {
 	log.Infof("ResetReplication")
+	if err := tm.checkNotSharedMysqld("ResetReplication"); err != nil {
+		return err
+	}
 	if err := tm.lock(ctx); err != nil {
 		return err
 	}
@@ -244,6 +259,9 @@ func (tm *TabletManager) InitMaster(ctx context.Context) (string, error) {
 // InitPrimary enables writes and returns the replication position.
 func (tm *TabletManager) InitPrimary(ctx context.Context) (string, error) {
 	log.Infof("InitPrimary")
+	if err := tm.checkNotSharedMysqld("InitPrimary"); err != nil {
+		return "", err
+	}
 	if err := tm.lock(ctx); err != nil {
 		return "", err
 	}
@@ -298,6 +316,9 @@ func (tm *TabletManager) PopulateReparentJournal(ctx context.Context, timeCreate
 // reparent_journal table entry up to context timeout
 func (tm *TabletManager) InitReplica(ctx context.Context, parent *topodatapb.TabletAlias, position string, timeCreatedNS int64) error {
 	log.Infof("InitReplica: parent: %v  position: %v", parent, position)
+	if err := tm.checkNotSharedMysqld("InitReplica"); err != nil {
+		return err
+	}
 	if err := tm.lock(ctx); err != nil {
 		return err
 	}
@@ -362,6 +383,9 @@ func (tm *TabletManager) InitReplica(ctx context.Context, parent *topodatapb.Tab
 // If a step fails in the middle, it will try to undo any changes it made.
 func (tm *TabletManager) DemotePrimary(ctx context.Context) (*replicationdatapb.MasterStatus, error) {
 	log.Infof("DemotePrimary")
+	if err := tm.checkNotSharedMysqld("DemotePrimary"); err != nil {
+		return nil, err
+	}
 	// The public version always reverts on partial failure.
 	return tm.demotePrimary(ctx, true /* revertPartialFailure */)
 }
@@ -479,6 +503,9 @@ func (tm *TabletManager) UndoDemoteMaster(ctx context.Context) error {
 // and returns its master position.
 func (tm *TabletManager) UndoDemotePrimary(ctx context.Context) error {
 	log.Infof("UndoDemotePrimary")
+	if err := tm.checkNotSharedMysqld("UndoDemotePrimary"); err != nil {
+		return err
+	}
 	if err := tm.lock(ctx); err != nil {
 		return err
 	}
@@ -523,6 +550,9 @@ func (tm *TabletManager) ReplicaWasPromoted(ctx context.Context) error {
 // reparent_journal table entry up to context timeout
 func (tm *TabletManager) SetReplicationSource(ctx context.Context, parentAlias *topodatapb.TabletAlias, timeCreatedNS int64, waitPosition string, forceStartReplication bool) error {
 	log.Infof("SetReplicationSource: parent: %v  position: %v force: %v", parentAlias, waitPosition, forceStartReplication)
+	if err := tm.checkNotSharedMysqld("SetReplicationSource"); err != nil {
+		return err
+	}
 	if err := tm.lock(ctx); err != nil {
 		return err
 	}
@@ -690,6 +720,9 @@ func (tm *TabletManager) ReplicaWasRestarted(ctx context.Context, parent *topoda
 // current status.
 func (tm *TabletManager) StopReplicationAndGetStatus(ctx context.Context, stopReplicationMode replicationdatapb.StopReplicationMode) (StopReplicationAndGetStatusResponse, error) {
 	log.Infof("StopReplicationAndGetStatus: mode: %v", stopReplicationMode)
+	if err := tm.checkNotSharedMysqld("StopReplicationAndGetStatus"); err != nil {
+		return StopReplicationAndGetStatusResponse{}, err
+	}
 	if err := tm.lock(ctx); err != nil {
 		return StopReplicationAndGetStatusResponse{}, err
 	}
@@ -781,6 +814,9 @@ type StopReplicationAndGetStatusResponse struct {
 // PromoteReplica makes the current tablet the master
 func (tm *TabletManager) PromoteReplica(ctx context.Context) (string, error) {
 	log.Infof("PromoteReplica")
+	if err := tm.checkNotSharedMysqld("PromoteReplica"); err != nil {
+		return "", err
+	}
 	if err := tm.lock(ctx); err != nil {
 		return "", err
 	}
@@ -849,6 +885,10 @@ func (tm *TabletManager) fixSemiSyncAndReplication(tabletType topodatapb.TabletT
 		// Semi-sync handling is not enabled.
 		return nil
 	}
+	if *mysqlSharedInstance {
+		// The semi-sync of a shared mysqld is managed with its replication.
+		return nil
+	}
 
 	if tabletType == topodatapb.TabletType_MASTER {
 		// Master is special. It is always handled at the
@@ -0,0 +1,168 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletmanager
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/timer"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/mysqlctl"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
+)
+
+// A shared mysqld hosts the databases of several tablets, typically of
+// many tiny keyspaces consolidated on one instance. Each tablet runs its
+// queries in its own database, and refuses the operations which act on the
+// whole instance. The replication and read-only state of the instance are
+// shared too: as with -disable_active_reparents, they are managed outside
+// of Vitess, and the tablets only follow the reparents of the instance
+// with TabletExternallyReparented. A tablet doesn't restrict the
+// privileges of its db users: to isolate the databases from each other,
+// grant the users of each tablet access to its database only.
+
+var (
+	mysqlSharedInstance       = flag.Bool("mysql_shared_instance", false, "if set, the mysqld of the tablet also hosts the databases of other tablets: the queries of the tablet run in its database, the backups, restores, binary log purges, replication and read-only changes of the instance are refused, and its reparents must be external, and the resources used by the database are exported")
+	sharedMysqldStatsInterval = flag.Duration("mysql_shared_instance_stats_interval", 1*time.Minute, "with -mysql_shared_instance, the interval at which the resources used by the database of the tablet are read")
+
+	sharedMysqldDatabaseBytes = stats.NewGauge("SharedMysqldDatabaseBytes", "Size of the data and indexes of the database of the tablet in the shared mysqld")
+	sharedMysqldDatabaseRows  = stats.NewGauge("SharedMysqldDatabaseRows", "Estimated number of rows of the database of the tablet in the shared mysqld")
+	sharedMysqldConnections   = stats.NewGauge("SharedMysqldConnections", "Number of connections of the shared mysqld using the database of the tablet")
+	sharedMysqldRowsRead      = stats.NewGauge("SharedMysqldRowsRead", "Number of rows read from the tables of the database of the tablet since the shared mysqld started")
+	sharedMysqldRowsWritten   = stats.NewGauge("SharedMysqldRowsWritten", "Number of rows written to the tables of the database of the tablet since the shared mysqld started")
+)
+
+// sharedMysqldStatsTimeout bounds a read of the shared mysqld stats.
+const sharedMysqldStatsTimeout = 30 * time.Second
+
+// checkNotSharedMysqld returns an error if the mysqld of the tablet is
+// shared, as the operation acts on the whole instance.
+func (tm *TabletManager) checkNotSharedMysqld(operation string) error {
+	if *mysqlSharedInstance {
+		return fmt.Errorf("%v acts on the whole mysqld, which is shared with other tablets (-mysql_shared_instance)", operation)
+	}
+	return nil
+}
+
+// activeReparentsDisabled returns true if the tablet must not change the
+// replication of its mysqld, which is then reparented externally.
+func activeReparentsDisabled() bool {
+	return *mysqlctl.DisableActiveReparents || *mysqlSharedInstance
+}
+
+// scopedDBName returns the database a query of the tablet runs in. If the
+// mysqld is shared, the queries run in the database of the tablet, and the
+// other databases are refused. This only picks the default database of
+// the query: the isolation from the other databases relies on the grants
+// of the db users.
+func (tm *TabletManager) scopedDBName(dbName string) (string, error) {
+	if !*mysqlSharedInstance {
+		return dbName, nil
+	}
+	switch dbName {
+	case "", tm.DBConfigs.DBName:
+		return tm.DBConfigs.DBName, nil
+	}
+	return "", fmt.Errorf("cannot run a query in database %v: the mysqld is shared with other tablets (-mysql_shared_instance) and this tablet only serves database %v", dbName, tm.DBConfigs.DBName)
+}
+
+// sharedMysqldStats periodically reads the resources used by the database
+// of the tablet in the shared mysqld, so that the tablets sharing it can be
+// compared.
+type sharedMysqldStats struct {
+	ctx   context.Context
+	tm    *TabletManager
+	ticks *timer.Timer
+}
+
+func newSharedMysqldStats(ctx context.Context, tm *TabletManager, interval time.Duration) *sharedMysqldStats {
+	return &sharedMysqldStats{
+		ctx:   ctx,
+		tm:    tm,
+		ticks: timer.NewTimer(interval),
+	}
+}
+
+// Open starts reading the stats if the mysqld is shared.
+func (ss *sharedMysqldStats) Open() {
+	if !*mysqlSharedInstance || ss.ticks.Interval() == 0 {
+		return
+	}
+	ss.ticks.Start(ss.check)
+}
+
+// Close stops reading the stats.
+func (ss *sharedMysqldStats) Close() {
+	ss.ticks.Stop()
+}
+
+func (ss *sharedMysqldStats) check() {
+	ctx, cancel := context.WithTimeout(ss.ctx, sharedMysqldStatsTimeout)
+	defer cancel()
+	if err := ss.collect(ctx); err != nil {
+		log.Warningf("Cannot read the resources used by database %v in the shared mysqld: %v", ss.tm.DBConfigs.DBName, err)
+	}
+}
+
+// collect reads the stats. The row counters come from the performance
+// schema, which may be disabled: they're then left unchanged.
+func (ss *sharedMysqldStats) collect(ctx context.Context) error {
+	dbName := sqltypes.EncodeStringSQL(ss.tm.DBConfigs.DBName)
+
+	qr, err := ss.tm.MysqlDaemon.FetchSuperQuery(ctx, fmt.Sprintf("SELECT IFNULL(SUM(data_length + index_length), 0), IFNULL(SUM(table_rows), 0) FROM information_schema.tables WHERE table_schema = %s", dbName))
+	if err != nil {
+		return err
+	}
+	if err := setGaugesFromRow(qr, sharedMysqldDatabaseBytes, sharedMysqldDatabaseRows); err != nil {
+		return err
+	}
+
+	qr, err = ss.tm.MysqlDaemon.FetchSuperQuery(ctx, fmt.Sprintf("SELECT COUNT(*) FROM information_schema.processlist WHERE db = %s", dbName))
+	if err != nil {
+		return err
+	}
+	if err := setGaugesFromRow(qr, sharedMysqldConnections); err != nil {
+		return err
+	}
+
+	qr, err = ss.tm.MysqlDaemon.FetchSuperQuery(ctx, fmt.Sprintf("SELECT IFNULL(SUM(count_read), 0), IFNULL(SUM(count_write), 0) FROM performance_schema.table_io_waits_summary_by_table WHERE object_schema = %s", dbName))
+	if err != nil {
+		return fmt.Errorf("cannot read the row counters from the performance schema: %v", err)
+	}
+	return setGaugesFromRow(qr, sharedMysqldRowsRead, sharedMysqldRowsWritten)
+}
+
+// setGaugesFromRow sets the gauges to the values of the single row of the
+// result. The sums are decimals.
+func setGaugesFromRow(qr *sqltypes.Result, gauges ...*stats.Gauge) error {
+	if len(qr.Rows) != 1 || len(qr.Rows[0]) != len(gauges) {
+		return fmt.Errorf("unexpected result: %v", qr.Rows)
+	}
+	for i, gauge := range gauges {
+		value, err := evalengine.ToFloat64(qr.Rows[0][i])
+		if err != nil {
+			return err
+		}
+		gauge.Set(int64(value))
+	}
+	return nil
+}
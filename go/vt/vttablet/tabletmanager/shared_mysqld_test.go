@@ -0,0 +1,96 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletmanager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/dbconfigs"
+	"vitess.io/vitess/go/vt/mysqlctl/fakemysqldaemon"
+)
+
+func TestSharedMysqldScoping(t *testing.T) {
+	tm := &TabletManager{DBConfigs: &dbconfigs.DBConfigs{DBName: "vt_ks"}}
+
+	dbName, err := tm.scopedDBName("vt_other")
+	require.NoError(t, err)
+	assert.Equal(t, "vt_other", dbName)
+	assert.NoError(t, tm.checkNotSharedMysqld("Backup"))
+	assert.False(t, activeReparentsDisabled())
+
+	*mysqlSharedInstance = true
+	defer func() { *mysqlSharedInstance = false }()
+
+	dbName, err = tm.scopedDBName("")
+	require.NoError(t, err)
+	assert.Equal(t, "vt_ks", dbName)
+	dbName, err = tm.scopedDBName("vt_ks")
+	require.NoError(t, err)
+	assert.Equal(t, "vt_ks", dbName)
+	_, err = tm.scopedDBName("vt_other")
+	assert.EqualError(t, err, "cannot run a query in database vt_other: the mysqld is shared with other tablets (-mysql_shared_instance) and this tablet only serves database vt_ks")
+	assert.EqualError(t, tm.checkNotSharedMysqld("Backup"), "Backup acts on the whole mysqld, which is shared with other tablets (-mysql_shared_instance)")
+
+	// The replication of the shared mysqld is reparented externally.
+	assert.True(t, activeReparentsDisabled())
+	ctx := context.Background()
+	assert.EqualError(t, tm.SetReadOnly(ctx, false), "SetReadOnly acts on the whole mysqld, which is shared with other tablets (-mysql_shared_instance)")
+	assert.EqualError(t, tm.StopReplication(ctx), "StopReplication acts on the whole mysqld, which is shared with other tablets (-mysql_shared_instance)")
+	assert.EqualError(t, tm.SetReplicationSource(ctx, nil, 0, "", false), "SetReplicationSource acts on the whole mysqld, which is shared with other tablets (-mysql_shared_instance)")
+	_, err = tm.PromoteReplica(ctx)
+	assert.EqualError(t, err, "PromoteReplica acts on the whole mysqld, which is shared with other tablets (-mysql_shared_instance)")
+	_, err = tm.DemoteMaster(ctx)
+	assert.EqualError(t, err, "DemotePrimary acts on the whole mysqld, which is shared with other tablets (-mysql_shared_instance)")
+}
+
+func TestSharedMysqldStatsCollect(t *testing.T) {
+	fmd := fakemysqldaemon.NewFakeMysqlDaemon(nil)
+	fmd.FetchSuperQueryMap = map[string]*sqltypes.Result{
+		"SELECT IFNULL(SUM(data_length + index_length), 0), IFNULL(SUM(table_rows), 0) FROM information_schema.tables WHERE table_schema = 'vt_ks'": sqltypes.MakeTestResult(
+			sqltypes.MakeTestFields("size|rows", "decimal|decimal"),
+			"32768|120",
+		),
+		"SELECT COUNT(*) FROM information_schema.processlist WHERE db = 'vt_ks'": sqltypes.MakeTestResult(
+			sqltypes.MakeTestFields("count", "int64"),
+			"7",
+		),
+	}
+	ss := newSharedMysqldStats(context.Background(), &TabletManager{
+		MysqlDaemon: fmd,
+		DBConfigs:   &dbconfigs.DBConfigs{DBName: "vt_ks"},
+	}, 0)
+
+	// The performance schema is disabled.
+	err := ss.collect(context.Background())
+	assert.Contains(t, err.Error(), "cannot read the row counters from the performance schema")
+	assert.EqualValues(t, 32768, sharedMysqldDatabaseBytes.Get())
+	assert.EqualValues(t, 120, sharedMysqldDatabaseRows.Get())
+	assert.EqualValues(t, 7, sharedMysqldConnections.Get())
+
+	fmd.FetchSuperQueryMap["SELECT IFNULL(SUM(count_read), 0), IFNULL(SUM(count_write), 0) FROM performance_schema.table_io_waits_summary_by_table WHERE object_schema = 'vt_ks'"] = sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields("read|write", "decimal|decimal"),
+		"1000|50",
+	)
+	require.NoError(t, ss.collect(context.Background()))
+	assert.EqualValues(t, 1000, sharedMysqldRowsRead.Get())
+	assert.EqualValues(t, 50, sharedMysqldRowsWritten.Get())
+}
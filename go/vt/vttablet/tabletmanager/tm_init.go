@@ -57,6 +57,7 @@ import (
 	"vitess.io/vitess/go/vt/log"
 	"vitess.io/vitess/go/vt/logutil"
 	"vitess.io/vitess/go/vt/mysqlctl"
+	"vitess.io/vitess/go/vt/mysqlctl/backupstorage"
 	"vitess.io/vitess/go/vt/servenv"
 	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/topo/topoproto"
@@ -144,6 +145,19 @@ type TabletManager struct {
 	// replManager manages replication.
 	replManager *replManager
 
+	// binlogPurger purges the binary logs of the master.
+	binlogPurger *binlogPurger
+
+	// binlogArchiver archives the binary logs of the master.
+	binlogArchiver *binlogArchiver
+
+	// incrementalBackupper takes the incremental backups of the tablet.
+	incrementalBackupper *incrementalBackupper
+
+	// sharedMysqldStats exports the resources used by the database of the
+	// tablet in a shared mysqld.
+	sharedMysqldStats *sharedMysqldStats
+
 	// tabletAlias is saved away from tablet for read-only access
 	tabletAlias *topodatapb.TabletAlias
 
@@ -249,7 +263,17 @@ func BuildTabletFromInput(alias *topodatapb.TabletAlias, port, grpcPort int32) (
 func (tm *TabletManager) Start(tablet *topodatapb.Tablet, healthCheckInterval time.Duration) error {
 	tm.DBConfigs.DBName = topoproto.TabletDbName(tablet)
 	tm.replManager = newReplManager(tm.BatchCtx, tm, healthCheckInterval)
+	purgeInterval := *binlogPurgeInterval
+	if purgeInterval != 0 && *mysqlSharedInstance {
+		log.Warningf("-binlog_purge_interval is ignored with -mysql_shared_instance: the binary logs of the shared mysqld may still be needed by the other tablets")
+		purgeInterval = 0
+	}
+	tm.binlogPurger = newBinlogPurger(tm.BatchCtx, tm, purgeInterval)
+	tm.binlogArchiver = newBinlogArchiver(tm.BatchCtx, tm)
+	tm.incrementalBackupper = newIncrementalBackupper(tm.BatchCtx, tm, *incrementalBackupInterval)
+	tm.sharedMysqldStats = newSharedMysqldStats(tm.BatchCtx, tm, *sharedMysqldStatsInterval)
 	tm.tabletAlias = tablet.Alias
+	backupstorage.SetTopoServer(tm.TopoServer)
 	tm.tmState = newTMState(tm, tablet)
 	tm.actionSema = sync2.NewSemaphore(1, 0)
 
@@ -295,6 +319,7 @@ func (tm *TabletManager) Start(tablet *topodatapb.Tablet, healthCheckInterval ti
 	}
 
 	if tm.VREngine != nil {
+		tm.VREngine.SetKeyspace(tablet.Keyspace)
 		tm.VREngine.InitDBConfig(tm.DBConfigs)
 		servenv.OnTerm(tm.VREngine.Close)
 	}
@@ -303,6 +328,7 @@ func (tm *TabletManager) Start(tablet *topodatapb.Tablet, healthCheckInterval ti
 	// in any specific order.
 	tm.startShardSync()
 	tm.exportStats()
+	tm.sharedMysqldStats.Open()
 	orc, err := newOrcClient()
 	if err != nil {
 		return err
@@ -336,6 +362,7 @@ func (tm *TabletManager) Close() {
 	// running during lame duck.
 	tm.stopShardSync()
 	tm.stopRebuildKeyspace()
+	tm.sharedMysqldStats.Close()
 
 	// cleanup initialized fields in the tablet entry
 	f := func(tablet *topodatapb.Tablet) error {
@@ -367,6 +394,9 @@ func (tm *TabletManager) Stop() {
 	// here in addition to in Close() because tests do not call Close().
 	tm.stopShardSync()
 	tm.stopRebuildKeyspace()
+	if tm.sharedMysqldStats != nil {
+		tm.sharedMysqldStats.Close()
+	}
 
 	if tm.UpdateStream != nil {
 		tm.UpdateStream.Disable()
@@ -624,6 +654,9 @@ func (tm *TabletManager) initTablet(ctx context.Context) error {
 			return vterrors.Wrap(updateErr, "UpdateTabletReplicationData failed")
 		}
 
+		// Keep the drain state set by the operator.
+		topoproto.SetTabletDrained(tablet, topoproto.IsTabletDrained(oldTablet.Tablet))
+
 		// Then overwrite everything, ignoring version mismatch.
 		if err := tm.TopoServer.UpdateTablet(ctx, topo.NewTabletInfo(tablet, nil)); err != nil {
 			return vterrors.Wrap(err, "UpdateTablet failed")
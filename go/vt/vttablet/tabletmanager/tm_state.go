@@ -250,6 +250,9 @@ func (ts *tmState) updateLocked(ctx context.Context) {
 	}
 
 	ts.tm.replManager.SetTabletType(ts.tablet.Type)
+	ts.tm.binlogPurger.SetTabletType(ts.tablet.Type)
+	ts.tm.binlogArchiver.SetTabletType(ts.tablet.Type)
+	ts.tm.incrementalBackupper.SetTabletType(ts.tablet.Type)
 
 	if ts.tm.UpdateStream != nil {
 		if topo.IsRunningUpdateStream(ts.tablet.Type) {
@@ -367,8 +370,11 @@ func (ts *tmState) publishStateLocked(ctx context.Context) {
 			log.Error(err)
 			return topo.NewError(topo.NoUpdateNeeded, "")
 		}
+		// The drain state is set by the operator, keep it.
+		drained := topoproto.IsTabletDrained(tablet)
 		proto.Reset(tablet)
 		proto.Merge(tablet, ts.tablet)
+		topoproto.SetTabletDrained(tablet, drained)
 		return nil
 	})
 	if err != nil {
@@ -399,8 +405,10 @@ func (ts *tmState) retryPublish() {
 				log.Error(err)
 				return topo.NewError(topo.NoUpdateNeeded, "")
 			}
+			drained := topoproto.IsTabletDrained(tablet)
 			proto.Reset(tablet)
 			proto.Merge(tablet, ts.tablet)
+			topoproto.SetTabletDrained(tablet, drained)
 			return nil
 		})
 		cancel()
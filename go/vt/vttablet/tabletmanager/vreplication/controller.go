@@ -131,6 +131,7 @@ func newController(ctx context.Context, params map[string]string, dbClientFactor
 		if err != nil {
 			return nil, err
 		}
+		tp.SetMaxReplicationLag(*sourceMaxLag, *sourceWaitForCatchup)
 		ct.tabletPicker = tp
 	}
 
@@ -221,6 +222,18 @@ func (ct *controller) runBlp(ctx context.Context) (err error) {
 		ct.setMessage(dbClient, fmt.Sprintf("Picked source tablet: %s", tablet.Alias.String()))
 		log.Infof("found a tablet eligible for vreplication. stream id: %v  tablet: %s", ct.id, tablet.Alias.String())
 		ct.sourceTablet.Set(tablet.Alias.String())
+
+		if *sourceMaxLag > 0 && tablet.Type != topodatapb.TabletType_MASTER {
+			var stopWatching func() error
+			ctx, stopWatching = watchSourceLag(ctx, tablet, *sourceMaxLag, *sourceLagCheckInterval)
+			defer func() {
+				// Restart the stream from another source.
+				if lagErr := stopWatching(); lagErr != nil {
+					ct.blpStats.ErrorCounts.Add([]string{"Source Lagging"}, 1)
+					err = lagErr
+				}
+			}()
+		}
 	}
 	switch {
 	case len(ct.source.Tables) > 0:
@@ -268,6 +281,7 @@ func (ct *controller) runBlp(ctx context.Context) (err error) {
 		defer vsClient.Close(ctx)
 
 		vr := newVReplicator(ct.id, ct.source, vsClient, ct.blpStats, dbClient, ct.mysqld, ct.vre)
+		vr.throttler = ct.vre.workflowThrottler(ct.workflow)
 		return vr.Replicate(ctx)
 	}
 	ct.blpStats.ErrorCounts.Add([]string{"Invalid Source"}, 1)
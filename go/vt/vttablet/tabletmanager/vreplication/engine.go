@@ -35,6 +35,7 @@ import (
 	"vitess.io/vitess/go/vt/mysqlctl"
 	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
 	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/vterrors"
@@ -125,6 +126,14 @@ type Engine struct {
 	ec        *externalConnector
 
 	throttlerClient *throttle.Client
+
+	// keyspace is the keyspace of the tablet, whose workflow throttling
+	// is reloaded from the topo.
+	keyspace string
+	// throttlersMu synchronizes throttling and throttlers.
+	throttlersMu sync.Mutex
+	throttling   map[string]*topodatapb.WorkflowThrottling
+	throttlers   map[string]*workflowThrottler
 }
 
 type journalEvent struct {
@@ -216,6 +225,9 @@ func (vre *Engine) openLocked(ctx context.Context) error {
 
 	vre.ctx, vre.cancel = context.WithCancel(ctx)
 	vre.isOpen = true
+	if vre.keyspace != "" && *workflowThrottlingRefreshInterval > 0 {
+		go vre.runWorkflowThrottling(vre.ctx, *workflowThrottlingRefreshInterval)
+	}
 	vre.initControllers(rows)
 	vre.updateStats()
 	return nil
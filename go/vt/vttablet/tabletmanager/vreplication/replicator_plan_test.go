@@ -316,6 +316,58 @@ func TestBuildPlayerPlan(t *testing.T) {
 				},
 			},
 		},
+	}, {
+		// rollup of count(col) and sum(expr)
+		input: &binlogdatapb.Filter{
+			Rules: []*binlogdatapb.Rule{{
+				Match:  "t1",
+				Filter: "select c1, count(c2) as kount, sum(c3 * c4) as total from t2 group by c1",
+			}},
+		},
+		plan: &TestReplicatorPlan{
+			VStreamFilter: &binlogdatapb.Filter{
+				Rules: []*binlogdatapb.Rule{{
+					Match:  "t2",
+					Filter: "select c1, c2, c3, c4 from t2",
+				}},
+			},
+			TargetTables: []string{"t1"},
+			TablePlans: map[string]*TestTablePlan{
+				"t2": {
+					TargetName:   "t1",
+					SendRule:     "t2",
+					PKReferences: []string{"c1"},
+					InsertFront:  "insert into t1(c1,kount,total)",
+					InsertValues: "(:a_c1,ifnull(if(:a_c2 is null, 0, 1), 0),ifnull(:a_c3 * :a_c4, 0))",
+					InsertOnDup:  "on duplicate key update kount=kount+ifnull(values(kount), 0), total=total+ifnull(values(total), 0)",
+					Insert:       "insert into t1(c1,kount,total) values (:a_c1,ifnull(if(:a_c2 is null, 0, 1), 0),ifnull(:a_c3 * :a_c4, 0)) on duplicate key update kount=kount+ifnull(values(kount), 0), total=total+ifnull(values(total), 0)",
+					Update:       "update t1 set kount=kount-ifnull(if(:b_c2 is null, 0, 1), 0)+ifnull(if(:a_c2 is null, 0, 1), 0), total=total-ifnull(:b_c3 * :b_c4, 0)+ifnull(:a_c3 * :a_c4, 0) where c1=:b_c1",
+					Delete:       "update t1 set kount=kount-ifnull(if(:b_c2 is null, 0, 1), 0), total=total-ifnull(:b_c3 * :b_c4, 0) where c1=:b_c1",
+				},
+			},
+		},
+		planpk: &TestReplicatorPlan{
+			VStreamFilter: &binlogdatapb.Filter{
+				Rules: []*binlogdatapb.Rule{{
+					Match:  "t2",
+					Filter: "select c1, c2, c3, c4, pk1, pk2 from t2",
+				}},
+			},
+			TargetTables: []string{"t1"},
+			TablePlans: map[string]*TestTablePlan{
+				"t2": {
+					TargetName:   "t1",
+					SendRule:     "t2",
+					PKReferences: []string{"c1", "pk1", "pk2"},
+					InsertFront:  "insert into t1(c1,kount,total)",
+					InsertValues: "(:a_c1,ifnull(if(:a_c2 is null, 0, 1), 0),ifnull(:a_c3 * :a_c4, 0))",
+					InsertOnDup:  "on duplicate key update kount=kount+ifnull(values(kount), 0), total=total+ifnull(values(total), 0)",
+					Insert:       "insert into t1(c1,kount,total) select :a_c1, ifnull(if(:a_c2 is null, 0, 1), 0), ifnull(:a_c3 * :a_c4, 0) from dual where (:a_pk1,:a_pk2) <= (1,'aaa') on duplicate key update kount=kount+ifnull(values(kount), 0), total=total+ifnull(values(total), 0)",
+					Update:       "update t1 set kount=kount-ifnull(if(:b_c2 is null, 0, 1), 0)+ifnull(if(:a_c2 is null, 0, 1), 0), total=total-ifnull(:b_c3 * :b_c4, 0)+ifnull(:a_c3 * :a_c4, 0) where c1=:b_c1 and (:b_pk1,:b_pk2) <= (1,'aaa')",
+					Delete:       "update t1 set kount=kount-ifnull(if(:b_c2 is null, 0, 1), 0), total=total-ifnull(:b_c3 * :b_c4, 0) where c1=:b_c1 and (:b_pk1,:b_pk2) <= (1,'aaa')",
+				},
+			},
+		},
 	}, {
 		input: &binlogdatapb.Filter{
 			Rules: []*binlogdatapb.Rule{{
@@ -605,14 +657,14 @@ func TestBuildPlayerPlan(t *testing.T) {
 		},
 		err: "expression needs an alias: hour(c1)",
 	}, {
-		// only count(*)
+		// count should have only one argument
 		input: &binlogdatapb.Filter{
 			Rules: []*binlogdatapb.Rule{{
 				Match:  "t1",
-				Filter: "select count(c1) as c from t1",
+				Filter: "select count(a, b) as c from t1",
 			}},
 		},
-		err: "only count(*) is supported: count(c1)",
+		err: "unexpected: count(a, b)",
 	}, {
 		// no sum(*)
 		input: &binlogdatapb.Filter{
@@ -632,14 +684,23 @@ func TestBuildPlayerPlan(t *testing.T) {
 		},
 		err: "unexpected: sum(a, b)",
 	}, {
-		// no complex expr in sum
+		// no nested aggregates
+		input: &binlogdatapb.Filter{
+			Rules: []*binlogdatapb.Rule{{
+				Match:  "t1",
+				Filter: "select sum(a + count(*)) as c from t1",
+			}},
+		},
+		err: "unexpected: count(*)",
+	}, {
+		// only count and sum can be maintained
 		input: &binlogdatapb.Filter{
 			Rules: []*binlogdatapb.Rule{{
 				Match:  "t1",
-				Filter: "select sum(a + b) as c from t1",
+				Filter: "select a, max(b) as c from t1 group by a",
 			}},
 		},
-		err: "unexpected: sum(a + b)",
+		err: "only the count and sum aggregates are supported: max(b)",
 	}, {
 		// no complex expr in group by
 		input: &binlogdatapb.Filter{
@@ -0,0 +1,80 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vreplication
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"vitess.io/vitess/go/vt/discovery"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// This file bounds the replication lag of the source tablets of the
+// streams: a lagging tablet is not picked as a source, and a stream whose
+// source falls behind is restarted from another source.
+
+var (
+	sourceMaxLag           = flag.Duration("vreplication_source_max_lag", 0, "Maximum replication lag of the source tablet of a vreplication stream. A lagging tablet is not picked as a source, and a stream whose source falls behind is restarted from another source. 0 disables it.")
+	sourceWaitForCatchup   = flag.Bool("vreplication_source_wait_for_catchup", true, "If all the source tablets of a stream are above -vreplication_source_max_lag, wait for one of them to catch up instead of failing.")
+	sourceLagCheckInterval = flag.Duration("vreplication_source_lag_check_interval", 30*time.Second, "How often the replication lag of the source tablet of a running stream is checked, if -vreplication_source_max_lag is set.")
+
+	// sourceReplicationLag is overridden by tests.
+	sourceReplicationLag = discovery.TabletReplicationLag
+)
+
+// watchSourceLag returns a context which is canceled if the replication
+// lag of the source tablet goes above maxLag. The returned function stops
+// watching, and returns the error which canceled the context, if any.
+func watchSourceLag(ctx context.Context, tablet *topodatapb.Tablet, maxLag, interval time.Duration) (context.Context, func() error) {
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	var lagErr error
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			lag, err := sourceReplicationLag(ctx, tablet)
+			if err != nil {
+				// The stream fails by itself if its source is down.
+				log.Warningf("Cannot check the replication lag of source tablet %v: %v", topoproto.TabletAliasString(tablet.Alias), err)
+				continue
+			}
+			if lag > maxLag {
+				lagErr = fmt.Errorf("source tablet %v fell behind: its replication lag %v is above %v", topoproto.TabletAliasString(tablet.Alias), lag, maxLag)
+				cancel()
+				return
+			}
+		}
+	}()
+	return ctx, func() error {
+		cancel()
+		<-done
+		return lagErr
+	}
+}
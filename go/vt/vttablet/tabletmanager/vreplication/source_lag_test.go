@@ -0,0 +1,54 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vreplication
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func TestWatchSourceLag(t *testing.T) {
+	var lagSeconds int64
+	saved := sourceReplicationLag
+	defer func() { sourceReplicationLag = saved }()
+	sourceReplicationLag = func(ctx context.Context, tablet *topodatapb.Tablet) (time.Duration, error) {
+		return time.Duration(atomic.LoadInt64(&lagSeconds)) * time.Second, nil
+	}
+	tablet := &topodatapb.Tablet{Alias: &topodatapb.TabletAlias{Cell: "cell1", Uid: 100}}
+
+	// The source stays within the max lag.
+	ctx, stopWatching := watchSourceLag(context.Background(), tablet, 10*time.Second, time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	assert.NoError(t, ctx.Err())
+	assert.NoError(t, stopWatching())
+
+	// The source falls behind.
+	ctx, stopWatching = watchSourceLag(context.Background(), tablet, 10*time.Second, time.Millisecond)
+	atomic.StoreInt64(&lagSeconds, 30)
+	select {
+	case <-ctx.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("the context was not canceled when the source fell behind")
+	}
+	assert.EqualError(t, stopWatching(), "source tablet cell1-0000000100 fell behind: its replication lag 30s is above 10s")
+}
@@ -62,7 +62,9 @@ type colExpr struct {
 	colType querypb.Type
 	// operation==opExpr: full expression is set
 	// operation==opCount: nothing is set.
-	// operation==opSum: for 'sum(a)', expr is set to 'a'.
+	// operation==opSum: for 'sum(a)', expr is set to 'a', which can be
+	// any expression of the columns of the row. 'count(a)' is
+	// also an opSum, of 'if(a is null, 0, 1)'.
 	operation operation
 	// expr stores the expected field name from vstreamer and dictates
 	// the generated bindvar names, like a_col or b_col.
@@ -400,30 +402,45 @@ func (tpb *tablePlanBuilder) analyzeExpr(selExpr sqlparser.SelectExpr) (*colExpr
 		}
 		switch fname := expr.Name.Lowered(); fname {
 		case "count":
-			if _, ok := expr.Exprs[0].(*sqlparser.StarExpr); !ok {
-				return nil, fmt.Errorf("only count(*) is supported: %v", sqlparser.String(expr))
-			}
-			cexpr.operation = opCount
-			return cexpr, nil
-		case "sum":
 			if len(expr.Exprs) != 1 {
 				return nil, fmt.Errorf("unexpected: %v", sqlparser.String(expr))
 			}
+			if _, ok := expr.Exprs[0].(*sqlparser.StarExpr); ok {
+				cexpr.operation = opCount
+				return cexpr, nil
+			}
 			aInner, ok := expr.Exprs[0].(*sqlparser.AliasedExpr)
 			if !ok {
 				return nil, fmt.Errorf("unexpected: %v", sqlparser.String(expr))
 			}
-			innerCol, ok := aInner.Expr.(*sqlparser.ColName)
+			if err := tpb.analyzeReferences(aInner.Expr, cexpr); err != nil {
+				return nil, err
+			}
+			// count(a) is maintained as the sum of 1 for the rows
+			// where a is not null.
+			cexpr.operation = opSum
+			cexpr.expr = &sqlparser.FuncExpr{
+				Name: sqlparser.NewColIdent("if"),
+				Exprs: sqlparser.SelectExprs{
+					&sqlparser.AliasedExpr{Expr: &sqlparser.IsExpr{Left: aInner.Expr, Right: sqlparser.IsNullOp}},
+					&sqlparser.AliasedExpr{Expr: sqlparser.NewIntLiteral("0")},
+					&sqlparser.AliasedExpr{Expr: sqlparser.NewIntLiteral("1")},
+				},
+			}
+			return cexpr, nil
+		case "sum":
+			if len(expr.Exprs) != 1 {
+				return nil, fmt.Errorf("unexpected: %v", sqlparser.String(expr))
+			}
+			aInner, ok := expr.Exprs[0].(*sqlparser.AliasedExpr)
 			if !ok {
 				return nil, fmt.Errorf("unexpected: %v", sqlparser.String(expr))
 			}
-			if !innerCol.Qualifier.IsEmpty() {
-				return nil, fmt.Errorf("unsupported qualifier for column: %v", sqlparser.String(innerCol))
+			if err := tpb.analyzeReferences(aInner.Expr, cexpr); err != nil {
+				return nil, err
 			}
 			cexpr.operation = opSum
-			cexpr.expr = innerCol
-			tpb.addCol(innerCol.Name)
-			cexpr.references[innerCol.Name.Lowered()] = true
+			cexpr.expr = aInner.Expr
 			return cexpr, nil
 		case "keyspace_id":
 			if len(expr.Exprs) != 0 {
@@ -435,7 +452,18 @@ func (tpb *tablePlanBuilder) analyzeExpr(selExpr sqlparser.SelectExpr) (*colExpr
 			return cexpr, nil
 		}
 	}
-	err := sqlparser.Walk(func(node sqlparser.SQLNode) (kontinue bool, err error) {
+	if err := tpb.analyzeReferences(aliased.Expr, cexpr); err != nil {
+		return nil, err
+	}
+	cexpr.expr = aliased.Expr
+	return cexpr, nil
+}
+
+// analyzeReferences adds the columns referenced by the expression to the
+// send query and to the references of cexpr. Aggregates can't be nested
+// in the expression.
+func (tpb *tablePlanBuilder) analyzeReferences(expr sqlparser.Expr, cexpr *colExpr) error {
+	return sqlparser.Walk(func(node sqlparser.SQLNode) (kontinue bool, err error) {
 		switch node := node.(type) {
 		case *sqlparser.ColName:
 			if !node.Qualifier.IsEmpty() {
@@ -446,18 +474,18 @@ func (tpb *tablePlanBuilder) analyzeExpr(selExpr sqlparser.SelectExpr) (*colExpr
 		case *sqlparser.Subquery:
 			return false, fmt.Errorf("unsupported subquery: %v", sqlparser.String(node))
 		case *sqlparser.FuncExpr:
-			// Other aggregates are not supported.
+			// The other aggregates can't be maintained incrementally:
+			// e.g. the new max of a group isn't known when its row
+			// holding the max is deleted.
 			if node.IsAggregate() {
+				if node == expr {
+					return false, fmt.Errorf("only the count and sum aggregates are supported: %v", sqlparser.String(node))
+				}
 				return false, fmt.Errorf("unexpected: %v", sqlparser.String(node))
 			}
 		}
 		return true, nil
-	}, aliased.Expr)
-	if err != nil {
-		return nil, err
-	}
-	cexpr.expr = aliased.Expr
-	return cexpr, nil
+	}, expr)
 }
 
 // addCol adds the specified column to the send query
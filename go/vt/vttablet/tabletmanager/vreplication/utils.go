@@ -20,10 +20,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"regexp"
 	"strconv"
 
 	"vitess.io/vitess/go/vt/sqlparser"
 
+	"vitess.io/vitess/go/mysql"
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/vt/vtgate/evalengine"
 )
@@ -126,3 +128,23 @@ func insertLogWithParams(dbClient *vdbClient, action string, vreplID uint32, par
 	}
 	return nil
 }
+
+// checkConstraintRegexp extracts the name of the CHECK constraint from the
+// error MySQL returns when a row violates it.
+var checkConstraintRegexp = regexp.MustCompile(`Check constraint '([^']*)' is violated`)
+
+// checkConstraintError returns the error of a row of the table which
+// violates a CHECK constraint with the name of the constraint, so that the
+// message of the stream tells which one fails. Other errors are returned
+// as is.
+func checkConstraintError(err error, table string) error {
+	sqlErr, ok := err.(*mysql.SQLError)
+	if !ok || sqlErr.Number() != mysql.ERCheckConstraintViolated {
+		return err
+	}
+	constraint := "<unknown>"
+	if match := checkConstraintRegexp.FindStringSubmatch(sqlErr.Message); match != nil {
+		constraint = match[1]
+	}
+	return fmt.Errorf("row of table %v violates check constraint %v: %v", table, constraint, err)
+}
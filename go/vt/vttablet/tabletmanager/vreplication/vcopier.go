@@ -136,6 +136,11 @@ func (vc *vcopier) copyNext(ctx context.Context, settings binlogplayer.VRSetting
 	if err := vc.catchup(ctx, copyState); err != nil {
 		return err
 	}
+	release, err := vc.vr.throttler.acquireCopy(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
 	return vc.copyTable(ctx, tableToCopy, copyState)
 }
 
@@ -284,7 +289,7 @@ func (vc *vcopier) copyTable(ctx context.Context, tableName string, copyState ma
 			return qr, err
 		})
 		if err != nil {
-			return err
+			return checkConstraintError(err, tableName)
 		}
 
 		var buf []byte
@@ -312,6 +317,7 @@ func (vc *vcopier) copyTable(ctx context.Context, tableName string, copyState ma
 		if err := vc.vr.dbClient.Commit(); err != nil {
 			return err
 		}
+		vc.vr.throttler.throttleCopy(ctx, int64(len(rows.Rows)), rowsSize(rows.Rows))
 		return nil
 	})
 	// If there was a timeout, return without an error.
@@ -350,3 +356,12 @@ func (vc *vcopier) fastForward(ctx context.Context, copyState map[string]*sqltyp
 	}
 	return newVPlayer(vc.vr, settings, copyState, pos, "fastforward").play(ctx)
 }
+
+// rowsSize returns the size in bytes of the values of the rows.
+func rowsSize(rows []*querypb.Row) int64 {
+	var size int64
+	for _, row := range rows {
+		size += int64(len(row.Values))
+	}
+	return size
+}
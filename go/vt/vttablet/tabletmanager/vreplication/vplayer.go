@@ -224,7 +224,7 @@ func (vp *vplayer) applyRowEvent(ctx context.Context, rowEvent *binlogdatapb.Row
 			return qr, err
 		})
 		if err != nil {
-			return err
+			return checkConstraintError(err, rowEvent.TableName)
 		}
 	}
 	return nil
@@ -83,6 +83,8 @@ type vreplicator struct {
 	// mysqld is used to fetch the local schema.
 	mysqld     mysqlctl.MysqlDaemon
 	colInfoMap map[string][]*ColumnInfo
+	// throttler throttles the copy phase of the workflow of the stream.
+	throttler *workflowThrottler
 
 	originalFKCheckSetting int64
 }
@@ -0,0 +1,210 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vreplication
+
+import (
+	"context"
+	"flag"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"vitess.io/vitess/go/vt/log"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// This file applies the throttling of the workflows of the keyspace, set by
+// the UpdateWorkflowThrottling vtctl command, to the copy phase of their
+// streams. The throttling is reloaded from the topo periodically, so it can
+// be changed while the streams run, without restarting them. The limits
+// apply to each primary tablet, and are shared by the streams of a
+// workflow on it. The catchup and replication phases are not throttled, so
+// the streams don't lag behind their sources.
+
+var workflowThrottlingRefreshInterval = flag.Duration("vreplication_workflow_throttling_refresh_interval", 10*time.Second, "How often the throttling of the vreplication workflows of the keyspace is reloaded from the topo. 0 disables it.")
+
+// workflowThrottler throttles the copy phase of the streams of a workflow.
+// A nil workflowThrottler doesn't throttle.
+type workflowThrottler struct {
+	mu         sync.Mutex
+	throttling *topodatapb.WorkflowThrottling
+	// next is the time until which the copy is paced.
+	next time.Time
+	// copies is the number of streams copying rows.
+	copies int64
+	// changed is closed and replaced when a copy ends or the throttling
+	// changes, to wake up the streams waiting to copy.
+	changed chan struct{}
+}
+
+func newWorkflowThrottler() *workflowThrottler {
+	return &workflowThrottler{
+		throttling: &topodatapb.WorkflowThrottling{},
+		changed:    make(chan struct{}),
+	}
+}
+
+// set changes the throttling. A nil throttling removes it. It returns true
+// if the throttling changed.
+func (wt *workflowThrottler) set(throttling *topodatapb.WorkflowThrottling) bool {
+	if throttling == nil {
+		throttling = &topodatapb.WorkflowThrottling{}
+	}
+	wt.mu.Lock()
+	defer wt.mu.Unlock()
+	if proto.Equal(wt.throttling, throttling) {
+		return false
+	}
+	wt.throttling = proto.Clone(throttling).(*topodatapb.WorkflowThrottling)
+	wt.notifyLocked()
+	return true
+}
+
+func (wt *workflowThrottler) notifyLocked() {
+	close(wt.changed)
+	wt.changed = make(chan struct{})
+}
+
+// acquireCopy waits until fewer streams than the maximum are copying rows,
+// and returns the function which ends the copy.
+func (wt *workflowThrottler) acquireCopy(ctx context.Context) (func(), error) {
+	if wt == nil {
+		return func() {}, nil
+	}
+	for {
+		wt.mu.Lock()
+		if max := wt.throttling.MaxConcurrentCopies; max <= 0 || wt.copies < max {
+			wt.copies++
+			wt.mu.Unlock()
+			return wt.releaseCopy, nil
+		}
+		changed := wt.changed
+		wt.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+func (wt *workflowThrottler) releaseCopy() {
+	wt.mu.Lock()
+	defer wt.mu.Unlock()
+	wt.copies--
+	wt.notifyLocked()
+}
+
+// delay paces the copy of rows and bytes at the rates of the throttling,
+// and returns how long to wait before copying more.
+func (wt *workflowThrottler) delay(rows, bytes int64, now time.Time) time.Duration {
+	wt.mu.Lock()
+	defer wt.mu.Unlock()
+	var cost time.Duration
+	if rate := wt.throttling.RowsPerSecond; rate > 0 {
+		cost = time.Duration(rows * int64(time.Second) / rate)
+	}
+	if rate := wt.throttling.BytesPerSecond; rate > 0 {
+		if bytesCost := time.Duration(bytes * int64(time.Second) / rate); bytesCost > cost {
+			cost = bytesCost
+		}
+	}
+	if cost == 0 {
+		return 0
+	}
+	if wt.next.Before(now) {
+		wt.next = now
+	}
+	wt.next = wt.next.Add(cost)
+	return wt.next.Sub(now)
+}
+
+// throttleCopy waits after copying rows and bytes, until the rates of the
+// throttling allow copying more, or ctx is done.
+func (wt *workflowThrottler) throttleCopy(ctx context.Context, rows, bytes int64) {
+	if wt == nil {
+		return
+	}
+	delay := wt.delay(rows, bytes, time.Now())
+	if delay <= 0 {
+		return
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
+
+// SetKeyspace sets the keyspace of the tablet, whose workflow throttling
+// applies to the streams. It must be called before Open.
+func (vre *Engine) SetKeyspace(keyspace string) {
+	vre.keyspace = keyspace
+}
+
+// workflowThrottler returns the throttler shared by the streams of the
+// workflow.
+func (vre *Engine) workflowThrottler(workflow string) *workflowThrottler {
+	vre.throttlersMu.Lock()
+	defer vre.throttlersMu.Unlock()
+	if vre.throttlers == nil {
+		vre.throttlers = make(map[string]*workflowThrottler)
+	}
+	wt, ok := vre.throttlers[workflow]
+	if !ok {
+		wt = newWorkflowThrottler()
+		wt.set(vre.throttling[workflow])
+		vre.throttlers[workflow] = wt
+	}
+	return wt
+}
+
+// setWorkflowThrottling sets the throttling of the workflows, by workflow
+// name. The workflows missing from it are not throttled.
+func (vre *Engine) setWorkflowThrottling(throttling map[string]*topodatapb.WorkflowThrottling) {
+	vre.throttlersMu.Lock()
+	defer vre.throttlersMu.Unlock()
+	vre.throttling = throttling
+	for workflow, wt := range vre.throttlers {
+		if wt.set(throttling[workflow]) {
+			log.Infof("Throttling of workflow %v changed to %+v", workflow, throttling[workflow])
+		}
+	}
+}
+
+// runWorkflowThrottling reloads the throttling of the workflows of the
+// keyspace every interval until ctx is done.
+func (vre *Engine) runWorkflowThrottling(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		throttling, err := vre.ts.GetWorkflowThrottling(ctx, vre.keyspace)
+		if err != nil {
+			log.Warningf("Cannot read the workflow throttling of keyspace %v: %v", vre.keyspace, err)
+		} else {
+			vre.setWorkflowThrottling(throttling)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
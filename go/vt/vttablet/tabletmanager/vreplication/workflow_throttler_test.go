@@ -0,0 +1,120 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vreplication
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/test/utils"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func TestWorkflowThrottlerDelay(t *testing.T) {
+	wt := newWorkflowThrottler()
+	now := time.Now()
+	assert.Zero(t, wt.delay(1000, 1000000, now))
+
+	wt.set(&topodatapb.WorkflowThrottling{RowsPerSecond: 100, BytesPerSecond: 1000})
+	// The rows limit is the slowest.
+	assert.Equal(t, time.Second, wt.delay(100, 500, now))
+	// The bytes limit is the slowest, and the delays add up.
+	assert.Equal(t, 3*time.Second, wt.delay(10, 2000, now))
+	// The pacing restarts from now after an idle period.
+	assert.Equal(t, 100*time.Millisecond, wt.delay(10, 0, now.Add(time.Minute)))
+
+	var nilThrottler *workflowThrottler
+	nilThrottler.throttleCopy(context.Background(), 1000, 1000)
+}
+
+func TestWorkflowThrottlerAcquireCopy(t *testing.T) {
+	wt := newWorkflowThrottler()
+	wt.set(&topodatapb.WorkflowThrottling{MaxConcurrentCopies: 1})
+	release, err := wt.acquireCopy(context.Background())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = wt.acquireCopy(ctx)
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	acquired := make(chan struct{})
+	go func() {
+		release, err := wt.acquireCopy(context.Background())
+		assert.NoError(t, err)
+		release()
+		close(acquired)
+	}()
+	release()
+	select {
+	case <-acquired:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the copy was not acquired after the release")
+	}
+
+	// Removing the limit wakes up the waiting streams.
+	release, err = wt.acquireCopy(context.Background())
+	require.NoError(t, err)
+	defer release()
+	acquired = make(chan struct{})
+	go func() {
+		release, err := wt.acquireCopy(context.Background())
+		assert.NoError(t, err)
+		release()
+		close(acquired)
+	}()
+	assert.True(t, wt.set(nil))
+	select {
+	case <-acquired:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the copy was not acquired after the limit was removed")
+	}
+}
+
+func TestEngineWorkflowThrottling(t *testing.T) {
+	ctx := context.Background()
+	ts := memorytopo.NewServer("cell1")
+	require.NoError(t, ts.CreateKeyspace(ctx, "ks", &topodatapb.Keyspace{}))
+	require.NoError(t, ts.UpdateWorkflowThrottling(ctx, "ks", "wf1", &topodatapb.WorkflowThrottling{RowsPerSecond: 1000}))
+	require.NoError(t, ts.UpdateWorkflowThrottling(ctx, "ks", "wf2", &topodatapb.WorkflowThrottling{MaxConcurrentCopies: 2}))
+
+	vre := NewTestEngine(ts, "cell1", nil, nil, nil, "db", nil)
+	vre.SetKeyspace("ks")
+	wf1 := vre.workflowThrottler("wf1")
+	assert.Same(t, wf1, vre.workflowThrottler("wf1"))
+
+	throttling, err := ts.GetWorkflowThrottling(ctx, "ks")
+	require.NoError(t, err)
+	vre.setWorkflowThrottling(throttling)
+	utils.MustMatch(t, &topodatapb.WorkflowThrottling{RowsPerSecond: 1000}, wf1.throttling)
+	// New throttlers start with the last throttling read.
+	utils.MustMatch(t, &topodatapb.WorkflowThrottling{MaxConcurrentCopies: 2}, vre.workflowThrottler("wf2").throttling)
+
+	// An empty throttling removes the workflow.
+	require.NoError(t, ts.UpdateWorkflowThrottling(ctx, "ks", "wf1", &topodatapb.WorkflowThrottling{}))
+	throttling, err = ts.GetWorkflowThrottling(ctx, "ks")
+	require.NoError(t, err)
+	assert.NotContains(t, throttling, "wf1")
+	vre.setWorkflowThrottling(throttling)
+	utils.MustMatch(t, &topodatapb.WorkflowThrottling{}, wf1.throttling)
+}
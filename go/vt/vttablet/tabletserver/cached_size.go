@@ -23,7 +23,7 @@ func (cached *TabletPlan) CachedSize(alloc bool) int64 {
 	}
 	size := int64(0)
 	if alloc {
-		size += int64(128)
+		size += int64(152)
 	}
 	// field Plan *vitess.io/vitess/go/vt/vttablet/tabletserver/planbuilder.Plan
 	size += cached.Plan.CachedSize(true)
@@ -45,5 +45,12 @@ func (cached *TabletPlan) CachedSize(alloc bool) int64 {
 			size += elem.CachedSize(true)
 		}
 	}
+	// field Quotas []*vitess.io/vitess/go/vt/tableacl.Quota
+	{
+		size += int64(cap(cached.Quotas)) * int64(8)
+		for _, elem := range cached.Quotas {
+			size += elem.CachedSize(true)
+		}
+	}
 	return size
 }
@@ -86,9 +86,24 @@ func NewPool(env tabletenv.Env, name string, cfg tabletenv.ConnPoolConfig) *Pool
 	env.Exporter().NewGaugeDurationFunc(name+"IdleTimeout", "Tablet server idle timeout", cp.IdleTimeout)
 	env.Exporter().NewCounterFunc(name+"IdleClosed", "Tablet server conn pool idle closed", cp.IdleClosed)
 	env.Exporter().NewCounterFunc(name+"Exhausted", "Number of times pool had zero available slots", cp.Exhausted)
+	pools.RegisterStats(statsType(name), name, cp)
 	return cp
 }
 
+// statsType returns the type of the pool in the stats of the pools.
+func statsType(name string) string {
+	switch name {
+	case "ConnPool":
+		return pools.StatsTypeApp
+	case "StreamConnPool":
+		return pools.StatsTypeStream
+	case "TransactionPool", "FoundRowsPool", "TxReadPool":
+		return pools.StatsTypeTransaction
+	default:
+		return pools.StatsTypeInternal
+	}
+}
+
 func (cp *Pool) pool() (p *pools.ResourcePool) {
 	cp.mu.Lock()
 	p = cp.connections
@@ -119,8 +134,10 @@ func (cp *Pool) getLogWaitCallback() func(time.Time) {
 	if cp.name == "" {
 		return func(start time.Time) {} // no op
 	}
+	typ := statsType(cp.name)
 	return func(start time.Time) {
 		cp.env.Stats().WaitTimings.Record(cp.name+"ResourceWaitTime", start)
+		pools.RecordWait(typ, cp.name, start)
 	}
 }
 
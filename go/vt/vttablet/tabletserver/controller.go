@@ -19,6 +19,7 @@ package tabletserver
 import (
 	"context"
 
+	"vitess.io/vitess/go/mysql"
 	"vitess.io/vitess/go/vt/dbconfigs"
 	"vitess.io/vitess/go/vt/mysqlctl"
 	"vitess.io/vitess/go/vt/topo"
@@ -93,6 +94,10 @@ type Controller interface {
 
 	// TopoServer returns the topo server.
 	TopoServer() *topo.Server
+
+	// VStreamerPositions returns the binlog positions of the running
+	// vstreams.
+	VStreamerPositions() []mysql.Position
 }
 
 // Ensure TabletServer satisfies Controller interface.
@@ -60,6 +60,7 @@ type TabletPlan struct {
 	Fields     []*querypb.Field
 	Rules      *rules.Rules
 	Authorized []*tableacl.ACLResult
+	Quotas     []*tableacl.Quota
 
 	QueryCount   uint64
 	Time         uint64
@@ -96,6 +97,18 @@ func (ep *TabletPlan) buildAuthorized() {
 	for i, perm := range ep.Permissions {
 		ep.Authorized[i] = tableacl.Authorized(perm.TableName, perm.Role)
 	}
+	ep.buildQuotas()
+}
+
+// buildQuotas builds 'Quotas', the quotas of the tables read by the plan.
+func (ep *TabletPlan) buildQuotas() {
+	ep.Quotas = nil
+	for _, perm := range ep.Permissions {
+		if perm.Role != tableacl.READER {
+			continue
+		}
+		ep.Quotas = append(ep.Quotas, tableacl.Quotas(perm.TableName)...)
+	}
 }
 
 //_______________________________________________
@@ -117,6 +130,8 @@ type QueryEngine struct {
 	tables           map[string]*schema.Table
 	plans            cache.Cache
 	queryRuleSources *rules.Map
+	// pins replace the pinned queries before they are planned.
+	pins *queryPins
 
 	// Pools
 	conns       *connpool.Pool
@@ -174,6 +189,7 @@ func NewQueryEngine(env tabletenv.Env, se *schema.Engine) *QueryEngine {
 		plans:            cache.NewDefaultCacheImpl(cacheCfg),
 		queryRuleSources: rules.NewMap(),
 	}
+	qe.pins = newQueryPins(qe.ClearQueryPlanCache)
 
 	qe.conns = connpool.NewPool(env, "ConnPool", config.OltpReadPool)
 	qe.streamConns = connpool.NewPool(env, "StreamConnPool", config.OlapReadPool)
@@ -222,6 +238,7 @@ func NewQueryEngine(env tabletenv.Env, se *schema.Engine) *QueryEngine {
 	env.Exporter().NewGaugeFunc("QueryCacheSize", "Query engine query cache size", qe.plans.UsedCapacity)
 	env.Exporter().NewGaugeFunc("QueryCacheCapacity", "Query engine query cache capacity", qe.plans.MaxCapacity)
 	env.Exporter().NewCounterFunc("QueryCacheEvictions", "Query engine query cache evictions", qe.plans.Evictions)
+	env.Exporter().NewGaugeFunc("QueryPins", "Query engine number of active query pins", qe.pins.count)
 	qe.queryCounts = env.Exporter().NewCountersWithMultiLabels("QueryCounts", "query counts", []string{"Table", "Plan"})
 	qe.queryTimes = env.Exporter().NewCountersWithMultiLabels("QueryTimesNs", "query times in ns", []string{"Table", "Plan"})
 	qe.queryRowCounts = env.Exporter().NewCountersWithMultiLabels("QueryRowCounts", "query row counts", []string{"Table", "Plan"})
@@ -233,6 +250,7 @@ func NewQueryEngine(env tabletenv.Env, se *schema.Engine) *QueryEngine {
 	env.Exporter().HandleFunc("/debug/query_rules", qe.handleHTTPQueryRules)
 	env.Exporter().HandleFunc("/debug/consolidations", qe.handleHTTPConsolidations)
 	env.Exporter().HandleFunc("/debug/acl", qe.handleHTTPAclJSON)
+	env.Exporter().HandleFunc("/debug/query_pins", qe.pins.handleHTTP)
 
 	return qe
 }
@@ -263,6 +281,7 @@ func (qe *QueryEngine) Open() error {
 
 	qe.streamConns.Open(qe.env.Config().DB.AppWithDB(), qe.env.Config().DB.DbaWithDB(), qe.env.Config().DB.AppDebugWithDB())
 	qe.se.RegisterNotifier("qe", qe.schemaChanged)
+	qe.pins.Open()
 	qe.isOpen = true
 	return nil
 }
@@ -275,6 +294,7 @@ func (qe *QueryEngine) Close() {
 		return
 	}
 	// Close in reverse order of Open.
+	qe.pins.Close()
 	qe.se.UnregisterNotifier("qe")
 	qe.plans.Clear()
 	qe.tables = make(map[string]*schema.Table)
@@ -306,6 +326,11 @@ func (qe *QueryEngine) GetPlan(ctx context.Context, logStats *tabletenv.LogStats
 	if err != nil {
 		return nil, err
 	}
+	// The plan of a pinned query is built from its replacement, but it is
+	// still cached, reported and filtered by query rules as the original.
+	if statement, err = qe.pins.replace(statement); err != nil {
+		return nil, err
+	}
 	splan, err := planbuilder.Build(statement, qe.tables, isReservedConn, qe.env.Config().DB.DBName)
 	if err != nil {
 		return nil, err
@@ -152,7 +152,8 @@ func (qre *QueryExecutor) Execute() (reply *sqltypes.Result, err error) {
 	switch qre.plan.PlanID {
 	case p.PlanSelect, p.PlanSelectImpossible, p.PlanShow:
 		maxrows := qre.getSelectLimit()
-		qre.bindVars["#maxLimit"] = sqltypes.Int64BindVariable(maxrows + 1)
+		quota := qre.tableQuota()
+		qre.bindVars["#maxLimit"] = sqltypes.Int64BindVariable(quota.selectLimit(maxrows) + 1)
 		if qre.bindVars[sqltypes.BvReplaceSchemaName] != nil {
 			qre.bindVars[sqltypes.BvSchemaName] = sqltypes.StringBindVariable(qre.tsv.config.DB.DBName)
 		}
@@ -160,6 +161,9 @@ func (qre *QueryExecutor) Execute() (reply *sqltypes.Result, err error) {
 		if err != nil {
 			return nil, err
 		}
+		if err := quota.add(qre, qr.Rows); err != nil {
+			return nil, err
+		}
 		if err := qre.verifyRowCount(int64(len(qr.Rows)), maxrows); err != nil {
 			return nil, err
 		}
@@ -243,7 +247,8 @@ func (qre *QueryExecutor) txConnExec(conn *StatefulConnection) (*sqltypes.Result
 		return qre.execStatefulConn(conn, qre.query, true)
 	case p.PlanSelect, p.PlanSelectImpossible, p.PlanShow:
 		maxrows := qre.getSelectLimit()
-		qre.bindVars["#maxLimit"] = sqltypes.Int64BindVariable(maxrows + 1)
+		quota := qre.tableQuota()
+		qre.bindVars["#maxLimit"] = sqltypes.Int64BindVariable(quota.selectLimit(maxrows) + 1)
 		if qre.bindVars[sqltypes.BvReplaceSchemaName] != nil {
 			qre.bindVars[sqltypes.BvSchemaName] = sqltypes.StringBindVariable(qre.tsv.config.DB.DBName)
 		}
@@ -251,6 +256,9 @@ func (qre *QueryExecutor) txConnExec(conn *StatefulConnection) (*sqltypes.Result
 		if err != nil {
 			return nil, err
 		}
+		if err := quota.add(qre, qr.Rows); err != nil {
+			return nil, err
+		}
 		if err := qre.verifyRowCount(int64(len(qr.Rows)), maxrows); err != nil {
 			return nil, err
 		}
@@ -278,6 +286,16 @@ func (qre *QueryExecutor) Stream(callback StreamCallback) error {
 		return err
 	}
 
+	if quota := qre.tableQuota(); quota != nil {
+		streamCallback := callback
+		callback = func(result *sqltypes.Result) error {
+			if err := quota.add(qre, result.Rows); err != nil {
+				return err
+			}
+			return streamCallback(result)
+		}
+	}
+
 	sql, sqlWithoutComments, err := qre.generateFinalSQL(qre.plan.FullQuery, qre.bindVars)
 	if err != nil {
 		return err
@@ -763,6 +763,59 @@ func TestQueryExecutorTableAcl(t *testing.T) {
 	}
 }
 
+func TestQueryExecutorTableQuota(t *testing.T) {
+	db := setUpQueryExecutorTest(t)
+	defer db.Close()
+	query := "select * from test_table limit 1000"
+	db.AddQuery(query, sqltypes.MakeTestResult(getTestTableFields(), "1|10|100", "2|20|200", "3|30|300"))
+	db.AddQuery("select * from test_table where 1 != 1", &sqltypes.Result{
+		Fields: getTestTableFields(),
+	})
+
+	aclName := fmt.Sprintf("simpleacl-test-%d", rand.Int63())
+	tableacl.Register(aclName, &simpleacl.Factory{})
+	tableacl.SetDefaultACL(aclName)
+	require.NoError(t, tableacl.SetQuotas(&tableacl.QuotaConfig{
+		TableGroups: []*tableacl.QuotaGroup{{
+			Name:                 "dumps",
+			TableNamesOrPrefixes: []string{"test_%"},
+			Users:                []string{"u2"},
+			MaxRows:              2,
+		}, {
+			Name:                 "all",
+			TableNamesOrPrefixes: []string{"test_table"},
+			MaxResultBytes:       100,
+		}},
+	}))
+	defer tableacl.SetQuotas(&tableacl.QuotaConfig{})
+
+	ctx := callerid.NewContext(context.Background(), nil, &querypb.VTGateCallerID{Username: "u2"})
+	tsv := newTestTabletServer(ctx, noFlags, db)
+	defer tsv.StopService()
+	qre := newTestQueryExecutor(ctx, tsv, query, 0)
+	_, err := qre.Execute()
+	require.Error(t, err)
+	assert.Equal(t, mysql.ERVitessTableQuotaExceeded, err.(*mysql.SQLError).Number())
+	assert.Contains(t, err.Error(), "table quota dumps exceeded: row count exceeded 2")
+	assert.EqualValues(t, 1, tsv.Stats().TableQuotaExceeded.Counts()["dumps.u2"])
+
+	err = qre.Stream(func(*sqltypes.Result) error { return nil })
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "table quota dumps exceeded")
+
+	ctx = callerid.NewContext(context.Background(), nil, &querypb.VTGateCallerID{Username: "u3"})
+	qre = newTestQueryExecutor(ctx, tsv, query, 0)
+	got, err := qre.Execute()
+	require.NoError(t, err)
+	assert.Len(t, got.Rows, 3)
+
+	db.AddQuery(query, sqltypes.MakeTestResult(getTestTableFields(), "1|"+strings.Repeat("1", 100)+"|100"))
+	qre = newTestQueryExecutor(ctx, tsv, query, 0)
+	_, err = qre.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "table quota all exceeded: result size exceeded 100 bytes")
+}
+
 func TestQueryExecutorTableAclNoPermission(t *testing.T) {
 	aclName := fmt.Sprintf("simpleacl-test-%d", rand.Int63())
 	tableacl.Register(aclName, &simpleacl.Factory{})
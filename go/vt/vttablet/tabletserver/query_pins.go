@@ -0,0 +1,199 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletserver
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"vitess.io/vitess/go/acl"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+var queryPinsRefreshInterval = flag.Duration("query_pins_refresh_interval", 30*time.Second, "How often the query pins of the keyspace are reloaded from the topo, see the PinQuery vtctl command. 0 disables the query pins.")
+
+// queryPins holds the query pins of the keyspace of the tablet, which
+// replace the pinned queries by their replacement before they are planned.
+// They are reloaded from the topo periodically while the query engine is
+// open. The query plan cache is cleared when they change, including when
+// a pin expires.
+type queryPins struct {
+	onChange func()
+
+	mu       sync.RWMutex
+	ts       *topo.Server
+	keyspace string
+	pins     map[string]*topodatapb.QueryPin
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+}
+
+func newQueryPins(onChange func()) *queryPins {
+	return &queryPins{onChange: onChange}
+}
+
+// InitDBConfig sets the keyspace whose pins are loaded.
+func (qp *queryPins) InitDBConfig(ts *topo.Server, keyspace string) {
+	qp.mu.Lock()
+	defer qp.mu.Unlock()
+	qp.ts = ts
+	qp.keyspace = keyspace
+}
+
+// Open starts reloading the pins.
+func (qp *queryPins) Open() {
+	qp.mu.Lock()
+	defer qp.mu.Unlock()
+	if qp.cancel != nil || qp.ts == nil || qp.keyspace == "" || *queryPinsRefreshInterval <= 0 {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	qp.cancel = cancel
+	qp.wg.Add(1)
+	go qp.run(ctx, *queryPinsRefreshInterval)
+}
+
+// Close stops reloading the pins, and drops them.
+func (qp *queryPins) Close() {
+	qp.mu.Lock()
+	cancel := qp.cancel
+	qp.cancel = nil
+	qp.pins = nil
+	qp.mu.Unlock()
+	if cancel != nil {
+		cancel()
+		qp.wg.Wait()
+	}
+}
+
+func (qp *queryPins) run(ctx context.Context, interval time.Duration) {
+	defer qp.wg.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		qp.refresh(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// refresh reloads the pins from the keyspace record. The current pins are
+// kept if it can't be read.
+func (qp *queryPins) refresh(ctx context.Context) {
+	qp.mu.RLock()
+	ts, keyspace := qp.ts, qp.keyspace
+	qp.mu.RUnlock()
+	ki, err := ts.GetKeyspace(ctx, keyspace)
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Warningf("Failed to load the query pins of keyspace %v: %v", keyspace, err)
+		}
+		return
+	}
+	qp.set(ki.QueryPins, time.Now())
+}
+
+// set replaces the pins by the ones which are not expired at time now. It
+// calls onChange if they changed.
+func (qp *queryPins) set(pins []*topodatapb.QueryPin, now time.Time) {
+	active := make(map[string]*topodatapb.QueryPin)
+	for _, pin := range pins {
+		if !topoproto.QueryPinExpired(pin, now) {
+			active[pin.Query] = pin
+		}
+	}
+
+	qp.mu.Lock()
+	changed := len(active) != len(qp.pins)
+	for query, pin := range active {
+		old, ok := qp.pins[query]
+		if !ok || !proto.Equal(old, pin) {
+			changed = true
+		}
+	}
+	if changed {
+		qp.pins = active
+	}
+	qp.mu.Unlock()
+
+	if changed {
+		log.Infof("Query pins of keyspace %v changed: %v active pins", qp.keyspace, len(active))
+		qp.onChange()
+	}
+}
+
+// replace returns the replacement of the statement if it is pinned, or the
+// statement itself.
+func (qp *queryPins) replace(statement sqlparser.Statement) (sqlparser.Statement, error) {
+	qp.mu.RLock()
+	defer qp.mu.RUnlock()
+	if len(qp.pins) == 0 {
+		return statement, nil
+	}
+	pin, ok := qp.pins[sqlparser.String(statement)]
+	if !ok || topoproto.QueryPinExpired(pin, time.Now()) {
+		return statement, nil
+	}
+	return sqlparser.Parse(pin.Replacement)
+}
+
+// list returns the active pins, sorted by query.
+func (qp *queryPins) list() []*topodatapb.QueryPin {
+	qp.mu.RLock()
+	defer qp.mu.RUnlock()
+	pins := make([]*topodatapb.QueryPin, 0, len(qp.pins))
+	for _, pin := range qp.pins {
+		pins = append(pins, pin)
+	}
+	sort.Slice(pins, func(i, j int) bool { return pins[i].Query < pins[j].Query })
+	return pins
+}
+
+func (qp *queryPins) count() int64 {
+	qp.mu.RLock()
+	defer qp.mu.RUnlock()
+	return int64(len(qp.pins))
+}
+
+func (qp *queryPins) handleHTTP(response http.ResponseWriter, request *http.Request) {
+	if err := acl.CheckAccessHTTP(request, acl.DEBUGGING); err != nil {
+		acl.SendError(response, err)
+		return
+	}
+	b, err := json.MarshalIndent(qp.list(), "", " ")
+	if err != nil {
+		response.Write([]byte(err.Error()))
+		return
+	}
+	response.Header().Set("Content-Type", "application/json")
+	response.Write(b)
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql/fakesqldb"
+	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/schema/schematest"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func TestQueryPinsSet(t *testing.T) {
+	changes := 0
+	qp := newQueryPins(func() { changes++ })
+	now := time.Now()
+	pin := &topodatapb.QueryPin{Query: "select a from t", Replacement: "select a from t force index (a)"}
+	expired := &topodatapb.QueryPin{Query: "select b from t", Replacement: "select b from t force index (b)", Expires: logutil.TimeToProto(now)}
+
+	qp.set([]*topodatapb.QueryPin{pin, expired}, now)
+	assert.Equal(t, 1, changes)
+	assert.Equal(t, []*topodatapb.QueryPin{pin}, qp.list())
+
+	// Same pins: no change.
+	qp.set([]*topodatapb.QueryPin{{Query: pin.Query, Replacement: pin.Replacement}, expired}, now)
+	assert.Equal(t, 1, changes)
+
+	updated := &topodatapb.QueryPin{Query: pin.Query, Replacement: "select a from t use index (a)"}
+	qp.set([]*topodatapb.QueryPin{updated}, now)
+	assert.Equal(t, 2, changes)
+
+	stmt, err := sqlparser.Parse("select a from t")
+	require.NoError(t, err)
+	replaced, err := qp.replace(stmt)
+	require.NoError(t, err)
+	assert.Equal(t, "select a from t use index (a)", sqlparser.String(replaced))
+
+	stmt, err = sqlparser.Parse("select c from t")
+	require.NoError(t, err)
+	replaced, err = qp.replace(stmt)
+	require.NoError(t, err)
+	assert.Equal(t, stmt, replaced)
+
+	// A pin which expires is dropped.
+	qp.set([]*topodatapb.QueryPin{updated, {Query: "select c from t", Replacement: "select c from t", Expires: logutil.TimeToProto(now.Add(time.Minute))}}, now)
+	assert.Equal(t, 3, changes)
+	assert.EqualValues(t, 2, qp.count())
+	qp.set([]*topodatapb.QueryPin{updated, {Query: "select c from t", Replacement: "select c from t", Expires: logutil.TimeToProto(now.Add(time.Minute))}}, now.Add(time.Hour))
+	assert.Equal(t, 4, changes)
+	assert.EqualValues(t, 1, qp.count())
+
+	qp.set(nil, now)
+	assert.Equal(t, 5, changes)
+	assert.Empty(t, qp.list())
+}
+
+func TestGetPlanPinnedQuery(t *testing.T) {
+	db := fakesqldb.New(t)
+	defer db.Close()
+	for query, result := range schematest.Queries() {
+		db.AddQuery(query, result)
+	}
+	addSchemaEngineQueries(db)
+
+	qe := newTestQueryEngine(10*time.Second, true, newDBConfigs(db))
+	qe.se.Open()
+	qe.Open()
+	defer qe.Close()
+
+	ctx := context.Background()
+	logStats := tabletenv.NewLogStats(ctx, "GetPlanStats")
+	query := "select * from test_table_01"
+	plan, err := qe.GetPlan(ctx, logStats, query, false, false /* inReservedConn */)
+	require.NoError(t, err)
+	assert.Equal(t, "test_table_01", plan.TableName().String())
+
+	// Pinning the query clears the cached plan.
+	qe.pins.set([]*topodatapb.QueryPin{{Query: query, Replacement: "select * from test_table_02"}}, time.Now())
+	plan, err = qe.GetPlan(ctx, logStats, query, false, false /* inReservedConn */)
+	require.NoError(t, err)
+	assert.Equal(t, "test_table_02", plan.TableName().String())
+	assert.Equal(t, query, plan.Original)
+
+	qe.pins.set(nil, time.Now())
+	plan, err = qe.GetPlan(ctx, logStats, query, false, false /* inReservedConn */)
+	require.NoError(t, err)
+	assert.Equal(t, "test_table_01", plan.TableName().String())
+}
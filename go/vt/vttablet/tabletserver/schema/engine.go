@@ -35,6 +35,7 @@ import (
 	"vitess.io/vitess/go/timer"
 	"vitess.io/vitess/go/vt/concurrency"
 	"vitess.io/vitess/go/vt/dbconfigs"
+	"vitess.io/vitess/go/vt/eventlog"
 	"vitess.io/vitess/go/vt/log"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vterrors"
@@ -205,7 +206,7 @@ func (se *Engine) Open() error {
 
 	se.ticks.Start(func() {
 		if err := se.Reload(ctx); err != nil {
-			log.Errorf("periodic schema reload failed: %v", err)
+			eventlog.Errorf("schema_reload_error", "", eventlog.Fields{"error": err.Error()}, "periodic schema reload failed: %v", err)
 		}
 	})
 
@@ -407,7 +408,7 @@ func (se *Engine) updateInnoDBRowsRead(ctx context.Context, conn *connpool.DBCon
 
 		se.innoDbReadRowsGauge.Set(value)
 	} else {
-		log.Warningf("got strange results from 'show status': %v", readRowsData.Rows)
+		eventlog.Warningf("schema_reload_warning", "", eventlog.Fields{"rows": fmt.Sprint(readRowsData.Rows)}, "got strange results from 'show status': %v", readRowsData.Rows)
 	}
 	return nil
 }
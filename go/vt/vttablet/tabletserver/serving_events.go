@@ -0,0 +1,125 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/acl"
+	"vitess.io/vitess/go/vt/log"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+// Reasons of the serving state transitions.
+const (
+	// ServingReasonLag is used when the replication lag is above the
+	// unhealthy threshold.
+	ServingReasonLag = "lag"
+	// ServingReasonReplicationStopped is used when the replication status
+	// can't be determined, e.g. because replication is stopped.
+	ServingReasonReplicationStopped = "replication_stopped"
+	// ServingReasonOperator is used when the tablet was asked to stop or
+	// start serving, e.g. by a reparent or a change of tablet type.
+	ServingReasonOperator = "operator"
+	// ServingReasonShutdown is used when the tablet is shutting down.
+	ServingReasonShutdown = "shutdown"
+)
+
+// maxServingEvents is the number of serving state transitions kept.
+const maxServingEvents = 100
+
+// ServingEvent is a transition of the tablet between serving and not
+// serving, as reported to the health check. For a transition to serving,
+// the reason is the one of the previous transition to not serving.
+type ServingEvent struct {
+	Time       time.Time `json:"time"`
+	Keyspace   string    `json:"keyspace"`
+	Shard      string    `json:"shard"`
+	TabletType string    `json:"tablet_type"`
+	Serving    bool      `json:"serving"`
+	Reason     string    `json:"reason"`
+	Details    string    `json:"details,omitempty"`
+}
+
+// servingEvents keeps the last serving state transitions of the tablet,
+// which are also logged. They are served as JSON on /debug/serving_events.
+// The zero value is ready to use, the tablet starting as not serving.
+type servingEvents struct {
+	mu      sync.Mutex
+	serving bool
+	reason  string
+	events  []*ServingEvent
+}
+
+// record records a transition if serving differs from the last recorded
+// state.
+func (se *servingEvents) record(now time.Time, target *querypb.Target, serving bool, reason, details string) {
+	se.mu.Lock()
+	defer se.mu.Unlock()
+	if se.serving == serving {
+		return
+	}
+	if serving {
+		// When the tablet starts, it was asked to serve.
+		reason, details = ServingReasonOperator, ""
+		if se.reason != "" {
+			reason = se.reason
+		}
+	}
+	se.serving = serving
+	se.reason = reason
+	event := &ServingEvent{
+		Time:       now,
+		Keyspace:   target.Keyspace,
+		Shard:      target.Shard,
+		TabletType: target.TabletType.String(),
+		Serving:    serving,
+		Reason:     reason,
+		Details:    details,
+	}
+	log.Infof("Serving state transition: serving=%v reason=%v details=%q for tablet %s/%s %v",
+		serving, reason, details, target.Keyspace, target.Shard, target.TabletType)
+	se.events = append(se.events, event)
+	if len(se.events) > maxServingEvents {
+		se.events = se.events[len(se.events)-maxServingEvents:]
+	}
+}
+
+// list returns the recorded transitions, oldest first.
+func (se *servingEvents) list() []*ServingEvent {
+	se.mu.Lock()
+	defer se.mu.Unlock()
+	return append([]*ServingEvent(nil), se.events...)
+}
+
+func (se *servingEvents) handleHTTP(response http.ResponseWriter, request *http.Request) {
+	if err := acl.CheckAccessHTTP(request, acl.DEBUGGING); err != nil {
+		acl.SendError(response, err)
+		return
+	}
+	b, err := json.MarshalIndent(se.list(), "", " ")
+	if err != nil {
+		response.Write([]byte(err.Error()))
+		return
+	}
+	response.Header().Set("Content-Type", "application/json")
+	response.Write(b)
+}
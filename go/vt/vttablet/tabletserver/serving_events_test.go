@@ -0,0 +1,99 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletserver
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func TestServingEvents(t *testing.T) {
+	sm := newTestStateManager(t)
+	defer sm.StopService()
+	rt := sm.rt.(*testReplTracker)
+	// The health check ticks also read the replication status.
+	setRepl := func(lag time.Duration, err error) {
+		sm.mu.Lock()
+		defer sm.mu.Unlock()
+		rt.lag, rt.err = lag, err
+	}
+
+	reasons := func() []string {
+		var reasons []string
+		for _, event := range sm.events.list() {
+			state := "NOT_SERVING"
+			if event.Serving {
+				state = "SERVING"
+			}
+			reasons = append(reasons, state+":"+event.Reason)
+		}
+		return reasons
+	}
+
+	require.NoError(t, sm.SetServingType(topodatapb.TabletType_REPLICA, testNow, StateServing, ""))
+	sm.Broadcast()
+	assert.Equal(t, []string{"SERVING:operator"}, reasons())
+
+	setRepl(3*time.Hour, nil)
+	sm.Broadcast()
+	setRepl(time.Second, errors.New("replication stopped"))
+	sm.Broadcast()
+	setRepl(time.Second, nil)
+	sm.Broadcast()
+	assert.Equal(t, []string{"SERVING:operator", "NOT_SERVING:lag", "SERVING:lag"}, reasons())
+
+	setRepl(time.Second, errors.New("replication stopped"))
+	sm.Broadcast()
+	setRepl(time.Second, nil)
+	sm.Broadcast()
+	events := sm.events.list()
+	require.Len(t, events, 5)
+	assert.Equal(t, "replication_stopped", events[3].Reason)
+	assert.Equal(t, "replication stopped", events[3].Details)
+	assert.Equal(t, "REPLICA", events[3].TabletType)
+
+	require.NoError(t, sm.SetServingType(topodatapb.TabletType_REPLICA, testNow, StateNotServing, "demotion"))
+	sm.Broadcast()
+	events = sm.events.list()
+	require.Len(t, events, 6)
+	assert.Equal(t, "operator", events[5].Reason)
+	assert.Equal(t, "demotion", events[5].Details)
+
+	require.NoError(t, sm.SetServingType(topodatapb.TabletType_REPLICA, testNow, StateServing, ""))
+	sm.Broadcast()
+	sm.EnterLameduck()
+	assert.Equal(t, "SERVING:operator", reasons()[6])
+	assert.Equal(t, "NOT_SERVING:shutdown", reasons()[7])
+}
+
+func TestServingEventsLimit(t *testing.T) {
+	var se servingEvents
+	target := newTestStateManager(t).target
+	for i := 0; i < maxServingEvents; i++ {
+		se.record(time.Now(), target, true, "", "")
+		se.record(time.Now(), target, false, ServingReasonLag, "")
+	}
+	events := se.list()
+	assert.Len(t, events, maxServingEvents)
+	assert.False(t, events[len(events)-1].Serving)
+}
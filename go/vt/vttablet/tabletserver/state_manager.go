@@ -94,6 +94,9 @@ type stateManager struct {
 
 	requests sync.WaitGroup
 
+	// events records the transitions between serving and not serving.
+	events servingEvents
+
 	// QueryList does not have an Open or Close.
 	statelessql *QueryList
 	statefulql  *QueryList
@@ -614,9 +617,34 @@ func (sm *stateManager) Broadcast() {
 	defer sm.mu.Unlock()
 
 	lag, err := sm.refreshReplHealthLocked()
+	sm.recordServingLocked(err)
 	sm.hs.ChangeState(sm.target.TabletType, sm.terTimestamp, lag, err, sm.isServingLocked())
 }
 
+// recordServingLocked records a transition between serving and not
+// serving, with its reason. replErr is the last replication error.
+func (sm *stateManager) recordServingLocked(replErr error) {
+	if sm.target == nil {
+		return
+	}
+	serving := sm.isServingLocked()
+	var reason, details string
+	switch {
+	case serving:
+	case sm.lameduck:
+		reason, details = ServingReasonShutdown, "lameduck"
+	case sm.wantState == StateNotConnected:
+		reason, details = ServingReasonShutdown, sm.reason
+	case sm.state != StateServing || sm.wantState != StateServing:
+		reason, details = ServingReasonOperator, sm.reason
+	case replErr != nil:
+		reason, details = ServingReasonReplicationStopped, replErr.Error()
+	default:
+		reason, details = ServingReasonLag, fmt.Sprintf("replication lag above %v", sm.unhealthyThreshold.Get())
+	}
+	sm.events.record(time.Now(), sm.target, serving, reason, details)
+}
+
 func (sm *stateManager) refreshReplHealthLocked() (time.Duration, error) {
 	if sm.target.TabletType == topodatapb.TabletType_MASTER {
 		sm.replHealthy = true
@@ -653,6 +681,7 @@ func (sm *stateManager) EnterLameduck() {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
 	sm.lameduck = true
+	sm.recordServingLocked(nil)
 }
 
 // ExitLameduck causes the tabletserver to exit the lameduck mode.
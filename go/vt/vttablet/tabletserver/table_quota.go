@@ -0,0 +1,122 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletserver
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/tableacl"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
+)
+
+// InitTableQuotas loads the per-table quotas and sets up a SIGHUP handler
+// for reloading them.
+func (tsv *TabletServer) InitTableQuotas(configFile string) {
+	tsv.initTableQuotas(configFile)
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGHUP)
+	go func() {
+		for range sigChan {
+			tsv.initTableQuotas(configFile)
+		}
+	}()
+}
+
+func (tsv *TabletServer) initTableQuotas(configFile string) {
+	err := tableacl.InitQuotas(
+		configFile,
+		func() {
+			tsv.ClearQueryPlanCache()
+		},
+	)
+	if err != nil {
+		log.Errorf("Fail to initialize table quotas: %v", err)
+	}
+}
+
+// tableQuota is the tightest of the table quotas which apply to a query.
+type tableQuota struct {
+	username       string
+	maxRows        int64
+	maxRowsGroup   string
+	maxBytes       int64
+	maxBytesGroup  string
+	rows, numBytes int64
+}
+
+// tableQuota returns the quota of the query, or nil if it has none.
+func (qre *QueryExecutor) tableQuota() *tableQuota {
+	if len(qre.plan.Quotas) == 0 || tabletenv.IsLocalContext(qre.ctx) {
+		return nil
+	}
+	callerID := callerid.ImmediateCallerIDFromContext(qre.ctx)
+	var tq *tableQuota
+	for _, quota := range qre.plan.Quotas {
+		if !quota.AppliesTo(callerID) {
+			continue
+		}
+		if tq == nil {
+			tq = &tableQuota{username: callerID.GetUsername()}
+		}
+		if quota.MaxRows > 0 && (tq.maxRows == 0 || quota.MaxRows < tq.maxRows) {
+			tq.maxRows, tq.maxRowsGroup = quota.MaxRows, quota.GroupName
+		}
+		if quota.MaxResultBytes > 0 && (tq.maxBytes == 0 || quota.MaxResultBytes < tq.maxBytes) {
+			tq.maxBytes, tq.maxBytesGroup = quota.MaxResultBytes, quota.GroupName
+		}
+	}
+	return tq
+}
+
+// selectLimit returns the number of rows to read from MySQL, so that it
+// stops scanning once the quota is exceeded.
+func (tq *tableQuota) selectLimit(maxrows int64) int64 {
+	if tq != nil && tq.maxRows > 0 && tq.maxRows < maxrows {
+		return tq.maxRows
+	}
+	return maxrows
+}
+
+// add accounts for rows read from MySQL, and returns an error if the
+// quota is exceeded.
+func (tq *tableQuota) add(qre *QueryExecutor, rows [][]sqltypes.Value) error {
+	if tq == nil {
+		return nil
+	}
+	tq.rows += int64(len(rows))
+	for _, row := range rows {
+		for _, v := range row {
+			tq.numBytes += int64(len(v.Raw()))
+		}
+	}
+	if tq.maxRows > 0 && tq.rows > tq.maxRows {
+		qre.tsv.Stats().TableQuotaExceeded.Add([]string{tq.maxRowsGroup, tq.username}, 1)
+		return mysql.NewSQLError(mysql.ERVitessTableQuotaExceeded, mysql.SSUnknownSQLState, "caller id: %s: table quota %s exceeded: row count exceeded %d", tq.username, tq.maxRowsGroup, tq.maxRows)
+	}
+	if tq.maxBytes > 0 && tq.numBytes > tq.maxBytes {
+		qre.tsv.Stats().TableQuotaExceeded.Add([]string{tq.maxBytesGroup, tq.username}, 1)
+		return mysql.NewSQLError(mysql.ERVitessTableQuotaExceeded, mysql.SSUnknownSQLState, "caller id: %s: table quota %s exceeded: result size exceeded %d bytes", tq.username, tq.maxBytesGroup, tq.maxBytes)
+	}
+	return nil
+}
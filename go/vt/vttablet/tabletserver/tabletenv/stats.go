@@ -29,6 +29,8 @@ type Stats struct {
 	MySQLTimings           *servenv.TimingsWrapper        // Time spent executing MySQL commands
 	QueryTimings           *servenv.TimingsWrapper        // Query timings
 	QPSRates               *stats.Rates                   // Human readable QPS rates
+	QPSRateWindows         *stats.WindowRates             // QPS over the last minute, 5 minutes and hour
+	ErrorRateWindows       *stats.WindowRates             // Errors per second over the last minute, 5 minutes and hour
 	WaitTimings            *servenv.TimingsWrapper        // waits like Consolidations etc
 	KillCounters           *stats.CountersWithSingleLabel // Connection and transaction kills
 	ErrorCounters          *stats.CountersWithSingleLabel
@@ -43,12 +45,20 @@ type Stats struct {
 	TableaclAllowed        *stats.CountersWithMultiLabels // Number of allows
 	TableaclDenied         *stats.CountersWithMultiLabels // Number of denials
 	TableaclPseudoDenied   *stats.CountersWithMultiLabels // Number of pseudo denials
+	TableQuotaExceeded     *stats.CountersWithMultiLabels // Number of queries failed by a table quota
 
 	UserActiveReservedCount *stats.CountersWithSingleLabel // Per CallerID active reserved connection counts
 	UserReservedCount       *stats.CountersWithSingleLabel // Per CallerID reserved connection counts
 	UserReservedTimesNs     *stats.CountersWithSingleLabel // Per CallerID reserved connection duration
 }
 
+// rateWindows are the windows of the rates shown on /debug/rate_windows,
+// sampled every rateWindowsInterval.
+var (
+	rateWindows         = stats.DefaultRateWindows
+	rateWindowsInterval = 10 * time.Second
+)
+
 // NewStats instantiates a new set of stats scoped by exporter.
 func NewStats(exporter *servenv.Exporter) *Stats {
 	stats := &Stats{
@@ -89,11 +99,14 @@ func NewStats(exporter *servenv.Exporter) *Stats {
 		TableaclAllowed:        exporter.NewCountersWithMultiLabels("TableACLAllowed", "ACL acceptances", []string{"TableName", "TableGroup", "PlanID", "Username"}),
 		TableaclDenied:         exporter.NewCountersWithMultiLabels("TableACLDenied", "ACL denials", []string{"TableName", "TableGroup", "PlanID", "Username"}),
 		TableaclPseudoDenied:   exporter.NewCountersWithMultiLabels("TableACLPseudoDenied", "ACL pseudodenials", []string{"TableName", "TableGroup", "PlanID", "Username"}),
+		TableQuotaExceeded:     exporter.NewCountersWithMultiLabels("TableQuotaExceeded", "Queries failed because they exceeded a table quota", []string{"TableGroup", "Username"}),
 
 		UserActiveReservedCount: exporter.NewCountersWithSingleLabel("UserActiveReservedCount", "active reserved connection for each CallerID", "CallerID"),
 		UserReservedCount:       exporter.NewCountersWithSingleLabel("UserReservedCount", "reserved connection received for each CallerID", "CallerID"),
 		UserReservedTimesNs:     exporter.NewCountersWithSingleLabel("UserReservedTimesNs", "Total reserved connection latency for each CallerID", "CallerID"),
 	}
 	stats.QPSRates = exporter.NewRates("QPS", stats.QueryTimings, 15*60/5, 5*time.Second)
+	stats.QPSRateWindows = exporter.NewWindowRates("QPSWindows", stats.QueryTimings, rateWindows, rateWindowsInterval)
+	stats.ErrorRateWindows = exporter.NewWindowRates("ErrorsWindows", stats.ErrorCounters, rateWindows, rateWindowsInterval)
 	return stats
 }
@@ -35,6 +35,7 @@ import (
 
 	"vitess.io/vitess/go/acl"
 	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/pools"
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/stats"
 	"vitess.io/vitess/go/sync2"
@@ -216,6 +217,7 @@ func NewTabletServer(name string, config *tabletenv.TabletConfig, topoServer *to
 
 	tsv.registerHealthzHealthHandler()
 	tsv.registerDebugHealthHandler()
+	tsv.registerServingEventsHandler()
 	tsv.registerQueryzHandler()
 	tsv.registerQueryListHandlers([]*QueryList{tsv.statelessql, tsv.statefulql, tsv.olapql})
 	tsv.registerTwopczHandler()
@@ -235,11 +237,13 @@ func (tsv *TabletServer) InitDBConfig(target *querypb.Target, dbcfgs *dbconfigs.
 	tsv.sm.Init(tsv, target)
 	tsv.sm.target = proto.Clone(target).(*querypb.Target)
 	tsv.config.DB = dbcfgs
+	pools.SetStatsTarget(target.Keyspace, target.Shard)
 
 	tsv.se.InitDBConfig(tsv.config.DB.DbaWithDB())
+	tsv.qe.pins.InitDBConfig(tsv.topoServer, target.Keyspace)
 	tsv.rt.InitDBConfig(target, mysqld)
 	tsv.txThrottler.InitDBConfig(target)
-	tsv.vstreamer.InitDBConfig(target.Keyspace)
+	tsv.vstreamer.InitDBConfig(target.Keyspace, target.Shard)
 	tsv.hs.InitDBConfig(target, tsv.config.DB.DbaWithDB())
 	tsv.onlineDDLExecutor.InitDBConfig(target.Keyspace, target.Shard, dbcfgs.DBName)
 	tsv.lagThrottler.InitDBConfig(target.Keyspace, target.Shard)
@@ -1415,7 +1419,7 @@ func convertErrorCode(err error) vtrpcpb.Code {
 	case mysql.ERNotSupportedYet:
 		errCode = vtrpcpb.Code_UNIMPLEMENTED
 	case mysql.ERDiskFull, mysql.EROutOfMemory, mysql.EROutOfSortMemory, mysql.ERConCount, mysql.EROutOfResources, mysql.ERRecordFileFull, mysql.ERHostIsBlocked,
-		mysql.ERCantCreateThread, mysql.ERTooManyDelayedThreads, mysql.ERNetPacketTooLarge, mysql.ERTooManyUserConnections, mysql.ERLockTableFull, mysql.ERUserLimitReached, mysql.ERVitessMaxRowsExceeded:
+		mysql.ERCantCreateThread, mysql.ERTooManyDelayedThreads, mysql.ERNetPacketTooLarge, mysql.ERTooManyUserConnections, mysql.ERLockTableFull, mysql.ERUserLimitReached, mysql.ERVitessMaxRowsExceeded, mysql.ERVitessTableQuotaExceeded:
 		errCode = vtrpcpb.Code_RESOURCE_EXHAUSTED
 	case mysql.ERLockWaitTimeout:
 		errCode = vtrpcpb.Code_DEADLINE_EXCEEDED
@@ -1517,6 +1521,11 @@ func (tsv *TabletServer) TopoServer() *topo.Server {
 	return tsv.topoServer
 }
 
+// VStreamerPositions is part of the tabletserver.Controller interface.
+func (tsv *TabletServer) VStreamerPositions() []mysql.Position {
+	return tsv.vstreamer.StreamerPositions()
+}
+
 // HandlePanic is part of the queryservice.QueryService interface
 func (tsv *TabletServer) HandlePanic(err *error) {
 	if x := recover(); x != nil {
@@ -1568,6 +1577,10 @@ func (tsv *TabletServer) registerDebugHealthHandler() {
 	})
 }
 
+func (tsv *TabletServer) registerServingEventsHandler() {
+	tsv.exporter.HandleFunc("/debug/serving_events", tsv.sm.events.handleHTTP)
+}
+
 func (tsv *TabletServer) registerQueryzHandler() {
 	tsv.exporter.HandleFunc("/queryz", func(w http.ResponseWriter, r *http.Request) {
 		queryzHandler(tsv.qe, w, r)
@@ -123,6 +123,16 @@ func (mr *MockHealthCheckMockRecorder) SetListener(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetListener", reflect.TypeOf((*MockHealthCheck)(nil).SetListener), arg0, arg1)
 }
 
+// UpdateTablet mocks base method
+func (m *MockHealthCheck) UpdateTablet(arg0 *topodata.Tablet) {
+	m.ctrl.Call(m, "UpdateTablet", arg0)
+}
+
+// UpdateTablet indicates an expected call of UpdateTablet
+func (mr *MockHealthCheckMockRecorder) UpdateTablet(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTablet", reflect.TypeOf((*MockHealthCheck)(nil).UpdateTablet), arg0)
+}
+
 // WaitForInitialStatsUpdates mocks base method
 func (m *MockHealthCheck) WaitForInitialStatsUpdates() {
 	m.ctrl.Call(m, "WaitForInitialStatsUpdates")
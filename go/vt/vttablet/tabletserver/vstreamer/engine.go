@@ -27,6 +27,7 @@ import (
 	"vitess.io/vitess/go/vt/servenv"
 
 	"vitess.io/vitess/go/acl"
+	"vitess.io/vitess/go/mysql"
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/stats"
 	"vitess.io/vitess/go/vt/log"
@@ -52,8 +53,9 @@ type Engine struct {
 	se   *schema.Engine
 	cell string
 
-	// keyspace is initialized by InitDBConfig
+	// keyspace and shard are initialized by InitDBConfig
 	keyspace string
+	shard    string
 
 	// wg is incremented for every Stream, and decremented on end.
 	// Close waits for all current streams to end by waiting on wg.
@@ -130,8 +132,9 @@ func NewEngine(env tabletenv.Env, ts srvtopo.Server, se *schema.Engine, lagThrot
 }
 
 // InitDBConfig initializes the target parameters for the Engine.
-func (vse *Engine) InitDBConfig(keyspace string) {
+func (vse *Engine) InitDBConfig(keyspace, shard string) {
 	vse.keyspace = keyspace
+	vse.shard = shard
 }
 
 // Open starts the Engine service.
@@ -225,6 +228,20 @@ func (vse *Engine) Stream(ctx context.Context, startPos string, tablePKs []*binl
 	return streamer.Stream()
 }
 
+// StreamerPositions returns the current binlog position of each running
+// stream. The streams whose start position isn't known yet are skipped.
+func (vse *Engine) StreamerPositions() []mysql.Position {
+	vse.mu.Lock()
+	defer vse.mu.Unlock()
+	var positions []mysql.Position
+	for _, s := range vse.streamers {
+		if pos := s.getPos(); !pos.IsZero() {
+			positions = append(positions, pos)
+		}
+	}
+	return positions
+}
+
 // StreamRows streams rows.
 // This streams the table data rows (so we can copy the table data snapshot)
 func (vse *Engine) StreamRows(ctx context.Context, query string, lastpk []sqltypes.Value, send func(*binlogdatapb.VStreamRowsResponse) error) error {
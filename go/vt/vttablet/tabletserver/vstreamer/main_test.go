@@ -54,7 +54,7 @@ func TestMain(m *testing.M) {
 		// engine cannot be initialized in testenv because it introduces
 		// circular dependencies
 		engine = NewEngine(env.TabletEnv, env.SrvTopo, env.SchemaEngine, nil, env.Cells[0])
-		engine.InitDBConfig(env.KeyspaceName)
+		engine.InitDBConfig(env.KeyspaceName, env.ShardName)
 		engine.Open()
 		defer engine.Close()
 
@@ -71,7 +71,7 @@ func customEngine(t *testing.T, modifier func(mysql.ConnParams) mysql.ConnParams
 	config.DB = dbconfigs.NewTestDBConfigs(modified, modified, modified.DbName)
 
 	engine := NewEngine(tabletenv.NewEnv(config, "VStreamerTest"), env.SrvTopo, env.SchemaEngine, nil, env.Cells[0])
-	engine.InitDBConfig(env.KeyspaceName)
+	engine.InitDBConfig(env.KeyspaceName, env.ShardName)
 	engine.Open()
 	return engine
 }
@@ -282,8 +282,9 @@ func (uvs *uvstreamer) send2(evs []*binlogdatapb.VEvent) error {
 	}
 	for _, ev := range evs2 {
 		if ev.Type == binlogdatapb.VEventType_GTID {
-			uvs.pos, _ = mysql.DecodePosition(ev.Gtid)
-			if !uvs.stopPos.IsZero() && uvs.pos.AtLeast(uvs.stopPos) {
+			pos, _ := mysql.DecodePosition(ev.Gtid)
+			uvs.setPos(pos)
+			if !uvs.stopPos.IsZero() && pos.AtLeast(uvs.stopPos) {
 				err = io.EOF
 			}
 		}
@@ -314,7 +315,7 @@ func (uvs *uvstreamer) setStreamStartPosition() error {
 		return vterrors.Wrap(err, "could not obtain current position")
 	}
 	if uvs.startPos == "current" {
-		uvs.pos = curPos
+		uvs.setPos(curPos)
 		if err := uvs.sendEventsForCurrentPos(); err != nil {
 			return err
 		}
@@ -328,7 +329,7 @@ func (uvs *uvstreamer) setStreamStartPosition() error {
 		uvs.vse.errorCounts.Add("GTIDSet Mismatch", 1)
 		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "GTIDSet Mismatch: requested source position:%v, current target vrep position: %v", mysql.EncodePosition(pos), mysql.EncodePosition(curPos))
 	}
-	uvs.pos = pos
+	uvs.setPos(pos)
 	return nil
 }
 
@@ -479,10 +480,26 @@ func (uvs *uvstreamer) setPosition(gtid string, isInTx bool) error {
 	if err := uvs.send(evs); err != nil {
 		return err
 	}
-	uvs.pos = pos
+	uvs.setPos(pos)
 	return nil
 }
 
+// setPos sets the current position of the streamer. It's only called by
+// the goroutine of the stream, which can read uvs.pos without the lock.
+func (uvs *uvstreamer) setPos(pos mysql.Position) {
+	uvs.mu.Lock()
+	defer uvs.mu.Unlock()
+	uvs.pos = pos
+}
+
+// getPos returns the current position of the streamer. It's zero until
+// the start position of the stream is known.
+func (uvs *uvstreamer) getPos() mysql.Position {
+	uvs.mu.Lock()
+	defer uvs.mu.Unlock()
+	return uvs.pos
+}
+
 func (uvs *uvstreamer) getSecondsBehindMaster() int64 {
 	uvs.mu.Lock()
 	defer uvs.mu.Unlock()
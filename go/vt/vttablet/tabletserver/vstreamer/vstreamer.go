@@ -19,6 +19,8 @@ package vstreamer
 import (
 	"bytes"
 	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"io"
 	"strings"
@@ -32,8 +34,10 @@ import (
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/sync2"
 	"vitess.io/vitess/go/vt/binlog"
+	"vitess.io/vitess/go/vt/binlog/binlogarchive"
 	"vitess.io/vitess/go/vt/dbconfigs"
 	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/mysqlctl/backupstorage"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/schema"
 
@@ -54,6 +58,12 @@ var HeartbeatTime = 900 * time.Millisecond
 // TODO(sougou): find a better way for this.
 var vschemaUpdateCount sync2.AtomicInt64
 
+var binlogArchive = flag.Bool("vstream_binlog_archive", false, "if set, a vstream whose start position was purged from the binary logs catches up from the binlog archive of the shard, see -binlog_archive")
+
+// errServerEOF is returned by parseEvents when the events end before the
+// stream is canceled.
+var errServerEOF = errors.New("unexpected server EOF")
+
 // vstreamer is for serving a single vreplication stream on the source side.
 type vstreamer struct {
 	ctx    context.Context
@@ -165,6 +175,13 @@ func (vs *vstreamer) replicate(ctx context.Context) error {
 		return wrapError(err, vs.pos, vs.vse)
 	}
 
+	if *binlogArchive {
+		done, err := vs.replayArchive()
+		if err != nil || done {
+			return wrapError(err, vs.pos, vs.vse)
+		}
+	}
+
 	conn, err := binlog.NewBinlogConnection(vs.cp)
 	if err != nil {
 		return wrapError(err, vs.pos, vs.vse)
@@ -179,6 +196,62 @@ func (vs *vstreamer) replicate(ctx context.Context) error {
 	return wrapError(err, vs.pos, vs.vse)
 }
 
+// replayArchive streams the events of the binlog archive of the shard
+// if some of the GTIDs after the position were purged from the binary
+// logs. It returns true if the vstream ended while replaying.
+func (vs *vstreamer) replayArchive() (bool, error) {
+	purged, err := vs.purgedPosition()
+	if err != nil {
+		log.Warningf("Cannot get the purged GTIDs, not using the binlog archive: %v", err)
+		return false, nil
+	}
+	if vs.pos.AtLeast(purged) {
+		return false, nil
+	}
+
+	bs, err := backupstorage.GetBackupStorage()
+	if err != nil {
+		return false, err
+	}
+	defer bs.Close()
+	stream, err := binlogarchive.NewStream(vs.ctx, bs, vs.vse.keyspace, vs.vse.shard, vs.pos)
+	if err != nil {
+		return false, err
+	}
+	log.Infof("Streaming from the binlog archive of %v/%v from %v, the binary logs were purged up to %v", vs.vse.keyspace, vs.vse.shard, vs.pos, purged)
+	err = vs.parseEvents(vs.ctx, stream.Events())
+	if err != errServerEOF {
+		return err == nil, err
+	}
+	if err := stream.Err(); err != nil {
+		return false, err
+	}
+	if !vs.pos.AtLeast(purged) {
+		return false, fmt.Errorf("the binlog archive of %v/%v ends at %v, before the purged GTIDs %v", vs.vse.keyspace, vs.vse.shard, vs.pos, purged)
+	}
+	// The binary logs start with their own FORMAT_DESCRIPTION_EVENT.
+	vs.format = mysql.BinlogFormat{}
+	return false, nil
+}
+
+// purgedPosition returns the GTIDs purged from the binary logs.
+func (vs *vstreamer) purgedPosition() (mysql.Position, error) {
+	conn, err := vs.cp.Connect(vs.ctx)
+	if err != nil {
+		return mysql.Position{}, err
+	}
+	defer conn.Close()
+	qr, err := conn.ExecuteFetch("select @@global.gtid_purged", 1, false)
+	if err != nil {
+		return mysql.Position{}, err
+	}
+	if len(qr.Rows) != 1 {
+		return mysql.Position{}, fmt.Errorf("unexpected result for gtid_purged: %v", qr.Rows)
+	}
+	gtids := strings.Join(strings.Fields(qr.Rows[0][0].ToString()), "")
+	return mysql.ParsePosition(mysql.Mysql56FlavorID, gtids)
+}
+
 // parseEvents parses and sends events.
 func (vs *vstreamer) parseEvents(ctx context.Context, events <-chan mysql.BinlogEvent) error {
 	// bufferAndTransmit uses bufferedEvents and curSize to buffer events.
@@ -314,7 +387,7 @@ func (vs *vstreamer) parseEvents(ctx context.Context, events <-chan mysql.Binlog
 					return nil
 				default:
 				}
-				return fmt.Errorf("unexpected server EOF")
+				return errServerEOF
 			}
 			vevents, err := vs.parseEvent(ev)
 			if err != nil {
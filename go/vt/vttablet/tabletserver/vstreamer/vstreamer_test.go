@@ -253,7 +253,7 @@ func TestVersion(t *testing.T) {
 	defer env.SchemaEngine.EnableHistorian(false)
 
 	engine = NewEngine(engine.env, env.SrvTopo, env.SchemaEngine, nil, env.Cells[0])
-	engine.InitDBConfig(env.KeyspaceName)
+	engine.InitDBConfig(env.KeyspaceName, env.ShardName)
 	engine.Open()
 	defer engine.Close()
 
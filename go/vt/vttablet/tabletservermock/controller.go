@@ -26,6 +26,7 @@ import (
 
 	"time"
 
+	"vitess.io/vitess/go/mysql"
 	"vitess.io/vitess/go/vt/dbconfigs"
 	"vitess.io/vitess/go/vt/mysqlctl"
 	"vitess.io/vitess/go/vt/servenv"
@@ -82,6 +83,9 @@ type Controller struct {
 	// TS is the return value for TopoServer.
 	TS *topo.Server
 
+	// VStreamerPos is the return value for VStreamerPositions.
+	VStreamerPos []mysql.Position
+
 	// mu protects the next fields in this structure. They are
 	// accessed by both the methods in this interface, and the
 	// background health check.
@@ -226,6 +230,11 @@ func (tqsc *Controller) TopoServer() *topo.Server {
 	return tqsc.TS
 }
 
+// VStreamerPositions is part of the tabletserver.Controller interface.
+func (tqsc *Controller) VStreamerPositions() []mysql.Position {
+	return tqsc.VStreamerPos
+}
+
 // EnterLameduck implements tabletserver.Controller.
 func (tqsc *Controller) EnterLameduck() {
 	tqsc.mu.Lock()
@@ -100,6 +100,13 @@ type TabletManagerClient interface {
 	// query faster. Close() should close the pool in that case.
 	ExecuteFetchAsDba(ctx context.Context, tablet *topodatapb.Tablet, usePool bool, query []byte, maxRows int, disableBinlogs, reloadSchema bool) (*querypb.QueryResult, error)
 
+	// ExecuteFetchAsDbaMulti executes queries remotely using the DBA pool,
+	// in order and in a single round trip, and returns their results. It
+	// stops at the first query which fails, and its error names the query.
+	// The schema is reloaded once, after the last query, if reloadSchema
+	// is set.
+	ExecuteFetchAsDbaMulti(ctx context.Context, tablet *topodatapb.Tablet, usePool bool, queries [][]byte, maxRows int, disableBinlogs, reloadSchema bool) ([]*querypb.QueryResult, error)
+
 	// ExecuteFetchAsAllPrivs executes a query remotely using the allprivs user.
 	ExecuteFetchAsAllPrivs(ctx context.Context, tablet *topodatapb.Tablet, query []byte, maxRows int, reloadSchema bool) (*querypb.QueryResult, error)
 
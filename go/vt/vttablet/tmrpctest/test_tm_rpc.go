@@ -647,6 +647,18 @@ func (fra *fakeRPCTM) ExecuteFetchAsDba(ctx context.Context, query []byte, dbNam
 	return testExecuteFetchResult, nil
 }
 
+func (fra *fakeRPCTM) ExecuteFetchAsDbaMulti(ctx context.Context, queries [][]byte, dbName string, maxrows int, disableBinlogs bool, reloadSchema bool) ([]*querypb.QueryResult, error) {
+	if fra.panics {
+		panic(fmt.Errorf("test-triggered panic"))
+	}
+	compare(fra.t, "ExecuteFetchAsDbaMulti queries", queries, [][]byte{testExecuteFetchQuery, testExecuteFetchQuery})
+	compare(fra.t, "ExecuteFetchAsDbaMulti maxrows", maxrows, testExecuteFetchMaxRows)
+	compareBool(fra.t, "ExecuteFetchAsDbaMulti disableBinlogs", disableBinlogs)
+	compareBool(fra.t, "ExecuteFetchAsDbaMulti reloadSchema", reloadSchema)
+
+	return []*querypb.QueryResult{testExecuteFetchResult, testExecuteFetchResult}, nil
+}
+
 func (fra *fakeRPCTM) ExecuteFetchAsAllPrivs(ctx context.Context, query []byte, dbName string, maxrows int, reloadSchema bool) (*querypb.QueryResult, error) {
 	if fra.panics {
 		panic(fmt.Errorf("test-triggered panic"))
@@ -681,6 +693,8 @@ func tmRPCTestExecuteFetch(ctx context.Context, t *testing.T, client tmclient.Ta
 	compareError(t, "ExecuteFetchAsApp", err, qr, testExecuteFetchResult)
 	qr, err = client.ExecuteFetchAsAllPrivs(ctx, tablet, testExecuteFetchQuery, testExecuteFetchMaxRows, true)
 	compareError(t, "ExecuteFetchAsAllPrivs", err, qr, testExecuteFetchResult)
+	qrs, err := client.ExecuteFetchAsDbaMulti(ctx, tablet, false, [][]byte{testExecuteFetchQuery, testExecuteFetchQuery}, testExecuteFetchMaxRows, true, true)
+	compareError(t, "ExecuteFetchAsDbaMulti", err, qrs, []*querypb.QueryResult{testExecuteFetchResult, testExecuteFetchResult})
 
 }
 
@@ -694,6 +708,8 @@ func tmRPCTestExecuteFetchPanic(ctx context.Context, t *testing.T, client tmclie
 	// not using pool
 	_, err = client.ExecuteFetchAsDba(ctx, tablet, false, testExecuteFetchQuery, testExecuteFetchMaxRows, true, false)
 	expectHandleRPCPanic(t, "ExecuteFetchAsDba", false /*verbose*/, err)
+	_, err = client.ExecuteFetchAsDbaMulti(ctx, tablet, false, [][]byte{testExecuteFetchQuery, testExecuteFetchQuery}, testExecuteFetchMaxRows, true, false)
+	expectHandleRPCPanic(t, "ExecuteFetchAsDbaMulti", false /*verbose*/, err)
 	_, err = client.ExecuteFetchAsApp(ctx, tablet, false, testExecuteFetchQuery, testExecuteFetchMaxRows)
 	expectHandleRPCPanic(t, "ExecuteFetchAsApp", false /*verbose*/, err)
 	_, err = client.ExecuteFetchAsAllPrivs(ctx, tablet, testExecuteFetchQuery, testExecuteFetchMaxRows, false)
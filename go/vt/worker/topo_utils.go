@@ -124,7 +124,9 @@ func FindWorkerTablet(ctx context.Context, wr *wrangler.Wrangler, cleaner *wrang
 	wr.Logger().Infof("Changing tablet %v to '%v'", topoproto.TabletAliasString(tabletAlias), topodatapb.TabletType_DRAINED)
 	shortCtx, cancel := context.WithTimeout(ctx, *remoteActionsTimeout)
 	defer cancel()
-	if err := wr.ChangeTabletType(shortCtx, tabletAlias, topodatapb.TabletType_DRAINED); err != nil {
+	// FindHealthyTablet checked that enough healthy tablets are left, and
+	// the cleaner restores the type of the tablet.
+	if err := wr.ChangeTabletType(shortCtx, tabletAlias, topodatapb.TabletType_DRAINED, true /* force */); err != nil {
 		return nil, err
 	}
 	// Record a clean-up action to take the tablet back to tabletAlias.
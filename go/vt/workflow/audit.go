@@ -0,0 +1,182 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/peer"
+
+	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/topo"
+
+	workflowpb "vitess.io/vitess/go/vt/proto/workflow"
+)
+
+// This file contains the audit trail of the workflow actions. Each action
+// is recorded with who requested it and from which address, in the
+// AuditSink selected by -workflow_audit_sink.
+
+var auditSinkName = flag.String("workflow_audit_sink", "topo", "where the workflow manager records the audit trail of the workflow actions: topo, log, or the name of a registered AuditSink. Empty disables the audit trail.")
+
+// The actions recorded in the audit trail.
+const (
+	AuditCreated     = "created"
+	AuditImported    = "imported"
+	AuditStarted     = "started"
+	AuditStopped     = "stopped"
+	AuditFinished    = "finished"
+	AuditDeleted     = "deleted"
+	AuditTaskRetried = "task retried"
+	AuditAction      = "action"
+)
+
+// AuditSink records the audit trail of the workflows.
+type AuditSink interface {
+	Record(ctx context.Context, uuid string, entry *workflowpb.WorkflowAuditEntry) error
+}
+
+// AuditSinkFactory creates an AuditSink.
+type AuditSinkFactory func(ts *topo.Server) AuditSink
+
+var auditSinkFactories = map[string]AuditSinkFactory{
+	"topo": func(ts *topo.Server) AuditSink { return &topoAuditSink{ts: ts} },
+	"log":  func(ts *topo.Server) AuditSink { return logAuditSink{} },
+}
+
+// RegisterAuditSink registers an AuditSink implementation. It should be
+// called from an init function.
+func RegisterAuditSink(name string, factory AuditSinkFactory) {
+	if _, ok := auditSinkFactories[name]; ok {
+		log.Fatalf("audit sink %v is already registered", name)
+	}
+	auditSinkFactories[name] = factory
+}
+
+// topoAuditSink records the audit trail in the topology, where
+// GetWorkflowAuditLog reads it.
+type topoAuditSink struct {
+	ts *topo.Server
+}
+
+// Record is part of the AuditSink interface.
+func (s *topoAuditSink) Record(ctx context.Context, uuid string, entry *workflowpb.WorkflowAuditEntry) error {
+	return s.ts.AppendWorkflowAuditEntry(ctx, uuid, entry)
+}
+
+// logAuditSink records the audit trail in the logs.
+type logAuditSink struct{}
+
+// Record is part of the AuditSink interface.
+func (logAuditSink) Record(ctx context.Context, uuid string, entry *workflowpb.WorkflowAuditEntry) error {
+	log.Infof("Workflow %v audit: %v %v by %v from %v", uuid, entry.Action, entry.Details, entry.Caller, entry.Address)
+	return nil
+}
+
+type auditCallerKey struct{}
+
+// auditCaller is the caller of a workflow action set by NewAuditContext.
+type auditCaller struct {
+	caller  string
+	address string
+}
+
+// NewAuditContext returns a context recording that the workflow actions
+// are requested by caller from address, e.g. for the HTTP requests of the
+// UI. By default, the caller is the effective or immediate caller ID of
+// the context, and the address its gRPC peer.
+func NewAuditContext(ctx context.Context, caller, address string) context.Context {
+	return context.WithValue(ctx, auditCallerKey{}, &auditCaller{caller: caller, address: address})
+}
+
+// callerFromContext returns who requested the action of the context, and
+// from which address.
+func callerFromContext(ctx context.Context) (string, string) {
+	if ac, ok := ctx.Value(auditCallerKey{}).(*auditCaller); ok {
+		return ac.caller, ac.address
+	}
+	var caller, address string
+	if ef := callerid.EffectiveCallerIDFromContext(ctx); ef != nil && ef.Principal != "" {
+		caller = ef.Principal
+	} else if im := callerid.ImmediateCallerIDFromContext(ctx); im != nil {
+		caller = im.Username
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		address = p.Addr.String()
+	}
+	return caller, address
+}
+
+// recordAudit records the action on the workflow in the audit sink. The
+// errors are only logged, the audit trail never fails an action.
+func recordAudit(ctx context.Context, ts *topo.Server, uuid, action, details string) {
+	if *auditSinkName == "" {
+		return
+	}
+	factory, ok := auditSinkFactories[*auditSinkName]
+	if !ok {
+		log.Errorf("Cannot record the audit trail of workflow %v: no audit sink named %v", uuid, *auditSinkName)
+		return
+	}
+	if ctx == nil {
+		// The manager context is nil once it is shutting down.
+		ctx = context.Background()
+	}
+	entry := &workflowpb.WorkflowAuditEntry{
+		Time:    time.Now().UnixNano(),
+		Action:  action,
+		Details: details,
+	}
+	entry.Caller, entry.Address = callerFromContext(ctx)
+	if err := factory(ts).Record(ctx, uuid, entry); err != nil {
+		log.Errorf("Cannot record %v in the audit trail of workflow %v: %v", action, uuid, err)
+	}
+}
+
+// Action invokes the action on the node of a workflow, and records it in
+// the audit trail of the workflow.
+func (m *Manager) Action(ctx context.Context, ap *ActionParameters) error {
+	err := m.nodeManager.Action(ctx, ap)
+	action := AuditAction
+	if ap.Name == actionNameRetry {
+		action = AuditTaskRetried
+	}
+	details := fmt.Sprintf("%v on %v", ap.Name, ap.Path)
+	if err != nil {
+		details += fmt.Sprintf(", failed: %v", err)
+	}
+	recordAudit(ctx, m.ts, workflowUUIDFromPath(ap.Path), action, details)
+	return err
+}
+
+// workflowUUIDFromPath returns the uuid of the workflow of a node path,
+// e.g. /XXXX-XXXX-XXX/child1.
+func workflowUUIDFromPath(nodePath string) string {
+	for len(nodePath) > 0 && nodePath[0] == '/' {
+		nodePath = nodePath[1:]
+	}
+	for i := 0; i < len(nodePath); i++ {
+		if nodePath[i] == '/' {
+			return nodePath[:i]
+		}
+	}
+	return nodePath
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"context"
+	"testing"
+
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+)
+
+// TestAuditLog checks the actions on a workflow are recorded in its audit
+// log, which is kept after the workflow is deleted.
+func TestAuditLog(t *testing.T) {
+	ts := memorytopo.NewServer("cell1")
+	m := NewManager(ts)
+	wg, _, cancel := StartManager(m)
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	ctx := NewAuditContext(context.Background(), "alice", "10.0.0.1:1234")
+	uuid, err := m.Create(ctx, sleepFactoryName, []string{"-duration", "60"})
+	if err != nil {
+		t.Fatalf("cannot create sleep workflow: %v", err)
+	}
+	if err := m.Start(ctx, uuid); err != nil {
+		t.Fatalf("cannot start sleep workflow: %v", err)
+	}
+	if err := m.Action(ctx, &ActionParameters{Path: "/" + uuid + "/unknown", Name: actionNameRetry}); err == nil {
+		t.Fatalf("action on an unknown node should have failed")
+	}
+	if err := m.Stop(ctx, uuid); err != nil {
+		t.Fatalf("cannot stop sleep workflow: %v", err)
+	}
+	if err := m.Delete(ctx, uuid); err != nil {
+		t.Fatalf("cannot delete sleep workflow: %v", err)
+	}
+
+	auditLog, err := ts.GetWorkflowAuditLog(context.Background(), uuid)
+	if err != nil {
+		t.Fatalf("GetWorkflowAuditLog failed: %v", err)
+	}
+	want := []string{AuditCreated, AuditStarted, AuditTaskRetried, AuditStopped, AuditFinished, AuditDeleted}
+	if len(auditLog.Entries) != len(want) {
+		t.Fatalf("got %v audit entries, want %v", len(auditLog.Entries), len(want))
+	}
+	for i, entry := range auditLog.Entries {
+		if entry.Action != want[i] {
+			t.Errorf("entry %v: got action %v, want %v", i, entry.Action, want[i])
+		}
+		// The workflow finishes on its own context.
+		if entry.Action == AuditFinished {
+			continue
+		}
+		if entry.Caller != "alice" || entry.Address != "10.0.0.1:1234" {
+			t.Errorf("entry %v: got caller %v from %v, want alice from 10.0.0.1:1234", i, entry.Caller, entry.Address)
+		}
+	}
+}
+
+func TestWorkflowUUIDFromPath(t *testing.T) {
+	for path, want := range map[string]string{
+		"/abc/child1/child2": "abc",
+		"/abc":               "abc",
+		"abc/child1":         "abc",
+	} {
+		if got := workflowUUIDFromPath(path); got != want {
+			t.Errorf("workflowUUIDFromPath(%v) = %v, want %v", path, got, want)
+		}
+	}
+}
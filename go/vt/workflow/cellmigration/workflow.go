@@ -0,0 +1,538 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cellmigration contains a workflow which migrates the tablets of
+// a keyspace from a cell to another one, once the tablets of the target
+// cell are provisioned. It verifies that the target cell has as many
+// replicas as the source cell, shifts the reads away from the source cell
+// in steps of traffic weight, reparents the masters of the source cell to
+// the target cell, and removes the source cell from the shards.
+//
+// The traffic weights only balance the tablets of the same cell, as the
+// vtgates prefer their local tablets. The reads of the vtgates of the
+// source cell only move to the target cell when the source tablets are
+// drained at the last step, so these vtgates must be able to route to the
+// target cell, e.g. through a cell alias.
+package cellmigration
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vttablet/tmclient"
+	"vitess.io/vitess/go/vt/workflow"
+	"vitess.io/vitess/go/vt/wrangler"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	workflowpb "vitess.io/vitess/go/vt/proto/workflow"
+)
+
+const (
+	codeVersion                                 = 1
+	cellMigrationFactoryName                    = "cell_migration"
+	phaseVerify              workflow.PhaseType = "verify"
+	phaseShiftReads          workflow.PhaseType = "shift_reads"
+	phaseReparent            workflow.PhaseType = "reparent"
+	phaseCleanup             workflow.PhaseType = "cleanup"
+)
+
+// Register registers the Factory of the cell migration workflow in the
+// workflow framework.
+func Register() {
+	workflow.Register(cellMigrationFactoryName, &Factory{})
+}
+
+// WorkflowPhases returns the phases of the cell migration workflow, in
+// execution order.
+func WorkflowPhases() []string {
+	return []string{
+		string(phaseVerify),
+		string(phaseShiftReads),
+		string(phaseReparent),
+		string(phaseCleanup),
+	}
+}
+
+// Factory is the factory to create a cell migration workflow.
+type Factory struct{}
+
+// Init is part of the workflow.Factory interface.
+func (*Factory) Init(m *workflow.Manager, w *workflowpb.Workflow, args []string) error {
+	subFlags := flag.NewFlagSet(cellMigrationFactoryName, flag.ContinueOnError)
+	keyspace := subFlags.String("keyspace", "", "The keyspace whose tablets are migrated")
+	sourceCell := subFlags.String("source_cell", "", "The cell the tablets are migrated from")
+	targetCell := subFlags.String("target_cell", "", "The cell the tablets are migrated to. Its tablets must be provisioned before the migration")
+	readWeightSteps := subFlags.String("read_weight_steps", "75,50,25", "Comma-separated decreasing traffic weights the replicas of the source cell are set to in turn, before they are drained")
+	waitReplicasTimeout := subFlags.Duration("wait_replicas_timeout", 30*time.Second, "The time to wait for the replicas to catch up during the planned reparent of a master")
+	deleteSourceTablets := subFlags.Bool("delete_source_tablets", false, "Delete the tablets of the source cell from the topology during the cleanup, assuming their processes have been terminated. Otherwise the cleanup fails until they are deleted")
+	phaseEnableApprovalsStr := subFlags.String("phase_enable_approvals", strings.Join(WorkflowPhases()[1:], ","), fmt.Sprintf("Comma-separated phases that require explicit approval in the UI to execute. Phase names are: %v", strings.Join(WorkflowPhases(), ",")))
+
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if *keyspace == "" || *sourceCell == "" || *targetCell == "" {
+		return fmt.Errorf("the keyspace, source_cell and target_cell flags must be provided for the cell migration")
+	}
+	if *sourceCell == *targetCell {
+		return fmt.Errorf("the source and target cells must be different: %v", *sourceCell)
+	}
+	weights, err := parseReadWeightSteps(*readWeightSteps)
+	if err != nil {
+		return err
+	}
+	for _, phase := range splitList(*phaseEnableApprovalsStr) {
+		if !isWorkflowPhase(phase) {
+			return fmt.Errorf("invalid phase in phase_enable_approvals: %v", phase)
+		}
+	}
+
+	ctx := context.Background()
+	for _, cell := range []string{*sourceCell, *targetCell} {
+		if _, err := m.TopoServer().GetCellInfo(ctx, cell, false /* strongRead */); err != nil {
+			return fmt.Errorf("cannot get cell %v: %v", cell, err)
+		}
+	}
+	shards, err := m.TopoServer().GetShardNames(ctx, *keyspace)
+	if err != nil {
+		return fmt.Errorf("cannot get the shards of keyspace %v: %v", *keyspace, err)
+	}
+	if len(shards) == 0 {
+		return fmt.Errorf("keyspace %v has no shards", *keyspace)
+	}
+	sort.Strings(shards)
+
+	tasks := make(map[string]*workflowpb.Task)
+	settings := map[string]string{
+		"keyspace":               *keyspace,
+		"source_cell":            *sourceCell,
+		"target_cell":            *targetCell,
+		"wait_replicas_timeout":  waitReplicasTimeout.String(),
+		"delete_source_tablets":  strconv.FormatBool(*deleteSourceTablets),
+		"phase_enable_approvals": *phaseEnableApprovalsStr,
+	}
+	addTask := func(phase workflow.PhaseType, name string, attributes map[string]string) {
+		taskID := taskID(phase, name)
+		tasks[taskID] = &workflowpb.Task{
+			Id:         taskID,
+			State:      workflowpb.TaskState_TaskNotStarted,
+			Attributes: attributes,
+		}
+		if settings[string(phase)] != "" {
+			settings[string(phase)] += ","
+		}
+		settings[string(phase)] += taskID
+	}
+	for _, phase := range []workflow.PhaseType{phaseVerify, phaseReparent, phaseCleanup} {
+		for _, shard := range shards {
+			addTask(phase, shard, map[string]string{"keyspace": *keyspace, "shard": shard})
+		}
+	}
+	for _, weight := range weights {
+		addTask(phaseShiftReads, strconv.Itoa(weight), map[string]string{"keyspace": *keyspace, "weight": strconv.Itoa(weight)})
+	}
+
+	w.Name = fmt.Sprintf("Migrate the tablets of keyspace %v from cell %v to cell %v.", *keyspace, *sourceCell, *targetCell)
+	checkpoint := &workflowpb.WorkflowCheckpoint{
+		CodeVersion: codeVersion,
+		Tasks:       tasks,
+		Settings:    settings,
+	}
+	w.Data, err = proto.Marshal(checkpoint)
+	return err
+}
+
+// parseReadWeightSteps parses the traffic weight steps of the reads, and
+// appends the final step at 0, which also drains the replicas.
+func parseReadWeightSteps(value string) ([]int, error) {
+	var weights []int
+	for _, step := range splitList(value) {
+		weight, err := strconv.Atoi(step)
+		if err != nil || weight < 0 || weight >= topoproto.MaxTabletTrafficWeight {
+			return nil, fmt.Errorf("invalid read_weight_steps %q: the weights must be between 0 and %v", value, topoproto.MaxTabletTrafficWeight-1)
+		}
+		if len(weights) > 0 && weight >= weights[len(weights)-1] {
+			return nil, fmt.Errorf("invalid read_weight_steps %q: the weights must be decreasing", value)
+		}
+		weights = append(weights, weight)
+	}
+	if len(weights) == 0 || weights[len(weights)-1] != 0 {
+		weights = append(weights, 0)
+	}
+	return weights, nil
+}
+
+// splitList splits a comma-separated list, ignoring the empty items.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func isWorkflowPhase(phase string) bool {
+	for _, p := range WorkflowPhases() {
+		if p == phase {
+			return true
+		}
+	}
+	return false
+}
+
+// taskID returns the ID of a task of a phase, which is also the path of
+// its UI node.
+func taskID(phase workflow.PhaseType, name string) string {
+	return fmt.Sprintf("%s/%s", phase, name)
+}
+
+// Instantiate is part the workflow.Factory interface.
+func (*Factory) Instantiate(m *workflow.Manager, w *workflowpb.Workflow, rootNode *workflow.Node) (workflow.Workflow, error) {
+	rootNode.Message = "This is a workflow to migrate the tablets of a keyspace from a cell to another one."
+
+	checkpoint := &workflowpb.WorkflowCheckpoint{}
+	if err := proto.Unmarshal(w.Data, checkpoint); err != nil {
+		return nil, err
+	}
+	waitReplicasTimeout, err := time.ParseDuration(checkpoint.Settings["wait_replicas_timeout"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid wait_replicas_timeout setting: %v", err)
+	}
+
+	cw := &cellMigrationWorkflow{
+		checkpoint:           checkpoint,
+		rootUINode:           rootNode,
+		logger:               logutil.NewMemoryLogger(),
+		wr:                   wrangler.New(logutil.NewConsoleLogger(), m.TopoServer(), tmclient.NewTabletManagerClient()),
+		topoServer:           m.TopoServer(),
+		keyspace:             checkpoint.Settings["keyspace"],
+		sourceCell:           checkpoint.Settings["source_cell"],
+		targetCell:           checkpoint.Settings["target_cell"],
+		waitReplicasTimeout:  waitReplicasTimeout,
+		deleteSourceTablets:  checkpoint.Settings["delete_source_tablets"] == "true",
+		phaseEnableApprovals: make(map[string]bool),
+		taskUINodes:          make(map[string]*workflow.Node),
+	}
+	for _, phase := range splitList(checkpoint.Settings["phase_enable_approvals"]) {
+		cw.phaseEnableApprovals[phase] = true
+	}
+
+	phaseNames := map[workflow.PhaseType]string{
+		phaseVerify:     fmt.Sprintf("Verify the tablets of cell %v", cw.targetCell),
+		phaseShiftReads: fmt.Sprintf("Shift the reads away from cell %v", cw.sourceCell),
+		phaseReparent:   fmt.Sprintf("Reparent the masters to cell %v", cw.targetCell),
+		phaseCleanup:    fmt.Sprintf("Remove cell %v from the shards", cw.sourceCell),
+	}
+	for _, phase := range WorkflowPhases() {
+		phaseUINode := &workflow.Node{
+			Name:     phaseNames[workflow.PhaseType(phase)],
+			PathName: phase,
+		}
+		for _, task := range cw.getTasks(workflow.PhaseType(phase)) {
+			taskUINode := &workflow.Node{PathName: strings.TrimPrefix(task.Id, phase+"/")}
+			if weight, ok := task.Attributes["weight"]; ok {
+				taskUINode.Name = fmt.Sprintf("Traffic weight %v", weight)
+			} else {
+				taskUINode.Name = fmt.Sprintf("Shard %v/%v", task.Attributes["keyspace"], task.Attributes["shard"])
+			}
+			phaseUINode.Children = append(phaseUINode.Children, taskUINode)
+			cw.taskUINodes[task.Id] = taskUINode
+		}
+		cw.rootUINode.Children = append(cw.rootUINode.Children, phaseUINode)
+	}
+	return cw, nil
+}
+
+// cellMigrationWorkflow contains meta-information and methods to control
+// the cell migration workflow.
+type cellMigrationWorkflow struct {
+	ctx        context.Context
+	wr         Wrangler
+	topoServer *topo.Server
+	wi         *topo.WorkflowInfo
+	// logger is the logger we export UI logs from.
+	logger *logutil.MemoryLogger
+
+	// rootUINode is the root node representing the workflow in the UI.
+	rootUINode *workflow.Node
+	// taskUINodes has the UI node of each task.
+	taskUINodes map[string]*workflow.Node
+
+	checkpoint       *workflowpb.WorkflowCheckpoint
+	checkpointWriter *workflow.CheckpointWriter
+
+	keyspace             string
+	sourceCell           string
+	targetCell           string
+	waitReplicasTimeout  time.Duration
+	deleteSourceTablets  bool
+	phaseEnableApprovals map[string]bool
+}
+
+// Run executes the cell migration, phase by phase.
+// It implements the workflow.Workflow interface.
+func (cw *cellMigrationWorkflow) Run(ctx context.Context, manager *workflow.Manager, wi *topo.WorkflowInfo) error {
+	cw.ctx = ctx
+	cw.wi = wi
+	cw.checkpointWriter = workflow.NewCheckpointWriter(cw.topoServer, cw.checkpoint, cw.wi)
+	cw.rootUINode.Display = workflow.NodeDisplayDeterminate
+	cw.rootUINode.BroadcastChanges(true /* updateChildren */)
+
+	for _, phase := range []struct {
+		phase       workflow.PhaseType
+		executeFunc func(context.Context, *workflowpb.Task) error
+	}{
+		{phase: phaseVerify, executeFunc: cw.runVerify},
+		{phase: phaseShiftReads, executeFunc: cw.runShiftReads},
+		{phase: phaseReparent, executeFunc: cw.runReparent},
+		{phase: phaseCleanup, executeFunc: cw.runCleanup},
+	} {
+		// The verification of the shards is read-only, the other phases
+		// change the serving graph one step at a time.
+		concurrencyLevel := workflow.Sequential
+		if phase.phase == phaseVerify {
+			concurrencyLevel = workflow.Parallel
+		}
+		runner := workflow.NewParallelRunner(cw.ctx, cw.rootUINode, cw.checkpointWriter, cw.getTasks(phase.phase), phase.executeFunc, concurrencyLevel, cw.phaseEnableApprovals[string(phase.phase)])
+		if err := runner.Run(); err != nil {
+			return err
+		}
+	}
+	cw.setUIMessage(fmt.Sprintf("Migration of keyspace %v from cell %v to cell %v is finished successfully.", cw.keyspace, cw.sourceCell, cw.targetCell))
+	return nil
+}
+
+// getTasks returns the tasks of a phase, in execution order.
+func (cw *cellMigrationWorkflow) getTasks(phase workflow.PhaseType) []*workflowpb.Task {
+	var tasks []*workflowpb.Task
+	for _, taskID := range strings.Split(cw.checkpoint.Settings[string(phase)], ",") {
+		tasks = append(tasks, cw.checkpoint.Tasks[taskID])
+	}
+	return tasks
+}
+
+// servingReplicas returns the REPLICA and RDONLY tablets of the shard in
+// the cell which aren't drained, sorted by alias.
+func (cw *cellMigrationWorkflow) servingReplicas(ctx context.Context, keyspace, shard, cell string) ([]*topodatapb.Tablet, error) {
+	tabletMap, err := cw.topoServer.GetTabletMapForShardByCell(ctx, keyspace, shard, []string{cell})
+	if err != nil {
+		return nil, fmt.Errorf("GetTabletMapForShardByCell(%v, %v, %v) failed: %v", keyspace, shard, cell, err)
+	}
+	var tablets []*topodatapb.Tablet
+	for _, ti := range tabletMap {
+		if ti.Type != topodatapb.TabletType_REPLICA && ti.Type != topodatapb.TabletType_RDONLY {
+			continue
+		}
+		if topoproto.IsTabletDrained(ti.Tablet) {
+			continue
+		}
+		tablets = append(tablets, ti.Tablet)
+	}
+	sort.Slice(tablets, func(i, j int) bool {
+		return topoproto.TabletAliasString(tablets[i].Alias) < topoproto.TabletAliasString(tablets[j].Alias)
+	})
+	return tablets, nil
+}
+
+func countByType(tablets []*topodatapb.Tablet) map[topodatapb.TabletType]int {
+	counts := make(map[topodatapb.TabletType]int)
+	for _, tablet := range tablets {
+		counts[tablet.Type]++
+	}
+	return counts
+}
+
+// runVerify checks that the target cell has at least as many serving
+// replicas of each type as the source cell in the shard of the task, and
+// a replica to reparent to if the master is in the source cell.
+func (cw *cellMigrationWorkflow) runVerify(ctx context.Context, t *workflowpb.Task) error {
+	keyspace := t.Attributes["keyspace"]
+	shard := t.Attributes["shard"]
+	si, err := cw.topoServer.GetShard(ctx, keyspace, shard)
+	if err != nil {
+		return err
+	}
+	sourceReplicas, err := cw.servingReplicas(ctx, keyspace, shard, cw.sourceCell)
+	if err != nil {
+		return err
+	}
+	targetReplicas, err := cw.servingReplicas(ctx, keyspace, shard, cw.targetCell)
+	if err != nil {
+		return err
+	}
+	sourceCounts := countByType(sourceReplicas)
+	targetCounts := countByType(targetReplicas)
+	var missing []string
+	for _, tabletType := range []topodatapb.TabletType{topodatapb.TabletType_REPLICA, topodatapb.TabletType_RDONLY} {
+		if targetCounts[tabletType] < sourceCounts[tabletType] {
+			missing = append(missing, fmt.Sprintf("%v %v tablets (%v in cell %v)", targetCounts[tabletType], tabletType, sourceCounts[tabletType], cw.sourceCell))
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("cell %v of shard %v/%v only has %v", cw.targetCell, keyspace, shard, strings.Join(missing, ", "))
+	}
+	if si.MasterAlias != nil && si.MasterAlias.Cell == cw.sourceCell && targetCounts[topodatapb.TabletType_REPLICA] == 0 {
+		return fmt.Errorf("cell %v of shard %v/%v has no REPLICA tablet to reparent master %v to", cw.targetCell, keyspace, shard, topoproto.TabletAliasString(si.MasterAlias))
+	}
+	cw.setTaskUIMessage(t, fmt.Sprintf("Cell %v of shard %v/%v has %v serving replicas, cell %v has %v.", cw.targetCell, keyspace, shard, len(targetReplicas), cw.sourceCell, len(sourceReplicas)))
+	return nil
+}
+
+// runShiftReads sets the traffic weight of the task on the serving
+// replicas of the source cell, in all the shards. The replicas are also
+// drained at weight 0, s.t. the vtgates of the source cell read from the
+// target cell.
+func (cw *cellMigrationWorkflow) runShiftReads(ctx context.Context, t *workflowpb.Task) error {
+	keyspace := t.Attributes["keyspace"]
+	weight, err := strconv.Atoi(t.Attributes["weight"])
+	if err != nil {
+		return fmt.Errorf("invalid weight in task %v: %v", t.Id, err)
+	}
+	shards, err := cw.topoServer.GetShardNames(ctx, keyspace)
+	if err != nil {
+		return fmt.Errorf("cannot get the shards of keyspace %v: %v", keyspace, err)
+	}
+	sort.Strings(shards)
+	for _, shard := range shards {
+		replicas, err := cw.servingReplicas(ctx, keyspace, shard, cw.sourceCell)
+		if err != nil {
+			return err
+		}
+		for _, tablet := range replicas {
+			if err := cw.shiftTablet(ctx, t, tablet.Alias, weight); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// shiftTablet sets the traffic weight of a replica of the source cell, and
+// drains it at weight 0.
+func (cw *cellMigrationWorkflow) shiftTablet(ctx context.Context, t *workflowpb.Task, tabletAlias *topodatapb.TabletAlias, weight int) error {
+	alias := topoproto.TabletAliasString(tabletAlias)
+	cw.setTaskUIMessage(t, fmt.Sprintf("Setting the traffic weight of tablet %v to %v.", alias, weight))
+	if err := cw.wr.SetTabletTrafficWeight(ctx, tabletAlias, weight); err != nil {
+		return fmt.Errorf("cannot set the traffic weight of tablet %v: %v", alias, err)
+	}
+	if weight > 0 {
+		return nil
+	}
+	cw.setTaskUIMessage(t, fmt.Sprintf("Draining tablet %v.", alias))
+	if err := cw.wr.SetTabletDrained(ctx, tabletAlias, true); err != nil {
+		return fmt.Errorf("cannot drain tablet %v: %v", alias, err)
+	}
+	return nil
+}
+
+// runReparent reparents the shard of the task to a replica of the target
+// cell if its master is in the source cell. The old master is drained
+// with the other replicas of the source cell.
+func (cw *cellMigrationWorkflow) runReparent(ctx context.Context, t *workflowpb.Task) error {
+	keyspace := t.Attributes["keyspace"]
+	shard := t.Attributes["shard"]
+	si, err := cw.topoServer.GetShard(ctx, keyspace, shard)
+	if err != nil {
+		return err
+	}
+	if si.MasterAlias != nil && si.MasterAlias.Cell == cw.sourceCell {
+		targetReplicas, err := cw.servingReplicas(ctx, keyspace, shard, cw.targetCell)
+		if err != nil {
+			return err
+		}
+		var masterElect *topodatapb.TabletAlias
+		for _, tablet := range targetReplicas {
+			if tablet.Type == topodatapb.TabletType_REPLICA {
+				masterElect = tablet.Alias
+				break
+			}
+		}
+		if masterElect == nil {
+			return fmt.Errorf("cell %v of shard %v/%v has no serving REPLICA tablet to reparent to", cw.targetCell, keyspace, shard)
+		}
+		cw.setTaskUIMessage(t, fmt.Sprintf("Reparenting %v/%v from %v to %v.", keyspace, shard, topoproto.TabletAliasString(si.MasterAlias), topoproto.TabletAliasString(masterElect)))
+		if err := cw.wr.PlannedReparentShard(ctx, keyspace, shard, masterElect, nil /* avoidMasterAlias */, cw.waitReplicasTimeout); err != nil {
+			return fmt.Errorf("cannot reparent %v/%v to %v: %v", keyspace, shard, topoproto.TabletAliasString(masterElect), err)
+		}
+	} else {
+		cw.setTaskUIMessage(t, fmt.Sprintf("The master of %v/%v isn't in cell %v.", keyspace, shard, cw.sourceCell))
+	}
+
+	// The old master is a replica of the source cell now. It's also
+	// drained when a previous attempt reparented the shard but failed
+	// before.
+	replicas, err := cw.servingReplicas(ctx, keyspace, shard, cw.sourceCell)
+	if err != nil {
+		return err
+	}
+	for _, tablet := range replicas {
+		if err := cw.shiftTablet(ctx, t, tablet.Alias, 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runCleanup removes the source cell from the shard of the task, and its
+// tablets from the topology if asked to.
+func (cw *cellMigrationWorkflow) runCleanup(ctx context.Context, t *workflowpb.Task) error {
+	keyspace := t.Attributes["keyspace"]
+	shard := t.Attributes["shard"]
+	si, err := cw.topoServer.GetShard(ctx, keyspace, shard)
+	if err != nil {
+		return err
+	}
+	cells, err := cw.topoServer.GetShardServingCells(ctx, si)
+	if err != nil {
+		return err
+	}
+	if !topo.InCellList(cw.sourceCell, cells) {
+		cw.setTaskUIMessage(t, fmt.Sprintf("Cell %v was already removed from %v/%v.", cw.sourceCell, keyspace, shard))
+		return nil
+	}
+	cw.setTaskUIMessage(t, fmt.Sprintf("Removing cell %v from %v/%v.", cw.sourceCell, keyspace, shard))
+	if err := cw.wr.RemoveShardCell(ctx, keyspace, shard, cw.sourceCell, false /* force */, cw.deleteSourceTablets); err != nil {
+		return fmt.Errorf("cannot remove cell %v from %v/%v: %v", cw.sourceCell, keyspace, shard, err)
+	}
+	return nil
+}
+
+func (cw *cellMigrationWorkflow) setTaskUIMessage(t *workflowpb.Task, message string) {
+	log.Infof("Cell migration: %v", message)
+	node := cw.taskUINodes[t.Id]
+	node.Message = message
+	node.BroadcastChanges(false /* updateChildren */)
+}
+
+func (cw *cellMigrationWorkflow) setUIMessage(message string) {
+	log.Infof("Cell migration: %v", message)
+	cw.logger.Infof(message)
+	cw.rootUINode.Log = cw.logger.String()
+	cw.rootUINode.Message = message
+	cw.rootUINode.BroadcastChanges(false /* updateChildren */)
+}
@@ -0,0 +1,282 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cellmigration
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/workflow"
+
+	// import the gRPC client implementation for tablet manager
+	_ "vitess.io/vitess/go/vt/vttablet/grpctmclient"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	workflowpb "vitess.io/vitess/go/vt/proto/workflow"
+)
+
+func init() {
+	Register()
+}
+
+// fakeWrangler records the calls of the workflow, and applies the drains,
+// reparents and cell removals to the topo server.
+type fakeWrangler struct {
+	ts *topo.Server
+
+	mu    sync.Mutex
+	calls []string
+}
+
+func (f *fakeWrangler) record(format string, args ...interface{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, fmt.Sprintf(format, args...))
+}
+
+func (f *fakeWrangler) SetTabletTrafficWeight(ctx context.Context, tabletAlias *topodatapb.TabletAlias, weight int) error {
+	f.record("weight %v: %v", topoproto.TabletAliasString(tabletAlias), weight)
+	return nil
+}
+
+func (f *fakeWrangler) SetTabletDrained(ctx context.Context, tabletAlias *topodatapb.TabletAlias, drained bool) error {
+	f.record("drained %v: %v", topoproto.TabletAliasString(tabletAlias), drained)
+	_, err := f.ts.UpdateTabletFields(ctx, tabletAlias, func(tablet *topodatapb.Tablet) error {
+		topoproto.SetTabletDrained(tablet, drained)
+		return nil
+	})
+	return err
+}
+
+func (f *fakeWrangler) PlannedReparentShard(ctx context.Context, keyspace, shard string, masterElectTabletAlias, avoidMasterAlias *topodatapb.TabletAlias, waitReplicasTimeout time.Duration) error {
+	f.record("reparent %v/%v to %v", keyspace, shard, topoproto.TabletAliasString(masterElectTabletAlias))
+	si, err := f.ts.GetShard(ctx, keyspace, shard)
+	if err != nil {
+		return err
+	}
+	for alias, tabletType := range map[*topodatapb.TabletAlias]topodatapb.TabletType{
+		masterElectTabletAlias: topodatapb.TabletType_MASTER,
+		si.MasterAlias:         topodatapb.TabletType_REPLICA,
+	} {
+		if _, err := f.ts.UpdateTabletFields(ctx, alias, func(tablet *topodatapb.Tablet) error {
+			tablet.Type = tabletType
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+	_, err = f.ts.UpdateShardFields(ctx, keyspace, shard, func(si *topo.ShardInfo) error {
+		si.MasterAlias = masterElectTabletAlias
+		return nil
+	})
+	return err
+}
+
+func (f *fakeWrangler) RemoveShardCell(ctx context.Context, keyspace, shard, cell string, force, recursive bool) error {
+	f.record("remove cell %v from %v/%v, recursive: %v", cell, keyspace, shard, recursive)
+	srvKeyspace, err := f.ts.GetSrvKeyspace(ctx, cell, keyspace)
+	if err != nil {
+		return err
+	}
+	for _, partition := range srvKeyspace.Partitions {
+		var shardReferences []*topodatapb.ShardReference
+		for _, shardReference := range partition.ShardReferences {
+			if shardReference.Name != shard {
+				shardReferences = append(shardReferences, shardReference)
+			}
+		}
+		partition.ShardReferences = shardReferences
+	}
+	return f.ts.UpdateSrvKeyspace(ctx, cell, keyspace, srvKeyspace)
+}
+
+func addTablet(ctx context.Context, t *testing.T, ts *topo.Server, cell string, uid uint32, shard string, tabletType topodatapb.TabletType) {
+	alias := &topodatapb.TabletAlias{Cell: cell, Uid: uid}
+	require.NoError(t, ts.CreateTablet(ctx, &topodatapb.Tablet{
+		Alias:    alias,
+		Keyspace: "ks",
+		Shard:    shard,
+		Type:     tabletType,
+	}))
+	if tabletType == topodatapb.TabletType_MASTER {
+		_, err := ts.UpdateShardFields(ctx, "ks", shard, func(si *topo.ShardInfo) error {
+			si.MasterAlias = alias
+			return nil
+		})
+		require.NoError(t, err)
+	}
+}
+
+// setupTopology creates a keyspace served in cells cell1 and cell2. The
+// master of -80 is in cell1, the master of 80- in cell2.
+func setupTopology(ctx context.Context, t *testing.T) *topo.Server {
+	ts := memorytopo.NewServer("cell1", "cell2")
+	require.NoError(t, ts.CreateKeyspace(ctx, "ks", &topodatapb.Keyspace{}))
+	require.NoError(t, ts.CreateShard(ctx, "ks", "-80"))
+	require.NoError(t, ts.CreateShard(ctx, "ks", "80-"))
+	addTablet(ctx, t, ts, "cell1", 100, "-80", topodatapb.TabletType_MASTER)
+	addTablet(ctx, t, ts, "cell1", 101, "-80", topodatapb.TabletType_REPLICA)
+	addTablet(ctx, t, ts, "cell1", 102, "-80", topodatapb.TabletType_RDONLY)
+	addTablet(ctx, t, ts, "cell2", 200, "-80", topodatapb.TabletType_REPLICA)
+	addTablet(ctx, t, ts, "cell2", 201, "-80", topodatapb.TabletType_REPLICA)
+	addTablet(ctx, t, ts, "cell2", 202, "-80", topodatapb.TabletType_RDONLY)
+	addTablet(ctx, t, ts, "cell2", 300, "80-", topodatapb.TabletType_MASTER)
+	addTablet(ctx, t, ts, "cell1", 301, "80-", topodatapb.TabletType_REPLICA)
+	addTablet(ctx, t, ts, "cell2", 400, "80-", topodatapb.TabletType_REPLICA)
+	for _, cell := range []string{"cell1", "cell2"} {
+		require.NoError(t, ts.UpdateSrvKeyspace(ctx, cell, "ks", &topodatapb.SrvKeyspace{
+			Partitions: []*topodatapb.SrvKeyspace_KeyspacePartition{{
+				ServedType:      topodatapb.TabletType_REPLICA,
+				ShardReferences: []*topodatapb.ShardReference{{Name: "-80"}, {Name: "80-"}},
+			}},
+		}))
+	}
+	return ts
+}
+
+func TestCellMigrationInit(t *testing.T) {
+	ctx := context.Background()
+	ts := setupTopology(ctx, t)
+	m := workflow.NewManager(ts)
+
+	for _, args := range [][]string{
+		{},
+		{"-keyspace=ks", "-source_cell=cell1"},
+		{"-keyspace=ks", "-source_cell=cell1", "-target_cell=cell1"},
+		{"-keyspace=ks", "-source_cell=cell1", "-target_cell=cell3"},
+		{"-keyspace=ks2", "-source_cell=cell1", "-target_cell=cell2"},
+		{"-keyspace=ks", "-source_cell=cell1", "-target_cell=cell2", "-read_weight_steps=50,75"},
+		{"-keyspace=ks", "-source_cell=cell1", "-target_cell=cell2", "-read_weight_steps=100"},
+		{"-keyspace=ks", "-source_cell=cell1", "-target_cell=cell2", "-phase_enable_approvals=drain"},
+	} {
+		_, err := m.Create(ctx, cellMigrationFactoryName, args)
+		assert.Error(t, err, "%v", args)
+	}
+}
+
+func TestParseReadWeightSteps(t *testing.T) {
+	weights, err := parseReadWeightSteps("75, 50,25")
+	require.NoError(t, err)
+	assert.Equal(t, []int{75, 50, 25, 0}, weights)
+
+	weights, err = parseReadWeightSteps("50,0")
+	require.NoError(t, err)
+	assert.Equal(t, []int{50, 0}, weights)
+
+	weights, err = parseReadWeightSteps("")
+	require.NoError(t, err)
+	assert.Equal(t, []int{0}, weights)
+
+	for _, value := range []string{"50,50", "-1", "a"} {
+		_, err := parseReadWeightSteps(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestCellMigration(t *testing.T) {
+	ctx := context.Background()
+	ts := setupTopology(ctx, t)
+	wr := &fakeWrangler{ts: ts}
+
+	m := workflow.NewManager(ts)
+	wg, _, cancel := workflow.StartManager(m)
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+	uuid, err := m.Create(ctx, cellMigrationFactoryName, []string{
+		"-keyspace=ks",
+		"-source_cell=cell1",
+		"-target_cell=cell2",
+		"-read_weight_steps=50",
+		"-phase_enable_approvals=",
+	})
+	require.NoError(t, err)
+	w, err := m.WorkflowForTesting(uuid)
+	require.NoError(t, err)
+	w.(*cellMigrationWorkflow).wr = wr
+	require.NoError(t, m.Start(ctx, uuid))
+	require.NoError(t, m.Wait(ctx, uuid))
+
+	wi, err := ts.GetWorkflow(ctx, uuid)
+	require.NoError(t, err)
+	assert.Empty(t, wi.Error)
+	require.NoError(t, workflow.VerifyAllTasksDone(ctx, ts, uuid))
+	// The replicas of cell1 are weighed down then drained, the master of
+	// -80 is moved to cell2 and drained, and cell1 is removed.
+	assert.Equal(t, []string{
+		"weight cell1-0000000101: 50",
+		"weight cell1-0000000102: 50",
+		"weight cell1-0000000301: 50",
+		"weight cell1-0000000101: 0",
+		"drained cell1-0000000101: true",
+		"weight cell1-0000000102: 0",
+		"drained cell1-0000000102: true",
+		"weight cell1-0000000301: 0",
+		"drained cell1-0000000301: true",
+		"reparent ks/-80 to cell2-0000000200",
+		"weight cell1-0000000100: 0",
+		"drained cell1-0000000100: true",
+		"remove cell cell1 from ks/-80, recursive: false",
+		"remove cell cell1 from ks/80-, recursive: false",
+	}, wr.calls)
+
+	si, err := ts.GetShard(ctx, "ks", "-80")
+	require.NoError(t, err)
+	assert.Equal(t, "cell2-0000000200", topoproto.TabletAliasString(si.MasterAlias))
+}
+
+func TestCellMigrationVerify(t *testing.T) {
+	ctx := context.Background()
+	ts := setupTopology(ctx, t)
+	cw := &cellMigrationWorkflow{
+		topoServer: ts,
+		sourceCell: "cell1",
+		targetCell: "cell2",
+	}
+	task := &workflowpb.Task{Attributes: map[string]string{"keyspace": "ks", "shard": "-80"}}
+
+	// The target RDONLY tablet is drained.
+	_, err := ts.UpdateTabletFields(ctx, &topodatapb.TabletAlias{Cell: "cell2", Uid: 202}, func(tablet *topodatapb.Tablet) error {
+		topoproto.SetTabletDrained(tablet, true)
+		return nil
+	})
+	require.NoError(t, err)
+	err = cw.runVerify(ctx, task)
+	require.EqualError(t, err, "cell cell2 of shard ks/-80 only has 0 RDONLY tablets (1 in cell cell1)")
+
+	// The master needs a replica to move to in the target cell.
+	require.NoError(t, ts.DeleteTablet(ctx, &topodatapb.TabletAlias{Cell: "cell1", Uid: 301}))
+	require.NoError(t, ts.DeleteTablet(ctx, &topodatapb.TabletAlias{Cell: "cell2", Uid: 400}))
+	_, err = ts.UpdateShardFields(ctx, "ks", "80-", func(si *topo.ShardInfo) error {
+		si.MasterAlias = &topodatapb.TabletAlias{Cell: "cell1", Uid: 300}
+		return nil
+	})
+	require.NoError(t, err)
+	task.Attributes["shard"] = "80-"
+	err = cw.runVerify(ctx, task)
+	require.EqualError(t, err, "cell cell2 of shard ks/80- has no REPLICA tablet to reparent master cell1-0000000300 to")
+}
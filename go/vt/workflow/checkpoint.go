@@ -17,7 +17,9 @@ limitations under the License.
 package workflow
 
 import (
+	"strconv"
 	"sync"
+	"time"
 
 	"google.golang.org/protobuf/proto"
 
@@ -64,6 +66,63 @@ func (c *CheckpointWriter) UpdateTask(taskID string, status workflowpb.TaskState
 	return c.saveLocked()
 }
 
+// UpdateTaskAttempt marks an execution of a task as done in the
+// checkpointing copy, records its number of attempts and the time of its
+// next automatic retry, if any, and saves the full checkpoint to the
+// topology server.
+func (c *CheckpointWriter) UpdateTaskAttempt(taskID string, err error, attempts int, nextRetry time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := c.checkpoint.Tasks[taskID]
+	t.State = workflowpb.TaskState_TaskDone
+	t.Error = ""
+	if err != nil {
+		t.Error = err.Error()
+	}
+	if t.Attributes == nil {
+		t.Attributes = make(map[string]string)
+	}
+	t.Attributes[TaskAttemptsAttribute] = strconv.Itoa(attempts)
+	if nextRetry.IsZero() {
+		delete(t.Attributes, TaskNextRetryAttribute)
+	} else {
+		t.Attributes[TaskNextRetryAttribute] = nextRetry.Format(time.RFC3339)
+	}
+	return c.saveLocked()
+}
+
+// UpdateSettings sets the settings in the checkpointing copy and saves
+// the full checkpoint to the topology server.
+func (c *CheckpointWriter) UpdateSettings(settings map[string]string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.checkpoint.Settings == nil {
+		c.checkpoint.Settings = make(map[string]string)
+	}
+	for k, v := range settings {
+		c.checkpoint.Settings[k] = v
+	}
+	return c.saveLocked()
+}
+
+// UpdateTaskAttributes sets attributes of the task in the checkpointing
+// copy and saves the full checkpoint to the topology server.
+func (c *CheckpointWriter) UpdateTaskAttributes(taskID string, attributes map[string]string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := c.checkpoint.Tasks[taskID]
+	if t.Attributes == nil {
+		t.Attributes = make(map[string]string)
+	}
+	for k, v := range attributes {
+		t.Attributes[k] = v
+	}
+	return c.saveLocked()
+}
+
 func (c *CheckpointWriter) saveLocked() error {
 	var err error
 	c.wi.Data, err = proto.Marshal(c.checkpoint)
@@ -0,0 +1,83 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCronNext(t *testing.T) {
+	// A Wednesday.
+	start := time.Date(2021, time.March, 10, 13, 17, 30, 0, time.UTC)
+	testcases := []struct {
+		cron string
+		want time.Time
+	}{{
+		cron: "* * * * *",
+		want: time.Date(2021, time.March, 10, 13, 18, 0, 0, time.UTC),
+	}, {
+		cron: "*/15 * * * *",
+		want: time.Date(2021, time.March, 10, 13, 30, 0, 0, time.UTC),
+	}, {
+		cron: "@nightly",
+		want: time.Date(2021, time.March, 11, 0, 0, 0, 0, time.UTC),
+	}, {
+		cron: "30 2 * * 0",
+		want: time.Date(2021, time.March, 14, 2, 30, 0, 0, time.UTC),
+	}, {
+		cron: "0 9-17/4 * * 1-5",
+		want: time.Date(2021, time.March, 10, 17, 0, 0, 0, time.UTC),
+	}, {
+		// Either the 1st of the month or a Friday.
+		cron: "0 0 1 * 5",
+		want: time.Date(2021, time.March, 12, 0, 0, 0, 0, time.UTC),
+	}, {
+		cron: "0 0 29 2 *",
+		want: time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC),
+	}, {
+		cron: "0 0 30 2 *",
+		want: time.Time{},
+	}}
+	for _, tc := range testcases {
+		c, err := parseCron(tc.cron)
+		require.NoError(t, err, tc.cron)
+		assert.Equal(t, tc.want, c.next(start), tc.cron)
+	}
+}
+
+func TestParseCronErrors(t *testing.T) {
+	for _, cron := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 7",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+		"@often",
+	} {
+		_, err := parseCron(cron)
+		assert.Error(t, err, cron)
+	}
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/topo"
+
+	workflowpb "vitess.io/vitess/go/vt/proto/workflow"
+)
+
+// exportVersion is the version of the format of the exported workflows.
+const exportVersion = 1
+
+// exportedWorkflow is the portable representation of a workflow. The
+// workflow is kept as stored in the topo server: its data contains the
+// checkpoint of the workflow, e.g. the tasks with their states and
+// attributes, from which the node tree is rebuilt when it's instantiated.
+// The other fields are informational.
+type exportedWorkflow struct {
+	Version     int    `json:"version"`
+	ExportTime  int64  `json:"export_time"`
+	UUID        string `json:"uuid"`
+	FactoryName string `json:"factory_name"`
+	Name        string `json:"name"`
+	State       string `json:"state"`
+	// Workflow is the proto-encoded workflowpb.Workflow.
+	Workflow []byte `json:"workflow"`
+}
+
+// Export returns the last checkpoint of a workflow, as saved in the topo
+// server, as a portable blob which can be imported into another topo
+// server with Import.
+func (m *Manager) Export(ctx context.Context, uuid string) ([]byte, error) {
+	wi, err := m.ts.GetWorkflow(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+	data, err := proto.Marshal(wi.Workflow)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(&exportedWorkflow{
+		Version:     exportVersion,
+		ExportTime:  time.Now().Unix(),
+		UUID:        wi.Uuid,
+		FactoryName: wi.FactoryName,
+		Name:        wi.Name,
+		State:       wi.State.String(),
+		Workflow:    data,
+	}, "", "  ")
+}
+
+// Import creates a workflow from a blob returned by Export, keeping its
+// UUID and state. A workflow which was running is resumed from its
+// checkpoint if the Manager is running, or the next time it runs. The
+// exported workflow must not run anymore where it was exported from.
+func (m *Manager) Import(ctx context.Context, blob []byte) (string, error) {
+	ew := &exportedWorkflow{}
+	if err := json.Unmarshal(blob, ew); err != nil {
+		return "", fmt.Errorf("cannot parse the exported workflow: %v", err)
+	}
+	if ew.Version != exportVersion {
+		return "", fmt.Errorf("unsupported version %v of the exported workflow, expected %v", ew.Version, exportVersion)
+	}
+	w := &workflowpb.Workflow{}
+	if err := proto.Unmarshal(ew.Workflow, w); err != nil {
+		return "", fmt.Errorf("cannot parse the exported workflow: %v", err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.workflows[w.Uuid]; ok {
+		return "", fmt.Errorf("workflow %v already exists", w.Uuid)
+	}
+	rw, err := m.instantiateWorkflow(w)
+	if err != nil {
+		return "", err
+	}
+	rw.wi, err = m.ts.CreateWorkflow(ctx, w)
+	if err != nil {
+		m.nodeManager.RemoveRootNode(rw.rootNode)
+		delete(m.workflows, w.Uuid)
+		if topo.IsErrType(err, topo.NodeExists) {
+			return "", fmt.Errorf("workflow %v already exists", w.Uuid)
+		}
+		return "", err
+	}
+	log.Infof("Imported workflow %s (%s, %s) in state %v", w.Uuid, w.FactoryName, w.Name, w.State)
+	recordAudit(ctx, m.ts, w.Uuid, AuditImported, fmt.Sprintf("in state %v", w.State))
+
+	if w.State == workflowpb.WorkflowState_Running && m.ctx != nil {
+		m.runWorkflow(rw)
+	}
+	return w.Uuid, nil
+}
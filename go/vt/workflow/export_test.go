@@ -0,0 +1,76 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+
+	workflowpb "vitess.io/vitess/go/vt/proto/workflow"
+)
+
+// TestManagerExportImport moves a running workflow to another topo
+// server, where it is resumed.
+func TestManagerExportImport(t *testing.T) {
+	ctx := context.Background()
+	ts := memorytopo.NewServer("cell1")
+	m := NewManager(ts)
+	wg, _, cancel := StartManager(m)
+	uuid, err := m.Create(ctx, sleepFactoryName, []string{"-duration", "60"})
+	require.NoError(t, err)
+	require.NoError(t, m.Start(ctx, uuid))
+	// Stopping the manager leaves the workflow running in the topo server.
+	cancel()
+	wg.Wait()
+
+	blob, err := NewManager(ts).Export(ctx, uuid)
+	require.NoError(t, err)
+	assert.Contains(t, string(blob), uuid)
+
+	newTS := memorytopo.NewServer("cell2")
+	newManager := NewManager(newTS)
+	wg, _, cancel = StartManager(newManager)
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+	imported, err := newManager.Import(ctx, blob)
+	require.NoError(t, err)
+	assert.Equal(t, uuid, imported)
+
+	wi, err := newTS.GetWorkflow(ctx, uuid)
+	require.NoError(t, err)
+	assert.Equal(t, workflowpb.WorkflowState_Running, wi.State)
+	w, err := newManager.WorkflowForTesting(uuid)
+	require.NoError(t, err)
+	sw := w.(*SleepWorkflow)
+	sw.mu.Lock()
+	assert.Equal(t, 60, sw.data.Duration)
+	sw.mu.Unlock()
+	require.NoError(t, newManager.Stop(ctx, uuid))
+
+	// A workflow can only be imported once.
+	_, err = newManager.Import(ctx, blob)
+	assert.Error(t, err)
+	_, err = newManager.Import(ctx, []byte("{}"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,39 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostmaintenance
+
+import (
+	"context"
+	"time"
+
+	replicationdatapb "vitess.io/vitess/go/vt/proto/replicationdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// Wrangler is the subset of the methods of go/vt/wrangler.Wrangler used by
+// the host maintenance workflow, so that unit tests can fake it.
+type Wrangler interface {
+	SetTabletDrained(ctx context.Context, tabletAlias *topodatapb.TabletAlias, drained bool) error
+
+	ReplicationStatus(ctx context.Context, tabletAlias *topodatapb.TabletAlias) (*replicationdatapb.Status, error)
+
+	StopReplication(ctx context.Context, tabletAlias *topodatapb.TabletAlias) error
+
+	StartReplication(ctx context.Context, tabletAlias *topodatapb.TabletAlias) error
+
+	PlannedReparentShard(ctx context.Context, keyspace, shard string, masterElectTabletAlias, avoidMasterAlias *topodatapb.TabletAlias, waitReplicasTimeout time.Duration) error
+}
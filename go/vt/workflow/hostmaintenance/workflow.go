@@ -0,0 +1,460 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package hostmaintenance contains a workflow which cordons all the
+// tablets of a host before a maintenance, tablet by tablet: the shards
+// mastered on the host are reparented to a replica on another host, then
+// each tablet is drained and its replication stopped. The same workflow
+// created with -uncordon undoes it after the maintenance, restarting only
+// the replication which was running before the cordon.
+package hostmaintenance
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vttablet/tmclient"
+	"vitess.io/vitess/go/vt/workflow"
+	"vitess.io/vitess/go/vt/wrangler"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	workflowpb "vitess.io/vitess/go/vt/proto/workflow"
+)
+
+const (
+	codeVersion                                   = 1
+	hostMaintenanceFactoryName                    = "host_maintenance"
+	phaseCordon                workflow.PhaseType = "cordon"
+	phaseUncordon              workflow.PhaseType = "uncordon"
+
+	// replicationRunningAttribute is the task attribute recording whether
+	// the replication of the tablet was running before it was cordoned.
+	replicationRunningAttribute = "replication_running"
+)
+
+// Register registers the Factory of the host maintenance workflow in the
+// workflow framework.
+func Register() {
+	workflow.Register(hostMaintenanceFactoryName, &Factory{})
+}
+
+// Factory is the factory to create a host maintenance workflow.
+type Factory struct{}
+
+// Init is part of the workflow.Factory interface.
+func (*Factory) Init(m *workflow.Manager, w *workflowpb.Workflow, args []string) error {
+	subFlags := flag.NewFlagSet(hostMaintenanceFactoryName, flag.ContinueOnError)
+	cell := subFlags.String("cell", "", "The cell of the host")
+	hostname := subFlags.String("hostname", "", "The hostname of the tablets to cordon")
+	uncordon := subFlags.Bool("uncordon", false, "Uncordon the tablets of the host after the maintenance: restart the replication which was running before the cordon and undrain them")
+	drainGrace := subFlags.Duration("drain_grace", 30*time.Second, "How long to wait after draining a tablet for its in-flight queries to complete")
+	waitReplicasTimeout := subFlags.Duration("wait_replicas_timeout", 30*time.Second, "The time to wait for the replicas to catch up during the planned reparent of a master")
+
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if *cell == "" || *hostname == "" {
+		return fmt.Errorf("the cell and hostname flags must be provided for the host maintenance")
+	}
+
+	tablets, err := hostTablets(context.Background(), m.TopoServer(), *cell, *hostname)
+	if err != nil {
+		return err
+	}
+	if len(tablets) == 0 {
+		return fmt.Errorf("host %v of cell %v has no tablets", *hostname, *cell)
+	}
+	phase := phaseCordon
+	var replicationRunning map[string]string
+	if *uncordon {
+		phase = phaseUncordon
+		if replicationRunning, err = cordonReplicationRunning(context.Background(), m.TopoServer(), *cell, *hostname); err != nil {
+			return err
+		}
+	}
+	var taskIDs []string
+	tasks := make(map[string]*workflowpb.Task)
+	for _, ti := range tablets {
+		taskID := tabletTaskID(phase, ti.AliasString())
+		taskIDs = append(taskIDs, taskID)
+		attributes := map[string]string{
+			"alias":    ti.AliasString(),
+			"keyspace": ti.Keyspace,
+			"shard":    ti.Shard,
+		}
+		if running, ok := replicationRunning[ti.AliasString()]; ok {
+			attributes[replicationRunningAttribute] = running
+		}
+		tasks[taskID] = &workflowpb.Task{
+			Id:         taskID,
+			State:      workflowpb.TaskState_TaskNotStarted,
+			Attributes: attributes,
+		}
+	}
+
+	if *uncordon {
+		w.Name = fmt.Sprintf("Uncordon of the tablets of host %v in cell %v.", *hostname, *cell)
+	} else {
+		w.Name = fmt.Sprintf("Cordon of the tablets of host %v in cell %v.", *hostname, *cell)
+	}
+	checkpoint := &workflowpb.WorkflowCheckpoint{
+		CodeVersion: codeVersion,
+		Tasks:       tasks,
+		Settings: map[string]string{
+			"cell":                  *cell,
+			"hostname":              *hostname,
+			"uncordon":              strconv.FormatBool(*uncordon),
+			"tasks":                 strings.Join(taskIDs, ","),
+			"drain_grace":           drainGrace.String(),
+			"wait_replicas_timeout": waitReplicasTimeout.String(),
+		},
+	}
+	w.Data, err = proto.Marshal(checkpoint)
+	return err
+}
+
+// hostTablets returns the tablets of a host which belong to a shard. The
+// masters come first, s.t. the shards are reparented away from the host
+// before anything else.
+func hostTablets(ctx context.Context, ts *topo.Server, cell, hostname string) ([]*topo.TabletInfo, error) {
+	aliases, err := ts.GetTabletsByCell(ctx, cell)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get the tablets of cell %v: %v", cell, err)
+	}
+	var tablets []*topo.TabletInfo
+	for _, alias := range aliases {
+		ti, err := ts.GetTablet(ctx, alias)
+		if err != nil {
+			return nil, err
+		}
+		if ti.Hostname != hostname || ti.Keyspace == "" || ti.Shard == "" {
+			continue
+		}
+		tablets = append(tablets, ti)
+	}
+	sort.Slice(tablets, func(i, j int) bool {
+		iMaster := tablets[i].Type == topodatapb.TabletType_MASTER
+		jMaster := tablets[j].Type == topodatapb.TabletType_MASTER
+		if iMaster != jMaster {
+			return iMaster
+		}
+		return tablets[i].AliasString() < tablets[j].AliasString()
+	})
+	return tablets, nil
+}
+
+// cordonReplicationRunning returns whether the replication of the tablets
+// of a host was running before the last cordon of the host, as recorded by
+// its workflow, by tablet alias.
+func cordonReplicationRunning(ctx context.Context, ts *topo.Server, cell, hostname string) (map[string]string, error) {
+	uuids, err := ts.GetWorkflowNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var last *topo.WorkflowInfo
+	var lastCheckpoint *workflowpb.WorkflowCheckpoint
+	for _, uuid := range uuids {
+		wi, err := ts.GetWorkflow(ctx, uuid)
+		switch {
+		case topo.IsErrType(err, topo.NoNode):
+			continue
+		case err != nil:
+			return nil, err
+		}
+		if wi.FactoryName != hostMaintenanceFactoryName || (last != nil && wi.CreateTime < last.CreateTime) {
+			continue
+		}
+		checkpoint := &workflowpb.WorkflowCheckpoint{}
+		if err := proto.Unmarshal(wi.Data, checkpoint); err != nil {
+			return nil, err
+		}
+		if checkpoint.Settings["cell"] != cell || checkpoint.Settings["hostname"] != hostname || checkpoint.Settings["uncordon"] == "true" {
+			continue
+		}
+		last, lastCheckpoint = wi, checkpoint
+	}
+
+	running := make(map[string]string)
+	if lastCheckpoint == nil {
+		return running, nil
+	}
+	for _, task := range lastCheckpoint.Tasks {
+		if value, ok := task.Attributes[replicationRunningAttribute]; ok {
+			running[task.Attributes["alias"]] = value
+		}
+	}
+	return running, nil
+}
+
+// Instantiate is part the workflow.Factory interface.
+func (*Factory) Instantiate(m *workflow.Manager, w *workflowpb.Workflow, rootNode *workflow.Node) (workflow.Workflow, error) {
+	checkpoint := &workflowpb.WorkflowCheckpoint{}
+	if err := proto.Unmarshal(w.Data, checkpoint); err != nil {
+		return nil, err
+	}
+
+	hw := &hostMaintenanceWorkflow{
+		checkpoint: checkpoint,
+		rootUINode: rootNode,
+		logger:     logutil.NewMemoryLogger(),
+		wr:         wrangler.New(logutil.NewConsoleLogger(), m.TopoServer(), tmclient.NewTabletManagerClient()),
+		topoServer: m.TopoServer(),
+		hostname:   checkpoint.Settings["hostname"],
+		uncordon:   checkpoint.Settings["uncordon"] == "true",
+	}
+	for setting, d := range map[string]*time.Duration{
+		"drain_grace":           &hw.drainGrace,
+		"wait_replicas_timeout": &hw.waitReplicasTimeout,
+	} {
+		var err error
+		if *d, err = time.ParseDuration(checkpoint.Settings[setting]); err != nil {
+			return nil, fmt.Errorf("invalid %v setting: %v", setting, err)
+		}
+	}
+
+	phase := phaseCordon
+	phaseName := "Cordon"
+	if hw.uncordon {
+		phase = phaseUncordon
+		phaseName = "Uncordon"
+	}
+	rootNode.Message = fmt.Sprintf("This is a workflow to %v the tablets of host %v in cell %v.", strings.ToLower(phaseName), hw.hostname, checkpoint.Settings["cell"])
+	phaseUINode := &workflow.Node{
+		Name:     phaseName,
+		PathName: string(phase),
+	}
+	hw.rootUINode.Children = []*workflow.Node{phaseUINode}
+	hw.tabletUINodes = make(map[string]*workflow.Node)
+	for _, task := range hw.getTasks() {
+		tabletUINode := &workflow.Node{
+			Name:     fmt.Sprintf("Tablet %v (%v/%v)", task.Attributes["alias"], task.Attributes["keyspace"], task.Attributes["shard"]),
+			PathName: task.Attributes["alias"],
+		}
+		phaseUINode.Children = append(phaseUINode.Children, tabletUINode)
+		hw.tabletUINodes[task.Id] = tabletUINode
+	}
+	return hw, nil
+}
+
+// tabletTaskID returns the ID of the task of a tablet, which is also the
+// path of its UI node.
+func tabletTaskID(phase workflow.PhaseType, alias string) string {
+	return fmt.Sprintf("%s/%s", phase, alias)
+}
+
+// hostMaintenanceWorkflow contains meta-information and methods to control
+// the host maintenance workflow.
+type hostMaintenanceWorkflow struct {
+	ctx        context.Context
+	wr         Wrangler
+	topoServer *topo.Server
+	wi         *topo.WorkflowInfo
+	// logger is the logger we export UI logs from.
+	logger *logutil.MemoryLogger
+
+	// rootUINode is the root node representing the workflow in the UI.
+	rootUINode *workflow.Node
+	// tabletUINodes has the UI node of each task.
+	tabletUINodes map[string]*workflow.Node
+
+	checkpoint       *workflowpb.WorkflowCheckpoint
+	checkpointWriter *workflow.CheckpointWriter
+
+	hostname            string
+	uncordon            bool
+	drainGrace          time.Duration
+	waitReplicasTimeout time.Duration
+}
+
+// Run cordons or uncordons the tablets of the host.
+// It implements the workflow.Workflow interface.
+func (hw *hostMaintenanceWorkflow) Run(ctx context.Context, manager *workflow.Manager, wi *topo.WorkflowInfo) error {
+	hw.ctx = ctx
+	hw.wi = wi
+	hw.checkpointWriter = workflow.NewCheckpointWriter(hw.topoServer, hw.checkpoint, hw.wi)
+	hw.rootUINode.Display = workflow.NodeDisplayDeterminate
+	hw.rootUINode.BroadcastChanges(true /* updateChildren */)
+
+	executeFunc := hw.cordonTablet
+	if hw.uncordon {
+		executeFunc = hw.uncordonTablet
+	}
+	runner := workflow.NewParallelRunner(hw.ctx, hw.rootUINode, hw.checkpointWriter, hw.getTasks(), executeFunc, workflow.Sequential, false /* enableApprovals */)
+	if err := runner.Run(); err != nil {
+		return err
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if hw.uncordon {
+		hw.setUIMessage(fmt.Sprintf("The tablets of host %v are uncordoned.", hw.hostname))
+	} else {
+		hw.setUIMessage(fmt.Sprintf("The tablets of host %v are cordoned, the host can be taken down.", hw.hostname))
+	}
+	return nil
+}
+
+// getTasks returns the tasks of the tablets, in execution order.
+func (hw *hostMaintenanceWorkflow) getTasks() []*workflowpb.Task {
+	var tasks []*workflowpb.Task
+	for _, taskID := range strings.Split(hw.checkpoint.Settings["tasks"], ",") {
+		tasks = append(tasks, hw.checkpoint.Tasks[taskID])
+	}
+	return tasks
+}
+
+// cordonTablet reparents the shard of the tablet of the task to a replica
+// on another host if the tablet is its master, records whether its
+// replication is running, then drains the tablet and stops its
+// replication. Each step is a no-op if it was done by a previous attempt,
+// s.t. a failed task can be retried.
+func (hw *hostMaintenanceWorkflow) cordonTablet(ctx context.Context, t *workflowpb.Task) error {
+	keyspace := t.Attributes["keyspace"]
+	shard := t.Attributes["shard"]
+	tabletAlias, err := topoproto.ParseTabletAlias(t.Attributes["alias"])
+	if err != nil {
+		return err
+	}
+	alias := t.Attributes["alias"]
+
+	si, err := hw.topoServer.GetShard(ctx, keyspace, shard)
+	if err != nil {
+		return err
+	}
+	if topoproto.TabletAliasEqual(si.MasterAlias, tabletAlias) {
+		masterElect, err := hw.masterElect(ctx, keyspace, shard)
+		if err != nil {
+			return err
+		}
+		hw.setTaskUIMessage(t, fmt.Sprintf("Reparenting %v/%v from master %v to %v.", keyspace, shard, alias, topoproto.TabletAliasString(masterElect)))
+		if err := hw.wr.PlannedReparentShard(ctx, keyspace, shard, masterElect, tabletAlias, hw.waitReplicasTimeout); err != nil {
+			return fmt.Errorf("cannot reparent %v/%v away from master %v: %v", keyspace, shard, alias, err)
+		}
+	}
+
+	// The replication state is recorded once, before the first attempt
+	// stops the replication.
+	running, ok := t.Attributes[replicationRunningAttribute]
+	if !ok {
+		status, err := hw.wr.ReplicationStatus(ctx, tabletAlias)
+		if err != nil {
+			return fmt.Errorf("cannot get the replication status of tablet %v: %v", alias, err)
+		}
+		running = strconv.FormatBool(status.IoThreadRunning && status.SqlThreadRunning)
+		if err := hw.checkpointWriter.UpdateTaskAttributes(t.Id, map[string]string{replicationRunningAttribute: running}); err != nil {
+			return err
+		}
+	}
+
+	hw.setTaskUIMessage(t, fmt.Sprintf("Draining tablet %v.", alias))
+	if err := hw.wr.SetTabletDrained(ctx, tabletAlias, true); err != nil {
+		return fmt.Errorf("cannot drain tablet %v: %v", alias, err)
+	}
+	if err := sleep(ctx, hw.drainGrace); err != nil {
+		return err
+	}
+
+	if running == "true" {
+		hw.setTaskUIMessage(t, fmt.Sprintf("Stopping the replication of tablet %v.", alias))
+		if err := hw.wr.StopReplication(ctx, tabletAlias); err != nil {
+			return fmt.Errorf("cannot stop the replication of tablet %v, it is left drained: %v", alias, err)
+		}
+	}
+	hw.setTaskUIMessage(t, fmt.Sprintf("Tablet %v is cordoned.", alias))
+	return nil
+}
+
+// masterElect returns the replica of the shard to reparent to, which must
+// not be on the host under maintenance.
+func (hw *hostMaintenanceWorkflow) masterElect(ctx context.Context, keyspace, shard string) (*topodatapb.TabletAlias, error) {
+	tabletMap, err := hw.topoServer.GetTabletMapForShard(ctx, keyspace, shard)
+	if err != nil {
+		return nil, fmt.Errorf("GetTabletMapForShard(%v, %v) failed: %v", keyspace, shard, err)
+	}
+	var candidates []*topodatapb.TabletAlias
+	for _, ti := range tabletMap {
+		if ti.Type != topodatapb.TabletType_REPLICA || ti.Hostname == hw.hostname || topoproto.IsTabletDrained(ti.Tablet) {
+			continue
+		}
+		candidates = append(candidates, ti.Alias)
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("shard %v/%v has no serving replica outside of host %v to reparent to", keyspace, shard, hw.hostname)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return topoproto.TabletAliasString(candidates[i]) < topoproto.TabletAliasString(candidates[j])
+	})
+	return candidates[0], nil
+}
+
+// uncordonTablet restarts the replication of the tablet of the task, unless
+// it wasn't running before the cordon, and undrains it. The former masters
+// of the host stay replicas.
+func (hw *hostMaintenanceWorkflow) uncordonTablet(ctx context.Context, t *workflowpb.Task) error {
+	tabletAlias, err := topoproto.ParseTabletAlias(t.Attributes["alias"])
+	if err != nil {
+		return err
+	}
+	alias := t.Attributes["alias"]
+
+	if t.Attributes[replicationRunningAttribute] != "false" {
+		hw.setTaskUIMessage(t, fmt.Sprintf("Starting the replication of tablet %v.", alias))
+		if err := hw.wr.StartReplication(ctx, tabletAlias); err != nil {
+			return fmt.Errorf("cannot start the replication of tablet %v: %v", alias, err)
+		}
+	}
+	hw.setTaskUIMessage(t, fmt.Sprintf("Undraining tablet %v.", alias))
+	if err := hw.wr.SetTabletDrained(ctx, tabletAlias, false); err != nil {
+		return fmt.Errorf("cannot undrain tablet %v: %v", alias, err)
+	}
+	hw.setTaskUIMessage(t, fmt.Sprintf("Tablet %v is uncordoned.", alias))
+	return nil
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(d):
+		return nil
+	}
+}
+
+func (hw *hostMaintenanceWorkflow) setTaskUIMessage(t *workflowpb.Task, message string) {
+	log.Infof("Host maintenance: %v", message)
+	node := hw.tabletUINodes[t.Id]
+	node.Message = message
+	node.BroadcastChanges(false /* updateChildren */)
+}
+
+func (hw *hostMaintenanceWorkflow) setUIMessage(message string) {
+	log.Infof("Host maintenance: %v", message)
+	hw.logger.Infof(message)
+	hw.rootUINode.Log = hw.logger.String()
+	hw.rootUINode.Message = message
+	hw.rootUINode.BroadcastChanges(false /* updateChildren */)
+}
@@ -0,0 +1,226 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostmaintenance
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/workflow"
+
+	// import the gRPC client implementation for tablet manager
+	_ "vitess.io/vitess/go/vt/vttablet/grpctmclient"
+
+	replicationdatapb "vitess.io/vitess/go/vt/proto/replicationdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func init() {
+	Register()
+}
+
+// fakeWrangler records the calls of the workflow. Its planned reparent
+// promotes the master elect in the topo server.
+type fakeWrangler struct {
+	ts *topo.Server
+	// stopped are the tablets whose replication is stopped.
+	stopped map[string]bool
+
+	mu    sync.Mutex
+	calls []string
+}
+
+func (f *fakeWrangler) record(format string, args ...interface{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, fmt.Sprintf(format, args...))
+}
+
+func (f *fakeWrangler) SetTabletDrained(ctx context.Context, tabletAlias *topodatapb.TabletAlias, drained bool) error {
+	f.record("drained %v: %v", topoproto.TabletAliasString(tabletAlias), drained)
+	return nil
+}
+
+func (f *fakeWrangler) ReplicationStatus(ctx context.Context, tabletAlias *topodatapb.TabletAlias) (*replicationdatapb.Status, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	running := !f.stopped[topoproto.TabletAliasString(tabletAlias)]
+	return &replicationdatapb.Status{IoThreadRunning: running, SqlThreadRunning: running}, nil
+}
+
+func (f *fakeWrangler) StopReplication(ctx context.Context, tabletAlias *topodatapb.TabletAlias) error {
+	f.record("stop replication %v", topoproto.TabletAliasString(tabletAlias))
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.stopped[topoproto.TabletAliasString(tabletAlias)] = true
+	return nil
+}
+
+func (f *fakeWrangler) StartReplication(ctx context.Context, tabletAlias *topodatapb.TabletAlias) error {
+	f.record("start replication %v", topoproto.TabletAliasString(tabletAlias))
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.stopped, topoproto.TabletAliasString(tabletAlias))
+	return nil
+}
+
+func (f *fakeWrangler) PlannedReparentShard(ctx context.Context, keyspace, shard string, masterElectTabletAlias, avoidMasterAlias *topodatapb.TabletAlias, waitReplicasTimeout time.Duration) error {
+	f.record("reparent %v/%v from %v to %v", keyspace, shard, topoproto.TabletAliasString(avoidMasterAlias), topoproto.TabletAliasString(masterElectTabletAlias))
+	if _, err := f.ts.UpdateShardFields(ctx, keyspace, shard, func(si *topo.ShardInfo) error {
+		si.MasterAlias = masterElectTabletAlias
+		return nil
+	}); err != nil {
+		return err
+	}
+	for alias, tabletType := range map[*topodatapb.TabletAlias]topodatapb.TabletType{
+		masterElectTabletAlias: topodatapb.TabletType_MASTER,
+		avoidMasterAlias:       topodatapb.TabletType_REPLICA,
+	} {
+		if _, err := f.ts.UpdateTabletFields(ctx, alias, func(tablet *topodatapb.Tablet) error {
+			tablet.Type = tabletType
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func addTablet(ctx context.Context, t *testing.T, ts *topo.Server, uid uint32, hostname, shard string, tabletType topodatapb.TabletType) {
+	alias := &topodatapb.TabletAlias{Cell: "cell", Uid: uid}
+	require.NoError(t, ts.CreateTablet(ctx, &topodatapb.Tablet{
+		Alias:    alias,
+		Hostname: hostname,
+		Keyspace: "ks",
+		Shard:    shard,
+		Type:     tabletType,
+	}))
+	if tabletType == topodatapb.TabletType_MASTER {
+		_, err := ts.UpdateShardFields(ctx, "ks", shard, func(si *topo.ShardInfo) error {
+			si.MasterAlias = alias
+			return nil
+		})
+		require.NoError(t, err)
+	}
+}
+
+// setupTopology creates host1 with the master of -80 and a replica of
+// 80-, and host2 with the other tablets.
+func setupTopology(ctx context.Context, t *testing.T) *topo.Server {
+	ts := memorytopo.NewServer("cell")
+	require.NoError(t, ts.CreateKeyspace(ctx, "ks", &topodatapb.Keyspace{}))
+	require.NoError(t, ts.CreateShard(ctx, "ks", "-80"))
+	require.NoError(t, ts.CreateShard(ctx, "ks", "80-"))
+	addTablet(ctx, t, ts, 100, "host1", "-80", topodatapb.TabletType_MASTER)
+	addTablet(ctx, t, ts, 101, "host2", "-80", topodatapb.TabletType_REPLICA)
+	addTablet(ctx, t, ts, 200, "host2", "80-", topodatapb.TabletType_MASTER)
+	addTablet(ctx, t, ts, 201, "host1", "80-", topodatapb.TabletType_REPLICA)
+	return ts
+}
+
+func runWorkflow(ctx context.Context, t *testing.T, ts *topo.Server, wr *fakeWrangler, args []string) *topo.WorkflowInfo {
+	m := workflow.NewManager(ts)
+	wg, _, cancel := workflow.StartManager(m)
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+	uuid, err := m.Create(ctx, hostMaintenanceFactoryName, args)
+	require.NoError(t, err)
+	w, err := m.WorkflowForTesting(uuid)
+	require.NoError(t, err)
+	w.(*hostMaintenanceWorkflow).wr = wr
+	require.NoError(t, m.Start(ctx, uuid))
+	require.NoError(t, m.Wait(ctx, uuid))
+
+	wi, err := ts.GetWorkflow(ctx, uuid)
+	require.NoError(t, err)
+	return wi
+}
+
+func TestHostMaintenanceInit(t *testing.T) {
+	ctx := context.Background()
+	ts := setupTopology(ctx, t)
+	m := workflow.NewManager(ts)
+
+	for _, args := range [][]string{
+		{},
+		{"-cell=cell"},
+		{"-cell=cell", "-hostname=host3"},
+		{"-cell=cell2", "-hostname=host1"},
+	} {
+		_, err := m.Create(ctx, hostMaintenanceFactoryName, args)
+		assert.Error(t, err, "%v", args)
+	}
+}
+
+func TestHostMaintenance(t *testing.T) {
+	ctx := context.Background()
+	ts := setupTopology(ctx, t)
+	// The replication of the replica of 80- on host1 was stopped before
+	// the maintenance.
+	wr := &fakeWrangler{ts: ts, stopped: map[string]bool{"cell-0000000201": true}}
+
+	// The master of -80 is reparented to host2 before being cordoned.
+	wi := runWorkflow(ctx, t, ts, wr, []string{"-cell=cell", "-hostname=host1", "-drain_grace=0s"})
+	assert.Empty(t, wi.Error)
+	require.NoError(t, workflow.VerifyAllTasksDone(ctx, ts, wi.Uuid))
+	assert.Equal(t, []string{
+		"reparent ks/-80 from cell-0000000100 to cell-0000000101",
+		"drained cell-0000000100: true",
+		"stop replication cell-0000000100",
+		"drained cell-0000000201: true",
+	}, wr.calls)
+	si, err := ts.GetShard(ctx, "ks", "-80")
+	require.NoError(t, err)
+	assert.Equal(t, "cell-0000000101", topoproto.TabletAliasString(si.MasterAlias))
+
+	// Only the replication which was running before the cordon is
+	// restarted.
+	wr.calls = nil
+	wi = runWorkflow(ctx, t, ts, wr, []string{"-cell=cell", "-hostname=host1", "-uncordon"})
+	assert.Empty(t, wi.Error)
+	assert.Equal(t, []string{
+		"start replication cell-0000000100",
+		"drained cell-0000000100: false",
+		"drained cell-0000000201: false",
+	}, wr.calls)
+	assert.True(t, wr.stopped["cell-0000000201"])
+}
+
+func TestMasterElect(t *testing.T) {
+	ctx := context.Background()
+	ts := setupTopology(ctx, t)
+
+	hw := &hostMaintenanceWorkflow{topoServer: ts, hostname: "host1"}
+	masterElect, err := hw.masterElect(ctx, "ks", "-80")
+	require.NoError(t, err)
+	assert.Equal(t, "cell-0000000101", topoproto.TabletAliasString(masterElect))
+
+	// The only replica of 80- is on host1.
+	_, err = hw.masterElect(ctx, "ks", "80-")
+	assert.EqualError(t, err, "shard ks/80- has no serving replica outside of host host1 to reparent to")
+}
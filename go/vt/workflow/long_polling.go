@@ -281,8 +281,8 @@ func (m *Manager) HandleHTTPLongPolling(pattern string) {
 			return err
 		}
 
-		ctx := context.TODO()
-		if err := m.NodeManager().Action(ctx, ap); err != nil {
+		ctx := NewAuditContext(context.TODO(), "", r.RemoteAddr)
+		if err := m.Action(ctx, ap); err != nil {
 			return fmt.Errorf("action failed: %v", err)
 		}
 		http.Error(w, "", http.StatusOK)
@@ -21,6 +21,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"os"
+	"strings"
 	"sync"
 	"time"
 
@@ -96,6 +98,8 @@ type Manager struct {
 	workflows map[string]*runningWorkflow
 	// sanitizeHTTPHeaders toggles sanitizeRequestHeader() behavior
 	sanitizeHTTPHeaders bool
+	// id identifies this Manager as the owner of the workflows it runs.
+	id string
 }
 
 // runningWorkflow holds information about a running workflow.
@@ -127,11 +131,16 @@ type runningWorkflow struct {
 
 // NewManager creates an initialized Manager.
 func NewManager(ts *topo.Server) *Manager {
+	id, err := os.Hostname()
+	if err != nil {
+		log.Warningf("Cannot get the hostname for the workflow manager ID: %v", err)
+	}
 	return &Manager{
 		ts:          ts,
 		nodeManager: NewNodeManager(),
 		started:     make(chan struct{}),
 		workflows:   make(map[string]*runningWorkflow),
+		id:          id,
 	}
 }
 
@@ -176,13 +185,25 @@ func (m *Manager) Run(ctx context.Context) {
 	m.started = make(chan struct{})
 	m.mu.Unlock()
 
+	// Run the schedules, and share the states of the workflows, while
+	// we are running.
+	go m.runSchedules(ctx)
+	go m.syncStates(ctx)
+
 	// Wait for the context to be canceled.
 	<-ctx.Done()
 
 	// Clear context and get a copy of the running jobs.
 	m.mu.Lock()
 	m.ctx = nil
-	runningWorkflows := m.workflows
+	runningWorkflows := make(map[string]*runningWorkflow)
+	for uuid, rw := range m.workflows {
+		// The workflows loaded from the topo server which aren't
+		// running were never started.
+		if rw.cancel != nil {
+			runningWorkflows[uuid] = rw
+		}
+	}
 	m.workflows = make(map[string]*runningWorkflow)
 	m.mu.Unlock()
 
@@ -241,6 +262,7 @@ func (m *Manager) loadAndStartJobsLocked() {
 		rw.wi = wi
 
 		if rw.wi.State == workflowpb.WorkflowState_Running {
+			m.adoptLocked(rw)
 			m.runWorkflow(rw)
 		}
 	}
@@ -286,6 +308,7 @@ func (m *Manager) Create(ctx context.Context, factoryName string, args []string)
 
 	// And we're done.
 	log.Infof("Created workflow %s (%s, %s)", w.Uuid, factoryName, w.Name)
+	recordAudit(ctx, m.ts, w.Uuid, AuditCreated, fmt.Sprintf("%v %v", factoryName, strings.Join(args, " ")))
 	return w.Uuid, nil
 }
 
@@ -351,6 +374,8 @@ func (m *Manager) Start(ctx context.Context, uuid string) error {
 
 	rw.rootNode.State = workflowpb.WorkflowState_Running
 	rw.rootNode.BroadcastChanges(false /* updateChildren */)
+	recordAudit(ctx, m.ts, uuid, AuditStarted, "")
+	m.saveStateLocked(ctx, rw)
 
 	m.runWorkflow(rw)
 	return nil
@@ -413,6 +438,8 @@ func (m *Manager) executeWorkflowRun(ctx context.Context, rw *runningWorkflow) {
 	if err := m.ts.SaveWorkflow(m.ctx, rw.wi); err != nil {
 		log.Errorf("Could not save workflow %v after completion: %v", rw.wi, err)
 	}
+	recordAudit(m.ctx, m.ts, rw.wi.Uuid, AuditFinished, rw.wi.Error)
+	m.saveStateLocked(m.ctx, rw)
 
 	rw.rootNode.State = workflowpb.WorkflowState_Done
 	rw.rootNode.BroadcastChanges(false /* updateChildren */)
@@ -430,6 +457,7 @@ func (m *Manager) Stop(ctx context.Context, uuid string) error {
 	}
 	rw.stopped = true
 	m.mu.Unlock()
+	recordAudit(ctx, m.ts, uuid, AuditStopped, "")
 
 	// Cancel the running guy, and waits for it.
 	rw.cancel()
@@ -457,8 +485,12 @@ func (m *Manager) Delete(ctx context.Context, uuid string) error {
 	if err := m.ts.DeleteWorkflow(m.ctx, rw.wi); err != nil {
 		log.Errorf("Could not delete workflow %v: %v", rw.wi, err)
 	}
+	if err := m.ts.DeleteWorkflowRunState(ctx, uuid); err != nil {
+		log.Errorf("Could not delete the state of workflow %v: %v", uuid, err)
+	}
 	m.nodeManager.RemoveRootNode(rw.rootNode)
 	delete(m.workflows, uuid)
+	recordAudit(ctx, m.ts, uuid, AuditDeleted, "")
 	return nil
 }
 
@@ -327,6 +327,19 @@ func (m *NodeManager) RemoveRootNode(n *Node) {
 	m.broadcastUpdateLocked(u)
 }
 
+// rootProgress returns the progress of the toplevel Node of a workflow,
+// with its messages.
+func (m *NodeManager) rootProgress(uuid string) (int, string, string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	n, ok := m.roots[uuid]
+	if !ok {
+		return 0, "", ""
+	}
+	return n.Progress, n.ProgressMessage, n.Message
+}
+
 func (m *NodeManager) toJSON(index int) ([]byte, error) {
 	u := &Update{
 		Index:      index,
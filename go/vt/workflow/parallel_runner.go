@@ -19,8 +19,10 @@ package workflow
 import (
 	"fmt"
 	"path"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"context"
 
@@ -68,6 +70,9 @@ type ParallelRunner struct {
 	concurrencyLevel level
 	executeFunc      func(context.Context, *workflowpb.Task) error
 	enableApprovals  bool
+	// retryPolicy controls the automatic retries of the failed tasks. If
+	// it's nil, failed tasks are only retried through the UI.
+	retryPolicy *RetryPolicy
 
 	// mu is used to protect the access to retryActionRegistry, channels for task
 	// approvals and serialize UI node changes.
@@ -112,6 +117,12 @@ func NewParallelRunner(ctx context.Context, rootUINode *Node, cp *CheckpointWrit
 	return p
 }
 
+// SetRetryPolicy sets the policy to retry failed tasks automatically. It
+// must be called before Run.
+func (p *ParallelRunner) SetRetryPolicy(retryPolicy *RetryPolicy) {
+	p.retryPolicy = retryPolicy
+}
+
 // Run is the entry point for controlling task executions.
 func (p *ParallelRunner) Run() error {
 	// default value is 0. The task will not run in this case.
@@ -161,8 +172,21 @@ func (p *ParallelRunner) Run() error {
 	return nil
 }
 
+// recordAutomaticRetry records the automatic retry of a task in the audit
+// trail of the workflow.
+func (p *ParallelRunner) recordAutomaticRetry(taskID string, attempts int) {
+	cp := p.checkpointWriter
+	if cp.topoServer == nil || cp.wi == nil {
+		return
+	}
+	recordAudit(NewAuditContext(p.ctx, "retry policy", ""), cp.topoServer, cp.wi.Uuid, AuditTaskRetried, fmt.Sprintf("automatic retry of %v after %v attempts", taskID, attempts))
+}
+
 func (p *ParallelRunner) executeTask(t *workflowpb.Task) {
 	taskID := t.Id
+	// The number of attempts is kept in the checkpoint, s.t. the retry
+	// policy still applies if the workflow is restarted.
+	attempts, _ := strconv.Atoi(t.Attributes[TaskAttemptsAttribute])
 	for {
 		// Update the task status to running in the checkpoint.
 		if updateErr := p.checkpointWriter.UpdateTask(taskID, workflowpb.TaskState_TaskRunning, nil); updateErr != nil {
@@ -172,8 +196,15 @@ func (p *ParallelRunner) executeTask(t *workflowpb.Task) {
 			log.Errorf("%v", updateErr)
 		}
 		err := p.executeFunc(p.ctx, t)
+		attempts++
+		var backoff time.Duration
+		var nextRetry time.Time
+		if err != nil && p.retryPolicy.shouldRetry(err, attempts) {
+			backoff = p.retryPolicy.backoff(attempts)
+			nextRetry = time.Now().Add(backoff)
+		}
 		// Update the task status to done in the checkpoint.
-		if updateErr := p.checkpointWriter.UpdateTask(taskID, workflowpb.TaskState_TaskDone, err); updateErr != nil {
+		if updateErr := p.checkpointWriter.UpdateTaskAttempt(taskID, err, attempts, nextRetry); updateErr != nil {
 			log.Errorf("%v", updateErr)
 		}
 
@@ -192,12 +223,29 @@ func (p *ParallelRunner) executeTask(t *workflowpb.Task) {
 		}
 		retryChannel := p.addRetryAction(taskID)
 
-		// Block the task execution until the retry action is triggered
-		// or the context is canceled.
+		// Block the task execution until the retry action is triggered,
+		// the automatic retry is due or the context is canceled.
+		if nextRetry.IsZero() {
+			p.setTaskUIMessage(taskID, fmt.Sprintf("attempt %v failed: %v; waiting for a manual retry", attempts, err))
+			select {
+			case <-retryChannel:
+				continue
+			case <-p.ctx.Done():
+				return
+			}
+		}
+		p.setTaskUIMessage(taskID, fmt.Sprintf("attempt %v failed: %v; next retry at %v", attempts, err, nextRetry.Format(time.RFC3339)))
+		retryTimer := time.NewTimer(backoff)
 		select {
 		case <-retryChannel:
+			retryTimer.Stop()
+			continue
+		case <-retryTimer.C:
+			p.removeRetryAction(taskID)
+			p.recordAutomaticRetry(taskID, attempts)
 			continue
 		case <-p.ctx.Done():
+			retryTimer.Stop()
 			return
 		}
 	}
@@ -262,6 +310,24 @@ func (p *ParallelRunner) triggerRetry(taskID string) error {
 	return nil
 }
 
+// removeRetryAction disables the retry action of a task which is retried
+// automatically. It's a no-op if the action was triggered in the meantime.
+func (p *ParallelRunner) removeRetryAction(taskID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.retryActionRegistry[taskID]; !ok {
+		return
+	}
+	delete(p.retryActionRegistry, taskID)
+	node, err := p.rootUINode.GetChildByPath(taskID)
+	if err != nil {
+		log.Fatalf("BUG: node on child path %v not found", taskID)
+	}
+	node.Actions = []*Action{}
+	node.BroadcastChanges(false /* updateChildren */)
+}
+
 func (p *ParallelRunner) addRetryAction(taskID string) chan struct{} {
 	node, err := p.rootUINode.GetChildByPath(taskID)
 	if err != nil {
@@ -415,6 +481,18 @@ func (p *ParallelRunner) setFinishUIMessage(taskID string) {
 	taskNode.BroadcastChanges(false /* updateChildren */)
 }
 
+func (p *ParallelRunner) setTaskUIMessage(taskID, message string) {
+	taskNode, err := p.rootUINode.GetChildByPath(taskID)
+	if err != nil {
+		log.Fatalf("BUG: nodepath %v not found", taskID)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	taskNode.Message = message
+	taskNode.BroadcastChanges(false /* updateChildren */)
+}
+
 func (p *ParallelRunner) setUIMessage(message string) {
 	p.uiLogger.Infof(message)
 
@@ -525,6 +525,48 @@ func TestParallelRunnerRetry(t *testing.T) {
 	wg.Wait()
 }
 
+func TestParallelRunnerAutomaticRetry(t *testing.T) {
+	// Tasks in the workflow are forced to fail at the first attempt, and
+	// are retried automatically by the retry policy.
+	ctx := context.Background()
+	ts := memorytopo.NewServer("cell")
+	m := NewManager(ts)
+	wg, _, cancel := StartManager(m)
+
+	uuid, err := m.Create(ctx, testWorkflowFactoryName, []string{"-retry=true", "-count=2", "-retry_max_attempts=2", "-retry_backoff=10ms"})
+	if err != nil {
+		t.Fatalf("cannot create testworkflow: %v", err)
+	}
+	if err := m.Start(ctx, uuid); err != nil {
+		t.Fatalf("cannot start testworkflow: %v", err)
+	}
+
+	// Wait for the workflow to end.
+	m.Wait(ctx, uuid)
+
+	if err := VerifyAllTasksDone(ctx, ts, uuid); err != nil {
+		t.Fatal(err)
+	}
+	checkpoint, err := checkpoint(ctx, ts, uuid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, task := range checkpoint.Tasks {
+		if got, want := task.Attributes[TaskAttemptsAttribute], "2"; got != want {
+			t.Errorf("task %v: got %v attempts, want %v", task.Id, got, want)
+		}
+		if got, ok := task.Attributes[TaskNextRetryAttribute]; ok {
+			t.Errorf("task %v: got next retry time %v, want none", task.Id, got)
+		}
+	}
+	// Stop the manager.
+	if err := m.Stop(ctx, uuid); err != nil {
+		t.Fatalf("cannot stop testworkflow: %v", err)
+	}
+	cancel()
+	wg.Wait()
+}
+
 func setupTestWorkflow(ctx context.Context, ts *topo.Server, enableApprovals, retry, sequential bool) (*Manager, string, *sync.WaitGroup, context.CancelFunc, error) {
 	m := NewManager(ts)
 	// Run the manager in the background.
@@ -80,6 +80,7 @@ func (*Factory) Init(m *workflow.Manager, w *workflowpb.Workflow, args []string)
 	phaseEnaableApprovalsDesc := fmt.Sprintf("Comma separated phases that require explicit approval in the UI to execute. Phase names are: %v", strings.Join(WorkflowPhases(), ","))
 	phaseEnableApprovalsStr := subFlags.String("phase_enable_approvals", strings.Join(WorkflowPhases(), ","), phaseEnaableApprovalsDesc)
 	useConsistentSnapshot := subFlags.Bool("use_consistent_snapshot", false, "Instead of pausing replication on the source, uses transactions with consistent snapshot to have a stable view of the data.")
+	retryPolicy := workflow.NewRetryPolicyFlags(subFlags)
 
 	if err := subFlags.Parse(args); err != nil {
 		return err
@@ -121,6 +122,9 @@ func (*Factory) Init(m *workflow.Manager, w *workflowpb.Workflow, args []string)
 	}
 
 	checkpoint.Settings["phase_enable_approvals"] = *phaseEnableApprovalsStr
+	if err := retryPolicy.SaveSettings(checkpoint.Settings); err != nil {
+		return err
+	}
 
 	w.Data, err = proto.Marshal(checkpoint)
 	if err != nil {
@@ -143,6 +147,11 @@ func (*Factory) Instantiate(m *workflow.Manager, w *workflowpb.Workflow, rootNod
 		phaseEnableApprovals[phase] = true
 	}
 
+	retryPolicy, err := workflow.RetryPolicyFromSettings(checkpoint.Settings)
+	if err != nil {
+		return nil, err
+	}
+
 	hw := &horizontalReshardingWorkflow{
 		checkpoint:           checkpoint,
 		rootUINode:           rootNode,
@@ -151,6 +160,7 @@ func (*Factory) Instantiate(m *workflow.Manager, w *workflowpb.Workflow, rootNod
 		topoServer:           m.TopoServer(),
 		manager:              m,
 		phaseEnableApprovals: phaseEnableApprovals,
+		retryPolicy:          retryPolicy,
 	}
 	copySchemaUINode := &workflow.Node{
 		Name:     "CopySchemaShard",
@@ -403,6 +413,7 @@ type horizontalReshardingWorkflow struct {
 	checkpointWriter *workflow.CheckpointWriter
 
 	phaseEnableApprovals map[string]bool
+	retryPolicy          *workflow.RetryPolicy
 }
 
 // Run executes the horizontal resharding process.
@@ -424,42 +435,49 @@ func (hw *horizontalReshardingWorkflow) Run(ctx context.Context, manager *workfl
 func (hw *horizontalReshardingWorkflow) runWorkflow() error {
 	copySchemaTasks := hw.GetTasks(phaseCopySchema)
 	copySchemaRunner := workflow.NewParallelRunner(hw.ctx, hw.rootUINode, hw.checkpointWriter, copySchemaTasks, hw.runCopySchema, workflow.Parallel, hw.phaseEnableApprovals[string(phaseCopySchema)])
+	copySchemaRunner.SetRetryPolicy(hw.retryPolicy)
 	if err := copySchemaRunner.Run(); err != nil {
 		return err
 	}
 
 	cloneTasks := hw.GetTasks(phaseClone)
 	cloneRunner := workflow.NewParallelRunner(hw.ctx, hw.rootUINode, hw.checkpointWriter, cloneTasks, hw.runSplitClone, workflow.Parallel, hw.phaseEnableApprovals[string(phaseClone)])
+	cloneRunner.SetRetryPolicy(hw.retryPolicy)
 	if err := cloneRunner.Run(); err != nil {
 		return err
 	}
 
 	waitForFilteredReplicationTasks := hw.GetTasks(phaseWaitForFilteredReplication)
 	waitForFilteredReplicationRunner := workflow.NewParallelRunner(hw.ctx, hw.rootUINode, hw.checkpointWriter, waitForFilteredReplicationTasks, hw.runWaitForFilteredReplication, workflow.Parallel, hw.phaseEnableApprovals[string(phaseWaitForFilteredReplication)])
+	waitForFilteredReplicationRunner.SetRetryPolicy(hw.retryPolicy)
 	if err := waitForFilteredReplicationRunner.Run(); err != nil {
 		return err
 	}
 
 	diffTasks := hw.GetTasks(phaseDiff)
 	diffRunner := workflow.NewParallelRunner(hw.ctx, hw.rootUINode, hw.checkpointWriter, diffTasks, hw.runSplitDiff, workflow.Parallel, hw.phaseEnableApprovals[string(phaseWaitForFilteredReplication)])
+	diffRunner.SetRetryPolicy(hw.retryPolicy)
 	if err := diffRunner.Run(); err != nil {
 		return err
 	}
 
 	migrateRdonlyTasks := hw.GetTasks(phaseMigrateRdonly)
 	migrateRdonlyRunner := workflow.NewParallelRunner(hw.ctx, hw.rootUINode, hw.checkpointWriter, migrateRdonlyTasks, hw.runMigrate, workflow.Sequential, hw.phaseEnableApprovals[string(phaseMigrateRdonly)])
+	migrateRdonlyRunner.SetRetryPolicy(hw.retryPolicy)
 	if err := migrateRdonlyRunner.Run(); err != nil {
 		return err
 	}
 
 	migrateReplicaTasks := hw.GetTasks(phaseMigrateReplica)
 	migrateReplicaRunner := workflow.NewParallelRunner(hw.ctx, hw.rootUINode, hw.checkpointWriter, migrateReplicaTasks, hw.runMigrate, workflow.Sequential, hw.phaseEnableApprovals[string(phaseMigrateReplica)])
+	migrateReplicaRunner.SetRetryPolicy(hw.retryPolicy)
 	if err := migrateReplicaRunner.Run(); err != nil {
 		return err
 	}
 
 	migrateMasterTasks := hw.GetTasks(phaseMigrateMaster)
 	migrateMasterRunner := workflow.NewParallelRunner(hw.ctx, hw.rootUINode, hw.checkpointWriter, migrateMasterTasks, hw.runMigrate, workflow.Sequential, hw.phaseEnableApprovals[string(phaseMigrateReplica)])
+	migrateMasterRunner.SetRetryPolicy(hw.retryPolicy)
 	return migrateMasterRunner.Run()
 }
 
@@ -0,0 +1,178 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"flag"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"vitess.io/vitess/go/flagutil"
+)
+
+// Keys of the retry policy in the settings of a workflow checkpoint.
+const (
+	retryMaxAttemptsSetting = "retry_max_attempts"
+	retryBackoffSetting     = "retry_backoff"
+	retryMaxBackoffSetting  = "retry_max_backoff"
+	retryableErrorsSetting  = "retryable_errors"
+	// retryableErrorsSeparator separates the regular expressions of the
+	// retryable errors setting, as they may contain commas.
+	retryableErrorsSeparator = "\n"
+)
+
+const (
+	defaultRetryBackoff        = 30 * time.Second
+	defaultRetryMaxBackoff     = 10 * time.Minute
+	retryBackoffMultiplication = 2
+)
+
+// Keys of the retry state in the attributes of a task.
+const (
+	// TaskAttemptsAttribute is the number of times a task was executed.
+	TaskAttemptsAttribute = "attempts"
+	// TaskNextRetryAttribute is the time, in RFC 3339 format, at which a
+	// failed task is retried automatically.
+	TaskNextRetryAttribute = "next_retry_time"
+)
+
+// RetryPolicy controls the automatic retries of the failed tasks of a
+// ParallelRunner. A task which isn't retried automatically waits for
+// the retry action to be triggered in the UI, as without a policy.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of executions of a task,
+	// including the first one. A value of 1 or less disables the
+	// automatic retries.
+	MaxAttempts int
+	// Backoff is the delay before the first retry. It doubles for each
+	// subsequent retry, up to MaxBackoff.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// RetryableErrors are the errors retried automatically. If it's
+	// empty, all the errors are.
+	RetryableErrors []*regexp.Regexp
+}
+
+// RetryPolicyFlags are the flags of a factory which configure the retry
+// policy of a workflow.
+type RetryPolicyFlags struct {
+	maxAttempts     *int
+	backoff         *time.Duration
+	maxBackoff      *time.Duration
+	retryableErrors flagutil.StringListValue
+}
+
+// NewRetryPolicyFlags registers the retry policy flags on the flag set
+// a factory parses the workflow arguments with.
+func NewRetryPolicyFlags(fs *flag.FlagSet) *RetryPolicyFlags {
+	f := &RetryPolicyFlags{
+		maxAttempts: fs.Int(retryMaxAttemptsSetting, 1, "Maximum number of executions of a task before it waits for a manual retry. 1 disables the automatic retries."),
+		backoff:     fs.Duration(retryBackoffSetting, defaultRetryBackoff, "Delay before the first automatic retry of a task. It doubles for each subsequent retry."),
+		maxBackoff:  fs.Duration(retryMaxBackoffSetting, defaultRetryMaxBackoff, "Maximum delay between two automatic retries of a task."),
+	}
+	fs.Var(&f.retryableErrors, retryableErrorsSetting, "Comma-separated list of regular expressions matching the errors which are retried automatically. All the errors are if empty.")
+	return f
+}
+
+// SaveSettings validates the parsed flags and saves the retry policy in
+// the settings of a workflow checkpoint.
+func (f *RetryPolicyFlags) SaveSettings(settings map[string]string) error {
+	if *f.backoff < 0 || *f.maxBackoff < 0 {
+		return fmt.Errorf("%v and %v cannot be negative", retryBackoffSetting, retryMaxBackoffSetting)
+	}
+	for _, expr := range f.retryableErrors {
+		if strings.Contains(expr, retryableErrorsSeparator) {
+			return fmt.Errorf("invalid retryable error %q: it cannot contain a newline", expr)
+		}
+		if _, err := regexp.Compile(expr); err != nil {
+			return fmt.Errorf("invalid retryable error %q: %v", expr, err)
+		}
+	}
+	settings[retryMaxAttemptsSetting] = strconv.Itoa(*f.maxAttempts)
+	settings[retryBackoffSetting] = f.backoff.String()
+	settings[retryMaxBackoffSetting] = f.maxBackoff.String()
+	settings[retryableErrorsSetting] = strings.Join(f.retryableErrors, retryableErrorsSeparator)
+	return nil
+}
+
+// RetryPolicyFromSettings returns the retry policy saved in the settings
+// of a workflow checkpoint by RetryPolicyFlags.SaveSettings. It returns
+// nil if there is none, e.g. for workflows created before retry policies
+// existed.
+func RetryPolicyFromSettings(settings map[string]string) (*RetryPolicy, error) {
+	maxAttempts, ok := settings[retryMaxAttemptsSetting]
+	if !ok {
+		return nil, nil
+	}
+	rp := &RetryPolicy{}
+	var err error
+	if rp.MaxAttempts, err = strconv.Atoi(maxAttempts); err != nil {
+		return nil, fmt.Errorf("invalid %v setting: %v", retryMaxAttemptsSetting, err)
+	}
+	if rp.Backoff, err = time.ParseDuration(settings[retryBackoffSetting]); err != nil {
+		return nil, fmt.Errorf("invalid %v setting: %v", retryBackoffSetting, err)
+	}
+	if rp.MaxBackoff, err = time.ParseDuration(settings[retryMaxBackoffSetting]); err != nil {
+		return nil, fmt.Errorf("invalid %v setting: %v", retryMaxBackoffSetting, err)
+	}
+	if retryableErrors := settings[retryableErrorsSetting]; retryableErrors != "" {
+		for _, expr := range strings.Split(retryableErrors, retryableErrorsSeparator) {
+			re, err := regexp.Compile(expr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %v setting: %v", retryableErrorsSetting, err)
+			}
+			rp.RetryableErrors = append(rp.RetryableErrors, re)
+		}
+	}
+	return rp, nil
+}
+
+// shouldRetry returns true if a task which failed with err after the
+// given number of attempts must be retried automatically.
+func (rp *RetryPolicy) shouldRetry(err error, attempts int) bool {
+	if rp == nil || attempts >= rp.MaxAttempts {
+		return false
+	}
+	if len(rp.RetryableErrors) == 0 {
+		return true
+	}
+	for _, re := range rp.RetryableErrors {
+		if re.MatchString(err.Error()) {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns the delay before the retry of a task which failed
+// after the given number of attempts.
+func (rp *RetryPolicy) backoff(attempts int) time.Duration {
+	backoff := rp.Backoff
+	for i := 1; i < attempts; i++ {
+		backoff *= retryBackoffMultiplication
+		if rp.MaxBackoff > 0 && backoff >= rp.MaxBackoff {
+			break
+		}
+	}
+	if rp.MaxBackoff > 0 && backoff > rp.MaxBackoff {
+		backoff = rp.MaxBackoff
+	}
+	return backoff
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"errors"
+	"flag"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryPolicySettings(t *testing.T) {
+	rp, err := RetryPolicyFromSettings(map[string]string{})
+	require.NoError(t, err)
+	assert.Nil(t, rp)
+	assert.False(t, rp.shouldRetry(errors.New("error"), 1))
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags := NewRetryPolicyFlags(fs)
+	require.NoError(t, fs.Parse([]string{"-retry_max_attempts=3", "-retry_backoff=1s", "-retry_max_backoff=3s", `-retryable_errors=timeout,a\,b`}))
+	settings := make(map[string]string)
+	require.NoError(t, flags.SaveSettings(settings))
+
+	rp, err = RetryPolicyFromSettings(settings)
+	require.NoError(t, err)
+	assert.Equal(t, 3, rp.MaxAttempts)
+	assert.Len(t, rp.RetryableErrors, 2)
+
+	assert.True(t, rp.shouldRetry(errors.New("rpc timeout"), 1))
+	assert.True(t, rp.shouldRetry(errors.New("a,b"), 2))
+	assert.False(t, rp.shouldRetry(errors.New("rpc timeout"), 3))
+	assert.False(t, rp.shouldRetry(errors.New("permission denied"), 1))
+
+	assert.Equal(t, time.Second, rp.backoff(1))
+	assert.Equal(t, 2*time.Second, rp.backoff(2))
+	assert.Equal(t, 3*time.Second, rp.backoff(3))
+	assert.Equal(t, 3*time.Second, rp.backoff(10))
+
+	fs = flag.NewFlagSet("test", flag.ContinueOnError)
+	flags = NewRetryPolicyFlags(fs)
+	require.NoError(t, fs.Parse([]string{"-retryable_errors=("}))
+	assert.Error(t, flags.SaveSettings(settings))
+}
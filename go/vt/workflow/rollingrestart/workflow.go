@@ -0,0 +1,512 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package rollingrestart contains a workflow which restarts all the
+// tablets of a keyspace, shard by shard, e.g. to upgrade them. Each
+// replica is drained, restarted by a hook and waited for until it is
+// healthy again. The master of a shard is restarted last, after a planned
+// reparent to one of its restarted replicas.
+package rollingrestart
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"vitess.io/vitess/go/vt/hook"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vttablet/tmclient"
+	"vitess.io/vitess/go/vt/workflow"
+	"vitess.io/vitess/go/vt/wrangler"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	workflowpb "vitess.io/vitess/go/vt/proto/workflow"
+)
+
+const (
+	codeVersion                                  = 1
+	rollingRestartFactoryName                    = "rolling_restart"
+	phaseRestart              workflow.PhaseType = "restart"
+
+	actionNamePause  = "Pause"
+	actionNameResume = "Resume"
+
+	// pausedSetting is set in the checkpoint while the restart is paused,
+	// s.t. it stays paused if the workflow is restarted.
+	pausedSetting = "paused"
+	// restartedAttribute lists the aliases of the restarted tablets of the
+	// shard of a task, s.t. they aren't restarted again when the task is
+	// retried.
+	restartedAttribute = "restarted"
+)
+
+// Register registers the Factory of the rolling restart workflow in the
+// workflow framework.
+func Register() {
+	workflow.Register(rollingRestartFactoryName, &Factory{})
+}
+
+// Factory is the factory to create a rolling restart workflow.
+type Factory struct{}
+
+// Init is part of the workflow.Factory interface.
+func (*Factory) Init(m *workflow.Manager, w *workflowpb.Workflow, args []string) error {
+	subFlags := flag.NewFlagSet(rollingRestartFactoryName, flag.ContinueOnError)
+	keyspace := subFlags.String("keyspace", "", "The keyspace whose tablets are restarted")
+	restartHook := subFlags.String("restart_hook", "restart_tablet", "The hook which restarts a tablet. It's run on the tablet through the ExecuteHook RPC, and must return before the tablet goes down, e.g. by scheduling the restart")
+	drainGrace := subFlags.Duration("drain_grace", 30*time.Second, "How long to wait after draining a replica for its in-flight queries to complete")
+	maxReplicationLag := subFlags.Duration("max_replication_lag", 30*time.Second, "The maximum replication lag of a healthy replica. 0 means the lag isn't checked")
+	healthCheckTimeout := subFlags.Duration("health_check_timeout", 10*time.Minute, "How long to wait for a restarted tablet to become healthy")
+	healthCheckInterval := subFlags.Duration("health_check_interval", 10*time.Second, "The interval at which the health of a restarted tablet is checked")
+	waitReplicasTimeout := subFlags.Duration("wait_replicas_timeout", 30*time.Second, "The time to wait for the replicas to catch up during the planned reparent of a master")
+	enableApprovals := subFlags.Bool("enable_approvals", false, "Require an explicit approval in the UI before restarting the first shard, and the remaining shards")
+
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if *keyspace == "" {
+		return fmt.Errorf("the keyspace flag must be provided for the rolling restart")
+	}
+	if *restartHook == "" || strings.Contains(*restartHook, "/") {
+		return fmt.Errorf("invalid restart_hook: %q", *restartHook)
+	}
+	if *healthCheckInterval <= 0 {
+		return fmt.Errorf("invalid health_check_interval: %v", *healthCheckInterval)
+	}
+
+	shards, err := m.TopoServer().GetShardNames(context.Background(), *keyspace)
+	if err != nil {
+		return fmt.Errorf("cannot get the shards of keyspace %v: %v", *keyspace, err)
+	}
+	if len(shards) == 0 {
+		return fmt.Errorf("keyspace %v has no shards", *keyspace)
+	}
+	sort.Strings(shards)
+	var taskIDs []string
+	tasks := make(map[string]*workflowpb.Task)
+	for _, shard := range shards {
+		taskID := shardTaskID(shard)
+		taskIDs = append(taskIDs, taskID)
+		tasks[taskID] = &workflowpb.Task{
+			Id:         taskID,
+			State:      workflowpb.TaskState_TaskNotStarted,
+			Attributes: map[string]string{"keyspace": *keyspace, "shard": shard},
+		}
+	}
+
+	w.Name = fmt.Sprintf("Rolling restart of the tablets of keyspace %v.", *keyspace)
+	checkpoint := &workflowpb.WorkflowCheckpoint{
+		CodeVersion: codeVersion,
+		Tasks:       tasks,
+		Settings: map[string]string{
+			"keyspace":              *keyspace,
+			"tasks":                 strings.Join(taskIDs, ","),
+			"restart_hook":          *restartHook,
+			"drain_grace":           drainGrace.String(),
+			"max_replication_lag":   maxReplicationLag.String(),
+			"health_check_timeout":  healthCheckTimeout.String(),
+			"health_check_interval": healthCheckInterval.String(),
+			"wait_replicas_timeout": waitReplicasTimeout.String(),
+			"enable_approvals":      strconv.FormatBool(*enableApprovals),
+		},
+	}
+	w.Data, err = proto.Marshal(checkpoint)
+	return err
+}
+
+// Instantiate is part the workflow.Factory interface.
+func (*Factory) Instantiate(m *workflow.Manager, w *workflowpb.Workflow, rootNode *workflow.Node) (workflow.Workflow, error) {
+	rootNode.Message = "This is a workflow to restart the tablets of a keyspace, shard by shard."
+
+	checkpoint := &workflowpb.WorkflowCheckpoint{}
+	if err := proto.Unmarshal(w.Data, checkpoint); err != nil {
+		return nil, err
+	}
+
+	rw := &rollingRestartWorkflow{
+		checkpoint:      checkpoint,
+		rootUINode:      rootNode,
+		logger:          logutil.NewMemoryLogger(),
+		wr:              wrangler.New(logutil.NewConsoleLogger(), m.TopoServer(), tmclient.NewTabletManagerClient()),
+		topoServer:      m.TopoServer(),
+		restartHook:     checkpoint.Settings["restart_hook"],
+		enableApprovals: checkpoint.Settings["enable_approvals"] == "true",
+	}
+	for setting, d := range map[string]*time.Duration{
+		"drain_grace":           &rw.drainGrace,
+		"max_replication_lag":   &rw.maxReplicationLag,
+		"health_check_timeout":  &rw.healthCheckTimeout,
+		"health_check_interval": &rw.healthCheckInterval,
+		"wait_replicas_timeout": &rw.waitReplicasTimeout,
+	} {
+		var err error
+		if *d, err = time.ParseDuration(checkpoint.Settings[setting]); err != nil {
+			return nil, fmt.Errorf("invalid %v setting: %v", setting, err)
+		}
+	}
+
+	restartUINode := &workflow.Node{
+		Name:     "Restart",
+		PathName: string(phaseRestart),
+	}
+	rw.rootUINode.Children = []*workflow.Node{restartUINode}
+	rw.shardUINodes = make(map[string]*workflow.Node)
+	for _, task := range rw.getTasks() {
+		shardUINode := &workflow.Node{
+			Name:     fmt.Sprintf("Shard %v/%v", task.Attributes["keyspace"], task.Attributes["shard"]),
+			PathName: task.Attributes["shard"],
+		}
+		restartUINode.Children = append(restartUINode.Children, shardUINode)
+		rw.shardUINodes[task.Id] = shardUINode
+	}
+	return rw, nil
+}
+
+// shardTaskID returns the ID of the task of a shard, which is also the
+// path of its UI node.
+func shardTaskID(shard string) string {
+	return fmt.Sprintf("%s/%s", phaseRestart, shard)
+}
+
+// rollingRestartWorkflow contains meta-information and methods to control
+// the rolling restart workflow.
+type rollingRestartWorkflow struct {
+	ctx        context.Context
+	wr         Wrangler
+	topoServer *topo.Server
+	wi         *topo.WorkflowInfo
+	// logger is the logger we export UI logs from.
+	logger *logutil.MemoryLogger
+
+	// rootUINode is the root node representing the workflow in the UI.
+	rootUINode *workflow.Node
+	// shardUINodes has the UI node of each task.
+	shardUINodes map[string]*workflow.Node
+
+	checkpoint       *workflowpb.WorkflowCheckpoint
+	checkpointWriter *workflow.CheckpointWriter
+
+	restartHook         string
+	drainGrace          time.Duration
+	maxReplicationLag   time.Duration
+	healthCheckTimeout  time.Duration
+	healthCheckInterval time.Duration
+	waitReplicasTimeout time.Duration
+	enableApprovals     bool
+
+	// mu protects resumed and the actions of the root node.
+	mu sync.Mutex
+	// resumed is closed when a paused restart is resumed. It's nil if the
+	// restart isn't paused.
+	resumed chan struct{}
+}
+
+// Run executes the rolling restart.
+// It implements the workflow.Workflow interface.
+func (rw *rollingRestartWorkflow) Run(ctx context.Context, manager *workflow.Manager, wi *topo.WorkflowInfo) error {
+	rw.ctx = ctx
+	rw.wi = wi
+	rw.checkpointWriter = workflow.NewCheckpointWriter(rw.topoServer, rw.checkpoint, rw.wi)
+	rw.rootUINode.Display = workflow.NodeDisplayDeterminate
+	rw.rootUINode.BroadcastChanges(true /* updateChildren */)
+
+	rw.mu.Lock()
+	if rw.checkpoint.Settings[pausedSetting] == "true" {
+		rw.resumed = make(chan struct{})
+	}
+	rw.updatePauseActionLocked()
+	rw.mu.Unlock()
+
+	runner := workflow.NewParallelRunner(rw.ctx, rw.rootUINode, rw.checkpointWriter, rw.getTasks(), rw.runShard, workflow.Sequential, rw.enableApprovals)
+	err := runner.Run()
+
+	rw.mu.Lock()
+	rw.rootUINode.Actions = []*workflow.Action{}
+	rw.rootUINode.BroadcastChanges(false /* updateChildren */)
+	rw.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	rw.setUIMessage("Rolling restart is finished successfully.")
+	return nil
+}
+
+// getTasks returns the tasks of the shards, in execution order.
+func (rw *rollingRestartWorkflow) getTasks() []*workflowpb.Task {
+	var tasks []*workflowpb.Task
+	for _, taskID := range strings.Split(rw.checkpoint.Settings["tasks"], ",") {
+		tasks = append(tasks, rw.checkpoint.Tasks[taskID])
+	}
+	return tasks
+}
+
+// runShard restarts the replicas of the shard of the task one by one,
+// then reparents the shard away from its master and restarts it. The
+// tablets restarted by a previous attempt are skipped.
+func (rw *rollingRestartWorkflow) runShard(ctx context.Context, t *workflowpb.Task) error {
+	keyspace := t.Attributes["keyspace"]
+	shard := t.Attributes["shard"]
+	if err := rw.waitWhilePaused(ctx, keyspace, shard); err != nil {
+		return err
+	}
+
+	si, err := rw.topoServer.GetShard(ctx, keyspace, shard)
+	if err != nil {
+		return err
+	}
+	tabletMap, err := rw.topoServer.GetTabletMapForShard(ctx, keyspace, shard)
+	if err != nil {
+		return fmt.Errorf("GetTabletMapForShard(%v, %v) failed: %v", keyspace, shard, err)
+	}
+	restarted := make(map[string]bool)
+	if t.Attributes[restartedAttribute] != "" {
+		for _, alias := range strings.Split(t.Attributes[restartedAttribute], ",") {
+			restarted[alias] = true
+		}
+	}
+
+	var replicas []*topodatapb.Tablet
+	hasServingReplica := false
+	for _, ti := range tabletMap {
+		if topoproto.TabletAliasEqual(ti.Alias, si.MasterAlias) {
+			continue
+		}
+		switch ti.Type {
+		case topodatapb.TabletType_REPLICA:
+			hasServingReplica = true
+		case topodatapb.TabletType_RDONLY:
+		default:
+			rw.setUIMessage(fmt.Sprintf("Skipping tablet %v of type %v.", topoproto.TabletAliasString(ti.Alias), ti.Type))
+			continue
+		}
+		replicas = append(replicas, ti.Tablet)
+	}
+	sort.Slice(replicas, func(i, j int) bool {
+		return topoproto.TabletAliasString(replicas[i].Alias) < topoproto.TabletAliasString(replicas[j].Alias)
+	})
+	total := len(replicas)
+	if si.MasterAlias != nil {
+		total++
+	}
+
+	for _, tablet := range replicas {
+		alias := topoproto.TabletAliasString(tablet.Alias)
+		if restarted[alias] {
+			continue
+		}
+		if err := rw.restartTablet(ctx, t, tablet.Alias, true /* drain */); err != nil {
+			return err
+		}
+		restarted[alias] = true
+		if err := rw.recordRestarted(t, restarted, total); err != nil {
+			return err
+		}
+	}
+
+	if si.MasterAlias == nil || restarted[topoproto.TabletAliasString(si.MasterAlias)] {
+		return nil
+	}
+	masterAlias := si.MasterAlias
+	drain := false
+	if hasServingReplica {
+		rw.setUIMessage(fmt.Sprintf("Reparenting %v/%v away from master %v.", keyspace, shard, topoproto.TabletAliasString(masterAlias)))
+		if err := rw.wr.PlannedReparentShard(ctx, keyspace, shard, nil /* masterElectTabletAlias */, masterAlias, rw.waitReplicasTimeout); err != nil {
+			return fmt.Errorf("cannot reparent %v/%v away from master %v: %v", keyspace, shard, topoproto.TabletAliasString(masterAlias), err)
+		}
+		// The old master is a replica now.
+		drain = true
+	} else {
+		rw.setUIMessage(fmt.Sprintf("Shard %v/%v has no replica to reparent to, its master %v is restarted in place.", keyspace, shard, topoproto.TabletAliasString(masterAlias)))
+	}
+	if err := rw.restartTablet(ctx, t, masterAlias, drain); err != nil {
+		return err
+	}
+	restarted[topoproto.TabletAliasString(masterAlias)] = true
+	return rw.recordRestarted(t, restarted, total)
+}
+
+// restartTablet drains the tablet if asked to, restarts it with the hook,
+// waits until it is healthy and undrains it. A tablet which fails to
+// restart is left drained.
+func (rw *rollingRestartWorkflow) restartTablet(ctx context.Context, t *workflowpb.Task, tabletAlias *topodatapb.TabletAlias, drain bool) error {
+	alias := topoproto.TabletAliasString(tabletAlias)
+	if drain {
+		rw.setTaskUIMessage(t, fmt.Sprintf("Draining tablet %v.", alias))
+		if err := rw.wr.SetTabletDrained(ctx, tabletAlias, true); err != nil {
+			return fmt.Errorf("cannot drain tablet %v: %v", alias, err)
+		}
+		if err := sleep(ctx, rw.drainGrace); err != nil {
+			return err
+		}
+	}
+
+	rw.setTaskUIMessage(t, fmt.Sprintf("Restarting tablet %v.", alias))
+	hr, err := rw.wr.ExecuteHook(ctx, tabletAlias, hook.NewSimpleHook(rw.restartHook))
+	if err != nil {
+		return fmt.Errorf("cannot run hook %v on tablet %v: %v", rw.restartHook, alias, err)
+	}
+	if hr.ExitStatus != hook.HOOK_SUCCESS {
+		return fmt.Errorf("hook %v failed on tablet %v: %v", rw.restartHook, alias, hr.String())
+	}
+
+	rw.setTaskUIMessage(t, fmt.Sprintf("Waiting for tablet %v to become healthy.", alias))
+	if err := rw.waitForHealthy(ctx, tabletAlias); err != nil {
+		return err
+	}
+	if drain {
+		if err := rw.wr.SetTabletDrained(ctx, tabletAlias, false); err != nil {
+			return fmt.Errorf("cannot undrain tablet %v: %v", alias, err)
+		}
+	}
+	return nil
+}
+
+// waitForHealthy waits for the restarted tablet to report a healthy
+// record. The first check happens after one interval, to give the tablet
+// time to go down.
+func (rw *rollingRestartWorkflow) waitForHealthy(ctx context.Context, tabletAlias *topodatapb.TabletAlias) error {
+	deadline := time.Now().Add(rw.healthCheckTimeout)
+	for {
+		if err := sleep(ctx, rw.healthCheckInterval); err != nil {
+			return err
+		}
+		err := rw.wr.CheckTabletHealth(ctx, tabletAlias, rw.maxReplicationLag)
+		if err == nil {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("tablet %v did not become healthy within %v after the restart: %v", topoproto.TabletAliasString(tabletAlias), rw.healthCheckTimeout, err)
+		}
+	}
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(d):
+		return nil
+	}
+}
+
+// recordRestarted saves the restarted tablets of the shard of the task in
+// the checkpoint, and shows the progress of the shard.
+func (rw *rollingRestartWorkflow) recordRestarted(t *workflowpb.Task, restarted map[string]bool, total int) error {
+	var aliases []string
+	for alias := range restarted {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+	if err := rw.checkpointWriter.UpdateTaskAttributes(t.Id, map[string]string{restartedAttribute: strings.Join(aliases, ",")}); err != nil {
+		return err
+	}
+
+	node := rw.shardUINodes[t.Id]
+	node.Display = workflow.NodeDisplayDeterminate
+	node.Progress = len(aliases) * 100 / total
+	node.ProgressMessage = fmt.Sprintf("%v/%v tablets restarted", len(aliases), total)
+	node.BroadcastChanges(false /* updateChildren */)
+	return nil
+}
+
+// waitWhilePaused blocks while the restart is paused.
+func (rw *rollingRestartWorkflow) waitWhilePaused(ctx context.Context, keyspace, shard string) error {
+	rw.mu.Lock()
+	resumed := rw.resumed
+	rw.mu.Unlock()
+	if resumed == nil {
+		return nil
+	}
+
+	rw.setUIMessage(fmt.Sprintf("Rolling restart is paused before %v/%v.", keyspace, shard))
+	select {
+	case <-resumed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Action handles the pause and resume actions of the root node. It
+// implements the workflow.ActionListener interface. The shard being
+// restarted when the workflow is paused is not interrupted.
+func (rw *rollingRestartWorkflow) Action(ctx context.Context, path, name string) error {
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+
+	paused := ""
+	switch name {
+	case actionNamePause:
+		if rw.resumed != nil {
+			return fmt.Errorf("the rolling restart is already paused")
+		}
+		rw.resumed = make(chan struct{})
+		paused = "true"
+	case actionNameResume:
+		if rw.resumed == nil {
+			return fmt.Errorf("the rolling restart is not paused")
+		}
+		close(rw.resumed)
+		rw.resumed = nil
+	default:
+		return fmt.Errorf("unknown action: %v", name)
+	}
+	rw.updatePauseActionLocked()
+	return rw.checkpointWriter.UpdateSettings(map[string]string{pausedSetting: paused})
+}
+
+func (rw *rollingRestartWorkflow) updatePauseActionLocked() {
+	action := &workflow.Action{
+		Name:  actionNamePause,
+		State: workflow.ActionStateEnabled,
+		Style: workflow.ActionStyleNormal,
+	}
+	if rw.resumed != nil {
+		action.Name = actionNameResume
+		action.Style = workflow.ActionStyleWaiting
+	}
+	rw.rootUINode.Actions = []*workflow.Action{action}
+	rw.rootUINode.Listener = rw
+	rw.rootUINode.BroadcastChanges(false /* updateChildren */)
+}
+
+func (rw *rollingRestartWorkflow) setTaskUIMessage(t *workflowpb.Task, message string) {
+	log.Infof("Rolling restart: %v", message)
+	node := rw.shardUINodes[t.Id]
+	node.Message = message
+	node.BroadcastChanges(false /* updateChildren */)
+}
+
+func (rw *rollingRestartWorkflow) setUIMessage(message string) {
+	log.Infof("Rolling restart: %v", message)
+	rw.logger.Infof(message)
+	rw.rootUINode.Log = rw.logger.String()
+	rw.rootUINode.Message = message
+	rw.rootUINode.BroadcastChanges(false /* updateChildren */)
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rollingrestart
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/hook"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/workflow"
+
+	// import the gRPC client implementation for tablet manager
+	_ "vitess.io/vitess/go/vt/vttablet/grpctmclient"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func init() {
+	Register()
+}
+
+// fakeWrangler records the calls of the workflow. Its planned reparent
+// promotes the first replica of the shard in the topo server.
+type fakeWrangler struct {
+	ts *topo.Server
+
+	mu    sync.Mutex
+	calls []string
+}
+
+func (f *fakeWrangler) record(format string, args ...interface{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, fmt.Sprintf(format, args...))
+}
+
+func (f *fakeWrangler) SetTabletDrained(ctx context.Context, tabletAlias *topodatapb.TabletAlias, drained bool) error {
+	f.record("drained %v: %v", topoproto.TabletAliasString(tabletAlias), drained)
+	return nil
+}
+
+func (f *fakeWrangler) ExecuteHook(ctx context.Context, tabletAlias *topodatapb.TabletAlias, h *hook.Hook) (*hook.HookResult, error) {
+	f.record("hook %v: %v", topoproto.TabletAliasString(tabletAlias), h.Name)
+	return &hook.HookResult{ExitStatus: hook.HOOK_SUCCESS}, nil
+}
+
+func (f *fakeWrangler) CheckTabletHealth(ctx context.Context, tabletAlias *topodatapb.TabletAlias, maxReplicationLag time.Duration) error {
+	return nil
+}
+
+func (f *fakeWrangler) PlannedReparentShard(ctx context.Context, keyspace, shard string, masterElectTabletAlias, avoidMasterAlias *topodatapb.TabletAlias, waitReplicasTimeout time.Duration) error {
+	f.record("reparent %v/%v away from %v", keyspace, shard, topoproto.TabletAliasString(avoidMasterAlias))
+	tabletMap, err := f.ts.GetTabletMapForShard(ctx, keyspace, shard)
+	if err != nil {
+		return err
+	}
+	for _, ti := range tabletMap {
+		if ti.Type != topodatapb.TabletType_REPLICA {
+			continue
+		}
+		if _, err := f.ts.UpdateShardFields(ctx, keyspace, shard, func(si *topo.ShardInfo) error {
+			si.MasterAlias = ti.Alias
+			return nil
+		}); err != nil {
+			return err
+		}
+		for alias, tabletType := range map[*topodatapb.TabletAlias]topodatapb.TabletType{
+			ti.Alias:         topodatapb.TabletType_MASTER,
+			avoidMasterAlias: topodatapb.TabletType_REPLICA,
+		} {
+			if _, err := f.ts.UpdateTabletFields(ctx, alias, func(tablet *topodatapb.Tablet) error {
+				tablet.Type = tabletType
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("no replica to reparent to")
+}
+
+func addTablet(ctx context.Context, t *testing.T, ts *topo.Server, uid uint32, shard string, tabletType topodatapb.TabletType) {
+	alias := &topodatapb.TabletAlias{Cell: "cell", Uid: uid}
+	require.NoError(t, ts.CreateTablet(ctx, &topodatapb.Tablet{
+		Alias:    alias,
+		Keyspace: "ks",
+		Shard:    shard,
+		Type:     tabletType,
+	}))
+	if tabletType == topodatapb.TabletType_MASTER {
+		_, err := ts.UpdateShardFields(ctx, "ks", shard, func(si *topo.ShardInfo) error {
+			si.MasterAlias = alias
+			return nil
+		})
+		require.NoError(t, err)
+	}
+}
+
+func setupTopology(ctx context.Context, t *testing.T) *topo.Server {
+	ts := memorytopo.NewServer("cell")
+	require.NoError(t, ts.CreateKeyspace(ctx, "ks", &topodatapb.Keyspace{}))
+	require.NoError(t, ts.CreateShard(ctx, "ks", "-80"))
+	require.NoError(t, ts.CreateShard(ctx, "ks", "80-"))
+	addTablet(ctx, t, ts, 100, "-80", topodatapb.TabletType_MASTER)
+	addTablet(ctx, t, ts, 101, "-80", topodatapb.TabletType_REPLICA)
+	addTablet(ctx, t, ts, 102, "-80", topodatapb.TabletType_RDONLY)
+	addTablet(ctx, t, ts, 103, "-80", topodatapb.TabletType_BACKUP)
+	addTablet(ctx, t, ts, 200, "80-", topodatapb.TabletType_MASTER)
+	return ts
+}
+
+func TestRollingRestartInit(t *testing.T) {
+	ctx := context.Background()
+	ts := setupTopology(ctx, t)
+	m := workflow.NewManager(ts)
+
+	for _, args := range [][]string{
+		{},
+		{"-keyspace=ks2"},
+		{"-keyspace=ks", "-restart_hook=bin/restart"},
+		{"-keyspace=ks", "-health_check_interval=0s"},
+	} {
+		_, err := m.Create(ctx, rollingRestartFactoryName, args)
+		assert.Error(t, err, "%v", args)
+	}
+}
+
+func TestRollingRestart(t *testing.T) {
+	ctx := context.Background()
+	ts := setupTopology(ctx, t)
+	wr := &fakeWrangler{ts: ts}
+
+	m := workflow.NewManager(ts)
+	wg, _, cancel := workflow.StartManager(m)
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+	uuid, err := m.Create(ctx, rollingRestartFactoryName, []string{
+		"-keyspace=ks",
+		"-restart_hook=upgrade",
+		"-drain_grace=0s",
+		"-health_check_interval=1ms",
+	})
+	require.NoError(t, err)
+	w, err := m.WorkflowForTesting(uuid)
+	require.NoError(t, err)
+	w.(*rollingRestartWorkflow).wr = wr
+	require.NoError(t, m.Start(ctx, uuid))
+	require.NoError(t, m.Wait(ctx, uuid))
+
+	wi, err := ts.GetWorkflow(ctx, uuid)
+	require.NoError(t, err)
+	assert.Empty(t, wi.Error)
+	require.NoError(t, workflow.VerifyAllTasksDone(ctx, ts, uuid))
+	// The replicas are restarted first, then the master after a reparent.
+	// The backup tablet is skipped, and the master of 80- is restarted in
+	// place as it has no replica.
+	assert.Equal(t, []string{
+		"drained cell-0000000101: true",
+		"hook cell-0000000101: upgrade",
+		"drained cell-0000000101: false",
+		"drained cell-0000000102: true",
+		"hook cell-0000000102: upgrade",
+		"drained cell-0000000102: false",
+		"reparent ks/-80 away from cell-0000000100",
+		"drained cell-0000000100: true",
+		"hook cell-0000000100: upgrade",
+		"drained cell-0000000100: false",
+		"hook cell-0000000200: upgrade",
+	}, wr.calls)
+
+	si, err := ts.GetShard(ctx, "ks", "-80")
+	require.NoError(t, err)
+	assert.Equal(t, "cell-0000000101", topoproto.TabletAliasString(si.MasterAlias))
+}
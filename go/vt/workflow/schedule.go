@@ -0,0 +1,228 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/topo"
+
+	workflowpb "vitess.io/vitess/go/vt/proto/workflow"
+)
+
+// This file implements the schedules of the workflows. A schedule creates
+// and starts a workflow whenever its cron expression matches, while the
+// Manager is running. A run is skipped if the workflow of the previous run
+// of the schedule is still running. The runs missed while no Manager was
+// running are coalesced into a single run.
+
+// scheduleCheckInterval is how often the running Manager checks if a run
+// of a schedule is due.
+var scheduleCheckInterval = 30 * time.Second
+
+// WorkflowScheduleStatus is a workflow schedule, with its next run.
+type WorkflowScheduleStatus struct {
+	*workflowpb.WorkflowSchedule
+	// NextRunTime is when the next run is due. It's zero if the schedule
+	// is disabled.
+	NextRunTime time.Time `json:"next_run_time"`
+	// Running is true if the workflow of the last run is running.
+	Running bool `json:"running"`
+}
+
+// CreateSchedule creates a schedule which creates and starts a workflow
+// from the given factory name with the provided args, whenever the cron
+// expression matches.
+func (m *Manager) CreateSchedule(ctx context.Context, name, cron, factoryName string, args []string, enabled bool) error {
+	if name == "" || strings.Contains(name, "/") {
+		return fmt.Errorf("invalid schedule name: %q", name)
+	}
+	if _, err := parseCron(cron); err != nil {
+		return err
+	}
+	if _, ok := factories[factoryName]; !ok {
+		return fmt.Errorf("no factory named %v is registered", factoryName)
+	}
+	schedule := &workflowpb.WorkflowSchedule{
+		Name:        name,
+		Cron:        cron,
+		FactoryName: factoryName,
+		Args:        args,
+		Enabled:     enabled,
+		CreateTime:  time.Now().UnixNano(),
+	}
+	if err := m.ts.CreateWorkflowSchedule(ctx, schedule); err != nil {
+		if topo.IsErrType(err, topo.NodeExists) {
+			return fmt.Errorf("schedule %v already exists", name)
+		}
+		return err
+	}
+	log.Infof("Created workflow schedule %v (%v, %v %v)", name, cron, factoryName, args)
+	return nil
+}
+
+// SetScheduleEnabled enables or disables the schedule. A schedule which
+// is enabled again doesn't run for the runs it missed while disabled.
+func (m *Manager) SetScheduleEnabled(ctx context.Context, name string, enabled bool) error {
+	_, err := m.ts.UpdateWorkflowScheduleFields(ctx, name, func(schedule *workflowpb.WorkflowSchedule) error {
+		if schedule.Enabled == enabled {
+			return topo.NewError(topo.NoUpdateNeeded, name)
+		}
+		schedule.Enabled = enabled
+		if enabled {
+			schedule.LastScheduledTime = time.Now().UnixNano()
+		}
+		return nil
+	})
+	return err
+}
+
+// DeleteSchedule deletes the schedule. The workflows it created are kept.
+func (m *Manager) DeleteSchedule(ctx context.Context, name string) error {
+	return m.ts.DeleteWorkflowSchedule(ctx, name)
+}
+
+// Schedules returns the status of all the schedules, sorted by name.
+func (m *Manager) Schedules(ctx context.Context) ([]*WorkflowScheduleStatus, error) {
+	names, err := m.ts.GetWorkflowScheduleNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]*WorkflowScheduleStatus, 0, len(names))
+	for _, name := range names {
+		schedule, err := m.ts.GetWorkflowSchedule(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		status := &WorkflowScheduleStatus{
+			WorkflowSchedule: schedule,
+			Running:          m.isWorkflowRunning(schedule.LastRunUuid),
+		}
+		if schedule.Enabled {
+			if status.NextRunTime, err = nextScheduledRun(schedule); err != nil {
+				return nil, err
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// nextScheduledRun returns when the next run of the schedule is due.
+func nextScheduledRun(schedule *workflowpb.WorkflowSchedule) (time.Time, error) {
+	cron, err := parseCron(schedule.Cron)
+	if err != nil {
+		return time.Time{}, err
+	}
+	last := schedule.LastScheduledTime
+	if last == 0 {
+		last = schedule.CreateTime
+	}
+	return cron.next(time.Unix(0, last)), nil
+}
+
+func (m *Manager) isWorkflowRunning(uuid string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	rw, ok := m.workflows[uuid]
+	return ok && rw.wi.State == workflowpb.WorkflowState_Running
+}
+
+// runSchedules starts the due runs of the schedules every
+// scheduleCheckInterval, until ctx is done.
+func (m *Manager) runSchedules(ctx context.Context) {
+	ticker := time.NewTicker(scheduleCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		m.checkSchedules(ctx, time.Now())
+	}
+}
+
+func (m *Manager) checkSchedules(ctx context.Context, now time.Time) {
+	names, err := m.ts.GetWorkflowScheduleNames(ctx)
+	if err != nil {
+		log.Warningf("Cannot get the workflow schedules: %v", err)
+		return
+	}
+	for _, name := range names {
+		if err := m.checkSchedule(ctx, name, now); err != nil {
+			log.Warningf("Cannot check workflow schedule %v: %v", name, err)
+		}
+	}
+}
+
+// checkSchedule starts a run of the schedule if it is due, unless the
+// previous run is still running.
+func (m *Manager) checkSchedule(ctx context.Context, name string, now time.Time) error {
+	schedule, err := m.ts.GetWorkflowSchedule(ctx, name)
+	if err != nil {
+		return err
+	}
+	if !schedule.Enabled {
+		return nil
+	}
+	next, err := nextScheduledRun(schedule)
+	if err != nil {
+		return err
+	}
+	if next.IsZero() || next.After(now) {
+		return nil
+	}
+
+	if m.isWorkflowRunning(schedule.LastRunUuid) {
+		log.Infof("Skipping the run of workflow schedule %v due at %v, its previous run %v is still running", name, next, schedule.LastRunUuid)
+		_, err := m.ts.UpdateWorkflowScheduleFields(ctx, name, func(schedule *workflowpb.WorkflowSchedule) error {
+			schedule.LastScheduledTime = now.UnixNano()
+			schedule.SkippedRuns++
+			return nil
+		})
+		return err
+	}
+
+	runCtx := NewAuditContext(ctx, "schedule "+name, "")
+	uuid, runErr := m.Create(runCtx, schedule.FactoryName, schedule.Args)
+	if runErr == nil {
+		runErr = m.Start(runCtx, uuid)
+	}
+	if runErr != nil {
+		log.Warningf("Cannot run workflow schedule %v: %v", name, runErr)
+	} else {
+		log.Infof("Started workflow %v for schedule %v", uuid, name)
+	}
+	_, err = m.ts.UpdateWorkflowScheduleFields(ctx, name, func(schedule *workflowpb.WorkflowSchedule) error {
+		schedule.LastScheduledTime = now.UnixNano()
+		schedule.LastError = ""
+		if runErr != nil {
+			schedule.LastError = runErr.Error()
+		}
+		if uuid != "" {
+			schedule.LastRunUuid = uuid
+			schedule.LastRunTime = now.UnixNano()
+		}
+		return nil
+	})
+	return err
+}
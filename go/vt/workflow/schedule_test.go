@@ -0,0 +1,93 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+)
+
+func TestSchedules(t *testing.T) {
+	ctx := context.Background()
+	ts := memorytopo.NewServer("cell1")
+	m := NewManager(ts)
+	wg, _, cancel := StartManager(m)
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	assert.Error(t, m.CreateSchedule(ctx, "bad", "* * *", sleepFactoryName, nil, true))
+	assert.Error(t, m.CreateSchedule(ctx, "bad", "* * * * *", "unknown", nil, true))
+	require.NoError(t, m.CreateSchedule(ctx, "sleep", "* * * * *", sleepFactoryName, []string{"-duration", "60"}, true))
+	assert.Error(t, m.CreateSchedule(ctx, "sleep", "@daily", sleepFactoryName, nil, true))
+
+	getStatus := func() *WorkflowScheduleStatus {
+		t.Helper()
+		statuses, err := m.Schedules(ctx)
+		require.NoError(t, err)
+		require.Len(t, statuses, 1)
+		return statuses[0]
+	}
+	status := getStatus()
+	assert.Empty(t, status.LastRunUuid)
+	assert.False(t, status.NextRunTime.IsZero())
+
+	// The first run is started once due.
+	now := time.Now().Add(2 * time.Minute)
+	m.checkSchedules(ctx, now)
+	status = getStatus()
+	firstRun := status.LastRunUuid
+	assert.NotEmpty(t, firstRun)
+	assert.True(t, status.Running)
+	assert.True(t, status.NextRunTime.After(now))
+
+	// The next run is skipped while the first one is running.
+	now = now.Add(2 * time.Minute)
+	m.checkSchedules(ctx, now)
+	status = getStatus()
+	assert.Equal(t, firstRun, status.LastRunUuid)
+	assert.EqualValues(t, 1, status.SkippedRuns)
+
+	// Once the first run is done, the next one starts.
+	require.NoError(t, m.Stop(ctx, firstRun))
+	now = now.Add(2 * time.Minute)
+	m.checkSchedules(ctx, now)
+	status = getStatus()
+	secondRun := status.LastRunUuid
+	assert.NotEqual(t, firstRun, secondRun)
+	require.NoError(t, m.Stop(ctx, secondRun))
+
+	// A disabled schedule doesn't run.
+	require.NoError(t, m.SetScheduleEnabled(ctx, "sleep", false))
+	now = now.Add(2 * time.Minute)
+	m.checkSchedules(ctx, now)
+	status = getStatus()
+	assert.Equal(t, secondRun, status.LastRunUuid)
+	assert.True(t, status.NextRunTime.IsZero())
+
+	require.NoError(t, m.DeleteSchedule(ctx, "sleep"))
+	statuses, err := m.Schedules(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, statuses)
+}
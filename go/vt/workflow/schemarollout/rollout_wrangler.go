@@ -0,0 +1,30 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schemarollout
+
+import (
+	"context"
+	"time"
+)
+
+// Wrangler is the subset of the methods of go/vt/wrangler.Wrangler used by
+// the schema rollout workflow, so that unit tests can fake it.
+type Wrangler interface {
+	ApplySchemaShard(ctx context.Context, keyspace, shard string, changes []string) error
+
+	CheckShardHealth(ctx context.Context, keyspace, shard string, maxReplicationLag time.Duration) error
+}
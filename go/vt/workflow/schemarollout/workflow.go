@@ -0,0 +1,546 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package schemarollout contains a workflow which applies a schema change
+// to several keyspaces, shard by shard. The change is first applied to a
+// canary shard, whose health is watched for a while before the change is
+// rolled out to the other shards. The health of each shard is verified
+// after the change, and the change is rolled back on all the changed
+// shards if a shard fails.
+package schemarollout
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vttablet/tmclient"
+	"vitess.io/vitess/go/vt/workflow"
+	"vitess.io/vitess/go/vt/wrangler"
+
+	workflowpb "vitess.io/vitess/go/vt/proto/workflow"
+)
+
+const (
+	codeVersion                                 = 1
+	schemaRolloutFactoryName                    = "schema_rollout"
+	phaseCanary              workflow.PhaseType = "canary"
+	phaseRollout             workflow.PhaseType = "rollout"
+
+	actionNamePause  = "Pause"
+	actionNameResume = "Resume"
+
+	// pausedSetting is set in the checkpoint while the rollout is paused,
+	// s.t. it stays paused if the workflow is restarted.
+	pausedSetting = "paused"
+	// failureSetting records the failure of the rollout and the result of
+	// its rollback, s.t. the workflow isn't resumed once rolled back.
+	failureSetting = "failure"
+)
+
+// Register registers the Factory of the schema rollout workflow in the
+// workflow framework.
+func Register() {
+	workflow.Register(schemaRolloutFactoryName, &Factory{})
+}
+
+// Factory is the factory to create a schema rollout workflow.
+type Factory struct{}
+
+// Init is part of the workflow.Factory interface.
+func (*Factory) Init(m *workflow.Manager, w *workflowpb.Workflow, args []string) error {
+	subFlags := flag.NewFlagSet(schemaRolloutFactoryName, flag.ContinueOnError)
+	keyspacesStr := subFlags.String("keyspaces", "", "A comma-separated list of the keyspaces to apply the schema change to, in order")
+	sql := subFlags.String("sql", "", "A list of semicolon-delimited DDL statements")
+	rollbackSQL := subFlags.String("rollback_sql", "", "A list of semicolon-delimited DDL statements which revert the schema change. If empty, the changed shards are left as is when the rollout fails")
+	canaryShard := subFlags.String("canary_shard", "", "The keyspace/shard to apply the schema change to first. Defaults to the first shard of the first keyspace")
+	canarySoak := subFlags.Duration("canary_soak", time.Minute, "How long the canary shard must stay healthy before the change is rolled out to the other shards")
+	maxReplicationLag := subFlags.Duration("max_replication_lag", 30*time.Second, "The maximum replication lag of a healthy replica. 0 means the lag isn't checked")
+	healthCheckTimeout := subFlags.Duration("health_check_timeout", 5*time.Minute, "How long to wait for a changed shard to become healthy")
+	healthCheckInterval := subFlags.Duration("health_check_interval", 10*time.Second, "The interval at which the health of a changed shard is checked")
+	enableApprovals := subFlags.Bool("enable_approvals", false, "Require an explicit approval in the UI before rolling out the change to the first shard after the canary, and to the remaining shards")
+
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if *keyspacesStr == "" || *sql == "" {
+		return fmt.Errorf("the keyspaces and sql flags must be provided for the schema rollout")
+	}
+	statements, err := splitStatements(*sql)
+	if err != nil {
+		return fmt.Errorf("invalid sql: %v", err)
+	}
+	if len(statements) == 0 {
+		return fmt.Errorf("no statements in sql")
+	}
+	if _, err := splitStatements(*rollbackSQL); err != nil {
+		return fmt.Errorf("invalid rollback_sql: %v", err)
+	}
+	if *healthCheckInterval <= 0 {
+		return fmt.Errorf("invalid health_check_interval: %v", *healthCheckInterval)
+	}
+
+	ctx := context.Background()
+	var taskIDs []string
+	tasks := make(map[string]*workflowpb.Task)
+	for _, keyspace := range strings.Split(*keyspacesStr, ",") {
+		shards, err := m.TopoServer().GetShardNames(ctx, keyspace)
+		if err != nil {
+			return fmt.Errorf("cannot get the shards of keyspace %v: %v", keyspace, err)
+		}
+		if len(shards) == 0 {
+			return fmt.Errorf("keyspace %v has no shards", keyspace)
+		}
+		sort.Strings(shards)
+		for _, shard := range shards {
+			taskID := shardTaskID(phaseRollout, keyspace, shard)
+			if tasks[taskID] != nil {
+				return fmt.Errorf("keyspace %v is listed twice", keyspace)
+			}
+			taskIDs = append(taskIDs, taskID)
+			tasks[taskID] = &workflowpb.Task{
+				Id:         taskID,
+				State:      workflowpb.TaskState_TaskNotStarted,
+				Attributes: map[string]string{"keyspace": keyspace, "shard": shard},
+			}
+		}
+	}
+
+	// The canary task replaces the rollout task of its shard.
+	canaryIndex := 0
+	if *canaryShard != "" {
+		keyspace, shard, err := topoproto.ParseKeyspaceShard(*canaryShard)
+		if err != nil {
+			return err
+		}
+		canaryIndex = -1
+		for i, taskID := range taskIDs {
+			if taskID == shardTaskID(phaseRollout, keyspace, shard) {
+				canaryIndex = i
+			}
+		}
+		if canaryIndex < 0 {
+			return fmt.Errorf("the canary shard %v is not a shard of the keyspaces", *canaryShard)
+		}
+	}
+	canaryTask := tasks[taskIDs[canaryIndex]]
+	delete(tasks, canaryTask.Id)
+	taskIDs = append(taskIDs[:canaryIndex], taskIDs[canaryIndex+1:]...)
+	canaryTask.Id = shardTaskID(phaseCanary, canaryTask.Attributes["keyspace"], canaryTask.Attributes["shard"])
+	tasks[canaryTask.Id] = canaryTask
+
+	w.Name = fmt.Sprintf("Roll out a schema change to keyspaces %v.", *keyspacesStr)
+	checkpoint := &workflowpb.WorkflowCheckpoint{
+		CodeVersion: codeVersion,
+		Tasks:       tasks,
+		Settings: map[string]string{
+			"keyspaces":             *keyspacesStr,
+			"sql":                   *sql,
+			"rollback_sql":          *rollbackSQL,
+			"canary_task":           canaryTask.Id,
+			"rollout_tasks":         strings.Join(taskIDs, ","),
+			"canary_soak":           canarySoak.String(),
+			"max_replication_lag":   maxReplicationLag.String(),
+			"health_check_timeout":  healthCheckTimeout.String(),
+			"health_check_interval": healthCheckInterval.String(),
+			"enable_approvals":      strconv.FormatBool(*enableApprovals),
+		},
+	}
+	w.Data, err = proto.Marshal(checkpoint)
+	return err
+}
+
+// Instantiate is part the workflow.Factory interface.
+func (*Factory) Instantiate(m *workflow.Manager, w *workflowpb.Workflow, rootNode *workflow.Node) (workflow.Workflow, error) {
+	rootNode.Message = "This is a workflow to roll out a schema change across keyspaces, shard by shard."
+
+	checkpoint := &workflowpb.WorkflowCheckpoint{}
+	if err := proto.Unmarshal(w.Data, checkpoint); err != nil {
+		return nil, err
+	}
+
+	sw := &schemaRolloutWorkflow{
+		checkpoint:      checkpoint,
+		rootUINode:      rootNode,
+		logger:          logutil.NewMemoryLogger(),
+		wr:              wrangler.New(logutil.NewConsoleLogger(), m.TopoServer(), tmclient.NewTabletManagerClient()),
+		topoServer:      m.TopoServer(),
+		enableApprovals: checkpoint.Settings["enable_approvals"] == "true",
+	}
+	var err error
+	if sw.statements, err = splitStatements(checkpoint.Settings["sql"]); err != nil {
+		return nil, err
+	}
+	if sw.rollbackStatements, err = splitStatements(checkpoint.Settings["rollback_sql"]); err != nil {
+		return nil, err
+	}
+	for setting, d := range map[string]*time.Duration{
+		"canary_soak":           &sw.canarySoak,
+		"max_replication_lag":   &sw.maxReplicationLag,
+		"health_check_timeout":  &sw.healthCheckTimeout,
+		"health_check_interval": &sw.healthCheckInterval,
+	} {
+		if *d, err = time.ParseDuration(checkpoint.Settings[setting]); err != nil {
+			return nil, fmt.Errorf("invalid %v setting: %v", setting, err)
+		}
+	}
+
+	canaryUINode := &workflow.Node{
+		Name:     "Canary",
+		PathName: string(phaseCanary),
+	}
+	rolloutUINode := &workflow.Node{
+		Name:     "Rollout",
+		PathName: string(phaseRollout),
+	}
+	sw.rootUINode.Children = []*workflow.Node{canaryUINode, rolloutUINode}
+	for _, task := range sw.allTasks() {
+		phaseUINode := canaryUINode
+		if task.Id != checkpoint.Settings["canary_task"] {
+			phaseUINode = rolloutUINode
+		}
+		phaseUINode.Children = append(phaseUINode.Children, &workflow.Node{
+			Name:     fmt.Sprintf("Shard %v/%v", task.Attributes["keyspace"], task.Attributes["shard"]),
+			PathName: task.Id[strings.Index(task.Id, "/")+1:],
+		})
+	}
+	return sw, nil
+}
+
+// shardTaskID returns the ID of the task of a shard. The keyspace and the
+// shard are joined with a dot, as the task ID is also the path of its UI
+// node.
+func shardTaskID(phase workflow.PhaseType, keyspace, shard string) string {
+	return fmt.Sprintf("%s/%s.%s", phase, keyspace, shard)
+}
+
+// splitStatements returns the non-empty statements of sql.
+func splitStatements(sql string) ([]string, error) {
+	pieces, err := sqlparser.SplitStatementToPieces(sql)
+	if err != nil {
+		return nil, err
+	}
+	var statements []string
+	for _, piece := range pieces {
+		if piece = strings.TrimSpace(piece); piece != "" {
+			statements = append(statements, piece)
+		}
+	}
+	return statements, nil
+}
+
+// schemaRolloutWorkflow contains meta-information and methods to control
+// the schema rollout workflow.
+type schemaRolloutWorkflow struct {
+	ctx        context.Context
+	cancel     context.CancelFunc
+	wr         Wrangler
+	topoServer *topo.Server
+	wi         *topo.WorkflowInfo
+	// logger is the logger we export UI logs from.
+	logger *logutil.MemoryLogger
+
+	// rootUINode is the root node representing the workflow in the UI.
+	rootUINode *workflow.Node
+
+	checkpoint       *workflowpb.WorkflowCheckpoint
+	checkpointWriter *workflow.CheckpointWriter
+
+	statements          []string
+	rollbackStatements  []string
+	canarySoak          time.Duration
+	maxReplicationLag   time.Duration
+	healthCheckTimeout  time.Duration
+	healthCheckInterval time.Duration
+	enableApprovals     bool
+
+	// mu protects resumed, failure and the actions of the root node.
+	mu sync.Mutex
+	// resumed is closed when a paused rollout is resumed. It's nil if the
+	// rollout isn't paused.
+	resumed chan struct{}
+	// failure is set once the rollout failed and was rolled back.
+	failure error
+}
+
+// Run executes the schema rollout.
+// It implements the workflow.Workflow interface.
+func (sw *schemaRolloutWorkflow) Run(ctx context.Context, manager *workflow.Manager, wi *topo.WorkflowInfo) error {
+	sw.ctx, sw.cancel = context.WithCancel(ctx)
+	defer sw.cancel()
+	sw.wi = wi
+	sw.checkpointWriter = workflow.NewCheckpointWriter(sw.topoServer, sw.checkpoint, sw.wi)
+	sw.rootUINode.Display = workflow.NodeDisplayDeterminate
+	sw.rootUINode.BroadcastChanges(true /* updateChildren */)
+
+	if failure := sw.checkpoint.Settings[failureSetting]; failure != "" {
+		return errors.New(failure)
+	}
+	sw.mu.Lock()
+	if sw.checkpoint.Settings[pausedSetting] == "true" {
+		sw.resumed = make(chan struct{})
+	}
+	sw.updatePauseActionLocked()
+	sw.mu.Unlock()
+
+	err := sw.runWorkflow()
+
+	sw.mu.Lock()
+	sw.rootUINode.Actions = []*workflow.Action{}
+	sw.rootUINode.BroadcastChanges(false /* updateChildren */)
+	failure := sw.failure
+	sw.mu.Unlock()
+	switch {
+	case failure != nil:
+		sw.setUIMessage(failure.Error())
+		return failure
+	case err != nil:
+		return err
+	case ctx.Err() != nil:
+		return ctx.Err()
+	}
+	sw.setUIMessage("Schema rollout is finished successfully.")
+	return nil
+}
+
+func (sw *schemaRolloutWorkflow) runWorkflow() error {
+	canaryRunner := workflow.NewParallelRunner(sw.ctx, sw.rootUINode, sw.checkpointWriter, sw.getTasks(phaseCanary), sw.runShard, workflow.Sequential, false /* enableApprovals */)
+	if err := canaryRunner.Run(); err != nil || sw.ctx.Err() != nil {
+		return err
+	}
+
+	rolloutTasks := sw.getTasks(phaseRollout)
+	if len(rolloutTasks) == 0 {
+		return nil
+	}
+	rolloutRunner := workflow.NewParallelRunner(sw.ctx, sw.rootUINode, sw.checkpointWriter, rolloutTasks, sw.runShard, workflow.Sequential, sw.enableApprovals)
+	return rolloutRunner.Run()
+}
+
+// getTasks returns the tasks of a phase, in execution order.
+func (sw *schemaRolloutWorkflow) getTasks(phase workflow.PhaseType) []*workflowpb.Task {
+	var taskIDs []string
+	switch phase {
+	case phaseCanary:
+		taskIDs = []string{sw.checkpoint.Settings["canary_task"]}
+	case phaseRollout:
+		if rolloutTasks := sw.checkpoint.Settings["rollout_tasks"]; rolloutTasks != "" {
+			taskIDs = strings.Split(rolloutTasks, ",")
+		}
+	default:
+		log.Fatalf("BUG: unknown phase type: %v", phase)
+	}
+	var tasks []*workflowpb.Task
+	for _, taskID := range taskIDs {
+		tasks = append(tasks, sw.checkpoint.Tasks[taskID])
+	}
+	return tasks
+}
+
+// allTasks returns the tasks of all the phases, in execution order.
+func (sw *schemaRolloutWorkflow) allTasks() []*workflowpb.Task {
+	return append(sw.getTasks(phaseCanary), sw.getTasks(phaseRollout)...)
+}
+
+// runShard applies the schema change to the shard of the task, and
+// verifies its health.
+func (sw *schemaRolloutWorkflow) runShard(ctx context.Context, t *workflowpb.Task) error {
+	keyspace := t.Attributes["keyspace"]
+	shard := t.Attributes["shard"]
+	if err := sw.waitWhilePaused(ctx, keyspace, shard); err != nil {
+		return err
+	}
+
+	sw.setUIMessage(fmt.Sprintf("Applying the schema change to %v/%v.", keyspace, shard))
+	if err := sw.wr.ApplySchemaShard(ctx, keyspace, shard, sw.statements); err != nil {
+		return sw.fail(ctx, t, fmt.Errorf("cannot apply the schema change to %v/%v: %v", keyspace, shard, err))
+	}
+	if err := sw.verifyHealth(ctx, keyspace, shard, t.Id == sw.checkpoint.Settings["canary_task"]); err != nil {
+		return sw.fail(ctx, t, err)
+	}
+	return nil
+}
+
+// verifyHealth waits for the shard to become healthy after the schema
+// change. A canary shard must then stay healthy for the soak period.
+func (sw *schemaRolloutWorkflow) verifyHealth(ctx context.Context, keyspace, shard string, canary bool) error {
+	deadline := time.Now().Add(sw.healthCheckTimeout)
+	for {
+		err := sw.wr.CheckShardHealth(ctx, keyspace, shard, sw.maxReplicationLag)
+		if err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("%v/%v did not become healthy within %v after the schema change: %v", keyspace, shard, sw.healthCheckTimeout, err)
+		}
+		if err := sleep(ctx, sw.healthCheckInterval); err != nil {
+			return err
+		}
+	}
+	if !canary {
+		return nil
+	}
+
+	sw.setUIMessage(fmt.Sprintf("Watching the health of the canary shard %v/%v for %v.", keyspace, shard, sw.canarySoak))
+	soakEnd := time.Now().Add(sw.canarySoak)
+	for time.Now().Before(soakEnd) {
+		if err := sleep(ctx, sw.healthCheckInterval); err != nil {
+			return err
+		}
+		if err := sw.wr.CheckShardHealth(ctx, keyspace, shard, sw.maxReplicationLag); err != nil {
+			return fmt.Errorf("the canary shard %v/%v became unhealthy after the schema change: %v", keyspace, shard, err)
+		}
+	}
+	return nil
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(d):
+		return nil
+	}
+}
+
+// fail rolls the schema change back on the failed shard and on all the
+// shards it was applied to, in reverse order, then stops the rollout. It
+// returns the failure of the task. Nothing is rolled back if the workflow
+// is being stopped, so that it can resume.
+func (sw *schemaRolloutWorkflow) fail(ctx context.Context, failed *workflowpb.Task, taskErr error) error {
+	if ctx.Err() != nil {
+		return taskErr
+	}
+
+	var shards []*workflowpb.Task
+	for _, t := range sw.allTasks() {
+		if t.Id != failed.Id && t.State == workflowpb.TaskState_TaskDone && t.Error == "" {
+			shards = append(shards, t)
+		}
+	}
+	shards = append(shards, failed)
+
+	var failure error
+	if len(sw.rollbackStatements) == 0 {
+		failure = fmt.Errorf("schema rollout failed: %v; no rollback_sql, the changed shards are left as is", taskErr)
+	} else {
+		var rollbackErrs []string
+		for i := len(shards) - 1; i >= 0; i-- {
+			keyspace := shards[i].Attributes["keyspace"]
+			shard := shards[i].Attributes["shard"]
+			sw.setUIMessage(fmt.Sprintf("Rolling back the schema change on %v/%v.", keyspace, shard))
+			if err := sw.wr.ApplySchemaShard(ctx, keyspace, shard, sw.rollbackStatements); err != nil {
+				rollbackErrs = append(rollbackErrs, fmt.Sprintf("%v/%v: %v", keyspace, shard, err))
+			}
+		}
+		if len(rollbackErrs) == 0 {
+			failure = fmt.Errorf("schema rollout failed: %v; the schema change was rolled back on %v shards", taskErr, len(shards))
+		} else {
+			failure = fmt.Errorf("schema rollout failed: %v; the rollback failed on %v of %v shards: %v", taskErr, len(rollbackErrs), len(shards), strings.Join(rollbackErrs, "; "))
+		}
+	}
+
+	if err := sw.checkpointWriter.UpdateSettings(map[string]string{failureSetting: failure.Error()}); err != nil {
+		log.Errorf("%v", err)
+	}
+	sw.mu.Lock()
+	sw.failure = failure
+	sw.mu.Unlock()
+	sw.cancel()
+	return taskErr
+}
+
+// waitWhilePaused blocks while the rollout is paused.
+func (sw *schemaRolloutWorkflow) waitWhilePaused(ctx context.Context, keyspace, shard string) error {
+	sw.mu.Lock()
+	resumed := sw.resumed
+	sw.mu.Unlock()
+	if resumed == nil {
+		return nil
+	}
+
+	sw.setUIMessage(fmt.Sprintf("Schema rollout is paused before %v/%v.", keyspace, shard))
+	select {
+	case <-resumed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Action handles the pause and resume actions of the root node. It
+// implements the workflow.ActionListener interface. The shard being
+// changed when the rollout is paused is not interrupted.
+func (sw *schemaRolloutWorkflow) Action(ctx context.Context, path, name string) error {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+
+	paused := ""
+	switch name {
+	case actionNamePause:
+		if sw.resumed != nil {
+			return fmt.Errorf("the schema rollout is already paused")
+		}
+		sw.resumed = make(chan struct{})
+		paused = "true"
+	case actionNameResume:
+		if sw.resumed == nil {
+			return fmt.Errorf("the schema rollout is not paused")
+		}
+		close(sw.resumed)
+		sw.resumed = nil
+	default:
+		return fmt.Errorf("unknown action: %v", name)
+	}
+	sw.updatePauseActionLocked()
+	return sw.checkpointWriter.UpdateSettings(map[string]string{pausedSetting: paused})
+}
+
+func (sw *schemaRolloutWorkflow) updatePauseActionLocked() {
+	action := &workflow.Action{
+		Name:  actionNamePause,
+		State: workflow.ActionStateEnabled,
+		Style: workflow.ActionStyleNormal,
+	}
+	if sw.resumed != nil {
+		action.Name = actionNameResume
+		action.Style = workflow.ActionStyleWaiting
+	}
+	sw.rootUINode.Actions = []*workflow.Action{action}
+	sw.rootUINode.Listener = sw
+	sw.rootUINode.BroadcastChanges(false /* updateChildren */)
+}
+
+func (sw *schemaRolloutWorkflow) setUIMessage(message string) {
+	log.Infof("Schema rollout: %v", message)
+	sw.logger.Infof(message)
+	sw.rootUINode.Log = sw.logger.String()
+	sw.rootUINode.Message = message
+	sw.rootUINode.BroadcastChanges(false /* updateChildren */)
+}
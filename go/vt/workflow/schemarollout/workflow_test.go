@@ -0,0 +1,159 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schemarollout
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+	"vitess.io/vitess/go/vt/workflow"
+
+	// import the gRPC client implementation for tablet manager
+	_ "vitess.io/vitess/go/vt/vttablet/grpctmclient"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func init() {
+	Register()
+}
+
+// fakeWrangler records the statements applied to each shard, and reports
+// the shards of unhealthy as unhealthy.
+type fakeWrangler struct {
+	mu        sync.Mutex
+	applied   []string
+	unhealthy map[string]bool
+}
+
+func (f *fakeWrangler) ApplySchemaShard(ctx context.Context, keyspace, shard string, changes []string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.applied = append(f.applied, fmt.Sprintf("%v/%v: %v", keyspace, shard, strings.Join(changes, "; ")))
+	return nil
+}
+
+func (f *fakeWrangler) CheckShardHealth(ctx context.Context, keyspace, shard string, maxReplicationLag time.Duration) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.unhealthy[keyspace+"/"+shard] {
+		return fmt.Errorf("replication lag exceeds %v", maxReplicationLag)
+	}
+	return nil
+}
+
+func setupTopology(ctx context.Context, t *testing.T) *topo.Server {
+	ts := memorytopo.NewServer("cell")
+	require.NoError(t, ts.CreateKeyspace(ctx, "ks1", &topodatapb.Keyspace{}))
+	require.NoError(t, ts.CreateShard(ctx, "ks1", "-80"))
+	require.NoError(t, ts.CreateShard(ctx, "ks1", "80-"))
+	require.NoError(t, ts.CreateKeyspace(ctx, "ks2", &topodatapb.Keyspace{}))
+	require.NoError(t, ts.CreateShard(ctx, "ks2", "0"))
+	return ts
+}
+
+func runSchemaRollout(ctx context.Context, t *testing.T, ts *topo.Server, wr *fakeWrangler, args []string) (*topo.WorkflowInfo, error) {
+	m := workflow.NewManager(ts)
+	wg, _, cancel := workflow.StartManager(m)
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	uuid, err := m.Create(ctx, schemaRolloutFactoryName, args)
+	if err != nil {
+		return nil, err
+	}
+	w, err := m.WorkflowForTesting(uuid)
+	require.NoError(t, err)
+	w.(*schemaRolloutWorkflow).wr = wr
+	require.NoError(t, m.Start(ctx, uuid))
+	require.NoError(t, m.Wait(ctx, uuid))
+	return ts.GetWorkflow(ctx, uuid)
+}
+
+func TestSchemaRolloutInit(t *testing.T) {
+	ctx := context.Background()
+	ts := setupTopology(ctx, t)
+	m := workflow.NewManager(ts)
+
+	for _, args := range [][]string{
+		{"-keyspaces=ks1"},
+		{"-keyspaces=ks1", "-sql=;"},
+		{"-keyspaces=ks3", "-sql=alter table t add c int"},
+		{"-keyspaces=ks1,ks1", "-sql=alter table t add c int"},
+		{"-keyspaces=ks1", "-sql=alter table t add c int", "-canary_shard=ks2/0"},
+	} {
+		_, err := m.Create(ctx, schemaRolloutFactoryName, args)
+		assert.Error(t, err, "%v", args)
+	}
+}
+
+func TestSchemaRollout(t *testing.T) {
+	ctx := context.Background()
+	ts := setupTopology(ctx, t)
+	wr := &fakeWrangler{}
+	wi, err := runSchemaRollout(ctx, t, ts, wr, []string{
+		"-keyspaces=ks1,ks2",
+		"-sql=alter table t add c int",
+		"-canary_shard=ks1/80-",
+		"-canary_soak=10ms",
+		"-health_check_interval=1ms",
+	})
+	require.NoError(t, err)
+	assert.Empty(t, wi.Error)
+	assert.Equal(t, []string{
+		"ks1/80-: alter table t add c int",
+		"ks1/-80: alter table t add c int",
+		"ks2/0: alter table t add c int",
+	}, wr.applied)
+	require.NoError(t, workflow.VerifyAllTasksDone(ctx, ts, wi.Uuid))
+}
+
+func TestSchemaRolloutRollback(t *testing.T) {
+	ctx := context.Background()
+	ts := setupTopology(ctx, t)
+	wr := &fakeWrangler{unhealthy: map[string]bool{"ks2/0": true}}
+	wi, err := runSchemaRollout(ctx, t, ts, wr, []string{
+		"-keyspaces=ks1,ks2",
+		"-sql=alter table t add c int",
+		"-rollback_sql=alter table t drop column c",
+		"-canary_soak=0s",
+		"-health_check_timeout=10ms",
+		"-health_check_interval=1ms",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, wi.Error, "ks2/0 did not become healthy")
+	assert.Contains(t, wi.Error, "the schema change was rolled back on 3 shards")
+	assert.Equal(t, []string{
+		"ks1/-80: alter table t add c int",
+		"ks1/80-: alter table t add c int",
+		"ks2/0: alter table t add c int",
+		"ks2/0: alter table t drop column c",
+		"ks1/80-: alter table t drop column c",
+		"ks1/-80: alter table t drop column c",
+	}, wr.applied)
+}
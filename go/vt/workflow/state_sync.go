@@ -0,0 +1,150 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/topo"
+
+	workflowpb "vitess.io/vitess/go/vt/proto/workflow"
+)
+
+// This file implements the sync of the state of the workflows to the
+// topo. The running Manager saves the progress of its workflows every
+// -workflow_manager_state_sync_interval, so the standby vtctlds can report
+// it. When a standby takes over, e.g. because the running one died, it
+// adopts the running workflows from their last checkpoint, and records it
+// in their audit trail.
+
+var stateSyncInterval = flag.Duration("workflow_manager_state_sync_interval", 10*time.Second, "how often the running workflow manager saves the progress of its workflows in the topo, so the other vtctlds can report it")
+
+// staleStateIntervals is the number of sync intervals after which the
+// state of a running workflow is stale, i.e. its Manager probably died.
+const staleStateIntervals = 3
+
+// AuditAdopted is recorded in the audit trail of a running workflow when
+// another Manager takes it over.
+const AuditAdopted = "adopted"
+
+// RunState is the state of a workflow, as reported by any vtctld.
+type RunState struct {
+	*topo.WorkflowRunState
+	// Stale is true if the workflow is running, but its state was not
+	// saved for a while: its Manager probably died, and the workflow
+	// is waiting for another one to adopt it.
+	Stale bool `json:"stale,omitempty"`
+}
+
+// GetRunStates returns the states of all the workflows, as last saved by
+// the Manager running them.
+func GetRunStates(ctx context.Context, ts *topo.Server) ([]*RunState, error) {
+	states, err := ts.GetWorkflowRunStates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	staleAfter := staleStateIntervals * *stateSyncInterval
+	result := make([]*RunState, 0, len(states))
+	for _, state := range states {
+		result = append(result, &RunState{
+			WorkflowRunState: state,
+			Stale:            state.State == workflowpb.WorkflowState_Running.String() && time.Since(state.Heartbeat) > staleAfter,
+		})
+	}
+	return result, nil
+}
+
+// SetID sets the ID of the Manager recorded as the owner of the workflows
+// it runs, e.g. the host:port of its vtctld. It defaults to the hostname.
+func (m *Manager) SetID(id string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.id = id
+}
+
+// adoptLocked records that the running workflow is run by this Manager,
+// if it was run by another one. It needs to be run holding m.mu.
+func (m *Manager) adoptLocked(rw *runningWorkflow) {
+	previous, err := m.ts.GetWorkflowRunState(m.ctx, rw.wi.Uuid)
+	switch {
+	case topo.IsErrType(err, topo.NoNode):
+		return
+	case err != nil:
+		log.Warningf("Cannot read the state of workflow %v: %v", rw.wi.Uuid, err)
+		return
+	}
+	if previous.Owner == m.id {
+		return
+	}
+	log.Infof("Adopting workflow %v from %v, last seen at %v", rw.wi.Uuid, previous.Owner, previous.Heartbeat)
+	recordAudit(m.ctx, m.ts, rw.wi.Uuid, AuditAdopted, fmt.Sprintf("by %v from %v, last seen at %v", m.id, previous.Owner, previous.Heartbeat.UTC().Format(time.RFC3339)))
+}
+
+// runStateLocked returns the state of the workflow to save. It needs to
+// be run holding m.mu.
+func (m *Manager) runStateLocked(rw *runningWorkflow) *topo.WorkflowRunState {
+	state := &topo.WorkflowRunState{
+		UUID:        rw.wi.Uuid,
+		FactoryName: rw.wi.FactoryName,
+		Name:        rw.wi.Name,
+		Owner:       m.id,
+		State:       rw.wi.State.String(),
+		Error:       rw.wi.Error,
+		Heartbeat:   time.Now().UTC(),
+	}
+	state.Progress, state.ProgressMessage, state.Message = m.nodeManager.rootProgress(rw.wi.Uuid)
+	return state
+}
+
+// syncStates saves the states of the running workflows every
+// -workflow_manager_state_sync_interval, until ctx is done.
+func (m *Manager) syncStates(ctx context.Context) {
+	ticker := time.NewTicker(*stateSyncInterval)
+	defer ticker.Stop()
+	for {
+		m.saveRunningStates(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (m *Manager) saveRunningStates(ctx context.Context) {
+	// The states are saved holding m.mu, so a workflow which is done
+	// meanwhile doesn't get its final state overwritten.
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, rw := range m.workflows {
+		if rw.wi.State == workflowpb.WorkflowState_Running {
+			m.saveStateLocked(ctx, rw)
+		}
+	}
+}
+
+// saveStateLocked saves the state of the workflow right away, e.g. once
+// it is done. It needs to be run holding m.mu.
+func (m *Manager) saveStateLocked(ctx context.Context, rw *runningWorkflow) {
+	if err := m.ts.SaveWorkflowRunState(ctx, m.runStateLocked(rw)); err != nil {
+		log.Warningf("Cannot save the state of workflow %v: %v", rw.wi.Uuid, err)
+	}
+}
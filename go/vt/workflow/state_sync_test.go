@@ -0,0 +1,115 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+
+	workflowpb "vitess.io/vitess/go/vt/proto/workflow"
+)
+
+// TestStateSyncAndAdoption checks the state of a running workflow is
+// shared in the topo, and that a standby Manager adopts the workflow when
+// it takes over.
+func TestStateSyncAndAdoption(t *testing.T) {
+	ctx := context.Background()
+	ts := memorytopo.NewServer("cell1")
+	active := NewManager(ts)
+	active.SetID("vtctld1:15000")
+	wg, _, cancel := StartManager(active)
+
+	uuid, err := active.Create(ctx, sleepFactoryName, []string{"-duration", "60"})
+	if err != nil {
+		t.Fatalf("cannot create sleep workflow: %v", err)
+	}
+	if err := active.Start(ctx, uuid); err != nil {
+		t.Fatalf("cannot start sleep workflow: %v", err)
+	}
+
+	// Any vtctld can read the state.
+	states, err := GetRunStates(ctx, ts)
+	if err != nil {
+		t.Fatalf("GetRunStates failed: %v", err)
+	}
+	if len(states) != 1 {
+		t.Fatalf("got %v workflow states, want 1", len(states))
+	}
+	state := states[0]
+	if state.UUID != uuid || state.Owner != "vtctld1:15000" || state.State != workflowpb.WorkflowState_Running.String() || state.FactoryName != sleepFactoryName || state.Stale {
+		t.Errorf("unexpected workflow state %+v", state.WorkflowRunState)
+	}
+
+	// The active Manager dies: its state gets stale.
+	cancel()
+	wg.Wait()
+	state.Heartbeat = time.Now().Add(-staleStateIntervals * *stateSyncInterval).Add(-time.Second)
+	if err := ts.SaveWorkflowRunState(ctx, state.WorkflowRunState); err != nil {
+		t.Fatalf("SaveWorkflowRunState failed: %v", err)
+	}
+	states, err = GetRunStates(ctx, ts)
+	if err != nil {
+		t.Fatalf("GetRunStates failed: %v", err)
+	}
+	if !states[0].Stale {
+		t.Errorf("the state of the workflow should be stale: %+v", states[0].WorkflowRunState)
+	}
+
+	// The standby takes over.
+	standby := NewManager(ts)
+	standby.SetID("vtctld2:15000")
+	wg, _, cancel = StartManager(standby)
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+	if err := standby.Stop(ctx, uuid); err != nil {
+		t.Fatalf("cannot stop sleep workflow: %v", err)
+	}
+	got, err := ts.GetWorkflowRunState(ctx, uuid)
+	if err != nil {
+		t.Fatalf("GetWorkflowRunState failed: %v", err)
+	}
+	if got.Owner != "vtctld2:15000" || got.State != workflowpb.WorkflowState_Done.String() {
+		t.Errorf("unexpected workflow state after the takeover %+v", got)
+	}
+	auditLog, err := ts.GetWorkflowAuditLog(ctx, uuid)
+	if err != nil {
+		t.Fatalf("GetWorkflowAuditLog failed: %v", err)
+	}
+	adopted := false
+	for _, entry := range auditLog.Entries {
+		if entry.Action == AuditAdopted {
+			adopted = true
+		}
+	}
+	if !adopted {
+		t.Errorf("the adoption of the workflow is not in its audit log: %+v", auditLog.Entries)
+	}
+
+	// The state is deleted with the workflow.
+	if err := standby.Delete(ctx, uuid); err != nil {
+		t.Fatalf("cannot delete sleep workflow: %v", err)
+	}
+	if _, err := ts.GetWorkflowRunState(ctx, uuid); !topo.IsErrType(err, topo.NoNode) {
+		t.Errorf("GetWorkflowRunState after Delete: got %v, want NoNode", err)
+	}
+}
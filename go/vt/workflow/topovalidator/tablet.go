@@ -0,0 +1,228 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topovalidator
+
+import (
+	"fmt"
+	"io"
+
+	"context"
+
+	"vitess.io/vitess/go/netutil"
+	"vitess.io/vitess/go/vt/grpcclient"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/topotools"
+	"vitess.io/vitess/go/vt/vttablet/tabletconn"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// This file contains the Tablet validator. It uses GetKnownCells and
+// GetTabletsByCell to find all the tablets, and checks that:
+// - the shard of the tablet exists.
+// - the ShardReplication record of the cell references the tablet.
+// - a tablet of type MASTER is the master of its shard, and the master
+//   of the shard is a tablet.
+// - the tablet serving at the address of the tablet record is the tablet.
+// For each problem, it adds a fixer with the possible repairs.
+
+// RegisterTabletValidator registers the Tablet Validator.
+func RegisterTabletValidator() {
+	RegisterValidator("Tablet Validator", &TabletValidator{
+		servingTablet: streamHealthTablet,
+	})
+}
+
+// TabletValidator implements Validator.
+type TabletValidator struct {
+	// servingTablet returns the alias of the tablet serving at the address
+	// of the tablet record. If nil, the addresses are not checked.
+	servingTablet func(ctx context.Context, tablet *topodatapb.Tablet) (*topodatapb.TabletAlias, error)
+}
+
+// Audit is part of the Validator interface.
+func (tv *TabletValidator) Audit(ctx context.Context, ts *topo.Server, w *Workflow) error {
+	cells, err := ts.GetKnownCells(ctx)
+	if err != nil {
+		return err
+	}
+
+	// shards caches the shard records by keyspace/shard. The value is nil
+	// for a shard which doesn't exist.
+	shards := make(map[string]*topo.ShardInfo)
+	// tablets is the set of the aliases of all the tablets.
+	tablets := make(map[string]bool)
+	for _, cell := range cells {
+		aliases, err := ts.GetTabletsByCell(ctx, cell)
+		if err != nil {
+			return err
+		}
+		// replications caches the ShardReplication records of the cell by
+		// keyspace/shard.
+		replications := make(map[string]*topo.ShardReplicationInfo)
+		for _, alias := range aliases {
+			tablets[topoproto.TabletAliasString(alias)] = true
+			ti, err := ts.GetTablet(ctx, alias)
+			if err != nil {
+				// The tablet may have been deleted meanwhile.
+				continue
+			}
+			if err := tv.auditTablet(ctx, ts, w, ti, shards, replications); err != nil {
+				return err
+			}
+		}
+	}
+
+	for keyspaceShard, si := range shards {
+		if si == nil || si.MasterAlias == nil || tablets[topoproto.TabletAliasString(si.MasterAlias)] {
+			continue
+		}
+		w.AddFixer(keyspaceShard, fmt.Sprintf("Shard %v has master %v, which is not a tablet", keyspaceShard, topoproto.TabletAliasString(si.MasterAlias)), &TabletFixer{
+			ts:       ts,
+			keyspace: si.Keyspace(),
+			shard:    si.ShardName(),
+		}, []string{"ClearShardMaster"})
+	}
+	return nil
+}
+
+func (tv *TabletValidator) auditTablet(ctx context.Context, ts *topo.Server, w *Workflow, ti *topo.TabletInfo, shards map[string]*topo.ShardInfo, replications map[string]*topo.ShardReplicationInfo) error {
+	name := ti.AliasString()
+	keyspaceShard := topoproto.KeyspaceShardString(ti.Keyspace, ti.Shard)
+	fixer := &TabletFixer{
+		ts:       ts,
+		tablet:   ti.Tablet,
+		keyspace: ti.Keyspace,
+		shard:    ti.Shard,
+	}
+
+	si, ok := shards[keyspaceShard]
+	if !ok {
+		var err error
+		si, err = ts.GetShard(ctx, ti.Keyspace, ti.Shard)
+		switch {
+		case topo.IsErrType(err, topo.NoNode):
+			si = nil
+		case err != nil:
+			// The Shard validator reports the shards which can't be read.
+			return nil
+		}
+		shards[keyspaceShard] = si
+	}
+	if si == nil {
+		w.AddFixer(name, fmt.Sprintf("Tablet %v is in shard %v, which doesn't exist", name, keyspaceShard), fixer, []string{"CreateShard", "DeleteTablet"})
+		return nil
+	}
+
+	sri, ok := replications[keyspaceShard]
+	if !ok {
+		var err error
+		sri, err = ts.GetShardReplication(ctx, ti.Alias.Cell, ti.Keyspace, ti.Shard)
+		switch {
+		case topo.IsErrType(err, topo.NoNode):
+			sri = topo.NewShardReplicationInfo(&topodatapb.ShardReplication{}, ti.Alias.Cell, ti.Keyspace, ti.Shard)
+		case err != nil:
+			return err
+		}
+		replications[keyspaceShard] = sri
+	}
+	if _, err := sri.GetShardReplicationNode(ti.Alias); err != nil {
+		w.AddFixer(name, fmt.Sprintf("Tablet %v is not in the ShardReplication record of shard %v in cell %v", name, keyspaceShard, ti.Alias.Cell), fixer, []string{"AddToShardReplication", "DeleteTablet"})
+	}
+
+	if ti.Type == topodatapb.TabletType_MASTER && !topoproto.TabletAliasEqual(si.MasterAlias, ti.Alias) {
+		if ti.GetMasterTermStartTime().After(si.GetMasterTermStartTime()) {
+			w.AddFixer(name, fmt.Sprintf("Tablet %v is a master more recent than %v, the master of shard %v", name, topoproto.TabletAliasString(si.MasterAlias), keyspaceShard), fixer, []string{"UpdateShardMaster", "ChangeTypeToReplica"})
+		} else {
+			w.AddFixer(name, fmt.Sprintf("Tablet %v is a stale master, the master of shard %v is %v", name, keyspaceShard, topoproto.TabletAliasString(si.MasterAlias)), fixer, []string{"ChangeTypeToReplica", "UpdateShardMaster"})
+		}
+	}
+
+	if tv.servingTablet != nil {
+		serving, err := tv.servingTablet(ctx, ti.Tablet)
+		// An unreachable tablet is not a topology problem.
+		if err == nil && serving != nil && !topoproto.TabletAliasEqual(serving, ti.Alias) {
+			addr := netutil.JoinHostPort(ti.Hostname, ti.PortMap["grpc"])
+			w.AddFixer(name, fmt.Sprintf("Tablet %v has address %v, which is served by tablet %v", name, addr, topoproto.TabletAliasString(serving)), fixer, []string{"DeleteTablet"})
+		}
+	}
+	return nil
+}
+
+// streamHealthTablet returns the alias of the tablet serving at the
+// address of the tablet record, from its first health response.
+func streamHealthTablet(ctx context.Context, tablet *topodatapb.Tablet) (*topodatapb.TabletAlias, error) {
+	ctx, cancel := context.WithTimeout(ctx, *topo.RemoteOperationTimeout)
+	defer cancel()
+
+	conn, err := tabletconn.GetDialer()(tablet, grpcclient.FailFast(true))
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close(ctx)
+
+	var alias *topodatapb.TabletAlias
+	err = conn.StreamHealth(ctx, func(shr *querypb.StreamHealthResponse) error {
+		alias = shr.TabletAlias
+		return io.EOF
+	})
+	if err != nil {
+		return nil, err
+	}
+	return alias, nil
+}
+
+// TabletFixer implements Fixer.
+type TabletFixer struct {
+	ts *topo.Server
+	// tablet is nil for the fixers of a shard.
+	tablet   *topodatapb.Tablet
+	keyspace string
+	shard    string
+}
+
+// Action is part of the Fixer interface.
+func (tf *TabletFixer) Action(ctx context.Context, name string) error {
+	switch name {
+	case "CreateShard":
+		return tf.ts.CreateShard(ctx, tf.keyspace, tf.shard)
+	case "DeleteTablet":
+		return topotools.DeleteTablet(ctx, tf.ts, tf.tablet)
+	case "AddToShardReplication":
+		return topo.UpdateShardReplicationRecord(ctx, tf.ts, tf.keyspace, tf.shard, tf.tablet.Alias)
+	case "ChangeTypeToReplica":
+		_, err := topotools.ChangeType(ctx, tf.ts, tf.tablet.Alias, topodatapb.TabletType_REPLICA, nil)
+		return err
+	case "UpdateShardMaster":
+		_, err := tf.ts.UpdateShardFields(ctx, tf.keyspace, tf.shard, func(si *topo.ShardInfo) error {
+			si.MasterAlias = tf.tablet.Alias
+			si.MasterTermStartTime = tf.tablet.MasterTermStartTime
+			return nil
+		})
+		return err
+	case "ClearShardMaster":
+		_, err := tf.ts.UpdateShardFields(ctx, tf.keyspace, tf.shard, func(si *topo.ShardInfo) error {
+			si.MasterAlias = nil
+			si.MasterTermStartTime = nil
+			return nil
+		})
+		return err
+	}
+	return fmt.Errorf("unknown TabletFixer action: %v", name)
+}
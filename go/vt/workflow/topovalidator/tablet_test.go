@@ -0,0 +1,222 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topovalidator
+
+import (
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"context"
+
+	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	"vitess.io/vitess/go/vt/proto/vttime"
+)
+
+// This file contains tests for the tablet.go file.
+
+func TestTablet(t *testing.T) {
+	cell := "cell1"
+	keyspace := "ks1"
+	shard := "sh1"
+	ctx := context.Background()
+	ts := memorytopo.NewServer(cell)
+
+	if err := ts.CreateKeyspace(ctx, keyspace, &topodatapb.Keyspace{}); err != nil {
+		t.Fatalf("CreateKeyspace failed: %v", err)
+	}
+	if err := ts.CreateShard(ctx, keyspace, shard); err != nil {
+		t.Fatalf("CreateShard failed: %v", err)
+	}
+	newTablet := func(uid uint32, keyspace, shard string, tabletType topodatapb.TabletType) *topodatapb.Tablet {
+		tablet := &topodatapb.Tablet{
+			Alias:    &topodatapb.TabletAlias{Cell: cell, Uid: uid},
+			Hostname: "host",
+			PortMap:  map[string]int32{"grpc": int32(uid)},
+			Keyspace: keyspace,
+			Shard:    shard,
+			Type:     tabletType,
+		}
+		if err := ts.CreateTablet(ctx, tablet); err != nil {
+			t.Fatalf("CreateTablet failed: %v", err)
+		}
+		return tablet
+	}
+
+	// The master of the shard, and a consistent replica.
+	master := newTablet(1, keyspace, shard, topodatapb.TabletType_MASTER)
+	masterTermStartTime := &vttime.Time{Seconds: time.Now().Unix()}
+	if _, err := ts.UpdateShardFields(ctx, keyspace, shard, func(si *topo.ShardInfo) error {
+		si.MasterAlias = master.Alias
+		si.MasterTermStartTime = masterTermStartTime
+		return nil
+	}); err != nil {
+		t.Fatalf("UpdateShardFields failed: %v", err)
+	}
+	newTablet(2, keyspace, shard, topodatapb.TabletType_REPLICA)
+
+	// A stale master.
+	newTablet(3, keyspace, shard, topodatapb.TabletType_MASTER)
+	// A tablet missing from the ShardReplication record.
+	missing := newTablet(4, keyspace, shard, topodatapb.TabletType_REPLICA)
+	if err := topo.RemoveShardReplicationRecord(ctx, ts, cell, keyspace, shard, missing.Alias); err != nil {
+		t.Fatalf("RemoveShardReplicationRecord failed: %v", err)
+	}
+	// A tablet in a shard which doesn't exist.
+	newTablet(5, keyspace, "sh2", topodatapb.TabletType_REPLICA)
+	// A tablet whose address is served by another tablet.
+	newTablet(6, keyspace, shard, topodatapb.TabletType_RDONLY)
+
+	w := &Workflow{
+		logger: logutil.NewMemoryLogger(),
+	}
+	tv := &TabletValidator{
+		servingTablet: func(ctx context.Context, tablet *topodatapb.Tablet) (*topodatapb.TabletAlias, error) {
+			if tablet.Alias.Uid == 6 {
+				return &topodatapb.TabletAlias{Cell: cell, Uid: 7}, nil
+			}
+			return tablet.Alias, nil
+		},
+	}
+	if err := tv.Audit(ctx, ts, w); err != nil {
+		t.Fatalf("Audit failed: %v", err)
+	}
+	sort.Slice(w.fixers, func(i, j int) bool {
+		return w.fixers[i].name < w.fixers[j].name
+	})
+	want := []struct {
+		name    string
+		message string
+	}{
+		{"cell1-0000000003", "stale master"},
+		{"cell1-0000000004", "not in the ShardReplication record"},
+		{"cell1-0000000005", "doesn't exist"},
+		{"cell1-0000000006", "served by tablet cell1-0000000007"},
+	}
+	if len(w.fixers) != len(want) {
+		for _, f := range w.fixers {
+			t.Logf("fixer %v: %v", f.name, f.message)
+		}
+		t.Fatalf("got %v fixers, want %v", len(w.fixers), len(want))
+	}
+	for i, f := range w.fixers {
+		if f.name != want[i].name || !strings.Contains(f.message, want[i].message) {
+			t.Errorf("fixer %v: %v, want %v: %v", f.name, f.message, want[i].name, want[i].message)
+		}
+	}
+
+	// Run the first action of each fixer, except the deletion of the
+	// tablet with a bad address, which is tested below.
+	for _, f := range w.fixers {
+		if f.actions[0] == "DeleteTablet" {
+			continue
+		}
+		if err := f.fixer.Action(ctx, f.actions[0]); err != nil {
+			t.Fatalf("Action %v of %v failed: %v", f.actions[0], f.name, err)
+		}
+	}
+	ti, err := ts.GetTablet(ctx, &topodatapb.TabletAlias{Cell: cell, Uid: 3})
+	if err != nil || ti.Type != topodatapb.TabletType_REPLICA {
+		t.Errorf("stale master not changed to replica: %v %v", ti, err)
+	}
+	sri, err := ts.GetShardReplication(ctx, cell, keyspace, shard)
+	if err != nil {
+		t.Fatalf("GetShardReplication failed: %v", err)
+	}
+	if _, err := sri.GetShardReplicationNode(missing.Alias); err != nil {
+		t.Errorf("tablet not added to the ShardReplication record: %v", err)
+	}
+	if _, err := ts.GetShard(ctx, keyspace, "sh2"); err != nil {
+		t.Errorf("shard not created: %v", err)
+	}
+
+	// The tablets are now consistent, except the one with a bad address
+	// which can only be deleted.
+	w = &Workflow{
+		logger: logutil.NewMemoryLogger(),
+	}
+	if err := tv.Audit(ctx, ts, w); err != nil {
+		t.Fatalf("Audit failed: %v", err)
+	}
+	if len(w.fixers) != 1 || w.fixers[0].actions[0] != "DeleteTablet" {
+		t.Fatalf("bad fixers after the repairs: %v", w.fixers)
+	}
+	if err := w.fixers[0].fixer.Action(ctx, "DeleteTablet"); err != nil {
+		t.Fatalf("Action failed: %v", err)
+	}
+	if _, err := ts.GetTablet(ctx, &topodatapb.TabletAlias{Cell: cell, Uid: 6}); !topo.IsErrType(err, topo.NoNode) {
+		t.Errorf("tablet not deleted: %v", err)
+	}
+}
+
+func TestTabletShardMaster(t *testing.T) {
+	cell := "cell1"
+	keyspace := "ks1"
+	shard := "sh1"
+	ctx := context.Background()
+	ts := memorytopo.NewServer(cell)
+
+	if err := ts.CreateKeyspace(ctx, keyspace, &topodatapb.Keyspace{}); err != nil {
+		t.Fatalf("CreateKeyspace failed: %v", err)
+	}
+	if err := ts.CreateShard(ctx, keyspace, shard); err != nil {
+		t.Fatalf("CreateShard failed: %v", err)
+	}
+	replica := &topodatapb.Tablet{
+		Alias:    &topodatapb.TabletAlias{Cell: cell, Uid: 1},
+		Keyspace: keyspace,
+		Shard:    shard,
+		Type:     topodatapb.TabletType_REPLICA,
+	}
+	if err := ts.CreateTablet(ctx, replica); err != nil {
+		t.Fatalf("CreateTablet failed: %v", err)
+	}
+	// The master of the shard is not a tablet.
+	if _, err := ts.UpdateShardFields(ctx, keyspace, shard, func(si *topo.ShardInfo) error {
+		si.MasterAlias = &topodatapb.TabletAlias{Cell: cell, Uid: 2}
+		return nil
+	}); err != nil {
+		t.Fatalf("UpdateShardFields failed: %v", err)
+	}
+
+	w := &Workflow{
+		logger: logutil.NewMemoryLogger(),
+	}
+	tv := &TabletValidator{}
+	if err := tv.Audit(ctx, ts, w); err != nil {
+		t.Fatalf("Audit failed: %v", err)
+	}
+	if len(w.fixers) != 1 || !strings.Contains(w.fixers[0].message, "which is not a tablet") {
+		t.Fatalf("bad fixers: %v", w.fixers)
+	}
+	if err := w.fixers[0].fixer.Action(ctx, "ClearShardMaster"); err != nil {
+		t.Fatalf("Action failed: %v", err)
+	}
+	si, err := ts.GetShard(ctx, keyspace, shard)
+	if err != nil {
+		t.Fatalf("GetShard failed: %v", err)
+	}
+	if si.MasterAlias != nil {
+		t.Errorf("shard master not cleared: %v", topoproto.TabletAliasString(si.MasterAlias))
+	}
+}
@@ -0,0 +1,269 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topovalidator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"context"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/topo"
+
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+)
+
+// This file contains the VSchema validator. It uses GetKeyspaces and
+// GetVSchema to read the vschemas of all the keyspaces, and checks that:
+// - the column vindexes of the tables reference vindexes of the keyspace.
+// - the sequences of the auto increments are sequence tables.
+// - the reference tables of a sharded keyspace have their source table
+//   in an unsharded keyspace.
+// - the routing rules only reference existing keyspaces.
+// For each problem, it adds a fixer with the possible repairs. The
+// repairs rebuild the SrvVSchema of all the cells.
+
+// RegisterVSchemaValidator registers the VSchema Validator.
+func RegisterVSchemaValidator() {
+	RegisterValidator("VSchema Validator", &VSchemaValidator{})
+}
+
+// VSchemaValidator implements Validator.
+type VSchemaValidator struct{}
+
+// Audit is part of the Validator interface.
+func (vv *VSchemaValidator) Audit(ctx context.Context, ts *topo.Server, w *Workflow) error {
+	keyspaces, err := ts.GetKeyspaces(ctx)
+	if err != nil {
+		return err
+	}
+	vschemas := make(map[string]*vschemapb.Keyspace)
+	for _, keyspace := range keyspaces {
+		vschema, err := ts.GetVSchema(ctx, keyspace)
+		switch {
+		case topo.IsErrType(err, topo.NoNode):
+			vschema = &vschemapb.Keyspace{}
+		case err != nil:
+			return err
+		}
+		vschemas[keyspace] = vschema
+	}
+
+	for _, keyspace := range keyspaces {
+		vschema := vschemas[keyspace]
+		fixer := &VSchemaFixer{
+			ts:       ts,
+			keyspace: keyspace,
+		}
+		if tables := danglingVindexTables(vschema); len(tables) > 0 {
+			w.AddFixer(keyspace, fmt.Sprintf("Keyspace %v has tables with column vindexes which don't exist: %v", keyspace, strings.Join(tables, ", ")), fixer, []string{"DeleteColumnVindexes", "DeleteTables"})
+		}
+
+		for _, tname := range sortedTables(vschema) {
+			table := vschema.Tables[tname]
+			name := keyspace + "." + tname
+			tableFixer := &VSchemaFixer{
+				ts:       ts,
+				keyspace: keyspace,
+				table:    tname,
+			}
+			if table.AutoIncrement != nil && !sequenceExists(vschemas, table.AutoIncrement.Sequence) {
+				actions := []string{"DeleteAutoIncrement"}
+				if seqks, _, err := sqlparser.ParseTable(table.AutoIncrement.Sequence); err == nil && seqks != "" && vschemas[seqks] != nil && !vschemas[seqks].Sharded {
+					actions = []string{"CreateSequence", "DeleteAutoIncrement"}
+				}
+				w.AddFixer(name, fmt.Sprintf("Table %v has sequence %v, which is not a sequence table", name, table.AutoIncrement.Sequence), tableFixer, actions)
+			}
+			if vschema.Sharded && table.Type == "reference" && !referenceSourceExists(vschemas, tname) {
+				w.AddFixer(name, fmt.Sprintf("Reference table %v has no source table in an unsharded keyspace", name), tableFixer, []string{"DeleteTable"})
+			}
+		}
+	}
+
+	rules, err := ts.GetRoutingRules(ctx)
+	if err != nil {
+		return err
+	}
+	for _, rule := range rules.Rules {
+		for _, to := range rule.ToTables {
+			toks, _, err := sqlparser.ParseTable(to)
+			if err != nil || toks == "" || vschemas[toks] != nil {
+				continue
+			}
+			w.AddFixer(rule.FromTable, fmt.Sprintf("Routing rule %v references %v, in keyspace %v which doesn't exist", rule.FromTable, to, toks), &VSchemaFixer{
+				ts:   ts,
+				rule: rule.FromTable,
+			}, []string{"DeleteRoutingRule"})
+			break
+		}
+	}
+	return nil
+}
+
+// sortedTables returns the names of the tables of the vschema, sorted.
+func sortedTables(vschema *vschemapb.Keyspace) []string {
+	tables := make([]string, 0, len(vschema.Tables))
+	for tname := range vschema.Tables {
+		tables = append(tables, tname)
+	}
+	sort.Strings(tables)
+	return tables
+}
+
+// danglingVindexTables returns the tables of the vschema with column
+// vindexes which are not vindexes of the keyspace.
+func danglingVindexTables(vschema *vschemapb.Keyspace) []string {
+	var tables []string
+	for _, tname := range sortedTables(vschema) {
+		for _, cv := range vschema.Tables[tname].ColumnVindexes {
+			if vschema.Vindexes[cv.Name] == nil {
+				tables = append(tables, tname)
+				break
+			}
+		}
+	}
+	return tables
+}
+
+// sequenceExists returns true if the sequence, qualified by its keyspace
+// or not, is a sequence table of a keyspace.
+func sequenceExists(vschemas map[string]*vschemapb.Keyspace, sequence string) bool {
+	seqks, seqtab, err := sqlparser.ParseTable(sequence)
+	if err != nil {
+		return false
+	}
+	for keyspace, vschema := range vschemas {
+		if seqks != "" && keyspace != seqks {
+			continue
+		}
+		if table := vschema.Tables[seqtab]; table != nil && table.Type == "sequence" {
+			return true
+		}
+	}
+	return false
+}
+
+// referenceSourceExists returns true if an unsharded keyspace has the
+// table.
+func referenceSourceExists(vschemas map[string]*vschemapb.Keyspace, tname string) bool {
+	for _, vschema := range vschemas {
+		if !vschema.Sharded && vschema.Tables[tname] != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// VSchemaFixer implements Fixer.
+type VSchemaFixer struct {
+	ts *topo.Server
+	// keyspace is set for the fixers of a vschema, and table for the
+	// fixers of one of its tables.
+	keyspace string
+	table    string
+	// rule is set for the fixers of a routing rule.
+	rule string
+}
+
+// Action is part of the Fixer interface.
+func (vf *VSchemaFixer) Action(ctx context.Context, name string) error {
+	switch name {
+	case "DeleteColumnVindexes":
+		return vf.updateVSchema(ctx, vf.keyspace, func(vschema *vschemapb.Keyspace) {
+			for _, table := range vschema.Tables {
+				var cvs []*vschemapb.ColumnVindex
+				for _, cv := range table.ColumnVindexes {
+					if vschema.Vindexes[cv.Name] != nil {
+						cvs = append(cvs, cv)
+					}
+				}
+				table.ColumnVindexes = cvs
+			}
+		})
+	case "DeleteTables":
+		return vf.updateVSchema(ctx, vf.keyspace, func(vschema *vschemapb.Keyspace) {
+			for _, tname := range danglingVindexTables(vschema) {
+				delete(vschema.Tables, tname)
+			}
+		})
+	case "DeleteAutoIncrement":
+		return vf.updateVSchema(ctx, vf.keyspace, func(vschema *vschemapb.Keyspace) {
+			if table := vschema.Tables[vf.table]; table != nil {
+				table.AutoIncrement = nil
+			}
+		})
+	case "CreateSequence":
+		vschema, err := vf.ts.GetVSchema(ctx, vf.keyspace)
+		if err != nil {
+			return err
+		}
+		table := vschema.Tables[vf.table]
+		if table == nil || table.AutoIncrement == nil {
+			return fmt.Errorf("table %v.%v has no auto increment", vf.keyspace, vf.table)
+		}
+		seqks, seqtab, err := sqlparser.ParseTable(table.AutoIncrement.Sequence)
+		if err != nil {
+			return err
+		}
+		return vf.updateVSchema(ctx, seqks, func(vschema *vschemapb.Keyspace) {
+			if vschema.Tables == nil {
+				vschema.Tables = make(map[string]*vschemapb.Table)
+			}
+			vschema.Tables[seqtab] = &vschemapb.Table{Type: "sequence"}
+		})
+	case "DeleteTable":
+		return vf.updateVSchema(ctx, vf.keyspace, func(vschema *vschemapb.Keyspace) {
+			delete(vschema.Tables, vf.table)
+		})
+	case "DeleteRoutingRule":
+		rules, err := vf.ts.GetRoutingRules(ctx)
+		if err != nil {
+			return err
+		}
+		var kept []*vschemapb.RoutingRule
+		for _, rule := range rules.Rules {
+			if rule.FromTable != vf.rule {
+				kept = append(kept, rule)
+			}
+		}
+		rules.Rules = kept
+		if err := vf.ts.SaveRoutingRules(ctx, rules); err != nil {
+			return err
+		}
+		return vf.ts.RebuildSrvVSchema(ctx, nil)
+	}
+	return fmt.Errorf("unknown VSchemaFixer action: %v", name)
+}
+
+// updateVSchema applies update to the vschema of the keyspace, saves it
+// and rebuilds the SrvVSchema.
+func (vf *VSchemaFixer) updateVSchema(ctx context.Context, keyspace string, update func(*vschemapb.Keyspace)) error {
+	vschema, err := vf.ts.GetVSchema(ctx, keyspace)
+	switch {
+	case topo.IsErrType(err, topo.NoNode):
+		vschema = &vschemapb.Keyspace{}
+	case err != nil:
+		return err
+	}
+	update(vschema)
+	if err := vf.ts.SaveVSchema(ctx, keyspace, vschema); err != nil {
+		return err
+	}
+	return vf.ts.RebuildSrvVSchema(ctx, nil)
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topovalidator
+
+import (
+	"path"
+	"sort"
+	"strings"
+	"testing"
+
+	"context"
+
+	"google.golang.org/protobuf/proto"
+
+	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+)
+
+// This file contains tests for the vschema.go file.
+
+func TestVSchema(t *testing.T) {
+	cell := "cell1"
+	ctx := context.Background()
+	ts := memorytopo.NewServer(cell)
+
+	for _, keyspace := range []string{"sharded", "unsharded"} {
+		if err := ts.CreateKeyspace(ctx, keyspace, &topodatapb.Keyspace{}); err != nil {
+			t.Fatalf("CreateKeyspace failed: %v", err)
+		}
+	}
+	if err := ts.SaveVSchema(ctx, "unsharded", &vschemapb.Keyspace{
+		Tables: map[string]*vschemapb.Table{
+			"ref": {},
+		},
+	}); err != nil {
+		t.Fatalf("SaveVSchema failed: %v", err)
+	}
+
+	// The sharded vschema is invalid, so it's written directly to the
+	// topology backend.
+	hashVindex := []*vschemapb.ColumnVindex{{Column: "id", Name: "hash"}}
+	data, err := proto.Marshal(&vschemapb.Keyspace{
+		Sharded: true,
+		Vindexes: map[string]*vschemapb.Vindex{
+			"hash": {Type: "hash"},
+		},
+		Tables: map[string]*vschemapb.Table{
+			// A dangling vindex.
+			"t1": {ColumnVindexes: []*vschemapb.ColumnVindex{{Column: "id", Name: "hash"}, {Column: "name", Name: "missing"}}},
+			// A missing sequence.
+			"t2": {ColumnVindexes: hashVindex, AutoIncrement: &vschemapb.AutoIncrement{Column: "id", Sequence: "unsharded.t2_seq"}},
+			// A reference table with and without a source.
+			"ref":  {Type: "reference"},
+			"ref2": {Type: "reference"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	conn, err := ts.ConnForCell(ctx, topo.GlobalCell)
+	if err != nil {
+		t.Fatalf("ConnForCell() failed: %v", err)
+	}
+	if _, err := conn.Create(ctx, path.Join("/keyspaces", "sharded", topo.VSchemaFile), data); err != nil {
+		t.Fatalf("failed to write the vschema: %v", err)
+	}
+
+	// A routing rule to a deleted keyspace.
+	if err := ts.SaveRoutingRules(ctx, &vschemapb.RoutingRules{
+		Rules: []*vschemapb.RoutingRule{
+			{FromTable: "t1", ToTables: []string{"deleted.t1"}},
+			{FromTable: "t2", ToTables: []string{"sharded.t2"}},
+		},
+	}); err != nil {
+		t.Fatalf("SaveRoutingRules failed: %v", err)
+	}
+
+	w := &Workflow{
+		logger: logutil.NewMemoryLogger(),
+	}
+	vv := &VSchemaValidator{}
+	if err := vv.Audit(ctx, ts, w); err != nil {
+		t.Fatalf("Audit failed: %v", err)
+	}
+	sort.Slice(w.fixers, func(i, j int) bool {
+		return w.fixers[i].name < w.fixers[j].name
+	})
+	want := []struct {
+		name    string
+		message string
+	}{
+		{"sharded", "column vindexes which don't exist: t1"},
+		{"sharded.ref2", "no source table"},
+		{"sharded.t2", "sequence unsharded.t2_seq"},
+		{"t1", "keyspace deleted which doesn't exist"},
+	}
+	if len(w.fixers) != len(want) {
+		for _, f := range w.fixers {
+			t.Logf("fixer %v: %v", f.name, f.message)
+		}
+		t.Fatalf("got %v fixers, want %v", len(w.fixers), len(want))
+	}
+	for i, f := range w.fixers {
+		if f.name != want[i].name || !strings.Contains(f.message, want[i].message) {
+			t.Errorf("fixer %v: %v, want %v: %v", f.name, f.message, want[i].name, want[i].message)
+		}
+	}
+
+	// Run the first action of each fixer.
+	for _, f := range w.fixers {
+		if err := f.fixer.Action(ctx, f.actions[0]); err != nil {
+			t.Fatalf("Action %v of %v failed: %v", f.actions[0], f.name, err)
+		}
+	}
+	vschema, err := ts.GetVSchema(ctx, "unsharded")
+	if err != nil {
+		t.Fatalf("GetVSchema failed: %v", err)
+	}
+	if vschema.Tables["t2_seq"].GetType() != "sequence" {
+		t.Errorf("sequence not created: %v", vschema)
+	}
+	rules, err := ts.GetRoutingRules(ctx)
+	if err != nil || len(rules.Rules) != 1 || rules.Rules[0].FromTable != "t2" {
+		t.Errorf("routing rule not deleted: %v %v", rules, err)
+	}
+	srvVSchema, err := ts.GetSrvVSchema(ctx, cell)
+	if err != nil {
+		t.Fatalf("GetSrvVSchema failed: %v", err)
+	}
+	if srvVSchema.Keyspaces["sharded"].Tables["ref2"] != nil {
+		t.Errorf("SrvVSchema not rebuilt: %v", srvVSchema)
+	}
+
+	// The vschemas are now consistent.
+	w = &Workflow{
+		logger: logutil.NewMemoryLogger(),
+	}
+	if err := vv.Audit(ctx, ts, w); err != nil {
+		t.Fatalf("Audit failed: %v", err)
+	}
+	if len(w.fixers) != 0 {
+		t.Fatalf("bad fixers after the repairs: %v", w.fixers)
+	}
+}
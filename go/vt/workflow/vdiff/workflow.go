@@ -0,0 +1,181 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package vdiff contains a workflow which runs a VDiff of a vreplication
+// workflow and stores its result, optionally only comparing the rows
+// modified since the previous VDiff. Create a workflow schedule of it to
+// run the VDiffs of a long migration periodically, e.g. hourly.
+package vdiff
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/vttablet/tmclient"
+	"vitess.io/vitess/go/vt/workflow"
+	"vitess.io/vitess/go/vt/wrangler"
+
+	workflowpb "vitess.io/vitess/go/vt/proto/workflow"
+)
+
+const vdiffFactoryName = "vdiff"
+
+// Register registers the Factory of the VDiff workflow in the workflow
+// framework.
+func Register() {
+	workflow.Register(vdiffFactoryName, &Factory{})
+}
+
+// Wrangler is the subset of the methods of go/vt/wrangler.Wrangler used by
+// the VDiff workflow, so that unit tests can fake it.
+type Wrangler interface {
+	RunVDiff(ctx context.Context, targetKeyspace, workflowName string, params *wrangler.VDiffParams) (*workflowpb.VDiffResult, error)
+}
+
+// vdiffWorkflowData is the data of the workflow, serialized as JSON in
+// Workflow.Data.
+type vdiffWorkflowData struct {
+	Keyspace string
+	Workflow string
+	Params   *wrangler.VDiffParams
+}
+
+// Factory is the factory to create a VDiff workflow.
+type Factory struct{}
+
+// Init is part of the workflow.Factory interface.
+func (*Factory) Init(m *workflow.Manager, w *workflowpb.Workflow, args []string) error {
+	subFlags := flag.NewFlagSet(vdiffFactoryName, flag.ContinueOnError)
+	sourceCell := subFlags.String("source_cell", "", "The source cell to compare from")
+	targetCell := subFlags.String("target_cell", "", "The target cell to compare with")
+	tabletTypes := subFlags.String("tablet_types", "master,replica,rdonly", "Tablet types for source and target")
+	filteredReplicationWaitTime := subFlags.Duration("filtered_replication_wait_time", 30*time.Second, "Specifies the maximum time to wait for filtered replication to catch up")
+	tables := subFlags.String("tables", "", "Only run vdiff for these tables in the workflow")
+	incrementalColumn := subFlags.String("incremental_column", "", "Only compare the rows whose value of this update timestamp column changed since the previous stored vdiff of the workflow")
+	maxSourceLag := subFlags.Duration("max_source_lag", 0, "Only read from tablets whose replication lag is at most this, and restart the vdiff with other tablets if it failed while one of its tablets was lagging. 0 disables it")
+	waitForSourceCatchup := subFlags.Bool("wait_for_source_catchup", false, "If all the tablets of a shard are above -max_source_lag, wait for one of them to catch up instead of failing")
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("the <keyspace.workflow> argument is required for the %v workflow", vdiffFactoryName)
+	}
+	splits := strings.Split(subFlags.Arg(0), ".")
+	if len(splits) != 2 || splits[0] == "" || splits[1] == "" {
+		return fmt.Errorf("invalid format for <keyspace.workflow>: %v", subFlags.Arg(0))
+	}
+
+	data := &vdiffWorkflowData{
+		Keyspace: splits[0],
+		Workflow: splits[1],
+		Params: &wrangler.VDiffParams{
+			SourceCell:                  *sourceCell,
+			TargetCell:                  *targetCell,
+			TabletTypes:                 *tabletTypes,
+			FilteredReplicationWaitTime: *filteredReplicationWaitTime,
+			Tables:                      *tables,
+			IncrementalColumn:           *incrementalColumn,
+			MaxSourceLag:                *maxSourceLag,
+			WaitForSourceCatchup:        *waitForSourceCatchup,
+		},
+	}
+	if *incrementalColumn != "" {
+		w.Name = fmt.Sprintf("Incremental VDiff of workflow %v.%v on %v.", data.Keyspace, data.Workflow, *incrementalColumn)
+	} else {
+		w.Name = fmt.Sprintf("VDiff of workflow %v.%v.", data.Keyspace, data.Workflow)
+	}
+	var err error
+	w.Data, err = json.Marshal(data)
+	return err
+}
+
+// Instantiate is part the workflow.Factory interface.
+func (*Factory) Instantiate(m *workflow.Manager, w *workflowpb.Workflow, rootNode *workflow.Node) (workflow.Workflow, error) {
+	data := &vdiffWorkflowData{}
+	if err := json.Unmarshal(w.Data, data); err != nil {
+		return nil, err
+	}
+	rootNode.Message = fmt.Sprintf("This is a workflow to compare the source and target tables of workflow %v.%v.", data.Keyspace, data.Workflow)
+	logger := logutil.NewMemoryLogger()
+	return &vdiffWorkflow{
+		data:       data,
+		logger:     logger,
+		wr:         wrangler.New(logger, m.TopoServer(), tmclient.NewTabletManagerClient()),
+		rootUINode: rootNode,
+	}, nil
+}
+
+// vdiffWorkflow runs a VDiff and stores its result.
+type vdiffWorkflow struct {
+	data *vdiffWorkflowData
+	wr   Wrangler
+	// logger is the logger we export UI logs from. The wrangler logs the
+	// summary of the VDiff to it.
+	logger *logutil.MemoryLogger
+
+	// rootUINode is the root node representing the workflow in the UI.
+	rootUINode *workflow.Node
+}
+
+// Run runs the VDiff. It fails if the VDiff found differences, s.t. they
+// stand out in the list of workflows.
+// It implements the workflow.Workflow interface.
+func (vw *vdiffWorkflow) Run(ctx context.Context, manager *workflow.Manager, wi *topo.WorkflowInfo) error {
+	vw.rootUINode.Display = workflow.NodeDisplayIndeterminate
+	vw.setUIMessage(fmt.Sprintf("Running the VDiff of workflow %v.%v.", vw.data.Keyspace, vw.data.Workflow))
+
+	result, err := vw.wr.RunVDiff(ctx, vw.data.Keyspace, vw.data.Workflow, vw.data.Params)
+	vw.rootUINode.Display = workflow.NodeDisplayNone
+	if err != nil {
+		vw.setUIMessage(fmt.Sprintf("The VDiff of workflow %v.%v failed: %v", vw.data.Keyspace, vw.data.Workflow, err))
+		return err
+	}
+	if tables := tablesWithDifferences(result); len(tables) > 0 {
+		vw.setUIMessage(fmt.Sprintf("The VDiff of workflow %v.%v found differences in tables %v.", vw.data.Keyspace, vw.data.Workflow, strings.Join(tables, ", ")))
+		return fmt.Errorf("the VDiff of workflow %v.%v found differences", vw.data.Keyspace, vw.data.Workflow)
+	}
+	vw.setUIMessage(fmt.Sprintf("The VDiff of workflow %v.%v found no difference in %v tables.", vw.data.Keyspace, vw.data.Workflow, len(result.Tables)))
+	return nil
+}
+
+// tablesWithDifferences returns the sorted names of the tables in which the
+// VDiff found differences.
+func tablesWithDifferences(result *workflowpb.VDiffResult) []string {
+	var tables []string
+	for name, table := range result.Tables {
+		if table.MismatchedRows != 0 || table.ExtraRowsSource != 0 || table.ExtraRowsTarget != 0 {
+			tables = append(tables, name)
+		}
+	}
+	sort.Strings(tables)
+	return tables
+}
+
+func (vw *vdiffWorkflow) setUIMessage(message string) {
+	log.Infof("VDiff workflow: %v", message)
+	vw.logger.Infof(message)
+	vw.rootUINode.Log = vw.logger.String()
+	vw.rootUINode.Message = message
+	vw.rootUINode.BroadcastChanges(false /* updateChildren */)
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vdiff
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+	"vitess.io/vitess/go/vt/workflow"
+	"vitess.io/vitess/go/vt/wrangler"
+
+	workflowpb "vitess.io/vitess/go/vt/proto/workflow"
+
+	// import the gRPC client implementation for tablet manager
+	_ "vitess.io/vitess/go/vt/vttablet/grpctmclient"
+)
+
+func init() {
+	Register()
+}
+
+// fakeWrangler records the parameters of the VDiff, and returns result.
+type fakeWrangler struct {
+	keyspace string
+	workflow string
+	params   *wrangler.VDiffParams
+	result   *workflowpb.VDiffResult
+}
+
+func (f *fakeWrangler) RunVDiff(ctx context.Context, targetKeyspace, workflowName string, params *wrangler.VDiffParams) (*workflowpb.VDiffResult, error) {
+	f.keyspace = targetKeyspace
+	f.workflow = workflowName
+	f.params = params
+	return f.result, nil
+}
+
+func runWorkflow(ctx context.Context, t *testing.T, ts *topo.Server, wr *fakeWrangler, args []string) *topo.WorkflowInfo {
+	m := workflow.NewManager(ts)
+	wg, _, cancel := workflow.StartManager(m)
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+	uuid, err := m.Create(ctx, vdiffFactoryName, args)
+	require.NoError(t, err)
+	w, err := m.WorkflowForTesting(uuid)
+	require.NoError(t, err)
+	w.(*vdiffWorkflow).wr = wr
+	require.NoError(t, m.Start(ctx, uuid))
+	// The workflow fails if differences were found.
+	_ = m.Wait(ctx, uuid)
+
+	wi, err := ts.GetWorkflow(ctx, uuid)
+	require.NoError(t, err)
+	return wi
+}
+
+func TestVDiffWorkflowInit(t *testing.T) {
+	ctx := context.Background()
+	m := workflow.NewManager(memorytopo.NewServer("cell"))
+	for _, args := range [][]string{
+		{},
+		{"ks"},
+		{"ks.wf", "extra"},
+		{".wf"},
+	} {
+		_, err := m.Create(ctx, vdiffFactoryName, args)
+		assert.Error(t, err, "%v", args)
+	}
+}
+
+func TestVDiffWorkflow(t *testing.T) {
+	ctx := context.Background()
+	ts := memorytopo.NewServer("cell")
+	wr := &fakeWrangler{result: &workflowpb.VDiffResult{Tables: map[string]*workflowpb.VDiffTableResult{
+		"t1": {ProcessedRows: 10, MatchingRows: 10},
+	}}}
+
+	wi := runWorkflow(ctx, t, ts, wr, []string{"-incremental_column=updated_at", "-filtered_replication_wait_time=1m", "ks.wf"})
+	assert.Empty(t, wi.Error)
+	assert.Equal(t, "ks", wr.keyspace)
+	assert.Equal(t, "wf", wr.workflow)
+	assert.Equal(t, &wrangler.VDiffParams{
+		TabletTypes:                 "master,replica,rdonly",
+		FilteredReplicationWaitTime: time.Minute,
+		IncrementalColumn:           "updated_at",
+	}, wr.params)
+
+	wr.result.Tables["t2"] = &workflowpb.VDiffTableResult{ProcessedRows: 10, MatchingRows: 9, MismatchedRows: 1}
+	wi = runWorkflow(ctx, t, ts, wr, []string{"ks.wf"})
+	assert.Equal(t, "the VDiff of workflow ks.wf found differences", wi.Error)
+	assert.Equal(t, []string{"t2"}, tablesWithDifferences(wr.result))
+}
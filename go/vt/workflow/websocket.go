@@ -114,8 +114,8 @@ func (m *Manager) HandleHTTPWebSocket(pattern string) {
 					return
 				}
 
-				ctx := context.TODO()
-				if err := m.NodeManager().Action(ctx, ap); err != nil {
+				ctx := NewAuditContext(context.TODO(), "", r.RemoteAddr)
+				if err := m.Action(ctx, ap); err != nil {
 					log.Warningf("Action failed: %v", err)
 				}
 
@@ -60,6 +60,7 @@ func (*TestWorkflowFactory) Init(_ *Manager, w *workflowpb.Workflow, args []stri
 	count := subFlags.Int("count", 0, "The number of simple tasks")
 	enableApprovals := subFlags.Bool("enable_approvals", false, "If true, executions of tasks require user's approvals on the UI.")
 	sequential := subFlags.Bool("sequential", false, "If true, executions of tasks are sequential")
+	retryPolicy := NewRetryPolicyFlags(subFlags)
 	if err := subFlags.Parse(args); err != nil {
 		return err
 	}
@@ -79,6 +80,9 @@ func (*TestWorkflowFactory) Init(_ *Manager, w *workflowpb.Workflow, args []stri
 		Tasks:       taskMap,
 		Settings:    map[string]string{"count": fmt.Sprintf("%v", *count), "retry": fmt.Sprintf("%v", *retryFlag), "enable_approvals": fmt.Sprintf("%v", *enableApprovals), "sequential": fmt.Sprintf("%v", *sequential)},
 	}
+	if err := retryPolicy.SaveSettings(checkpoint.Settings); err != nil {
+		return err
+	}
 	var err error
 	w.Data, err = proto.Marshal(checkpoint)
 	if err != nil {
@@ -117,6 +121,11 @@ func (*TestWorkflowFactory) Instantiate(m *Manager, w *workflowpb.Workflow, root
 		return nil, err
 	}
 
+	retryPolicy, err := RetryPolicyFromSettings(checkpoint.Settings)
+	if err != nil {
+		return nil, err
+	}
+
 	tw := &TestWorkflow{
 		topoServer:      m.TopoServer(),
 		manager:         m,
@@ -126,6 +135,7 @@ func (*TestWorkflowFactory) Instantiate(m *Manager, w *workflowpb.Workflow, root
 		retryFlags:      retryFlags,
 		enableApprovals: enableApprovals,
 		sequential:      sequential,
+		retryPolicy:     retryPolicy,
 	}
 
 	count, err := strconv.Atoi(checkpoint.Settings["count"])
@@ -173,6 +183,7 @@ type TestWorkflow struct {
 
 	enableApprovals bool
 	sequential      bool
+	retryPolicy     *RetryPolicy
 }
 
 // Run implements the workflow.Workflow interface.
@@ -190,6 +201,7 @@ func (tw *TestWorkflow) Run(ctx context.Context, manager *Manager, wi *topo.Work
 		concurrencyLevel = Sequential
 	}
 	simpleRunner := NewParallelRunner(tw.ctx, tw.rootUINode, tw.checkpointWriter, simpleTasks, tw.runSimple, concurrencyLevel, tw.enableApprovals)
+	simpleRunner.SetRetryPolicy(tw.retryPolicy)
 	return simpleRunner.Run()
 }
 
@@ -0,0 +1,239 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrangler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/sqlescape"
+	"vitess.io/vitess/go/vt/grpcclient"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vttablet/queryservice"
+	"vitess.io/vitess/go/vt/vttablet/tabletconn"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// blacklistRuleDescription is the description of the query rule installed
+// by the tablets to enforce the blacklisted tables of their shard record.
+// See tabletmanager.tmState.
+const blacklistRuleDescription = "enforce blacklisted tables"
+
+// ValidateBlacklistedTablesOptions configures ValidateBlacklistedTables.
+type ValidateBlacklistedTablesOptions struct {
+	// TabletType is the tablet type whose tablet control is checked.
+	TabletType topodatapb.TabletType
+	// Tables is the list of tables which must be blacklisted in every
+	// shard. If empty, the union of the blacklisted tables of all shards
+	// is used.
+	Tables []string
+	// Probe sends a query for every blacklisted table to every tablet
+	// of TabletType, and reports the tablets which still serve it.
+	Probe bool
+	// ProbeTimeout bounds every probe query.
+	ProbeTimeout time.Duration
+}
+
+// ValidateBlacklistedTables checks that the blacklisted tables are
+// consistently set in all the shard records of a keyspace and, if
+// requested, that all tablets enforce them. A table which is blacklisted
+// on some shards only, or still served by a tablet, while writes are
+// switched causes writes to go to both sides of the traffic switch.
+func (wr *Wrangler) ValidateBlacklistedTables(ctx context.Context, keyspace string, opts *ValidateBlacklistedTablesOptions) error {
+	shards, err := wr.ts.FindAllShardsInKeyspace(ctx, keyspace)
+	if err != nil {
+		return err
+	}
+	shardNames := make([]string, 0, len(shards))
+	for shard := range shards {
+		shardNames = append(shardNames, shard)
+	}
+	sort.Strings(shardNames)
+
+	expected := opts.Tables
+	if len(expected) == 0 {
+		expected = unionBlacklistedTables(shards, opts.TabletType)
+	}
+	if len(expected) == 0 {
+		wr.Logger().Printf("No %v tables are blacklisted in keyspace %v\n", topoproto.TabletTypeLString(opts.TabletType), keyspace)
+		return nil
+	}
+
+	wg := &sync.WaitGroup{}
+	results := make(chan error, 16)
+	for _, shard := range shardNames {
+		si := shards[shard]
+		tc := si.GetTabletControl(opts.TabletType)
+		var blacklisted []string
+		if tc != nil {
+			blacklisted = tc.BlacklistedTables
+		}
+		if missing := missingTables(expected, blacklisted); len(missing) > 0 {
+			wg.Add(1)
+			go func(keyspace, shard string) {
+				defer wg.Done()
+				results <- fmt.Errorf("shard %v/%v: %v tables not blacklisted for %v: %v", keyspace, shard, len(missing), topoproto.TabletTypeLString(opts.TabletType), strings.Join(missing, ", "))
+			}(si.Keyspace(), si.ShardName())
+		}
+		if !opts.Probe || len(blacklisted) == 0 {
+			continue
+		}
+		wg.Add(1)
+		go func(si *topo.ShardInfo, tc *topodatapb.Shard_TabletControl) {
+			defer wg.Done()
+			wr.probeBlacklistedTables(ctx, si, tc, opts, wg, results)
+		}(si, tc)
+	}
+	return wr.waitForResults(wg, results)
+}
+
+// probeBlacklistedTables sends a query for each blacklisted table of the
+// shard to all its tablets of the checked type.
+func (wr *Wrangler) probeBlacklistedTables(ctx context.Context, si *topo.ShardInfo, tc *topodatapb.Shard_TabletControl, opts *ValidateBlacklistedTablesOptions, wg *sync.WaitGroup, results chan<- error) {
+	tabletMap, err := wr.ts.GetTabletMapForShard(ctx, si.Keyspace(), si.ShardName())
+	if err != nil {
+		results <- fmt.Errorf("GetTabletMapForShard(%v, %v) failed: %v", si.Keyspace(), si.ShardName(), err)
+		return
+	}
+	for alias, ti := range tabletMap {
+		if ti.Type != opts.TabletType {
+			continue
+		}
+		if len(tc.Cells) > 0 && !topo.InCellList(ti.Alias.Cell, tc.Cells) {
+			continue
+		}
+		wg.Add(1)
+		go func(alias string, ti *topo.TabletInfo) {
+			defer wg.Done()
+			conn, err := tabletconn.GetDialer()(ti.Tablet, grpcclient.FailFast(true))
+			if err != nil {
+				results <- fmt.Errorf("tablet %v: cannot connect to probe blacklisted tables: %v", alias, err)
+				return
+			}
+			defer conn.Close(ctx)
+			tables, err := wr.probedTables(ctx, ti.Tablet, tc.BlacklistedTables)
+			if err != nil {
+				results <- fmt.Errorf("tablet %v: cannot expand the blacklisted tables: %v", alias, err)
+				return
+			}
+			target := &querypb.Target{Keyspace: si.Keyspace(), Shard: si.ShardName(), TabletType: ti.Type}
+			for _, table := range tables {
+				if err := checkBlacklistProbe(probeTable(ctx, conn, target, table, opts.ProbeTimeout)); err != nil {
+					results <- fmt.Errorf("tablet %v: table %v: %v", alias, table, err)
+				}
+			}
+		}(alias, ti)
+	}
+}
+
+// probedTables returns the tables to probe for the blacklisted tables of
+// a shard. Entries starting with '/' are regular expressions, they are
+// expanded to the matching tables of the tablet schema.
+func (wr *Wrangler) probedTables(ctx context.Context, tablet *topodatapb.Tablet, blacklisted []string) ([]string, error) {
+	var tables, patterns []string
+	for _, table := range blacklisted {
+		if strings.HasPrefix(table, "/") {
+			patterns = append(patterns, table)
+			continue
+		}
+		tables = append(tables, table)
+	}
+	if len(patterns) == 0 {
+		return tables, nil
+	}
+	sd, err := wr.tmc.GetSchema(ctx, tablet, patterns, nil, false)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool, len(tables))
+	for _, table := range tables {
+		seen[table] = true
+	}
+	for _, td := range sd.TableDefinitions {
+		if !seen[td.Name] {
+			seen[td.Name] = true
+			tables = append(tables, td.Name)
+		}
+	}
+	return tables, nil
+}
+
+// probeTable runs a lightweight query against table.
+func probeTable(ctx context.Context, conn queryservice.QueryService, target *querypb.Target, table string, timeout time.Duration) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	query := fmt.Sprintf("select 1 from %s limit 1", sqlescape.EscapeID(table))
+	_, err := conn.Execute(ctx, target, query, nil, 0, 0, nil)
+	return err
+}
+
+// checkBlacklistProbe interprets the result of a probe query: the tablet
+// must have rejected it because of the blacklist rule.
+func checkBlacklistProbe(err error) error {
+	switch {
+	case err == nil:
+		return fmt.Errorf("still serving a blacklisted table")
+	case strings.Contains(err.Error(), blacklistRuleDescription):
+		return nil
+	default:
+		return fmt.Errorf("cannot verify the blacklist, probe failed: %v", err)
+	}
+}
+
+// unionBlacklistedTables returns the sorted list of all the tables
+// blacklisted for tabletType in any of the shards.
+func unionBlacklistedTables(shards map[string]*topo.ShardInfo, tabletType topodatapb.TabletType) []string {
+	tables := make(map[string]bool)
+	for _, si := range shards {
+		if tc := si.GetTabletControl(tabletType); tc != nil {
+			for _, table := range tc.BlacklistedTables {
+				tables[table] = true
+			}
+		}
+	}
+	union := make([]string, 0, len(tables))
+	for table := range tables {
+		union = append(union, table)
+	}
+	sort.Strings(union)
+	return union
+}
+
+// missingTables returns the tables of expected which are not in actual.
+func missingTables(expected, actual []string) []string {
+	present := make(map[string]bool, len(actual))
+	for _, table := range actual {
+		present[table] = true
+	}
+	var missing []string
+	for _, table := range expected {
+		if !present[table] {
+			missing = append(missing, table)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrangler
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+	"vitess.io/vitess/go/vt/vttablet/tmclient"
+
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// blacklistTMClient serves a schema for the tables it is asked for.
+type blacklistTMClient struct {
+	tmclient.TabletManagerClient
+	schema *tabletmanagerdatapb.SchemaDefinition
+	tables []string
+}
+
+func (tmc *blacklistTMClient) GetSchema(ctx context.Context, tablet *topodatapb.Tablet, tables, excludeTables []string, includeViews bool) (*tabletmanagerdatapb.SchemaDefinition, error) {
+	tmc.tables = tables
+	return tmc.schema, nil
+}
+
+func TestValidateBlacklistedTables(t *testing.T) {
+	ctx := context.Background()
+	ts := memorytopo.NewServer("cell1")
+	logger := logutil.NewMemoryLogger()
+	wr := New(logger, ts, nil)
+
+	require.NoError(t, ts.CreateKeyspace(ctx, "ks", &topodatapb.Keyspace{}))
+	for _, shard := range []string{"-80", "80-"} {
+		require.NoError(t, ts.CreateShard(ctx, "ks", shard))
+	}
+	opts := &ValidateBlacklistedTablesOptions{TabletType: topodatapb.TabletType_MASTER}
+
+	// Nothing is blacklisted.
+	require.NoError(t, wr.ValidateBlacklistedTables(ctx, "ks", opts))
+
+	blacklist := func(shard string, tables ...string) {
+		_, err := ts.UpdateShardFields(ctx, "ks", shard, func(si *topo.ShardInfo) error {
+			si.TabletControls = []*topodatapb.Shard_TabletControl{{
+				TabletType:        topodatapb.TabletType_MASTER,
+				BlacklistedTables: tables,
+			}}
+			return nil
+		})
+		require.NoError(t, err)
+	}
+
+	// Consistent across shards.
+	blacklist("-80", "t1", "t2")
+	blacklist("80-", "t1", "t2")
+	require.NoError(t, wr.ValidateBlacklistedTables(ctx, "ks", opts))
+
+	// An explicit list which isn't blacklisted.
+	logger.Clear()
+	opts.Tables = []string{"t1", "t3"}
+	require.Error(t, wr.ValidateBlacklistedTables(ctx, "ks", opts))
+	assert.Contains(t, logger.String(), "shard ks/-80: 1 tables not blacklisted for master: t3")
+	assert.Contains(t, logger.String(), "shard ks/80-: 1 tables not blacklisted for master: t3")
+
+	// Inconsistent across shards.
+	logger.Clear()
+	opts.Tables = nil
+	blacklist("80-", "t1", "t2", "t3")
+	require.Error(t, wr.ValidateBlacklistedTables(ctx, "ks", opts))
+	assert.Contains(t, logger.String(), "shard ks/-80: 1 tables not blacklisted for master: t3")
+	assert.NotContains(t, logger.String(), "shard ks/80-")
+}
+
+func TestCheckBlacklistProbe(t *testing.T) {
+	assert.EqualError(t, checkBlacklistProbe(nil), "still serving a blacklisted table")
+	assert.NoError(t, checkBlacklistProbe(fmt.Errorf("disallowed due to rule: enforce blacklisted tables")))
+	assert.EqualError(t, checkBlacklistProbe(fmt.Errorf("connection refused")), "cannot verify the blacklist, probe failed: connection refused")
+}
+
+func TestProbedTables(t *testing.T) {
+	ctx := context.Background()
+	tmc := &blacklistTMClient{
+		schema: &tabletmanagerdatapb.SchemaDefinition{
+			TableDefinitions: []*tabletmanagerdatapb.TableDefinition{{Name: "t1"}, {Name: "t1_old"}},
+		},
+	}
+	wr := New(logutil.NewMemoryLogger(), memorytopo.NewServer("cell1"), tmc)
+	tablet := &topodatapb.Tablet{}
+
+	// Literal names are probed as is, without fetching the schema.
+	tables, err := wr.probedTables(ctx, tablet, []string{"t1", "t2"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"t1", "t2"}, tables)
+	assert.Nil(t, tmc.tables)
+
+	// Regular expressions are expanded to the matching tables.
+	tables, err = wr.probedTables(ctx, tablet, []string{"t1", "/^t1"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"t1", "t1_old"}, tables)
+	assert.Equal(t, []string{"/^t1"}, tmc.tables)
+}
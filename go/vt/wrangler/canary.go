@@ -0,0 +1,111 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrangler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"vitess.io/vitess/go/vt/concurrency"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+const defaultCanaryInterval = time.Second
+
+// CanaryQueryExecutor runs a canary query against a keyspace. Implementations
+// are expected to go through vtgate, so that the query is routed exactly
+// like application traffic would be.
+type CanaryQueryExecutor interface {
+	ExecuteCanaryQuery(ctx context.Context, keyspace, query string) error
+}
+
+// CanaryVerification configures the optional verification step run by
+// SwitchTraffic once writes have been switched. The canary queries are run
+// repeatedly until Window has elapsed; if any of them fails, writes are
+// switched back to the source.
+type CanaryVerification struct {
+	// Queries are typically read-after-write checks, e.g. an insert into
+	// a canary table followed by a select of the inserted row.
+	Queries []string
+	// Window is how long the queries keep being run after the switch.
+	// A zero Window runs the queries exactly once.
+	Window time.Duration
+	// Interval is the pause between two runs of the queries.
+	Interval time.Duration
+	Executor CanaryQueryExecutor
+}
+
+// enabled returns true if a verification step was requested.
+func (cv *CanaryVerification) enabled() bool {
+	return cv != nil && len(cv.Queries) > 0
+}
+
+// verify runs the canary queries against keyspace until the verification
+// window has elapsed. It returns the first error encountered.
+func (cv *CanaryVerification) verify(ctx context.Context, keyspace string) error {
+	if cv.Executor == nil {
+		return fmt.Errorf("no canary query executor configured")
+	}
+	interval := cv.Interval
+	if interval <= 0 {
+		interval = defaultCanaryInterval
+	}
+	deadline := time.Now().Add(cv.Window)
+	for {
+		for _, query := range cv.Queries {
+			if err := cv.Executor.ExecuteCanaryQuery(ctx, keyspace, query); err != nil {
+				return vterrors.Wrapf(err, "canary query %q failed", query)
+			}
+		}
+		if !time.Now().Add(interval).Before(deadline) {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// recordWorkflowMessage sets the message of all the streams of a workflow
+// in the given keyspace, so the outcome of an operation is visible in
+// Workflow Show and _vt.vreplication.
+func (wr *Wrangler) recordWorkflowMessage(ctx context.Context, keyspace, workflowName, message string) error {
+	shards, err := wr.ts.FindAllShardsInKeyspace(ctx, keyspace)
+	if err != nil {
+		return err
+	}
+	allErrors := &concurrency.AllErrorRecorder{}
+	for _, si := range shards {
+		if si.MasterAlias == nil {
+			continue
+		}
+		ti, err := wr.ts.GetTablet(ctx, si.MasterAlias)
+		if err != nil {
+			allErrors.RecordError(err)
+			continue
+		}
+		query := fmt.Sprintf("update _vt.vreplication set message=%s where db_name=%s and workflow=%s",
+			encodeString(message), encodeString(ti.DbName()), encodeString(workflowName))
+		if _, err := wr.tmc.VReplicationExec(ctx, ti.Tablet, query); err != nil {
+			allErrors.RecordError(err)
+		}
+	}
+	return allErrors.AggrError(vterrors.Aggregate)
+}
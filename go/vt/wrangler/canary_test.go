@@ -0,0 +1,91 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrangler
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/vtctl/workflow"
+)
+
+type fakeCanaryExecutor struct {
+	keyspaces []string
+	queries   []string
+	// failAfter makes the executor fail once it has run that many queries.
+	failAfter int
+}
+
+func (f *fakeCanaryExecutor) ExecuteCanaryQuery(ctx context.Context, keyspace, query string) error {
+	if f.failAfter > 0 && len(f.queries) >= f.failAfter {
+		return fmt.Errorf("row not found")
+	}
+	f.keyspaces = append(f.keyspaces, keyspace)
+	f.queries = append(f.queries, query)
+	return nil
+}
+
+func TestCanaryVerification(t *testing.T) {
+	ctx := context.Background()
+
+	var cv *CanaryVerification
+	assert.False(t, cv.enabled())
+	cv = &CanaryVerification{}
+	assert.False(t, cv.enabled())
+
+	queries := []string{"insert into canary(id) values (1)", "select id from canary where id = 1"}
+
+	// No window: the queries are run exactly once.
+	executor := &fakeCanaryExecutor{}
+	cv = &CanaryVerification{Queries: queries, Executor: executor}
+	require.True(t, cv.enabled())
+	require.NoError(t, cv.verify(ctx, "ks2"))
+	assert.Equal(t, queries, executor.queries)
+	assert.Equal(t, []string{"ks2", "ks2"}, executor.keyspaces)
+
+	// With a window, the queries keep being run.
+	executor = &fakeCanaryExecutor{}
+	cv = &CanaryVerification{Queries: queries, Window: 50 * time.Millisecond, Interval: 10 * time.Millisecond, Executor: executor}
+	require.NoError(t, cv.verify(ctx, "ks2"))
+	assert.Greater(t, len(executor.queries), len(queries))
+
+	// A failure within the window is reported.
+	executor = &fakeCanaryExecutor{failAfter: 3}
+	cv = &CanaryVerification{Queries: queries, Window: time.Second, Interval: 10 * time.Millisecond, Executor: executor}
+	err := cv.verify(ctx, "ks2")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "select id from canary where id = 1")
+
+	// An executor is required.
+	cv = &CanaryVerification{Queries: queries}
+	assert.Error(t, cv.verify(ctx, "ks2"))
+}
+
+func TestCanaryVerificationRequiresReverseReplication(t *testing.T) {
+	vrw := &VReplicationWorkflow{
+		params: &VReplicationWorkflowParams{
+			Canary: &CanaryVerification{Queries: []string{"select 1"}, Executor: &fakeCanaryExecutor{}},
+		},
+	}
+	_, err := vrw.SwitchTraffic(workflow.DirectionForward)
+	assert.EqualError(t, err, "canary verification requires reverse replication")
+}
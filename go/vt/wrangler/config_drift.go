@@ -0,0 +1,38 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrangler
+
+import (
+	"context"
+
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/vtctl/validateutil"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// getFlagsFromTabletDebugFlags returns the values of the given flags of a
+// tablet, as exported on its /debug/flags page.
+var getFlagsFromTabletDebugFlags = validateutil.GetFlagsFromTabletDebugFlags
+
+// ValidateConfig reports the tablets whose MySQL global variables or
+// vttablet flags drift from the golden configuration or from the majority.
+func (wr *Wrangler) ValidateConfig(ctx context.Context, opts validateutil.Options, configOpts validateutil.ConfigOptions) (*validateutil.ConfigReport, error) {
+	return validateutil.ValidateConfig(ctx, wr.ts, wr.tmc, func(ctx context.Context, tablet *topodatapb.Tablet, names []string) (map[string]string, error) {
+		return getFlagsFromTabletDebugFlags(topo.NewTabletInfo(tablet, nil).Addr(), names)
+	}, opts, configOpts)
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrangler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vtctl/workflow"
+)
+
+const defaultCutoverGateInterval = time.Second
+
+// CutoverGate configures the optional gate SwitchTraffic waits for before
+// it switches traffic forward: all the streams of the workflow must have
+// replicated below MaxLag, without errors, for StableFor.
+type CutoverGate struct {
+	// MaxLag is the replication lag all the streams must stay below. A zero
+	// MaxLag disables the gate.
+	MaxLag time.Duration
+	// StableFor is how long the streams must stay below MaxLag, without
+	// errors, for the gate to open. An error of a stream within StableFor
+	// keeps the gate closed.
+	StableFor time.Duration
+	// Auto keeps waiting for the gate to open, and then switches traffic.
+	// Without it, SwitchTraffic fails as soon as a stream lags or errs.
+	Auto bool
+	// Deadline is how long SwitchTraffic waits for the gate to open before
+	// it aborts. A zero Deadline waits until the context is done.
+	Deadline time.Duration
+	// Interval is the pause between two checks of the streams.
+	Interval time.Duration
+}
+
+// enabled returns true if a cutover gate was requested.
+func (cg *CutoverGate) enabled() bool {
+	return cg != nil && cg.MaxLag > 0
+}
+
+// wait checks the progress of the workflow every interval until the gate
+// opens. It returns an error if the gate stays closed, or did not open
+// before the deadline.
+func (cg *CutoverGate) wait(ctx context.Context, getProgress func(ctx context.Context) (*workflow.WorkflowProgress, error), logger logutil.Logger) error {
+	interval := cg.Interval
+	if interval <= 0 {
+		interval = defaultCutoverGateInterval
+	}
+	var deadline time.Time
+	if cg.Deadline > 0 {
+		deadline = time.Now().Add(cg.Deadline)
+	}
+	var stableSince time.Time
+	lastReason := ""
+	for {
+		progress, err := getProgress(ctx)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		reason := cg.check(progress, now)
+		switch {
+		case reason != "" && !cg.Auto:
+			return fmt.Errorf("cutover gate is closed: %s", reason)
+		case reason != "":
+			if reason != lastReason {
+				logger.Infof("Cutover gate is closed: %s", reason)
+			}
+			stableSince = time.Time{}
+		case stableSince.IsZero():
+			stableSince = now
+		}
+		lastReason = reason
+		if reason == "" && now.Sub(stableSince) >= cg.StableFor {
+			return nil
+		}
+		if !deadline.IsZero() && !now.Add(interval).Before(deadline) {
+			if reason == "" {
+				reason = fmt.Sprintf("the streams have only been stable for %v", now.Sub(stableSince).Round(time.Second))
+			}
+			return fmt.Errorf("cutover gate did not open within %v: %s", cg.Deadline, reason)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// check returns why the gate is closed for the given progress of the
+// workflow, or an empty string if all its streams replicate below the lag
+// threshold without recent errors.
+func (cg *CutoverGate) check(progress *workflow.WorkflowProgress, now time.Time) string {
+	if len(progress.Streams) == 0 {
+		return "the workflow has no streams"
+	}
+	for _, sp := range progress.Streams {
+		stream := fmt.Sprintf("stream %d on %s", sp.ID, topoproto.TabletAliasString(sp.Tablet))
+		switch {
+		case sp.Phase == workflow.ProgressPhaseCopy:
+			return fmt.Sprintf("%s is still copying", stream)
+		case sp.State == "Error":
+			return fmt.Sprintf("%s is in error: %s", stream, sp.LastError)
+		case sp.LastErrorTime != nil && now.Sub(*sp.LastErrorTime) < cg.StableFor:
+			return fmt.Sprintf("%s had an error at %s: %s", stream, sp.LastErrorTime.Format(time.RFC3339), sp.LastError)
+		case time.Duration(sp.ReplicationLagSeconds)*time.Second > cg.MaxLag:
+			return fmt.Sprintf("%s lags by %ds, more than %v", stream, sp.ReplicationLagSeconds, cg.MaxLag)
+		}
+	}
+	return ""
+}
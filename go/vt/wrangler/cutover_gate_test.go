@@ -0,0 +1,113 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrangler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/vtctl/workflow"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// fakeWorkflowProgress returns the given lags of a stream, one per call,
+// and then the last one.
+type fakeWorkflowProgress struct {
+	lags  []int64
+	calls int
+}
+
+func (f *fakeWorkflowProgress) get(ctx context.Context) (*workflow.WorkflowProgress, error) {
+	lag := f.lags[len(f.lags)-1]
+	if f.calls < len(f.lags) {
+		lag = f.lags[f.calls]
+	}
+	f.calls++
+	return &workflow.WorkflowProgress{
+		Streams: []*workflow.StreamProgress{{
+			ID:                    1,
+			Tablet:                &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+			State:                 "Running",
+			Phase:                 workflow.ProgressPhaseReplicate,
+			ReplicationLagSeconds: lag,
+		}},
+	}, nil
+}
+
+func TestCutoverGate(t *testing.T) {
+	ctx := context.Background()
+	logger := logutil.NewMemoryLogger()
+
+	var cg *CutoverGate
+	assert.False(t, cg.enabled())
+	cg = &CutoverGate{StableFor: time.Second}
+	assert.False(t, cg.enabled())
+
+	// The gate opens once the streams have been stable long enough.
+	cg = &CutoverGate{MaxLag: 5 * time.Second, StableFor: 30 * time.Millisecond, Interval: 10 * time.Millisecond, Deadline: time.Second}
+	require.True(t, cg.enabled())
+	progress := &fakeWorkflowProgress{lags: []int64{1}}
+	require.NoError(t, cg.wait(ctx, progress.get, logger))
+	assert.GreaterOrEqual(t, progress.calls, 4)
+
+	// Without Auto, a lagging stream closes the gate.
+	progress = &fakeWorkflowProgress{lags: []int64{1, 10}}
+	err := cg.wait(ctx, progress.get, logger)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "stream 1 on zone1-0000000100 lags by 10s")
+
+	// With Auto, the gate waits for the lag to come back down.
+	cg.Auto = true
+	progress = &fakeWorkflowProgress{lags: []int64{1, 10, 10, 1}}
+	require.NoError(t, cg.wait(ctx, progress.get, logger))
+	assert.GreaterOrEqual(t, progress.calls, 7)
+
+	// The deadline aborts the wait.
+	cg.Deadline = 50 * time.Millisecond
+	progress = &fakeWorkflowProgress{lags: []int64{10}}
+	err = cg.wait(ctx, progress.get, logger)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "did not open within 50ms")
+
+	// Streams in copy, in error or with a recent error keep the gate closed.
+	now := time.Now()
+	recent := now.Add(-10 * time.Millisecond)
+	old := now.Add(-time.Minute)
+	for _, tc := range []struct {
+		stream *workflow.StreamProgress
+		closed string
+	}{
+		{&workflow.StreamProgress{Phase: workflow.ProgressPhaseCopy}, "is still copying"},
+		{&workflow.StreamProgress{State: "Error", LastError: "duplicate key"}, "is in error: duplicate key"},
+		{&workflow.StreamProgress{LastError: "lost connection", LastErrorTime: &recent}, "had an error"},
+		{&workflow.StreamProgress{LastError: "lost connection", LastErrorTime: &old}, ""},
+	} {
+		reason := cg.check(&workflow.WorkflowProgress{Streams: []*workflow.StreamProgress{tc.stream}}, now)
+		if tc.closed == "" {
+			assert.Empty(t, reason)
+			continue
+		}
+		assert.Contains(t, reason, tc.closed)
+	}
+	assert.Equal(t, "the workflow has no streams", cg.check(&workflow.WorkflowProgress{}, now))
+}
@@ -0,0 +1,34 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrangler
+
+import (
+	"context"
+	"time"
+
+	"vitess.io/vitess/go/vt/topotools"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// DecommissionTablet removes a tablet which isn't needed anymore: it
+// checks that nothing depends on it, drains it from the gateways, waits
+// drainGrace for its in-flight queries, stops its replication and deletes
+// its record. It refuses with the reasons otherwise.
+func (wr *Wrangler) DecommissionTablet(ctx context.Context, tabletAlias *topodatapb.TabletAlias, drainGrace time.Duration) error {
+	return topotools.DecommissionTablet(ctx, wr.ts, wr.tmc, wr.Logger(), tabletAlias, drainGrace, topotools.GetVReplicationSourcesFromDebugVars)
+}
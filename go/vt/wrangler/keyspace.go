@@ -91,6 +91,125 @@ func (wr *Wrangler) SetKeyspaceShardingInfo(ctx context.Context, keyspace, shard
 	return wr.ts.UpdateKeyspace(ctx, ki)
 }
 
+// PinQuery locks a keyspace and adds the query pin to it, replacing the
+// pin of the same query if any.
+func (wr *Wrangler) PinQuery(ctx context.Context, keyspace string, pin *topodatapb.QueryPin) (err error) {
+	ctx, unlock, lockErr := wr.ts.LockKeyspace(ctx, keyspace, "PinQuery")
+	if lockErr != nil {
+		return lockErr
+	}
+	defer unlock(&err)
+
+	ki, err := wr.ts.GetKeyspace(ctx, keyspace)
+	if err != nil {
+		return err
+	}
+	replaced := false
+	for i, p := range ki.QueryPins {
+		if p.Query == pin.Query {
+			ki.QueryPins[i] = pin
+			replaced = true
+		}
+	}
+	if !replaced {
+		ki.QueryPins = append(ki.QueryPins, pin)
+	}
+	return wr.ts.UpdateKeyspace(ctx, ki)
+}
+
+// UnpinQuery locks a keyspace and removes the pin of the query from it,
+// or all its expired pins if expired is true.
+func (wr *Wrangler) UnpinQuery(ctx context.Context, keyspace, query string, expired bool) (err error) {
+	ctx, unlock, lockErr := wr.ts.LockKeyspace(ctx, keyspace, "UnpinQuery")
+	if lockErr != nil {
+		return lockErr
+	}
+	defer unlock(&err)
+
+	ki, err := wr.ts.GetKeyspace(ctx, keyspace)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	var kept []*topodatapb.QueryPin
+	for _, p := range ki.QueryPins {
+		if (expired && topoproto.QueryPinExpired(p, now)) || (!expired && p.Query == query) {
+			wr.Logger().Printf("Removing pin of query: %v\n", p.Query)
+			continue
+		}
+		kept = append(kept, p)
+	}
+	if len(kept) == len(ki.QueryPins) {
+		if expired {
+			return nil
+		}
+		return fmt.Errorf("query is not pinned in keyspace %v: %v", keyspace, query)
+	}
+	ki.QueryPins = kept
+	return wr.ts.UpdateKeyspace(ctx, ki)
+}
+
+// SetKeyspaceServingFlags locks a keyspace and replaces its serving flags.
+// No flags removes them.
+func (wr *Wrangler) SetKeyspaceServingFlags(ctx context.Context, keyspace string, flags []string) (err error) {
+	flags, err = topo.ParseServingFlags(strings.Join(flags, ","))
+	if err != nil {
+		return err
+	}
+
+	ctx, unlock, lockErr := wr.ts.LockKeyspace(ctx, keyspace, "SetKeyspaceServingFlags")
+	if lockErr != nil {
+		return lockErr
+	}
+	defer unlock(&err)
+
+	ki, err := wr.ts.GetKeyspace(ctx, keyspace)
+	if err != nil {
+		return err
+	}
+	if len(flags) == 0 {
+		flags = nil
+	}
+	ki.ServingFlags = flags
+	return wr.ts.UpdateKeyspace(ctx, ki)
+}
+
+// SetReplicationLagThreshold locks a keyspace and sets the replication lag
+// threshold of a tablet type, in lower case, or of all its tablet types if
+// tabletType is empty. A nil threshold removes it.
+func (wr *Wrangler) SetReplicationLagThreshold(ctx context.Context, keyspace, tabletType string, threshold *topodatapb.ReplicationLagThreshold) (err error) {
+	ctx, unlock, lockErr := wr.ts.LockKeyspace(ctx, keyspace, "SetReplicationLagThreshold")
+	if lockErr != nil {
+		return lockErr
+	}
+	defer unlock(&err)
+
+	ki, err := wr.ts.GetKeyspace(ctx, keyspace)
+	if err != nil {
+		return err
+	}
+	rt := ki.ReplicationLagThresholds
+	if rt == nil {
+		rt = &topodatapb.ReplicationLagThresholds{}
+	}
+	switch {
+	case tabletType == "":
+		rt.Default = threshold
+	case threshold == nil:
+		delete(rt.TabletTypes, tabletType)
+	default:
+		if rt.TabletTypes == nil {
+			rt.TabletTypes = make(map[string]*topodatapb.ReplicationLagThreshold)
+		}
+		rt.TabletTypes[tabletType] = threshold
+	}
+	if topoproto.ReplicationLagThresholdsEmpty(rt) {
+		rt = nil
+	}
+	ki.ReplicationLagThresholds = rt
+	return wr.ts.UpdateKeyspace(ctx, ki)
+}
+
 // validateNewWorkflow ensures that the specified workflow doesn't already exist
 // in the keyspace.
 func (wr *Wrangler) validateNewWorkflow(ctx context.Context, keyspace, workflow string) error {
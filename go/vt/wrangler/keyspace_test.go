@@ -0,0 +1,114 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrangler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/test/utils"
+	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func TestPinQuery(t *testing.T) {
+	ctx := context.Background()
+	ts := memorytopo.NewServer("cell1")
+	wr := New(logutil.NewConsoleLogger(), ts, nil)
+	require.NoError(t, ts.CreateKeyspace(ctx, "ks", &topodatapb.Keyspace{}))
+
+	pinA := &topodatapb.QueryPin{Query: "select * from t where a = :a", Replacement: "select * from t force index (a) where a = :a", Comment: "bad plan"}
+	pinB := &topodatapb.QueryPin{Query: "select * from t where b = :b", Replacement: "select * from t force index (b) where b = :b", Expires: logutil.TimeToProto(time.Now().Add(-time.Minute))}
+	require.NoError(t, wr.PinQuery(ctx, "ks", pinA))
+	require.NoError(t, wr.PinQuery(ctx, "ks", pinB))
+
+	// Pinning a query again replaces its pin.
+	pinA = &topodatapb.QueryPin{Query: pinA.Query, Replacement: "select * from t use index (a) where a = :a"}
+	require.NoError(t, wr.PinQuery(ctx, "ks", pinA))
+	ki, err := ts.GetKeyspace(ctx, "ks")
+	require.NoError(t, err)
+	require.Len(t, ki.QueryPins, 2)
+	assert.Equal(t, pinA.Replacement, ki.QueryPins[0].Replacement)
+
+	require.NoError(t, wr.UnpinQuery(ctx, "ks", "", true))
+	ki, err = ts.GetKeyspace(ctx, "ks")
+	require.NoError(t, err)
+	require.Len(t, ki.QueryPins, 1)
+	assert.Equal(t, pinA.Query, ki.QueryPins[0].Query)
+
+	assert.Error(t, wr.UnpinQuery(ctx, "ks", pinB.Query, false))
+	require.NoError(t, wr.UnpinQuery(ctx, "ks", pinA.Query, false))
+	ki, err = ts.GetKeyspace(ctx, "ks")
+	require.NoError(t, err)
+	assert.Empty(t, ki.QueryPins)
+
+	assert.Error(t, wr.PinQuery(ctx, "nosuchks", pinA))
+}
+
+func TestSetReplicationLagThreshold(t *testing.T) {
+	ctx := context.Background()
+	ts := memorytopo.NewServer("cell1")
+	wr := New(logutil.NewConsoleLogger(), ts, nil)
+	require.NoError(t, ts.CreateKeyspace(ctx, "ks", &topodatapb.Keyspace{}))
+
+	require.NoError(t, wr.SetReplicationLagThreshold(ctx, "ks", "", &topodatapb.ReplicationLagThreshold{SoftSeconds: 10}))
+	require.NoError(t, wr.SetReplicationLagThreshold(ctx, "ks", "rdonly", &topodatapb.ReplicationLagThreshold{SoftSeconds: 60, HardSeconds: 600}))
+	ki, err := ts.GetKeyspace(ctx, "ks")
+	require.NoError(t, err)
+	utils.MustMatch(t, &topodatapb.ReplicationLagThresholds{
+		Default:     &topodatapb.ReplicationLagThreshold{SoftSeconds: 10},
+		TabletTypes: map[string]*topodatapb.ReplicationLagThreshold{"rdonly": {SoftSeconds: 60, HardSeconds: 600}},
+	}, ki.ReplicationLagThresholds)
+
+	// Removing all the thresholds clears them from the keyspace record.
+	require.NoError(t, wr.SetReplicationLagThreshold(ctx, "ks", "", nil))
+	require.NoError(t, wr.SetReplicationLagThreshold(ctx, "ks", "rdonly", nil))
+	ki, err = ts.GetKeyspace(ctx, "ks")
+	require.NoError(t, err)
+	assert.Nil(t, ki.ReplicationLagThresholds)
+
+	assert.Error(t, wr.SetReplicationLagThreshold(ctx, "nosuchks", "", nil))
+}
+
+func TestSetKeyspaceServingFlags(t *testing.T) {
+	ctx := context.Background()
+	ts := memorytopo.NewServer("cell1")
+	wr := New(logutil.NewConsoleLogger(), ts, nil)
+	require.NoError(t, ts.CreateKeyspace(ctx, "ks", &topodatapb.Keyspace{}))
+
+	require.NoError(t, wr.SetKeyspaceServingFlags(ctx, "ks", []string{topo.ServingFlagReshardingInProgress, "Custom_Flag", topo.ServingFlagReshardingInProgress}))
+	ki, err := ts.GetKeyspace(ctx, "ks")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"custom_flag", topo.ServingFlagReshardingInProgress}, ki.ServingFlags)
+
+	assert.Error(t, wr.SetKeyspaceServingFlags(ctx, "ks", []string{"bad flag"}))
+
+	// No flags clears them.
+	require.NoError(t, wr.SetKeyspaceServingFlags(ctx, "ks", nil))
+	ki, err = ts.GetKeyspace(ctx, "ks")
+	require.NoError(t, err)
+	assert.Empty(t, ki.ServingFlags)
+
+	assert.Error(t, wr.SetKeyspaceServingFlags(ctx, "nosuchks", []string{topo.ServingFlagReadOnlyMaintenance}))
+}
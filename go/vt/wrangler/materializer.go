@@ -62,6 +62,9 @@ type materializer struct {
 const (
 	createDDLAsCopy               = "copy"
 	createDDLAsCopyDropConstraint = "copy:drop_constraint"
+	// createDDLAsCopyDropForeignKeys copies the schema of the source table
+	// without its foreign keys, but with its CHECK constraints.
+	createDDLAsCopyDropForeignKeys = "copy:drop_foreign_keys"
 )
 
 // MoveTables initiates moving table(s) over to another keyspace
@@ -934,8 +937,11 @@ func (mz *materializer) deploySchema(ctx context.Context) error {
 			}
 
 			createDDL := ts.CreateDdl
-			if createDDL == createDDLAsCopy || createDDL == createDDLAsCopyDropConstraint {
+			if createDDL == createDDLAsCopy || createDDL == createDDLAsCopyDropConstraint || createDDL == createDDLAsCopyDropForeignKeys {
 				if ts.SourceExpression != "" {
+					if isRollup(ts.SourceExpression) {
+						return fmt.Errorf("the schema of rollup table %v cannot be copied from its source table, a create ddl is required", ts.TargetTable)
+					}
 					// Check for table if non-empty SourceExpression.
 					sourceTableName, err := sqlparser.TableFromStatement(ts.SourceExpression)
 					if err != nil {
@@ -960,6 +966,14 @@ func (mz *materializer) deploySchema(ctx context.Context) error {
 
 					ddl = strippedDDL
 				}
+				if createDDL == createDDLAsCopyDropForeignKeys {
+					strippedDDL, err := stripTableForeignKeys(ddl)
+					if err != nil {
+						return err
+					}
+
+					ddl = strippedDDL
+				}
 				createDDL = ddl
 			}
 
@@ -1005,6 +1019,34 @@ func stripTableConstraints(ddl string) (string, error) {
 	return newDDL, nil
 }
 
+// stripTableForeignKeys removes the foreign keys of a table, and keeps its
+// other constraints, e.g. its CHECK constraints.
+func stripTableForeignKeys(ddl string) (string, error) {
+	ast, err := sqlparser.ParseStrictDDL(ddl)
+	if err != nil {
+		return "", err
+	}
+
+	stripForeignKeys := func(cursor *sqlparser.Cursor) bool {
+		switch node := cursor.Node().(type) {
+		case sqlparser.DDLStatement:
+			if spec := node.GetTableSpec(); spec != nil {
+				var constraints []*sqlparser.ConstraintDefinition
+				for _, constraint := range spec.Constraints {
+					if _, ok := constraint.Details.(*sqlparser.ForeignKeyDefinition); !ok {
+						constraints = append(constraints, constraint)
+					}
+				}
+				spec.Constraints = constraints
+			}
+		}
+		return true
+	}
+
+	noForeignKeysAST := sqlparser.Rewrite(ast, stripForeignKeys, nil)
+	return sqlparser.String(noForeignKeysAST), nil
+}
+
 func (mz *materializer) generateInserts(ctx context.Context) (string, error) {
 	ig := vreplication.NewInsertGenerator(binlogplayer.BlpStopped, "{{.dbname}}")
 
@@ -1047,6 +1089,11 @@ func (mz *materializer) generateInserts(ctx context.Context) (string, error) {
 					if err != nil {
 						return "", err
 					}
+					// The rows of a group of a rollup table must all be
+					// streamed to the same target shard.
+					if len(sel.GroupBy) != 0 && !isGroupedBy(col, sel) {
+						return "", fmt.Errorf("vindex column %v of rollup table %v must be in the group by of its source expression: %v", col, ts.TargetTable, ts.SourceExpression)
+					}
 					mappedCols = append(mappedCols, colName)
 				}
 				subExprs := make(sqlparser.SelectExprs, 0, len(mappedCols)+2)
@@ -1087,6 +1134,29 @@ func (mz *materializer) generateInserts(ctx context.Context) (string, error) {
 	return ig.String(), nil
 }
 
+// isRollup returns true if the source expression of a table aggregates
+// its source rows into groups, e.g.
+// "select region, count(*) as orders from orders group by region".
+func isRollup(sourceExpression string) bool {
+	stmt, err := sqlparser.Parse(sourceExpression)
+	if err != nil {
+		return false
+	}
+	sel, ok := stmt.(*sqlparser.Select)
+	return ok && len(sel.GroupBy) != 0
+}
+
+// isGroupedBy returns true if the target column is in the group by of the
+// select. The group by references the target columns by their alias.
+func isGroupedBy(col sqlparser.ColIdent, sel *sqlparser.Select) bool {
+	for _, expr := range sel.GroupBy {
+		if colName, ok := expr.(*sqlparser.ColName); ok && colName.Name.Equal(col) {
+			return true
+		}
+	}
+	return false
+}
+
 func matchColInSelect(col sqlparser.ColIdent, sel *sqlparser.Select) (*sqlparser.ColName, error) {
 	for _, selExpr := range sel.SelectExprs {
 		switch selExpr := selExpr.(type) {
@@ -2179,6 +2179,27 @@ func TestMaterializerTableMismatchCopy(t *testing.T) {
 	require.EqualError(t, err, "source and target table names must match for copying schema: t2 vs t1")
 }
 
+func TestMaterializerRollupCopy(t *testing.T) {
+	ms := &vtctldatapb.MaterializeSettings{
+		Workflow:       "workflow",
+		SourceKeyspace: "sourceks",
+		TargetKeyspace: "targetks",
+		TableSettings: []*vtctldatapb.TableMaterializeSettings{{
+			TargetTable:      "t1",
+			SourceExpression: "select c1, count(*) as rcount from t1 group by c1",
+			CreateDdl:        "copy",
+		}},
+	}
+	env := newTestMaterializerEnv(t, ms, []string{"0"}, []string{"0"})
+	defer env.close()
+
+	delete(env.tmc.schema, "targetks.t1")
+
+	env.tmc.expectVRQuery(200, mzSelectFrozenQuery, &sqltypes.Result{})
+	err := env.wr.Materialize(context.Background(), ms)
+	require.EqualError(t, err, "the schema of rollup table t1 cannot be copied from its source table, a create ddl is required")
+}
+
 func TestMaterializerNoSourceTable(t *testing.T) {
 	ms := &vtctldatapb.MaterializeSettings{
 		Workflow:       "workflow",
@@ -2362,6 +2383,47 @@ func TestMaterializerNoVindexInExpression(t *testing.T) {
 	require.EqualError(t, err, "could not find vindex column c1")
 }
 
+func TestMaterializerRollupVindexNotGrouped(t *testing.T) {
+	ms := &vtctldatapb.MaterializeSettings{
+		Workflow:       "workflow",
+		SourceKeyspace: "sourceks",
+		TargetKeyspace: "targetks",
+		TableSettings: []*vtctldatapb.TableMaterializeSettings{{
+			TargetTable:      "t1",
+			SourceExpression: "select c1, c2, sum(c3) as c3 from t1 group by c2",
+			CreateDdl:        "t1ddl",
+		}},
+	}
+	env := newTestMaterializerEnv(t, ms, []string{"0"}, []string{"-80", "80-"})
+	defer env.close()
+
+	vs := &vschemapb.Keyspace{
+		Sharded: true,
+		Vindexes: map[string]*vschemapb.Vindex{
+			"hash": {
+				Type: "hash",
+			},
+		},
+		Tables: map[string]*vschemapb.Table{
+			"t1": {
+				ColumnVindexes: []*vschemapb.ColumnVindex{{
+					Column: "c1",
+					Name:   "hash",
+				}},
+			},
+		},
+	}
+
+	if err := env.topoServ.SaveVSchema(context.Background(), "targetks", vs); err != nil {
+		t.Fatal(err)
+	}
+
+	env.tmc.expectVRQuery(200, mzSelectFrozenQuery, &sqltypes.Result{})
+	env.tmc.expectVRQuery(210, mzSelectFrozenQuery, &sqltypes.Result{})
+	err := env.wr.Materialize(context.Background(), ms)
+	require.EqualError(t, err, "vindex column c1 of rollup table t1 must be in the group by of its source expression: select c1, c2, sum(c3) as c3 from t1 group by c2")
+}
+
 func TestStripConstraints(t *testing.T) {
 	tcs := []struct {
 		desc string
@@ -2435,3 +2497,29 @@ func TestStripConstraints(t *testing.T) {
 		}
 	}
 }
+
+func TestStripForeignKeys(t *testing.T) {
+	ddl := "CREATE TABLE `table1` (\n" +
+		"`id` int(11) NOT NULL AUTO_INCREMENT,\n" +
+		"`user_id` int(11) NOT NULL,\n" +
+		"`price` int(11) NOT NULL,\n" +
+		"PRIMARY KEY (`id`),\n" +
+		"KEY `fk_table1_ref_user_id` (`user_id`),\n" +
+		"CONSTRAINT `fk_table1_ref_user_id` FOREIGN KEY (`user_id`) REFERENCES `core_user` (`id`),\n" +
+		"CONSTRAINT `price_positive` CHECK (`price` > 0)\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=latin1;"
+	newDDL, err := stripTableForeignKeys(ddl)
+	require.NoError(t, err)
+	utils.MustMatch(t, "create table table1 (\n"+
+		"\tid int(11) not null auto_increment,\n"+
+		"\tuser_id int(11) not null,\n"+
+		"\tprice int(11) not null,\n"+
+		"\tPRIMARY KEY (id),\n"+
+		"\tKEY fk_table1_ref_user_id (user_id),\n"+
+		"\tconstraint price_positive check (price > 0)\n"+
+		") ENGINE InnoDB,\n"+
+		"  CHARSET latin1", newDDL)
+
+	_, err = stripTableForeignKeys("bad ddl")
+	require.Error(t, err)
+}
@@ -0,0 +1,292 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrangler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/textutil"
+	"vitess.io/vitess/go/vt/concurrency"
+	"vitess.io/vitess/go/vt/schema"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// This file runs validation queries against the shadow table of an online
+// DDL migration of the online strategy while it runs, so the transformed
+// data can be checked before the cut-over. The queries are read-only
+// SELECTs on the migrated table, which are rewritten to read its shadow
+// table on a replica of each shard, with a row limit, a time limit and a
+// limit on the rows MySQL expects to examine.
+
+// ShadowTablePreviewOptions are the guardrails of a shadow table preview.
+type ShadowTablePreviewOptions struct {
+	// MaxRows is the maximum number of rows returned by each shard. A
+	// larger or missing LIMIT is replaced with it.
+	MaxRows int
+	// Timeout is the MAX_EXECUTION_TIME of the query on each shard.
+	Timeout time.Duration
+	// MaxExaminedRows is the maximum number of rows the query plan may
+	// examine on each shard. 0 disables the check.
+	MaxExaminedRows int64
+}
+
+// ShadowTablePreview is the result of a preview query on the shadow table
+// of a migration in a shard.
+type ShadowTablePreview struct {
+	Shard       string
+	Tablet      string
+	ShadowTable string
+	Query       string
+	Result      *sqltypes.Result
+}
+
+// PreviewOnlineDDLShadowTable runs a read-only query against the shadow
+// table of a running online DDL migration, on a replica of each shard. The
+// query must be a SELECT which only reads the migrated table. The rows of
+// the shadow table are not cut over yet, so they may be incomplete.
+func (wr *Wrangler) PreviewOnlineDDLShadowTable(ctx context.Context, keyspace, uuid, query string, opts ShadowTablePreviewOptions) ([]*ShadowTablePreview, error) {
+	if !schema.IsOnlineDDLUUID(uuid) {
+		return nil, fmt.Errorf("not an online DDL UUID: %s", uuid)
+	}
+	if _, err := parsePreviewQuery(query); err != nil {
+		return nil, err
+	}
+
+	migrationQuery, err := sqlparser.ParseAndBind(
+		"select shard, mysql_table, strategy, migration_status, artifacts from _vt.schema_migrations where migration_uuid=%a",
+		sqltypes.StringBindVariable(uuid))
+	if err != nil {
+		return nil, err
+	}
+	results, err := wr.VExec(ctx, uuid, keyspace, migrationQuery, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var previews []*ShadowTablePreview
+	for _, result := range results {
+		for _, row := range result.Named().Rows {
+			shard := row["shard"].ToString()
+			if strategy := row["strategy"].ToString(); strategy != string(schema.DDLStrategyOnline) {
+				return nil, fmt.Errorf("migration %s has strategy %s: only the shadow tables of the %s strategy can be previewed", uuid, strategy, schema.DDLStrategyOnline)
+			}
+			if status := row["migration_status"].ToString(); status != string(schema.OnlineDDLStatusRunning) {
+				return nil, fmt.Errorf("migration %s is %s on shard %s: only the shadow tables of running migrations can be previewed", uuid, status, shard)
+			}
+			shadowTable := findShadowTable(uuid, row["artifacts"].ToString())
+			if shadowTable == "" {
+				return nil, fmt.Errorf("migration %s has no shadow table on shard %s yet", uuid, shard)
+			}
+			previewQuery, err := rewritePreviewQuery(query, row["mysql_table"].ToString(), shadowTable, opts)
+			if err != nil {
+				return nil, err
+			}
+			previews = append(previews, &ShadowTablePreview{Shard: shard, ShadowTable: shadowTable, Query: previewQuery})
+		}
+	}
+	if len(previews) == 0 {
+		return nil, fmt.Errorf("migration %s not found in keyspace %s", uuid, keyspace)
+	}
+	sort.Slice(previews, func(i, j int) bool { return previews[i].Shard < previews[j].Shard })
+
+	rec := concurrency.AllErrorRecorder{}
+	for _, preview := range previews {
+		if err := wr.runShadowTablePreview(ctx, keyspace, preview, opts); err != nil {
+			rec.RecordError(fmt.Errorf("shard %s: %v", preview.Shard, err))
+		}
+	}
+	if rec.HasErrors() {
+		return nil, rec.AggrError(vterrors.Aggregate)
+	}
+	return previews, nil
+}
+
+// runShadowTablePreview checks the query plan of the preview, and runs it
+// on a replica of its shard.
+func (wr *Wrangler) runShadowTablePreview(ctx context.Context, keyspace string, preview *ShadowTablePreview, opts ShadowTablePreviewOptions) error {
+	tablet, err := wr.previewTablet(ctx, keyspace, preview.Shard)
+	if err != nil {
+		return err
+	}
+	preview.Tablet = topoproto.TabletAliasString(tablet.Alias)
+
+	if opts.MaxExaminedRows > 0 {
+		p3qr, err := wr.tmc.ExecuteFetchAsApp(ctx, tablet, true, []byte("explain "+preview.Query), 100)
+		if err != nil {
+			return err
+		}
+		examined, err := explainExaminedRows(sqltypes.Proto3ToResult(p3qr))
+		if err != nil {
+			return err
+		}
+		if examined > opts.MaxExaminedRows {
+			return fmt.Errorf("the query is expected to examine about %d rows, more than %d: add a more selective WHERE clause", examined, opts.MaxExaminedRows)
+		}
+	}
+
+	p3qr, err := wr.tmc.ExecuteFetchAsApp(ctx, tablet, true, []byte(preview.Query), opts.MaxRows)
+	if err != nil {
+		return err
+	}
+	preview.Result = sqltypes.Proto3ToResult(p3qr)
+	return nil
+}
+
+// previewTablet returns a replica of the shard, or else one of its rdonly
+// tablets, so previews don't load the primary.
+func (wr *Wrangler) previewTablet(ctx context.Context, keyspace, shard string) (*topodatapb.Tablet, error) {
+	tabletMap, err := wr.ts.GetTabletMapForShard(ctx, keyspace, shard)
+	if err != nil && !topo.IsErrType(err, topo.PartialResult) {
+		return nil, err
+	}
+	aliases := make([]string, 0, len(tabletMap))
+	for alias := range tabletMap {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+	for _, tabletType := range []topodatapb.TabletType{topodatapb.TabletType_REPLICA, topodatapb.TabletType_RDONLY} {
+		for _, alias := range aliases {
+			if tabletMap[alias].Type == tabletType {
+				return tabletMap[alias].Tablet, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("shard %s/%s has no replica or rdonly tablet to run the preview on", keyspace, shard)
+}
+
+// findShadowTable returns the vreplication shadow table of the migration
+// among its artifacts, or an empty string.
+func findShadowTable(uuid, artifacts string) string {
+	for _, artifact := range textutil.SplitDelimitedList(artifacts) {
+		if strings.HasPrefix(artifact, "_"+uuid+"_") && strings.HasSuffix(artifact, "_vrepl") {
+			return artifact
+		}
+	}
+	return ""
+}
+
+// parsePreviewQuery parses a preview query, and checks it is a SELECT which
+// neither locks rows nor writes its result.
+func parsePreviewQuery(query string) (*sqlparser.Select, error) {
+	stmt, err := sqlparser.Parse(query)
+	if err != nil {
+		return nil, err
+	}
+	sel, ok := stmt.(*sqlparser.Select)
+	if !ok {
+		return nil, fmt.Errorf("only SELECT queries can preview a shadow table: %s", query)
+	}
+	if sel.Lock != sqlparser.NoLock || sel.Into != nil {
+		return nil, fmt.Errorf("preview queries cannot lock rows or use INTO: %s", query)
+	}
+	return sel, nil
+}
+
+// rewritePreviewQuery rewrites a preview query of the migrated table to
+// read the shadow table instead, with the limits of opts. The migrated
+// table keeps its name as an alias, so the columns qualified with it still
+// resolve.
+func rewritePreviewQuery(query, table, shadowTable string, opts ShadowTablePreviewOptions) (string, error) {
+	sel, err := parsePreviewQuery(query)
+	if err != nil {
+		return "", err
+	}
+
+	var rewriteErr error
+	sqlparser.Rewrite(sel, func(cursor *sqlparser.Cursor) bool {
+		switch node := cursor.Node().(type) {
+		case *sqlparser.AliasedTableExpr:
+			tableName, ok := node.Expr.(sqlparser.TableName)
+			if !ok {
+				return true
+			}
+			if !tableName.Qualifier.IsEmpty() || tableName.Name.String() != table {
+				rewriteErr = fmt.Errorf("preview queries can only read table %s, not %s", table, sqlparser.String(tableName))
+				return false
+			}
+			node.Expr = sqlparser.TableName{Name: sqlparser.NewTableIdent(shadowTable)}
+			if node.As.IsEmpty() {
+				node.As = sqlparser.NewTableIdent(table)
+			}
+		case *sqlparser.Subquery:
+			if _, ok := node.Select.(*sqlparser.Select); !ok {
+				rewriteErr = fmt.Errorf("preview queries cannot use UNION: %s", query)
+				return false
+			}
+		}
+		return true
+	}, nil)
+	if rewriteErr != nil {
+		return "", rewriteErr
+	}
+
+	if opts.MaxRows > 0 && !limitWithin(sel.Limit, opts.MaxRows) {
+		offset := sqlparser.Expr(nil)
+		if sel.Limit != nil {
+			offset = sel.Limit.Offset
+		}
+		sel.Limit = &sqlparser.Limit{Offset: offset, Rowcount: sqlparser.NewIntLiteral(strconv.Itoa(opts.MaxRows))}
+	}
+	if opts.Timeout > 0 {
+		sel.Comments = append(sqlparser.Comments{fmt.Sprintf("/*+ MAX_EXECUTION_TIME(%d) */", opts.Timeout.Milliseconds())}, sel.Comments...)
+	}
+	return sqlparser.String(sel), nil
+}
+
+// limitWithin returns true if the LIMIT returns at most maxRows rows.
+func limitWithin(limit *sqlparser.Limit, maxRows int) bool {
+	if limit == nil {
+		return false
+	}
+	literal, ok := limit.Rowcount.(*sqlparser.Literal)
+	if !ok || literal.Type != sqlparser.IntVal {
+		return false
+	}
+	rowcount, err := strconv.Atoi(literal.Val)
+	return err == nil && rowcount <= maxRows
+}
+
+// explainExaminedRows returns the number of rows the plan of an EXPLAIN
+// expects to examine: the product of the rows of its nested loops.
+func explainExaminedRows(explain *sqltypes.Result) (int64, error) {
+	var examined int64 = 1
+	for _, row := range explain.Named().Rows {
+		value, ok := row["rows"]
+		if !ok || value.IsNull() {
+			continue
+		}
+		rows, err := value.ToInt64()
+		if err != nil {
+			return 0, err
+		}
+		if rows > 0 {
+			examined *= rows
+		}
+	}
+	return examined, nil
+}
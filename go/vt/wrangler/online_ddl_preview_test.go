@@ -0,0 +1,96 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrangler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+)
+
+func TestRewritePreviewQuery(t *testing.T) {
+	shadowTable := "_bf9d54b6_58b3_11eb_87cc_0a43f95f28a3_20210120153211_vrepl"
+	opts := ShadowTablePreviewOptions{MaxRows: 100, Timeout: 5 * time.Second}
+	tcs := []struct {
+		query string
+		want  string
+		err   string
+	}{{
+		query: "select count(*) from t where c is null",
+		want:  "select /*+ MAX_EXECUTION_TIME(5000) */ count(*) from " + shadowTable + " as t where c is null limit 100",
+	}, {
+		query: "select x.id, x.c from t as x where x.id > 10 limit 5",
+		want:  "select /*+ MAX_EXECUTION_TIME(5000) */ x.id, x.c from " + shadowTable + " as x where x.id > 10 limit 5",
+	}, {
+		query: "select id from t limit 20, 1000",
+		want:  "select /*+ MAX_EXECUTION_TIME(5000) */ id from " + shadowTable + " as t limit 20, 100",
+	}, {
+		query: "select id from t where id in (select id from t where c = 1)",
+		want:  "select /*+ MAX_EXECUTION_TIME(5000) */ id from " + shadowTable + " as t where id in (select id from " + shadowTable + " as t where c = 1) limit 100",
+	}, {
+		query: "select * from t join u on t.id = u.id",
+		err:   "preview queries can only read table t, not u",
+	}, {
+		query: "select * from other.t",
+		err:   "preview queries can only read table t, not other.t",
+	}, {
+		query: "select * from t for update",
+		err:   "preview queries cannot lock rows or use INTO",
+	}, {
+		query: "delete from t",
+		err:   "only SELECT queries can preview a shadow table",
+	}, {
+		query: "select id from t union select id from t",
+		err:   "only SELECT queries can preview a shadow table",
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.query, func(t *testing.T) {
+			got, err := rewritePreviewQuery(tc.query, "t", shadowTable, opts)
+			if tc.err != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestFindShadowTable(t *testing.T) {
+	uuid := "bf9d54b6_58b3_11eb_87cc_0a43f95f28a3"
+	shadowTable := "_bf9d54b6_58b3_11eb_87cc_0a43f95f28a3_20210120153211_vrepl"
+	assert.Equal(t, shadowTable, findShadowTable(uuid, shadowTable))
+	assert.Equal(t, shadowTable, findShadowTable(uuid, "_vt_HOLD_0a43f95f28a3_20210120153211,"+shadowTable))
+	assert.Empty(t, findShadowTable(uuid, ""))
+	assert.Empty(t, findShadowTable("aaaaaaaa_58b3_11eb_87cc_0a43f95f28a3", shadowTable))
+}
+
+func TestExplainExaminedRows(t *testing.T) {
+	explain := sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields("id|select_type|table|rows", "int64|varchar|varchar|int64"),
+		"1|SIMPLE|t|200",
+		"1|SIMPLE|u|3",
+	)
+	examined, err := explainExaminedRows(explain)
+	require.NoError(t, err)
+	assert.Equal(t, int64(600), examined)
+}
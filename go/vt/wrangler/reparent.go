@@ -152,20 +152,32 @@ func (wr *Wrangler) PlannedReparentShard(ctx context.Context, keyspace, shard st
 // EmergencyReparentShard will make the provided tablet the master for
 // the shard, when the old master is completely unreachable.
 func (wr *Wrangler) EmergencyReparentShard(ctx context.Context, keyspace, shard string, masterElectTabletAlias *topodatapb.TabletAlias, waitReplicasTimeout time.Duration, ignoredTablets sets.String) (err error) {
-	_, err = reparentutil.NewEmergencyReparenter(wr.ts, wr.tmc, wr.logger).ReparentShard(
-		ctx,
-		keyspace,
-		shard,
-		reparentutil.EmergencyReparentOptions{
-			NewPrimaryAlias:     masterElectTabletAlias,
-			WaitReplicasTimeout: waitReplicasTimeout,
-			IgnoreReplicas:      ignoredTablets,
-		},
-	)
+	return wr.EmergencyReparentShardWithOptions(ctx, keyspace, shard, reparentutil.EmergencyReparentOptions{
+		NewPrimaryAlias:     masterElectTabletAlias,
+		WaitReplicasTimeout: waitReplicasTimeout,
+		IgnoreReplicas:      ignoredTablets,
+	})
+}
 
+// EmergencyReparentShardWithOptions is EmergencyReparentShard with the
+// constraints on the candidates and the dry run of the options.
+func (wr *Wrangler) EmergencyReparentShardWithOptions(ctx context.Context, keyspace, shard string, opts reparentutil.EmergencyReparentOptions) (err error) {
+	_, err = reparentutil.NewEmergencyReparenter(wr.ts, wr.tmc, wr.logger).ReparentShard(ctx, keyspace, shard, opts)
 	return err
 }
 
+// PlanReparentShard plans a reparent of the shard without changing it, for
+// the operator to review it before running it.
+func (wr *Wrangler) PlanReparentShard(ctx context.Context, keyspace, shard string, opts reparentutil.ReparentPlanOptions) (*reparentutil.ReparentPlan, error) {
+	return reparentutil.PlanReparent(ctx, wr.ts, wr.tmc, keyspace, shard, opts, wr.logger)
+}
+
+// VerifyReparentShard checks that the replicas of the shard replicate from
+// its master, typically after a reparent.
+func (wr *Wrangler) VerifyReparentShard(ctx context.Context, keyspace, shard string) (*reparentutil.ReparentVerification, error) {
+	return reparentutil.VerifyReparent(ctx, wr.ts, wr.tmc, keyspace, shard)
+}
+
 // TabletExternallyReparented changes the type of new master for this shard to MASTER
 // and updates it's tablet record in the topo. Updating the shard record is handled
 // by the new master tablet
@@ -0,0 +1,217 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrangler
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/vtctl/workflow"
+)
+
+// Once writes are switched, the reverse workflow keeps the old source up to
+// date so that the traffic can be switched back. If it breaks, a rollback
+// would lose writes. The functions below check the health of the reverse
+// workflow and report whether a rollback is still safe.
+
+var (
+	rollbackSafetyMaxLag = flag.Duration("rollback_safety_max_lag", 30*time.Second, "Maximum lag of the reverse replication of a workflow for which writes were switched, after which a rollback is reported as unsafe.")
+
+	rollbackSafeGauge = stats.NewGaugesWithMultiLabels(
+		"WorkflowRollbackSafe",
+		"Whether the writes of a switched workflow can be safely switched back: 1 if the reverse workflow is healthy, 0 otherwise",
+		[]string{"Keyspace", "Workflow"})
+	reverseReplicationLagGauge = stats.NewGaugesWithMultiLabels(
+		"WorkflowReverseReplicationLagSeconds",
+		"Replication lag of the reverse workflow of a workflow for which writes were switched",
+		[]string{"Keyspace", "Workflow"})
+
+	// lastRollbackSafety remembers the last result per workflow, so that
+	// changes are only logged once.
+	lastRollbackSafetyMu sync.Mutex
+	lastRollbackSafety   = make(map[string]bool)
+)
+
+// RollbackSafety reports whether the writes of a workflow can be switched
+// back to the original source.
+type RollbackSafety struct {
+	// Safe is true if the reverse workflow is running without errors and
+	// its lag is below -rollback_safety_max_lag.
+	Safe bool
+	// Reason explains the value of Safe.
+	Reason string
+	// ReverseKeyspace and ReverseWorkflow identify the reverse workflow.
+	ReverseKeyspace string
+	ReverseWorkflow string
+	// MaxReverseLag is the maximum lag of the reverse streams, in seconds.
+	MaxReverseLag int64
+}
+
+// CheckRollbackSafety returns whether the writes of the workflow can be
+// switched back. It returns nil if writes have not been switched.
+func (wr *Wrangler) CheckRollbackSafety(ctx context.Context, targetKeyspace, workflowName string) (*RollbackSafety, error) {
+	rsr, err := wr.getStreams(ctx, workflowName, targetKeyspace)
+	if err != nil {
+		return nil, err
+	}
+	if !writesSwitched(rsr) {
+		return nil, nil
+	}
+	return wr.checkReverseWorkflow(ctx, targetKeyspace, workflowName, rsr.SourceLocation.Keyspace), nil
+}
+
+// checkReverseWorkflow checks the reverse workflow of workflowName, which
+// runs in sourceKeyspace, and updates the stats.
+func (wr *Wrangler) checkReverseWorkflow(ctx context.Context, targetKeyspace, workflowName, sourceKeyspace string) *RollbackSafety {
+	rs := &RollbackSafety{
+		ReverseKeyspace: sourceKeyspace,
+		ReverseWorkflow: workflow.ReverseWorkflowName(workflowName),
+	}
+	rsr, err := wr.getStreams(ctx, rs.ReverseWorkflow, rs.ReverseKeyspace)
+	if err != nil {
+		rs.Reason = fmt.Sprintf("cannot read the reverse workflow %s.%s: %v", rs.ReverseKeyspace, rs.ReverseWorkflow, err)
+	} else {
+		rs.MaxReverseLag = rsr.MaxVReplicationLag
+		rs.Safe, rs.Reason = evaluateRollbackSafety(rsr, *rollbackSafetyMaxLag)
+	}
+	recordRollbackSafety(targetKeyspace, workflowName, rs)
+	return rs
+}
+
+// evaluateRollbackSafety inspects the streams of a reverse workflow.
+func evaluateRollbackSafety(rsr *ReplicationStatusResult, maxLag time.Duration) (bool, string) {
+	if rsr == nil || len(rsr.ShardStatuses) == 0 {
+		return false, "no reverse streams found"
+	}
+	shards := make([]string, 0, len(rsr.ShardStatuses))
+	for shard := range rsr.ShardStatuses {
+		shards = append(shards, shard)
+	}
+	sort.Strings(shards)
+	for _, shard := range shards {
+		for _, st := range rsr.ShardStatuses[shard].MasterReplicationStatuses {
+			switch {
+			case st.State == "Error":
+				return false, fmt.Sprintf("reverse stream %d on %s is in error: %s", st.ID, shard, st.Message)
+			case st.State == "Stopped" && st.Message != "":
+				return false, fmt.Sprintf("reverse stream %d on %s is stopped: %s", st.ID, shard, st.Message)
+			case st.State == "Stopped":
+				return false, fmt.Sprintf("reverse stream %d on %s is stopped", st.ID, shard)
+			}
+		}
+	}
+	if lag := time.Duration(rsr.MaxVReplicationLag) * time.Second; lag > maxLag {
+		return false, fmt.Sprintf("reverse replication lag %v exceeds %v", lag, maxLag)
+	}
+	return true, fmt.Sprintf("reverse replication is running, lag %ds", rsr.MaxVReplicationLag)
+}
+
+// writesSwitched returns true if the streams of a workflow were frozen by
+// SwitchWrites.
+func writesSwitched(rsr *ReplicationStatusResult) bool {
+	for _, ss := range rsr.ShardStatuses {
+		for _, st := range ss.MasterReplicationStatuses {
+			if st.Message == workflow.Frozen {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func recordRollbackSafety(keyspace, workflowName string, rs *RollbackSafety) {
+	labels := []string{keyspace, workflowName}
+	safe := int64(0)
+	if rs.Safe {
+		safe = 1
+	}
+	rollbackSafeGauge.Set(labels, safe)
+	reverseReplicationLagGauge.Set(labels, rs.MaxReverseLag)
+
+	key := keyspace + "." + workflowName
+	lastRollbackSafetyMu.Lock()
+	last, seen := lastRollbackSafety[key]
+	lastRollbackSafety[key] = rs.Safe
+	lastRollbackSafetyMu.Unlock()
+	if seen && last == rs.Safe {
+		return
+	}
+	if rs.Safe {
+		log.Infof("Workflow %s: rollback is safe: %s", key, rs.Reason)
+	} else {
+		log.Warningf("Workflow %s: rollback is NOT safe: %s", key, rs.Reason)
+	}
+}
+
+// MonitorRollbackSafety periodically checks the reverse workflows of all
+// keyspaces until the context is canceled.
+func (wr *Wrangler) MonitorRollbackSafety(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		wr.checkAllReverseWorkflows(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (wr *Wrangler) checkAllReverseWorkflows(ctx context.Context) {
+	keyspaces, err := wr.ts.GetKeyspaces(ctx)
+	if err != nil {
+		log.Warningf("MonitorRollbackSafety: cannot list keyspaces: %v", err)
+		return
+	}
+	for _, keyspace := range keyspaces {
+		workflows, err := wr.ListAllWorkflows(ctx, keyspace, false)
+		if err != nil {
+			log.Warningf("MonitorRollbackSafety: cannot list workflows of keyspace %s: %v", keyspace, err)
+			continue
+		}
+		for _, reverseWorkflow := range workflows {
+			if !strings.HasSuffix(reverseWorkflow, "_reverse") {
+				continue
+			}
+			rsr, err := wr.getStreams(ctx, reverseWorkflow, keyspace)
+			if err != nil {
+				log.Warningf("MonitorRollbackSafety: cannot read workflow %s.%s: %v", keyspace, reverseWorkflow, err)
+				continue
+			}
+			if writesSwitched(rsr) {
+				// The traffic was reversed: the workflow is not used for a
+				// rollback anymore.
+				continue
+			}
+			rs := &RollbackSafety{
+				ReverseKeyspace: keyspace,
+				ReverseWorkflow: reverseWorkflow,
+				MaxReverseLag:   rsr.MaxVReplicationLag,
+			}
+			rs.Safe, rs.Reason = evaluateRollbackSafety(rsr, *rollbackSafetyMaxLag)
+			recordRollbackSafety(rsr.SourceLocation.Keyspace, workflow.ReverseWorkflowName(reverseWorkflow), rs)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrangler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"vitess.io/vitess/go/vt/vtctl/workflow"
+)
+
+func TestEvaluateRollbackSafety(t *testing.T) {
+	newResult := func(lag int64, statuses ...*ReplicationStatus) *ReplicationStatusResult {
+		return &ReplicationStatusResult{
+			MaxVReplicationLag: lag,
+			ShardStatuses: map[string]*ShardReplicationStatus{
+				"0/zone1-0000000100": {MasterReplicationStatuses: statuses},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name   string
+		rsr    *ReplicationStatusResult
+		safe   bool
+		reason string
+	}{{
+		name:   "not found",
+		rsr:    &ReplicationStatusResult{},
+		reason: "no reverse streams found",
+	}, {
+		name:   "running",
+		rsr:    newResult(2, &ReplicationStatus{ID: 1, State: "Running"}),
+		safe:   true,
+		reason: "reverse replication is running, lag 2s",
+	}, {
+		name:   "error",
+		rsr:    newResult(2, &ReplicationStatus{ID: 1, State: "Error", Message: "Error: table not found"}),
+		reason: "reverse stream 1 on 0/zone1-0000000100 is in error: Error: table not found",
+	}, {
+		name:   "stopped",
+		rsr:    newResult(2, &ReplicationStatus{ID: 1, State: "Running"}, &ReplicationStatus{ID: 2, State: "Stopped"}),
+		reason: "reverse stream 2 on 0/zone1-0000000100 is stopped",
+	}, {
+		name:   "lagging",
+		rsr:    newResult(60, &ReplicationStatus{ID: 1, State: "Running"}),
+		reason: "reverse replication lag 1m0s exceeds 30s",
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			safe, reason := evaluateRollbackSafety(tc.rsr, 30*time.Second)
+			assert.Equal(t, tc.safe, safe)
+			assert.Equal(t, tc.reason, reason)
+		})
+	}
+}
+
+func TestWritesSwitched(t *testing.T) {
+	rsr := &ReplicationStatusResult{
+		ShardStatuses: map[string]*ShardReplicationStatus{
+			"-80/zone1-0000000100": {MasterReplicationStatuses: []*ReplicationStatus{{ID: 1, State: "Running"}}},
+		},
+	}
+	assert.False(t, writesSwitched(rsr))
+	rsr.ShardStatuses["80-/zone1-0000000200"] = &ShardReplicationStatus{
+		MasterReplicationStatuses: []*ReplicationStatus{{ID: 1, State: "Stopped", Message: workflow.Frozen}},
+	}
+	assert.True(t, writesSwitched(rsr))
+}
+
+func TestRecordRollbackSafety(t *testing.T) {
+	recordRollbackSafety("ks2", "wf_test", &RollbackSafety{Safe: true, MaxReverseLag: 3})
+	assert.EqualValues(t, 1, rollbackSafeGauge.Counts()["ks2.wf_test"])
+	assert.EqualValues(t, 3, reverseReplicationLagGauge.Counts()["ks2.wf_test"])
+
+	recordRollbackSafety("ks2", "wf_test", &RollbackSafety{Safe: false, MaxReverseLag: 90})
+	assert.EqualValues(t, 0, rollbackSafeGauge.Counts()["ks2.wf_test"])
+	assert.EqualValues(t, 90, reverseReplicationLagGauge.Counts()["ks2.wf_test"])
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"html/template"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -36,6 +37,7 @@ import (
 
 	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
 )
 
 const (
@@ -299,8 +301,16 @@ func (wr *Wrangler) ValidateVSchema(ctx context.Context, keyspace string, shards
 				return
 			}
 			for _, tableDef := range masterSchema.TableDefinitions {
-				if _, ok := vschm.Tables[tableDef.Name]; !ok {
+				table, ok := vschm.Tables[tableDef.Name]
+				if !ok {
 					notFoundTables = append(notFoundTables, tableDef.Name)
+					continue
+				}
+				if err := validateTablePartitioning(tableDef, table); err != nil {
+					shardFailures.RecordError(fmt.Errorf("%v/%v: %v", keyspace, shard, err))
+				}
+				if err := validateVindexColumns(tableDef, table); err != nil {
+					shardFailures.RecordError(fmt.Errorf("%v/%v: %v", keyspace, shard, err))
 				}
 			}
 			if len(notFoundTables) > 0 {
@@ -316,6 +326,75 @@ func (wr *Wrangler) ValidateVSchema(ctx context.Context, keyspace string, shards
 	return nil
 }
 
+// validateTablePartitioning checks the partitioning of a table is aligned
+// with its primary vindex: all the partitioning columns must be columns of
+// the vindex, so the queries routed to a shard by the vindex are also
+// pruned to a single partition.
+func validateTablePartitioning(tableDef *tabletmanagerdatapb.TableDefinition, table *vschemapb.Table) error {
+	if len(table.ColumnVindexes) == 0 {
+		return nil
+	}
+	partitioning, err := tmutils.ParseTablePartitioning(tableDef.Schema)
+	if err != nil {
+		return fmt.Errorf("cannot parse the partitioning of table %v: %v", tableDef.Name, err)
+	}
+	if partitioning == nil {
+		return nil
+	}
+	primary := table.ColumnVindexes[0]
+	vindexColumns := map[string]bool{strings.ToLower(primary.Column): true}
+	for _, column := range primary.Columns {
+		vindexColumns[strings.ToLower(column)] = true
+	}
+	var unaligned []string
+	for _, column := range partitioning.Columns {
+		if !vindexColumns[column] {
+			unaligned = append(unaligned, column)
+		}
+	}
+	if len(unaligned) > 0 {
+		return fmt.Errorf("table %v is partitioned by %v, on columns %v which are not columns of its primary vindex %v", tableDef.Name, partitioning, unaligned, primary.Name)
+	}
+	return nil
+}
+
+// validateVindexColumns checks the columns of the vindexes of a table
+// exist. The columns of its primary vindex can be generated columns, but
+// they must be stored: the value of a virtual column is only computed when
+// it is read, so the rows streamed by resharding don't carry it.
+func validateVindexColumns(tableDef *tabletmanagerdatapb.TableDefinition, table *vschemapb.Table) error {
+	if len(table.ColumnVindexes) == 0 {
+		return nil
+	}
+	var generated map[string]*tmutils.GeneratedColumn
+	if tableDef.Schema != "" && tableDef.Type != tmutils.TableView {
+		var err error
+		if generated, err = tmutils.ParseGeneratedColumns(tableDef.Schema); err != nil {
+			return fmt.Errorf("cannot parse the generated columns of table %v: %v", tableDef.Name, err)
+		}
+	}
+	var errs []string
+	for i, columnVindex := range table.ColumnVindexes {
+		columns := columnVindex.Columns
+		if columnVindex.Column != "" {
+			columns = append([]string{columnVindex.Column}, columns...)
+		}
+		for _, column := range columns {
+			if _, ok := tmutils.TableDefinitionGetColumn(tableDef, column); !ok {
+				errs = append(errs, fmt.Sprintf("table %v has no column %v of vindex %v", tableDef.Name, column, columnVindex.Name))
+				continue
+			}
+			if gc, ok := generated[strings.ToLower(column)]; ok && i == 0 && !gc.Stored {
+				errs = append(errs, fmt.Sprintf("column %v of the primary vindex %v of table %v is a virtual generated column, it must be stored", column, columnVindex.Name, tableDef.Name))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%v", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
 // PreflightSchema will try a schema change on the remote tablet.
 func (wr *Wrangler) PreflightSchema(ctx context.Context, tabletAlias *topodatapb.TabletAlias, changes []string) ([]*tabletmanagerdatapb.SchemaChangeResult, error) {
 	ti, err := wr.ts.GetTablet(ctx, tabletAlias)
@@ -325,6 +404,23 @@ func (wr *Wrangler) PreflightSchema(ctx context.Context, tabletAlias *topodatapb
 	return wr.tmc.PreflightSchema(ctx, ti.Tablet, changes)
 }
 
+// ApplySchemaShard applies the changes on the master of the shard. They
+// are propagated to the replicas through binlogs.
+func (wr *Wrangler) ApplySchemaShard(ctx context.Context, keyspace, shard string, changes []string) error {
+	si, err := wr.ts.GetShard(ctx, keyspace, shard)
+	if err != nil {
+		return fmt.Errorf("GetShard(%v, %v) failed: %v", keyspace, shard, err)
+	}
+	if !si.HasMaster() {
+		return fmt.Errorf("no master in shard record %v/%v", keyspace, shard)
+	}
+	ti, err := wr.ts.GetTablet(ctx, si.MasterAlias)
+	if err != nil {
+		return fmt.Errorf("GetTablet(%v) failed: %v", si.MasterAlias, err)
+	}
+	return wr.applySQLShard(ctx, ti, changes, true /* reloadSchema */)
+}
+
 // CopySchemaShardFromShard copies the schema from a source shard to the specified destination shard.
 // For both source and destination it picks the master tablet. See also CopySchemaShard.
 func (wr *Wrangler) CopySchemaShardFromShard(ctx context.Context, tables, excludeTables []string, includeViews bool, sourceKeyspace, sourceShard, destKeyspace, destShard string, waitReplicasTimeout time.Duration, skipVerify bool) error {
@@ -376,14 +472,11 @@ func (wr *Wrangler) CopySchemaShard(ctx context.Context, sourceTabletAlias *topo
 	if err != nil {
 		return fmt.Errorf("GetTablet(%v) failed: %v", destShardInfo.MasterAlias, err)
 	}
-	for i, sqlLine := range createSQL {
-		err = wr.applySQLShard(ctx, destTabletInfo, sqlLine, i == len(createSQL)-1)
-		if err != nil {
-			return fmt.Errorf("creating a table failed."+
-				" Most likely some tables already exist on the destination and differ from the source."+
-				" Please remove all to be copied tables from the destination manually and run this command again."+
-				" Full error: %v", err)
-		}
+	if err := wr.applySQLShard(ctx, destTabletInfo, createSQL, true /* reloadSchema */); err != nil {
+		return fmt.Errorf("creating a table failed."+
+			" Most likely some tables already exist on the destination and differ from the source."+
+			" Please remove all to be copied tables from the destination manually and run this command again."+
+			" Full error: %v", err)
 	}
 
 	// Remember the replication position after all the above were applied.
@@ -437,6 +530,10 @@ func (wr *Wrangler) copyShardMetadata(ctx context.Context, srcTabletAlias *topod
 		return fmt.Errorf("ExecuteFetchAsDba(%v, %v, 100, false, false) failed: %v", srcTabletAlias, sql, err)
 	}
 	data := sqltypes.Proto3ToResult(dataProto)
+	if len(data.Rows) == 0 {
+		return nil
+	}
+	queries := make([][]byte, 0, len(data.Rows))
 	for _, row := range data.Rows {
 		dbName := row[0]
 		name := row[1]
@@ -450,11 +547,15 @@ func (wr *Wrangler) copyShardMetadata(ctx context.Context, srcTabletAlias *topod
 		value.EncodeSQL(&queryBuf)
 		queryBuf.WriteString(") ON DUPLICATE KEY UPDATE value = ")
 		value.EncodeSQL(&queryBuf)
+		queries = append(queries, queryBuf.Bytes())
+	}
 
-		_, err := wr.ExecuteFetchAsDba(ctx, destTabletAlias, queryBuf.String(), 0, false, false)
-		if err != nil {
-			return fmt.Errorf("ExecuteFetchAsDba(%v, %v, 0, false, false) failed: %v", destTabletAlias, queryBuf.String(), err)
-		}
+	destTabletInfo, err := wr.ts.GetTablet(ctx, destTabletAlias)
+	if err != nil {
+		return fmt.Errorf("GetTablet(%v) failed: %v", destTabletAlias, err)
+	}
+	if _, err := wr.tmc.ExecuteFetchAsDbaMulti(ctx, destTabletInfo.Tablet, false, queries, 0, false, false); err != nil {
+		return fmt.Errorf("ExecuteFetchAsDbaMulti(%v, %d queries, 0, false, false) failed: %v", destTabletAlias, len(queries), err)
 	}
 	return nil
 }
@@ -474,22 +575,29 @@ func (wr *Wrangler) compareSchemas(ctx context.Context, sourceAlias, destAlias *
 	return tmutils.DiffSchemaToArray("source", sourceSd, "dest", destSd), nil
 }
 
-// applySQLShard applies a given SQL change on a given tablet alias. It allows executing arbitrary
+// applySQLShard applies the given SQL changes, in order, on a given tablet alias. It allows executing arbitrary
 // SQL statements, but doesn't return any results, so it's only useful for SQL statements
 // that would be run for their effects (e.g., CREATE).
-// It works by applying the SQL statement on the shard's master tablet with replication turned on.
+// It works by applying the SQL statements on the shard's master tablet with replication turned on.
 // Thus it should be used only for changes that can be applied on a live instance without causing issues;
 // it shouldn't be used for anything that will require a pivot.
-// The SQL statement string is expected to have {{.DatabaseName}} in place of the actual db name.
-func (wr *Wrangler) applySQLShard(ctx context.Context, tabletInfo *topo.TabletInfo, change string, reloadSchema bool) error {
-	filledChange, err := fillStringTemplate(change, map[string]string{"DatabaseName": tabletInfo.DbName()})
-	if err != nil {
-		return fmt.Errorf("fillStringTemplate failed: %v", err)
+// The SQL statement strings are expected to have {{.DatabaseName}} in place of the actual db name.
+func (wr *Wrangler) applySQLShard(ctx context.Context, tabletInfo *topo.TabletInfo, changes []string, reloadSchema bool) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	filledChanges := make([][]byte, 0, len(changes))
+	for _, change := range changes {
+		filledChange, err := fillStringTemplate(change, map[string]string{"DatabaseName": tabletInfo.DbName()})
+		if err != nil {
+			return fmt.Errorf("fillStringTemplate failed: %v", err)
+		}
+		filledChanges = append(filledChanges, []byte(filledChange))
 	}
-	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(len(changes))*30*time.Second)
 	defer cancel()
-	// Need to make sure that we enable binlog, since we're only applying the statement on masters.
-	_, err = wr.tmc.ExecuteFetchAsDba(ctx, tabletInfo.Tablet, false, []byte(filledChange), 0, false, reloadSchema)
+	// Need to make sure that we enable binlog, since we're only applying the statements on masters.
+	_, err := wr.tmc.ExecuteFetchAsDbaMulti(ctx, tabletInfo.Tablet, false, filledChanges, 0, false, reloadSchema)
 	return err
 }
 
@@ -0,0 +1,184 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrangler
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/mysqlctl/tmutils"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+	"vitess.io/vitess/go/vt/vttablet/tmclient"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// copySchemaTMClient serves the schemas of a source and a destination
+// tablet, and records the batches of queries run on the destination.
+type copySchemaTMClient struct {
+	tmclient.TabletManagerClient
+	sourceSchema, destSchema *tabletmanagerdatapb.SchemaDefinition
+	shardMetadata            *sqltypes.Result
+	// failAt is the index of the query of a batch which fails, -1 if none.
+	failAt int
+
+	batches   [][]string
+	deadlines []time.Time
+}
+
+func (tmc *copySchemaTMClient) GetSchema(ctx context.Context, tablet *topodatapb.Tablet, tables, excludeTables []string, includeViews bool) (*tabletmanagerdatapb.SchemaDefinition, error) {
+	if tablet.Shard == "src" {
+		return tmc.sourceSchema, nil
+	}
+	return tmc.destSchema, nil
+}
+
+func (tmc *copySchemaTMClient) ExecuteFetchAsDba(ctx context.Context, tablet *topodatapb.Tablet, usePool bool, query []byte, maxRows int, disableBinlogs, reloadSchema bool) (*querypb.QueryResult, error) {
+	if tmc.shardMetadata == nil {
+		return &querypb.QueryResult{}, nil
+	}
+	if string(query) == "SELECT db_name, name, value FROM _vt.shard_metadata" {
+		return sqltypes.ResultToProto3(tmc.shardMetadata), nil
+	}
+	return sqltypes.ResultToProto3(sqltypes.MakeTestResult(sqltypes.MakeTestFields("1", "int64"), "1")), nil
+}
+
+func (tmc *copySchemaTMClient) ExecuteFetchAsDbaMulti(ctx context.Context, tablet *topodatapb.Tablet, usePool bool, queries [][]byte, maxRows int, disableBinlogs, reloadSchema bool) ([]*querypb.QueryResult, error) {
+	batch := make([]string, 0, len(queries))
+	for _, query := range queries {
+		batch = append(batch, string(query))
+	}
+	tmc.batches = append(tmc.batches, batch)
+	deadline, _ := ctx.Deadline()
+	tmc.deadlines = append(tmc.deadlines, deadline)
+	if tmc.failAt >= 0 && tmc.failAt < len(queries) {
+		return nil, fmt.Errorf("query %d of %d failed: %s: table already exists", tmc.failAt+1, len(queries), queries[tmc.failAt])
+	}
+	if reloadSchema {
+		tmc.destSchema = tmc.sourceSchema
+	}
+	return make([]*querypb.QueryResult, len(queries)), nil
+}
+
+func (tmc *copySchemaTMClient) MasterPosition(ctx context.Context, tablet *topodatapb.Tablet) (string, error) {
+	return "", nil
+}
+
+func (tmc *copySchemaTMClient) ReloadSchema(ctx context.Context, tablet *topodatapb.Tablet, waitPosition string) error {
+	return nil
+}
+
+func newCopySchemaEnv(t *testing.T) (*Wrangler, *copySchemaTMClient) {
+	t.Helper()
+	ctx := context.Background()
+	ts := memorytopo.NewServer("cell1")
+	require.NoError(t, ts.CreateKeyspace(ctx, "ks", &topodatapb.Keyspace{}))
+	for i, shard := range []string{"src", "dst"} {
+		require.NoError(t, ts.CreateShard(ctx, "ks", shard))
+		master := &topodatapb.Tablet{
+			Alias:    &topodatapb.TabletAlias{Cell: "cell1", Uid: uint32(100 + i)},
+			Keyspace: "ks",
+			Shard:    shard,
+			Type:     topodatapb.TabletType_MASTER,
+		}
+		require.NoError(t, ts.CreateTablet(ctx, master))
+		_, err := ts.UpdateShardFields(ctx, "ks", shard, func(si *topo.ShardInfo) error {
+			si.MasterAlias = master.Alias
+			return nil
+		})
+		require.NoError(t, err)
+	}
+
+	tmc := &copySchemaTMClient{
+		sourceSchema: &tabletmanagerdatapb.SchemaDefinition{
+			DatabaseSchema: "CREATE DATABASE `{{.DatabaseName}}`",
+			TableDefinitions: []*tabletmanagerdatapb.TableDefinition{{
+				Name:   "t1",
+				Schema: "CREATE TABLE `t1` (`id` bigint)",
+				Type:   tmutils.TableBaseTable,
+			}, {
+				Name:   "t2",
+				Schema: "CREATE TABLE `t2` (`id` bigint)",
+				Type:   tmutils.TableBaseTable,
+			}},
+		},
+		destSchema: &tabletmanagerdatapb.SchemaDefinition{},
+		failAt:     -1,
+	}
+	return New(logutil.NewMemoryLogger(), ts, tmc), tmc
+}
+
+func TestCopySchemaShardBatch(t *testing.T) {
+	ctx := context.Background()
+	wr, tmc := newCopySchemaEnv(t)
+	tmc.shardMetadata = sqltypes.MakeTestResult(sqltypes.MakeTestFields("db_name|name|value", "varchar|varchar|varchar"),
+		"vt_ks|a|1",
+		"vt_ks|b|2",
+	)
+
+	start := time.Now()
+	err := wr.CopySchemaShard(ctx, &topodatapb.TabletAlias{Cell: "cell1", Uid: 100}, nil, nil, false, "ks", "dst", time.Second, false)
+	require.NoError(t, err)
+
+	// The shard metadata is copied in one batch, and the schema in another,
+	// with a timeout of 30s per statement.
+	require.Len(t, tmc.batches, 2)
+	assert.Equal(t, []string{
+		"INSERT INTO _vt.shard_metadata (db_name, name, value) VALUES ('vt_ks','a','1') ON DUPLICATE KEY UPDATE value = '1'",
+		"INSERT INTO _vt.shard_metadata (db_name, name, value) VALUES ('vt_ks','b','2') ON DUPLICATE KEY UPDATE value = '2'",
+	}, tmc.batches[0])
+	assert.Equal(t, []string{
+		"CREATE DATABASE `vt_ks`",
+		"CREATE TABLE `vt_ks`.`t1` (`id` bigint)",
+		"CREATE TABLE `vt_ks`.`t2` (`id` bigint)",
+	}, tmc.batches[1])
+	assert.WithinDuration(t, start.Add(3*30*time.Second), tmc.deadlines[1], 10*time.Second)
+}
+
+func TestCopySchemaShardBatchFailure(t *testing.T) {
+	ctx := context.Background()
+	wr, tmc := newCopySchemaEnv(t)
+
+	// The second table fails to be created: the batch stops there, and the
+	// error names the statement.
+	tmc.failAt = 2
+	err := wr.CopySchemaShard(ctx, &topodatapb.TabletAlias{Cell: "cell1", Uid: 100}, nil, nil, false, "ks", "dst", time.Second, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "creating a table failed")
+	assert.Contains(t, err.Error(), "query 3 of 3 failed: CREATE TABLE `vt_ks`.`t2` (`id` bigint): table already exists")
+	require.Len(t, tmc.batches, 1)
+
+	// A failure to copy the shard metadata names the batch.
+	wr, tmc = newCopySchemaEnv(t)
+	tmc.shardMetadata = sqltypes.MakeTestResult(sqltypes.MakeTestFields("db_name|name|value", "varchar|varchar|varchar"),
+		"vt_ks|a|1",
+	)
+	tmc.failAt = 0
+	err = wr.CopySchemaShard(ctx, &topodatapb.TabletAlias{Cell: "cell1", Uid: 100}, nil, nil, false, "ks", "dst", time.Second, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 queries, 0, false, false) failed: query 1 of 1 failed: INSERT INTO _vt.shard_metadata")
+}
@@ -24,6 +24,7 @@ import (
 
 	"vitess.io/vitess/go/sqltypes"
 	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
 )
 
 func TestValidateSchemaShard(t *testing.T) {
@@ -132,3 +133,51 @@ func TestValidateSchemaKeyspace(t *testing.T) {
 	shouldErr := tmeDiffs.wr.ValidateSchemaKeyspace(ctx, "ks", nil /*excludeTables*/, true /*includeViews*/, true /*skipNoMaster*/, true /*includeVSchema*/)
 	require.Error(t, shouldErr)
 }
+
+func TestValidateTablePartitioning(t *testing.T) {
+	table := &vschemapb.Table{
+		ColumnVindexes: []*vschemapb.ColumnVindex{{Name: "hash", Column: "user_id"}},
+	}
+	tableDef := &tabletmanagerdatapb.TableDefinition{
+		Name:   "t1",
+		Schema: "CREATE TABLE `t1` (`user_id` bigint, `id` bigint, PRIMARY KEY (`user_id`, `id`)) ENGINE=InnoDB",
+	}
+	require.NoError(t, validateTablePartitioning(tableDef, table))
+
+	tableDef.Schema += " PARTITION BY HASH (`user_id`) PARTITIONS 4"
+	require.NoError(t, validateTablePartitioning(tableDef, table))
+
+	tableDef.Schema = "CREATE TABLE `t1` (`user_id` bigint, `id` bigint, PRIMARY KEY (`user_id`, `id`)) ENGINE=InnoDB PARTITION BY KEY (`user_id`, `id`) PARTITIONS 4"
+	err := validateTablePartitioning(tableDef, table)
+	require.EqualError(t, err, "table t1 is partitioned by KEY (user_id,id), on columns [id] which are not columns of its primary vindex hash")
+
+	// A table without vindexes, e.g. in an unsharded keyspace, can be partitioned in any way.
+	require.NoError(t, validateTablePartitioning(tableDef, &vschemapb.Table{}))
+}
+
+func TestValidateVindexColumns(t *testing.T) {
+	table := &vschemapb.Table{
+		ColumnVindexes: []*vschemapb.ColumnVindex{
+			{Name: "hash", Column: "user_id"},
+			{Name: "email_lookup", Columns: []string{"email_lower"}},
+		},
+	}
+	tableDef := &tabletmanagerdatapb.TableDefinition{
+		Name:    "t1",
+		Columns: []string{"id", "user_id", "email", "email_lower"},
+		Schema: "CREATE TABLE `t1` (`id` bigint, `user_id` bigint GENERATED ALWAYS AS (`id` >> 32) STORED, `email` varchar(128), " +
+			"`email_lower` varchar(128) GENERATED ALWAYS AS (lower(`email`)) VIRTUAL, PRIMARY KEY (`id`)) ENGINE=InnoDB",
+	}
+	// The secondary vindexes can use virtual columns.
+	require.NoError(t, validateVindexColumns(tableDef, table))
+
+	table.ColumnVindexes[0].Column = "email_lower"
+	err := validateVindexColumns(tableDef, table)
+	require.EqualError(t, err, "column email_lower of the primary vindex hash of table t1 is a virtual generated column, it must be stored")
+
+	table.ColumnVindexes[0].Column = "missing"
+	err = validateVindexColumns(tableDef, table)
+	require.EqualError(t, err, "table t1 has no column missing of vindex hash")
+
+	require.NoError(t, validateVindexColumns(tableDef, &vschemapb.Table{}))
+}
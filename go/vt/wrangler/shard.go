@@ -113,6 +113,29 @@ func (wr *Wrangler) UpdateSrvKeyspacePartitions(ctx context.Context, keyspace, s
 	return wr.ts.AddSrvKeyspacePartitions(ctx, keyspace, []*topo.ShardInfo{si}, tabletType, cells)
 }
 
+// UpdateSrvKeyspace replaces the SrvKeyspace of a keyspace in a cell, if it
+// is still at expectedVersion, after validating its partitions. It is meant
+// for manual recoveries, instead of writing the SrvKeyspace to the topo by
+// hand. The keyspace is locked, so it doesn't race with a rebuild of its
+// serving graph.
+func (wr *Wrangler) UpdateSrvKeyspace(ctx context.Context, cell, keyspace string, srvKeyspace *topodatapb.SrvKeyspace, expectedVersion string) (version topo.Version, err error) {
+	ctx, unlock, lockErr := wr.ts.LockKeyspace(ctx, keyspace, fmt.Sprintf("UpdateSrvKeyspace(%v,%v)", cell, keyspace))
+	if lockErr != nil {
+		return nil, lockErr
+	}
+	defer unlock(&err)
+
+	version, err = wr.ts.UpdateSrvKeyspaceIfVersion(ctx, cell, keyspace, srvKeyspace, expectedVersion)
+	if err != nil {
+		if topo.IsErrType(err, topo.BadVersion) {
+			return nil, fmt.Errorf("the SrvKeyspace of %v in cell %v changed since version %v, get it again: %v", keyspace, cell, expectedVersion, err)
+		}
+		return nil, err
+	}
+	wr.Logger().Infof("Updated the SrvKeyspace of %v in cell %v to version %v", keyspace, cell, version)
+	return version, nil
+}
+
 // DeleteShard will do all the necessary changes in the topology server
 // to entirely remove a shard.
 func (wr *Wrangler) DeleteShard(ctx context.Context, keyspace, shard string, recursive, evenIfServing bool) error {
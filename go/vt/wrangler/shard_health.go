@@ -0,0 +1,114 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrangler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/vt/grpcclient"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vttablet/tabletconn"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// shardHealthTimeout is how long CheckShardHealth waits for the health
+// record of a tablet.
+var shardHealthTimeout = 30 * time.Second
+
+// CheckShardHealth checks the latest health record of the serving
+// tablets of the shard. It fails if a tablet reports a health error, or
+// if the replication lag of a replica exceeds maxReplicationLag. 0 means
+// the lag isn't checked.
+func (wr *Wrangler) CheckShardHealth(ctx context.Context, keyspace, shard string, maxReplicationLag time.Duration) error {
+	tabletMap, err := wr.ts.GetTabletMapForShard(ctx, keyspace, shard)
+	if err != nil {
+		return fmt.Errorf("GetTabletMapForShard(%v, %v) failed: %v", keyspace, shard, err)
+	}
+
+	var mu sync.Mutex
+	var problems []string
+	var wg sync.WaitGroup
+	for _, ti := range tabletMap {
+		if !topo.IsRunningQueryService(ti.Type) {
+			continue
+		}
+		wg.Add(1)
+		go func(ti *topo.TabletInfo) {
+			defer wg.Done()
+			if err := checkTabletHealth(ctx, ti.Tablet, maxReplicationLag); err != nil {
+				mu.Lock()
+				problems = append(problems, fmt.Sprintf("%v: %v", topoproto.TabletAliasString(ti.Alias), err))
+				mu.Unlock()
+			}
+		}(ti)
+	}
+	wg.Wait()
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return fmt.Errorf("shard %v/%v is not healthy: %v", keyspace, shard, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// CheckTabletHealth checks the latest health record of the tablet, like
+// CheckShardHealth does for the tablets of a shard.
+func (wr *Wrangler) CheckTabletHealth(ctx context.Context, tabletAlias *topodatapb.TabletAlias, maxReplicationLag time.Duration) error {
+	ti, err := wr.ts.GetTablet(ctx, tabletAlias)
+	if err != nil {
+		return err
+	}
+	return checkTabletHealth(ctx, ti.Tablet, maxReplicationLag)
+}
+
+// checkTabletHealth checks the next health record of the tablet.
+func checkTabletHealth(ctx context.Context, tablet *topodatapb.Tablet, maxReplicationLag time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, shardHealthTimeout)
+	defer cancel()
+	conn, err := tabletconn.GetDialer()(tablet, grpcclient.FailFast(true))
+	if err != nil {
+		return err
+	}
+	defer conn.Close(ctx)
+
+	var stats *querypb.RealtimeStats
+	err = conn.StreamHealth(ctx, func(shr *querypb.StreamHealthResponse) error {
+		stats = shr.RealtimeStats
+		return io.EOF
+	})
+	switch {
+	case err != nil:
+		return err
+	case stats == nil:
+		return fmt.Errorf("health record does not include RealtimeStats message")
+	case stats.HealthError != "":
+		return fmt.Errorf("health error: %v", stats.HealthError)
+	}
+	lag := time.Duration(stats.SecondsBehindMaster) * time.Second
+	if tablet.Type != topodatapb.TabletType_MASTER && maxReplicationLag > 0 && lag > maxReplicationLag {
+		return fmt.Errorf("replication lag %v exceeds %v", lag, maxReplicationLag)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrangler
+
+import (
+	"context"
+	"fmt"
+
+	"vitess.io/vitess/go/vt/vtctl/workflow"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// SplitReads switches a percentage of the reads of the tables of a
+// MoveTables workflow to its target keyspace, instead of all of them like
+// SwitchReads, so the reads can be switched progressively, e.g. 1%, 10%,
+// 50%, then 100%. The routing rules of the tables get weights, which the
+// vtgates use to pick the keyspace of each query. 0% routes all the reads
+// to the source keyspace again, and 100% is the same as SwitchReads.
+func (wr *Wrangler) SplitReads(ctx context.Context, targetKeyspace, workflowName string, servedTypes []topodatapb.TabletType, cells []string, percent int) (err error) {
+	if err := workflow.ValidateSplitReads(servedTypes, percent); err != nil {
+		return err
+	}
+	ts, err := wr.buildTrafficSwitcher(ctx, targetKeyspace, workflowName)
+	if err != nil {
+		return err
+	}
+	if ts.migrationType != binlogdatapb.MigrationType_TABLES {
+		return fmt.Errorf("the reads of workflow %v.%v can't be split: only the reads of MoveTables workflows can", targetKeyspace, workflowName)
+	}
+	if err := ts.validate(ctx); err != nil {
+		return err
+	}
+
+	// For reads, locking the source keyspace is sufficient.
+	ctx, unlock, lockErr := wr.ts.LockKeyspace(ctx, ts.sourceKeyspace, "SplitReads")
+	if lockErr != nil {
+		return lockErr
+	}
+	defer unlock(&err)
+
+	if err := workflow.SplitTableReads(ctx, wr.ts, ts.sourceKeyspace, ts.targetKeyspace, ts.tables, servedTypes, cells, percent); err != nil {
+		return err
+	}
+	wr.Logger().Infof("Routed %d%% of the %v reads of the tables of workflow %v.%v to keyspace %v", percent, servedTypes, targetKeyspace, workflowName, targetKeyspace)
+	return nil
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrangler
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/topotools"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func TestSplitReads(t *testing.T) {
+	ctx := context.Background()
+	tme := newTestTableMigrater(ctx, t)
+	defer tme.stopTablets(t)
+	rdonly := []topodatapb.TabletType{topodatapb.TabletType_RDONLY}
+
+	require.NoError(t, tme.wr.SplitReads(ctx, tme.targetKeyspace, "test", rdonly, nil, 10))
+	checkRouting(t, tme.wr, map[string][]string{
+		"t1":            {"ks1.t1"},
+		"ks2.t1":        {"ks1.t1"},
+		"t2":            {"ks1.t2"},
+		"ks2.t2":        {"ks1.t2"},
+		"t1@rdonly":     {"ks1.t1", "ks2.t1"},
+		"ks2.t1@rdonly": {"ks1.t1", "ks2.t1"},
+		"ks1.t1@rdonly": {"ks1.t1", "ks2.t1"},
+		"t2@rdonly":     {"ks1.t2", "ks2.t2"},
+		"ks2.t2@rdonly": {"ks1.t2", "ks2.t2"},
+		"ks1.t2@rdonly": {"ks1.t2", "ks2.t2"},
+	})
+	rules, err := tme.wr.ts.GetRoutingRules(ctx)
+	require.NoError(t, err)
+	for _, rule := range rules.Rules {
+		if strings.HasSuffix(rule.FromTable, "@rdonly") {
+			assert.Equal(t, []int32{90, 10}, rule.Weights, rule.FromTable)
+		} else {
+			assert.Empty(t, rule.Weights, rule.FromTable)
+		}
+	}
+
+	// Updating the other rules keeps the weights.
+	routing, err := topotools.GetRoutingRules(ctx, tme.wr.ts)
+	require.NoError(t, err)
+	routing["t2@rdonly"] = []string{"ks1.t2"}
+	require.NoError(t, topotools.SaveRoutingRules(ctx, tme.wr.ts, routing))
+	rules, err = tme.wr.ts.GetRoutingRules(ctx)
+	require.NoError(t, err)
+	for _, rule := range rules.Rules {
+		switch rule.FromTable {
+		case "t1@rdonly":
+			assert.Equal(t, []int32{90, 10}, rule.Weights)
+		case "t2@rdonly":
+			assert.Empty(t, rule.Weights)
+		}
+	}
+	require.NoError(t, tme.wr.SplitReads(ctx, tme.targetKeyspace, "test", rdonly, nil, 10))
+
+	// 100% is the same as SwitchReads.
+	require.NoError(t, tme.wr.SplitReads(ctx, tme.targetKeyspace, "test", rdonly, nil, 100))
+	checkRouting(t, tme.wr, map[string][]string{
+		"t1":            {"ks1.t1"},
+		"ks2.t1":        {"ks1.t1"},
+		"t2":            {"ks1.t2"},
+		"ks2.t2":        {"ks1.t2"},
+		"t1@rdonly":     {"ks2.t1"},
+		"ks2.t1@rdonly": {"ks2.t1"},
+		"ks1.t1@rdonly": {"ks2.t1"},
+		"t2@rdonly":     {"ks2.t2"},
+		"ks2.t2@rdonly": {"ks2.t2"},
+		"ks1.t2@rdonly": {"ks2.t2"},
+	})
+
+	// 0% routes the reads back to the source.
+	require.NoError(t, tme.wr.SplitReads(ctx, tme.targetKeyspace, "test", rdonly, nil, 0))
+	checkRouting(t, tme.wr, map[string][]string{
+		"t1":            {"ks1.t1"},
+		"ks2.t1":        {"ks1.t1"},
+		"t2":            {"ks1.t2"},
+		"ks2.t2":        {"ks1.t2"},
+		"t1@rdonly":     {"ks1.t1"},
+		"ks2.t1@rdonly": {"ks1.t1"},
+		"ks1.t1@rdonly": {"ks1.t1"},
+		"t2@rdonly":     {"ks1.t2"},
+		"ks2.t2@rdonly": {"ks1.t2"},
+		"ks1.t2@rdonly": {"ks1.t2"},
+	})
+
+	assert.Error(t, tme.wr.SplitReads(ctx, tme.targetKeyspace, "test", rdonly, nil, 101))
+	assert.Error(t, tme.wr.SplitReads(ctx, tme.targetKeyspace, "test", []topodatapb.TabletType{topodatapb.TabletType_MASTER}, nil, 10))
+}
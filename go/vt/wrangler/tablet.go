@@ -29,8 +29,10 @@ import (
 	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/topo/topoproto"
 	"vitess.io/vitess/go/vt/topotools"
+	"vitess.io/vitess/go/vt/vtctl/tablettypeutil"
 
 	querypb "vitess.io/vitess/go/vt/proto/query"
+	replicationdatapb "vitess.io/vitess/go/vt/proto/replicationdata"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 )
 
@@ -157,9 +159,13 @@ func (wr *Wrangler) DeleteTablet(ctx context.Context, tabletAlias *topodatapb.Ta
 // ChangeTabletType changes the type of tablet and recomputes all
 // necessary derived paths in the serving graph, if necessary.
 //
+// Unless force is set, it fails if fewer serving tablets of the former
+// type than the -min_tablets_per_cell minimum would be left in the shard
+// and cell of the tablet.
+//
 // Note we don't update the master record in the Shard here, as we
 // can't ChangeType from and out of master anyway.
-func (wr *Wrangler) ChangeTabletType(ctx context.Context, tabletAlias *topodatapb.TabletAlias, tabletType topodatapb.TabletType) error {
+func (wr *Wrangler) ChangeTabletType(ctx context.Context, tabletAlias *topodatapb.TabletAlias, tabletType topodatapb.TabletType, force bool) error {
 	// Load tablet to find endpoint, and keyspace and shard assignment.
 	ti, err := wr.ts.GetTablet(ctx, tabletAlias)
 	if err != nil {
@@ -169,11 +175,63 @@ func (wr *Wrangler) ChangeTabletType(ctx context.Context, tabletAlias *topodatap
 	if !topo.IsTrivialTypeChange(ti.Type, tabletType) {
 		return fmt.Errorf("tablet %v type change %v -> %v is not an allowed transition for ChangeTabletType", tabletAlias, ti.Type, tabletType)
 	}
+	if !force {
+		if _, err := tablettypeutil.Check(ctx, wr.ts, ti.Tablet, tabletType); err != nil {
+			return err
+		}
+	}
 
 	// and ask the tablet to make the change
 	return wr.tmc.ChangeType(ctx, ti.Tablet, tabletType)
 }
 
+// SetTabletDrained drains or undrains a tablet. The gateways stop sending
+// new queries to a drained tablet, but let the in-flight ones finish. It
+// only applies to non-master tablets.
+func (wr *Wrangler) SetTabletDrained(ctx context.Context, tabletAlias *topodatapb.TabletAlias, drained bool) error {
+	return topotools.SetTabletDrained(ctx, wr.ts, tabletAlias, drained)
+}
+
+// SetTabletTrafficWeight sets the traffic weight of a tablet, from 0 to
+// topoproto.MaxTabletTrafficWeight. The vtgates send it a share of the
+// queries of its target proportional to its weight.
+func (wr *Wrangler) SetTabletTrafficWeight(ctx context.Context, tabletAlias *topodatapb.TabletAlias, weight int) error {
+	_, err := wr.ts.UpdateTabletFields(ctx, tabletAlias, func(tablet *topodatapb.Tablet) error {
+		if topoproto.TabletTrafficWeight(tablet) == weight {
+			return topo.NewError(topo.NoUpdateNeeded, topoproto.TabletAliasString(tabletAlias))
+		}
+		return topoproto.SetTabletTrafficWeight(tablet, weight)
+	})
+	return err
+}
+
+// ReplicationStatus returns the replication status of a tablet.
+func (wr *Wrangler) ReplicationStatus(ctx context.Context, tabletAlias *topodatapb.TabletAlias) (*replicationdatapb.Status, error) {
+	ti, err := wr.ts.GetTablet(ctx, tabletAlias)
+	if err != nil {
+		return nil, err
+	}
+	return wr.tmc.ReplicationStatus(ctx, ti.Tablet)
+}
+
+// StopReplication stops the replication of a tablet.
+func (wr *Wrangler) StopReplication(ctx context.Context, tabletAlias *topodatapb.TabletAlias) error {
+	ti, err := wr.ts.GetTablet(ctx, tabletAlias)
+	if err != nil {
+		return err
+	}
+	return wr.tmc.StopReplication(ctx, ti.Tablet)
+}
+
+// StartReplication starts the replication of a tablet.
+func (wr *Wrangler) StartReplication(ctx context.Context, tabletAlias *topodatapb.TabletAlias) error {
+	ti, err := wr.ts.GetTablet(ctx, tabletAlias)
+	if err != nil {
+		return err
+	}
+	return wr.tmc.StartReplication(ctx, ti.Tablet)
+}
+
 // RefreshTabletState refreshes tablet state
 func (wr *Wrangler) RefreshTabletState(ctx context.Context, tabletAlias *topodatapb.TabletAlias) error {
 	// Load tablet to find endpoint, and keyspace and shard assignment.
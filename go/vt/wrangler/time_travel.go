@@ -0,0 +1,258 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrangler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"vitess.io/vitess/go/vt/binlog/binlogarchive"
+	"vitess.io/vitess/go/vt/hook"
+	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/mysqlctl"
+	"vitess.io/vitess/go/vt/mysqlctl/backupstorage"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// This file implements the time-travel keyspaces: SNAPSHOT keyspaces of a
+// base keyspace as of a past time. Their tablets restore the closest
+// backup of the base keyspace taken before that time, and replay the
+// binlogs up to it. They are provisioned by the
+// provision_time_travel_tablet hook, run on vtctld for each shard, and
+// are only routed by vtgate for the queries which qualify the tables with
+// the name of the time-travel keyspace, e.g. commerce_asof_20210102150405.
+
+const (
+	// timeTravelSeparator separates the base keyspace from the time in the
+	// name of the time-travel keyspaces.
+	timeTravelSeparator = "_asof_"
+	// timeTravelTimeFormat is the format of the time in the name of the
+	// time-travel keyspaces, in UTC.
+	timeTravelTimeFormat = "20060102150405"
+
+	provisionTimeTravelHook   = "provision_time_travel_tablet"
+	deprovisionTimeTravelHook = "deprovision_time_travel_tablet"
+)
+
+// TimeTravelPlan describes how the shards of a time-travel keyspace are
+// restored.
+type TimeTravelPlan struct {
+	Keyspace     string
+	BaseKeyspace string
+	AsOf         time.Time
+	Shards       []*TimeTravelShard
+}
+
+// TimeTravelShard describes how a shard of a time-travel keyspace is
+// restored: from Backup, then by replaying the binlogs from
+// BackupPosition up to the time of the keyspace. BinlogSegments are the
+// segments of the binlog archive of the shard which hold these binlogs, if
+// the shard is archived.
+type TimeTravelShard struct {
+	Shard          string
+	Backup         string
+	BackupTime     string
+	BackupPosition string
+	BinlogSegments []string `json:",omitempty"`
+}
+
+// TimeTravelKeyspaceName returns the name of the time-travel keyspace of
+// the base keyspace as of the time.
+func TimeTravelKeyspaceName(baseKeyspace string, asOf time.Time) string {
+	return baseKeyspace + timeTravelSeparator + asOf.UTC().Format(timeTravelTimeFormat)
+}
+
+// PlanTimeTravel locates, for each shard of the base keyspace, the closest
+// backup taken before asOf and the binlogs to replay from it.
+func (wr *Wrangler) PlanTimeTravel(ctx context.Context, baseKeyspace string, asOf time.Time) (*TimeTravelPlan, error) {
+	if asOf.After(time.Now()) {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "cannot time travel to %v, it is in the future", asOf)
+	}
+	shards, err := wr.ts.GetShardNames(ctx, baseKeyspace)
+	if err != nil {
+		return nil, err
+	}
+	bs, err := backupstorage.GetBackupStorage()
+	if err != nil {
+		return nil, err
+	}
+	defer bs.Close()
+
+	plan := &TimeTravelPlan{
+		Keyspace:     TimeTravelKeyspaceName(baseKeyspace, asOf),
+		BaseKeyspace: baseKeyspace,
+		AsOf:         asOf.UTC(),
+	}
+	for _, shard := range shards {
+		shardPlan, err := wr.planTimeTravelShard(ctx, bs, baseKeyspace, shard, asOf)
+		if err != nil {
+			return nil, vterrors.Wrapf(err, "cannot time travel shard %v/%v to %v", baseKeyspace, shard, asOf)
+		}
+		plan.Shards = append(plan.Shards, shardPlan)
+	}
+	return plan, nil
+}
+
+func (wr *Wrangler) planTimeTravelShard(ctx context.Context, bs backupstorage.BackupStorage, keyspace, shard string, asOf time.Time) (*TimeTravelShard, error) {
+	bhs, err := bs.ListBackups(ctx, mysqlctl.GetBackupDir(keyspace, shard))
+	if err != nil {
+		return nil, err
+	}
+	if len(bhs) == 0 {
+		return nil, mysqlctl.ErrNoBackup
+	}
+	// This is the backup the tablets restore for the SNAPSHOT keyspace.
+	bh, err := mysqlctl.FindBackupToRestore(ctx, mysqlctl.RestoreParams{
+		Logger:    wr.Logger(),
+		Keyspace:  keyspace,
+		Shard:     shard,
+		StartTime: asOf,
+	}, bhs)
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := mysqlctl.GetBackupManifest(ctx, bh)
+	if err != nil {
+		return nil, err
+	}
+	shardPlan := &TimeTravelShard{
+		Shard:          shard,
+		Backup:         bh.Name(),
+		BackupTime:     manifest.BackupTime,
+		BackupPosition: manifest.Position.String(),
+	}
+
+	segments, err := binlogarchive.ListSegments(ctx, bs, keyspace, shard)
+	if err != nil {
+		return nil, err
+	}
+	for _, segment := range segments {
+		if manifest.Position.AtLeast(segment.Manifest.EndPosition) || segment.Manifest.StartTime.After(asOf) {
+			continue
+		}
+		shardPlan.BinlogSegments = append(shardPlan.BinlogSegments, segment.Name())
+	}
+	return shardPlan, nil
+}
+
+// CreateTimeTravelKeyspace creates the time-travel keyspace of the plan,
+// and runs the provision_time_travel_tablet hook for each of its shards.
+// The hook gets the keyspace, shard, tablet type and the tablet tags to
+// start a vttablet with in its environment.
+func (wr *Wrangler) CreateTimeTravelKeyspace(ctx context.Context, plan *TimeTravelPlan) error {
+	ki := &topodatapb.Keyspace{
+		KeyspaceType: topodatapb.KeyspaceType_SNAPSHOT,
+		BaseKeyspace: plan.BaseKeyspace,
+		SnapshotTime: logutil.TimeToProto(plan.AsOf),
+	}
+	if err := wr.ts.CreateKeyspace(ctx, plan.Keyspace, ki); err != nil {
+		return err
+	}
+
+	// Like for the other SNAPSHOT keyspaces, the vschema of the base
+	// keyspace is copied, and excluded from global routing so that only
+	// the queries qualified with the time-travel keyspace reach it.
+	vs, err := wr.ts.GetVSchema(ctx, plan.BaseKeyspace)
+	switch {
+	case topo.IsErrType(err, topo.NoNode):
+		vs = &vschemapb.Keyspace{
+			Tables:   make(map[string]*vschemapb.Table),
+			Vindexes: make(map[string]*vschemapb.Vindex),
+		}
+	case err != nil:
+		return err
+	}
+	vs.RequireExplicitRouting = true
+	if err := wr.ts.SaveVSchema(ctx, plan.Keyspace, vs); err != nil {
+		return err
+	}
+	if err := wr.ts.RebuildSrvVSchema(ctx, nil /* cells */); err != nil {
+		return err
+	}
+
+	for _, shard := range plan.Shards {
+		if err := wr.runTimeTravelHook(ctx, provisionTimeTravelHook, plan, shard.Shard); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteTimeTravelKeyspace runs the deprovision_time_travel_tablet hook
+// for each shard of the time-travel keyspace, then deletes it with its
+// tablets.
+func (wr *Wrangler) DeleteTimeTravelKeyspace(ctx context.Context, keyspace string) error {
+	ki, err := wr.ts.GetKeyspace(ctx, keyspace)
+	if err != nil {
+		return err
+	}
+	if ki.KeyspaceType != topodatapb.KeyspaceType_SNAPSHOT || !strings.HasPrefix(keyspace, ki.BaseKeyspace+timeTravelSeparator) {
+		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "keyspace %v is not a time-travel keyspace", keyspace)
+	}
+	plan := &TimeTravelPlan{
+		Keyspace:     keyspace,
+		BaseKeyspace: ki.BaseKeyspace,
+		AsOf:         logutil.ProtoToTime(ki.SnapshotTime),
+	}
+	shards, err := wr.ts.GetShardNames(ctx, keyspace)
+	if err != nil {
+		return err
+	}
+	for _, shard := range shards {
+		if err := wr.runTimeTravelHook(ctx, deprovisionTimeTravelHook, plan, shard); err != nil {
+			return err
+		}
+	}
+	if err := wr.DeleteKeyspace(ctx, keyspace, true /* recursive */); err != nil {
+		return err
+	}
+	return wr.ts.RebuildSrvVSchema(ctx, nil /* cells */)
+}
+
+// runTimeTravelHook runs a time-travel hook for a shard. If the hook
+// doesn't exist, it logs how to start the tablet of the shard by hand.
+func (wr *Wrangler) runTimeTravelHook(ctx context.Context, name string, plan *TimeTravelPlan, shard string) error {
+	env := map[string]string{
+		"KEYSPACE":      plan.Keyspace,
+		"SHARD":         shard,
+		"BASE_KEYSPACE": plan.BaseKeyspace,
+		"AS_OF":         plan.AsOf.Format(time.RFC3339),
+		"TABLET_TYPE":   strings.ToLower(topodatapb.TabletType_RDONLY.String()),
+		"TABLET_TAGS":   "time_travel:" + plan.AsOf.Format(timeTravelTimeFormat),
+	}
+	hr := hook.NewHookWithEnv(name, nil, env).ExecuteContext(ctx)
+	switch hr.ExitStatus {
+	case hook.HOOK_SUCCESS:
+		return nil
+	case hook.HOOK_DOES_NOT_EXIST:
+		if name == provisionTimeTravelHook {
+			wr.Logger().Warningf("No %v hook: start a vttablet for %v/%v with -init_keyspace %v -init_shard %v -init_tablet_type %v -init_tags %v, and the -binlog_host flags of the binlog server", name, plan.Keyspace, shard, plan.Keyspace, shard, env["TABLET_TYPE"], env["TABLET_TAGS"])
+		} else {
+			wr.Logger().Warningf("No %v hook: stop the vttablets of %v/%v", name, plan.Keyspace, shard)
+		}
+		return nil
+	default:
+		return fmt.Errorf("%v hook failed for %v/%v: %v", name, plan.Keyspace, shard, hr.String())
+	}
+}
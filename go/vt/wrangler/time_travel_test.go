@@ -0,0 +1,84 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrangler
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/mysqlctl"
+	"vitess.io/vitess/go/vt/mysqlctl/filebackupstorage"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+)
+
+func TestTimeTravelKeyspaceName(t *testing.T) {
+	asOf := time.Date(2021, 1, 2, 15, 4, 5, 0, time.FixedZone("UTC+1", 3600))
+	assert.Equal(t, "commerce_asof_20210102140405", TimeTravelKeyspaceName("commerce", asOf))
+}
+
+func TestPlanTimeTravelShard(t *testing.T) {
+	root, err := ioutil.TempDir("", "timetravel")
+	require.NoError(t, err)
+	defer os.RemoveAll(root)
+	defer func(saved string) { *filebackupstorage.FileBackupStorageRoot = saved }(*filebackupstorage.FileBackupStorageRoot)
+	*filebackupstorage.FileBackupStorageRoot = root
+	bs := &filebackupstorage.FileBackupStorage{}
+	ctx := context.Background()
+
+	backup := func(name string, backupTime time.Time, pos string) {
+		bh, err := bs.StartBackup(ctx, mysqlctl.GetBackupDir("ks", "0"), name)
+		require.NoError(t, err)
+		w, err := bh.AddFile(ctx, "MANIFEST", 0)
+		require.NoError(t, err)
+		data, err := json.Marshal(&mysqlctl.BackupManifest{
+			BackupMethod: "builtin",
+			Position:     mysql.MustParsePosition(mysql.MariadbFlavorID, pos),
+			BackupTime:   backupTime.Format(time.RFC3339),
+		})
+		require.NoError(t, err)
+		_, err = w.Write(data)
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+		require.NoError(t, bh.EndBackup(ctx))
+	}
+	day1 := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	backup("2021-01-01.000000.cell1-0000000100", day1, "0-1-10")
+	backup("2021-01-03.000000.cell1-0000000100", day1.Add(48*time.Hour), "0-1-30")
+
+	wr := New(logutil.NewMemoryLogger(), memorytopo.NewServer("cell1"), nil)
+	shardPlan, err := wr.planTimeTravelShard(ctx, bs, "ks", "0", day1.Add(24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, &TimeTravelShard{
+		Shard:          "0",
+		Backup:         "2021-01-01.000000.cell1-0000000100",
+		BackupTime:     "2021-01-01T00:00:00Z",
+		BackupPosition: "0-1-10",
+	}, shardPlan)
+
+	// There is no backup before the first one.
+	_, err = wr.planTimeTravelShard(ctx, bs, "ks", "0", day1.Add(-time.Hour))
+	assert.Error(t, err)
+}
@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"sort"
 	"strings"
@@ -54,6 +55,10 @@ import (
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 )
 
+var (
+	vdiffSourceLagRetries = flag.Int("vdiff_source_lag_retries", 3, "Number of times a VDiff with a maximum source lag is restarted with other tablets if it failed while one of its tablets was lagging.")
+)
+
 // DiffReport is the summary of differences for one table.
 type DiffReport struct {
 	ProcessedRows         int
@@ -65,6 +70,9 @@ type DiffReport struct {
 	ExtraRowsTargetSample []*RowDiff
 	MismatchedRowsSample  []*DiffMismatch
 	TableName             string
+	// Incremental is true if only the rows modified since the previous
+	// VDiff were compared.
+	Incremental bool
 }
 
 // DiffMismatch is a sample of row diffs between source and target.
@@ -97,6 +105,14 @@ type vdiff struct {
 	workflow       string
 	targetKeyspace string
 	tables         []string
+
+	// incremental is set for an incremental VDiff.
+	incremental *vdiffIncremental
+
+	// maxSourceLag is the maximum replication lag of the tablets read
+	// from, or 0 for no limit.
+	maxSourceLag         time.Duration
+	waitForSourceCatchup bool
 }
 
 // compareColInfo contains the metadata for a column of the table being diffed
@@ -126,6 +142,10 @@ type tableDiffer struct {
 	// selectPks is the list of pk columns as they appear in the select clause for the diff.
 	selectPks []int
 
+	// incremental is true if only the rows modified since the previous
+	// VDiff are compared.
+	incremental bool
+
 	// source Primitive and targetPrimitive are used for streaming
 	sourcePrimitive engine.Primitive
 	targetPrimitive engine.Primitive
@@ -150,17 +170,104 @@ type shardStreamer struct {
 // VDiff reports differences between the sources and targets of a vreplication workflow.
 func (wr *Wrangler) VDiff(ctx context.Context, targetKeyspace, workflowName, sourceCell, targetCell, tabletTypesStr string,
 	filteredReplicationWaitTime time.Duration, format string, maxRows int64, tables string, debug, onlyPks bool) (map[string]*DiffReport, error) {
+	params := &VDiffParams{
+		SourceCell:                  sourceCell,
+		TargetCell:                  targetCell,
+		TabletTypes:                 tabletTypesStr,
+		FilteredReplicationWaitTime: filteredReplicationWaitTime,
+		Tables:                      tables,
+	}
+	return wr.vdiff(ctx, targetKeyspace, workflowName, params, format, maxRows, debug, onlyPks, nil)
+}
+
+// VDiffWithParams reports differences between the sources and targets of a
+// vreplication workflow, like VDiff. If params.MaxSourceLag is set, it only
+// reads from tablets within this replication lag, and it restarts if one
+// of them falls behind.
+func (wr *Wrangler) VDiffWithParams(ctx context.Context, targetKeyspace, workflowName string, params *VDiffParams,
+	format string, maxRows int64, debug, onlyPks bool) (map[string]*DiffReport, error) {
+	return wr.vdiff(ctx, targetKeyspace, workflowName, params, format, maxRows, debug, onlyPks, nil)
+}
+
+// vdiff performs a VDiff which only compares the rows modified since the
+// previous VDiff if incremental is set. It is restarted with other tablets
+// if it failed while one of its tablets was above params.MaxSourceLag.
+func (wr *Wrangler) vdiff(ctx context.Context, targetKeyspace, workflowName string, params *VDiffParams,
+	format string, maxRows int64, debug, onlyPks bool, incremental *vdiffIncremental) (map[string]*DiffReport, error) {
+	var diffReports map[string]*DiffReport
+	for attempt := 0; ; attempt++ {
+		df, reports, err := wr.diffOnce(ctx, targetKeyspace, workflowName, params, maxRows, debug, onlyPks, incremental)
+		if err == nil {
+			diffReports = reports
+			break
+		}
+		if df == nil || params.MaxSourceLag <= 0 || attempt >= *vdiffSourceLagRetries {
+			return nil, err
+		}
+		lagging := df.laggingTablets(ctx, params.MaxSourceLag)
+		if len(lagging) == 0 {
+			return nil, err
+		}
+		wr.Logger().Warningf("VDiff of workflow %s.%s failed while tablets %v were lagging, retrying with other tablets: %v",
+			targetKeyspace, workflowName, strings.Join(lagging, ", "), err)
+	}
+
+	jsonOutput := ""
+	if format == "json" {
+		json, err := json.MarshalIndent(diffReports, "", "")
+		if err != nil {
+			wr.Logger().Printf("Error converting report to json: %v", err.Error())
+		}
+		jsonOutput += fmt.Sprintf("%s", json)
+		wr.logger.Printf("%s", jsonOutput)
+	} else {
+		for table, dr := range diffReports {
+			wr.Logger().Printf("Summary for table %v:\n", table)
+			wr.Logger().Printf("\tProcessedRows: %v\n", dr.ProcessedRows)
+			wr.Logger().Printf("\tMatchingRows: %v\n", dr.MatchingRows)
+			wr.Logger().Printf("\tMismatchedRows: %v\n", dr.MismatchedRows)
+			wr.Logger().Printf("\tExtraRowsSource: %v\n", dr.ExtraRowsSource)
+			wr.Logger().Printf("\tExtraRowsTarget: %v\n", dr.ExtraRowsTarget)
+			if dr.Incremental {
+				wr.Logger().Printf("\tIncremental: %v\n", dr.Incremental)
+			}
+			for i, rs := range dr.ExtraRowsSourceSample {
+				wr.Logger().Printf("\tSample extra row in source %v:\n", i)
+				formatSampleRow(wr.Logger(), rs, debug)
+			}
+			for i, rs := range dr.ExtraRowsTargetSample {
+				wr.Logger().Printf("\tSample extra row in target %v:\n", i)
+				formatSampleRow(wr.Logger(), rs, debug)
+			}
+			for i, rs := range dr.MismatchedRowsSample {
+				wr.Logger().Printf("\tSample rows with mismatch %v:\n", i)
+				wr.Logger().Printf("\t\tSource row:\n")
+				formatSampleRow(wr.Logger(), rs.Source, debug)
+				wr.Logger().Printf("\t\tTarget row:\n")
+				formatSampleRow(wr.Logger(), rs.Target, debug)
+			}
+		}
+	}
+	return diffReports, nil
+}
+
+// diffOnce performs a VDiff. It returns the vdiff if the tablets were
+// selected, even if it failed.
+func (wr *Wrangler) diffOnce(ctx context.Context, targetKeyspace, workflowName string, params *VDiffParams,
+	maxRows int64, debug, onlyPks bool, incremental *vdiffIncremental) (*vdiff, map[string]*DiffReport, error) {
+	sourceCell, targetCell, tabletTypesStr, tables := params.SourceCell, params.TargetCell, params.TabletTypes, params.Tables
+	filteredReplicationWaitTime := params.FilteredReplicationWaitTime
 	log.Infof("Starting VDiff for %s.%s, sourceCell %s, targetCell %s, tabletTypes %s, timeout %s",
 		targetKeyspace, workflowName, sourceCell, targetCell, tabletTypesStr, filteredReplicationWaitTime.String())
 	// Assign defaults to sourceCell and targetCell if not specified.
 	if sourceCell == "" && targetCell == "" {
 		cells, err := wr.ts.GetCellInfoNames(ctx)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		if len(cells) == 0 {
 			// Unreachable
-			return nil, fmt.Errorf("there are no cells in the topo")
+			return nil, nil, fmt.Errorf("there are no cells in the topo")
 		}
 		sourceCell = cells[0]
 		targetCell = sourceCell
@@ -176,11 +283,11 @@ func (wr *Wrangler) VDiff(ctx context.Context, targetKeyspace, workflowName, sou
 	ts, err := wr.buildTrafficSwitcher(ctx, targetKeyspace, workflowName)
 	if err != nil {
 		wr.Logger().Errorf("buildTrafficSwitcher: %v", err)
-		return nil, err
+		return nil, nil, err
 	}
 	if err := ts.validate(ctx); err != nil {
 		ts.wr.Logger().Errorf("validate: %v", err)
-		return nil, err
+		return nil, nil, err
 	}
 	tables = strings.TrimSpace(tables)
 	var includeTables []string
@@ -198,6 +305,10 @@ func (wr *Wrangler) VDiff(ctx context.Context, targetKeyspace, workflowName, sou
 		workflow:       workflowName,
 		targetKeyspace: targetKeyspace,
 		tables:         includeTables,
+		incremental:    incremental,
+
+		maxSourceLag:         params.MaxSourceLag,
+		waitForSourceCatchup: params.WaitForSourceCatchup,
 	}
 	for shard, source := range ts.sources {
 		df.sources[shard] = &shardStreamer{
@@ -218,14 +329,14 @@ func (wr *Wrangler) VDiff(ctx context.Context, targetKeyspace, workflowName, sou
 	}
 	schm, err := wr.GetSchema(ctx, oneTarget.GetPrimary().Alias, nil, nil, false)
 	if err != nil {
-		return nil, vterrors.Wrap(err, "GetSchema")
+		return df, nil, vterrors.Wrap(err, "GetSchema")
 	}
 	if err = df.buildVDiffPlan(ctx, oneFilter, schm, df.tables); err != nil {
-		return nil, vterrors.Wrap(err, "buildVDiffPlan")
+		return df, nil, vterrors.Wrap(err, "buildVDiffPlan")
 	}
 
 	if err := df.selectTablets(ctx, ts); err != nil {
-		return nil, vterrors.Wrap(err, "selectTablets")
+		return df, nil, vterrors.Wrap(err, "selectTablets")
 	}
 	defer func(ctx context.Context) {
 		if err := df.restartTargets(ctx); err != nil {
@@ -242,56 +353,24 @@ func (wr *Wrangler) VDiff(ctx context.Context, targetKeyspace, workflowName, sou
 	// TODO(sougou): parallelize
 	rowsToCompare := maxRows
 	diffReports := make(map[string]*DiffReport)
-	jsonOutput := ""
 	for table, td := range df.differs {
 		// Skip internal operation tables for vdiff
 		if schema.IsInternalOperationTableName(table) {
 			continue
 		}
 		if err := df.diffTable(ctx, wr, table, td, filteredReplicationWaitTime); err != nil {
-			return nil, err
+			return df, nil, err
 		}
 		// Perform the diff of source and target streams.
 		dr, err := td.diff(ctx, df.ts.wr, &rowsToCompare, debug, onlyPks)
 		if err != nil {
-			return nil, vterrors.Wrap(err, "diff")
+			return df, nil, vterrors.Wrap(err, "diff")
 		}
 		dr.TableName = table
+		dr.Incremental = td.incremental
 		diffReports[table] = dr
 	}
-	if format == "json" {
-		json, err := json.MarshalIndent(diffReports, "", "")
-		if err != nil {
-			wr.Logger().Printf("Error converting report to json: %v", err.Error())
-		}
-		jsonOutput += fmt.Sprintf("%s", json)
-		wr.logger.Printf("%s", jsonOutput)
-	} else {
-		for table, dr := range diffReports {
-			wr.Logger().Printf("Summary for table %v:\n", table)
-			wr.Logger().Printf("\tProcessedRows: %v\n", dr.ProcessedRows)
-			wr.Logger().Printf("\tMatchingRows: %v\n", dr.MatchingRows)
-			wr.Logger().Printf("\tMismatchedRows: %v\n", dr.MismatchedRows)
-			wr.Logger().Printf("\tExtraRowsSource: %v\n", dr.ExtraRowsSource)
-			wr.Logger().Printf("\tExtraRowsTarget: %v\n", dr.ExtraRowsTarget)
-			for i, rs := range dr.ExtraRowsSourceSample {
-				wr.Logger().Printf("\tSample extra row in source %v:\n", i)
-				formatSampleRow(wr.Logger(), rs, debug)
-			}
-			for i, rs := range dr.ExtraRowsTargetSample {
-				wr.Logger().Printf("\tSample extra row in target %v:\n", i)
-				formatSampleRow(wr.Logger(), rs, debug)
-			}
-			for i, rs := range dr.MismatchedRowsSample {
-				wr.Logger().Printf("\tSample rows with mismatch %v:\n", i)
-				wr.Logger().Printf("\t\tSource row:\n")
-				formatSampleRow(wr.Logger(), rs.Source, debug)
-				wr.Logger().Printf("\t\tTarget row:\n")
-				formatSampleRow(wr.Logger(), rs.Target, debug)
-			}
-		}
-	}
-	return diffReports, nil
+	return df, diffReports, nil
 }
 
 func (df *vdiff) diffTable(ctx context.Context, wr *Wrangler, table string, td *tableDiffer, filteredReplicationWaitTime time.Duration) error {
@@ -513,6 +592,14 @@ func (df *vdiff) buildTablePlan(table *tabletmanagerdatapb.TableDefinition, quer
 	}
 	// Remove in_keyrange. It's not understood by mysql.
 	sourceSelect.Where = removeKeyrange(sel.Where)
+	// An incremental VDiff can't compare partial aggregates.
+	if len(aggregates) == 0 {
+		if filter := df.incremental.filter(table); filter != nil {
+			sourceSelect.AddWhere(filter)
+			targetSelect.AddWhere(filter)
+			td.incremental = true
+		}
+	}
 	// The source should also perform the group by.
 	sourceSelect.GroupBy = sel.GroupBy
 	sourceSelect.OrderBy = orderby
@@ -576,6 +663,7 @@ func (df *vdiff) selectTablets(ctx context.Context, ts *trafficSwitcher) error {
 			if err != nil {
 				return err
 			}
+			tp.SetMaxReplicationLag(df.maxSourceLag, df.waitForSourceCatchup)
 
 			tablet, err := tp.PickForStreaming(ctx)
 			if err != nil {
@@ -594,6 +682,7 @@ func (df *vdiff) selectTablets(ctx context.Context, ts *trafficSwitcher) error {
 			if err != nil {
 				return err
 			}
+			tp.SetMaxReplicationLag(df.maxSourceLag, df.waitForSourceCatchup)
 
 			tablet, err := tp.PickForStreaming(ctx)
 			if err != nil {
@@ -611,6 +700,33 @@ func (df *vdiff) selectTablets(ctx context.Context, ts *trafficSwitcher) error {
 	return err2
 }
 
+// laggingTablets returns the aliases of the selected tablets whose
+// replication lag is above maxLag.
+func (df *vdiff) laggingTablets(ctx context.Context, maxLag time.Duration) []string {
+	var mu sync.Mutex
+	var lagging []string
+	check := func(shard string, participant *shardStreamer) error {
+		if participant.tablet == nil {
+			return nil
+		}
+		lag, err := discovery.TabletReplicationLag(ctx, participant.tablet)
+		if err != nil {
+			log.Warningf("Cannot check the replication lag of tablet %v: %v", topoproto.TabletAliasString(participant.tablet.Alias), err)
+			return nil
+		}
+		if lag > maxLag {
+			mu.Lock()
+			lagging = append(lagging, topoproto.TabletAliasString(participant.tablet.Alias))
+			mu.Unlock()
+		}
+		return nil
+	}
+	_ = df.forAll(df.sources, check)
+	_ = df.forAll(df.targets, check)
+	sort.Strings(lagging)
+	return lagging
+}
+
 // stopTargets stops all the targets and records their source positions.
 func (df *vdiff) stopTargets(ctx context.Context) error {
 	var mu sync.Mutex
@@ -0,0 +1,193 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrangler
+
+import (
+	"context"
+	"flag"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/sqlparser"
+
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	workflowpb "vitess.io/vitess/go/vt/proto/workflow"
+)
+
+// This file contains the VDiffs whose results are stored in the topo, s.t.
+// the VDiffs of a long migration can be run on a schedule and reviewed
+// later. Such a VDiff can be incremental: it then only compares the rows
+// of the tables whose update timestamp column was modified since the
+// previous VDiff of the workflow started. An incremental VDiff doesn't
+// find the rows deleted on one side only, so a full VDiff should still be
+// run from time to time.
+
+var (
+	vdiffMaxResults = flag.Int("vdiff_max_results", 100, "Maximum number of VDiff results stored per workflow, the oldest ones are removed first.")
+)
+
+// incrementalVDiffOverlap is how long before the start of the previous
+// VDiff an incremental VDiff compares the rows from, s.t. the rows of the
+// transactions in flight then, and the clock skew between the vtctld and
+// the databases, are covered.
+const incrementalVDiffOverlap = time.Minute
+
+// VDiffParams are the parameters of a VDiff.
+type VDiffParams struct {
+	SourceCell                  string
+	TargetCell                  string
+	TabletTypes                 string
+	FilteredReplicationWaitTime time.Duration
+	// Tables is the comma-separated list of tables to compare, all the
+	// tables of the workflow if empty.
+	Tables string
+	// IncrementalColumn is the update timestamp column of the tables, for
+	// an incremental VDiff. The tables without it are fully compared.
+	IncrementalColumn string
+	// MaxSourceLag is the maximum replication lag of the tablets read
+	// from, or 0 for no limit. If no tablet of a shard is within it, the
+	// VDiff fails, or waits for one to catch up if WaitForSourceCatchup is
+	// set. The VDiff is restarted with other tablets if it failed while
+	// one of its tablets was lagging.
+	MaxSourceLag         time.Duration
+	WaitForSourceCatchup bool
+}
+
+// vdiffIncremental selects the rows compared by an incremental VDiff.
+type vdiffIncremental struct {
+	column string
+	since  time.Time
+}
+
+// filter returns the condition on the rows of the table to compare, or nil
+// if all of them must be compared.
+func (vi *vdiffIncremental) filter(table *tabletmanagerdatapb.TableDefinition) sqlparser.Expr {
+	if vi == nil || vi.since.IsZero() {
+		return nil
+	}
+	found := false
+	for _, column := range table.Columns {
+		if strings.EqualFold(column, vi.column) {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil
+	}
+	return &sqlparser.ComparisonExpr{
+		Operator: sqlparser.GreaterEqualOp,
+		Left:     &sqlparser.ColName{Name: sqlparser.NewColIdent(vi.column)},
+		Right: &sqlparser.FuncExpr{
+			Name:  sqlparser.NewColIdent("from_unixtime"),
+			Exprs: sqlparser.SelectExprs{&sqlparser.AliasedExpr{Expr: sqlparser.NewIntLiteral(strconv.FormatInt(vi.since.Unix(), 10))}},
+		},
+	}
+}
+
+// incrementalVDiffSince returns the time from which an incremental VDiff on
+// the column compares the rows, given the previous results of the
+// workflow. It's zero if there was no successful VDiff which compared the
+// rows modified until then.
+func incrementalVDiffSince(results []*workflowpb.VDiffResult, column string) time.Time {
+	for i := len(results) - 1; i >= 0; i-- {
+		result := results[i]
+		if result.Error != "" {
+			continue
+		}
+		if result.IncrementalColumn != "" && !strings.EqualFold(result.IncrementalColumn, column) {
+			continue
+		}
+		return protoutil.TimeFromProto(result.StartTime).Add(-incrementalVDiffOverlap)
+	}
+	return time.Time{}
+}
+
+// RunVDiff performs a VDiff of the workflow, incremental if
+// params.IncrementalColumn is set, and stores its result, even if it
+// failed.
+func (wr *Wrangler) RunVDiff(ctx context.Context, targetKeyspace, workflowName string, params *VDiffParams) (*workflowpb.VDiffResult, error) {
+	var incremental *vdiffIncremental
+	if params.IncrementalColumn != "" {
+		results, err := wr.ts.GetVDiffResults(ctx, targetKeyspace, workflowName)
+		if err != nil {
+			return nil, err
+		}
+		incremental = &vdiffIncremental{
+			column: params.IncrementalColumn,
+			since:  incrementalVDiffSince(results, params.IncrementalColumn),
+		}
+		if incremental.since.IsZero() {
+			wr.Logger().Infof("No previous VDiff of workflow %v.%v, all the rows are compared", targetKeyspace, workflowName)
+		}
+	}
+
+	result := &workflowpb.VDiffResult{
+		StartTime: protoutil.TimeToProto(time.Now()),
+	}
+	if incremental != nil {
+		result.IncrementalColumn = incremental.column
+		if !incremental.since.IsZero() {
+			result.Since = protoutil.TimeToProto(incremental.since)
+		}
+	}
+	diffReports, err := wr.vdiff(ctx, targetKeyspace, workflowName, params, "", math.MaxInt64, false /* debug */, true /* onlyPks */, incremental)
+	result.EndTime = protoutil.TimeToProto(time.Now())
+	if err != nil {
+		result.Error = err.Error()
+	}
+	if len(diffReports) != 0 {
+		result.Tables = make(map[string]*workflowpb.VDiffTableResult, len(diffReports))
+		for table, dr := range diffReports {
+			result.Tables[table] = &workflowpb.VDiffTableResult{
+				ProcessedRows:   int64(dr.ProcessedRows),
+				MatchingRows:    int64(dr.MatchingRows),
+				MismatchedRows:  int64(dr.MismatchedRows),
+				ExtraRowsSource: int64(dr.ExtraRowsSource),
+				ExtraRowsTarget: int64(dr.ExtraRowsTarget),
+				Incremental:     dr.Incremental,
+			}
+		}
+	}
+	if storeErr := wr.ts.AppendVDiffResult(ctx, targetKeyspace, workflowName, result, *vdiffMaxResults); storeErr != nil {
+		wr.Logger().Errorf("Cannot store the VDiff result of workflow %v.%v: %v", targetKeyspace, workflowName, storeErr)
+		if err == nil {
+			err = storeErr
+		}
+	}
+	return result, err
+}
+
+// ListVDiffResults returns the stored results of the VDiffs of the
+// workflow, from the newest, at most limit of them if limit is positive.
+func (wr *Wrangler) ListVDiffResults(ctx context.Context, targetKeyspace, workflowName string, limit int) ([]*workflowpb.VDiffResult, error) {
+	results, err := wr.ts.GetVDiffResults(ctx, targetKeyspace, workflowName)
+	if err != nil {
+		return nil, err
+	}
+	newest := make([]*workflowpb.VDiffResult, 0, len(results))
+	for i := len(results) - 1; i >= 0; i-- {
+		if limit > 0 && len(newest) == limit {
+			break
+		}
+		newest = append(newest, results[i])
+	}
+	return newest, nil
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wrangler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	workflowpb "vitess.io/vitess/go/vt/proto/workflow"
+)
+
+func TestVDiffPlanIncremental(t *testing.T) {
+	schm := &tabletmanagerdatapb.SchemaDefinition{
+		TableDefinitions: []*tabletmanagerdatapb.TableDefinition{{
+			Name:              "t1",
+			Columns:           []string{"c1", "c2", "updated_at"},
+			PrimaryKeyColumns: []string{"c1"},
+			Fields:            sqltypes.MakeTestFields("c1|c2|updated_at", "int64|int64|timestamp"),
+		}, {
+			Name:              "t2",
+			Columns:           []string{"c1", "c2"},
+			PrimaryKeyColumns: []string{"c1"},
+			Fields:            sqltypes.MakeTestFields("c1|c2", "int64|int64"),
+		}},
+	}
+	filter := &binlogdatapb.Filter{Rules: []*binlogdatapb.Rule{{Match: "/.*"}}}
+	df := &vdiff{incremental: &vdiffIncremental{column: "updated_at", since: time.Unix(1600000000, 0)}}
+	require.NoError(t, df.buildVDiffPlan(context.Background(), filter, schm, nil))
+
+	// The tables without the column are fully compared.
+	assert.True(t, df.differs["t1"].incremental)
+	assert.Equal(t, "select c1, c2, updated_at from t1 where updated_at >= from_unixtime(1600000000) order by c1 asc", df.differs["t1"].sourceExpression)
+	assert.Equal(t, "select c1, c2, updated_at from t1 where updated_at >= from_unixtime(1600000000) order by c1 asc", df.differs["t1"].targetExpression)
+	assert.False(t, df.differs["t2"].incremental)
+	assert.Equal(t, "select c1, c2 from t2 order by c1 asc", df.differs["t2"].sourceExpression)
+}
+
+func TestIncrementalVDiffSince(t *testing.T) {
+	start := time.Unix(1600000000, 0)
+	results := []*workflowpb.VDiffResult{
+		{StartTime: protoutil.TimeToProto(start)},
+		{StartTime: protoutil.TimeToProto(start.Add(time.Hour)), IncrementalColumn: "updated_at"},
+		{StartTime: protoutil.TimeToProto(start.Add(2 * time.Hour)), IncrementalColumn: "modified_at"},
+		{StartTime: protoutil.TimeToProto(start.Add(3 * time.Hour)), IncrementalColumn: "updated_at", Error: "vdiff timed out"},
+	}
+	assert.Equal(t, start.Add(time.Hour-incrementalVDiffOverlap), incrementalVDiffSince(results, "updated_at"))
+	assert.Equal(t, start.Add(2*time.Hour-incrementalVDiffOverlap), incrementalVDiffSince(results, "modified_at"))
+	assert.Equal(t, start.Add(-incrementalVDiffOverlap), incrementalVDiffSince(results[:1], "updated_at"))
+	assert.True(t, incrementalVDiffSince(nil, "updated_at").IsZero())
+}
+
+func TestListVDiffResults(t *testing.T) {
+	ctx := context.Background()
+	ts := memorytopo.NewServer("cell1")
+	wr := New(logutil.NewConsoleLogger(), ts, nil)
+	start := time.Unix(1600000000, 0)
+	for i := 0; i < 3; i++ {
+		result := &workflowpb.VDiffResult{
+			StartTime: protoutil.TimeToProto(start.Add(time.Duration(i) * time.Hour)),
+			Tables:    map[string]*workflowpb.VDiffTableResult{"t1": {ProcessedRows: int64(i), MatchingRows: int64(i)}},
+		}
+		require.NoError(t, ts.AppendVDiffResult(ctx, "ks", "wf", result, 2))
+	}
+
+	results, err := wr.ListVDiffResults(ctx, "ks", "wf", 0)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, int64(2), results[0].Tables["t1"].ProcessedRows)
+	assert.Equal(t, int64(1), results[1].Tables["t1"].ProcessedRows)
+
+	results, err = wr.ListVDiffResults(ctx, "ks", "wf", 1)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.True(t, protoutil.TimeFromProto(results[0].StartTime).Equal(start.Add(2*time.Hour)))
+
+	results, err = wr.ListVDiffResults(ctx, "ks", "other", 0)
+	require.NoError(t, err)
+	assert.Empty(t, results)
+}
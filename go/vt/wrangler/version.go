@@ -17,10 +17,7 @@ limitations under the License.
 package wrangler
 
 import (
-	"encoding/json"
 	"fmt"
-	"io/ioutil"
-	"net/http"
 	"sort"
 	"sync"
 
@@ -28,36 +25,14 @@ import (
 
 	"vitess.io/vitess/go/vt/concurrency"
 	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vtctl/validateutil"
 
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 )
 
-var getVersionFromTabletDebugVars = func(tabletAddr string) (string, error) {
-	resp, err := http.Get("http://" + tabletAddr + "/debug/vars")
-	if err != nil {
-		return "", err
-	}
-	defer resp.Body.Close()
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return "", err
-	}
-
-	var vars struct {
-		BuildHost      string
-		BuildUser      string
-		BuildTimestamp int64
-		BuildGitRev    string
-	}
-	err = json.Unmarshal(body, &vars)
-	if err != nil {
-		return "", err
-	}
-
-	version := fmt.Sprintf("%v", vars)
-	return version, nil
-}
+var getVersionFromTabletDebugVars = validateutil.GetVersionFromTabletDebugVars
 
 var getVersionFromTablet = getVersionFromTabletDebugVars
 
@@ -200,3 +175,11 @@ func (wr *Wrangler) ValidateVersionKeyspace(ctx context.Context, keyspace string
 	}
 	return nil
 }
+
+// ValidateVersionReport gathers the versions of the tablets of a keyspace
+// or shard, and reports the ones running each version.
+func (wr *Wrangler) ValidateVersionReport(ctx context.Context, opts validateutil.Options) (*validateutil.VersionReport, error) {
+	return validateutil.ValidateVersion(ctx, wr.ts, func(ctx context.Context, tablet *topodatapb.Tablet) (string, error) {
+		return getVersionFromTablet(topo.NewTabletInfo(tablet, nil).Addr())
+	}, opts)
+}
@@ -338,6 +338,8 @@ type ReplicationStatusResult struct {
 
 	// Statuses is a map of <shard>/<master tablet alias> : ShardReplicationStatus (for the given shard).
 	ShardStatuses map[string]*ShardReplicationStatus
+	// RollbackSafety is set once writes are switched, and reports whether they can be switched back.
+	RollbackSafety *RollbackSafety `json:",omitempty"`
 }
 
 // ReplicationLocation represents a location that data is either replicating from, or replicating into.
@@ -552,6 +554,9 @@ func (wr *Wrangler) ShowWorkflow(ctx context.Context, workflow, keyspace string)
 	if len(replStatus.ShardStatuses) == 0 {
 		return nil, fmt.Errorf("no streams found for workflow %s in keyspace %s", workflow, keyspace)
 	}
+	if writesSwitched(replStatus) {
+		replStatus.RollbackSafety = wr.checkReverseWorkflow(ctx, keyspace, workflow, replStatus.SourceLocation.Keyspace)
+	}
 
 	return replStatus, nil
 }
@@ -125,6 +125,8 @@ func newSchemaMigrationsPlanner(vx *vexec) vexecPlanner {
 				`update _vt.schema_migrations set migration_status='val1'`,
 				`update _vt.schema_migrations set migration_status='val1' where migration_uuid='val2'`,
 				`update _vt.schema_migrations set migration_status='val1' where migration_uuid='val2' and shard='val3'`,
+				`update _vt.schema_migrations set migration_status='val1', reviewed_by='val2' where migration_uuid='val3'`,
+				`update _vt.schema_migrations set migration_status='val1', reviewed_by='val2', message='val3' where migration_uuid='val4'`,
 			},
 			insertTemplates: []string{
 				`INSERT IGNORE INTO _vt.schema_migrations (
@@ -75,6 +75,14 @@ type VReplicationWorkflowParams struct {
 
 	// Migrate specific
 	ExternalCluster string
+
+	// Canary, if set, verifies the target after writes are switched
+	// forward, and switches them back if the verification fails.
+	Canary *CanaryVerification
+	// CutoverGate, if set, blocks switching traffic forward until the
+	// streams have replicated below a lag threshold, without errors, for a
+	// while.
+	CutoverGate *CutoverGate
 }
 
 // NewVReplicationWorkflow sets up a MoveTables or Reshard workflow based on options provided, deduces the state of the
@@ -247,6 +255,11 @@ func (vrw *VReplicationWorkflow) SwitchTraffic(direction workflow.TrafficSwitchD
 	var err error
 	var hasReplica, hasRdonly, hasMaster bool
 
+	// The outcome of the canary verification is recorded in the reverse
+	// workflow, and writes are switched back through it if it fails.
+	if direction == workflow.DirectionForward && vrw.params.Canary.enabled() && !vrw.params.EnableReverseReplication {
+		return nil, fmt.Errorf("canary verification requires reverse replication")
+	}
 	if !vrw.Exists() {
 		return nil, fmt.Errorf("workflow has not yet been started")
 	}
@@ -263,6 +276,14 @@ func (vrw *VReplicationWorkflow) SwitchTraffic(direction workflow.TrafficSwitchD
 	}
 
 	vrw.params.Direction = direction
+	if direction == workflow.DirectionForward && vrw.params.CutoverGate.enabled() {
+		if vrw.params.DryRun {
+			dryRunResults = append(dryRunResults, fmt.Sprintf("Wait for all streams to replicate below %v, without errors, for %v",
+				vrw.params.CutoverGate.MaxLag, vrw.params.CutoverGate.StableFor))
+		} else if err := vrw.waitForCutoverGate(); err != nil {
+			return nil, err
+		}
+	}
 	hasReplica, hasRdonly, hasMaster, err = vrw.parseTabletTypes()
 	if err != nil {
 		return nil, err
@@ -440,9 +461,72 @@ func (vrw *VReplicationWorkflow) switchWrites() (*[]string, error) {
 		return nil, err
 	}
 	log.Infof("switchWrites succeeded with journal id %s", journalID)
+	if vrw.params.Direction == workflow.DirectionForward && !vrw.params.DryRun && vrw.params.Canary.enabled() {
+		if err := vrw.verifyCanary(); err != nil {
+			return nil, err
+		}
+	}
 	return dryRunResults, nil
 }
 
+// waitForCutoverGate waits until the cutover gate of the workflow opens.
+func (vrw *VReplicationWorkflow) waitForCutoverGate() error {
+	ctx := vrw.ctx
+	targetKeyspace, workflowName := vrw.params.TargetKeyspace, vrw.params.Workflow
+	gate := vrw.params.CutoverGate
+	ws := workflow.NewServer(vrw.wr.ts, vrw.wr.tmc)
+	getProgress := func(ctx context.Context) (*workflow.WorkflowProgress, error) {
+		return ws.GetWorkflowProgress(ctx, targetKeyspace, workflowName)
+	}
+
+	vrw.wr.Logger().Infof("Waiting for all streams of workflow %s.%s to replicate below %v, without errors, for %v",
+		targetKeyspace, workflowName, gate.MaxLag, gate.StableFor)
+	if err := gate.wait(ctx, getProgress, vrw.wr.Logger()); err != nil {
+		return fmt.Errorf("cannot switch traffic for workflow %s.%s: %v", targetKeyspace, workflowName, err)
+	}
+	vrw.wr.Logger().Infof("Cutover gate opened for workflow %s.%s", targetKeyspace, workflowName)
+	return nil
+}
+
+// verifyCanary runs the canary queries against the target keyspace after
+// writes have been switched. If they fail, writes are switched back to the
+// source keyspace. The outcome is recorded in the message of the streams
+// that are left running.
+func (vrw *VReplicationWorkflow) verifyCanary() error {
+	ctx := vrw.ctx
+	targetKeyspace, sourceKeyspace := vrw.params.TargetKeyspace, vrw.params.SourceKeyspace
+	workflowName := vrw.params.Workflow
+	reverseWorkflowName := workflow.ReverseWorkflowName(workflowName)
+
+	vrw.wr.Logger().Infof("Running canary verification for workflow %s.%s for %v", targetKeyspace, workflowName, vrw.params.Canary.Window)
+	verifyErr := vrw.params.Canary.verify(ctx, targetKeyspace)
+	now := time.Now().UTC().Format(time.RFC3339)
+	if verifyErr == nil {
+		vrw.wr.Logger().Infof("Canary verification passed for workflow %s.%s", targetKeyspace, workflowName)
+		msg := fmt.Sprintf("Canary verification of %s.%s passed at %s", targetKeyspace, workflowName, now)
+		if err := vrw.wr.recordWorkflowMessage(ctx, sourceKeyspace, reverseWorkflowName, msg); err != nil {
+			log.Warningf("Could not record canary verification outcome: %v", err)
+		}
+		return nil
+	}
+
+	vrw.wr.Logger().Errorf("Canary verification failed for workflow %s.%s: %v", targetKeyspace, workflowName, verifyErr)
+	vrw.wr.Logger().Infof("Switching writes back to keyspace %s", sourceKeyspace)
+	if _, _, err := vrw.wr.SwitchWrites(ctx, sourceKeyspace, reverseWorkflowName, vrw.params.Timeout,
+		false, true, true, false); err != nil {
+		msg := fmt.Sprintf("Canary verification failed at %s, switching writes back failed: %v", now, err)
+		if err := vrw.wr.recordWorkflowMessage(ctx, sourceKeyspace, reverseWorkflowName, msg); err != nil {
+			log.Warningf("Could not record canary verification outcome: %v", err)
+		}
+		return fmt.Errorf("%v; switching writes back failed: %v", verifyErr, err)
+	}
+	msg := fmt.Sprintf("Canary verification failed at %s, writes were switched back: %v", now, verifyErr)
+	if err := vrw.wr.recordWorkflowMessage(ctx, targetKeyspace, workflowName, msg); err != nil {
+		log.Warningf("Could not record canary verification outcome: %v", err)
+	}
+	return fmt.Errorf("%v; writes have been switched back to keyspace %s", verifyErr, sourceKeyspace)
+}
+
 // endregion
 
 // region Copy Progress